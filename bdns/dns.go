@@ -34,7 +34,7 @@ type ResolverAddrs []string
 
 // Client queries for DNS records
 type Client interface {
-	LookupTXT(context.Context, string) (txts []string, resolver ResolverAddrs, err error)
+	LookupTXT(context.Context, string) (txts []string, aliases []string, resolver ResolverAddrs, err error)
 	LookupHost(context.Context, string) ([]netip.Addr, ResolverAddrs, error)
 	LookupCAA(context.Context, string) ([]*dns.CAA, string, ResolverAddrs, error)
 }
@@ -43,6 +43,7 @@ type Client interface {
 type impl struct {
 	dnsClient                exchanger
 	servers                  ServerProvider
+	txtServers               ServerProvider
 	allowRestrictedAddresses bool
 	maxTries                 int
 	clk                      clock.Clock
@@ -158,6 +159,15 @@ func NewTest(
 	return resolver
 }
 
+// SetTXTServers makes a resolver constructed by New or NewTest send its TXT
+// queries, which are made only during DNS-01 validation, to the given servers
+// rather than to those it was constructed with. This allows operators to route
+// them through resolvers which validate DNSSEC, for example, without also
+// routing the A, AAAA, and CAA queries made for other validations there.
+func SetTXTServers(resolver Client, servers ServerProvider) {
+	resolver.(*impl).txtServers = servers
+}
+
 // exchangeOne performs a single DNS exchange with a randomly chosen server
 // out of the server list, returning the response, time, and error (if any).
 // We assume that the upstream resolver requests and validates DNSSEC records
@@ -178,7 +188,11 @@ func (dnsClient *impl) exchangeOne(ctx context.Context, hostname string, qtype u
 	// present.
 	m.SetEdns0(4096, false)
 
-	servers, err := dnsClient.servers.Addrs()
+	provider := dnsClient.servers
+	if qtype == dns.TypeTXT && dnsClient.txtServers != nil {
+		provider = dnsClient.txtServers
+	}
+	servers, err := provider.Addrs()
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to list DNS servers: %w", err)
 	}
@@ -320,15 +334,15 @@ type dnsResp struct {
 }
 
 // LookupTXT sends a DNS query to find all TXT records associated with
-// the provided hostname which it returns along with the returned
-// DNS authority section.
-func (dnsClient *impl) LookupTXT(ctx context.Context, hostname string) ([]string, ResolverAddrs, error) {
+// the provided hostname. It also returns the names which hostname was aliased
+// to, in order, by any CNAME or DNAME records in the answer.
+func (dnsClient *impl) LookupTXT(ctx context.Context, hostname string) ([]string, []string, ResolverAddrs, error) {
 	var txt []string
 	dnsType := dns.TypeTXT
 	r, resolver, err := dnsClient.exchangeOne(ctx, hostname, dnsType)
 	errWrap := wrapErr(dnsType, hostname, r, err)
 	if errWrap != nil {
-		return nil, ResolverAddrs{resolver}, errWrap
+		return nil, nil, ResolverAddrs{resolver}, errWrap
 	}
 
 	for _, answer := range r.Answer {
@@ -339,7 +353,47 @@ func (dnsClient *impl) LookupTXT(ctx context.Context, hostname string) ([]string
 		}
 	}
 
-	return txt, ResolverAddrs{resolver}, err
+	return txt, followAliases(hostname, r.Answer), ResolverAddrs{resolver}, err
+}
+
+// followAliases walks the CNAME and DNAME records in answers, starting from
+// hostname, and returns each name it is aliased to in order, without trailing
+// dots. A CNAME for a name takes precedence over a DNAME for one of its
+// parents, since resolvers include the CNAME synthesized from a DNAME
+// alongside it. If the aliases loop, the walk stops after the first repeated
+// name, which is included so that callers can detect the loop.
+func followAliases(hostname string, answers []dns.RR) []string {
+	var aliases []string
+	name := dns.Fqdn(hostname)
+	seen := map[string]bool{strings.ToLower(name): true}
+	// Each record can extend the chain at most once, so this bounds the walk
+	// even if the answer is malicious.
+	for range answers {
+		var next string
+		for _, answer := range answers {
+			switch rec := answer.(type) {
+			case *dns.CNAME:
+				if strings.EqualFold(rec.Hdr.Name, name) {
+					next = rec.Target
+				}
+			case *dns.DNAME:
+				owner := dns.Fqdn(rec.Hdr.Name)
+				if next == "" && len(name) > len(owner) && strings.HasSuffix(strings.ToLower(name), "."+strings.ToLower(owner)) {
+					next = name[:len(name)-len(owner)] + dns.Fqdn(rec.Target)
+				}
+			}
+		}
+		if next == "" {
+			break
+		}
+		name = dns.Fqdn(next)
+		aliases = append(aliases, strings.TrimSuffix(name, "."))
+		if seen[strings.ToLower(name)] {
+			break
+		}
+		seen[strings.ToLower(name)] = true
+	}
+	return aliases
 }
 
 func (dnsClient *impl) lookupIP(ctx context.Context, hostname string, ipType uint16) ([]dns.RR, string, error) {
@@ -182,6 +182,15 @@ func mockDNSQuery(w http.ResponseWriter, httpReq *http.Request) {
 				record.Hdr = dns.RR_Header{Name: "split-txt.letsencrypt.org.", Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: 0}
 				record.Txt = []string{"a", "b", "c"}
 				appendAnswer(record)
+			} else if q.Name == "cname-txt.letsencrypt.org." {
+				alias := new(dns.CNAME)
+				alias.Hdr = dns.RR_Header{Name: "cname-txt.letsencrypt.org.", Rrtype: dns.TypeCNAME, Class: dns.ClassINET, Ttl: 0}
+				alias.Target = "split-txt.letsencrypt.org."
+				appendAnswer(alias)
+				record := new(dns.TXT)
+				record.Hdr = dns.RR_Header{Name: "split-txt.letsencrypt.org.", Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: 0}
+				record.Txt = []string{"a", "b", "c"}
+				appendAnswer(record)
 			} else {
 				auth := new(dns.SOA)
 				auth.Hdr = dns.RR_Header{Name: "letsencrypt.org.", Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: 0}
@@ -291,7 +300,7 @@ func TestDNSNoServers(t *testing.T) {
 	test.AssertEquals(t, len(resolvers), 0)
 	test.AssertError(t, err, "No servers")
 
-	_, _, err = obj.LookupTXT(context.Background(), "letsencrypt.org")
+	_, _, _, err = obj.LookupTXT(context.Background(), "letsencrypt.org")
 	test.AssertError(t, err, "No servers")
 
 	_, _, _, err = obj.LookupCAA(context.Background(), "letsencrypt.org")
@@ -331,7 +340,7 @@ func TestDNSServFail(t *testing.T) {
 	obj := New(time.Second*10, staticProvider, metrics.NoopRegisterer, clock.NewFake(), 1, "", blog.UseMock(), tlsConfig)
 	bad := "servfail.com"
 
-	_, _, err = obj.LookupTXT(context.Background(), bad)
+	_, _, _, err = obj.LookupTXT(context.Background(), bad)
 	test.AssertError(t, err, "LookupTXT didn't return an error")
 
 	_, _, err = obj.LookupHost(context.Background(), bad)
@@ -348,15 +357,81 @@ func TestDNSLookupTXT(t *testing.T) {
 
 	obj := New(time.Second*10, staticProvider, metrics.NoopRegisterer, clock.NewFake(), 1, "", blog.UseMock(), tlsConfig)
 
-	a, _, err := obj.LookupTXT(context.Background(), "letsencrypt.org")
+	a, _, _, err := obj.LookupTXT(context.Background(), "letsencrypt.org")
 	t.Logf("A: %v", a)
 	test.AssertNotError(t, err, "No message")
 
-	a, _, err = obj.LookupTXT(context.Background(), "split-txt.letsencrypt.org")
+	a, _, _, err = obj.LookupTXT(context.Background(), "split-txt.letsencrypt.org")
 	t.Logf("A: %v ", a)
 	test.AssertNotError(t, err, "No message")
 	test.AssertEquals(t, len(a), 1)
 	test.AssertEquals(t, a[0], "abc")
+
+	a, aliases, _, err := obj.LookupTXT(context.Background(), "cname-txt.letsencrypt.org")
+	test.AssertNotError(t, err, "No message")
+	test.AssertDeepEquals(t, a, []string{"abc"})
+	test.AssertDeepEquals(t, aliases, []string{"split-txt.letsencrypt.org"})
+}
+
+func TestFollowAliases(t *testing.T) {
+	cname := func(name, target string) dns.RR {
+		return &dns.CNAME{Hdr: dns.RR_Header{Name: name, Rrtype: dns.TypeCNAME, Class: dns.ClassINET}, Target: target}
+	}
+	dname := func(name, target string) dns.RR {
+		return &dns.DNAME{Hdr: dns.RR_Header{Name: name, Rrtype: dns.TypeDNAME, Class: dns.ClassINET}, Target: target}
+	}
+	txt := &dns.TXT{Hdr: dns.RR_Header{Name: "c.example.net.", Rrtype: dns.TypeTXT, Class: dns.ClassINET}, Txt: []string{"hi"}}
+
+	testCases := []struct {
+		name     string
+		hostname string
+		answers  []dns.RR
+		expected []string
+	}{
+		{
+			name:     "no aliases",
+			hostname: "a.example.com",
+			answers:  []dns.RR{txt},
+		},
+		{
+			name:     "CNAME chain in any order",
+			hostname: "a.example.com",
+			answers:  []dns.RR{txt, cname("b.example.org.", "c.example.net."), cname("A.example.com.", "b.example.org.")},
+			expected: []string{"b.example.org", "c.example.net"},
+		},
+		{
+			name:     "DNAME",
+			hostname: "_acme-challenge.a.example.com",
+			answers:  []dns.RR{dname("example.com.", "example.net.")},
+			expected: []string{"_acme-challenge.a.example.net"},
+		},
+		{
+			name:     "CNAME synthesized from DNAME takes precedence",
+			hostname: "_acme-challenge.a.example.com",
+			answers: []dns.RR{
+				dname("example.com.", "example.net."),
+				cname("_acme-challenge.a.example.com.", "_acme-challenge.a.example.net."),
+				cname("_acme-challenge.a.example.net.", "c.example.net."),
+			},
+			expected: []string{"_acme-challenge.a.example.net", "c.example.net"},
+		},
+		{
+			name:     "DNAME does not apply to its own name",
+			hostname: "example.com",
+			answers:  []dns.RR{dname("example.com.", "example.net.")},
+		},
+		{
+			name:     "loop",
+			hostname: "a.example.com",
+			answers:  []dns.RR{cname("a.example.com.", "b.example.com."), cname("b.example.com.", "a.example.com.")},
+			expected: []string{"b.example.com", "a.example.com"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			test.AssertDeepEquals(t, followAliases(tc.hostname, tc.answers), tc.expected)
+		})
+	}
 }
 
 // TODO(#8213): Convert this to a table test.
@@ -457,7 +532,7 @@ func TestDNSNXDOMAIN(t *testing.T) {
 	test.AssertContains(t, err.Error(), "NXDOMAIN looking up A for")
 	test.AssertContains(t, err.Error(), "NXDOMAIN looking up AAAA for")
 
-	_, _, err = obj.LookupTXT(context.Background(), hostname)
+	_, _, _, err = obj.LookupTXT(context.Background(), hostname)
 	expected := Error{dns.TypeTXT, hostname, nil, dns.RcodeNameError, nil}
 	test.AssertDeepEquals(t, err, expected)
 }
@@ -678,7 +753,7 @@ func TestRetry(t *testing.T) {
 			testClient := New(time.Second*10, staticProvider, metrics.NoopRegisterer, clock.NewFake(), tc.maxTries, "", blog.UseMock(), tlsConfig)
 			dr := testClient.(*impl)
 			dr.dnsClient = tc.te
-			_, _, err = dr.LookupTXT(context.Background(), "example.com")
+			_, _, _, err = dr.LookupTXT(context.Background(), "example.com")
 			if err == errTooManyRequests {
 				t.Errorf("#%d, sent more requests than the test case handles", i)
 			}
@@ -711,7 +786,7 @@ func TestRetry(t *testing.T) {
 	dr.dnsClient = &testExchanger{errs: []error{isTempErr, isTempErr, nil}}
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
-	_, _, err = dr.LookupTXT(ctx, "example.com")
+	_, _, _, err = dr.LookupTXT(ctx, "example.com")
 	if err == nil ||
 		err.Error() != "DNS problem: query timed out (and was canceled) looking up TXT for example.com" {
 		t.Errorf("expected %s, got %s", context.Canceled, err)
@@ -720,7 +795,7 @@ func TestRetry(t *testing.T) {
 	dr.dnsClient = &testExchanger{errs: []error{isTempErr, isTempErr, nil}}
 	ctx, cancel = context.WithTimeout(context.Background(), -10*time.Hour)
 	defer cancel()
-	_, _, err = dr.LookupTXT(ctx, "example.com")
+	_, _, _, err = dr.LookupTXT(ctx, "example.com")
 	if err == nil ||
 		err.Error() != "DNS problem: query timed out looking up TXT for example.com" {
 		t.Errorf("expected %s, got %s", context.DeadlineExceeded, err)
@@ -729,7 +804,7 @@ func TestRetry(t *testing.T) {
 	dr.dnsClient = &testExchanger{errs: []error{isTempErr, isTempErr, nil}}
 	ctx, deadlineCancel := context.WithTimeout(context.Background(), -10*time.Hour)
 	deadlineCancel()
-	_, _, err = dr.LookupTXT(ctx, "example.com")
+	_, _, _, err = dr.LookupTXT(ctx, "example.com")
 	if err == nil ||
 		err.Error() != "DNS problem: query timed out looking up TXT for example.com" {
 		t.Errorf("expected %s, got %s", context.DeadlineExceeded, err)
@@ -829,7 +904,7 @@ func TestRotateServerOnErr(t *testing.T) {
 	// servers *all* queries should eventually succeed by being retried against
 	// server "[2606:4700:4700::1111]:53".
 	for range maxTries * 2 {
-		_, resolvers, err := client.LookupTXT(context.Background(), "example.com")
+		_, _, resolvers, err := client.LookupTXT(context.Background(), "example.com")
 		test.AssertEquals(t, len(resolvers), 1)
 		test.AssertEquals(t, resolvers[0], "[2606:4700:4700::1111]:53")
 		// Any errors are unexpected - server "[2606:4700:4700::1111]:53" should
@@ -848,6 +923,32 @@ func TestRotateServerOnErr(t *testing.T) {
 
 }
 
+func TestSetTXTServers(t *testing.T) {
+	staticProvider, err := NewStaticProvider([]string{"a:53"})
+	test.AssertNotError(t, err, "Got error creating StaticProvider")
+	txtProvider, err := NewStaticProvider([]string{"validating:53"})
+	test.AssertNotError(t, err, "Got error creating StaticProvider")
+
+	client := New(time.Second*10, staticProvider, metrics.NoopRegisterer, clock.NewFake(), 1, "", blog.UseMock(), tlsConfig)
+	SetTXTServers(client, txtProvider)
+	mock := &rotateFailureExchanger{lookups: make(map[string]int)}
+	client.(*impl).dnsClient = mock
+
+	_, _, resolvers, err := client.LookupTXT(context.Background(), "_acme-challenge.example.com")
+	test.AssertNotError(t, err, "LookupTXT failed")
+	test.AssertDeepEquals(t, resolvers, ResolverAddrs{"validating:53"})
+
+	_, resolvers, _ = client.LookupHost(context.Background(), "example.com")
+	test.AssertDeepEquals(t, resolvers, ResolverAddrs{"A:a:53", "AAAA:a:53"})
+
+	_, _, resolvers, err = client.LookupCAA(context.Background(), "example.com")
+	test.AssertNotError(t, err, "LookupCAA failed")
+	test.AssertDeepEquals(t, resolvers, ResolverAddrs{"a:53"})
+
+	test.AssertEquals(t, mock.lookups["validating:53"], 1)
+	test.AssertEquals(t, mock.lookups["a:53"], 3)
+}
+
 type mockTempURLError struct{}
 
 func (m *mockTempURLError) Error() string   { return "whoops, oh gosh" }
@@ -19,36 +19,54 @@ type MockClient struct {
 }
 
 // LookupTXT is a mock
-func (mock *MockClient) LookupTXT(_ context.Context, hostname string) ([]string, ResolverAddrs, error) {
+func (mock *MockClient) LookupTXT(_ context.Context, hostname string) ([]string, []string, ResolverAddrs, error) {
 	if hostname == "_acme-challenge.servfail.com" {
-		return nil, ResolverAddrs{"MockClient"}, fmt.Errorf("SERVFAIL")
+		return nil, nil, ResolverAddrs{"MockClient"}, fmt.Errorf("SERVFAIL")
 	}
 	if hostname == "_acme-challenge.good-dns01.com" {
 		// base64(sha256("LoqXcYV8q5ONbJQxbmR7SCTNo3tiAXDfowyjxAjEuX0"
 		//               + "." + "9jg46WB3rR_AHD-EBXdN7cBkH1WOu0tA3M9fm21mqTI"))
 		// expected token + test account jwk thumbprint
-		return []string{"LPsIwTo7o8BoG0-vjCyGQGBWSVIPxI-i_X336eUOQZo"}, ResolverAddrs{"MockClient"}, nil
+		return []string{"LPsIwTo7o8BoG0-vjCyGQGBWSVIPxI-i_X336eUOQZo"}, nil, ResolverAddrs{"MockClient"}, nil
 	}
 	if hostname == "_acme-challenge.wrong-dns01.com" {
-		return []string{"a"}, ResolverAddrs{"MockClient"}, nil
+		return []string{"a"}, nil, ResolverAddrs{"MockClient"}, nil
 	}
 	if hostname == "_acme-challenge.wrong-many-dns01.com" {
-		return []string{"a", "b", "c", "d", "e"}, ResolverAddrs{"MockClient"}, nil
+		return []string{"a", "b", "c", "d", "e"}, nil, ResolverAddrs{"MockClient"}, nil
 	}
 	if hostname == "_acme-challenge.long-dns01.com" {
-		return []string{"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}, ResolverAddrs{"MockClient"}, nil
+		return []string{"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}, nil, ResolverAddrs{"MockClient"}, nil
 	}
 	if hostname == "_acme-challenge.no-authority-dns01.com" {
 		// base64(sha256("LoqXcYV8q5ONbJQxbmR7SCTNo3tiAXDfowyjxAjEuX0"
 		//               + "." + "9jg46WB3rR_AHD-EBXdN7cBkH1WOu0tA3M9fm21mqTI"))
 		// expected token + test account jwk thumbprint
-		return []string{"LPsIwTo7o8BoG0-vjCyGQGBWSVIPxI-i_X336eUOQZo"}, ResolverAddrs{"MockClient"}, nil
+		return []string{"LPsIwTo7o8BoG0-vjCyGQGBWSVIPxI-i_X336eUOQZo"}, nil, ResolverAddrs{"MockClient"}, nil
+	}
+	// good-cname-dns01.com is delegated to a name whose TXT record the
+	// resolver returns along with the alias.
+	if hostname == "_acme-challenge.good-cname-dns01.com" {
+		return []string{"LPsIwTo7o8BoG0-vjCyGQGBWSVIPxI-i_X336eUOQZo"}, []string{"good-dns01.delegated.com"}, ResolverAddrs{"MockClient"}, nil
+	}
+	// chase-cname-dns01.com is delegated through two names, but the resolver
+	// doesn't return the TXT record of the final one.
+	if hostname == "_acme-challenge.chase-cname-dns01.com" {
+		return nil, []string{"one.delegated.com", "_acme-challenge.good-dns01.com"}, ResolverAddrs{"MockClient"}, nil
+	}
+	// loop-cname-dns01.com is aliased to itself via another name.
+	if hostname == "_acme-challenge.loop-cname-dns01.com" {
+		return nil, []string{"loop.delegated.com", "_acme-challenge.loop-cname-dns01.com"}, ResolverAddrs{"MockClient"}, nil
+	}
+	// deep-cname-dns01.com is aliased through more names than are allowed.
+	if hostname == "_acme-challenge.deep-cname-dns01.com" {
+		return nil, []string{"1.delegated.com", "2.delegated.com", "3.delegated.com", "4.delegated.com", "5.delegated.com", "6.delegated.com", "7.delegated.com", "8.delegated.com", "9.delegated.com"}, ResolverAddrs{"MockClient"}, nil
 	}
 	// empty-txts.com always returns zero TXT records
 	if hostname == "_acme-challenge.empty-txts.com" {
-		return []string{}, ResolverAddrs{"MockClient"}, nil
+		return []string{}, nil, ResolverAddrs{"MockClient"}, nil
 	}
-	return []string{"hostname"}, ResolverAddrs{"MockClient"}, nil
+	return []string{"hostname"}, nil, ResolverAddrs{"MockClient"}, nil
 }
 
 // makeTimeoutError returns a a net.OpError for which Timeout() returns true.
@@ -0,0 +1,53 @@
+package ca
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+)
+
+// SigningBudget limits how many OCSP responses and CRLs the CA signs per
+// second, combined, so that bulk work such as regenerating every CRL shard
+// can't monopolize the HSMs which certificate issuance also depends on. A nil
+// *SigningBudget is unlimited.
+type SigningBudget struct {
+	limiter *rate.Limiter
+	waited  *prometheus.CounterVec
+}
+
+// NewSigningBudget returns a SigningBudget which allows opsPerSecond signing
+// operations per second, with bursts of up to that many.
+func NewSigningBudget(opsPerSecond int, stats prometheus.Registerer) (*SigningBudget, error) {
+	if opsPerSecond <= 0 {
+		return nil, errors.New("signing operations per second must be positive")
+	}
+	waited := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "signing_budget_wait_seconds",
+		Help: "Total time spent waiting for the shared signing budget, by purpose=[ocsp|crl]",
+	}, []string{"purpose"})
+	stats.MustRegister(waited)
+
+	return &SigningBudget{
+		limiter: rate.NewLimiter(rate.Limit(opsPerSecond), opsPerSecond),
+		waited:  waited,
+	}, nil
+}
+
+// wait blocks until the budget allows one more signing operation for the given
+// purpose, or returns an error if ctx is done first.
+func (b *SigningBudget) wait(ctx context.Context, purpose string) error {
+	if b == nil {
+		return nil
+	}
+	start := time.Now()
+	err := b.limiter.Wait(ctx)
+	b.waited.With(prometheus.Labels{"purpose": purpose}).Add(time.Since(start).Seconds())
+	if err != nil {
+		return fmt.Errorf("waiting for signing budget: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package ca
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	capb "github.com/letsencrypt/boulder/ca/proto"
+	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestSigningBudget(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewSigningBudget(0, metrics.NoopRegisterer)
+	test.AssertError(t, err, "zero budget accepted")
+
+	// A nil budget never waits.
+	var unlimited *SigningBudget
+	test.AssertNotError(t, unlimited.wait(context.Background(), "ocsp"), "waiting on nil budget")
+
+	budget, err := NewSigningBudget(1, metrics.NoopRegisterer)
+	test.AssertNotError(t, err, "creating budget")
+	test.AssertNotError(t, budget.wait(context.Background(), "crl"), "waiting on fresh budget")
+
+	// The budget is spent for the next second, which is longer than this
+	// context allows.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	test.AssertError(t, budget.wait(ctx, "ocsp"), "waiting on spent budget")
+}
+
+func TestOCSPWaitsForSigningBudget(t *testing.T) {
+	t.Parallel()
+	testCtx := setup(t)
+
+	budget, err := NewSigningBudget(1, metrics.NoopRegisterer)
+	test.AssertNotError(t, err, "creating budget")
+	ocspi := testCtx.ocsp
+	ocspi.budget = budget
+
+	req := &capb.GenerateOCSPRequest{
+		Serial:   "000000000000000000000000000000000001",
+		IssuerID: int64(testCtx.boulderIssuers[0].NameID()),
+		Status:   string(core.OCSPStatusGood),
+	}
+	_, err = ocspi.GenerateOCSP(context.Background(), req)
+	test.AssertNotError(t, err, "generating OCSP within budget")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = ocspi.GenerateOCSP(ctx, req)
+	test.AssertError(t, err, "generating OCSP beyond budget")
+	test.AssertContains(t, err.Error(), "signing budget")
+	test.AssertMetricWithLabelsEquals(t, testCtx.metrics.signatureCount, prometheus.Labels{"purpose": "ocsp"}, 1)
+}
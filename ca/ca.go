@@ -9,6 +9,7 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/asn1"
+	"encoding/base64"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -30,6 +31,8 @@ import (
 	"golang.org/x/crypto/ocsp"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/letsencrypt/boulder/ca/journal"
+	"github.com/letsencrypt/boulder/ca/orphan"
 	capb "github.com/letsencrypt/boulder/ca/proto"
 	"github.com/letsencrypt/boulder/core"
 	csrlib "github.com/letsencrypt/boulder/csr"
@@ -39,6 +42,7 @@ import (
 	"github.com/letsencrypt/boulder/issuance"
 	"github.com/letsencrypt/boulder/linter"
 	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
 	rapb "github.com/letsencrypt/boulder/ra/proto"
 	sapb "github.com/letsencrypt/boulder/sa/proto"
 )
@@ -61,13 +65,64 @@ type issuanceEvent struct {
 	Issuer          string
 	OrderID         int64
 	Profile         string
+	Delegated       bool `json:",omitempty"`
 	Requester       int64
+	SCTs            *sctAudit `json:",omitempty"`
 	Result          struct {
 		Precertificate string `json:",omitempty"`
 		Certificate    string `json:",omitempty"`
 	}
 }
 
+// sctAudit describes the SCTs embedded in a final certificate, so that
+// compliance reports can be generated from the audit log without querying CT
+// logs.
+type sctAudit struct {
+	// Policy is the CT log policy which the SCTs satisfy, as reported by the
+	// SCT provider.
+	Policy string `json:",omitempty"`
+	Logs   []sctAuditLog
+}
+
+// sctAuditLog describes a single SCT and the log which issued it. The log's
+// details other than its ID are omitted if the SCT provider didn't report
+// them.
+type sctAuditLog struct {
+	LogID     string
+	Timestamp time.Time
+	LogName   string `json:",omitempty"`
+	Operator  string `json:",omitempty"`
+	LogURL    string `json:",omitempty"`
+	Tiled     bool   `json:",omitempty"`
+	// SubmissionLatency is how long the log took to issue the SCT, in
+	// seconds.
+	SubmissionLatency float64 `json:",omitempty"`
+}
+
+// newSCTAudit returns the audit record of the given SCTs, filling in the
+// details of their logs from the sources in resp.
+func newSCTAudit(scts []ct.SignedCertificateTimestamp, resp *rapb.SCTResponse) *sctAudit {
+	audit := &sctAudit{Policy: resp.GetPolicy()}
+	for _, sct := range scts {
+		entry := sctAuditLog{
+			LogID:     base64.StdEncoding.EncodeToString(sct.LogID.KeyID[:]),
+			Timestamp: time.UnixMilli(int64(sct.Timestamp)).UTC(),
+		}
+		for _, source := range resp.GetSources() {
+			if source.LogID == entry.LogID {
+				entry.LogName = source.LogName
+				entry.Operator = source.Operator
+				entry.LogURL = source.LogURL
+				entry.Tiled = source.Tiled
+				entry.SubmissionLatency = source.SubmissionLatency.AsDuration().Seconds()
+				break
+			}
+		}
+		audit.Logs = append(audit.Logs, entry)
+	}
+	return audit
+}
+
 // Two maps of keys to Issuers. Lookup by PublicKeyAlgorithm is useful for
 // determining the set of issuers which can sign a given (pre)cert, based on its
 // PublicKeyAlgorithm. Lookup by NameID is useful for looking up a specific
@@ -90,6 +145,15 @@ type caMetrics struct {
 	signErrorCount *prometheus.CounterVec
 	lintErrorCount prometheus.Counter
 	certificates   *prometheus.CounterVec
+	// sanLimitErrorCount counts precertificates refused because they exceed
+	// their profile's SAN limits, by profile.
+	sanLimitErrorCount *prometheus.CounterVec
+	// serialConflicts counts candidate serials which the SA refused to
+	// reserve because they were already in use.
+	serialConflicts prometheus.Counter
+	// signLatency is a histogram of the time taken by the issuer to sign
+	// certificates and precertificates, by purpose and issuer.
+	signLatency *prometheus.HistogramVec
 }
 
 func NewCAMetrics(stats prometheus.Registerer) *caMetrics {
@@ -122,7 +186,31 @@ func NewCAMetrics(stats prometheus.Registerer) *caMetrics {
 		[]string{"profile"})
 	stats.MustRegister(certificates)
 
-	return &caMetrics{signatureCount, signErrorCount, lintErrorCount, certificates}
+	sanLimitErrorCount := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "san_limit_errors",
+			Help: "Number of issuances that were refused for exceeding their profile's SAN limits",
+		},
+		[]string{"profile"})
+	stats.MustRegister(sanLimitErrorCount)
+
+	serialConflicts := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "serial_reservation_conflicts",
+			Help: "Number of generated serials which could not be reserved because they were already in use",
+		})
+	stats.MustRegister(serialConflicts)
+
+	signLatency := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "sign_latency",
+			Help:    "Histogram of the latency of signing certificates and precertificates, by purpose and issuer",
+			Buckets: []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		},
+		[]string{"purpose", "issuer"})
+	stats.MustRegister(signLatency)
+
+	return &caMetrics{signatureCount, signErrorCount, lintErrorCount, certificates, sanLimitErrorCount, serialConflicts, signLatency}
 }
 
 func (m *caMetrics) noteSignError(err error) {
@@ -132,6 +220,12 @@ func (m *caMetrics) noteSignError(err error) {
 	}
 }
 
+// observeSignLatency records the latency of a signature in the signLatency
+// histogram, with the trace ID from ctx, if any, as an exemplar.
+func (m *caMetrics) observeSignLatency(ctx context.Context, purpose certificateType, issuer string, latency time.Duration) {
+	metrics.ObserveWithTraceID(ctx, m.signLatency.With(prometheus.Labels{"purpose": string(purpose), "issuer": issuer}), latency.Seconds())
+}
+
 // certificateAuthorityImpl represents a CA that signs certificates.
 // It can sign OCSP responses as well, but only via delegation to an ocspImpl.
 type certificateAuthorityImpl struct {
@@ -146,6 +240,12 @@ type certificateAuthorityImpl struct {
 	prefix    byte
 	maxNames  int
 	keyPolicy goodkey.KeyPolicy
+	// journal, if non-nil, records every signing operation before it's
+	// performed.
+	journal *journal.Journal
+	// orphanWAL, if non-nil, records every final certificate until the SA
+	// has stored it.
+	orphanWAL *orphan.WAL
 	clk       clock.Clock
 	log       blog.Logger
 	metrics   *caMetrics
@@ -205,7 +305,9 @@ func makeCertificateProfilesMap(profiles map[string]*issuance.ProfileConfig) (ma
 
 // NewCertificateAuthorityImpl creates a CA instance that can sign certificates
 // from any number of issuance.Issuers according to their profiles, and can sign
-// OCSP (via delegation to an ocspImpl and its issuers).
+// OCSP (via delegation to an ocspImpl and its issuers). If issuanceJournal is
+// non-nil, every precertificate and certificate signature is recorded in it
+// first, and none is made if it can't be.
 func NewCertificateAuthorityImpl(
 	sa sapb.StorageAuthorityCertificateClient,
 	sctService rapb.SCTProviderClient,
@@ -215,6 +317,8 @@ func NewCertificateAuthorityImpl(
 	serialPrefix byte,
 	maxNames int,
 	keyPolicy goodkey.KeyPolicy,
+	issuanceJournal *journal.Journal,
+	orphanWAL *orphan.WAL,
 	logger blog.Logger,
 	metrics *caMetrics,
 	clk clock.Clock,
@@ -250,6 +354,8 @@ func NewCertificateAuthorityImpl(
 		prefix:       serialPrefix,
 		maxNames:     maxNames,
 		keyPolicy:    keyPolicy,
+		journal:      issuanceJournal,
+		orphanWAL:    orphanWAL,
 		log:          logger,
 		metrics:      metrics,
 		tracer:       otel.GetTracerProvider().Tracer("github.com/letsencrypt/boulder/ca"),
@@ -277,24 +383,13 @@ var ocspStatusToCode = map[string]int{
 //
 // [issuance cycle]: https://github.com/letsencrypt/boulder/blob/main/docs/ISSUANCE-CYCLE.md
 func (ca *certificateAuthorityImpl) issuePrecertificate(ctx context.Context, certProfile *certProfileWithID, issueReq *capb.IssueCertificateRequest) ([]byte, error) {
-	serialBigInt, err := ca.generateSerialNumber()
-	if err != nil {
-		return nil, err
-	}
-
 	notBefore, notAfter := certProfile.profile.GenerateValidity(ca.clk.Now())
 
-	serialHex := core.SerialToString(serialBigInt)
-	regID := issueReq.RegistrationID
-	_, err = ca.sa.AddSerial(ctx, &sapb.AddSerialRequest{
-		Serial:  serialHex,
-		RegID:   regID,
-		Created: timestamppb.New(ca.clk.Now()),
-		Expires: timestamppb.New(notAfter),
-	})
+	serialBigInt, err := ca.reserveSerial(ctx, issueReq.RegistrationID, notAfter)
 	if err != nil {
 		return nil, err
 	}
+	serialHex := core.SerialToString(serialBigInt)
 
 	precertDER, _, err := ca.issuePrecertificateInner(ctx, issueReq, certProfile, serialBigInt, notBefore, notAfter)
 	if err != nil {
@@ -331,7 +426,7 @@ func (ca *certificateAuthorityImpl) IssueCertificate(ctx context.Context, issueR
 	if err != nil {
 		return nil, err
 	}
-	certDER, err := ca.issueCertificateForPrecertificate(ctx, certProfile, precertDER, scts.SctDER, issueReq.RegistrationID, issueReq.OrderID)
+	certDER, err := ca.issueCertificateForPrecertificate(ctx, certProfile, precertDER, scts, issueReq.RegistrationID, issueReq.OrderID)
 	if err != nil {
 		return nil, err
 	}
@@ -340,7 +435,8 @@ func (ca *certificateAuthorityImpl) IssueCertificate(ctx context.Context, issueR
 
 // issueCertificateForPrecertificate is final step in the [issuance cycle].
 //
-// Given a precertificate and a set of SCTs for that precertificate, it generates
+// Given a precertificate and a set of SCTs for that precertificate, along with
+// the sources of those SCTs to record in the audit log, it generates
 // a linting final certificate, then signs a final certificate using a real issuer.
 // The poison extension is removed from the precertificate and a
 // SCT list extension is inserted in its place. Except for this and the
@@ -366,7 +462,7 @@ func (ca *certificateAuthorityImpl) IssueCertificate(ctx context.Context, issueR
 func (ca *certificateAuthorityImpl) issueCertificateForPrecertificate(ctx context.Context,
 	certProfile *certProfileWithID,
 	precertDER []byte,
-	sctBytes [][]byte,
+	sctResp *rapb.SCTResponse,
 	regID int64,
 	orderID int64,
 ) ([]byte, error) {
@@ -384,7 +480,7 @@ func (ca *certificateAuthorityImpl) issueCertificateForPrecertificate(ctx contex
 		return nil, fmt.Errorf("error checking for duplicate issuance of %s: %s", serialHex, err)
 	}
 	var scts []ct.SignedCertificateTimestamp
-	for _, singleSCTBytes := range sctBytes {
+	for _, singleSCTBytes := range sctResp.GetSctDER() {
 		var sct ct.SignedCertificateTimestamp
 		_, err = cttls.Unmarshal(singleSCTBytes, &sct)
 		if err != nil {
@@ -414,10 +510,17 @@ func (ca *certificateAuthorityImpl) issueCertificateForPrecertificate(ctx contex
 		Issuer:          issuer.Name(),
 		OrderID:         orderID,
 		Profile:         certProfile.name,
+		Delegated:       certProfile.profile.IncludesDelegationUsage(),
 		Requester:       regID,
+		SCTs:            newSCTAudit(scts, sctResp),
 	}
 	ca.log.AuditObject("Signing cert", logEvent)
 
+	err = ca.journalSigning(journal.TypeCertificate, serialHex, issuer, certProfile.name, issuanceReq.SubjectKeyId, precertDER)
+	if err != nil {
+		return nil, err
+	}
+
 	var ipStrings []string
 	for _, ip := range issuanceReq.IPAddresses {
 		ipStrings = append(ipStrings, ip.String())
@@ -430,7 +533,9 @@ func (ca *certificateAuthorityImpl) issueCertificateForPrecertificate(ctx contex
 		attribute.StringSlice("names", issuanceReq.DNSNames),
 		attribute.StringSlice("ipAddresses", ipStrings),
 	))
+	signStart := ca.clk.Now()
 	certDER, err := issuer.Issue(issuanceToken)
+	ca.metrics.observeSignLatency(ctx, certType, issuer.Name(), ca.clk.Since(signStart))
 	if err != nil {
 		ca.metrics.noteSignError(err)
 		ca.log.AuditErrf("Signing cert failed: serial=[%s] err=[%v]", serialHex, err)
@@ -449,20 +554,80 @@ func (ca *certificateAuthorityImpl) issueCertificateForPrecertificate(ctx contex
 	ca.metrics.certificates.With(prometheus.Labels{"profile": certProfile.name}).Inc()
 	logEvent.Result.Certificate = hex.EncodeToString(certDER)
 	ca.log.AuditObject("Signing cert success", logEvent)
+	ca.journalIssued(serialHex, certDER)
+
+	issued := ca.clk.Now()
+	if ca.orphanWAL != nil {
+		// If the SA doesn't store the certificate, it's replayed from the
+		// write-ahead log when the CA next starts. It has already been
+		// signed, so failing to write it doesn't fail issuance.
+		err = ca.orphanWAL.Append(orphan.Record{Serial: serialHex, RegID: regID, Issued: issued, DER: certDER})
+		if err != nil {
+			ca.log.AuditErrf("Writing cert to write-ahead log failed: serial=[%s] err=[%v]", serialHex, err)
+		}
+	}
 
 	_, err = ca.sa.AddCertificate(ctx, &sapb.AddCertificateRequest{
 		Der:    certDER,
 		RegID:  regID,
-		Issued: timestamppb.New(ca.clk.Now()),
+		Issued: timestamppb.New(issued),
 	})
 	if err != nil {
 		ca.log.AuditErrf("Failed RPC to store at SA: serial=[%s] err=[%v]", serialHex, hex.EncodeToString(certDER))
 		return nil, err
 	}
 
+	if ca.orphanWAL != nil {
+		err = ca.orphanWAL.Ack(serialHex)
+		if err != nil {
+			ca.log.Warningf("Acknowledging stored cert in write-ahead log failed: serial=[%s] err=[%v]", serialHex, err)
+		}
+	}
+
 	return certDER, nil
 }
 
+// journalSigning records, in the issuance journal if there is one, that the
+// certificate with the given serial is about to be signed from request: the
+// CSR for a precertificate, or the precertificate for a final certificate.
+func (ca *certificateAuthorityImpl) journalSigning(entryType string, serialHex string, issuer *issuance.Issuer, profile string, skid []byte, request []byte) error {
+	if ca.journal == nil {
+		return nil
+	}
+	requestHash := sha256.Sum256(request)
+	err := ca.journal.Append(journal.Entry{
+		Type:        entryType,
+		Serial:      serialHex,
+		Issuer:      issuer.Name(),
+		Profile:     profile,
+		SKID:        hex.EncodeToString(skid),
+		RequestHash: hex.EncodeToString(requestHash[:]),
+	})
+	if err != nil {
+		ca.log.AuditErrf("Journaling %s signing failed: serial=[%s] err=[%v]", entryType, serialHex, err)
+		return berrors.InternalServerError("failed to journal %s signing: %s", entryType, err)
+	}
+	return nil
+}
+
+// journalIssued records, in the issuance journal if there is one, a final
+// certificate which has been signed, so that the issuance-reconciler can
+// recover it if it isn't stored. The certificate already exists, so failing
+// to journal it is logged but doesn't fail issuance.
+func (ca *certificateAuthorityImpl) journalIssued(serialHex string, certDER []byte) {
+	if ca.journal == nil {
+		return
+	}
+	err := ca.journal.Append(journal.Entry{
+		Type:        journal.TypeIssued,
+		Serial:      serialHex,
+		Certificate: certDER,
+	})
+	if err != nil {
+		ca.log.AuditErrf("Journaling issued certificate failed: serial=[%s] err=[%v]", serialHex, err)
+	}
+}
+
 // generateSerialNumber produces a big.Int which has more than 64 bits of
 // entropy and has the CA's configured one-byte prefix.
 func (ca *certificateAuthorityImpl) generateSerialNumber() (*big.Int, error) {
@@ -482,6 +647,41 @@ func (ca *certificateAuthorityImpl) generateSerialNumber() (*big.Int, error) {
 	return serialBigInt, nil
 }
 
+// maxSerialReservationAttempts is how many serials reserveSerial generates
+// before giving up, if each is already in use.
+const maxSerialReservationAttempts = 3
+
+// reserveSerial generates a serial and records it in the SA, which refuses to
+// record a serial twice. It must be called before signing anything with the
+// serial, so that a serial is never signed twice even if the source of
+// randomness or the CA's serial prefix configuration is faulty. If the serial
+// is already in use, it generates another.
+func (ca *certificateAuthorityImpl) reserveSerial(ctx context.Context, regID int64, notAfter time.Time) (*big.Int, error) {
+	for range maxSerialReservationAttempts {
+		serialBigInt, err := ca.generateSerialNumber()
+		if err != nil {
+			return nil, err
+		}
+		serialHex := core.SerialToString(serialBigInt)
+		_, err = ca.sa.AddSerial(ctx, &sapb.AddSerialRequest{
+			Serial:  serialHex,
+			RegID:   regID,
+			Created: timestamppb.New(ca.clk.Now()),
+			Expires: timestamppb.New(notAfter),
+		})
+		if errors.Is(err, berrors.Duplicate) {
+			ca.metrics.serialConflicts.Inc()
+			ca.log.AuditErrf("Generated serial already in use: serial=[%s] regID=[%d]", serialHex, regID)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return serialBigInt, nil
+	}
+	return nil, berrors.InternalServerError("failed to reserve an unused serial after %d attempts", maxSerialReservationAttempts)
+}
+
 // generateSKID computes the Subject Key Identifier using one of the methods in
 // RFC 7093 Section 2 Additional Methods for Generating Key Identifiers:
 // The keyIdentifier [may be] composed of the leftmost 160-bits of the
@@ -505,6 +705,31 @@ func generateSKID(pk crypto.PublicKey) ([]byte, error) {
 	return skid[0:20:20], nil
 }
 
+// pickIssuer picks one of the pool's issuers at random, in proportion to the
+// shares their ramps give them at the given time. It returns nil if none of
+// them has a share.
+func pickIssuer(pool []*issuance.Issuer, now time.Time) *issuance.Issuer {
+	ramps := make([][]issuance.RampStep, len(pool))
+	for i, issuer := range pool {
+		ramps[i] = issuer.Ramp()
+	}
+	shares := issuance.RampShares(ramps, now)
+	r := mrand.Float64()
+	var last *issuance.Issuer
+	for i, share := range shares {
+		if share == 0 {
+			continue
+		}
+		if r < share {
+			return pool[i]
+		}
+		r -= share
+		last = pool[i]
+	}
+	// The shares may add up to slightly less than 1.
+	return last
+}
+
 func (ca *certificateAuthorityImpl) issuePrecertificateInner(ctx context.Context, issueReq *capb.IssueCertificateRequest, certProfile *certProfileWithID, serialBigInt *big.Int, notBefore time.Time, notAfter time.Time) ([]byte, *certProfileWithID, error) {
 	csr, err := x509.ParseCertificateRequest(issueReq.Csr)
 	if err != nil {
@@ -523,12 +748,16 @@ func (ca *certificateAuthorityImpl) issuePrecertificateInner(ctx context.Context
 	// type.
 	alg := csr.PublicKeyAlgorithm
 
-	// Select a random issuer from among the active issuers of this key type.
+	// Select a random issuer from among the active issuers of this key type,
+	// according to their ramps.
 	issuerPool, ok := ca.issuers.byAlg[alg]
 	if !ok || len(issuerPool) == 0 {
 		return nil, nil, berrors.InternalServerError("no issuers found for public key algorithm %s", csr.PublicKeyAlgorithm)
 	}
-	issuer := issuerPool[mrand.IntN(len(issuerPool))]
+	issuer := pickIssuer(issuerPool, ca.clk.Now())
+	if issuer == nil {
+		return nil, nil, berrors.InternalServerError("no issuers for public key algorithm %s are ramped up", csr.PublicKeyAlgorithm)
+	}
 
 	if issuer.Cert.NotAfter.Before(notAfter) {
 		err = berrors.InternalServerError("cannot issue a certificate that expires after the issuer certificate")
@@ -566,6 +795,12 @@ func (ca *certificateAuthorityImpl) issuePrecertificateInner(ctx context.Context
 		if errors.Is(err, linter.ErrLinting) {
 			ca.metrics.lintErrorCount.Inc()
 		}
+		if errors.Is(err, issuance.ErrSANLimits) {
+			// The RA should have refused this request already, so this
+			// indicates that its profile and ours disagree.
+			ca.metrics.sanLimitErrorCount.WithLabelValues(certProfile.name).Inc()
+			return nil, nil, berrors.BadCSRError("certificate profile %q does not allow this request: %s", certProfile.name, err)
+		}
 		return nil, nil, berrors.InternalServerError("failed to prepare precertificate signing: %s", err)
 	}
 
@@ -590,11 +825,17 @@ func (ca *certificateAuthorityImpl) issuePrecertificateInner(ctx context.Context
 		IssuanceRequest: req,
 		Issuer:          issuer.Name(),
 		Profile:         certProfile.name,
+		Delegated:       certProfile.profile.IncludesDelegationUsage(),
 		Requester:       issueReq.RegistrationID,
 		OrderID:         issueReq.OrderID,
 	}
 	ca.log.AuditObject("Signing precert", logEvent)
 
+	err = ca.journalSigning(journal.TypePrecertificate, serialHex, issuer, certProfile.name, subjectKeyId, csr.Raw)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var ipStrings []string
 	for _, ip := range csr.IPAddresses {
 		ipStrings = append(ipStrings, ip.String())
@@ -607,7 +848,9 @@ func (ca *certificateAuthorityImpl) issuePrecertificateInner(ctx context.Context
 		attribute.StringSlice("names", csr.DNSNames),
 		attribute.StringSlice("ipAddresses", ipStrings),
 	))
+	signStart := ca.clk.Now()
 	certDER, err := issuer.Issue(issuanceToken)
+	ca.metrics.observeSignLatency(ctx, precertType, issuer.Name(), ca.clk.Since(signStart))
 	if err != nil {
 		ca.metrics.noteSignError(err)
 		ca.log.AuditErrf("Signing precert failed: serial=[%s] err=[%v]", serialHex, err)
@@ -5,14 +5,20 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/sha256"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"math/big"
 	mrand "math/rand"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -24,8 +30,11 @@ import (
 	"github.com/miekg/pkcs11"
 	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
 
+	"github.com/letsencrypt/boulder/ca/journal"
+	"github.com/letsencrypt/boulder/ca/orphan"
 	capb "github.com/letsencrypt/boulder/ca/proto"
 	"github.com/letsencrypt/boulder/config"
 	"github.com/letsencrypt/boulder/core"
@@ -211,13 +220,29 @@ func setup(t *testing.T) *testCtx {
 			Name: "certificates",
 			Help: "Number of certificates issued",
 		}, []string{"profile"})
-	cametrics := &caMetrics{signatureCount, signErrorCount, lintErrorCount, certificatesCount}
+	sanLimitErrorCount := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "san_limit_errors",
+			Help: "Number of issuances that were refused for exceeding their profile's SAN limits",
+		}, []string{"profile"})
+	serialConflicts := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "serial_reservation_conflicts",
+			Help: "Number of generated serials which could not be reserved because they were already in use",
+		})
+	signLatency := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "sign_latency",
+			Help: "Histogram of the latency of signing certificates and precertificates, by purpose and issuer",
+		}, []string{"purpose", "issuer"})
+	cametrics := &caMetrics{signatureCount, signErrorCount, lintErrorCount, certificatesCount, sanLimitErrorCount, serialConflicts, signLatency}
 
 	ocsp, err := NewOCSPImpl(
 		boulderIssuers,
 		24*time.Hour,
 		0,
 		time.Second,
+		nil,
 		blog.NewMock(),
 		metrics.NoopRegisterer,
 		cametrics,
@@ -232,8 +257,10 @@ func setup(t *testing.T) *testCtx {
 			MaxBackdate:      config.Duration{Duration: time.Hour},
 		},
 		100,
+		nil,
 		blog.NewMock(),
 		cametrics,
+		fc,
 	)
 	test.AssertNotError(t, err, "Failed to create crl impl")
 
@@ -265,6 +292,8 @@ func TestSerialPrefix(t *testing.T) {
 		0x00,
 		testCtx.maxNames,
 		testCtx.keyPolicy,
+		nil,
+		nil,
 		testCtx.logger,
 		nil,
 		testCtx.fc)
@@ -279,6 +308,8 @@ func TestSerialPrefix(t *testing.T) {
 		0x80,
 		testCtx.maxNames,
 		testCtx.keyPolicy,
+		nil,
+		nil,
 		testCtx.logger,
 		nil,
 		testCtx.fc)
@@ -379,6 +410,8 @@ func issueCertificateSubTestSetup(t *testing.T) (*certificateAuthorityImpl, *moc
 		testCtx.serialPrefix,
 		testCtx.maxNames,
 		testCtx.keyPolicy,
+		nil,
+		nil,
 		testCtx.logger,
 		testCtx.metrics,
 		testCtx.fc)
@@ -418,6 +451,8 @@ func TestNoIssuers(t *testing.T) {
 		testCtx.serialPrefix,
 		testCtx.maxNames,
 		testCtx.keyPolicy,
+		nil,
+		nil,
 		testCtx.logger,
 		testCtx.metrics,
 		testCtx.fc)
@@ -439,6 +474,8 @@ func TestMultipleIssuers(t *testing.T) {
 		testCtx.serialPrefix,
 		testCtx.maxNames,
 		testCtx.keyPolicy,
+		nil,
+		nil,
 		testCtx.logger,
 		testCtx.metrics,
 		testCtx.fc)
@@ -511,6 +548,8 @@ func TestUnpredictableIssuance(t *testing.T) {
 		testCtx.serialPrefix,
 		testCtx.maxNames,
 		testCtx.keyPolicy,
+		nil,
+		nil,
 		testCtx.logger,
 		testCtx.metrics,
 		testCtx.fc)
@@ -549,6 +588,56 @@ func TestUnpredictableIssuance(t *testing.T) {
 	test.Assert(t, seenR3, "Expected at least one issuance from active issuer")
 }
 
+func TestPickIssuerRamp(t *testing.T) {
+	t.Parallel()
+	fc := clock.NewFake()
+
+	loadIssuer := func(name string, ramp []issuance.RampStep) *issuance.Issuer {
+		issuer, err := issuance.LoadIssuer(issuance.IssuerConfig{
+			Active:     true,
+			Ramp:       ramp,
+			IssuerURL:  fmt.Sprintf("http://not-example.com/i/%s", name),
+			CRLURLBase: fmt.Sprintf("http://not-example.com/c/%s/", name),
+			Location: issuance.IssuerLoc{
+				File:     fmt.Sprintf("../test/hierarchy/%s.key.pem", name),
+				CertFile: fmt.Sprintf("../test/hierarchy/%s.cert.pem", name),
+			},
+		}, fc)
+		test.AssertNotError(t, err, "Couldn't load test issuer")
+		return issuer
+	}
+
+	// int-e2 is introduced in an hour, and takes over entirely from int-e1 in
+	// two.
+	e1 := loadIssuer("int-e1", nil)
+	e2 := loadIssuer("int-e2", []issuance.RampStep{
+		{Start: fc.Now().Add(time.Hour), Percent: 50},
+		{Start: fc.Now().Add(2 * time.Hour), Percent: 100},
+	})
+	pool := []*issuance.Issuer{e1, e2}
+
+	for range 20 {
+		test.AssertEquals(t, pickIssuer(pool, fc.Now()), e1)
+	}
+	seenE1, seenE2 := false, false
+	for range 40 {
+		switch pickIssuer(pool, fc.Now().Add(time.Hour)) {
+		case e1:
+			seenE1 = true
+		case e2:
+			seenE2 = true
+		}
+	}
+	test.Assert(t, seenE1 && seenE2, "Expected issuance from both issuers halfway through the ramp")
+	for range 20 {
+		test.AssertEquals(t, pickIssuer(pool, fc.Now().Add(2*time.Hour)), e2)
+	}
+
+	// If only ramped issuers are left, and none of them has started, there's
+	// no issuer to pick.
+	test.Assert(t, pickIssuer([]*issuance.Issuer{e2}, fc.Now()) == nil, "Expected no issuer before the ramp starts")
+}
+
 func TestMakeCertificateProfilesMap(t *testing.T) {
 	t.Parallel()
 	testCtx := setup(t)
@@ -676,6 +765,8 @@ func TestInvalidCSRs(t *testing.T) {
 			testCtx.serialPrefix,
 			testCtx.maxNames,
 			testCtx.keyPolicy,
+			nil,
+			nil,
 			testCtx.logger,
 			testCtx.metrics,
 			testCtx.fc)
@@ -716,6 +807,8 @@ func TestRejectValidityTooLong(t *testing.T) {
 		testCtx.serialPrefix,
 		testCtx.maxNames,
 		testCtx.keyPolicy,
+		nil,
+		nil,
 		testCtx.logger,
 		testCtx.metrics,
 		testCtx.fc)
@@ -728,6 +821,57 @@ func TestRejectValidityTooLong(t *testing.T) {
 	test.AssertErrorIs(t, err, berrors.InternalServer)
 }
 
+func TestProfileSANLimits(t *testing.T) {
+	t.Parallel()
+
+	// CNandSANCSR contains two names, whose SAN extension is 40 bytes long.
+	testCases := []struct {
+		name        string
+		maxNames    int
+		maxSANBytes int
+		wantErr     bool
+	}{
+		{"no limits", 0, 0, false},
+		{"within limits", 2, 40, false},
+		{"too many names", 1, 0, true},
+		{"too many bytes", 0, 39, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			testCtx := setup(t)
+			testCtx.certProfiles["legacy"].MaxNames = tc.maxNames
+			testCtx.certProfiles["legacy"].MaxSANBytes = tc.maxSANBytes
+			ca, err := NewCertificateAuthorityImpl(
+				&mockSA{},
+				mockSCTService{},
+				testCtx.pa,
+				testCtx.boulderIssuers,
+				testCtx.certProfiles,
+				testCtx.serialPrefix,
+				testCtx.maxNames,
+				testCtx.keyPolicy,
+				nil,
+				nil,
+				testCtx.logger,
+				testCtx.metrics,
+				testCtx.fc)
+			test.AssertNotError(t, err, "Failed to create CA")
+
+			profile := ca.certProfiles["legacy"]
+			_, err = ca.issuePrecertificate(ctx, profile, &capb.IssueCertificateRequest{Csr: CNandSANCSR, RegistrationID: mrand.Int63(), OrderID: mrand.Int63(), CertProfileName: "legacy"})
+			if !tc.wantErr {
+				test.AssertNotError(t, err, "Failed to issue precertificate")
+				return
+			}
+			test.AssertErrorIs(t, err, berrors.BadCSR)
+			test.AssertContains(t, err.Error(), `certificate profile "legacy" does not allow this request`)
+			test.AssertMetricWithLabelsEquals(t, ca.metrics.sanLimitErrorCount, prometheus.Labels{"profile": "legacy"}, 1)
+		})
+	}
+}
+
 func issueCertificateSubTestProfileSelectionRSA(t *testing.T, i *TestCertificateIssuance) {
 	// Certificates for RSA keys should be marked as usable for signatures and encryption.
 	expectedKeyUsage := x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
@@ -792,6 +936,8 @@ func TestIssueCertificateForPrecertificate(t *testing.T) {
 		testCtx.serialPrefix,
 		testCtx.maxNames,
 		testCtx.keyPolicy,
+		nil,
+		nil,
 		testCtx.logger,
 		testCtx.metrics,
 		testCtx.fc)
@@ -821,7 +967,7 @@ func TestIssueCertificateForPrecertificate(t *testing.T) {
 	certDER, err := ca.issueCertificateForPrecertificate(ctx,
 		profile,
 		precertDER,
-		sctBytes,
+		&rapb.SCTResponse{SctDER: sctBytes},
 		mrand.Int63(),
 		mrand.Int63())
 	test.AssertNotError(t, err, "Failed to issue cert from precert")
@@ -841,6 +987,174 @@ func TestIssueCertificateForPrecertificate(t *testing.T) {
 	test.Assert(t, len(sctList) == 1, fmt.Sprintf("Wrong number of SCTs, wanted: 1, got: %d", len(sctList)))
 }
 
+func TestIssueCertificateForPrecertificateAuditsSCTs(t *testing.T) {
+	t.Parallel()
+	testCtx := setup(t)
+	ca, err := NewCertificateAuthorityImpl(
+		&mockSA{},
+		mockSCTService{},
+		testCtx.pa,
+		testCtx.boulderIssuers,
+		testCtx.certProfiles,
+		testCtx.serialPrefix,
+		testCtx.maxNames,
+		testCtx.keyPolicy,
+		nil,
+		nil,
+		testCtx.logger,
+		testCtx.metrics,
+		testCtx.fc)
+	test.AssertNotError(t, err, "Failed to create CA")
+
+	profile := ca.certProfiles["legacy"]
+	issueReq := capb.IssueCertificateRequest{Csr: CNandSANCSR, RegistrationID: mrand.Int63(), OrderID: mrand.Int63(), CertProfileName: "legacy"}
+	precertDER, err := ca.issuePrecertificate(ctx, profile, &issueReq)
+	test.AssertNotError(t, err, "Failed to issue precert")
+
+	sctBytes, err := makeSCTs()
+	test.AssertNotError(t, err, "Failed to marshal SCT")
+	_, err = ca.issueCertificateForPrecertificate(ctx,
+		profile,
+		precertDER,
+		&rapb.SCTResponse{
+			SctDER: sctBytes,
+			Sources: []*rapb.SCTSource{{
+				LogID:             base64.StdEncoding.EncodeToString(make([]byte, 32)),
+				LogName:           "LogA1",
+				Operator:          "OperA",
+				LogURL:            "https://a1.example/",
+				SubmissionLatency: durationpb.New(1500 * time.Millisecond),
+			}},
+			Policy: "two operators",
+		},
+		mrand.Int63(),
+		mrand.Int63())
+	test.AssertNotError(t, err, "Failed to issue cert from precert")
+
+	lines := testCtx.logger.GetAllMatching("Signing cert success")
+	test.AssertEquals(t, len(lines), 1)
+	test.AssertContains(t, lines[0], `"SCTs":{"Policy":"two operators","Logs":[{"LogID":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=","Timestamp":"1970-01-01T00:00:02.02Z","LogName":"LogA1","Operator":"OperA","LogURL":"https://a1.example/","SubmissionLatency":1.5}]}`)
+}
+
+func TestIssuePrecertificateAuditsDelegated(t *testing.T) {
+	t.Parallel()
+	testCtx := setup(t)
+	profiles := maps.Clone(testCtx.certProfiles)
+	delegated := *profiles["modern"]
+	delegated.IncludeDelegationUsage = true
+	profiles["delegated"] = &delegated
+	ca, err := NewCertificateAuthorityImpl(
+		&mockSA{},
+		mockSCTService{},
+		testCtx.pa,
+		testCtx.boulderIssuers,
+		profiles,
+		testCtx.serialPrefix,
+		testCtx.maxNames,
+		testCtx.keyPolicy,
+		nil,
+		nil,
+		testCtx.logger,
+		testCtx.metrics,
+		testCtx.fc)
+	test.AssertNotError(t, err, "Failed to create CA")
+
+	for _, name := range []string{"legacy", "delegated"} {
+		issueReq := capb.IssueCertificateRequest{Csr: CNandSANCSR, RegistrationID: mrand.Int63(), OrderID: mrand.Int63(), CertProfileName: name}
+		_, err = ca.issuePrecertificate(ctx, ca.certProfiles[name], &issueReq)
+		test.AssertNotError(t, err, "Failed to issue precert")
+	}
+
+	lines := testCtx.logger.GetAllMatching("Signing precert success")
+	test.AssertEquals(t, len(lines), 2)
+	test.AssertContains(t, lines[0], `"Profile":"legacy","Requester"`)
+	test.AssertContains(t, lines[1], `"Profile":"delegated","Delegated":true,"Requester"`)
+}
+
+func TestIssuanceJournal(t *testing.T) {
+	t.Parallel()
+	testCtx := setup(t)
+	journalKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating journal key")
+	journalPath := filepath.Join(t.TempDir(), "journal")
+	issuanceJournal, err := journal.Open(journalPath, journalKey, testCtx.fc)
+	test.AssertNotError(t, err, "opening journal")
+	ca, err := NewCertificateAuthorityImpl(
+		&mockSA{},
+		mockSCTService{},
+		testCtx.pa,
+		testCtx.boulderIssuers,
+		testCtx.certProfiles,
+		testCtx.serialPrefix,
+		testCtx.maxNames,
+		testCtx.keyPolicy,
+		issuanceJournal,
+		nil,
+		testCtx.logger,
+		testCtx.metrics,
+		testCtx.fc)
+	test.AssertNotError(t, err, "Failed to create CA")
+
+	profile := ca.certProfiles["legacy"]
+	issueReq := capb.IssueCertificateRequest{Csr: CNandSANCSR, RegistrationID: mrand.Int63(), OrderID: mrand.Int63(), CertProfileName: "legacy"}
+	precertDER, err := ca.issuePrecertificate(ctx, profile, &issueReq)
+	test.AssertNotError(t, err, "Failed to issue precert")
+	sctBytes, err := makeSCTs()
+	test.AssertNotError(t, err, "Failed to make SCTs")
+	certDER, err := ca.issueCertificateForPrecertificate(ctx, profile, precertDER, &rapb.SCTResponse{SctDER: sctBytes}, mrand.Int63(), mrand.Int63())
+	test.AssertNotError(t, err, "Failed to issue cert from precert")
+	cert, err := x509.ParseCertificate(certDER)
+	test.AssertNotError(t, err, "Failed to parse cert")
+	test.AssertNotError(t, issuanceJournal.Close(), "closing journal")
+
+	contents, err := os.ReadFile(journalPath)
+	test.AssertNotError(t, err, "reading journal")
+	var entries []journal.Entry
+	for _, line := range strings.Split(strings.TrimSpace(string(contents)), "\n") {
+		var entry journal.Entry
+		test.AssertNotError(t, json.Unmarshal([]byte(line), &entry), "parsing journal entry")
+		entries = append(entries, entry)
+	}
+	test.AssertEquals(t, len(entries), 4)
+
+	csrHash := sha256.Sum256(CNandSANCSR)
+	precertHash := sha256.Sum256(precertDER)
+	for i, want := range []struct {
+		entryType   string
+		requestHash []byte
+	}{
+		{journal.TypePrecertificate, csrHash[:]},
+		{journal.TypeCertificate, precertHash[:]},
+	} {
+		test.AssertEquals(t, entries[i].Type, want.entryType)
+		test.AssertEquals(t, entries[i].Serial, core.SerialToString(cert.SerialNumber))
+		test.AssertEquals(t, entries[i].Issuer, cert.Issuer.CommonName)
+		test.AssertEquals(t, entries[i].Profile, "legacy")
+		test.AssertEquals(t, entries[i].SKID, hex.EncodeToString(cert.SubjectKeyId))
+		test.AssertEquals(t, entries[i].RequestHash, hex.EncodeToString(want.requestHash))
+	}
+	// The signed final certificate is journaled after it, so that it can be
+	// recovered if it isn't stored.
+	test.AssertEquals(t, entries[2].Type, journal.TypeIssued)
+	test.AssertEquals(t, entries[2].Serial, core.SerialToString(cert.SerialNumber))
+	test.AssertByteEquals(t, entries[2].Certificate, certDER)
+	test.AssertEquals(t, entries[3].Type, journal.TypeCheckpoint)
+
+	f, err := os.Open(journalPath)
+	test.AssertNotError(t, err, "opening journal")
+	defer f.Close()
+	summary, err := journal.Verify(f, journalKey.Public())
+	test.AssertNotError(t, err, "verifying journal")
+	test.AssertEquals(t, summary.Entries, 3)
+	test.AssertEquals(t, summary.Unsigned, 0)
+
+	// Nothing is signed if it can't be journaled first.
+	_, err = ca.issuePrecertificate(ctx, profile, &issueReq)
+	test.AssertError(t, err, "issued a precert which couldn't be journaled")
+	test.AssertContains(t, err.Error(), "failed to journal precertificate signing")
+	test.AssertMetricWithLabelsEquals(t, ca.metrics.signatureCount, prometheus.Labels{"purpose": "precertificate"}, 1)
+}
+
 func TestIssueCertificateForPrecertificateWithSpecificCertificateProfile(t *testing.T) {
 	t.Parallel()
 	testCtx := setup(t)
@@ -854,6 +1168,8 @@ func TestIssueCertificateForPrecertificateWithSpecificCertificateProfile(t *test
 		testCtx.serialPrefix,
 		testCtx.maxNames,
 		testCtx.keyPolicy,
+		nil,
+		nil,
 		testCtx.logger,
 		testCtx.metrics,
 		testCtx.fc)
@@ -891,7 +1207,7 @@ func TestIssueCertificateForPrecertificateWithSpecificCertificateProfile(t *test
 	certDER, err := ca.issueCertificateForPrecertificate(ctx,
 		certProfile,
 		precertDER,
-		sctBytes,
+		&rapb.SCTResponse{SctDER: sctBytes},
 		mrand.Int63(),
 		mrand.Int63())
 	test.AssertNotError(t, err, "Failed to issue cert from precert")
@@ -937,6 +1253,63 @@ func deserializeSCTList(serializedSCTList []byte) ([]ct.SignedCertificateTimesta
 	return list, nil
 }
 
+// addCertErrorSA fails to store final certificates.
+type addCertErrorSA struct {
+	mockSA
+}
+
+func (m *addCertErrorSA) AddCertificate(ctx context.Context, req *sapb.AddCertificateRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	return nil, errors.New("SA is down")
+}
+
+func TestOrphanWAL(t *testing.T) {
+	t.Parallel()
+	testCtx := setup(t)
+	orphanWAL, err := orphan.OpenWAL(filepath.Join(t.TempDir(), "wal"))
+	test.AssertNotError(t, err, "opening write-ahead log")
+	defer orphanWAL.Close()
+
+	sctBytes, err := makeSCTs()
+	test.AssertNotError(t, err, "Failed to make SCTs")
+	for _, sa := range []sapb.StorageAuthorityCertificateClient{&mockSA{}, &addCertErrorSA{}} {
+		ca, err := NewCertificateAuthorityImpl(
+			sa,
+			mockSCTService{},
+			testCtx.pa,
+			testCtx.boulderIssuers,
+			testCtx.certProfiles,
+			testCtx.serialPrefix,
+			testCtx.maxNames,
+			testCtx.keyPolicy,
+			nil,
+			orphanWAL,
+			testCtx.logger,
+			testCtx.metrics,
+			testCtx.fc)
+		test.AssertNotError(t, err, "Failed to create CA")
+
+		profile := ca.certProfiles["legacy"]
+		issueReq := capb.IssueCertificateRequest{Csr: CNandSANCSR, RegistrationID: mrand.Int63(), OrderID: mrand.Int63(), CertProfileName: "legacy"}
+		precertDER, err := ca.issuePrecertificate(ctx, profile, &issueReq)
+		test.AssertNotError(t, err, "Failed to issue precert")
+		_, err = ca.issueCertificateForPrecertificate(ctx, profile, precertDER, &rapb.SCTResponse{SctDER: sctBytes}, 1234, mrand.Int63())
+		if _, ok := sa.(*addCertErrorSA); ok {
+			test.AssertError(t, err, "stored a cert with the SA down")
+		} else {
+			test.AssertNotError(t, err, "Failed to issue cert from precert")
+		}
+	}
+
+	// Only the certificate which the SA failed to store is left to recover.
+	pending := orphanWAL.Pending()
+	test.AssertEquals(t, len(pending), 1)
+	cert, err := x509.ParseCertificate(pending[0].DER)
+	test.AssertNotError(t, err, "parsing pending certificate")
+	test.AssertEquals(t, pending[0].Serial, core.SerialToString(cert.SerialNumber))
+	test.AssertEquals(t, pending[0].RegID, int64(1234))
+	test.AssertEquals(t, pending[0].Issued, testCtx.fc.Now())
+}
+
 // dupeSA returns a non-error to GetCertificate in order to simulate a request
 // to issue a final certificate with a duplicate serial.
 type dupeSA struct {
@@ -956,6 +1329,61 @@ func (m *getCertErrorSA) GetCertificate(ctx context.Context, req *sapb.Serial, _
 	return nil, fmt.Errorf("i don't like it")
 }
 
+// conflictingSerialSA refuses to reserve the first conflicts serials it's
+// given, as if they were already in use.
+type conflictingSerialSA struct {
+	mockSA
+	conflicts int
+	reserved  []string
+}
+
+func (m *conflictingSerialSA) AddSerial(ctx context.Context, req *sapb.AddSerialRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	if m.conflicts > 0 {
+		m.conflicts--
+		return nil, berrors.DuplicateError("serial %q is already in use", req.Serial)
+	}
+	m.reserved = append(m.reserved, req.Serial)
+	return &emptypb.Empty{}, nil
+}
+
+func TestIssuePrecertificateSerialConflict(t *testing.T) {
+	t.Parallel()
+	testCtx := setup(t)
+	sa := &conflictingSerialSA{conflicts: 2}
+	ca, err := NewCertificateAuthorityImpl(
+		sa,
+		mockSCTService{},
+		testCtx.pa,
+		testCtx.boulderIssuers,
+		testCtx.certProfiles,
+		testCtx.serialPrefix,
+		testCtx.maxNames,
+		testCtx.keyPolicy,
+		nil,
+		nil,
+		testCtx.logger,
+		testCtx.metrics,
+		testCtx.fc)
+	test.AssertNotError(t, err, "Failed to create CA")
+
+	// Conflicting serials are never signed; a fresh one is reserved instead.
+	profile := ca.certProfiles["legacy"]
+	issueReq := capb.IssueCertificateRequest{Csr: CNandSANCSR, RegistrationID: mrand.Int63(), OrderID: mrand.Int63(), CertProfileName: "legacy"}
+	precertDER, err := ca.issuePrecertificate(ctx, profile, &issueReq)
+	test.AssertNotError(t, err, "Failed to issue precert")
+	precert, err := x509.ParseCertificate(precertDER)
+	test.AssertNotError(t, err, "Failed to parse precert")
+	test.AssertDeepEquals(t, sa.reserved, []string{core.SerialToString(precert.SerialNumber)})
+	test.AssertMetricWithLabelsEquals(t, ca.metrics.serialConflicts, nil, 2)
+	test.AssertEquals(t, len(testCtx.logger.GetAllMatching("Generated serial already in use")), 2)
+
+	// If every serial conflicts, nothing is signed.
+	sa.conflicts = maxSerialReservationAttempts
+	_, err = ca.issuePrecertificate(ctx, profile, &issueReq)
+	test.AssertError(t, err, "Issued precert with conflicting serials")
+	test.AssertMetricWithLabelsEquals(t, ca.metrics.signatureCount, prometheus.Labels{"purpose": "precertificate", "status": "success"}, 1)
+}
+
 func TestIssueCertificateForPrecertificateDuplicateSerial(t *testing.T) {
 	t.Parallel()
 	testCtx := setup(t)
@@ -969,6 +1397,8 @@ func TestIssueCertificateForPrecertificateDuplicateSerial(t *testing.T) {
 		testCtx.serialPrefix,
 		testCtx.maxNames,
 		testCtx.keyPolicy,
+		nil,
+		nil,
 		testCtx.logger,
 		testCtx.metrics,
 		testCtx.fc)
@@ -987,7 +1417,7 @@ func TestIssueCertificateForPrecertificateDuplicateSerial(t *testing.T) {
 	_, err = ca.issueCertificateForPrecertificate(ctx,
 		profile,
 		precertDER,
-		sctBytes,
+		&rapb.SCTResponse{SctDER: sctBytes},
 		mrand.Int63(),
 		mrand.Int63())
 	if err == nil {
@@ -1012,6 +1442,8 @@ func TestIssueCertificateForPrecertificateDuplicateSerial(t *testing.T) {
 		testCtx.serialPrefix,
 		testCtx.maxNames,
 		testCtx.keyPolicy,
+		nil,
+		nil,
 		testCtx.logger,
 		testCtx.metrics,
 		testCtx.fc)
@@ -1020,7 +1452,7 @@ func TestIssueCertificateForPrecertificateDuplicateSerial(t *testing.T) {
 	_, err = errorca.issueCertificateForPrecertificate(ctx,
 		profile,
 		precertDER,
-		sctBytes,
+		&rapb.SCTResponse{SctDER: sctBytes},
 		mrand.Int63(),
 		mrand.Int63())
 	if err == nil {
@@ -8,6 +8,7 @@ import (
 	"io"
 	"strings"
 
+	"github.com/jmhodges/clock"
 	"google.golang.org/grpc"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -18,6 +19,7 @@ import (
 	bcrl "github.com/letsencrypt/boulder/crl"
 	"github.com/letsencrypt/boulder/issuance"
 	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/skewclock"
 )
 
 type crlImpl struct {
@@ -25,8 +27,10 @@ type crlImpl struct {
 	issuers   map[issuance.NameID]*issuance.Issuer
 	profile   *issuance.CRLProfile
 	maxLogLen int
+	budget    *SigningBudget
 	log       blog.Logger
 	metrics   *caMetrics
+	clk       clock.Clock
 }
 
 var _ capb.CRLGeneratorServer = (*crlImpl)(nil)
@@ -34,13 +38,16 @@ var _ capb.CRLGeneratorServer = (*crlImpl)(nil)
 // NewCRLImpl returns a new object which fulfils the ca.proto CRLGenerator
 // interface. It uses the list of issuers to determine what issuers it can
 // issue CRLs from. lifetime sets the validity period (inclusive) of the
-// resulting CRLs.
+// resulting CRLs. If budget is non-nil, each CRL waits for it before being
+// signed. CRLs are refused while clk reports that the host's clock has jumped.
 func NewCRLImpl(
 	issuers []*issuance.Issuer,
 	profileConfig issuance.CRLProfileConfig,
 	maxLogLen int,
+	budget *SigningBudget,
 	logger blog.Logger,
 	metrics *caMetrics,
+	clk clock.Clock,
 ) (*crlImpl, error) {
 	issuersByNameID := make(map[issuance.NameID]*issuance.Issuer, len(issuers))
 	for _, issuer := range issuers {
@@ -56,8 +63,10 @@ func NewCRLImpl(
 		issuers:   issuersByNameID,
 		profile:   profile,
 		maxLogLen: maxLogLen,
+		budget:    budget,
 		log:       logger,
 		metrics:   metrics,
+		clk:       clk,
 	}, nil
 }
 
@@ -138,6 +147,16 @@ func (ci *crlImpl) GenerateCRL(stream grpc.BidiStreamingServer[capb.GenerateCRLR
 
 	req.Entries = rcs
 
+	err := skewclock.Check(ci.clk)
+	if err != nil {
+		return fmt.Errorf("refusing to sign CRL: %w", err)
+	}
+
+	err = ci.budget.wait(stream.Context(), "crl")
+	if err != nil {
+		return err
+	}
+
 	crlBytes, err := issuer.IssueCRL(ci.profile, req)
 	if err != nil {
 		ci.metrics.noteSignError(err)
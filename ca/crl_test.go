@@ -1,6 +1,7 @@
 package ca
 
 import (
+	"context"
 	"crypto/x509"
 	"fmt"
 	"io"
@@ -11,6 +12,7 @@ import (
 
 	capb "github.com/letsencrypt/boulder/ca/proto"
 	corepb "github.com/letsencrypt/boulder/core/proto"
+	"github.com/letsencrypt/boulder/skewclock"
 	"github.com/letsencrypt/boulder/test"
 )
 
@@ -33,6 +35,10 @@ func (s mockGenerateCRLBidiStream) Send(entry *capb.GenerateCRLResponse) error {
 	return nil
 }
 
+func (s mockGenerateCRLBidiStream) Context() context.Context {
+	return context.Background()
+}
+
 func TestGenerateCRL(t *testing.T) {
 	t.Parallel()
 	testCtx := setup(t)
@@ -269,3 +275,24 @@ func TestGenerateCRL(t *testing.T) {
 	err = crl.CheckSignatureFrom(testCtx.boulderIssuers[0].Cert.Certificate)
 	test.AssertNotError(t, err, "CRL signature should validate")
 }
+
+func TestGenerateCRLRefusedDuringClockSkew(t *testing.T) {
+	t.Parallel()
+	testCtx := setup(t)
+	crli := testCtx.crl
+	crli.clk = skewedClock{testCtx.fc}
+
+	ins := make(chan *capb.GenerateCRLRequest, 1)
+	ins <- &capb.GenerateCRLRequest{
+		Payload: &capb.GenerateCRLRequest_Metadata{
+			Metadata: &capb.CRLMetadata{
+				IssuerNameID: int64(testCtx.boulderIssuers[0].NameID()),
+				ThisUpdate:   timestamppb.New(testCtx.fc.Now()),
+				ShardIdx:     1,
+			},
+		},
+	}
+	close(ins)
+	err := crli.GenerateCRL(mockGenerateCRLBidiStream{input: ins, output: nil})
+	test.AssertErrorIs(t, err, skewclock.ErrSkew)
+}
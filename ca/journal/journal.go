@@ -0,0 +1,453 @@
+// Package journal implements a tamper-evident, append-only record of the
+// CA's signing operations. Each entry includes the hash of the entry before
+// it, so that no entry can be removed, reordered, or altered without breaking
+// the chain, and the head of the chain is periodically signed with a key
+// dedicated to the journal. Because the journal is written by the CA itself,
+// before each signature is made, it provides evidence of everything the CA
+// has signed which is independent of the SA's database.
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/jmhodges/clock"
+)
+
+const (
+	// TypePrecertificate is the type of entries which record the signing of
+	// a precertificate.
+	TypePrecertificate = "precertificate"
+
+	// TypeCertificate is the type of entries which record the signing of a
+	// final certificate.
+	TypeCertificate = "certificate"
+
+	// TypeIssued is the type of entries which record a final certificate
+	// after it has been signed, so that it can be recovered if the CA fails
+	// to store it.
+	TypeIssued = "issued"
+
+	// TypeCheckpoint is the type of entries which sign the head of the chain.
+	TypeCheckpoint = "checkpoint"
+
+	// maxLineLength bounds the length of a single entry, so that a corrupt
+	// journal can't cause unbounded memory use.
+	maxLineLength = 64 * 1024
+)
+
+// Entry is a single line of the journal.
+type Entry struct {
+	// Seq is the position of the entry in the journal, starting at 1.
+	Seq int64 `json:"seq"`
+
+	// Time is when the entry was written.
+	Time time.Time `json:"time"`
+
+	// Type is one of TypePrecertificate, TypeCertificate, TypeIssued, or
+	// TypeCheckpoint.
+	Type string `json:"type"`
+
+	// Serial, Issuer, Profile, and SKID describe the certificate to be
+	// signed. They're empty for checkpoints, and only Serial is set for
+	// issued entries.
+	Serial  string `json:"serial,omitempty"`
+	Issuer  string `json:"issuer,omitempty"`
+	Profile string `json:"profile,omitempty"`
+	SKID    string `json:"skid,omitempty"`
+
+	// RequestHash is the hex-encoded SHA-256 hash of what was submitted to
+	// be signed: the CSR for a precertificate, or the precertificate for a
+	// final certificate.
+	RequestHash string `json:"requestHash,omitempty"`
+
+	// Certificate, for issued entries only, is the DER of the signed final
+	// certificate.
+	Certificate []byte `json:"certificate,omitempty"`
+
+	// Prev is the hex-encoded SHA-256 hash of the previous line of the
+	// journal, excluding its newline, or empty for the first entry.
+	Prev string `json:"prev"`
+
+	// Signature, for checkpoints only, is the journal key's signature over
+	// the checkpoint's Seq, Time, and Prev. See checkpointMessage.
+	Signature []byte `json:"signature,omitempty"`
+}
+
+// checkpointMessage returns the message which is signed by a checkpoint.
+func checkpointMessage(e *Entry) []byte {
+	return fmt.Appendf(nil, "boulder issuance journal checkpoint\n%d\n%s\n%s",
+		e.Seq, e.Time.UTC().Format(time.RFC3339Nano), e.Prev)
+}
+
+// lineHash returns the hex-encoded SHA-256 hash of a line of the journal,
+// which the following entry must have as its Prev.
+func lineHash(line []byte) string {
+	h := sha256.Sum256(line)
+	return hex.EncodeToString(h[:])
+}
+
+// Journal appends entries to a journal file. It is safe for concurrent use.
+type Journal struct {
+	signer crypto.Signer
+	clk    clock.Clock
+
+	mu sync.Mutex
+	f  *os.File
+	// seq and head are the Seq and hash of the last line of the journal.
+	seq  int64
+	head string
+	// unsigned is the number of entries since the last checkpoint.
+	unsigned int
+	// failed is the error from the first write which failed, after which
+	// nothing more can be appended.
+	failed error
+
+	// stop is closed by Close to stop the CheckpointLoop, which closes done
+	// once it has, if it was running.
+	stop    chan struct{}
+	done    chan struct{}
+	looping bool
+}
+
+// Open opens the journal at path, creating it if it doesn't exist, so that
+// further entries can be appended to it. Checkpoints are signed by signer,
+// which must hold an RSA or ECDSA key.
+//
+// The existing journal isn't verified, but its last line must be a complete
+// entry: if the CA stopped part way through writing one, the journal must be
+// repaired by an operator before the CA can resume signing.
+func Open(path string, signer crypto.Signer, clk clock.Clock) (*Journal, error) {
+	switch signer.Public().(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+	default:
+		return nil, fmt.Errorf("unsupported journal signing key type %T", signer.Public())
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	j := &Journal{
+		signer: signer,
+		clk:    clk,
+		f:      f,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+
+	last, err := lastLine(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("reading journal %q: %w", path, err)
+	}
+	if last != nil {
+		var e Entry
+		err = json.Unmarshal(last, &e)
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("parsing last entry of journal %q: %w", path, err)
+		}
+		j.seq = e.Seq
+		j.head = lineHash(last)
+		if e.Type != TypeCheckpoint {
+			// Make sure the next checkpoint covers the entries written
+			// since the last one before the CA stopped.
+			j.unsigned = 1
+		}
+	}
+	return j, nil
+}
+
+// lastLine returns the last line of f, without its newline, or nil if f is
+// empty. It returns an error if f doesn't end with a newline.
+func lastLine(f *os.File) ([]byte, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := info.Size()
+	if size == 0 {
+		return nil, nil
+	}
+
+	n := min(size, maxLineLength+1)
+	buf := make([]byte, n)
+	_, err = f.ReadAt(buf, size-n)
+	if err != nil {
+		return nil, err
+	}
+	if buf[len(buf)-1] != '\n' {
+		return nil, errors.New("the last entry is incomplete")
+	}
+	buf = buf[:len(buf)-1]
+	i := bytes.LastIndexByte(buf, '\n')
+	if i == -1 && n < size {
+		return nil, errors.New("the last entry is too long")
+	}
+	return buf[i+1:], nil
+}
+
+// Append adds an entry to the journal, filling in its Seq, Time, and Prev,
+// and syncs it to disk. The caller must not sign anything if Append fails.
+func (j *Journal) Append(e Entry) error {
+	if e.Type != TypePrecertificate && e.Type != TypeCertificate && e.Type != TypeIssued {
+		return fmt.Errorf("unexpected journal entry type %q", e.Type)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.fill(&e)
+	err := j.write(&e)
+	if err != nil {
+		return err
+	}
+	j.unsigned++
+	return nil
+}
+
+// Checkpoint signs the head of the chain, if any entries have been appended
+// since the last checkpoint.
+func (j *Journal) Checkpoint() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.unsigned == 0 {
+		return nil
+	}
+
+	e := Entry{Type: TypeCheckpoint}
+	j.fill(&e)
+	digest := sha256.Sum256(checkpointMessage(&e))
+	sig, err := j.signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return fmt.Errorf("signing journal checkpoint: %w", err)
+	}
+	e.Signature = sig
+
+	err = j.write(&e)
+	if err != nil {
+		return err
+	}
+	j.unsigned = 0
+	return nil
+}
+
+// fill sets the Seq, Time, and Prev of the next entry. The caller must hold
+// the lock.
+func (j *Journal) fill(e *Entry) {
+	e.Seq = j.seq + 1
+	e.Time = j.clk.Now().UTC()
+	e.Prev = j.head
+}
+
+// write appends e, which must have been filled, to the journal file and syncs
+// it. The caller must hold the lock.
+func (j *Journal) write(e *Entry) error {
+	if j.failed != nil {
+		return fmt.Errorf("journal is unusable after an earlier failure: %w", j.failed)
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	// A write which fails part way through leaves a partial line, which Open
+	// will refuse to resume from, so don't append anything after it.
+	_, err = j.f.Write(append(line, '\n'))
+	if err != nil {
+		j.failed = fmt.Errorf("writing journal entry %d: %w", e.Seq, err)
+		return j.failed
+	}
+	err = j.f.Sync()
+	if err != nil {
+		j.failed = fmt.Errorf("syncing journal entry %d: %w", e.Seq, err)
+		return j.failed
+	}
+	j.seq = e.Seq
+	j.head = lineHash(line)
+	return nil
+}
+
+// CheckpointLoop writes a checkpoint every interval until Close is called.
+// Checkpoints which fail are reported to logErr and retried at the next
+// interval.
+func (j *Journal) CheckpointLoop(interval time.Duration, logErr func(error)) {
+	j.mu.Lock()
+	j.looping = true
+	j.mu.Unlock()
+	defer close(j.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-j.stop:
+			return
+		case <-ticker.C:
+			err := j.Checkpoint()
+			if err != nil {
+				logErr(err)
+			}
+		}
+	}
+}
+
+// Close stops the CheckpointLoop, if it's running, signs a final checkpoint,
+// and closes the journal file. It must only be called once nothing else is
+// being appended.
+func (j *Journal) Close() error {
+	close(j.stop)
+	j.mu.Lock()
+	looping := j.looping
+	j.mu.Unlock()
+	if looping {
+		<-j.done
+	}
+
+	err := j.Checkpoint()
+	closeErr := j.f.Close()
+	if err != nil {
+		return err
+	}
+	return closeErr
+}
+
+// Summary describes a journal which has been verified.
+type Summary struct {
+	// Entries is the number of precertificate, certificate, and issued
+	// entries.
+	Entries int
+
+	// Checkpoints is the number of checkpoints.
+	Checkpoints int
+
+	// LastCheckpoint is the Seq of the last checkpoint, or 0 if there are
+	// none.
+	LastCheckpoint int64
+
+	// Unsigned is the number of entries after the last checkpoint. They're
+	// part of the chain, but they could have been truncated from the end of
+	// the journal without detection.
+	Unsigned int
+}
+
+// Verify reads a journal from r and checks that its entries are numbered
+// consecutively from 1, that each is chained to the line before it, and that
+// every checkpoint is correctly signed by pub. It returns an error describing
+// the first problem it finds.
+func Verify(r io.Reader, pub crypto.PublicKey) (*Summary, error) {
+	return Scan(r, pub, nil)
+}
+
+// Scan verifies a journal like Verify, and also calls fn, if it isn't nil,
+// with each entry other than checkpoints, in order, once it has been checked.
+// If fn returns an error, Scan stops and returns it.
+func Scan(r io.Reader, pub crypto.PublicKey, fn func(*Entry) error) (*Summary, error) {
+	var summary Summary
+	var seq int64
+	var head string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineLength)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		var e Entry
+		err := json.Unmarshal(line, &e)
+		if err != nil {
+			return nil, fmt.Errorf("parsing entry %d: %w", seq+1, err)
+		}
+		if e.Seq != seq+1 {
+			return nil, fmt.Errorf("entry %d has seq %d", seq+1, e.Seq)
+		}
+		seq = e.Seq
+		if e.Prev != head {
+			return nil, fmt.Errorf("entry %d isn't chained to entry %d: prev is %q, want %q", seq, seq-1, e.Prev, head)
+		}
+		head = lineHash(line)
+
+		switch e.Type {
+		case TypePrecertificate, TypeCertificate, TypeIssued:
+			summary.Entries++
+			summary.Unsigned++
+			if fn != nil {
+				err = fn(&e)
+				if err != nil {
+					return nil, err
+				}
+			}
+		case TypeCheckpoint:
+			err = verifySignature(pub, checkpointMessage(&e), e.Signature)
+			if err != nil {
+				return nil, fmt.Errorf("checkpoint %d: %w", seq, err)
+			}
+			summary.Checkpoints++
+			summary.LastCheckpoint = seq
+			summary.Unsigned = 0
+		default:
+			return nil, fmt.Errorf("entry %d has unknown type %q", seq, e.Type)
+		}
+	}
+	err := scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("reading entry %d: %w", seq+1, err)
+	}
+	return &summary, nil
+}
+
+// LoadPublicKey reads a journal's PEM-encoded public key, or a certificate for
+// it, from path.
+func LoadPublicKey(path string) (crypto.PublicKey, error) {
+	pemBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in %q", path)
+	}
+	switch block.Type {
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return cert.PublicKey, nil
+	default:
+		return nil, fmt.Errorf("unexpected PEM block type %q in %q", block.Type, path)
+	}
+}
+
+// verifySignature checks a checkpoint signature made by Journal.Checkpoint.
+func verifySignature(pub crypto.PublicKey, msg []byte, sig []byte) error {
+	digest := sha256.Sum256(msg)
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		err := rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], sig)
+		if err != nil {
+			return fmt.Errorf("invalid signature: %w", err)
+		}
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(k, digest[:], sig) {
+			return errors.New("invalid signature")
+		}
+	default:
+		return fmt.Errorf("unsupported journal public key type %T", pub)
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package journal
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jmhodges/clock"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	return key
+}
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+	contents, err := os.ReadFile(path)
+	test.AssertNotError(t, err, "reading journal")
+	return strings.SplitAfter(strings.TrimSuffix(string(contents), "\n"), "\n")
+}
+
+func TestJournal(t *testing.T) {
+	t.Parallel()
+	key := newKey(t)
+	fc := clock.NewFake()
+	path := filepath.Join(t.TempDir(), "journal")
+
+	j, err := Open(path, key, fc)
+	test.AssertNotError(t, err, "opening new journal")
+
+	// A checkpoint with nothing to sign is skipped.
+	test.AssertNotError(t, j.Checkpoint(), "checkpointing empty journal")
+
+	test.AssertNotError(t, j.Append(Entry{Type: TypePrecertificate, Serial: "01"}), "appending")
+	test.AssertNotError(t, j.Append(Entry{Type: TypeCertificate, Serial: "01"}), "appending")
+	test.AssertNotError(t, j.Checkpoint(), "checkpointing")
+	test.AssertNotError(t, j.Append(Entry{Type: TypePrecertificate, Serial: "02"}), "appending")
+	test.AssertError(t, j.Append(Entry{Type: TypeCheckpoint}), "appended a checkpoint without a signature")
+
+	f, err := os.Open(path)
+	test.AssertNotError(t, err, "opening journal")
+	summary, err := Verify(f, key.Public())
+	f.Close()
+	test.AssertNotError(t, err, "verifying journal")
+	test.AssertDeepEquals(t, *summary, Summary{Entries: 3, Checkpoints: 1, LastCheckpoint: 3, Unsigned: 1})
+
+	// Closing the journal covers the last entry with a checkpoint.
+	test.AssertNotError(t, j.Close(), "closing journal")
+
+	// Reopening the journal continues the chain.
+	j, err = Open(path, key, fc)
+	test.AssertNotError(t, err, "reopening journal")
+	test.AssertNotError(t, j.Append(Entry{Type: TypeCertificate, Serial: "02"}), "appending")
+	test.AssertNotError(t, j.Close(), "closing journal")
+
+	f, err = os.Open(path)
+	test.AssertNotError(t, err, "opening journal")
+	summary, err = Verify(f, key.Public())
+	f.Close()
+	test.AssertNotError(t, err, "verifying journal")
+	test.AssertDeepEquals(t, *summary, Summary{Entries: 4, Checkpoints: 3, LastCheckpoint: 7, Unsigned: 0})
+
+	// Checkpoints signed by another key aren't accepted.
+	f, err = os.Open(path)
+	test.AssertNotError(t, err, "opening journal")
+	_, err = Verify(f, newKey(t).Public())
+	f.Close()
+	test.AssertContains(t, err.Error(), "checkpoint 3: invalid signature")
+}
+
+func TestJournalReopenAfterCrash(t *testing.T) {
+	t.Parallel()
+	key := newKey(t)
+	fc := clock.NewFake()
+	path := filepath.Join(t.TempDir(), "journal")
+
+	j, err := Open(path, key, fc)
+	test.AssertNotError(t, err, "opening new journal")
+	test.AssertNotError(t, j.Append(Entry{Type: TypePrecertificate, Serial: "01"}), "appending")
+	// Simulate a crash by not closing the journal. When it's reopened, the
+	// next checkpoint must cover the entry written before the crash.
+	j, err = Open(path, key, fc)
+	test.AssertNotError(t, err, "reopening journal")
+	test.AssertNotError(t, j.Checkpoint(), "checkpointing")
+
+	f, err := os.Open(path)
+	test.AssertNotError(t, err, "opening journal")
+	summary, err := Verify(f, key.Public())
+	f.Close()
+	test.AssertNotError(t, err, "verifying journal")
+	test.AssertEquals(t, summary.LastCheckpoint, int64(2))
+
+	// A partially written entry must be repaired before the journal can be
+	// reopened.
+	f, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	test.AssertNotError(t, err, "opening journal")
+	_, err = f.WriteString(`{"seq":3,"ty`)
+	test.AssertNotError(t, err, "writing partial entry")
+	f.Close()
+	_, err = Open(path, key, fc)
+	test.AssertError(t, err, "reopened journal with a partial entry")
+	test.AssertContains(t, err.Error(), "the last entry is incomplete")
+}
+
+func TestVerifyTampering(t *testing.T) {
+	t.Parallel()
+	key := newKey(t)
+	fc := clock.NewFake()
+	path := filepath.Join(t.TempDir(), "journal")
+
+	j, err := Open(path, key, fc)
+	test.AssertNotError(t, err, "opening new journal")
+	for _, serial := range []string{"01", "02", "03"} {
+		test.AssertNotError(t, j.Append(Entry{Type: TypePrecertificate, Serial: serial}), "appending")
+	}
+	test.AssertNotError(t, j.Close(), "closing journal")
+	lines := readLines(t, path)
+
+	testCases := []struct {
+		name    string
+		lines   []string
+		wantErr string
+	}{
+		{
+			name:    "entry removed",
+			lines:   []string{lines[0], lines[2], lines[3]},
+			wantErr: "entry 2 has seq 3",
+		},
+		{
+			name:    "entry altered",
+			lines:   []string{lines[0], strings.Replace(lines[1], `"02"`, `"04"`, 1), lines[2], lines[3]},
+			wantErr: "entry 3 isn't chained to entry 2",
+		},
+		{
+			name:    "entries reordered",
+			lines:   []string{lines[1], lines[0], lines[2], lines[3]},
+			wantErr: "entry 1 has seq 2",
+		},
+		{
+			name:    "not JSON",
+			lines:   []string{lines[0], "tampered\n"},
+			wantErr: "parsing entry 2",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Verify(strings.NewReader(strings.Join(tc.lines, "")), key.Public())
+			test.AssertError(t, err, "verified a tampered journal")
+			test.AssertContains(t, err.Error(), tc.wantErr)
+		})
+	}
+
+	// Truncating the journal can't be detected, but the entries after the
+	// last checkpoint are reported.
+	summary, err := Verify(bytes.NewBufferString(strings.Join(lines[:2], "")), key.Public())
+	test.AssertNotError(t, err, "verifying truncated journal")
+	test.AssertEquals(t, summary.Unsigned, 2)
+}
+
+func TestScan(t *testing.T) {
+	t.Parallel()
+	key := newKey(t)
+	fc := clock.NewFake()
+	path := filepath.Join(t.TempDir(), "journal")
+
+	j, err := Open(path, key, fc)
+	test.AssertNotError(t, err, "opening new journal")
+	test.AssertNotError(t, j.Append(Entry{Type: TypeCertificate, Serial: "01"}), "appending")
+	test.AssertNotError(t, j.Checkpoint(), "checkpointing")
+	test.AssertNotError(t, j.Append(Entry{Type: TypeIssued, Serial: "01", Certificate: []byte{1, 2, 3}}), "appending")
+	test.AssertNotError(t, j.Close(), "closing journal")
+	contents, err := os.ReadFile(path)
+	test.AssertNotError(t, err, "reading journal")
+
+	// Checkpoints aren't passed to fn.
+	var entries []Entry
+	summary, err := Scan(bytes.NewReader(contents), key.Public(), func(e *Entry) error {
+		entries = append(entries, *e)
+		return nil
+	})
+	test.AssertNotError(t, err, "scanning journal")
+	test.AssertEquals(t, summary.Entries, 2)
+	test.AssertEquals(t, len(entries), 2)
+	test.AssertEquals(t, entries[0].Type, TypeCertificate)
+	test.AssertEquals(t, entries[1].Type, TypeIssued)
+	test.AssertByteEquals(t, entries[1].Certificate, []byte{1, 2, 3})
+
+	// An error from fn stops the scan.
+	_, err = Scan(bytes.NewReader(contents), key.Public(), func(e *Entry) error {
+		return errors.New("stop")
+	})
+	test.AssertError(t, err, "scan should have stopped")
+	test.AssertEquals(t, err.Error(), "stop")
+}
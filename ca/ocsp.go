@@ -16,6 +16,7 @@ import (
 	berrors "github.com/letsencrypt/boulder/errors"
 	"github.com/letsencrypt/boulder/issuance"
 	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/skewclock"
 )
 
 // ocspImpl provides a backing implementation for the OCSP gRPC service.
@@ -24,6 +25,7 @@ type ocspImpl struct {
 	issuers      map[issuance.NameID]*issuance.Issuer
 	ocspLifetime time.Duration
 	ocspLogQueue *ocspLogQueue
+	budget       *SigningBudget
 	log          blog.Logger
 	metrics      *caMetrics
 	clk          clock.Clock
@@ -36,6 +38,7 @@ func NewOCSPImpl(
 	ocspLifetime time.Duration,
 	ocspLogMaxLength int,
 	ocspLogPeriod time.Duration,
+	budget *SigningBudget,
 	logger blog.Logger,
 	stats prometheus.Registerer,
 	metrics *caMetrics,
@@ -59,6 +62,7 @@ func NewOCSPImpl(
 		issuers:      issuersByNameID,
 		ocspLifetime: ocspLifetime,
 		ocspLogQueue: ocspLogQueue,
+		budget:       budget,
 		log:          logger,
 		metrics:      metrics,
 		clk:          clk,
@@ -102,6 +106,11 @@ func (oi *ocspImpl) GenerateOCSP(ctx context.Context, req *capb.GenerateOCSPRequ
 		return nil, fmt.Errorf("unrecognized issuer ID %d", req.IssuerID)
 	}
 
+	err = skewclock.Check(oi.clk)
+	if err != nil {
+		return nil, berrors.InternalServerError("refusing to sign OCSP response: %s", err)
+	}
+
 	now := oi.clk.Now().Truncate(time.Minute)
 	tbsResponse := ocsp.Response{
 		Status:       ocspStatusToCode[req.Status],
@@ -114,6 +123,11 @@ func (oi *ocspImpl) GenerateOCSP(ctx context.Context, req *capb.GenerateOCSPRequ
 		tbsResponse.RevocationReason = int(req.Reason)
 	}
 
+	err = oi.budget.wait(ctx, "ocsp")
+	if err != nil {
+		return nil, err
+	}
+
 	if oi.ocspLogQueue != nil {
 		oi.ocspLogQueue.enqueue(serial.Bytes(), now, tbsResponse.Status, tbsResponse.RevocationReason)
 	}
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jmhodges/clock"
 	"golang.org/x/crypto/ocsp"
 
 	capb "github.com/letsencrypt/boulder/ca/proto"
@@ -15,6 +16,7 @@ import (
 	"github.com/letsencrypt/boulder/issuance"
 	blog "github.com/letsencrypt/boulder/log"
 	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/skewclock"
 	"github.com/letsencrypt/boulder/test"
 )
 
@@ -39,6 +41,8 @@ func TestOCSP(t *testing.T) {
 		testCtx.serialPrefix,
 		testCtx.maxNames,
 		testCtx.keyPolicy,
+		nil,
+		nil,
 		testCtx.logger,
 		testCtx.metrics,
 		testCtx.fc)
@@ -115,6 +119,40 @@ func TestOCSP(t *testing.T) {
 
 // Set up an ocspLogQueue with a very long period and a large maxLen,
 // to ensure any buffered entries get flushed on `.stop()`.
+// skewedClock is a clock which reports that the host's clock has just jumped.
+type skewedClock struct {
+	clock.FakeClock
+}
+
+func (skewedClock) Check() error {
+	return skewclock.ErrSkew
+}
+
+func TestOCSPRefusedDuringClockSkew(t *testing.T) {
+	t.Parallel()
+	testCtx := setup(t)
+	ocspi, err := NewOCSPImpl(
+		testCtx.boulderIssuers,
+		24*time.Hour,
+		0,
+		time.Second,
+		nil,
+		blog.NewMock(),
+		metrics.NoopRegisterer,
+		testCtx.metrics,
+		skewedClock{testCtx.fc},
+	)
+	test.AssertNotError(t, err, "Failed to create ocsp impl")
+
+	_, err = ocspi.GenerateOCSP(ctx, &capb.GenerateOCSPRequest{
+		Serial:   "000000000000000000000000000000000001",
+		IssuerID: int64(testCtx.boulderIssuers[0].NameID()),
+		Status:   string(core.OCSPStatusGood),
+	})
+	test.AssertError(t, err, "OCSP should be refused while the clock is skewed")
+	test.AssertContains(t, err.Error(), "refusing to sign OCSP response")
+}
+
 func TestOcspLogFlushOnExit(t *testing.T) {
 	t.Parallel()
 	log := blog.NewMock()
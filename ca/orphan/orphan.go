@@ -0,0 +1,334 @@
+// Package orphan recovers certificates which the CA signed but didn't store.
+// Such "orphaned" certificates are trusted, and may be in use, but can't be
+// revoked or included in CRLs until they're stored by the SA. The CA records
+// each final certificate in a write-ahead log before asking the SA to store
+// it, and acknowledges it once the SA has. When the CA starts, a Recoverer
+// replays every unacknowledged certificate into the SA and the final CT logs.
+package orphan
+
+import (
+	"bufio"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/letsencrypt/boulder/core"
+	berrors "github.com/letsencrypt/boulder/errors"
+	blog "github.com/letsencrypt/boulder/log"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+)
+
+// maxLineLength bounds the length of a single line of the log, which is far
+// longer than any certificate, so that a corrupt log is rejected.
+const maxLineLength = 1024 * 1024
+
+// compactThreshold is how many bytes may be written to the log between
+// compactions. Once an acknowledgement takes it past this, the log is
+// compacted, so that it doesn't grow without bound while the CA runs.
+const compactThreshold = 64 * 1024 * 1024
+
+// Record is a final certificate which has been signed, with what's needed to
+// store it.
+type Record struct {
+	Serial string    `json:"serial"`
+	RegID  int64     `json:"regID"`
+	Issued time.Time `json:"issued"`
+	DER    []byte    `json:"der"`
+}
+
+// line is a single line of the log. Exactly one of its fields is set.
+type line struct {
+	Issued *Record `json:"issued,omitempty"`
+	Acked  string  `json:"acked,omitempty"`
+}
+
+// WAL is a write-ahead log of signed final certificates. It is safe for
+// concurrent use.
+type WAL struct {
+	path string
+
+	mu sync.Mutex
+	f  *os.File
+	// pending are the records which haven't been acknowledged, by serial.
+	pending map[string]*Record
+	// written is the number of bytes written to the log since it was last
+	// compacted, and compactAt the number at which Ack compacts it.
+	written   int64
+	compactAt int64
+}
+
+// OpenWAL opens the write-ahead log at path, creating it if it doesn't exist.
+// If the CA stopped part way through writing the last line, that line is
+// discarded: the certificate it was for wasn't passed to the SA.
+func OpenWAL(path string) (*WAL, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	w := &WAL{path: path, f: f, pending: make(map[string]*Record), compactAt: compactThreshold}
+	err = w.load()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("reading write-ahead log %q: %w", path, err)
+	}
+	return w, nil
+}
+
+// load reads the log's pending records, truncates any partial last line, and
+// leaves the file positioned for appending. The caller must hold the lock, or
+// be the only user of the log.
+func (w *WAL) load() error {
+	r := bufio.NewReader(w.f)
+	var complete int64
+	for {
+		b, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// Anything after the last newline is a partial line.
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if len(b) > maxLineLength {
+			return fmt.Errorf("line at offset %d is too long", complete)
+		}
+		var l line
+		err = json.Unmarshal(b, &l)
+		if err != nil {
+			return fmt.Errorf("parsing line at offset %d: %w", complete, err)
+		}
+		switch {
+		case l.Issued != nil:
+			w.pending[l.Issued.Serial] = l.Issued
+		case l.Acked != "":
+			delete(w.pending, l.Acked)
+		default:
+			return fmt.Errorf("empty line at offset %d", complete)
+		}
+		complete += int64(len(b))
+	}
+
+	err := w.f.Truncate(complete)
+	if err != nil {
+		return err
+	}
+	w.written = complete
+	_, err = w.f.Seek(complete, io.SeekStart)
+	return err
+}
+
+// write appends l to the log, and syncs it to disk if sync is true. The
+// caller must hold the lock.
+func (w *WAL) write(l line, sync bool) error {
+	b, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	n, err := w.f.Write(append(b, '\n'))
+	w.written += int64(n)
+	if err != nil {
+		return err
+	}
+	if sync {
+		return w.f.Sync()
+	}
+	return nil
+}
+
+// Append records a signed final certificate, and syncs it to disk, before the
+// CA asks the SA to store it.
+func (w *WAL) Append(r Record) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	err := w.write(line{Issued: &r}, true)
+	if err != nil {
+		return fmt.Errorf("writing certificate %s to write-ahead log: %w", r.Serial, err)
+	}
+	w.pending[r.Serial] = &r
+	return nil
+}
+
+// Ack records that the certificate with the given serial has been stored.
+// Acknowledgements aren't synced to disk: if one is lost, the certificate is
+// replayed, and the SA rejects it as a duplicate. If enough has been written to
+// the log since it was last compacted, Ack compacts it.
+func (w *WAL) Ack(serial string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	err := w.write(line{Acked: serial}, false)
+	if err != nil {
+		return fmt.Errorf("acknowledging certificate %s in write-ahead log: %w", serial, err)
+	}
+	delete(w.pending, serial)
+	if w.written >= w.compactAt {
+		err = w.compact()
+		if err != nil {
+			return fmt.Errorf("compacting write-ahead log: %w", err)
+		}
+	}
+	return nil
+}
+
+// Pending returns the records which haven't been acknowledged, oldest first.
+func (w *WAL) Pending() []Record {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var pending []Record
+	for _, r := range w.pending {
+		pending = append(pending, *r)
+	}
+	slices.SortFunc(pending, func(a, b Record) int {
+		return a.Issued.Compare(b.Issued)
+	})
+	return pending
+}
+
+// Compact rewrites the log so that it contains only the records which haven't
+// been acknowledged. The new log replaces the old one atomically.
+func (w *WAL) Compact() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.compact()
+}
+
+// compact implements Compact. The caller must hold the lock.
+func (w *WAL) compact() error {
+	tmp, err := os.CreateTemp(filepath.Dir(w.path), ".wal-compact-*")
+	if err != nil {
+		return err
+	}
+	err = writeRecords(tmp, w.pending)
+	if err == nil {
+		err = os.Rename(tmp.Name(), w.path)
+	}
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	// The replacement is open for writing at its end, so it takes the old
+	// log's place.
+	w.f.Close()
+	w.f = tmp
+	w.written = 0
+	return nil
+}
+
+// writeRecords writes each of records to f, and syncs it to disk.
+func writeRecords(f *os.File, records map[string]*Record) error {
+	for _, r := range records {
+		b, err := json.Marshal(line{Issued: r})
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(append(b, '\n'))
+		if err != nil {
+			return err
+		}
+	}
+	return f.Sync()
+}
+
+// Close closes the log.
+func (w *WAL) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.f.Close()
+}
+
+// storage is the subset of the SA used by a Recoverer.
+type storage interface {
+	AddCertificate(ctx context.Context, req *sapb.AddCertificateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+// FinalCertSubmitter submits final certificates to the final CT logs, on a
+// best-effort basis. It's implemented by *ctpolicy.CTPolicy.
+type FinalCertSubmitter interface {
+	SubmitFinalCert(cert core.CertDER, expiration time.Time)
+}
+
+// Recoverer replays unacknowledged certificates from a WAL.
+type Recoverer struct {
+	sa        storage
+	ct        FinalCertSubmitter
+	log       blog.Logger
+	recovered *prometheus.CounterVec
+}
+
+// NewRecoverer returns a Recoverer which stores certificates in sa and, if ct
+// isn't nil, submits those it stores to the final CT logs.
+func NewRecoverer(sa storage, ct FinalCertSubmitter, logger blog.Logger, stats prometheus.Registerer) *Recoverer {
+	recovered := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ca_orphans_recovered",
+		Help: "A counter of unacknowledged certificates replayed from the CA's write-ahead log, labelled by result",
+	}, []string{"result"})
+	stats.MustRegister(recovered)
+	return &Recoverer{sa: sa, ct: ct, log: logger, recovered: recovered}
+}
+
+// Recover stores every unacknowledged certificate in w, and acknowledges each
+// once it's stored or the SA reports that it already was. Certificates which
+// can't be stored remain pending, to be replayed the next time the CA starts.
+// Once every certificate has been tried, w is compacted.
+//
+// Recover must be called before the CA begins issuing, so that it doesn't race
+// with the CA to store a certificate which was just signed.
+func (rc *Recoverer) Recover(ctx context.Context, w *WAL) error {
+	var errs []error
+	for _, r := range w.Pending() {
+		_, err := rc.sa.AddCertificate(ctx, &sapb.AddCertificateRequest{
+			Der:    r.DER,
+			RegID:  r.RegID,
+			Issued: timestamppb.New(r.Issued),
+		})
+		switch {
+		case err == nil:
+			rc.recovered.WithLabelValues("stored").Inc()
+			rc.log.AuditInfof("Recovered orphaned certificate: serial=[%s] regID=[%d]", r.Serial, r.RegID)
+			if rc.ct != nil {
+				rc.submitFinal(r)
+			}
+		case errors.Is(err, berrors.Duplicate):
+			// The CA stored it but didn't record the acknowledgement.
+			rc.recovered.WithLabelValues("duplicate").Inc()
+		default:
+			rc.recovered.WithLabelValues("failed").Inc()
+			rc.log.AuditErrf("Failed to recover orphaned certificate: serial=[%s] err=[%v]", r.Serial, err)
+			errs = append(errs, fmt.Errorf("storing certificate %s: %w", r.Serial, err))
+			continue
+		}
+		err = w.Ack(r.Serial)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	err := w.Compact()
+	if err != nil {
+		errs = append(errs, fmt.Errorf("compacting write-ahead log: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// submitFinal submits a recovered certificate to the final CT logs, which the
+// RA would have done had the CA stored it.
+func (rc *Recoverer) submitFinal(r Record) {
+	cert, err := x509.ParseCertificate(r.DER)
+	if err != nil {
+		rc.log.Warningf("Not submitting recovered certificate %s to CT: %s", r.Serial, err)
+		return
+	}
+	rc.ct.SubmitFinalCert(r.DER, cert.NotAfter)
+}
@@ -0,0 +1,199 @@
+package orphan
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/letsencrypt/boulder/core"
+	berrors "github.com/letsencrypt/boulder/errors"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func makeRecord(t *testing.T, serial int64, issued time.Time) Record {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		NotBefore:    issued,
+		NotAfter:     issued.Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	test.AssertNotError(t, err, "creating certificate")
+	return Record{Serial: core.SerialToString(template.SerialNumber), RegID: serial, Issued: issued.UTC(), DER: der}
+}
+
+func TestWAL(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "wal")
+	now := time.Now()
+	a, b, c := makeRecord(t, 1, now), makeRecord(t, 2, now.Add(time.Second)), makeRecord(t, 3, now.Add(2*time.Second))
+
+	w, err := OpenWAL(path)
+	test.AssertNotError(t, err, "opening new WAL")
+	test.AssertEquals(t, len(w.Pending()), 0)
+	test.AssertNotError(t, w.Append(b), "appending")
+	test.AssertNotError(t, w.Append(a), "appending")
+	test.AssertNotError(t, w.Append(c), "appending")
+	test.AssertNotError(t, w.Ack(b.Serial), "acknowledging")
+	test.AssertDeepEquals(t, w.Pending(), []Record{a, c})
+	test.AssertNotError(t, w.Close(), "closing WAL")
+
+	// Simulate a crash part way through writing a line. The partial line is
+	// discarded when the WAL is reopened.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	test.AssertNotError(t, err, "opening WAL file")
+	_, err = f.WriteString(`{"acked":"`)
+	test.AssertNotError(t, err, "writing partial line")
+	f.Close()
+
+	w, err = OpenWAL(path)
+	test.AssertNotError(t, err, "reopening WAL")
+	test.AssertDeepEquals(t, w.Pending(), []Record{a, c})
+	test.AssertNotError(t, w.Ack(a.Serial), "acknowledging")
+
+	// Compacting keeps only the pending record, and the WAL can still be
+	// appended to afterwards.
+	test.AssertNotError(t, w.Compact(), "compacting")
+	test.AssertNotError(t, w.Append(b), "appending")
+	test.AssertNotError(t, w.Close(), "closing WAL")
+	contents, err := os.ReadFile(path)
+	test.AssertNotError(t, err, "reading WAL")
+	test.AssertEquals(t, bytes.Count(contents, []byte("\n")), 2)
+
+	w, err = OpenWAL(path)
+	test.AssertNotError(t, err, "reopening WAL")
+	test.AssertDeepEquals(t, w.Pending(), []Record{b, c})
+	test.AssertNotError(t, w.Close(), "closing WAL")
+
+	// A corrupt line isn't skipped.
+	err = os.WriteFile(path, []byte("corrupt\n"), 0600)
+	test.AssertNotError(t, err, "writing WAL")
+	_, err = OpenWAL(path)
+	test.AssertError(t, err, "opened a corrupt WAL")
+	test.AssertContains(t, err.Error(), "parsing line at offset 0")
+}
+
+func TestWALCompactsOnAck(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "wal")
+	now := time.Now()
+	a, b := makeRecord(t, 1, now), makeRecord(t, 2, now.Add(time.Second))
+
+	w, err := OpenWAL(path)
+	test.AssertNotError(t, err, "opening new WAL")
+	w.compactAt = 1
+	test.AssertNotError(t, w.Append(a), "appending")
+	test.AssertNotError(t, w.Append(b), "appending")
+
+	// Once the log passes the threshold, acknowledging a record compacts it,
+	// dropping the acknowledged record and its acknowledgement.
+	test.AssertNotError(t, w.Ack(a.Serial), "acknowledging")
+	test.AssertEquals(t, w.written, int64(0))
+	contents, err := os.ReadFile(path)
+	test.AssertNotError(t, err, "reading WAL")
+	test.AssertEquals(t, bytes.Count(contents, []byte("\n")), 1)
+	test.AssertContains(t, string(contents), b.Serial)
+	test.Assert(t, !bytes.Contains(contents, []byte(a.Serial)), "acknowledged record should have been dropped")
+
+	// The compacted log can still be appended to.
+	test.AssertNotError(t, w.Ack(b.Serial), "acknowledging")
+	test.AssertNotError(t, w.Append(a), "appending")
+	test.AssertNotError(t, w.Close(), "closing WAL")
+	w, err = OpenWAL(path)
+	test.AssertNotError(t, err, "reopening WAL")
+	test.AssertDeepEquals(t, w.Pending(), []Record{a})
+	test.AssertNotError(t, w.Close(), "closing WAL")
+}
+
+// fakeSA stores certificates by serial, failing for those in fail.
+type fakeSA struct {
+	stored map[string]*sapb.AddCertificateRequest
+	fail   map[string]bool
+}
+
+func (sa *fakeSA) AddCertificate(_ context.Context, req *sapb.AddCertificateRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	cert, err := x509.ParseCertificate(req.Der)
+	if err != nil {
+		return nil, err
+	}
+	serial := core.SerialToString(cert.SerialNumber)
+	if sa.fail[serial] {
+		return nil, errors.New("SA is down")
+	}
+	if sa.stored[serial] != nil {
+		return nil, berrors.DuplicateError("cannot add a duplicate cert")
+	}
+	sa.stored[serial] = req
+	return &emptypb.Empty{}, nil
+}
+
+type fakeSubmitter struct {
+	submitted []core.CertDER
+}
+
+func (s *fakeSubmitter) SubmitFinalCert(cert core.CertDER, _ time.Time) {
+	s.submitted = append(s.submitted, cert)
+}
+
+func TestRecover(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "wal")
+	now := time.Now()
+	orphaned, duplicate, failed := makeRecord(t, 1, now), makeRecord(t, 2, now), makeRecord(t, 3, now)
+
+	w, err := OpenWAL(path)
+	test.AssertNotError(t, err, "opening new WAL")
+	for _, r := range []Record{orphaned, duplicate, failed} {
+		test.AssertNotError(t, w.Append(r), "appending")
+	}
+
+	// The duplicate was stored, but its acknowledgement was lost.
+	sa := &fakeSA{
+		stored: map[string]*sapb.AddCertificateRequest{duplicate.Serial: {}},
+		fail:   map[string]bool{failed.Serial: true},
+	}
+	ct := &fakeSubmitter{}
+	rc := NewRecoverer(sa, ct, blog.NewMock(), metrics.NoopRegisterer)
+	err = rc.Recover(context.Background(), w)
+	test.AssertError(t, err, "recovered a certificate the SA couldn't store")
+	test.AssertContains(t, err.Error(), "SA is down")
+
+	test.AssertByteEquals(t, sa.stored[orphaned.Serial].Der, orphaned.DER)
+	test.AssertEquals(t, sa.stored[orphaned.Serial].RegID, orphaned.RegID)
+	test.AssertEquals(t, sa.stored[orphaned.Serial].Issued.AsTime(), orphaned.Issued)
+	test.AssertEquals(t, len(ct.submitted), 1)
+	test.AssertByteEquals(t, ct.submitted[0], orphaned.DER)
+	test.AssertMetricWithLabelsEquals(t, rc.recovered, prometheus.Labels{"result": "stored"}, 1)
+	test.AssertMetricWithLabelsEquals(t, rc.recovered, prometheus.Labels{"result": "duplicate"}, 1)
+	test.AssertMetricWithLabelsEquals(t, rc.recovered, prometheus.Labels{"result": "failed"}, 1)
+
+	// The certificate which couldn't be stored is replayed next time.
+	test.AssertDeepEquals(t, w.Pending(), []Record{failed})
+	test.AssertNotError(t, w.Close(), "closing WAL")
+	w, err = OpenWAL(path)
+	test.AssertNotError(t, err, "reopening WAL")
+	test.AssertDeepEquals(t, w.Pending(), []Record{failed})
+
+	delete(sa.fail, failed.Serial)
+	test.AssertNotError(t, rc.Recover(context.Background(), w), "recovering")
+	test.AssertEquals(t, len(w.Pending()), 0)
+	test.AssertEquals(t, len(ct.submitted), 2)
+}
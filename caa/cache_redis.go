@@ -0,0 +1,113 @@
+package caa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/proto"
+
+	caapb "github.com/letsencrypt/boulder/caa/proto"
+)
+
+// Compile-time check that RedisCache implements the Cache interface.
+var _ Cache = (*RedisCache)(nil)
+
+// RedisCache is a Cache backed by sharded Redis. Each name on a tree-climb path
+// is stored under its own key, so that hostnames with a common parent share the
+// cached result for that parent.
+type RedisCache struct {
+	client  *redis.Ring
+	clk     clock.Clock
+	latency *prometheus.HistogramVec
+}
+
+// NewRedisCache returns a new Redis backed cache using the provided *redis.Ring
+// client.
+func NewRedisCache(client *redis.Ring, clk clock.Clock, stats prometheus.Registerer) *RedisCache {
+	latency := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "caa_cache_latency",
+			Help: "Histogram of Redis call latencies labeled by call=[get|set] and result=[success|error]",
+			// Exponential buckets ranging from 0.0005s to 3s.
+			Buckets: prometheus.ExponentialBucketsRange(0.0005, 3, 8),
+		},
+		[]string{"call", "result"},
+	)
+	stats.MustRegister(latency)
+
+	return &RedisCache{
+		client:  client,
+		clk:     clk,
+		latency: latency,
+	}
+}
+
+// cacheKey returns the Redis key for the CAA result of the given name.
+func cacheKey(name string) string {
+	return "caa:" + name
+}
+
+func (r *RedisCache) observeLatency(call string, start time.Time, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	r.latency.With(prometheus.Labels{"call": call, "result": result}).Observe(r.clk.Since(start).Seconds())
+}
+
+// Get retrieves the cached results for the given names using a pipelined Redis
+// transaction, in order to reduce the number of round-trips to each shard.
+func (r *RedisCache) Get(ctx context.Context, names []string) (map[string]*caapb.CAASet, error) {
+	start := r.clk.Now()
+
+	pipeline := r.client.Pipeline()
+	cmds := make(map[string]*redis.StringCmd, len(names))
+	for _, name := range names {
+		cmds[name] = pipeline.Get(ctx, cacheKey(name))
+	}
+	_, err := pipeline.Exec(ctx)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		r.observeLatency("get", start, err)
+		return nil, err
+	}
+
+	sets := make(map[string]*caapb.CAASet, len(names))
+	for name, cmd := range cmds {
+		raw, err := cmd.Bytes()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			r.observeLatency("get", start, err)
+			return nil, err
+		}
+		var set caapb.CAASet
+		err = proto.Unmarshal(raw, &set)
+		if err != nil {
+			r.observeLatency("get", start, err)
+			return nil, fmt.Errorf("unmarshaling cached CAA result for %q: %w", name, err)
+		}
+		sets[name] = &set
+	}
+
+	r.observeLatency("get", start, nil)
+	return sets, nil
+}
+
+// Set stores the given result under its name until the ttl expires.
+func (r *RedisCache) Set(ctx context.Context, set *caapb.CAASet, ttl time.Duration) error {
+	start := r.clk.Now()
+
+	raw, err := proto.Marshal(set)
+	if err != nil {
+		return err
+	}
+	err = r.client.Set(ctx, cacheKey(set.Name), raw, ttl).Err()
+	r.observeLatency("set", start, err)
+	return err
+}
@@ -0,0 +1,196 @@
+// Package caa implements the caa-checker service, which performs the DNS
+// lookups of the CAA tree-climb on behalf of the VA and shares their results,
+// through a cache, between all of the VA instances in a perspective. This means
+// that the CAA lookups for names which have recently been checked, whether
+// during initial validation, during a recheck at finalize time, or for another
+// name with the same parent, are not repeated.
+//
+// The caa-checker only looks up records: evaluating them against the request
+// remains the VA's job.
+package caa
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/bdns"
+	caapb "github.com/letsencrypt/boulder/caa/proto"
+	berrors "github.com/letsencrypt/boulder/errors"
+	blog "github.com/letsencrypt/boulder/log"
+)
+
+// Cache stores the results of CAA lookups, keyed by the name which was looked
+// up. Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the cached result for each of the given names which has
+	// one. Names without a cached result are absent from the returned map.
+	Get(ctx context.Context, names []string) (map[string]*caapb.CAASet, error)
+	// Set caches the given result until the ttl expires.
+	Set(ctx context.Context, set *caapb.CAASet, ttl time.Duration) error
+}
+
+// Checker implements the caa.CAAChecker gRPC service.
+type Checker struct {
+	caapb.UnsafeCAACheckerServer
+
+	dnsClient      bdns.Client
+	cache          Cache
+	maxAge         time.Duration
+	negativeMaxAge time.Duration
+	clk            clock.Clock
+	log            blog.Logger
+
+	lookups *prometheus.CounterVec
+}
+
+var _ caapb.CAACheckerServer = (*Checker)(nil)
+
+// NewChecker returns a Checker which looks up CAA records using dnsClient. If
+// cache is nil, every lookup goes to DNS. Results with records are cached for
+// the shortest of their TTLs, capped at maxAge; results without records are
+// cached for negativeMaxAge.
+func NewChecker(
+	dnsClient bdns.Client,
+	cache Cache,
+	maxAge time.Duration,
+	negativeMaxAge time.Duration,
+	clk clock.Clock,
+	stats prometheus.Registerer,
+	log blog.Logger,
+) *Checker {
+	lookups := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "caa_checker_lookups",
+		Help: "Number of names on CAA tree-climb paths looked up, by result=[hit|miss|error]",
+	}, []string{"result"})
+	stats.MustRegister(lookups)
+
+	return &Checker{
+		dnsClient:      dnsClient,
+		cache:          cache,
+		maxAge:         maxAge,
+		negativeMaxAge: negativeMaxAge,
+		clk:            clk,
+		log:            log,
+		lookups:        lookups,
+	}
+}
+
+// treeClimb returns the names whose CAA records must be considered for the
+// given hostname: the hostname itself, followed by each of its parents up to
+// and including its TLD.
+func treeClimb(hostname string) []string {
+	labels := strings.Split(hostname, ".")
+	names := make([]string, len(labels))
+	for i := range labels {
+		names[i] = strings.Join(labels[i:], ".")
+	}
+	return names
+}
+
+// LookupCAA returns the CAA records of each name on the tree-climb path of the
+// requested hostname, serving them from the cache where possible. Names which
+// can't be looked up are reported as such in the response, rather than as an
+// error, so that the VA can decide whether the failure matters.
+func (c *Checker) LookupCAA(ctx context.Context, req *caapb.LookupCAARequest) (*caapb.LookupCAAResponse, error) {
+	hostname := strings.ToLower(strings.TrimRight(req.Hostname, "."))
+	if hostname == "" || strings.HasPrefix(hostname, "*.") {
+		return nil, berrors.InternalServerError("invalid hostname %q for CAA lookup", req.Hostname)
+	}
+	names := treeClimb(hostname)
+
+	cached := map[string]*caapb.CAASet{}
+	if c.cache != nil {
+		var err error
+		cached, err = c.cache.Get(ctx, names)
+		if err != nil {
+			// The cache is an optimization: carry on without it.
+			c.log.Warningf("getting cached CAA results for %q: %s", hostname, err)
+			cached = map[string]*caapb.CAASet{}
+		}
+	}
+
+	sets := make([]*caapb.CAASet, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		set, ok := cached[name]
+		if ok {
+			set.Cached = true
+			sets[i] = set
+			c.lookups.WithLabelValues("hit").Inc()
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sets[i] = c.lookup(ctx, name)
+		}()
+	}
+	wg.Wait()
+
+	return &caapb.LookupCAAResponse{Sets: sets}, nil
+}
+
+// lookup queries DNS for the CAA records of a single name and caches the
+// result if the query succeeded.
+func (c *Checker) lookup(ctx context.Context, name string) *caapb.CAASet {
+	records, dig, resolvers, err := c.dnsClient.LookupCAA(ctx, name)
+	if err != nil {
+		c.lookups.WithLabelValues("error").Inc()
+		return &caapb.CAASet{Name: name, Error: err.Error()}
+	}
+	c.lookups.WithLabelValues("miss").Inc()
+
+	set := &caapb.CAASet{Name: name, Dig: dig, Resolvers: resolvers}
+	ttl := c.negativeMaxAge
+	if len(records) > 0 {
+		ttl = c.maxAge
+	}
+	for _, rr := range records {
+		set.Records = append(set.Records, &caapb.CAARecord{
+			Flag:  uint32(rr.Flag),
+			Tag:   rr.Tag,
+			Value: rr.Value,
+			Ttl:   rr.Hdr.Ttl,
+		})
+		ttl = min(ttl, time.Duration(rr.Hdr.Ttl)*time.Second)
+	}
+
+	if c.cache != nil && ttl > 0 {
+		err = c.cache.Set(ctx, set, ttl)
+		if err != nil {
+			c.log.Warningf("caching CAA result for %q: %s", name, err)
+		}
+	}
+	return set
+}
+
+// SetToRecords converts the records of a CAASet, as returned by LookupCAA, back
+// into the form returned by bdns.Client.LookupCAA. If the lookup failed, it
+// returns the lookup's error.
+func SetToRecords(set *caapb.CAASet) ([]*dns.CAA, bdns.ResolverAddrs, error) {
+	if set.Error != "" {
+		return nil, set.Resolvers, errors.New(set.Error)
+	}
+	var records []*dns.CAA
+	for _, r := range set.Records {
+		records = append(records, &dns.CAA{
+			Hdr: dns.RR_Header{
+				Name:   dns.Fqdn(set.Name),
+				Rrtype: dns.TypeCAA,
+				Class:  dns.ClassINET,
+				Ttl:    r.Ttl,
+			},
+			Flag:  uint8(r.Flag),
+			Tag:   r.Tag,
+			Value: r.Value,
+		})
+	}
+	return records, set.Resolvers, nil
+}
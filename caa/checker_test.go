@@ -0,0 +1,230 @@
+package caa
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/bdns"
+	caapb "github.com/letsencrypt/boulder/caa/proto"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// caaDNS answers CAA queries from a fixed set of records, and counts the
+// queries it receives for each name.
+type caaDNS struct {
+	bdns.MockClient
+
+	sync.Mutex
+	queries map[string]int
+}
+
+func (mock *caaDNS) LookupCAA(_ context.Context, domain string) ([]*dns.CAA, string, bdns.ResolverAddrs, error) {
+	mock.Lock()
+	mock.queries[domain]++
+	mock.Unlock()
+
+	resolvers := bdns.ResolverAddrs{"caaDNS"}
+	switch domain {
+	case "servfail.com":
+		return nil, "", resolvers, errors.New("SERVFAIL")
+	case "present.com":
+		return []*dns.CAA{
+			{Hdr: dns.RR_Header{Ttl: 300}, Tag: "issue", Value: "letsencrypt.org"},
+			{Hdr: dns.RR_Header{Ttl: 120}, Flag: 128, Tag: "iodef", Value: "mailto:caa@present.com"},
+		}, "present.com. 300 IN CAA 0 issue \"letsencrypt.org\"", resolvers, nil
+	case "long-ttl.com":
+		return []*dns.CAA{
+			{Hdr: dns.RR_Header{Ttl: 86400}, Tag: "issue", Value: "letsencrypt.org"},
+		}, "", resolvers, nil
+	}
+	return nil, "", resolvers, nil
+}
+
+func (mock *caaDNS) count(domain string) int {
+	mock.Lock()
+	defer mock.Unlock()
+	return mock.queries[domain]
+}
+
+// memCache is an in-memory Cache which records the TTL of each entry.
+type memCache struct {
+	sync.Mutex
+	sets   map[string]*caapb.CAASet
+	ttls   map[string]time.Duration
+	getErr error
+}
+
+func newMemCache() *memCache {
+	return &memCache{
+		sets: make(map[string]*caapb.CAASet),
+		ttls: make(map[string]time.Duration),
+	}
+}
+
+func (c *memCache) Get(_ context.Context, names []string) (map[string]*caapb.CAASet, error) {
+	c.Lock()
+	defer c.Unlock()
+	if c.getErr != nil {
+		return nil, c.getErr
+	}
+	found := make(map[string]*caapb.CAASet)
+	for _, name := range names {
+		set, ok := c.sets[name]
+		if ok {
+			found[name] = set
+		}
+	}
+	return found, nil
+}
+
+func (c *memCache) Set(_ context.Context, set *caapb.CAASet, ttl time.Duration) error {
+	c.Lock()
+	defer c.Unlock()
+	c.sets[set.Name] = set
+	c.ttls[set.Name] = ttl
+	return nil
+}
+
+func setup(cache Cache) (*Checker, *caaDNS) {
+	dnsClient := &caaDNS{queries: make(map[string]int)}
+	checker := NewChecker(dnsClient, cache, time.Hour, time.Minute, clock.NewFake(), metrics.NoopRegisterer, blog.NewMock())
+	return checker, dnsClient
+}
+
+func TestTreeClimb(t *testing.T) {
+	t.Parallel()
+
+	test.AssertDeepEquals(t, treeClimb("www.example.com"), []string{"www.example.com", "example.com", "com"})
+	test.AssertDeepEquals(t, treeClimb("com"), []string{"com"})
+}
+
+func TestLookupCAA(t *testing.T) {
+	t.Parallel()
+
+	cache := newMemCache()
+	checker, dnsClient := setup(cache)
+
+	resp, err := checker.LookupCAA(context.Background(), &caapb.LookupCAARequest{Hostname: "www.present.com."})
+	test.AssertNotError(t, err, "LookupCAA failed")
+	test.AssertEquals(t, len(resp.Sets), 3)
+	test.AssertEquals(t, resp.Sets[0].Name, "www.present.com")
+	test.AssertEquals(t, len(resp.Sets[0].Records), 0)
+	test.AssertEquals(t, resp.Sets[1].Name, "present.com")
+	test.AssertEquals(t, len(resp.Sets[1].Records), 2)
+	test.AssertEquals(t, resp.Sets[2].Name, "com")
+	for _, set := range resp.Sets {
+		test.Assert(t, !set.Cached, "first lookup should not be served from the cache")
+	}
+	test.AssertMetricWithLabelsEquals(t, checker.lookups, prometheus.Labels{"result": "miss"}, 3)
+
+	// Empty sets are cached for negativeMaxAge, and sets with records for the
+	// shortest of their TTLs.
+	test.AssertEquals(t, cache.ttls["www.present.com"], time.Minute)
+	test.AssertEquals(t, cache.ttls["present.com"], 120*time.Second)
+	test.AssertEquals(t, cache.ttls["com"], time.Minute)
+
+	// A sibling shares the cached results for its parents.
+	resp, err = checker.LookupCAA(context.Background(), &caapb.LookupCAARequest{Hostname: "mail.present.com"})
+	test.AssertNotError(t, err, "LookupCAA failed")
+	test.AssertEquals(t, len(resp.Sets), 3)
+	test.Assert(t, !resp.Sets[0].Cached, "mail.present.com should not have been cached")
+	test.Assert(t, resp.Sets[1].Cached, "present.com should have been cached")
+	test.Assert(t, resp.Sets[2].Cached, "com should have been cached")
+	test.AssertEquals(t, dnsClient.count("present.com"), 1)
+	test.AssertEquals(t, dnsClient.count("com"), 1)
+	test.AssertMetricWithLabelsEquals(t, checker.lookups, prometheus.Labels{"result": "hit"}, 2)
+}
+
+func TestLookupCAAMaxAge(t *testing.T) {
+	t.Parallel()
+
+	cache := newMemCache()
+	checker, _ := setup(cache)
+
+	_, err := checker.LookupCAA(context.Background(), &caapb.LookupCAARequest{Hostname: "long-ttl.com"})
+	test.AssertNotError(t, err, "LookupCAA failed")
+	test.AssertEquals(t, cache.ttls["long-ttl.com"], time.Hour)
+}
+
+func TestLookupCAAErrorsNotCached(t *testing.T) {
+	t.Parallel()
+
+	cache := newMemCache()
+	checker, dnsClient := setup(cache)
+
+	for range 2 {
+		resp, err := checker.LookupCAA(context.Background(), &caapb.LookupCAARequest{Hostname: "servfail.com"})
+		test.AssertNotError(t, err, "LookupCAA should report lookup failures in the response")
+		test.AssertEquals(t, resp.Sets[0].Error, "SERVFAIL")
+	}
+	test.AssertEquals(t, dnsClient.count("servfail.com"), 2)
+	_, ok := cache.sets["servfail.com"]
+	test.Assert(t, !ok, "failed lookup should not have been cached")
+	test.AssertMetricWithLabelsEquals(t, checker.lookups, prometheus.Labels{"result": "error"}, 2)
+}
+
+func TestLookupCAACacheUnavailable(t *testing.T) {
+	t.Parallel()
+
+	cache := newMemCache()
+	cache.getErr = errors.New("connection refused")
+	checker, dnsClient := setup(cache)
+
+	resp, err := checker.LookupCAA(context.Background(), &caapb.LookupCAARequest{Hostname: "present.com"})
+	test.AssertNotError(t, err, "LookupCAA should fall back to DNS when the cache is unavailable")
+	test.AssertEquals(t, len(resp.Sets[0].Records), 2)
+	test.AssertEquals(t, dnsClient.count("present.com"), 1)
+}
+
+func TestLookupCAANoCache(t *testing.T) {
+	t.Parallel()
+
+	checker, dnsClient := setup(nil)
+
+	for range 2 {
+		_, err := checker.LookupCAA(context.Background(), &caapb.LookupCAARequest{Hostname: "present.com"})
+		test.AssertNotError(t, err, "LookupCAA failed")
+	}
+	test.AssertEquals(t, dnsClient.count("present.com"), 2)
+}
+
+func TestLookupCAAInvalidHostname(t *testing.T) {
+	t.Parallel()
+
+	checker, _ := setup(nil)
+
+	for _, hostname := range []string{"", ".", "*.example.com"} {
+		_, err := checker.LookupCAA(context.Background(), &caapb.LookupCAARequest{Hostname: hostname})
+		test.AssertError(t, err, "LookupCAA should reject "+hostname)
+	}
+}
+
+func TestSetToRecords(t *testing.T) {
+	t.Parallel()
+
+	checker, _ := setup(nil)
+
+	resp, err := checker.LookupCAA(context.Background(), &caapb.LookupCAARequest{Hostname: "present.com"})
+	test.AssertNotError(t, err, "LookupCAA failed")
+	records, resolvers, err := SetToRecords(resp.Sets[0])
+	test.AssertNotError(t, err, "SetToRecords failed")
+	test.AssertDeepEquals(t, resolvers, bdns.ResolverAddrs{"caaDNS"})
+	test.AssertEquals(t, len(records), 2)
+	test.AssertEquals(t, records[0].Tag, "issue")
+	test.AssertEquals(t, records[0].Value, "letsencrypt.org")
+	test.AssertEquals(t, records[1].Flag, uint8(128))
+	test.AssertEquals(t, records[1].Hdr.Ttl, uint32(120))
+	test.AssertEquals(t, records[1].Hdr.Name, "present.com.")
+
+	_, _, err = SetToRecords(&caapb.CAASet{Name: "servfail.com", Error: "SERVFAIL"})
+	test.AssertError(t, err, "SetToRecords should return the lookup's error")
+}
@@ -0,0 +1,366 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        v3.20.1
+// source: caa.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LookupCAARequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Next unused field number: 2
+	// The hostname whose CAA tree-climb to look up, without any wildcard prefix.
+	Hostname      string `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupCAARequest) Reset() {
+	*x = LookupCAARequest{}
+	mi := &file_caa_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupCAARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupCAARequest) ProtoMessage() {}
+
+func (x *LookupCAARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_caa_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupCAARequest.ProtoReflect.Descriptor instead.
+func (*LookupCAARequest) Descriptor() ([]byte, []int) {
+	return file_caa_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LookupCAARequest) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+type LookupCAAResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Next unused field number: 2
+	// The result of querying each name on the tree-climb path, starting with the
+	// hostname itself and ending with its TLD.
+	Sets          []*CAASet `protobuf:"bytes,1,rep,name=sets,proto3" json:"sets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupCAAResponse) Reset() {
+	*x = LookupCAAResponse{}
+	mi := &file_caa_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupCAAResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupCAAResponse) ProtoMessage() {}
+
+func (x *LookupCAAResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_caa_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupCAAResponse.ProtoReflect.Descriptor instead.
+func (*LookupCAAResponse) Descriptor() ([]byte, []int) {
+	return file_caa_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LookupCAAResponse) GetSets() []*CAASet {
+	if x != nil {
+		return x.Sets
+	}
+	return nil
+}
+
+type CAASet struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Next unused field number: 7
+	Name    string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Records []*CAARecord `protobuf:"bytes,2,rep,name=records,proto3" json:"records,omitempty"`
+	// The raw DNS response, for logging.
+	Dig       string   `protobuf:"bytes,3,opt,name=dig,proto3" json:"dig,omitempty"`
+	Resolvers []string `protobuf:"bytes,4,rep,name=resolvers,proto3" json:"resolvers,omitempty"`
+	// If non-empty, the lookup of this name failed and no other fields but name
+	// are set. Failed lookups are never cached.
+	Error string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	// Whether this result was served from the shared cache.
+	Cached        bool `protobuf:"varint,6,opt,name=cached,proto3" json:"cached,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CAASet) Reset() {
+	*x = CAASet{}
+	mi := &file_caa_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CAASet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CAASet) ProtoMessage() {}
+
+func (x *CAASet) ProtoReflect() protoreflect.Message {
+	mi := &file_caa_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CAASet.ProtoReflect.Descriptor instead.
+func (*CAASet) Descriptor() ([]byte, []int) {
+	return file_caa_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CAASet) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CAASet) GetRecords() []*CAARecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *CAASet) GetDig() string {
+	if x != nil {
+		return x.Dig
+	}
+	return ""
+}
+
+func (x *CAASet) GetResolvers() []string {
+	if x != nil {
+		return x.Resolvers
+	}
+	return nil
+}
+
+func (x *CAASet) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CAASet) GetCached() bool {
+	if x != nil {
+		return x.Cached
+	}
+	return false
+}
+
+type CAARecord struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Next unused field number: 5
+	Flag  uint32 `protobuf:"varint,1,opt,name=flag,proto3" json:"flag,omitempty"`
+	Tag   string `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	Value string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	// The TTL of the record when it was looked up.
+	Ttl           uint32 `protobuf:"varint,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CAARecord) Reset() {
+	*x = CAARecord{}
+	mi := &file_caa_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CAARecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CAARecord) ProtoMessage() {}
+
+func (x *CAARecord) ProtoReflect() protoreflect.Message {
+	mi := &file_caa_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CAARecord.ProtoReflect.Descriptor instead.
+func (*CAARecord) Descriptor() ([]byte, []int) {
+	return file_caa_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CAARecord) GetFlag() uint32 {
+	if x != nil {
+		return x.Flag
+	}
+	return 0
+}
+
+func (x *CAARecord) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *CAARecord) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *CAARecord) GetTtl() uint32 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
+var File_caa_proto protoreflect.FileDescriptor
+
+var file_caa_proto_rawDesc = string([]byte{
+	0x0a, 0x09, 0x63, 0x61, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x63, 0x61, 0x61,
+	0x22, 0x2e, 0x0a, 0x10, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x43, 0x41, 0x41, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65,
+	0x22, 0x34, 0x0a, 0x11, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x43, 0x41, 0x41, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x04, 0x73, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x63, 0x61, 0x61, 0x2e, 0x43, 0x41, 0x41, 0x53, 0x65, 0x74,
+	0x52, 0x04, 0x73, 0x65, 0x74, 0x73, 0x22, 0xa4, 0x01, 0x0a, 0x06, 0x43, 0x41, 0x41, 0x53, 0x65,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x63, 0x61, 0x61, 0x2e, 0x43, 0x41, 0x41,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12,
+	0x10, 0x0a, 0x03, 0x64, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x69,
+	0x67, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x22, 0x59, 0x0a,
+	0x09, 0x43, 0x41, 0x41, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x6c,
+	0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x66, 0x6c, 0x61, 0x67, 0x12, 0x10,
+	0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x32, 0x4a, 0x0a, 0x0a, 0x43, 0x41, 0x41, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x3c, 0x0a, 0x09, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70,
+	0x43, 0x41, 0x41, 0x12, 0x15, 0x2e, 0x63, 0x61, 0x61, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70,
+	0x43, 0x41, 0x41, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x61, 0x61,
+	0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x43, 0x41, 0x41, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x42, 0x2a, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x6c, 0x65, 0x74, 0x73, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x2f, 0x62,
+	0x6f, 0x75, 0x6c, 0x64, 0x65, 0x72, 0x2f, 0x63, 0x61, 0x61, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_caa_proto_rawDescOnce sync.Once
+	file_caa_proto_rawDescData []byte
+)
+
+func file_caa_proto_rawDescGZIP() []byte {
+	file_caa_proto_rawDescOnce.Do(func() {
+		file_caa_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_caa_proto_rawDesc), len(file_caa_proto_rawDesc)))
+	})
+	return file_caa_proto_rawDescData
+}
+
+var file_caa_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_caa_proto_goTypes = []any{
+	(*LookupCAARequest)(nil),  // 0: caa.LookupCAARequest
+	(*LookupCAAResponse)(nil), // 1: caa.LookupCAAResponse
+	(*CAASet)(nil),            // 2: caa.CAASet
+	(*CAARecord)(nil),         // 3: caa.CAARecord
+}
+var file_caa_proto_depIdxs = []int32{
+	2, // 0: caa.LookupCAAResponse.sets:type_name -> caa.CAASet
+	3, // 1: caa.CAASet.records:type_name -> caa.CAARecord
+	0, // 2: caa.CAAChecker.LookupCAA:input_type -> caa.LookupCAARequest
+	1, // 3: caa.CAAChecker.LookupCAA:output_type -> caa.LookupCAAResponse
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_caa_proto_init() }
+func file_caa_proto_init() {
+	if File_caa_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_caa_proto_rawDesc), len(file_caa_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_caa_proto_goTypes,
+		DependencyIndexes: file_caa_proto_depIdxs,
+		MessageInfos:      file_caa_proto_msgTypes,
+	}.Build()
+	File_caa_proto = out.File
+	file_caa_proto_goTypes = nil
+	file_caa_proto_depIdxs = nil
+}
@@ -0,0 +1,207 @@
+// Package cdn contains clients for purging cached OCSP responses and CRLs from
+// CDNs other than Akamai, and a Distribution type which rewrites the URLs to be
+// purged for each CDN distribution they are cached by.
+package cdn
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/metrics"
+)
+
+// requestTimeout bounds each HTTP request made to a CDN's purge API, so that a
+// CDN which stops responding can't stall the purger's queue indefinitely.
+const requestTimeout = 30 * time.Second
+
+var (
+	// ErrAllRetriesFailed indicates that all purge submission attempts have
+	// failed.
+	ErrAllRetriesFailed = errors.New("all attempts to submit purge request failed")
+
+	// errFatal is returned by the purge methods of the clients in this package
+	// to indicate that a request failed for a reason that cannot be remediated
+	// by retrying it.
+	errFatal = errors.New("fatal error")
+)
+
+// Purger is implemented by each supported CDN purge backend, including
+// akamai.CachePurgeClient.
+type Purger interface {
+	Purge(urls []string) error
+}
+
+// DistributionConfig configures a single CDN distribution whose cached copies
+// of the URLs sent to the purger should be purged. Exactly one of Fastly or
+// CloudFront must be set.
+type DistributionConfig struct {
+	// Name identifies this distribution in logs and metrics.
+	Name string `validate:"required"`
+
+	// URLTemplates are Go text/template strings used to rewrite each URL sent
+	// to the purger into the URLs (or, for CloudFront, paths) cached by this
+	// distribution. Templates may reference {{.URL}}, {{.Scheme}}, {{.Host}}
+	// and {{.RequestURI}} (the escaped path and query) of the original URL.
+	// For example, "https://cdn.example.net{{.RequestURI}}". If empty, URLs
+	// are purged unmodified.
+	URLTemplates []string `validate:"omitempty,dive,required"`
+
+	Fastly     *FastlyConfig     `validate:"required_without=CloudFront,excluded_with=CloudFront"`
+	CloudFront *CloudFrontConfig `validate:"required_without=Fastly"`
+}
+
+// urlTemplateData is the data made available to URL templates.
+type urlTemplateData struct {
+	URL        string
+	Scheme     string
+	Host       string
+	RequestURI string
+}
+
+// Distribution purges URLs from a single CDN distribution, rewriting them with
+// its URL templates first.
+type Distribution struct {
+	name      string
+	templates []*template.Template
+	purger    Purger
+}
+
+// NewDistribution parses the given URL templates and returns a Distribution
+// which purges the URLs they produce using the given Purger.
+func NewDistribution(name string, urlTemplates []string, purger Purger) (*Distribution, error) {
+	var templates []*template.Template
+	for i, text := range urlTemplates {
+		tmpl, err := template.New(fmt.Sprintf("%s-%d", name, i)).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("parsing URL template %q for distribution %q: %w", text, name, err)
+		}
+		templates = append(templates, tmpl)
+	}
+	return &Distribution{name: name, templates: templates, purger: purger}, nil
+}
+
+// Name returns the name of the distribution.
+func (d *Distribution) Name() string {
+	return d.name
+}
+
+// rewrite returns the URLs which must be purged from this distribution for the
+// given input URLs, without duplicates.
+func (d *Distribution) rewrite(urls []string) ([]string, error) {
+	if len(d.templates) == 0 {
+		return urls, nil
+	}
+	var out []string
+	seen := make(map[string]bool)
+	for _, u := range urls {
+		parsed, err := url.Parse(u)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %q as URL: %w", u, err)
+		}
+		data := urlTemplateData{
+			URL:        u,
+			Scheme:     parsed.Scheme,
+			Host:       parsed.Host,
+			RequestURI: parsed.RequestURI(),
+		}
+		for _, tmpl := range d.templates {
+			var b strings.Builder
+			err := tmpl.Execute(&b, data)
+			if err != nil {
+				return nil, fmt.Errorf("executing URL template for distribution %q: %w", d.name, err)
+			}
+			if !seen[b.String()] {
+				seen[b.String()] = true
+				out = append(out, b.String())
+			}
+		}
+	}
+	return out, nil
+}
+
+// MultiPurger purges URLs from each of a set of CDN distributions. It
+// implements Purger.
+type MultiPurger struct {
+	distributions []*Distribution
+	purgeLatency  *prometheus.HistogramVec
+	purges        *prometheus.CounterVec
+	clk           clock.Clock
+}
+
+// NewMultiPurger returns a MultiPurger which purges from all of the given
+// distributions.
+func NewMultiPurger(distributions []*Distribution, scope prometheus.Registerer, clk clock.Clock) *MultiPurger {
+	purgeLatency := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "cdn_purge_latency",
+		Help:    "Histogram of latencies of CDN purges, labeled by distribution",
+		Buckets: metrics.InternetFacingBuckets,
+	}, []string{"distribution"})
+	scope.MustRegister(purgeLatency)
+
+	purges := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cdn_purges",
+		Help: "A counter of CDN purges, labeled by distribution and result=[success|failure]",
+	}, []string{"distribution", "result"})
+	scope.MustRegister(purges)
+
+	return &MultiPurger{
+		distributions: distributions,
+		purgeLatency:  purgeLatency,
+		purges:        purges,
+		clk:           clk,
+	}
+}
+
+// Purge purges the given URLs from every distribution. A failure to purge from
+// one distribution does not prevent purging from the others; all errors are
+// returned together.
+func (mp *MultiPurger) Purge(urls []string) error {
+	var errs []error
+	for _, d := range mp.distributions {
+		rewritten, err := d.rewrite(urls)
+		if err != nil {
+			mp.purges.WithLabelValues(d.name, "failure").Inc()
+			errs = append(errs, err)
+			continue
+		}
+
+		start := mp.clk.Now()
+		err = d.purger.Purge(rewritten)
+		mp.purgeLatency.WithLabelValues(d.name).Observe(mp.clk.Since(start).Seconds())
+		if err != nil {
+			mp.purges.WithLabelValues(d.name, "failure").Inc()
+			errs = append(errs, fmt.Errorf("purging from distribution %q: %w", d.name, err))
+			continue
+		}
+		mp.purges.WithLabelValues(d.name, "success").Inc()
+	}
+	return errors.Join(errs...)
+}
+
+// withRetries calls purge until it succeeds, returns an error wrapping
+// errFatal, or has been retried the given number of times, sleeping with
+// exponential backoff before each attempt. It returns ErrAllRetriesFailed if no
+// attempt succeeded.
+func withRetries(clk clock.Clock, retries int, retryBackoff time.Duration, onRetry func(error), purge func() error) error {
+	for i := range retries + 1 {
+		clk.Sleep(core.RetryBackoff(i, retryBackoff, time.Minute, 1.3))
+
+		err := purge()
+		if err == nil {
+			return nil
+		}
+		if errors.Is(err, errFatal) {
+			return err
+		}
+		onRetry(err)
+	}
+	return ErrAllRetriesFailed
+}
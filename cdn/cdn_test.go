@@ -0,0 +1,86 @@
+package cdn
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+)
+
+type recordingPurger struct {
+	purged [][]string
+	err    error
+}
+
+func (rp *recordingPurger) Purge(urls []string) error {
+	rp.purged = append(rp.purged, urls)
+	return rp.err
+}
+
+func TestDistributionRewrite(t *testing.T) {
+	urls := []string{
+		"http://ocsp.example.com/?body-md5=d6101198a9d9f1f6",
+		"http://ocsp.example.com/MFQwUjBQME4wTDAJBgUrDgMCGgUABBR+5mrncpqz/PiiIGRsFqEtYHEIXQQUqEpqYwR93brm0Tm3pkVl7/Oo7KECEwD/Ly9OjzD1pUsgRaUT6CNa/Ec=",
+		"http://ocsp.example.com/MFQwUjBQME4wTDAJBgUrDgMCGgUABBR%2B5mrncpqz%2FPiiIGRsFqEtYHEIXQQUqEpqYwR93brm0Tm3pkVl7%2FOo7KECEwD%2FLy9OjzD1pUsgRaUT6CNa%2FEc%3D",
+	}
+
+	// Without templates, URLs are unmodified.
+	d, err := NewDistribution("identity", nil, nil)
+	test.AssertNotError(t, err, "creating distribution")
+	got, err := d.rewrite(urls)
+	test.AssertNotError(t, err, "rewriting URLs")
+	test.AssertDeepEquals(t, got, urls)
+
+	// Templates can swap the host while preserving the escaped path and query.
+	d, err = NewDistribution("rehost", []string{"https://cdn.example.net{{.RequestURI}}"}, nil)
+	test.AssertNotError(t, err, "creating distribution")
+	got, err = d.rewrite(urls)
+	test.AssertNotError(t, err, "rewriting URLs")
+	test.AssertDeepEquals(t, got, []string{
+		"https://cdn.example.net/?body-md5=d6101198a9d9f1f6",
+		"https://cdn.example.net/MFQwUjBQME4wTDAJBgUrDgMCGgUABBR+5mrncpqz/PiiIGRsFqEtYHEIXQQUqEpqYwR93brm0Tm3pkVl7/Oo7KECEwD/Ly9OjzD1pUsgRaUT6CNa/Ec=",
+		"https://cdn.example.net/MFQwUjBQME4wTDAJBgUrDgMCGgUABBR%2B5mrncpqz%2FPiiIGRsFqEtYHEIXQQUqEpqYwR93brm0Tm3pkVl7%2FOo7KECEwD%2FLy9OjzD1pUsgRaUT6CNa%2FEc%3D",
+	})
+
+	// Multiple templates produce multiple URLs per input, without duplicates.
+	d, err = NewDistribution("multi", []string{"{{.Scheme}}://a.example.net/", "{{.Scheme}}://b.example.net/"}, nil)
+	test.AssertNotError(t, err, "creating distribution")
+	got, err = d.rewrite(urls)
+	test.AssertNotError(t, err, "rewriting URLs")
+	test.AssertDeepEquals(t, got, []string{"http://a.example.net/", "http://b.example.net/"})
+
+	// Invalid templates are rejected up front.
+	_, err = NewDistribution("invalid", []string{"{{.URL"}, nil)
+	test.AssertError(t, err, "expected invalid template to be rejected")
+
+	// Templates referencing unknown fields fail when executed.
+	d, err = NewDistribution("unknown", []string{"{{.Port}}"}, nil)
+	test.AssertNotError(t, err, "creating distribution")
+	_, err = d.rewrite(urls)
+	test.AssertError(t, err, "expected unknown template field to fail")
+}
+
+func TestMultiPurger(t *testing.T) {
+	good := &recordingPurger{}
+	bad := &recordingPurger{err: errors.New("oops")}
+
+	goodDist, err := NewDistribution("good", []string{"https://cdn.example.net{{.RequestURI}}"}, good)
+	test.AssertNotError(t, err, "creating distribution")
+	badDist, err := NewDistribution("bad", nil, bad)
+	test.AssertNotError(t, err, "creating distribution")
+
+	mp := NewMultiPurger([]*Distribution{badDist, goodDist}, metrics.NoopRegisterer, clock.NewFake())
+	err = mp.Purge([]string{"http://ocsp.example.com/abc"})
+	test.AssertError(t, err, "expected failure from bad distribution")
+	test.AssertContains(t, err.Error(), `distribution "bad"`)
+
+	// A failure in one distribution doesn't prevent purging from the others.
+	test.AssertDeepEquals(t, bad.purged, [][]string{{"http://ocsp.example.com/abc"}})
+	test.AssertDeepEquals(t, good.purged, [][]string{{"https://cdn.example.net/abc"}})
+	test.AssertMetricWithLabelsEquals(t, mp.purges, prometheus.Labels{"distribution": "good", "result": "success"}, 1)
+	test.AssertMetricWithLabelsEquals(t, mp.purges, prometheus.Labels{"distribution": "bad", "result": "failure"}, 1)
+}
@@ -0,0 +1,238 @@
+package cdn
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/jmhodges/clock"
+
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/core"
+	blog "github.com/letsencrypt/boulder/log"
+)
+
+const (
+	defaultCloudFrontEndpoint = "https://cloudfront.amazonaws.com"
+	cloudFrontAPIVersion      = "2020-05-31"
+
+	// CloudFront is a global service whose API requests are always signed for
+	// us-east-1.
+	cloudFrontSigningRegion = "us-east-1"
+)
+
+// CloudFrontConfig configures an Amazon CloudFront purge backend.
+type CloudFrontConfig struct {
+	// DistributionID is the ID of the CloudFront distribution in which
+	// invalidations are created.
+	DistributionID string `validate:"required"`
+
+	// AWSConfigFile is the path to a file on disk containing an AWS config.
+	// The format of the configuration file is specified at
+	// https://docs.aws.amazon.com/sdkref/latest/guide/file-format.html.
+	AWSConfigFile string
+
+	// AWSCredsFile is the path to a file on disk containing AWS credentials.
+	// The format of the credentials file is specified at
+	// https://docs.aws.amazon.com/sdkref/latest/guide/file-format.html.
+	AWSCredsFile string
+
+	// Endpoint overrides the CloudFront API endpoint. It is intended only for
+	// use in tests.
+	Endpoint string `validate:"omitempty,url"`
+
+	// PurgeRetries is the maximum number of times a failed invalidation
+	// request will be retried.
+	PurgeRetries int `validate:"min=0"`
+
+	// PurgeRetryBackoff is the base duration waited before retrying a failed
+	// invalidation request.
+	PurgeRetryBackoff config.Duration `validate:"-"`
+}
+
+type cloudFrontPaths struct {
+	Quantity int      `xml:"Quantity"`
+	Items    []string `xml:"Items>Path"`
+}
+
+type cloudFrontInvalidationBatch struct {
+	XMLName         xml.Name        `xml:"http://cloudfront.amazonaws.com/doc/2020-05-31/ InvalidationBatch"`
+	CallerReference string          `xml:"CallerReference"`
+	Paths           cloudFrontPaths `xml:"Paths"`
+}
+
+type cloudFrontInvalidation struct {
+	ID     string `xml:"Id"`
+	Status string `xml:"Status"`
+}
+
+// CloudFrontPurgeClient purges URLs from a CloudFront distribution by creating
+// invalidations. It is safe to make concurrent requests using this client.
+type CloudFrontPurgeClient struct {
+	client         *http.Client
+	endpoint       string
+	distributionID string
+	creds          aws.CredentialsProvider
+	signer         *v4.Signer
+	retries        int
+	retryBackoff   time.Duration
+	log            blog.Logger
+	clk            clock.Clock
+}
+
+var _ Purger = (*CloudFrontPurgeClient)(nil)
+
+// NewCloudFrontPurgeClient returns a CloudFrontPurgeClient which creates
+// invalidations in the given distribution, signing its requests with the given
+// credentials. If endpoint is empty, the public CloudFront API is used.
+func NewCloudFrontPurgeClient(
+	endpoint,
+	distributionID string,
+	creds aws.CredentialsProvider,
+	retries int,
+	retryBackoff time.Duration,
+	log blog.Logger,
+) (*CloudFrontPurgeClient, error) {
+	if distributionID == "" {
+		return nil, fmt.Errorf("CloudFront distribution ID must not be empty")
+	}
+	if endpoint == "" {
+		endpoint = defaultCloudFrontEndpoint
+	}
+	_, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CloudFront endpoint as a URL: %s", err)
+	}
+
+	return &CloudFrontPurgeClient{
+		client:         &http.Client{Timeout: requestTimeout},
+		endpoint:       strings.TrimSuffix(endpoint, "/"),
+		distributionID: distributionID,
+		creds:          creds,
+		signer:         v4.NewSigner(),
+		retries:        retries,
+		retryBackoff:   retryBackoff,
+		log:            log,
+		clk:            clock.New(),
+	}, nil
+}
+
+// invalidationPath converts a URL or path into a CloudFront invalidation path.
+// Invalidation paths are relative to the distribution and cannot include a
+// query string, so only the escaped path of a full URL is used.
+func invalidationPath(u string) (string, error) {
+	if strings.HasPrefix(u, "/") {
+		return u, nil
+	}
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return "", fmt.Errorf("parsing %q as URL: %s: %w", u, err, errFatal)
+	}
+	path := parsed.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	return path, nil
+}
+
+// createInvalidation creates a single invalidation for all of the given paths.
+func (cfpc *CloudFrontPurgeClient) createInvalidation(paths []string) error {
+	batch := cloudFrontInvalidationBatch{
+		CallerReference: fmt.Sprintf("boulder-%d-%s", cfpc.clk.Now().UnixNano(), core.RandomString(8)),
+		Paths: cloudFrontPaths{
+			Quantity: len(paths),
+			Items:    paths,
+		},
+	}
+	reqBody, err := xml.Marshal(batch)
+	if err != nil {
+		return fmt.Errorf("%s: %w", err, errFatal)
+	}
+	reqBody = append([]byte(xml.Header), reqBody...)
+
+	endpoint := fmt.Sprintf("%s/%s/distribution/%s/invalidation", cfpc.endpoint, cloudFrontAPIVersion, url.PathEscape(cfpc.distributionID))
+	req, err := http.NewRequest("POST", endpoint, bytes.NewReader(reqBody))
+	if err != nil {
+		return fmt.Errorf("%s: %w", err, errFatal)
+	}
+	req.Header.Set("Content-Type", "text/xml")
+
+	ctx := context.Background()
+	creds, err := cfpc.creds.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("retrieving AWS credentials: %w", err)
+	}
+	bodyHash := sha256.Sum256(reqBody)
+	err = cfpc.signer.SignHTTP(ctx, creds, req, hex.EncodeToString(bodyHash[:]), "cloudfront", cloudFrontSigningRegion, cfpc.clk.Now())
+	if err != nil {
+		return fmt.Errorf("signing CloudFront request: %s: %w", err, errFatal)
+	}
+
+	resp, err := cfpc.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("while POSTing to endpoint %q: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	// Success for a request to create an invalidation is 'HTTP 201'.
+	// https://docs.aws.amazon.com/cloudfront/latest/APIReference/API_CreateInvalidation.html
+	switch {
+	case resp.StatusCode == http.StatusCreated:
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return fmt.Errorf("received HTTP %d (body %q) for URL %q", resp.StatusCode, respBody, endpoint)
+	default:
+		return fmt.Errorf("received HTTP %d (body %q) for URL %q: %w", resp.StatusCode, respBody, endpoint, errFatal)
+	}
+
+	var invalidation cloudFrontInvalidation
+	err = xml.Unmarshal(respBody, &invalidation)
+	if err != nil {
+		return fmt.Errorf("while unmarshalling body %q from URL %q as XML: %w", respBody, endpoint, err)
+	}
+
+	cfpc.log.AuditInfof("CloudFront invalidation created successfully (ID %s) (status %s) (paths %s)",
+		invalidation.ID, invalidation.Status, strings.Join(paths, ","))
+	return nil
+}
+
+// Purge creates a CloudFront invalidation for the provided URLs or paths. The
+// request will be attempted cfpc.retries number of times before giving up and
+// returning ErrAllRetriesFailed.
+func (cfpc *CloudFrontPurgeClient) Purge(urls []string) error {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, u := range urls {
+		path, err := invalidationPath(u)
+		if err != nil {
+			return err
+		}
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	return withRetries(cfpc.clk, cfpc.retries, cfpc.retryBackoff, func(err error) {
+		cfpc.log.AuditErrf("CloudFront invalidation failed, retrying: %s", err)
+	}, func() error {
+		return cfpc.createInvalidation(paths)
+	})
+}
@@ -0,0 +1,98 @@
+package cdn
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/jmhodges/clock"
+
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/test"
+)
+
+type cloudFrontServer struct {
+	responseCode int
+	batches      []cloudFrontInvalidationBatch
+	*httptest.Server
+}
+
+func (cs *cloudFrontServer) handler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != "/2020-05-31/distribution/EDFDVBD6EXAMPLE/invalidation" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	var batch cloudFrontInvalidationBatch
+	err = xml.Unmarshal(body, &batch)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if cs.responseCode != http.StatusCreated {
+		w.WriteHeader(cs.responseCode)
+		return
+	}
+	cs.batches = append(cs.batches, batch)
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(`<?xml version="1.0"?><Invalidation><Id>I2J0I21PCUYOIK</Id><Status>InProgress</Status></Invalidation>`))
+}
+
+func newCloudFrontServer(code int) *cloudFrontServer {
+	cs := &cloudFrontServer{responseCode: code}
+	cs.Server = httptest.NewServer(http.HandlerFunc(cs.handler))
+	return cs
+}
+
+func TestCloudFrontPurge(t *testing.T) {
+	cs := newCloudFrontServer(http.StatusCreated)
+	defer cs.Close()
+
+	creds := credentials.NewStaticCredentialsProvider("AKIDEXAMPLE", "secret", "")
+	cfpc, err := NewCloudFrontPurgeClient(cs.URL, "EDFDVBD6EXAMPLE", creds, 2, time.Second, blog.NewMock())
+	test.AssertNotError(t, err, "creating CloudFront client")
+	fc := clock.NewFake()
+	cfpc.clk = fc
+
+	// Full URLs are converted to paths, query strings are dropped, and
+	// duplicates are removed.
+	err = cfpc.Purge([]string{
+		"http://ocsp.example.com/?body-md5=d6101198a9d9f1f6",
+		"http://ocsp.example.com/MFQw%2B5mrncpqz",
+		"/MFQw%2B5mrncpqz",
+		"/crl/1.crl",
+	})
+	test.AssertNotError(t, err, "purging URLs")
+	test.AssertEquals(t, len(cs.batches), 1)
+	test.AssertEquals(t, cs.batches[0].Paths.Quantity, 3)
+	test.AssertDeepEquals(t, cs.batches[0].Paths.Items, []string{"/", "/MFQw%2B5mrncpqz", "/crl/1.crl"})
+	test.Assert(t, cs.batches[0].CallerReference != "", "expected a caller reference")
+
+	// Throttling is retried until the retries are exhausted.
+	cs.responseCode = http.StatusTooManyRequests
+	err = cfpc.Purge([]string{"/crl/1.crl"})
+	test.AssertErrorIs(t, err, ErrAllRetriesFailed)
+
+	// Client errors are not retried.
+	cs.responseCode = http.StatusBadRequest
+	started := fc.Now()
+	err = cfpc.Purge([]string{"/crl/1.crl"})
+	test.AssertErrorIs(t, err, errFatal)
+	test.AssertEquals(t, fc.Since(started), time.Duration(0))
+
+	_, err = NewCloudFrontPurgeClient(cs.URL, "", creds, 2, time.Second, blog.NewMock())
+	test.AssertError(t, err, "expected empty distribution ID to be rejected")
+}
@@ -0,0 +1,132 @@
+package cdn
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/jmhodges/clock"
+
+	"github.com/letsencrypt/boulder/cmd"
+	"github.com/letsencrypt/boulder/config"
+	blog "github.com/letsencrypt/boulder/log"
+)
+
+// FastlyConfig configures a Fastly purge backend.
+type FastlyConfig struct {
+	// APIKey contains the path to a file holding a Fastly API token with
+	// purge_select scope for the service caching the purged URLs.
+	APIKey cmd.PasswordConfig
+
+	// SoftPurge, if true, marks cached content as stale rather than
+	// evicting it, so that Fastly may continue to serve it if the origin is
+	// unavailable.
+	SoftPurge bool
+
+	// PurgeRetries is the maximum number of times a failed purge request will
+	// be retried.
+	PurgeRetries int `validate:"min=0"`
+
+	// PurgeRetryBackoff is the base duration waited before retrying a failed
+	// purge request.
+	PurgeRetryBackoff config.Duration `validate:"-"`
+}
+
+type fastlyPurgeResponse struct {
+	Status string `json:"status"`
+	ID     string `json:"id"`
+}
+
+// FastlyPurgeClient purges individual URLs from Fastly by sending an
+// authenticated PURGE request to each URL. It is safe to make concurrent
+// requests using this client.
+type FastlyPurgeClient struct {
+	client       *http.Client
+	apiKey       string
+	softPurge    bool
+	retries      int
+	retryBackoff time.Duration
+	log          blog.Logger
+	clk          clock.Clock
+}
+
+var _ Purger = (*FastlyPurgeClient)(nil)
+
+// NewFastlyPurgeClient returns a FastlyPurgeClient which authenticates using
+// the given API token.
+func NewFastlyPurgeClient(apiKey string, softPurge bool, retries int, retryBackoff time.Duration, log blog.Logger) (*FastlyPurgeClient, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("Fastly API key must not be empty")
+	}
+	return &FastlyPurgeClient{
+		client:       &http.Client{Timeout: requestTimeout},
+		apiKey:       apiKey,
+		softPurge:    softPurge,
+		retries:      retries,
+		retryBackoff: retryBackoff,
+		log:          log,
+		clk:          clock.New(),
+	}, nil
+}
+
+// purgeURL sends a single PURGE request for the given URL.
+func (fpc *FastlyPurgeClient) purgeURL(u string) error {
+	req, err := http.NewRequest("PURGE", u, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", err, errFatal)
+	}
+	req.Header.Set("Fastly-Key", fpc.apiKey)
+	req.Header.Set("Accept", "application/json")
+	if fpc.softPurge {
+		req.Header.Set("Fastly-Soft-Purge", "1")
+	}
+
+	resp, err := fpc.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("while sending PURGE for %q: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("client not authorized to purge %q, got HTTP %d: %w", u, resp.StatusCode, errFatal)
+	default:
+		return fmt.Errorf("received HTTP %d (body %q) purging %q", resp.StatusCode, respBody, u)
+	}
+
+	var purgeInfo fastlyPurgeResponse
+	err = json.Unmarshal(respBody, &purgeInfo)
+	if err != nil {
+		return fmt.Errorf("while unmarshalling body %q purging %q as JSON: %w", respBody, u, err)
+	}
+	if purgeInfo.Status != "ok" {
+		return fmt.Errorf("unexpected status %q (body %q) purging %q", purgeInfo.Status, respBody, u)
+	}
+
+	fpc.log.AuditInfof("Fastly purge request sent successfully (ID %s) (URL %s)", purgeInfo.ID, u)
+	return nil
+}
+
+// Purge purges each of the provided URLs from Fastly. Each URL is attempted
+// fpc.retries number of times before giving up.
+func (fpc *FastlyPurgeClient) Purge(urls []string) error {
+	for _, u := range urls {
+		err := withRetries(fpc.clk, fpc.retries, fpc.retryBackoff, func(err error) {
+			fpc.log.AuditErrf("Fastly cache purge failed, retrying: %s", err)
+		}, func() error {
+			return fpc.purgeURL(u)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
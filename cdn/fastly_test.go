@@ -0,0 +1,89 @@
+package cdn
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/test"
+)
+
+type fastlyServer struct {
+	sync.Mutex
+	responseCode int
+	purged       []string
+	softPurges   int
+	*httptest.Server
+}
+
+func (fs *fastlyServer) handler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "PURGE" {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if r.Header.Get("Fastly-Key") != "its-a-key" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	fs.Lock()
+	defer fs.Unlock()
+	if fs.responseCode != http.StatusOK {
+		w.WriteHeader(fs.responseCode)
+		return
+	}
+	fs.purged = append(fs.purged, r.URL.RequestURI())
+	if r.Header.Get("Fastly-Soft-Purge") == "1" {
+		fs.softPurges++
+	}
+	w.Write([]byte(`{"status":"ok","id":"1234-1234-1234"}`))
+}
+
+func newFastlyServer(code int) *fastlyServer {
+	fs := &fastlyServer{responseCode: code}
+	fs.Server = httptest.NewServer(http.HandlerFunc(fs.handler))
+	return fs
+}
+
+func TestFastlyPurge(t *testing.T) {
+	fs := newFastlyServer(http.StatusOK)
+	defer fs.Close()
+
+	fpc, err := NewFastlyPurgeClient("its-a-key", true, 3, time.Second, blog.NewMock())
+	test.AssertNotError(t, err, "creating Fastly client")
+	fc := clock.NewFake()
+	fpc.clk = fc
+
+	err = fpc.Purge([]string{fs.URL + "/abc", fs.URL + "/?body-md5=d6101198a9d9f1f6"})
+	test.AssertNotError(t, err, "purging URLs")
+	test.AssertDeepEquals(t, fs.purged, []string{"/abc", "/?body-md5=d6101198a9d9f1f6"})
+	test.AssertEquals(t, fs.softPurges, 2)
+
+	// Server errors are retried until the retries are exhausted.
+	fs.responseCode = http.StatusInternalServerError
+	started := fc.Now()
+	err = fpc.Purge([]string{fs.URL + "/abc"})
+	test.AssertError(t, err, "expected purge to fail")
+	test.Assert(t, errors.Is(err, ErrAllRetriesFailed), "expected ErrAllRetriesFailed")
+	test.Assert(t, fc.Since(started) > time.Second, "expected backoff between retries")
+
+	// Authorization failures are not retried.
+	fpc, err = NewFastlyPurgeClient("wrong-key", false, 3, time.Second, blog.NewMock())
+	test.AssertNotError(t, err, "creating Fastly client")
+	fpc.clk = fc
+	fs.responseCode = http.StatusOK
+	started = fc.Now()
+	err = fpc.Purge([]string{fs.URL + "/abc"})
+	test.AssertError(t, err, "expected purge to fail")
+	test.AssertErrorIs(t, err, errFatal)
+	test.AssertEquals(t, fc.Since(started), time.Duration(0))
+
+	_, err = NewFastlyPurgeClient("", false, 3, time.Second, blog.NewMock())
+	test.AssertError(t, err, "expected empty API key to be rejected")
+}
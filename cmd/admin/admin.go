@@ -15,6 +15,8 @@ import (
 	rapb "github.com/letsencrypt/boulder/ra/proto"
 	"github.com/letsencrypt/boulder/sa"
 	sapb "github.com/letsencrypt/boulder/sa/proto"
+	vapb "github.com/letsencrypt/boulder/va/proto"
+	"github.com/letsencrypt/boulder/web"
 )
 
 // admin holds all of the external connections necessary to perform admin
@@ -23,6 +25,10 @@ type admin struct {
 	rac   rapb.RegistrationAuthorityClient
 	sac   sapb.StorageAuthorityClient
 	saroc sapb.StorageAuthorityReadOnlyClient
+	// vac is nil if no VA is configured.
+	vac vapb.HTTPResponseArchiveClient
+	// logHasher is nil if log identifier hashing isn't configured.
+	logHasher *web.IdentifierHasher
 	// TODO: Remove this and only use sac and saroc to interact with the db.
 	// We cannot have true dry-run safety as long as we have a direct dbMap.
 	dbMap *db.WrappedMap
@@ -80,19 +86,42 @@ func newAdmin(configFile string, dryRun bool) (*admin, error) {
 		sac = sapb.NewStorageAuthorityClient(saConn)
 	}
 
+	var vac vapb.HTTPResponseArchiveClient
+	if c.Admin.VAService != nil {
+		vaConn, err := bgrpc.ClientSetup(c.Admin.VAService, tlsConfig, scope, clk)
+		if err != nil {
+			return nil, fmt.Errorf("creating VA gRPC client: %w", err)
+		}
+		vac = vapb.NewHTTPResponseArchiveClient(vaConn)
+	}
+
+	var logHasher *web.IdentifierHasher
+	if c.Admin.LogIdentifierHashing != nil {
+		secret, err := c.Admin.LogIdentifierHashing.Secret.Load()
+		if err != nil {
+			return nil, fmt.Errorf("loading log identifier hashing secret: %w", err)
+		}
+		logHasher, err = web.NewIdentifierHasher(secret, c.Admin.LogIdentifierHashing.RotationPeriod.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("creating log identifier hasher: %w", err)
+		}
+	}
+
 	dbMap, err := sa.InitWrappedDb(c.Admin.DB, nil, logger)
 	if err != nil {
 		return nil, fmt.Errorf("creating database connection: %w", err)
 	}
 
 	return &admin{
-		rac:    rac,
-		sac:    sac,
-		saroc:  saroc,
-		dbMap:  dbMap,
-		dryRun: dryRun,
-		clk:    clk,
-		log:    logger,
+		rac:       rac,
+		sac:       sac,
+		saroc:     saroc,
+		vac:       vac,
+		logHasher: logHasher,
+		dbMap:     dbMap,
+		dryRun:    dryRun,
+		clk:       clk,
+		log:       logger,
 	}, nil
 }
 
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"io"
 
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/encoding/prototext"
@@ -26,6 +27,24 @@ func (d dryRunRAC) AdministrativelyRevokeCertificate(_ context.Context, req *rap
 	return &emptypb.Empty{}, nil
 }
 
+func (d dryRunRAC) ReportKeyCompromise(_ context.Context, req *rapb.ReportKeyCompromiseRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[rapb.KeyCompromiseProgress], error) {
+	b, err := prototext.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	d.log.Infof("dry-run: %#v", string(b))
+	return dryRunStream[rapb.KeyCompromiseProgress]{}, nil
+}
+
+// dryRunStream is a server stream which ends without returning any results.
+type dryRunStream[T any] struct {
+	grpc.ClientStream
+}
+
+func (dryRunStream[T]) Recv() (*T, error) {
+	return nil, io.EOF
+}
+
 type dryRunSAC struct {
 	sapb.StorageAuthorityClient
 	log blog.Logger
@@ -35,3 +54,14 @@ func (d dryRunSAC) AddBlockedKey(_ context.Context, req *sapb.AddBlockedKeyReque
 	d.log.Infof("dry-run: Block SPKI hash %x by %s %s", req.KeyHash, req.Comment, req.Source)
 	return &emptypb.Empty{}, nil
 }
+
+func (d dryRunSAC) AddRateLimitOverride(_ context.Context, req *sapb.AddRateLimitOverrideRequest, _ ...grpc.CallOption) (*sapb.AddRateLimitOverrideResponse, error) {
+	d.log.Infof("dry-run: Add rate limit override for limit %d bucket %q: %d per %s, %q",
+		req.Override.LimitEnum, req.Override.BucketKey, req.Override.Count, req.Override.Period.AsDuration(), req.Override.Comment)
+	return &sapb.AddRateLimitOverrideResponse{Inserted: true, Enabled: true}, nil
+}
+
+func (d dryRunSAC) ReviewExemptionRequest(_ context.Context, req *sapb.ReviewExemptionRequestRequest, _ ...grpc.CallOption) (*sapb.ExemptionRequest, error) {
+	d.log.Infof("dry-run: Mark exemption request %d %s by %s", req.Id, req.Status, req.Reviewer)
+	return &sapb.ExemptionRequest{Id: req.Id, Status: req.Status, Reviewer: req.Reviewer, ReviewComment: req.Comment}, nil
+}
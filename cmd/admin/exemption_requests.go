@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/letsencrypt/boulder/ratelimits"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+)
+
+// subcommandListExemptionRequests encapsulates the "admin
+// list-exemption-requests" command.
+type subcommandListExemptionRequests struct {
+	status    string
+	accountID int64
+	limit     int64
+}
+
+var _ subcommand = (*subcommandListExemptionRequests)(nil)
+
+func (s *subcommandListExemptionRequests) Desc() string {
+	return "List rate limit exemption requests, oldest first"
+}
+
+func (s *subcommandListExemptionRequests) Flags(flag *flag.FlagSet) {
+	flag.StringVar(&s.status, "status", "pending", "The status of the requests to list: pending, approved, or rejected")
+	flag.Int64Var(&s.accountID, "account", 0, "If set, only list this account's requests")
+	flag.Int64Var(&s.limit, "limit", 100, "The most requests to list")
+}
+
+func (s *subcommandListExemptionRequests) Run(ctx context.Context, a *admin) error {
+	if s.limit <= 0 {
+		return errors.New("-limit must be positive")
+	}
+	resp, err := a.saroc.GetExemptionRequests(ctx, &sapb.GetExemptionRequestsRequest{
+		Status:         s.status,
+		RegistrationID: s.accountID,
+		Limit:          s.limit,
+	})
+	if err != nil {
+		return fmt.Errorf("getting exemption requests: %w", err)
+	}
+	return writeExemptionRequests(os.Stdout, resp.Requests)
+}
+
+// writeExemptionRequests writes one tab-separated line to w for each request:
+// its ID, account, limit, bucket key, requested count and period, creation
+// time, and justification.
+func writeExemptionRequests(w io.Writer, requests []*sapb.ExemptionRequest) error {
+	for _, er := range requests {
+		// Justifications are written by subscribers, so they mustn't be able
+		// to break up the output.
+		justification := strings.Join(strings.Fields(er.Justification), " ")
+		_, err := fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%d/%s\t%s\t%s\n",
+			er.Id,
+			er.RegistrationID,
+			ratelimits.Name(er.Override.LimitEnum),
+			er.Override.BucketKey,
+			er.Override.Count,
+			er.Override.Period.AsDuration(),
+			er.Created.AsTime().Format(time.RFC3339),
+			justification,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// subcommandReviewExemptionRequest encapsulates the "admin
+// review-exemption-request" command.
+type subcommandReviewExemptionRequest struct {
+	id       int64
+	approve  bool
+	reject   bool
+	reviewer string
+	comment  string
+}
+
+var _ subcommand = (*subcommandReviewExemptionRequest)(nil)
+
+func (s *subcommandReviewExemptionRequest) Desc() string {
+	return "Approve or reject a rate limit exemption request, creating its override if approved"
+}
+
+func (s *subcommandReviewExemptionRequest) Flags(flag *flag.FlagSet) {
+	flag.Int64Var(&s.id, "id", 0, "The ID of the exemption request to review")
+	flag.BoolVar(&s.approve, "approve", false, "Approve the request and create the override it asks for")
+	flag.BoolVar(&s.reject, "reject", false, "Reject the request")
+	flag.StringVar(&s.reviewer, "reviewer", "", "The name of the person reviewing the request")
+	flag.StringVar(&s.comment, "comment", "", "An optional note on the decision")
+}
+
+func (s *subcommandReviewExemptionRequest) Run(ctx context.Context, a *admin) error {
+	if s.id == 0 {
+		return errors.New("-id is required")
+	}
+	if s.reviewer == "" {
+		return errors.New("-reviewer is required")
+	}
+	setInputs := map[string]bool{
+		"-approve": s.approve,
+		"-reject":  s.reject,
+	}
+	activeFlag, err := findActiveInputMethodFlag(setInputs)
+	if err != nil {
+		return err
+	}
+
+	er, err := a.saroc.GetExemptionRequest(ctx, &sapb.ExemptionRequestID{Id: s.id})
+	if err != nil {
+		return fmt.Errorf("getting exemption request %d: %w", s.id, err)
+	}
+	if er.Status != "pending" {
+		return fmt.Errorf("exemption request %d has already been %s", s.id, er.Status)
+	}
+
+	status := "rejected"
+	if activeFlag == "-approve" {
+		status = "approved"
+		// The override is added before the request is marked approved, so
+		// that if adding it fails the request can simply be reviewed again.
+		override := er.Override
+		override.Comment = fmt.Sprintf("Exemption request %d approved by %s", er.Id, s.reviewer)
+		resp, err := a.sac.AddRateLimitOverride(ctx, &sapb.AddRateLimitOverrideRequest{Override: override})
+		if err != nil {
+			return fmt.Errorf("adding rate limit override for exemption request %d: %w", s.id, err)
+		}
+		if !resp.Enabled {
+			a.log.Warningf("The override for exemption request %d already existed and is disabled", s.id)
+		}
+	}
+
+	_, err = a.sac.ReviewExemptionRequest(ctx, &sapb.ReviewExemptionRequestRequest{
+		Id:       s.id,
+		Status:   status,
+		Reviewer: s.reviewer,
+		Comment:  s.comment,
+	})
+	if err != nil {
+		return fmt.Errorf("reviewing exemption request %d: %w", s.id, err)
+	}
+	a.log.AuditInfof("Exemption request %d from account %d for %s bucket %q %s by %s",
+		s.id, er.RegistrationID, ratelimits.Name(er.Override.LimitEnum), er.Override.BucketKey, status, s.reviewer)
+	return nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/ratelimits"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+	"github.com/letsencrypt/boulder/test"
+	isa "github.com/letsencrypt/boulder/test/inmem/sa"
+)
+
+func TestWriteExemptionRequests(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	err := writeExemptionRequests(&buf, []*sapb.ExemptionRequest{{
+		Id:             7,
+		RegistrationID: 1234,
+		Override: &sapb.RateLimitOverride{
+			LimitEnum: int64(ratelimits.CertificatesPerDomain),
+			BucketKey: "5:example.com",
+			Count:     100,
+			Period:    durationpb.New(24 * time.Hour),
+		},
+		Justification: "hosting\nprovider\t",
+		Created:       timestamppb.New(time.Date(2025, 3, 4, 5, 0, 0, 0, time.UTC)),
+	}})
+	test.AssertNotError(t, err, "writing exemption requests")
+	test.AssertEquals(t, buf.String(), "7\t1234\tCertificatesPerDomain\t5:example.com\t100/24h0m0s\t2025-03-04T05:00:00Z\thosting provider\n")
+}
+
+func TestReviewExemptionRequest(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	sa := isa.SA{Impl: isa.NewMemoryStorageAuthority(clock.NewFake())}
+	a := &admin{sac: sa, saroc: sa, log: blog.NewMock()}
+	add := func() int64 {
+		er, err := sa.AddExemptionRequest(ctx, &sapb.ExemptionRequest{
+			RegistrationID: 1234,
+			Override: &sapb.RateLimitOverride{
+				LimitEnum: int64(ratelimits.NewOrdersPerAccount),
+				BucketKey: "3:1234",
+				Count:     100,
+				Burst:     100,
+				Period:    durationpb.New(time.Hour),
+			},
+			Justification: "hosting provider",
+		})
+		test.AssertNotError(t, err, "AddExemptionRequest failed")
+		return er.Id
+	}
+
+	approved := add()
+	err := (&subcommandReviewExemptionRequest{id: approved, reviewer: "admin"}).Run(ctx, a)
+	test.AssertError(t, err, "neither -approve nor -reject accepted")
+	err = (&subcommandReviewExemptionRequest{id: approved, approve: true, reject: true, reviewer: "admin"}).Run(ctx, a)
+	test.AssertError(t, err, "both -approve and -reject accepted")
+	err = (&subcommandReviewExemptionRequest{id: approved, approve: true}).Run(ctx, a)
+	test.AssertError(t, err, "missing -reviewer accepted")
+
+	err = (&subcommandReviewExemptionRequest{id: approved, approve: true, reviewer: "admin"}).Run(ctx, a)
+	test.AssertNotError(t, err, "approving request")
+	er, err := sa.GetExemptionRequest(ctx, &sapb.ExemptionRequestID{Id: approved})
+	test.AssertNotError(t, err, "GetExemptionRequest failed")
+	test.AssertEquals(t, er.Status, "approved")
+	test.AssertEquals(t, er.Reviewer, "admin")
+	override, err := sa.GetRateLimitOverride(ctx, &sapb.GetRateLimitOverrideRequest{
+		LimitEnum: int64(ratelimits.NewOrdersPerAccount),
+		BucketKey: "3:1234",
+	})
+	test.AssertNotError(t, err, "override wasn't created")
+	test.AssertEquals(t, override.Override.Count, int64(100))
+	test.AssertContains(t, override.Override.Comment, "approved by admin")
+
+	err = (&subcommandReviewExemptionRequest{id: approved, reject: true, reviewer: "admin"}).Run(ctx, a)
+	test.AssertError(t, err, "reviewed request reviewed again")
+
+	rejected := add()
+	err = (&subcommandReviewExemptionRequest{id: rejected, reject: true, reviewer: "admin", comment: "no"}).Run(ctx, a)
+	test.AssertNotError(t, err, "rejecting request")
+	er, err = sa.GetExemptionRequest(ctx, &sapb.ExemptionRequestID{Id: rejected})
+	test.AssertNotError(t, err, "GetExemptionRequest failed")
+	test.AssertEquals(t, er.Status, "rejected")
+	test.AssertEquals(t, er.ReviewComment, "no")
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/letsencrypt/boulder/identifier"
+	"github.com/letsencrypt/boulder/web"
+)
+
+// subcommandHashLogIdentifiers encapsulates the "admin hash-log-identifiers"
+// command.
+type subcommandHashLogIdentifiers struct {
+	dnsName   string
+	ipAddress string
+	accountID int64
+	start     string
+	end       string
+}
+
+var _ subcommand = (*subcommandHashLogIdentifiers)(nil)
+
+func (s *subcommandHashLogIdentifiers) Desc() string {
+	return "Print the hashes which replace an identifier or account ID in the WFE's request logs over a range of dates"
+}
+
+func (s *subcommandHashLogIdentifiers) Flags(flag *flag.FlagSet) {
+	flag.StringVar(&s.dnsName, "dns", "", "A DNS name whose hashes to print")
+	flag.StringVar(&s.ipAddress, "ip", "", "An IP address whose hashes to print")
+	flag.Int64Var(&s.accountID, "account", 0, "An account ID whose hashes to print")
+	flag.StringVar(&s.start, "start", "", "The first date (YYYY-MM-DD, UTC) of the logs to be searched (default: today)")
+	flag.StringVar(&s.end, "end", "", "The last date (YYYY-MM-DD, UTC) of the logs to be searched (default: the start date)")
+}
+
+func (s *subcommandHashLogIdentifiers) Run(ctx context.Context, a *admin) error {
+	if a.logHasher == nil {
+		return errors.New("no logIdentifierHashing is configured")
+	}
+
+	setInputs := map[string]bool{
+		"-dns":     s.dnsName != "",
+		"-ip":      s.ipAddress != "",
+		"-account": s.accountID != 0,
+	}
+	activeFlag, err := findActiveInputMethodFlag(setInputs)
+	if err != nil {
+		return err
+	}
+
+	var hash func(time.Time) string
+	switch activeFlag {
+	case "-dns":
+		ident := identifier.NewDNS(strings.ToLower(s.dnsName))
+		hash = func(at time.Time) string { return a.logHasher.HashIdentifier(ident, at) }
+	case "-ip":
+		ip, err := netip.ParseAddr(s.ipAddress)
+		if err != nil {
+			return fmt.Errorf("parsing IP address: %w", err)
+		}
+		ident := identifier.NewIP(ip)
+		hash = func(at time.Time) string { return a.logHasher.HashIdentifier(ident, at) }
+	case "-account":
+		hash = func(at time.Time) string { return a.logHasher.HashAccount(s.accountID, at) }
+	}
+
+	start := a.clk.Now().UTC().Truncate(24 * time.Hour)
+	if s.start != "" {
+		start, err = time.Parse(time.DateOnly, s.start)
+		if err != nil {
+			return fmt.Errorf("parsing -start: %w", err)
+		}
+	}
+	end := start
+	if s.end != "" {
+		end, err = time.Parse(time.DateOnly, s.end)
+		if err != nil {
+			return fmt.Errorf("parsing -end: %w", err)
+		}
+	}
+	if end.Before(start) {
+		return errors.New("-end must not be before -start")
+	}
+
+	a.log.AuditInfof("Computing log hashes of %s from %s to %s", activeFlag, start.Format(time.DateOnly), end.Format(time.DateOnly))
+	return writeLogHashes(os.Stdout, a.logHasher, hash, start, end.Add(24*time.Hour))
+}
+
+// writeLogHashes writes the ID of each key in use between start (inclusive)
+// and end (exclusive) to w, followed by the hash which that key produces.
+func writeLogHashes(w io.Writer, hasher *web.IdentifierHasher, hash func(time.Time) string, start, end time.Time) error {
+	var lastKeyID string
+	for at := start; at.Before(end); at = at.Add(time.Hour) {
+		keyID := hasher.KeyID(at)
+		if keyID == lastKeyID {
+			continue
+		}
+		lastKeyID = keyID
+		_, err := fmt.Fprintf(w, "%s\t%s\n", keyID, hash(at))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
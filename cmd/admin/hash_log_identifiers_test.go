@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+
+	"github.com/letsencrypt/boulder/identifier"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/test"
+	"github.com/letsencrypt/boulder/web"
+)
+
+func TestWriteLogHashes(t *testing.T) {
+	t.Parallel()
+
+	hasher, err := web.NewIdentifierHasher([]byte("secret"), 24*time.Hour)
+	test.AssertNotError(t, err, "NewIdentifierHasher failed")
+	ident := identifier.NewDNS("example.com")
+	hash := func(at time.Time) string { return hasher.HashIdentifier(ident, at) }
+
+	start := time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	err = writeLogHashes(&buf, hasher, hash, start, start.Add(48*time.Hour))
+	test.AssertNotError(t, err, "writing hashes")
+	test.AssertEquals(t, buf.String(), fmt.Sprintf("20250304T000000Z\t%s\n20250305T000000Z\t%s\n",
+		hash(start), hash(start.Add(24*time.Hour))))
+}
+
+func TestHashLogIdentifiersRun(t *testing.T) {
+	t.Parallel()
+
+	a := &admin{log: blog.NewMock(), clk: clock.NewFake()}
+	err := (&subcommandHashLogIdentifiers{dnsName: "example.com"}).Run(context.Background(), a)
+	test.AssertError(t, err, "missing logIdentifierHashing accepted")
+
+	a.logHasher, err = web.NewIdentifierHasher([]byte("secret"), 24*time.Hour)
+	test.AssertNotError(t, err, "NewIdentifierHasher failed")
+
+	err = (&subcommandHashLogIdentifiers{}).Run(context.Background(), a)
+	test.AssertError(t, err, "no input accepted")
+	err = (&subcommandHashLogIdentifiers{dnsName: "example.com", accountID: 1}).Run(context.Background(), a)
+	test.AssertError(t, err, "multiple inputs accepted")
+	err = (&subcommandHashLogIdentifiers{ipAddress: "example.com"}).Run(context.Background(), a)
+	test.AssertError(t, err, "invalid IP accepted")
+	err = (&subcommandHashLogIdentifiers{accountID: 1, start: "2025-03-05", end: "2025-03-04"}).Run(context.Background(), a)
+	test.AssertError(t, err, "end before start accepted")
+	err = (&subcommandHashLogIdentifiers{accountID: 1, start: "2025-03-04", end: "2025-03-05"}).Run(context.Background(), a)
+	test.AssertNotError(t, err, "valid range rejected")
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os/user"
+
+	"github.com/letsencrypt/boulder/privatekey"
+	rapb "github.com/letsencrypt/boulder/ra/proto"
+	"github.com/letsencrypt/boulder/revocation"
+)
+
+// subcommandKeyCompromise encapsulates the "admin key-compromise" command.
+type subcommandKeyCompromise struct {
+	comment string
+
+	privKey  string
+	spkiHash string
+}
+
+var _ subcommand = (*subcommandKeyCompromise)(nil)
+
+func (s *subcommandKeyCompromise) Desc() string {
+	return "Block a compromised keypair and immediately revoke all certificates with it"
+}
+
+func (s *subcommandKeyCompromise) Flags(flag *flag.FlagSet) {
+	flag.StringVar(&s.comment, "comment", "", "Additional context to add to database comment column")
+
+	// Flags specifying the input method for the compromised key.
+	flag.StringVar(&s.privKey, "private-key", "", "Report the compromise of this private key, proving possession of it")
+	flag.StringVar(&s.spkiHash, "spki-hash", "", "Report the compromise of the key with this SHA256 hash of SPKI, hex encoded, without proving possession of it")
+}
+
+func (s *subcommandKeyCompromise) Run(ctx context.Context, a *admin) error {
+	setInputs := map[string]bool{
+		"-private-key": s.privKey != "",
+		"-spki-hash":   s.spkiHash != "",
+	}
+	activeFlag, err := findActiveInputMethodFlag(setInputs)
+	if err != nil {
+		return err
+	}
+
+	u, err := user.Current()
+	if err != nil {
+		return fmt.Errorf("getting admin username: %w", err)
+	}
+
+	req := &rapb.ReportKeyCompromiseRequest{
+		AdminName: u.Username,
+		Comment:   s.comment,
+	}
+	switch activeFlag {
+	case "-private-key":
+		req.Spki, req.Signature, err = a.signKeyCompromise(s.privKey)
+	case "-spki-hash":
+		req.KeyHash, err = hex.DecodeString(s.spkiHash)
+		if err == nil && len(req.KeyHash) != 32 {
+			err = fmt.Errorf("got spki hash of unexpected length: %q (%d)", s.spkiHash, len(req.KeyHash))
+		}
+	default:
+		return errors.New("no recognized input method flag set (this shouldn't happen)")
+	}
+	if err != nil {
+		return fmt.Errorf("collecting compromised key: %w", err)
+	}
+
+	return a.reportKeyCompromise(ctx, req)
+}
+
+// signKeyCompromise loads the private key in keyFile and returns its SPKI and
+// a signature proving possession of it.
+func (a *admin) signKeyCompromise(keyFile string) ([]byte, []byte, error) {
+	signer, _, err := privatekey.Load(keyFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading private key file: %w", err)
+	}
+	return revocation.SignKeyCompromise(signer)
+}
+
+// reportKeyCompromise sends req to the RA and logs the progress it reports,
+// returning an error if any certificate couldn't be revoked.
+func (a *admin) reportKeyCompromise(ctx context.Context, req *rapb.ReportKeyCompromiseRequest) error {
+	stream, err := a.rac.ReportKeyCompromise(ctx, req)
+	if err != nil {
+		return fmt.Errorf("reporting key compromise to RA: %w", err)
+	}
+
+	var revoked, alreadyRevoked, failed int
+	for {
+		progress, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return fmt.Errorf("streaming key compromise progress from RA: %w", err)
+		}
+
+		if progress.Serial == "" {
+			if progress.AlreadyBlocked {
+				a.log.Infof("Key %x was already blocked", progress.KeyHash)
+			} else {
+				a.log.Infof("Blocked key %x (possession verified: %t)", progress.KeyHash, progress.PossessionVerified)
+			}
+			continue
+		}
+
+		switch progress.Result {
+		case "revoked":
+			revoked++
+			a.log.Infof("Revoked %s", progress.Serial)
+		case "alreadyRevoked":
+			alreadyRevoked++
+			a.log.Infof("Already revoked %s", progress.Serial)
+		default:
+			failed++
+			a.log.Errf("Failed to revoke %s: %s", progress.Serial, progress.Error)
+		}
+	}
+
+	a.log.Infof("Revoked %d certificates, %d were already revoked, %d failed", revoked, alreadyRevoked, failed)
+	if failed > 0 {
+		return fmt.Errorf("failed to revoke %d certificates; see logs above for details, or leave them to bad-key-revoker", failed)
+	}
+
+	return nil
+}
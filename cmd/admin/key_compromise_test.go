@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/letsencrypt/boulder/core"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/mocks"
+	rapb "github.com/letsencrypt/boulder/ra/proto"
+	"github.com/letsencrypt/boulder/revocation"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// mockRAKeyCompromise is a mock which only implements the ReportKeyCompromise
+// gRPC method. It records the request it received and streams back the
+// progress it was initialized with.
+type mockRAKeyCompromise struct {
+	rapb.RegistrationAuthorityClient
+	progress []*rapb.KeyCompromiseProgress
+	req      *rapb.ReportKeyCompromiseRequest
+}
+
+func (mra *mockRAKeyCompromise) ReportKeyCompromise(_ context.Context, req *rapb.ReportKeyCompromiseRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[rapb.KeyCompromiseProgress], error) {
+	mra.req = req
+	return &mocks.ServerStreamClient[rapb.KeyCompromiseProgress]{Results: mra.progress}, nil
+}
+
+func TestSignKeyCompromise(t *testing.T) {
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "creating test private key")
+	keyHash, err := core.KeyDigest(privKey.Public())
+	test.AssertNotError(t, err, "computing test SPKI hash")
+
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(privKey)
+	test.AssertNotError(t, err, "marshalling test private key bytes")
+	keyFile := path.Join(t.TempDir(), "key.pem")
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes})
+	err = os.WriteFile(keyFile, keyPEM, 0600)
+	test.AssertNotError(t, err, "writing test private key file")
+
+	a := admin{}
+
+	spki, sig, err := a.signKeyCompromise(keyFile)
+	test.AssertNotError(t, err, "signing key compromise message")
+	verifiedHash, err := revocation.VerifyKeyCompromise(spki, sig)
+	test.AssertNotError(t, err, "verifying key compromise signature")
+	test.AssertByteEquals(t, verifiedHash, keyHash[:])
+}
+
+func TestReportKeyCompromise(t *testing.T) {
+	keyHash := make([]byte, 32)
+	log := blog.NewMock()
+	mra := &mockRAKeyCompromise{progress: []*rapb.KeyCompromiseProgress{
+		{KeyHash: keyHash, PossessionVerified: true},
+		{Serial: "foo", Result: "revoked"},
+		{Serial: "bar", Result: "alreadyRevoked"},
+	}}
+	a := admin{rac: mra, log: log}
+
+	req := &rapb.ReportKeyCompromiseRequest{AdminName: "root", KeyHash: keyHash}
+	err := a.reportKeyCompromise(context.Background(), req)
+	test.AssertNotError(t, err, "reporting key compromise")
+	test.AssertEquals(t, mra.req, req)
+	test.AssertEquals(t, len(log.GetAllMatching("Blocked key 0000")), 1)
+	test.AssertEquals(t, len(log.GetAllMatching("Revoked foo")), 1)
+	test.AssertEquals(t, len(log.GetAllMatching("Already revoked bar")), 1)
+	test.AssertEquals(t, len(log.GetAllMatching("Revoked 1 certificates, 1 were already revoked, 0 failed")), 1)
+
+	// A certificate which the RA couldn't revoke fails the command, after the
+	// rest of the progress has been logged.
+	log.Clear()
+	mra.progress = []*rapb.KeyCompromiseProgress{
+		{KeyHash: keyHash, AlreadyBlocked: true},
+		{Serial: "foo", Result: "failed", Error: "oops"},
+		{Serial: "bar", Result: "revoked"},
+	}
+	err = a.reportKeyCompromise(context.Background(), req)
+	test.AssertError(t, err, "reporting key compromise should have failed")
+	test.AssertContains(t, err.Error(), "failed to revoke 1 certificates")
+	test.AssertEquals(t, len(log.GetAllMatching("was already blocked")), 1)
+	test.AssertEquals(t, len(log.GetAllMatching("Failed to revoke foo: oops")), 1)
+	test.AssertEquals(t, len(log.GetAllMatching("Revoked bar")), 1)
+
+	// In dry-run mode nothing is reported back, and nothing fails.
+	log.Clear()
+	a.rac = dryRunRAC{log: log}
+	err = a.reportKeyCompromise(context.Background(), req)
+	test.AssertNotError(t, err, "reporting key compromise in dry-run mode")
+	test.AssertEquals(t, len(log.GetAllMatching("dry-run:")), 1)
+	test.AssertEquals(t, len(log.GetAllMatching("Revoked 0 certificates")), 1)
+}
@@ -30,6 +30,13 @@ type Config struct {
 
 		RAService *cmd.GRPCClientConfig
 		SAService *cmd.GRPCClientConfig
+		// VAService, if set, is used to look up HTTP responses archived by
+		// the VA during failed HTTP-01 validations.
+		VAService *cmd.GRPCClientConfig
+		// LogIdentifierHashing, if set, must match the WFE's config of the same
+		// name, so that the hash-log-identifiers subcommand can compute the
+		// hashes which the WFE logged.
+		LogIdentifierHashing *cmd.IdentifierHashingConfig
 
 		Features features.Config
 	}
@@ -68,10 +75,16 @@ func main() {
 
 	// This is the registry of all subcommands that the admin tool can run.
 	subcommands := map[string]subcommand{
-		"revoke-cert":      &subcommandRevokeCert{},
-		"block-key":        &subcommandBlockKey{},
-		"pause-identifier": &subcommandPauseIdentifier{},
-		"unpause-account":  &subcommandUnpauseAccount{},
+		"revoke-cert":              &subcommandRevokeCert{},
+		"block-key":                &subcommandBlockKey{},
+		"key-compromise":           &subcommandKeyCompromise{},
+		"pause-identifier":         &subcommandPauseIdentifier{},
+		"unpause-account":          &subcommandUnpauseAccount{},
+		"validation-responses":     &subcommandValidationResponses{},
+		"hash-log-identifiers":     &subcommandHashLogIdentifiers{},
+		"list-exemption-requests":  &subcommandListExemptionRequests{},
+		"review-exemption-request": &subcommandReviewExemptionRequest{},
+		"search-certs":             &subcommandSearchCerts{},
 	}
 
 	defaultUsage := flag.Usage
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/letsencrypt/boulder/revocation"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+)
+
+// revocationStatusBatchSize is the number of serials whose revocation status
+// is requested from the SA at once. It matches the most the SA will accept.
+const revocationStatusBatchSize = 1000
+
+// subcommandSearchCerts encapsulates the "admin search-certs" command.
+type subcommandSearchCerts struct {
+	san          string
+	spkiHash     string
+	serialPrefix string
+	limit        int64
+}
+
+var _ subcommand = (*subcommandSearchCerts)(nil)
+
+func (s *subcommandSearchCerts) Desc() string {
+	return "Find certificates, including expired ones, by SAN, SPKI hash, or serial prefix"
+}
+
+func (s *subcommandSearchCerts) Flags(flag *flag.FlagSet) {
+	flag.StringVar(&s.san, "san", "", "Find certificates containing exactly this DNS name or IP address")
+	flag.StringVar(&s.spkiHash, "spki-hash", "", "Find certificates whose public key has this hex-encoded SHA256 hash of SPKI")
+	flag.StringVar(&s.serialPrefix, "serial-prefix", "", "Find certificates whose hex serial begins with this prefix")
+	flag.Int64Var(&s.limit, "limit", 100, "The most certificates to list")
+}
+
+func (s *subcommandSearchCerts) Run(ctx context.Context, a *admin) error {
+	if s.limit <= 0 {
+		return errors.New("-limit must be positive")
+	}
+	setInputs := map[string]bool{
+		"-san":           s.san != "",
+		"-spki-hash":     s.spkiHash != "",
+		"-serial-prefix": s.serialPrefix != "",
+	}
+	activeFlag, err := findActiveInputMethodFlag(setInputs)
+	if err != nil {
+		return err
+	}
+
+	req := &sapb.SearchCertificatesRequest{Limit: s.limit}
+	switch activeFlag {
+	case "-san":
+		req.San = strings.ToLower(s.san)
+	case "-spki-hash":
+		keyHash, err := hex.DecodeString(s.spkiHash)
+		if err != nil {
+			return fmt.Errorf("decoding hex spki hash %q: %w", s.spkiHash, err)
+		}
+		if len(keyHash) != sha256.Size {
+			return fmt.Errorf("spki hash %q is not a SHA256 hash", s.spkiHash)
+		}
+		req.KeyHash = keyHash
+	case "-serial-prefix":
+		// Accept serials as they're commonly displayed, e.g. "03:A1:...".
+		req.SerialPrefix = strings.ToLower(strings.ReplaceAll(s.serialPrefix, ":", ""))
+	default:
+		return errors.New("no recognized input method flag set (this shouldn't happen)")
+	}
+
+	stream, err := a.saroc.SearchCertificates(ctx, req)
+	if err != nil {
+		return fmt.Errorf("setting up stream of certificates from SA: %w", err)
+	}
+	var certs []*sapb.SerialMetadata
+	var serials []string
+	for {
+		cert, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return fmt.Errorf("streaming certificates from SA: %w", err)
+		}
+		certs = append(certs, cert)
+		serials = append(serials, cert.Serial)
+	}
+	if len(certs) == 0 {
+		a.log.Infof("No certificates found")
+		return nil
+	}
+
+	statuses := make(map[string]*sapb.RevocationStatus, len(serials))
+	for start := 0; start < len(serials); start += revocationStatusBatchSize {
+		end := min(start+revocationStatusBatchSize, len(serials))
+		resp, err := a.saroc.GetRevocationStatuses(ctx, &sapb.Serials{Serials: serials[start:end]})
+		if err != nil {
+			return fmt.Errorf("getting revocation statuses: %w", err)
+		}
+		for serial, status := range resp.Statuses {
+			statuses[serial] = status
+		}
+	}
+	return writeCertificateSearchResults(os.Stdout, certs, statuses)
+}
+
+// writeCertificateSearchResults writes one tab-separated line to w for each
+// certificate: its serial, account, issuance time, expiry, and revocation
+// status, followed by the revocation reason and time, or "-" for each if the
+// certificate isn't revoked.
+func writeCertificateSearchResults(w io.Writer, certs []*sapb.SerialMetadata, statuses map[string]*sapb.RevocationStatus) error {
+	for _, cert := range certs {
+		state, reason, revoked := "unknown", "-", "-"
+		status, ok := statuses[cert.Serial]
+		if ok {
+			switch status.Status {
+			case int64(ocsp.Good):
+				state = "good"
+			case int64(ocsp.Revoked):
+				state = "revoked"
+				reason = revocation.ReasonToString[revocation.Reason(status.RevokedReason)]
+				revoked = status.RevokedDate.AsTime().Format(time.RFC3339)
+			}
+		}
+		_, err := fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%s\n",
+			cert.Serial,
+			cert.RegistrationID,
+			cert.Created.AsTime().Format(time.RFC3339),
+			cert.Expires.AsTime().Format(time.RFC3339),
+			state,
+			reason,
+			revoked,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
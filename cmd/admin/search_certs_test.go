@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"golang.org/x/crypto/ocsp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	blog "github.com/letsencrypt/boulder/log"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+	"github.com/letsencrypt/boulder/test"
+	isa "github.com/letsencrypt/boulder/test/inmem/sa"
+)
+
+func TestWriteCertificateSearchResults(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2025, 3, 4, 5, 0, 0, 0, time.UTC)
+	cert := func(serial string) *sapb.SerialMetadata {
+		return &sapb.SerialMetadata{
+			Serial:         serial,
+			RegistrationID: 1234,
+			Created:        timestamppb.New(created),
+			Expires:        timestamppb.New(created.Add(90 * 24 * time.Hour)),
+		}
+	}
+
+	var buf bytes.Buffer
+	err := writeCertificateSearchResults(&buf,
+		[]*sapb.SerialMetadata{cert("good"), cert("revoked"), cert("missing")},
+		map[string]*sapb.RevocationStatus{
+			"good": {Status: int64(ocsp.Good)},
+			"revoked": {
+				Status:        int64(ocsp.Revoked),
+				RevokedReason: int64(ocsp.KeyCompromise),
+				RevokedDate:   timestamppb.New(created.Add(time.Hour)),
+			},
+		})
+	test.AssertNotError(t, err, "writing search results")
+	test.AssertEquals(t, buf.String(),
+		"good\t1234\t2025-03-04T05:00:00Z\t2025-06-02T05:00:00Z\tgood\t-\t-\n"+
+			"revoked\t1234\t2025-03-04T05:00:00Z\t2025-06-02T05:00:00Z\trevoked\tkeyCompromise\t2025-03-04T06:00:00Z\n"+
+			"missing\t1234\t2025-03-04T05:00:00Z\t2025-06-02T05:00:00Z\tunknown\t-\t-\n")
+}
+
+func TestSearchCerts(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	sa := isa.SA{Impl: isa.NewMemoryStorageAuthority(clock.NewFake())}
+	a := &admin{sac: sa, saroc: sa, log: blog.NewMock()}
+
+	err := (&subcommandSearchCerts{limit: 10}).Run(ctx, a)
+	test.AssertError(t, err, "no search flag accepted")
+	err = (&subcommandSearchCerts{san: "example.com", serialPrefix: "00", limit: 10}).Run(ctx, a)
+	test.AssertError(t, err, "two search flags accepted")
+	err = (&subcommandSearchCerts{san: "example.com"}).Run(ctx, a)
+	test.AssertError(t, err, "zero limit accepted")
+	err = (&subcommandSearchCerts{spkiHash: "not hex", limit: 10}).Run(ctx, a)
+	test.AssertError(t, err, "non-hex spki hash accepted")
+	err = (&subcommandSearchCerts{spkiHash: "abcd", limit: 10}).Run(ctx, a)
+	test.AssertError(t, err, "short spki hash accepted")
+
+	err = (&subcommandSearchCerts{serialPrefix: "00:AB", limit: 10}).Run(ctx, a)
+	test.AssertNotError(t, err, "searching by colon-separated serial prefix")
+	err = (&subcommandSearchCerts{san: "Example.com", limit: 10}).Run(ctx, a)
+	test.AssertNotError(t, err, "searching by SAN")
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	vapb "github.com/letsencrypt/boulder/va/proto"
+)
+
+// subcommandValidationResponses encapsulates the "admin validation-responses"
+// command.
+type subcommandValidationResponses struct {
+	authzID string
+}
+
+var _ subcommand = (*subcommandValidationResponses)(nil)
+
+func (s *subcommandValidationResponses) Desc() string {
+	return "Print the HTTP responses archived by the VA during failed HTTP-01 validations of an authorization"
+}
+
+func (s *subcommandValidationResponses) Flags(flag *flag.FlagSet) {
+	flag.StringVar(&s.authzID, "authz", "", "The ID of the authorization whose failed validations to look up")
+}
+
+func (s *subcommandValidationResponses) Run(ctx context.Context, a *admin) error {
+	if s.authzID == "" {
+		return errors.New("the -authz flag is required")
+	}
+	if a.vac == nil {
+		return errors.New("no vaService is configured")
+	}
+
+	resp, err := a.vac.GetArchivedHTTPResponses(ctx, &vapb.GetArchivedHTTPResponsesRequest{AuthzID: s.authzID})
+	if err != nil {
+		return fmt.Errorf("getting archived HTTP responses: %w", err)
+	}
+	a.log.Infof("Found %d archived HTTP responses for authorization %q", len(resp.Responses), s.authzID)
+
+	return writeArchivedHTTPResponses(os.Stdout, resp.Responses)
+}
+
+// writeArchivedHTTPResponses writes each response to w, preceded by a summary
+// of the validation it was received during. Headers and bodies are written
+// exactly as they were archived.
+func writeArchivedHTTPResponses(w io.Writer, responses []*vapb.ArchivedHTTPResponse) error {
+	for _, r := range responses {
+		truncated := ""
+		if r.BodyTruncated {
+			truncated = " (body truncated)"
+		}
+		_, err := fmt.Fprintf(w,
+			"=== %s from %s perspective%s\nURL: %s\nAddress: %s\nProblem: %s\n\nHTTP %d\n%s\n%s\n\n",
+			r.Archived.AsTime().Format(time.RFC3339), r.Perspective, truncated,
+			r.Url, r.AddressUsed, r.Problem, r.StatusCode, r.Header, r.Body)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
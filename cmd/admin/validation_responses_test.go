@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/test"
+	vapb "github.com/letsencrypt/boulder/va/proto"
+)
+
+func TestWriteArchivedHTTPResponses(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	err := writeArchivedHTTPResponses(&buf, []*vapb.ArchivedHTTPResponse{{
+		Archived:      timestamppb.New(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)),
+		Perspective:   "Primary",
+		Url:           "http://example.com/.well-known/acme-challenge/token",
+		AddressUsed:   "10.0.0.1",
+		StatusCode:    404,
+		Header:        []byte("Content-Type: text/plain\r\n"),
+		Body:          []byte("not here"),
+		BodyTruncated: true,
+		Problem:       "Invalid response",
+	}})
+	test.AssertNotError(t, err, "writing responses")
+	test.AssertEquals(t, buf.String(), "=== 2025-01-02T03:04:05Z from Primary perspective (body truncated)\n"+
+		"URL: http://example.com/.well-known/acme-challenge/token\n"+
+		"Address: 10.0.0.1\n"+
+		"Problem: Invalid response\n\n"+
+		"HTTP 404\n"+
+		"Content-Type: text/plain\r\n\n"+
+		"not here\n\n")
+}
+
+type mockHTTPResponseArchive struct {
+	vapb.HTTPResponseArchiveClient
+	gotAuthzID string
+}
+
+func (m *mockHTTPResponseArchive) GetArchivedHTTPResponses(_ context.Context, req *vapb.GetArchivedHTTPResponsesRequest, _ ...grpc.CallOption) (*vapb.ArchivedHTTPResponses, error) {
+	m.gotAuthzID = req.AuthzID
+	return &vapb.ArchivedHTTPResponses{}, nil
+}
+
+func TestValidationResponsesRun(t *testing.T) {
+	t.Parallel()
+
+	a := &admin{log: blog.NewMock()}
+	err := (&subcommandValidationResponses{}).Run(context.Background(), a)
+	test.AssertError(t, err, "missing -authz accepted")
+	err = (&subcommandValidationResponses{authzID: "123"}).Run(context.Background(), a)
+	test.AssertError(t, err, "missing vaService accepted")
+
+	mock := &mockHTTPResponseArchive{}
+	a.vac = mock
+	err = (&subcommandValidationResponses{authzID: "123"}).Run(context.Background(), a)
+	test.AssertNotError(t, err, "looking up responses")
+	test.AssertEquals(t, mock.gotAuthzID, "123")
+}
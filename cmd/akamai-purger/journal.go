@@ -0,0 +1,221 @@
+package notmain
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// journalPush records a new entry added to the top of the purge stack.
+	journalPush = "push"
+
+	// journalTake records N entries taken from the top of the purge stack to
+	// be purged. Until they are acknowledged, taken entries are still pending,
+	// and are returned to the top of the stack if the journal is replayed.
+	journalTake = "take"
+
+	// journalAck records that the entries most recently taken from the purge
+	// stack were purged, or were requeued after failing to be purged.
+	journalAck = "ack"
+
+	// journalRequeue records an entry returned to the bottom of the purge
+	// stack after failing to be purged.
+	journalRequeue = "requeue"
+
+	// minCompactionRecords is the minimum number of records written to the
+	// journal before it is considered for compaction.
+	minCompactionRecords = 10000
+)
+
+// journalRecord is a single line of the purge journal.
+type journalRecord struct {
+	Op       string    `json:"op"`
+	URLs     []string  `json:"urls,omitempty"`
+	QueuedAt time.Time `json:"queuedAt,omitzero"`
+	N        int       `json:"n,omitempty"`
+}
+
+// purgeJournal is an append-only log of changes to the purge stack, stored in
+// a local file, from which the stack can be rebuilt after a restart. Records
+// are written to the operating system as they happen but are not fsynced, so
+// the journal survives restarts of the purger but not necessarily of the host.
+// It is not safe for concurrent use; callers must hold the akamaiPurger lock.
+type purgeJournal struct {
+	path    string
+	f       *os.File
+	records int
+}
+
+// replayJournal reads the journal at path, if it exists, and returns the purge
+// stack it describes, oldest entry first, along with the time each entry was
+// queued. Entries which were taken but never acknowledged were in flight when
+// the purger stopped, so they are returned to the top of the stack. The stack
+// is bounded by maxStackSize in the same way as akamaiPurger.Purge.
+func replayJournal(path string, maxStackSize int) ([][]string, []time.Time, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	var stack, taken [][]string
+	var queuedAt, takenQueuedAt []time.Time
+	r := bufio.NewReader(f)
+	for line := 1; ; line++ {
+		raw, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// A final line without a newline was only partially written
+			// before the purger stopped; ignore it.
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		var rec journalRecord
+		err = json.Unmarshal(raw, &rec)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing line %d of %q: %w", line, path, err)
+		}
+		switch rec.Op {
+		case journalPush:
+			if len(stack) >= maxStackSize {
+				stack, queuedAt = stack[1:], queuedAt[1:]
+			}
+			stack = append(stack, rec.URLs)
+			queuedAt = append(queuedAt, rec.QueuedAt)
+		case journalTake:
+			n := min(rec.N, len(stack))
+			taken = append(taken, stack[len(stack)-n:]...)
+			takenQueuedAt = append(takenQueuedAt, queuedAt[len(queuedAt)-n:]...)
+			stack, queuedAt = stack[:len(stack)-n], queuedAt[:len(queuedAt)-n]
+		case journalAck:
+			taken, takenQueuedAt = nil, nil
+		case journalRequeue:
+			if len(stack) >= maxStackSize {
+				continue
+			}
+			stack = append([][]string{rec.URLs}, stack...)
+			queuedAt = append([]time.Time{rec.QueuedAt}, queuedAt...)
+		default:
+			return nil, nil, fmt.Errorf("unknown operation %q on line %d of %q", rec.Op, line, path)
+		}
+	}
+	stack = append(stack, taken...)
+	queuedAt = append(queuedAt, takenQueuedAt...)
+	if len(stack) > maxStackSize {
+		drop := len(stack) - maxStackSize
+		stack, queuedAt = stack[drop:], queuedAt[drop:]
+	}
+	return stack, queuedAt, nil
+}
+
+// openJournal compacts the journal at path so that it contains exactly the
+// given stack and returns a purgeJournal for appending further changes to it.
+func openJournal(path string, stack [][]string, queuedAt []time.Time) (*purgeJournal, error) {
+	j := &purgeJournal{path: path}
+	err := j.compact(stack, queuedAt, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// compact atomically replaces the journal with one which contains a single
+// push record for each entry of the given stack and of the given batch, which
+// has been taken from the stack but not yet acknowledged, followed by a take
+// record for that batch.
+func (j *purgeJournal) compact(stack [][]string, queuedAt []time.Time, batch [][]string, batchQueuedAt []time.Time) error {
+	tmp, err := os.CreateTemp(filepath.Dir(j.path), filepath.Base(j.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for i, urls := range stack {
+		err = enc.Encode(journalRecord{Op: journalPush, URLs: urls, QueuedAt: queuedAt[i]})
+		if err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	for i, urls := range batch {
+		err = enc.Encode(journalRecord{Op: journalPush, URLs: urls, QueuedAt: batchQueuedAt[i]})
+		if err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	records := len(stack) + len(batch)
+	if len(batch) > 0 {
+		err = enc.Encode(journalRecord{Op: journalTake, N: len(batch)})
+		if err != nil {
+			tmp.Close()
+			return err
+		}
+		records++
+	}
+	err = w.Flush()
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	err = tmp.Sync()
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmp.Name(), j.path)
+	if err != nil {
+		return err
+	}
+
+	if j.f != nil {
+		j.f.Close()
+	}
+	j.f, err = os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	j.records = records
+	return nil
+}
+
+// needsCompaction returns true if the journal has grown large relative to the
+// stack it describes.
+func (j *purgeJournal) needsCompaction(stackSize int) bool {
+	return j.records > minCompactionRecords && j.records > 2*stackSize
+}
+
+// append writes a single record to the journal.
+func (j *purgeJournal) append(rec journalRecord) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	_, err = j.f.Write(append(line, '\n'))
+	if err != nil {
+		return err
+	}
+	j.records++
+	return nil
+}
+
+// close closes the journal file.
+func (j *purgeJournal) close() error {
+	return j.f.Close()
+}
@@ -0,0 +1,141 @@
+package notmain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	akamaipb "github.com/letsencrypt/boulder/akamai/proto"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/test"
+)
+
+type okCCU struct{}
+
+func (okCCU) Purge(urls []string) error {
+	return nil
+}
+
+func newJournaledPurger(t *testing.T, path string, client cachePurgeClient, clk clock.Clock) *akamaiPurger {
+	t.Helper()
+	ap := &akamaiPurger{
+		maxStackSize:    5,
+		entriesPerBatch: 2,
+		client:          client,
+		log:             blog.NewMock(),
+		journalErrors:   prometheus.NewCounter(prometheus.CounterOpts{Name: "journal_errors"}),
+		clk:             clk,
+	}
+	err := ap.restore(path)
+	test.AssertNotError(t, err, "restoring purge queue")
+	return ap
+}
+
+func TestPurgeJournalReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.jsonl")
+	clk := clock.NewFake()
+	queuedAt := clk.Now()
+
+	// Restoring from a journal which doesn't exist yet yields an empty stack.
+	ap := newJournaledPurger(t, path, &mockCCU{}, clk)
+	test.AssertEquals(t, ap.len(), 0)
+
+	for i := range 6 {
+		_, err := ap.Purge(context.Background(), &akamaipb.PurgeRequest{Urls: []string{fmt.Sprintf("http://test.com/%d", i)}})
+		test.AssertNotError(t, err, "Purge failed")
+		clk.Add(time.Minute)
+	}
+	// The stack is bounded, so the first entry was dropped.
+	test.AssertEquals(t, ap.len(), 5)
+
+	// A failed batch is returned to the bottom of the stack.
+	batch := ap.takeBatch()
+	test.AssertEquals(t, len(batch), 2)
+	err := ap.purgeBatch(batch)
+	test.AssertError(t, err, "Mock should have failed to purge.")
+	test.AssertEquals(t, ap.len(), 5)
+	test.AssertEquals(t, ap.toPurge[0][0], "http://test.com/4")
+	test.AssertEquals(t, ap.toPurge[1][0], "http://test.com/5")
+	test.AssertEquals(t, ap.oldestAge(), 2*time.Minute)
+
+	// A successful batch is removed from the stack.
+	ap.client = okCCU{}
+	batch = ap.takeBatch()
+	err = ap.purgeBatch(batch)
+	test.AssertNotError(t, err, "purging batch")
+	test.AssertEquals(t, ap.len(), 3)
+	err = ap.journal.close()
+	test.AssertNotError(t, err, "closing journal")
+
+	// Simulate a restart: a new purger replays the same stack from the
+	// journal.
+	restored := newJournaledPurger(t, path, okCCU{}, clk)
+	test.AssertDeepEquals(t, restored.toPurge, ap.toPurge)
+	test.AssertEquals(t, len(restored.queuedAt), 3)
+	test.Assert(t, restored.queuedAt[0].Equal(queuedAt.Add(4*time.Minute)), "expected queue time to be restored")
+
+	// Restoring compacts the journal down to one record per entry.
+	contents, err := os.ReadFile(path)
+	test.AssertNotError(t, err, "reading journal")
+	test.AssertEquals(t, bytes.Count(contents, []byte("\n")), 3)
+	test.AssertEquals(t, restored.journal.records, 3)
+}
+
+func TestPurgeJournalInFlightBatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.jsonl")
+	clk := clock.NewFake()
+
+	ap := newJournaledPurger(t, path, okCCU{}, clk)
+	for i := range 3 {
+		_, err := ap.Purge(context.Background(), &akamaipb.PurgeRequest{Urls: []string{fmt.Sprintf("http://test.com/%d", i)}})
+		test.AssertNotError(t, err, "Purge failed")
+	}
+
+	// The purger stops after taking a batch but before purging it.
+	batch := ap.takeBatch()
+	test.AssertEquals(t, len(batch), 2)
+	test.AssertEquals(t, ap.len(), 1)
+
+	// Compacting while the batch is in flight must not lose it.
+	err := ap.journal.compact(ap.toPurge, ap.queuedAt, ap.batch, ap.batchQueuedAt)
+	test.AssertNotError(t, err, "compacting journal")
+	err = ap.journal.close()
+	test.AssertNotError(t, err, "closing journal")
+
+	// The batch is returned to the top of the stack after a restart.
+	restored := newJournaledPurger(t, path, okCCU{}, clk)
+	test.AssertDeepEquals(t, restored.toPurge, [][]string{{"http://test.com/0"}, {"http://test.com/1"}, {"http://test.com/2"}})
+
+	// Once the batch has been purged, it is gone for good.
+	batch = restored.takeBatch()
+	err = restored.purgeBatch(batch)
+	test.AssertNotError(t, err, "purging batch")
+	err = restored.journal.close()
+	test.AssertNotError(t, err, "closing journal")
+
+	restored = newJournaledPurger(t, path, okCCU{}, clk)
+	test.AssertDeepEquals(t, restored.toPurge, [][]string{{"http://test.com/0"}})
+}
+
+func TestPurgeJournalPartialRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.jsonl")
+	err := os.WriteFile(path, []byte(`{"op":"push","urls":["http://test.com/0"]}`+"\n"+`{"op":"push","ur`), 0600)
+	test.AssertNotError(t, err, "writing journal")
+
+	stack, queuedAt, err := replayJournal(path, 10)
+	test.AssertNotError(t, err, "replaying journal")
+	test.AssertDeepEquals(t, stack, [][]string{{"http://test.com/0"}})
+	test.AssertEquals(t, len(queuedAt), 1)
+
+	err = os.WriteFile(path, []byte(`{"op":"pop"}`+"\n"), 0600)
+	test.AssertNotError(t, err, "writing journal")
+	_, _, err = replayJournal(path, 10)
+	test.AssertError(t, err, "expected unknown operation to fail")
+}
@@ -12,11 +12,14 @@ import (
 	"sync"
 	"time"
 
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/jmhodges/clock"
 	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/letsencrypt/boulder/akamai"
 	akamaipb "github.com/letsencrypt/boulder/akamai/proto"
+	"github.com/letsencrypt/boulder/cdn"
 	"github.com/letsencrypt/boulder/cmd"
 	"github.com/letsencrypt/boulder/config"
 	bgrpc "github.com/letsencrypt/boulder/grpc"
@@ -152,11 +155,23 @@ type Config struct {
 		// isn't provided it will default to `defaultQueueSize`.
 		MaxQueueSize int
 
-		BaseURL      string `validate:"required,url"`
-		ClientToken  string `validate:"required"`
-		ClientSecret string `validate:"required"`
-		AccessToken  string `validate:"required"`
-		V3Network    string `validate:"required,oneof=staging production"`
+		// BaseURL, ClientToken, ClientSecret, AccessToken and V3Network
+		// configure purging from Akamai. They are required unless at least one
+		// other CDN distribution is configured in Distributions.
+		BaseURL      string `validate:"required_without=Distributions,omitempty,url"`
+		ClientToken  string `validate:"required_with=BaseURL"`
+		ClientSecret string `validate:"required_with=BaseURL"`
+		AccessToken  string `validate:"required_with=BaseURL"`
+		V3Network    string `validate:"required_with=BaseURL,omitempty,oneof=staging production"`
+
+		// URLTemplates are Go text/template strings used to rewrite each URL
+		// before it is purged from Akamai. See cdn.DistributionConfig for the
+		// fields available to templates. If empty, URLs are purged unmodified.
+		URLTemplates []string `validate:"omitempty,dive,required"`
+
+		// Distributions configures additional, non-Akamai CDN distributions
+		// from which every purged URL is also purged.
+		Distributions []cdn.DistributionConfig `validate:"omitempty,dive"`
 
 		// Throughput is a container for all throughput related akamai-purger
 		// settings.
@@ -170,6 +185,12 @@ type Config struct {
 		// attempting to purge a batch of URLs which previously failed to be
 		// purged.
 		PurgeRetryBackoff config.Duration `validate:"-"`
+
+		// QueueFile is the path to a local file in which the purge stack is
+		// journaled, so that queued purges, including any batch which was
+		// being purged, are replayed after the purger restarts. If unset, the
+		// stack is held only in memory.
+		QueueFile string
 	}
 	Syslog        cmd.SyslogConfig
 	OpenTelemetry cmd.OpenTelemetryConfig
@@ -195,6 +216,19 @@ type akamaiPurger struct {
 	entriesPerBatch int
 	client          cachePurgeClient
 	log             blog.Logger
+
+	// queuedAt holds the time each entry of toPurge was queued, in the same
+	// order as toPurge.
+	queuedAt []time.Time
+	// batch holds the entries most recently returned by takeBatch until they
+	// are purged or requeued, and batchQueuedAt the times they were queued.
+	// takeBatch and purgeBatch are only called from a single goroutine.
+	batch         [][]string
+	batchQueuedAt []time.Time
+	// journal, if non-nil, persists every change to toPurge.
+	journal       *purgeJournal
+	journalErrors prometheus.Counter
+	clk           clock.Clock
 }
 
 var _ akamaipb.AkamaiPurgerServer = (*akamaiPurger)(nil)
@@ -205,6 +239,79 @@ func (ap *akamaiPurger) len() int {
 	return len(ap.toPurge)
 }
 
+// oldestAge returns how long the oldest entry on the stack has been queued, or
+// zero if the stack is empty.
+func (ap *akamaiPurger) oldestAge() time.Duration {
+	ap.Lock()
+	defer ap.Unlock()
+	if len(ap.queuedAt) == 0 {
+		return 0
+	}
+	return ap.clk.Since(ap.queuedAt[0])
+}
+
+// writeJournal records a change to the stack in the journal, if there is one.
+// The caller must hold the lock. Failures are logged but otherwise ignored, so
+// that purging continues even if the journal cannot be written.
+func (ap *akamaiPurger) writeJournal(rec journalRecord) {
+	if ap.journal == nil {
+		return
+	}
+	err := ap.journal.append(rec)
+	if err == nil && ap.journal.needsCompaction(len(ap.toPurge)+len(ap.batch)) {
+		err = ap.journal.compact(ap.toPurge, ap.queuedAt, ap.batch, ap.batchQueuedAt)
+	}
+	if err != nil {
+		ap.journalErrors.Inc()
+		ap.log.Errf("Failed to write purge queue journal: %s", err)
+	}
+}
+
+// restore rebuilds the stack from the journal at path, if it exists, and
+// journals all further changes to the stack to it.
+func (ap *akamaiPurger) restore(path string) error {
+	ap.Lock()
+	defer ap.Unlock()
+	stack, queuedAt, err := replayJournal(path, ap.maxStackSize)
+	if err != nil {
+		return fmt.Errorf("replaying purge queue journal: %w", err)
+	}
+	journal, err := openJournal(path, stack, queuedAt)
+	if err != nil {
+		return fmt.Errorf("opening purge queue journal: %w", err)
+	}
+	ap.toPurge = stack
+	ap.queuedAt = queuedAt
+	ap.journal = journal
+	return nil
+}
+
+// ackBatch records that the batch most recently returned by takeBatch is no
+// longer in flight. The caller must hold the lock.
+func (ap *akamaiPurger) ackBatch() {
+	ap.batch = nil
+	ap.batchQueuedAt = nil
+	ap.writeJournal(journalRecord{Op: journalAck})
+}
+
+// requeue returns the batch most recently returned by takeBatch, which failed
+// to be purged, to the bottom of the stack, as long as the stack has room for
+// it.
+func (ap *akamaiPurger) requeue() {
+	ap.Lock()
+	defer ap.Unlock()
+	for i := len(ap.batch) - 1; i >= 0; i-- {
+		if len(ap.toPurge) >= ap.maxStackSize {
+			ap.log.Warningf("Purge stack is full; dropping %d OCSP responses which failed to be purged", i+1)
+			break
+		}
+		ap.toPurge = append([][]string{ap.batch[i]}, ap.toPurge...)
+		ap.queuedAt = append([]time.Time{ap.batchQueuedAt[i]}, ap.queuedAt...)
+		ap.writeJournal(journalRecord{Op: journalRequeue, URLs: ap.batch[i], QueuedAt: ap.batchQueuedAt[i]})
+	}
+	ap.ackBatch()
+}
+
 func (ap *akamaiPurger) purgeBatch(batch [][]string) error {
 	// Flatten the batch of stack entries into a single slice of URLs.
 	var urls []string
@@ -215,13 +322,19 @@ func (ap *akamaiPurger) purgeBatch(batch [][]string) error {
 	err := ap.client.Purge(urls)
 	if err != nil {
 		ap.log.Errf("Failed to purge %d OCSP responses (%s): %s", len(batch), strings.Join(urls, ","), err)
+		ap.requeue()
 		return err
 	}
+	ap.Lock()
+	defer ap.Unlock()
+	ap.ackBatch()
 	return nil
 }
 
 // takeBatch returns a slice containing the next batch of entries from the purge stack.
 // It copies at most entriesPerBatch entries from the top of the stack into a new slice which is returned.
+// The batch stays in flight, and is replayed from the journal after a restart,
+// until purgeBatch either purges or requeues it.
 func (ap *akamaiPurger) takeBatch() [][]string {
 	ap.Lock()
 	defer ap.Unlock()
@@ -245,7 +358,11 @@ func (ap *akamaiPurger) takeBatch() [][]string {
 	for i, entry := range ap.toPurge[batchBegin:batchEnd] {
 		batch[i] = slices.Clone(entry)
 	}
+	ap.batch = batch
+	ap.batchQueuedAt = slices.Clone(ap.queuedAt[batchBegin:batchEnd])
 	ap.toPurge = ap.toPurge[:batchBegin]
+	ap.queuedAt = ap.queuedAt[:batchBegin]
+	ap.writeJournal(journalRecord{Op: journalTake, N: batchSize})
 	return batch
 }
 
@@ -258,9 +375,13 @@ func (ap *akamaiPurger) Purge(ctx context.Context, req *akamaipb.PurgeRequest) (
 	if stackSize >= ap.maxStackSize {
 		// Drop the oldest entry from the bottom of the stack to make room.
 		ap.toPurge = ap.toPurge[1:]
+		ap.queuedAt = ap.queuedAt[1:]
 	}
 	// Add the entry from the new request to the top of the stack.
+	now := ap.clk.Now()
 	ap.toPurge = append(ap.toPurge, req.Urls)
+	ap.queuedAt = append(ap.queuedAt, now)
+	ap.writeJournal(journalRecord{Op: journalPush, URLs: req.Urls, QueuedAt: now})
 	return &emptypb.Empty{}, nil
 }
 
@@ -338,24 +459,38 @@ func main() {
 		apc.MaxQueueSize = defaultQueueSize
 	}
 
-	ccu, err := akamai.NewCachePurgeClient(
-		apc.BaseURL,
-		apc.ClientToken,
-		apc.ClientSecret,
-		apc.AccessToken,
-		apc.V3Network,
-		apc.PurgeRetries,
-		apc.PurgeRetryBackoff.Duration,
-		logger,
-		scope,
-	)
-	cmd.FailOnError(err, "Failed to setup Akamai CCU client")
+	var ccu *akamai.CachePurgeClient
+	if apc.BaseURL != "" {
+		ccu, err = akamai.NewCachePurgeClient(
+			apc.BaseURL,
+			apc.ClientToken,
+			apc.ClientSecret,
+			apc.AccessToken,
+			apc.V3Network,
+			apc.PurgeRetries,
+			apc.PurgeRetryBackoff.Duration,
+			logger,
+			scope,
+		)
+		cmd.FailOnError(err, "Failed to setup Akamai CCU client")
+	}
+
+	client, err := newPurgeClient(ccu, apc.URLTemplates, apc.Distributions, logger, scope, cmd.Clock())
+	cmd.FailOnError(err, "Failed to setup CDN purge clients")
+
+	journalErrors := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "ccu_purge_queue_journal_errors",
+		Help: "A counter of failures to write the akamai-purger queue journal",
+	})
+	scope.MustRegister(journalErrors)
 
 	ap := &akamaiPurger{
 		maxStackSize:    apc.MaxQueueSize,
 		entriesPerBatch: apc.Throughput.QueueEntriesPerBatch,
-		client:          ccu,
+		client:          client,
 		log:             logger,
+		journalErrors:   journalErrors,
+		clk:             cmd.Clock(),
 	}
 
 	var gaugePurgeQueueLength = prometheus.NewGaugeFunc(
@@ -367,13 +502,103 @@ func main() {
 	)
 	scope.MustRegister(gaugePurgeQueueLength)
 
+	var gaugePurgeQueueOldestAge = prometheus.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Name: "ccu_purge_queue_oldest_age_seconds",
+			Help: "The age of the oldest entry in the akamai-purger queue. Captured on each prometheus scrape.",
+		},
+		func() float64 { return ap.oldestAge().Seconds() },
+	)
+	scope.MustRegister(gaugePurgeQueueOldestAge)
+
 	if manualMode {
+		if ccu == nil {
+			cmd.Fail("Manual tag purging requires an Akamai configuration")
+		}
 		manualPurge(ccu, *tag, *tagFile)
 	} else {
 		daemon(c, ap, logger, scope)
 	}
 }
 
+// newPurgeClient returns the cachePurgeClient used to purge each batch of URLs.
+// If only Akamai is configured, with no URL templates, the Akamai client is
+// used directly. Otherwise every configured distribution, including Akamai if
+// present, is purged by a cdn.MultiPurger.
+func newPurgeClient(ccu *akamai.CachePurgeClient, akamaiURLTemplates []string, distributions []cdn.DistributionConfig, logger blog.Logger, scope prometheus.Registerer, clk clock.Clock) (cachePurgeClient, error) {
+	if ccu != nil && len(akamaiURLTemplates) == 0 && len(distributions) == 0 {
+		return ccu, nil
+	}
+
+	var dists []*cdn.Distribution
+	if ccu != nil {
+		d, err := cdn.NewDistribution("akamai", akamaiURLTemplates, ccu)
+		if err != nil {
+			return nil, err
+		}
+		dists = append(dists, d)
+	}
+
+	for _, dc := range distributions {
+		var purger cdn.Purger
+		switch {
+		case dc.Fastly != nil:
+			apiKey, err := dc.Fastly.APIKey.Pass()
+			if err != nil {
+				return nil, fmt.Errorf("loading Fastly API key for distribution %q: %w", dc.Name, err)
+			}
+			purger, err = cdn.NewFastlyPurgeClient(
+				apiKey,
+				dc.Fastly.SoftPurge,
+				dc.Fastly.PurgeRetries,
+				dc.Fastly.PurgeRetryBackoff.Duration,
+				logger,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("setting up Fastly client for distribution %q: %w", dc.Name, err)
+			}
+		case dc.CloudFront != nil:
+			// Load the "default" AWS configuration, but override the set of
+			// config and credential files it reads from to just those
+			// specified in our JSON config, to ensure that it's not
+			// accidentally reading anything from the homedir or its other
+			// default config locations.
+			awsConfig, err := awsconfig.LoadDefaultConfig(
+				context.Background(),
+				awsconfig.WithSharedConfigFiles([]string{dc.CloudFront.AWSConfigFile}),
+				awsconfig.WithSharedCredentialsFiles([]string{dc.CloudFront.AWSCredsFile}),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("loading AWS config for distribution %q: %w", dc.Name, err)
+			}
+			purger, err = cdn.NewCloudFrontPurgeClient(
+				dc.CloudFront.Endpoint,
+				dc.CloudFront.DistributionID,
+				awsConfig.Credentials,
+				dc.CloudFront.PurgeRetries,
+				dc.CloudFront.PurgeRetryBackoff.Duration,
+				logger,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("setting up CloudFront client for distribution %q: %w", dc.Name, err)
+			}
+		default:
+			return nil, fmt.Errorf("distribution %q has no CDN backend configured", dc.Name)
+		}
+
+		d, err := cdn.NewDistribution(dc.Name, dc.URLTemplates, purger)
+		if err != nil {
+			return nil, err
+		}
+		dists = append(dists, d)
+	}
+
+	if len(dists) == 0 {
+		return nil, errors.New("no CDN distributions configured")
+	}
+	return cdn.NewMultiPurger(dists, scope, clk), nil
+}
+
 // manualPurge is called ad-hoc to purge either a single tag, or a batch of tags,
 // passed on the CLI. All tags will be added to a single request, please ensure
 // that you don't violate the Fast-Purge API limits for tags detailed here:
@@ -399,6 +624,12 @@ func daemon(c Config, ap *akamaiPurger, logger blog.Logger, scope prometheus.Reg
 	tlsConfig, err := c.AkamaiPurger.TLS.Load(scope)
 	cmd.FailOnError(err, "tlsConfig config")
 
+	if c.AkamaiPurger.QueueFile != "" {
+		err = ap.restore(c.AkamaiPurger.QueueFile)
+		cmd.FailOnError(err, "Failed to restore purge queue")
+		logger.Infof("Restored %d queued OCSP responses from %q", ap.len(), c.AkamaiPurger.QueueFile)
+	}
+
 	stop, stopped := make(chan bool, 1), make(chan bool, 1)
 	ticker := time.NewTicker(c.AkamaiPurger.Throughput.PurgeBatchInterval.Duration)
 	go func() {
@@ -429,6 +660,14 @@ func daemon(c Config, ap *akamaiPurger, logger blog.Logger, scope prometheus.Reg
 		} else {
 			logger.Info("Shutting down; queue is already empty.")
 		}
+		if ap.journal != nil {
+			ap.Lock()
+			err := ap.journal.close()
+			ap.Unlock()
+			if err != nil {
+				logger.Errf("Shutting down; failed to close purge queue journal: %s", err)
+			}
+		}
 		stopped <- true
 	}()
 
@@ -456,4 +695,7 @@ func daemon(c Config, ap *akamaiPurger, logger blog.Logger, scope prometheus.Reg
 
 func init() {
 	cmd.RegisterCommand("akamai-purger", main, &cmd.ConfigValidator{Config: &Config{}})
+	// cdn-purger is another name for the same command. Its config is
+	// validated under akamai-purger.
+	cmd.RegisterCommand("cdn-purger", main, nil)
 }
@@ -7,9 +7,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jmhodges/clock"
+
+	"github.com/letsencrypt/boulder/akamai"
 	akamaipb "github.com/letsencrypt/boulder/akamai/proto"
+	"github.com/letsencrypt/boulder/cdn"
+	"github.com/letsencrypt/boulder/cmd"
 	"github.com/letsencrypt/boulder/config"
 	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
 	"github.com/letsencrypt/boulder/test"
 )
 
@@ -123,6 +129,7 @@ func TestAkamaiPurgerQueue(t *testing.T) {
 		entriesPerBatch: 2,
 		client:          &mockCCU{},
 		log:             blog.NewMock(),
+		clk:             clock.NewFake(),
 	}
 
 	// Add 250 entries to fill the stack.
@@ -155,8 +162,10 @@ func TestAkamaiPurgerQueue(t *testing.T) {
 	err = ap.purgeBatch(batch)
 	test.AssertError(t, err, "Mock should have failed to purge.")
 
-	// Verify that the stack is no longer full.
-	test.AssertEquals(t, len(ap.toPurge), 248)
+	// Verify that the failed batch was returned to the bottom of the stack.
+	test.AssertEquals(t, len(ap.toPurge), 250)
+	test.AssertEquals(t, ap.toPurge[0][0], batch[0][0])
+	test.AssertEquals(t, ap.toPurge[1][0], batch[1][0])
 
 	// The first entry of the next batch should be on the top after the failed
 	// purge.
@@ -169,6 +178,7 @@ func TestAkamaiPurgerQueueWithOneEntry(t *testing.T) {
 		entriesPerBatch: 2,
 		client:          &mockCCU{},
 		log:             blog.NewMock(),
+		clk:             clock.NewFake(),
 	}
 
 	// Add one entry to the stack and using the Purge method.
@@ -185,6 +195,36 @@ func TestAkamaiPurgerQueueWithOneEntry(t *testing.T) {
 	err = ap.purgeBatch(batch)
 	test.AssertError(t, err, "Mock should have failed to purge.")
 
-	// Verify that the stack no longer contains our entry.
-	test.AssertEquals(t, len(ap.toPurge), 0)
+	// Verify that our entry was returned to the stack.
+	test.AssertEquals(t, len(ap.toPurge), 1)
+	test.AssertEquals(t, ap.toPurge[0][0], "http://test.com/0")
+}
+
+func TestNewPurgeClient(t *testing.T) {
+	ccu, err := akamai.NewCachePurgeClient(
+		"http://localhost:6789", "token", "secret", "access", "staging",
+		1, time.Millisecond, blog.NewMock(), metrics.NoopRegisterer)
+	test.AssertNotError(t, err, "creating Akamai client")
+
+	// With only Akamai configured, the Akamai client is used directly.
+	client, err := newPurgeClient(ccu, nil, nil, blog.NewMock(), metrics.NoopRegisterer, clock.NewFake())
+	test.AssertNotError(t, err, "creating purge client")
+	test.AssertEquals(t, client, cachePurgeClient(ccu))
+
+	// URL templates or other distributions fan out to every distribution.
+	client, err = newPurgeClient(ccu, []string{"{{.URL}}"}, nil, blog.NewMock(), metrics.NoopRegisterer, clock.NewFake())
+	test.AssertNotError(t, err, "creating purge client")
+	_, ok := client.(*cdn.MultiPurger)
+	test.Assert(t, ok, "expected a MultiPurger")
+
+	client, err = newPurgeClient(nil, nil, []cdn.DistributionConfig{{
+		Name:   "fastly",
+		Fastly: &cdn.FastlyConfig{APIKey: cmd.PasswordConfig{PasswordFile: "../../test/secrets/does-not-exist"}},
+	}}, blog.NewMock(), metrics.NoopRegisterer, clock.NewFake())
+	test.AssertError(t, err, "expected missing Fastly API key file to fail")
+	test.AssertEquals(t, client, nil)
+
+	// At least one distribution must be configured.
+	_, err = newPurgeClient(nil, nil, nil, blog.NewMock(), metrics.NoopRegisterer, clock.NewFake())
+	test.AssertError(t, err, "expected no distributions to fail")
 }
@@ -0,0 +1,102 @@
+package notmain
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"os"
+
+	"github.com/letsencrypt/boulder/cmd"
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/gateway"
+	bgrpc "github.com/letsencrypt/boulder/grpc"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+	"github.com/letsencrypt/boulder/web"
+)
+
+// Config holds the configuration for the api-gateway service.
+type Config struct {
+	APIGateway struct {
+		DebugAddr string `validate:"omitempty,hostname_port"`
+
+		// ListenAddress is the address:port on which to serve HTTPS.
+		ListenAddress string `validate:"required,hostname_port"`
+
+		// TLS is used both to serve HTTPS, requiring clients to present a
+		// certificate issued by its CA, and to connect to the SA.
+		TLS cmd.TLSConfig
+
+		SAService *cmd.GRPCClientConfig `validate:"required"`
+
+		// ClientNames are the names, one of which must be in a client's
+		// certificate for it to use the gateway.
+		ClientNames []string `validate:"min=1,dive,hostname"`
+
+		// ShutdownStopTimeout determines the maximum amount of time to wait
+		// for extant request handlers to complete before exiting.
+		ShutdownStopTimeout config.Duration
+	}
+	Syslog        cmd.SyslogConfig
+	OpenTelemetry cmd.OpenTelemetryConfig
+}
+
+func main() {
+	configFile := flag.String("config", "", "Path to configuration file")
+	listenAddr := flag.String("addr", "", "HTTPS listen address override")
+	debugAddr := flag.String("debug-addr", "", "Debug server address override")
+	flag.Parse()
+
+	if *configFile == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	var c Config
+	err := cmd.ReadConfigFile(*configFile, &c)
+	cmd.FailOnError(err, "Reading JSON config file into config structure")
+
+	if *listenAddr != "" {
+		c.APIGateway.ListenAddress = *listenAddr
+	}
+	if *debugAddr != "" {
+		c.APIGateway.DebugAddr = *debugAddr
+	}
+
+	scope, logger, oTelShutdown := cmd.StatsAndLogging(c.Syslog, c.OpenTelemetry, c.APIGateway.DebugAddr)
+	logger.Info(cmd.VersionString())
+
+	clk := cmd.Clock()
+
+	tlsConfig, err := c.APIGateway.TLS.Load(scope)
+	cmd.FailOnError(err, "Loading api-gateway TLS config")
+
+	saConn, err := bgrpc.ClientSetup(c.APIGateway.SAService, tlsConfig, scope, clk)
+	cmd.FailOnError(err, "Failed to load credentials and create gRPC connection to SA")
+	sac := sapb.NewStorageAuthorityReadOnlyClient(saConn)
+
+	gw, err := gateway.New(sac, c.APIGateway.ClientNames, logger)
+	cmd.FailOnError(err, "Unable to create gateway")
+
+	srv := web.NewServer(c.APIGateway.ListenAddress, gw, logger)
+	srv.TLSConfig = tlsConfig
+	go func() {
+		logger.Infof("Server running, listening on %s....", c.APIGateway.ListenAddress)
+		err := srv.ListenAndServeTLS("", "")
+		if err != nil && err != http.ErrServerClosed {
+			cmd.FailOnError(err, "Running HTTPS server")
+		}
+	}()
+
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), c.APIGateway.ShutdownStopTimeout.Duration)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+		oTelShutdown(ctx)
+	}()
+
+	cmd.WaitForSignal()
+}
+
+func init() {
+	cmd.RegisterCommand("api-gateway", main, &cmd.ConfigValidator{Config: &Config{}})
+}
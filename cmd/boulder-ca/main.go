@@ -9,9 +9,12 @@ import (
 	"time"
 
 	"github.com/letsencrypt/boulder/ca"
+	"github.com/letsencrypt/boulder/ca/journal"
+	"github.com/letsencrypt/boulder/ca/orphan"
 	capb "github.com/letsencrypt/boulder/ca/proto"
 	"github.com/letsencrypt/boulder/cmd"
 	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/ctpolicy"
 	"github.com/letsencrypt/boulder/ctpolicy/loglist"
 	"github.com/letsencrypt/boulder/features"
 	"github.com/letsencrypt/boulder/goodkey"
@@ -19,8 +22,11 @@ import (
 	bgrpc "github.com/letsencrypt/boulder/grpc"
 	"github.com/letsencrypt/boulder/issuance"
 	"github.com/letsencrypt/boulder/policy"
+	"github.com/letsencrypt/boulder/privatekey"
+	pubpb "github.com/letsencrypt/boulder/publisher/proto"
 	rapb "github.com/letsencrypt/boulder/ra/proto"
 	sapb "github.com/letsencrypt/boulder/sa/proto"
+	"github.com/letsencrypt/boulder/skewclock"
 )
 
 type Config struct {
@@ -110,6 +116,27 @@ type Config struct {
 		// preventing any CRLs from being issued.
 		DisableCRLService bool
 
+		// MaxSigningOpsPerSecond, if non-zero, is the most OCSP responses and
+		// CRLs which may be signed per second, combined. Requests beyond it
+		// wait, so that regenerating every CRL shard at once can't crowd out
+		// certificate issuance on the same HSMs.
+		MaxSigningOpsPerSecond int `validate:"min=0"`
+
+		// ClockSkew configures the detection of jumps of the host's clock,
+		// such as NTP steps. OCSP responses and CRLs are refused for a
+		// hold-down period after a jump, and no signed timestamp is ever
+		// earlier than one signed before it. Unset fields take defaults.
+		ClockSkew skewclock.Config
+
+		// Journal, if set, causes every precertificate and certificate
+		// signature to be recorded in a hash-chained journal before it's made.
+		Journal *JournalConfig
+
+		// OrphanRecovery, if set, causes every final certificate to be
+		// recorded in a local write-ahead log until the SA has stored it, and
+		// any which the SA didn't store to be replayed when the CA starts.
+		OrphanRecovery *OrphanRecoveryConfig
+
 		Features features.Config
 	}
 
@@ -119,6 +146,39 @@ type Config struct {
 	OpenTelemetry cmd.OpenTelemetryConfig
 }
 
+// JournalConfig configures the CA's issuance journal.
+type JournalConfig struct {
+	// Path is the local file to which journal entries are appended. It's
+	// created if it doesn't exist. Each CA instance must have its own.
+	Path string `validate:"required"`
+
+	// SigningKeyFile is the path to a PEM-encoded RSA or ECDSA private key,
+	// dedicated to the journal, with which its checkpoints are signed.
+	SigningKeyFile string `validate:"required"`
+
+	// CheckpointInterval is how often the head of the journal is signed, if
+	// anything has been appended to it since it last was. Default 1m.
+	CheckpointInterval config.Duration `validate:"-"`
+}
+
+// OrphanRecoveryConfig configures the recovery of certificates which the CA
+// signed but didn't store.
+type OrphanRecoveryConfig struct {
+	// WALFile is the local file in which final certificates are recorded
+	// until they're stored. It's created if it doesn't exist. Each CA
+	// instance must have its own.
+	WALFile string `validate:"required"`
+
+	// PublisherService, if set, is used to submit recovered certificates to
+	// FinalLogs, as the RA would have done had they been stored. It requires
+	// CTLogListFile.
+	PublisherService *cmd.GRPCClientConfig
+
+	// FinalLogs are the names of the CT logs to which recovered certificates
+	// are submitted.
+	FinalLogs []string `validate:"required_with=PublisherService"`
+}
+
 func main() {
 	grpcAddr := flag.String("addr", "", "gRPC listen address override")
 	debugAddr := flag.String("debug-addr", "", "Debug server address override")
@@ -142,6 +202,13 @@ func main() {
 		c.CA.DebugAddr = *debugAddr
 	}
 
+	run(c)
+}
+
+// run runs the CA until the process is signaled to stop. Features must already
+// be set.
+func run(c Config) {
+
 	serialPrefix := byte(c.CA.SerialPrefix)
 	if c.CA.SerialPrefixHex != "" {
 		parsedSerialPrefix, err := strconv.ParseUint(c.CA.SerialPrefixHex, 16, 8)
@@ -169,6 +236,9 @@ func main() {
 	pa, err := policy.New(c.PA.Identifiers, c.PA.Challenges, logger)
 	cmd.FailOnError(err, "Couldn't create PA")
 
+	err = pa.SetConfusableIDNAction(policy.ConfusableIDNAction(c.PA.ConfusableIDNs))
+	cmd.FailOnError(err, "Invalid PA configuration")
+
 	if c.CA.HostnamePolicyFile == "" {
 		cmd.Fail("HostnamePolicyFile was empty")
 	}
@@ -182,7 +252,7 @@ func main() {
 		cmd.FailOnError(err, "Failed to load CT Log List")
 	}
 
-	clk := cmd.Clock()
+	clk := skewclock.New(cmd.Clock(), c.CA.ClockSkew, scope, logger)
 	var crlShards int
 	issuers := make([]*issuance.Issuer, 0, len(c.CA.Issuance.Issuers))
 	for i, issuerConfig := range c.CA.Issuance.Issuers {
@@ -220,15 +290,23 @@ func main() {
 
 	kp, err := sagoodkey.NewPolicy(&c.CA.GoodKey, sa.KeyBlocked)
 	cmd.FailOnError(err, "Unable to create key policy")
+	kp.RegisterMetrics(scope)
 
 	srv := bgrpc.NewServer(c.CA.GRPCCA, logger)
 
+	var budget *ca.SigningBudget
+	if c.CA.MaxSigningOpsPerSecond > 0 {
+		budget, err = ca.NewSigningBudget(c.CA.MaxSigningOpsPerSecond, scope)
+		cmd.FailOnError(err, "Failed to create signing budget")
+	}
+
 	if !c.CA.DisableOCSPService {
 		ocspi, err := ca.NewOCSPImpl(
 			issuers,
 			c.CA.LifespanOCSP.Duration,
 			c.CA.OCSPLogMaxLength,
 			c.CA.OCSPLogPeriod.Duration,
+			budget,
 			logger,
 			scope,
 			metrics,
@@ -246,8 +324,10 @@ func main() {
 			issuers,
 			c.CA.Issuance.CRLProfile,
 			c.CA.OCSPLogMaxLength,
+			budget,
 			logger,
 			metrics,
+			clk,
 		)
 		cmd.FailOnError(err, "Failed to create CRL impl")
 
@@ -255,6 +335,57 @@ func main() {
 	}
 
 	if !c.CA.DisableCertService {
+		var issuanceJournal *journal.Journal
+		if c.CA.Journal != nil {
+			signer, _, err := privatekey.Load(c.CA.Journal.SigningKeyFile)
+			cmd.FailOnError(err, "Loading issuance journal signing key")
+			issuanceJournal, err = journal.Open(c.CA.Journal.Path, signer, clk)
+			cmd.FailOnError(err, "Opening issuance journal")
+			defer func() {
+				err := issuanceJournal.Close()
+				if err != nil {
+					logger.AuditErrf("Failed to close issuance journal: %s", err)
+				}
+			}()
+
+			interval := c.CA.Journal.CheckpointInterval.Duration
+			if interval == 0 {
+				interval = time.Minute
+			}
+			go issuanceJournal.CheckpointLoop(interval, func(err error) {
+				logger.AuditErrf("Failed to checkpoint issuance journal: %s", err)
+			})
+		}
+
+		var orphanWAL *orphan.WAL
+		if c.CA.OrphanRecovery != nil {
+			orphanWAL, err = orphan.OpenWAL(c.CA.OrphanRecovery.WALFile)
+			cmd.FailOnError(err, "Opening orphan write-ahead log")
+			defer orphanWAL.Close()
+
+			var submitter orphan.FinalCertSubmitter
+			if c.CA.OrphanRecovery.PublisherService != nil {
+				if c.CA.CTLogListFile == "" {
+					cmd.Fail("OrphanRecovery.PublisherService requires CTLogListFile")
+				}
+				pubConn, err := bgrpc.ClientSetup(c.CA.OrphanRecovery.PublisherService, tlsConfig, scope, clk)
+				cmd.FailOnError(err, "Failed to load credentials and create gRPC connection to Publisher")
+				allLogs, err := loglist.New(c.CA.CTLogListFile)
+				cmd.FailOnError(err, "Failed to load CT Log List")
+				finalLogs, err := allLogs.SubsetForPurpose(c.CA.OrphanRecovery.FinalLogs, loglist.Informational)
+				cmd.FailOnError(err, "Failed to load final logs")
+				submitter = ctpolicy.New(pubpb.NewPublisherClient(pubConn), nil, nil, finalLogs, 0, logger, scope)
+			}
+			recoverer := orphan.NewRecoverer(sa, submitter, logger, scope)
+
+			// Replay orphans before serving, so that the recovery doesn't
+			// race with the storage of newly issued certificates.
+			err = recoverer.Recover(context.Background(), orphanWAL)
+			if err != nil {
+				logger.AuditErrf("Failed to recover orphaned certificates: %s", err)
+			}
+		}
+
 		cai, err := ca.NewCertificateAuthorityImpl(
 			sa,
 			sctService,
@@ -264,6 +395,8 @@ func main() {
 			serialPrefix,
 			c.CA.MaxNames,
 			kp,
+			issuanceJournal,
+			orphanWAL,
 			logger,
 			metrics,
 			clk)
@@ -280,4 +413,5 @@ func main() {
 
 func init() {
 	cmd.RegisterCommand("boulder-ca", main, &cmd.ConfigValidator{Config: &Config{}})
+	cmd.RegisterRunner("boulder-ca", run)
 }
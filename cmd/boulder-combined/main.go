@@ -0,0 +1,129 @@
+package notmain
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+
+	"github.com/letsencrypt/boulder/cmd"
+	"github.com/letsencrypt/boulder/features"
+	bgrpc "github.com/letsencrypt/boulder/grpc"
+)
+
+type Config struct {
+	Combined struct {
+		// InProcessNames are the TLS names of the gRPC services run by this
+		// process. Each service whose certificate includes one of them serves
+		// in memory rather than on its configured address, and each client
+		// whose hostOverride is one of them connects to it in memory. Every
+		// name listed must be served by one of Services, or clients of it will
+		// wait until their RPCs time out.
+		InProcessNames []string `validate:"min=1,dive,hostname"`
+
+		// Services are the Boulder services to run, each with its usual config
+		// file. Features enabled for any of them are enabled for all of them.
+		Services []ServiceConfig `validate:"min=1,dive"`
+	}
+}
+
+// ServiceConfig names a service to be run by boulder-combined.
+type ServiceConfig struct {
+	// Command is the service's subcommand, e.g. "boulder-sa".
+	Command string `validate:"oneof=boulder-sa boulder-ca boulder-va boulder-ra boulder-wfe2"`
+
+	// Config is the path to the service's config file.
+	Config string `validate:"required"`
+}
+
+// serviceFeatures returns the features.Config found in a field of config, or in
+// a field of one of its struct fields, which is where each service keeps it.
+func serviceFeatures(config any) features.Config {
+	featuresType := reflect.TypeOf(features.Config{})
+	v := reflect.Indirect(reflect.ValueOf(config))
+	for i := range v.NumField() {
+		field := v.Field(i)
+		if field.Type() == featuresType {
+			return field.Interface().(features.Config)
+		}
+		if field.Kind() != reflect.Struct {
+			continue
+		}
+		for j := range field.NumField() {
+			if field.Field(j).Type() == featuresType {
+				return field.Field(j).Interface().(features.Config)
+			}
+		}
+	}
+	return features.Config{}
+}
+
+// mergeFeatures returns a features.Config in which each feature is enabled if it
+// is enabled in any of configs.
+func mergeFeatures(configs ...features.Config) features.Config {
+	var merged features.Config
+	mv := reflect.ValueOf(&merged).Elem()
+	for _, c := range configs {
+		cv := reflect.ValueOf(c)
+		for i := range cv.NumField() {
+			if cv.Field(i).Bool() {
+				mv.Field(i).SetBool(true)
+			}
+		}
+	}
+	return merged
+}
+
+// readServiceConfig validates the config file of service with the config
+// validator registered for its command, and returns the config.
+func readServiceConfig(service ServiceConfig) (any, error) {
+	if cmd.LookupConfigValidator(service.Command) == nil || cmd.LookupRunner(service.Command) == nil {
+		return nil, fmt.Errorf("%s can't be run by boulder-combined", service.Command)
+	}
+	config, err := cmd.ReadAndValidateConfigFile(service.Command, service.Config)
+	if err != nil {
+		return nil, fmt.Errorf("validating %s config %q: %w", service.Command, service.Config, err)
+	}
+	return config, nil
+}
+
+func main() {
+	configFile := flag.String("config", "", "File path to the configuration file for this service")
+	flag.Parse()
+	if *configFile == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	var c Config
+	err := cmd.ReadConfigFile(*configFile, &c)
+	cmd.FailOnError(err, "Reading JSON config file into config structure")
+
+	var serviceFeatureConfigs []features.Config
+	for _, service := range c.Combined.Services {
+		serviceConfig, err := readServiceConfig(service)
+		cmd.FailOnError(err, "Reading service config")
+		serviceFeatureConfigs = append(serviceFeatureConfigs, serviceFeatures(serviceConfig))
+	}
+	features.Set(mergeFeatures(serviceFeatureConfigs...))
+
+	bgrpc.ServeInProcess(c.Combined.InProcessNames...)
+
+	// Each service stops when the process is signaled, and a service which
+	// fails exits the whole process.
+	var wg sync.WaitGroup
+	for _, service := range c.Combined.Services {
+		run := cmd.LookupRunner(service.Command)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			run(service.Config)
+		}()
+	}
+	wg.Wait()
+}
+
+func init() {
+	cmd.RegisterCommand("boulder-combined", main, &cmd.ConfigValidator{Config: &Config{}})
+}
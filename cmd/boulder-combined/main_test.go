@@ -0,0 +1,42 @@
+package notmain
+
+import (
+	"testing"
+
+	"github.com/letsencrypt/boulder/features"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestServiceFeatures(t *testing.T) {
+	t.Parallel()
+	var c struct {
+		SA struct {
+			DebugAddr string
+			Features  features.Config
+		}
+	}
+	c.SA.Features.StorePrecertificateDigests = true
+	test.AssertDeepEquals(t, serviceFeatures(&c), features.Config{StorePrecertificateDigests: true})
+
+	var noFeatures struct {
+		Syslog struct{ StdoutLevel int }
+	}
+	test.AssertDeepEquals(t, serviceFeatures(&noFeatures), features.Config{})
+}
+
+func TestMergeFeatures(t *testing.T) {
+	t.Parallel()
+	merged := mergeFeatures(
+		features.Config{StorePrecertificateDigests: true},
+		features.Config{AsyncFinalize: true},
+		features.Config{},
+	)
+	test.AssertDeepEquals(t, merged, features.Config{StorePrecertificateDigests: true, AsyncFinalize: true})
+	test.AssertDeepEquals(t, mergeFeatures(), features.Config{})
+}
+
+func TestReadServiceConfig(t *testing.T) {
+	t.Parallel()
+	_, err := readServiceConfig(ServiceConfig{Command: "boulder-unknown", Config: "../../test/config/sa.json"})
+	test.AssertError(t, err, "unregistered command should fail")
+}
@@ -53,6 +53,28 @@ var (
 	ErrPickChallengeAuthzMissingChallenges = errors.New("PickChallenge: provided authorization had no challenges")
 )
 
+// NewWeightedChallengeStrategy returns a ChallengeStrategy which picks each
+// type of challenge in proportion to its weight in the given mix, keyed by
+// challenge type (e.g. "http-01"), from those offered by the authorization.
+// Challenge types absent from the mix are never picked.
+func NewWeightedChallengeStrategy(mix map[string]int) (ChallengeStrategy, error) {
+	if len(mix) == 0 {
+		return nil, errors.New("challenge mix must not be empty")
+	}
+	weights := make(map[core.AcmeChallenge]int, len(mix))
+	for rawType, weight := range mix {
+		chalType := core.AcmeChallenge(strings.ToLower(rawType))
+		if !chalType.IsValid() {
+			return nil, fmt.Errorf("challenge mix has unknown challenge type %q", rawType)
+		}
+		if weight < 0 {
+			return nil, fmt.Errorf("challenge mix has negative weight for %q", rawType)
+		}
+		weights[chalType] = weight
+	}
+	return &weightedChallengeStrategy{weights: weights}, nil
+}
+
 // randomChallengeStrategy is a ChallengeStrategy implementation that always
 // returns a random challenge from the given authorization.
 type randomChallengeStrategy struct {
@@ -96,3 +118,39 @@ func (strategy preferredTypeChallengeStrategy) PickChallenge(authz *core.Authori
 		authz.ID,
 		strategy.preferredType)
 }
+
+// weightedChallengeStrategy is a ChallengeStrategy implementation that picks
+// from the authorization's challenges in proportion to the weight of their
+// type.
+type weightedChallengeStrategy struct {
+	weights map[core.AcmeChallenge]int
+}
+
+// PickChallenge for a weightedChallengeStrategy returns one of the
+// authorization's challenges, chosen at random in proportion to the weight of
+// its type. An error is returned if none of the authorization's challenges has
+// a type with non-zero weight.
+func (strategy weightedChallengeStrategy) PickChallenge(authz *core.Authorization) (*core.Challenge, error) {
+	if authz == nil {
+		return nil, ErrPickChallengeNilAuthz
+	}
+	if len(authz.Challenges) == 0 {
+		return nil, ErrPickChallengeAuthzMissingChallenges
+	}
+	var total int
+	for _, chall := range authz.Challenges {
+		total += strategy.weights[chall.Type]
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("authorization (ID %q) had no challenge of a type in the challenge mix", authz.ID)
+	}
+	n := mrand.IntN(total)
+	for i, chall := range authz.Challenges {
+		n -= strategy.weights[chall.Type]
+		if n < 0 {
+			return &authz.Challenges[i], nil
+		}
+	}
+	// Unreachable, since n < total.
+	return nil, errors.New("weighted challenge pick out of range")
+}
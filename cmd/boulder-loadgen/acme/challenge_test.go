@@ -136,3 +136,40 @@ func TestPickChallenge(t *testing.T) {
 		})
 	}
 }
+
+func TestWeightedChallengeStrategy(t *testing.T) {
+	_, err := NewWeightedChallengeStrategy(nil)
+	test.AssertError(t, err, "empty mix should be rejected")
+	_, err = NewWeightedChallengeStrategy(map[string]int{"arm-wrestling": 1})
+	test.AssertError(t, err, "unknown challenge type should be rejected")
+	_, err = NewWeightedChallengeStrategy(map[string]int{"http-01": -1})
+	test.AssertError(t, err, "negative weight should be rejected")
+
+	strategy, err := NewWeightedChallengeStrategy(map[string]int{"HTTP-01": 3, "dns-01": 1, "tls-alpn-01": 0})
+	test.AssertNotError(t, err, "Failed to create challenge strategy")
+
+	_, err = strategy.PickChallenge(&core.Authorization{
+		ID:         "1234",
+		Challenges: []core.Challenge{{Type: "tls-alpn-01"}},
+	})
+	test.AssertError(t, err, "authz with only zero-weight challenges should be rejected")
+
+	authz := &core.Authorization{
+		ID: "1234",
+		Challenges: []core.Challenge{
+			{Type: "tls-alpn-01"},
+			{Type: "dns-01"},
+			{Type: "http-01"},
+		},
+	}
+	picked := make(map[core.AcmeChallenge]int)
+	for range 4000 {
+		chall, err := strategy.PickChallenge(authz)
+		test.AssertNotError(t, err, "PickChallenge failed")
+		picked[chall.Type]++
+	}
+	test.AssertEquals(t, picked["tls-alpn-01"], 0)
+	// Expect about 3000 http-01 and 1000 dns-01 picks.
+	test.Assert(t, picked["http-01"] > 2700 && picked["http-01"] < 3300, fmt.Sprintf("picked http-01 %d times", picked["http-01"]))
+	test.Assert(t, picked["dns-01"] > 700 && picked["dns-01"] < 1300, fmt.Sprintf("picked dns-01 %d times", picked["dns-01"]))
+}
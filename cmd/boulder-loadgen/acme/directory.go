@@ -1,5 +1,5 @@
 // Package acme provides ACME client functionality tailored to the needs of the
-// load-generator. It is not a general purpose ACME client library.
+// boulder-loadgen. It is not a general purpose ACME client library.
 package acme
 
 import (
@@ -47,7 +47,7 @@ var (
 	ErrInvalidTermsOfService = errors.New(`server's directory resource had invalid or missing "meta.termsOfService" key`)
 
 	// RequiredEndpoints is a slice of Endpoint keys that must be present in the
-	// ACME server's directory. The load-generator uses each of these endpoints
+	// ACME server's directory. boulder-loadgen uses each of these endpoints
 	// and expects to be able to find a URL for each in the server's directory
 	// resource.
 	RequiredEndpoints = []Endpoint{
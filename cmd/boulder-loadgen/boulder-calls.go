@@ -1,4 +1,4 @@
-package main
+package notmain
 
 import (
 	"crypto"
@@ -21,10 +21,10 @@ import (
 	"github.com/go-jose/go-jose/v4"
 	"golang.org/x/crypto/ocsp"
 
+	"github.com/letsencrypt/boulder/cmd/boulder-loadgen/acme"
 	"github.com/letsencrypt/boulder/core"
 	"github.com/letsencrypt/boulder/identifier"
 	"github.com/letsencrypt/boulder/probs"
-	"github.com/letsencrypt/boulder/test/load-generator/acme"
 )
 
 var (
@@ -33,13 +33,28 @@ var (
 	stringToOperation = map[string]func(*State, *acmeCache) error{
 		"newAccount":        newAccount,
 		"getAccount":        getAccount,
+		"deactivateAccount": deactivateAccount,
 		"newOrder":          newOrder,
 		"fulfillOrder":      fulfillOrder,
 		"finalizeOrder":     finalizeOrder,
 		"revokeCertificate": revokeCertificate,
 	}
+
+	// errInjectedFailure is returned by an action when the server rejected a
+	// failure injected into it, so that the rest of the flow is skipped.
+	errInjectedFailure = errors.New("injected failure was rejected")
+
+	// errAuthorizationInvalid is returned when polling an authorization which
+	// failed its challenge.
+	errAuthorizationInvalid = errors.New("authorization failed challenge and is status invalid")
 )
 
+// inject returns true, with the given chance between 0.0 and 1.0, if a failure
+// should be injected.
+func inject(chance float32) bool {
+	return chance > 0 && mrand.Float32() < chance
+}
+
 // OrderJSON is used because it's awkward to work with core.Order or corepb.Order
 // when the API returns a different object than either of these types can represent without
 // converting field values. The WFE uses an unexported `orderJSON` type for the
@@ -146,6 +161,41 @@ func newAccount(s *State, c *acmeCache) error {
 	return nil
 }
 
+// deactivateAccount deactivates the context's account and removes it from the
+// state, so that later calls use other accounts. Together with newAccount it
+// churns the state's accounts. Calls concurrently using the same account may
+// fail once it's deactivated.
+func deactivateAccount(s *State, c *acmeCache) error {
+	if c.acct == nil {
+		return errors.New("no account in the context to deactivate")
+	}
+
+	jws, err := c.signKeyIDV2Request([]byte(`{"status":"deactivated"}`), c.acct.id)
+	if err != nil {
+		return err
+	}
+	requestPayload := []byte(jws.FullSerialize())
+
+	resp, err := s.post(
+		c.acct.id,
+		requestPayload,
+		c.ns,
+		"/acme/acct/{ID}", // We want all account updates to be grouped
+		http.StatusOK,
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	s.removeAccount(c.acct)
+	return nil
+}
+
 // randDomain generates a random(-ish) domain name as a subdomain of the
 // provided base domain.
 func randDomain(base string) string {
@@ -159,9 +209,9 @@ func randDomain(base string) string {
 // newOrder creates a new pending order object for a random set of domains using
 // the context's account.
 func newOrder(s *State, c *acmeCache) error {
-	// Pick a random number of names within the constraints of the maxNamesPerCert
-	// parameter
-	orderSize := 1 + mrand.IntN(s.maxNamesPerCert-1)
+	// Pick a random number of names within the constraints of the orderSizes
+	// or maxNamesPerCert parameters
+	orderSize := s.pickOrderSize()
 	// Generate that many random domain names. There may be some duplicates, we
 	// don't care. The ACME server will collapse those down for us, how handy!
 	dnsNames := identifier.ACMEIdentifiers{}
@@ -180,8 +230,30 @@ func newOrder(s *State, c *acmeCache) error {
 		return err
 	}
 
-	// Sign the new order request with the context account's key/key ID
 	newOrderURL := s.directory.EndpointURL(acme.NewOrderEndpoint)
+
+	// Send the request with a nonce the server never issued, expecting it to
+	// be rejected, before retrying it as a client would.
+	if inject(s.failures.BadNonce) {
+		jws, err := c.signKeyIDV2RequestWithNonce(initOrderStr, newOrderURL, staticNonce(randNonce()))
+		if err != nil {
+			return err
+		}
+		rejected, err := s.postInjected(
+			newOrderURL,
+			[]byte(jws.FullSerialize()),
+			c.ns,
+			string(acme.NewOrderEndpoint)+" (bad nonce)")
+		if err != nil {
+			return err
+		}
+		s.stats.addInjected("badNonce", rejected)
+		if !rejected {
+			return fmt.Errorf("%s, server accepted a bad nonce", newOrderURL)
+		}
+	}
+
+	// Sign the new order request with the context account's key/key ID
 	jws, err := c.signKeyIDV2Request(initOrderStr, newOrderURL)
 	if err != nil {
 		return err
@@ -222,6 +294,16 @@ func newOrder(s *State, c *acmeCache) error {
 	return nil
 }
 
+// pickOrderSize returns the number of names in a new order, picked from the
+// state's orderSizes if set, or uniformly between one and maxNamesPerCert
+// otherwise.
+func (s *State) pickOrderSize() int {
+	if len(s.orderSizes) != 0 {
+		return pickWeighted(s.orderSizes, func(o OrderSizeConfig) int { return o.Weight }).Names
+	}
+	return 1 + mrand.IntN(s.maxNamesPerCert)
+}
+
 // popPendingOrder *removes* a random pendingOrder from the context, returning
 // it.
 func popPendingOrder(c *acmeCache) *OrderJSON {
@@ -263,7 +345,9 @@ func getAuthorization(s *State, c *acmeCache, url string) (*core.Authorization,
 // completeAuthorization processes a provided authorization by solving its
 // HTTP-01 challenge using the context's account and the state's challenge
 // server. Aftering POSTing the authorization's HTTP-01 challenge the
-// authorization will be polled waiting for a state change.
+// authorization will be polled waiting for a state change. If a bad key
+// authorization is injected, the authorization is expected to become invalid
+// instead.
 func completeAuthorization(authz *core.Authorization, s *State, c *acmeCache) error {
 	// Skip if the authz isn't pending
 	if authz.Status != core.StatusPending {
@@ -271,7 +355,7 @@ func completeAuthorization(authz *core.Authorization, s *State, c *acmeCache) er
 	}
 
 	// Find a challenge to solve from the pending authorization using the
-	// challenge selection strategy from the load-generator state.
+	// challenge selection strategy from the boulder-loadgen state.
 	chalToSolve, err := s.challStrat.PickChallenge(authz)
 	if err != nil {
 		return err
@@ -284,6 +368,11 @@ func completeAuthorization(authz *core.Authorization, s *State, c *acmeCache) er
 		return err
 	}
 	authStr := fmt.Sprintf("%s.%s", chalToSolve.Token, base64.RawURLEncoding.EncodeToString(thumbprint))
+	injected := inject(s.failures.BadKeyAuthorization)
+	if injected {
+		// Provision the key authorization for a different token.
+		authStr = fmt.Sprintf("%s.%s", randNonce(), base64.RawURLEncoding.EncodeToString(thumbprint))
+	}
 
 	// Add the challenge response to the state's test server and defer a clean-up.
 	switch chalToSolve.Type {
@@ -333,6 +422,17 @@ func completeAuthorization(authz *core.Authorization, s *State, c *acmeCache) er
 	// Poll the authorization waiting for the challenge response to be recorded in
 	// a change of state. The polling may sleep and retry a few times if required
 	err = pollAuthorization(authz, s, c)
+	if injected {
+		rejected := errors.Is(err, errAuthorizationInvalid)
+		s.stats.addInjected("badKeyAuthorization", rejected)
+		if rejected {
+			return errInjectedFailure
+		}
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("Authorization %q was valid despite an incorrect key authorization", authz.ID)
+	}
 	if err != nil {
 		return err
 	}
@@ -356,7 +456,7 @@ func pollAuthorization(authz *core.Authorization, s *State, c *acmeCache) error
 		}
 		// If the authz is invalid, abort with an error
 		if authz.Status == "invalid" {
-			return fmt.Errorf("Authorization %q failed challenge and is status invalid", authzURL)
+			return fmt.Errorf("Authorization %q: %w", authzURL, errAuthorizationInvalid)
 		}
 		// If the authz is valid, return with no error - the authz is ready to go!
 		if authz.Status == "valid" {
@@ -497,6 +597,15 @@ func finalizeOrder(s *State, c *acmeCache) error {
 		dnsNames[i] = ident.Value
 	}
 
+	// Inject a CSR with a name which isn't in the order, expecting it to be
+	// rejected.
+	injected := inject(s.failures.BadCSR)
+	latencyTag := "/acme/order/finalize" // We want all order finalizations to be grouped.
+	if injected {
+		dnsNames = append(dnsNames, randDomain(s.domainBase))
+		latencyTag += " (bad CSR)"
+	}
+
 	// Create a CSR using the state's certKey
 	csr, err := x509.CreateCertificateRequest(
 		rand.Reader,
@@ -520,11 +629,23 @@ func finalizeOrder(s *State, c *acmeCache) error {
 	}
 	requestPayload := []byte(jws.FullSerialize())
 
+	if injected {
+		rejected, err := s.postInjected(finalizeURL, requestPayload, c.ns, latencyTag)
+		if err != nil {
+			return err
+		}
+		s.stats.addInjected("badCSR", rejected)
+		if rejected {
+			return errInjectedFailure
+		}
+		return fmt.Errorf("Order %q was finalized with a CSR for a name not in the order", order.URL)
+	}
+
 	resp, err := s.post(
 		finalizeURL,
 		requestPayload,
 		c.ns,
-		"/acme/order/finalize", // We want all order finalizations to be grouped.
+		latencyTag,
 		http.StatusOK,
 	)
 	if err != nil {
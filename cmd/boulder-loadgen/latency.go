@@ -1,4 +1,4 @@
-package main
+package notmain
 
 import (
 	"encoding/json"
@@ -0,0 +1,180 @@
+package notmain
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/letsencrypt/boulder/cmd"
+)
+
+// FlowConfig is a sequence of actions performed, in order, by a call. A call
+// stops at the first action which fails.
+type FlowConfig struct {
+	// Name identifies the flow in the report printed at the end of a run.
+	Name string `validate:"required"`
+
+	// Weight is the share of calls which perform this flow, relative to the
+	// weights of the other flows.
+	Weight int `validate:"min=1"`
+
+	// Actions are the names of the actions performed, in order.
+	Actions []string `validate:"min=1,dive,oneof=newAccount getAccount deactivateAccount newOrder fulfillOrder finalizeOrder revokeCertificate"`
+}
+
+// OrderSizeConfig is the share of orders which have a given number of names.
+type OrderSizeConfig struct {
+	Names  int `validate:"min=1"`
+	Weight int `validate:"min=1"`
+}
+
+// FailureInjectionConfig sets the chance, between 0.0 and 1.0, of each kind of
+// failure being injected into the action which can inject it. An injected
+// failure which the server rejects, as it should, doesn't count as an error
+// but does stop the call, except for a bad nonce, which is retried as a client
+// would. The report printed at the end of a run counts the injected failures
+// which the server rejected, and those which it accepted.
+type FailureInjectionConfig struct {
+	// BadNonce is the chance of newOrder first sending its request with a
+	// nonce which the server never issued.
+	BadNonce float32 `validate:"min=0,max=1"`
+
+	// BadKeyAuthorization is the chance of fulfillOrder provisioning an
+	// incorrect key authorization for a challenge.
+	BadKeyAuthorization float32 `validate:"min=0,max=1"`
+
+	// BadCSR is the chance of finalizeOrder sending a CSR which is missing one
+	// of the order's names.
+	BadCSR float32 `validate:"min=0,max=1"`
+}
+
+type Config struct {
+	// Execution plan parameters
+	Plan struct {
+		// Actions are performed by every call, if Flows is empty.
+		Actions []string `validate:"required_without=Flows,omitempty,dive,oneof=newAccount getAccount deactivateAccount newOrder fulfillOrder finalizeOrder revokeCertificate"`
+		// Flows are the mix of action sequences performed by calls, each
+		// picked at random in proportion to its weight.
+		Flows     []FlowConfig `validate:"omitempty,dive"`
+		Rate      int64        `validate:"min=1"` // requests / s
+		RateDelta string       // requests / s^2
+		Runtime   string       `validate:"required"` // how long to run for
+	}
+	ExternalState   string   // path to file to load/save registrations etc to/from
+	DontSaveState   bool     // don't save changes to external state
+	DirectoryURL    string   `validate:"required,url"` // ACME server directory URL
+	DomainBase      string   `validate:"required"`     // base domain name to create authorizations for
+	HTTPOneAddrs    []string // addresses to listen for http-01 validation requests on
+	TLSALPNOneAddrs []string // addresses to listen for tls-alpn-01 validation requests on
+	DNSAddrs        []string // addresses to listen for DNS requests on
+	FakeDNS         string   // IPv6 address to use for all DNS A requests
+	RealIP          string   // value of the Real-IP header to use when bypassing CDN
+	RegEmail        string   // email to use in registrations
+	Results         string   // path to save metrics to
+	MaxRegs         int      // maximum number of registrations to create
+	MaxNamesPerCert int      `validate:"required_without=OrderSizes,omitempty,min=1"` // maximum number of names on one certificate/order
+	// OrderSizes, if set, is the distribution of the number of names in each
+	// order, instead of a number picked uniformly up to MaxNamesPerCert.
+	OrderSizes        []OrderSizeConfig `validate:"omitempty,dive"`
+	ChallengeStrategy string            // challenge selection strategy ("random", "http-01", "dns-01", "tls-alpn-01")
+	// ChallengeMix, if set, is the share of each type of challenge, e.g.
+	// {"http-01": 80, "dns-01": 20}, which is solved, instead of using the
+	// ChallengeStrategy.
+	ChallengeMix     map[string]int `validate:"omitempty,dive,min=0"`
+	RevokeChance     float32        `validate:"min=0,max=1"` // chance of revoking certificate after issuance, between 0.0 and 1.0
+	FailureInjection FailureInjectionConfig
+}
+
+func init() {
+	cmd.RegisterCommand("boulder-loadgen", main, &cmd.ConfigValidator{Config: &Config{}})
+}
+
+func main() {
+	configPath := flag.String("config", "", "Path to configuration file for boulder-loadgen")
+	resultsPath := flag.String("results", "", "Path to latency results file")
+	rateArg := flag.Int("rate", 0, "")
+	runtimeArg := flag.String("runtime", "", "")
+	deltaArg := flag.String("delta", "", "")
+	flag.Parse()
+
+	if *configPath == "" {
+		fmt.Fprintf(os.Stderr, "-config argument must not be empty\n")
+		os.Exit(1)
+	}
+
+	var config Config
+	err := cmd.ReadConfigFile(*configPath, &config)
+	cmd.FailOnError(err, "Failed to read boulder-loadgen config file")
+
+	if *resultsPath != "" {
+		config.Results = *resultsPath
+	}
+	if *rateArg != 0 {
+		config.Plan.Rate = int64(*rateArg)
+	}
+	if *runtimeArg != "" {
+		config.Plan.Runtime = *runtimeArg
+	}
+	if *deltaArg != "" {
+		config.Plan.RateDelta = *deltaArg
+	}
+
+	s, err := New(config)
+	cmd.FailOnError(err, "Failed to create load generator")
+
+	if config.ExternalState != "" {
+		err = s.Restore(config.ExternalState)
+		cmd.FailOnError(err, "Failed to load registration snapshot")
+	}
+
+	runtime, err := time.ParseDuration(config.Plan.Runtime)
+	cmd.FailOnError(err, "Failed to parse plan runtime")
+
+	var delta *RateDelta
+	if config.Plan.RateDelta != "" {
+		parts := strings.Split(config.Plan.RateDelta, "/")
+		if len(parts) != 2 {
+			fmt.Fprintf(os.Stderr, "RateDelta is malformed")
+			os.Exit(1)
+		}
+		rate, err := strconv.Atoi(parts[0])
+		cmd.FailOnError(err, "Failed to parse increase portion of RateDelta")
+		period, err := time.ParseDuration(parts[1])
+		cmd.FailOnError(err, "Failed to parse period portion of RateDelta")
+		delta = &RateDelta{Inc: int64(rate), Period: period}
+	}
+
+	if len(config.HTTPOneAddrs) == 0 &&
+		len(config.TLSALPNOneAddrs) == 0 &&
+		len(config.DNSAddrs) == 0 {
+		cmd.Fail("There must be at least one bind address in " +
+			"HTTPOneAddrs, TLSALPNOneAddrs or DNSAddrs\n")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go cmd.CatchSignals(cancel)
+
+	err = s.Run(
+		ctx,
+		config.HTTPOneAddrs,
+		config.TLSALPNOneAddrs,
+		config.DNSAddrs,
+		config.FakeDNS,
+		Plan{
+			Runtime: runtime,
+			Rate:    config.Plan.Rate,
+			Delta:   delta,
+		})
+	cmd.FailOnError(err, "Failed to run load generator")
+
+	if config.ExternalState != "" && !config.DontSaveState {
+		err = s.Snapshot(config.ExternalState)
+		cmd.FailOnError(err, "Failed to save registration snapshot")
+	}
+
+	fmt.Println("[+] All done, bye bye ^_^")
+}
@@ -0,0 +1,167 @@
+package notmain
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// callResults are the latencies of the calls made for one action, and the
+// number of them which failed.
+type callResults struct {
+	latencies []time.Duration
+	errors    int
+}
+
+// flowResults are the number of calls of one flow which were performed from
+// start to finish, and the number which stopped early because an action
+// failed.
+type flowResults struct {
+	completed int
+	failed    int
+}
+
+// injectedResults are the number of failures of one kind which were injected
+// and rejected by the server, as they should be, and the number which it
+// accepted.
+type injectedResults struct {
+	rejected int
+	accepted int
+}
+
+// callStats accumulates the results of a run for the report printed at its
+// end.
+type callStats struct {
+	mu       sync.Mutex
+	calls    map[string]*callResults
+	flows    map[string]*flowResults
+	injected map[string]*injectedResults
+}
+
+func newCallStats() *callStats {
+	return &callStats{
+		calls:    make(map[string]*callResults),
+		flows:    make(map[string]*flowResults),
+		injected: make(map[string]*injectedResults),
+	}
+}
+
+// addCall records the latency of a call made for the action, and whether it
+// succeeded.
+func (cs *callStats) addCall(action string, took time.Duration, ok bool) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	r, present := cs.calls[action]
+	if !present {
+		r = &callResults{}
+		cs.calls[action] = r
+	}
+	r.latencies = append(r.latencies, took)
+	if !ok {
+		r.errors++
+	}
+}
+
+// addFlow records whether a call of the named flow was performed from start
+// to finish.
+func (cs *callStats) addFlow(name string, ok bool) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	r, present := cs.flows[name]
+	if !present {
+		r = &flowResults{}
+		cs.flows[name] = r
+	}
+	if ok {
+		r.completed++
+	} else {
+		r.failed++
+	}
+}
+
+// addInjected records whether the server rejected an injected failure of the
+// given kind.
+func (cs *callStats) addInjected(kind string, rejected bool) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	r, present := cs.injected[kind]
+	if !present {
+		r = &injectedResults{}
+		cs.injected[kind] = r
+	}
+	if rejected {
+		r.rejected++
+	} else {
+		r.accepted++
+	}
+}
+
+// percentile returns the nearest-rank percentile p, between 0 and 1, of the
+// sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// sortedKeys returns the keys of the map in order.
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Report writes tables of the latency and error rate of each action, the
+// result of each flow, and the outcome of each kind of injected failure.
+func (cs *callStats) Report(out io.Writer) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "action\tcalls\terrors\terror rate\tp50\tp90\tp99\tmax\t")
+	for _, action := range sortedKeys(cs.calls) {
+		r := cs.calls[action]
+		sorted := slices.Clone(r.latencies)
+		slices.Sort(sorted)
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.2f%%\t%s\t%s\t%s\t%s\t\n",
+			action,
+			len(sorted),
+			r.errors,
+			100*float64(r.errors)/float64(len(sorted)),
+			percentile(sorted, 0.5).Round(time.Millisecond),
+			percentile(sorted, 0.9).Round(time.Millisecond),
+			percentile(sorted, 0.99).Round(time.Millisecond),
+			percentile(sorted, 1).Round(time.Millisecond),
+		)
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "flow\tcompleted\tfailed\t")
+	for _, name := range sortedKeys(cs.flows) {
+		r := cs.flows[name]
+		fmt.Fprintf(w, "%s\t%d\t%d\t\n", name, r.completed, r.failed)
+	}
+
+	if len(cs.injected) != 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "injected failure\trejected\taccepted\t")
+		for _, kind := range sortedKeys(cs.injected) {
+			r := cs.injected[kind]
+			fmt.Fprintf(w, "%s\t%d\t%d\t\n", kind, r.rejected, r.accepted)
+		}
+	}
+	_ = w.Flush()
+}
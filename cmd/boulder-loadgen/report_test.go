@@ -0,0 +1,54 @@
+package notmain
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestPercentile(t *testing.T) {
+	test.AssertEquals(t, percentile(nil, 0.5), time.Duration(0))
+
+	var sorted []time.Duration
+	for i := range 100 {
+		sorted = append(sorted, time.Duration(i+1)*time.Millisecond)
+	}
+	test.AssertEquals(t, percentile(sorted, 0), time.Millisecond)
+	test.AssertEquals(t, percentile(sorted, 0.5), 50*time.Millisecond)
+	test.AssertEquals(t, percentile(sorted, 0.99), 99*time.Millisecond)
+	test.AssertEquals(t, percentile(sorted, 1), 100*time.Millisecond)
+}
+
+func TestReport(t *testing.T) {
+	cs := newCallStats()
+	cs.addCall("/acme/new-order", 10*time.Millisecond, true)
+	cs.addCall("/acme/new-order", 30*time.Millisecond, false)
+	cs.addCall("HEAD newNonce", time.Millisecond, true)
+	cs.addFlow("issue", true)
+	cs.addFlow("issue", false)
+	cs.addFlow("churn", true)
+
+	var out bytes.Buffer
+	cs.Report(&out)
+	report := out.String()
+	rows := make(map[string]string)
+	for _, line := range strings.Split(report, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 1 {
+			rows[fields[0]] = strings.Join(fields[1:], " ")
+		}
+	}
+	test.AssertEquals(t, rows["/acme/new-order"], "2 1 50.00% 10ms 30ms 30ms 30ms")
+	test.AssertEquals(t, rows["issue"], "1 1")
+	test.AssertEquals(t, rows["churn"], "1 0")
+	test.Assert(t, !strings.Contains(report, "injected failure"), "report should omit injected failures if there were none")
+
+	cs.addInjected("badCSR", true)
+	cs.addInjected("badCSR", false)
+	out.Reset()
+	cs.Report(&out)
+	test.AssertContains(t, out.String(), "injected failure")
+}
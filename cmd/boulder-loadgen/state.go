@@ -1,4 +1,4 @@
-package main
+package notmain
 
 import (
 	"bytes"
@@ -8,16 +8,17 @@ import (
 	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log"
+	mrand "math/rand/v2"
 	"net"
 	"net/http"
 	"os"
-	"reflect"
-	"runtime"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -26,7 +27,7 @@ import (
 
 	"github.com/go-jose/go-jose/v4"
 
-	"github.com/letsencrypt/boulder/test/load-generator/acme"
+	"github.com/letsencrypt/boulder/cmd/boulder-loadgen/acme"
 	"github.com/letsencrypt/challtestsrv"
 )
 
@@ -107,6 +108,12 @@ func (c *acmeCache) signEmbeddedV2Request(data []byte, url string) (*jose.JSONWe
 // style for ACME v2 requests and should be used everywhere but where the key ID
 // is unknown (e.g. new-account requests where an account doesn't exist yet).
 func (c *acmeCache) signKeyIDV2Request(data []byte, url string) (*jose.JSONWebSignature, error) {
+	return c.signKeyIDV2RequestWithNonce(data, url, c.ns)
+}
+
+// signKeyIDV2RequestWithNonce is signKeyIDV2Request, but with nonces from the
+// provided nonce source rather than the acmeCache's.
+func (c *acmeCache) signKeyIDV2RequestWithNonce(data []byte, url string, ns jose.NonceSource) (*jose.JSONWebSignature, error) {
 	// Create a JWK with the account's private key and key ID
 	jwk := &jose.JSONWebKey{
 		Key:       c.acct.key,
@@ -122,7 +129,7 @@ func (c *acmeCache) signKeyIDV2Request(data []byte, url string) (*jose.JSONWebSi
 
 	// Ensure the signer's nonce source and URL header will be set
 	opts := &jose.SignerOptions{
-		NonceSource: c.ns,
+		NonceSource: ns,
 		ExtraHeaders: map[jose.HeaderKey]interface{}{
 			"url": url,
 		},
@@ -153,6 +160,37 @@ type Plan struct {
 	Delta   *RateDelta
 }
 
+// operation is a named action which can operate on a state/context.
+type operation struct {
+	name string
+	fn   func(*State, *acmeCache) error
+}
+
+// flow is a sequence of operations performed by a call.
+type flow struct {
+	name       string
+	weight     int
+	operations []operation
+}
+
+// pickWeighted returns one of the items, chosen at random in proportion to
+// its weight. The items must have a positive total weight.
+func pickWeighted[T any](items []T, weight func(T) int) T {
+	var total int
+	for _, item := range items {
+		total += weight(item)
+	}
+	n := mrand.IntN(total)
+	for _, item := range items {
+		n -= weight(item)
+		if n < 0 {
+			return item
+		}
+	}
+	// Unreachable, since n < total.
+	return items[len(items)-1]
+}
+
 type respCode struct {
 	code int
 	num  int
@@ -164,10 +202,11 @@ type State struct {
 	email           string
 	maxRegs         int
 	maxNamesPerCert int
+	orderSizes      []OrderSizeConfig
 	realIP          string
 	certKey         *ecdsa.PrivateKey
 
-	operations []func(*State, *acmeCache) error
+	flows []flow
 
 	rMu sync.RWMutex
 
@@ -182,6 +221,9 @@ type State struct {
 	httpClient *http.Client
 
 	revokeChance float32
+	failures     FailureInjectionConfig
+
+	stats *callStats
 
 	reqTotal  int64
 	respCodes map[int]*respCode
@@ -270,32 +312,68 @@ func (s *State) Restore(filename string) error {
 	return nil
 }
 
+// newFlows converts the configured plan to flows. If the plan has no flows,
+// every call performs its actions.
+func newFlows(actions []string, configs []FlowConfig) ([]flow, error) {
+	if len(configs) == 0 {
+		configs = []FlowConfig{{Name: "default", Weight: 1, Actions: actions}}
+	}
+	var flows []flow
+	for _, fc := range configs {
+		if fc.Weight < 1 {
+			return nil, fmt.Errorf("flow %q must have a positive weight", fc.Name)
+		}
+		f := flow{name: fc.Name, weight: fc.Weight}
+		// convert operations strings to methods
+		for _, opName := range fc.Actions {
+			op, present := stringToOperation[opName]
+			if !present {
+				return nil, fmt.Errorf("unknown operation %q", opName)
+			}
+			f.operations = append(f.operations, operation{opName, op})
+		}
+		if len(f.operations) == 0 {
+			return nil, fmt.Errorf("flow %q has no actions", fc.Name)
+		}
+		flows = append(flows, f)
+	}
+	return flows, nil
+}
+
 // New returns a pointer to a new State struct or an error
-func New(
-	directoryURL string,
-	domainBase string,
-	realIP string,
-	maxRegs, maxNamesPerCert int,
-	latencyPath string,
-	userEmail string,
-	operations []string,
-	challStrat string,
-	revokeChance float32) (*State, error) {
+func New(c Config) (*State, error) {
 	certKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	if err != nil {
 		return nil, err
 	}
-	directory, err := acme.NewDirectory(directoryURL)
+	directory, err := acme.NewDirectory(c.DirectoryURL)
 	if err != nil {
 		return nil, err
 	}
-	strategy, err := acme.NewChallengeStrategy(challStrat)
+	var strategy acme.ChallengeStrategy
+	if len(c.ChallengeMix) != 0 {
+		strategy, err = acme.NewWeightedChallengeStrategy(c.ChallengeMix)
+	} else {
+		strategy, err = acme.NewChallengeStrategy(c.ChallengeStrategy)
+	}
 	if err != nil {
 		return nil, err
 	}
-	if revokeChance > 1 {
+	if c.RevokeChance > 1 {
 		return nil, errors.New("revokeChance must be between 0.0 and 1.0")
 	}
+	if c.MaxNamesPerCert < 1 && len(c.OrderSizes) == 0 {
+		return nil, errors.New("maxNamesPerCert must be positive if orderSizes is empty")
+	}
+	for _, size := range c.OrderSizes {
+		if size.Names < 1 || size.Weight < 1 {
+			return nil, errors.New("orderSizes must have a positive number of names and weight")
+		}
+	}
+	flows, err := newFlows(c.Plan.Actions, c.Plan.Flows)
+	if err != nil {
+		return nil, err
+	}
 	httpClient := &http.Client{
 		Transport: &http.Transport{
 			DialContext: (&net.Dialer{
@@ -311,39 +389,32 @@ func New(
 		},
 		Timeout: 10 * time.Second,
 	}
-	latencyFile, err := newLatencyFile(latencyPath)
+	latencyFile, err := newLatencyFile(c.Results)
 	if err != nil {
 		return nil, err
 	}
-	s := &State{
+	return &State{
 		httpClient:      httpClient,
 		directory:       directory,
 		challStrat:      strategy,
 		certKey:         certKey,
-		domainBase:      domainBase,
+		domainBase:      c.DomainBase,
 		callLatency:     latencyFile,
+		stats:           newCallStats(),
 		wg:              new(sync.WaitGroup),
-		realIP:          realIP,
-		maxRegs:         maxRegs,
-		maxNamesPerCert: maxNamesPerCert,
-		email:           userEmail,
+		realIP:          c.RealIP,
+		maxRegs:         c.MaxRegs,
+		maxNamesPerCert: c.MaxNamesPerCert,
+		orderSizes:      c.OrderSizes,
+		email:           c.RegEmail,
 		respCodes:       make(map[int]*respCode),
-		revokeChance:    revokeChance,
-	}
-
-	// convert operations strings to methods
-	for _, opName := range operations {
-		op, present := stringToOperation[opName]
-		if !present {
-			return nil, fmt.Errorf("unknown operation %q", opName)
-		}
-		s.operations = append(s.operations, op)
-	}
-
-	return s, nil
+		revokeChance:    c.RevokeChance,
+		failures:        c.FailureInjection,
+		flows:           flows,
+	}, nil
 }
 
-// Run runs the WFE load-generator
+// Run runs the WFE load generator
 func (s *State) Run(
 	ctx context.Context,
 	httpOneAddrs []string,
@@ -356,8 +427,8 @@ func (s *State) Run(
 		HTTPOneAddrs:    httpOneAddrs,
 		TLSALPNOneAddrs: tlsALPNOneAddrs,
 		DNSOneAddrs:     dnsAddrs,
-		// Use a logger that has a load-generator prefix
-		Log: log.New(os.Stdout, "load-generator challsrv - ", log.LstdFlags),
+		// Use a logger that has a boulder-loadgen prefix
+		Log: log.New(os.Stdout, "boulder-loadgen challsrv - ", log.LstdFlags),
 	})
 	// Setup the challenge server to return the mock "fake DNS" IP address
 	challSrv.SetDefaultDNSIPv4(fakeDNS)
@@ -432,11 +503,20 @@ func (s *State) Run(
 	s.wg.Wait()
 	fmt.Println("[+] Shutting down challenge server")
 	s.challSrv.Shutdown()
+	fmt.Println("[+] Results")
+	s.stats.Report(os.Stdout)
 	return nil
 }
 
 // HTTP utils
 
+// addCall records the latency and result of a call in the results file and the
+// report printed at the end of the run.
+func (s *State) addCall(action string, sent, finished time.Time, state string) {
+	s.callLatency.Add(action, sent, finished, state)
+	s.stats.addCall(action, finished.Sub(sent), state == "good")
+}
+
 func (s *State) addRespCode(code int) {
 	s.cMu.Lock()
 	defer s.cMu.Unlock()
@@ -481,7 +561,7 @@ func (s *State) respCodeString() string {
 	return strings.Join(counts, ", ")
 }
 
-var userAgent = "boulder load-generator -- heyo ^_^"
+var userAgent = "boulder-loadgen -- heyo ^_^"
 
 func (s *State) post(
 	url string,
@@ -489,6 +569,50 @@ func (s *State) post(
 	ns *nonceSource,
 	latencyTag string,
 	expectedCode int) (*http.Response, error) {
+	resp, err := s.postRaw(url, payload, ns, latencyTag, func(code int) bool {
+		return code == expectedCode
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != expectedCode {
+		resp.Body.Close()
+		return nil, fmt.Errorf("POST %q returned HTTP status %d, expected %d",
+			url, resp.StatusCode, expectedCode)
+	}
+	return resp, nil
+}
+
+// postInjected POSTs a request into which a failure was injected, and returns
+// true if the server rejected it with a 4xx status, as it should.
+func (s *State) postInjected(
+	url string,
+	payload []byte,
+	ns *nonceSource,
+	latencyTag string) (bool, error) {
+	resp, err := s.postRaw(url, payload, ns, latencyTag, func(code int) bool {
+		return code/100 == 4
+	})
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	_, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return false, err
+	}
+	return resp.StatusCode/100 == 4, nil
+}
+
+// postRaw POSTs the payload to the URL, recording the latency of the request
+// and whether its response had an expected status. The caller is responsible
+// for closing the response body.
+func (s *State) postRaw(
+	url string,
+	payload []byte,
+	ns *nonceSource,
+	latencyTag string,
+	expected func(int) bool) (*http.Response, error) {
 	req, err := http.NewRequest("POST", url, bytes.NewBuffer(payload))
 	if err != nil {
 		return nil, err
@@ -503,7 +627,7 @@ func (s *State) post(
 	state := "error"
 	// Defer logging the latency and result
 	defer func() {
-		s.callLatency.Add(latencyTag, started, finished, state)
+		s.addCall(latencyTag, started, finished, state)
 	}()
 	if err != nil {
 		return nil, err
@@ -512,11 +636,9 @@ func (s *State) post(
 	if newNonce := resp.Header.Get("Replay-Nonce"); newNonce != "" {
 		ns.addNonce(newNonce)
 	}
-	if resp.StatusCode != expectedCode {
-		return nil, fmt.Errorf("POST %q returned HTTP status %d, expected %d",
-			url, resp.StatusCode, expectedCode)
+	if expected(resp.StatusCode) {
+		state = "good"
 	}
-	state = "good"
 	return resp, nil
 }
 
@@ -534,7 +656,7 @@ func (ns *nonceSource) getNonce() (string, error) {
 	finished := time.Now()
 	state := "error"
 	defer func() {
-		ns.s.callLatency.Add(fmt.Sprintf("HEAD %s", latencyTag),
+		ns.s.addCall(fmt.Sprintf("HEAD %s", latencyTag),
 			started, finished, state)
 	}()
 	if err != nil {
@@ -565,6 +687,21 @@ func (ns *nonceSource) Nonce() (string, error) {
 	return nonce, nil
 }
 
+// staticNonce is a jose.NonceSource which always returns the same nonce.
+type staticNonce string
+
+func (n staticNonce) Nonce() (string, error) {
+	return string(n), nil
+}
+
+// randNonce returns a random value which looks like, but isn't, a nonce
+// issued by the server.
+func randNonce() string {
+	var b [32]byte
+	_, _ = rand.Read(b[:])
+	return base64.RawURLEncoding.EncodeToString(b[:])
+}
+
 func (ns *nonceSource) addNonce(nonce string) {
 	ns.mu.Lock()
 	defer ns.mu.Unlock()
@@ -579,18 +716,34 @@ func (s *State) addAccount(acct *account) {
 	s.accts = append(s.accts, acct)
 }
 
+// removeAccount removes the provided account from the state's list of accts
+func (s *State) removeAccount(acct *account) {
+	s.rMu.Lock()
+	defer s.rMu.Unlock()
+
+	s.accts = slices.DeleteFunc(s.accts, func(a *account) bool { return a == acct })
+}
+
 func (s *State) sendCall() {
 	defer s.wg.Done()
 	c := &acmeCache{}
 
-	for _, op := range s.operations {
-		err := op(s, c)
+	f := pickWeighted(s.flows, func(f flow) int { return f.weight })
+	ok := true
+	for _, op := range f.operations {
+		err := op.fn(s, c)
+		if errors.Is(err, errInjectedFailure) {
+			// The server rejected an injected failure, so the rest of the
+			// flow can't be performed, but nothing went wrong.
+			break
+		}
 		if err != nil {
-			method := runtime.FuncForPC(reflect.ValueOf(op).Pointer()).Name()
-			fmt.Printf("[FAILED] %s: %s\n", method, err)
+			fmt.Printf("[FAILED] %s/%s: %s\n", f.name, op.name, err)
+			ok = false
 			break
 		}
 	}
+	s.stats.addFlow(f.name, ok)
 	// If the acmeCache's V2 account isn't nil, update it based on the cache's
 	// finalizedOrders and certs.
 	if c.acct != nil {
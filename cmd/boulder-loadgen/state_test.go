@@ -0,0 +1,54 @@
+package notmain
+
+import (
+	"testing"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestNewFlows(t *testing.T) {
+	flows, err := newFlows([]string{"newAccount", "newOrder"}, nil)
+	test.AssertNotError(t, err, "newFlows failed for plain actions")
+	test.AssertEquals(t, len(flows), 1)
+	test.AssertEquals(t, flows[0].name, "default")
+	test.AssertEquals(t, len(flows[0].operations), 2)
+	test.AssertEquals(t, flows[0].operations[1].name, "newOrder")
+
+	flows, err = newFlows(nil, []FlowConfig{
+		{Name: "issue", Weight: 9, Actions: []string{"newAccount", "newOrder", "fulfillOrder", "finalizeOrder"}},
+		{Name: "churn", Weight: 1, Actions: []string{"newAccount", "deactivateAccount"}},
+	})
+	test.AssertNotError(t, err, "newFlows failed for flows")
+	test.AssertEquals(t, len(flows), 2)
+	test.AssertEquals(t, flows[1].operations[1].name, "deactivateAccount")
+
+	_, err = newFlows([]string{"fly"}, nil)
+	test.AssertError(t, err, "unknown action should be rejected")
+	_, err = newFlows(nil, []FlowConfig{{Name: "empty", Weight: 1}})
+	test.AssertError(t, err, "flow without actions should be rejected")
+	_, err = newFlows(nil, []FlowConfig{{Name: "weightless", Actions: []string{"newAccount"}}})
+	test.AssertError(t, err, "flow without weight should be rejected")
+}
+
+func TestPickOrderSize(t *testing.T) {
+	s := &State{maxNamesPerCert: 1}
+	for range 100 {
+		test.AssertEquals(t, s.pickOrderSize(), 1)
+	}
+
+	s = &State{
+		maxNamesPerCert: 1,
+		orderSizes: []OrderSizeConfig{
+			{Names: 2, Weight: 3},
+			{Names: 100, Weight: 1},
+		},
+	}
+	picked := make(map[int]int)
+	for range 4000 {
+		picked[s.pickOrderSize()]++
+	}
+	test.AssertEquals(t, len(picked), 2)
+	// Expect about 3000 orders for two names, and 1000 for 100.
+	test.Assert(t, picked[2] > 2700 && picked[2] < 3300, "unexpected share of orders for two names")
+	test.Assert(t, picked[100] > 700 && picked[100] < 1300, "unexpected share of orders for 100 names")
+}
@@ -32,6 +32,11 @@ type Config struct {
 		// a chain, starting with the issuing intermediate, followed by one or
 		// more additional certificates, up to and including a root.
 		Chains [][]string `validate:"min=1,dive,min=2,dive,required"`
+
+		// LogLimits limits the rate and concurrency of submissions to each CT
+		// log, so that one slow log can't back up submissions to the others.
+		// If omitted, submissions aren't limited.
+		LogLimits publisher.LimitsConfig
 	}
 
 	Syslog        cmd.SyslogConfig
@@ -91,6 +96,7 @@ func main() {
 	clk := cmd.Clock()
 
 	pubi := publisher.New(bundles, c.Publisher.UserAgent, logger, scope)
+	pubi.SetLogLimits(c.Publisher.LogLimits)
 
 	start, err := bgrpc.NewServer(c.Publisher.GRPC, logger).Add(
 		&pubpb.Publisher_ServiceDesc, pubi).Build(tlsConfig, scope, clk)
@@ -24,6 +24,7 @@ import (
 	"github.com/letsencrypt/boulder/ratelimits"
 	bredis "github.com/letsencrypt/boulder/redis"
 	sapb "github.com/letsencrypt/boulder/sa/proto"
+	"github.com/letsencrypt/boulder/skewclock"
 	"github.com/letsencrypt/boulder/va"
 	vapb "github.com/letsencrypt/boulder/va/proto"
 )
@@ -70,6 +71,11 @@ type Config struct {
 			// Note: At this time, only the Failed Authorizations overrides are
 			// necessary in the RA.
 			Overrides string
+
+			// Keys configures how the bucket keys of limits which group
+			// requests by address block or registered domain are derived. It
+			// must be identical to that in the WFE.
+			Keys ratelimits.KeyConfig
 		}
 
 		// MaxNames is the maximum number of subjectAltNames in a single cert.
@@ -114,6 +120,21 @@ type Config struct {
 		// complete.
 		FinalizeTimeout config.Duration `validate:"-"`
 
+		// CAARecheck configures background rechecking of CAA for reused
+		// authorizations whose CAA checks will soon be too old to reuse at
+		// finalize time. If unset, all CAA rechecks happen during finalize.
+		CAARecheck ra.CAARecheckConfig
+
+		// AccountRevocation configures the background revocation of the
+		// certificates of deactivated accounts which requested it. If unset,
+		// this RA leaves their revocation to other RAs.
+		AccountRevocation ra.AccountRevocationConfig
+
+		// CSRPolicy configures additional checks applied to CSRs at finalize
+		// time. If unset, only the identifiers and key of each CSR are checked
+		// against its order and account.
+		CSRPolicy ra.CSRPolicyConfig
+
 		// CTLogs contains groupings of CT logs organized by what organization
 		// operates them. When we submit precerts to logs in order to get SCTs, we
 		// will submit the cert to one randomly-chosen log from each group, and use
@@ -161,6 +182,13 @@ func main() {
 		c.RA.DebugAddr = *debugAddr
 	}
 
+	run(c)
+}
+
+// run runs the RA until the process is signaled to stop. Features must already
+// be set.
+func run(c Config) {
+
 	scope, logger, oTelShutdown := cmd.StatsAndLogging(c.Syslog, c.OpenTelemetry, c.RA.DebugAddr)
 	defer oTelShutdown(context.Background())
 	logger.Info(cmd.VersionString())
@@ -172,6 +200,9 @@ func main() {
 	pa, err := policy.New(c.PA.Identifiers, c.PA.Challenges, logger)
 	cmd.FailOnError(err, "Couldn't create PA")
 
+	err = pa.SetConfusableIDNAction(policy.ConfusableIDNAction(c.PA.ConfusableIDNs))
+	cmd.FailOnError(err, "Invalid PA configuration")
+
 	if c.RA.HostnamePolicyFile == "" {
 		cmd.Fail("HostnamePolicyFile must be provided.")
 	}
@@ -181,7 +212,7 @@ func main() {
 	tlsConfig, err := c.RA.TLS.Load(scope)
 	cmd.FailOnError(err, "TLS config")
 
-	clk := cmd.Clock()
+	clk := skewclock.New(cmd.Clock(), skewclock.Config{}, scope, logger)
 
 	vaConn, err := bgrpc.ClientSetup(c.RA.VAService, tlsConfig, scope, clk)
 	cmd.FailOnError(err, "Unable to create VA client")
@@ -261,19 +292,27 @@ func main() {
 
 	kp, err := sagoodkey.NewPolicy(&c.RA.GoodKey, sac.KeyBlocked)
 	cmd.FailOnError(err, "Unable to create key policy")
+	kp.RegisterMetrics(scope)
 
 	var limiter *ratelimits.Limiter
 	var txnBuilder *ratelimits.TransactionBuilder
 	var limiterRedis *bredis.Ring
 	if c.RA.Limiter.Defaults != "" {
 		// Setup rate limiting.
-		limiterRedis, err = bredis.NewRingFromConfig(*c.RA.Limiter.Redis, scope, logger)
-		cmd.FailOnError(err, "Failed to create Redis ring")
+		var redisClient ratelimits.RedisClient
+		if len(c.RA.Limiter.Redis.ClusterAddrs) != 0 {
+			redisClient, err = bredis.NewClusterFromConfig(*c.RA.Limiter.Redis, scope)
+			cmd.FailOnError(err, "Failed to create Redis cluster client")
+		} else {
+			limiterRedis, err = bredis.NewRingFromConfig(*c.RA.Limiter.Redis, scope, logger)
+			cmd.FailOnError(err, "Failed to create Redis ring")
+			redisClient = limiterRedis.Ring
+		}
 
-		source := ratelimits.NewRedisSource(limiterRedis.Ring, clk, scope)
-		limiter, err = ratelimits.NewLimiter(clk, source, scope)
+		source := ratelimits.NewRedisSource(redisClient, clk, scope)
+		limiter, err = ratelimits.NewLimiter(clk, source, scope, logger)
 		cmd.FailOnError(err, "Failed to create rate limiter")
-		txnBuilder, err = ratelimits.NewTransactionBuilderFromFiles(c.RA.Limiter.Defaults, c.RA.Limiter.Overrides)
+		txnBuilder, err = ratelimits.NewTransactionBuilderFromFiles(c.RA.Limiter.Defaults, c.RA.Limiter.Overrides, c.RA.Limiter.Keys)
 		cmd.FailOnError(err, "Failed to create rate limits transaction builder")
 	}
 
@@ -304,6 +343,11 @@ func main() {
 	rai.CA = cac
 	rai.OCSP = ocspc
 	rai.SA = sac
+	rai.EnableCAARecheckScheduler(scope, c.RA.CAARecheck)
+	err = rai.EnableAccountRevocation(c.RA.AccountRevocation)
+	cmd.FailOnError(err, "Invalid account revocation config")
+	err = rai.SetCSRPolicy(c.RA.CSRPolicy)
+	cmd.FailOnError(err, "Invalid CSR policy")
 
 	start, err := bgrpc.NewServer(c.RA.GRPC, logger).Add(
 		&rapb.RegistrationAuthority_ServiceDesc, rai).Add(
@@ -316,4 +360,5 @@ func main() {
 
 func init() {
 	cmd.RegisterCommand("boulder-ra", main, &cmd.ConfigValidator{Config: &Config{}})
+	cmd.RegisterRunner("boulder-ra", run)
 }
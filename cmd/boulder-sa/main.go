@@ -27,6 +27,35 @@ type Config struct {
 		// LagFactor is how long to sleep before retrying a read request that may
 		// have failed solely due to replication lag.
 		LagFactor config.Duration `validate:"-"`
+
+		// QueryBudgets configures per-RPC and per-table latency budgets for
+		// database queries. Queries which exceed a budget are canceled.
+		QueryBudgets sa.QueryBudgetConfig
+
+		// PoolPartitions, if set, divides the database connections between
+		// priority classes of RPCs, so that batch jobs can't starve issuance
+		// of connections.
+		PoolPartitions *sa.PoolPartitionConfig
+
+		// Partitions, if set, configures this SA to manage the partitions of
+		// its largest tables. It should be set on only one SA instance.
+		Partitions *sa.PartitionConfig
+
+		// OrderSweep, if set, configures this SA to periodically move expired
+		// pending authorizations to the expired status. It should be set on
+		// only one SA instance.
+		OrderSweep *sa.OrderSweepConfig
+
+		// CertScrub, if set, configures this SA to continuously check stored
+		// certificates and precertificates for corruption. It reads from
+		// ReadOnlyDB, if configured, since that's what serves them.
+		CertScrub *sa.CertScrubConfig
+
+		// AuthzCountReconcile, if set, configures this SA to periodically
+		// correct the pending authorization counters maintained when the
+		// MaintainAuthzCounts feature is enabled. It should be set on only one
+		// SA instance.
+		AuthzCountReconcile *sa.AuthzCountReconcileConfig
 	}
 
 	Syslog        cmd.SyslogConfig
@@ -56,6 +85,12 @@ func main() {
 		c.SA.DebugAddr = *debugAddr
 	}
 
+	run(c)
+}
+
+// run runs the SA until the process is signaled to stop. Features must already
+// be set.
+func run(c Config) {
 	scope, logger, oTelShutdown := cmd.StatsAndLogging(c.Syslog, c.OpenTelemetry, c.SA.DebugAddr)
 	defer oTelShutdown(context.Background())
 	logger.Info(cmd.VersionString())
@@ -75,8 +110,39 @@ func main() {
 		cmd.FailOnError(err, "While initializing dbIncidentsMap")
 	}
 
+	budgets := sa.NewQueryBudgets(c.SA.QueryBudgets, scope)
+	dbMap = dbMap.WithQueryLimiter(budgets)
+	dbReadOnlyMap = dbReadOnlyMap.WithQueryLimiter(budgets)
+	dbIncidentsMap = dbIncidentsMap.WithQueryLimiter(budgets)
+
 	clk := cmd.Clock()
 
+	if c.SA.Partitions != nil {
+		partitionsMap, err := sa.InitWrappedDb(c.SA.Partitions.DB, scope, logger)
+		cmd.FailOnError(err, "While initializing partitions dbMap")
+		pm, err := sa.NewPartitionManager(*c.SA.Partitions, partitionsMap, clk, scope, logger)
+		cmd.FailOnError(err, "Failed to create partition manager")
+		go pm.Run(context.Background())
+	}
+
+	if c.SA.OrderSweep != nil {
+		sweeper, err := sa.NewOrderSweeper(*c.SA.OrderSweep, dbMap, clk, scope, logger)
+		cmd.FailOnError(err, "Failed to create order sweeper")
+		go sweeper.Run(context.Background())
+	}
+
+	if c.SA.CertScrub != nil {
+		scrubber, err := sa.NewCertScrubber(*c.SA.CertScrub, dbReadOnlyMap, clk, scope, logger)
+		cmd.FailOnError(err, "Failed to create certificate scrubber")
+		go scrubber.Run(context.Background())
+	}
+
+	if c.SA.AuthzCountReconcile != nil {
+		reconciler, err := sa.NewAuthzCountReconciler(*c.SA.AuthzCountReconcile, dbMap, clk, scope, logger)
+		cmd.FailOnError(err, "Failed to create authorization count reconciler")
+		go reconciler.Run(context.Background())
+	}
+
 	parallel := c.SA.ParallelismPerRPC
 	if parallel < 1 {
 		parallel = 1
@@ -92,7 +158,13 @@ func main() {
 	sai, err := sa.NewSQLStorageAuthorityWrapping(saroi, dbMap, scope)
 	cmd.FailOnError(err, "Failed to create SA impl")
 
-	start, err := bgrpc.NewServer(c.SA.GRPC, logger).WithCheckInterval(c.SA.HealthCheckInterval.Duration).Add(
+	srv := bgrpc.NewServer(c.SA.GRPC, logger).WithCheckInterval(c.SA.HealthCheckInterval.Duration).WithUnaryInterceptor(budgets.Unary)
+	if c.SA.PoolPartitions != nil {
+		partitions, err := sa.NewPoolPartitions(*c.SA.PoolPartitions, scope)
+		cmd.FailOnError(err, "Failed to create pool partitions")
+		srv = srv.WithUnaryInterceptor(partitions.Unary).WithStreamInterceptor(partitions.Stream)
+	}
+	start, err := srv.Add(
 		&sapb.StorageAuthorityReadOnly_ServiceDesc, saroi).Add(
 		&sapb.StorageAuthority_ServiceDesc, sai).Build(
 		tls, scope, clk)
@@ -103,4 +175,5 @@ func main() {
 
 func init() {
 	cmd.RegisterCommand("boulder-sa", main, &cmd.ConfigValidator{Config: &Config{}})
+	cmd.RegisterRunner("boulder-sa", run)
 }
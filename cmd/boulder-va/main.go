@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/letsencrypt/boulder/bdns"
+	caapb "github.com/letsencrypt/boulder/caa/proto"
 	"github.com/letsencrypt/boulder/cmd"
 	"github.com/letsencrypt/boulder/features"
 	bgrpc "github.com/letsencrypt/boulder/grpc"
@@ -76,12 +77,20 @@ func main() {
 	cmd.FailOnError(err, "Setting and validating default config values")
 
 	features.Set(c.VA.Features)
+
+	run(c)
+}
+
+// run runs the VA until the process is signaled to stop. Features must already
+// be set.
+func run(c Config) {
 	scope, logger, oTelShutdown := cmd.StatsAndLogging(c.Syslog, c.OpenTelemetry, c.VA.DebugAddr)
 	defer oTelShutdown(context.Background())
 	logger.Info(cmd.VersionString())
 	clk := cmd.Clock()
 
 	var servers bdns.ServerProvider
+	var err error
 
 	if len(c.VA.DNSStaticResolvers) != 0 {
 		servers, err = bdns.NewStaticProvider(c.VA.DNSStaticResolvers)
@@ -92,6 +101,18 @@ func main() {
 	}
 	defer servers.Stop()
 
+	var txtServers bdns.ServerProvider
+	if len(c.VA.DNSTXTStaticResolvers) != 0 {
+		txtServers, err = bdns.NewStaticProvider(c.VA.DNSTXTStaticResolvers)
+		cmd.FailOnError(err, "Couldn't start static DNS TXT server resolver")
+	} else if c.VA.DNSTXTProvider != nil {
+		txtServers, err = bdns.StartDynamicProvider(c.VA.DNSTXTProvider, 60*time.Second, "tcp")
+		cmd.FailOnError(err, "Couldn't start dynamic DNS TXT server resolver")
+	}
+	if txtServers != nil {
+		defer txtServers.Stop()
+	}
+
 	tlsConfig, err := c.VA.TLS.Load(scope)
 	cmd.FailOnError(err, "tlsConfig config")
 
@@ -117,6 +138,10 @@ func main() {
 			logger,
 			tlsConfig)
 	}
+	if txtServers != nil {
+		bdns.SetTXTServers(resolver, txtServers)
+	}
+
 	var remotes []va.RemoteVA
 	if len(c.VA.RemoteVAs) > 0 {
 		for _, rva := range c.VA.RemoteVAs {
@@ -127,8 +152,9 @@ func main() {
 				remotes,
 				va.RemoteVA{
 					RemoteClients: va.RemoteClients{
-						VAClient:  vapb.NewVAClient(vaConn),
-						CAAClient: vapb.NewCAAClient(vaConn),
+						VAClient:             vapb.NewVAClient(vaConn),
+						CAAClient:            vapb.NewCAAClient(vaConn),
+						ChallengePortsClient: vapb.NewChallengePortsClient(vaConn),
 					},
 					Address:     rva.ServerAddress,
 					Perspective: rva.Perspective,
@@ -149,16 +175,63 @@ func main() {
 		c.VA.AccountURIPrefixes,
 		va.PrimaryPerspective,
 		"",
-		iana.IsReservedAddr)
+		iana.IsReservedAddr,
+		c.VA.DNSMaxAliasDepth)
 	cmd.FailOnError(err, "Unable to create VA server")
 
+	if c.VA.CAAChecker != nil {
+		caaConn, err := bgrpc.ClientSetup(c.VA.CAAChecker, tlsConfig, scope, clk)
+		cmd.FailOnError(err, "Unable to create caa-checker client")
+		vai.SetCAAChecker(caapb.NewCAACheckerClient(caaConn))
+	}
+
+	if c.VA.ChallengePorts != nil {
+		err = vai.SetChallengePorts(*c.VA.ChallengePorts)
+		cmd.FailOnError(err, "Unable to configure challenge ports")
+	}
+
+	if len(c.VA.HTTPFingerprints) > 0 {
+		err = vai.SetHTTPFingerprints(c.VA.HTTPFingerprints)
+		cmd.FailOnError(err, "Unable to configure HTTP fingerprints")
+	}
+	if c.VA.HTTPRedirectPolicy != nil {
+		err = vai.SetHTTPRedirectPolicy(*c.VA.HTTPRedirectPolicy)
+		cmd.FailOnError(err, "Unable to configure HTTP redirect policy")
+	}
+	if c.VA.ValidationConcurrency != nil {
+		err = vai.SetValidationConcurrency(*c.VA.ValidationConcurrency)
+		cmd.FailOnError(err, "Unable to configure validation concurrency limits")
+	}
+	if c.VA.HTTPResponseArchive != nil {
+		err = vai.SetHTTPResponseArchive(*c.VA.HTTPResponseArchive)
+		cmd.FailOnError(err, "Unable to configure HTTP response archive")
+	}
+	if c.VA.HTTPBandwidth != nil {
+		err = vai.SetHTTPBandwidth(*c.VA.HTTPBandwidth)
+		cmd.FailOnError(err, "Unable to configure HTTP bandwidth limits")
+	}
+
+	if len(remotes) > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		err = vai.CheckRemoteChallengePorts(ctx)
+		cancel()
+		cmd.FailOnError(err, "Remote VAs disagree about challenge ports")
+	}
+
 	start, err := bgrpc.NewServer(c.VA.GRPC, logger).Add(
 		&vapb.VA_ServiceDesc, vai).Add(
-		&vapb.CAA_ServiceDesc, vai).Build(tlsConfig, scope, clk)
+		&vapb.CAA_ServiceDesc, vai).Add(
+		&vapb.HTTPResponseArchive_ServiceDesc, vai).Add(
+		&vapb.ChallengePorts_ServiceDesc, vai).Build(tlsConfig, scope, clk)
 	cmd.FailOnError(err, "Unable to setup VA gRPC server")
 	cmd.FailOnError(start(), "VA gRPC service failed")
 }
 
 func init() {
 	cmd.RegisterCommand("boulder-va", main, &cmd.ConfigValidator{Config: &Config{}})
+	cmd.RegisterRunner("boulder-va", func(c Config) {
+		err := c.VA.SetDefaultsAndValidate(new(string), new(string))
+		cmd.FailOnError(err, "Setting and validating default config values")
+		run(c)
+	})
 }
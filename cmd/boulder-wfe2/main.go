@@ -3,9 +3,13 @@ package notmain
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/pem"
+	"errors"
 	"flag"
 	"fmt"
+	"io/fs"
+	"net"
 	"net/http"
 	"os"
 	"time"
@@ -13,6 +17,7 @@ import (
 	"github.com/letsencrypt/boulder/cmd"
 	"github.com/letsencrypt/boulder/config"
 	emailpb "github.com/letsencrypt/boulder/email/proto"
+	exemptionspb "github.com/letsencrypt/boulder/exemptions/proto"
 	"github.com/letsencrypt/boulder/features"
 	"github.com/letsencrypt/boulder/goodkey"
 	"github.com/letsencrypt/boulder/goodkey/sagoodkey"
@@ -46,6 +51,42 @@ type Config struct {
 		// lower than the upstream's timeout when making requests to this service.
 		Timeout config.Duration `validate:"-"`
 
+		// RouteTimeouts overrides Timeout for individual endpoints, keyed by
+		// the endpoint's path pattern, e.g. "/acme/new-nonce" or
+		// "/acme/finalize/". The server's write timeout for these endpoints is
+		// adjusted to match.
+		RouteTimeouts map[string]config.Duration `validate:"omitempty,dive,keys,startswith=/,endkeys"`
+
+		// FinalizeKeepaliveInterval, if set, is how often an interim 102
+		// (Processing) response is sent to HTTP/2 clients while a finalize
+		// request is still in progress, so that idle connection timeouts in
+		// load balancers don't expire during long finalizations.
+		FinalizeKeepaliveInterval config.Duration `validate:"-"`
+
+		// ValidationWait, if set, is how long a poll of a pending
+		// authorization or challenge waits for the RA to finish a validation
+		// of it which is in progress, so that aggressive clients get the
+		// result at once instead of polling repeatedly. Only the RA which is
+		// performing the validation can answer, so with several RAs the
+		// RAService should set SRVResolver to "affinity-srv", which sends the
+		// validation and the polls of each authorization to the same RA.
+		// Otherwise some polls are answered from the SA as before. It must be
+		// shorter than the WFE's request timeout.
+		ValidationWait config.Duration `validate:"-"`
+
+		// DiscardContacts, if set, causes the WFE to keep no contact
+		// information at all: the contacts in new-account and account update
+		// requests are discarded without being validated or sent to the
+		// email-exporter or contact-verifier, and a Warning header tells the
+		// client so. Requests aren't rejected, so that clients which always
+		// send a contact keep working.
+		DiscardContacts bool
+
+		// HTTP2 enables HTTP/2 on both listeners. On ListenAddress, which does
+		// not use TLS, this is HTTP/2 with prior knowledge (h2c), intended for
+		// use behind a load balancer that speaks it.
+		HTTP2 bool
+
 		// ShutdownStopTimeout determines the maximum amount of time to wait
 		// for extant request handlers to complete before exiting. It should be
 		// greater than Timeout.
@@ -64,6 +105,35 @@ type Config struct {
 		SAService     *cmd.GRPCClientConfig
 		EmailExporter *cmd.GRPCClientConfig
 
+		// ContactVerifier, if set, is sent the contacts of new accounts to
+		// verify. Contacts which it has found to be invalid are not sent to
+		// the EmailExporter.
+		ContactVerifier *cmd.GRPCClientConfig
+
+		// ExemptionQueue, if set, is sent the rate limit exemption requests
+		// which subscribers submit to the Boulder-specific
+		// rate-limit-exemption endpoint, which is only served if it's set.
+		ExemptionQueue *cmd.GRPCClientConfig
+
+		// ClientIdentity, if set, binds each new account to the identity in the
+		// TLS client certificate with which it was created, so that only the
+		// holder of that certificate can use the account. It's intended for
+		// internal deployments in which every ACME client has a certificate
+		// from a private CA. Client certificates are requested, but not
+		// required, by the TLS listener.
+		ClientIdentity *wfe2.ClientIdentityConfig
+
+		// SelfIssuance, if set, has the WFE obtain and renew the certificate
+		// for TLSListenAddress from this Boulder instance, keeping it at
+		// ServerCertificatePath and ServerKeyPath, instead of requiring one to
+		// be provisioned there from elsewhere.
+		SelfIssuance *wfe2.SelfIssuanceConfig
+
+		// NoncePrefetch, if set, includes a fresh Replay-Nonce in every
+		// response, including GETs, errors, and 404s, taking them from a pool
+		// of nonces fetched ahead of time from the GetNonceService.
+		NoncePrefetch *wfe2.NoncePrefetchConfig
+
 		// GetNonceService is a gRPC config which contains a single SRV name
 		// used to lookup nonce-service instances used exclusively for nonce
 		// creation. In a multi-DC deployment this should refer to local
@@ -84,6 +154,22 @@ type Config struct {
 		// boulder-wfe and nonce-service instances.
 		NonceHMACKey cmd.HMACKeyConfig `validate:"-"`
 
+		// RotationNonceHMACKeys are HMAC keys which nonce-service instances
+		// are scheduled to rotate to, or have recently rotated from, in place
+		// of NonceHMACKey. Nonces with prefixes derived from them are routed
+		// for redemption like those derived from NonceHMACKey.
+		RotationNonceHMACKeys []cmd.HMACKeyConfig `validate:"omitempty,dive"`
+
+		// NoncePrefixRoutes maps nonce prefixes to the "host:port" address of
+		// a redeemNonceService backend which should redeem nonces carrying
+		// that prefix. It's only consulted for prefixes which can't be
+		// derived from the resolved backends, e.g. nonces minted in another
+		// datacenter. When configured, nonces with prefixes matching neither
+		// are sent to a backend chosen by consistent hashing of the prefix,
+		// which is expected to route them onwards using the "nonce-prefix"
+		// gRPC header.
+		NoncePrefixRoutes map[string]string `validate:"omitempty,dive,keys,len=8,endkeys,hostname_port"`
+
 		// Chains is a list of lists of certificate filenames. Each inner list is
 		// a chain (starting with the issuing intermediate, followed by one or
 		// more additional certificates, up to and including a root) which we are
@@ -132,6 +218,60 @@ type Config struct {
 		// contacts than this are rejected. Default: 10.
 		MaxContactsPerRegistration int `validate:"omitempty,min=1"`
 
+		// ContactPolicy, if set, configures which contact schemes and mail
+		// domains NewAccount requests may use, and whether rejections name
+		// every invalid contact. Otherwise, only mailto: contacts are
+		// accepted.
+		ContactPolicy *wfe2.ContactPolicyConfig
+
+		// JWSAlgorithms, if set, configures which JWS signature algorithms
+		// requests may be signed with, and which of those are deprecated.
+		// Otherwise, RS256, ES256, ES384 and ES512 are accepted.
+		JWSAlgorithms *wfe2.JWSAlgorithmPolicyConfig
+
+		// Maintenance, if set, configures endpoints which are temporarily out
+		// of service, and optionally an admin socket for changing them at
+		// runtime.
+		Maintenance *wfe2.MaintenanceConfig
+
+		// ClientTelemetry, if set, configures which ACME clients are given
+		// their own metrics labels, and which client versions are refused
+		// because they're known to be broken.
+		ClientTelemetry *wfe2.ClientTelemetryConfig
+
+		// ProblemMessages, if set, configures translations of problem
+		// document details, chosen by each request's Accept-Language header.
+		// Otherwise, problem documents are always in English.
+		ProblemMessages *wfe2.ProblemMessagesConfig
+
+		// ReplayCache, if set, remembers the signatures of JWS request bodies
+		// in Redis for a short window, and rejects any redeemed twice, in case
+		// the nonce service wrongly accepts a replayed nonce.
+		ReplayCache *wfe2.ReplayCacheConfig
+
+		// LogIdentifierHashing, if set, replaces the identifiers and account
+		// IDs in request logs with keyed hashes which rotate periodically.
+		LogIdentifierHashing *cmd.IdentifierHashingConfig
+
+		// PreflightOrders, if true, serves an endpoint at
+		// /debug/preflight-order which runs the policy, rate limit, and CAA
+		// checks for a hypothetical order, so that integrators can check
+		// before requesting many certificates.
+		PreflightOrders bool
+
+		// AccountKeyAlgorithms, if true, serves an endpoint at
+		// /debug/account-key-algorithm which reports the algorithm of the
+		// requesting account's key and whether it's deprecated, so that
+		// integrators can roll over their keys before it's disabled.
+		AccountKeyAlgorithms bool
+
+		// RateLimitDebugHeaders, if true, adds an X-RateLimit-Debug header to
+		// new-account and new-order responses for each rate limit bucket
+		// consulted, so that integrators can diagnose which limits their
+		// requests interact with. It exposes internal bucket keys, so it's
+		// only meant for staging environments.
+		RateLimitDebugHeaders bool
+
 		AccountCache *CacheConfig
 
 		Limiter struct {
@@ -154,6 +294,12 @@ type Config struct {
 			// overrides passed in this file must be identical to those in the
 			// RA.
 			Overrides string
+
+			// Keys configures how the bucket keys of limits which group
+			// requests by address block or registered domain are derived, e.g.
+			// the length of the IPv6 prefixes by which certificates for IP
+			// addresses are counted. It must be identical to that in the RA.
+			Keys ratelimits.KeyConfig
 		}
 
 		// CertProfiles is a map of acceptable certificate profile names to
@@ -162,6 +308,12 @@ type Config struct {
 		// This field is optional; if unset, no profile names are accepted.
 		CertProfiles map[string]string `validate:"omitempty,dive,keys,alphanum,min=1,max=32,endkeys"`
 
+		// Directories, if set, are additional ACME directories served under
+		// their own hostnames or path prefixes, each of which may offer its
+		// own subset of CertProfiles, terms of service, and website. Requests
+		// which match none of them are served by the default directory.
+		Directories []wfe2.DirectoryConfig `validate:"omitempty,dive"`
+
 		Unpause struct {
 			// HMACKey signs outgoing JWTs for redemption at the unpause
 			// endpoint. This key must match the one configured for all SFEs.
@@ -242,6 +394,13 @@ func main() {
 		c.WFE.DebugAddr = *debugAddr
 	}
 
+	run(c)
+}
+
+// run runs the WFE until the process is signaled to stop. Features must already
+// be set.
+func run(c Config) {
+
 	certChains := map[issuance.NameID][][]byte{}
 	issuerCerts := map[issuance.NameID]*issuance.Certificate{}
 	for _, files := range c.WFE.Chains {
@@ -263,6 +422,7 @@ func main() {
 
 	clk := cmd.Clock()
 
+	var err error
 	var unpauseSigner unpause.JWTSigner
 	if features.Get().CheckIdentifiersPaused {
 		unpauseSigner, err = unpause.NewJWTSigner(c.WFE.Unpause.HMACKey)
@@ -287,6 +447,20 @@ func main() {
 		eec = emailpb.NewExporterClient(emailExporterConn)
 	}
 
+	var cvc emailpb.ContactVerifierClient
+	if c.WFE.ContactVerifier != nil {
+		contactVerifierConn, err := bgrpc.ClientSetup(c.WFE.ContactVerifier, tlsConfig, stats, clk)
+		cmd.FailOnError(err, "Failed to load credentials and create gRPC connection to contact-verifier")
+		cvc = emailpb.NewContactVerifierClient(contactVerifierConn)
+	}
+
+	var eqc exemptionspb.ExemptionQueueClient
+	if c.WFE.ExemptionQueue != nil {
+		exemptionQueueConn, err := bgrpc.ClientSetup(c.WFE.ExemptionQueue, tlsConfig, stats, clk)
+		cmd.FailOnError(err, "Failed to load credentials and create gRPC connection to exemption-queue")
+		eqc = exemptionspb.NewExemptionQueueClient(exemptionQueueConn)
+	}
+
 	if c.WFE.RedeemNonceService == nil {
 		cmd.Fail("'redeemNonceService' must be configured.")
 	}
@@ -312,6 +486,7 @@ func main() {
 
 	kp, err := sagoodkey.NewPolicy(&c.WFE.GoodKey, sac.KeyBlocked)
 	cmd.FailOnError(err, "Unable to create key policy")
+	kp.RegisterMetrics(stats)
 
 	if c.WFE.StaleTimeout.Duration == 0 {
 		c.WFE.StaleTimeout.Duration = time.Minute * 10
@@ -326,13 +501,20 @@ func main() {
 	var limiterRedis *bredis.Ring
 	if c.WFE.Limiter.Defaults != "" {
 		// Setup rate limiting.
-		limiterRedis, err = bredis.NewRingFromConfig(*c.WFE.Limiter.Redis, stats, logger)
-		cmd.FailOnError(err, "Failed to create Redis ring")
+		var redisClient ratelimits.RedisClient
+		if len(c.WFE.Limiter.Redis.ClusterAddrs) != 0 {
+			redisClient, err = bredis.NewClusterFromConfig(*c.WFE.Limiter.Redis, stats)
+			cmd.FailOnError(err, "Failed to create Redis cluster client")
+		} else {
+			limiterRedis, err = bredis.NewRingFromConfig(*c.WFE.Limiter.Redis, stats, logger)
+			cmd.FailOnError(err, "Failed to create Redis ring")
+			redisClient = limiterRedis.Ring
+		}
 
-		source := ratelimits.NewRedisSource(limiterRedis.Ring, clk, stats)
-		limiter, err = ratelimits.NewLimiter(clk, source, stats)
+		source := ratelimits.NewRedisSource(redisClient, clk, stats)
+		limiter, err = ratelimits.NewLimiter(clk, source, stats, logger)
 		cmd.FailOnError(err, "Failed to create rate limiter")
-		txnBuilder, err = ratelimits.NewTransactionBuilderFromFiles(c.WFE.Limiter.Defaults, c.WFE.Limiter.Overrides)
+		txnBuilder, err = ratelimits.NewTransactionBuilderFromFiles(c.WFE.Limiter.Defaults, c.WFE.Limiter.Overrides, c.WFE.Limiter.Keys)
 		cmd.FailOnError(err, "Failed to create rate limits transaction builder")
 	}
 
@@ -359,6 +541,8 @@ func main() {
 		rac,
 		sac,
 		eec,
+		cvc,
+		eqc,
 		gnc,
 		rnc,
 		noncePrefixKey,
@@ -377,6 +561,92 @@ func main() {
 	wfe.DirectoryCAAIdentity = c.WFE.DirectoryCAAIdentity
 	wfe.DirectoryWebsite = c.WFE.DirectoryWebsite
 	wfe.LegacyKeyIDPrefix = c.WFE.LegacyKeyIDPrefix
+	wfe.FinalizeKeepaliveInterval = c.WFE.FinalizeKeepaliveInterval.Duration
+	wfe.ValidationWait = c.WFE.ValidationWait.Duration
+	wfe.DiscardContacts = c.WFE.DiscardContacts
+	wfe.NoncePrefixRoutes = c.WFE.NoncePrefixRoutes
+	for _, rotationKey := range c.WFE.RotationNonceHMACKeys {
+		key, err := rotationKey.Load()
+		cmd.FailOnError(err, "Failed to load rotationNonceHMACKeys file")
+		wfe.RotationNonceKeys = append(wfe.RotationNonceKeys, key)
+	}
+	wfe.PreflightOrders = c.WFE.PreflightOrders
+	wfe.AccountKeyAlgorithms = c.WFE.AccountKeyAlgorithms
+	wfe.RateLimitDebugHeaders = c.WFE.RateLimitDebugHeaders
+
+	if c.WFE.ClientIdentity != nil {
+		wfe.ClientIdentifier, err = wfe2.NewClientIdentifier(*c.WFE.ClientIdentity)
+		cmd.FailOnError(err, "Unable to configure client identity")
+	}
+
+	var selfIssuer *wfe2.SelfIssuer
+	if c.WFE.SelfIssuance != nil {
+		if c.WFE.TLSListenAddress == "" {
+			cmd.Fail("'selfIssuance' requires 'tlsListenAddress'")
+		}
+		_, ok := c.WFE.CertProfiles[c.WFE.SelfIssuance.Profile]
+		if !ok {
+			cmd.Fail(fmt.Sprintf("'selfIssuance.profile' %q is not one of 'certProfiles'", c.WFE.SelfIssuance.Profile))
+		}
+		selfIssuer, err = wfe2.NewSelfIssuer(*c.WFE.SelfIssuance, c.WFE.ServerCertificatePath, c.WFE.ServerKeyPath, clk, logger)
+		cmd.FailOnError(err, "Unable to configure self-issuance")
+	}
+
+	if c.WFE.ContactPolicy != nil {
+		wfe.ContactPolicy, err = wfe2.NewContactPolicy(*c.WFE.ContactPolicy)
+		cmd.FailOnError(err, "Unable to configure contact policy")
+	}
+
+	if len(c.WFE.Directories) != 0 {
+		wfe.Directories, err = wfe2.NewDirectories(c.WFE.Directories, c.WFE.CertProfiles)
+		cmd.FailOnError(err, "Unable to configure directories")
+	}
+
+	if c.WFE.JWSAlgorithms != nil {
+		wfe.JWSAlgorithms, err = wfe2.NewJWSAlgorithmPolicy(*c.WFE.JWSAlgorithms)
+		cmd.FailOnError(err, "Unable to configure JWS algorithm policy")
+	}
+
+	if c.WFE.Maintenance != nil {
+		wfe.Maintenance, err = wfe2.NewMaintenance(c.WFE.Maintenance.Endpoints, logger)
+		cmd.FailOnError(err, "Unable to configure maintenance")
+	}
+
+	if c.WFE.ClientTelemetry != nil {
+		wfe.ClientTelemetry, err = wfe2.NewClientTelemetry(*c.WFE.ClientTelemetry)
+		cmd.FailOnError(err, "Unable to configure client telemetry")
+	}
+
+	if c.WFE.ProblemMessages != nil {
+		wfe.ProblemMessages, err = wfe2.NewProblemMessages(*c.WFE.ProblemMessages)
+		cmd.FailOnError(err, "Unable to configure problem messages")
+	}
+
+	var replayRedis *bredis.Ring
+	if c.WFE.ReplayCache != nil {
+		replayRedis, err = bredis.NewRingFromConfig(c.WFE.ReplayCache.Redis, stats, logger)
+		cmd.FailOnError(err, "Failed to create replay cache Redis ring")
+		wfe.ReplayCache = wfe2.NewReplayCache(replayRedis.Ring, c.WFE.ReplayCache.Window.Duration, stats, logger)
+	}
+
+	if c.WFE.LogIdentifierHashing != nil {
+		secret, err := c.WFE.LogIdentifierHashing.Secret.Load()
+		cmd.FailOnError(err, "Failed to load log identifier hashing secret")
+		wfe.LogHasher, err = web.NewIdentifierHasher(secret, c.WFE.LogIdentifierHashing.RotationPeriod.Duration)
+		cmd.FailOnError(err, "Unable to configure log identifier hashing")
+	}
+
+	if c.WFE.NoncePrefetch != nil {
+		err = wfe.EnableNoncePrefetch(context.Background(), *c.WFE.NoncePrefetch)
+		cmd.FailOnError(err, "Unable to configure nonce prefetching")
+	}
+
+	routeTimeouts := make(map[string]time.Duration, len(c.WFE.RouteTimeouts))
+	for pattern, timeout := range c.WFE.RouteTimeouts {
+		routeTimeouts[pattern] = timeout.Duration
+	}
+	err = wfe.SetRouteTimeouts(routeTimeouts)
+	cmd.FailOnError(err, "Invalid route timeouts")
 
 	logger.Infof("WFE using key policy: %#v", kp)
 
@@ -388,6 +658,11 @@ func main() {
 	handler := wfe.Handler(stats, c.OpenTelemetryHTTPConfig.Options()...)
 
 	srv := web.NewServer(c.WFE.ListenAddress, handler, logger)
+	if c.WFE.HTTP2 {
+		srv.Protocols = new(http.Protocols)
+		srv.Protocols.SetHTTP1(true)
+		srv.Protocols.SetUnencryptedHTTP2(true)
+	}
 	go func() {
 		err := srv.ListenAndServe()
 		if err != nil && err != http.ErrServerClosed {
@@ -396,16 +671,59 @@ func main() {
 	}()
 
 	tlsSrv := web.NewServer(c.WFE.TLSListenAddress, handler, logger)
+	if c.WFE.HTTP2 {
+		tlsSrv.Protocols = new(http.Protocols)
+		tlsSrv.Protocols.SetHTTP1(true)
+		tlsSrv.Protocols.SetHTTP2(true)
+	}
+	if wfe.ClientIdentifier != nil {
+		tlsSrv.TLSConfig = &tls.Config{
+			ClientAuth: tls.RequestClientCert,
+			ClientCAs:  wfe.ClientIdentifier.ClientCAs(),
+		}
+	}
+	certPath, keyPath := c.WFE.ServerCertificatePath, c.WFE.ServerKeyPath
+	selfIssuerCtx, stopSelfIssuer := context.WithCancel(context.Background())
+	defer stopSelfIssuer()
+	if selfIssuer != nil {
+		if tlsSrv.TLSConfig == nil {
+			tlsSrv.TLSConfig = &tls.Config{}
+		}
+		selfIssuer.ConfigureTLS(tlsSrv.TLSConfig)
+		// The certificate comes from the SelfIssuer, not from these files.
+		certPath, keyPath = "", ""
+		go selfIssuer.Run(selfIssuerCtx)
+	}
 	if tlsSrv.Addr != "" {
 		go func() {
 			logger.Infof("TLS server listening on %s", tlsSrv.Addr)
-			err := tlsSrv.ListenAndServeTLS(c.WFE.ServerCertificatePath, c.WFE.ServerKeyPath)
+			err := tlsSrv.ListenAndServeTLS(certPath, keyPath)
 			if err != nil && err != http.ErrServerClosed {
 				cmd.FailOnError(err, "Running TLS server")
 			}
 		}()
 	}
 
+	adminSrv := &http.Server{Handler: wfe.Maintenance, ReadHeaderTimeout: 10 * time.Second}
+	if c.WFE.Maintenance != nil && c.WFE.Maintenance.AdminSocket != "" {
+		// Remove any socket left behind by a previous process.
+		err = os.Remove(c.WFE.Maintenance.AdminSocket)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			cmd.FailOnError(err, "Removing stale maintenance admin socket")
+		}
+		adminListener, err := net.Listen("unix", c.WFE.Maintenance.AdminSocket)
+		cmd.FailOnError(err, "Listening on maintenance admin socket")
+		err = os.Chmod(c.WFE.Maintenance.AdminSocket, 0600)
+		cmd.FailOnError(err, "Restricting maintenance admin socket permissions")
+		go func() {
+			logger.Infof("Maintenance admin API listening on %s", c.WFE.Maintenance.AdminSocket)
+			err := adminSrv.Serve(adminListener)
+			if err != nil && err != http.ErrServerClosed {
+				cmd.FailOnError(err, "Running maintenance admin server")
+			}
+		}()
+	}
+
 	// When main is ready to exit (because it has received a shutdown signal),
 	// gracefully shutdown the servers. Calling these shutdown functions causes
 	// ListenAndServe() and ListenAndServeTLS() to immediately return, then waits
@@ -415,7 +733,9 @@ func main() {
 		defer cancel()
 		_ = srv.Shutdown(ctx)
 		_ = tlsSrv.Shutdown(ctx)
+		_ = adminSrv.Shutdown(ctx)
 		limiterRedis.StopLookups()
+		replayRedis.StopLookups()
 		oTelShutdown(ctx)
 	}()
 
@@ -424,4 +744,5 @@ func main() {
 
 func init() {
 	cmd.RegisterCommand("boulder-wfe2", main, &cmd.ConfigValidator{Config: &Config{}})
+	cmd.RegisterRunner("boulder-wfe2", run)
 }
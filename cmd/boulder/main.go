@@ -6,51 +6,41 @@ import (
 	"strings"
 
 	_ "github.com/letsencrypt/boulder/cmd/akamai-purger"
+	_ "github.com/letsencrypt/boulder/cmd/api-gateway"
 	_ "github.com/letsencrypt/boulder/cmd/bad-key-revoker"
 	_ "github.com/letsencrypt/boulder/cmd/boulder-ca"
+	_ "github.com/letsencrypt/boulder/cmd/boulder-combined"
+	_ "github.com/letsencrypt/boulder/cmd/boulder-loadgen"
 	_ "github.com/letsencrypt/boulder/cmd/boulder-observer"
 	_ "github.com/letsencrypt/boulder/cmd/boulder-publisher"
 	_ "github.com/letsencrypt/boulder/cmd/boulder-ra"
 	_ "github.com/letsencrypt/boulder/cmd/boulder-sa"
 	_ "github.com/letsencrypt/boulder/cmd/boulder-va"
 	_ "github.com/letsencrypt/boulder/cmd/boulder-wfe2"
+	_ "github.com/letsencrypt/boulder/cmd/caa-checker"
 	_ "github.com/letsencrypt/boulder/cmd/cert-checker"
+	_ "github.com/letsencrypt/boulder/cmd/contact-verifier"
 	_ "github.com/letsencrypt/boulder/cmd/crl-checker"
 	_ "github.com/letsencrypt/boulder/cmd/crl-storer"
 	_ "github.com/letsencrypt/boulder/cmd/crl-updater"
 	_ "github.com/letsencrypt/boulder/cmd/email-exporter"
+	_ "github.com/letsencrypt/boulder/cmd/exemption-queue"
+	_ "github.com/letsencrypt/boulder/cmd/issuance-journal-checker"
+	_ "github.com/letsencrypt/boulder/cmd/issuance-reconciler"
+	_ "github.com/letsencrypt/boulder/cmd/issuer-rotation-checker"
 	_ "github.com/letsencrypt/boulder/cmd/log-validator"
 	_ "github.com/letsencrypt/boulder/cmd/nonce-service"
 	_ "github.com/letsencrypt/boulder/cmd/ocsp-responder"
 	_ "github.com/letsencrypt/boulder/cmd/remoteva"
 	_ "github.com/letsencrypt/boulder/cmd/reversed-hostname-checker"
 	_ "github.com/letsencrypt/boulder/cmd/rocsp-tool"
+	_ "github.com/letsencrypt/boulder/cmd/sa-vacuum"
 	_ "github.com/letsencrypt/boulder/cmd/sfe"
 	"github.com/letsencrypt/boulder/core"
 
 	"github.com/letsencrypt/boulder/cmd"
 )
 
-// readAndValidateConfigFile uses the ConfigValidator registered for the given
-// command to validate the provided config file. If the command does not have a
-// registered ConfigValidator, this function does nothing.
-func readAndValidateConfigFile(name, filename string) error {
-	cv := cmd.LookupConfigValidator(name)
-	if cv == nil {
-		return nil
-	}
-	file, err := os.Open(filename)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-	if name == "boulder-observer" {
-		// Only the boulder-observer uses YAML config files.
-		return cmd.ValidateYAMLConfig(cv, file)
-	}
-	return cmd.ValidateJSONConfig(cv, file)
-}
-
 // getConfigPath returns the path to the config file if it was provided as a
 // command line flag. If the flag was not provided, it returns an empty string.
 func getConfigPath() string {
@@ -71,11 +61,31 @@ func getConfigPath() string {
 	return ""
 }
 
+// getValidateConfig removes the -validate-config flag from os.Args, and
+// returns true if it was present. The flag is handled here rather than by
+// each subcommand, none of which knows about it.
+func getValidateConfig() bool {
+	var found bool
+	args := os.Args[:0]
+	for _, arg := range os.Args {
+		if arg == "--validate-config" || arg == "-validate-config" {
+			found = true
+			continue
+		}
+		args = append(args, arg)
+	}
+	os.Args = args
+	return found
+}
+
 var boulderUsage = fmt.Sprintf(`Usage: %s <subcommand> [flags]
 
   Each boulder component has its own subcommand. Use --list to see
   a list of the available components. Use <subcommand> --help to
   see the usage for a specific component.
+
+  Use <subcommand> --config <file> --validate-config to validate a
+  component's config file and exit, without starting the component.
 `,
 	core.Command())
 
@@ -106,16 +116,33 @@ func main() {
 	command := os.Args[1]
 	os.Args = os.Args[1:]
 
+	validateOnly := getValidateConfig()
 	config := getConfigPath()
+	if validateOnly {
+		if config == "" {
+			fmt.Fprintf(os.Stderr, "--validate-config requires --config.\n")
+			os.Exit(1)
+		}
+		if cmd.LookupConfigValidator(command) == nil {
+			fmt.Fprintf(os.Stderr, "No config validator is registered for command %q.\n", command)
+			os.Exit(1)
+		}
+	}
+
 	if config != "" {
 		// Config flag passed.
-		err := readAndValidateConfigFile(command, config)
+		_, err := cmd.ReadAndValidateConfigFile(command, config)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error validating config file %q for command %q: %s\n", config, command, err)
 			os.Exit(1)
 		}
 	}
 
+	if validateOnly {
+		fmt.Printf("Config file %q for command %q is valid.\n", config, command)
+		return
+	}
+
 	commandFunc := cmd.LookupCommand(command)
 	if commandFunc == nil {
 		fmt.Fprintf(os.Stderr, "Unknown subcommand %q.\n", command)
@@ -31,6 +31,10 @@ func TestConfigValidation(t *testing.T) {
 		switch cmdName {
 		case "boulder-ca":
 			fileNames = []string{"ca.json"}
+		case "boulder-combined":
+			fileNames = []string{"combined.json"}
+		case "boulder-loadgen":
+			fileNames = []string{"loadgen.json"}
 		case "boulder-observer":
 			fileNames = []string{"observer.yml"}
 		case "boulder-publisher":
@@ -64,9 +68,26 @@ func TestConfigValidation(t *testing.T) {
 	for cmdName, paths := range components {
 		for _, path := range paths {
 			t.Run(path, func(t *testing.T) {
-				err := readAndValidateConfigFile(cmdName, fmt.Sprintf("%s/%s", configPath, path))
+				_, err := cmd.ReadAndValidateConfigFile(cmdName, fmt.Sprintf("%s/%s", configPath, path))
 				test.AssertNotError(t, err, fmt.Sprintf("Failed to validate config file %q", path))
 			})
 		}
 	}
 }
+
+func TestGetValidateConfig(t *testing.T) {
+	origArgs := os.Args
+	defer func() { os.Args = origArgs }()
+
+	os.Args = []string{"boulder-ra", "--config", "ra.json"}
+	test.Assert(t, !getValidateConfig(), "flag wasn't passed")
+	test.AssertDeepEquals(t, os.Args, []string{"boulder-ra", "--config", "ra.json"})
+
+	os.Args = []string{"boulder-ra", "--validate-config", "--config", "ra.json"}
+	test.Assert(t, getValidateConfig(), "flag was passed")
+	test.AssertDeepEquals(t, os.Args, []string{"boulder-ra", "--config", "ra.json"})
+
+	os.Args = []string{"boulder-ra", "-config=ra.json", "-validate-config"}
+	test.Assert(t, getValidateConfig(), "flag was passed")
+	test.AssertDeepEquals(t, os.Args, []string{"boulder-ra", "-config=ra.json"})
+}
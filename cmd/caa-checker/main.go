@@ -0,0 +1,137 @@
+package notmain
+
+import (
+	"context"
+	"flag"
+	"os"
+	"time"
+
+	"github.com/letsencrypt/boulder/bdns"
+	"github.com/letsencrypt/boulder/caa"
+	caapb "github.com/letsencrypt/boulder/caa/proto"
+	"github.com/letsencrypt/boulder/cmd"
+	"github.com/letsencrypt/boulder/config"
+	bgrpc "github.com/letsencrypt/boulder/grpc"
+	bredis "github.com/letsencrypt/boulder/redis"
+)
+
+type Config struct {
+	CAAChecker struct {
+		cmd.ServiceConfig
+
+		// UserAgent is the "User-Agent" header sent with DoH queries.
+		UserAgent string
+
+		// DNSTries is the number of times to try a DNS query (that has a
+		// temporary error) before giving up. May be short-circuited by
+		// deadlines. A zero value will be turned into 1.
+		DNSTries    int
+		DNSProvider *cmd.DNSProvider `validate:"required_without=DNSStaticResolvers"`
+		// DNSStaticResolvers is a list of DNS resolvers. Each entry must
+		// be a host or IP and port separated by a colon. IPv6 addresses
+		// must be enclosed in square brackets.
+		DNSStaticResolvers        []string        `validate:"required_without=DNSProvider,dive,hostname_port"`
+		DNSTimeout                config.Duration `validate:"required"`
+		DNSAllowLoopbackAddresses bool
+
+		// Redis configures the cache shared by every caa-checker in this
+		// perspective. If unset, every lookup goes to DNS.
+		Redis *bredis.Config
+
+		// MaxAge is the longest a CAA result with records may be served from
+		// the cache. Results are never cached for longer than the TTL of
+		// their records. Per the Baseline Requirements, this must not exceed
+		// 8 hours.
+		MaxAge config.Duration `validate:"required"`
+		// NegativeMaxAge is how long a CAA result without records is served
+		// from the cache. If zero, MaxAge is used.
+		NegativeMaxAge config.Duration `validate:"-"`
+	}
+
+	Syslog        cmd.SyslogConfig
+	OpenTelemetry cmd.OpenTelemetryConfig
+}
+
+func main() {
+	grpcAddr := flag.String("addr", "", "gRPC listen address override")
+	debugAddr := flag.String("debug-addr", "", "Debug server address override")
+	configFile := flag.String("config", "", "File path to the configuration file for this service")
+	flag.Parse()
+	if *configFile == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	var c Config
+	err := cmd.ReadConfigFile(*configFile, &c)
+	cmd.FailOnError(err, "Reading JSON config file into config structure")
+
+	if *grpcAddr != "" {
+		c.CAAChecker.GRPC.Address = *grpcAddr
+	}
+	if *debugAddr != "" {
+		c.CAAChecker.DebugAddr = *debugAddr
+	}
+	if c.CAAChecker.DNSTries < 1 {
+		c.CAAChecker.DNSTries = 1
+	}
+	if c.CAAChecker.MaxAge.Duration > 8*time.Hour {
+		cmd.Fail("'maxAge' must not exceed 8h")
+	}
+	negativeMaxAge := c.CAAChecker.NegativeMaxAge.Duration
+	if negativeMaxAge <= 0 {
+		negativeMaxAge = c.CAAChecker.MaxAge.Duration
+	}
+
+	scope, logger, oTelShutdown := cmd.StatsAndLogging(c.Syslog, c.OpenTelemetry, c.CAAChecker.DebugAddr)
+	defer oTelShutdown(context.Background())
+	logger.Info(cmd.VersionString())
+	clk := cmd.Clock()
+
+	var servers bdns.ServerProvider
+	if len(c.CAAChecker.DNSStaticResolvers) != 0 {
+		servers, err = bdns.NewStaticProvider(c.CAAChecker.DNSStaticResolvers)
+		cmd.FailOnError(err, "Couldn't start static DNS server resolver")
+	} else {
+		servers, err = bdns.StartDynamicProvider(c.CAAChecker.DNSProvider, 60*time.Second, "tcp")
+		cmd.FailOnError(err, "Couldn't start dynamic DNS server resolver")
+	}
+	defer servers.Stop()
+
+	tlsConfig, err := c.CAAChecker.TLS.Load(scope)
+	cmd.FailOnError(err, "tlsConfig config")
+
+	newResolver := bdns.New
+	if c.CAAChecker.DNSAllowLoopbackAddresses {
+		newResolver = bdns.NewTest
+	}
+	resolver := newResolver(
+		c.CAAChecker.DNSTimeout.Duration,
+		servers,
+		scope,
+		clk,
+		c.CAAChecker.DNSTries,
+		c.CAAChecker.UserAgent,
+		logger,
+		tlsConfig)
+
+	var cache caa.Cache
+	if c.CAAChecker.Redis != nil {
+		ring, err := bredis.NewRingFromConfig(*c.CAAChecker.Redis, scope, logger)
+		cmd.FailOnError(err, "Failed to create Redis ring")
+		defer ring.StopLookups()
+		cache = caa.NewRedisCache(ring.Ring, clk, scope)
+	}
+
+	checker := caa.NewChecker(resolver, cache, c.CAAChecker.MaxAge.Duration, negativeMaxAge, clk, scope, logger)
+
+	start, err := bgrpc.NewServer(c.CAAChecker.GRPC, logger).Add(
+		&caapb.CAAChecker_ServiceDesc, checker).Build(tlsConfig, scope, clk)
+	cmd.FailOnError(err, "Unable to setup caa-checker gRPC server")
+
+	cmd.FailOnError(start(), "caa-checker gRPC service failed")
+}
+
+func init() {
+	cmd.RegisterCommand("caa-checker", main, &cmd.ConfigValidator{Config: &Config{}})
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/letsencrypt/boulder/config"
 	"github.com/letsencrypt/boulder/core"
 	corepb "github.com/letsencrypt/boulder/core/proto"
+	"github.com/letsencrypt/boulder/ctpolicy/ctpresence"
 	"github.com/letsencrypt/boulder/ctpolicy/loglist"
 	"github.com/letsencrypt/boulder/features"
 	"github.com/letsencrypt/boulder/goodkey"
@@ -65,6 +66,7 @@ type report struct {
 	GoodCerts int64                  `json:"good-certs"`
 	BadCerts  int64                  `json:"bad-certs"`
 	DbErrs    int64                  `json:"db-errs"`
+	CTErrs    int64                  `json:"ct-errs"`
 	Entries   map[string]reportEntry `json:"entries"`
 }
 
@@ -109,6 +111,9 @@ type certChecker struct {
 	acceptableValidityDurations map[time.Duration]bool
 	lints                       lint.Registry
 	logger                      blog.Logger
+	// ctPresence, if non-nil, checks that a sample of the certificates' SCTs
+	// were honored by their logs.
+	ctPresence *ctpresence.Checker
 }
 
 func newChecker(saDbMap certDB,
@@ -507,6 +512,17 @@ func (c *certChecker) checkCert(ctx context.Context, cert *corepb.Certificate) (
 		}
 	}
 
+	if c.ctPresence != nil && c.ctPresence.Sample() {
+		ctProblems, err := c.ctPresence.Check(ctx, cert.Der)
+		problems = append(problems, ctProblems...)
+		if err != nil {
+			// Log and continue, since a log being unreachable or unknown isn't a
+			// problem with the cert itself.
+			c.logger.Errf("checking CT log presence for %s: %s", cert.Serial, err)
+			atomic.AddInt64(&c.issuedReport.CTErrs, 1)
+		}
+	}
+
 	if features.Get().CertCheckerChecksValidations {
 		idents := identifier.FromCert(p)
 		err = c.checkValidations(ctx, cert, idents)
@@ -558,6 +574,12 @@ type Config struct {
 		// https://www.gstatic.com/ct/log_list/v3/log_list_schema.json
 		CTLogListFile string
 
+		// CTPresence, if set, enables checking that the SCTs embedded in a
+		// sample of certificates were honored, by asking each log for an
+		// inclusion proof of the precertificate entry. It requires
+		// CTLogListFile, which is used to find the logs.
+		CTPresence *ctpresence.Config
+
 		Features features.Config
 	}
 	PA     cmd.PAConfig
@@ -612,6 +634,9 @@ func main() {
 	pa, err := policy.New(config.PA.Identifiers, config.PA.Challenges, logger)
 	cmd.FailOnError(err, "Failed to create PA")
 
+	err = pa.SetConfusableIDNAction(policy.ConfusableIDNAction(config.PA.ConfusableIDNs))
+	cmd.FailOnError(err, "Invalid PA configuration")
+
 	err = pa.LoadIdentPolicyFile(config.CertChecker.HostnamePolicyFile)
 	cmd.FailOnError(err, "Failed to load HostnamePolicyFile")
 
@@ -638,6 +663,15 @@ func main() {
 		lints,
 		logger,
 	)
+	if config.CertChecker.CTPresence != nil {
+		if config.CertChecker.CTLogListFile == "" {
+			cmd.Fail("CTPresence requires CTLogListFile")
+		}
+		logs, err := loglist.New(config.CertChecker.CTLogListFile)
+		cmd.FailOnError(err, "Failed to load CT Log List")
+		checker.ctPresence, err = ctpresence.New(*config.CertChecker.CTPresence, logs, "letsencrypt/boulder cert-checker")
+		cmd.FailOnError(err, "Failed to create CT presence checker")
+	}
 	fmt.Fprintf(os.Stderr, "# Getting certificates issued in the last %s\n", config.CertChecker.CheckPeriod)
 
 	// Since we grab certificates in batches we don't want this to block, when it
@@ -16,7 +16,9 @@ import (
 
 	"github.com/letsencrypt/boulder/config"
 	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/features"
 	"github.com/letsencrypt/boulder/identifier"
+	blog "github.com/letsencrypt/boulder/log"
 )
 
 // PasswordConfig contains a path to a file containing a password.
@@ -94,8 +96,15 @@ func (d *DBConfig) URL() (string, error) {
 // it should offer.
 type PAConfig struct {
 	DBConfig    `validate:"-"`
-	Challenges  map[core.AcmeChallenge]bool        `validate:"omitempty,dive,keys,oneof=http-01 dns-01 tls-alpn-01,endkeys"`
-	Identifiers map[identifier.IdentifierType]bool `validate:"omitempty,dive,keys,oneof=dns ip,endkeys"`
+	Challenges  map[core.AcmeChallenge]bool        `validate:"omitempty,dive,keys,oneof=http-01 dns-01 tls-alpn-01 email-reply-00,endkeys"`
+	Identifiers map[identifier.IdentifierType]bool `validate:"omitempty,dive,keys,oneof=dns ip email,endkeys"`
+
+	// ConfusableIDNs, if set, enables the detection of internationalized
+	// domain names with a label which mixes scripts or could otherwise be
+	// mistaken for another name, and determines what the PA does about them:
+	// "reject" refuses to issue for them, "dns-01-only" only offers the DNS-01
+	// challenge for them, and "log-only" just logs them.
+	ConfusableIDNs string `validate:"omitempty,oneof=reject dns-01-only log-only"`
 }
 
 // CheckChallenges checks whether the list of challenges in the PA config
@@ -108,6 +117,9 @@ func (pc PAConfig) CheckChallenges() error {
 		if !c.IsValid() {
 			return fmt.Errorf("invalid challenge in PA config: %s", c)
 		}
+		if c == core.ChallengeTypeEmailReply00 && !features.Get().EmailIdentifiers {
+			return fmt.Errorf("challenge %s in PA config requires the EmailIdentifiers feature", c)
+		}
 	}
 	return nil
 }
@@ -119,6 +131,9 @@ func (pc PAConfig) CheckIdentifiers() error {
 		if !i.IsValid() {
 			return fmt.Errorf("invalid identifier type in PA config: %s", i)
 		}
+		if i == identifier.TypeEmail && !features.Get().EmailIdentifiers {
+			return fmt.Errorf("identifier type %s in PA config requires the EmailIdentifiers feature", i)
+		}
 	}
 	return nil
 }
@@ -233,6 +248,13 @@ type SyslogConfig struct {
 	// When absent or zero, this defaults to logging all messages of level 6
 	// or below. To disable syslog logging entirely, set this to -1.
 	SyslogLevel int `validate:"min=-1,max=7"`
+	// StdoutFormat is the format of lines emitted on stdout/stderr: "text",
+	// the default, imitates syslog's format, and "json" emits each line as a
+	// JSON object, for container log collectors.
+	StdoutFormat string `validate:"omitempty,oneof=text json"`
+	// File, if present, causes lines to also be written to a log file which
+	// is rotated by size or age, for deployments without a syslog daemon.
+	File *blog.FileConfig `validate:"omitempty"`
 }
 
 // ServiceDomain contains the service and domain name the gRPC or bdns provider
@@ -307,7 +329,7 @@ type GRPCClientConfig struct {
 	// implementation of the SRV resolver should be used. The default is 'srv'
 	// For more details, see the documentation in:
 	// grpc/internal/resolver/dns/dns_resolver.go.
-	SRVResolver string `validate:"excluded_with=ServerAddress ServerIPAddresses,isdefault|oneof=srv nonce-srv"`
+	SRVResolver string `validate:"excluded_with=ServerAddress ServerIPAddresses,isdefault|oneof=srv nonce-srv affinity-srv"`
 
 	// ServerAddress is a single <hostname|IPv4|[IPv6]>:<port> or `:<port>` that
 	// the gRPC client will, if necessary, resolve via DNS and then connect to.
@@ -345,6 +367,14 @@ type GRPCClientConfig struct {
 	// HostOverride is an optional override for the dNSName the client will
 	// verify in the certificate presented by the server.
 	HostOverride string `validate:"excluded_with=ServerIPAddresses,omitempty,hostname"`
+
+	// ServiceNames, if set, are logical names of the service, such as
+	// "sa.boulder", for at least one of which the certificate presented by
+	// each server must be valid, in place of the dialed hostname or
+	// HostOverride. This allows servers discovered by SRV lookup, whose
+	// targets are per-instance hostnames, to use certificates for the
+	// service as a whole rather than for each instance or a wildcard.
+	ServiceNames []string `validate:"excluded_with=HostOverride,omitempty,dive,hostname"`
 	Timeout      config.Duration
 
 	// NoWaitForReady turns off our (current) default of setting grpc.WaitForReady(true).
@@ -353,6 +383,36 @@ type GRPCClientConfig struct {
 	// backends are down, it will wait until either one becomes available or the RPC
 	// times out.
 	NoWaitForReady bool
+
+	// KeepaliveTime is how long a connection may go without any activity
+	// before the client pings the server to check it's still alive. It must be
+	// at least the KeepaliveMinTime of the server, or the server will close
+	// the connection. If zero, the client doesn't send keepalive pings.
+	KeepaliveTime config.Duration `validate:"-"`
+	// KeepaliveTimeout is how long the client waits for a response to a
+	// keepalive ping before closing the connection. If zero, the gRPC default
+	// of 20 seconds is used.
+	KeepaliveTimeout config.Duration `validate:"-"`
+	// KeepalivePermitWithoutStream allows the client to send keepalive pings
+	// when it has no RPCs outstanding.
+	KeepalivePermitWithoutStream bool
+
+	// TLSSessionCacheSize is the number of TLS sessions the client caches in
+	// order to resume them, sparing the server a full mTLS handshake when the
+	// client reconnects. If zero, 64 sessions are cached.
+	TLSSessionCacheSize int `validate:"min=0"`
+	// DisableTLSSessionResumption disables the TLS session cache, so that
+	// every new connection performs a full mTLS handshake.
+	DisableTLSSessionResumption bool
+
+	// RequireOCSPStaple rejects servers which don't staple an OCSP response
+	// for their certificate. Stapled responses are always verified, and
+	// servers whose certificates they say are revoked are always rejected.
+	RequireOCSPStaple bool
+
+	// Compression, if set, compresses the requests of unary RPCs. Servers
+	// can always decompress them, whether or not they compress responses.
+	Compression *GRPCCompressionConfig `validate:"omitempty"`
 }
 
 // MakeTargetAndHostOverride constructs the target URI that the gRPC client will
@@ -463,6 +523,68 @@ type GRPCServerConfig struct {
 	// backends.
 	// https://pkg.go.dev/google.golang.org/grpc/keepalive#ServerParameters
 	MaxConnectionAge config.Duration `validate:"required"`
+	// MaxConnectionAgeGrace is how long the server waits, after sending a
+	// GoAway because of MaxConnectionAge, for outstanding RPCs to complete
+	// before closing the connection. If zero, RPCs may take as long as they
+	// need.
+	MaxConnectionAgeGrace config.Duration `validate:"-"`
+	// MaxConnectionIdle is how long a connection may go without any RPCs
+	// before the server sends a GoAway to the client. If zero, idle
+	// connections are kept open.
+	MaxConnectionIdle config.Duration `validate:"-"`
+	// KeepaliveTime is how long a connection may go without any activity
+	// before the server pings the client to check it's still alive. If zero,
+	// the gRPC default of two hours is used.
+	KeepaliveTime config.Duration `validate:"-"`
+	// KeepaliveTimeout is how long the server waits for a response to a
+	// keepalive ping before closing the connection. If zero, the gRPC default
+	// of 20 seconds is used.
+	KeepaliveTimeout config.Duration `validate:"-"`
+	// KeepaliveMinTime is the shortest interval at which clients may send
+	// keepalive pings, whether or not they have RPCs outstanding. Clients which
+	// ping more often are disconnected, so this must be no greater than the
+	// KeepaliveTime of any client. If zero, 10 seconds is used, which is the
+	// shortest KeepaliveTime a gRPC client can be configured with.
+	KeepaliveMinTime config.Duration `validate:"-"`
+	// DisableTLSSessionTickets prevents clients from resuming TLS sessions, so
+	// that every new connection performs a full mTLS handshake.
+	DisableTLSSessionTickets bool
+	// OCSPStapling, if set, makes the server fetch OCSP responses for its
+	// certificate from the internal CA and staple them to its handshakes, so
+	// that clients stop connecting to it soon after its certificate is
+	// revoked.
+	OCSPStapling *OCSPStaplingConfig `validate:"omitempty"`
+	// Compression, if set, compresses responses for clients which advertise
+	// support for the configured algorithm. Every Boulder client does.
+	Compression *GRPCCompressionConfig `validate:"omitempty"`
+}
+
+// GRPCCompressionConfig configures the compression of gRPC messages.
+type GRPCCompressionConfig struct {
+	// Algorithm is the compressor to use: "gzip" or "zstd".
+	Algorithm string `validate:"required,oneof=gzip zstd"`
+	// MinSize is the size of the smallest message which is compressed, e.g.
+	// 1024 or "1KiB". Smaller messages are sent uncompressed, since compressing
+	// them costs more CPU than it saves in bandwidth. Since gRPC fixes the
+	// compressor of a streaming RPC when it sends the first response, streamed
+	// responses are compressed only if the first is at least this long. If
+	// zero, 1024 bytes is used.
+	MinSize config.ByteSize `validate:"min=0"`
+}
+
+// OCSPStaplingConfig configures a gRPC server to staple OCSP responses for its
+// certificate.
+type OCSPStaplingConfig struct {
+	// IssuerCertFile is the path to the certificate of the internal CA which
+	// issued the server's certificate.
+	IssuerCertFile string `validate:"required"`
+	// ResponderURL is the URL of the internal CA's OCSP responder. If empty,
+	// the OCSP server listed in the server's certificate is used.
+	ResponderURL string `validate:"omitempty,url"`
+	// RefreshInterval is how often a new OCSP response is fetched. It must be
+	// shorter than the validity period of the responses. If zero, a default of
+	// one hour is used.
+	RefreshInterval config.Duration `validate:"-"`
 }
 
 // GRPCServiceConfig contains the information needed to configure a gRPC service.
@@ -561,6 +683,20 @@ type DNSProvider struct {
 	SRVLookup ServiceDomain `validate:"required"`
 }
 
+// IdentifierHashingConfig configures the replacement of identifiers and
+// account IDs in the WFE's request logs with keyed hashes.
+type IdentifierHashingConfig struct {
+	// Secret is the HMAC key from which the key for each rotation period is
+	// derived. It must be the same for every WFE, and for the admin tool,
+	// which uses it to compute the hashes of identifiers under investigation.
+	Secret HMACKeyConfig
+
+	// RotationPeriod is how long each derived key is used for, e.g. "24h".
+	// Hashes made with different keys can't be correlated with each other.
+	// It must be at least an hour.
+	RotationPeriod config.Duration `validate:"required"`
+}
+
 // HMACKeyConfig specifies a path to a file containing a hexadecimal-encoded
 // HMAC key. The key must represent exactly 256 bits (32 bytes) of random data
 // to be suitable for use as a 256-bit hashing key (e.g., the output of `openssl
@@ -0,0 +1,150 @@
+package notmain
+
+import (
+	"context"
+	"flag"
+	"net"
+	"os"
+	"time"
+
+	"github.com/letsencrypt/boulder/cmd"
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/email"
+	emailpb "github.com/letsencrypt/boulder/email/proto"
+	bgrpc "github.com/letsencrypt/boulder/grpc"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+)
+
+// Config holds the configuration for the contact-verifier service.
+type Config struct {
+	ContactVerifier struct {
+		cmd.ServiceConfig
+
+		SAService *cmd.GRPCClientConfig `validate:"required"`
+
+		// DNSResolver is the host and port of the resolver used to look up MX
+		// records. If unset, the system resolver is used.
+		DNSResolver string          `validate:"omitempty,hostname_port"`
+		DNSTimeout  config.Duration `validate:"required"`
+
+		// Workers is the number of contacts which are verified concurrently.
+		Workers int `validate:"required,min=1"`
+
+		// Confirmation, if set, causes a confirmation email to be sent to each
+		// contact whose domain can receive mail. Such contacts remain pending
+		// until the recipient follows the link in that email. If unset, they
+		// are considered valid immediately.
+		Confirmation *struct {
+			Server   string `validate:"required"`
+			Port     int    `validate:"required,min=1,max=65535"`
+			Username string
+			Password cmd.PasswordConfig
+			From     string `validate:"required"`
+
+			// URL is the page, served by a frontend which calls the
+			// ConfirmContact RPC, which the emails link to. The token is
+			// appended as the "token" query parameter.
+			URL string `validate:"required,url"`
+
+			// TokenKey is used to authenticate confirmation tokens, so that
+			// they need not be stored.
+			TokenKey cmd.HMACKeyConfig
+
+			// TokenLifetime is how long a recipient has to follow the link.
+			TokenLifetime config.Duration `validate:"required"`
+		}
+	}
+	Syslog        cmd.SyslogConfig
+	OpenTelemetry cmd.OpenTelemetryConfig
+}
+
+func main() {
+	configFile := flag.String("config", "", "Path to configuration file")
+	grpcAddr := flag.String("addr", "", "gRPC listen address override")
+	debugAddr := flag.String("debug-addr", "", "Debug server address override")
+	flag.Parse()
+
+	if *configFile == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	var c Config
+	err := cmd.ReadConfigFile(*configFile, &c)
+	cmd.FailOnError(err, "Reading JSON config file into config structure")
+
+	if *grpcAddr != "" {
+		c.ContactVerifier.ServiceConfig.GRPC.Address = *grpcAddr
+	}
+	if *debugAddr != "" {
+		c.ContactVerifier.ServiceConfig.DebugAddr = *debugAddr
+	}
+
+	scope, logger, oTelShutdown := cmd.StatsAndLogging(c.Syslog, c.OpenTelemetry, c.ContactVerifier.ServiceConfig.DebugAddr)
+	defer oTelShutdown(context.Background())
+
+	logger.Info(cmd.VersionString())
+
+	clk := cmd.Clock()
+
+	tlsConfig, err := c.ContactVerifier.TLS.Load(scope)
+	cmd.FailOnError(err, "Loading contact-verifier TLS config")
+
+	saConn, err := bgrpc.ClientSetup(c.ContactVerifier.SAService, tlsConfig, scope, clk)
+	cmd.FailOnError(err, "Failed to load credentials and create gRPC connection to SA")
+	sac := sapb.NewStorageAuthorityClient(saConn)
+
+	resolver := net.DefaultResolver
+	if c.ContactVerifier.DNSResolver != "" {
+		resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, c.ContactVerifier.DNSResolver)
+			},
+		}
+	}
+
+	var sender email.ConfirmationSender
+	var tokenKey []byte
+	var tokenLifetime time.Duration
+	if c.ContactVerifier.Confirmation != nil {
+		conf := c.ContactVerifier.Confirmation
+		password, err := conf.Password.Pass()
+		cmd.FailOnError(err, "Loading SMTP password")
+		sender, err = email.NewSMTPSender(conf.Server, conf.Port, conf.Username, password, conf.From, conf.URL)
+		cmd.FailOnError(err, "Creating SMTP sender")
+		tokenKey, err = conf.TokenKey.Load()
+		cmd.FailOnError(err, "Loading confirmation token key")
+		tokenLifetime = conf.TokenLifetime.Duration
+	}
+
+	verifierServer := email.NewVerifierImpl(
+		sac,
+		resolver,
+		c.ContactVerifier.DNSTimeout.Duration,
+		sender,
+		tokenKey,
+		tokenLifetime,
+		c.ContactVerifier.Workers,
+		clk,
+		scope,
+		logger,
+	)
+
+	daemonCtx, shutdownVerifierServer := context.WithCancel(context.Background())
+	verifierServer.Start(daemonCtx)
+
+	start, err := bgrpc.NewServer(c.ContactVerifier.GRPC, logger).Add(
+		&emailpb.ContactVerifier_ServiceDesc, verifierServer).Build(tlsConfig, scope, clk)
+	cmd.FailOnError(err, "Configuring contact-verifier gRPC server")
+
+	err = start()
+	shutdownVerifierServer()
+	verifierServer.Drain()
+	cmd.FailOnError(err, "contact-verifier gRPC service failed to start")
+}
+
+func init() {
+	cmd.RegisterCommand("contact-verifier", main, &cmd.ConfigValidator{Config: &Config{}})
+}
@@ -35,8 +35,9 @@ type Config struct {
 		// blank by default.
 		S3Endpoint string
 		// S3Bucket is the AWS Bucket that uploads should go to. Must be created
-		// (and have appropriate permissions set) beforehand.
-		S3Bucket string
+		// (and have appropriate permissions set) beforehand. If empty, CRLs are
+		// only written to FilesystemDirectory.
+		S3Bucket string `validate:"required_without=FilesystemDirectory"`
 		// AWSConfigFile is the path to a file on disk containing an AWS config.
 		// The format of the configuration file is specified at
 		// https://docs.aws.amazon.com/sdkref/latest/guide/file-format.html.
@@ -46,6 +47,15 @@ type Config struct {
 		// https://docs.aws.amazon.com/sdkref/latest/guide/file-format.html.
 		AWSCredsFile string
 
+		// FilesystemDirectory, if set, is a directory (which may be on a
+		// network filesystem) to which CRLs are also written, for deployments
+		// which serve CRLs from their own web servers. Each CRL is written to
+		// <issuer name ID>/<shard index>.crl, alongside a manifest.json which
+		// describes the issuer's shards, and every file is replaced atomically.
+		// The directory must already exist, and only one crl-storer should
+		// write to it.
+		FilesystemDirectory string
+
 		Features features.Config
 	}
 
@@ -105,33 +115,43 @@ func main() {
 		issuers = append(issuers, cert)
 	}
 
-	// Load the "default" AWS configuration, but override the set of config and
-	// credential files it reads from to just those specified in our JSON config,
-	// to ensure that it's not accidentally reading anything from the homedir or
-	// its other default config locations.
-	awsConfig, err := config.LoadDefaultConfig(
-		context.Background(),
-		config.WithSharedConfigFiles([]string{c.CRLStorer.AWSConfigFile}),
-		config.WithSharedCredentialsFiles([]string{c.CRLStorer.AWSCredsFile}),
-		config.WithHTTPClient(new(http.Client)),
-		config.WithLogger(awsLogger{logger}),
-		config.WithClientLogMode(aws.LogRequestEventMessage|aws.LogResponseEventMessage),
-	)
-	cmd.FailOnError(err, "Failed to load AWS config")
-
-	s3opts := make([]func(*s3.Options), 0)
-	if c.CRLStorer.S3Endpoint != "" {
-		s3opts = append(
-			s3opts,
-			s3.WithEndpointResolver(s3.EndpointResolverFromURL(c.CRLStorer.S3Endpoint)),
-			func(o *s3.Options) { o.UsePathStyle = true },
+	// Only create an S3 client if there's a bucket to upload to, so that
+	// deployments which only write CRLs to the filesystem need no AWS config.
+	var s3client *s3.Client
+	if c.CRLStorer.S3Bucket != "" {
+		// Load the "default" AWS configuration, but override the set of config
+		// and credential files it reads from to just those specified in our JSON
+		// config, to ensure that it's not accidentally reading anything from the
+		// homedir or its other default config locations.
+		awsConfig, err := config.LoadDefaultConfig(
+			context.Background(),
+			config.WithSharedConfigFiles([]string{c.CRLStorer.AWSConfigFile}),
+			config.WithSharedCredentialsFiles([]string{c.CRLStorer.AWSCredsFile}),
+			config.WithHTTPClient(new(http.Client)),
+			config.WithLogger(awsLogger{logger}),
+			config.WithClientLogMode(aws.LogRequestEventMessage|aws.LogResponseEventMessage),
 		)
+		cmd.FailOnError(err, "Failed to load AWS config")
+
+		s3opts := make([]func(*s3.Options), 0)
+		if c.CRLStorer.S3Endpoint != "" {
+			s3opts = append(
+				s3opts,
+				s3.WithEndpointResolver(s3.EndpointResolverFromURL(c.CRLStorer.S3Endpoint)),
+				func(o *s3.Options) { o.UsePathStyle = true },
+			)
+		}
+		s3client = s3.NewFromConfig(awsConfig, s3opts...)
 	}
-	s3client := s3.NewFromConfig(awsConfig, s3opts...)
 
 	csi, err := storer.New(issuers, s3client, c.CRLStorer.S3Bucket, scope, logger, clk)
 	cmd.FailOnError(err, "Failed to create CRLStorer impl")
 
+	if c.CRLStorer.FilesystemDirectory != "" {
+		err = csi.SetFilesystemTarget(c.CRLStorer.FilesystemDirectory)
+		cmd.FailOnError(err, "Failed to configure CRL filesystem target")
+	}
+
 	start, err := bgrpc.NewServer(c.CRLStorer.GRPC, logger).Add(
 		&cspb.CRLStorer_ServiceDesc, csi).Build(tlsConfig, scope, clk)
 	cmd.FailOnError(err, "Unable to setup CRLStorer gRPC server")
@@ -16,6 +16,7 @@ import (
 	bgrpc "github.com/letsencrypt/boulder/grpc"
 	"github.com/letsencrypt/boulder/issuance"
 	sapb "github.com/letsencrypt/boulder/sa/proto"
+	"github.com/letsencrypt/boulder/skewclock"
 )
 
 type Config struct {
@@ -83,10 +84,12 @@ type Config struct {
 		// recognize the new prefixes as well as the old ones).
 		TemporallyShardedSerialPrefixes []string
 
-		// MaxParallelism controls how many workers may be running in parallel.
+		// MaxParallelism controls how many shards may be updated in parallel.
 		// A higher value reduces the total time necessary to update all CRL shards
 		// that this updater is responsible for, but also increases the memory used
-		// by this updater. Only relevant in -runOnce mode.
+		// by this updater and the load on the CA's signers. In -runOnce mode it
+		// defaults to 1. Otherwise, if it's unset, every shard is updated as
+		// soon as it's due.
 		MaxParallelism int `validate:"min=0"`
 
 		// MaxAttempts control how many times the updater will attempt to generate
@@ -110,6 +113,17 @@ type Config struct {
 		// admin-invoked revocations that may have a time requirement.
 		ExpiresMargin config.Duration
 
+		// MaxUnchangedAge is the longest the crl-updater will go without
+		// re-signing and re-uploading a shard whose set of entries has not
+		// changed since the last time it was generated. While a shard is
+		// unchanged and younger than this, the previously uploaded CRL is left
+		// in place. Each shard's entries are compared against a digest stored
+		// with the SA in the crlShards table, which must have the
+		// entriesDigest column. Must be zero or at least UpdatePeriod, and
+		// should be comfortably less than the CRL's validity period. If zero
+		// (the default), every shard is re-signed on every update.
+		MaxUnchangedAge config.Duration `validate:"-"`
+
 		// CacheControl is a string passed verbatim to the crl-storer to store on
 		// the S3 object.
 		//
@@ -156,7 +170,7 @@ func main() {
 	scope, logger, oTelShutdown := cmd.StatsAndLogging(c.Syslog, c.OpenTelemetry, c.CRLUpdater.DebugAddr)
 	defer oTelShutdown(context.Background())
 	logger.Info(cmd.VersionString())
-	clk := cmd.Clock()
+	clk := skewclock.New(cmd.Clock(), skewclock.Config{}, scope, logger)
 
 	tlsConfig, err := c.CRLUpdater.TLS.Load(scope)
 	cmd.FailOnError(err, "TLS config")
@@ -202,6 +216,7 @@ func main() {
 		c.CRLUpdater.CacheControl,
 		c.CRLUpdater.ExpiresMargin.Duration,
 		c.CRLUpdater.TemporallyShardedSerialPrefixes,
+		c.CRLUpdater.MaxUnchangedAge.Duration,
 		sac,
 		cac,
 		csc,
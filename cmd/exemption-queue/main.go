@@ -0,0 +1,89 @@
+package notmain
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"github.com/letsencrypt/boulder/cmd"
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/exemptions"
+	exemptionspb "github.com/letsencrypt/boulder/exemptions/proto"
+	bgrpc "github.com/letsencrypt/boulder/grpc"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+)
+
+// Config holds the configuration for the exemption-queue service.
+type Config struct {
+	ExemptionQueue struct {
+		cmd.ServiceConfig
+
+		SAService *cmd.GRPCClientConfig `validate:"required"`
+
+		// MaxPendingPerAccount is the most exemption requests an account may
+		// have awaiting review. Further requests are refused until some of
+		// them are reviewed.
+		MaxPendingPerAccount int `validate:"required,min=1"`
+
+		// MaxPeriod is the longest period for which an override may be
+		// requested.
+		MaxPeriod config.Duration `validate:"required"`
+	}
+	Syslog        cmd.SyslogConfig
+	OpenTelemetry cmd.OpenTelemetryConfig
+}
+
+func main() {
+	configFile := flag.String("config", "", "Path to configuration file")
+	grpcAddr := flag.String("addr", "", "gRPC listen address override")
+	debugAddr := flag.String("debug-addr", "", "Debug server address override")
+	flag.Parse()
+
+	if *configFile == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	var c Config
+	err := cmd.ReadConfigFile(*configFile, &c)
+	cmd.FailOnError(err, "Reading JSON config file into config structure")
+
+	if *grpcAddr != "" {
+		c.ExemptionQueue.ServiceConfig.GRPC.Address = *grpcAddr
+	}
+	if *debugAddr != "" {
+		c.ExemptionQueue.ServiceConfig.DebugAddr = *debugAddr
+	}
+
+	scope, logger, oTelShutdown := cmd.StatsAndLogging(c.Syslog, c.OpenTelemetry, c.ExemptionQueue.ServiceConfig.DebugAddr)
+	defer oTelShutdown(context.Background())
+
+	logger.Info(cmd.VersionString())
+
+	clk := cmd.Clock()
+
+	tlsConfig, err := c.ExemptionQueue.TLS.Load(scope)
+	cmd.FailOnError(err, "Loading exemption-queue TLS config")
+
+	saConn, err := bgrpc.ClientSetup(c.ExemptionQueue.SAService, tlsConfig, scope, clk)
+	cmd.FailOnError(err, "Failed to load credentials and create gRPC connection to SA")
+	sac := sapb.NewStorageAuthorityClient(saConn)
+
+	queueServer := exemptions.NewQueueImpl(
+		sac,
+		c.ExemptionQueue.MaxPendingPerAccount,
+		c.ExemptionQueue.MaxPeriod.Duration,
+		scope,
+		logger,
+	)
+
+	start, err := bgrpc.NewServer(c.ExemptionQueue.GRPC, logger).Add(
+		&exemptionspb.ExemptionQueue_ServiceDesc, queueServer).Build(tlsConfig, scope, clk)
+	cmd.FailOnError(err, "Configuring exemption-queue gRPC server")
+
+	cmd.FailOnError(start(), "exemption-queue gRPC service failed to start")
+}
+
+func init() {
+	cmd.RegisterCommand("exemption-queue", main, &cmd.ConfigValidator{Config: &Config{}})
+}
@@ -0,0 +1,46 @@
+package notmain
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/letsencrypt/boulder/ca/journal"
+	"github.com/letsencrypt/boulder/cmd"
+)
+
+func main() {
+	journalFile := flag.String("journal", "", "path to a CA issuance journal, required")
+	keyFile := flag.String("key", "", "path to the journal's PEM-encoded public key, or a certificate for it, required")
+	allowUnsigned := flag.Bool("allowUnsigned", false, "don't fail if the journal ends with entries which no checkpoint covers")
+	flag.Parse()
+
+	logger := cmd.NewLogger(cmd.SyslogConfig{StdoutLevel: 6, SyslogLevel: -1})
+	logger.Info(cmd.VersionString())
+
+	if *journalFile == "" || *keyFile == "" {
+		cmd.Fail("-journal and -key are required")
+	}
+
+	pub, err := journal.LoadPublicKey(*keyFile)
+	cmd.FailOnError(err, "Loading journal public key")
+
+	f, err := os.Open(*journalFile)
+	cmd.FailOnError(err, "Opening journal")
+	defer f.Close()
+
+	summary, err := journal.Verify(f, pub)
+	cmd.FailOnError(err, "Verifying journal")
+
+	logger.AuditInfof(
+		"Verified journal %q: %d entries, %d checkpoints, last checkpoint at entry %d, %d entries after it",
+		*journalFile, summary.Entries, summary.Checkpoints, summary.LastCheckpoint, summary.Unsigned)
+
+	if summary.Unsigned != 0 && !*allowUnsigned {
+		cmd.Fail(fmt.Sprintf("%d entries at the end of the journal aren't covered by a checkpoint", summary.Unsigned))
+	}
+}
+
+func init() {
+	cmd.RegisterCommand("issuance-journal-checker", main, nil)
+}
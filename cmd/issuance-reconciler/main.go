@@ -0,0 +1,443 @@
+package notmain
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/letsencrypt/boulder/ca/journal"
+	"github.com/letsencrypt/boulder/cmd"
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/core"
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	"github.com/letsencrypt/boulder/ctpolicy/ctpresence"
+	"github.com/letsencrypt/boulder/ctpolicy/loglist"
+	berrors "github.com/letsencrypt/boulder/errors"
+	bgrpc "github.com/letsencrypt/boulder/grpc"
+	blog "github.com/letsencrypt/boulder/log"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+)
+
+type Config struct {
+	IssuanceReconciler struct {
+		DebugAddr string `validate:"omitempty,hostname_port"`
+
+		TLS       cmd.TLSConfig
+		SAService *cmd.GRPCClientConfig
+
+		// JournalFiles are the paths to the issuance journals of every CA
+		// instance. Each is read, and its checkpoints verified, every time a
+		// window is reconciled.
+		JournalFiles []string `validate:"min=1,dive,required"`
+
+		// JournalKeyFile is the path to the PEM-encoded public key which
+		// signs the journals' checkpoints, or a certificate for it.
+		JournalKeyFile string `validate:"required"`
+
+		// Window is the length of the periods of issuance which are
+		// reconciled together. Defaults to one hour.
+		Window config.Duration `validate:"-"`
+
+		// Lag is how long after a window closes before it's reconciled. It
+		// must exceed the maximum merge delay of the CT logs, or SCTs which
+		// haven't been honored yet won't be checked. Defaults to 25 hours.
+		Lag config.Duration `validate:"-"`
+
+		// Lookback is how far before the most recent window which is ready
+		// to reconcile to start from when the reconciler starts. Progress
+		// isn't saved, so windows further back than this are never
+		// reconciled after a restart. Defaults to one window.
+		Lookback config.Duration `validate:"-"`
+
+		// Interval is how often to check whether another window is ready to
+		// reconcile, and how long to wait before retrying a window which
+		// couldn't be reconciled. Defaults to five minutes.
+		Interval config.Duration `validate:"-"`
+
+		// RecoverOrphans enables adding certificates which were signed but
+		// not stored to the SA, using the copy of each in its CA's journal.
+		// When it's false, such certificates are only reported.
+		RecoverOrphans bool
+
+		// CTLogListFile is the path to a JSON file on disk containing the set
+		// of all logs trusted by Chrome. It's required by CTPresence.
+		CTLogListFile string `validate:"required_with=CTPresence"`
+
+		// CTPresence, if set, enables checking that the SCTs embedded in each
+		// stored certificate were honored by the logs which issued them.
+		CTPresence *ctpresence.Config
+	}
+
+	Syslog        cmd.SyslogConfig
+	OpenTelemetry cmd.OpenTelemetryConfig
+}
+
+const (
+	// orphanUnstoredPrecert is a precertificate which was journaled, which
+	// happens only after its linting precertificate is stored, but which the
+	// SA doesn't have.
+	orphanUnstoredPrecert = "unstored_precertificate"
+
+	// orphanUnstoredCert is a final certificate which was signed, according
+	// to its CA's journal, but which the SA doesn't have.
+	orphanUnstoredCert = "unstored_certificate"
+
+	// orphanUnconfirmedCert is a final certificate whose signing was
+	// journaled, but which was neither journaled as issued nor stored. Its
+	// signing may have failed, or the CA may have failed to journal it.
+	orphanUnconfirmedCert = "unconfirmed_certificate"
+
+	// orphanUnloggedCert is a stored final certificate which embeds an SCT
+	// that its log never honored.
+	orphanUnloggedCert = "unlogged_certificate"
+)
+
+// storage is the subset of the SA used by the reconciler.
+type storage interface {
+	GetLintPrecertificate(ctx context.Context, req *sapb.Serial, opts ...grpc.CallOption) (*corepb.Certificate, error)
+	GetCertificate(ctx context.Context, req *sapb.Serial, opts ...grpc.CallOption) (*corepb.Certificate, error)
+	GetSerialMetadata(ctx context.Context, req *sapb.Serial, opts ...grpc.CallOption) (*sapb.SerialMetadata, error)
+	AddCertificate(ctx context.Context, req *sapb.AddCertificateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+// ctChecker is the subset of ctpresence.Checker used by the reconciler.
+type ctChecker interface {
+	Refresh()
+	Sample() bool
+	Check(ctx context.Context, der []byte) ([]string, error)
+}
+
+type reconcilerMetrics struct {
+	orphans           *prometheus.CounterVec
+	recoveries        *prometheus.CounterVec
+	windows           *prometheus.CounterVec
+	reconciledThrough prometheus.Gauge
+}
+
+func newReconcilerMetrics(stats prometheus.Registerer) *reconcilerMetrics {
+	orphans := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "issuance_reconciler_orphans",
+		Help: "A counter of certificates whose journal, SA, and CT records don't agree, labelled by kind",
+	}, []string{"kind"})
+	stats.MustRegister(orphans)
+
+	recoveries := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "issuance_reconciler_recoveries",
+		Help: "A counter of attempts to store unstored certificates from the journal, labelled by result",
+	}, []string{"result"})
+	stats.MustRegister(recoveries)
+
+	windows := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "issuance_reconciler_windows",
+		Help: "A counter of attempts to reconcile a window, labelled by result",
+	}, []string{"result"})
+	stats.MustRegister(windows)
+
+	reconciledThrough := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "issuance_reconciler_reconciled_through_seconds",
+		Help: "The Unix timestamp of the end of the last window which was reconciled",
+	})
+	stats.MustRegister(reconciledThrough)
+
+	return &reconcilerMetrics{
+		orphans:           orphans,
+		recoveries:        recoveries,
+		windows:           windows,
+		reconciledThrough: reconciledThrough,
+	}
+}
+
+type reconciler struct {
+	sa           storage
+	ct           ctChecker
+	journalFiles []string
+	journalKey   crypto.PublicKey
+	recover      bool
+	window       time.Duration
+	lag          time.Duration
+	interval     time.Duration
+	clk          clock.Clock
+	log          blog.Logger
+	metrics      *reconcilerMetrics
+
+	// next is the start of the next window to reconcile.
+	next time.Time
+}
+
+// windowEntries are the journal entries for signing operations in a window.
+type windowEntries struct {
+	// precerts and certs are the serials of the precertificates and final
+	// certificates whose signing was journaled in the window, in order.
+	precerts []string
+	certs    []string
+	// issued are the issued entries for the final certificates in certs, by
+	// serial. They may have been journaled after the window closed.
+	issued map[string]*journal.Entry
+}
+
+// readJournals verifies every journal and returns the entries for signing
+// operations which were journaled in the window [start, end).
+func (r *reconciler) readJournals(start, end time.Time) (*windowEntries, error) {
+	entries := &windowEntries{issued: make(map[string]*journal.Entry)}
+	seen := make(map[string]bool)
+	for _, path := range r.journalFiles {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		_, err = journal.Scan(f, r.journalKey, func(e *journal.Entry) error {
+			if e.Type == journal.TypeIssued {
+				// The issued entry for a final certificate always follows
+				// its certificate entry in the same journal, so only those
+				// for certificates in the window need to be kept.
+				if seen[journal.TypeCertificate+e.Serial] {
+					entries.issued[e.Serial] = e
+				}
+				return nil
+			}
+			if e.Time.Before(start) || !e.Time.Before(end) || seen[e.Type+e.Serial] {
+				return nil
+			}
+			seen[e.Type+e.Serial] = true
+			switch e.Type {
+			case journal.TypePrecertificate:
+				entries.precerts = append(entries.precerts, e.Serial)
+			case journal.TypeCertificate:
+				entries.certs = append(entries.certs, e.Serial)
+			}
+			return nil
+		})
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading journal %q: %w", path, err)
+		}
+	}
+	return entries, nil
+}
+
+// orphan reports a certificate whose records don't agree.
+func (r *reconciler) orphan(kind string, serial string, msg string) {
+	r.metrics.orphans.WithLabelValues(kind).Inc()
+	r.log.AuditErrf("Orphaned certificate: kind=[%s] serial=[%s] %s", kind, serial, msg)
+}
+
+// reconcile checks that every precertificate and final certificate which was
+// journaled in the window [start, end) is stored, recovering those which
+// aren't if it can, and that every stored final certificate's SCTs were
+// honored. It returns an error if any of them couldn't be checked.
+func (r *reconciler) reconcile(ctx context.Context, start, end time.Time) error {
+	entries, err := r.readJournals(start, end)
+	if err != nil {
+		return err
+	}
+	if r.ct != nil {
+		r.ct.Refresh()
+	}
+
+	var errs []error
+	for _, serial := range entries.precerts {
+		_, err := r.sa.GetLintPrecertificate(ctx, &sapb.Serial{Serial: serial})
+		if errors.Is(err, berrors.NotFound) {
+			r.orphan(orphanUnstoredPrecert, serial, "precertificate was journaled but isn't stored")
+		} else if err != nil {
+			errs = append(errs, fmt.Errorf("getting precertificate %s: %w", serial, err))
+		}
+	}
+
+	for _, serial := range entries.certs {
+		var der []byte
+		cert, err := r.sa.GetCertificate(ctx, &sapb.Serial{Serial: serial})
+		if err == nil {
+			der = cert.Der
+		} else if errors.Is(err, berrors.NotFound) {
+			issued, ok := entries.issued[serial]
+			if !ok {
+				r.orphan(orphanUnconfirmedCert, serial, "certificate signing was journaled, but it was neither journaled as issued nor stored")
+				continue
+			}
+			r.orphan(orphanUnstoredCert, serial, "certificate was signed but isn't stored")
+			if !r.recover {
+				continue
+			}
+			err = r.recoverCertificate(ctx, issued)
+			if err != nil {
+				r.metrics.recoveries.WithLabelValues("failure").Inc()
+				errs = append(errs, fmt.Errorf("recovering certificate %s: %w", serial, err))
+				continue
+			}
+			r.metrics.recoveries.WithLabelValues("success").Inc()
+			r.log.AuditInfof("Recovered orphaned certificate: serial=[%s]", serial)
+			der = issued.Certificate
+		} else {
+			errs = append(errs, fmt.Errorf("getting certificate %s: %w", serial, err))
+			continue
+		}
+
+		if r.ct == nil || !r.ct.Sample() {
+			continue
+		}
+		problems, err := r.ct.Check(ctx, der)
+		for _, problem := range problems {
+			r.orphan(orphanUnloggedCert, serial, problem)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("checking CT log presence of %s: %w", serial, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// recoverCertificate stores the final certificate from an issued entry, for
+// the account which its serial was allocated to.
+func (r *reconciler) recoverCertificate(ctx context.Context, issued *journal.Entry) error {
+	cert, err := x509.ParseCertificate(issued.Certificate)
+	if err != nil {
+		return fmt.Errorf("parsing journaled certificate: %w", err)
+	}
+	if core.SerialToString(cert.SerialNumber) != issued.Serial {
+		return fmt.Errorf("journaled certificate has serial %s", core.SerialToString(cert.SerialNumber))
+	}
+	metadata, err := r.sa.GetSerialMetadata(ctx, &sapb.Serial{Serial: issued.Serial})
+	if err != nil {
+		return fmt.Errorf("getting serial metadata: %w", err)
+	}
+	_, err = r.sa.AddCertificate(ctx, &sapb.AddCertificateRequest{
+		Der:    issued.Certificate,
+		RegID:  metadata.RegistrationID,
+		Issued: timestamppb.New(issued.Time),
+	})
+	return err
+}
+
+// reconcileReady reconciles, in order, each window which closed at least lag
+// ago. It stops at the first window which can't be fully reconciled, so that
+// it's retried by the next call.
+func (r *reconciler) reconcileReady(ctx context.Context) error {
+	for {
+		end := r.next.Add(r.window)
+		if end.After(r.clk.Now().Add(-r.lag)) {
+			return nil
+		}
+		err := r.reconcile(ctx, r.next, end)
+		if err != nil {
+			r.metrics.windows.WithLabelValues("failure").Inc()
+			return fmt.Errorf("reconciling window starting at %s: %w", r.next.Format(time.RFC3339), err)
+		}
+		r.metrics.windows.WithLabelValues("success").Inc()
+		r.metrics.reconciledThrough.Set(float64(end.Unix()))
+		r.log.Infof("Reconciled window from %s to %s", r.next.Format(time.RFC3339), end.Format(time.RFC3339))
+		r.next = end
+	}
+}
+
+// run reconciles each window once it's ready, forever.
+func (r *reconciler) run(ctx context.Context) {
+	for {
+		err := r.reconcileReady(ctx)
+		if err != nil {
+			r.log.Errf("%s", err)
+		}
+		r.clk.Sleep(r.interval)
+	}
+}
+
+// newReconciler applies the config's defaults and returns a reconciler which
+// starts from the window lookback before the most recent one which is ready.
+func newReconciler(c Config, sa storage, ct ctChecker, journalKey crypto.PublicKey, clk clock.Clock, logger blog.Logger, stats prometheus.Registerer) (*reconciler, error) {
+	rc := c.IssuanceReconciler
+	window := rc.Window.Duration
+	if window == 0 {
+		window = time.Hour
+	}
+	lag := rc.Lag.Duration
+	if lag == 0 {
+		lag = 25 * time.Hour
+	}
+	lookback := rc.Lookback.Duration
+	if lookback == 0 {
+		lookback = window
+	}
+	interval := rc.Interval.Duration
+	if interval == 0 {
+		interval = 5 * time.Minute
+	}
+	if window < 0 || lag < 0 || lookback < 0 || interval < 0 {
+		return nil, errors.New("Window, Lag, Lookback, and Interval must not be negative")
+	}
+
+	return &reconciler{
+		sa:           sa,
+		ct:           ct,
+		journalFiles: rc.JournalFiles,
+		journalKey:   journalKey,
+		recover:      rc.RecoverOrphans,
+		window:       window,
+		lag:          lag,
+		interval:     interval,
+		clk:          clk,
+		log:          logger,
+		metrics:      newReconcilerMetrics(stats),
+		next:         clk.Now().Add(-lag - lookback).Truncate(window),
+	}, nil
+}
+
+func main() {
+	debugAddr := flag.String("debug-addr", "", "Debug server address override")
+	configPath := flag.String("config", "", "File path to the configuration file for this service")
+	flag.Parse()
+
+	if *configPath == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+	var c Config
+	err := cmd.ReadConfigFile(*configPath, &c)
+	cmd.FailOnError(err, "Failed reading config file")
+
+	if *debugAddr != "" {
+		c.IssuanceReconciler.DebugAddr = *debugAddr
+	}
+
+	scope, logger, oTelShutdown := cmd.StatsAndLogging(c.Syslog, c.OpenTelemetry, c.IssuanceReconciler.DebugAddr)
+	defer oTelShutdown(context.Background())
+	logger.Info(cmd.VersionString())
+	clk := cmd.Clock()
+
+	tlsConfig, err := c.IssuanceReconciler.TLS.Load(scope)
+	cmd.FailOnError(err, "TLS config")
+
+	saConn, err := bgrpc.ClientSetup(c.IssuanceReconciler.SAService, tlsConfig, scope, clk)
+	cmd.FailOnError(err, "Failed to load credentials and create gRPC connection to SA")
+	sac := sapb.NewStorageAuthorityClient(saConn)
+
+	journalKey, err := journal.LoadPublicKey(c.IssuanceReconciler.JournalKeyFile)
+	cmd.FailOnError(err, "Failed to load journal public key")
+
+	var ct ctChecker
+	if c.IssuanceReconciler.CTPresence != nil {
+		logs, err := loglist.New(c.IssuanceReconciler.CTLogListFile)
+		cmd.FailOnError(err, "Failed to load CT Log List")
+		ct, err = ctpresence.New(*c.IssuanceReconciler.CTPresence, logs, "letsencrypt/boulder issuance-reconciler")
+		cmd.FailOnError(err, "Failed to create CT presence checker")
+	}
+
+	r, err := newReconciler(c, sac, ct, journalKey, clk, logger, scope)
+	cmd.FailOnError(err, "Invalid issuance-reconciler config")
+
+	r.run(context.Background())
+}
+
+func init() {
+	cmd.RegisterCommand("issuance-reconciler", main, &cmd.ConfigValidator{Config: &Config{}})
+}
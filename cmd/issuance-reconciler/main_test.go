@@ -0,0 +1,245 @@
+package notmain
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/letsencrypt/boulder/ca/journal"
+	"github.com/letsencrypt/boulder/core"
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	berrors "github.com/letsencrypt/boulder/errors"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// fakeSA stores precertificates and certificates by serial.
+type fakeSA struct {
+	precerts map[string]bool
+	certs    map[string][]byte
+	regIDs   map[string]int64
+	getErr   error
+	added    []*sapb.AddCertificateRequest
+}
+
+func (sa *fakeSA) GetLintPrecertificate(_ context.Context, req *sapb.Serial, _ ...grpc.CallOption) (*corepb.Certificate, error) {
+	if !sa.precerts[req.Serial] {
+		return nil, berrors.NotFoundError("precertificate %s not found", req.Serial)
+	}
+	return &corepb.Certificate{Serial: req.Serial}, nil
+}
+
+func (sa *fakeSA) GetCertificate(_ context.Context, req *sapb.Serial, _ ...grpc.CallOption) (*corepb.Certificate, error) {
+	if sa.getErr != nil {
+		return nil, sa.getErr
+	}
+	der, ok := sa.certs[req.Serial]
+	if !ok {
+		return nil, berrors.NotFoundError("certificate %s not found", req.Serial)
+	}
+	return &corepb.Certificate{Serial: req.Serial, Der: der}, nil
+}
+
+func (sa *fakeSA) GetSerialMetadata(_ context.Context, req *sapb.Serial, _ ...grpc.CallOption) (*sapb.SerialMetadata, error) {
+	regID, ok := sa.regIDs[req.Serial]
+	if !ok {
+		return nil, berrors.NotFoundError("serial %s not found", req.Serial)
+	}
+	return &sapb.SerialMetadata{Serial: req.Serial, RegistrationID: regID}, nil
+}
+
+func (sa *fakeSA) AddCertificate(_ context.Context, req *sapb.AddCertificateRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	sa.added = append(sa.added, req)
+	return &emptypb.Empty{}, nil
+}
+
+// fakeCT reports a problem for every SCT in the certificates in unlogged.
+type fakeCT struct {
+	unlogged  [][]byte
+	refreshes int
+}
+
+func (f *fakeCT) Refresh() {
+	f.refreshes++
+}
+
+func (f *fakeCT) Sample() bool {
+	return true
+}
+
+func (f *fakeCT) Check(_ context.Context, der []byte) ([]string, error) {
+	for _, unlogged := range f.unlogged {
+		if bytes.Equal(der, unlogged) {
+			return []string{"SCT was never honored"}, nil
+		}
+	}
+	return nil, nil
+}
+
+// makeCert returns a self-signed certificate with the given serial.
+func makeCert(t *testing.T, serial int64) (string, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		DNSNames:     []string{"example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	test.AssertNotError(t, err, "creating certificate")
+	return core.SerialToString(template.SerialNumber), der
+}
+
+func TestReconcile(t *testing.T) {
+	t.Parallel()
+
+	journalKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating journal key")
+	windowStart := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	clk := clock.NewFake()
+	clk.Set(windowStart.Add(10 * time.Minute))
+
+	storedSerial, storedDER := makeCert(t, 1)
+	unstoredSerial, unstoredDER := makeCert(t, 2)
+	unconfirmedSerial, _ := makeCert(t, 3)
+	laterSerial, _ := makeCert(t, 4)
+
+	// Journal the signing of a precertificate and final certificate for
+	// each serial, across two CAs, in the first window, except laterSerial,
+	// which is in the next window.
+	dir := t.TempDir()
+	journalA, err := journal.Open(filepath.Join(dir, "a"), journalKey, clk)
+	test.AssertNotError(t, err, "opening journal")
+	journalB, err := journal.Open(filepath.Join(dir, "b"), journalKey, clk)
+	test.AssertNotError(t, err, "opening journal")
+	for _, e := range []journal.Entry{
+		{Type: journal.TypePrecertificate, Serial: storedSerial},
+		{Type: journal.TypePrecertificate, Serial: unstoredSerial},
+		{Type: journal.TypePrecertificate, Serial: unconfirmedSerial},
+		{Type: journal.TypeCertificate, Serial: storedSerial},
+		{Type: journal.TypeIssued, Serial: storedSerial, Certificate: storedDER},
+	} {
+		test.AssertNotError(t, journalA.Append(e), "appending")
+	}
+	for _, e := range []journal.Entry{
+		{Type: journal.TypeCertificate, Serial: unstoredSerial},
+		{Type: journal.TypeIssued, Serial: unstoredSerial, Certificate: unstoredDER},
+		{Type: journal.TypeCertificate, Serial: unconfirmedSerial},
+	} {
+		test.AssertNotError(t, journalB.Append(e), "appending")
+	}
+	clk.Set(windowStart.Add(70 * time.Minute))
+	test.AssertNotError(t, journalB.Append(journal.Entry{Type: journal.TypeCertificate, Serial: laterSerial}), "appending")
+	test.AssertNotError(t, journalA.Close(), "closing journal")
+	test.AssertNotError(t, journalB.Close(), "closing journal")
+
+	sa := &fakeSA{
+		precerts: map[string]bool{storedSerial: true, unconfirmedSerial: true, laterSerial: true},
+		certs:    map[string][]byte{storedSerial: storedDER},
+		regIDs:   map[string]int64{unstoredSerial: 1234},
+	}
+	ct := &fakeCT{unlogged: [][]byte{storedDER}}
+
+	var c Config
+	c.IssuanceReconciler.JournalFiles = []string{filepath.Join(dir, "a"), filepath.Join(dir, "b")}
+	c.IssuanceReconciler.RecoverOrphans = true
+	// Only the first window has closed at least the default lag ago.
+	clk.Set(windowStart.Add(time.Hour + 25*time.Hour))
+	r, err := newReconciler(c, sa, ct, journalKey.Public(), clk, blog.NewMock(), metrics.NoopRegisterer)
+	test.AssertNotError(t, err, "creating reconciler")
+	test.AssertEquals(t, r.next, windowStart)
+
+	err = r.reconcileReady(context.Background())
+	test.AssertNotError(t, err, "reconciling")
+	test.AssertEquals(t, r.next, windowStart.Add(time.Hour))
+	test.AssertEquals(t, ct.refreshes, 1)
+	test.AssertMetricWithLabelsEquals(t, r.metrics.orphans, prometheus.Labels{"kind": orphanUnstoredPrecert}, 1)
+	test.AssertMetricWithLabelsEquals(t, r.metrics.orphans, prometheus.Labels{"kind": orphanUnstoredCert}, 1)
+	test.AssertMetricWithLabelsEquals(t, r.metrics.orphans, prometheus.Labels{"kind": orphanUnconfirmedCert}, 1)
+	test.AssertMetricWithLabelsEquals(t, r.metrics.orphans, prometheus.Labels{"kind": orphanUnloggedCert}, 1)
+	test.AssertMetricWithLabelsEquals(t, r.metrics.recoveries, prometheus.Labels{"result": "success"}, 1)
+	test.AssertMetricWithLabelsEquals(t, r.metrics.reconciledThrough, nil, float64(windowStart.Add(time.Hour).Unix()))
+
+	// The unstored certificate was recovered from its journal.
+	test.AssertEquals(t, len(sa.added), 1)
+	test.AssertByteEquals(t, sa.added[0].Der, unstoredDER)
+	test.AssertEquals(t, sa.added[0].RegID, int64(1234))
+	test.AssertEquals(t, sa.added[0].Issued.AsTime(), windowStart.Add(10*time.Minute))
+
+	// A window which can't be fully reconciled is retried.
+	clk.Add(time.Hour)
+	sa.getErr = errors.New("SA is down")
+	err = r.reconcileReady(context.Background())
+	test.AssertError(t, err, "reconciled with the SA down")
+	test.AssertContains(t, err.Error(), "SA is down")
+	test.AssertEquals(t, r.next, windowStart.Add(time.Hour))
+	test.AssertMetricWithLabelsEquals(t, r.metrics.windows, prometheus.Labels{"result": "failure"}, 1)
+
+	sa.getErr = nil
+	err = r.reconcileReady(context.Background())
+	test.AssertNotError(t, err, "reconciling")
+	test.AssertEquals(t, r.next, windowStart.Add(2*time.Hour))
+	test.AssertMetricWithLabelsEquals(t, r.metrics.orphans, prometheus.Labels{"kind": orphanUnconfirmedCert}, 2)
+	test.AssertMetricWithLabelsEquals(t, r.metrics.windows, prometheus.Labels{"result": "success"}, 2)
+
+	// Nothing is reconciled from a journal which fails verification.
+	contents, err := os.ReadFile(filepath.Join(dir, "b"))
+	test.AssertNotError(t, err, "reading journal")
+	err = os.WriteFile(filepath.Join(dir, "b"), bytes.Replace(contents, []byte(`"seq":2`), []byte(`"seq":5`), 1), 0600)
+	test.AssertNotError(t, err, "writing journal")
+	r.next = windowStart
+	err = r.reconcileReady(context.Background())
+	test.AssertError(t, err, "reconciled a tampered journal")
+	test.AssertContains(t, err.Error(), "entry 2 has seq 5")
+	test.AssertEquals(t, len(sa.added), 1)
+}
+
+func TestReconcileWithoutRecovery(t *testing.T) {
+	t.Parallel()
+
+	journalKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating journal key")
+	clk := clock.NewFake()
+	windowStart := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	clk.Set(windowStart)
+
+	serial, der := makeCert(t, 1)
+	path := filepath.Join(t.TempDir(), "journal")
+	j, err := journal.Open(path, journalKey, clk)
+	test.AssertNotError(t, err, "opening journal")
+	test.AssertNotError(t, j.Append(journal.Entry{Type: journal.TypeCertificate, Serial: serial}), "appending")
+	test.AssertNotError(t, j.Append(journal.Entry{Type: journal.TypeIssued, Serial: serial, Certificate: der}), "appending")
+	test.AssertNotError(t, j.Close(), "closing journal")
+
+	sa := &fakeSA{regIDs: map[string]int64{serial: 1}}
+	var c Config
+	c.IssuanceReconciler.JournalFiles = []string{path}
+	c.IssuanceReconciler.Lag.Duration = time.Hour
+	clk.Set(windowStart.Add(2 * time.Hour))
+	r, err := newReconciler(c, sa, nil, journalKey.Public(), clk, blog.NewMock(), metrics.NoopRegisterer)
+	test.AssertNotError(t, err, "creating reconciler")
+
+	// The orphan is reported but not stored.
+	err = r.reconcileReady(context.Background())
+	test.AssertNotError(t, err, "reconciling")
+	test.AssertMetricWithLabelsEquals(t, r.metrics.orphans, prometheus.Labels{"kind": orphanUnstoredCert}, 1)
+	test.AssertEquals(t, len(sa.added), 0)
+}
@@ -0,0 +1,187 @@
+package notmain
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/letsencrypt/boulder/cmd"
+	"github.com/letsencrypt/boulder/issuance"
+)
+
+// caConfig is the part of boulder-ca's config which configures its issuers.
+type caConfig struct {
+	CA struct {
+		Issuance struct {
+			Issuers []issuance.IssuerConfig
+		}
+	}
+}
+
+// wfeConfig is the part of boulder-wfe2's config which configures the chains
+// it serves.
+type wfeConfig struct {
+	WFE struct {
+		Chains [][]string
+	}
+}
+
+// activeIssuer is an active issuer from the CA's config.
+type activeIssuer struct {
+	name    string
+	nameID  issuance.NameID
+	keyType x509.PublicKeyAlgorithm
+	ramp    []issuance.RampStep
+}
+
+// checkRotation writes the share of precertificates which each of the active
+// issuers signs, by key type, at the given time and at the start of each later
+// ramp step. If chains isn't nil, it's the set of issuers for which the WFE
+// serves a chain. It returns an error if any ramp is malformed, if the WFE
+// doesn't serve a chain for an issuer, or if at any of those times there's a
+// key type for which no issuer would sign precertificates.
+func checkRotation(issuers []activeIssuer, chains map[issuance.NameID]bool, at time.Time, out io.Writer) error {
+	var problems []error
+	byKeyType := make(map[x509.PublicKeyAlgorithm][]activeIssuer)
+	times := []time.Time{at}
+	for _, issuer := range issuers {
+		err := issuance.ValidateRamp(issuer.ramp)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("issuer %q: %w", issuer.name, err))
+		}
+		if chains != nil && !chains[issuer.nameID] {
+			problems = append(problems, fmt.Errorf("issuer %q: the WFE serves no chain for it", issuer.name))
+		}
+		byKeyType[issuer.keyType] = append(byKeyType[issuer.keyType], issuer)
+		for _, step := range issuer.ramp {
+			if step.Start.After(at) {
+				times = append(times, step.Start)
+			}
+		}
+	}
+	slices.SortFunc(times, func(a, b time.Time) int { return a.Compare(b) })
+	times = slices.CompactFunc(times, func(a, b time.Time) bool { return a.Equal(b) })
+
+	for _, keyType := range []x509.PublicKeyAlgorithm{x509.ECDSA, x509.RSA} {
+		pool := byKeyType[keyType]
+		if len(pool) == 0 {
+			problems = append(problems, fmt.Errorf("no active %s issuers", keyType))
+			continue
+		}
+		ramps := make([][]issuance.RampStep, len(pool))
+		for i, issuer := range pool {
+			ramps[i] = issuer.ramp
+		}
+		for _, t := range times {
+			shares := issuance.RampShares(ramps, t)
+			var parts []string
+			for i, share := range shares {
+				parts = append(parts, fmt.Sprintf("%s %.1f%%", pool[i].name, 100*share))
+			}
+			fmt.Fprintf(out, "%s %s: %s\n", t.UTC().Format(time.RFC3339), keyType, strings.Join(parts, ", "))
+			if !slices.ContainsFunc(shares, func(s float64) bool { return s > 0 }) {
+				problems = append(problems, fmt.Errorf("no %s issuer would sign precertificates at %s", keyType, t.UTC().Format(time.RFC3339)))
+			}
+		}
+	}
+	return errors.Join(problems...)
+}
+
+// loadActiveIssuers returns the active issuers from the CA config file.
+func loadActiveIssuers(caConfigFile string) ([]activeIssuer, error) {
+	contents, err := os.ReadFile(caConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	var c caConfig
+	err = json.Unmarshal(contents, &c)
+	if err != nil {
+		return nil, fmt.Errorf("parsing CA config: %w", err)
+	}
+
+	var issuers []activeIssuer
+	for _, ic := range c.CA.Issuance.Issuers {
+		if !ic.Active {
+			continue
+		}
+		cert, err := issuance.LoadCertificate(ic.Location.CertFile)
+		if err != nil {
+			return nil, err
+		}
+		issuers = append(issuers, activeIssuer{
+			name:    cert.Subject.CommonName,
+			nameID:  cert.NameID(),
+			keyType: cert.PublicKeyAlgorithm,
+			ramp:    ic.Ramp,
+		})
+	}
+	return issuers, nil
+}
+
+// loadChainIssuers returns the set of issuers for which the WFE config file
+// configures a chain.
+func loadChainIssuers(wfeConfigFile string) (map[issuance.NameID]bool, error) {
+	contents, err := os.ReadFile(wfeConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	var c wfeConfig
+	err = json.Unmarshal(contents, &c)
+	if err != nil {
+		return nil, fmt.Errorf("parsing WFE config: %w", err)
+	}
+
+	chains := make(map[issuance.NameID]bool)
+	for _, files := range c.WFE.Chains {
+		if len(files) == 0 {
+			continue
+		}
+		cert, err := issuance.LoadCertificate(files[0])
+		if err != nil {
+			return nil, err
+		}
+		chains[cert.NameID()] = true
+	}
+	return chains, nil
+}
+
+func main() {
+	caConfigFile := flag.String("ca-config", "", "path to a boulder-ca config file, required")
+	wfeConfigFile := flag.String("wfe-config", "", "path to a boulder-wfe2 config file, to check that it serves a chain for every active issuer")
+	atArg := flag.String("at", "", "time, in RFC 3339 format, from which to check the rotation (default now)")
+	flag.Parse()
+
+	if *caConfigFile == "" {
+		cmd.Fail("-ca-config is required")
+	}
+
+	at := time.Now()
+	if *atArg != "" {
+		var err error
+		at, err = time.Parse(time.RFC3339, *atArg)
+		cmd.FailOnError(err, "Parsing -at")
+	}
+
+	issuers, err := loadActiveIssuers(*caConfigFile)
+	cmd.FailOnError(err, "Loading CA issuers")
+
+	var chains map[issuance.NameID]bool
+	if *wfeConfigFile != "" {
+		chains, err = loadChainIssuers(*wfeConfigFile)
+		cmd.FailOnError(err, "Loading WFE chains")
+	}
+
+	err = checkRotation(issuers, chains, at, os.Stdout)
+	cmd.FailOnError(err, "Checking issuer rotation")
+}
+
+func init() {
+	cmd.RegisterCommand("issuer-rotation-checker", main, nil)
+}
@@ -0,0 +1,67 @@
+package notmain
+
+import (
+	"crypto/x509"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/letsencrypt/boulder/issuance"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestCheckRotation(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	ramp := []issuance.RampStep{
+		{Start: start.Add(time.Hour), Percent: 1},
+		{Start: start.Add(2 * time.Hour), Percent: 50},
+		{Start: start.Add(3 * time.Hour), Percent: 100},
+	}
+	issuers := []activeIssuer{
+		{name: "E1", nameID: 1, keyType: x509.ECDSA},
+		{name: "E2", nameID: 2, keyType: x509.ECDSA, ramp: ramp},
+		{name: "R1", nameID: 3, keyType: x509.RSA},
+	}
+	chains := map[issuance.NameID]bool{1: true, 2: true, 3: true}
+
+	var out strings.Builder
+	err := checkRotation(issuers, chains, start, &out)
+	test.AssertNotError(t, err, "rotation should be valid")
+	test.AssertEquals(t, out.String(), strings.Join([]string{
+		"2026-01-01T00:00:00Z ECDSA: E1 100.0%, E2 0.0%",
+		"2026-01-01T01:00:00Z ECDSA: E1 99.0%, E2 1.0%",
+		"2026-01-01T02:00:00Z ECDSA: E1 50.0%, E2 50.0%",
+		"2026-01-01T03:00:00Z ECDSA: E1 0.0%, E2 100.0%",
+		"2026-01-01T00:00:00Z RSA: R1 100.0%",
+		"2026-01-01T01:00:00Z RSA: R1 100.0%",
+		"2026-01-01T02:00:00Z RSA: R1 100.0%",
+		"2026-01-01T03:00:00Z RSA: R1 100.0%",
+		"",
+	}, "\n"))
+
+	// Steps which have already started aren't listed.
+	out.Reset()
+	err = checkRotation(issuers, chains, start.Add(150*time.Minute), &out)
+	test.AssertNotError(t, err, "rotation should be valid")
+	test.AssertContains(t, out.String(), "2026-01-01T02:30:00Z ECDSA: E1 50.0%, E2 50.0%\n2026-01-01T03:00:00Z ECDSA")
+	test.AssertNotContains(t, out.String(), "T01:00:00Z")
+
+	// The WFE must serve a chain for the new issuer before it's ramped up.
+	err = checkRotation(issuers, map[issuance.NameID]bool{1: true, 3: true}, start, &out)
+	test.AssertError(t, err, "rotation without a chain should be rejected")
+	test.AssertContains(t, err.Error(), `issuer "E2": the WFE serves no chain for it`)
+
+	// A pool made only of ramped issuers has no issuer before the ramp starts.
+	err = checkRotation(issuers[1:], nil, start, &out)
+	test.AssertError(t, err, "rotation with a gap should be rejected")
+	test.AssertContains(t, err.Error(), "no ECDSA issuer would sign precertificates at 2026-01-01T00:00:00Z")
+
+	// Malformed ramps are rejected.
+	err = checkRotation([]activeIssuer{
+		{name: "E1", nameID: 1, keyType: x509.ECDSA, ramp: []issuance.RampStep{ramp[1], ramp[0]}},
+		{name: "R1", nameID: 3, keyType: x509.RSA},
+	}, nil, start, &out)
+	test.AssertError(t, err, "malformed ramp should be rejected")
+	test.AssertContains(t, err.Error(), `issuer "E1": ramp step 1 doesn't start after step 0`)
+}
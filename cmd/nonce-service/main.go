@@ -7,11 +7,16 @@ import (
 	"net"
 	"net/netip"
 	"os"
+	"time"
+
+	"github.com/jmhodges/clock"
 
 	"github.com/letsencrypt/boulder/cmd"
+	"github.com/letsencrypt/boulder/config"
 	bgrpc "github.com/letsencrypt/boulder/grpc"
 	"github.com/letsencrypt/boulder/nonce"
 	noncepb "github.com/letsencrypt/boulder/nonce/proto"
+	"github.com/letsencrypt/boulder/skewclock"
 )
 
 type Config struct {
@@ -28,11 +33,32 @@ type Config struct {
 		// boulder-wfe and nonce-service instances.
 		NonceHMACKey cmd.HMACKeyConfig `validate:"required"`
 
+		// NextNonceHMACKeys schedules the rotation of NonceHMACKey. From the
+		// ActiveFrom time of each, nonces are minted with a prefix derived
+		// from it in place of the key before it, without a restart, so that
+		// outstanding nonces aren't invalidated. Each key must also be listed
+		// in the RotationNonceHMACKeys of every boulder-wfe instance.
+		NextNonceHMACKeys []NonceHMACKeyConfig `validate:"omitempty,dive"`
+
+		// NonceHMACKeyOverlap is how long nonces minted with a key's prefix
+		// are still redeemed after the next key replaces it. It should be at
+		// least as long as a nonce is expected to be outstanding.
+		NonceHMACKeyOverlap config.Duration `validate:"-"`
+
 		Syslog        cmd.SyslogConfig
 		OpenTelemetry cmd.OpenTelemetryConfig
 	}
 }
 
+// NonceHMACKeyConfig is an HMAC key which becomes active at a scheduled time.
+type NonceHMACKeyConfig struct {
+	cmd.HMACKeyConfig
+
+	// ActiveFrom is the time, in RFC 3339 format, from which nonces are
+	// minted with a prefix derived from this key.
+	ActiveFrom time.Time `validate:"required"`
+}
+
 func derivePrefix(key []byte, grpcAddr string) (string, error) {
 	host, port, err := net.SplitHostPort(grpcAddr)
 	if err != nil {
@@ -88,6 +114,20 @@ func main() {
 	ns, err := nonce.NewNonceService(scope, c.NonceService.MaxUsed, noncePrefix)
 	cmd.FailOnError(err, "Failed to initialize nonce service")
 
+	if len(c.NonceService.NextNonceHMACKeys) > 0 {
+		var schedule []nonce.ScheduledPrefix
+		for _, next := range c.NonceService.NextNonceHMACKeys {
+			nextKey, err := next.Load()
+			cmd.FailOnError(err, "Failed to load nextNonceHMACKeys file")
+			nextPrefix, err := derivePrefix(nextKey, c.NonceService.GRPC.Address)
+			cmd.FailOnError(err, "Failed to derive next nonce prefix")
+			schedule = append(schedule, nonce.ScheduledPrefix{Prefix: nextPrefix, ActiveFrom: next.ActiveFrom})
+		}
+		clk := skewclock.New(clock.New(), skewclock.Config{}, scope, logger)
+		err = ns.SchedulePrefixes(schedule, c.NonceService.NonceHMACKeyOverlap.Duration, clk)
+		cmd.FailOnError(err, "Failed to schedule nonce HMAC key rotation")
+	}
+
 	tlsConfig, err := c.NonceService.TLS.Load(scope)
 	cmd.FailOnError(err, "tlsConfig config")
 
@@ -2,6 +2,8 @@ package notmain
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"net/http"
@@ -43,10 +45,26 @@ type Config struct {
 
 		// The list of issuer certificates, against which OCSP requests/responses
 		// are checked to ensure we're not responding for anyone else's certs.
-		IssuerCerts []string `validate:"min=1,dive,required"`
+		IssuerCerts []string `validate:"required_without=IssuanceConfigFile,dive,required"`
+
+		// IssuanceConfigFile is the path to a boulder-ca JSON config file. Every
+		// issuer listed in its issuance stanza, whether active or retired, is
+		// loaded in addition to any IssuerCerts, so that the responder does not
+		// need to be reconfigured each time an issuer is added to the CA.
+		// Requests are routed to the matching issuer by issuerNameHash and
+		// issuerKeyHash, which must be computed with SHA1.
+		IssuanceConfigFile string `validate:"omitempty"`
 
 		Path string
 
+		// RedirectNonCanonicalGETs causes GET requests whose path isn't the
+		// canonical RFC 5019 encoding of their OCSP request, for instance
+		// because it's unescaped, unpadded, or uses the URL-safe base64
+		// alphabet, to be permanently redirected to the canonical path. This
+		// lets a CDN in front of the responder cache a single copy of each
+		// response.
+		RedirectNonCanonicalGETs bool
+
 		// ListenAddress is the address:port on which to listen for incoming
 		// OCSP requests. This has a default value of ":80".
 		ListenAddress string `validate:"omitempty,hostname_port"`
@@ -109,6 +127,28 @@ type Config struct {
 		// avoids flooding the logs during outages. 1 out of N log lines will be emitted.
 		// If LogSampleRate is 0, no logs will be emitted.
 		LogSampleRate int `validate:"min=0"`
+
+		// AuditSampleRate is the fraction, between 0 and 1, of responses
+		// served from Redis which are audited by signing a fresh response
+		// from the database's status and comparing the two. Divergence between
+		// the cache and the database is audit logged and counted in the
+		// ocsp_audit_results metric. If zero, no responses are audited.
+		AuditSampleRate float64 `validate:"min=0,max=1"`
+
+		// MaxInflightAudits limits how many audits may be in progress at once.
+		// Audits beyond this limit are skipped. Each audit uses one of the
+		// MaxInflightSignings. This has a default value of 10.
+		MaxInflightAudits int `validate:"min=0"`
+
+		// HotSerialsTopN, if non-zero, causes the HotSerialsTopN most requested
+		// serials, and approximately how often each was requested, to be logged
+		// once every HotSerialsPeriod. This helps find responses worth caching
+		// longer at the CDN, and clients scraping the responder.
+		HotSerialsTopN int `validate:"min=0"`
+
+		// HotSerialsPeriod is how often the most requested serials are logged.
+		// This has a default value of 5m.
+		HotSerialsPeriod config.Duration `validate:"-"`
 	}
 
 	Syslog        cmd.SyslogConfig
@@ -209,14 +249,21 @@ as generated by Boulder's ceremony command.
 
 		source, err = redis_responder.NewCheckedRedisSource(rocspSource, dbMap, sac, scope, logger)
 		cmd.FailOnError(err, "Could not create checkedRedis source")
+
+		if c.OCSPResponder.AuditSampleRate > 0 {
+			maxInflightAudits := c.OCSPResponder.MaxInflightAudits
+			if maxInflightAudits == 0 {
+				maxInflightAudits = 10
+			}
+			source, err = responder.NewAuditSource(source, liveSource, c.OCSPResponder.AuditSampleRate, maxInflightAudits, scope, logger)
+			cmd.FailOnError(err, "Could not create audit source")
+		}
 	}
 
-	// Load the certificate from the file path.
-	issuerCerts := make([]*issuance.Certificate, len(c.OCSPResponder.IssuerCerts))
-	for i, issuerFile := range c.OCSPResponder.IssuerCerts {
-		issuerCert, err := issuance.LoadCertificate(issuerFile)
-		cmd.FailOnError(err, "Could not load issuer cert")
-		issuerCerts[i] = issuerCert
+	issuerCerts, err := loadIssuerCerts(c.OCSPResponder.IssuerCerts, c.OCSPResponder.IssuanceConfigFile)
+	cmd.FailOnError(err, "Could not load issuer certs")
+	for _, issuerCert := range issuerCerts {
+		logger.Infof("Loaded issuer: name=[%s] nameID=[%d]", issuerCert.Subject.CommonName, issuerCert.NameID())
 	}
 
 	source, err = responder.NewFilterSource(
@@ -229,7 +276,12 @@ as generated by Boulder's ceremony command.
 	)
 	cmd.FailOnError(err, "Could not create filtered source")
 
-	m := mux(c.OCSPResponder.Path, source, c.OCSPResponder.Timeout.Duration, scope, c.OpenTelemetryHTTPConfig.Options(), logger, c.OCSPResponder.LogSampleRate)
+	hotSerialsPeriod := c.OCSPResponder.HotSerialsPeriod.Duration
+	if hotSerialsPeriod == 0 {
+		hotSerialsPeriod = 5 * time.Minute
+	}
+
+	m := mux(c.OCSPResponder.Path, c.OCSPResponder.RedirectNonCanonicalGETs, issuerCerts, c.OCSPResponder.HotSerialsTopN, hotSerialsPeriod, source, c.OCSPResponder.Timeout.Duration, scope, c.OpenTelemetryHTTPConfig.Options(), logger, c.OCSPResponder.LogSampleRate)
 
 	if c.OCSPResponder.ListenAddress == "" {
 		cmd.Fail("HTTP listen address is not configured")
@@ -266,6 +318,57 @@ as generated by Boulder's ceremony command.
 	cmd.WaitForSignal()
 }
 
+// caIssuanceConfig is the subset of the boulder-ca config file which describes
+// the CA's issuers.
+type caIssuanceConfig struct {
+	CA struct {
+		Issuance struct {
+			Issuers []issuance.IssuerConfig
+		}
+	}
+}
+
+// loadIssuerCerts loads each of the given issuer certificate files, followed
+// by the certificate of every issuer (active or not) configured in the given
+// boulder-ca config file, if any. Issuers which appear more than once are only
+// returned once.
+func loadIssuerCerts(certFiles []string, caConfigFile string) ([]*issuance.Certificate, error) {
+	if caConfigFile != "" {
+		// The CA config contains many fields we don't care about, so don't use
+		// cmd.ReadConfigFile, which rejects unknown fields.
+		data, err := os.ReadFile(caConfigFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA config file: %w", err)
+		}
+		var caConfig caIssuanceConfig
+		err = json.Unmarshal(data, &caConfig)
+		if err != nil {
+			return nil, fmt.Errorf("parsing CA config file: %w", err)
+		}
+		for _, ic := range caConfig.CA.Issuance.Issuers {
+			certFiles = append(certFiles, ic.Location.CertFile)
+		}
+	}
+
+	var issuerCerts []*issuance.Certificate
+	seen := make(map[issuance.NameID]bool)
+	for _, certFile := range certFiles {
+		issuerCert, err := issuance.LoadCertificate(certFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading issuer cert %q: %w", certFile, err)
+		}
+		if seen[issuerCert.NameID()] {
+			continue
+		}
+		seen[issuerCert.NameID()] = true
+		issuerCerts = append(issuerCerts, issuerCert)
+	}
+	if len(issuerCerts) == 0 {
+		return nil, errors.New("no issuer certs configured")
+	}
+	return issuerCerts, nil
+}
+
 // ocspMux partially implements the interface defined for http.ServeMux but doesn't implement
 // the path cleaning its Handler method does. Notably http.ServeMux will collapse repeated
 // slashes into a single slash which breaks the base64 encoding that is used in OCSP GET
@@ -279,8 +382,17 @@ func (om *ocspMux) Handler(_ *http.Request) (http.Handler, string) {
 	return om.handler, "/"
 }
 
-func mux(responderPath string, source responder.Source, timeout time.Duration, stats prometheus.Registerer, oTelHTTPOptions []otelhttp.Option, logger blog.Logger, sampleRate int) http.Handler {
-	stripPrefix := http.StripPrefix(responderPath, responder.NewResponder(source, timeout, stats, logger, sampleRate))
+func mux(responderPath string, redirectNonCanonical bool, issuerCerts []*issuance.Certificate, hotSerialsTopN int, hotSerialsPeriod time.Duration, source responder.Source, timeout time.Duration, stats prometheus.Registerer, oTelHTTPOptions []otelhttp.Option, logger blog.Logger, sampleRate int) http.Handler {
+	r := responder.NewResponder(source, timeout, stats, logger, sampleRate)
+	if redirectNonCanonical {
+		r.RedirectNonCanonicalGETs(responderPath)
+	}
+	err := r.LabelIssuers(issuerCerts)
+	cmd.FailOnError(err, "Could not label issuers")
+	if hotSerialsTopN > 0 {
+		r.ReportHotSerials(hotSerialsTopN, hotSerialsPeriod)
+	}
+	stripPrefix := http.StripPrefix(responderPath, r)
 	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == "GET" && r.URL.Path == "/" {
 			w.Header().Set("Cache-Control", "max-age=43200") // Cache for 12 hours
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -41,7 +42,7 @@ func TestMux(t *testing.T) {
 	src, err := responder.NewMemorySource(responses, blog.NewMock())
 	test.AssertNotError(t, err, "failed to create inMemorySource")
 
-	h := mux("/foobar/", src, time.Second, metrics.NoopRegisterer, []otelhttp.Option{}, blog.NewMock(), 1000)
+	h := mux("/foobar/", false, nil, 0, 0, src, time.Second, metrics.NoopRegisterer, []otelhttp.Option{}, blog.NewMock(), 1000)
 
 	type muxTest struct {
 		method   string
@@ -69,3 +70,65 @@ func TestMux(t *testing.T) {
 		}
 	}
 }
+
+func TestMuxRedirectNonCanonicalGETs(t *testing.T) {
+	reqBytes, err := base64.StdEncoding.DecodeString("MFMwUTBPME0wSzAJBgUrDgMCGgUABBR+5mrncpqz/PiiIGRsFqEtYHEIXQQUqEpqYwR93brm0Tm3pkVl7/Oo7KECEgO/AC2R1FW8hePAj4xp//8Jhw==")
+	test.AssertNotError(t, err, "failed to decode OCSP request")
+	req, err := ocsp.ParseRequest(reqBytes)
+	test.AssertNotError(t, err, "failed to parse OCSP request")
+	respBytes, err := os.ReadFile("./testdata/ocsp.resp")
+	test.AssertNotError(t, err, "failed to read OCSP response")
+	resp, err := ocsp.ParseResponse(respBytes, nil)
+	test.AssertNotError(t, err, "failed to parse OCSP response")
+	src, err := responder.NewMemorySource(map[string]*responder.Response{
+		req.SerialNumber.String(): {Response: resp, Raw: respBytes},
+	}, blog.NewMock())
+	test.AssertNotError(t, err, "failed to create inMemorySource")
+
+	h := mux("/foobar/", true, nil, 0, 0, src, time.Second, metrics.NoopRegisterer, []otelhttp.Option{}, blog.NewMock(), 1000)
+
+	canonical := "/foobar/MFMwUTBPME0wSzAJBgUrDgMCGgUABBR%2B5mrncpqz%2FPiiIGRsFqEtYHEIXQQUqEpqYwR93brm0Tm3pkVl7%2FOo7KECEgO%2FAC2R1FW8hePAj4xp%2F%2F8Jhw%3D%3D"
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/foobar/MFMwUTBPME0wSzAJBgUrDgMCGgUABBR-5mrncpqz_PiiIGRsFqEtYHEIXQQUqEpqYwR93brm0Tm3pkVl7_Oo7KECEgO_AC2R1FW8hePAj4xp__8Jhw", nil))
+	test.AssertEquals(t, w.Code, http.StatusMovedPermanently)
+	test.AssertEquals(t, w.Header().Get("Location"), canonical)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", canonical, nil))
+	test.AssertEquals(t, w.Code, http.StatusOK)
+	test.AssertByteEquals(t, w.Body.Bytes(), respBytes)
+}
+
+func TestLoadIssuerCerts(t *testing.T) {
+	_, err := loadIssuerCerts(nil, "")
+	test.AssertError(t, err, "loading no issuer certs should fail")
+
+	certs, err := loadIssuerCerts([]string{"../../test/hierarchy/int-e1.cert.pem"}, "")
+	test.AssertNotError(t, err, "loading issuer certs")
+	test.AssertEquals(t, len(certs), 1)
+
+	// Issuers from the CA config are loaded regardless of whether they're
+	// active, and duplicates are removed.
+	caConfig := filepath.Join(t.TempDir(), "ca.json")
+	err = os.WriteFile(caConfig, []byte(`{
+		"ca": {
+			"issuance": {
+				"issuers": [
+					{"active": true, "location": {"certFile": "../../test/hierarchy/int-e1.cert.pem"}},
+					{"active": false, "location": {"certFile": "../../test/hierarchy/int-r3.cert.pem"}}
+				]
+			},
+			"unrelatedField": true
+		}
+	}`), 0600)
+	test.AssertNotError(t, err, "writing CA config")
+
+	certs, err = loadIssuerCerts([]string{"../../test/hierarchy/int-e1.cert.pem"}, caConfig)
+	test.AssertNotError(t, err, "loading issuer certs from CA config")
+	test.AssertEquals(t, len(certs), 2)
+	test.AssertEquals(t, certs[0].Subject.CommonName, "(TEST) Elegant Elephant E1")
+	test.AssertEquals(t, certs[1].Subject.CommonName, "(TEST) Radical Rhino R3")
+
+	_, err = loadIssuerCerts(nil, filepath.Join(t.TempDir(), "missing.json"))
+	test.AssertError(t, err, "loading a missing CA config should fail")
+}
@@ -18,6 +18,7 @@ var registry struct {
 	sync.Mutex
 	commands map[string]func()
 	configs  map[string]*ConfigValidator
+	runners  map[string]func(configFile string)
 }
 
 // RegisterCommand registers a subcommand and its corresponding config
@@ -51,6 +52,38 @@ func RegisterCommand(name string, f func(), cv *ConfigValidator) {
 	registry.configs[name] = cv
 }
 
+// RegisterRunner registers a function which runs the named subcommand's
+// service with the config C, until the process is signaled to stop. Unlike the
+// func() given to RegisterCommand it doesn't parse command line flags or set
+// features, so that boulder-combined can run several services in one process.
+func RegisterRunner[C any](name string, run func(C)) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	if registry.runners == nil {
+		registry.runners = make(map[string]func(string))
+	}
+
+	if registry.runners[name] != nil {
+		panic(fmt.Sprintf("runner for command %q was registered twice", name))
+	}
+	registry.runners[name] = func(configFile string) {
+		var c C
+		err := ReadConfigFile(configFile, &c)
+		FailOnError(err, "Reading JSON config file into config structure")
+		run(c)
+	}
+}
+
+// LookupRunner returns a function which reads the given config file and runs
+// the named subcommand's service with it. If no runner was registered, nil is
+// returned.
+func LookupRunner(name string) func(configFile string) {
+	registry.Lock()
+	defer registry.Unlock()
+	return registry.runners[name]
+}
+
 func LookupCommand(name string) func() {
 	registry.Lock()
 	defer registry.Unlock()
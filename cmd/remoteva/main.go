@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/letsencrypt/boulder/bdns"
+	caapb "github.com/letsencrypt/boulder/caa/proto"
 	"github.com/letsencrypt/boulder/cmd"
 	"github.com/letsencrypt/boulder/features"
 	bgrpc "github.com/letsencrypt/boulder/grpc"
@@ -97,6 +98,18 @@ func main() {
 	}
 	defer servers.Stop()
 
+	var txtServers bdns.ServerProvider
+	if len(c.RVA.DNSTXTStaticResolvers) != 0 {
+		txtServers, err = bdns.NewStaticProvider(c.RVA.DNSTXTStaticResolvers)
+		cmd.FailOnError(err, "Couldn't start static DNS TXT server resolver")
+	} else if c.RVA.DNSTXTProvider != nil {
+		txtServers, err = bdns.StartDynamicProvider(c.RVA.DNSTXTProvider, 60*time.Second, "tcp")
+		cmd.FailOnError(err, "Couldn't start dynamic DNS TXT server resolver")
+	}
+	if txtServers != nil {
+		defer txtServers.Stop()
+	}
+
 	tlsConfig, err := c.RVA.TLS.Load(scope)
 	cmd.FailOnError(err, "tlsConfig config")
 
@@ -126,6 +139,9 @@ func main() {
 			logger,
 			tlsConfig)
 	}
+	if txtServers != nil {
+		bdns.SetTXTServers(resolver, txtServers)
+	}
 
 	vai, err := va.NewValidationAuthorityImpl(
 		resolver,
@@ -138,12 +154,47 @@ func main() {
 		c.RVA.AccountURIPrefixes,
 		c.RVA.Perspective,
 		c.RVA.RIR,
-		iana.IsReservedAddr)
+		iana.IsReservedAddr,
+		c.RVA.DNSMaxAliasDepth)
 	cmd.FailOnError(err, "Unable to create Remote-VA server")
 
+	if c.RVA.CAAChecker != nil {
+		caaConn, err := bgrpc.ClientSetup(c.RVA.CAAChecker, tlsConfig, scope, clk)
+		cmd.FailOnError(err, "Unable to create caa-checker client")
+		vai.SetCAAChecker(caapb.NewCAACheckerClient(caaConn))
+	}
+
+	if c.RVA.ChallengePorts != nil {
+		err = vai.SetChallengePorts(*c.RVA.ChallengePorts)
+		cmd.FailOnError(err, "Unable to configure challenge ports")
+	}
+
+	if len(c.RVA.HTTPFingerprints) > 0 {
+		err = vai.SetHTTPFingerprints(c.RVA.HTTPFingerprints)
+		cmd.FailOnError(err, "Unable to configure HTTP fingerprints")
+	}
+	if c.RVA.HTTPRedirectPolicy != nil {
+		err = vai.SetHTTPRedirectPolicy(*c.RVA.HTTPRedirectPolicy)
+		cmd.FailOnError(err, "Unable to configure HTTP redirect policy")
+	}
+	if c.RVA.ValidationConcurrency != nil {
+		err = vai.SetValidationConcurrency(*c.RVA.ValidationConcurrency)
+		cmd.FailOnError(err, "Unable to configure validation concurrency limits")
+	}
+	if c.RVA.HTTPResponseArchive != nil {
+		err = vai.SetHTTPResponseArchive(*c.RVA.HTTPResponseArchive)
+		cmd.FailOnError(err, "Unable to configure HTTP response archive")
+	}
+	if c.RVA.HTTPBandwidth != nil {
+		err = vai.SetHTTPBandwidth(*c.RVA.HTTPBandwidth)
+		cmd.FailOnError(err, "Unable to configure HTTP bandwidth limits")
+	}
+
 	start, err := bgrpc.NewServer(c.RVA.GRPC, logger).Add(
 		&vapb.VA_ServiceDesc, vai).Add(
-		&vapb.CAA_ServiceDesc, vai).Build(tlsConfig, scope, clk)
+		&vapb.CAA_ServiceDesc, vai).Add(
+		&vapb.HTTPResponseArchive_ServiceDesc, vai).Add(
+		&vapb.ChallengePorts_ServiceDesc, vai).Build(tlsConfig, scope, clk)
 	cmd.FailOnError(err, "Unable to setup Remote-VA gRPC server")
 	cmd.FailOnError(start(), "Remote-VA gRPC service failed")
 }
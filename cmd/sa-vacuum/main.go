@@ -0,0 +1,565 @@
+package notmain
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/cmd"
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/db"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/sa"
+)
+
+// lockName is the name of the MariaDB user-level lock which sa-vacuum holds on
+// the primary while it performs maintenance, so that multiple instances never
+// run at the same time.
+const lockName = "boulder.sa-vacuum"
+
+// MaintenanceWindow is a daily period of low traffic during which maintenance
+// may run.
+type MaintenanceWindow struct {
+	// Start is the time of day, in UTC, at which the window opens, e.g.
+	// "02:30".
+	Start string `validate:"required,datetime=15:04"`
+
+	// Duration is how long the window stays open. It must be positive and no
+	// more than 24 hours. A window may extend past midnight.
+	Duration config.Duration `validate:"-"`
+}
+
+// DeleteTask describes rows which should be periodically deleted from a table
+// because they're older than a retention period.
+type DeleteTask struct {
+	// Table is the table to delete rows from.
+	Table string `validate:"required"`
+
+	// Column is a DATETIME column of Table. Rows are deleted once it's older
+	// than Retention.
+	Column string `validate:"required"`
+
+	// Retention is how long rows are kept.
+	Retention config.Duration `validate:"-"`
+
+	// BatchSize is the maximum number of rows deleted by each statement.
+	BatchSize int `validate:"required,min=1"`
+
+	// BatchInterval is how long to wait between batches, to give replication
+	// a chance to keep up.
+	BatchInterval config.Duration `validate:"-"`
+}
+
+type Config struct {
+	SAVacuum struct {
+		// DB is the primary database. Deletes run here, and it's where the
+		// lock which prevents overlapping maintenance is held.
+		DB cmd.DBConfig
+
+		// Replicas are the primary's replicas, by name. ANALYZE and OPTIMIZE
+		// aren't replicated, so they're run against each replica in turn
+		// after the primary.
+		Replicas map[string]cmd.DBConfig `validate:"omitempty,dive"`
+
+		DebugAddr string `validate:"omitempty,hostname_port"`
+
+		// Windows are the daily periods during which maintenance may run.
+		// Maintenance runs at most once per window, and stops when the
+		// window closes, even if it's unfinished.
+		Windows []MaintenanceWindow `validate:"min=1,dive"`
+
+		// Analyze are the tables to update index statistics for with ANALYZE
+		// TABLE.
+		Analyze []string `validate:"omitempty,dive,required"`
+
+		// Optimize are the tables to rebuild with OPTIMIZE TABLE, reclaiming
+		// space left by deletes.
+		Optimize []string `validate:"omitempty,dive,required"`
+
+		// Deletes are the rows to remove from the primary. They run before
+		// OPTIMIZE, so that space they free can be reclaimed in the same
+		// window.
+		Deletes []DeleteTask `validate:"omitempty,dive"`
+
+		// CheckInterval is how often to check whether a window has opened,
+		// and how long to wait before retrying after an error or while
+		// another instance holds the lock. Defaults to one minute.
+		CheckInterval config.Duration `validate:"-"`
+	}
+
+	Syslog        cmd.SyslogConfig
+	OpenTelemetry cmd.OpenTelemetryConfig
+}
+
+// identifierRegexp matches table and column names which are safe to
+// interpolate into maintenance statements.
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// window is a MaintenanceWindow, parsed.
+type window struct {
+	// start is the offset from midnight UTC at which the window opens.
+	start  time.Duration
+	length time.Duration
+}
+
+// windowEnd returns the time at which the window containing now closes, or
+// false if now isn't in any window.
+func windowEnd(windows []window, now time.Time) (time.Time, bool) {
+	now = now.UTC()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	for _, w := range windows {
+		// A window which opened yesterday may not have closed yet.
+		for _, day := range []time.Time{midnight.AddDate(0, 0, -1), midnight} {
+			start := day.Add(w.start)
+			end := start.Add(w.length)
+			if !now.Before(start) && now.Before(end) {
+				return end, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// maintainer runs maintenance statements against a single database.
+type maintainer interface {
+	// maintainTable runs an ANALYZE or OPTIMIZE statement for table.
+	maintainTable(ctx context.Context, op string, table string) error
+
+	// deleteBatch deletes up to limit rows from table whose column is before
+	// cutoff, returning the number deleted.
+	deleteBatch(ctx context.Context, table string, column string, cutoff time.Time, limit int) (int64, error)
+}
+
+// locker is an exclusive lock shared by all sa-vacuum instances.
+type locker interface {
+	// tryLock acquires the lock if it's free, without waiting.
+	tryLock(ctx context.Context) (bool, error)
+
+	// held reports whether the lock is still held. The lock can be lost
+	// without calling unlock, e.g. if the database connection is closed.
+	held(ctx context.Context) (bool, error)
+
+	unlock(ctx context.Context) error
+}
+
+// target is a named database to maintain.
+type target struct {
+	name string
+	db   maintainer
+}
+
+// task is a single maintenance operation.
+type task struct {
+	op     string
+	target target
+	table  string
+	delete *DeleteTask
+}
+
+func (t task) String() string {
+	return fmt.Sprintf("%s %s on %s", t.op, t.table, t.target.name)
+}
+
+var errLockHeld = errors.New("maintenance lock is held by another instance")
+
+type vacuumMetrics struct {
+	operations     *prometheus.CounterVec
+	remaining      prometheus.Gauge
+	rowsDeleted    *prometheus.CounterVec
+	lastCompletion prometheus.Gauge
+}
+
+func newVacuumMetrics(stats prometheus.Registerer) *vacuumMetrics {
+	operations := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "sa_vacuum_operations",
+		Help: "A counter of maintenance operations labelled by operation, target, table, and result",
+	}, []string{"operation", "target", "table", "result"})
+	stats.MustRegister(operations)
+
+	remaining := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "sa_vacuum_operations_remaining",
+		Help: "The number of maintenance operations not yet completed in the current window",
+	})
+	stats.MustRegister(remaining)
+
+	rowsDeleted := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "sa_vacuum_rows_deleted",
+		Help: "A counter of rows deleted labelled by table",
+	}, []string{"table"})
+	stats.MustRegister(rowsDeleted)
+
+	lastCompletion := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "sa_vacuum_last_completion_seconds",
+		Help: "The Unix timestamp at which all maintenance operations in a window last completed",
+	})
+	stats.MustRegister(lastCompletion)
+
+	return &vacuumMetrics{
+		operations:     operations,
+		remaining:      remaining,
+		rowsDeleted:    rowsDeleted,
+		lastCompletion: lastCompletion,
+	}
+}
+
+type vacuum struct {
+	primary       target
+	replicas      []target
+	lock          locker
+	windows       []window
+	analyze       []string
+	optimize      []string
+	deletes       []DeleteTask
+	checkInterval time.Duration
+	clk           clock.Clock
+	log           blog.Logger
+	metrics       *vacuumMetrics
+
+	// done holds the tasks completed in the window which closes at
+	// doneWindow, so that they aren't repeated after a retry.
+	done       map[string]bool
+	doneWindow time.Time
+}
+
+// tasks returns every maintenance operation, in the order they run. Deletes
+// come first, then each database is analyzed and optimized in turn, so that
+// only one of them is under load at a time.
+func (v *vacuum) tasks() []task {
+	var tasks []task
+	for i := range v.deletes {
+		tasks = append(tasks, task{op: "DELETE", target: v.primary, table: v.deletes[i].Table, delete: &v.deletes[i]})
+	}
+	for _, t := range append([]target{v.primary}, v.replicas...) {
+		for _, table := range v.analyze {
+			tasks = append(tasks, task{op: "ANALYZE", target: t, table: table})
+		}
+		for _, table := range v.optimize {
+			tasks = append(tasks, task{op: "OPTIMIZE", target: t, table: table})
+		}
+	}
+	return tasks
+}
+
+// runWindow performs every task not yet done in the window which closes at
+// end, holding the lock throughout. It returns early if the window closes, the
+// lock is lost, or a task fails.
+func (v *vacuum) runWindow(ctx context.Context, end time.Time) error {
+	if !v.doneWindow.Equal(end) {
+		v.done = make(map[string]bool)
+		v.doneWindow = end
+	}
+
+	ok, err := v.lock.tryLock(ctx)
+	if err != nil {
+		return fmt.Errorf("acquiring maintenance lock: %w", err)
+	}
+	if !ok {
+		return errLockHeld
+	}
+	defer func() {
+		err := v.lock.unlock(ctx)
+		if err != nil {
+			v.log.Warningf("releasing maintenance lock: %s", err)
+		}
+	}()
+
+	tasks := v.tasks()
+	for _, t := range tasks {
+		v.metrics.remaining.Set(float64(len(tasks) - len(v.done)))
+		if v.done[t.String()] {
+			continue
+		}
+		err := v.checkWindow(ctx, end)
+		if err != nil {
+			return err
+		}
+
+		v.log.Infof("Starting %s", t)
+		start := v.clk.Now()
+		err = v.runTask(ctx, t, end)
+		result := "success"
+		if err != nil {
+			result = "failure"
+		}
+		v.metrics.operations.With(prometheus.Labels{
+			"operation": t.op,
+			"target":    t.target.name,
+			"table":     t.table,
+			"result":    result,
+		}).Inc()
+		if err != nil {
+			return fmt.Errorf("%s: %w", t, err)
+		}
+		v.log.Infof("Completed %s in %s", t, v.clk.Since(start))
+		v.done[t.String()] = true
+	}
+	v.metrics.remaining.Set(0)
+	v.metrics.lastCompletion.Set(float64(v.clk.Now().Unix()))
+	v.log.Infof("Completed all %d maintenance operations", len(tasks))
+	return nil
+}
+
+// checkWindow returns an error if maintenance must stop, because the window
+// has closed or the lock has been lost.
+func (v *vacuum) checkWindow(ctx context.Context, end time.Time) error {
+	if !v.clk.Now().Before(end) {
+		return errors.New("maintenance window closed")
+	}
+	held, err := v.lock.held(ctx)
+	if err != nil {
+		return fmt.Errorf("checking maintenance lock: %w", err)
+	}
+	if !held {
+		return errors.New("maintenance lock was lost")
+	}
+	return nil
+}
+
+func (v *vacuum) runTask(ctx context.Context, t task, end time.Time) error {
+	if t.delete == nil {
+		return t.target.db.maintainTable(ctx, t.op, t.table)
+	}
+
+	// The cutoff is fixed for the whole task, so that it finishes even if
+	// new rows age past the retention period while it runs.
+	cutoff := v.clk.Now().Add(-t.delete.Retention.Duration)
+	var total int64
+	for {
+		n, err := t.target.db.deleteBatch(ctx, t.table, t.delete.Column, cutoff, t.delete.BatchSize)
+		if err != nil {
+			return err
+		}
+		total += n
+		v.metrics.rowsDeleted.WithLabelValues(t.table).Add(float64(n))
+		v.log.Infof("Deleted %d rows from %s older than %s (%d so far)", n, t.table, cutoff.Format(time.RFC3339), total)
+		if n < int64(t.delete.BatchSize) {
+			return nil
+		}
+		v.clk.Sleep(t.delete.BatchInterval.Duration)
+		err = v.checkWindow(ctx, end)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// run performs maintenance in each window, forever.
+func (v *vacuum) run(ctx context.Context) {
+	for {
+		end, ok := windowEnd(v.windows, v.clk.Now())
+		if !ok {
+			v.clk.Sleep(v.checkInterval)
+			continue
+		}
+		if v.doneWindow.Equal(end) && len(v.done) == len(v.tasks()) {
+			// Maintenance runs at most once per window.
+			v.clk.Sleep(end.Sub(v.clk.Now()))
+			continue
+		}
+		err := v.runWindow(ctx, end)
+		if err != nil {
+			v.log.Warningf("Maintenance incomplete: %s", err)
+			v.clk.Sleep(min(v.checkInterval, end.Sub(v.clk.Now())))
+		}
+	}
+}
+
+// newVacuum validates the parts of the config which can't be expressed as
+// struct tags, and returns a vacuum which maintains the given databases.
+func newVacuum(c Config, primary maintainer, replicas map[string]maintainer, lock locker, clk clock.Clock, logger blog.Logger, stats prometheus.Registerer) (*vacuum, error) {
+	var windows []window
+	for _, w := range c.SAVacuum.Windows {
+		start, err := time.Parse("15:04", w.Start)
+		if err != nil {
+			return nil, fmt.Errorf("parsing window start %q: %w", w.Start, err)
+		}
+		if w.Duration.Duration <= 0 || w.Duration.Duration > 24*time.Hour {
+			return nil, fmt.Errorf("window starting at %s must last between 0 and 24 hours, not %s", w.Start, w.Duration.Duration)
+		}
+		windows = append(windows, window{
+			start:  time.Duration(start.Hour())*time.Hour + time.Duration(start.Minute())*time.Minute,
+			length: w.Duration.Duration,
+		})
+	}
+
+	for _, table := range append(c.SAVacuum.Analyze, c.SAVacuum.Optimize...) {
+		if !identifierRegexp.MatchString(table) {
+			return nil, fmt.Errorf("invalid table name %q", table)
+		}
+	}
+	for _, d := range c.SAVacuum.Deletes {
+		if !identifierRegexp.MatchString(d.Table) {
+			return nil, fmt.Errorf("invalid table name %q", d.Table)
+		}
+		if !identifierRegexp.MatchString(d.Column) {
+			return nil, fmt.Errorf("invalid column name %q", d.Column)
+		}
+		if d.Retention.Duration <= 0 {
+			return nil, fmt.Errorf("retention for %s must be positive", d.Table)
+		}
+	}
+
+	var names []string
+	for name := range replicas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var replicaTargets []target
+	for _, name := range names {
+		replicaTargets = append(replicaTargets, target{name: name, db: replicas[name]})
+	}
+
+	checkInterval := c.SAVacuum.CheckInterval.Duration
+	if checkInterval <= 0 {
+		checkInterval = time.Minute
+	}
+
+	return &vacuum{
+		primary:       target{name: "primary", db: primary},
+		replicas:      replicaTargets,
+		lock:          lock,
+		windows:       windows,
+		analyze:       c.SAVacuum.Analyze,
+		optimize:      c.SAVacuum.Optimize,
+		deletes:       c.SAVacuum.Deletes,
+		checkInterval: checkInterval,
+		clk:           clk,
+		log:           logger,
+		metrics:       newVacuumMetrics(stats),
+	}, nil
+}
+
+// dbMaintainer runs maintenance statements against a database.
+type dbMaintainer struct {
+	dbMap *db.WrappedMap
+}
+
+func (m dbMaintainer) maintainTable(ctx context.Context, op string, table string) error {
+	// NO_WRITE_TO_BINLOG keeps the statement from being replicated, so that
+	// each replica is only maintained when its turn comes.
+	rows, err := m.dbMap.QueryContext(ctx, fmt.Sprintf("%s NO_WRITE_TO_BINLOG TABLE `%s`", op, table))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	// Problems with individual tables are reported in the result set, not
+	// as errors.
+	for rows.Next() {
+		var tbl, msgOp, msgType, msgText string
+		err := rows.Scan(&tbl, &msgOp, &msgType, &msgText)
+		if err != nil {
+			return err
+		}
+		if msgType == "error" {
+			return fmt.Errorf("%s %s: %s", msgOp, tbl, msgText)
+		}
+	}
+	return rows.Err()
+}
+
+func (m dbMaintainer) deleteBatch(ctx context.Context, table string, column string, cutoff time.Time, limit int) (int64, error) {
+	res, err := m.dbMap.ExecContext(ctx, fmt.Sprintf("DELETE FROM `%s` WHERE `%s` < ? LIMIT ?", table, column), cutoff, limit)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// dbLock is a MariaDB user-level lock. User-level locks belong to a session,
+// so it holds a dedicated connection while locked.
+type dbLock struct {
+	dbMap *db.WrappedMap
+	name  string
+	conn  *sql.Conn
+}
+
+func (l *dbLock) tryLock(ctx context.Context) (bool, error) {
+	conn, err := l.dbMap.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	var got sql.NullInt64
+	err = conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 0)", l.name).Scan(&got)
+	if err != nil || got.Int64 != 1 {
+		conn.Close()
+		return false, err
+	}
+	l.conn = conn
+	return true, nil
+}
+
+func (l *dbLock) held(ctx context.Context) (bool, error) {
+	if l.conn == nil {
+		return false, nil
+	}
+	var held sql.NullBool
+	err := l.conn.QueryRowContext(ctx, "SELECT IS_USED_LOCK(?) = CONNECTION_ID()", l.name).Scan(&held)
+	if err != nil {
+		return false, err
+	}
+	return held.Bool, nil
+}
+
+func (l *dbLock) unlock(ctx context.Context) error {
+	if l.conn == nil {
+		return nil
+	}
+	defer func() {
+		l.conn.Close()
+		l.conn = nil
+	}()
+	_, err := l.conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", l.name)
+	return err
+}
+
+func main() {
+	debugAddr := flag.String("debug-addr", "", "Debug server address override")
+	configPath := flag.String("config", "", "File path to the configuration file for this service")
+	flag.Parse()
+
+	if *configPath == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+	var c Config
+	err := cmd.ReadConfigFile(*configPath, &c)
+	cmd.FailOnError(err, "Failed reading config file")
+
+	if *debugAddr != "" {
+		c.SAVacuum.DebugAddr = *debugAddr
+	}
+
+	scope, logger, oTelShutdown := cmd.StatsAndLogging(c.Syslog, c.OpenTelemetry, c.SAVacuum.DebugAddr)
+	defer oTelShutdown(context.Background())
+	logger.Info(cmd.VersionString())
+	clk := cmd.Clock()
+
+	dbMap, err := sa.InitWrappedDb(c.SAVacuum.DB, scope, logger)
+	cmd.FailOnError(err, "While initializing dbMap")
+
+	replicas := make(map[string]maintainer)
+	for name, dbConfig := range c.SAVacuum.Replicas {
+		replicaMap, err := sa.InitWrappedDb(dbConfig, scope, logger)
+		cmd.FailOnError(err, fmt.Sprintf("While initializing dbMap for replica %q", name))
+		replicas[name] = dbMaintainer{dbMap: replicaMap}
+	}
+
+	v, err := newVacuum(c, dbMaintainer{dbMap: dbMap}, replicas, &dbLock{dbMap: dbMap, name: lockName}, clk, logger, scope)
+	cmd.FailOnError(err, "Invalid sa-vacuum config")
+
+	v.run(context.Background())
+}
+
+func init() {
+	cmd.RegisterCommand("sa-vacuum", main, &cmd.ConfigValidator{Config: &Config{}})
+}
@@ -0,0 +1,260 @@
+package notmain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/config"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestWindowEnd(t *testing.T) {
+	t.Parallel()
+
+	windows := []window{
+		{start: 2 * time.Hour, length: 2 * time.Hour},
+		{start: 23 * time.Hour, length: 2 * time.Hour},
+	}
+	day := time.Date(2025, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		now     time.Time
+		wantEnd time.Time
+		wantOK  bool
+	}{
+		{now: day.Add(90 * time.Minute), wantOK: false},
+		{now: day.Add(2 * time.Hour), wantEnd: day.Add(4 * time.Hour), wantOK: true},
+		{now: day.Add(3 * time.Hour), wantEnd: day.Add(4 * time.Hour), wantOK: true},
+		{now: day.Add(4 * time.Hour), wantOK: false},
+		// A window which extends past midnight.
+		{now: day.Add(23*time.Hour + 30*time.Minute), wantEnd: day.Add(25 * time.Hour), wantOK: true},
+		{now: day.Add(30 * time.Minute), wantEnd: day.Add(time.Hour), wantOK: true},
+		// Times in other zones are converted to UTC.
+		{now: day.Add(3 * time.Hour).In(time.FixedZone("UTC-5", -5*60*60)), wantEnd: day.Add(4 * time.Hour), wantOK: true},
+	} {
+		t.Run(tc.now.String(), func(t *testing.T) {
+			t.Parallel()
+			end, ok := windowEnd(windows, tc.now)
+			test.AssertEquals(t, ok, tc.wantOK)
+			test.AssertEquals(t, end, tc.wantEnd)
+		})
+	}
+}
+
+// fakeMaintainer records the operations it's asked to perform. Its
+// deleteBatch deletes rows from a fake table of the given size.
+type fakeMaintainer struct {
+	name string
+	ops  *[]string
+	rows int64
+	err  error
+}
+
+func (m *fakeMaintainer) maintainTable(_ context.Context, op string, table string) error {
+	*m.ops = append(*m.ops, fmt.Sprintf("%s %s on %s", op, table, m.name))
+	return m.err
+}
+
+func (m *fakeMaintainer) deleteBatch(_ context.Context, table string, column string, _ time.Time, limit int) (int64, error) {
+	*m.ops = append(*m.ops, fmt.Sprintf("DELETE %s.%s on %s", table, column, m.name))
+	if m.err != nil {
+		return 0, m.err
+	}
+	n := min(m.rows, int64(limit))
+	m.rows -= n
+	return n, nil
+}
+
+type fakeLocker struct {
+	free bool
+	lost bool
+	// locked is true while the lock is held.
+	locked bool
+}
+
+func (l *fakeLocker) tryLock(context.Context) (bool, error) {
+	if !l.free {
+		return false, nil
+	}
+	l.locked = true
+	return true, nil
+}
+
+func (l *fakeLocker) held(context.Context) (bool, error) {
+	return l.locked && !l.lost, nil
+}
+
+func (l *fakeLocker) unlock(context.Context) error {
+	l.locked = false
+	return nil
+}
+
+func testConfig() Config {
+	var c Config
+	c.SAVacuum.Windows = []MaintenanceWindow{{Start: "02:00", Duration: config.Duration{Duration: 2 * time.Hour}}}
+	c.SAVacuum.Analyze = []string{"authz2", "orders"}
+	c.SAVacuum.Optimize = []string{"authz2"}
+	c.SAVacuum.Deletes = []DeleteTask{{
+		Table:     "authz2",
+		Column:    "expires",
+		Retention: config.Duration{Duration: 90 * 24 * time.Hour},
+		BatchSize: 10,
+	}}
+	return c
+}
+
+func setup(t *testing.T, c Config) (*vacuum, *[]string, *fakeMaintainer, *fakeLocker, clock.FakeClock) {
+	t.Helper()
+	clk := clock.NewFake()
+	clk.Set(time.Date(2025, 10, 16, 2, 0, 0, 0, time.UTC))
+	var ops []string
+	primary := &fakeMaintainer{name: "primary", ops: &ops}
+	replicas := map[string]maintainer{
+		"replica-b": &fakeMaintainer{name: "replica-b", ops: &ops},
+		"replica-a": &fakeMaintainer{name: "replica-a", ops: &ops},
+	}
+	lock := &fakeLocker{free: true}
+	v, err := newVacuum(c, primary, replicas, lock, clk, blog.NewMock(), metrics.NoopRegisterer)
+	test.AssertNotError(t, err, "creating vacuum")
+	return v, &ops, primary, lock, clk
+}
+
+func TestNewVacuumErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		modify func(*Config)
+	}{
+		{"empty window", func(c *Config) { c.SAVacuum.Windows[0].Duration.Duration = 0 }},
+		{"window longer than a day", func(c *Config) { c.SAVacuum.Windows[0].Duration.Duration = 25 * time.Hour }},
+		{"bad window start", func(c *Config) { c.SAVacuum.Windows[0].Start = "2am" }},
+		{"bad analyze table", func(c *Config) { c.SAVacuum.Analyze[0] = "authz2; DROP TABLE orders" }},
+		{"bad optimize table", func(c *Config) { c.SAVacuum.Optimize[0] = "`authz2`" }},
+		{"bad delete table", func(c *Config) { c.SAVacuum.Deletes[0].Table = "authz2 a" }},
+		{"bad delete column", func(c *Config) { c.SAVacuum.Deletes[0].Column = "expires OR 1" }},
+		{"no retention", func(c *Config) { c.SAVacuum.Deletes[0].Retention.Duration = 0 }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			c := testConfig()
+			tc.modify(&c)
+			_, err := newVacuum(c, &fakeMaintainer{}, nil, &fakeLocker{}, clock.NewFake(), blog.NewMock(), metrics.NoopRegisterer)
+			test.AssertError(t, err, "invalid config accepted")
+		})
+	}
+}
+
+func TestRunWindow(t *testing.T) {
+	t.Parallel()
+
+	v, ops, primary, lock, clk := setup(t, testConfig())
+	primary.rows = 25
+	end, ok := windowEnd(v.windows, clk.Now())
+	test.Assert(t, ok, "not in window")
+
+	err := v.runWindow(context.Background(), end)
+	test.AssertNotError(t, err, "running maintenance")
+	test.Assert(t, !lock.locked, "lock wasn't released")
+
+	// Deletes run in batches until one is short, and each database is
+	// maintained in turn, replicas in name order.
+	test.AssertDeepEquals(t, *ops, []string{
+		"DELETE authz2.expires on primary",
+		"DELETE authz2.expires on primary",
+		"DELETE authz2.expires on primary",
+		"ANALYZE authz2 on primary",
+		"ANALYZE orders on primary",
+		"OPTIMIZE authz2 on primary",
+		"ANALYZE authz2 on replica-a",
+		"ANALYZE orders on replica-a",
+		"OPTIMIZE authz2 on replica-a",
+		"ANALYZE authz2 on replica-b",
+		"ANALYZE orders on replica-b",
+		"OPTIMIZE authz2 on replica-b",
+	})
+	test.AssertMetricWithLabelsEquals(t, v.metrics.rowsDeleted, prometheus.Labels{"table": "authz2"}, 25)
+	test.AssertMetricWithLabelsEquals(t, v.metrics.operations, prometheus.Labels{"operation": "ANALYZE", "result": "success"}, 6)
+	test.AssertMetricWithLabelsEquals(t, v.metrics.remaining, nil, 0)
+	test.AssertMetricWithLabelsEquals(t, v.metrics.lastCompletion, nil, float64(clk.Now().Unix()))
+}
+
+func TestRunWindowLockHeld(t *testing.T) {
+	t.Parallel()
+
+	v, ops, _, lock, clk := setup(t, testConfig())
+	lock.free = false
+	end, _ := windowEnd(v.windows, clk.Now())
+
+	err := v.runWindow(context.Background(), end)
+	test.AssertErrorIs(t, err, errLockHeld)
+	test.AssertEquals(t, len(*ops), 0)
+}
+
+func TestRunWindowLockLost(t *testing.T) {
+	t.Parallel()
+
+	v, ops, _, lock, clk := setup(t, testConfig())
+	lock.lost = true
+	end, _ := windowEnd(v.windows, clk.Now())
+
+	err := v.runWindow(context.Background(), end)
+	test.AssertError(t, err, "maintenance continued without the lock")
+	test.AssertContains(t, err.Error(), "lock was lost")
+	test.AssertEquals(t, len(*ops), 0)
+}
+
+func TestRunWindowResumes(t *testing.T) {
+	t.Parallel()
+
+	v, ops, primary, _, clk := setup(t, testConfig())
+	end, _ := windowEnd(v.windows, clk.Now())
+
+	primary.err = errors.New("oops")
+	err := v.runWindow(context.Background(), end)
+	test.AssertError(t, err, "failed delete ignored")
+	test.AssertMetricWithLabelsEquals(t, v.metrics.operations, prometheus.Labels{"operation": "DELETE", "result": "failure"}, 1)
+
+	// A retry in the same window starts with the failed task, and later
+	// retries skip what's already done.
+	primary.err = nil
+	*ops = nil
+	v.done["ANALYZE authz2 on primary"] = true
+	err = v.runWindow(context.Background(), end)
+	test.AssertNotError(t, err, "retrying maintenance")
+	test.AssertEquals(t, (*ops)[0], "DELETE authz2.expires on primary")
+	test.Assert(t, !slices.Contains(*ops, "ANALYZE authz2 on primary"), "completed task was repeated")
+
+	// The next window starts afresh.
+	*ops = nil
+	err = v.runWindow(context.Background(), end.Add(24*time.Hour))
+	test.AssertNotError(t, err, "running maintenance in next window")
+	test.Assert(t, slices.Contains(*ops, "ANALYZE authz2 on primary"), "task wasn't repeated in the next window")
+}
+
+func TestRunWindowStopsWhenWindowCloses(t *testing.T) {
+	t.Parallel()
+
+	c := testConfig()
+	c.SAVacuum.Deletes[0].BatchInterval = config.Duration{Duration: time.Hour}
+	v, ops, primary, _, clk := setup(t, c)
+	primary.rows = 100
+	end, _ := windowEnd(v.windows, clk.Now())
+
+	// Each batch waits an hour, so the two hour window closes after the
+	// second.
+	err := v.runWindow(context.Background(), end)
+	test.AssertError(t, err, "maintenance continued after the window closed")
+	test.AssertContains(t, err.Error(), "window closed")
+	test.AssertEquals(t, len(*ops), 2)
+	test.AssertEquals(t, primary.rows, int64(80))
+}
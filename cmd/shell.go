@@ -14,9 +14,11 @@ import (
 	"net/http/pprof"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"runtime"
 	"runtime/debug"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -188,12 +190,46 @@ func StatsAndLogging(logConf SyslogConfig, otConf OpenTelemetryConfig, addr stri
 	return newStatsRegistry(addr, logger), logger, shutdown
 }
 
+// setGlobalLoggers ensures that only the first logger created by NewLogger is
+// used globally, when several services run in one process.
+var setGlobalLoggers sync.Once
+
 // NewLogger creates a logger object with the provided settings, sets it as
 // the global logger, and returns it.
 //
 // It also sets the logging systems for various packages we use to go through
 // the created logger, and sets up a periodic log event for the current timestamp.
+// If a logger was already created, only the first one is used globally.
 func NewLogger(logConf SyslogConfig) blog.Logger {
+	var logger blog.Logger
+	if logConf.StdoutFormat == "" && logConf.File == nil {
+		logger = newSyslogOrStdoutLogger(logConf)
+	} else {
+		logger = newSinksLogger(logConf)
+	}
+
+	setGlobalLoggers.Do(func() {
+		_ = blog.Set(logger)
+		_ = mysql.SetLogger(mysqlLogger{logger})
+		grpclog.SetLoggerV2(grpcLogger{logger})
+		log.SetOutput(logWriter{logger})
+		redis.SetLogger(redisLogger{logger})
+	})
+
+	// Periodically log the current timestamp, to ensure syslog timestamps match
+	// Boulder's conception of time.
+	go func() {
+		for {
+			time.Sleep(time.Hour)
+			logger.Info(fmt.Sprintf("time=%s", time.Now().Format(time.RFC3339Nano)))
+		}
+	}()
+	return logger
+}
+
+// newSyslogOrStdoutLogger returns a logger which writes to syslog and stdout,
+// or only stdout if syslog is disabled.
+func newSyslogOrStdoutLogger(logConf SyslogConfig) blog.Logger {
 	var logger blog.Logger
 	if logConf.SyslogLevel >= 0 {
 		syslogger, err := syslog.Dial(
@@ -211,21 +247,38 @@ func NewLogger(logConf SyslogConfig) blog.Logger {
 	} else {
 		logger = blog.StdoutLogger(logConf.StdoutLevel)
 	}
+	return logger
+}
 
-	_ = blog.Set(logger)
-	_ = mysql.SetLogger(mysqlLogger{logger})
-	grpclog.SetLoggerV2(grpcLogger{logger})
-	log.SetOutput(logWriter{logger})
-	redis.SetLogger(redisLogger{logger})
-
-	// Periodically log the current timestamp, to ensure syslog timestamps match
-	// Boulder's conception of time.
-	go func() {
-		for {
-			time.Sleep(time.Hour)
-			logger.Info(fmt.Sprintf("time=%s", time.Now().Format(time.RFC3339Nano)))
+// newSinksLogger returns a logger which writes to each of syslog, stdout, and
+// a log file which logConf enables.
+func newSinksLogger(logConf SyslogConfig) blog.Logger {
+	var sinks []blog.Sink
+	if logConf.SyslogLevel >= 0 {
+		syslogger, err := syslog.Dial(
+			"",
+			"",
+			syslog.LOG_INFO, // default, not actually used
+			core.Command())
+		FailOnError(err, "Could not connect to Syslog")
+		syslogLevel := int(syslog.LOG_INFO)
+		if logConf.SyslogLevel != 0 {
+			syslogLevel = logConf.SyslogLevel
 		}
-	}()
+		sink, err := blog.SyslogSink(syslogger, syslogLevel)
+		FailOnError(err, "Could not connect to Syslog")
+		sinks = append(sinks, sink)
+	}
+	sink, err := blog.StdoutSink(logConf.StdoutLevel, logConf.StdoutFormat)
+	FailOnError(err, "Could not configure stdout logging")
+	sinks = append(sinks, sink)
+	if logConf.File != nil {
+		sink, err := blog.FileSink(*logConf.File)
+		FailOnError(err, "Could not open log file")
+		sinks = append(sinks, sink)
+	}
+	logger, err := blog.NewWithSinks(sinks...)
+	FailOnError(err, "Could not create logger")
 	return logger
 }
 
@@ -291,6 +344,9 @@ func newStatsRegistry(addr string, logger blog.Logger) prometheus.Registerer {
 	mux.Handle("/debug/vars", expvar.Handler())
 	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{
 		ErrorLog: promLogger{logger},
+		// OpenMetrics is required to expose the trace ID exemplars attached
+		// to latency histograms.
+		EnableOpenMetrics: true,
 	}))
 
 	logger.Infof("Debug server listening on %s", addr)
@@ -444,38 +500,11 @@ func ValidateJSONConfig(cv *ConfigValidator, in io.Reader) error {
 		return errors.New("config validator cannot be nil")
 	}
 
-	// Initialize the validator and load any custom tags.
-	validate := validator.New()
-	for tag, v := range cv.Validators {
-		err := validate.RegisterValidation(tag, v)
-		if err != nil {
-			return err
-		}
-	}
-
-	// Register custom types for use with existing validation tags.
-	validate.RegisterCustomTypeFunc(config.DurationCustomTypeFunc, config.Duration{})
-
 	err := decodeJSONStrict(in, cv.Config)
 	if err != nil {
 		return err
 	}
-	err = validate.Struct(cv.Config)
-	if err != nil {
-		errs, ok := err.(validator.ValidationErrors)
-		if !ok {
-			// This should never happen.
-			return err
-		}
-		if len(errs) > 0 {
-			allErrs := []string{}
-			for _, e := range errs {
-				allErrs = append(allErrs, e.Error())
-			}
-			return errors.New(strings.Join(allErrs, ", "))
-		}
-	}
-	return nil
+	return validateConfig(cv)
 }
 
 // ValidateYAMLConfig takes a *ConfigValidator and an io.Reader containing a
@@ -489,6 +518,21 @@ func ValidateYAMLConfig(cv *ConfigValidator, in io.Reader) error {
 		return errors.New("config validator cannot be nil")
 	}
 
+	inBytes, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	err = strictyaml.Unmarshal(inBytes, cv.Config)
+	if err != nil {
+		return err
+	}
+	return validateConfig(cv)
+}
+
+// validateConfig validates the *ConfigValidator's already unmarshaled inner
+// Config according to the 'validate' tags on each field, and returns all of
+// the failures as a single error.
+func validateConfig(cv *ConfigValidator) error {
 	// Initialize the validator and load any custom tags.
 	validate := validator.New()
 	for tag, v := range cv.Validators {
@@ -501,15 +545,7 @@ func ValidateYAMLConfig(cv *ConfigValidator, in io.Reader) error {
 	// Register custom types for use with existing validation tags.
 	validate.RegisterCustomTypeFunc(config.DurationCustomTypeFunc, config.Duration{})
 
-	inBytes, err := io.ReadAll(in)
-	if err != nil {
-		return err
-	}
-	err = strictyaml.Unmarshal(inBytes, cv.Config)
-	if err != nil {
-		return err
-	}
-	err = validate.Struct(cv.Config)
+	err := validate.Struct(cv.Config)
 	if err != nil {
 		errs, ok := err.(validator.ValidationErrors)
 		if !ok {
@@ -527,6 +563,34 @@ func ValidateYAMLConfig(cv *ConfigValidator, in io.Reader) error {
 	return nil
 }
 
+// ReadAndValidateConfigFile reads the given config file with the
+// *ConfigValidator registered for the named Boulder component, and returns
+// the validated config. Files named *.yml or *.yaml are read as YAML, and all
+// others as JSON. Unknown fields are rejected either way. If the component has
+// no registered *ConfigValidator, it returns nil and a nil error.
+func ReadAndValidateConfigFile(name, filename string) (any, error) {
+	cv := LookupConfigValidator(name)
+	if cv == nil {
+		return nil, nil
+	}
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	switch filepath.Ext(filename) {
+	case ".yml", ".yaml":
+		err = ValidateYAMLConfig(cv, file)
+	default:
+		err = ValidateJSONConfig(cv, file)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return cv.Config, nil
+}
+
 // VersionString produces a friendly Application version string.
 func VersionString() string {
 	return fmt.Sprintf("Versions: %s=(%s %s) Golang=(%s) BuildHost=(%s)", core.Command(), core.GetBuildID(), core.GetBuildTime(), runtime.Version(), core.GetBuildHost())
@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
@@ -15,6 +16,8 @@ import (
 
 	"github.com/letsencrypt/boulder/config"
 	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/features"
+	"github.com/letsencrypt/boulder/identifier"
 	blog "github.com/letsencrypt/boulder/log"
 	"github.com/letsencrypt/boulder/test"
 )
@@ -68,6 +71,17 @@ func TestPAConfigUnmarshal(t *testing.T) {
 	test.AssertNotError(t, err, "Failed to unmarshal PAConfig")
 	test.AssertError(t, pc4.CheckChallenges(), "Disallow empty challenges map")
 	test.AssertNotError(t, pc4.CheckIdentifiers(), "Disallowed empty identifiers map")
+
+	pc5 := PAConfig{
+		Challenges:  map[core.AcmeChallenge]bool{core.ChallengeTypeEmailReply00: true},
+		Identifiers: map[identifier.IdentifierType]bool{identifier.TypeEmail: true},
+	}
+	test.AssertError(t, pc5.CheckChallenges(), "Allowed email-reply-00 without EmailIdentifiers")
+	test.AssertError(t, pc5.CheckIdentifiers(), "Allowed email identifiers without EmailIdentifiers")
+	features.Set(features.Config{EmailIdentifiers: true})
+	defer features.Reset()
+	test.AssertNotError(t, pc5.CheckChallenges(), "Disallowed email-reply-00 with EmailIdentifiers")
+	test.AssertNotError(t, pc5.CheckIdentifiers(), "Disallowed email identifiers with EmailIdentifiers")
 }
 
 func TestMysqlLogger(t *testing.T) {
@@ -265,6 +279,84 @@ func TestFailedConfigValidation(t *testing.T) {
 	test.AssertContains(t, err.Error(), "missing unit in duration")
 }
 
+func TestReadAndValidateConfigFile(t *testing.T) {
+	type BarConfig struct {
+		Name    string          `yaml:"name" validate:"required"`
+		MaxSize config.ByteSize `yaml:"maxSize" validate:"max=1048576"`
+	}
+	RegisterCommand("test-read-and-validate", func() {}, &ConfigValidator{Config: &BarConfig{}})
+
+	testCases := []struct {
+		name     string
+		file     string
+		contents string
+		wantErr  string
+	}{
+		{
+			name:     "valid JSON",
+			file:     "bar.json",
+			contents: `{"name": "bar", "maxSize": "1KiB"}`,
+		},
+		{
+			name:     "valid YAML",
+			file:     "bar.yaml",
+			contents: "name: bar\nmaxSize: 1024\n",
+		},
+		{
+			name:     "unknown JSON field",
+			file:     "bar.json",
+			contents: `{"name": "bar", "minSize": "1KiB"}`,
+			wantErr:  "unknown field",
+		},
+		{
+			name:     "unknown YAML field",
+			file:     "bar.yml",
+			contents: "name: bar\nminSize: 1024\n",
+			wantErr:  "not found",
+		},
+		{
+			name:     "missing required field",
+			file:     "bar.json",
+			contents: `{"maxSize": 1024}`,
+			wantErr:  "'required'",
+		},
+		{
+			name:     "byte size too large",
+			file:     "bar.json",
+			contents: `{"name": "bar", "maxSize": "2MiB"}`,
+			wantErr:  "'max'",
+		},
+		{
+			name:     "invalid byte size",
+			file:     "bar.json",
+			contents: `{"name": "bar", "maxSize": "2 furlongs"}`,
+			wantErr:  "invalid byte size",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tc.file)
+			err := os.WriteFile(path, []byte(tc.contents), 0600)
+			test.AssertNotError(t, err, "writing config file")
+
+			c, err := ReadAndValidateConfigFile("test-read-and-validate", path)
+			if tc.wantErr != "" {
+				test.AssertError(t, err, "expected validation error")
+				test.AssertContains(t, err.Error(), tc.wantErr)
+				return
+			}
+			test.AssertNotError(t, err, "ReadAndValidateConfigFile failed")
+			test.AssertDeepEquals(t, c, &BarConfig{Name: "bar", MaxSize: 1024})
+		})
+	}
+
+	// Commands without a registered validator aren't validated.
+	c, err := ReadAndValidateConfigFile("test-unregistered", "does-not-exist.json")
+	test.AssertNotError(t, err, "unregistered command shouldn't be validated")
+	test.Assert(t, c == nil, "unregistered command shouldn't return a config")
+}
+
 func TestFailExit(t *testing.T) {
 	// Test that when Fail is called with a `defer AuditPanic()`,
 	// the program exits with a non-zero exit code and logs
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ByteSize is a size in bytes which can be written in a config either as a
+// plain number of bytes, or as a string with a unit suffix such as "512KiB" or
+// "10MB". Decimal (kB, MB, GB) and binary (KiB, MiB, GiB) units are accepted.
+// Because it's an integer type, the standard numeric validation tags such as
+// min and max apply to it directly.
+type ByteSize int64
+
+// byteSizeUnits are the accepted unit suffixes, longest first so that "MiB"
+// isn't mistaken for "B".
+var byteSizeUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"KiB", 1 << 10},
+	{"MiB", 1 << 20},
+	{"GiB", 1 << 30},
+	{"kB", 1000},
+	{"KB", 1000},
+	{"MB", 1000 * 1000},
+	{"GB", 1000 * 1000 * 1000},
+	{"B", 1},
+}
+
+// parseByteSize parses a number of bytes with an optional unit suffix.
+func parseByteSize(s string) (ByteSize, error) {
+	s = strings.TrimSpace(s)
+	multiplier := int64(1)
+	for _, unit := range byteSizeUnits {
+		if strings.HasSuffix(s, unit.suffix) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, unit.suffix))
+			multiplier = unit.multiplier
+			break
+		}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte size %q", s)
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("byte size %q is negative", s)
+	}
+	if n > (1<<63-1)/multiplier {
+		return 0, fmt.Errorf("byte size %q is too large", s)
+	}
+	return ByteSize(n * multiplier), nil
+}
+
+// ErrByteSizeMustBeNumberOrString is returned when a value which is neither a
+// number nor a string is presented to be deserialized as a ByteSize.
+var ErrByteSizeMustBeNumberOrString = errors.New("cannot JSON unmarshal something other than a number or a string into a ByteSize")
+
+// UnmarshalJSON parses either a JSON number of bytes, or a JSON string parsed
+// by parseByteSize.
+func (b *ByteSize) UnmarshalJSON(data []byte) error {
+	var n int64
+	err := json.Unmarshal(data, &n)
+	if err == nil {
+		if n < 0 {
+			return fmt.Errorf("byte size %d is negative", n)
+		}
+		*b = ByteSize(n)
+		return nil
+	}
+	var s string
+	err = json.Unmarshal(data, &s)
+	if err != nil {
+		return ErrByteSizeMustBeNumberOrString
+	}
+	size, err := parseByteSize(s)
+	if err != nil {
+		return err
+	}
+	*b = size
+	return nil
+}
+
+// UnmarshalYAML uses the same format as JSON, but is called by the YAML
+// parser (vs. the JSON parser).
+func (b *ByteSize) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var n int64
+	err := unmarshal(&n)
+	if err == nil {
+		if n < 0 {
+			return fmt.Errorf("byte size %d is negative", n)
+		}
+		*b = ByteSize(n)
+		return nil
+	}
+	var s string
+	err = unmarshal(&s)
+	if err != nil {
+		return err
+	}
+	size, err := parseByteSize(s)
+	if err != nil {
+		return err
+	}
+	*b = size
+	return nil
+}
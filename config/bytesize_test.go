@@ -0,0 +1,50 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestByteSizeUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		in      string
+		want    ByteSize
+		wantErr bool
+	}{
+		{in: `0`, want: 0},
+		{in: `1024`, want: 1024},
+		{in: `"1024"`, want: 1024},
+		{in: `"512B"`, want: 512},
+		{in: `"2kB"`, want: 2000},
+		{in: `"2KB"`, want: 2000},
+		{in: `"2KiB"`, want: 2048},
+		{in: `"10 MB"`, want: 10 * 1000 * 1000},
+		{in: `"10MiB"`, want: 10 << 20},
+		{in: `"1GiB"`, want: 1 << 30},
+		{in: `-1`, wantErr: true},
+		{in: `"-1KiB"`, wantErr: true},
+		{in: `"1.5MiB"`, wantErr: true},
+		{in: `"1TiB"`, wantErr: true},
+		{in: `"KiB"`, wantErr: true},
+		{in: `"9999999999999GiB"`, wantErr: true},
+		{in: `true`, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			var b ByteSize
+			err := json.Unmarshal([]byte(tc.in), &b)
+			if tc.wantErr {
+				test.AssertError(t, err, "expected error")
+				return
+			}
+			test.AssertNotError(t, err, "unmarshaling byte size")
+			test.AssertEquals(t, b, tc.want)
+		})
+	}
+}
@@ -48,7 +48,7 @@ func (d *Duration) UnmarshalJSON(b []byte) error {
 
 // MarshalJSON returns the string form of the duration, as a byte array.
 func (d Duration) MarshalJSON() ([]byte, error) {
-	return []byte(d.Duration.String()), nil
+	return json.Marshal(d.Duration.String())
 }
 
 // UnmarshalYAML uses the same format as JSON, but is called by the YAML
@@ -0,0 +1,481 @@
+// Package jws parses and verifies the JSON Web Signatures which authenticate
+// ACME requests. It enforces the protected header policy of RFC 8555 Section
+// 6.2, but leaves finding the key which signed a JWS identified by Key ID, and
+// redeeming its nonce, to the caller.
+package jws
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/go-jose/go-jose/v4"
+
+	"github.com/letsencrypt/boulder/core"
+	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/nonce"
+)
+
+// Error is returned when a JWS fails one of the checks in this package. Kind
+// names the check which failed, e.g. "JWSMismatchedURL", so that callers can
+// count failures without matching on error messages. Err is the
+// *berrors.BoulderError which should be returned to the client.
+type Error struct {
+	Kind string
+
+	// Algorithm is the algorithm the JWS was signed with, if it was rejected
+	// because that algorithm isn't allowed.
+	Algorithm jose.SignatureAlgorithm
+
+	Err error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func fail(kind string, err error) error {
+	return &Error{Kind: kind, Err: err}
+}
+
+// AlgorithmForKey returns the signature algorithm which must be used with the
+// provided key, based on its Golang type.
+func AlgorithmForKey(key *jose.JSONWebKey) (jose.SignatureAlgorithm, error) {
+	switch k := key.Key.(type) {
+	case *rsa.PublicKey:
+		return jose.RS256, nil
+	case ed25519.PublicKey:
+		return jose.EdDSA, nil
+	case *ecdsa.PublicKey:
+		switch k.Params().Name {
+		case "P-256":
+			return jose.ES256, nil
+		case "P-384":
+			return jose.ES384, nil
+		case "P-521":
+			return jose.ES512, nil
+		}
+	}
+	return "", berrors.BadPublicKeyError("JWK contains unsupported key type (expected RSA, or ECDSA P-256, P-384, or P-521)")
+}
+
+// AuthType represents whether a JWS is authenticated using an embedded JWK
+// (new-account, revoke-cert) or an embedded Key ID (everything else), or an
+// unsupported/unknown auth type.
+type AuthType int
+
+const (
+	EmbeddedJWK AuthType = iota
+	EmbeddedKeyID
+	InvalidAuthType
+)
+
+// CheckAuthType examines the protected header of a JWS to determine if it is
+// authenticated using an embedded JWK or an embedded Key ID. If both are
+// present an error is returned. CheckAuthType is separate from
+// EnforceAuthType so that endpoints which handle both kinds of request can
+// determine which they have and act accordingly (e.g. revocation).
+func CheckAuthType(header jose.Header) (AuthType, error) {
+	// There must not be a Key ID *and* an embedded JWK
+	if header.KeyID != "" && header.JSONWebKey != nil {
+		return InvalidAuthType, fail("JWSAuthTypeInvalid", berrors.MalformedError("jwk and kid header fields are mutually exclusive"))
+	} else if header.KeyID != "" {
+		return EmbeddedKeyID, nil
+	} else if header.JSONWebKey != nil {
+		return EmbeddedJWK, nil
+	}
+
+	return InvalidAuthType, nil
+}
+
+// EnforceAuthType returns an error unless the protected header of a JWS has
+// the expected auth type.
+func EnforceAuthType(header jose.Header, expected AuthType) error {
+	authType, err := CheckAuthType(header)
+	if err != nil {
+		return err
+	}
+	if authType != expected {
+		switch expected {
+		case EmbeddedKeyID:
+			return fail("JWSAuthTypeWrong", berrors.MalformedError("No Key ID in JWS header"))
+		case EmbeddedJWK:
+			return fail("JWSAuthTypeWrong", berrors.MalformedError("No embedded JWK in JWS header"))
+		}
+	}
+	return nil
+}
+
+// ExtractJWK returns the JWK embedded in the protected header of a JWS. It
+// returns an error if the JWS is identified by Key ID instead, or the JWK
+// isn't valid.
+func ExtractJWK(header jose.Header) (*jose.JSONWebKey, error) {
+	err := EnforceAuthType(header, EmbeddedJWK)
+	if err != nil {
+		return nil, err
+	}
+
+	// We can be sure that JSONWebKey is != nil because we have already called
+	// EnforceAuthType()
+	key := header.JSONWebKey
+	if !key.Valid() {
+		return nil, fail("JWKInvalid", berrors.MalformedError("Invalid JWK in JWS header"))
+	}
+	return key, nil
+}
+
+// Nonce returns the anti-replay nonce from the protected header of a JWS, if
+// it's well-formed. Whether the nonce has been issued and not yet redeemed is
+// left to the caller to check.
+func Nonce(header jose.Header) (string, error) {
+	if len(header.Nonce) == 0 {
+		return "", fail("JWSMissingNonce", berrors.BadNonceError("JWS has no anti-replay nonce"))
+	}
+	err := NonceWellFormed(header.Nonce, nonce.PrefixLen)
+	if err != nil {
+		return "", fail("JWSMalformedNonce", err)
+	}
+	return header.Nonce, nil
+}
+
+// NonceWellFormed checks a JWS' Nonce header to ensure it is well-formed,
+// otherwise a bad nonce error is returned. This avoids unnecessary RPCs to
+// the nonce redemption service.
+func NonceWellFormed(nonceHeader string, prefixLen int) error {
+	errBadNonce := berrors.BadNonceError("JWS has an invalid anti-replay nonce: %q", nonceHeader)
+	if len(nonceHeader) <= prefixLen {
+		// Nonce header was an unexpected length because there is either:
+		// 1) no nonce, or
+		// 2) no nonce material after the prefix.
+		return errBadNonce
+	}
+	body, err := base64.RawURLEncoding.DecodeString(nonceHeader[prefixLen:])
+	if err != nil {
+		// Nonce was not valid base64url.
+		return errBadNonce
+	}
+	if len(body) != nonce.NonceLen {
+		// Nonce was an unexpected length.
+		return errBadNonce
+	}
+	return nil
+}
+
+// CheckURL checks that the "url" protected header of a JWS is the URL which
+// the request carrying it was sent to. This prevents a JWS intended for one
+// endpoint being replayed against a different endpoint.
+func CheckURL(header jose.Header, expected string) error {
+	// Check that there is at least one Extra Header
+	if len(header.ExtraHeaders) == 0 {
+		return fail("JWSNoExtraHeaders", berrors.MalformedError("JWS header parameter 'url' required"))
+	}
+	headerURL, ok := header.ExtraHeaders[jose.HeaderKey("url")].(string)
+	if !ok || len(headerURL) == 0 {
+		return fail("JWSMissingURL", berrors.MalformedError("JWS header parameter 'url' required"))
+	}
+	if headerURL != expected {
+		return fail("JWSMismatchedURL", berrors.MalformedError("JWS header parameter 'url' incorrect. Expected %q got %q", expected, headerURL))
+	}
+	return nil
+}
+
+// MatchURLs checks that the "url" protected headers of two JWS are present
+// and equal. This is used during key rollover to check that the inner JWS URL
+// matches the outer JWS URL.
+func MatchURLs(outer, inner jose.Header) error {
+	// Verify that the outer JWS has a non-empty URL header. This is strictly
+	// defensive, since callers are expected to have checked the outer JWS
+	// with CheckURL before looking at the inner JWS.
+	outerURL, ok := outer.ExtraHeaders[jose.HeaderKey("url")].(string)
+	if !ok || len(outerURL) == 0 {
+		return fail("KeyRolloverOuterJWSNoURL", berrors.MalformedError("Outer JWS header parameter 'url' required"))
+	}
+
+	innerURL, ok := inner.ExtraHeaders[jose.HeaderKey("url")].(string)
+	if !ok || len(innerURL) == 0 {
+		return fail("KeyRolloverInnerJWSNoURL", berrors.MalformedError("Inner JWS header parameter 'url' required"))
+	}
+
+	if outerURL != innerURL {
+		return fail("KeyRolloverMismatchedURLs", berrors.MalformedError("Outer JWS 'url' value %q does not match inner JWS 'url' value %q", outerURL, innerURL))
+	}
+	return nil
+}
+
+// CriticalParam validates the value of an extension header parameter which a
+// JWS lists in its "crit" header.
+type CriticalParam func(value any) error
+
+// Verifier parses and verifies JWS according to its policy. The zero value
+// allows no signature algorithms, so it rejects every JWS.
+type Verifier struct {
+	// Algorithms are the signature algorithms which a JWS may be signed with.
+	Algorithms []jose.SignatureAlgorithm
+
+	// Critical maps each extension header parameter which a JWS may list in
+	// its "crit" header to a function which validates its value. As RFC 7515
+	// Section 4.1.11 requires, a JWS listing any other parameter is rejected.
+	Critical map[string]CriticalParam
+}
+
+// Parse extracts a JWS from a byte slice. If there is an error reading the JWS
+// or it is unacceptable (e.g. too many/too few signatures, presence of
+// unprotected headers, an unsupported critical header parameter) an error is
+// returned. Parse doesn't verify the signature.
+func (v *Verifier) Parse(body []byte) (*jose.JSONWebSignature, error) {
+	// Parse the raw JWS JSON to check that:
+	// * the unprotected Header field is not being used.
+	// * the "signatures" member isn't present, just "signature".
+	//
+	// This must be done prior to `jose.ParseSigned` since it will strip away
+	// these headers.
+	var unprotected struct {
+		Header     map[string]string
+		Signatures []interface{}
+	}
+	err := json.Unmarshal(body, &unprotected)
+	if err != nil {
+		return nil, fail("JWSUnmarshalFailed", berrors.MalformedError("Parse error reading JWS"))
+	}
+
+	// ACME v2 never uses values from the unprotected JWS header. Reject JWS that
+	// include unprotected headers.
+	if unprotected.Header != nil {
+		return nil, fail("JWSUnprotectedHeaders", berrors.MalformedError(
+			"JWS \"header\" field not allowed. All headers must be in \"protected\" field"))
+	}
+
+	// ACME v2 never uses the "signatures" array of JSON serialized JWS, just the
+	// mandatory "signature" field. Reject JWS that include the "signatures" array.
+	if len(unprotected.Signatures) > 0 {
+		return nil, fail("JWSMultiSig", berrors.MalformedError(
+			"JWS \"signatures\" field not allowed. Only the \"signature\" field should contain a signature"))
+	}
+
+	// Parse the JWS using go-jose and enforce that the expected one non-empty
+	// signature is present in the parsed JWS.
+	parsedJWS, err := jose.ParseSigned(string(body), v.Algorithms)
+	if err != nil {
+		var unexpectedSignAlgoErr *jose.ErrUnexpectedSignatureAlgorithm
+		if errors.As(err, &unexpectedSignAlgoErr) {
+			return nil, &Error{
+				Kind:      "JWSAlgorithmCheckFailed",
+				Algorithm: unexpectedSignAlgoErr.Got,
+				Err: berrors.BadSignatureAlgorithmError(
+					"JWS signature header contains unsupported algorithm %q, expected one of %s",
+					unexpectedSignAlgoErr.Got,
+					v.Algorithms,
+				),
+			}
+		}
+		return nil, fail("JWSParseError", berrors.MalformedError("Parse error reading JWS"))
+	}
+	if len(parsedJWS.Signatures) > 1 {
+		return nil, fail("JWSTooManySignatures", berrors.MalformedError("Too many signatures in POST body"))
+	}
+	if len(parsedJWS.Signatures) == 0 {
+		return nil, fail("JWSNoSignatures", berrors.MalformedError("POST JWS not signed"))
+	}
+	if len(parsedJWS.Signatures[0].Signature) == 0 {
+		return nil, fail("JWSEmptySignature", berrors.MalformedError("POST JWS not signed"))
+	}
+
+	_, err = v.checkCritical(parsedJWS.Signatures[0].Header)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsedJWS, nil
+}
+
+// checkCritical checks that every header parameter listed in the "crit"
+// header of a JWS is present and valid, and returns their names.
+func (v *Verifier) checkCritical(header jose.Header) ([]string, error) {
+	raw, ok := header.ExtraHeaders[jose.HeaderKey("crit")]
+	if !ok {
+		return nil, nil
+	}
+	list, ok := raw.([]interface{})
+	if !ok || len(list) == 0 {
+		return nil, fail("JWSInvalidCritical", berrors.MalformedError("JWS header parameter 'crit' must be a non-empty list of names"))
+	}
+
+	var names []string
+	for _, item := range list {
+		name, ok := item.(string)
+		if !ok || name == "" {
+			return nil, fail("JWSInvalidCritical", berrors.MalformedError("JWS header parameter 'crit' must be a non-empty list of names"))
+		}
+		validate, ok := v.Critical[name]
+		if !ok {
+			return nil, fail("JWSUnsupportedCritical", berrors.MalformedError("JWS critical header parameter %q is not supported", name))
+		}
+		value, ok := header.ExtraHeaders[jose.HeaderKey(name)]
+		if !ok {
+			return nil, fail("JWSInvalidCritical", berrors.MalformedError("JWS critical header parameter %q is missing", name))
+		}
+		err := validate(value)
+		if err != nil {
+			return nil, fail("JWSInvalidCritical", berrors.MalformedError("JWS critical header parameter %q is invalid: %s", name, err))
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// Verify checks the signature of a JWS returned by Parse using the provided
+// key, and returns the payload. The caller is responsible for checking that
+// the key's algorithm matches the JWS' and is acceptable.
+func (v *Verifier) Verify(jws *jose.JSONWebSignature, key *jose.JSONWebKey) ([]byte, error) {
+	payload, err := v.verify(jws, key)
+	if err != nil {
+		return nil, fail("JWSVerifyFailed", berrors.MalformedError("JWS verification error"))
+	}
+	return payload, nil
+}
+
+func (v *Verifier) verify(jws *jose.JSONWebSignature, key *jose.JSONWebKey) ([]byte, error) {
+	critical, err := v.checkCritical(jws.Signatures[0].Header)
+	if err != nil {
+		return nil, err
+	}
+	if len(critical) == 0 {
+		return jws.Verify(key)
+	}
+
+	// go-jose refuses to verify a JWS with critical header parameters it
+	// doesn't know itself, so verify the signature over the serialized
+	// protected header and payload here instead.
+	var serialized struct {
+		Protected string
+		Payload   string
+	}
+	err = json.Unmarshal([]byte(jws.FullSerialize()), &serialized)
+	if err != nil {
+		return nil, err
+	}
+	signingInput := []byte(serialized.Protected + "." + serialized.Payload)
+	alg := jose.SignatureAlgorithm(jws.Signatures[0].Header.Algorithm)
+	err = verifySignature(alg, key.Key, signingInput, jws.Signatures[0].Signature)
+	if err != nil {
+		return nil, err
+	}
+	return base64.RawURLEncoding.DecodeString(serialized.Payload)
+}
+
+// verifySignature verifies a JWS signature made with alg over signingInput.
+func verifySignature(alg jose.SignatureAlgorithm, key any, signingInput, sig []byte) error {
+	errMismatch := fmt.Errorf("key of type %T can't verify a %s signature", key, alg)
+	switch alg {
+	case jose.RS256:
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return errMismatch
+		}
+		digest := sha256.Sum256(signingInput)
+		return rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig)
+
+	case jose.ES256, jose.ES384, jose.ES512:
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return errMismatch
+		}
+		var digest []byte
+		switch alg {
+		case jose.ES256:
+			d := sha256.Sum256(signingInput)
+			digest = d[:]
+		case jose.ES384:
+			d := sha512.Sum384(signingInput)
+			digest = d[:]
+		case jose.ES512:
+			d := sha512.Sum512(signingInput)
+			digest = d[:]
+		}
+		// RFC 7518 Section 3.4: the signature is R and S, each padded to the
+		// size of the curve.
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return errors.New("ECDSA signature has the wrong length")
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return errors.New("ECDSA signature is invalid")
+		}
+		return nil
+
+	case jose.EdDSA:
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return errMismatch
+		}
+		if !ed25519.Verify(pub, signingInput, sig) {
+			return errors.New("EdDSA signature is invalid")
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported signature algorithm %q", alg)
+}
+
+// RolloverRequest is the payload of the inner JWS of a key change request.
+// It asks to change the key for the account with the given URL from OldKey
+// to the key embedded in the inner JWS.
+type RolloverRequest struct {
+	OldKey  jose.JSONWebKey
+	Account string
+}
+
+// VerifyRollover checks that the inner JWS of a key change request verifies
+// with newKey, which must be the JWK embedded in it, has the same "url" header
+// as the outer JWS that carried it, and asks to change the account's key from
+// oldKey. The outer JWS must already have been verified: VerifyRollover
+// doesn't check its signature. Checking that newKey is acceptable, and that
+// the request's Account matches the account which signed the outer JWS, is
+// left to the caller.
+func (v *Verifier) VerifyRollover(outer, inner *jose.JSONWebSignature, newKey, oldKey *jose.JSONWebKey) (*RolloverRequest, error) {
+	// We don't use Verify here because the inner JWS of a key rollover is
+	// special (e.g. has no nonce, isn't sent to a URL of its own).
+	innerPayload, err := v.verify(inner, newKey)
+	if err != nil {
+		return nil, fail("KeyRolloverJWSVerifyFailed", berrors.MalformedError("Inner JWS does not verify with embedded JWK"))
+	}
+
+	err = MatchURLs(outer.Signatures[0].Header, inner.Signatures[0].Header)
+	if err != nil {
+		return nil, err
+	}
+
+	var req RolloverRequest
+	if json.Unmarshal(innerPayload, &req) != nil {
+		return nil, fail("KeyRolloverUnmarshalFailed", berrors.MalformedError("Inner JWS payload did not parse as JSON key rollover object"))
+	}
+
+	// If there's no oldkey specified fail before trying to use
+	// core.PublicKeysEqual on a nil argument.
+	if req.OldKey.Key == nil {
+		return nil, fail("KeyRolloverWrongOldKey", berrors.MalformedError("Inner JWS does not contain old key field matching current account key"))
+	}
+
+	keysEqual, err := core.PublicKeysEqual(req.OldKey.Key, oldKey.Key)
+	if err != nil {
+		return nil, berrors.MalformedError("Unable to compare new and old keys: %s", err.Error())
+	}
+	if !keysEqual {
+		return nil, fail("KeyRolloverWrongOldKey", berrors.MalformedError("Inner JWS does not contain old key field matching current account key"))
+	}
+
+	return &req, nil
+}
@@ -0,0 +1,404 @@
+package jws
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-jose/go-jose/v4"
+
+	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/test"
+)
+
+var allAlgs = []jose.SignatureAlgorithm{jose.RS256, jose.ES256, jose.ES384, jose.ES512, jose.EdDSA}
+
+// sign returns the flattened JSON serialization of a JWS over payload, with
+// the given protected headers and an embedded JWK.
+func sign(t *testing.T, key crypto.Signer, alg jose.SignatureAlgorithm, payload string, headers map[jose.HeaderKey]interface{}) string {
+	t.Helper()
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Key: key, Algorithm: alg},
+		&jose.SignerOptions{EmbedJWK: true, ExtraHeaders: headers},
+	)
+	test.AssertNotError(t, err, "creating signer")
+	signed, err := signer.Sign([]byte(payload))
+	test.AssertNotError(t, err, "signing")
+	return signed.FullSerialize()
+}
+
+// assertFailed checks that err is an *Error of the given kind, wrapping a
+// BoulderError of the given type.
+func assertFailed(t *testing.T, err error, kind string, errType berrors.ErrorType) {
+	t.Helper()
+	var jwsErr *Error
+	if !errors.As(err, &jwsErr) {
+		t.Fatalf("got %#v, want *jws.Error", err)
+	}
+	test.AssertEquals(t, jwsErr.Kind, kind)
+	test.AssertErrorIs(t, err, errType)
+}
+
+func TestAlgorithmForKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	test.AssertNotError(t, err, "generating key")
+	edKey, _, err := ed25519.GenerateKey(rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+
+	testCases := []struct {
+		key  interface{}
+		want jose.SignatureAlgorithm
+	}{
+		{&rsaKey.PublicKey, jose.RS256},
+		{edKey, jose.EdDSA},
+	}
+	for _, curve := range []struct {
+		curve elliptic.Curve
+		alg   jose.SignatureAlgorithm
+	}{{elliptic.P256(), jose.ES256}, {elliptic.P384(), jose.ES384}, {elliptic.P521(), jose.ES512}} {
+		k, err := ecdsa.GenerateKey(curve.curve, rand.Reader)
+		test.AssertNotError(t, err, "generating key")
+		testCases = append(testCases, struct {
+			key  interface{}
+			want jose.SignatureAlgorithm
+		}{&k.PublicKey, curve.alg})
+	}
+	for _, tc := range testCases {
+		got, err := AlgorithmForKey(&jose.JSONWebKey{Key: tc.key})
+		test.AssertNotError(t, err, "AlgorithmForKey")
+		test.AssertEquals(t, got, tc.want)
+	}
+
+	p224, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	_, err = AlgorithmForKey(&jose.JSONWebKey{Key: &p224.PublicKey})
+	test.AssertErrorIs(t, err, berrors.BadPublicKey)
+}
+
+func TestCheckAuthType(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	jwk := &jose.JSONWebKey{Key: key.Public()}
+
+	authType, err := CheckAuthType(jose.Header{JSONWebKey: jwk})
+	test.AssertNotError(t, err, "embedded JWK")
+	test.AssertEquals(t, authType, EmbeddedJWK)
+
+	authType, err = CheckAuthType(jose.Header{KeyID: "https://example.com/acct/1"})
+	test.AssertNotError(t, err, "embedded Key ID")
+	test.AssertEquals(t, authType, EmbeddedKeyID)
+
+	authType, err = CheckAuthType(jose.Header{})
+	test.AssertNotError(t, err, "neither")
+	test.AssertEquals(t, authType, InvalidAuthType)
+
+	_, err = CheckAuthType(jose.Header{JSONWebKey: jwk, KeyID: "https://example.com/acct/1"})
+	assertFailed(t, err, "JWSAuthTypeInvalid", berrors.Malformed)
+
+	err = EnforceAuthType(jose.Header{JSONWebKey: jwk}, EmbeddedKeyID)
+	assertFailed(t, err, "JWSAuthTypeWrong", berrors.Malformed)
+	test.AssertContains(t, err.Error(), "No Key ID in JWS header")
+
+	_, err = ExtractJWK(jose.Header{KeyID: "https://example.com/acct/1"})
+	assertFailed(t, err, "JWSAuthTypeWrong", berrors.Malformed)
+	test.AssertContains(t, err.Error(), "No embedded JWK in JWS header")
+
+	_, err = ExtractJWK(jose.Header{JSONWebKey: &jose.JSONWebKey{}})
+	assertFailed(t, err, "JWKInvalid", berrors.Malformed)
+
+	got, err := ExtractJWK(jose.Header{JSONWebKey: jwk})
+	test.AssertNotError(t, err, "ExtractJWK")
+	test.AssertEquals(t, got, jwk)
+}
+
+func TestNonce(t *testing.T) {
+	_, err := Nonce(jose.Header{})
+	assertFailed(t, err, "JWSMissingNonce", berrors.BadNonce)
+
+	_, err = Nonce(jose.Header{Nonce: "im-a-nonce"})
+	assertFailed(t, err, "JWSMalformedNonce", berrors.BadNonce)
+
+	_, err = Nonce(jose.Header{Nonce: "woww"})
+	assertFailed(t, err, "JWSMalformedNonce", berrors.BadNonce)
+
+	good := "mlolmlol3ov77I5Ui-cdaY_k8IcjK58FvbG0y_BCRrx5rGQ8rjA"
+	got, err := Nonce(jose.Header{Nonce: good})
+	test.AssertNotError(t, err, "well-formed nonce")
+	test.AssertEquals(t, got, good)
+}
+
+func TestCheckURL(t *testing.T) {
+	expected := "https://example.com/acme/new-order"
+
+	err := CheckURL(jose.Header{}, expected)
+	assertFailed(t, err, "JWSNoExtraHeaders", berrors.Malformed)
+
+	err = CheckURL(jose.Header{ExtraHeaders: map[jose.HeaderKey]interface{}{"url": 1}}, expected)
+	assertFailed(t, err, "JWSMissingURL", berrors.Malformed)
+
+	err = CheckURL(jose.Header{ExtraHeaders: map[jose.HeaderKey]interface{}{"url": "https://example.com/acme/new-acct"}}, expected)
+	assertFailed(t, err, "JWSMismatchedURL", berrors.Malformed)
+
+	err = CheckURL(jose.Header{ExtraHeaders: map[jose.HeaderKey]interface{}{"url": expected}}, expected)
+	test.AssertNotError(t, err, "matching URL")
+}
+
+func TestMatchURLs(t *testing.T) {
+	withURL := func(u string) jose.Header {
+		return jose.Header{ExtraHeaders: map[jose.HeaderKey]interface{}{"url": u}}
+	}
+
+	err := MatchURLs(jose.Header{}, withURL("a"))
+	assertFailed(t, err, "KeyRolloverOuterJWSNoURL", berrors.Malformed)
+
+	err = MatchURLs(withURL("a"), jose.Header{})
+	assertFailed(t, err, "KeyRolloverInnerJWSNoURL", berrors.Malformed)
+
+	err = MatchURLs(withURL("a"), withURL("b"))
+	assertFailed(t, err, "KeyRolloverMismatchedURLs", berrors.Malformed)
+
+	err = MatchURLs(withURL("a"), withURL("a"))
+	test.AssertNotError(t, err, "matching URLs")
+}
+
+func TestParse(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	v := &Verifier{Algorithms: []jose.SignatureAlgorithm{jose.RS256, jose.ES256}}
+
+	good := sign(t, key, jose.ES256, `{}`, map[jose.HeaderKey]interface{}{"url": "https://example.com"})
+	var fields map[string]interface{}
+	err = json.Unmarshal([]byte(good), &fields)
+	test.AssertNotError(t, err, "unmarshalling JWS")
+	withFields := func(extra map[string]interface{}) []byte {
+		body := map[string]interface{}{}
+		for k, v := range fields {
+			body[k] = v
+		}
+		for k, v := range extra {
+			body[k] = v
+		}
+		out, err := json.Marshal(body)
+		test.AssertNotError(t, err, "marshalling JWS")
+		return out
+	}
+
+	testCases := []struct {
+		name    string
+		body    []byte
+		kind    string
+		errType berrors.ErrorType
+	}{
+		{"not JSON", []byte(`{`), "JWSUnmarshalFailed", berrors.Malformed},
+		{"unprotected header", withFields(map[string]interface{}{"header": map[string]string{"kid": "1"}}), "JWSUnprotectedHeaders", berrors.Malformed},
+		{"signatures array", withFields(map[string]interface{}{"signatures": []interface{}{"x"}}), "JWSMultiSig", berrors.Malformed},
+		{"empty signature", withFields(map[string]interface{}{"signature": ""}), "JWSEmptySignature", berrors.Malformed},
+		{"garbage", []byte(`{"protected": "!!", "payload": "", "signature": "AA"}`), "JWSParseError", berrors.Malformed},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := v.Parse(tc.body)
+			assertFailed(t, err, tc.kind, tc.errType)
+		})
+	}
+
+	parsed, err := v.Parse([]byte(good))
+	test.AssertNotError(t, err, "parsing a good JWS")
+	test.AssertEquals(t, len(parsed.Signatures), 1)
+
+	_, err = (&Verifier{Algorithms: []jose.SignatureAlgorithm{jose.RS256}}).Parse([]byte(good))
+	assertFailed(t, err, "JWSAlgorithmCheckFailed", berrors.BadSignatureAlgorithm)
+	var jwsErr *Error
+	test.Assert(t, errors.As(err, &jwsErr), "not a *jws.Error")
+	test.AssertEquals(t, jwsErr.Algorithm, jose.ES256)
+}
+
+func TestCritical(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+
+	v := &Verifier{
+		Algorithms: allAlgs,
+		Critical: map[string]CriticalParam{
+			"example": func(value any) error {
+				if value != "ok" {
+					return fmt.Errorf("want %q, got %v", "ok", value)
+				}
+				return nil
+			},
+		},
+	}
+
+	testCases := []struct {
+		name    string
+		headers map[jose.HeaderKey]interface{}
+		kind    string
+		detail  string
+	}{
+		{
+			name:    "empty crit",
+			headers: map[jose.HeaderKey]interface{}{"crit": []string{}},
+			kind:    "JWSInvalidCritical",
+			detail:  "must be a non-empty list of names",
+		},
+		{
+			name:    "crit isn't a list of names",
+			headers: map[jose.HeaderKey]interface{}{"crit": []int{1}},
+			kind:    "JWSInvalidCritical",
+			detail:  "must be a non-empty list of names",
+		},
+		{
+			name:    "unsupported",
+			headers: map[jose.HeaderKey]interface{}{"crit": []string{"other"}, "other": true},
+			kind:    "JWSUnsupportedCritical",
+			detail:  `"other" is not supported`,
+		},
+		{
+			name:    "b64 is unsupported",
+			headers: map[jose.HeaderKey]interface{}{"crit": []string{"b64"}, "b64": true},
+			kind:    "JWSUnsupportedCritical",
+			detail:  `"b64" is not supported`,
+		},
+		{
+			name:    "missing",
+			headers: map[jose.HeaderKey]interface{}{"crit": []string{"example"}},
+			kind:    "JWSInvalidCritical",
+			detail:  `"example" is missing`,
+		},
+		{
+			name:    "invalid",
+			headers: map[jose.HeaderKey]interface{}{"crit": []string{"example"}, "example": "not ok"},
+			kind:    "JWSInvalidCritical",
+			detail:  `"example" is invalid: want "ok", got not ok`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := v.Parse([]byte(sign(t, key, jose.ES256, `{}`, tc.headers)))
+			assertFailed(t, err, tc.kind, berrors.Malformed)
+			test.AssertContains(t, err.Error(), tc.detail)
+		})
+	}
+
+	// A JWS listing a supported critical parameter can be verified, although
+	// go-jose doesn't understand it.
+	body := sign(t, key, jose.ES256, `{"a":"b"}`, map[jose.HeaderKey]interface{}{"crit": []string{"example"}, "example": "ok"})
+	parsed, err := v.Parse([]byte(body))
+	test.AssertNotError(t, err, "parsing a JWS with a supported critical parameter")
+	payload, err := v.Verify(parsed, parsed.Signatures[0].Header.JSONWebKey)
+	test.AssertNotError(t, err, "verifying a JWS with a supported critical parameter")
+	test.AssertEquals(t, string(payload), `{"a":"b"}`)
+
+	// A Verifier which doesn't support the parameter won't verify it, even if
+	// the JWS wasn't parsed by it.
+	_, err = (&Verifier{Algorithms: allAlgs}).Verify(parsed, parsed.Signatures[0].Header.JSONWebKey)
+	assertFailed(t, err, "JWSVerifyFailed", berrors.Malformed)
+}
+
+func TestVerify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	test.AssertNotError(t, err, "generating key")
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	p521Key, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+
+	v := &Verifier{
+		Algorithms: allAlgs,
+		Critical:   map[string]CriticalParam{"example": func(any) error { return nil }},
+	}
+
+	for _, tc := range []struct {
+		key crypto.Signer
+		alg jose.SignatureAlgorithm
+	}{
+		{rsaKey, jose.RS256},
+		{p256Key, jose.ES256},
+		{p384Key, jose.ES384},
+		{p521Key, jose.ES512},
+		{edKey, jose.EdDSA},
+	} {
+		// Both with go-jose, and with this package's own signature
+		// verification for JWS with critical parameters.
+		for _, headers := range []map[jose.HeaderKey]interface{}{
+			nil,
+			{"crit": []string{"example"}, "example": 1},
+		} {
+			t.Run(fmt.Sprintf("%s crit=%t", tc.alg, headers != nil), func(t *testing.T) {
+				parsed, err := v.Parse([]byte(sign(t, tc.key, tc.alg, "hello", headers)))
+				test.AssertNotError(t, err, "parsing")
+
+				payload, err := v.Verify(parsed, &jose.JSONWebKey{Key: tc.key.Public()})
+				test.AssertNotError(t, err, "verifying with the signing key")
+				test.AssertEquals(t, string(payload), "hello")
+
+				_, err = v.Verify(parsed, &jose.JSONWebKey{Key: otherKey.Public()})
+				assertFailed(t, err, "JWSVerifyFailed", berrors.Malformed)
+
+				parsed.Signatures[0].Signature[0] ^= 0xff
+				_, err = v.Verify(parsed, &jose.JSONWebKey{Key: tc.key.Public()})
+				assertFailed(t, err, "JWSVerifyFailed", berrors.Malformed)
+			})
+		}
+	}
+}
+
+func TestVerifyRollover(t *testing.T) {
+	oldKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	oldJWK := &jose.JSONWebKey{Key: oldKey.Public()}
+	newJWK := &jose.JSONWebKey{Key: newKey.Public()}
+
+	v := &Verifier{Algorithms: allAlgs}
+	parse := func(key crypto.Signer, url, payload string) *jose.JSONWebSignature {
+		t.Helper()
+		parsed, err := v.Parse([]byte(sign(t, key, jose.ES256, payload, map[jose.HeaderKey]interface{}{"url": url})))
+		test.AssertNotError(t, err, "parsing")
+		return parsed
+	}
+	rolloverPayload := func(key crypto.PublicKey) string {
+		t.Helper()
+		payload, err := json.Marshal(RolloverRequest{OldKey: jose.JSONWebKey{Key: key}, Account: "https://example.com/acct/1"})
+		test.AssertNotError(t, err, "marshalling rollover request")
+		return string(payload)
+	}
+
+	url := "https://example.com/key-change"
+	outer := parse(oldKey, url, "{}")
+
+	req, err := v.VerifyRollover(outer, parse(newKey, url, rolloverPayload(oldKey.Public())), newJWK, oldJWK)
+	test.AssertNotError(t, err, "valid rollover")
+	test.AssertEquals(t, req.Account, "https://example.com/acct/1")
+
+	_, err = v.VerifyRollover(outer, parse(otherKey, url, rolloverPayload(oldKey.Public())), newJWK, oldJWK)
+	assertFailed(t, err, "KeyRolloverJWSVerifyFailed", berrors.Malformed)
+
+	_, err = v.VerifyRollover(outer, parse(newKey, "https://example.com/other", rolloverPayload(oldKey.Public())), newJWK, oldJWK)
+	assertFailed(t, err, "KeyRolloverMismatchedURLs", berrors.Malformed)
+
+	_, err = v.VerifyRollover(outer, parse(newKey, url, "not JSON"), newJWK, oldJWK)
+	assertFailed(t, err, "KeyRolloverUnmarshalFailed", berrors.Malformed)
+
+	_, err = v.VerifyRollover(outer, parse(newKey, url, `{"account": "https://example.com/acct/1"}`), newJWK, oldJWK)
+	assertFailed(t, err, "KeyRolloverWrongOldKey", berrors.Malformed)
+
+	_, err = v.VerifyRollover(outer, parse(newKey, url, rolloverPayload(otherKey.Public())), newJWK, oldJWK)
+	assertFailed(t, err, "KeyRolloverWrongOldKey", berrors.Malformed)
+}
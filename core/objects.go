@@ -31,6 +31,7 @@ const (
 	StatusInvalid     = AcmeStatus("invalid")     // Validation failed
 	StatusRevoked     = AcmeStatus("revoked")     // Object no longer valid
 	StatusDeactivated = AcmeStatus("deactivated") // Object has been deactivated
+	StatusExpired     = AcmeStatus("expired")     // Authorization expired while pending
 )
 
 // AcmeResource values identify different types of ACME resources
@@ -53,15 +54,16 @@ type AcmeChallenge string
 
 // These types are the available challenges
 const (
-	ChallengeTypeHTTP01    = AcmeChallenge("http-01")
-	ChallengeTypeDNS01     = AcmeChallenge("dns-01")
-	ChallengeTypeTLSALPN01 = AcmeChallenge("tls-alpn-01")
+	ChallengeTypeHTTP01       = AcmeChallenge("http-01")
+	ChallengeTypeDNS01        = AcmeChallenge("dns-01")
+	ChallengeTypeTLSALPN01    = AcmeChallenge("tls-alpn-01")
+	ChallengeTypeEmailReply00 = AcmeChallenge("email-reply-00")
 )
 
 // IsValid tests whether the challenge is a known challenge
 func (c AcmeChallenge) IsValid() bool {
 	switch c {
-	case ChallengeTypeHTTP01, ChallengeTypeDNS01, ChallengeTypeTLSALPN01:
+	case ChallengeTypeHTTP01, ChallengeTypeDNS01, ChallengeTypeTLSALPN01, ChallengeTypeEmailReply00:
 		return true
 	default:
 		return false
@@ -149,6 +151,17 @@ type ValidationRecord struct {
 	// lookup for AddressUsed. During recursive A and AAAA lookups, a record may
 	// instead look like A:host:port or AAAA:host:port
 	ResolverAddrs []string `json:"resolverAddrs,omitempty"`
+
+	// AliasChain is the sequence of names followed via CNAME and DNAME records
+	// during a DNS-01 validation, starting with the name which was queried and
+	// ending with the name whose TXT records were examined. It is only set if
+	// the queried name was an alias.
+	AliasChain []string `json:"aliasChain,omitempty"`
+
+	// Fingerprint names the combination of User-Agent and TLS client
+	// parameters with which an HTTP-01 request was made. It is only set if
+	// the VA is configured to rotate among several of them.
+	Fingerprint string `json:"fingerprint,omitempty"`
 }
 
 // Challenge is an aggregate of all data needed for any challenges.
@@ -238,6 +251,12 @@ func (ch Challenge) RecordsSane() bool {
 			return false
 		}
 		return true
+	case ChallengeTypeEmailReply00:
+		// The Hostname is the domain of the address from which the reply was
+		// received.
+		if len(ch.ValidationRecord) > 1 || ch.ValidationRecord[0].Hostname == "" {
+			return false
+		}
 	default: // Unsupported challenge type
 		return false
 	}
@@ -463,6 +482,10 @@ func (window SuggestedWindow) IsWithin(now time.Time) bool {
 type RenewalInfo struct {
 	SuggestedWindow SuggestedWindow `json:"suggestedWindow"`
 	ExplanationURL  string          `json:"explanationURL,omitempty"`
+	// Replaced is a Boulder extension to the draft, set when an order naming
+	// this certificate in its "replaces" field is in progress or has been
+	// finalized. Clients which see it needn't renew this certificate again.
+	Replaced bool `json:"replaced,omitempty"`
 }
 
 // RenewalInfoSimple constructs a `RenewalInfo` object and suggested window
@@ -183,6 +183,21 @@ func ValidSerial(serial string) bool {
 	return err == nil
 }
 
+// ValidSerialPrefix tests whether the input string is a non-empty prefix of a
+// serial number as formatted by SerialToString, i.e., that it is made up of at
+// most 36 lowercase hex characters.
+func ValidSerialPrefix(prefix string) bool {
+	if len(prefix) == 0 || len(prefix) > 36 {
+		return false
+	}
+	for _, c := range prefix {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return false
+		}
+	}
+	return true
+}
+
 // GetBuildID identifies what build is running.
 func GetBuildID() (retID string) {
 	retID = BuildID
@@ -268,6 +268,16 @@ func TestValidSerial(t *testing.T) {
 	test.AssertEquals(t, isValidSerial, true)
 }
 
+func TestValidSerialPrefix(t *testing.T) {
+	test.Assert(t, ValidSerialPrefix("0"), "single digit should be valid")
+	test.Assert(t, ValidSerialPrefix("03a1f"), "hex prefix should be valid")
+	test.Assert(t, ValidSerialPrefix(strings.Repeat("a", 36)), "full serial should be valid")
+	test.Assert(t, !ValidSerialPrefix(""), "empty prefix should be invalid")
+	test.Assert(t, !ValidSerialPrefix(strings.Repeat("a", 37)), "overlong prefix should be invalid")
+	test.Assert(t, !ValidSerialPrefix("03A1F"), "uppercase prefix should be invalid")
+	test.Assert(t, !ValidSerialPrefix("03%"), "wildcard should be invalid")
+}
+
 func TestLoadCert(t *testing.T) {
 	var osPathErr *os.PathError
 	_, err := LoadCert("")
@@ -0,0 +1,170 @@
+package storer
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// filesystemTarget publishes CRLs to a directory, which may be a network
+// filesystem, for deployments which serve CRLs from their own web servers. Each
+// CRL is written to the same path relative to the directory as its key in the
+// S3 bucket, and each issuer's directory also holds a manifest describing its
+// current shards. Every file is replaced atomically, so that readers only ever
+// see complete files.
+type filesystemTarget struct {
+	dir string
+
+	// manifestMu serializes updates to the manifests. It doesn't protect them
+	// from other processes, so only one crl-storer should publish to each
+	// directory.
+	manifestMu sync.Mutex
+}
+
+// shardManifest describes the CRL shards published for one issuer.
+type shardManifest struct {
+	IssuerNameID int64 `json:"issuerNameID"`
+	// Shards is keyed by shard index.
+	Shards map[int64]shardManifestEntry `json:"shards"`
+}
+
+// shardManifestEntry describes a published CRL shard.
+type shardManifestEntry struct {
+	Filename   string    `json:"filename"`
+	CRLNumber  string    `json:"crlNumber"`
+	ThisUpdate time.Time `json:"thisUpdate"`
+	NextUpdate time.Time `json:"nextUpdate"`
+	Size       int       `json:"size"`
+	SHA256     string    `json:"sha256"`
+}
+
+// SetFilesystemTarget configures the storer to publish CRLs to the given
+// directory, which must already exist, in addition to S3. If the storer has no
+// S3 client, the previous CRL for each shard is read from the directory
+// instead.
+func (cs *crlStorer) SetFilesystemTarget(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("checking CRL directory: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("CRL directory %q is not a directory", dir)
+	}
+	cs.fsTarget = &filesystemTarget{dir: dir}
+	return nil
+}
+
+// get returns the contents of the file at the given path relative to the
+// target's directory, or nil if it doesn't exist.
+func (t *filesystemTarget) get(name string) ([]byte, error) {
+	b, err := os.ReadFile(filepath.Join(t.dir, filepath.FromSlash(name)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return b, err
+}
+
+// put atomically publishes a CRL to the given path relative to the target's
+// directory, and then records it in its issuer's manifest.
+func (t *filesystemTarget) put(issuerNameID int64, shardIdx int64, name string, crlBytes []byte, entry shardManifestEntry) error {
+	path := filepath.Join(t.dir, filepath.FromSlash(name))
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+	err = writeFileAtomically(path, crlBytes)
+	if err != nil {
+		return err
+	}
+
+	t.manifestMu.Lock()
+	defer t.manifestMu.Unlock()
+
+	manifestPath := filepath.Join(filepath.Dir(path), "manifest.json")
+	manifest := shardManifest{IssuerNameID: issuerNameID, Shards: make(map[int64]shardManifestEntry)}
+	manifestBytes, err := os.ReadFile(manifestPath)
+	if err == nil {
+		err = json.Unmarshal(manifestBytes, &manifest)
+		if err != nil {
+			return fmt.Errorf("parsing manifest %q: %w", manifestPath, err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if manifest.Shards == nil {
+		manifest.Shards = make(map[int64]shardManifestEntry)
+	}
+	manifest.Shards[shardIdx] = entry
+
+	manifestBytes, err = json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomically(manifestPath, manifestBytes)
+}
+
+// newShardManifestEntry returns the manifest entry for a CRL published to the
+// given path.
+func newShardManifestEntry(name string, crlNumber string, thisUpdate, nextUpdate time.Time, crlBytes []byte, checksum [32]byte) shardManifestEntry {
+	return shardManifestEntry{
+		Filename:   filepath.Base(name),
+		CRLNumber:  crlNumber,
+		ThisUpdate: thisUpdate.UTC(),
+		NextUpdate: nextUpdate.UTC(),
+		Size:       len(crlBytes),
+		SHA256:     hex.EncodeToString(checksum[:]),
+	}
+}
+
+// writeFileAtomically replaces the file at path with one containing b. The
+// contents are written to a temporary file in the same directory and synced
+// before it's renamed over path, and the directory is synced afterwards, so
+// that neither readers nor a crash can observe a partially written file.
+func writeFileAtomically(path string, b []byte) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// This fails harmlessly once the file has been renamed.
+		_ = os.Remove(tmp.Name())
+	}()
+
+	_, err = tmp.Write(b)
+	if err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	err = tmp.Chmod(0644)
+	if err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	err = tmp.Sync()
+	if err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		return err
+	}
+
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
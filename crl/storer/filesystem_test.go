@@ -0,0 +1,197 @@
+package storer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+
+	"github.com/letsencrypt/boulder/crl/idp"
+	cspb "github.com/letsencrypt/boulder/crl/storer/proto"
+	"github.com/letsencrypt/boulder/issuance"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// uploadTestCRL signs a CRL with the given number for the given shard and
+// uploads it to storer.
+func uploadTestCRL(t *testing.T, storer *crlStorer, iss *issuance.Issuer, number int64, shardIdx int64) ([]byte, error) {
+	t.Helper()
+
+	idpExt, err := idp.MakeUserCertsExt([]string{"http://c.ex.org"})
+	test.AssertNotError(t, err, "creating test IDP extension")
+	crlBytes, err := x509.CreateRevocationList(
+		rand.Reader,
+		&x509.RevocationList{
+			ThisUpdate: storer.clk.Now(),
+			NextUpdate: storer.clk.Now().Add(time.Hour),
+			Number:     big.NewInt(number),
+			RevokedCertificateEntries: []x509.RevocationListEntry{
+				{SerialNumber: big.NewInt(123), RevocationTime: storer.clk.Now().Add(-time.Hour)},
+			},
+			ExtraExtensions: []pkix.Extension{idpExt},
+		},
+		iss.Cert.Certificate,
+		iss.Signer,
+	)
+	test.AssertNotError(t, err, "creating test CRL")
+
+	errs := make(chan error, 1)
+	ins := make(chan *cspb.UploadCRLRequest, 2)
+	ins <- &cspb.UploadCRLRequest{
+		Payload: &cspb.UploadCRLRequest_Metadata{
+			Metadata: &cspb.CRLMetadata{
+				IssuerNameID: int64(iss.Cert.NameID()),
+				Number:       number,
+				ShardIdx:     shardIdx,
+			},
+		},
+	}
+	ins <- &cspb.UploadCRLRequest{
+		Payload: &cspb.UploadCRLRequest_CrlChunk{
+			CrlChunk: crlBytes,
+		},
+	}
+	close(ins)
+	go func() {
+		errs <- storer.UploadCRL(&fakeUploadCRLServerStream{input: ins})
+	}()
+	return crlBytes, <-errs
+}
+
+// memorySimpleS3 implements the simpleS3 interface by holding objects in a map.
+// If failPuts is set, every upload fails.
+type memorySimpleS3 struct {
+	objects  map[string][]byte
+	failPuts bool
+}
+
+func (m *memorySimpleS3) PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	if m.failPuts {
+		return nil, errors.New("sorry")
+	}
+	b, err := io.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+	m.objects[*params.Key] = b
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (m *memorySimpleS3) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	b, ok := m.objects[*params.Key]
+	if !ok {
+		return nil, &smithyhttp.ResponseError{Response: &smithyhttp.Response{Response: &http.Response{StatusCode: 404}}}
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(b))}, nil
+}
+
+func readManifest(t *testing.T, path string) shardManifest {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	test.AssertNotError(t, err, "reading manifest")
+	var manifest shardManifest
+	err = json.Unmarshal(b, &manifest)
+	test.AssertNotError(t, err, "parsing manifest")
+	return manifest
+}
+
+func TestSetFilesystemTarget(t *testing.T) {
+	storer, _ := setupTestUploadCRL(t)
+	dir := t.TempDir()
+
+	err := storer.SetFilesystemTarget(filepath.Join(dir, "missing"))
+	test.AssertError(t, err, "missing directory accepted")
+
+	file := filepath.Join(dir, "file")
+	err = os.WriteFile(file, nil, 0644)
+	test.AssertNotError(t, err, "creating file")
+	err = storer.SetFilesystemTarget(file)
+	test.AssertContains(t, err.Error(), "is not a directory")
+
+	err = storer.SetFilesystemTarget(dir)
+	test.AssertNotError(t, err, "valid directory rejected")
+}
+
+// Test that CRLs are written to the filesystem, and checked against the
+// previous ones there, when there's no S3 bucket.
+func TestUploadCRLFilesystem(t *testing.T) {
+	storer, iss := setupTestUploadCRL(t)
+	dir := t.TempDir()
+	err := storer.SetFilesystemTarget(dir)
+	test.AssertNotError(t, err, "configuring filesystem target")
+	issuerDir := filepath.Join(dir, fmt.Sprint(iss.Cert.NameID()))
+
+	crl0, err := uploadTestCRL(t, storer, iss, 1, 0)
+	test.AssertNotError(t, err, "uploading first CRL for shard 0")
+	crl1, err := uploadTestCRL(t, storer, iss, 1, 1)
+	test.AssertNotError(t, err, "uploading first CRL for shard 1")
+
+	storer.clk.Sleep(time.Minute)
+	crl0, err = uploadTestCRL(t, storer, iss, 2, 0)
+	test.AssertNotError(t, err, "uploading second CRL for shard 0")
+
+	_, err = uploadTestCRL(t, storer, iss, 1, 0)
+	test.AssertContains(t, err.Error(), "crlNumber not strictly increasing")
+
+	for name, want := range map[string][]byte{"0.crl": crl0, "1.crl": crl1} {
+		got, err := os.ReadFile(filepath.Join(issuerDir, name))
+		test.AssertNotError(t, err, "reading CRL")
+		test.AssertByteEquals(t, got, want)
+	}
+
+	manifest := readManifest(t, filepath.Join(issuerDir, "manifest.json"))
+	test.AssertEquals(t, manifest.IssuerNameID, int64(iss.Cert.NameID()))
+	test.AssertEquals(t, len(manifest.Shards), 2)
+	test.AssertEquals(t, manifest.Shards[0].Filename, "0.crl")
+	test.AssertEquals(t, manifest.Shards[0].CRLNumber, "2")
+	test.AssertEquals(t, manifest.Shards[0].Size, len(crl0))
+	test.AssertEquals(t, manifest.Shards[0].NextUpdate, storer.clk.Now().UTC().Add(time.Hour))
+	test.AssertEquals(t, manifest.Shards[1].CRLNumber, "1")
+
+	// No temporary files are left behind.
+	entries, err := os.ReadDir(issuerDir)
+	test.AssertNotError(t, err, "reading issuer directory")
+	test.AssertEquals(t, len(entries), 3)
+}
+
+// Test that CRLs are written to both S3 and the filesystem when both are
+// configured.
+func TestUploadCRLFilesystemAndS3(t *testing.T) {
+	storer, iss := setupTestUploadCRL(t)
+	dir := t.TempDir()
+	err := storer.SetFilesystemTarget(dir)
+	test.AssertNotError(t, err, "configuring filesystem target")
+	fake := &memorySimpleS3{objects: make(map[string][]byte)}
+	storer.s3Client = fake
+	filename := fmt.Sprintf("%d/0.crl", iss.Cert.NameID())
+
+	crlBytes, err := uploadTestCRL(t, storer, iss, 1, 0)
+	test.AssertNotError(t, err, "uploading CRL")
+	test.AssertByteEquals(t, fake.objects[filename], crlBytes)
+	got, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(filename)))
+	test.AssertNotError(t, err, "reading CRL")
+	test.AssertByteEquals(t, got, crlBytes)
+
+	// If the upload to S3 fails, the CRL isn't written to the filesystem.
+	storer.clk.Sleep(time.Minute)
+	fake.failPuts = true
+	_, err = uploadTestCRL(t, storer, iss, 2, 0)
+	test.AssertContains(t, err.Error(), "uploading to S3")
+	got, err = os.ReadFile(filepath.Join(dir, filepath.FromSlash(filename)))
+	test.AssertNotError(t, err, "reading CRL")
+	test.AssertByteEquals(t, got, crlBytes)
+}
@@ -39,6 +39,7 @@ type crlStorer struct {
 	cspb.UnsafeCRLStorerServer
 	s3Client         simpleS3
 	s3Bucket         string
+	fsTarget         *filesystemTarget
 	issuers          map[issuance.NameID]*issuance.Certificate
 	uploadCount      *prometheus.CounterVec
 	sizeHistogram    *prometheus.HistogramVec
@@ -49,6 +50,9 @@ type crlStorer struct {
 
 var _ cspb.CRLStorerServer = (*crlStorer)(nil)
 
+// New returns a CRLStorer which uploads CRLs to the given S3 bucket. If
+// s3Bucket is empty, s3Client is ignored, and CRLs are only written to the
+// filesystem target configured with SetFilesystemTarget.
 func New(
 	issuers []*issuance.Certificate,
 	s3Client simpleS3,
@@ -82,6 +86,10 @@ func New(
 	}, []string{"issuer"})
 	stats.MustRegister(latencyHistogram)
 
+	if s3Bucket == "" {
+		s3Client = nil
+	}
+
 	return &crlStorer{
 		issuers:          issuersByNameID,
 		s3Client:         s3Client,
@@ -99,7 +107,7 @@ func New(
 
 // UploadCRL implements the gRPC method of the same name. It takes a stream of
 // bytes as its input, parses and runs some sanity checks on the CRL, and then
-// uploads it to S3.
+// uploads it to S3 and/or writes it to the filesystem target.
 func (cs *crlStorer) UploadCRL(stream grpc.ClientStreamingServer[cspb.UploadCRLRequest, emptypb.Empty]) error {
 	var issuer *issuance.Certificate
 	var shardIdx int64
@@ -173,22 +181,13 @@ func (cs *crlStorer) UploadCRL(stream grpc.ClientStreamingServer[cspb.UploadCRLR
 	// crl-updaters are working on the same shard at the same time. We only run
 	// these checks if we found a CRL, so we don't block uploading brand new CRLs.
 	filename := fmt.Sprintf("%d/%d.crl", issuer.NameID(), shardIdx)
-	prevObj, err := cs.s3Client.GetObject(stream.Context(), &s3.GetObjectInput{
-		Bucket: &cs.s3Bucket,
-		Key:    &filename,
-	})
+	prevBytes, err := cs.getPrevious(stream.Context(), filename)
 	if err != nil {
-		var smithyErr *smithyhttp.ResponseError
-		if !errors.As(err, &smithyErr) || smithyErr.HTTPStatusCode() != 404 {
-			return fmt.Errorf("getting previous CRL for %s: %w", crlId, err)
-		}
+		return fmt.Errorf("getting previous CRL for %s: %w", crlId, err)
+	}
+	if prevBytes == nil {
 		cs.log.Infof("No previous CRL found for %s, proceeding", crlId)
 	} else {
-		prevBytes, err := io.ReadAll(prevObj.Body)
-		if err != nil {
-			return fmt.Errorf("downloading previous CRL for %s: %w", crlId, err)
-		}
-
 		prevCRL, err := x509.ParseRevocationList(prevBytes)
 		if err != nil {
 			return fmt.Errorf("parsing previous CRL for %s: %w", crlId, err)
@@ -224,19 +223,31 @@ func (cs *crlStorer) UploadCRL(stream grpc.ClientStreamingServer[cspb.UploadCRLR
 	start := cs.clk.Now()
 
 	checksum := sha256.Sum256(crlBytes)
-	checksumb64 := base64.StdEncoding.EncodeToString(checksum[:])
-	crlContentType := "application/pkix-crl"
-	_, err = cs.s3Client.PutObject(stream.Context(), &s3.PutObjectInput{
-		Bucket:            &cs.s3Bucket,
-		Key:               &filename,
-		Body:              bytes.NewReader(crlBytes),
-		ChecksumAlgorithm: types.ChecksumAlgorithmSha256,
-		ChecksumSHA256:    &checksumb64,
-		ContentType:       &crlContentType,
-		Metadata:          map[string]string{"crlNumber": crlNumber.String()},
-		Expires:           &expires,
-		CacheControl:      &cacheControl,
-	})
+	if cs.s3Client != nil {
+		checksumb64 := base64.StdEncoding.EncodeToString(checksum[:])
+		crlContentType := "application/pkix-crl"
+		_, err = cs.s3Client.PutObject(stream.Context(), &s3.PutObjectInput{
+			Bucket:            &cs.s3Bucket,
+			Key:               &filename,
+			Body:              bytes.NewReader(crlBytes),
+			ChecksumAlgorithm: types.ChecksumAlgorithmSha256,
+			ChecksumSHA256:    &checksumb64,
+			ContentType:       &crlContentType,
+			Metadata:          map[string]string{"crlNumber": crlNumber.String()},
+			Expires:           &expires,
+			CacheControl:      &cacheControl,
+		})
+		if err != nil {
+			err = fmt.Errorf("uploading to S3: %w", err)
+		}
+	}
+	if err == nil && cs.fsTarget != nil {
+		err = cs.fsTarget.put(int64(issuer.NameID()), shardIdx, filename, crlBytes,
+			newShardManifestEntry(filename, crlNumber.String(), crl.ThisUpdate, crl.NextUpdate, crlBytes, checksum))
+		if err != nil {
+			err = fmt.Errorf("writing to filesystem: %w", err)
+		}
+	}
 
 	latency := cs.clk.Now().Sub(start)
 	cs.latencyHistogram.WithLabelValues(issuer.Subject.CommonName).Observe(latency.Seconds())
@@ -244,7 +255,7 @@ func (cs *crlStorer) UploadCRL(stream grpc.ClientStreamingServer[cspb.UploadCRLR
 	if err != nil {
 		cs.uploadCount.WithLabelValues(issuer.Subject.CommonName, "failed").Inc()
 		cs.log.AuditErrf("CRL upload failed: id=[%s] err=[%s]", crlId, err)
-		return fmt.Errorf("uploading to S3: %w", err)
+		return err
 	}
 
 	cs.uploadCount.WithLabelValues(issuer.Subject.CommonName, "success").Inc()
@@ -255,3 +266,31 @@ func (cs *crlStorer) UploadCRL(stream grpc.ClientStreamingServer[cspb.UploadCRLR
 
 	return stream.SendAndClose(&emptypb.Empty{})
 }
+
+// getPrevious returns the previously uploaded CRL with the given filename, or
+// nil if there is none. It's read from S3 if the storer has an S3 client, and
+// from its filesystem target otherwise.
+func (cs *crlStorer) getPrevious(ctx context.Context, filename string) ([]byte, error) {
+	if cs.s3Client == nil {
+		if cs.fsTarget == nil {
+			return nil, nil
+		}
+		return cs.fsTarget.get(filename)
+	}
+	prevObj, err := cs.s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: &cs.s3Bucket,
+		Key:    &filename,
+	})
+	if err != nil {
+		var smithyErr *smithyhttp.ResponseError
+		if errors.As(err, &smithyErr) && smithyErr.HTTPStatusCode() == 404 {
+			return nil, nil
+		}
+		return nil, err
+	}
+	prevBytes, err := io.ReadAll(prevObj.Body)
+	if err != nil {
+		return nil, fmt.Errorf("downloading: %w", err)
+	}
+	return prevBytes, nil
+}
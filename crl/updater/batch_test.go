@@ -29,6 +29,7 @@ func TestRunOnce(t *testing.T) {
 		"stale-if-error=60",
 		5*time.Minute,
 		nil,
+		0,
 		&fakeSAC{revokedCerts: revokedCertsStream{err: errors.New("db no worky")}, maxNotAfter: clk.Now().Add(90 * 24 * time.Hour)},
 		&fakeCA{gcc: generateCRLStream{}},
 		&fakeStorer{uploaderStream: &noopUploader{}},
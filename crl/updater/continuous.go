@@ -12,7 +12,8 @@ import (
 
 // Run causes the crlUpdater to enter its processing loop. It starts one
 // goroutine for every shard it intends to update, each of which will wake at
-// the appropriate interval.
+// the appropriate interval. If maxParallelism was configured, shards which
+// are due while that many others are being updated wait for one to finish.
 func (cu *crlUpdater) Run(ctx context.Context) error {
 	var wg sync.WaitGroup
 
@@ -40,8 +41,13 @@ func (cu *crlUpdater) Run(ctx context.Context) error {
 				return
 			}
 
+			release, err := cu.acquireShardSlot(ctx)
+			if err != nil {
+				return
+			}
 			atTime := cu.clk.Now()
-			err := cu.updateShardWithRetry(ctx, atTime, issuerNameID, shardIdx, nil)
+			err = cu.updateShardWithRetry(ctx, atTime, issuerNameID, shardIdx, nil)
+			release()
 			if err != nil {
 				// We only log, rather than return, so that the long-lived process can
 				// continue and try again at the next tick.
@@ -72,3 +78,18 @@ func (cu *crlUpdater) Run(ctx context.Context) error {
 	wg.Wait()
 	return ctx.Err()
 }
+
+// acquireShardSlot blocks until fewer than maxParallelism shards are being
+// updated by Run, or until ctx is done. On success, it returns a function which
+// must be called once the shard has been updated.
+func (cu *crlUpdater) acquireShardSlot(ctx context.Context) (func(), error) {
+	if cu.shardSlots == nil {
+		return func() {}, nil
+	}
+	select {
+	case cu.shardSlots <- struct{}{}:
+		return func() { <-cu.shardSlots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,61 @@
+package updater
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+
+	"github.com/letsencrypt/boulder/issuance"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestAcquireShardSlot(t *testing.T) {
+	e1, err := issuance.LoadCertificate("../../test/hierarchy/int-e1.cert.pem")
+	test.AssertNotError(t, err, "loading test issuer")
+
+	newUpdater := func(maxParallelism int) *crlUpdater {
+		cu, err := NewUpdater(
+			[]*issuance.Certificate{e1},
+			2, 18*time.Hour, 24*time.Hour,
+			6*time.Hour, time.Minute, maxParallelism, 1,
+			"stale-if-error=60",
+			5*time.Minute,
+			nil,
+			0,
+			&fakeSAC{},
+			&fakeCA{},
+			&fakeStorer{},
+			metrics.NoopRegisterer, blog.NewMock(), clock.NewFake(),
+		)
+		test.AssertNotError(t, err, "building test crlUpdater")
+		return cu
+	}
+
+	// Without a configured maxParallelism, shards never wait.
+	cu := newUpdater(0)
+	for range 5 {
+		_, err := cu.acquireShardSlot(context.Background())
+		test.AssertNotError(t, err, "acquiring unlimited slot")
+	}
+
+	cu = newUpdater(2)
+	release1, err := cu.acquireShardSlot(context.Background())
+	test.AssertNotError(t, err, "acquiring first slot")
+	_, err = cu.acquireShardSlot(context.Background())
+	test.AssertNotError(t, err, "acquiring second slot")
+
+	// A third shard waits until its context is done...
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = cu.acquireShardSlot(ctx)
+	test.AssertErrorIs(t, err, context.DeadlineExceeded)
+
+	// ...or another shard is finished.
+	release1()
+	_, err = cu.acquireShardSlot(context.Background())
+	test.AssertNotError(t, err, "acquiring released slot")
+}
@@ -1,6 +1,7 @@
 package updater
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"errors"
@@ -8,6 +9,7 @@ import (
 	"io"
 	"math"
 	"slices"
+	"sort"
 	"time"
 
 	"github.com/jmhodges/clock"
@@ -36,11 +38,22 @@ type crlUpdater struct {
 	maxParallelism int
 	maxAttempts    int
 
+	// shardSlots holds a token for each shard being updated by Run, so that
+	// no more than maxParallelism are updated at once. It's nil if
+	// maxParallelism wasn't configured, in which case Run updates every shard
+	// as soon as it's due.
+	shardSlots chan struct{}
+
 	cacheControl  string
 	expiresMargin time.Duration
 
 	temporallyShardedPrefixes []string
 
+	// maxUnchangedAge is the longest we will go without re-signing a shard
+	// whose set of entries has not changed since it was last generated, as
+	// recorded with the SA. If zero, every shard is re-signed on every update.
+	maxUnchangedAge time.Duration
+
 	sa sapb.StorageAuthorityClient
 	ca capb.CRLGeneratorClient
 	cs cspb.CRLStorerClient
@@ -64,6 +77,7 @@ func NewUpdater(
 	cacheControl string,
 	expiresMargin time.Duration,
 	temporallyShardedPrefixes []string,
+	maxUnchangedAge time.Duration,
 	sa sapb.StorageAuthorityClient,
 	ca capb.CRLGeneratorClient,
 	cs cspb.CRLStorerClient,
@@ -92,7 +106,14 @@ func NewUpdater(
 		return nil, fmt.Errorf("lookbackPeriod must be at least 2x updatePeriod: %s !< 2 * %s", lookbackPeriod, updatePeriod)
 	}
 
-	if maxParallelism <= 0 {
+	if maxUnchangedAge != 0 && maxUnchangedAge < updatePeriod {
+		return nil, fmt.Errorf("maxUnchangedAge must be zero or at least updatePeriod: %s !>= %s", maxUnchangedAge, updatePeriod)
+	}
+
+	var shardSlots chan struct{}
+	if maxParallelism > 0 {
+		shardSlots = make(chan struct{}, maxParallelism)
+	} else {
 		maxParallelism = 1
 	}
 
@@ -109,32 +130,76 @@ func NewUpdater(
 
 	updatedCounter := prometheus.NewCounterVec(prometheus.CounterOpts{
 		Name: "crl_updater_generated",
-		Help: "A counter of CRL generation calls labeled by result",
+		Help: "A counter of CRL generation calls labeled by result=[success|failed|unchanged]",
 	}, []string{"issuer", "result"})
 	stats.MustRegister(updatedCounter)
 
 	return &crlUpdater{
-		issuersByNameID,
-		numShards,
-		shardWidth,
-		lookbackPeriod,
-		updatePeriod,
-		updateTimeout,
-		maxParallelism,
-		maxAttempts,
-		cacheControl,
-		expiresMargin,
-		temporallyShardedPrefixes,
-		sa,
-		ca,
-		cs,
-		tickHistogram,
-		updatedCounter,
-		log,
-		clk,
+		issuers:                   issuersByNameID,
+		numShards:                 numShards,
+		shardWidth:                shardWidth,
+		lookbackPeriod:            lookbackPeriod,
+		updatePeriod:              updatePeriod,
+		updateTimeout:             updateTimeout,
+		maxParallelism:            maxParallelism,
+		maxAttempts:               maxAttempts,
+		shardSlots:                shardSlots,
+		cacheControl:              cacheControl,
+		expiresMargin:             expiresMargin,
+		temporallyShardedPrefixes: temporallyShardedPrefixes,
+		maxUnchangedAge:           maxUnchangedAge,
+		sa:                        sa,
+		ca:                        ca,
+		cs:                        cs,
+		tickHistogram:             tickHistogram,
+		updatedCounter:            updatedCounter,
+		log:                       log,
+		clk:                       clk,
 	}, nil
 }
 
+// digestEntries computes a digest over the given CRL entries which is
+// independent of map iteration order.
+func digestEntries(crlEntries map[string]*proto.CRLEntry) []byte {
+	serials := make([]string, 0, len(crlEntries))
+	for serial := range crlEntries {
+		serials = append(serials, serial)
+	}
+	sort.Strings(serials)
+
+	h := sha256.New()
+	for _, serial := range serials {
+		entry := crlEntries[serial]
+		fmt.Fprintf(h, "%s,%d,%d\n", serial, entry.Reason, entry.RevokedAt.AsTime().UnixNano())
+	}
+	return h.Sum(nil)
+}
+
+// unchangedSince returns the thisUpdate of the shard's current CRL, as
+// recorded with the SA, and true, if that CRL had exactly the entries with the
+// given digest and is recent enough that it need not be re-signed yet.
+// Otherwise it returns false.
+func (cu *crlUpdater) unchangedSince(ctx context.Context, issuerNameID issuance.NameID, shardIdx int, digest []byte, atTime time.Time) (time.Time, bool, error) {
+	if cu.maxUnchangedAge == 0 {
+		return time.Time{}, false, nil
+	}
+	prev, err := cu.sa.GetCRLShard(ctx, &sapb.GetCRLShardRequest{
+		IssuerNameID: int64(issuerNameID),
+		ShardIdx:     int64(shardIdx),
+	})
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if prev.ThisUpdate == nil || !bytes.Equal(prev.EntriesDigest, digest) {
+		return time.Time{}, false, nil
+	}
+	thisUpdate := prev.ThisUpdate.AsTime()
+	if thisUpdate.After(atTime) || atTime.Sub(thisUpdate) >= cu.maxUnchangedAge {
+		return time.Time{}, false, nil
+	}
+	return thisUpdate, true, nil
+}
+
 // updateShardWithRetry calls updateShard repeatedly (with exponential backoff
 // between attempts) until it succeeds or the max number of attempts is reached.
 func (cu *crlUpdater) updateShardWithRetry(ctx context.Context, atTime time.Time, issuerNameID issuance.NameID, shardIdx int, chunks []chunk) error {
@@ -164,6 +229,8 @@ func (cu *crlUpdater) updateShardWithRetry(ctx context.Context, atTime time.Time
 
 	crlID := crl.Id(issuerNameID, shardIdx, crl.Number(atTime))
 
+	var thisUpdate time.Time
+	var digest []byte
 	for i := range cu.maxAttempts {
 		// core.RetryBackoff always returns 0 when its first argument is zero.
 		sleepTime := core.RetryBackoff(i, time.Second, time.Minute, 2)
@@ -174,7 +241,7 @@ func (cu *crlUpdater) updateShardWithRetry(ctx context.Context, atTime time.Time
 		}
 		cu.clk.Sleep(sleepTime)
 
-		err = cu.updateShard(ctx, atTime, issuerNameID, shardIdx, chunks)
+		thisUpdate, digest, err = cu.updateShard(ctx, atTime, issuerNameID, shardIdx, chunks)
 		if err == nil {
 			break
 		}
@@ -183,11 +250,14 @@ func (cu *crlUpdater) updateShardWithRetry(ctx context.Context, atTime time.Time
 		return err
 	}
 
-	// Notify the database that that we're done.
+	// Notify the database that that we're done. If the shard was unchanged and
+	// so not re-signed, this reports the thisUpdate of the CRL which is still
+	// current, which releases our lease without moving thisUpdate forward.
 	_, err = cu.sa.UpdateCRLShard(ctx, &sapb.UpdateCRLShardRequest{
-		IssuerNameID: int64(issuerNameID),
-		ShardIdx:     int64(shardIdx),
-		ThisUpdate:   timestamppb.New(atTime),
+		IssuerNameID:  int64(issuerNameID),
+		ShardIdx:      int64(shardIdx),
+		ThisUpdate:    timestamppb.New(thisUpdate),
+		EntriesDigest: digest,
 	})
 	if err != nil {
 		return fmt.Errorf("updating db metadata: %w", err)
@@ -262,11 +332,13 @@ func addFromStream(crlEntries map[string]*proto.CRLEntry, stream crlStream, allo
 
 // updateShard processes a single shard. It computes the shard's boundaries, gets
 // the list of revoked certs in that shard from the SA, gets the CA to sign the
-// resulting CRL, and gets the crl-storer to upload it. It returns an error if
-// any of these operations fail.
-func (cu *crlUpdater) updateShard(ctx context.Context, atTime time.Time, issuerNameID issuance.NameID, shardIdx int, chunks []chunk) (err error) {
+// resulting CRL, and gets the crl-storer to upload it. It returns the
+// thisUpdate of the shard's current CRL and, if unchanged shards are being
+// skipped, the digest of its entries. It returns an error if any of these
+// operations fail.
+func (cu *crlUpdater) updateShard(ctx context.Context, atTime time.Time, issuerNameID issuance.NameID, shardIdx int, chunks []chunk) (thisUpdate time.Time, entriesDigest []byte, err error) {
 	if shardIdx <= 0 {
-		return fmt.Errorf("invalid shard %d", shardIdx)
+		return time.Time{}, nil, fmt.Errorf("invalid shard %d", shardIdx)
 	}
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -274,11 +346,14 @@ func (cu *crlUpdater) updateShard(ctx context.Context, atTime time.Time, issuerN
 	crlID := crl.Id(issuerNameID, shardIdx, crl.Number(atTime))
 
 	start := cu.clk.Now()
+	var unchanged bool
 	defer func() {
 		// This func closes over the named return value `err`, so can reference it.
 		result := "success"
 		if err != nil {
 			result = "failed"
+		} else if unchanged {
+			result = "unchanged"
 		}
 		cu.tickHistogram.WithLabelValues(cu.issuers[issuerNameID].Subject.CommonName, result).Observe(cu.clk.Since(start).Seconds())
 		cu.updatedCounter.WithLabelValues(cu.issuers[issuerNameID].Subject.CommonName, result).Inc()
@@ -299,12 +374,12 @@ func (cu *crlUpdater) updateShard(ctx context.Context, atTime time.Time, issuerN
 			RevokedBefore: timestamppb.New(atTime),
 		})
 		if err != nil {
-			return fmt.Errorf("GetRevokedCerts: %w", err)
+			return time.Time{}, nil, fmt.Errorf("GetRevokedCerts: %w", err)
 		}
 
 		n, err := addFromStream(crlEntries, saStream, cu.temporallyShardedPrefixes)
 		if err != nil {
-			return fmt.Errorf("streaming GetRevokedCerts: %w", err)
+			return time.Time{}, nil, fmt.Errorf("streaming GetRevokedCerts: %w", err)
 		}
 
 		cu.log.Infof(
@@ -323,21 +398,40 @@ func (cu *crlUpdater) updateShard(ctx context.Context, atTime time.Time, issuerN
 		RevokedBefore: timestamppb.New(atTime),
 	})
 	if err != nil {
-		return fmt.Errorf("GetRevokedCertsByShard: %w", err)
+		return time.Time{}, nil, fmt.Errorf("GetRevokedCertsByShard: %w", err)
 	}
 
 	n, err := addFromStream(crlEntries, saStream, nil)
 	if err != nil {
-		return fmt.Errorf("streaming GetRevokedCertsByShard: %w", err)
+		return time.Time{}, nil, fmt.Errorf("streaming GetRevokedCertsByShard: %w", err)
 	}
 
 	cu.log.Infof(
 		"Queried SA by CRL shard number: id=[%s] shardIdx=[%d] numEntries=[%d]", crlID, shardIdx, n)
 
+	// If the set of entries hasn't changed since this shard was last generated,
+	// and that CRL is recent enough, there's no need to sign and upload an
+	// identical CRL.
+	var digest []byte
+	if cu.maxUnchangedAge != 0 {
+		digest = digestEntries(crlEntries)
+		prevThisUpdate, ok, err := cu.unchangedSince(ctx, issuerNameID, shardIdx, digest, atTime)
+		if err != nil {
+			return time.Time{}, nil, fmt.Errorf("getting CRL shard metadata: %w", err)
+		}
+		if ok {
+			unchanged = true
+			cu.log.Infof(
+				"Skipping unchanged CRL shard: id=[%s] numEntries=[%d] lastThisUpdate=[%s]",
+				crlID, len(crlEntries), prevThisUpdate)
+			return prevThisUpdate, digest, nil
+		}
+	}
+
 	// Send the full list of CRL Entries to the CA.
 	caStream, err := cu.ca.GenerateCRL(ctx)
 	if err != nil {
-		return fmt.Errorf("connecting to CA: %w", err)
+		return time.Time{}, nil, fmt.Errorf("connecting to CA: %w", err)
 	}
 
 	err = caStream.Send(&capb.GenerateCRLRequest{
@@ -350,7 +444,7 @@ func (cu *crlUpdater) updateShard(ctx context.Context, atTime time.Time, issuerN
 		},
 	})
 	if err != nil {
-		return fmt.Errorf("sending CA metadata: %w", err)
+		return time.Time{}, nil, fmt.Errorf("sending CA metadata: %w", err)
 	}
 
 	for _, entry := range crlEntries {
@@ -360,13 +454,13 @@ func (cu *crlUpdater) updateShard(ctx context.Context, atTime time.Time, issuerN
 			},
 		})
 		if err != nil {
-			return fmt.Errorf("sending entry to CA: %w", err)
+			return time.Time{}, nil, fmt.Errorf("sending entry to CA: %w", err)
 		}
 	}
 
 	err = caStream.CloseSend()
 	if err != nil {
-		return fmt.Errorf("closing CA request stream: %w", err)
+		return time.Time{}, nil, fmt.Errorf("closing CA request stream: %w", err)
 	}
 
 	// Receive the full bytes of the signed CRL from the CA.
@@ -379,7 +473,7 @@ func (cu *crlUpdater) updateShard(ctx context.Context, atTime time.Time, issuerN
 			if err == io.EOF {
 				break
 			}
-			return fmt.Errorf("receiving CRL bytes: %w", err)
+			return time.Time{}, nil, fmt.Errorf("receiving CRL bytes: %w", err)
 		}
 
 		crlLen += len(out.Chunk)
@@ -390,7 +484,7 @@ func (cu *crlUpdater) updateShard(ctx context.Context, atTime time.Time, issuerN
 	// Send the full bytes of the signed CRL to the Storer.
 	csStream, err := cu.cs.UploadCRL(ctx)
 	if err != nil {
-		return fmt.Errorf("connecting to CRLStorer: %w", err)
+		return time.Time{}, nil, fmt.Errorf("connecting to CRLStorer: %w", err)
 	}
 
 	err = csStream.Send(&cspb.UploadCRLRequest{
@@ -405,7 +499,7 @@ func (cu *crlUpdater) updateShard(ctx context.Context, atTime time.Time, issuerN
 		},
 	})
 	if err != nil {
-		return fmt.Errorf("sending CRLStorer metadata: %w", err)
+		return time.Time{}, nil, fmt.Errorf("sending CRLStorer metadata: %w", err)
 	}
 
 	for _, chunk := range crlChunks {
@@ -415,20 +509,20 @@ func (cu *crlUpdater) updateShard(ctx context.Context, atTime time.Time, issuerN
 			},
 		})
 		if err != nil {
-			return fmt.Errorf("uploading CRL bytes: %w", err)
+			return time.Time{}, nil, fmt.Errorf("uploading CRL bytes: %w", err)
 		}
 	}
 
 	_, err = csStream.CloseAndRecv()
 	if err != nil {
-		return fmt.Errorf("closing CRLStorer upload stream: %w", err)
+		return time.Time{}, nil, fmt.Errorf("closing CRLStorer upload stream: %w", err)
 	}
 
 	cu.log.Infof(
 		"Generated CRL shard: id=[%s] size=[%d] hash=[%x]",
 		crlID, crlLen, crlHash.Sum(nil))
 
-	return nil
+	return atTime, digest, nil
 }
 
 // anchorTime is used as a universal starting point against which other times
@@ -3,6 +3,7 @@ package updater
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -52,14 +53,16 @@ func (f *revokedCertsStream) Recv() (*corepb.CRLEntry, error) {
 }
 
 // fakeSAC is a fake sapb.StorageAuthorityClient which can be populated with a
-// fakeGRCC to be used as the return value for calls to GetRevokedCerts, and a
-// fake timestamp to serve as the database's maximum notAfter value.
+// fakeGRCC to be used as the return value for calls to GetRevokedCerts, a
+// fake timestamp to serve as the database's maximum notAfter value, and the
+// stored metadata of every CRL shard.
 type fakeSAC struct {
 	sapb.StorageAuthorityClient
 	revokedCerts        revokedCertsStream
 	revokedCertsByShard revokedCertsStream
 	maxNotAfter         time.Time
 	leaseError          error
+	shard               *sapb.CRLShard
 }
 
 func (f *fakeSAC) GetRevokedCerts(ctx context.Context, _ *sapb.GetRevokedCertsRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[corepb.CRLEntry], error) {
@@ -94,6 +97,13 @@ func (f *fakeSAC) LeaseCRLShard(_ context.Context, req *sapb.LeaseCRLShardReques
 	return &sapb.LeaseCRLShardResponse{IssuerNameID: req.IssuerNameID, ShardIdx: req.MinShardIdx}, nil
 }
 
+func (f *fakeSAC) GetCRLShard(_ context.Context, req *sapb.GetCRLShardRequest, _ ...grpc.CallOption) (*sapb.CRLShard, error) {
+	if f.shard == nil {
+		return &sapb.CRLShard{}, nil
+	}
+	return f.shard, nil
+}
+
 // generateCRLStream implements the streaming API returned from GenerateCRL.
 //
 // Specifically it implements grpc.BidiStreamingClient.
@@ -242,6 +252,7 @@ func TestUpdateShard(t *testing.T) {
 		"stale-if-error=60",
 		5*time.Minute,
 		nil,
+		0,
 		&fakeSAC{
 			revokedCerts: revokedCertsStream{},
 			maxNotAfter:  clk.Now().Add(90 * 24 * time.Hour),
@@ -257,7 +268,7 @@ func TestUpdateShard(t *testing.T) {
 	}
 
 	// Ensure that getting no results from the SA still works.
-	err = cu.updateShard(ctx, cu.clk.Now(), e1.NameID(), 1, testChunks)
+	_, _, err = cu.updateShard(ctx, cu.clk.Now(), e1.NameID(), 1, testChunks)
 	test.AssertNotError(t, err, "empty CRL")
 	test.AssertMetricWithLabelsEquals(t, cu.updatedCounter, prometheus.Labels{
 		"issuer": "(TEST) Elegant Elephant E1", "result": "success",
@@ -306,7 +317,7 @@ func TestUpdateShard(t *testing.T) {
 	}
 	// We ask for shard 2 specifically because GetRevokedCertsByShard only returns our
 	// certificate for that shard.
-	err = cu.updateShard(ctx, cu.clk.Now(), e1.NameID(), 2, testChunks)
+	_, _, err = cu.updateShard(ctx, cu.clk.Now(), e1.NameID(), 2, testChunks)
 	test.AssertNotError(t, err, "updateShard")
 
 	expectedEntries := map[string]int32{
@@ -341,7 +352,7 @@ func TestUpdateShard(t *testing.T) {
 	cu.updatedCounter.Reset()
 
 	// Ensure that getting no results from the SA still works.
-	err = cu.updateShard(ctx, cu.clk.Now(), e1.NameID(), 1, testChunks)
+	_, _, err = cu.updateShard(ctx, cu.clk.Now(), e1.NameID(), 1, testChunks)
 	test.AssertNotError(t, err, "empty CRL")
 	test.AssertMetricWithLabelsEquals(t, cu.updatedCounter, prometheus.Labels{
 		"issuer": "(TEST) Elegant Elephant E1", "result": "success",
@@ -350,7 +361,7 @@ func TestUpdateShard(t *testing.T) {
 
 	// Errors closing the Storer upload stream should bubble up.
 	cu.cs = &fakeStorer{uploaderStream: &noopUploader{recvErr: sentinelErr}}
-	err = cu.updateShard(ctx, cu.clk.Now(), e1.NameID(), 1, testChunks)
+	_, _, err = cu.updateShard(ctx, cu.clk.Now(), e1.NameID(), 1, testChunks)
 	test.AssertError(t, err, "storer error")
 	test.AssertContains(t, err.Error(), "closing CRLStorer upload stream")
 	test.AssertErrorIs(t, err, sentinelErr)
@@ -361,7 +372,7 @@ func TestUpdateShard(t *testing.T) {
 
 	// Errors sending to the Storer should bubble up sooner.
 	cu.cs = &fakeStorer{uploaderStream: &noopUploader{sendErr: sentinelErr}}
-	err = cu.updateShard(ctx, cu.clk.Now(), e1.NameID(), 1, testChunks)
+	_, _, err = cu.updateShard(ctx, cu.clk.Now(), e1.NameID(), 1, testChunks)
 	test.AssertError(t, err, "storer error")
 	test.AssertContains(t, err.Error(), "sending CRLStorer metadata")
 	test.AssertErrorIs(t, err, sentinelErr)
@@ -372,7 +383,7 @@ func TestUpdateShard(t *testing.T) {
 
 	// Errors reading from the CA should bubble up sooner.
 	cu.ca = &fakeCA{gcc: generateCRLStream{recvErr: sentinelErr}}
-	err = cu.updateShard(ctx, cu.clk.Now(), e1.NameID(), 1, testChunks)
+	_, _, err = cu.updateShard(ctx, cu.clk.Now(), e1.NameID(), 1, testChunks)
 	test.AssertError(t, err, "CA error")
 	test.AssertContains(t, err.Error(), "receiving CRL bytes")
 	test.AssertErrorIs(t, err, sentinelErr)
@@ -383,7 +394,7 @@ func TestUpdateShard(t *testing.T) {
 
 	// Errors sending to the CA should bubble up sooner.
 	cu.ca = &fakeCA{gcc: generateCRLStream{sendErr: sentinelErr}}
-	err = cu.updateShard(ctx, cu.clk.Now(), e1.NameID(), 1, testChunks)
+	_, _, err = cu.updateShard(ctx, cu.clk.Now(), e1.NameID(), 1, testChunks)
 	test.AssertError(t, err, "CA error")
 	test.AssertContains(t, err.Error(), "sending CA metadata")
 	test.AssertErrorIs(t, err, sentinelErr)
@@ -394,7 +405,7 @@ func TestUpdateShard(t *testing.T) {
 
 	// Errors reading from the SA should bubble up soonest.
 	cu.sa = &fakeSAC{revokedCerts: revokedCertsStream{err: sentinelErr}, maxNotAfter: clk.Now().Add(90 * 24 * time.Hour)}
-	err = cu.updateShard(ctx, cu.clk.Now(), e1.NameID(), 1, testChunks)
+	_, _, err = cu.updateShard(ctx, cu.clk.Now(), e1.NameID(), 1, testChunks)
 	test.AssertError(t, err, "database error")
 	test.AssertContains(t, err.Error(), "retrieving entry from SA")
 	test.AssertErrorIs(t, err, sentinelErr)
@@ -404,6 +415,108 @@ func TestUpdateShard(t *testing.T) {
 	cu.updatedCounter.Reset()
 }
 
+func TestUpdateShardSkipsUnchanged(t *testing.T) {
+	e1, err := issuance.LoadCertificate("../../test/hierarchy/int-e1.cert.pem")
+	test.AssertNotError(t, err, "loading test issuer")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clk := clock.NewFake()
+	clk.Set(time.Date(2020, time.January, 18, 0, 0, 0, 0, time.UTC))
+
+	revokedAt := timestamppb.New(clk.Now().Add(-time.Hour))
+	makeSAC := func(entries ...*corepb.CRLEntry) *fakeSAC {
+		return &fakeSAC{
+			revokedCerts: revokedCertsStream{entries: entries},
+			maxNotAfter:  clk.Now().Add(90 * 24 * time.Hour),
+		}
+	}
+	first := &corepb.CRLEntry{Serial: "0311b5d430823cfa25b0fc85d14c54ee35", Reason: int32(ocsp.KeyCompromise), RevokedAt: revokedAt}
+	second := &corepb.CRLEntry{Serial: "037d6a05a0f6a975380456ae605cee9889", Reason: int32(ocsp.Unspecified), RevokedAt: revokedAt}
+
+	cu, err := NewUpdater(
+		[]*issuance.Certificate{e1},
+		2, 18*time.Hour, 24*time.Hour,
+		6*time.Hour, time.Minute, 1, 1,
+		"stale-if-error=60",
+		5*time.Minute,
+		nil,
+		24*time.Hour, // maxUnchangedAge
+		makeSAC(first),
+		&fakeCA{gcc: generateCRLStream{}},
+		&fakeStorer{uploaderStream: &noopUploader{}},
+		metrics.NoopRegisterer, blog.NewMock(), clk,
+	)
+	test.AssertNotError(t, err, "building test crlUpdater")
+
+	testChunks := []chunk{
+		{clk.Now(), clk.Now().Add(18 * time.Hour), 0},
+	}
+	labels := func(result string) prometheus.Labels {
+		return prometheus.Labels{"issuer": e1.Subject.CommonName, "result": result}
+	}
+
+	// The first generation of a shard always signs and uploads a CRL.
+	thisUpdate, digest, err := cu.updateShard(ctx, clk.Now(), e1.NameID(), 1, testChunks)
+	test.AssertNotError(t, err, "first updateShard")
+	test.AssertMetricWithLabelsEquals(t, cu.updatedCounter, labels("success"), 1)
+	test.AssertEquals(t, thisUpdate, clk.Now())
+	test.AssertEquals(t, len(digest), sha256.Size)
+
+	// Generating the same shard again with the same entries, as recorded with
+	// the SA, is skipped, and the previous thisUpdate is retained.
+	generatedAt := clk.Now().Add(-time.Hour)
+	sac := makeSAC(first)
+	sac.shard = &sapb.CRLShard{ThisUpdate: timestamppb.New(generatedAt), EntriesDigest: digest}
+	cu.sa = sac
+	uploader := &recordingUploader{}
+	cu.cs = &fakeStorer{uploaderStream: uploader}
+	thisUpdate, _, err = cu.updateShard(ctx, clk.Now(), e1.NameID(), 1, testChunks)
+	test.AssertNotError(t, err, "unchanged updateShard")
+	test.AssertMetricWithLabelsEquals(t, cu.updatedCounter, labels("unchanged"), 1)
+	test.AssertEquals(t, len(uploader.crlBody), 0)
+	test.AssertEquals(t, thisUpdate, generatedAt)
+
+	// A new entry causes the shard to be regenerated.
+	sac = makeSAC(first, second)
+	sac.shard = &sapb.CRLShard{ThisUpdate: timestamppb.New(generatedAt), EntriesDigest: digest}
+	cu.sa = sac
+	thisUpdate, changedDigest, err := cu.updateShard(ctx, clk.Now(), e1.NameID(), 1, testChunks)
+	test.AssertNotError(t, err, "changed updateShard")
+	test.AssertMetricWithLabelsEquals(t, cu.updatedCounter, labels("success"), 2)
+	test.Assert(t, len(uploader.crlBody) > 0, "expected changed CRL to be uploaded")
+	test.AssertEquals(t, thisUpdate, clk.Now())
+	test.Assert(t, !bytes.Equal(changedDigest, digest), "expected a different digest for different entries")
+
+	// An unchanged shard is regenerated once its CRL is older than
+	// maxUnchangedAge.
+	sac = makeSAC(first, second)
+	sac.shard = &sapb.CRLShard{ThisUpdate: timestamppb.New(clk.Now().Add(-25 * time.Hour)), EntriesDigest: changedDigest}
+	cu.sa = sac
+	thisUpdate, _, err = cu.updateShard(ctx, clk.Now(), e1.NameID(), 1, testChunks)
+	test.AssertNotError(t, err, "aged updateShard")
+	test.AssertMetricWithLabelsEquals(t, cu.updatedCounter, labels("success"), 3)
+	test.AssertMetricWithLabelsEquals(t, cu.updatedCounter, labels("unchanged"), 1)
+	test.AssertEquals(t, thisUpdate, clk.Now())
+
+	// maxUnchangedAge may not be shorter than updatePeriod.
+	_, err = NewUpdater(
+		[]*issuance.Certificate{e1},
+		2, 18*time.Hour, 24*time.Hour,
+		6*time.Hour, time.Minute, 1, 1,
+		"stale-if-error=60",
+		5*time.Minute,
+		nil,
+		time.Hour,
+		makeSAC(),
+		&fakeCA{gcc: generateCRLStream{}},
+		&fakeStorer{uploaderStream: &noopUploader{}},
+		metrics.NoopRegisterer, blog.NewMock(), clk,
+	)
+	test.AssertError(t, err, "maxUnchangedAge shorter than updatePeriod")
+}
+
 func TestUpdateShardWithRetry(t *testing.T) {
 	e1, err := issuance.LoadCertificate("../../test/hierarchy/int-e1.cert.pem")
 	test.AssertNotError(t, err, "loading test issuer")
@@ -425,6 +538,7 @@ func TestUpdateShardWithRetry(t *testing.T) {
 		"stale-if-error=60",
 		5*time.Minute,
 		nil,
+		0,
 		&fakeSAC{revokedCerts: revokedCertsStream{err: sentinelErr}, maxNotAfter: clk.Now().Add(90 * 24 * time.Hour)},
 		&fakeCA{gcc: generateCRLStream{}},
 		&fakeStorer{uploaderStream: &noopUploader{}},
@@ -75,10 +75,22 @@ func New(pub pubpb.PublisherClient, sctLogs loglist.List, infoLogs loglist.List,
 	}
 }
 
+// Policy describes the CT log policy which every set of SCTs returned by
+// GetSCTs satisfies.
+const Policy = "2 SCTs from logs run by distinct operators, at least 1 from an RFC 6962 log"
+
+// SCTSource describes the log which issued an SCT, and how long it took to
+// respond to the submission.
+type SCTSource struct {
+	Log               loglist.Log
+	SubmissionLatency time.Duration
+}
+
 type result struct {
-	log loglist.Log
-	sct []byte
-	err error
+	log     loglist.Log
+	sct     []byte
+	err     error
+	latency time.Duration
 }
 
 // GetSCTs retrieves exactly two SCTs from the total collection of configured
@@ -86,15 +98,16 @@ type result struct {
 // logs run by a single operator (e.g. Google) are in the same group, to
 // guarantee that SCTs from logs in different groups do not end up coming from
 // the same operator. As such, it enforces Google's current CT Policy, which
-// requires that certs have two SCTs from logs run by different operators.
-func (ctp *CTPolicy) GetSCTs(ctx context.Context, cert core.CertDER, expiration time.Time) (core.SCTDERs, error) {
+// requires that certs have two SCTs from logs run by different operators. The
+// source of each SCT is returned alongside it.
+func (ctp *CTPolicy) GetSCTs(ctx context.Context, cert core.CertDER, expiration time.Time) (core.SCTDERs, []SCTSource, error) {
 	// We'll cancel this sub-context when we have the two SCTs we need, to cause
 	// any other ongoing submission attempts to quit.
 	subCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	// This closure will be called in parallel once for each log.
-	getOne := func(i int, l loglist.Log) ([]byte, error) {
+	getOne := func(i int, l loglist.Log) ([]byte, time.Duration, error) {
 		// Sleep a little bit to stagger our requests to the later logs. Use `i-1`
 		// to compute the stagger duration so that the first two logs (indices 0
 		// and 1) get negative or zero (i.e. instant) sleep durations. If the
@@ -102,21 +115,23 @@ func (ctp *CTPolicy) GetSCTs(ctx context.Context, cert core.CertDER, expiration
 		// logs already) before the sleep is complete, quit instead.
 		select {
 		case <-subCtx.Done():
-			return nil, subCtx.Err()
+			return nil, 0, subCtx.Err()
 		case <-time.After(time.Duration(i-1) * ctp.stagger):
 		}
 
+		start := time.Now()
 		sct, err := ctp.pub.SubmitToSingleCTWithResult(ctx, &pubpb.Request{
 			LogURL:       l.Url,
 			LogPublicKey: base64.StdEncoding.EncodeToString(l.Key),
 			Der:          cert,
 			Kind:         pubpb.SubmissionType_sct,
 		})
+		latency := time.Since(start)
 		if err != nil {
-			return nil, fmt.Errorf("ct submission to %q (%q) failed: %w", l.Name, l.Url, err)
+			return nil, latency, fmt.Errorf("ct submission to %q (%q) failed: %w", l.Name, l.Url, err)
 		}
 
-		return sct.Sct, nil
+		return sct.Sct, latency, nil
 	}
 
 	// Identify the set of candidate logs whose temporal interval includes this
@@ -131,8 +146,8 @@ func (ctp *CTPolicy) GetSCTs(ctx context.Context, cert core.CertDER, expiration
 	resChan := make(chan result, len(logs))
 	for i, log := range logs {
 		go func(i int, l loglist.Log) {
-			sctDER, err := getOne(i, l)
-			resChan <- result{log: l, sct: sctDER, err: err}
+			sctDER, latency, err := getOne(i, l)
+			resChan <- result{log: l, sct: sctDER, err: err, latency: latency}
 		}(i, log)
 	}
 
@@ -153,9 +168,15 @@ func (ctp *CTPolicy) GetSCTs(ctx context.Context, cert core.CertDER, expiration
 		results = append(results, res)
 		ctp.winnerCounter.WithLabelValues(res.log.Url, succeeded).Inc()
 
-		scts := compliantSet(results)
-		if scts != nil {
-			return scts, nil
+		set := compliantSet(results)
+		if set != nil {
+			var scts core.SCTDERs
+			var sources []SCTSource
+			for _, res := range set {
+				scts = append(scts, res.sct)
+				sources = append(sources, SCTSource{Log: res.log, SubmissionLatency: res.latency})
+			}
+			return scts, sources, nil
 		}
 	}
 
@@ -164,19 +185,19 @@ func (ctp *CTPolicy) GetSCTs(ctx context.Context, cert core.CertDER, expiration
 	if ctx.Err() != nil {
 		// We timed out (the calling function returned and canceled our context),
 		// thereby causing all of our getOne sub-goroutines to be cancelled.
-		return nil, berrors.MissingSCTsError("failed to get 2 SCTs before ctx finished: %s", ctx.Err())
+		return nil, nil, berrors.MissingSCTsError("failed to get 2 SCTs before ctx finished: %s", ctx.Err())
 	}
-	return nil, berrors.MissingSCTsError("failed to get 2 SCTs, got %d error(s): %s", len(errs), strings.Join(errs, "; "))
+	return nil, nil, berrors.MissingSCTsError("failed to get 2 SCTs, got %d error(s): %s", len(errs), strings.Join(errs, "; "))
 }
 
-// compliantSet returns a slice of SCTs which complies with all relevant CT Log
-// Policy requirements, namely that the set of SCTs:
+// compliantSet returns a set of results whose SCTs comply with all relevant CT
+// Log Policy requirements, namely that the set of SCTs:
 // - contain at least two SCTs, which
 // - come from logs run by at least two different operators, and
 // - contain at least one RFC6962-compliant (i.e. non-static/tiled) log.
 //
 // If no such set of SCTs exists, returns nil.
-func compliantSet(results []result) core.SCTDERs {
+func compliantSet(results []result) []result {
 	for _, first := range results {
 		if first.err != nil {
 			continue
@@ -193,7 +214,7 @@ func compliantSet(results []result) core.SCTDERs {
 				// At least one must come from a non-tiled log.
 				continue
 			}
-			return core.SCTDERs{first.sct, second.sct}
+			return []result{first, second}
 		}
 	}
 	return nil
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -96,7 +97,7 @@ func TestGetSCTs(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			ctp := New(tc.mock, tc.logs, nil, nil, 0, blog.NewMock(), metrics.NoopRegisterer)
-			ret, err := ctp.GetSCTs(tc.ctx, []byte{0}, time.Time{})
+			ret, _, err := ctp.GetSCTs(tc.ctx, []byte{0}, time.Time{})
 			if tc.result != nil {
 				test.AssertDeepEquals(t, ret, tc.result)
 			} else if tc.expectErr != "" {
@@ -128,18 +129,36 @@ func TestGetSCTsMetrics(t *testing.T) {
 		{Name: "LogB1", Operator: "OperB", Url: "UrlB1", Key: []byte("KeyB1")},
 		{Name: "LogC1", Operator: "OperC", Url: "UrlC1", Key: []byte("KeyC1")},
 	}, nil, nil, 0, blog.NewMock(), metrics.NoopRegisterer)
-	_, err := ctp.GetSCTs(context.Background(), []byte{0}, time.Time{})
+	_, _, err := ctp.GetSCTs(context.Background(), []byte{0}, time.Time{})
 	test.AssertNotError(t, err, "GetSCTs failed")
 	test.AssertMetricWithLabelsEquals(t, ctp.winnerCounter, prometheus.Labels{"url": "UrlB1", "result": succeeded}, 1)
 	test.AssertMetricWithLabelsEquals(t, ctp.winnerCounter, prometheus.Labels{"url": "UrlC1", "result": succeeded}, 1)
 }
 
+func TestGetSCTsSources(t *testing.T) {
+	ctp := New(&mockFailOnePub{badURL: "UrlA1"}, loglist.List{
+		{Name: "LogA1", Operator: "OperA", Url: "UrlA1", Key: []byte("KeyA1")},
+		{Name: "LogB1", Operator: "OperB", Url: "UrlB1", Key: []byte("KeyB1"), Tiled: true},
+		{Name: "LogC1", Operator: "OperC", Url: "UrlC1", Key: []byte("KeyC1")},
+	}, nil, nil, 0, blog.NewMock(), metrics.NoopRegisterer)
+	scts, sources, err := ctp.GetSCTs(context.Background(), []byte{0}, time.Time{})
+	test.AssertNotError(t, err, "GetSCTs failed")
+	test.AssertEquals(t, len(scts), 2)
+	test.AssertEquals(t, len(sources), 2)
+	var names []string
+	for _, source := range sources {
+		names = append(names, source.Log.Name)
+	}
+	slices.Sort(names)
+	test.AssertDeepEquals(t, names, []string{"LogB1", "LogC1"})
+}
+
 func TestGetSCTsFailMetrics(t *testing.T) {
 	// Ensure the proper metrics are incremented when GetSCTs fails.
 	ctp := New(&mockFailOnePub{badURL: "UrlA1"}, loglist.List{
 		{Name: "LogA1", Operator: "OperA", Url: "UrlA1", Key: []byte("KeyA1")},
 	}, nil, nil, 0, blog.NewMock(), metrics.NoopRegisterer)
-	_, err := ctp.GetSCTs(context.Background(), []byte{0}, time.Time{})
+	_, _, err := ctp.GetSCTs(context.Background(), []byte{0}, time.Time{})
 	test.AssertError(t, err, "GetSCTs should have failed")
 	test.AssertErrorIs(t, err, berrors.MissingSCTs)
 	test.AssertMetricWithLabelsEquals(t, ctp.winnerCounter, prometheus.Labels{"url": "UrlA1", "result": failed}, 1)
@@ -151,7 +170,7 @@ func TestGetSCTsFailMetrics(t *testing.T) {
 	ctp = New(&mockSlowPub{}, loglist.List{
 		{Name: "LogA1", Operator: "OperA", Url: "UrlA1", Key: []byte("KeyA1")},
 	}, nil, nil, 0, blog.NewMock(), metrics.NoopRegisterer)
-	_, err = ctp.GetSCTs(ctx, []byte{0}, time.Time{})
+	_, _, err = ctp.GetSCTs(ctx, []byte{0}, time.Time{})
 	test.AssertError(t, err, "GetSCTs should have timed out")
 	test.AssertErrorIs(t, err, berrors.MissingSCTs)
 	test.AssertContains(t, err.Error(), context.DeadlineExceeded.Error())
@@ -245,7 +264,7 @@ func TestCompliantSet(t *testing.T) {
 				t.Fatalf("compliantSet(%#v) returned %d SCTs, but want %d", tc.results, len(got), len(tc.want))
 			}
 			for i, sct := range tc.want {
-				if !bytes.Equal(got[i], sct) {
+				if !bytes.Equal(got[i].sct, sct) {
 					t.Errorf("compliantSet(%#v) returned unexpected SCT at index %d", tc.results, i)
 				}
 			}
@@ -0,0 +1,291 @@
+// Package ctpresence checks that the SCTs embedded in certificates were
+// honored, by asking the logs which issued them for inclusion proofs of the
+// corresponding precertificate entries.
+package ctpresence
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+
+	ct "github.com/google/certificate-transparency-go"
+	ctClient "github.com/google/certificate-transparency-go/client"
+	"github.com/google/certificate-transparency-go/jsonclient"
+	ctx509 "github.com/google/certificate-transparency-go/x509"
+	"github.com/google/certificate-transparency-go/x509util"
+
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/ctpolicy/loglist"
+	"github.com/letsencrypt/boulder/issuance"
+)
+
+// Config configures a cross-check of the SCTs embedded in certificates against
+// the CT logs which issued them.
+type Config struct {
+	// SampleRate is the fraction of checked certificates whose SCTs are
+	// looked up in their logs, between 0 and 1.
+	SampleRate float64 `validate:"required,gt=0,lte=1"`
+
+	// IssuerCerts are the paths to the certificates of the issuers of the
+	// checked certificates. They're needed to reconstruct the precertificate
+	// entries which the SCTs commit to.
+	IssuerCerts []string `validate:"min=1,dive,required"`
+
+	// MaximumMergeDelay is how long a log may take to incorporate an entry
+	// after issuing an SCT for it. SCTs which were issued less than this long
+	// before the log's current tree head aren't checked. If zero, a default of
+	// 24 hours is used.
+	MaximumMergeDelay config.Duration `validate:"-"`
+
+	// Timeout is the deadline for each request to a log. If zero, a default
+	// of 30 seconds is used.
+	Timeout config.Duration `validate:"-"`
+}
+
+// logClient is the subset of the CT log client used by Checker.
+type logClient interface {
+	GetSTH(context.Context) (*ct.SignedTreeHead, error)
+	GetProofByHash(ctx context.Context, hash []byte, treeSize uint64) (*ct.GetProofByHashResponse, error)
+}
+
+// logState is a log client and the tree head which every check against that
+// log uses. The tree head is fetched on first use, and again after Refresh.
+type logState struct {
+	once   sync.Once
+	client logClient
+	sth    *ct.SignedTreeHead
+	err    error
+}
+
+// Checker checks that the SCTs embedded in a certificate were honored, i.e.
+// that each log which issued one has incorporated the corresponding
+// precertificate entry into its tree. It is safe for concurrent use.
+type Checker struct {
+	logs       loglist.List
+	issuers    map[string]*ctx509.Certificate
+	newClient  func(loglist.Log) (logClient, error)
+	sampleRate float64
+	mmd        time.Duration
+	timeout    time.Duration
+
+	mu    sync.Mutex
+	state map[string]*logState
+}
+
+// New returns a Checker for certificates issued by c.IssuerCerts, which looks
+// up their SCTs' logs in logs. Requests to the logs identify themselves with
+// userAgent.
+func New(c Config, logs loglist.List, userAgent string) (*Checker, error) {
+	issuers := make(map[string]*ctx509.Certificate)
+	for _, path := range c.IssuerCerts {
+		issuer, err := issuance.LoadCertificate(path)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := ctx509.ParseCertificate(issuer.Raw)
+		if ctx509.IsFatal(err) {
+			return nil, fmt.Errorf("parsing issuer certificate %q: %w", path, err)
+		}
+		issuers[string(parsed.SubjectKeyId)] = parsed
+	}
+
+	mmd := c.MaximumMergeDelay.Duration
+	if mmd == 0 {
+		mmd = 24 * time.Hour
+	}
+	timeout := c.Timeout.Duration
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+
+	httpClient := &http.Client{Timeout: timeout}
+	return &Checker{
+		logs:    logs,
+		issuers: issuers,
+		newClient: func(log loglist.Log) (logClient, error) {
+			return ctClient.New(log.Url, httpClient, jsonclient.Options{
+				PublicKeyDER: log.Key,
+				UserAgent:    userAgent,
+			})
+		},
+		sampleRate: c.SampleRate,
+		mmd:        mmd,
+		timeout:    timeout,
+		state:      make(map[string]*logState),
+	}, nil
+}
+
+// Sample returns true for the fraction of certificates which should be
+// checked.
+func (c *Checker) Sample() bool {
+	return rand.Float64() < c.sampleRate
+}
+
+// Refresh discards the logs' tree heads, so that each is fetched again by the
+// next check against that log. Without it, a long-running Checker would
+// never see entries incorporated after its first check.
+func (c *Checker) Refresh() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.state = make(map[string]*logState)
+}
+
+// logState returns the client and current tree head for log, fetching the
+// tree head if this is the first check against the log.
+func (c *Checker) logState(ctx context.Context, log loglist.Log) (logClient, *ct.SignedTreeHead, error) {
+	c.mu.Lock()
+	s, ok := c.state[log.Id]
+	if !ok {
+		s = &logState{}
+		c.state[log.Id] = s
+	}
+	c.mu.Unlock()
+
+	s.once.Do(func() {
+		s.client, s.err = c.newClient(log)
+		if s.err != nil {
+			return
+		}
+		ctx, cancel := context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+		s.sth, s.err = s.client.GetSTH(ctx)
+	})
+	return s.client, s.sth, s.err
+}
+
+// Check returns a problem for each SCT embedded in der whose entry its log
+// says it doesn't have, or for which it returns an invalid inclusion proof.
+// SCTs which can't be checked, because their log is unknown or unreachable,
+// are returned as errors rather than problems. SCTs from tiled logs, which
+// don't implement get-proof-by-hash, and SCTs within the log's maximum merge
+// delay of its tree head are skipped.
+func (c *Checker) Check(ctx context.Context, der []byte) ([]string, error) {
+	cert, err := ctx509.ParseCertificate(der)
+	if ctx509.IsFatal(err) {
+		return nil, fmt.Errorf("parsing certificate: %w", err)
+	}
+	scts, err := x509util.ParseSCTsFromSCTList(&cert.SCTList)
+	if err != nil {
+		return []string{fmt.Sprintf("Certificate has malformed SCT list: %s", err)}, nil
+	}
+	if len(scts) == 0 {
+		return nil, nil
+	}
+	issuer, ok := c.issuers[string(cert.AuthorityKeyId)]
+	if !ok {
+		return nil, fmt.Errorf("no issuer with key ID %x", cert.AuthorityKeyId)
+	}
+
+	var problems []string
+	var errs []error
+	for _, sct := range scts {
+		problem, err := c.checkSCT(ctx, cert, issuer, sct)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+	return problems, errors.Join(errs...)
+}
+
+func (c *Checker) checkSCT(ctx context.Context, cert, issuer *ctx509.Certificate, sct *ct.SignedCertificateTimestamp) (string, error) {
+	logID := base64.StdEncoding.EncodeToString(sct.LogID.KeyID[:])
+	log, err := c.logs.GetByID(logID)
+	if err != nil {
+		return "", err
+	}
+	if log.Tiled {
+		return "", nil
+	}
+
+	client, sth, err := c.logState(ctx, log)
+	if err != nil {
+		return "", fmt.Errorf("getting tree head of CT log %q: %w", log.Name, err)
+	}
+	timestamp := time.UnixMilli(int64(sct.Timestamp))
+	if time.UnixMilli(int64(sth.Timestamp)).Before(timestamp.Add(c.mmd)) {
+		// The log isn't required to have incorporated the entry yet.
+		return "", nil
+	}
+
+	leaf, err := ct.MerkleTreeLeafForEmbeddedSCT([]*ctx509.Certificate{cert, issuer}, sct.Timestamp)
+	if err != nil {
+		return "", fmt.Errorf("building Merkle tree leaf: %w", err)
+	}
+	leaf.TimestampedEntry.Extensions = sct.Extensions
+	leafHash, err := ct.LeafHashForLeaf(leaf)
+	if err != nil {
+		return "", fmt.Errorf("hashing Merkle tree leaf: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := client.GetProofByHash(ctx, leafHash[:], sth.TreeSize)
+	if err != nil {
+		var rspErr jsonclient.RspError
+		if errors.As(err, &rspErr) && (rspErr.StatusCode == http.StatusNotFound || rspErr.StatusCode == http.StatusBadRequest) {
+			return fmt.Sprintf("SCT issued by CT log %q at %s was never honored: no entry in tree of size %d",
+				log.Name, timestamp.UTC().Format(time.RFC3339), sth.TreeSize), nil
+		}
+		return "", fmt.Errorf("getting inclusion proof from CT log %q: %w", log.Name, err)
+	}
+
+	err = verifyInclusion(uint64(resp.LeafIndex), sth.TreeSize, leafHash[:], resp.AuditPath, sth.SHA256RootHash[:])
+	if err != nil {
+		return fmt.Sprintf("SCT issued by CT log %q at %s was never honored: %s",
+			log.Name, timestamp.UTC().Format(time.RFC3339), err), nil
+	}
+	return "", nil
+}
+
+// verifyInclusion checks that proof is a valid inclusion proof for the leaf
+// with the given hash and index in the tree with the given size and root
+// hash, using the algorithm in RFC 9162 Section 2.1.3.2.
+func verifyInclusion(index, size uint64, leafHash []byte, proof [][]byte, root []byte) error {
+	if index >= size {
+		return fmt.Errorf("leaf index %d is beyond tree size %d", index, size)
+	}
+	fn, sn := index, size-1
+	r := leafHash
+	for _, p := range proof {
+		if sn == 0 {
+			return errors.New("inclusion proof is too long")
+		}
+		if fn&1 == 1 || fn == sn {
+			r = hashChildren(p, r)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			r = hashChildren(r, p)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+	if sn != 0 {
+		return errors.New("inclusion proof is too short")
+	}
+	if !bytes.Equal(r, root) {
+		return errors.New("inclusion proof doesn't lead to the tree's root hash")
+	}
+	return nil
+}
+
+// hashChildren returns the hash of an interior Merkle tree node.
+func hashChildren(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{ct.TreeNodePrefix})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
@@ -0,0 +1,264 @@
+package ctpresence
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	ct "github.com/google/certificate-transparency-go"
+	"github.com/google/certificate-transparency-go/jsonclient"
+	cttls "github.com/google/certificate-transparency-go/tls"
+	ctx509 "github.com/google/certificate-transparency-go/x509"
+
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/ctpolicy/loglist"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// treeHash returns the RFC 6962 Merkle tree hash of the given leaf hashes.
+func treeHash(leaves [][]byte) []byte {
+	if len(leaves) == 1 {
+		return leaves[0]
+	}
+	k := splitPoint(len(leaves))
+	return hashChildren(treeHash(leaves[:k]), treeHash(leaves[k:]))
+}
+
+// auditPath returns the RFC 6962 inclusion proof of the m'th of the given leaf
+// hashes.
+func auditPath(m int, leaves [][]byte) [][]byte {
+	if len(leaves) == 1 {
+		return nil
+	}
+	k := splitPoint(len(leaves))
+	if m < k {
+		return append(auditPath(m, leaves[:k]), treeHash(leaves[k:]))
+	}
+	return append(auditPath(m-k, leaves[k:]), treeHash(leaves[:k]))
+}
+
+// splitPoint returns the largest power of two smaller than n.
+func splitPoint(n int) int {
+	k := 1
+	for k*2 < n {
+		k *= 2
+	}
+	return k
+}
+
+func TestVerifyInclusion(t *testing.T) {
+	t.Parallel()
+
+	var leaves [][]byte
+	for i := range 9 {
+		leaves = append(leaves, bytes.Repeat([]byte{byte(i)}, 32))
+	}
+	for size := 1; size <= len(leaves); size++ {
+		root := treeHash(leaves[:size])
+		for index := range size {
+			proof := auditPath(index, leaves[:size])
+			err := verifyInclusion(uint64(index), uint64(size), leaves[index], proof, root)
+			test.AssertNotError(t, err, "valid inclusion proof rejected")
+
+			err = verifyInclusion(uint64(index), uint64(size), leaves[(index+1)%len(leaves)], proof, root)
+			test.AssertError(t, err, "inclusion proof for another leaf accepted")
+			err = verifyInclusion(uint64(index), uint64(size), leaves[index], append(proof, root), root)
+			test.AssertError(t, err, "overlong inclusion proof accepted")
+			if len(proof) > 0 {
+				err = verifyInclusion(uint64(index), uint64(size), leaves[index], proof[1:], root)
+				test.AssertError(t, err, "truncated inclusion proof accepted")
+			}
+		}
+		err := verifyInclusion(uint64(size), uint64(size), leaves[0], nil, root)
+		test.AssertError(t, err, "leaf index beyond tree size accepted")
+	}
+}
+
+// fakeCTLog is a CT log whose tree contains the given leaf hashes.
+type fakeCTLog struct {
+	leaves    [][]byte
+	timestamp time.Time
+	sthErr    error
+	badProofs bool
+}
+
+func (f *fakeCTLog) GetSTH(context.Context) (*ct.SignedTreeHead, error) {
+	if f.sthErr != nil {
+		return nil, f.sthErr
+	}
+	sth := &ct.SignedTreeHead{
+		TreeSize:  uint64(len(f.leaves)),
+		Timestamp: uint64(f.timestamp.UnixMilli()),
+	}
+	copy(sth.SHA256RootHash[:], treeHash(f.leaves))
+	return sth, nil
+}
+
+func (f *fakeCTLog) GetProofByHash(_ context.Context, hash []byte, treeSize uint64) (*ct.GetProofByHashResponse, error) {
+	for i, leaf := range f.leaves[:treeSize] {
+		if bytes.Equal(leaf, hash) {
+			proof := auditPath(i, f.leaves[:treeSize])
+			if f.badProofs {
+				proof[0] = make([]byte, 32)
+			}
+			return &ct.GetProofByHashResponse{LeafIndex: int64(i), AuditPath: proof}, nil
+		}
+	}
+	return nil, jsonclient.RspError{Err: errors.New("not found"), StatusCode: http.StatusNotFound}
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	issuerKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating issuer key")
+	issuerTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "CT presence test issuer"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		SubjectKeyId:          []byte{1, 2, 3, 4},
+	}
+	issuerDER, err := x509.CreateCertificate(rand.Reader, issuerTemplate, issuerTemplate, issuerKey.Public(), issuerKey)
+	test.AssertNotError(t, err, "creating issuer certificate")
+	issuer, err := x509.ParseCertificate(issuerDER)
+	test.AssertNotError(t, err, "parsing issuer certificate")
+	issuerFile := path.Join(t.TempDir(), "issuer.pem")
+	err = os.WriteFile(issuerFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: issuerDER}), 0600)
+	test.AssertNotError(t, err, "writing issuer certificate")
+
+	// The certificate has one SCT from each of three logs.
+	sctTime := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	logs := loglist.List{
+		{Name: "A", Id: base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{'A'}, 32))},
+		{Name: "B", Id: base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{'B'}, 32))},
+		{Name: "C", Id: base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{'C'}, 32)), Tiled: true},
+	}
+	var sctList ctx509.SignedCertificateTimestampList
+	for _, log := range logs {
+		sct := ct.SignedCertificateTimestamp{
+			SCTVersion: ct.V1,
+			Timestamp:  uint64(sctTime.UnixMilli()),
+			Signature: ct.DigitallySigned{
+				Algorithm: cttls.SignatureAndHashAlgorithm{Hash: cttls.SHA256, Signature: cttls.ECDSA},
+				Signature: []byte{1},
+			},
+		}
+		copy(sct.LogID.KeyID[:], bytes.Repeat([]byte(log.Name), 32))
+		sctBytes, err := cttls.Marshal(sct)
+		test.AssertNotError(t, err, "marshalling SCT")
+		sctList.SCTList = append(sctList.SCTList, ctx509.SerializedSCT{Val: sctBytes})
+	}
+	listBytes, err := cttls.Marshal(sctList)
+	test.AssertNotError(t, err, "marshalling SCT list")
+	extBytes, err := asn1.Marshal(listBytes)
+	test.AssertNotError(t, err, "marshalling SCT list extension")
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating leaf key")
+	leafDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		DNSNames:     []string{"example.com"},
+		NotBefore:    sctTime,
+		NotAfter:     sctTime.Add(time.Hour),
+		ExtraExtensions: []pkix.Extension{
+			{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}, Value: extBytes},
+		},
+	}, issuer, leafKey.Public(), issuerKey)
+	test.AssertNotError(t, err, "creating leaf certificate")
+
+	// Compute the leaf hash which logs A and B should have in their trees.
+	leafCert, err := ctx509.ParseCertificate(leafDER)
+	test.AssertNotError(t, err, "parsing leaf certificate")
+	ctIssuer, err := ctx509.ParseCertificate(issuerDER)
+	test.AssertNotError(t, err, "parsing issuer certificate")
+	leaf, err := ct.MerkleTreeLeafForEmbeddedSCT([]*ctx509.Certificate{leafCert, ctIssuer}, uint64(sctTime.UnixMilli()))
+	test.AssertNotError(t, err, "building Merkle tree leaf")
+	leafHash, err := ct.LeafHashForLeaf(leaf)
+	test.AssertNotError(t, err, "hashing Merkle tree leaf")
+	other := bytes.Repeat([]byte{0xff}, 32)
+
+	newCTChecker := func(fakes map[string]*fakeCTLog) *Checker {
+		c, err := New(Config{
+			SampleRate:        1,
+			IssuerCerts:       []string{issuerFile},
+			MaximumMergeDelay: config.Duration{Duration: 24 * time.Hour},
+		}, logs, "test")
+		test.AssertNotError(t, err, "creating CT presence checker")
+		c.newClient = func(log loglist.Log) (logClient, error) {
+			return fakes[log.Name], nil
+		}
+		return c
+	}
+	sthTime := sctTime.Add(48 * time.Hour)
+
+	// Both logs have incorporated the entry, and the tiled log isn't asked.
+	c := newCTChecker(map[string]*fakeCTLog{
+		"A": {leaves: [][]byte{other, leafHash[:], other}, timestamp: sthTime},
+		"B": {leaves: [][]byte{leafHash[:]}, timestamp: sthTime},
+	})
+	problems, err := c.Check(context.Background(), leafDER)
+	test.AssertNotError(t, err, "check failed")
+	test.AssertEquals(t, len(problems), 0)
+
+	// Log B never incorporated the entry, and log A returns a bad proof.
+	c = newCTChecker(map[string]*fakeCTLog{
+		"A": {leaves: [][]byte{other, leafHash[:], other}, timestamp: sthTime, badProofs: true},
+		"B": {leaves: [][]byte{other}, timestamp: sthTime},
+	})
+	problems, err = c.Check(context.Background(), leafDER)
+	test.AssertNotError(t, err, "check failed")
+	test.AssertEquals(t, len(problems), 2)
+	test.AssertContains(t, problems[0], `SCT issued by CT log "A" at 2026-10-14T00:00:00Z was never honored: inclusion proof doesn't lead`)
+	test.AssertContains(t, problems[1], `SCT issued by CT log "B" at 2026-10-14T00:00:00Z was never honored: no entry in tree of size 1`)
+
+	// Log B's tree head is within the maximum merge delay of the SCT, so its
+	// absence isn't a problem, and log A is unreachable, which is an error
+	// but not a problem.
+	c = newCTChecker(map[string]*fakeCTLog{
+		"A": {sthErr: errors.New("connection refused")},
+		"B": {leaves: [][]byte{other}, timestamp: sctTime.Add(time.Hour)},
+	})
+	problems, err = c.Check(context.Background(), leafDER)
+	test.AssertError(t, err, "check should have failed")
+	test.AssertContains(t, err.Error(), `getting tree head of CT log "A": connection refused`)
+	test.AssertEquals(t, len(problems), 0)
+
+	// The tree head is only fetched once.
+	fakeA := &fakeCTLog{leaves: [][]byte{leafHash[:]}, timestamp: sthTime}
+	c = newCTChecker(map[string]*fakeCTLog{"A": fakeA, "B": {leaves: [][]byte{leafHash[:]}, timestamp: sthTime}})
+	_, err = c.Check(context.Background(), leafDER)
+	test.AssertNotError(t, err, "check failed")
+	fakeA.sthErr = errors.New("tree head fetched twice")
+	problems, err = c.Check(context.Background(), leafDER)
+	test.AssertNotError(t, err, "check failed")
+	test.AssertEquals(t, len(problems), 0)
+
+	// Until it's refreshed.
+	c.Refresh()
+	_, err = c.Check(context.Background(), leafDER)
+	test.AssertError(t, err, "check should have fetched the tree head again")
+	test.AssertContains(t, err.Error(), "tree head fetched twice")
+
+	// Certificates without SCTs have nothing to check.
+	problems, err = c.Check(context.Background(), issuerDER)
+	test.AssertNotError(t, err, "check failed")
+	test.AssertEquals(t, len(problems), 0)
+}
@@ -36,6 +36,11 @@ type Execer interface {
 	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
 }
 
+// A Queryer is anything that provides a `QueryContext` function.
+type Queryer interface {
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+}
+
 // SelectExecer offers a subset of borp.SqlExecutor's methods: Select and
 // ExecContext.
 type SelectExecer interface {
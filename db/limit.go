@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+// QueryLimiter bounds how long individual queries may run, for instance to
+// enforce a latency budget on the RPC which issued them.
+//
+// Queries made through QueryContext and QueryRowContext are not limited: their
+// results are read after the call returns, at a pace set by the caller.
+type QueryLimiter interface {
+	// Limit is called before a query against the named table, which may be
+	// empty if the table couldn't be determined. It returns the context to run
+	// the query under and a function which must be called with the query's
+	// error, if any, once it returns. That function returns the error which
+	// should be reported to the caller in its place.
+	Limit(ctx context.Context, table string) (context.Context, func(error) error)
+}
+
+func passthroughErr(err error) error {
+	return err
+}
+
+func (we WrappedExecutor) limit(ctx context.Context, table string) (context.Context, func(error) error) {
+	if we.limiter == nil {
+		return ctx, passthroughErr
+	}
+	return we.limiter.Limit(ctx, table)
+}
+
+// tableForHolder returns the name of the table which the first of the given
+// holders is mapped to, or the empty string if it isn't mapped.
+func (we WrappedExecutor) tableForHolder(list ...interface{}) string {
+	if we.dbMap == nil || len(list) == 0 {
+		return ""
+	}
+	t := reflect.TypeOf(list[0])
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil {
+		return ""
+	}
+	table, err := we.dbMap.TableFor(t, false)
+	if err != nil {
+		return ""
+	}
+	return table.TableName
+}
+
+// tableForBudget returns the name of the first table named by the query, with
+// any quoting removed, or the empty string if it can't be determined.
+func tableForBudget(query string) string {
+	table := tableFromQuery(query)
+	table, _, _ = strings.Cut(strings.TrimSpace(table), ",")
+	table, _, _ = strings.Cut(strings.TrimSpace(table), " ")
+	return strings.Trim(table, "`")
+}
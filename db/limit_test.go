@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestTableForBudget(t *testing.T) {
+	testCases := []struct {
+		query string
+		table string
+	}{
+		{"SELECT id FROM certificateStatus WHERE serial = ?", "certificateStatus"},
+		{"SELECT id FROM `orders` WHERE id = ?", "orders"},
+		{"SELECT a.id FROM authz2 AS a WHERE a.id = ?", "authz2"},
+		{"SELECT 1 FROM certificates, fqdnSets WHERE 1", "certificates"},
+		{"INSERT INTO orderToAuthz2 (orderID, authzID) VALUES (?, ?)", "orderToAuthz2"},
+		{"UPDATE registrations SET status = ?", "registrations"},
+		{"SHOW TABLES", ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			test.AssertEquals(t, tableForBudget(tc.query), tc.table)
+		})
+	}
+}
+
+type recordingLimiter struct {
+	tables []string
+	errs   []error
+}
+
+var errLimited = errors.New("limited")
+
+func (rl *recordingLimiter) Limit(ctx context.Context, table string) (context.Context, func(error) error) {
+	rl.tables = append(rl.tables, table)
+	return ctx, func(err error) error {
+		rl.errs = append(rl.errs, err)
+		return errLimited
+	}
+}
+
+func TestWrappedExecutorLimit(t *testing.T) {
+	rl := &recordingLimiter{}
+	we := WrappedExecutor{sqlExecutor: MockSqlExecutor{}, limiter: rl}
+
+	err := we.SelectOne(context.Background(), nil, "SELECT id FROM orders WHERE id = ?", 1)
+	test.AssertErrorIs(t, err, errLimited)
+	_, err = we.ExecContext(context.Background(), "UPDATE registrations SET status = ?", "valid")
+	test.AssertErrorIs(t, err, errLimited)
+
+	test.AssertDeepEquals(t, rl.tables, []string{"orders", "registrations"})
+	test.AssertEquals(t, len(rl.errs), 2)
+	test.AssertEquals(t, rl.errs[0].Error(), "unimplemented")
+
+	// Without a limiter, errors are passed through.
+	err = WrappedExecutor{sqlExecutor: MockSqlExecutor{}}.SelectOne(context.Background(), nil, "SELECT id FROM orders WHERE id = ?", 1)
+	test.AssertNotNil(t, err, "expected an error")
+	test.Assert(t, !errors.Is(err, errLimited), "unlimited executor should not use a limiter")
+}
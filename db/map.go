@@ -58,62 +58,97 @@ func IsDuplicate(err error) bool {
 	return errors.As(err, &dbErr) && dbErr.Number == 1062
 }
 
+// IsDeadlock is a utility function for determining if an error wraps MySQL's
+// Error 1213: Deadlock found when trying to get lock, or Error 1205: Lock wait
+// timeout exceeded. Either may be returned when concurrent transactions
+// contend for the same rows, and the transaction which receives it may succeed
+// if it's retried from the start.
+func IsDeadlock(err error) bool {
+	var dbErr *mysql.MySQLError
+	return errors.As(err, &dbErr) && (dbErr.Number == 1213 || dbErr.Number == 1205)
+}
+
 // WrappedMap wraps a *borp.DbMap such that its major functions wrap error
 // results in ErrDatabaseOp instances before returning them to the caller.
 type WrappedMap struct {
-	dbMap *borp.DbMap
+	dbMap   *borp.DbMap
+	limiter QueryLimiter
 }
 
 func NewWrappedMap(dbMap *borp.DbMap) *WrappedMap {
 	return &WrappedMap{dbMap: dbMap}
 }
 
+// WithQueryLimiter returns a copy of the WrappedMap which runs every query,
+// including those in transactions it begins, under the given QueryLimiter.
+func (m *WrappedMap) WithQueryLimiter(limiter QueryLimiter) *WrappedMap {
+	return &WrappedMap{dbMap: m.dbMap, limiter: limiter}
+}
+
+func (m *WrappedMap) executor() WrappedExecutor {
+	return WrappedExecutor{sqlExecutor: m.dbMap, dbMap: m.dbMap, limiter: m.limiter}
+}
+
 func (m *WrappedMap) TableFor(t reflect.Type, checkPK bool) (*borp.TableMap, error) {
 	return m.dbMap.TableFor(t, checkPK)
 }
 
 func (m *WrappedMap) Get(ctx context.Context, holder interface{}, keys ...interface{}) (interface{}, error) {
-	return WrappedExecutor{sqlExecutor: m.dbMap}.Get(ctx, holder, keys...)
+	return m.executor().Get(ctx, holder, keys...)
 }
 
 func (m *WrappedMap) Insert(ctx context.Context, list ...interface{}) error {
-	return WrappedExecutor{sqlExecutor: m.dbMap}.Insert(ctx, list...)
+	return m.executor().Insert(ctx, list...)
 }
 
 func (m *WrappedMap) Update(ctx context.Context, list ...interface{}) (int64, error) {
-	return WrappedExecutor{sqlExecutor: m.dbMap}.Update(ctx, list...)
+	return m.executor().Update(ctx, list...)
 }
 
 func (m *WrappedMap) Delete(ctx context.Context, list ...interface{}) (int64, error) {
-	return WrappedExecutor{sqlExecutor: m.dbMap}.Delete(ctx, list...)
+	return m.executor().Delete(ctx, list...)
 }
 
 func (m *WrappedMap) Select(ctx context.Context, holder interface{}, query string, args ...interface{}) ([]interface{}, error) {
-	return WrappedExecutor{sqlExecutor: m.dbMap}.Select(ctx, holder, query, args...)
+	return m.executor().Select(ctx, holder, query, args...)
 }
 
 func (m *WrappedMap) SelectOne(ctx context.Context, holder interface{}, query string, args ...interface{}) error {
-	return WrappedExecutor{sqlExecutor: m.dbMap}.SelectOne(ctx, holder, query, args...)
+	return m.executor().SelectOne(ctx, holder, query, args...)
 }
 
 func (m *WrappedMap) SelectNullInt(ctx context.Context, query string, args ...interface{}) (sql.NullInt64, error) {
-	return WrappedExecutor{sqlExecutor: m.dbMap}.SelectNullInt(ctx, query, args...)
+	return m.executor().SelectNullInt(ctx, query, args...)
 }
 
 func (m *WrappedMap) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
-	return WrappedExecutor{sqlExecutor: m.dbMap}.QueryContext(ctx, query, args...)
+	return m.executor().QueryContext(ctx, query, args...)
 }
 
 func (m *WrappedMap) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
-	return WrappedExecutor{sqlExecutor: m.dbMap}.QueryRowContext(ctx, query, args...)
+	return m.executor().QueryRowContext(ctx, query, args...)
 }
 
 func (m *WrappedMap) SelectStr(ctx context.Context, query string, args ...interface{}) (string, error) {
-	return WrappedExecutor{sqlExecutor: m.dbMap}.SelectStr(ctx, query, args...)
+	return m.executor().SelectStr(ctx, query, args...)
 }
 
 func (m *WrappedMap) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
-	return WrappedExecutor{sqlExecutor: m.dbMap}.ExecContext(ctx, query, args...)
+	return m.executor().ExecContext(ctx, query, args...)
+}
+
+// Conn returns a single connection from the pool, for statements which must
+// run in the same session, such as GET_LOCK and RELEASE_LOCK. The caller must
+// close it to return it to the pool.
+func (m *WrappedMap) Conn(ctx context.Context) (*sql.Conn, error) {
+	conn, err := m.dbMap.Db.Conn(ctx)
+	if err != nil {
+		return nil, ErrDatabaseOp{
+			Op:  "get connection",
+			Err: err,
+		}
+	}
+	return conn, nil
 }
 
 func (m *WrappedMap) BeginTx(ctx context.Context) (Transaction, error) {
@@ -126,6 +161,8 @@ func (m *WrappedMap) BeginTx(ctx context.Context) (Transaction, error) {
 	}
 	return WrappedTransaction{
 		transaction: tx,
+		dbMap:       m.dbMap,
+		limiter:     m.limiter,
 	}, err
 }
 
@@ -146,6 +183,12 @@ func (m *WrappedMap) ColumnsForModel(model interface{}) ([]string, error) {
 // caller.
 type WrappedTransaction struct {
 	transaction *borp.Transaction
+	dbMap       *borp.DbMap
+	limiter     QueryLimiter
+}
+
+func (tx WrappedTransaction) executor() WrappedExecutor {
+	return WrappedExecutor{sqlExecutor: tx.transaction, dbMap: tx.dbMap, limiter: tx.limiter}
 }
 
 func (tx WrappedTransaction) Commit() error {
@@ -157,35 +200,35 @@ func (tx WrappedTransaction) Rollback() error {
 }
 
 func (tx WrappedTransaction) Get(ctx context.Context, holder interface{}, keys ...interface{}) (interface{}, error) {
-	return (WrappedExecutor{sqlExecutor: tx.transaction}).Get(ctx, holder, keys...)
+	return tx.executor().Get(ctx, holder, keys...)
 }
 
 func (tx WrappedTransaction) Insert(ctx context.Context, list ...interface{}) error {
-	return (WrappedExecutor{sqlExecutor: tx.transaction}).Insert(ctx, list...)
+	return tx.executor().Insert(ctx, list...)
 }
 
 func (tx WrappedTransaction) Update(ctx context.Context, list ...interface{}) (int64, error) {
-	return (WrappedExecutor{sqlExecutor: tx.transaction}).Update(ctx, list...)
+	return tx.executor().Update(ctx, list...)
 }
 
 func (tx WrappedTransaction) Delete(ctx context.Context, list ...interface{}) (int64, error) {
-	return (WrappedExecutor{sqlExecutor: tx.transaction}).Delete(ctx, list...)
+	return tx.executor().Delete(ctx, list...)
 }
 
 func (tx WrappedTransaction) Select(ctx context.Context, holder interface{}, query string, args ...interface{}) ([]interface{}, error) {
-	return (WrappedExecutor{sqlExecutor: tx.transaction}).Select(ctx, holder, query, args...)
+	return tx.executor().Select(ctx, holder, query, args...)
 }
 
 func (tx WrappedTransaction) SelectOne(ctx context.Context, holder interface{}, query string, args ...interface{}) error {
-	return (WrappedExecutor{sqlExecutor: tx.transaction}).SelectOne(ctx, holder, query, args...)
+	return tx.executor().SelectOne(ctx, holder, query, args...)
 }
 
 func (tx WrappedTransaction) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
-	return (WrappedExecutor{sqlExecutor: tx.transaction}).QueryContext(ctx, query, args...)
+	return tx.executor().QueryContext(ctx, query, args...)
 }
 
 func (tx WrappedTransaction) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
-	return (WrappedExecutor{sqlExecutor: tx.transaction}).ExecContext(ctx, query, args...)
+	return tx.executor().ExecContext(ctx, query, args...)
 }
 
 // WrappedExecutor wraps a borp.SqlExecutor such that its major functions
@@ -193,6 +236,11 @@ func (tx WrappedTransaction) ExecContext(ctx context.Context, query string, args
 // caller.
 type WrappedExecutor struct {
 	sqlExecutor borp.SqlExecutor
+	// dbMap, if set, is used to find the table name of the holders passed to
+	// Get, Insert, Update, and Delete.
+	dbMap *borp.DbMap
+	// limiter, if set, bounds the duration of each query.
+	limiter QueryLimiter
 }
 
 func errForOp(operation string, err error, list []interface{}) ErrDatabaseOp {
@@ -229,7 +277,9 @@ func errForQuery(query, operation string, err error, list []interface{}) ErrData
 }
 
 func (we WrappedExecutor) Get(ctx context.Context, holder interface{}, keys ...interface{}) (interface{}, error) {
+	ctx, done := we.limit(ctx, we.tableForHolder(holder))
 	res, err := we.sqlExecutor.Get(ctx, holder, keys...)
+	err = done(err)
 	if err != nil {
 		return res, errForOp("get", err, []interface{}{holder})
 	}
@@ -237,7 +287,9 @@ func (we WrappedExecutor) Get(ctx context.Context, holder interface{}, keys ...i
 }
 
 func (we WrappedExecutor) Insert(ctx context.Context, list ...interface{}) error {
+	ctx, done := we.limit(ctx, we.tableForHolder(list...))
 	err := we.sqlExecutor.Insert(ctx, list...)
+	err = done(err)
 	if err != nil {
 		return errForOp("insert", err, list)
 	}
@@ -245,7 +297,9 @@ func (we WrappedExecutor) Insert(ctx context.Context, list ...interface{}) error
 }
 
 func (we WrappedExecutor) Update(ctx context.Context, list ...interface{}) (int64, error) {
+	ctx, done := we.limit(ctx, we.tableForHolder(list...))
 	updatedRows, err := we.sqlExecutor.Update(ctx, list...)
+	err = done(err)
 	if err != nil {
 		return updatedRows, errForOp("update", err, list)
 	}
@@ -253,7 +307,9 @@ func (we WrappedExecutor) Update(ctx context.Context, list ...interface{}) (int6
 }
 
 func (we WrappedExecutor) Delete(ctx context.Context, list ...interface{}) (int64, error) {
+	ctx, done := we.limit(ctx, we.tableForHolder(list...))
 	deletedRows, err := we.sqlExecutor.Delete(ctx, list...)
+	err = done(err)
 	if err != nil {
 		return deletedRows, errForOp("delete", err, list)
 	}
@@ -261,7 +317,9 @@ func (we WrappedExecutor) Delete(ctx context.Context, list ...interface{}) (int6
 }
 
 func (we WrappedExecutor) Select(ctx context.Context, holder interface{}, query string, args ...interface{}) ([]interface{}, error) {
+	ctx, done := we.limit(ctx, tableForBudget(query))
 	result, err := we.sqlExecutor.Select(ctx, holder, query, args...)
+	err = done(err)
 	if err != nil {
 		return result, errForQuery(query, "select", err, []interface{}{holder})
 	}
@@ -269,7 +327,9 @@ func (we WrappedExecutor) Select(ctx context.Context, holder interface{}, query
 }
 
 func (we WrappedExecutor) SelectOne(ctx context.Context, holder interface{}, query string, args ...interface{}) error {
+	ctx, done := we.limit(ctx, tableForBudget(query))
 	err := we.sqlExecutor.SelectOne(ctx, holder, query, args...)
+	err = done(err)
 	if err != nil {
 		return errForQuery(query, "select one", err, []interface{}{holder})
 	}
@@ -277,7 +337,9 @@ func (we WrappedExecutor) SelectOne(ctx context.Context, holder interface{}, que
 }
 
 func (we WrappedExecutor) SelectNullInt(ctx context.Context, query string, args ...interface{}) (sql.NullInt64, error) {
+	ctx, done := we.limit(ctx, tableForBudget(query))
 	rows, err := we.sqlExecutor.SelectNullInt(ctx, query, args...)
+	err = done(err)
 	if err != nil {
 		return sql.NullInt64{}, errForQuery(query, "select", err, nil)
 	}
@@ -291,7 +353,9 @@ func (we WrappedExecutor) QueryRowContext(ctx context.Context, query string, arg
 }
 
 func (we WrappedExecutor) SelectStr(ctx context.Context, query string, args ...interface{}) (string, error) {
+	ctx, done := we.limit(ctx, tableForBudget(query))
 	str, err := we.sqlExecutor.SelectStr(ctx, query, args...)
+	err = done(err)
 	if err != nil {
 		return "", errForQuery(query, "select", err, nil)
 	}
@@ -343,7 +407,9 @@ func tableFromQuery(query string) string {
 }
 
 func (we WrappedExecutor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, done := we.limit(ctx, tableForBudget(query))
 	res, err := we.sqlExecutor.ExecContext(ctx, query, args...)
+	err = done(err)
 	if err != nil {
 		return res, errForQuery(query, "exec", err, args)
 	}
@@ -115,6 +115,41 @@ func TestIsDuplicate(t *testing.T) {
 	}
 }
 
+func TestIsDeadlock(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            error
+		expectDeadlock bool
+	}{
+		{
+			name:           "deadlock",
+			err:            ErrDatabaseOp{Op: "test", Table: "testTable", Err: &mysql.MySQLError{Number: 1213}},
+			expectDeadlock: true,
+		},
+		{
+			name:           "lock wait timeout",
+			err:            fmt.Errorf("some wrapper around %w", &mysql.MySQLError{Number: 1205}),
+			expectDeadlock: true,
+		},
+		{
+			name:           "duplicate",
+			err:            &mysql.MySQLError{Number: 1062},
+			expectDeadlock: false,
+		},
+		{
+			name:           "not a MySQL error",
+			err:            errors.New("deadlock"),
+			expectDeadlock: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			test.AssertEquals(t, IsDeadlock(tc.err), tc.expectDeadlock)
+		})
+	}
+}
+
 func TestTableFromQuery(t *testing.T) {
 	// A sample of example queries logged by the SA during Boulder
 	// unit/integration tests.
@@ -57,8 +57,8 @@ func (mi *MultiInserter) Add(row []interface{}) error {
 }
 
 // query returns the formatted query string, and the slice of arguments for
-// for borp to use in place of the query's question marks. Currently only
-// used by .Insert(), below.
+// for borp to use in place of the query's question marks. Used by .Insert()
+// and .InsertReturning(), below.
 func (mi *MultiInserter) query() (string, []interface{}) {
 	var questionsBuf strings.Builder
 	var queryArgs []interface{}
@@ -105,3 +105,48 @@ func (mi *MultiInserter) Insert(ctx context.Context, db Execer) error {
 
 	return nil
 }
+
+// InsertReturning inserts all the collected rows into the database represented
+// by `queryer`, like Insert, and returns the value of the given integer column,
+// typically an auto-increment ID, for each row in the order the rows were
+// added. It uses MariaDB's INSERT ... RETURNING, so that the rows and their IDs
+// take a single round trip.
+// Safety: `column` must be known at compile time. It must not be
+// user-controlled.
+func (mi *MultiInserter) InsertReturning(ctx context.Context, db Queryer, column string) ([]int64, error) {
+	if len(mi.values) == 0 {
+		return nil, nil
+	}
+
+	err := validMariaDBUnquotedIdentifier(column)
+	if err != nil {
+		return nil, err
+	}
+
+	query, queryArgs := mi.query()
+	// Safety: `column` was verified above to be a valid unquoted identifier.
+	rows, err := db.QueryContext(ctx, query+" RETURNING "+column, queryArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]int64, 0, len(mi.values))
+	for rows.Next() {
+		var id int64
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) != len(mi.values) {
+		return nil, fmt.Errorf("unexpected number of rows inserted: %d != %d", len(ids), len(mi.values))
+	}
+
+	return ids, nil
+}
@@ -1,6 +1,9 @@
 package db
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"testing"
 
 	"github.com/letsencrypt/boulder/test"
@@ -63,3 +66,40 @@ func TestMultiQuery(t *testing.T) {
 	test.AssertEquals(t, query, "INSERT INTO table (a,b,c) VALUES (?,?,?),(?,?,?)")
 	test.AssertDeepEquals(t, queryArgs, []interface{}{"one", "two", "three", "egy", "kettö", "három"})
 }
+
+type recordingQueryer struct {
+	query string
+	args  []interface{}
+}
+
+func (q *recordingQueryer) QueryContext(_ context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	q.query = query
+	q.args = args
+	return nil, errors.New("unimplemented")
+}
+
+func TestMultiInsertReturning(t *testing.T) {
+	mi, err := NewMultiInserter("table", []string{"a", "b"})
+	test.AssertNotError(t, err, "Failed to create test MultiInserter")
+
+	// Nothing is inserted, or queried, without any rows.
+	q := &recordingQueryer{}
+	ids, err := mi.InsertReturning(context.Background(), q, "id")
+	test.AssertNotError(t, err, "Inserting no rows should not fail")
+	test.AssertEquals(t, len(ids), 0)
+	test.AssertEquals(t, q.query, "")
+
+	err = mi.Add([]interface{}{"one", "two"})
+	test.AssertNotError(t, err, "Failed to insert test row")
+	err = mi.Add([]interface{}{"egy", "kettö"})
+	test.AssertNotError(t, err, "Failed to insert test row")
+
+	_, err = mi.InsertReturning(context.Background(), q, "foo\"bar")
+	test.AssertError(t, err, "expected error for invalid returning column name")
+	test.AssertEquals(t, q.query, "")
+
+	_, err = mi.InsertReturning(context.Background(), q, "id")
+	test.AssertError(t, err, "expected error from queryer")
+	test.AssertEquals(t, q.query, "INSERT INTO table (a,b) VALUES (?,?),(?,?) RETURNING id")
+	test.AssertDeepEquals(t, q.args, []interface{}{"one", "two", "egy", "kettö"})
+}
@@ -0,0 +1,66 @@
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/url"
+	"strconv"
+)
+
+// SMTPSender is a ConfirmationSender which submits confirmation emails to an
+// SMTP server.
+type SMTPSender struct {
+	addr            string
+	auth            smtp.Auth
+	from            string
+	confirmationURL string
+}
+
+var _ ConfirmationSender = (*SMTPSender)(nil)
+
+// NewSMTPSender returns an SMTPSender which submits mail to the given server as
+// from, authenticating with username and password if username is non-empty.
+// The link in each email is confirmationURL with the token appended as the
+// "token" query parameter.
+func NewSMTPSender(server string, port int, username, password, from, confirmationURL string) (*SMTPSender, error) {
+	_, err := url.Parse(confirmationURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing confirmation URL: %w", err)
+	}
+
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, server)
+	}
+	return &SMTPSender{
+		addr:            net.JoinHostPort(server, strconv.Itoa(port)),
+		auth:            auth,
+		from:            from,
+		confirmationURL: confirmationURL,
+	}, nil
+}
+
+// SendConfirmation implements ConfirmationSender.
+func (s *SMTPSender) SendConfirmation(to string, token string) error {
+	link, err := url.Parse(s.confirmationURL)
+	if err != nil {
+		return err
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: Confirm your ACME account contact\r\n")
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=UTF-8\r\n")
+	fmt.Fprintf(&msg, "\r\n")
+	fmt.Fprintf(&msg, "This address was provided as the contact for an ACME account. To confirm\r\n")
+	fmt.Fprintf(&msg, "that you can receive mail here, visit:\r\n\r\n%s\r\n\r\n", link)
+	fmt.Fprintf(&msg, "If you didn't create this account, you can ignore this email.\r\n")
+
+	return smtp.SendMail(s.addr, s.auth, s.from, []string{to}, msg.Bytes())
+}
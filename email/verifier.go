@@ -0,0 +1,329 @@
+package email
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/letsencrypt/boulder/core"
+	emailpb "github.com/letsencrypt/boulder/email/proto"
+	berrors "github.com/letsencrypt/boulder/errors"
+	blog "github.com/letsencrypt/boulder/log"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+)
+
+// verifyQueueCap limits the verification queue size to prevent unbounded
+// growth.
+const verifyQueueCap = 10000
+
+var ErrVerifyQueueFull = errors.New("contact-verifier queue is full")
+
+// ContactHash returns the SHA-256 hash of the given email address, which is
+// how the SA identifies a contact's verification state.
+func ContactHash(email string) []byte {
+	sum := sha256.Sum256([]byte(email))
+	return sum[:]
+}
+
+// MXResolver looks up the records which determine whether a domain can receive
+// mail. It is satisfied by *net.Resolver.
+type MXResolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// ConfirmationSender sends a confirmation email, containing the given token, to
+// a contact address.
+type ConfirmationSender interface {
+	SendConfirmation(to string, token string) error
+}
+
+// VerifierImpl implements the ContactVerifier gRPC service. Contacts are
+// verified asynchronously: VerifyContacts only enqueues them.
+type VerifierImpl struct {
+	emailpb.UnsafeContactVerifierServer
+
+	queue   chan string
+	drainWG sync.WaitGroup
+
+	sa            sapb.StorageAuthorityClient
+	resolver      MXResolver
+	dnsTimeout    time.Duration
+	sender        ConfirmationSender
+	tokenKey      []byte
+	tokenLifetime time.Duration
+	workers       int
+	clk           clock.Clock
+	log           blog.Logger
+
+	results *prometheus.CounterVec
+}
+
+var _ emailpb.ContactVerifierServer = (*VerifierImpl)(nil)
+
+// NewVerifierImpl returns a VerifierImpl which records the verification state
+// of each contact in the SA. If sender is nil, contacts whose domains can
+// receive mail are immediately considered valid. Otherwise, they're sent a
+// confirmation email, and are considered pending until the token it contains is
+// passed to ConfirmContact, which must happen within tokenLifetime.
+func NewVerifierImpl(
+	sa sapb.StorageAuthorityClient,
+	resolver MXResolver,
+	dnsTimeout time.Duration,
+	sender ConfirmationSender,
+	tokenKey []byte,
+	tokenLifetime time.Duration,
+	workers int,
+	clk clock.Clock,
+	scope prometheus.Registerer,
+	logger blog.Logger,
+) *VerifierImpl {
+	results := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "contact_verifier_results",
+		Help: "Number of contacts verified, by result=[valid|invalid|pending|confirmed|error]",
+	}, []string{"result"})
+	scope.MustRegister(results)
+
+	impl := &VerifierImpl{
+		queue:         make(chan string, verifyQueueCap),
+		sa:            sa,
+		resolver:      resolver,
+		dnsTimeout:    dnsTimeout,
+		sender:        sender,
+		tokenKey:      tokenKey,
+		tokenLifetime: tokenLifetime,
+		workers:       workers,
+		clk:           clk,
+		log:           logger,
+		results:       results,
+	}
+
+	queueGauge := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "contact_verifier_queue_length",
+		Help: "Current length of the contact verification queue",
+	}, func() float64 {
+		return float64(len(impl.queue))
+	})
+	scope.MustRegister(queueGauge)
+
+	return impl
+}
+
+// VerifyContacts enqueues the provided email addresses for verification. If the
+// queue cannot accommodate all of them, an ErrVerifyQueueFull is returned and
+// none are enqueued.
+func (impl *VerifierImpl) VerifyContacts(ctx context.Context, req *emailpb.VerifyContactsRequest) (*emptypb.Empty, error) {
+	if core.IsAnyNilOrZero(req, req.Emails) {
+		return nil, berrors.InternalServerError("Incomplete gRPC request message")
+	}
+	if cap(impl.queue)-len(impl.queue) < len(req.Emails) {
+		return nil, ErrVerifyQueueFull
+	}
+	for _, email := range req.Emails {
+		select {
+		case impl.queue <- email:
+		default:
+			// Another caller filled the queue in the meantime.
+			return nil, ErrVerifyQueueFull
+		}
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// Start begins asynchronous processing of the verification queue. When the
+// parent daemonCtx is cancelled the queue will be drained and the workers will
+// exit.
+func (impl *VerifierImpl) Start(daemonCtx context.Context) {
+	worker := func() {
+		defer impl.drainWG.Done()
+		for {
+			select {
+			case email := <-impl.queue:
+				impl.verify(email)
+			case <-daemonCtx.Done():
+				// Drain whatever remains before exiting.
+				for {
+					select {
+					case email := <-impl.queue:
+						impl.verify(email)
+					default:
+						return
+					}
+				}
+			}
+		}
+	}
+
+	for range impl.workers {
+		impl.drainWG.Add(1)
+		go worker()
+	}
+}
+
+// Drain blocks until all workers have finished processing the verification
+// queue.
+func (impl *VerifierImpl) Drain() {
+	impl.drainWG.Wait()
+}
+
+// verify checks a single contact and records the result in the SA. Temporary
+// failures are logged and not recorded, so that a later attempt may succeed.
+func (impl *VerifierImpl) verify(email string) {
+	ctx, cancel := context.WithTimeout(context.Background(), impl.dnsTimeout)
+	defer cancel()
+
+	status, reason, err := impl.check(ctx, email)
+	if err != nil {
+		impl.results.WithLabelValues("error").Inc()
+		impl.log.Warningf("Verifying contact: %s", err)
+		return
+	}
+
+	if status == core.StatusValid && impl.sender != nil {
+		err = impl.sender.SendConfirmation(email, impl.newToken(email))
+		if err != nil {
+			impl.results.WithLabelValues("error").Inc()
+			impl.log.Errf("Sending contact confirmation: %s", err)
+			return
+		}
+		status, reason = core.StatusPending, "confirmation email sent"
+	}
+
+	err = impl.record(ctx, email, status, reason)
+	if err != nil {
+		impl.results.WithLabelValues("error").Inc()
+		impl.log.Errf("Recording contact verification: %s", err)
+		return
+	}
+	impl.results.WithLabelValues(string(status)).Inc()
+}
+
+// check determines whether the domain of the given address can receive mail.
+// Per RFC 5321, Section 5.1, a domain with no MX records may still receive mail
+// at its address records, while a domain with a "null MX" record (RFC 7505)
+// explicitly receives none.
+func (impl *VerifierImpl) check(ctx context.Context, email string) (core.AcmeStatus, string, error) {
+	at := strings.LastIndex(email, "@")
+	if at < 0 || strings.ContainsAny(email, "\r\n") {
+		return core.StatusInvalid, "malformed address", nil
+	}
+	domain := strings.ToLower(email[at+1:])
+
+	mxs, err := impl.resolver.LookupMX(ctx, domain)
+	if err == nil {
+		if len(mxs) == 1 && (mxs[0].Host == "." || mxs[0].Host == "") {
+			return core.StatusInvalid, fmt.Sprintf("%s does not accept mail", domain), nil
+		}
+		if len(mxs) > 0 {
+			return core.StatusValid, fmt.Sprintf("found MX records for %s", domain), nil
+		}
+	}
+	var dnsErr *net.DNSError
+	if err != nil && (!errors.As(err, &dnsErr) || !dnsErr.IsNotFound) {
+		return "", "", fmt.Errorf("looking up MX records for %s: %w", domain, err)
+	}
+
+	addrs, err := impl.resolver.LookupHost(ctx, domain)
+	if err != nil {
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return core.StatusInvalid, fmt.Sprintf("no MX or address records for %s", domain), nil
+		}
+		return "", "", fmt.Errorf("looking up address records for %s: %w", domain, err)
+	}
+	if len(addrs) == 0 {
+		return core.StatusInvalid, fmt.Sprintf("no MX or address records for %s", domain), nil
+	}
+	return core.StatusValid, fmt.Sprintf("found address records for %s", domain), nil
+}
+
+func (impl *VerifierImpl) record(ctx context.Context, email string, status core.AcmeStatus, reason string) error {
+	_, err := impl.sa.SetContactVerification(ctx, &sapb.ContactVerification{
+		ContactHash: ContactHash(email),
+		Status:      string(status),
+		Reason:      reason,
+	})
+	return err
+}
+
+// newToken returns a token which confirms the given address until
+// tokenLifetime has passed. Tokens are self-authenticating, so that they need
+// not be stored.
+func (impl *VerifierImpl) newToken(email string) string {
+	expires := impl.clk.Now().Add(impl.tokenLifetime).Unix()
+	payload := []byte(email + "\x00" + strconv.FormatInt(expires, 10))
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(impl.mac(payload))
+}
+
+func (impl *VerifierImpl) mac(payload []byte) []byte {
+	h := hmac.New(sha256.New, impl.tokenKey)
+	h.Write(payload)
+	return h.Sum(nil)
+}
+
+// parseToken returns the address confirmed by the given token, or an error if
+// the token is malformed, forged, or expired.
+func (impl *VerifierImpl) parseToken(token string) (string, error) {
+	encodedPayload, encodedMAC, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", berrors.MalformedError("malformed confirmation token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return "", berrors.MalformedError("malformed confirmation token")
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(encodedMAC)
+	if err != nil {
+		return "", berrors.MalformedError("malformed confirmation token")
+	}
+	if !hmac.Equal(mac, impl.mac(payload)) {
+		return "", berrors.UnauthorizedError("invalid confirmation token")
+	}
+
+	email, expiresStr, ok := strings.Cut(string(payload), "\x00")
+	if !ok {
+		return "", berrors.MalformedError("malformed confirmation token")
+	}
+	expires, err := strconv.ParseInt(expiresStr, 10, 64)
+	if err != nil {
+		return "", berrors.MalformedError("malformed confirmation token")
+	}
+	if impl.clk.Now().After(time.Unix(expires, 0)) {
+		return "", berrors.UnauthorizedError("expired confirmation token")
+	}
+	return email, nil
+}
+
+// ConfirmContact marks the contact to which the given token was sent as valid.
+// It is intended to be called by a frontend when the recipient follows the
+// link in their confirmation email.
+func (impl *VerifierImpl) ConfirmContact(ctx context.Context, req *emailpb.ConfirmContactRequest) (*emptypb.Empty, error) {
+	if core.IsAnyNilOrZero(req, req.Token) {
+		return nil, berrors.InternalServerError("Incomplete gRPC request message")
+	}
+	if impl.sender == nil {
+		return nil, berrors.InternalServerError("contact confirmation is not enabled")
+	}
+
+	email, err := impl.parseToken(req.Token)
+	if err != nil {
+		return nil, err
+	}
+	err = impl.record(ctx, email, core.StatusValid, "confirmed by recipient")
+	if err != nil {
+		return nil, err
+	}
+	impl.results.WithLabelValues("confirmed").Inc()
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,196 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/letsencrypt/boulder/core"
+	emailpb "github.com/letsencrypt/boulder/email/proto"
+	berrors "github.com/letsencrypt/boulder/errors"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// mockMXResolver answers MX and address queries for a handful of domains.
+type mockMXResolver struct{}
+
+func (mockMXResolver) LookupMX(_ context.Context, name string) ([]*net.MX, error) {
+	switch name {
+	case "example.com":
+		return []*net.MX{{Host: "mail.example.com.", Pref: 10}}, nil
+	case "nullmx.com":
+		return []*net.MX{{Host: ".", Pref: 0}}, nil
+	case "servfail.com":
+		return nil, &net.DNSError{Err: "server misbehaving", Name: name, IsTemporary: true}
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (mockMXResolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	switch host {
+	case "implicit.com":
+		return []string{"192.0.2.1"}, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+// mockVerificationSA records the contact verifications it's given, keyed by
+// contact hash.
+type mockVerificationSA struct {
+	sapb.StorageAuthorityClient
+
+	sync.Mutex
+	verifications map[string]*sapb.ContactVerification
+}
+
+func (sa *mockVerificationSA) SetContactVerification(_ context.Context, req *sapb.ContactVerification, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	sa.Lock()
+	defer sa.Unlock()
+	sa.verifications[string(req.ContactHash)] = req
+	return &emptypb.Empty{}, nil
+}
+
+func (sa *mockVerificationSA) get(email string) *sapb.ContactVerification {
+	sa.Lock()
+	defer sa.Unlock()
+	return sa.verifications[string(ContactHash(email))]
+}
+
+// mockConfirmationSender records the token most recently sent to each address.
+type mockConfirmationSender struct {
+	sync.Mutex
+	tokens map[string]string
+	err    error
+}
+
+func (s *mockConfirmationSender) SendConfirmation(to string, token string) error {
+	s.Lock()
+	defer s.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.tokens[to] = token
+	return nil
+}
+
+func setupVerifier(sender ConfirmationSender) (*VerifierImpl, *mockVerificationSA, clock.FakeClock) {
+	sa := &mockVerificationSA{verifications: make(map[string]*sapb.ContactVerification)}
+	clk := clock.NewFake()
+	verifier := NewVerifierImpl(sa, mockMXResolver{}, time.Second, sender, []byte("secret"), time.Hour, 2, clk, metrics.NoopRegisterer, blog.NewMock())
+	return verifier, sa, clk
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		email      string
+		wantStatus core.AcmeStatus
+	}{
+		{"a@example.com", core.StatusValid},
+		{"a@implicit.com", core.StatusValid},
+		{"a@nullmx.com", core.StatusInvalid},
+		{"a@nonexistent.com", core.StatusInvalid},
+		{"nodomain", core.StatusInvalid},
+	}
+
+	verifier, sa, _ := setupVerifier(nil)
+	for _, tc := range testCases {
+		t.Run(tc.email, func(t *testing.T) {
+			verifier.verify(tc.email)
+			got := sa.get(tc.email)
+			test.AssertNotNil(t, got, "verification should have been recorded")
+			test.AssertEquals(t, core.AcmeStatus(got.Status), tc.wantStatus)
+		})
+	}
+}
+
+func TestVerifyTemporaryFailure(t *testing.T) {
+	t.Parallel()
+
+	verifier, sa, _ := setupVerifier(nil)
+	verifier.verify("a@servfail.com")
+	test.Assert(t, sa.get("a@servfail.com") == nil, "temporary failure should not have been recorded")
+	test.AssertMetricWithLabelsEquals(t, verifier.results, prometheus.Labels{"result": "error"}, 1)
+
+	// Failing to send a confirmation is also temporary.
+	verifier, sa, _ = setupVerifier(&mockConfirmationSender{err: errors.New("connection refused")})
+	verifier.verify("a@example.com")
+	test.Assert(t, sa.get("a@example.com") == nil, "failure to send confirmation should not have been recorded")
+}
+
+func TestVerifyContactsQueue(t *testing.T) {
+	t.Parallel()
+
+	verifier, sa, _ := setupVerifier(nil)
+	daemonCtx, cancel := context.WithCancel(context.Background())
+	verifier.Start(daemonCtx)
+
+	_, err := verifier.VerifyContacts(ctx, &emailpb.VerifyContactsRequest{Emails: []string{"a@example.com", "b@nullmx.com"}})
+	test.AssertNotError(t, err, "VerifyContacts failed")
+	cancel()
+	verifier.Drain()
+
+	test.AssertEquals(t, core.AcmeStatus(sa.get("a@example.com").Status), core.StatusValid)
+	test.AssertEquals(t, core.AcmeStatus(sa.get("b@nullmx.com").Status), core.StatusInvalid)
+
+	_, err = verifier.VerifyContacts(ctx, &emailpb.VerifyContactsRequest{})
+	test.AssertError(t, err, "VerifyContacts should reject an empty request")
+
+	tooMany := make([]string, verifyQueueCap+1)
+	for i := range tooMany {
+		tooMany[i] = "a@example.com"
+	}
+	_, err = verifier.VerifyContacts(ctx, &emailpb.VerifyContactsRequest{Emails: tooMany})
+	test.AssertErrorIs(t, err, ErrVerifyQueueFull)
+}
+
+func TestConfirmContact(t *testing.T) {
+	t.Parallel()
+
+	sender := &mockConfirmationSender{tokens: make(map[string]string)}
+	verifier, sa, clk := setupVerifier(sender)
+
+	verifier.verify("a@example.com")
+	test.AssertEquals(t, core.AcmeStatus(sa.get("a@example.com").Status), core.StatusPending)
+	token := sender.tokens["a@example.com"]
+	test.Assert(t, token != "", "confirmation should have been sent")
+
+	_, err := verifier.ConfirmContact(ctx, &emailpb.ConfirmContactRequest{Token: token})
+	test.AssertNotError(t, err, "ConfirmContact failed")
+	test.AssertEquals(t, core.AcmeStatus(sa.get("a@example.com").Status), core.StatusValid)
+
+	// A token for one address can't be altered to confirm another.
+	verifier.verify("b@example.com")
+	forged := verifier.newToken("b@example.com")
+	forged = forged[:len(forged)-2] + "AA"
+	_, err = verifier.ConfirmContact(ctx, &emailpb.ConfirmContactRequest{Token: forged})
+	test.AssertErrorIs(t, err, berrors.Unauthorized)
+
+	_, err = verifier.ConfirmContact(ctx, &emailpb.ConfirmContactRequest{Token: "garbage"})
+	test.AssertErrorIs(t, err, berrors.Malformed)
+
+	clk.Add(2 * time.Hour)
+	_, err = verifier.ConfirmContact(ctx, &emailpb.ConfirmContactRequest{Token: sender.tokens["b@example.com"]})
+	test.AssertErrorIs(t, err, berrors.Unauthorized)
+	test.AssertEquals(t, core.AcmeStatus(sa.get("b@example.com").Status), core.StatusPending)
+}
+
+func TestConfirmContactDisabled(t *testing.T) {
+	t.Parallel()
+
+	verifier, _, _ := setupVerifier(nil)
+	_, err := verifier.ConfirmContact(ctx, &emailpb.ConfirmContactRequest{Token: verifier.newToken("a@example.com")})
+	test.AssertError(t, err, "ConfirmContact should fail when confirmation is disabled")
+}
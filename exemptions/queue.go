@@ -0,0 +1,216 @@
+package exemptions
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/letsencrypt/boulder/core"
+	berrors "github.com/letsencrypt/boulder/errors"
+	exemptionspb "github.com/letsencrypt/boulder/exemptions/proto"
+	"github.com/letsencrypt/boulder/identifier"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/policy"
+	"github.com/letsencrypt/boulder/ratelimits"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+)
+
+// maxJustificationLength is the longest justification, in bytes, which a
+// subscriber may give for an exemption request.
+const maxJustificationLength = 4000
+
+// exemptibleLimits are the rate limits for which subscribers may request an
+// override, and whether the override applies to a single identifier, a set of
+// identifiers, or the whole account.
+var exemptibleLimits = map[ratelimits.Name]identScope{
+	ratelimits.NewOrdersPerAccount:                     scopeAccount,
+	ratelimits.CertificatesPerDomain:                   scopeSingleIdent,
+	ratelimits.CertificatesPerDomainPerAccount:         scopeAccount,
+	ratelimits.CertificatesPerFQDNSet:                  scopeIdentSet,
+	ratelimits.FailedAuthorizationsPerDomainPerAccount: scopeAccount,
+}
+
+// identScope is the part of the request a limit's override is keyed on,
+// besides the account.
+type identScope int
+
+const (
+	scopeAccount identScope = iota
+	scopeSingleIdent
+	scopeIdentSet
+)
+
+// QueueImpl implements the ExemptionQueue gRPC service. It stores exemption
+// requests in the SA, where they wait to be approved or rejected with the
+// admin tool.
+type QueueImpl struct {
+	exemptionspb.UnsafeExemptionQueueServer
+
+	sa                   sapb.StorageAuthorityClient
+	maxPendingPerAccount int
+	maxPeriod            time.Duration
+	log                  blog.Logger
+
+	submitted *prometheus.CounterVec
+}
+
+var _ exemptionspb.ExemptionQueueServer = (*QueueImpl)(nil)
+
+// NewQueueImpl returns a QueueImpl which accepts requests for overrides of at
+// most maxPeriod, and refuses new requests from accounts which already have
+// maxPendingPerAccount requests awaiting review.
+func NewQueueImpl(
+	sa sapb.StorageAuthorityClient,
+	maxPendingPerAccount int,
+	maxPeriod time.Duration,
+	scope prometheus.Registerer,
+	logger blog.Logger,
+) *QueueImpl {
+	submitted := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "exemption_requests_submitted",
+		Help: "Number of rate limit exemption requests submitted, by limit and result=[queued|too_many_pending|invalid|error]",
+	}, []string{"limit", "result"})
+	scope.MustRegister(submitted)
+
+	return &QueueImpl{
+		sa:                   sa,
+		maxPendingPerAccount: maxPendingPerAccount,
+		maxPeriod:            maxPeriod,
+		log:                  logger,
+		submitted:            submitted,
+	}
+}
+
+// SubmitExemptionRequest validates a subscriber's request for a rate limit
+// override and queues it for review. Errors caused by the request itself are
+// Malformed or RejectedIdentifier, so that they can be shown to the subscriber.
+func (impl *QueueImpl) SubmitExemptionRequest(ctx context.Context, req *exemptionspb.SubmitExemptionRequestRequest) (*exemptionspb.SubmitExemptionRequestResponse, error) {
+	if core.IsAnyNilOrZero(req, req.RegistrationID, req.Limit) {
+		return nil, berrors.InternalServerError("Incomplete gRPC request message")
+	}
+
+	// The limit name comes from the subscriber, so only known names are used
+	// as metric labels.
+	limit := "unknown"
+	if _, ok := ratelimits.StringToName[req.Limit]; ok {
+		limit = req.Limit
+	}
+
+	override, err := impl.buildOverride(req)
+	if err != nil {
+		impl.submitted.With(prometheus.Labels{"limit": limit, "result": "invalid"}).Inc()
+		return nil, err
+	}
+
+	pending, err := impl.sa.GetExemptionRequests(ctx, &sapb.GetExemptionRequestsRequest{
+		Status:         "pending",
+		RegistrationID: req.RegistrationID,
+		Limit:          int64(impl.maxPendingPerAccount),
+	})
+	if err != nil {
+		impl.submitted.With(prometheus.Labels{"limit": limit, "result": "error"}).Inc()
+		return nil, fmt.Errorf("getting pending exemption requests: %w", err)
+	}
+	if len(pending.Requests) >= impl.maxPendingPerAccount {
+		impl.submitted.With(prometheus.Labels{"limit": limit, "result": "too_many_pending"}).Inc()
+		return nil, berrors.MalformedError(
+			"account already has %d exemption requests awaiting review", len(pending.Requests))
+	}
+
+	stored, err := impl.sa.AddExemptionRequest(ctx, &sapb.ExemptionRequest{
+		RegistrationID: req.RegistrationID,
+		Override:       override,
+		Justification:  req.Justification,
+	})
+	if err != nil {
+		impl.submitted.With(prometheus.Labels{"limit": limit, "result": "error"}).Inc()
+		return nil, fmt.Errorf("adding exemption request: %w", err)
+	}
+	impl.submitted.With(prometheus.Labels{"limit": limit, "result": "queued"}).Inc()
+	impl.log.Infof("Queued exemption request %d from registration %d for %s bucket %q: %d per %s",
+		stored.Id, req.RegistrationID, limit, override.BucketKey, override.Count, override.Period.AsDuration())
+
+	return &exemptionspb.SubmitExemptionRequestResponse{
+		Id:     stored.Id,
+		Status: stored.Status,
+	}, nil
+}
+
+// buildOverride returns the override which req asks for, or an error describing
+// why it can't be requested.
+func (impl *QueueImpl) buildOverride(req *exemptionspb.SubmitExemptionRequestRequest) (*sapb.RateLimitOverride, error) {
+	name, ok := ratelimits.StringToName[req.Limit]
+	if !ok {
+		return nil, berrors.MalformedError("unknown rate limit %q", req.Limit)
+	}
+	scope, ok := exemptibleLimits[name]
+	if !ok {
+		return nil, berrors.MalformedError("exemptions from rate limit %s cannot be requested", name)
+	}
+	if req.Count <= 0 {
+		return nil, berrors.MalformedError("count must be positive")
+	}
+	period := req.Period.AsDuration()
+	if period <= 0 {
+		return nil, berrors.MalformedError("period must be positive")
+	}
+	if period > impl.maxPeriod {
+		return nil, berrors.MalformedError("period must not be longer than %s", impl.maxPeriod)
+	}
+	if req.Justification == "" {
+		return nil, berrors.MalformedError("justification is required")
+	}
+	if len(req.Justification) > maxJustificationLength {
+		return nil, berrors.MalformedError("justification must not be longer than %d bytes", maxJustificationLength)
+	}
+
+	idents := identifier.Normalize(identifier.FromProtoSlice(req.Identifiers))
+	switch scope {
+	case scopeAccount:
+		if len(idents) != 0 {
+			return nil, berrors.MalformedError("rate limit %s applies to the whole account, so no identifiers may be given", name)
+		}
+	case scopeSingleIdent:
+		if len(idents) != 1 {
+			return nil, berrors.MalformedError("rate limit %s requires exactly one identifier", name)
+		}
+	case scopeIdentSet:
+		if len(idents) == 0 {
+			return nil, berrors.MalformedError("rate limit %s requires at least one identifier", name)
+		}
+	}
+	if len(idents) != 0 {
+		err := policy.WellFormedIdentifiers(idents)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var regID int64
+	var single identifier.ACMEIdentifier
+	var set identifier.ACMEIdentifiers
+	switch scope {
+	case scopeAccount:
+		regID = req.RegistrationID
+	case scopeSingleIdent:
+		single = idents[0]
+	case scopeIdentSet:
+		set = idents
+	}
+	bucketKey, err := ratelimits.BuildBucketKey(name, regID, single, set, netip.Addr{})
+	if err != nil {
+		return nil, berrors.MalformedError("building bucket key: %s", err)
+	}
+
+	return &sapb.RateLimitOverride{
+		LimitEnum: int64(name),
+		BucketKey: bucketKey,
+		Period:    durationpb.New(period),
+		Count:     req.Count,
+		Burst:     req.Count,
+	}, nil
+}
@@ -0,0 +1,164 @@
+package exemptions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	berrors "github.com/letsencrypt/boulder/errors"
+	exemptionspb "github.com/letsencrypt/boulder/exemptions/proto"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/ratelimits"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+	"github.com/letsencrypt/boulder/test"
+	isa "github.com/letsencrypt/boulder/test/inmem/sa"
+)
+
+func setup() (*QueueImpl, isa.SA) {
+	fc := clock.NewFake()
+	fc.Set(time.Date(2020, 3, 4, 5, 0, 0, 0, time.UTC))
+	sa := isa.SA{Impl: isa.NewMemoryStorageAuthority(fc)}
+	return NewQueueImpl(sa, 2, 7*24*time.Hour, metrics.NoopRegisterer, blog.NewMock()), sa
+}
+
+func dnsIdents(names ...string) []*corepb.Identifier {
+	var idents []*corepb.Identifier
+	for _, name := range names {
+		idents = append(idents, &corepb.Identifier{Type: "dns", Value: name})
+	}
+	return idents
+}
+
+func TestSubmitExemptionRequest(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testCases := []struct {
+		name          string
+		limit         string
+		idents        []*corepb.Identifier
+		wantBucketKey string
+	}{
+		{
+			name:          "account",
+			limit:         "NewOrdersPerAccount",
+			wantBucketKey: "3:1234",
+		},
+		{
+			name:          "domain",
+			limit:         "CertificatesPerDomain",
+			idents:        dnsIdents("www.example.com"),
+			wantBucketKey: "5:example.com",
+		},
+		{
+			name:          "account-wide per domain",
+			limit:         "CertificatesPerDomainPerAccount",
+			wantBucketKey: "6:1234",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			impl, sa := setup()
+			resp, err := impl.SubmitExemptionRequest(ctx, &exemptionspb.SubmitExemptionRequestRequest{
+				RegistrationID: 1234,
+				Limit:          tc.limit,
+				Identifiers:    tc.idents,
+				Count:          100,
+				Period:         durationpb.New(24 * time.Hour),
+				Justification:  "hosting provider",
+			})
+			test.AssertNotError(t, err, "SubmitExemptionRequest failed")
+			test.AssertEquals(t, resp.Status, "pending")
+
+			stored, err := sa.GetExemptionRequest(ctx, &sapb.ExemptionRequestID{Id: resp.Id})
+			test.AssertNotError(t, err, "GetExemptionRequest failed")
+			test.AssertEquals(t, stored.RegistrationID, int64(1234))
+			test.AssertEquals(t, stored.Override.LimitEnum, int64(ratelimits.StringToName[tc.limit]))
+			test.AssertEquals(t, stored.Override.BucketKey, tc.wantBucketKey)
+			test.AssertEquals(t, stored.Override.Count, int64(100))
+			test.AssertEquals(t, stored.Override.Burst, int64(100))
+			test.AssertEquals(t, stored.Justification, "hosting provider")
+			test.AssertMetricWithLabelsEquals(t, impl.submitted, prometheus.Labels{"limit": tc.limit, "result": "queued"}, 1)
+		})
+	}
+}
+
+func TestSubmitExemptionRequestInvalid(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	valid := func() *exemptionspb.SubmitExemptionRequestRequest {
+		return &exemptionspb.SubmitExemptionRequestRequest{
+			RegistrationID: 1234,
+			Limit:          "CertificatesPerDomain",
+			Identifiers:    dnsIdents("example.com"),
+			Count:          100,
+			Period:         durationpb.New(24 * time.Hour),
+			Justification:  "hosting provider",
+		}
+	}
+	testCases := []struct {
+		name   string
+		modify func(*exemptionspb.SubmitExemptionRequestRequest)
+	}{
+		{"unknown limit", func(r *exemptionspb.SubmitExemptionRequestRequest) { r.Limit = "Bogus" }},
+		{"unexemptible limit", func(r *exemptionspb.SubmitExemptionRequestRequest) { r.Limit = "NewRegistrationsPerIPAddress" }},
+		{"no count", func(r *exemptionspb.SubmitExemptionRequestRequest) { r.Count = 0 }},
+		{"no period", func(r *exemptionspb.SubmitExemptionRequestRequest) { r.Period = nil }},
+		{"long period", func(r *exemptionspb.SubmitExemptionRequestRequest) { r.Period = durationpb.New(30 * 24 * time.Hour) }},
+		{"no justification", func(r *exemptionspb.SubmitExemptionRequestRequest) { r.Justification = "" }},
+		{"no identifiers", func(r *exemptionspb.SubmitExemptionRequestRequest) { r.Identifiers = nil }},
+		{"too many identifiers", func(r *exemptionspb.SubmitExemptionRequestRequest) { r.Identifiers = dnsIdents("a.com", "b.com") }},
+		{"identifiers for account limit", func(r *exemptionspb.SubmitExemptionRequestRequest) { r.Limit = "NewOrdersPerAccount" }},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			impl, _ := setup()
+			req := valid()
+			tc.modify(req)
+			_, err := impl.SubmitExemptionRequest(ctx, req)
+			test.AssertErrorIs(t, err, berrors.Malformed)
+		})
+	}
+
+	impl, _ := setup()
+	req := valid()
+	req.Identifiers = dnsIdents("not a domain")
+	_, err := impl.SubmitExemptionRequest(ctx, req)
+	test.AssertErrorIs(t, err, berrors.RejectedIdentifier)
+}
+
+func TestSubmitExemptionRequestTooManyPending(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	impl, sa := setup()
+
+	req := &exemptionspb.SubmitExemptionRequestRequest{
+		RegistrationID: 1234,
+		Limit:          "NewOrdersPerAccount",
+		Count:          100,
+		Period:         durationpb.New(time.Hour),
+		Justification:  "hosting provider",
+	}
+	first, err := impl.SubmitExemptionRequest(ctx, req)
+	test.AssertNotError(t, err, "SubmitExemptionRequest failed")
+	_, err = impl.SubmitExemptionRequest(ctx, req)
+	test.AssertNotError(t, err, "SubmitExemptionRequest failed")
+	_, err = impl.SubmitExemptionRequest(ctx, req)
+	test.AssertErrorIs(t, err, berrors.Malformed)
+	test.AssertMetricWithLabelsEquals(t, impl.submitted, prometheus.Labels{"limit": "NewOrdersPerAccount", "result": "too_many_pending"}, 1)
+
+	// Once one is reviewed, another may be submitted.
+	_, err = sa.ReviewExemptionRequest(ctx, &sapb.ReviewExemptionRequestRequest{Id: first.Id, Status: "rejected", Reviewer: "admin"})
+	test.AssertNotError(t, err, "ReviewExemptionRequest failed")
+	_, err = impl.SubmitExemptionRequest(ctx, req)
+	test.AssertNotError(t, err, "SubmitExemptionRequest failed")
+}
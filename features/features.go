@@ -80,6 +80,43 @@ type Config struct {
 	// StoreARIReplacesInOrders causes the SA to store and retrieve the optional
 	// ARI replaces field in the orders table.
 	StoreARIReplacesInOrders bool
+
+	// StorePrecertificateDigests causes the SA to store the SHA-256 digest of
+	// each precertificate's DER in the precertificates table, as it already
+	// does for certificates, so that the CertScrubber can detect corruption.
+	StorePrecertificateDigests bool
+
+	// MaintainAuthzCounts causes the SA to keep the pendingAuthzCounts and
+	// invalidAuthzCounts tables up to date, in the same transactions as the
+	// authorization writes which change them.
+	MaintainAuthzCounts bool
+
+	// UseAuthzCounts causes the SA to answer CountPendingAuthorizations2 and
+	// CountInvalidAuthorizations2 from the pendingAuthzCounts and
+	// invalidAuthzCounts tables instead of counting authz2 rows. It must not
+	// be enabled until MaintainAuthzCounts has been enabled on every SA for
+	// longer than the longest authorization lifetime.
+	UseAuthzCounts bool
+
+	// StoreValidationPerspectives causes the SA to record the outcome of each
+	// network perspective's part in a validation attempt, as reported by the
+	// primary VA, in the validationPerspectives table, in the same transaction
+	// as the authorization is finalized.
+	StoreValidationPerspectives bool
+
+	// IdempotentFinalize causes the SA to store the SHA-256 hash of the CSR
+	// with which each order is finalized in the orders table, and the RA to
+	// respond to a repeated finalization of an order with the same CSR, such
+	// as a client's retry after a timeout, with the order as it stands rather
+	// than an error.
+	IdempotentFinalize bool
+
+	// EmailIdentifiers permits the "email" identifier type and the
+	// "email-reply-00" challenge type, both specified in RFC 8823, to be
+	// enabled in the PA config. This is groundwork for S/MIME issuance: the VA
+	// can't validate email-reply-00 challenges unless it's given an
+	// EmailReplyValidator.
+	EmailIdentifiers bool
 }
 
 var fMu = new(sync.RWMutex)
@@ -0,0 +1,143 @@
+// Package gateway serves a few of the SA's read-only queries as JSON over
+// HTTPS, for internal dashboards which can't speak gRPC. Requests are routed
+// and responses marshaled by grpc-gateway, so the JSON is the canonical
+// protojson encoding of the SA's responses. Clients authenticate with the
+// same mTLS certificates, and are authorized by the same certificate names, as
+// Boulder's gRPC clients.
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/letsencrypt/boulder/core"
+	berrors "github.com/letsencrypt/boulder/errors"
+	blog "github.com/letsencrypt/boulder/log"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+)
+
+// Gateway is an http.Handler which answers read-only queries from the SA.
+type Gateway struct {
+	sa          sapb.StorageAuthorityReadOnlyClient
+	clientNames map[string]struct{}
+	log         blog.Logger
+	mux         *runtime.ServeMux
+}
+
+var _ http.Handler = (*Gateway)(nil)
+
+// New returns a Gateway which answers queries using sa, for clients whose
+// mTLS certificates contain one of clientNames.
+func New(sa sapb.StorageAuthorityReadOnlyClient, clientNames []string, logger blog.Logger) (*Gateway, error) {
+	if len(clientNames) == 0 {
+		return nil, errors.New("at least one client name must be allowed")
+	}
+	g := &Gateway{
+		sa:          sa,
+		clientNames: make(map[string]struct{}, len(clientNames)),
+		log:         logger,
+		mux:         runtime.NewServeMux(),
+	}
+	for _, name := range clientNames {
+		g.clientNames[name] = struct{}{}
+	}
+
+	err := g.mux.HandlePath(http.MethodGet, "/v1/orders/{id}", g.getOrder)
+	if err != nil {
+		return nil, err
+	}
+	err = g.mux.HandlePath(http.MethodGet, "/v1/certificates/{serial}", g.getCertificate)
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// ServeHTTP checks that the client is allowed to use the gateway, then routes
+// its request.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	err := g.authorize(r)
+	if err != nil {
+		g.log.Warningf("rejecting gateway request for %s: %s", r.URL.Path, err)
+		g.writeError(w, r, status.Error(codes.PermissionDenied, err.Error()))
+		return
+	}
+	g.mux.ServeHTTP(w, r)
+}
+
+// authorize returns an error unless the client presented a certificate with
+// one of the allowed names. The certificate itself is verified by the TLS
+// server.
+func (g *Gateway) authorize(r *http.Request) error {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return errors.New("no client certificate presented")
+	}
+	cert := r.TLS.PeerCertificates[0]
+	for _, name := range cert.DNSNames {
+		_, ok := g.clientNames[name]
+		if ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("client certificate names %v are not allowed", cert.DNSNames)
+}
+
+func (g *Gateway) getOrder(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil || id <= 0 {
+		g.writeError(w, r, status.Errorf(codes.InvalidArgument, "invalid order ID %q", params["id"]))
+		return
+	}
+	order, err := g.sa.GetOrder(r.Context(), &sapb.OrderRequest{Id: id})
+	if err != nil {
+		g.writeError(w, r, g.toStatus(err))
+		return
+	}
+	_, outbound := runtime.MarshalerForRequest(g.mux, r)
+	runtime.ForwardResponseMessage(r.Context(), g.mux, outbound, w, r, order)
+}
+
+func (g *Gateway) getCertificate(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	serial := params["serial"]
+	if !core.ValidSerial(serial) {
+		g.writeError(w, r, status.Errorf(codes.InvalidArgument, "invalid serial %q", serial))
+		return
+	}
+	cert, err := g.sa.GetCertificate(r.Context(), &sapb.Serial{Serial: serial})
+	if err != nil {
+		g.writeError(w, r, g.toStatus(err))
+		return
+	}
+	_, outbound := runtime.MarshalerForRequest(g.mux, r)
+	runtime.ForwardResponseMessage(r.Context(), g.mux, outbound, w, r, cert)
+}
+
+// toStatus converts an error from the SA into a gRPC status, from which
+// grpc-gateway derives the HTTP status code. Errors other than those the
+// client can act on are logged and reported as internal errors, so that their
+// details don't leak.
+func (g *Gateway) toStatus(err error) error {
+	switch {
+	case errors.Is(err, berrors.NotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, berrors.Malformed):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "request timed out")
+	default:
+		g.log.Errf("gateway query to SA failed: %s", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+func (g *Gateway) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	_, outbound := runtime.MarshalerForRequest(g.mux, r)
+	runtime.HTTPError(r.Context(), g.mux, outbound, w, r, err)
+}
@@ -0,0 +1,126 @@
+package gateway
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jmhodges/clock"
+	"google.golang.org/grpc"
+
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/mocks"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+	"github.com/letsencrypt/boulder/test"
+)
+
+const goodSerial = "000000000000000000000000000000000001"
+
+// certSA is a mock SA which has a certificate with goodSerial.
+type certSA struct {
+	*mocks.StorageAuthorityReadOnly
+}
+
+func (sa certSA) GetCertificate(ctx context.Context, req *sapb.Serial, opts ...grpc.CallOption) (*corepb.Certificate, error) {
+	if req.Serial == goodSerial {
+		return &corepb.Certificate{RegistrationID: 1, Serial: goodSerial, Der: []byte{1, 2, 3}}, nil
+	}
+	return sa.StorageAuthorityReadOnly.GetCertificate(ctx, req, opts...)
+}
+
+func setup(t *testing.T) *Gateway {
+	t.Helper()
+	g, err := New(certSA{mocks.NewStorageAuthorityReadOnly(clock.NewFake())}, []string{"dashboard.boulder"}, blog.NewMock())
+	test.AssertNotError(t, err, "creating gateway")
+	return g
+}
+
+func get(g *Gateway, path string, clientNames ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if clientNames != nil {
+		req.TLS = &tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{{DNSNames: clientNames}},
+		}
+	}
+	rec := httptest.NewRecorder()
+	g.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	_, err := New(mocks.NewStorageAuthorityReadOnly(clock.NewFake()), nil, blog.NewMock())
+	test.AssertError(t, err, "gateway without allowed clients accepted")
+}
+
+func TestAuthorization(t *testing.T) {
+	t.Parallel()
+	g := setup(t)
+
+	rec := get(g, "/v1/orders/1")
+	test.AssertEquals(t, rec.Code, http.StatusForbidden)
+	rec = get(g, "/v1/orders/1", "wfe.boulder")
+	test.AssertEquals(t, rec.Code, http.StatusForbidden)
+	rec = get(g, "/v1/orders/1", "wfe.boulder", "dashboard.boulder")
+	test.AssertEquals(t, rec.Code, http.StatusOK)
+}
+
+func TestGetOrder(t *testing.T) {
+	t.Parallel()
+	g := setup(t)
+
+	rec := get(g, "/v1/orders/1", "dashboard.boulder")
+	test.AssertEquals(t, rec.Code, http.StatusOK)
+	test.AssertEquals(t, rec.Header().Get("Content-Type"), "application/json")
+	var order struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	}
+	err := json.Unmarshal(rec.Body.Bytes(), &order)
+	test.AssertNotError(t, err, "unmarshaling order")
+	test.AssertEquals(t, order.ID, "1")
+	test.AssertEquals(t, order.Status, "valid")
+
+	rec = get(g, "/v1/orders/2", "dashboard.boulder")
+	test.AssertEquals(t, rec.Code, http.StatusNotFound)
+	rec = get(g, "/v1/orders/3", "dashboard.boulder")
+	test.AssertEquals(t, rec.Code, http.StatusInternalServerError)
+	test.AssertNotContains(t, rec.Body.String(), "very bad")
+	rec = get(g, "/v1/orders/zero", "dashboard.boulder")
+	test.AssertEquals(t, rec.Code, http.StatusBadRequest)
+	rec = get(g, "/v1/orders/-1", "dashboard.boulder")
+	test.AssertEquals(t, rec.Code, http.StatusBadRequest)
+}
+
+func TestGetCertificate(t *testing.T) {
+	t.Parallel()
+	g := setup(t)
+
+	rec := get(g, "/v1/certificates/"+goodSerial, "dashboard.boulder")
+	test.AssertEquals(t, rec.Code, http.StatusOK)
+	var cert struct {
+		Serial string `json:"serial"`
+		Der    []byte `json:"der"`
+	}
+	err := json.Unmarshal(rec.Body.Bytes(), &cert)
+	test.AssertNotError(t, err, "unmarshaling certificate")
+	test.AssertEquals(t, cert.Serial, goodSerial)
+	test.AssertByteEquals(t, cert.Der, []byte{1, 2, 3})
+
+	rec = get(g, "/v1/certificates/000000000000000000000000000000000002", "dashboard.boulder")
+	test.AssertEquals(t, rec.Code, http.StatusNotFound)
+	rec = get(g, "/v1/certificates/not-a-serial", "dashboard.boulder")
+	test.AssertEquals(t, rec.Code, http.StatusBadRequest)
+
+	// Only GET is served.
+	req := httptest.NewRequest(http.MethodPost, "/v1/certificates/"+goodSerial, nil)
+	req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{DNSNames: []string{"dashboard.boulder"}}}}
+	rec = httptest.NewRecorder()
+	g.ServeHTTP(rec, req)
+	test.Assert(t, rec.Code != http.StatusOK, "POST should not be served")
+}
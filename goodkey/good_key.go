@@ -4,16 +4,19 @@ import (
 	"context"
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rsa"
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 	"sync"
 
-	"github.com/letsencrypt/boulder/core"
-
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/titanous/rocacheck"
+
+	"github.com/letsencrypt/boulder/core"
 )
 
 // To generate, run: primes 2 752 | tr '\n' ,
@@ -47,9 +50,27 @@ type Config struct {
 	// be trivially factored because the two factors are very close to each other.
 	// If this config value is empty or 0, it will default to 110 rounds.
 	FermatRounds int
+	// RSA further constrains the RSA keys allowed. If nil, RSA keys with the
+	// sizes enabled in AllowedKeys and the exponent 65537 are allowed.
+	RSA *RSAConfig
+}
+
+// RSAConfig constrains the RSA keys allowed by a KeyPolicy.
+type RSAConfig struct {
+	// AllowedModulusLengths, if set, replaces the RSA sizes enabled in
+	// AllowedKeys, e.g. [3072, 4096] to stop accepting 2048-bit keys. Only
+	// the sizes for which Debian weak keys have been enumerated may be used;
+	// see AllowedKeys.
+	AllowedModulusLengths []int `validate:"omitempty,dive,oneof=2048 3072 4096"`
+	// MinExponent and MaxExponent bound the public exponent. The exponent must
+	// always be odd and at least 3, per Baseline Requirements Section 6.1.6.
+	// If both are zero, only 65537 is allowed. If MaxExponent is zero but
+	// MinExponent isn't, there is no upper bound.
+	MinExponent int `validate:"omitempty,min=3"`
+	MaxExponent int `validate:"omitempty,min=3"`
 }
 
-// AllowedKeys is a map of six specific key algorithm and size combinations to
+// AllowedKeys is a map of seven specific key algorithm and size combinations to
 // booleans indicating whether keys of that type are considered good.
 type AllowedKeys struct {
 	// Baseline Requirements, Section 6.1.5 requires key size >= 2048 and a multiple
@@ -66,6 +87,10 @@ type AllowedKeys struct {
 	ECDSAP256 bool
 	ECDSAP384 bool
 	ECDSAP521 bool
+	// Ed25519 keys can't be certified under the Baseline Requirements, and CSRs
+	// signed with them are always rejected, so this should only be enabled for
+	// the account key policies of the WFE and RA, to accept EdDSA-signed JWS.
+	Ed25519 bool
 }
 
 // LetsEncryptCPS encodes the five key algorithms and sizes allowed by the Let's
@@ -93,6 +118,27 @@ func badKey(msg string, args ...interface{}) error {
 	return fmt.Errorf("%w%s", ErrBadKey, fmt.Errorf(msg, args...))
 }
 
+// ErrRSAModulusLength, ErrRSAExponent, and ErrRSAFactorsTooClose distinguish
+// the reasons an RSA key can be rejected. Errors wrapping them also wrap
+// ErrBadKey.
+var (
+	ErrRSAModulusLength   = errors.New("")
+	ErrRSAExponent        = errors.New("")
+	ErrRSAFactorsTooClose = errors.New("")
+)
+
+// badRSAKey returns an error wrapping ErrBadKey and, if it's non-nil, reason,
+// and counts the rejection under label if metrics are enabled.
+func (policy *KeyPolicy) badRSAKey(reason error, label string, msg string, args ...interface{}) error {
+	if policy.rsaRejections != nil {
+		policy.rsaRejections.WithLabelValues(label).Inc()
+	}
+	if reason == nil {
+		return badKey(msg, args...)
+	}
+	return fmt.Errorf("%w%w%s", ErrBadKey, reason, fmt.Errorf(msg, args...))
+}
+
 // BlockedKeyCheckFunc is used to pass in the sa.BlockedKey functionality to KeyPolicy,
 // rather than storing a full sa.SQLStorageAuthority. This allows external
 // users who don’t want to import all of boulder/sa, and makes testing
@@ -106,6 +152,17 @@ type KeyPolicy struct {
 	allowedKeys  AllowedKeys
 	fermatRounds int
 	blockedCheck BlockedKeyCheckFunc
+
+	// rsaModulusLengths, if non-empty, replaces the RSA sizes in allowedKeys.
+	rsaModulusLengths []int
+	// minRSAExponent and maxRSAExponent bound the RSA public exponent. If both
+	// are zero, only 65537 is allowed. If only maxRSAExponent is zero, there
+	// is no upper bound.
+	minRSAExponent int
+	maxRSAExponent int
+
+	// rsaRejections, if set, counts the RSA keys rejected, by reason.
+	rsaRejections *prometheus.CounterVec
 }
 
 // NewPolicy returns a key policy based on the given configuration, with sane
@@ -132,9 +189,42 @@ func NewPolicy(config *Config, bkc BlockedKeyCheckFunc) (KeyPolicy, error) {
 	} else {
 		kp.fermatRounds = config.FermatRounds
 	}
+	if config.RSA != nil {
+		for _, l := range config.RSA.AllowedModulusLengths {
+			if l != 2048 && l != 3072 && l != 4096 {
+				return KeyPolicy{}, fmt.Errorf("RSA modulus length %d is not supported", l)
+			}
+		}
+		kp.rsaModulusLengths = slices.Clone(config.RSA.AllowedModulusLengths)
+		slices.Sort(kp.rsaModulusLengths)
+
+		minE, maxE := config.RSA.MinExponent, config.RSA.MaxExponent
+		if minE == 0 && maxE != 0 {
+			return KeyPolicy{}, fmt.Errorf("RSA MaxExponent %d is set without MinExponent", maxE)
+		}
+		if minE != 0 && minE < 3 {
+			return KeyPolicy{}, fmt.Errorf("RSA MinExponent must be at least 3: %d", minE)
+		}
+		if maxE != 0 && maxE < minE {
+			return KeyPolicy{}, fmt.Errorf("RSA MaxExponent %d is less than MinExponent %d", maxE, minE)
+		}
+		kp.minRSAExponent = minE
+		kp.maxRSAExponent = maxE
+	}
 	return kp, nil
 }
 
+// RegisterMetrics counts the RSA keys the policy rejects, by reason, in the
+// goodkey_rsa_rejections metric. It must be called before the policy is
+// copied.
+func (policy *KeyPolicy) RegisterMetrics(stats prometheus.Registerer) {
+	policy.rsaRejections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "goodkey_rsa_rejections",
+		Help: "Number of RSA public keys rejected, by reason",
+	}, []string{"reason"})
+	stats.MustRegister(policy.rsaRejections)
+}
+
 // GoodKey returns true if the key is acceptable for both TLS use and account
 // key use (our requirements are the same for either one), according to basic
 // strength and algorithm checking. GoodKey only supports pointers: *rsa.PublicKey
@@ -145,6 +235,10 @@ func (policy *KeyPolicy) GoodKey(ctx context.Context, key crypto.PublicKey) erro
 	switch t := key.(type) {
 	case *rsa.PublicKey, *ecdsa.PublicKey:
 		break
+	case ed25519.PublicKey:
+		if !policy.allowedKeys.Ed25519 {
+			return badKey("unsupported key type %T", t)
+		}
 	default:
 		return badKey("unsupported key type %T", t)
 	}
@@ -165,11 +259,23 @@ func (policy *KeyPolicy) GoodKey(ctx context.Context, key crypto.PublicKey) erro
 		return policy.goodKeyRSA(t)
 	case *ecdsa.PublicKey:
 		return policy.goodKeyECDSA(t)
+	case ed25519.PublicKey:
+		return policy.goodKeyEd25519(t)
 	default:
 		return badKey("unsupported key type %T", key)
 	}
 }
 
+// goodKeyEd25519 determines if an Ed25519 pubkey meets our requirements.
+// Unlike ECDSA keys, any 32 bytes are a valid Ed25519 public key encoding, so
+// only the length is checked.
+func (policy *KeyPolicy) goodKeyEd25519(key ed25519.PublicKey) error {
+	if len(key) != ed25519.PublicKeySize {
+		return badKey("Ed25519 key must be %d bytes, got %d", ed25519.PublicKeySize, len(key))
+	}
+	return nil
+}
+
 // GoodKeyECDSA determines if an ECDSA pubkey meets our requirements
 func (policy *KeyPolicy) goodKeyECDSA(key *ecdsa.PublicKey) (err error) {
 	// Check the curve.
@@ -292,47 +398,43 @@ func (policy *KeyPolicy) goodKeyRSA(key *rsa.PublicKey) error {
 		return err
 	}
 
-	// Rather than support arbitrary exponents, which significantly increases
-	// the size of the key space we allow, we restrict E to the defacto standard
-	// RSA exponent 65537. There is no specific standards document that specifies
-	// 65537 as the 'best' exponent, but ITU X.509 Annex C suggests there are
-	// notable merits for using it if using a fixed exponent.
-	//
-	// The CABF Baseline Requirements state:
-	//   The CA SHALL confirm that the value of the public exponent is an
-	//   odd number equal to 3 or more. Additionally, the public exponent
-	//   SHOULD be in the range between 2^16 + 1 and 2^256-1.
-	//
-	// By only allowing one exponent, which fits these constraints, we satisfy
-	// these requirements.
-	if key.E != 65537 {
-		return badKey("key exponent must be 65537")
+	err = policy.goodRSAExponent(key)
+	if err != nil {
+		return err
 	}
 
 	// The modulus SHOULD also have the following characteristics: an odd
 	// number, not the power of a prime, and have no factors smaller than 752.
 	// TODO: We don't yet check for "power of a prime."
 	if checkSmallPrimes(modulus) {
-		return badKey("key divisible by small prime")
+		return policy.badRSAKey(nil, "small_prime", "key divisible by small prime")
 	}
 	// Check for weak keys generated by Infineon hardware
 	// (see https://crocs.fi.muni.cz/public/papers/rsa_ccs17)
 	if rocacheck.IsWeak(key) {
-		return badKey("key generated by vulnerable Infineon-based hardware")
+		return policy.badRSAKey(nil, "roca", "key generated by vulnerable Infineon-based hardware")
 	}
 
 	// Check if the key can be easily factored via Fermat's factorization method.
 	err = checkPrimeFactorsTooClose(modulus, policy.fermatRounds)
 	if err != nil {
-		return badKey("key generated with factors too close together: %w", err)
+		return policy.badRSAKey(ErrRSAFactorsTooClose, "factors_too_close", "key generated with factors too close together: %w", err)
 	}
 
 	return nil
 }
 
 func (policy *KeyPolicy) goodRSABitLen(key *rsa.PublicKey) error {
-	// See comment on AllowedKeys above.
 	modulusBitLen := key.N.BitLen()
+	if len(policy.rsaModulusLengths) > 0 {
+		if slices.Contains(policy.rsaModulusLengths, modulusBitLen) {
+			return nil
+		}
+		return policy.badRSAKey(ErrRSAModulusLength, "modulus_length",
+			"key size not supported: %d (allowed sizes: %v)", modulusBitLen, policy.rsaModulusLengths)
+	}
+
+	// See comment on AllowedKeys above.
 	switch {
 	case modulusBitLen == 2048 && policy.allowedKeys.RSA2048:
 		return nil
@@ -341,8 +443,44 @@ func (policy *KeyPolicy) goodRSABitLen(key *rsa.PublicKey) error {
 	case modulusBitLen == 4096 && policy.allowedKeys.RSA4096:
 		return nil
 	default:
-		return badKey("key size not supported: %d", modulusBitLen)
+		return policy.badRSAKey(ErrRSAModulusLength, "modulus_length", "key size not supported: %d", modulusBitLen)
+	}
+}
+
+func (policy *KeyPolicy) goodRSAExponent(key *rsa.PublicKey) error {
+	if policy.minRSAExponent == 0 {
+		// Rather than support arbitrary exponents, which significantly
+		// increases the size of the key space we allow, we restrict E to the
+		// defacto standard RSA exponent 65537 by default. There is no specific
+		// standards document that specifies 65537 as the 'best' exponent, but
+		// ITU X.509 Annex C suggests there are notable merits for using it if
+		// using a fixed exponent.
+		//
+		// The CABF Baseline Requirements state:
+		//   The CA SHALL confirm that the value of the public exponent is an
+		//   odd number equal to 3 or more. Additionally, the public exponent
+		//   SHOULD be in the range between 2^16 + 1 and 2^256-1.
+		//
+		// By only allowing one exponent, which fits these constraints, we
+		// satisfy these requirements.
+		if key.E != 65537 {
+			return policy.badRSAKey(ErrRSAExponent, "exponent", "key exponent must be 65537")
+		}
+		return nil
 	}
+
+	// Otherwise the exponent must still satisfy the Baseline Requirements'
+	// SHALL, as well as the configured bounds.
+	if key.E%2 == 0 || key.E < 3 {
+		return policy.badRSAKey(ErrRSAExponent, "exponent", "key exponent must be an odd number of at least 3, got %d", key.E)
+	}
+	if key.E < policy.minRSAExponent {
+		return policy.badRSAKey(ErrRSAExponent, "exponent", "key exponent %d is less than the minimum of %d", key.E, policy.minRSAExponent)
+	}
+	if policy.maxRSAExponent != 0 && key.E > policy.maxRSAExponent {
+		return policy.badRSAKey(ErrRSAExponent, "exponent", "key exponent %d is greater than the maximum of %d", key.E, policy.maxRSAExponent)
+	}
+	return nil
 }
 
 // Returns true iff integer i is divisible by any of the primes in smallPrimes.
@@ -3,13 +3,18 @@ package goodkey
 import (
 	"context"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
+	"errors"
 	"fmt"
 	"math/big"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/metrics"
 	"github.com/letsencrypt/boulder/test"
 )
 
@@ -18,6 +23,7 @@ import (
 var testingPolicy = &KeyPolicy{allowedKeys: AllowedKeys{
 	RSA2048: true, RSA3072: true, RSA4096: true,
 	ECDSAP256: true, ECDSAP384: true, ECDSAP521: true,
+	Ed25519: true,
 }}
 
 func TestUnknownKeyType(t *testing.T) {
@@ -303,6 +309,22 @@ func TestDefaultAllowedKeys(t *testing.T) {
 	test.Assert(t, !policy.allowedKeys.ECDSAP521, "NIST P521 should not be allowed")
 }
 
+func TestEd25519(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	test.AssertNotError(t, err, "Error generating key")
+	test.AssertNotError(t, testingPolicy.GoodKey(context.Background(), pub), "Should have accepted good key")
+
+	err = testingPolicy.GoodKey(context.Background(), pub[:16])
+	test.AssertError(t, err, "Should have rejected short key")
+	test.AssertEquals(t, err.Error(), "Ed25519 key must be 32 bytes, got 16")
+
+	policy, err := NewPolicy(nil, nil)
+	test.AssertNotError(t, err, "NewPolicy with nil config failed")
+	err = policy.GoodKey(context.Background(), pub)
+	test.AssertError(t, err, "Should have rejected Ed25519 key by default")
+	test.AssertEquals(t, err.Error(), "unsupported key type ed25519.PublicKey")
+}
+
 func TestRSAStrangeSize(t *testing.T) {
 	k := &rsa.PublicKey{N: big.NewInt(10)}
 	err := testingPolicy.GoodKey(context.Background(), k)
@@ -310,6 +332,99 @@ func TestRSAStrangeSize(t *testing.T) {
 	test.AssertEquals(t, err.Error(), "key size not supported: 4")
 }
 
+func TestNewPolicyRSAConfig(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		config  RSAConfig
+		wantErr string
+	}{
+		{"modulus lengths", RSAConfig{AllowedModulusLengths: []int{4096, 3072}}, ""},
+		{"unsupported modulus length", RSAConfig{AllowedModulusLengths: []int{3072, 8192}}, "RSA modulus length 8192 is not supported"},
+		{"exponent range", RSAConfig{MinExponent: 3, MaxExponent: 65537}, ""},
+		{"no maximum exponent", RSAConfig{MinExponent: 65537}, ""},
+		{"maximum without minimum", RSAConfig{MaxExponent: 65537}, "set without MinExponent"},
+		{"minimum too small", RSAConfig{MinExponent: 1}, "RSA MinExponent must be at least 3"},
+		{"inverted range", RSAConfig{MinExponent: 65537, MaxExponent: 3}, "less than MinExponent"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := NewPolicy(&Config{RSA: &tc.config}, nil)
+			if tc.wantErr == "" {
+				test.AssertNotError(t, err, "NewPolicy failed")
+			} else {
+				test.AssertError(t, err, "NewPolicy succeeded")
+				test.AssertContains(t, err.Error(), tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestRSAPolicy(t *testing.T) {
+	t.Parallel()
+
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	test.AssertNotError(t, err, "Error generating key")
+	withExponent := func(e int) *rsa.PublicKey {
+		return &rsa.PublicKey{N: private.N, E: e}
+	}
+
+	policy, err := NewPolicy(&Config{RSA: &RSAConfig{
+		AllowedModulusLengths: []int{4096, 3072},
+	}}, nil)
+	test.AssertNotError(t, err, "NewPolicy failed")
+	policy.RegisterMetrics(metrics.NoopRegisterer)
+	err = policy.GoodKey(context.Background(), &private.PublicKey)
+	test.AssertErrorIs(t, err, ErrBadKey)
+	test.AssertErrorIs(t, err, ErrRSAModulusLength)
+	test.AssertEquals(t, err.Error(), "key size not supported: 2048 (allowed sizes: [3072 4096])")
+	test.AssertMetricWithLabelsEquals(t, policy.rsaRejections, prometheus.Labels{"reason": "modulus_length"}, 1)
+
+	policy, err = NewPolicy(&Config{RSA: &RSAConfig{MinExponent: 3, MaxExponent: 65537}}, nil)
+	test.AssertNotError(t, err, "NewPolicy failed")
+	policy.RegisterMetrics(metrics.NoopRegisterer)
+	test.AssertNotError(t, policy.GoodKey(context.Background(), withExponent(3)), "Should have accepted exponent 3")
+	test.AssertNotError(t, policy.GoodKey(context.Background(), withExponent(65537)), "Should have accepted exponent 65537")
+	for e, want := range map[int]string{
+		4:       "key exponent must be an odd number of at least 3, got 4",
+		65539:   "key exponent 65539 is greater than the maximum of 65537",
+		1 << 20: "key exponent must be an odd number of at least 3, got 1048576",
+	} {
+		err = policy.GoodKey(context.Background(), withExponent(e))
+		test.AssertErrorIs(t, err, ErrRSAExponent)
+		test.Assert(t, !errors.Is(err, ErrRSAModulusLength), "exponent error wraps ErrRSAModulusLength")
+		test.AssertEquals(t, err.Error(), want)
+	}
+	test.AssertMetricWithLabelsEquals(t, policy.rsaRejections, prometheus.Labels{"reason": "exponent"}, 3)
+
+	policy, err = NewPolicy(&Config{RSA: &RSAConfig{MinExponent: 17}}, nil)
+	test.AssertNotError(t, err, "NewPolicy failed")
+	err = policy.GoodKey(context.Background(), withExponent(3))
+	test.AssertErrorIs(t, err, ErrRSAExponent)
+	test.AssertEquals(t, err.Error(), "key exponent 3 is less than the minimum of 17")
+	test.AssertNotError(t, policy.GoodKey(context.Background(), withExponent(1<<30+1)), "Should have accepted large exponent")
+}
+
+func TestRSAFactorsTooCloseRejection(t *testing.T) {
+	t.Parallel()
+
+	// The factors of this 2048-bit modulus, from a Canon printer with a
+	// broken key generation mechanism, are found in one round.
+	p, _ := new(big.Int).SetString("155536235030272749691472293262418471207550926406427515178205576891522284497518443889075039382254334975506248481615035474816604875321501901699955105345417152355947783063521554077194367454070647740704883461064399268622437721385112646454393005862535727615809073410746393326688230040267160616554768771412289114449", 10)
+	q, _ := new(big.Int).SetString("155536235030272749691472293262418471207550926406427515178205576891522284497518443889075039382254334975506248481615035474816604875321501901699955105345417152355947783063521554077194367454070647740704883461064399268622437721385112646454393005862535727615809073410746393326688230040267160616554768771412289114113", 10)
+
+	policy, err := NewPolicy(nil, nil)
+	test.AssertNotError(t, err, "NewPolicy failed")
+	policy.RegisterMetrics(metrics.NoopRegisterer)
+	err = policy.GoodKey(context.Background(), &rsa.PublicKey{N: new(big.Int).Mul(p, q), E: 65537})
+	test.AssertErrorIs(t, err, ErrBadKey)
+	test.AssertErrorIs(t, err, ErrRSAFactorsTooClose)
+	test.AssertContains(t, err.Error(), "key generated with factors too close together")
+	test.AssertMetricWithLabelsEquals(t, policy.rsaRejections, prometheus.Labels{"reason": "factors_too_close"}, 1)
+}
+
 func TestCheckPrimeFactorsTooClose(t *testing.T) {
 	type testCase struct {
 		name         string
@@ -0,0 +1,109 @@
+// Package affinitybalancer provides a gRPC balancer which sends every RPC made
+// with the same affinity key to the same backend. The WFE uses it so that its
+// AwaitValidation requests reach the RA which is performing the validation
+// they wait for.
+package affinitybalancer
+
+import (
+	"context"
+	"hash/fnv"
+	"sync/atomic"
+
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+)
+
+const (
+	// Name is the name used to register the affinity balancer with the gRPC
+	// runtime.
+	Name = "affinity"
+
+	// SRVResolverScheme is the scheme used to invoke an instance of the SRV
+	// resolver which will use the affinitybalancer to pick backends.
+	SRVResolverScheme = "affinity-srv"
+)
+
+// keyCtxKey is the context key under which an RPC's affinity key is stored.
+type keyCtxKey struct{}
+
+// WithKey returns a copy of ctx which carries key, so that every RPC made with
+// it, and with any other context carrying the same key, is sent to the same
+// backend for as long as the set of backends doesn't change.
+func WithKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, keyCtxKey{}, key)
+}
+
+// Balancer implements the base.PickerBuilder interface. It's used to create new
+// balancer.Picker instances.
+type Balancer struct{}
+
+// Compile-time assertion that *Balancer implements the base.PickerBuilder
+// interface.
+var _ base.PickerBuilder = (*Balancer)(nil)
+
+// Build implements the base.PickerBuilder interface. It is called by the gRPC
+// runtime when the balancer is first initialized and when the set of backend
+// (SubConn) addresses changes.
+func (b *Balancer) Build(buildInfo base.PickerBuildInfo) balancer.Picker {
+	if len(buildInfo.ReadySCs) == 0 {
+		// The Picker must be rebuilt if there are no backends available.
+		return base.NewErrPicker(balancer.ErrNoSubConnAvailable)
+	}
+	p := &Picker{addrToBackend: make(map[string]balancer.SubConn, len(buildInfo.ReadySCs))}
+	for sc, scInfo := range buildInfo.ReadySCs {
+		p.addrToBackend[scInfo.Address.Addr] = sc
+		p.addrs = append(p.addrs, scInfo.Address.Addr)
+		p.backends = append(p.backends, sc)
+	}
+	return p
+}
+
+// Picker implements the balancer.Picker interface. It picks a backend (SubConn)
+// by the affinity key in each request's Context, or round-robin for requests
+// without one.
+type Picker struct {
+	addrToBackend map[string]balancer.SubConn
+	addrs         []string
+	backends      []balancer.SubConn
+	next          atomic.Uint64
+}
+
+// Compile-time assertion that *Picker implements the balancer.Picker interface.
+var _ balancer.Picker = (*Picker)(nil)
+
+// Pick implements the balancer.Picker interface. It is called by the gRPC
+// runtime for each RPC message.
+func (p *Picker) Pick(info balancer.PickInfo) (balancer.PickResult, error) {
+	key, ok := info.Ctx.Value(keyCtxKey{}).(string)
+	if !ok {
+		i := p.next.Add(1) - 1
+		return balancer.PickResult{SubConn: p.backends[i%uint64(len(p.backends))]}, nil
+	}
+	return balancer.PickResult{SubConn: p.addrToBackend[rendezvous(key, p.addrs)]}, nil
+}
+
+// rendezvous returns the address with the highest hash when combined with key.
+// For a given key the same address is chosen regardless of the order of addrs,
+// and adding or removing an address only moves the keys which hash highest to
+// it.
+func rendezvous(key string, addrs []string) string {
+	var best string
+	var bestScore uint64
+	for _, addr := range addrs {
+		h := fnv.New64a()
+		h.Write([]byte(key))
+		h.Write([]byte{0})
+		h.Write([]byte(addr))
+		score := h.Sum64()
+		if best == "" || score > bestScore || (score == bestScore && addr < best) {
+			best, bestScore = addr, score
+		}
+	}
+	return best
+}
+
+func init() {
+	balancer.Register(
+		base.NewBalancerBuilder(Name, &Balancer{}, base.Config{}),
+	)
+}
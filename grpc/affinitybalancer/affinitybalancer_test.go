@@ -0,0 +1,94 @@
+package affinitybalancer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/resolver"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestPickerAffinity(t *testing.T) {
+	t.Parallel()
+	p, subConns := setupTest(3)
+
+	// Every RPC with the same key goes to the same backend.
+	first, err := p.Pick(balancer.PickInfo{Ctx: WithKey(context.Background(), "1234")})
+	test.AssertNotError(t, err, "Pick failed")
+	for range 10 {
+		got, err := p.Pick(balancer.PickInfo{Ctx: WithKey(context.Background(), "1234")})
+		test.AssertNotError(t, err, "Pick failed")
+		test.AssertEquals(t, got.SubConn, first.SubConn)
+	}
+
+	// Different keys are spread across the backends.
+	picked := make(map[balancer.SubConn]bool)
+	for i := range 100 {
+		got, err := p.Pick(balancer.PickInfo{Ctx: WithKey(context.Background(), fmt.Sprint(i))})
+		test.AssertNotError(t, err, "Pick failed")
+		picked[got.SubConn] = true
+	}
+	test.AssertEquals(t, len(picked), len(subConns))
+}
+
+func TestPickerAffinityStableAcrossBuilds(t *testing.T) {
+	t.Parallel()
+
+	// A picker built from the same backends, as every WFE's is, picks the
+	// same backend for a key, regardless of the order of the backends.
+	p1, _ := setupTest(3)
+	p2, _ := setupTest(3)
+	for i := range 20 {
+		ctx := WithKey(context.Background(), fmt.Sprint(i))
+		got1, err := p1.Pick(balancer.PickInfo{Ctx: ctx})
+		test.AssertNotError(t, err, "Pick failed")
+		got2, err := p2.Pick(balancer.PickInfo{Ctx: ctx})
+		test.AssertNotError(t, err, "Pick failed")
+		test.AssertEquals(t, got1.SubConn.(*subConn).addr, got2.SubConn.(*subConn).addr)
+	}
+}
+
+func TestPickerRoundRobinWithoutKey(t *testing.T) {
+	t.Parallel()
+	p, subConns := setupTest(3)
+
+	picked := make(map[balancer.SubConn]int)
+	for range 6 {
+		got, err := p.Pick(balancer.PickInfo{Ctx: context.Background()})
+		test.AssertNotError(t, err, "Pick failed")
+		picked[got.SubConn]++
+	}
+	for _, sc := range subConns {
+		test.AssertEquals(t, picked[sc], 2)
+	}
+}
+
+func TestBuildNoBackends(t *testing.T) {
+	t.Parallel()
+	p, _ := setupTest(0)
+	_, err := p.Pick(balancer.PickInfo{Ctx: WithKey(context.Background(), "1234")})
+	test.AssertErrorIs(t, err, balancer.ErrNoSubConnAvailable)
+}
+
+func setupTest(n int) (balancer.Picker, []*subConn) {
+	var subConns []*subConn
+	bi := base.PickerBuildInfo{
+		ReadySCs: make(map[balancer.SubConn]base.SubConnInfo),
+	}
+	for i := range n {
+		sc := &subConn{addr: fmt.Sprintf("10.77.77.%d:8080", 77+i)}
+		bi.ReadySCs[sc] = base.SubConnInfo{Address: resolver.Address{Addr: sc.addr}}
+		subConns = append(subConns, sc)
+	}
+	return (&Balancer{}).Build(bi), subConns
+}
+
+// subConn is a test mock which implements the balancer.SubConn interface.
+type subConn struct {
+	balancer.SubConn
+	addr string
+}
@@ -1,15 +1,18 @@
 package grpc
 
 import (
+	"context"
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"net"
 
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
 	"github.com/jmhodges/clock"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 
 	"github.com/letsencrypt/boulder/cmd"
 	bcreds "github.com/letsencrypt/boulder/grpc/creds"
@@ -30,6 +33,7 @@ import (
 // a client certificate and validates the server certificate based
 // on the provided *tls.Config.
 // It dials the remote service and returns a grpc.ClientConn if successful.
+// If the service was declared by ServeInProcess, it's dialed in memory.
 func ClientSetup(c *cmd.GRPCClientConfig, tlsConfig *tls.Config, statsRegistry prometheus.Registerer, clk clock.Clock) (*grpc.ClientConn, error) {
 	if c == nil {
 		return nil, errors.New("nil gRPC client config provided: JSON config is probably missing a fooService section")
@@ -55,14 +59,27 @@ func ClientSetup(c *cmd.GRPCClientConfig, tlsConfig *tls.Config, statsRegistry p
 		cmi.metrics.grpcMetrics.StreamClientInterceptor(),
 	}
 
+	if c.Compression != nil {
+		unaryInterceptors = append(unaryInterceptors, newClientCompressionInterceptor(c.Compression).Unary)
+	}
+
+	compressionStats, err := newCompressionStatsHandler(statsRegistry)
+	if err != nil {
+		return nil, err
+	}
+
 	target, hostOverride, err := c.MakeTargetAndHostOverride()
 	if err != nil {
 		return nil, err
 	}
 
-	creds := bcreds.NewClientCredentials(tlsConfig.RootCAs, tlsConfig.Certificates, hostOverride)
-	return grpc.NewClient(
-		target,
+	var sessionCache tls.ClientSessionCache
+	if !c.DisableTLSSessionResumption {
+		sessionCache = tls.NewLRUClientSessionCache(c.TLSSessionCacheSize)
+	}
+
+	creds := bcreds.NewClientCredentials(tlsConfig.RootCAs, tlsConfig.Certificates, hostOverride, sessionCache, c.RequireOCSPStaple, c.ServiceNames)
+	options := []grpc.DialOption{
 		grpc.WithDefaultServiceConfig(
 			fmt.Sprintf(
 				// By setting the service name to an empty string in
@@ -82,7 +99,23 @@ func ClientSetup(c *cmd.GRPCClientConfig, tlsConfig *tls.Config, statsRegistry p
 		grpc.WithChainUnaryInterceptor(unaryInterceptors...),
 		grpc.WithChainStreamInterceptor(streamInterceptors...),
 		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
-	)
+		grpc.WithStatsHandler(compressionStats),
+	}
+	inProcessListener := inProcessListener(hostOverride)
+	if inProcessListener != nil {
+		target = "passthrough:///" + hostOverride
+		options = append(options, grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return inProcessListener.dial(ctx)
+		}))
+	}
+	if c.KeepaliveTime.Duration > 0 {
+		options = append(options, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                c.KeepaliveTime.Duration,
+			Timeout:             c.KeepaliveTimeout.Duration,
+			PermitWithoutStream: c.KeepalivePermitWithoutStream,
+		}))
+	}
+	return grpc.NewClient(target, options...)
 }
 
 // clientMetrics is a struct type used to return registered metrics from
@@ -0,0 +1,274 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/letsencrypt/boulder/cmd"
+
+	// 'grpc/encoding/gzip' is imported for its init function, which registers
+	// the gzip compressor.
+	_ "google.golang.org/grpc/encoding/gzip"
+)
+
+// zstdName is the name of the zstd compressor, as used in the grpc-encoding
+// and grpc-accept-encoding headers.
+const zstdName = "zstd"
+
+// defaultCompressionMinSize is the size, in bytes, of the smallest message
+// which is compressed if the config doesn't specify one. Smaller messages cost
+// more CPU to compress than they save in bandwidth.
+const defaultCompressionMinSize = 1024
+
+func init() {
+	encoding.RegisterCompressor(newZstdCompressor())
+}
+
+// zstdCompressor implements encoding.Compressor using zstd. Encoders and
+// decoders are expensive to allocate, so they're pooled.
+type zstdCompressor struct {
+	encoders sync.Pool
+	decoders sync.Pool
+}
+
+func newZstdCompressor() *zstdCompressor {
+	return &zstdCompressor{
+		encoders: sync.Pool{New: func() any {
+			// NewWriter only fails if given invalid options.
+			enc, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+			return enc
+		}},
+		decoders: sync.Pool{New: func() any {
+			// NewReader only fails if given invalid options.
+			dec, _ := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+			return dec
+		}},
+	}
+}
+
+func (c *zstdCompressor) Name() string {
+	return zstdName
+}
+
+func (c *zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	enc := c.encoders.Get().(*zstd.Encoder)
+	enc.Reset(w)
+	return &zstdWriter{Encoder: enc, pool: &c.encoders}, nil
+}
+
+func (c *zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	dec := c.decoders.Get().(*zstd.Decoder)
+	err := dec.Reset(r)
+	if err != nil {
+		c.decoders.Put(dec)
+		return nil, err
+	}
+	return &zstdReader{Decoder: dec, pool: &c.decoders}, nil
+}
+
+// zstdWriter returns its encoder to the pool once closed.
+type zstdWriter struct {
+	*zstd.Encoder
+	pool *sync.Pool
+}
+
+func (w *zstdWriter) Close() error {
+	err := w.Encoder.Close()
+	w.pool.Put(w.Encoder)
+	return err
+}
+
+// zstdReader returns its decoder to the pool once it's been read to the end.
+type zstdReader struct {
+	*zstd.Decoder
+	pool *sync.Pool
+}
+
+func (r *zstdReader) Read(p []byte) (int, error) {
+	if r.Decoder == nil {
+		return 0, io.EOF
+	}
+	n, err := r.Decoder.Read(p)
+	if errors.Is(err, io.EOF) {
+		r.pool.Put(r.Decoder)
+		r.Decoder = nil
+	}
+	return n, err
+}
+
+// compressionMinSize returns the configured minimum size of a compressed
+// message, or the default if none is configured.
+func compressionMinSize(c *cmd.GRPCCompressionConfig) int {
+	if c.MinSize > 0 {
+		return int(c.MinSize)
+	}
+	return defaultCompressionMinSize
+}
+
+// clientCompressionInterceptor compresses the requests of unary RPCs which are
+// at least minSize bytes long. Requests of streaming RPCs are never compressed,
+// since Boulder's streaming RPCs only stream responses.
+type clientCompressionInterceptor struct {
+	compressor string
+	minSize    int
+}
+
+func newClientCompressionInterceptor(c *cmd.GRPCCompressionConfig) *clientCompressionInterceptor {
+	return &clientCompressionInterceptor{compressor: c.Algorithm, minSize: compressionMinSize(c)}
+}
+
+// Unary implements the grpc.UnaryClientInterceptor interface.
+func (ci *clientCompressionInterceptor) Unary(ctx context.Context, fullMethod string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	msg, ok := req.(proto.Message)
+	if ok && proto.Size(msg) >= ci.minSize {
+		opts = append(opts, grpc.UseCompressor(ci.compressor))
+	}
+	return invoker(ctx, fullMethod, req, reply, cc, opts...)
+}
+
+// serverCompressionInterceptor compresses responses which are at least minSize
+// bytes long, if the client advertised support for the configured compressor.
+// gRPC fixes the compressor of a stream once its headers are sent, so a
+// streaming RPC is compressed only if its first response is long enough.
+type serverCompressionInterceptor struct {
+	compressor string
+	minSize    int
+}
+
+func newServerCompressionInterceptor(c *cmd.GRPCCompressionConfig) *serverCompressionInterceptor {
+	return &serverCompressionInterceptor{compressor: c.Algorithm, minSize: compressionMinSize(c)}
+}
+
+// maybeCompress sets the send compressor of the RPC in ctx if the response is
+// long enough and the client supports the compressor. Otherwise the response
+// is sent uncompressed, even if the request was compressed.
+func (si *serverCompressionInterceptor) maybeCompress(ctx context.Context, resp any) {
+	compressor := encoding.Identity
+	msg, ok := resp.(proto.Message)
+	if ok && proto.Size(msg) >= si.minSize {
+		supported, err := grpc.ClientSupportedCompressors(ctx)
+		if err == nil && slices.Contains(supported, si.compressor) {
+			compressor = si.compressor
+		}
+	}
+	// This only fails if the headers were already sent, in which case the
+	// response is sent with whatever compressor they named.
+	_ = grpc.SetSendCompressor(ctx, compressor)
+}
+
+// Unary implements the grpc.UnaryServerInterceptor interface.
+func (si *serverCompressionInterceptor) Unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	if err == nil {
+		si.maybeCompress(ctx, resp)
+	}
+	return resp, err
+}
+
+// Stream implements the grpc.StreamServerInterceptor interface.
+func (si *serverCompressionInterceptor) Stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &compressingServerStream{ServerStream: ss, si: si})
+}
+
+// compressingServerStream chooses the compressor of a stream when its first
+// response is sent.
+type compressingServerStream struct {
+	grpc.ServerStream
+	si   *serverCompressionInterceptor
+	sent bool
+}
+
+func (s *compressingServerStream) SendMsg(m any) error {
+	if !s.sent {
+		s.si.maybeCompress(s.Context(), m)
+		s.sent = true
+	}
+	return s.ServerStream.SendMsg(m)
+}
+
+// compressionStatsHandler is a stats.Handler which observes the ratio of the
+// compressed to the uncompressed size of each compressed message sent, by
+// service and method.
+type compressionStatsHandler struct {
+	ratio *prometheus.HistogramVec
+}
+
+// newCompressionStatsHandler constructs a compressionStatsHandler whose
+// histogram is registered with the given registry. If called more than once on
+// a single registry, it will gracefully avoid registering a duplicate metric.
+func newCompressionStatsHandler(statsRegistry prometheus.Registerer) (*compressionStatsHandler, error) {
+	ratio := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_compression_ratio",
+		Help:    "Ratio of compressed to uncompressed size of sent gRPC messages, by service, method and compressor",
+		Buckets: []float64{.1, .2, .3, .4, .5, .6, .7, .8, .9, 1, 1.1},
+	}, []string{"service", "method", "compressor"})
+	err := statsRegistry.Register(ratio)
+	if err != nil {
+		are := prometheus.AlreadyRegisteredError{}
+		if errors.As(err, &are) {
+			ratio = are.ExistingCollector.(*prometheus.HistogramVec)
+		} else {
+			return nil, err
+		}
+	}
+	return &compressionStatsHandler{ratio: ratio}, nil
+}
+
+// compressionRPCKey is the context key under which compressionStatsHandler
+// keeps the state of each RPC.
+type compressionRPCKey struct{}
+
+// compressionRPC is the state of an RPC observed by compressionStatsHandler.
+type compressionRPC struct {
+	service, method string
+	// compressor is the name of the compressor of sent messages, taken from
+	// the headers which were sent.
+	compressor atomic.Pointer[string]
+}
+
+// TagRPC implements the stats.Handler interface.
+func (h *compressionStatsHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	service, method := splitMethodName(info.FullMethodName)
+	return context.WithValue(ctx, compressionRPCKey{}, &compressionRPC{service: service, method: method})
+}
+
+// HandleRPC implements the stats.Handler interface.
+func (h *compressionStatsHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	rpc, ok := ctx.Value(compressionRPCKey{}).(*compressionRPC)
+	if !ok {
+		return
+	}
+	switch s := s.(type) {
+	case *stats.OutHeader:
+		rpc.compressor.Store(&s.Compression)
+	case *stats.OutPayload:
+		compressor := rpc.compressor.Load()
+		if compressor == nil || *compressor == "" || *compressor == encoding.Identity || s.Length == 0 {
+			return
+		}
+		h.ratio.With(prometheus.Labels{
+			"service":    rpc.service,
+			"method":     rpc.method,
+			"compressor": *compressor,
+		}).Observe(float64(s.CompressedLength) / float64(s.Length))
+	}
+}
+
+// TagConn implements the stats.Handler interface.
+func (h *compressionStatsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+// HandleConn implements the stats.Handler interface.
+func (h *compressionStatsHandler) HandleConn(context.Context, stats.ConnStats) {}
@@ -0,0 +1,116 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/letsencrypt/boulder/cmd"
+	"github.com/letsencrypt/boulder/grpc/test_proto"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestZstdCompressor(t *testing.T) {
+	t.Parallel()
+
+	c := encoding.GetCompressor(zstdName)
+	test.Assert(t, c != nil, "zstd compressor should be registered")
+	test.Assert(t, encoding.GetCompressor("gzip") != nil, "gzip compressor should be registered")
+
+	// Round-trip a few messages, so that pooled encoders and decoders are
+	// reused.
+	for range 3 {
+		msg := bytes.Repeat([]byte("compressible "), 100)
+		var compressed bytes.Buffer
+		w, err := c.Compress(&compressed)
+		test.AssertNotError(t, err, "Compress failed")
+		_, err = w.Write(msg)
+		test.AssertNotError(t, err, "Write failed")
+		test.AssertNotError(t, w.Close(), "Close failed")
+		test.Assert(t, compressed.Len() < len(msg), "message should have been compressed")
+
+		r, err := c.Decompress(&compressed)
+		test.AssertNotError(t, err, "Decompress failed")
+		decompressed, err := io.ReadAll(r)
+		test.AssertNotError(t, err, "ReadAll failed")
+		test.AssertByteEquals(t, decompressed, msg)
+	}
+}
+
+type echoServer struct {
+	test_proto.UnimplementedChillerServer
+}
+
+// Chill implements ChillerServer.Chill
+func (s *echoServer) Chill(_ context.Context, in *test_proto.Time) (*test_proto.Time, error) {
+	return in, nil
+}
+
+func TestCompression(t *testing.T) {
+	t.Parallel()
+
+	for _, algorithm := range []string{"gzip", zstdName} {
+		t.Run(algorithm, func(t *testing.T) {
+			t.Parallel()
+
+			serverStats, err := newCompressionStatsHandler(metrics.NoopRegisterer)
+			test.AssertNotError(t, err, "creating server stats handler")
+			clientStats, err := newCompressionStatsHandler(metrics.NoopRegisterer)
+			test.AssertNotError(t, err, "creating client stats handler")
+
+			// The server compresses shorter messages than the client.
+			si := newServerCompressionInterceptor(&cmd.GRPCCompressionConfig{Algorithm: algorithm, MinSize: 4})
+			s := grpc.NewServer(grpc.UnaryInterceptor(si.Unary), grpc.StatsHandler(serverStats))
+			test_proto.RegisterChillerServer(s, &echoServer{})
+			lis, err := net.Listen("tcp", "127.0.0.1:0")
+			test.AssertNotError(t, err, "listening")
+			go func() { _ = s.Serve(lis) }()
+			defer s.Stop()
+
+			ci := newClientCompressionInterceptor(&cmd.GRPCCompressionConfig{Algorithm: algorithm, MinSize: 8})
+			conn, err := grpc.NewClient(lis.Addr().String(),
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+				grpc.WithUnaryInterceptor(ci.Unary),
+				grpc.WithStatsHandler(clientStats))
+			test.AssertNotError(t, err, "creating client")
+			defer conn.Close()
+			client := test_proto.NewChillerClient(conn)
+
+			labels := prometheus.Labels{"service": "Chiller", "method": "Chill", "compressor": algorithm}
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			// Neither message is long enough to be compressed.
+			_, err = client.Chill(ctx, &test_proto.Time{})
+			test.AssertNotError(t, err, "Chill failed")
+			test.AssertMetricWithLabelsEquals(t, clientStats.ratio, labels, 0)
+
+			// This message is long enough to be compressed by the server, but
+			// not by the client.
+			_, err = client.Chill(ctx, &test_proto.Time{Duration: durationpb.New(time.Second)})
+			test.AssertNotError(t, err, "Chill failed")
+			test.AssertMetricWithLabelsEquals(t, clientStats.ratio, labels, 0)
+
+			// This message is long enough to be compressed by both.
+			_, err = client.Chill(ctx, &test_proto.Time{Duration: durationpb.New(1000*time.Hour + 999*time.Millisecond)})
+			test.AssertNotError(t, err, "Chill failed")
+			test.AssertMetricWithLabelsEquals(t, clientStats.ratio, labels, 1)
+
+			// The server records sent messages after sending them, so wait for
+			// its handlers to return before checking that it compressed the
+			// last two.
+			s.GracefulStop()
+			test.AssertMetricWithLabelsEquals(t, serverStats.ratio, labels, 2)
+		})
+	}
+}
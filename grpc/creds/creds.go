@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"time"
 
 	"google.golang.org/grpc/credentials"
 )
@@ -35,6 +36,15 @@ func (e ErrSANNotAccepted) Error() string {
 		"Got %q, expected one of %q.", e.got, e.expected)
 }
 
+type ErrServiceNameNotAccepted struct {
+	got, expected []string
+}
+
+func (e ErrServiceNameNotAccepted) Error() string {
+	return fmt.Sprintf("boulder/grpc/creds: server certificate is not valid for any accepted service name. "+
+		"Got %q, expected one of %q.", e.got, e.expected)
+}
+
 // clientTransportCredentials is a grpc/credentials.TransportCredentials which supports
 // connecting to, and verifying multiple DNS names
 type clientTransportCredentials struct {
@@ -43,11 +53,27 @@ type clientTransportCredentials struct {
 	// If set, this is used as the hostname to validate on certificates, instead
 	// of the value passed to ClientHandshake by grpc.
 	hostOverride string
+	// If set, TLS sessions are cached here and resumed by later handshakes.
+	sessionCache tls.ClientSessionCache
+	// If set, servers which don't staple an OCSP response are rejected.
+	// Stapled responses are verified whether or not this is set.
+	requireOCSPStaple bool
+	// If set, the server's certificate must be valid for at least one of these
+	// names, and the hostname which was dialed isn't checked.
+	serviceNames []string
 }
 
-// NewClientCredentials returns a new initialized grpc/credentials.TransportCredentials for client usage
-func NewClientCredentials(rootCAs *x509.CertPool, clientCerts []tls.Certificate, hostOverride string) credentials.TransportCredentials {
-	return &clientTransportCredentials{rootCAs, clientCerts, hostOverride}
+// NewClientCredentials returns a new initialized grpc/credentials.TransportCredentials for client usage.
+// If sessionCache is non-nil, it is used to resume TLS sessions with servers.
+// Any OCSP response stapled by a server is verified, and servers whose
+// certificates it says are revoked are rejected. If requireOCSPStaple is true,
+// servers which don't staple a response are rejected too. If serviceNames is
+// non-empty, servers are verified against those names in place of the
+// hostname which was dialed (or hostOverride), so that backends discovered by
+// SRV lookup can have certificates for a logical service name without
+// carrying their own per-instance hostname.
+func NewClientCredentials(rootCAs *x509.CertPool, clientCerts []tls.Certificate, hostOverride string, sessionCache tls.ClientSessionCache, requireOCSPStaple bool, serviceNames []string) credentials.TransportCredentials {
+	return &clientTransportCredentials{rootCAs, clientCerts, hostOverride, sessionCache, requireOCSPStaple, serviceNames}
 }
 
 // ClientHandshake does the authentication handshake specified by the corresponding
@@ -65,11 +91,30 @@ func (tc *clientTransportCredentials) ClientHandshake(ctx context.Context, addr
 			return nil, nil, err
 		}
 	}
-	conn := tls.Client(rawConn, &tls.Config{
-		ServerName:   host,
-		RootCAs:      tc.roots,
-		Certificates: tc.clients,
-	})
+	config := &tls.Config{
+		ServerName:         host,
+		RootCAs:            tc.roots,
+		Certificates:       tc.clients,
+		ClientSessionCache: tc.sessionCache,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			return verifyOCSPStaple(cs, tc.requireOCSPStaple, time.Now())
+		},
+	}
+	if len(tc.serviceNames) > 0 {
+		// The standard verification checks the certificate against
+		// ServerName, which is still sent as the SNI. Replace it with our own,
+		// which checks the certificate against the service names instead.
+		config.InsecureSkipVerify = true
+		config.VerifyConnection = func(cs tls.ConnectionState) error {
+			chains, err := tc.verifyServiceName(cs)
+			if err != nil {
+				return err
+			}
+			cs.VerifiedChains = chains
+			return verifyOCSPStaple(cs, tc.requireOCSPStaple, time.Now())
+		}
+	}
+	conn := tls.Client(rawConn, config)
 	err = conn.HandshakeContext(ctx)
 	if err != nil {
 		_ = rawConn.Close()
@@ -78,6 +123,38 @@ func (tc *clientTransportCredentials) ClientHandshake(ctx context.Context, addr
 	return conn, nil, nil
 }
 
+// verifyServiceName verifies the server's certificate chain against the
+// client's roots, and checks that the certificate is valid for at least one of
+// the client's service names. It returns the verified chains.
+func (tc *clientTransportCredentials) verifyServiceName(cs tls.ConnectionState) ([][]*x509.Certificate, error) {
+	if len(cs.PeerCertificates) == 0 {
+		return nil, errors.New("boulder/grpc/creds: server presented no certificates")
+	}
+	leaf := cs.PeerCertificates[0]
+	intermediates := x509.NewCertPool()
+	for _, cert := range cs.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	chains, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         tc.roots,
+		Intermediates: intermediates,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range tc.serviceNames {
+		if leaf.VerifyHostname(name) == nil {
+			return chains, nil
+		}
+	}
+	var got []string
+	got = append(got, leaf.DNSNames...)
+	for _, ip := range leaf.IPAddresses {
+		got = append(got, ip.String())
+	}
+	return nil, ErrServiceNameNotAccepted{got, tc.serviceNames}
+}
+
 // ServerHandshake is not implemented for a `clientTransportCredentials`, use
 // a `serverTransportCredentials` if you require `ServerHandshake`.
 func (tc *clientTransportCredentials) ServerHandshake(rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
@@ -101,7 +178,7 @@ func (tc *clientTransportCredentials) RequireTransportSecurity() bool {
 
 // Clone returns a copy of the clientTransportCredentials
 func (tc *clientTransportCredentials) Clone() credentials.TransportCredentials {
-	return NewClientCredentials(tc.roots, tc.clients, tc.hostOverride)
+	return NewClientCredentials(tc.roots, tc.clients, tc.hostOverride, tc.sessionCache, tc.requireOCSPStaple, tc.serviceNames)
 }
 
 // OverrideServerName is not implemented and here only to satisfy the interface
@@ -114,15 +191,25 @@ func (tc *clientTransportCredentials) OverrideServerName(serverNameOverride stri
 type serverTransportCredentials struct {
 	serverConfig *tls.Config
 	acceptedSANs map[string]struct{}
+	stapler      *OCSPStapler
 }
 
-// NewServerCredentials returns a new initialized grpc/credentials.TransportCredentials for server usage
-func NewServerCredentials(serverConfig *tls.Config, acceptedSANs map[string]struct{}) (credentials.TransportCredentials, error) {
+// NewServerCredentials returns a new initialized grpc/credentials.TransportCredentials for server usage.
+// If stapler is non-nil, the server's certificate is presented with the OCSP
+// response it has fetched stapled, in place of the certificates in
+// serverConfig.
+func NewServerCredentials(serverConfig *tls.Config, acceptedSANs map[string]struct{}, stapler *OCSPStapler) (credentials.TransportCredentials, error) {
 	if serverConfig == nil {
 		return nil, ErrNilServerConfig
 	}
 
-	return &serverTransportCredentials{serverConfig, acceptedSANs}, nil
+	if stapler != nil {
+		serverConfig = serverConfig.Clone()
+		serverConfig.Certificates = nil
+		serverConfig.GetCertificate = stapler.GetCertificate
+	}
+
+	return &serverTransportCredentials{serverConfig, acceptedSANs, stapler}, nil
 }
 
 // validateClient checks a peer's client certificate's SAN entries against
@@ -229,7 +316,7 @@ func (tc *serverTransportCredentials) RequireTransportSecurity() bool {
 
 // Clone returns a copy of the serverTransportCredentials
 func (tc *serverTransportCredentials) Clone() credentials.TransportCredentials {
-	clone, _ := NewServerCredentials(tc.serverConfig, tc.acceptedSANs)
+	clone, _ := NewServerCredentials(tc.serverConfig, tc.acceptedSANs, tc.stapler)
 	return clone
 }
 
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/jmhodges/clock"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/letsencrypt/boulder/test"
 )
@@ -30,25 +31,25 @@ func TestServerTransportCredentials(t *testing.T) {
 	servTLSConfig := &tls.Config{}
 
 	// NewServerCredentials with a nil serverTLSConfig should return an error
-	_, err := NewServerCredentials(nil, acceptedSANs)
+	_, err := NewServerCredentials(nil, acceptedSANs, nil)
 	test.AssertEquals(t, err, ErrNilServerConfig)
 
 	// A creds with a nil acceptedSANs list should consider any peer valid
-	wrappedCreds, err := NewServerCredentials(servTLSConfig, nil)
+	wrappedCreds, err := NewServerCredentials(servTLSConfig, nil, nil)
 	test.AssertNotError(t, err, "NewServerCredentials failed with nil acceptedSANs")
 	bcreds := wrappedCreds.(*serverTransportCredentials)
 	err = bcreds.validateClient(tls.ConnectionState{})
 	test.AssertNotError(t, err, "validateClient() errored for emptyState")
 
 	// A creds with a empty acceptedSANs list should consider any peer valid
-	wrappedCreds, err = NewServerCredentials(servTLSConfig, map[string]struct{}{})
+	wrappedCreds, err = NewServerCredentials(servTLSConfig, map[string]struct{}{}, nil)
 	test.AssertNotError(t, err, "NewServerCredentials failed with empty acceptedSANs")
 	bcreds = wrappedCreds.(*serverTransportCredentials)
 	err = bcreds.validateClient(tls.ConnectionState{})
 	test.AssertNotError(t, err, "validateClient() errored for emptyState")
 
 	// A properly-initialized creds should fail to verify an empty ConnectionState
-	bcreds = &serverTransportCredentials{servTLSConfig, acceptedSANs}
+	bcreds = &serverTransportCredentials{servTLSConfig, acceptedSANs, nil}
 	err = bcreds.validateClient(tls.ConnectionState{})
 	test.AssertEquals(t, err, ErrEmptyPeerCerts)
 
@@ -73,7 +74,7 @@ func TestServerTransportCredentials(t *testing.T) {
 	acceptedIPSans := map[string]struct{}{
 		"127.0.0.1": {},
 	}
-	bcreds = &serverTransportCredentials{servTLSConfig, acceptedIPSans}
+	bcreds = &serverTransportCredentials{servTLSConfig, acceptedIPSans, nil}
 	err = bcreds.validateClient(tls.ConnectionState{
 		PeerCertificates: []*x509.Certificate{goodCert},
 	})
@@ -110,7 +111,7 @@ func TestClientTransportCredentials(t *testing.T) {
 	serverB := httptest.NewUnstartedServer(nil)
 	serverB.TLS = &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{derB}, PrivateKey: priv}}}
 
-	tc := NewClientCredentials(roots, []tls.Certificate{}, "")
+	tc := NewClientCredentials(roots, []tls.Certificate{}, "", nil, false, nil)
 
 	serverA.StartTLS()
 	defer serverA.Close()
@@ -174,6 +175,120 @@ func TestClientTransportCredentials(t *testing.T) {
 	stop <- struct{}{}
 }
 
+func TestClientSessionResumption(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "failed to generate test key")
+	temp := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		DNSNames:              []string{"A"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(1, 0, 0),
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, temp, temp, priv.Public(), priv)
+	test.AssertNotError(t, err, "x509.CreateCertificate failed")
+	cert, err := x509.ParseCertificate(der)
+	test.AssertNotError(t, err, "x509.ParseCertificate failed")
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+	serverConfig := &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: priv}}}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	test.AssertNotError(t, err, "net.Listen failed")
+	defer func() {
+		_ = ln.Close()
+	}()
+	go func() {
+		for {
+			rawConn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			// Send a byte after the handshake, so that the client reads the
+			// session ticket which follows the handshake in TLS 1.3.
+			conn := tls.Server(rawConn, serverConfig)
+			_, _ = conn.Write([]byte{0})
+			_ = conn.Close()
+		}
+	}()
+
+	handshake := func(tc credentials.TransportCredentials) bool {
+		t.Helper()
+		rawConn, err := net.Dial("tcp", ln.Addr().String())
+		test.AssertNotError(t, err, "net.Dial failed")
+		conn, _, err := tc.ClientHandshake(context.Background(), "A:2020", rawConn)
+		test.AssertNotError(t, err, "tc.ClientHandshake failed")
+		defer func() {
+			_ = conn.Close()
+		}()
+		_, err = conn.Read(make([]byte, 1))
+		test.AssertNotError(t, err, "reading from server")
+		return conn.(*tls.Conn).ConnectionState().DidResume
+	}
+
+	tc := NewClientCredentials(roots, nil, "", tls.NewLRUClientSessionCache(1), false, nil)
+	test.Assert(t, !handshake(tc), "first handshake should not resume a session")
+	test.Assert(t, handshake(tc), "second handshake should resume a session")
+	test.Assert(t, handshake(tc.Clone()), "cloned credentials should share the session cache")
+
+	tc = NewClientCredentials(roots, nil, "", nil, false, nil)
+	test.Assert(t, !handshake(tc), "first handshake without a cache should not resume a session")
+	test.Assert(t, !handshake(tc), "second handshake without a cache should not resume a session")
+}
+
+func TestClientTransportCredentialsServiceNames(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "failed to generate test key")
+	temp := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		DNSNames:              []string{"sa.boulder"},
+		NotBefore:             time.Unix(1000, 0),
+		NotAfter:              time.Now().AddDate(1, 0, 0),
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, temp, temp, priv.Public(), priv)
+	test.AssertNotError(t, err, "x509.CreateCertificate failed")
+	cert, err := x509.ParseCertificate(der)
+	test.AssertNotError(t, err, "x509.ParseCertificate failed")
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+
+	server := httptest.NewUnstartedServer(nil)
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: priv}}}
+	server.StartTLS()
+	defer server.Close()
+
+	// The address of a backend discovered by SRV lookup.
+	const instanceAddr = "0a4d4d4d.addr.dc1.consul:9095"
+	handshake := func(tc credentials.TransportCredentials) error {
+		t.Helper()
+		rawConn, err := net.Dial("tcp", server.Listener.Addr().String())
+		test.AssertNotError(t, err, "net.Dial failed")
+		conn, _, err := tc.ClientHandshake(context.Background(), instanceAddr, rawConn)
+		if err == nil {
+			_ = conn.Close()
+		}
+		return err
+	}
+
+	err = handshake(NewClientCredentials(roots, nil, "", nil, false, nil))
+	test.AssertError(t, err, "handshake verified the instance hostname")
+
+	err = handshake(NewClientCredentials(roots, nil, "", nil, false, []string{"ra.boulder", "sa.boulder"}))
+	test.AssertNotError(t, err, "handshake with an accepted service name failed")
+
+	err = handshake(NewClientCredentials(roots, nil, "", nil, false, []string{"ra.boulder"}))
+	var errServiceNameNotAccepted ErrServiceNameNotAccepted
+	test.AssertErrorWraps(t, err, &errServiceNameNotAccepted)
+
+	// The certificate chain is still verified.
+	err = handshake(NewClientCredentials(x509.NewCertPool(), nil, "", nil, false, []string{"sa.boulder"}))
+	var errUnknownAuthority x509.UnknownAuthorityError
+	test.AssertErrorWraps(t, err, &errUnknownAuthority)
+}
+
 type brokenConn struct{}
 
 func (bc *brokenConn) Read([]byte) (int, error) {
@@ -192,7 +307,7 @@ func (bc *brokenConn) SetReadDeadline(time.Time) error  { return nil }
 func (bc *brokenConn) SetWriteDeadline(time.Time) error { return nil }
 
 func TestClientReset(t *testing.T) {
-	tc := NewClientCredentials(nil, []tls.Certificate{}, "")
+	tc := NewClientCredentials(nil, []tls.Certificate{}, "", nil, false, nil)
 	_, _, err := tc.ClientHandshake(context.Background(), "T:1010", &brokenConn{})
 	test.AssertError(t, err, "ClientHandshake succeeded with brokenConn")
 	var netErr net.Error
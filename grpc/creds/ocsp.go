@@ -0,0 +1,197 @@
+package creds
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"golang.org/x/crypto/ocsp"
+
+	blog "github.com/letsencrypt/boulder/log"
+)
+
+var (
+	ErrNoOCSPStaple = errors.New(
+		"boulder/grpc/creds: server did not staple an OCSP response")
+)
+
+// OCSPStapler fetches and caches an OCSP response for a gRPC server's
+// certificate, so that it can be stapled to every handshake.
+type OCSPStapler struct {
+	cert         tls.Certificate
+	issuer       *x509.Certificate
+	responderURL string
+	client       *http.Client
+	clk          clock.Clock
+	log          blog.Logger
+
+	mu     sync.RWMutex
+	staple *ocsp.Response
+}
+
+// NewOCSPStapler returns an OCSPStapler for cert, which must have been issued
+// by issuer. If responderURL is empty, the first OCSP server listed in cert is
+// used. No response is stapled until the first successful call to Refresh.
+func NewOCSPStapler(cert tls.Certificate, issuer *x509.Certificate, responderURL string, clk clock.Clock, logger blog.Logger) (*OCSPStapler, error) {
+	if len(cert.Certificate) == 0 {
+		return nil, errors.New("boulder/grpc/creds: no certificate to staple OCSP responses to")
+	}
+	if cert.Leaf == nil {
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			return nil, fmt.Errorf("parsing certificate: %w", err)
+		}
+		cert.Leaf = leaf
+	}
+	err := cert.Leaf.CheckSignatureFrom(issuer)
+	if err != nil {
+		return nil, fmt.Errorf("certificate was not issued by OCSP issuer %q: %w", issuer.Subject, err)
+	}
+	if responderURL == "" {
+		if len(cert.Leaf.OCSPServer) == 0 {
+			return nil, errors.New("boulder/grpc/creds: no OCSP responder configured or listed in certificate")
+		}
+		responderURL = cert.Leaf.OCSPServer[0]
+	}
+	return &OCSPStapler{
+		cert:         cert,
+		issuer:       issuer,
+		responderURL: responderURL,
+		client:       &http.Client{Timeout: 10 * time.Second},
+		clk:          clk,
+		log:          logger,
+	}, nil
+}
+
+// Refresh fetches a new OCSP response for the certificate, and staples it to
+// subsequent handshakes if it's valid and at least as recent as the current
+// one.
+func (s *OCSPStapler) Refresh(ctx context.Context) error {
+	reqBytes, err := ocsp.CreateRequest(s.cert.Leaf, s.issuer, nil)
+	if err != nil {
+		return fmt.Errorf("creating OCSP request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.responderURL, bytes.NewReader(reqBytes))
+	if err != nil {
+		return fmt.Errorf("creating OCSP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/ocsp-request")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetching OCSP response from %q: %w", s.responderURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching OCSP response from %q: status %d", s.responderURL, resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading OCSP response from %q: %w", s.responderURL, err)
+	}
+
+	staple, err := ocsp.ParseResponseForCert(body, s.cert.Leaf, s.issuer)
+	if err != nil {
+		return fmt.Errorf("parsing OCSP response from %q: %w", s.responderURL, err)
+	}
+	err = checkOCSPResponseFresh(staple, s.clk.Now())
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.staple != nil && staple.ThisUpdate.Before(s.staple.ThisUpdate) {
+		return fmt.Errorf("OCSP response from %q is older than the one already stapled", s.responderURL)
+	}
+	s.staple = staple
+	return nil
+}
+
+// Run refreshes the stapled OCSP response every interval until ctx is
+// canceled. While there's no response to staple, or after a failed refresh, it
+// retries every minute, or every interval if that's shorter.
+func (s *OCSPStapler) Run(ctx context.Context, interval time.Duration) {
+	retry := min(interval, time.Minute)
+	next := interval
+	s.mu.RLock()
+	if s.staple == nil {
+		next = retry
+	}
+	s.mu.RUnlock()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.clk.After(next):
+		}
+		next = interval
+		err := s.Refresh(ctx)
+		if err != nil {
+			s.log.Errf("Refreshing stapled OCSP response for gRPC server certificate: %s", err)
+			next = retry
+		}
+	}
+}
+
+// GetCertificate returns the certificate with the current OCSP response
+// stapled, if it hasn't expired. It's suitable for use as a
+// tls.Config.GetCertificate callback.
+func (s *OCSPStapler) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	s.mu.RLock()
+	staple := s.staple
+	s.mu.RUnlock()
+
+	cert := s.cert
+	if staple != nil && s.clk.Now().Before(staple.NextUpdate) {
+		cert.OCSPStaple = staple.Raw
+	}
+	return &cert, nil
+}
+
+// checkOCSPResponseFresh returns an error if resp isn't valid at now.
+func checkOCSPResponseFresh(resp *ocsp.Response, now time.Time) error {
+	if resp.ThisUpdate.After(now) {
+		return fmt.Errorf("OCSP response thisUpdate %s is in the future", resp.ThisUpdate)
+	}
+	if resp.NextUpdate.IsZero() || !resp.NextUpdate.After(now) {
+		return fmt.Errorf("OCSP response nextUpdate %s has passed", resp.NextUpdate)
+	}
+	return nil
+}
+
+// verifyOCSPStaple checks the OCSP response stapled by a server. If one was
+// stapled, it must be valid, fresh, and say that the server's certificate is
+// good. If none was stapled, the connection is only rejected if required is
+// true.
+func verifyOCSPStaple(cs tls.ConnectionState, required bool, now time.Time) error {
+	if len(cs.OCSPResponse) == 0 {
+		if required {
+			return ErrNoOCSPStaple
+		}
+		return nil
+	}
+	if len(cs.VerifiedChains) == 0 || len(cs.VerifiedChains[0]) < 2 {
+		return errors.New("boulder/grpc/creds: no issuer with which to verify stapled OCSP response")
+	}
+	leaf, issuer := cs.VerifiedChains[0][0], cs.VerifiedChains[0][1]
+	resp, err := ocsp.ParseResponseForCert(cs.OCSPResponse, leaf, issuer)
+	if err != nil {
+		return fmt.Errorf("boulder/grpc/creds: parsing stapled OCSP response: %w", err)
+	}
+	err = checkOCSPResponseFresh(resp, now)
+	if err != nil {
+		return fmt.Errorf("boulder/grpc/creds: stapled %w", err)
+	}
+	if resp.Status != ocsp.Good {
+		return fmt.Errorf("boulder/grpc/creds: server certificate %x is revoked according to its stapled OCSP response", leaf.SerialNumber)
+	}
+	return nil
+}
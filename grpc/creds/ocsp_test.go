@@ -0,0 +1,179 @@
+package creds
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"golang.org/x/crypto/ocsp"
+
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestOCSPStapling(t *testing.T) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating CA key")
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "internal CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(1, 0, 0),
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	test.AssertNotError(t, err, "creating CA certificate")
+	ca, err := x509.ParseCertificate(caDER)
+	test.AssertNotError(t, err, "parsing CA certificate")
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	// The responder says the server's certificate has whatever status is
+	// stored in status.
+	var status atomic.Int64
+	responder := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		test.AssertNotError(t, err, "reading OCSP request")
+		req, err := ocsp.ParseRequest(body)
+		test.AssertNotError(t, err, "parsing OCSP request")
+		resp, err := ocsp.CreateResponse(ca, ca, ocsp.Response{
+			Status:       int(status.Load()),
+			SerialNumber: req.SerialNumber,
+			ThisUpdate:   time.Now().Add(-time.Minute),
+			NextUpdate:   time.Now().Add(time.Hour),
+			RevokedAt:    time.Now().Add(-time.Minute),
+		}, caKey)
+		test.AssertNotError(t, err, "creating OCSP response")
+		_, _ = w.Write(resp)
+	}))
+	defer responder.Close()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating server key")
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		DNSNames:     []string{"A"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(1, 0, 0),
+		OCSPServer:   []string{responder.URL},
+	}, ca, key.Public(), caKey)
+	test.AssertNotError(t, err, "creating server certificate")
+	leaf, err := x509.ParseCertificate(der)
+	test.AssertNotError(t, err, "parsing server certificate")
+	cert := tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+
+	_, err = NewOCSPStapler(cert, leaf, "", clock.New(), blog.NewMock())
+	test.AssertError(t, err, "NewOCSPStapler accepted the wrong issuer")
+	stapler, err := NewOCSPStapler(cert, ca, "", clock.New(), blog.NewMock())
+	test.AssertNotError(t, err, "NewOCSPStapler failed")
+
+	serverCreds, err := NewServerCredentials(&tls.Config{Certificates: []tls.Certificate{cert}}, nil, stapler)
+	test.AssertNotError(t, err, "NewServerCredentials failed")
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	test.AssertNotError(t, err, "net.Listen failed")
+	defer func() {
+		_ = ln.Close()
+	}()
+	go func() {
+		for {
+			rawConn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn, _, err := serverCreds.ServerHandshake(rawConn)
+			if err != nil {
+				_ = rawConn.Close()
+				continue
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	handshake := func(requireOCSPStaple bool) error {
+		t.Helper()
+		rawConn, err := net.Dial("tcp", ln.Addr().String())
+		test.AssertNotError(t, err, "net.Dial failed")
+		defer func() {
+			_ = rawConn.Close()
+		}()
+		clientCreds := NewClientCredentials(roots, nil, "", nil, requireOCSPStaple, nil)
+		_, _, err = clientCreds.ClientHandshake(context.Background(), "A:2020", rawConn)
+		return err
+	}
+
+	// Before the first refresh there's nothing to staple, which only matters
+	// to clients which require a staple.
+	err = handshake(false)
+	test.AssertNotError(t, err, "handshake without staple failed")
+	err = handshake(true)
+	test.AssertErrorIs(t, err, ErrNoOCSPStaple)
+
+	status.Store(ocsp.Good)
+	err = stapler.Refresh(context.Background())
+	test.AssertNotError(t, err, "refreshing good staple")
+	err = handshake(true)
+	test.AssertNotError(t, err, "handshake with good staple failed")
+
+	// Once the certificate is revoked, clients refuse to connect whether or
+	// not they require a staple.
+	status.Store(ocsp.Revoked)
+	err = stapler.Refresh(context.Background())
+	test.AssertNotError(t, err, "refreshing revoked staple")
+	err = handshake(false)
+	test.AssertError(t, err, "handshake with revoked staple succeeded")
+	test.AssertContains(t, err.Error(), "is revoked according to its stapled OCSP response")
+}
+
+func TestVerifyOCSPStapleExpired(t *testing.T) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating CA key")
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "internal CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(1, 0, 0),
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	test.AssertNotError(t, err, "creating CA certificate")
+	ca, err := x509.ParseCertificate(caDER)
+	test.AssertNotError(t, err, "parsing CA certificate")
+	leaf := &x509.Certificate{SerialNumber: big.NewInt(2)}
+
+	now := time.Now()
+	resp, err := ocsp.CreateResponse(ca, ca, ocsp.Response{
+		Status:       ocsp.Good,
+		SerialNumber: leaf.SerialNumber,
+		ThisUpdate:   now.Add(-2 * time.Hour),
+		NextUpdate:   now.Add(-time.Hour),
+	}, caKey)
+	test.AssertNotError(t, err, "creating OCSP response")
+
+	cs := tls.ConnectionState{
+		OCSPResponse:   resp,
+		VerifiedChains: [][]*x509.Certificate{{leaf, ca}},
+	}
+	err = verifyOCSPStaple(cs, false, now)
+	test.AssertError(t, err, "expired staple accepted")
+	test.AssertContains(t, err.Error(), "nextUpdate")
+	err = verifyOCSPStaple(cs, false, now.Add(-90*time.Minute))
+	test.AssertNotError(t, err, "fresh staple rejected")
+}
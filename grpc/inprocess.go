@@ -0,0 +1,126 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"sync"
+)
+
+// inProcess holds a listener for each name declared by ServeInProcess.
+var inProcess struct {
+	sync.Mutex
+	listeners map[string]*pipeListener
+}
+
+// ServeInProcess declares that gRPC servers for the given names run in this
+// process. A server built afterwards whose certificate includes one of the
+// names serves on an in-memory listener instead of its configured address, and
+// a client set up afterwards whose host override is one of the names connects
+// to that listener instead of looking up its configured target. Connections are
+// still authenticated with TLS. Clients which connect before the server starts
+// wait for it, subject to their RPC deadlines.
+//
+// It's used by boulder-combined to run several services in one process.
+func ServeInProcess(names ...string) {
+	inProcess.Lock()
+	defer inProcess.Unlock()
+	if inProcess.listeners == nil {
+		inProcess.listeners = make(map[string]*pipeListener)
+	}
+	for _, name := range names {
+		if inProcess.listeners[name] == nil {
+			inProcess.listeners[name] = &pipeListener{
+				name:   name,
+				conns:  make(chan net.Conn),
+				closed: make(chan struct{}),
+			}
+		}
+	}
+}
+
+// inProcessListener returns the in-memory listener for name, or nil if name
+// wasn't declared by ServeInProcess.
+func inProcessListener(name string) *pipeListener {
+	inProcess.Lock()
+	defer inProcess.Unlock()
+	return inProcess.listeners[name]
+}
+
+// inProcessListenerFor returns the in-memory listener for the first name in
+// the certificate of tlsConfig which was declared by ServeInProcess, or nil if
+// there isn't one.
+func inProcessListenerFor(tlsConfig *tls.Config) (*pipeListener, error) {
+	if len(tlsConfig.Certificates) == 0 || len(tlsConfig.Certificates[0].Certificate) == 0 {
+		return nil, nil
+	}
+	leaf := tlsConfig.Certificates[0].Leaf
+	if leaf == nil {
+		var err error
+		leaf, err = x509.ParseCertificate(tlsConfig.Certificates[0].Certificate[0])
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range leaf.DNSNames {
+		l := inProcessListener(name)
+		if l != nil {
+			return l, nil
+		}
+	}
+	return nil, nil
+}
+
+// pipeListener is a net.Listener whose connections are made in memory, by
+// dial, rather than over the network.
+type pipeListener struct {
+	name      string
+	conns     chan net.Conn
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// dial returns a connection to the listener once it's been accepted.
+func (l *pipeListener) dial(ctx context.Context) (net.Conn, error) {
+	client, server := net.Pipe()
+	select {
+	case l.conns <- server:
+		return client, nil
+	case <-l.closed:
+		_ = client.Close()
+		_ = server.Close()
+		return nil, net.ErrClosed
+	case <-ctx.Done():
+		_ = client.Close()
+		_ = server.Close()
+		return nil, ctx.Err()
+	}
+}
+
+// Accept implements net.Listener.
+func (l *pipeListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+// Close implements net.Listener. Once closed, a listener can't be reopened.
+func (l *pipeListener) Close() error {
+	l.closeOnce.Do(func() { close(l.closed) })
+	return nil
+}
+
+// Addr implements net.Listener.
+func (l *pipeListener) Addr() net.Addr {
+	return pipeAddr(l.name)
+}
+
+// pipeAddr is the address of a pipeListener.
+type pipeAddr string
+
+func (a pipeAddr) Network() string { return "inprocess" }
+func (a pipeAddr) String() string  { return string(a) }
@@ -0,0 +1,117 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/letsencrypt/boulder/cmd"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestPipeListener(t *testing.T) {
+	t.Parallel()
+	ServeInProcess("pipe-listener.boulder")
+	l := inProcessListener("pipe-listener.boulder")
+	test.Assert(t, l != nil, "declared name has no listener")
+	test.Assert(t, inProcessListener("undeclared.boulder") == nil, "undeclared name has a listener")
+	test.AssertEquals(t, l.Addr().String(), "pipe-listener.boulder")
+
+	accepted := make(chan net.Conn)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			t.Errorf("Accept failed: %s", err)
+		}
+		accepted <- conn
+	}()
+	client, err := l.dial(context.Background())
+	test.AssertNotError(t, err, "dial failed")
+	server := <-accepted
+	go func() {
+		_, _ = client.Write([]byte("hello"))
+	}()
+	buf := make([]byte, 5)
+	_, err = server.Read(buf)
+	test.AssertNotError(t, err, "reading from accepted conn")
+	test.AssertEquals(t, string(buf), "hello")
+
+	// Nothing accepts this dial, so it waits until it times out.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = l.dial(ctx)
+	test.AssertErrorIs(t, err, context.DeadlineExceeded)
+
+	test.AssertNotError(t, l.Close(), "Close failed")
+	test.AssertNotError(t, l.Close(), "second Close failed")
+	_, err = l.Accept()
+	test.AssertErrorIs(t, err, net.ErrClosed)
+	_, err = l.dial(context.Background())
+	test.AssertErrorIs(t, err, net.ErrClosed)
+}
+
+func TestInProcessListenerFor(t *testing.T) {
+	t.Parallel()
+	_, cert := test.ThrowAwayCert(t, clock.NewFake())
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{cert.Raw}}}}
+
+	l, err := inProcessListenerFor(tlsConfig)
+	test.AssertNotError(t, err, "inProcessListenerFor failed")
+	test.Assert(t, l == nil, "undeclared certificate name has a listener")
+
+	ServeInProcess(cert.DNSNames[0])
+	l, err = inProcessListenerFor(tlsConfig)
+	test.AssertNotError(t, err, "inProcessListenerFor failed")
+	test.Assert(t, l == inProcessListener(cert.DNSNames[0]), "wrong listener for declared certificate name")
+
+	l, err = inProcessListenerFor(&tls.Config{})
+	test.AssertNotError(t, err, "inProcessListenerFor failed")
+	test.Assert(t, l == nil, "config without a certificate has a listener")
+}
+
+func TestInProcessRoundTrip(t *testing.T) {
+	t.Parallel()
+	ServeInProcess("round-trip.boulder")
+	l := inProcessListener("round-trip.boulder")
+
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go func() {
+		err := server.Serve(l)
+		if err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			t.Errorf("Serve failed: %s", err)
+		}
+	}()
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///round-trip.boulder",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return l.dial(ctx)
+		}))
+	test.AssertNotError(t, err, "NewClient failed")
+	defer conn.Close()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	test.AssertNotError(t, err, "health check failed")
+	test.AssertEquals(t, resp.Status, healthpb.HealthCheckResponse_SERVING)
+}
+
+func TestClientSetupInProcess(t *testing.T) {
+	t.Parallel()
+	ServeInProcess("client-setup.boulder")
+	cfg := &cmd.GRPCClientConfig{ServerAddress: "localhost:8080", HostOverride: "client-setup.boulder"}
+	client, err := ClientSetup(cfg, &tls.Config{}, metrics.NoopRegisterer, clock.NewFake())
+	test.AssertNotError(t, err, "ClientSetup failed")
+	test.AssertEquals(t, client.Target(), "passthrough:///client-setup.boulder")
+}
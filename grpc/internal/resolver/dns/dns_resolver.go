@@ -38,6 +38,7 @@ import (
 	"google.golang.org/grpc/serviceconfig"
 
 	"github.com/letsencrypt/boulder/bdns"
+	"github.com/letsencrypt/boulder/grpc/affinitybalancer"
 	"github.com/letsencrypt/boulder/grpc/internal/backoff"
 	"github.com/letsencrypt/boulder/grpc/noncebalancer"
 )
@@ -54,6 +55,7 @@ var (
 func init() {
 	resolver.Register(NewDefaultSRVBuilder())
 	resolver.Register(NewNonceSRVBuilder())
+	resolver.Register(NewAffinitySRVBuilder())
 }
 
 const defaultDNSSvrPort = "53"
@@ -96,6 +98,13 @@ func NewNonceSRVBuilder() resolver.Builder {
 	return &srvBuilder{scheme: noncebalancer.SRVResolverScheme, balancer: noncebalancer.Name}
 }
 
+// NewAffinitySRVBuilder creates a srvBuilder which is used to factory SRV DNS
+// resolvers with a custom grpc.Balancer which sends RPCs with the same
+// affinity key to the same backend.
+func NewAffinitySRVBuilder() resolver.Builder {
+	return &srvBuilder{scheme: affinitybalancer.SRVResolverScheme, balancer: affinitybalancer.Name}
+}
+
 type srvBuilder struct {
 	scheme   string
 	balancer string
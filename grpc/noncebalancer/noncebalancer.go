@@ -2,6 +2,7 @@ package noncebalancer
 
 import (
 	"errors"
+	"hash/fnv"
 	"sync"
 
 	"github.com/letsencrypt/boulder/nonce"
@@ -37,6 +38,8 @@ var errMissingPrefixCtxKey = errors.New("nonce.PrefixCtxKey value required in RP
 var errMissingHMACKeyCtxKey = errors.New("nonce.HMACKeyCtxKey value required in RPC context")
 var errInvalidPrefixCtxKeyType = errors.New("nonce.PrefixCtxKey value in RPC context must be a string")
 var errInvalidHMACKeyCtxKeyType = errors.New("nonce.HMACKeyCtxKey value in RPC context must be a byte slice")
+var errInvalidRotationHMACKeysCtxKeyType = errors.New("nonce.RotationHMACKeysCtxKey value in RPC context must be a slice of byte slices")
+var errInvalidPrefixRoutesCtxKeyType = errors.New("nonce.PrefixRoutesCtxKey value in RPC context must be a map[string]string")
 
 // Balancer implements the base.PickerBuilder interface. It's used to create new
 // balancer.Picker instances. It should only be used by nonce-service clients.
@@ -54,8 +57,16 @@ func (b *Balancer) Build(buildInfo base.PickerBuildInfo) balancer.Picker {
 		// The Picker must be rebuilt if there are no backends available.
 		return base.NewErrPicker(balancer.ErrNoSubConnAvailable)
 	}
+	addrToBackend := make(map[string]balancer.SubConn, len(buildInfo.ReadySCs))
+	addrs := make([]string, 0, len(buildInfo.ReadySCs))
+	for sc, scInfo := range buildInfo.ReadySCs {
+		addrToBackend[scInfo.Address.Addr] = sc
+		addrs = append(addrs, scInfo.Address.Addr)
+	}
 	return &Picker{
-		backends: buildInfo.ReadySCs,
+		backends:      buildInfo.ReadySCs,
+		addrToBackend: addrToBackend,
+		addrs:         addrs,
 	}
 }
 
@@ -65,6 +76,8 @@ type Picker struct {
 	backends            map[balancer.SubConn]base.SubConnInfo
 	prefixToBackend     map[string]balancer.SubConn
 	prefixToBackendOnce sync.Once
+	addrToBackend       map[string]balancer.SubConn
+	addrs               []string
 }
 
 // Compile-time assertion that *Picker implements the balancer.Picker interface.
@@ -92,12 +105,27 @@ func (p *Picker) Pick(info balancer.PickInfo) (balancer.PickResult, error) {
 		return balancer.PickResult{}, errInvalidHMACKeyCtxKeyType
 	}
 
+	// Nonces minted while the HMAC key is being rotated may carry prefixes
+	// derived from other keys.
+	hmacKeys := [][]byte{hmacKey}
+	rotationKeysVal := info.Ctx.Value(nonce.RotationHMACKeysCtxKey{})
+	if rotationKeysVal != nil {
+		rotationKeys, ok := rotationKeysVal.([][]byte)
+		if !ok {
+			// This should never happen.
+			return balancer.PickResult{}, errInvalidRotationHMACKeysCtxKeyType
+		}
+		hmacKeys = append(hmacKeys, rotationKeys...)
+	}
+
 	p.prefixToBackendOnce.Do(func() {
 		// First call to Pick with a new Picker.
 		prefixToBackend := make(map[string]balancer.SubConn)
 		for sc, scInfo := range p.backends {
-			scPrefix := nonce.DerivePrefix(scInfo.Address.Addr, hmacKey)
-			prefixToBackend[scPrefix] = sc
+			for _, key := range hmacKeys {
+				scPrefix := nonce.DerivePrefix(scInfo.Address.Addr, key)
+				prefixToBackend[scPrefix] = sc
+			}
 		}
 		p.prefixToBackend = prefixToBackend
 	})
@@ -115,11 +143,53 @@ func (p *Picker) Pick(info balancer.PickInfo) (balancer.PickResult, error) {
 	}
 
 	sc, ok := p.prefixToBackend[destPrefix]
-	if !ok {
+	if ok {
+		return balancer.PickResult{SubConn: sc}, nil
+	}
+
+	// The prefix wasn't derived from any of our backends. If the caller
+	// provided explicit routes, for instance to backends or proxies which
+	// front another datacenter, use those.
+	routesVal := info.Ctx.Value(nonce.PrefixRoutesCtxKey{})
+	if routesVal == nil {
 		// No backend SubConn was found for the destination prefix.
 		return balancer.PickResult{}, ErrNoBackendsMatchPrefix.Err()
 	}
-	return balancer.PickResult{SubConn: sc}, nil
+	routes, ok := routesVal.(map[string]string)
+	if !ok {
+		// This should never happen.
+		return balancer.PickResult{}, errInvalidPrefixRoutesCtxKeyType
+	}
+	sc, ok = p.addrToBackend[routes[destPrefix]]
+	if ok {
+		return balancer.PickResult{SubConn: sc}, nil
+	}
+
+	// Neither the derived prefixes nor the routes know this prefix. Pick a
+	// backend by consistent hashing, so that every WFE sends a given prefix to
+	// the same backend, which is expected to forward it based on the prefix
+	// header.
+	return balancer.PickResult{SubConn: p.addrToBackend[rendezvous(destPrefix, p.addrs)]}, nil
+}
+
+// rendezvous returns the address with the highest hash when combined with
+// prefix. For a given prefix the same address is chosen regardless of the
+// order of addrs, and adding or removing an address only moves the prefixes
+// which hash highest to it.
+func rendezvous(prefix string, addrs []string) string {
+	var best string
+	var bestScore uint64
+	for _, addr := range addrs {
+		h := fnv.New64a()
+		h.Write([]byte(prefix))
+		h.Write([]byte{0})
+		h.Write([]byte(addr))
+		score := h.Sum64()
+		if best == "" || score > bestScore || (score == bestScore && addr < best) {
+			best, bestScore = addr, score
+		}
+	}
+	return best
 }
 
 func init() {
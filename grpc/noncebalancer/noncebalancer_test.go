@@ -2,6 +2,7 @@ package noncebalancer
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"google.golang.org/grpc/balancer"
@@ -85,6 +86,88 @@ func TestPickerNoMatchingSubConnAvailable(t *testing.T) {
 	test.AssertNil(t, gotPick.SubConn, "subConn should be nil")
 }
 
+func TestPickerRotationHMACKeys(t *testing.T) {
+	_, p, subConns := setupTest(false)
+	currentKey := []byte("Kala namak")
+	nextKey := []byte("Fleur de sel")
+	prefix := nonce.DerivePrefix(subConns[0].addrs[0].Addr, nextKey)
+
+	testCtx := context.WithValue(context.Background(), nonce.PrefixCtxKey{}, prefix)
+	testCtx = context.WithValue(testCtx, nonce.HMACKeyCtxKey{}, currentKey)
+	testCtx = context.WithValue(testCtx, nonce.RotationHMACKeysCtxKey{}, [][]byte{nextKey})
+
+	gotPick, err := p.Pick(balancer.PickInfo{Ctx: testCtx})
+	test.AssertNotError(t, err, "Pick failed")
+	test.AssertDeepEquals(t, subConns[0], gotPick.SubConn)
+
+	testCtx = context.WithValue(testCtx, nonce.RotationHMACKeysCtxKey{}, nextKey)
+	_, err = p.Pick(balancer.PickInfo{Ctx: testCtx})
+	test.AssertErrorIs(t, err, errInvalidRotationHMACKeysCtxKeyType)
+}
+
+func TestPickerPrefixRoutes(t *testing.T) {
+	b := &Balancer{}
+	bi := base.PickerBuildInfo{ReadySCs: make(map[balancer.SubConn]base.SubConnInfo)}
+	var subConns []*subConn
+	for _, a := range []string{"10.77.77.77:9301", "10.77.77.77:9401", "10.77.77.77:9501"} {
+		sc := &subConn{}
+		addr := resolver.Address{Addr: a}
+		sc.UpdateAddresses([]resolver.Address{addr})
+		bi.ReadySCs[sc] = base.SubConnInfo{Address: addr}
+		subConns = append(subConns, sc)
+	}
+	p := b.Build(bi)
+
+	ctx := context.WithValue(context.Background(), nonce.HMACKeyCtxKey{}, []byte("Kala namak"))
+	ctx = context.WithValue(ctx, nonce.PrefixRoutesCtxKey{}, map[string]string{"zinc1234": "10.77.77.77:9401"})
+
+	// A prefix with an explicit route is sent to that backend.
+	gotPick, err := p.Pick(balancer.PickInfo{Ctx: context.WithValue(ctx, nonce.PrefixCtxKey{}, "zinc1234")})
+	test.AssertNotError(t, err, "Pick failed")
+	test.AssertDeepEquals(t, gotPick.SubConn, subConns[1])
+
+	// An unknown prefix is sent to the same backend every time, regardless of
+	// which Picker handles it.
+	unknownCtx := context.WithValue(ctx, nonce.PrefixCtxKey{}, "rUsTrUin")
+	first, err := p.Pick(balancer.PickInfo{Ctx: unknownCtx})
+	test.AssertNotError(t, err, "Pick failed")
+	test.AssertNotNil(t, first.SubConn, "expected a fallback subConn")
+	for range 5 {
+		gotPick, err = b.Build(bi).Pick(balancer.PickInfo{Ctx: unknownCtx})
+		test.AssertNotError(t, err, "Pick failed")
+		test.AssertDeepEquals(t, gotPick.SubConn, first.SubConn)
+	}
+
+	// A route to a backend which isn't available falls back in the same way.
+	ctx = context.WithValue(ctx, nonce.PrefixRoutesCtxKey{}, map[string]string{"rUsTrUin": "10.88.88.88:9401"})
+	gotPick, err = p.Pick(balancer.PickInfo{Ctx: context.WithValue(ctx, nonce.PrefixCtxKey{}, "rUsTrUin")})
+	test.AssertNotError(t, err, "Pick failed")
+	test.AssertDeepEquals(t, gotPick.SubConn, first.SubConn)
+
+	ctx = context.WithValue(ctx, nonce.PrefixRoutesCtxKey{}, 9)
+	_, err = p.Pick(balancer.PickInfo{Ctx: context.WithValue(ctx, nonce.PrefixCtxKey{}, "rUsTrUin")})
+	test.AssertErrorIs(t, err, errInvalidPrefixRoutesCtxKeyType)
+}
+
+func TestRendezvous(t *testing.T) {
+	addrs := []string{"a:1", "b:1", "c:1", "d:1"}
+	counts := make(map[string]int)
+	moved := 0
+	for i := range 1000 {
+		prefix := fmt.Sprintf("%08d", i)
+		got := rendezvous(prefix, addrs)
+		counts[got]++
+		test.AssertEquals(t, rendezvous(prefix, []string{"d:1", "c:1", "b:1", "a:1"}), got)
+		if rendezvous(prefix, addrs[:3]) != got {
+			moved++
+			test.AssertEquals(t, got, "d:1")
+		}
+	}
+	test.AssertEquals(t, len(counts), 4)
+	test.AssertEquals(t, moved, counts["d:1"])
+	test.AssertEquals(t, rendezvous("anything", nil), "")
+}
+
 func TestPickerNoSubConnsAvailable(t *testing.T) {
 	b, p, _ := setupTest(true)
 	b.Build(base.PickerBuildInfo{})
@@ -37,6 +37,7 @@ func ProblemDetailsToPB(prob *probs.ProblemDetails) (*corepb.ProblemDetails, err
 		ProblemType: string(prob.Type),
 		Detail:      prob.Detail,
 		HttpStatus:  int32(prob.HTTPStatus), //nolint: gosec // HTTP status codes are guaranteed to be small, no risk of overflow.
+		Code:        string(prob.Code),
 	}, nil
 }
 
@@ -51,6 +52,7 @@ func PBToProblemDetails(in *corepb.ProblemDetails) (*probs.ProblemDetails, error
 	prob := &probs.ProblemDetails{
 		Type:   probs.ProblemType(in.ProblemType),
 		Detail: in.Detail,
+		Code:   probs.Code(in.Code),
 	}
 	if in.HttpStatus != 0 {
 		prob.HTTPStatus = int(in.HttpStatus)
@@ -148,6 +150,8 @@ func ValidationRecordToPB(record core.ValidationRecord) (*corepb.ValidationRecor
 		Url:               record.URL,
 		AddressesTried:    addrsTried,
 		ResolverAddrs:     record.ResolverAddrs,
+		AliasChain:        record.AliasChain,
+		Fingerprint:       record.Fingerprint,
 	}, nil
 }
 
@@ -184,6 +188,8 @@ func PBToValidationRecord(in *corepb.ValidationRecord) (record core.ValidationRe
 		URL:               in.Url,
 		AddressesTried:    addrsTried,
 		ResolverAddrs:     in.ResolverAddrs,
+		AliasChain:        in.AliasChain,
+		Fingerprint:       in.Fingerprint,
 	}, nil
 }
 
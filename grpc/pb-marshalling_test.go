@@ -35,6 +35,14 @@ func TestProblemDetails(t *testing.T) {
 	test.AssertNotError(t, err, "PBToProblemDetails failed")
 	test.AssertDeepEquals(t, recon, prob)
 
+	prob = probs.New(probs.CodeCanceled).Detail("gone").Build()
+	pb, err = ProblemDetailsToPB(prob)
+	test.AssertNotError(t, err, "problemDetailToPB failed")
+	test.AssertEquals(t, pb.Code, string(probs.CodeCanceled))
+	recon, err = PBToProblemDetails(pb)
+	test.AssertNotError(t, err, "PBToProblemDetails failed")
+	test.AssertDeepEquals(t, recon, prob)
+
 	recon, err = PBToProblemDetails(nil)
 	test.AssertNotError(t, err, "PBToProblemDetails failed")
 	test.Assert(t, recon == nil, "Returned core.PRoblemDetails is not nil")
@@ -120,6 +128,8 @@ func TestValidationRecord(t *testing.T) {
 		URL:               "http://exampleA.com",
 		AddressesTried:    []netip.Addr{ip},
 		ResolverAddrs:     []string{"resolver:5353"},
+		AliasChain:        []string{"_acme-challenge.exampleA.com", "exampleB.com"},
+		Fingerprint:       "browser",
 	}
 
 	pb, err := ValidationRecordToPB(vr)
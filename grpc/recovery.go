@@ -0,0 +1,170 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/letsencrypt/boulder/core"
+	blog "github.com/letsencrypt/boulder/log"
+)
+
+// redactedFieldNames are substrings of the names of request fields whose values
+// are never included in crash reports, because they may be secret or personal.
+var redactedFieldNames = []string{"key", "token", "secret", "password", "contact"}
+
+// maxSummaryListLen is the number of elements of each repeated field included
+// in a crash report's request summary.
+const maxSummaryListLen = 10
+
+// crashReport is audit logged when an RPC handler panics.
+type crashReport struct {
+	// ID is also returned to the client, so that the error it logs can be
+	// correlated with this report.
+	ID      string
+	Method  string
+	Client  []string `json:",omitempty"`
+	Panic   string
+	Request any `json:",omitempty"`
+	Stack   string
+}
+
+// recoveryInterceptor provides two server interceptors (Unary and Stream) which
+// recover panics in RPC handlers and in the interceptors which run after them.
+// Instead of killing the process, the RPC fails with codes.Internal and a crash
+// report is written to the audit log.
+type recoveryInterceptor struct {
+	log    blog.Logger
+	panics *prometheus.CounterVec
+}
+
+func newRecoveryInterceptor(metrics serverMetrics, logger blog.Logger) *recoveryInterceptor {
+	return &recoveryInterceptor{log: logger, panics: metrics.panics}
+}
+
+// Unary implements the grpc.UnaryServerInterceptor interface.
+func (ri *recoveryInterceptor) Unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			err = ri.report(ctx, info.FullMethod, req, r)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// Stream implements the grpc.StreamServerInterceptor interface.
+func (ri *recoveryInterceptor) Stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			err = ri.report(ss.Context(), info.FullMethod, nil, r)
+		}
+	}()
+	return handler(srv, ss)
+}
+
+// report logs a crash report for a panic with value r in method, and returns
+// the error to send to the client.
+func (ri *recoveryInterceptor) report(ctx context.Context, method string, req interface{}, r any) error {
+	service, methodName := splitMethodName(method)
+	ri.panics.WithLabelValues(service, methodName).Inc()
+
+	report := crashReport{
+		ID:     core.RandomString(8),
+		Method: method,
+		Client: clientNames(ctx),
+		Panic:  fmt.Sprint(r),
+		Stack:  string(debug.Stack()),
+	}
+	msg, ok := req.(proto.Message)
+	if ok {
+		report.Request = summarizeMessage(msg.ProtoReflect())
+	}
+	ri.log.AuditObject("gRPC handler panicked", report)
+
+	return status.Errorf(codes.Internal, "internal error handling %s (crash report %s)", method, report.ID)
+}
+
+// clientNames returns the DNS names in the client's verified mTLS certificate,
+// if it has one.
+func clientNames(ctx context.Context) []string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	tlsAuth, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsAuth.State.VerifiedChains) == 0 || len(tlsAuth.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return tlsAuth.State.VerifiedChains[0][0].DNSNames
+}
+
+// summarizeMessage returns a representation of the populated fields of msg
+// suitable for a crash report. The values of fields named in redactedFieldNames
+// are omitted, bytes fields are summarized by their length, and only the first
+// few elements of repeated fields are included.
+func summarizeMessage(msg protoreflect.Message) map[string]any {
+	summary := make(map[string]any)
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		name := string(fd.Name())
+		if isRedactedField(name) {
+			summary[name] = "[redacted]"
+			return true
+		}
+		switch {
+		case fd.IsList():
+			list := v.List()
+			var elems []any
+			for i := 0; i < list.Len() && i < maxSummaryListLen; i++ {
+				elems = append(elems, summarizeValue(fd, list.Get(i)))
+			}
+			if list.Len() > maxSummaryListLen {
+				elems = append(elems, fmt.Sprintf("[%d more]", list.Len()-maxSummaryListLen))
+			}
+			summary[name] = elems
+		case fd.IsMap():
+			summary[name] = fmt.Sprintf("[%d entries]", v.Map().Len())
+		default:
+			summary[name] = summarizeValue(fd, v)
+		}
+		return true
+	})
+	return summary
+}
+
+func summarizeValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) any {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return summarizeMessage(v.Message())
+	case protoreflect.BytesKind:
+		return fmt.Sprintf("[%d bytes]", len(v.Bytes()))
+	case protoreflect.EnumKind:
+		return int32(v.Enum())
+	default:
+		return v.Interface()
+	}
+}
+
+func isRedactedField(name string) bool {
+	name = strings.ToLower(name)
+	for _, r := range redactedFieldNames {
+		if strings.Contains(name, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// Ensure recoveryInterceptor matches the serverInterceptor interface.
+var _ serverInterceptor = (*recoveryInterceptor)(nil)
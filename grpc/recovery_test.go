@@ -0,0 +1,131 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestRecoveryInterceptorUnary(t *testing.T) {
+	t.Parallel()
+	serverMetrics, err := newServerMetrics(metrics.NoopRegisterer)
+	test.AssertNotError(t, err, "creating server metrics")
+	log := blog.NewMock()
+	ri := newRecoveryInterceptor(serverMetrics, log)
+	info := &grpc.UnaryServerInfo{FullMethod: "/ra.RegistrationAuthority/NewOrder"}
+
+	// Handlers which don't panic are unaffected.
+	resp, err := ri.Unary(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	test.AssertNotError(t, err, "handler which didn't panic failed")
+	test.AssertEquals(t, resp, "ok")
+	_, err = ri.Unary(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+		return nil, errors.New("oops")
+	})
+	test.AssertEquals(t, err.Error(), "oops")
+
+	req := &corepb.Authorization{
+		Id:             "1234",
+		RegistrationID: 1,
+		Identifier:     &corepb.Identifier{Type: "dns", Value: "example.com"},
+		Challenges: []*corepb.Challenge{{
+			Type:  "http-01",
+			Token: "secret-token",
+		}},
+	}
+	_, rpcErr := ri.Unary(context.Background(), req, info, func(context.Context, interface{}) (interface{}, error) {
+		var m map[string]string
+		m["boom"] = "boom"
+		return nil, nil
+	})
+	test.AssertError(t, rpcErr, "panicking handler didn't fail")
+	test.AssertEquals(t, status.Code(rpcErr), codes.Internal)
+	test.AssertMetricWithLabelsEquals(t, serverMetrics.panics, prometheus.Labels{"service": "ra.RegistrationAuthority", "method": "NewOrder"}, 1)
+
+	test.AssertNotContains(t, log.GetAll()[0], "secret-token")
+	report := onlyCrashReport(t, log)
+	test.AssertContains(t, status.Convert(rpcErr).Message(), "crash report "+report.ID)
+	test.AssertEquals(t, report.Method, "/ra.RegistrationAuthority/NewOrder")
+	test.AssertContains(t, report.Panic, "assignment to entry in nil map")
+	test.AssertContains(t, report.Stack, "TestRecoveryInterceptorUnary")
+	test.AssertDeepEquals(t, report.Request, map[string]any{
+		"id":             "1234",
+		"registrationID": float64(1),
+		"identifier":     map[string]any{"type": "dns", "value": "example.com"},
+		"challenges": []any{map[string]any{
+			"type":  "http-01",
+			"token": "[redacted]",
+		}},
+	})
+}
+
+func TestRecoveryInterceptorStream(t *testing.T) {
+	t.Parallel()
+	serverMetrics, err := newServerMetrics(metrics.NoopRegisterer)
+	test.AssertNotError(t, err, "creating server metrics")
+	log := blog.NewMock()
+	ri := newRecoveryInterceptor(serverMetrics, log)
+	info := &grpc.StreamServerInfo{FullMethod: "/sa.StorageAuthority/SerialsForIncident"}
+
+	err = ri.Stream(nil, testServerStream{}, info, func(interface{}, grpc.ServerStream) error {
+		panic("boom")
+	})
+	test.AssertEquals(t, status.Code(err), codes.Internal)
+	report := onlyCrashReport(t, log)
+	test.AssertEquals(t, report.Panic, "boom")
+	test.Assert(t, report.Request == nil, "stream crash report shouldn't include a request")
+}
+
+func TestSummarizeMessage(t *testing.T) {
+	t.Parallel()
+	var idents []*corepb.Identifier
+	for range maxSummaryListLen + 2 {
+		idents = append(idents, &corepb.Identifier{Type: "dns", Value: "example.com"})
+	}
+	summary := summarizeMessage((&corepb.Order{
+		Id:          1,
+		Identifiers: idents,
+	}).ProtoReflect())
+	test.AssertEquals(t, len(summary["identifiers"].([]any)), maxSummaryListLen+1)
+	test.AssertEquals(t, summary["identifiers"].([]any)[maxSummaryListLen], "[2 more]")
+
+	summary = summarizeMessage((&corepb.Certificate{Serial: "1234", Der: []byte{1, 2, 3}}).ProtoReflect())
+	test.AssertDeepEquals(t, summary, map[string]any{"serial": "1234", "der": "[3 bytes]"})
+
+	summary = summarizeMessage((&corepb.Registration{Id: 1, Key: []byte("{}")}).ProtoReflect())
+	test.AssertEquals(t, summary["key"], "[redacted]")
+}
+
+// testServerStream is a grpc.ServerStream with a background context.
+type testServerStream struct {
+	grpc.ServerStream
+}
+
+func (testServerStream) Context() context.Context {
+	return context.Background()
+}
+
+// onlyCrashReport returns the crash report logged to log, which must be the
+// only one.
+func onlyCrashReport(t *testing.T, log *blog.Mock) crashReport {
+	t.Helper()
+	lines := log.GetAllMatching(`gRPC handler panicked JSON=`)
+	test.AssertEquals(t, len(lines), 1)
+	var report crashReport
+	err := json.Unmarshal([]byte(lines[0][strings.Index(lines[0], "JSON=")+len("JSON="):]), &report)
+	test.AssertNotError(t, err, "unmarshaling crash report")
+	return report
+}
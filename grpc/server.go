@@ -22,6 +22,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/letsencrypt/boulder/cmd"
+	"github.com/letsencrypt/boulder/core"
 	bcreds "github.com/letsencrypt/boulder/grpc/creds"
 	blog "github.com/letsencrypt/boulder/log"
 )
@@ -55,6 +56,8 @@ type serverBuilder struct {
 	healthSrv     *health.Server
 	checkInterval time.Duration
 	logger        blog.Logger
+	interceptors  []grpc.UnaryServerInterceptor
+	streams       []grpc.StreamServerInterceptor
 	err           error
 }
 
@@ -73,6 +76,22 @@ func (sb *serverBuilder) WithCheckInterval(i time.Duration) *serverBuilder {
 	return sb
 }
 
+// WithUnaryInterceptor adds a service-specific interceptor to every unary RPC.
+// Such interceptors run after Boulder's own, so they see only authorized
+// requests, in the order they were added.
+func (sb *serverBuilder) WithUnaryInterceptor(i grpc.UnaryServerInterceptor) *serverBuilder {
+	sb.interceptors = append(sb.interceptors, i)
+	return sb
+}
+
+// WithStreamInterceptor adds a service-specific interceptor to every streaming
+// RPC. Like those added by WithUnaryInterceptor, such interceptors run after
+// Boulder's own, in the order they were added.
+func (sb *serverBuilder) WithStreamInterceptor(i grpc.StreamServerInterceptor) *serverBuilder {
+	sb.streams = append(sb.streams, i)
+	return sb
+}
+
 // Add registers a new service (consisting of its description and its
 // implementation) to the set of services which will be exposed by this server.
 // It returns the modified-in-place serverBuilder so that calls can be chained.
@@ -88,6 +107,38 @@ func (sb *serverBuilder) Add(desc *grpc.ServiceDesc, impl any) *serverBuilder {
 	return sb
 }
 
+// startOCSPStapler creates an OCSP stapler for the server's certificate,
+// fetches its first OCSP response, and starts refreshing it in the background.
+// Failing to fetch the first response isn't fatal, so that an outage of the
+// internal CA's OCSP responder doesn't prevent the server from starting.
+func (sb *serverBuilder) startOCSPStapler(tlsConfig *tls.Config, clk clock.Clock) (*bcreds.OCSPStapler, error) {
+	if len(tlsConfig.Certificates) != 1 {
+		return nil, fmt.Errorf("OCSP stapling requires exactly one server certificate, got %d", len(tlsConfig.Certificates))
+	}
+	issuer, err := core.LoadCert(sb.cfg.OCSPStapling.IssuerCertFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading OCSP stapling issuer certificate: %w", err)
+	}
+	stapler, err := bcreds.NewOCSPStapler(tlsConfig.Certificates[0], issuer, sb.cfg.OCSPStapling.ResponderURL, clk, sb.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	err = stapler.Refresh(ctx)
+	if err != nil {
+		sb.logger.Errf("Fetching OCSP response to staple for gRPC server certificate: %s", err)
+	}
+
+	interval := sb.cfg.OCSPStapling.RefreshInterval.Duration
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	go stapler.Run(context.Background(), interval)
+	return stapler, nil
+}
+
 // Build creates a gRPC server that uses the provided *tls.Config and exposes
 // all of the services added to the builder. It also exposes a health check
 // service. It returns one functions, start(), which should be used to start
@@ -139,7 +190,21 @@ func (sb *serverBuilder) Build(tlsConfig *tls.Config, statsRegistry prometheus.R
 	// allowed to connect to the server.
 	sb.cfg.Services[healthpb.Health_ServiceDesc.ServiceName].ClientNames = acceptedSANsSlice
 
-	creds, err := bcreds.NewServerCredentials(tlsConfig, acceptedSANs)
+	if sb.cfg.DisableTLSSessionTickets {
+		tlsConfig = tlsConfig.Clone()
+		tlsConfig.SessionTicketsDisabled = true
+	}
+
+	var stapler *bcreds.OCSPStapler
+	if sb.cfg.OCSPStapling != nil {
+		var err error
+		stapler, err = sb.startOCSPStapler(tlsConfig, clk)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	creds, err := bcreds.NewServerCredentials(tlsConfig, acceptedSANs, stapler)
 	if err != nil {
 		return nil, err
 	}
@@ -159,31 +224,45 @@ func (sb *serverBuilder) Build(tlsConfig *tls.Config, statsRegistry prometheus.R
 	}
 
 	mi := newServerMetadataInterceptor(metrics, clk)
+	ri := newRecoveryInterceptor(metrics, sb.logger)
 
+	// The recovery interceptor runs after the metrics interceptor, so that
+	// recovered panics are counted as Internal errors.
 	unaryInterceptors := []grpc.UnaryServerInterceptor{
 		mi.metrics.grpcMetrics.UnaryServerInterceptor(),
+		ri.Unary,
 		ai.Unary,
 		mi.Unary,
 	}
+	unaryInterceptors = append(unaryInterceptors, sb.interceptors...)
 
 	streamInterceptors := []grpc.StreamServerInterceptor{
 		mi.metrics.grpcMetrics.StreamServerInterceptor(),
+		ri.Stream,
 		ai.Stream,
 		mi.Stream,
 	}
+	streamInterceptors = append(streamInterceptors, sb.streams...)
+
+	if sb.cfg.Compression != nil {
+		ci := newServerCompressionInterceptor(sb.cfg.Compression)
+		unaryInterceptors = append(unaryInterceptors, ci.Unary)
+		streamInterceptors = append(streamInterceptors, ci.Stream)
+	}
+
+	compressionStats, err := newCompressionStatsHandler(statsRegistry)
+	if err != nil {
+		return nil, err
+	}
 
 	options := []grpc.ServerOption{
 		grpc.Creds(creds),
 		grpc.ChainUnaryInterceptor(unaryInterceptors...),
 		grpc.ChainStreamInterceptor(streamInterceptors...),
 		grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithFilter(filters.Not(filters.HealthCheck())))),
+		grpc.StatsHandler(compressionStats),
 	}
-	if sb.cfg.MaxConnectionAge.Duration > 0 {
-		options = append(options,
-			grpc.KeepaliveParams(keepalive.ServerParameters{
-				MaxConnectionAge: sb.cfg.MaxConnectionAge.Duration,
-			}))
-	}
+	options = append(options, keepaliveOptions(sb.cfg)...)
 
 	// Create the server itself and register all of our services on it.
 	server := grpc.NewServer(options...)
@@ -191,13 +270,8 @@ func (sb *serverBuilder) Build(tlsConfig *tls.Config, statsRegistry prometheus.R
 		server.RegisterService(service.desc, service.impl)
 	}
 
-	if sb.cfg.Address == "" {
-		return nil, errors.New("GRPC listen address not configured")
-	}
-	sb.logger.Infof("grpc listening on %s", sb.cfg.Address)
-
 	// Finally return the functions which will start and stop the server.
-	listener, err := net.Listen("tcp", sb.cfg.Address)
+	listener, err := sb.listen(tlsConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -232,6 +306,55 @@ func (sb *serverBuilder) Build(tlsConfig *tls.Config, statsRegistry prometheus.R
 	return start, nil
 }
 
+// listen returns the listener for the server: an in-memory one if the server's
+// certificate names a service declared by ServeInProcess, or otherwise one for
+// the configured address.
+func (sb *serverBuilder) listen(tlsConfig *tls.Config) (net.Listener, error) {
+	inProcessListener, err := inProcessListenerFor(tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	if inProcessListener != nil {
+		sb.logger.Infof("grpc serving in-process as %s", inProcessListener.Addr())
+		return inProcessListener, nil
+	}
+
+	if sb.cfg.Address == "" {
+		return nil, errors.New("GRPC listen address not configured")
+	}
+	sb.logger.Infof("grpc listening on %s", sb.cfg.Address)
+	return net.Listen("tcp", sb.cfg.Address)
+}
+
+// defaultKeepaliveMinTime is the shortest interval at which the server allows
+// clients to send keepalive pings if the config doesn't specify one. It's the
+// shortest interval gRPC clients can be configured to use, so that no client
+// config can provoke the server into closing connections.
+const defaultKeepaliveMinTime = 10 * time.Second
+
+// keepaliveOptions returns the server options which implement the keepalive and
+// connection age settings in the given config. Zero values in the config leave
+// the gRPC defaults in place.
+func keepaliveOptions(c *cmd.GRPCServerConfig) []grpc.ServerOption {
+	minTime := c.KeepaliveMinTime.Duration
+	if minTime <= 0 {
+		minTime = defaultKeepaliveMinTime
+	}
+	return []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			MaxConnectionAge:      c.MaxConnectionAge.Duration,
+			MaxConnectionAgeGrace: c.MaxConnectionAgeGrace.Duration,
+			MaxConnectionIdle:     c.MaxConnectionIdle.Duration,
+			Time:                  c.KeepaliveTime.Duration,
+			Timeout:               c.KeepaliveTimeout.Duration,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             minTime,
+			PermitWithoutStream: true,
+		}),
+	}
+}
+
 // initLongRunningCheck initializes a goroutine which will periodically check
 // the health of the provided service and update the health server accordingly.
 //
@@ -300,6 +423,7 @@ func (sb *serverBuilder) initLongRunningCheck(shutdownCtx context.Context, servi
 type serverMetrics struct {
 	grpcMetrics *grpc_prometheus.ServerMetrics
 	rpcLag      prometheus.Histogram
+	panics      *prometheus.CounterVec
 }
 
 // newServerMetrics registers metrics with a registry. It constructs and
@@ -341,8 +465,26 @@ func newServerMetrics(stats prometheus.Registerer) (serverMetrics, error) {
 		}
 	}
 
+	// panics is a prometheus counter of the panics recovered from RPC
+	// handlers. Create and register it.
+	panics := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpc_server_panics",
+			Help: "Number of panics recovered from gRPC server handlers, by service and method",
+		}, []string{"service", "method"})
+	err = stats.Register(panics)
+	if err != nil {
+		are := prometheus.AlreadyRegisteredError{}
+		if errors.As(err, &are) {
+			panics = are.ExistingCollector.(*prometheus.CounterVec)
+		} else {
+			return serverMetrics{}, err
+		}
+	}
+
 	return serverMetrics{
 		grpcMetrics: grpcMetrics,
 		rpcLag:      rpcLag,
+		panics:      panics,
 	}, nil
 }
@@ -9,13 +9,17 @@
 package identifier
 
 import (
+	"cmp"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"net"
 	"net/netip"
 	"slices"
 	"strings"
 
+	"golang.org/x/net/idna"
+
 	corepb "github.com/letsencrypt/boulder/core/proto"
 )
 
@@ -28,15 +32,42 @@ const (
 	TypeDNS = IdentifierType("dns")
 	// TypeIP is specified in RFC 8738
 	TypeIP = IdentifierType("ip")
+	// TypeEmail is specified in RFC 8823
+	TypeEmail = IdentifierType("email")
 )
 
+// knownTypes is the closed set of identifier types Boulder understands, in the
+// order in which identifiers of each type are sorted. Adding a new identifier
+// type means adding it here and to canonicalizers.
+var knownTypes = []IdentifierType{TypeDNS, TypeIP, TypeEmail}
+
+// canonicalizers maps each known identifier type to a function which returns
+// the canonical form of an identifier value of that type, or an error if the
+// value is not valid for the type.
+var canonicalizers = map[IdentifierType]func(string) (string, error){
+	TypeDNS:   canonicalDNS,
+	TypeIP:    canonicalIP,
+	TypeEmail: canonicalEmail,
+}
+
 // IsValid tests whether the identifier type is known
 func (i IdentifierType) IsValid() bool {
-	switch i {
-	case TypeDNS, TypeIP:
-		return true
+	return slices.Contains(knownTypes, i)
+}
+
+// compareTypes orders identifier types by their position in knownTypes.
+// Unknown types sort after all known types, alphabetically.
+func compareTypes(a, b IdentifierType) int {
+	ai, bi := slices.Index(knownTypes, a), slices.Index(knownTypes, b)
+	switch {
+	case ai >= 0 && bi >= 0:
+		return cmp.Compare(ai, bi)
+	case ai >= 0:
+		return -1
+	case bi >= 0:
+		return 1
 	default:
-		return false
+		return strings.Compare(string(a), string(b))
 	}
 }
 
@@ -52,6 +83,85 @@ type ACMEIdentifier struct {
 	Value string `json:"value"`
 }
 
+// Compare orders identifiers first by type, with DNS identifiers preceding IP
+// address identifiers, which precede email identifiers, and then by value. It
+// does not canonicalize either identifier, so callers comparing identifiers
+// from untrusted input should use Canonicalize first.
+func Compare(a, b ACMEIdentifier) int {
+	if c := compareTypes(a.Type, b.Type); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Value, b.Value)
+}
+
+// Canonicalize returns the canonical form of the identifier: DNS names are
+// lowercased and converted from Unicode to their IDNA A-label form, IP
+// addresses are rendered as described in RFC 8738, Sec. 3, and email addresses
+// are lowercased with their domain canonicalized as a DNS name. It returns an error
+// if the identifier's type is unknown or its value is not valid for its type.
+// Canonicalize does not apply issuance policy; a canonical identifier may still
+// be rejected by the policy authority.
+func (i ACMEIdentifier) Canonicalize() (ACMEIdentifier, error) {
+	canonicalize, ok := canonicalizers[i.Type]
+	if !ok {
+		return ACMEIdentifier{}, fmt.Errorf("unknown identifier type %q", i.Type)
+	}
+	value, err := canonicalize(i.Value)
+	if err != nil {
+		return ACMEIdentifier{}, err
+	}
+	return ACMEIdentifier{Type: i.Type, Value: value}, nil
+}
+
+// idnaProfile converts Unicode domain names to A-labels. It doesn't enforce
+// STD3 rules, so that wildcards and other names rejected later by policy are
+// canonicalized rather than failing here.
+var idnaProfile = idna.New(
+	idna.MapForLookup(),
+	idna.BidiRule(),
+	idna.Transitional(false),
+	idna.StrictDomainName(false),
+)
+
+func canonicalDNS(value string) (string, error) {
+	if value == "" {
+		return "", errors.New("DNS identifier value is empty")
+	}
+	for _, r := range value {
+		if r >= 0x80 {
+			ascii, err := idnaProfile.ToASCII(value)
+			if err != nil {
+				return "", fmt.Errorf("converting DNS identifier %q to IDNA A-labels: %w", value, err)
+			}
+			return ascii, nil
+		}
+	}
+	return strings.ToLower(value), nil
+}
+
+func canonicalIP(value string) (string, error) {
+	ip, err := netip.ParseAddr(value)
+	if err != nil {
+		return "", fmt.Errorf("parsing IP address identifier: %w", err)
+	}
+	if ip.Zone() != "" {
+		return "", fmt.Errorf("IP address identifier %q must not have a zone", value)
+	}
+	return NewIP(ip).Value, nil
+}
+
+func canonicalEmail(value string) (string, error) {
+	at := strings.LastIndex(value, "@")
+	if at <= 0 {
+		return "", fmt.Errorf("email identifier %q must have a local part and a domain", value)
+	}
+	domain, err := canonicalDNS(value[at+1:])
+	if err != nil {
+		return "", fmt.Errorf("canonicalizing domain of email identifier: %w", err)
+	}
+	return strings.ToLower(value[:at]) + "@" + domain, nil
+}
+
 // ACMEIdentifiers is a named type for a slice of ACME identifiers, so that
 // methods can be applied to these slices.
 type ACMEIdentifiers []ACMEIdentifier
@@ -122,6 +232,15 @@ func NewIP(ip netip.Addr) ACMEIdentifier {
 	}
 }
 
+// NewEmail is a convenience function for creating an ACMEIdentifier with Type
+// "email" for a given email address.
+func NewEmail(address string) ACMEIdentifier {
+	return ACMEIdentifier{
+		Type:  TypeEmail,
+		Value: address,
+	}
+}
+
 // FromString converts a string to an ACMEIdentifier.
 func FromString(identStr string) ACMEIdentifier {
 	ip, err := netip.ParseAddr(identStr)
@@ -180,29 +299,15 @@ func FromCSR(csr *x509.CertificateRequest) ACMEIdentifiers {
 }
 
 // Normalize returns the set of all unique ACME identifiers in the input after
-// all of them are lowercased. The returned identifier values will be in their
-// lowercased form and sorted alphabetically by value. DNS identifiers will
-// precede IP address identifiers.
+// all of them are lowercased. The returned identifiers are sorted by Compare.
+// It doesn't otherwise canonicalize them: identifiers which aren't in canonical
+// form are left for the policy authority to reject.
 func Normalize(idents ACMEIdentifiers) ACMEIdentifiers {
 	for i := range idents {
 		idents[i].Value = strings.ToLower(idents[i].Value)
 	}
 
-	slices.SortFunc(idents, func(a, b ACMEIdentifier) int {
-		if a.Type == b.Type {
-			if a.Value == b.Value {
-				return 0
-			}
-			if a.Value < b.Value {
-				return -1
-			}
-			return 1
-		}
-		if a.Type == "dns" && b.Type == "ip" {
-			return -1
-		}
-		return 1
-	})
+	slices.SortFunc(idents, Compare)
 
 	return slices.Compact(idents)
 }
@@ -3,6 +3,7 @@ package identifier
 import (
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/json"
 	"net"
 	"net/netip"
 	"reflect"
@@ -168,6 +169,19 @@ func TestNormalize(t *testing.T) {
 				{Type: TypeIP, Value: "fe80::cafe"},
 			},
 		},
+		{
+			name: "lowercase without canonicalizing",
+			idents: ACMEIdentifiers{
+				{Type: TypeDNS, Value: "Bücher.example"},
+				{Type: TypeIP, Value: "2001:DB8:0:0::1"},
+				{Type: TypeEmail, Value: "Alice@Example.COM"},
+			},
+			want: ACMEIdentifiers{
+				{Type: TypeDNS, Value: "bücher.example"},
+				{Type: TypeIP, Value: "2001:db8:0:0::1"},
+				{Type: TypeEmail, Value: "alice@example.com"},
+			},
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -261,3 +275,135 @@ func TestToValues(t *testing.T) {
 		})
 	}
 }
+
+func TestCanonicalize(t *testing.T) {
+	cases := []struct {
+		name    string
+		ident   ACMEIdentifier
+		want    ACMEIdentifier
+		wantErr bool
+	}{
+		{
+			name:  "DNS name is lowercased",
+			ident: ACMEIdentifier{Type: TypeDNS, Value: "WWW.Example.COM"},
+			want:  ACMEIdentifier{Type: TypeDNS, Value: "www.example.com"},
+		},
+		{
+			name:  "wildcard DNS name",
+			ident: ACMEIdentifier{Type: TypeDNS, Value: "*.Example.com"},
+			want:  ACMEIdentifier{Type: TypeDNS, Value: "*.example.com"},
+		},
+		{
+			name:  "Unicode DNS name is converted to A-labels",
+			ident: ACMEIdentifier{Type: TypeDNS, Value: "Bücher.example"},
+			want:  ACMEIdentifier{Type: TypeDNS, Value: "xn--bcher-kva.example"},
+		},
+		{
+			name:    "empty DNS name",
+			ident:   ACMEIdentifier{Type: TypeDNS, Value: ""},
+			wantErr: true,
+		},
+		{
+			name:  "IPv6 address is compressed and lowercased",
+			ident: ACMEIdentifier{Type: TypeIP, Value: "2001:0DB8:0000:0000:0000:0000:0000:0001"},
+			want:  ACMEIdentifier{Type: TypeIP, Value: "2001:db8::1"},
+		},
+		{
+			name:  "IPv4-mapped IPv6 address",
+			ident: ACMEIdentifier{Type: TypeIP, Value: "::FFFF:192.0.2.1"},
+			want:  ACMEIdentifier{Type: TypeIP, Value: "::ffff:192.0.2.1"},
+		},
+		{
+			name:    "IPv4 address with leading zeros",
+			ident:   ACMEIdentifier{Type: TypeIP, Value: "192.000.002.001"},
+			wantErr: true,
+		},
+		{
+			name:    "IPv6 address with zone",
+			ident:   ACMEIdentifier{Type: TypeIP, Value: "fe80::1%eth0"},
+			wantErr: true,
+		},
+		{
+			name:  "email domain is canonicalized",
+			ident: ACMEIdentifier{Type: TypeEmail, Value: "Alice@Bücher.example"},
+			want:  ACMEIdentifier{Type: TypeEmail, Value: "alice@xn--bcher-kva.example"},
+		},
+		{
+			name:  "email local part containing @",
+			ident: ACMEIdentifier{Type: TypeEmail, Value: `"a@b"@example.com`},
+			want:  ACMEIdentifier{Type: TypeEmail, Value: `"a@b"@example.com`},
+		},
+		{
+			name:    "email without local part",
+			ident:   ACMEIdentifier{Type: TypeEmail, Value: "@example.com"},
+			wantErr: true,
+		},
+		{
+			name:    "email without domain",
+			ident:   ACMEIdentifier{Type: TypeEmail, Value: "alice@"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown type",
+			ident:   ACMEIdentifier{Type: "fakeID", Value: "a@example.com"},
+			wantErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tc.ident.Canonicalize()
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("Canonicalize(%#v) = %#v, but want error", tc.ident, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Canonicalize(%#v) returned error: %s", tc.ident, err)
+			}
+			if got != tc.want {
+				t.Errorf("Canonicalize(%#v) = %#v, but want %#v", tc.ident, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	want := []ACMEIdentifier{
+		NewDNS("a.com"),
+		NewDNS("b.com"),
+		NewIP(netip.MustParseAddr("10.0.0.1")),
+		{Type: "email", Value: "a@example.com"},
+		{Type: "onion", Value: "a.onion"},
+	}
+	got := slices.Clone(want)
+	slices.Reverse(got)
+	slices.SortFunc(got, Compare)
+	if !slices.Equal(got, want) {
+		t.Errorf("sorting with Compare = %#v, but want %#v", got, want)
+	}
+	if Compare(NewDNS("a.com"), NewDNS("a.com")) != 0 {
+		t.Error("Compare of identical identifiers should be 0")
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	for _, ident := range []ACMEIdentifier{
+		NewDNS("example.com"),
+		NewIP(netip.MustParseAddr("2001:db8::1")),
+	} {
+		b, err := json.Marshal(ident)
+		if err != nil {
+			t.Fatalf("marshaling %#v: %s", ident, err)
+		}
+		var got ACMEIdentifier
+		err = json.Unmarshal(b, &got)
+		if err != nil {
+			t.Fatalf("unmarshaling %q: %s", b, err)
+		}
+		if got != ident {
+			t.Errorf("round trip of %#v through JSON %q = %#v", ident, b, got)
+		}
+	}
+}
@@ -60,10 +60,27 @@ type ProfileConfig struct {
 	// IncludeCRLDistributionPoints causes the CRLDistributionPoints extension to
 	// be added to all certificates issued by this profile.
 	IncludeCRLDistributionPoints bool
+	// IncludeDelegationUsage causes the DelegationUsage extension (RFC 9345)
+	// to be added to all certificates issued by this profile, so that their
+	// subscribers, such as CDNs, can sign short-lived delegated credentials
+	// for TLS rather than distributing the certificate's private key. This
+	// cannot be true unless OmitClientAuth is also true, and the
+	// MaxValidityPeriod is at most seven days.
+	IncludeDelegationUsage bool
 
 	MaxValidityPeriod   config.Duration
 	MaxValidityBackdate config.Duration
 
+	// MaxNames, if non-zero, is the maximum number of DNS names and IP
+	// addresses in a certificate issued by this profile. It should match the
+	// limit enforced by the RA for the corresponding validation profile; this
+	// is a backstop in case the two disagree.
+	MaxNames int `validate:"omitempty,min=1,max=100"`
+	// MaxSANBytes, if non-zero, is the maximum length in bytes of the
+	// DER-encoded Subject Alternative Name extension value of a certificate
+	// issued by this profile.
+	MaxSANBytes int `validate:"omitempty,min=1"`
+
 	// LintConfig is a path to a zlint config file, which can be used to control
 	// the behavior of zlint's "customizable lints".
 	LintConfig string
@@ -85,10 +102,14 @@ type Profile struct {
 	omitSKID            bool
 
 	includeCRLDistributionPoints bool
+	includeDelegationUsage       bool
 
 	maxBackdate time.Duration
 	maxValidity time.Duration
 
+	maxNames    int
+	maxSANBytes int
+
 	lints lint.Registry
 }
 
@@ -114,6 +135,18 @@ func NewProfile(profileConfig *ProfileConfig) (*Profile, error) {
 		return nil, fmt.Errorf("at least one revocation mechanism must be included")
 	}
 
+	// Certificates which can sign delegated credentials are only valid for TLS
+	// server authentication, and are short-lived so that a compromised
+	// delegation key is of limited use.
+	if profileConfig.IncludeDelegationUsage {
+		if !profileConfig.OmitClientAuth {
+			return nil, fmt.Errorf("IncludeDelegationUsage requires OmitClientAuth")
+		}
+		if profileConfig.MaxValidityPeriod.Duration > maxDelegationValidity {
+			return nil, fmt.Errorf("validity period %q is too large for IncludeDelegationUsage", profileConfig.MaxValidityPeriod.Duration)
+		}
+	}
+
 	lints, err := linter.NewRegistry(profileConfig.IgnoredLints)
 	cmd.FailOnError(err, "Failed to create zlint registry")
 	if profileConfig.LintConfig != "" {
@@ -128,14 +161,27 @@ func NewProfile(profileConfig *ProfileConfig) (*Profile, error) {
 		omitClientAuth:               profileConfig.OmitClientAuth,
 		omitSKID:                     profileConfig.OmitSKID,
 		includeCRLDistributionPoints: profileConfig.IncludeCRLDistributionPoints,
+		includeDelegationUsage:       profileConfig.IncludeDelegationUsage,
 		maxBackdate:                  profileConfig.MaxValidityBackdate.Duration,
 		maxValidity:                  profileConfig.MaxValidityPeriod.Duration,
+		maxNames:                     profileConfig.MaxNames,
+		maxSANBytes:                  profileConfig.MaxSANBytes,
 		lints:                        lints,
 	}
 
 	return sp, nil
 }
 
+// maxDelegationValidity is the longest validity period of a certificate issued
+// by a profile with IncludeDelegationUsage.
+const maxDelegationValidity = 7 * 24 * time.Hour
+
+// IncludesDelegationUsage returns true if certificates issued by the profile
+// can be used to sign delegated credentials.
+func (p *Profile) IncludesDelegationUsage() bool {
+	return p.includeDelegationUsage
+}
+
 // GenerateValidity returns a notBefore/notAfter pair bracketing the input time,
 // based on the profile's configured backdate and validity.
 func (p *Profile) GenerateValidity(now time.Time) (time.Time, time.Time) {
@@ -194,9 +240,55 @@ func (i *Issuer) requestValid(clk clock.Clock, prof *Profile, req *IssuanceReque
 		return errors.New("serial must be between 9 and 19 bytes")
 	}
 
+	return prof.checkSANLimits(req)
+}
+
+// ErrSANLimits is returned (wrapped) by Prepare when a request has more names,
+// or a longer SAN extension, than its profile allows.
+var ErrSANLimits = errors.New("request exceeds the profile's SAN limits")
+
+// checkSANLimits returns an error wrapping ErrSANLimits if the request exceeds
+// the profile's limits on the number of names or the encoded length of the
+// Subject Alternative Name extension.
+func (p *Profile) checkSANLimits(req *IssuanceRequest) error {
+	names := len(req.DNSNames) + len(req.IPAddresses)
+	if p.maxNames > 0 && names > p.maxNames {
+		return fmt.Errorf("%w: %d names is more than the maximum of %d", ErrSANLimits, names, p.maxNames)
+	}
+	if p.maxSANBytes > 0 {
+		sanBytes, err := sanExtensionLength(req.DNSNames, req.IPAddresses)
+		if err != nil {
+			return err
+		}
+		if sanBytes > p.maxSANBytes {
+			return fmt.Errorf("%w: SAN extension of %d bytes is more than the maximum of %d", ErrSANLimits, sanBytes, p.maxSANBytes)
+		}
+	}
 	return nil
 }
 
+// sanExtensionLength returns the length of the DER encoding of a Subject
+// Alternative Name extension value containing the given names, encoded as
+// crypto/x509 does (RFC 5280, Section 4.2.1.6).
+func sanExtensionLength(dnsNames []string, ipAddresses []net.IP) (int, error) {
+	var rawValues []asn1.RawValue
+	for _, name := range dnsNames {
+		rawValues = append(rawValues, asn1.RawValue{Tag: 2, Class: asn1.ClassContextSpecific, Bytes: []byte(name)})
+	}
+	for _, ip := range ipAddresses {
+		ipBytes := ip.To4()
+		if ipBytes == nil {
+			ipBytes = ip.To16()
+		}
+		rawValues = append(rawValues, asn1.RawValue{Tag: 7, Class: asn1.ClassContextSpecific, Bytes: ipBytes})
+	}
+	der, err := asn1.Marshal(rawValues)
+	if err != nil {
+		return 0, fmt.Errorf("encoding SAN extension: %w", err)
+	}
+	return len(der), nil
+}
+
 // Baseline Requirements, Section 7.1.6.1: domain-validated
 var domainValidatedOID = func() x509.OID {
 	x509OID, err := x509.OIDFromInts([]uint64{2, 23, 140, 1, 2, 1})
@@ -226,6 +318,12 @@ var ctPoisonExt = pkix.Extension{
 	Critical: true,
 }
 
+// DelegationUsage extension, RFC 9345 Section 4.2
+var delegationUsageExt = pkix.Extension{
+	Id:    asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 44363, 44},
+	Value: asn1.NullBytes,
+}
+
 // OID for SCT list, RFC 6962 (was never assigned a proper id-pe- name)
 var sctListOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}
 
@@ -381,6 +479,10 @@ func (i *Issuer) Prepare(prof *Profile, req *IssuanceRequest) ([]byte, *issuance
 		return nil, nil, errors.New("invalid request contains neither sctList nor precertDER")
 	}
 
+	if prof.includeDelegationUsage {
+		template.ExtraExtensions = append(template.ExtraExtensions, delegationUsageExt)
+	}
+
 	// If explicit CRL sharding is enabled, pick a shard based on the serial number
 	// modulus the number of shards. This gives us random distribution that is
 	// nonetheless consistent between precert and cert.
@@ -9,7 +9,9 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/asn1"
 	"encoding/base64"
+	"math/big"
 	"net"
 	"reflect"
 	"strings"
@@ -255,6 +257,45 @@ func TestRequestValid(t *testing.T) {
 			},
 			expectedError: "serial must be between 9 and 19 bytes",
 		},
+		{
+			name: "too many names",
+			issuer: &Issuer{
+				active: true,
+			},
+			profile: &Profile{
+				maxValidity: time.Hour * 2,
+				maxNames:    1,
+			},
+			request: &IssuanceRequest{
+				PublicKey:    MarshalablePublicKey{&ecdsa.PublicKey{}},
+				SubjectKeyId: goodSKID,
+				NotBefore:    fc.Now(),
+				NotAfter:     fc.Now().Add(time.Hour),
+				Serial:       []byte{1, 2, 3, 4, 5, 6, 7, 8, 9},
+				DNSNames:     []string{"example.com"},
+				IPAddresses:  []net.IP{net.ParseIP("128.101.101.101")},
+			},
+			expectedError: "request exceeds the profile's SAN limits: 2 names is more than the maximum of 1",
+		},
+		{
+			name: "SAN extension too long",
+			issuer: &Issuer{
+				active: true,
+			},
+			profile: &Profile{
+				maxValidity: time.Hour * 2,
+				maxSANBytes: 14,
+			},
+			request: &IssuanceRequest{
+				PublicKey:    MarshalablePublicKey{&ecdsa.PublicKey{}},
+				SubjectKeyId: goodSKID,
+				NotBefore:    fc.Now(),
+				NotAfter:     fc.Now().Add(time.Hour),
+				Serial:       []byte{1, 2, 3, 4, 5, 6, 7, 8, 9},
+				DNSNames:     []string{"example.com"},
+			},
+			expectedError: "request exceeds the profile's SAN limits: SAN extension of 15 bytes is more than the maximum of 14",
+		},
 		{
 			name: "good with poison",
 			issuer: &Issuer{
@@ -307,6 +348,43 @@ func TestRequestValid(t *testing.T) {
 	}
 }
 
+func TestSANExtensionLength(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "failed to generate test key")
+
+	for _, tc := range []struct {
+		name        string
+		dnsNames    []string
+		ipAddresses []net.IP
+	}{
+		{"one name", []string{"example.com"}, nil},
+		{"names and addresses", []string{"example.com", "www.example.com"}, []net.IP{net.ParseIP("128.101.101.101"), net.ParseIP("3fff:aaa:a:c0ff:ee:a:bad:deed")}},
+		{"long name", []string{strings.Repeat("a", 63) + "." + strings.Repeat("b", 63) + ".example.com"}, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			template := &x509.Certificate{
+				SerialNumber: big.NewInt(1),
+				DNSNames:     tc.dnsNames,
+				IPAddresses:  tc.ipAddresses,
+			}
+			der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+			test.AssertNotError(t, err, "failed to create certificate")
+			cert, err := x509.ParseCertificate(der)
+			test.AssertNotError(t, err, "failed to parse certificate")
+
+			var want int
+			for _, ext := range cert.Extensions {
+				if ext.Id.Equal(asn1.ObjectIdentifier{2, 5, 29, 17}) {
+					want = len(ext.Value)
+				}
+			}
+			got, err := sanExtensionLength(tc.dnsNames, tc.ipAddresses)
+			test.AssertNotError(t, err, "sanExtensionLength failed")
+			test.AssertEquals(t, got, want)
+		})
+	}
+}
+
 func TestGenerateTemplate(t *testing.T) {
 	issuer := &Issuer{
 		issuerURL:  "http://issuer",
@@ -617,6 +695,53 @@ func TestIssueOmissions(t *testing.T) {
 	test.AssertEquals(t, len(cert.SubjectKeyId), 0)
 }
 
+func TestIssueDelegationUsage(t *testing.T) {
+	fc := clock.NewFake()
+	fc.Set(time.Now())
+
+	pc := defaultProfileConfig()
+	pc.IncludeDelegationUsage = true
+	pc.OmitClientAuth = true
+	prof, err := NewProfile(pc)
+	test.AssertNotError(t, err, "building test profile")
+	test.Assert(t, prof.IncludesDelegationUsage(), "profile doesn't include DelegationUsage")
+
+	signer, err := newIssuer(defaultIssuerConfig(), issuerCert, issuerSigner, fc)
+	test.AssertNotError(t, err, "NewIssuer failed")
+
+	pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "failed to generate test key")
+	_, issuanceToken, err := signer.Prepare(prof, &IssuanceRequest{
+		PublicKey:       MarshalablePublicKey{pk.Public()},
+		SubjectKeyId:    goodSKID,
+		Serial:          []byte{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		DNSNames:        []string{"example.com"},
+		IncludeCTPoison: true,
+		NotBefore:       fc.Now(),
+		NotAfter:        fc.Now().Add(time.Hour - time.Second),
+	})
+	test.AssertNotError(t, err, "Prepare failed")
+	certBytes, err := signer.Issue(issuanceToken)
+	test.AssertNotError(t, err, "Issue failed")
+	cert, err := x509.ParseCertificate(certBytes)
+	test.AssertNotError(t, err, "failed to parse certificate")
+
+	test.AssertDeepEquals(t, cert.ExtKeyUsage, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth})
+	test.AssertEquals(t, cert.KeyUsage, x509.KeyUsageDigitalSignature)
+	var found bool
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(delegationUsageExt.Id) {
+			found = true
+			test.Assert(t, !ext.Critical, "DelegationUsage extension is critical")
+			test.AssertByteEquals(t, ext.Value, asn1.NullBytes)
+		}
+	}
+	test.Assert(t, found, "certificate doesn't include the DelegationUsage extension")
+
+	// The default profile doesn't include it.
+	test.Assert(t, !defaultProfile().IncludesDelegationUsage(), "default profile includes DelegationUsage")
+}
+
 func TestIssueCTPoison(t *testing.T) {
 	fc := clock.NewFake()
 	fc.Set(time.Now())
@@ -943,6 +1068,37 @@ func TestNewProfile(t *testing.T) {
 			},
 			wantErr: "validity period \"9528h0m0s\" is too large",
 		},
+		{
+			name: "delegation usage",
+			config: ProfileConfig{
+				MaxValidityBackdate:          config.Duration{Duration: 1 * time.Hour},
+				MaxValidityPeriod:            config.Duration{Duration: 7 * 24 * time.Hour},
+				IncludeCRLDistributionPoints: true,
+				IncludeDelegationUsage:       true,
+				OmitClientAuth:               true,
+			},
+		},
+		{
+			name: "delegation usage with client auth",
+			config: ProfileConfig{
+				MaxValidityBackdate:          config.Duration{Duration: 1 * time.Hour},
+				MaxValidityPeriod:            config.Duration{Duration: 7 * 24 * time.Hour},
+				IncludeCRLDistributionPoints: true,
+				IncludeDelegationUsage:       true,
+			},
+			wantErr: "IncludeDelegationUsage requires OmitClientAuth",
+		},
+		{
+			name: "delegation usage with large validity",
+			config: ProfileConfig{
+				MaxValidityBackdate:          config.Duration{Duration: 1 * time.Hour},
+				MaxValidityPeriod:            config.Duration{Duration: 8 * 24 * time.Hour},
+				IncludeCRLDistributionPoints: true,
+				IncludeDelegationUsage:       true,
+				OmitClientAuth:               true,
+			},
+			wantErr: "validity period \"192h0m0s\" is too large for IncludeDelegationUsage",
+		},
 		{
 			name: "no revocation info",
 			config: ProfileConfig{
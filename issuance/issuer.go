@@ -160,6 +160,16 @@ type IssuerConfig struct {
 	// The selection of which pool depends on the precertificate's key algorithm.
 	Active bool
 
+	// Ramp, if set, schedules the share of its pool's precertificates which
+	// this issuer signs, so that a new intermediate can be introduced, and an
+	// old one retired, by a config change: e.g. 1%, then 50%, then 100% at
+	// three configured times. Certificates it signs carry its own IssuerURL,
+	// and the WFE serves the chain it's configured with for this issuer, so
+	// both switch with the ramp. Before the first step's Start the issuer
+	// signs no precertificates. Issuers without a Ramp evenly share whatever
+	// percentage the ramped issuers of their pool leave. Ignored unless Active.
+	Ramp []RampStep `validate:"omitempty,dive"`
+
 	IssuerURL  string `validate:"required,url"`
 	CRLURLBase string `validate:"required,url,startswith=http://,endswith=/"`
 
@@ -203,6 +213,7 @@ type Issuer struct {
 	keyAlg x509.PublicKeyAlgorithm
 	sigAlg x509.SignatureAlgorithm
 	active bool
+	ramp   []RampStep
 
 	// Used to set the Authority Information Access caIssuers URL in issued
 	// certificates.
@@ -264,6 +275,11 @@ func newIssuer(config IssuerConfig, cert *Certificate, signer crypto.Signer, clk
 		return nil, errors.New("end-entity signing cert does not have keyUsage digitalSignature")
 	}
 
+	err := ValidateRamp(config.Ramp)
+	if err != nil {
+		return nil, err
+	}
+
 	lintSigner, err := linter.New(cert.Certificate, signer)
 	if err != nil {
 		return nil, fmt.Errorf("creating fake lint signer: %w", err)
@@ -276,6 +292,7 @@ func newIssuer(config IssuerConfig, cert *Certificate, signer crypto.Signer, clk
 		keyAlg:     keyAlg,
 		sigAlg:     sigAlg,
 		active:     config.Active,
+		ramp:       config.Ramp,
 		issuerURL:  config.IssuerURL,
 		crlURLBase: config.CRLURLBase,
 		crlShards:  config.CRLShards,
@@ -297,6 +314,11 @@ func (i *Issuer) IsActive() bool {
 	return i.active
 }
 
+// Ramp returns the issuer's ramp, which is empty if it has none.
+func (i *Issuer) Ramp() []RampStep {
+	return i.ramp
+}
+
 // Name provides the Common Name specified in the issuer's certificate.
 func (i *Issuer) Name() string {
 	return i.Cert.Subject.CommonName
@@ -0,0 +1,84 @@
+package issuance
+
+import (
+	"fmt"
+	"time"
+)
+
+// RampStep is one step of an issuer's ramp: from Start until the next step's
+// Start, the issuer signs Percent percent of its pool's precertificates.
+type RampStep struct {
+	// Start is when the step takes effect, in RFC 3339 format.
+	Start time.Time `validate:"required"`
+	// Percent is between 0 and 100.
+	Percent int `validate:"min=0,max=100"`
+}
+
+// ValidateRamp returns an error if the ramp's steps aren't in order of Start,
+// or if any of their percentages is out of range.
+func ValidateRamp(ramp []RampStep) error {
+	for i, step := range ramp {
+		if step.Start.IsZero() {
+			return fmt.Errorf("ramp step %d has no start", i)
+		}
+		if step.Percent < 0 || step.Percent > 100 {
+			return fmt.Errorf("ramp step %d has percentage %d, which is not between 0 and 100", i, step.Percent)
+		}
+		if i > 0 && !step.Start.After(ramp[i-1].Start) {
+			return fmt.Errorf("ramp step %d doesn't start after step %d", i, i-1)
+		}
+	}
+	return nil
+}
+
+// rampPercent returns the percentage of the ramp's step which is in effect at
+// the given time, or 0 if none of its steps has started yet.
+func rampPercent(ramp []RampStep, at time.Time) int {
+	percent := 0
+	for _, step := range ramp {
+		if step.Start.After(at) {
+			break
+		}
+		percent = step.Percent
+	}
+	return percent
+}
+
+// RampShares returns the share, between 0 and 1, of a pool's precertificates
+// which each of its issuers signs at the given time, given their ramps in the
+// same order. An issuer with a ramp signs the percentage of its current step,
+// and those without one evenly share the remainder. If the ramped issuers'
+// percentages add up to more than 100, or if every issuer is ramped and they
+// add up to less, the shares are scaled so that they add up to 1. If no issuer
+// would sign any precertificates, every share is 0.
+func RampShares(ramps [][]RampStep, at time.Time) []float64 {
+	shares := make([]float64, len(ramps))
+	var ramped float64
+	var unramped int
+	for i, ramp := range ramps {
+		if len(ramp) == 0 {
+			unramped++
+			continue
+		}
+		shares[i] = float64(rampPercent(ramp, at))
+		ramped += shares[i]
+	}
+
+	total := ramped
+	if unramped > 0 && ramped < 100 {
+		each := (100 - ramped) / float64(unramped)
+		for i, ramp := range ramps {
+			if len(ramp) == 0 {
+				shares[i] = each
+			}
+		}
+		total = 100
+	}
+	if total == 0 {
+		return shares
+	}
+	for i := range shares {
+		shares[i] /= total
+	}
+	return shares
+}
@@ -0,0 +1,139 @@
+package issuance
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestValidateRamp(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		ramp    []RampStep
+		wantErr string
+	}{
+		{
+			name: "no ramp",
+		},
+		{
+			name: "ordered steps",
+			ramp: []RampStep{
+				{Start: start, Percent: 1},
+				{Start: start.Add(time.Hour), Percent: 50},
+				{Start: start.Add(2 * time.Hour), Percent: 100},
+			},
+		},
+		{
+			name:    "missing start",
+			ramp:    []RampStep{{Percent: 1}},
+			wantErr: "has no start",
+		},
+		{
+			name:    "percentage out of range",
+			ramp:    []RampStep{{Start: start, Percent: 101}},
+			wantErr: "not between 0 and 100",
+		},
+		{
+			name: "steps out of order",
+			ramp: []RampStep{
+				{Start: start.Add(time.Hour), Percent: 1},
+				{Start: start, Percent: 50},
+			},
+			wantErr: "doesn't start after step 0",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateRamp(tc.ramp)
+			if tc.wantErr == "" {
+				test.AssertNotError(t, err, "valid ramp should be accepted")
+			} else {
+				test.AssertError(t, err, "invalid ramp should be rejected")
+				test.AssertContains(t, err.Error(), tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestRampShares(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	ramp := []RampStep{
+		{Start: start, Percent: 1},
+		{Start: start.Add(time.Hour), Percent: 50},
+		{Start: start.Add(2 * time.Hour), Percent: 100},
+	}
+
+	testCases := []struct {
+		name  string
+		ramps [][]RampStep
+		at    time.Time
+		want  []float64
+	}{
+		{
+			name:  "no ramps",
+			ramps: [][]RampStep{nil, nil},
+			at:    start,
+			want:  []float64{0.5, 0.5},
+		},
+		{
+			name:  "before the ramp",
+			ramps: [][]RampStep{nil, ramp},
+			at:    start.Add(-time.Second),
+			want:  []float64{1, 0},
+		},
+		{
+			name:  "first step",
+			ramps: [][]RampStep{nil, nil, ramp},
+			at:    start,
+			want:  []float64{0.495, 0.495, 0.01},
+		},
+		{
+			name:  "second step",
+			ramps: [][]RampStep{nil, ramp},
+			at:    start.Add(90 * time.Minute),
+			want:  []float64{0.5, 0.5},
+		},
+		{
+			name:  "last step",
+			ramps: [][]RampStep{nil, ramp},
+			at:    start.Add(2 * time.Hour),
+			want:  []float64{0, 1},
+		},
+		{
+			name:  "ramps over 100",
+			ramps: [][]RampStep{nil, ramp, ramp},
+			at:    start.Add(2 * time.Hour),
+			want:  []float64{0, 0.5, 0.5},
+		},
+		{
+			name:  "only ramped issuers",
+			ramps: [][]RampStep{ramp, {{Start: start, Percent: 3}}},
+			at:    start,
+			want:  []float64{0.25, 0.75},
+		},
+		{
+			name:  "nothing ramped up",
+			ramps: [][]RampStep{ramp},
+			at:    start.Add(-time.Second),
+			want:  []float64{0},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := RampShares(tc.ramps, tc.at)
+			test.AssertEquals(t, len(got), len(tc.want))
+			for i := range got {
+				test.Assert(t, math.Abs(got[i]-tc.want[i]) < 1e-9, fmt.Sprintf("share %d is %f, want %f", i, got[i], tc.want[i]))
+			}
+		})
+	}
+}
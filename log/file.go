@@ -0,0 +1,180 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"log/syslog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jmhodges/clock"
+
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/core"
+)
+
+// FileConfig configures a log file, to which lines are written in the same
+// format as rsyslog writes them for Boulder, so that they can be checked by
+// log-validator. The file is rotated when it reaches a maximum size or age.
+type FileConfig struct {
+	// Path is the file to which lines are appended. It's created if it
+	// doesn't exist. Rotated files are renamed to Path followed by a
+	// timestamp.
+	Path string `validate:"required"`
+
+	// Level is the highest level of lines written to the file. If zero, it's
+	// 6 (info).
+	Level int `validate:"min=0,max=7"`
+
+	// MaxSize is the size, in bytes, beyond which the file is rotated. If
+	// zero, the file isn't rotated because of its size.
+	MaxSize int64 `validate:"min=0"`
+
+	// MaxAge is how long lines are appended to the file before it's rotated.
+	// If zero, the file isn't rotated because of its age.
+	MaxAge config.Duration `validate:"-"`
+
+	// MaxBackups is the number of rotated files which are kept. The oldest
+	// are deleted first. If zero, all rotated files are kept.
+	MaxBackups int `validate:"min=0"`
+}
+
+// rotatedSuffixFormat is the format of the timestamp appended to the names of
+// rotated files. It sorts in chronological order.
+const rotatedSuffixFormat = "20060102T150405.000000000Z"
+
+// fileWriter implements Sink and writes to a file which it rotates.
+type fileWriter struct {
+	FileConfig
+	prefix string
+	clk    clock.Clock
+
+	f      *os.File
+	size   int64
+	opened time.Time
+}
+
+// FileSink returns a Sink which writes lines to the file configured by c.
+func FileSink(c FileConfig) (Sink, error) {
+	if c.Path == "" {
+		return nil, errors.New("log file path is required")
+	}
+	if c.Level == 0 {
+		c.Level = int(syslog.LOG_INFO)
+	}
+	w := &fileWriter{
+		FileConfig: c,
+		prefix:     filePrefix(),
+		clk:        clock.New(),
+	}
+	err := w.open()
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// filePrefix returns the hostname, datacenter, and syslog tag which rsyslog
+// writes for Boulder, with a placeholder for the severity between the
+// datacenter and tag.
+func filePrefix() string {
+	shortHostname := "unknown"
+	datacenter := "unknown"
+	hostname, err := os.Hostname()
+	if err == nil {
+		splits := strings.SplitN(hostname, ".", 3)
+		shortHostname = splits[0]
+		if len(splits) > 1 {
+			datacenter = splits[1]
+		}
+	}
+	return fmt.Sprintf("%s %s %%d %s[%d]:", shortHostname, datacenter, core.Command(), os.Getpid())
+}
+
+// open opens the file for appending.
+func (w *fileWriter) open() error {
+	f, err := os.OpenFile(w.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o640)
+	if err != nil {
+		return fmt.Errorf("opening log file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("opening log file: %w", err)
+	}
+	w.f = f
+	w.size = info.Size()
+	w.opened = w.clk.Now()
+	return nil
+}
+
+// rotate renames the file, opens a new one in its place, and deletes the
+// oldest rotated files beyond MaxBackups.
+func (w *fileWriter) rotate() error {
+	err := w.f.Close()
+	if err != nil {
+		return fmt.Errorf("closing log file: %w", err)
+	}
+	rotated := w.Path + "." + w.clk.Now().UTC().Format(rotatedSuffixFormat)
+	err = os.Rename(w.Path, rotated)
+	if err != nil {
+		return fmt.Errorf("renaming log file: %w", err)
+	}
+	err = w.open()
+	if err != nil {
+		return err
+	}
+
+	if w.MaxBackups <= 0 {
+		return nil
+	}
+	backups, err := filepath.Glob(w.Path + ".*")
+	if err != nil {
+		return fmt.Errorf("listing rotated log files: %w", err)
+	}
+	slices.Sort(backups)
+	for len(backups) > w.MaxBackups {
+		err = os.Remove(backups[0])
+		if err != nil {
+			return fmt.Errorf("deleting rotated log file: %w", err)
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+// logAtLevel appends the provided message to the file, first rotating it if
+// the message would take it beyond MaxSize, or it's older than MaxAge.
+func (w *fileWriter) logAtLevel(level syslog.Priority, msg string, a ...interface{}) {
+	if int(level) > w.Level {
+		return
+	}
+	if a != nil {
+		msg = fmt.Sprintf(msg, a...)
+	}
+	line := fmt.Sprintf("%s %s %s\n",
+		w.clk.Now().UTC().Format("2006-01-02T15:04:05.000000+00:00"),
+		fmt.Sprintf(w.prefix, int(level)),
+		checkSummed(msg))
+
+	tooBig := w.MaxSize > 0 && w.size > 0 && w.size+int64(len(line)) > w.MaxSize
+	tooOld := w.MaxAge.Duration > 0 && w.clk.Since(w.opened) >= w.MaxAge.Duration
+	if tooBig || tooOld {
+		err := w.rotate()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to rotate log file %s: %s\n", w.Path, err)
+			if w.f == nil {
+				return
+			}
+		}
+	}
+
+	n, err := w.f.WriteString(line)
+	w.size += int64(n)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write to log file %s: %d %s (%s)\n", w.Path, int(level), checkSummed(msg), err)
+	}
+}
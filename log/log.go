@@ -12,6 +12,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/jmhodges/clock"
 	"golang.org/x/term"
@@ -158,6 +159,9 @@ type stdoutWriter struct {
 	stdout    io.Writer
 	stderr    io.Writer
 	isatty    bool
+	// json, if true, causes each line to be written as a JSON object rather
+	// than in syslog's format, for container log collectors.
+	json bool
 }
 
 func LogLineChecksum(line string) string {
@@ -176,8 +180,6 @@ func checkSummed(msg string) string {
 // logAtLevel logs the provided message at the appropriate level, writing to
 // both stdout and the Logger
 func (w *bothWriter) logAtLevel(level syslog.Priority, msg string, a ...interface{}) {
-	var err error
-
 	// Apply conditional formatting for f functions
 	if a != nil {
 		msg = fmt.Sprintf(msg, a...)
@@ -190,7 +192,15 @@ func (w *bothWriter) logAtLevel(level syslog.Priority, msg string, a ...interfac
 	w.Lock()
 	defer w.Unlock()
 
-	switch syslogAllowed := int(level) <= w.syslogLevel; level {
+	logToSyslog(w.Writer, w.syslogLevel, level, msg)
+	w.stdoutWriter.logAtLevel(level, msg)
+}
+
+// logToSyslog writes an already formatted and escaped message to syslog, if
+// its level is at or below syslogLevel.
+func logToSyslog(w *syslog.Writer, syslogLevel int, level syslog.Priority, msg string) {
+	var err error
+	switch syslogAllowed := int(level) <= syslogLevel; level {
 	case syslog.LOG_ERR:
 		if syslogAllowed {
 			err = w.Err(checkSummed(msg))
@@ -214,8 +224,6 @@ func (w *bothWriter) logAtLevel(level syslog.Priority, msg string, a ...interfac
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to write to syslog: %d %s (%s)\n", int(level), checkSummed(msg), err)
 	}
-
-	w.stdoutWriter.logAtLevel(level, msg)
 }
 
 // logAtLevel logs the provided message to stdout, or stderr if it is at Warning or Error level.
@@ -233,6 +241,11 @@ func (w *stdoutWriter) logAtLevel(level syslog.Priority, msg string, a ...interf
 
 		msg = strings.Replace(msg, "\n", "\\n", -1)
 
+		if w.json {
+			w.logJSON(output, level, msg)
+			return
+		}
+
 		var color string
 		var reset string
 
@@ -266,6 +279,43 @@ func (w *stdoutWriter) logAtLevel(level syslog.Priority, msg string, a ...interf
 	}
 }
 
+// jsonLine is a log line as written by a stdoutWriter in JSON format.
+type jsonLine struct {
+	Time     string `json:"time"`
+	Level    int    `json:"level"`
+	Severity string `json:"severity"`
+	Command  string `json:"command"`
+	Checksum string `json:"checksum"`
+	Message  string `json:"msg"`
+}
+
+// severityNames are the names of the syslog levels at which Boulder logs.
+var severityNames = map[syslog.Priority]string{
+	syslog.LOG_ERR:     "error",
+	syslog.LOG_WARNING: "warning",
+	syslog.LOG_INFO:    "info",
+	syslog.LOG_DEBUG:   "debug",
+}
+
+// logJSON writes an already formatted and escaped message to output as a JSON
+// object on a line of its own.
+func (w *stdoutWriter) logJSON(output io.Writer, level syslog.Priority, msg string) {
+	line, err := json.Marshal(jsonLine{
+		Time:     w.clk.Now().UTC().Format(time.RFC3339Nano),
+		Level:    int(level),
+		Severity: severityNames[level],
+		Command:  core.Command(),
+		Checksum: LogLineChecksum(msg),
+		Message:  msg,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("failed to marshal log line: %v\n", err))
+	}
+	if _, err := fmt.Fprintf(output, "%s\n", line); err != nil {
+		panic(fmt.Sprintf("failed to write to stdout: %v\n", err))
+	}
+}
+
 func (log *impl) auditAtLevel(level syslog.Priority, msg string, a ...interface{}) {
 	msg = fmt.Sprintf("%s %s", auditTag, msg)
 	log.w.logAtLevel(level, msg, a...)
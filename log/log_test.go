@@ -2,10 +2,12 @@ package log
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"log/syslog"
 	"net"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
@@ -342,3 +344,53 @@ func TestLogAtLevelEscapesNewlines(t *testing.T) {
 
 	test.Assert(t, strings.Contains(buf.String(), "foo\\nbar"), "failed to escape newline")
 }
+
+func TestStdoutSinkJSON(t *testing.T) {
+	var buf bytes.Buffer
+	sink, err := StdoutSink(6, "json")
+	test.AssertNotError(t, err, "creating stdout sink")
+	w := sink.(*stdoutWriter)
+	w.stdout = &buf
+	w.clk = clock.NewFake()
+
+	logger, err := NewWithSinks(sink)
+	test.AssertNotError(t, err, "creating logger")
+	logger.Info("foo\nbar")
+	logger.Debug("not logged")
+
+	var line jsonLine
+	err = json.Unmarshal(buf.Bytes(), &line)
+	test.AssertNotError(t, err, "unmarshaling log line")
+	test.AssertEquals(t, line.Message, "foo\\nbar")
+	test.AssertEquals(t, line.Severity, "info")
+	test.AssertEquals(t, line.Checksum, LogLineChecksum("foo\\nbar"))
+
+	_, err = StdoutSink(6, "xml")
+	test.AssertError(t, err, "unknown format should be rejected")
+}
+
+func TestFileSinkRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "boulder.log")
+	sink, err := FileSink(FileConfig{Path: path, MaxSize: 150, MaxBackups: 2})
+	test.AssertNotError(t, err, "creating file sink")
+	w := sink.(*fileWriter)
+	fc := clock.NewFake()
+	w.clk = fc
+
+	logger, err := NewWithSinks(sink)
+	test.AssertNotError(t, err, "creating logger")
+	for i := range 5 {
+		fc.Add(time.Second)
+		logger.Infof("line %d", i)
+	}
+	logger.Debug("not logged")
+
+	backups, err := filepath.Glob(path + ".*")
+	test.AssertNotError(t, err, "listing rotated files")
+	test.AssertEquals(t, len(backups), 2)
+
+	contents, err := os.ReadFile(path)
+	test.AssertNotError(t, err, "reading log file")
+	test.Assert(t, strings.Contains(string(contents), "line 4"), "latest line missing from log file")
+	test.Assert(t, !strings.Contains(string(contents), "not logged"), "debug line written to log file")
+}
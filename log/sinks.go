@@ -0,0 +1,89 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"log/syslog"
+	"strings"
+	"sync"
+)
+
+// A Sink is a destination to which a Logger created by NewWithSinks writes
+// log lines. Sinks are created by SyslogSink, StdoutSink, and FileSink.
+type Sink interface {
+	logAtLevel(level syslog.Priority, msg string, a ...interface{})
+}
+
+// NewWithSinks returns a Logger which writes each log line to every one of the
+// given sinks, each of which decides for itself whether the line's level is
+// one it writes. It is safe for concurrent use.
+func NewWithSinks(sinks ...Sink) (Logger, error) {
+	if len(sinks) == 0 {
+		return nil, errors.New("at least one log sink is required")
+	}
+	return &impl{&multiWriter{sinks: sinks}}, nil
+}
+
+// multiWriter implements writer and writes to each of its sinks.
+type multiWriter struct {
+	sync.Mutex
+	sinks []Sink
+}
+
+// logAtLevel formats the provided message once and passes it to each sink.
+func (w *multiWriter) logAtLevel(level syslog.Priority, msg string, a ...interface{}) {
+	// Apply conditional formatting for f functions
+	if a != nil {
+		msg = fmt.Sprintf(msg, a...)
+	}
+
+	// Since messages are delimited by newlines, we have to escape any internal or
+	// trailing newlines before generating the checksum or outputting the message.
+	msg = strings.Replace(msg, "\n", "\\n", -1)
+
+	w.Lock()
+	defer w.Unlock()
+
+	for _, sink := range w.sinks {
+		sink.logAtLevel(level, msg)
+	}
+}
+
+// syslogWriter implements Sink and writes to syslog.
+type syslogWriter struct {
+	w     *syslog.Writer
+	level int
+}
+
+// SyslogSink returns a Sink which writes lines at or below the given level to
+// the given syslog.Writer.
+func SyslogSink(w *syslog.Writer, level int) (Sink, error) {
+	if w == nil {
+		return nil, errors.New("Attempted to use a nil System Logger")
+	}
+	return &syslogWriter{w: w, level: level}, nil
+}
+
+func (w *syslogWriter) logAtLevel(level syslog.Priority, msg string, a ...interface{}) {
+	if a != nil {
+		msg = fmt.Sprintf(msg, a...)
+	}
+	logToSyslog(w.w, w.level, level, msg)
+}
+
+// StdoutSink returns a Sink which writes lines at or below the given level to
+// stdout, or to stderr if they're at Warning or Error level. The format is
+// either "text", the default, which imitates syslog's format, or "json", which
+// writes each line as a JSON object.
+func StdoutSink(level int, format string) (Sink, error) {
+	w := newStdoutWriter(level)
+	switch format {
+	case "", "text":
+	case "json":
+		w.json = true
+		w.isatty = false
+	default:
+		return nil, fmt.Errorf("unknown stdout log format %q", format)
+	}
+	return w, nil
+}
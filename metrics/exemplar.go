@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ObserveWithTraceID records v in the given histogram observer. If ctx carries
+// a sampled trace, its ID is attached to the observation as an OpenMetrics
+// exemplar, so that a latency spike on a dashboard can be followed to a
+// representative trace.
+func ObserveWithTraceID(ctx context.Context, o prometheus.Observer, v float64) {
+	sc := trace.SpanContextFromContext(ctx)
+	eo, ok := o.(prometheus.ExemplarObserver)
+	if !ok || !sc.IsValid() || !sc.IsSampled() {
+		o.Observe(v)
+		return
+	}
+	eo.ObserveWithExemplar(v, prometheus.Labels{"trace_id": sc.TraceID().String()})
+}
@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestObserveWithTraceID(t *testing.T) {
+	h := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "h", Buckets: []float64{1, 2}})
+
+	// Without a trace, no exemplar is attached.
+	ObserveWithTraceID(context.Background(), h, 0.5)
+	var m io_prometheus_client.Metric
+	test.AssertNotError(t, h.Write(&m), "writing histogram")
+	test.AssertEquals(t, m.Histogram.Bucket[0].GetExemplar() == nil, true)
+
+	traceID := trace.TraceID{1, 2, 3, 4}
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+	ObserveWithTraceID(ctx, h, 1.5)
+	test.AssertNotError(t, h.Write(&m), "writing histogram")
+	exemplar := m.Histogram.Bucket[1].GetExemplar()
+	test.Assert(t, exemplar != nil, "expected an exemplar")
+	test.AssertEquals(t, exemplar.Label[0].GetName(), "trace_id")
+	test.AssertEquals(t, exemplar.Label[0].GetValue(), traceID.String())
+}
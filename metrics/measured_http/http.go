@@ -7,6 +7,8 @@ import (
 	"github.com/jmhodges/clock"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"github.com/letsencrypt/boulder/metrics"
 )
 
 // responseWriterWithStatus satisfies http.ResponseWriter, but keeps track of the
@@ -16,12 +18,20 @@ type responseWriterWithStatus struct {
 	code int
 }
 
-// WriteHeader stores a status code for generating stats.
+// WriteHeader stores a status code for generating stats. Interim (1xx)
+// responses are not recorded, since they are followed by a final response.
 func (r *responseWriterWithStatus) WriteHeader(code int) {
-	r.code = code
+	if code >= 200 {
+		r.code = code
+	}
 	r.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap allows http.ResponseController to reach the underlying writer.
+func (r *responseWriterWithStatus) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
 // Write writes the body and sets the status code to 200 if a status code
 // has not already been set.
 func (r *responseWriterWithStatus) Write(body []byte) (int, error) {
@@ -97,11 +107,13 @@ func (h *MeasuredHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	defer func() {
-		h.stat.With(prometheus.Labels{
+		// The request's context carries the span started by otelhttp, whose
+		// trace ID is attached to the observation as an exemplar.
+		metrics.ObserveWithTraceID(r.Context(), h.stat.With(prometheus.Labels{
 			"endpoint": pattern,
 			"method":   method,
 			"code":     strconv.Itoa(rwws.code),
-		}).Observe(h.clk.Since(begin).Seconds())
+		}), h.clk.Since(begin).Seconds())
 	}()
 
 	subHandler.ServeHTTP(rwws, r)
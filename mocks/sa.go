@@ -222,6 +222,11 @@ func (sa *StorageAuthorityReadOnly) GetRevocationStatus(_ context.Context, req *
 	return nil, nil
 }
 
+// GetRevocationStatuses is a mock
+func (sa *StorageAuthorityReadOnly) GetRevocationStatuses(_ context.Context, req *sapb.Serials, _ ...grpc.CallOption) (*sapb.RevocationStatuses, error) {
+	return &sapb.RevocationStatuses{}, nil
+}
+
 // SerialsForIncident is a mock
 func (sa *StorageAuthorityReadOnly) SerialsForIncident(ctx context.Context, _ *sapb.SerialsForIncidentRequest, _ ...grpc.CallOption) (sapb.StorageAuthorityReadOnly_SerialsForIncidentClient, error) {
 	return &ServerStreamClient[sapb.IncidentSerial]{}, nil
@@ -333,7 +338,7 @@ func (sa *StorageAuthorityReadOnly) FQDNSetExists(_ context.Context, _ *sapb.FQD
 }
 
 // DeactivateRegistration is a mock
-func (sa *StorageAuthority) DeactivateRegistration(_ context.Context, _ *sapb.RegistrationID, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+func (sa *StorageAuthority) DeactivateRegistration(_ context.Context, _ *sapb.DeactivateRegistrationRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
 	return &emptypb.Empty{}, nil
 }
 
@@ -357,7 +362,7 @@ func (sa *StorageAuthority) NewOrderAndAuthzs(_ context.Context, req *sapb.NewOr
 }
 
 // SetOrderProcessing is a mock
-func (sa *StorageAuthority) SetOrderProcessing(_ context.Context, req *sapb.OrderRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+func (sa *StorageAuthority) SetOrderProcessing(_ context.Context, req *sapb.SetOrderProcessingRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
 	return &emptypb.Empty{}, nil
 }
 
@@ -506,6 +511,31 @@ func (sa *StorageAuthority) GetSerialsByKey(ctx context.Context, _ *sapb.SPKIHas
 	return &ServerStreamClient[sapb.Serial]{}, nil
 }
 
+// SearchCertificates is a mock
+func (sa *StorageAuthorityReadOnly) SearchCertificates(ctx context.Context, _ *sapb.SearchCertificatesRequest, _ ...grpc.CallOption) (sapb.StorageAuthorityReadOnly_SearchCertificatesClient, error) {
+	return &ServerStreamClient[sapb.SerialMetadata]{}, nil
+}
+
+// SearchCertificates is a mock
+func (sa *StorageAuthority) SearchCertificates(ctx context.Context, _ *sapb.SearchCertificatesRequest, _ ...grpc.CallOption) (sapb.StorageAuthority_SearchCertificatesClient, error) {
+	return &ServerStreamClient[sapb.SerialMetadata]{}, nil
+}
+
+// GetCertificatesByExpiry is a mock
+func (sa *StorageAuthorityReadOnly) GetCertificatesByExpiry(ctx context.Context, _ *sapb.GetCertificatesByExpiryRequest, _ ...grpc.CallOption) (sapb.StorageAuthorityReadOnly_GetCertificatesByExpiryClient, error) {
+	return &ServerStreamClient[corepb.Certificate]{}, nil
+}
+
+// GetCertificatesByExpiry is a mock
+func (sa *StorageAuthority) GetCertificatesByExpiry(ctx context.Context, _ *sapb.GetCertificatesByExpiryRequest, _ ...grpc.CallOption) (sapb.StorageAuthority_GetCertificatesByExpiryClient, error) {
+	return &ServerStreamClient[corepb.Certificate]{}, nil
+}
+
+// GetMPICComplianceStats is a mock
+func (sa *StorageAuthorityReadOnly) GetMPICComplianceStats(ctx context.Context, _ *sapb.GetMPICComplianceStatsRequest, _ ...grpc.CallOption) (*sapb.MPICComplianceStats, error) {
+	return &sapb.MPICComplianceStats{}, nil
+}
+
 // GetSerialsByAccount is a mock
 func (sa *StorageAuthorityReadOnly) GetSerialsByAccount(ctx context.Context, _ *sapb.RegistrationID, _ ...grpc.CallOption) (sapb.StorageAuthorityReadOnly_GetSerialsByAccountClient, error) {
 	return &ServerStreamClient[sapb.Serial]{}, nil
@@ -555,3 +585,78 @@ func (sa *StorageAuthority) UpdateCRLShard(ctx context.Context, req *sapb.Update
 func (sa *StorageAuthorityReadOnly) ReplacementOrderExists(ctx context.Context, req *sapb.Serial, _ ...grpc.CallOption) (*sapb.Exists, error) {
 	return nil, nil
 }
+
+// GetContactVerifications is a mock.
+func (sa *StorageAuthorityReadOnly) GetContactVerifications(ctx context.Context, req *sapb.ContactHashes, _ ...grpc.CallOption) (*sapb.ContactVerifications, error) {
+	return &sapb.ContactVerifications{}, nil
+}
+
+// SetContactVerification is a mock.
+func (sa *StorageAuthority) SetContactVerification(ctx context.Context, req *sapb.ContactVerification, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, nil
+}
+
+// GetRegistrationClientIdentity is a mock.
+func (sa *StorageAuthorityReadOnly) GetRegistrationClientIdentity(ctx context.Context, req *sapb.RegistrationID, _ ...grpc.CallOption) (*sapb.ClientIdentity, error) {
+	return &sapb.ClientIdentity{}, nil
+}
+
+// GetAccountRevocation is a mock.
+func (sa *StorageAuthorityReadOnly) GetAccountRevocation(ctx context.Context, req *sapb.RegistrationID, _ ...grpc.CallOption) (*sapb.AccountRevocation, error) {
+	return nil, berrors.NotFoundError("no certificate revocation for registration %d", req.Id)
+}
+
+// GetExemptionRequest is a mock.
+func (sa *StorageAuthorityReadOnly) GetExemptionRequest(ctx context.Context, req *sapb.ExemptionRequestID, _ ...grpc.CallOption) (*sapb.ExemptionRequest, error) {
+	return nil, berrors.NotFoundError("no exemption request with ID %d", req.Id)
+}
+
+// GetExemptionRequests is a mock.
+func (sa *StorageAuthorityReadOnly) GetExemptionRequests(ctx context.Context, req *sapb.GetExemptionRequestsRequest, _ ...grpc.CallOption) (*sapb.ExemptionRequests, error) {
+	return &sapb.ExemptionRequests{}, nil
+}
+
+// GetValidationFailures is a mock.
+func (sa *StorageAuthorityReadOnly) GetValidationFailures(ctx context.Context, req *sapb.AuthorizationID2, _ ...grpc.CallOption) (*sapb.ValidationFailures, error) {
+	return &sapb.ValidationFailures{}, nil
+}
+
+// GetAuthzCAARecheck is a mock.
+func (sa *StorageAuthorityReadOnly) GetAuthzCAARecheck(ctx context.Context, req *sapb.AuthorizationID2, _ ...grpc.CallOption) (*sapb.AuthzCAARecheck, error) {
+	return &sapb.AuthzCAARecheck{}, nil
+}
+
+// GetCRLShard is a mock.
+func (sa *StorageAuthorityReadOnly) GetCRLShard(ctx context.Context, req *sapb.GetCRLShardRequest, _ ...grpc.CallOption) (*sapb.CRLShard, error) {
+	return &sapb.CRLShard{}, nil
+}
+
+// SetAuthzCAARecheck is a mock.
+func (sa *StorageAuthority) SetAuthzCAARecheck(ctx context.Context, req *sapb.SetAuthzCAARecheckRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, nil
+}
+
+// AddExemptionRequest is a mock.
+func (sa *StorageAuthority) AddExemptionRequest(ctx context.Context, req *sapb.ExemptionRequest, _ ...grpc.CallOption) (*sapb.ExemptionRequest, error) {
+	return &sapb.ExemptionRequest{Id: 1, RegistrationID: req.RegistrationID, Override: req.Override, Justification: req.Justification, Status: "pending"}, nil
+}
+
+// AddValidationFailure is a mock.
+func (sa *StorageAuthority) AddValidationFailure(ctx context.Context, req *sapb.AddValidationFailureRequest, _ ...grpc.CallOption) (*sapb.ValidationFailures, error) {
+	return &sapb.ValidationFailures{Count: 1, LastFailure: timestamppb.Now(), LastChallenge: req.Challenge, LastProblem: req.Problem}, nil
+}
+
+// ReviewExemptionRequest is a mock.
+func (sa *StorageAuthority) ReviewExemptionRequest(ctx context.Context, req *sapb.ReviewExemptionRequestRequest, _ ...grpc.CallOption) (*sapb.ExemptionRequest, error) {
+	return nil, berrors.NotFoundError("no exemption request with ID %d", req.Id)
+}
+
+// LeaseAccountRevocation is a mock.
+func (sa *StorageAuthority) LeaseAccountRevocation(ctx context.Context, req *sapb.LeaseAccountRevocationRequest, _ ...grpc.CallOption) (*sapb.AccountRevocation, error) {
+	return nil, berrors.NotFoundError("no certificate revocation to lease")
+}
+
+// SetAccountRevocation is a mock.
+func (sa *StorageAuthority) SetAccountRevocation(ctx context.Context, req *sapb.AccountRevocation, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, nil
+}
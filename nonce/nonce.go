@@ -11,6 +11,10 @@
 // The MaxUsed value determines how long a generated nonce can be used before it
 // is forgotten. To calculate that period, divide the MaxUsed value by average
 // redemption rate (valid POSTs per second).
+// Each nonce begins with a prefix derived from the service's address and an
+// HMAC key, which identifies both the instance which minted it and the key.
+// The HMAC key can be rotated on a schedule, without invalidating outstanding
+// nonces, by scheduling the prefixes derived from the keys which replace it.
 package nonce
 
 import (
@@ -28,6 +32,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/jmhodges/clock"
 	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -39,6 +44,11 @@ const (
 	// PrefixLen is the character length of a nonce prefix.
 	PrefixLen = 8
 
+	// PrefixHeader is the gRPC metadata key under which the prefix of a nonce
+	// being redeemed is sent, so that proxies between datacenters can route
+	// redemption RPCs without inspecting the message.
+	PrefixHeader = "nonce-prefix"
+
 	// NonceLen is the character length of a nonce, excluding the prefix.
 	NonceLen       = 32
 	defaultMaxUsed = 65536
@@ -52,6 +62,17 @@ type PrefixCtxKey struct{}
 // HMACKeyCtxKey is exported for use as a key in a context.Context.
 type HMACKeyCtxKey struct{}
 
+// RotationHMACKeysCtxKey is exported for use as a key in a context.Context. Its
+// value, if present, is a [][]byte of HMAC keys which nonce services are
+// scheduled to rotate to, or have recently rotated from. Nonces with prefixes
+// derived from them are routed like those derived from the HMACKeyCtxKey.
+type RotationHMACKeysCtxKey struct{}
+
+// PrefixRoutesCtxKey is exported for use as a key in a context.Context. Its
+// value, if present, is a map[string]string of nonce prefixes to the address
+// of the backend which should redeem nonces with that prefix.
+type PrefixRoutesCtxKey struct{}
+
 // DerivePrefix derives a nonce prefix from the provided listening address and
 // key. The prefix is derived by take the first 8 characters of the base64url
 // encoded HMAC-SHA256 hash of the listening address using the provided key.
@@ -71,6 +92,9 @@ type NonceService struct {
 	gcm              cipher.AEAD
 	maxUsed          int
 	prefix           string
+	schedule         []ScheduledPrefix
+	overlap          time.Duration
+	clk              clock.Clock
 	nonceCreates     prometheus.Counter
 	nonceEarliest    prometheus.Gauge
 	nonceRedeems     *prometheus.CounterVec
@@ -103,15 +127,9 @@ func NewNonceService(stats prometheus.Registerer, maxUsed int, prefix string) (*
 	// we require the prefix to be six bytes (eight characters) so that the bytes
 	// preceding the prefix wouldn't impact the encoding.
 	if prefix != "" {
-		if len(prefix) != PrefixLen {
-			return nil, fmt.Errorf(
-				"nonce prefix must be %d characters, not %d",
-				PrefixLen,
-				len(prefix),
-			)
-		}
-		if _, err := base64.RawURLEncoding.DecodeString(prefix); err != nil {
-			return nil, errors.New("nonce prefix must be valid base64url")
+		err := validatePrefix(prefix)
+		if err != nil {
+			return nil, err
 		}
 	}
 
@@ -162,6 +180,7 @@ func NewNonceService(stats prometheus.Registerer, maxUsed int, prefix string) (*
 		gcm:              gcm,
 		maxUsed:          maxUsed,
 		prefix:           prefix,
+		clk:              clock.New(),
 		nonceCreates:     nonceCreates,
 		nonceEarliest:    nonceEarliest,
 		nonceRedeems:     nonceRedeems,
@@ -169,6 +188,85 @@ func NewNonceService(stats prometheus.Registerer, maxUsed int, prefix string) (*
 	}, nil
 }
 
+// validatePrefix checks that the given nonce prefix is well-formed.
+func validatePrefix(prefix string) error {
+	if len(prefix) != PrefixLen {
+		return fmt.Errorf(
+			"nonce prefix must be %d characters, not %d",
+			PrefixLen,
+			len(prefix),
+		)
+	}
+	if _, err := base64.RawURLEncoding.DecodeString(prefix); err != nil {
+		return errors.New("nonce prefix must be valid base64url")
+	}
+	return nil
+}
+
+// ScheduledPrefix is a nonce prefix which replaces the one before it at a
+// scheduled time.
+type ScheduledPrefix struct {
+	Prefix     string
+	ActiveFrom time.Time
+}
+
+// SchedulePrefixes schedules the rotation of the service's prefix, which is
+// usually derived from an HMAC key which is being rotated. Nonces are minted
+// with each of the given prefixes from its ActiveFrom time, which must be in
+// increasing order. Nonces minted with the prefix which each replaces are still
+// redeemed for the given overlap after it does so. Rotation follows clk, which
+// should be a skewclock.Clock so that a backwards jump of the host's clock
+// can't bring back a prefix which has been replaced. The service must have been
+// created with a prefix, and this must be called before it's used.
+func (ns *NonceService) SchedulePrefixes(schedule []ScheduledPrefix, overlap time.Duration, clk clock.Clock) error {
+	if ns.prefix == "" {
+		return errors.New("nonce prefixes can only be scheduled for a service with a prefix")
+	}
+	for i, sp := range schedule {
+		err := validatePrefix(sp.Prefix)
+		if err != nil {
+			return err
+		}
+		if i > 0 && !sp.ActiveFrom.After(schedule[i-1].ActiveFrom) {
+			return fmt.Errorf("scheduled nonce prefix %q must become active after the one before it", sp.Prefix)
+		}
+	}
+	ns.schedule = schedule
+	ns.overlap = overlap
+	ns.clk = clk
+	return nil
+}
+
+// currentPrefix returns the prefix with which nonces are minted.
+func (ns *NonceService) currentPrefix() string {
+	prefix := ns.prefix
+	now := ns.clk.Now()
+	for _, sp := range ns.schedule {
+		if now.Before(sp.ActiveFrom) {
+			break
+		}
+		prefix = sp.Prefix
+	}
+	return prefix
+}
+
+// redeemablePrefix returns true if nonces with the given prefix are redeemed:
+// either it's the current prefix, or it was replaced within the overlap.
+func (ns *NonceService) redeemablePrefix(prefix string) bool {
+	now := ns.clk.Now()
+	active := ns.prefix
+	for _, sp := range ns.schedule {
+		if now.Before(sp.ActiveFrom) {
+			break
+		}
+		if active == prefix && now.Before(sp.ActiveFrom.Add(ns.overlap)) {
+			return true
+		}
+		active = sp.Prefix
+	}
+	return active == prefix
+}
+
 func (ns *NonceService) encrypt(counter int64) (string, error) {
 	// Generate a nonce with upper 4 bytes zero
 	nonce := make([]byte, 12)
@@ -192,7 +290,7 @@ func (ns *NonceService) encrypt(counter int64) (string, error) {
 	copy(ret, nonce[4:])
 	copy(ret[8:], ct)
 
-	return ns.prefix + base64.RawURLEncoding.EncodeToString(ret), nil
+	return ns.currentPrefix() + base64.RawURLEncoding.EncodeToString(ret), nil
 }
 
 func (ns *NonceService) decrypt(nonce string) (int64, error) {
@@ -204,8 +302,8 @@ func (ns *NonceService) decrypt(nonce string) (int64, error) {
 		if err != nil {
 			return 0, err
 		}
-		if ns.prefix != prefix {
-			return 0, fmt.Errorf("nonce contains invalid prefix: expected %q, got %q", ns.prefix, prefix)
+		if !ns.redeemablePrefix(prefix) {
+			return 0, fmt.Errorf("nonce contains invalid prefix: expected %q, got %q", ns.currentPrefix(), prefix)
 		}
 	}
 	decoded, err := base64.RawURLEncoding.DecodeString(body)
@@ -3,6 +3,9 @@ package nonce
 import (
 	"fmt"
 	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
 
 	"github.com/letsencrypt/boulder/metrics"
 	"github.com/letsencrypt/boulder/test"
@@ -146,6 +149,59 @@ func TestNoncePrefixValidation(t *testing.T) {
 	test.AssertNotError(t, err, "NewNonceService failed with valid nonce prefix")
 }
 
+func TestSchedulePrefixes(t *testing.T) {
+	ns, err := NewNonceService(metrics.NoopRegisterer, 0, "aluminum")
+	test.AssertNotError(t, err, "Could not create nonce service")
+	fc := clock.NewFake()
+	ns.clk = fc
+
+	err = ns.SchedulePrefixes([]ScheduledPrefix{{Prefix: "zinc", ActiveFrom: fc.Now()}}, time.Minute, fc)
+	test.AssertError(t, err, "SchedulePrefixes accepted a short prefix")
+	err = ns.SchedulePrefixes([]ScheduledPrefix{
+		{Prefix: "titanium", ActiveFrom: fc.Now().Add(time.Hour)},
+		{Prefix: "chromium", ActiveFrom: fc.Now().Add(time.Hour)},
+	}, time.Minute, fc)
+	test.AssertError(t, err, "SchedulePrefixes accepted prefixes out of order")
+
+	err = ns.SchedulePrefixes([]ScheduledPrefix{
+		{Prefix: "titanium", ActiveFrom: fc.Now().Add(time.Hour)},
+		{Prefix: "chromium", ActiveFrom: fc.Now().Add(2 * time.Hour)},
+	}, time.Minute, fc)
+	test.AssertNotError(t, err, "SchedulePrefixes failed")
+
+	// Before the first rotation, only the original prefix is in use.
+	n1, err := ns.Nonce()
+	test.AssertNotError(t, err, "Could not create nonce")
+	test.AssertEquals(t, n1[:PrefixLen], "aluminum")
+	test.Assert(t, ns.Valid(n1), "Valid nonce rejected")
+	n1, err = ns.Nonce()
+	test.AssertNotError(t, err, "Could not create nonce")
+
+	// Once the next prefix is active, nonces are minted with it, and those
+	// with the previous prefix are redeemed until the overlap has passed.
+	fc.Add(time.Hour)
+	n2, err := ns.Nonce()
+	test.AssertNotError(t, err, "Could not create nonce")
+	test.AssertEquals(t, n2[:PrefixLen], "titanium")
+	test.Assert(t, ns.Valid(n2), "Valid nonce rejected")
+	test.Assert(t, ns.Valid(n1), "Nonce with previous prefix rejected during overlap")
+	n1, err = ns.Nonce()
+	test.AssertNotError(t, err, "Could not create nonce")
+	n1 = "aluminum" + n1[PrefixLen:]
+	fc.Add(time.Minute)
+	test.Assert(t, !ns.Valid(n1), "Nonce with previous prefix accepted after overlap")
+
+	// Nonces with a prefix which isn't yet active are rejected.
+	n3, err := ns.Nonce()
+	test.AssertNotError(t, err, "Could not create nonce")
+	test.Assert(t, !ns.Valid("chromium"+n3[PrefixLen:]), "Nonce with future prefix accepted")
+
+	ns, err = NewNonceService(metrics.NoopRegisterer, 0, "")
+	test.AssertNotError(t, err, "Could not create nonce service")
+	err = ns.SchedulePrefixes([]ScheduledPrefix{{Prefix: "titanium", ActiveFrom: fc.Now()}}, time.Minute, fc)
+	test.AssertError(t, err, "SchedulePrefixes succeeded without an original prefix")
+}
+
 func TestDerivePrefix(t *testing.T) {
 	prefix := DerivePrefix("192.168.1.1:8080", []byte("3b8c758dd85e113ea340ce0b3a99f389d40a308548af94d1730a7692c1874f1f"))
 	test.AssertEquals(t, prefix, "P9qQaK4o")
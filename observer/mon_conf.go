@@ -14,7 +14,7 @@ import (
 // MonConf is exported to receive YAML configuration in `ObsConf`.
 type MonConf struct {
 	Period   config.Duration  `yaml:"period"`
-	Kind     string           `yaml:"kind" validate:"required,oneof=DNS HTTP CRL TLS TCP"`
+	Kind     string           `yaml:"kind" validate:"required,oneof=DNS HTTP CRL OCSP TLS TCP ACME"`
 	Settings probers.Settings `yaml:"settings" validate:"min=1,dive"`
 }
 
@@ -5,9 +5,11 @@ import (
 
 	"github.com/letsencrypt/boulder/cmd"
 	blog "github.com/letsencrypt/boulder/log"
+	_ "github.com/letsencrypt/boulder/observer/probers/acme"
 	_ "github.com/letsencrypt/boulder/observer/probers/crl"
 	_ "github.com/letsencrypt/boulder/observer/probers/dns"
 	_ "github.com/letsencrypt/boulder/observer/probers/http"
+	_ "github.com/letsencrypt/boulder/observer/probers/ocsp"
 	_ "github.com/letsencrypt/boulder/observer/probers/tcp"
 	_ "github.com/letsencrypt/boulder/observer/probers/tls"
 )
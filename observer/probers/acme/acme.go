@@ -0,0 +1,219 @@
+package probers
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/eggsampler/acme/v3"
+	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// The steps of an issuance, as reported in the `step` label of the
+// `obs_acme_step_latency` metric.
+const (
+	stepNewAccount = "new-account"
+	stepNewOrder   = "new-order"
+	stepDNS01      = "dns-01"
+	stepFinalize   = "finalize"
+	stepDownload   = "download"
+)
+
+// ACMEProbe is the exported 'Prober' object for monitors configured to
+// perform a full ACME issuance.
+type ACMEProbe struct {
+	directoryURL string
+	domain       string
+	updater      dnsUpdater
+	stepLatency  *prometheus.HistogramVec
+}
+
+// Name returns a string that uniquely identifies the monitor.
+func (p ACMEProbe) Name() string {
+	return fmt.Sprintf("%s-%s", p.directoryURL, p.domain)
+}
+
+// Kind returns a name that uniquely identifies the `Kind` of `Prober`.
+func (p ACMEProbe) Kind() string {
+	return "ACME"
+}
+
+// Probe creates a new account, orders a certificate for the configured domain,
+// fulfills its dns-01 challenge by publishing a TXT record in the configured
+// zone, finalizes the order, and downloads the certificate. The latency and
+// outcome of each step are reported, and the probe stops at the first step to
+// fail.
+func (p ACMEProbe) Probe(timeout time.Duration) (bool, time.Duration) {
+	start := time.Now()
+	err := p.issue(timeout)
+	return err == nil, time.Since(start)
+}
+
+// step runs fn and reports its latency and outcome as the named step.
+func (p ACMEProbe) step(name string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	p.stepLatency.With(prometheus.Labels{
+		"name":    p.Name(),
+		"step":    name,
+		"success": strconv.FormatBool(err == nil),
+	}).Observe(time.Since(start).Seconds())
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+func (p ACMEProbe) issue(timeout time.Duration) error {
+	client, err := acme.NewClient(p.directoryURL,
+		acme.WithHTTPTimeout(timeout),
+		acme.WithUserAgentSuffix("boulder-observer"),
+	)
+	if err != nil {
+		return err
+	}
+	client.PollTimeout = timeout
+
+	var account acme.Account
+	err = p.step(stepNewAccount, func() error {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return err
+		}
+		account, err = client.NewAccount(key, false, true)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	var order acme.Order
+	err = p.step(stepNewOrder, func() error {
+		order, err = client.NewOrder(account, []acme.Identifier{{Type: "dns", Value: p.domain}})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	err = p.step(stepDNS01, func() error {
+		if len(order.Authorizations) != 1 {
+			return fmt.Errorf("got %d authorizations, expected 1", len(order.Authorizations))
+		}
+		authz, err := client.FetchAuthorization(account, order.Authorizations[0])
+		if err != nil {
+			return err
+		}
+		if authz.Status == "valid" {
+			// The authorization was reused, so there's nothing to fulfill.
+			return nil
+		}
+		chal, ok := authz.ChallengeMap[acme.ChallengeTypeDNS01]
+		if !ok {
+			return errors.New("authorization has no dns-01 challenge")
+		}
+		fqdn := dns.Fqdn("_acme-challenge." + p.domain)
+		err = p.updater.setTXT(fqdn, acme.EncodeDNS01KeyAuthorization(chal.KeyAuthorization), timeout)
+		if err != nil {
+			return fmt.Errorf("publishing TXT record: %w", err)
+		}
+		defer func() {
+			// Failing to clean up doesn't fail the probe, since the next
+			// probe replaces the record.
+			_ = p.updater.removeTXT(fqdn, timeout)
+		}()
+		_, err = client.UpdateChallenge(account, chal)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	err = p.step(stepFinalize, func() error {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return err
+		}
+		csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+			DNSNames: []string{p.domain},
+		}, key)
+		if err != nil {
+			return err
+		}
+		csr, err := x509.ParseCertificateRequest(csrDER)
+		if err != nil {
+			return err
+		}
+		order, err = client.FinalizeOrder(account, order, csr)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.step(stepDownload, func() error {
+		certs, err := client.FetchCertificates(account, order.Certificate)
+		if err != nil {
+			return err
+		}
+		if len(certs) == 0 {
+			return errors.New("no certificates returned")
+		}
+		return certs[0].VerifyHostname(p.domain)
+	})
+}
+
+// dnsUpdater publishes and removes TXT records in a zone using RFC 2136
+// dynamic updates, optionally signed with TSIG.
+type dnsUpdater struct {
+	server        string
+	zone          string
+	tsigKeyName   string
+	tsigAlgorithm string
+	tsigSecret    string
+}
+
+// setTXT replaces any TXT records at fqdn with one containing value.
+func (u dnsUpdater) setTXT(fqdn, value string, timeout time.Duration) error {
+	rr := &dns.TXT{
+		Hdr: dns.RR_Header{Name: fqdn, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: 0},
+		Txt: []string{value},
+	}
+	m := new(dns.Msg)
+	m.SetUpdate(u.zone)
+	m.RemoveRRset([]dns.RR{rr})
+	m.Insert([]dns.RR{rr})
+	return u.exchange(m, timeout)
+}
+
+// removeTXT removes any TXT records at fqdn.
+func (u dnsUpdater) removeTXT(fqdn string, timeout time.Duration) error {
+	m := new(dns.Msg)
+	m.SetUpdate(u.zone)
+	m.RemoveRRset([]dns.RR{&dns.TXT{
+		Hdr: dns.RR_Header{Name: fqdn, Rrtype: dns.TypeTXT, Class: dns.ClassINET},
+	}})
+	return u.exchange(m, timeout)
+}
+
+func (u dnsUpdater) exchange(m *dns.Msg, timeout time.Duration) error {
+	c := dns.Client{Timeout: timeout, Net: "tcp"}
+	if u.tsigKeyName != "" {
+		m.SetTsig(u.tsigKeyName, u.tsigAlgorithm, 300, time.Now().Unix())
+		c.TsigSecret = map[string]string{u.tsigKeyName: u.tsigSecret}
+	}
+	r, _, err := c.Exchange(m, u.server)
+	if err != nil {
+		return err
+	}
+	if r.Rcode != dns.RcodeSuccess {
+		return fmt.Errorf("update rejected with rcode %s", dns.RcodeToString[r.Rcode])
+	}
+	return nil
+}
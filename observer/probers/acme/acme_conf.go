@@ -0,0 +1,192 @@
+package probers
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/observer/probers"
+	"github.com/letsencrypt/boulder/strictyaml"
+)
+
+const (
+	stepLatencyName = "obs_acme_step_latency"
+)
+
+var validTSIGAlgorithms = map[string]string{
+	"hmac-sha256": dns.HmacSHA256,
+	"hmac-sha512": dns.HmacSHA512,
+}
+
+// ACMEConf is exported to receive YAML configuration.
+type ACMEConf struct {
+	DirectoryURL   string `yaml:"directory_url"`
+	Domain         string `yaml:"domain"`
+	DNSServer      string `yaml:"dns_server"`
+	Zone           string `yaml:"zone"`
+	TSIGKeyName    string `yaml:"tsig_key_name"`
+	TSIGAlgorithm  string `yaml:"tsig_algorithm"`
+	TSIGSecretFile string `yaml:"tsig_secret_file"`
+}
+
+// Kind returns a name that uniquely identifies the `Kind` of `Configurer`.
+func (c ACMEConf) Kind() string {
+	return "ACME"
+}
+
+// UnmarshalSettings constructs an ACMEConf object from YAML as bytes.
+func (c ACMEConf) UnmarshalSettings(settings []byte) (probers.Configurer, error) {
+	var conf ACMEConf
+	err := strictyaml.Unmarshal(settings, &conf)
+	if err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+func (c ACMEConf) validateDirectoryURL() error {
+	url, err := url.Parse(c.DirectoryURL)
+	if err != nil {
+		return fmt.Errorf(
+			"invalid 'directory_url', got: %q, expected a valid url", c.DirectoryURL)
+	}
+	if url.Scheme != "http" && url.Scheme != "https" {
+		return fmt.Errorf(
+			"invalid 'directory_url', got: %q, expected an http or https url", c.DirectoryURL)
+	}
+	return nil
+}
+
+func (c ACMEConf) validateDomain() error {
+	zone := dns.Fqdn(strings.ToLower(c.Zone))
+	if c.Zone == "" || !dns.IsFqdn(zone) {
+		return fmt.Errorf("invalid 'zone', got: %q, expected an fqdn", c.Zone)
+	}
+	domain := dns.Fqdn(strings.ToLower(c.Domain))
+	if c.Domain == "" || !dns.IsSubDomain(zone, domain) {
+		return fmt.Errorf(
+			"invalid 'domain', got: %q, expected a name in zone %q", c.Domain, c.Zone)
+	}
+	return nil
+}
+
+func (c ACMEConf) validateDNSServer() error {
+	_, port, err := net.SplitHostPort(c.DNSServer)
+	if err != nil {
+		return fmt.Errorf(
+			"invalid 'dns_server', %q, could not be split: %s", c.DNSServer, err)
+	}
+	portNum, err := strconv.Atoi(port)
+	if err != nil || portNum <= 0 || portNum > 65535 {
+		return fmt.Errorf(
+			"invalid 'dns_server', %q, port number must be one in [1-65535]", c.DNSServer)
+	}
+	return nil
+}
+
+// tsig returns the TSIG key name, algorithm, and secret with which to sign
+// DNS updates, or empty strings if they aren't to be signed.
+func (c ACMEConf) tsig() (string, string, string, error) {
+	if c.TSIGKeyName == "" && c.TSIGSecretFile == "" {
+		return "", "", "", nil
+	}
+	if c.TSIGKeyName == "" || c.TSIGSecretFile == "" {
+		return "", "", "", fmt.Errorf(
+			"invalid TSIG settings, 'tsig_key_name' and 'tsig_secret_file' must be set together")
+	}
+	algorithm := c.TSIGAlgorithm
+	if algorithm == "" {
+		algorithm = "hmac-sha256"
+	}
+	alg, ok := validTSIGAlgorithms[strings.ToLower(algorithm)]
+	if !ok {
+		return "", "", "", fmt.Errorf(
+			"invalid 'tsig_algorithm', got: %q, expected one of hmac-sha256 or hmac-sha512", c.TSIGAlgorithm)
+	}
+	secret, err := os.ReadFile(c.TSIGSecretFile)
+	if err != nil {
+		return "", "", "", fmt.Errorf("reading 'tsig_secret_file': %w", err)
+	}
+	return dns.Fqdn(c.TSIGKeyName), alg, strings.TrimSpace(string(secret)), nil
+}
+
+// MakeProber constructs an `ACMEProbe` object from the contents of the
+// bound `ACMEConf` object. If the `ACMEConf` cannot be validated, an
+// error appropriate for end-user consumption is returned instead.
+func (c ACMEConf) MakeProber(collectors map[string]prometheus.Collector) (probers.Prober, error) {
+	// validate `directory_url`
+	err := c.validateDirectoryURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// validate `domain` and `zone`
+	err = c.validateDomain()
+	if err != nil {
+		return nil, err
+	}
+
+	// validate `dns_server`
+	err = c.validateDNSServer()
+	if err != nil {
+		return nil, err
+	}
+
+	// validate and load the TSIG settings
+	keyName, alg, secret, err := c.tsig()
+	if err != nil {
+		return nil, err
+	}
+
+	// validate the prometheus collectors that were passed in
+	coll, ok := collectors[stepLatencyName]
+	if !ok {
+		return nil, fmt.Errorf("acme prober did not receive collector %q", stepLatencyName)
+	}
+	stepLatencyColl, ok := coll.(*prometheus.HistogramVec)
+	if !ok {
+		return nil, fmt.Errorf("acme prober received collector %q of wrong type, got: %T, expected *prometheus.HistogramVec", stepLatencyName, coll)
+	}
+
+	return ACMEProbe{
+		directoryURL: c.DirectoryURL,
+		domain:       strings.TrimSuffix(strings.ToLower(c.Domain), "."),
+		updater: dnsUpdater{
+			server:        c.DNSServer,
+			zone:          dns.Fqdn(strings.ToLower(c.Zone)),
+			tsigKeyName:   keyName,
+			tsigAlgorithm: alg,
+			tsigSecret:    secret,
+		},
+		stepLatency: stepLatencyColl,
+	}, nil
+}
+
+// Instrument constructs any `prometheus.Collector` objects the `ACMEProbe`
+// will need to report its own metrics. A map is returned containing the
+// constructed objects, indexed by the name of the prometheus metric. If no
+// objects were constructed, nil is returned.
+func (c ACMEConf) Instrument() map[string]prometheus.Collector {
+	stepLatency := prometheus.Collector(prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    stepLatencyName,
+			Help:    "Latency of each step of an ACME issuance, in seconds",
+			Buckets: []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+		}, []string{"name", "step", "success"},
+	))
+	return map[string]prometheus.Collector{
+		stepLatencyName: stepLatency,
+	}
+}
+
+// init is called at runtime and registers `ACMEConf`, a `Prober`
+// `Configurer` type, as "ACME".
+func init() {
+	probers.Register(ACMEConf{})
+}
@@ -0,0 +1,216 @@
+package probers
+
+import (
+	"os"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/letsencrypt/boulder/observer/probers"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestACMEConf_validateDirectoryURL(t *testing.T) {
+	type fields struct {
+		DirectoryURL string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{"valid https", fields{"https://acme-staging-v02.api.letsencrypt.org/directory"}, false},
+		{"valid http", fields{"http://boulder.service.consul:4001/directory"}, false},
+		{"bad scheme", fields{"ftp://example.com/directory"}, true},
+		{"no scheme", fields{"example.com/directory"}, true},
+		{"unparseable", fields{":::::"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := ACMEConf{
+				DirectoryURL: tt.fields.DirectoryURL,
+			}
+			err := c.validateDirectoryURL()
+			if tt.wantErr {
+				test.AssertError(t, err, "ACMEConf.validateDirectoryURL() should have errored")
+			} else {
+				test.AssertNotError(t, err, "ACMEConf.validateDirectoryURL() shouldn't have errored")
+			}
+		})
+	}
+}
+
+func TestACMEConf_validateDomain(t *testing.T) {
+	type fields struct {
+		Domain string
+		Zone   string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{"in zone", fields{"probe.test.example.com", "test.example.com"}, false},
+		{"in zone with trailing dots", fields{"probe.test.example.com.", "test.example.com."}, false},
+		{"in zone different case", fields{"Probe.Test.Example.com", "test.example.com"}, false},
+		{"zone apex", fields{"test.example.com", "test.example.com"}, false},
+		{"outside zone", fields{"probe.example.net", "test.example.com"}, true},
+		{"parent of zone", fields{"example.com", "test.example.com"}, true},
+		{"missing domain", fields{"", "test.example.com"}, true},
+		{"missing zone", fields{"probe.test.example.com", ""}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := ACMEConf{
+				Domain: tt.fields.Domain,
+				Zone:   tt.fields.Zone,
+			}
+			err := c.validateDomain()
+			if tt.wantErr {
+				test.AssertError(t, err, "ACMEConf.validateDomain() should have errored")
+			} else {
+				test.AssertNotError(t, err, "ACMEConf.validateDomain() shouldn't have errored")
+			}
+		})
+	}
+}
+
+func TestACMEConf_tsig(t *testing.T) {
+	secretFile := path.Join(t.TempDir(), "tsig")
+	err := os.WriteFile(secretFile, []byte("c2VjcmV0\n"), 0600)
+	test.AssertNotError(t, err, "writing TSIG secret file")
+
+	type fields struct {
+		TSIGKeyName    string
+		TSIGAlgorithm  string
+		TSIGSecretFile string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    []string
+		wantErr bool
+	}{
+		{"unsigned", fields{"", "", ""}, []string{"", "", ""}, false},
+		{"default algorithm", fields{"observer", "", secretFile}, []string{"observer.", "hmac-sha256.", "c2VjcmV0"}, false},
+		{"sha512", fields{"observer.", "HMAC-SHA512", secretFile}, []string{"observer.", "hmac-sha512.", "c2VjcmV0"}, false},
+		{"missing secret file", fields{"observer", "", ""}, nil, true},
+		{"missing key name", fields{"", "", secretFile}, nil, true},
+		{"unreadable secret file", fields{"observer", "", secretFile + ".missing"}, nil, true},
+		{"bad algorithm", fields{"observer", "hmac-md5", secretFile}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := ACMEConf{
+				TSIGKeyName:    tt.fields.TSIGKeyName,
+				TSIGAlgorithm:  tt.fields.TSIGAlgorithm,
+				TSIGSecretFile: tt.fields.TSIGSecretFile,
+			}
+			keyName, alg, secret, err := c.tsig()
+			if tt.wantErr {
+				test.AssertError(t, err, "ACMEConf.tsig() should have errored")
+				return
+			}
+			test.AssertNotError(t, err, "ACMEConf.tsig() shouldn't have errored")
+			test.AssertDeepEquals(t, []string{keyName, alg, secret}, tt.want)
+		})
+	}
+}
+
+func TestACMEConf_MakeProber(t *testing.T) {
+	conf := ACMEConf{}
+	colls := conf.Instrument()
+	badColl := prometheus.Collector(prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "obs_acme_foo",
+			Help: "Hmmm, this shouldn't be here...",
+		},
+		[]string{},
+	))
+	type fields struct {
+		DirectoryURL string
+		Domain       string
+		DNSServer    string
+		Zone         string
+		Colls        map[string]prometheus.Collector
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{"valid", fields{"https://acme.example.com/directory", "probe.test.example.com", "10.0.0.1:53", "test.example.com", colls}, false},
+		{"bad directory url", fields{"acme.example.com", "probe.test.example.com", "10.0.0.1:53", "test.example.com", colls}, true},
+		{"domain outside zone", fields{"https://acme.example.com/directory", "probe.example.net", "10.0.0.1:53", "test.example.com", colls}, true},
+		{"bad dns server", fields{"https://acme.example.com/directory", "probe.test.example.com", "10.0.0.1", "test.example.com", colls}, true},
+		{"missing step latency collector", fields{"https://acme.example.com/directory", "probe.test.example.com", "10.0.0.1:53", "test.example.com", map[string]prometheus.Collector{}}, true},
+		{"wrong step latency collector", fields{"https://acme.example.com/directory", "probe.test.example.com", "10.0.0.1:53", "test.example.com", map[string]prometheus.Collector{stepLatencyName: badColl}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := ACMEConf{
+				DirectoryURL: tt.fields.DirectoryURL,
+				Domain:       tt.fields.Domain,
+				DNSServer:    tt.fields.DNSServer,
+				Zone:         tt.fields.Zone,
+			}
+			p, err := c.MakeProber(tt.fields.Colls)
+			if tt.wantErr {
+				test.AssertError(t, err, "ACMEConf.MakeProber() should have errored")
+			} else {
+				test.AssertNotError(t, err, "ACMEConf.MakeProber() shouldn't have errored")
+				prober, ok := p.(ACMEProbe)
+				test.Assert(t, ok, "ACMEConf.MakeProber() should return an ACMEProbe")
+				test.AssertEquals(t, prober.domain, "probe.test.example.com")
+				test.AssertEquals(t, prober.updater.zone, "test.example.com.")
+			}
+		})
+	}
+}
+
+func TestACMEConf_UnmarshalSettings(t *testing.T) {
+	type fields struct {
+		directory_url interface{}
+		domain        interface{}
+		dns_server    interface{}
+		zone          interface{}
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    probers.Configurer
+		wantErr bool
+	}{
+		{"valid", fields{"https://acme.example.com/directory", "probe.test.example.com", "10.0.0.1:53", "test.example.com"}, ACMEConf{
+			DirectoryURL: "https://acme.example.com/directory",
+			Domain:       "probe.test.example.com",
+			DNSServer:    "10.0.0.1:53",
+			Zone:         "test.example.com",
+		}, false},
+		{"invalid directory_url (map)", fields{make(map[string]interface{}), 42, 42, 42}, nil, true},
+		{"invalid domain (list)", fields{42, make([]string, 0), 42, 42}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := probers.Settings{
+				"directory_url": tt.fields.directory_url,
+				"domain":        tt.fields.domain,
+				"dns_server":    tt.fields.dns_server,
+				"zone":          tt.fields.zone,
+			}
+			settingsBytes, _ := yaml.Marshal(settings)
+			c := ACMEConf{}
+			got, err := c.UnmarshalSettings(settingsBytes)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ACMEConf.UnmarshalSettings() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ACMEConf.UnmarshalSettings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
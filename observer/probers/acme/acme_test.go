@@ -0,0 +1,82 @@
+package probers
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+// updateRecorder is a DNS server which records the updates it accepts.
+type updateRecorder struct {
+	sync.Mutex
+	updates []*dns.Msg
+}
+
+func (r *updateRecorder) ServeDNS(w dns.ResponseWriter, req *dns.Msg) {
+	m := new(dns.Msg)
+	m.SetReply(req)
+	if req.IsTsig() != nil && w.TsigStatus() != nil {
+		m.Rcode = dns.RcodeNotAuth
+	} else {
+		r.Lock()
+		r.updates = append(r.updates, req)
+		r.Unlock()
+	}
+	_ = w.WriteMsg(m)
+}
+
+func TestDNSUpdater(t *testing.T) {
+	t.Parallel()
+
+	const keyName, secret = "observer.", "c2VjcmV0c2VjcmV0c2VjcmV0"
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	test.AssertNotError(t, err, "listening")
+	recorder := &updateRecorder{}
+	started := make(chan struct{})
+	server := &dns.Server{
+		Listener:          l,
+		Handler:           recorder,
+		TsigSecret:        map[string]string{keyName: secret},
+		NotifyStartedFunc: func() { close(started) },
+		// The default accept function rejects updates.
+		MsgAcceptFunc: func(dns.Header) dns.MsgAcceptAction { return dns.MsgAccept },
+	}
+	go func() { _ = server.ActivateAndServe() }()
+	defer func() { _ = server.Shutdown() }()
+	<-started
+
+	u := dnsUpdater{
+		server:        l.Addr().String(),
+		zone:          "test.example.com.",
+		tsigKeyName:   keyName,
+		tsigAlgorithm: dns.HmacSHA256,
+		tsigSecret:    secret,
+	}
+	err = u.setTXT("_acme-challenge.probe.test.example.com.", "token", time.Second)
+	test.AssertNotError(t, err, "setting TXT record")
+	err = u.removeTXT("_acme-challenge.probe.test.example.com.", time.Second)
+	test.AssertNotError(t, err, "removing TXT record")
+
+	recorder.Lock()
+	defer recorder.Unlock()
+	test.AssertEquals(t, len(recorder.updates), 2)
+	set := recorder.updates[0]
+	test.AssertEquals(t, set.Opcode, dns.OpcodeUpdate)
+	test.AssertEquals(t, set.Question[0].Name, "test.example.com.")
+	test.AssertEquals(t, len(set.Ns), 2)
+	test.AssertEquals(t, set.Ns[0].Header().Class, uint16(dns.ClassANY))
+	test.AssertDeepEquals(t, set.Ns[1].(*dns.TXT).Txt, []string{"token"})
+	remove := recorder.updates[1]
+	test.AssertEquals(t, len(remove.Ns), 1)
+	test.AssertEquals(t, remove.Ns[0].Header().Class, uint16(dns.ClassANY))
+
+	// Updates signed with the wrong secret are rejected.
+	u.tsigSecret = "d3Jvbmd3cm9uZ3dyb25n"
+	err = u.setTXT("_acme-challenge.probe.test.example.com.", "token", time.Second)
+	test.AssertError(t, err, "update with wrong TSIG secret should have failed")
+}
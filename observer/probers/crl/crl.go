@@ -1,7 +1,9 @@
 package probers
 
 import (
+	"context"
 	"crypto/x509"
+	"fmt"
 	"io"
 	"net/http"
 	"slices"
@@ -17,9 +19,12 @@ import (
 type CRLProbe struct {
 	url         string
 	partitioned bool
+	issuer      *x509.Certificate
+	maxAge      time.Duration
 	cNextUpdate *prometheus.GaugeVec
 	cThisUpdate *prometheus.GaugeVec
 	cCertCount  *prometheus.GaugeVec
+	cHorizon    *prometheus.GaugeVec
 }
 
 // Name returns a string that uniquely identifies the monitor.
@@ -33,12 +38,21 @@ func (p CRLProbe) Kind() string {
 }
 
 // Probe requests the configured CRL and publishes metrics about it if found.
+// The probe fails if the CRL isn't signed by the configured issuer, or isn't
+// fresh.
 func (p CRLProbe) Probe(timeout time.Duration) (bool, time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 	start := time.Now()
-	resp, err := http.Get(p.url)
+	req, err := http.NewRequestWithContext(ctx, "GET", p.url, nil)
 	if err != nil {
 		return false, time.Since(start)
 	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, time.Since(start)
+	}
+	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -51,6 +65,13 @@ func (p CRLProbe) Probe(timeout time.Duration) (bool, time.Duration) {
 		return false, dur
 	}
 
+	if p.issuer != nil {
+		err = crl.CheckSignatureFrom(p.issuer)
+		if err != nil {
+			return false, dur
+		}
+	}
+
 	// Partitioned CRLs MUST contain an issuingDistributionPoint extension, which
 	// MUST contain the URL from which they were fetched, to prevent substitution
 	// attacks.
@@ -65,9 +86,32 @@ func (p CRLProbe) Probe(timeout time.Duration) (bool, time.Duration) {
 	}
 
 	// Report metrics for this CRL
+	now := time.Now()
 	p.cThisUpdate.WithLabelValues(p.url).Set(float64(crl.ThisUpdate.Unix()))
 	p.cNextUpdate.WithLabelValues(p.url).Set(float64(crl.NextUpdate.Unix()))
 	p.cCertCount.WithLabelValues(p.url).Set(float64(len(crl.RevokedCertificateEntries)))
+	p.cHorizon.WithLabelValues(p.url).Set(crl.NextUpdate.Sub(now).Seconds())
+
+	err = checkFreshness(now, crl.ThisUpdate, crl.NextUpdate, p.maxAge)
+	if err != nil {
+		return false, dur
+	}
 
 	return true, dur
 }
+
+// checkFreshness returns an error if a publication with the given thisUpdate
+// and nextUpdate isn't valid at now, or if maxAge is non-zero and thisUpdate
+// is more than maxAge before now.
+func checkFreshness(now, thisUpdate, nextUpdate time.Time, maxAge time.Duration) error {
+	if thisUpdate.After(now) {
+		return fmt.Errorf("thisUpdate %s is in the future", thisUpdate)
+	}
+	if !nextUpdate.IsZero() && !nextUpdate.After(now) {
+		return fmt.Errorf("nextUpdate %s has passed", nextUpdate)
+	}
+	if maxAge != 0 && now.Sub(thisUpdate) > maxAge {
+		return fmt.Errorf("thisUpdate %s is more than %s ago", thisUpdate, maxAge)
+	}
+	return nil
+}
@@ -1,11 +1,14 @@
 package probers
 
 import (
+	"crypto/x509"
 	"fmt"
 	"net/url"
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/core"
 	"github.com/letsencrypt/boulder/observer/probers"
 	"github.com/letsencrypt/boulder/strictyaml"
 )
@@ -14,12 +17,15 @@ const (
 	nextUpdateName = "obs_crl_next_update"
 	thisUpdateName = "obs_crl_this_update"
 	certCountName  = "obs_crl_revoked_cert_count"
+	horizonName    = "obs_crl_seconds_until_next_update"
 )
 
 // CRLConf is exported to receive YAML configuration
 type CRLConf struct {
-	URL         string `yaml:"url"`
-	Partitioned bool   `yaml:"partitioned"`
+	URL         string          `yaml:"url"`
+	Partitioned bool            `yaml:"partitioned"`
+	Issuer      string          `yaml:"issuer"`
+	MaxAge      config.Duration `yaml:"max_age"`
 }
 
 // Kind returns a name that uniquely identifies the `Kind` of `Configurer`.
@@ -51,16 +57,48 @@ func (c CRLConf) validateURL() error {
 	return nil
 }
 
+// loadIssuer loads the issuer certificate against which the CRL's signature
+// is verified, or returns nil if none is configured.
+func (c CRLConf) loadIssuer() (*x509.Certificate, error) {
+	if c.Issuer == "" {
+		return nil, nil
+	}
+	issuer, err := core.LoadCert(c.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid 'issuer', got: %q, expected a PEM certificate: %s", c.Issuer, err)
+	}
+	return issuer, nil
+}
+
+func (c CRLConf) validateMaxAge() error {
+	if c.MaxAge.Duration < 0 {
+		return fmt.Errorf("invalid 'max_age', got: %s, expected a non-negative duration", c.MaxAge.Duration)
+	}
+	return nil
+}
+
 // MakeProber constructs a `CRLProbe` object from the contents of the
 // bound `CRLConf` object. If the `CRLConf` cannot be validated, an
 // error appropriate for end-user consumption is returned instead.
-func (c CRLConf) MakeProber(collectors map[string]prometheus.Collector) (probers.Prober, error) { // validate `url` err := c.validateURL()
+func (c CRLConf) MakeProber(collectors map[string]prometheus.Collector) (probers.Prober, error) {
 	// validate `url`
 	err := c.validateURL()
 	if err != nil {
 		return nil, err
 	}
 
+	// validate `max_age`
+	err = c.validateMaxAge()
+	if err != nil {
+		return nil, err
+	}
+
+	// load `issuer`
+	issuer, err := c.loadIssuer()
+	if err != nil {
+		return nil, err
+	}
+
 	// validate the prometheus collectors that were passed in
 	coll, ok := collectors[nextUpdateName]
 	if !ok {
@@ -89,7 +127,25 @@ func (c CRLConf) MakeProber(collectors map[string]prometheus.Collector) (probers
 		return nil, fmt.Errorf("crl prober received collector %q of wrong type, got: %T, expected *prometheus.GaugeVec", certCountName, coll)
 	}
 
-	return CRLProbe{c.URL, c.Partitioned, nextUpdateColl, thisUpdateColl, certCountColl}, nil
+	coll, ok = collectors[horizonName]
+	if !ok {
+		return nil, fmt.Errorf("crl prober did not receive collector %q", horizonName)
+	}
+	horizonColl, ok := coll.(*prometheus.GaugeVec)
+	if !ok {
+		return nil, fmt.Errorf("crl prober received collector %q of wrong type, got: %T, expected *prometheus.GaugeVec", horizonName, coll)
+	}
+
+	return CRLProbe{
+		url:         c.URL,
+		partitioned: c.Partitioned,
+		issuer:      issuer,
+		maxAge:      c.MaxAge.Duration,
+		cNextUpdate: nextUpdateColl,
+		cThisUpdate: thisUpdateColl,
+		cCertCount:  certCountColl,
+		cHorizon:    horizonColl,
+	}, nil
 }
 
 // Instrument constructs any `prometheus.Collector` objects the `CRLProbe` will
@@ -115,10 +171,17 @@ func (c CRLConf) Instrument() map[string]prometheus.Collector {
 			Help: "number of certificates revoked in CRL",
 		}, []string{"url"},
 	))
+	horizon := prometheus.Collector(prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: horizonName,
+			Help: "seconds remaining until CRL nextUpdate, as of the last probe",
+		}, []string{"url"},
+	))
 	return map[string]prometheus.Collector{
 		nextUpdateName: nextUpdate,
 		thisUpdateName: thisUpdate,
 		certCountName:  certCount,
+		horizonName:    horizon,
 	}
 }
 
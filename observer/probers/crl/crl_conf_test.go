@@ -2,10 +2,12 @@ package probers
 
 import (
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/yaml.v3"
 
+	"github.com/letsencrypt/boulder/config"
 	"github.com/letsencrypt/boulder/observer/probers"
 	"github.com/letsencrypt/boulder/test"
 )
@@ -21,7 +23,9 @@ func TestCRLConf_MakeProber(t *testing.T) {
 		[]string{},
 	))
 	type fields struct {
-		URL string
+		URL    string
+		Issuer string
+		MaxAge time.Duration
 	}
 	tests := []struct {
 		name    string
@@ -30,21 +34,23 @@ func TestCRLConf_MakeProber(t *testing.T) {
 		wantErr bool
 	}{
 		// valid
-		{"valid fqdn", fields{"http://example.com"}, colls, false},
-		{"valid fqdn with path", fields{"http://example.com/foo/bar"}, colls, false},
-		{"valid hostname", fields{"http://example"}, colls, false},
+		{"valid fqdn", fields{URL: "http://example.com"}, colls, false},
+		{"valid fqdn with path", fields{URL: "http://example.com/foo/bar"}, colls, false},
+		{"valid hostname", fields{URL: "http://example"}, colls, false},
 		// invalid
-		{"bad fqdn", fields{":::::"}, colls, true},
-		{"missing scheme", fields{"example.com"}, colls, true},
+		{"bad fqdn", fields{URL: ":::::"}, colls, true},
+		{"missing scheme", fields{URL: "example.com"}, colls, true},
+		{"missing issuer", fields{URL: "http://example.com", Issuer: "nonexistent.pem"}, colls, true},
+		{"negative max age", fields{URL: "http://example.com", MaxAge: -time.Hour}, colls, true},
 		{
 			"unexpected collector",
-			fields{"http://example.com"},
+			fields{URL: "http://example.com"},
 			map[string]prometheus.Collector{"obs_crl_foo": badColl},
 			true,
 		},
 		{
 			"missing collectors",
-			fields{"http://example.com"},
+			fields{URL: "http://example.com"},
 			map[string]prometheus.Collector{},
 			true,
 		},
@@ -52,7 +58,9 @@ func TestCRLConf_MakeProber(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := CRLConf{
-				URL: tt.fields.URL,
+				URL:    tt.fields.URL,
+				Issuer: tt.fields.Issuer,
+				MaxAge: config.Duration{Duration: tt.fields.MaxAge},
 			}
 			p, err := c.MakeProber(tt.colls)
 			if tt.wantErr {
@@ -65,6 +73,7 @@ func TestCRLConf_MakeProber(t *testing.T) {
 				test.AssertNotNil(t, prober.cThisUpdate, "CRLConf.MakeProber(): nil cThisUpdate")
 				test.AssertNotNil(t, prober.cNextUpdate, "CRLConf.MakeProber(): nil cNextUpdate")
 				test.AssertNotNil(t, prober.cCertCount, "CRLConf.MakeProber(): nil cCertCount")
+				test.AssertNotNil(t, prober.cHorizon, "CRLConf.MakeProber(): nil cHorizon")
 			}
 		})
 	}
@@ -77,8 +86,9 @@ func TestCRLConf_UnmarshalSettings(t *testing.T) {
 		want    probers.Configurer
 		wantErr bool
 	}{
-		{"valid", probers.Settings{"url": "google.com"}, CRLConf{"google.com", false}, false},
-		{"valid with partitioned", probers.Settings{"url": "google.com", "partitioned": true}, CRLConf{"google.com", true}, false},
+		{"valid", probers.Settings{"url": "google.com"}, CRLConf{URL: "google.com"}, false},
+		{"valid with partitioned", probers.Settings{"url": "google.com", "partitioned": true}, CRLConf{URL: "google.com", Partitioned: true}, false},
+		{"valid with freshness", probers.Settings{"url": "google.com", "issuer": "int.pem", "max_age": "48h"}, CRLConf{URL: "google.com", Issuer: "int.pem", MaxAge: config.Duration{Duration: 48 * time.Hour}}, false},
 		{"invalid (map)", probers.Settings{"url": make(map[string]interface{})}, nil, true},
 		{"invalid (list)", probers.Settings{"url": make([]string, 0)}, nil, true},
 	}
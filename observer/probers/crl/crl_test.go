@@ -0,0 +1,98 @@
+package probers
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+// newTestIssuer returns a self-signed CA certificate and its key.
+func newTestIssuer(t *testing.T) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating issuer key")
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "observer test issuer"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		SubjectKeyId:          []byte{1, 2, 3, 4},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	test.AssertNotError(t, err, "creating issuer certificate")
+	issuer, err := x509.ParseCertificate(der)
+	test.AssertNotError(t, err, "parsing issuer certificate")
+	return issuer, key
+}
+
+func TestCRLProbe(t *testing.T) {
+	t.Parallel()
+
+	issuer, key := newTestIssuer(t)
+	otherIssuer, _ := newTestIssuer(t)
+
+	now := time.Now().Truncate(time.Second)
+	tests := []struct {
+		name       string
+		thisUpdate time.Time
+		nextUpdate time.Time
+		issuer     *x509.Certificate
+		maxAge     time.Duration
+		want       bool
+	}{
+		{"fresh", now.Add(-time.Hour), now.Add(time.Hour), issuer, 0, true},
+		{"fresh within max age", now.Add(-time.Hour), now.Add(time.Hour), issuer, 2 * time.Hour, true},
+		{"fresh without issuer", now.Add(-time.Hour), now.Add(time.Hour), nil, 0, true},
+		{"wrong issuer", now.Add(-time.Hour), now.Add(time.Hour), otherIssuer, 0, false},
+		{"expired", now.Add(-2 * time.Hour), now.Add(-time.Hour), issuer, 0, false},
+		{"not yet valid", now.Add(time.Hour), now.Add(2 * time.Hour), issuer, 0, false},
+		{"older than max age", now.Add(-2 * time.Hour), now.Add(time.Hour), issuer, time.Hour, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			der, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+				Number:     big.NewInt(1),
+				ThisUpdate: tt.thisUpdate,
+				NextUpdate: tt.nextUpdate,
+			}, issuer, key)
+			test.AssertNotError(t, err, "creating CRL")
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write(der)
+			}))
+			defer srv.Close()
+
+			colls := CRLConf{}.Instrument()
+			p := CRLProbe{
+				url:         srv.URL,
+				issuer:      tt.issuer,
+				maxAge:      tt.maxAge,
+				cNextUpdate: colls[nextUpdateName].(*prometheus.GaugeVec),
+				cThisUpdate: colls[thisUpdateName].(*prometheus.GaugeVec),
+				cCertCount:  colls[certCountName].(*prometheus.GaugeVec),
+				cHorizon:    colls[horizonName].(*prometheus.GaugeVec),
+			}
+			got, _ := p.Probe(time.Second)
+			test.AssertEquals(t, got, tt.want)
+			if tt.issuer == issuer || tt.issuer == nil {
+				// Metrics are reported for validly signed CRLs even if
+				// they're stale.
+				test.AssertMetricWithLabelsEquals(t, p.cNextUpdate, prometheus.Labels{"url": srv.URL}, float64(tt.nextUpdate.Unix()))
+			}
+		})
+	}
+}
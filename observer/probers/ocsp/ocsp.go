@@ -0,0 +1,113 @@
+package probers
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/letsencrypt/boulder/core"
+)
+
+// OCSPProbe is the exported 'Prober' object for monitors configured to
+// monitor the OCSP responses for a canary certificate.
+type OCSPProbe struct {
+	url         string
+	issuer      *x509.Certificate
+	serial      *big.Int
+	response    int
+	maxAge      time.Duration
+	cNextUpdate *prometheus.GaugeVec
+	cThisUpdate *prometheus.GaugeVec
+	cHorizon    *prometheus.GaugeVec
+}
+
+// Name returns a string that uniquely identifies the monitor.
+func (p OCSPProbe) Name() string {
+	return fmt.Sprintf("%s-%s", p.url, core.SerialToString(p.serial))
+}
+
+// Kind returns a name that uniquely identifies the `Kind` of `Prober`.
+func (p OCSPProbe) Kind() string {
+	return "OCSP"
+}
+
+// Probe requests the OCSP response for the configured serial and publishes
+// metrics about it if found. The probe fails if the response isn't signed by
+// the configured issuer, doesn't have the expected status, or isn't fresh.
+func (p OCSPProbe) Probe(timeout time.Duration) (bool, time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	start := time.Now()
+	ocspReq, err := ocsp.CreateRequest(&x509.Certificate{SerialNumber: p.serial}, p.issuer, nil)
+	if err != nil {
+		return false, time.Since(start)
+	}
+	url := fmt.Sprintf("%s/%s", p.url, base64.StdEncoding.EncodeToString(ocspReq))
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return false, time.Since(start)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, time.Since(start)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, time.Since(start)
+	}
+	dur := time.Since(start)
+	if resp.StatusCode != http.StatusOK {
+		return false, dur
+	}
+
+	// ParseResponseForCert checks that the response is for the configured
+	// serial and is signed by the issuer, or a responder it delegated to.
+	ocspResp, err := ocsp.ParseResponseForCert(body, &x509.Certificate{SerialNumber: p.serial}, p.issuer)
+	if err != nil {
+		return false, dur
+	}
+
+	// Report metrics for this response
+	now := time.Now()
+	serial := core.SerialToString(p.serial)
+	p.cThisUpdate.WithLabelValues(p.url, serial).Set(float64(ocspResp.ThisUpdate.Unix()))
+	p.cNextUpdate.WithLabelValues(p.url, serial).Set(float64(ocspResp.NextUpdate.Unix()))
+	p.cHorizon.WithLabelValues(p.url, serial).Set(ocspResp.NextUpdate.Sub(now).Seconds())
+
+	if ocspResp.Status != p.response {
+		return false, dur
+	}
+
+	err = checkFreshness(now, ocspResp.ThisUpdate, ocspResp.NextUpdate, p.maxAge)
+	if err != nil {
+		return false, dur
+	}
+
+	return true, dur
+}
+
+// checkFreshness returns an error if a publication with the given thisUpdate
+// and nextUpdate isn't valid at now, or if maxAge is non-zero and thisUpdate
+// is more than maxAge before now.
+func checkFreshness(now, thisUpdate, nextUpdate time.Time, maxAge time.Duration) error {
+	if thisUpdate.After(now) {
+		return fmt.Errorf("thisUpdate %s is in the future", thisUpdate)
+	}
+	if !nextUpdate.IsZero() && !nextUpdate.After(now) {
+		return fmt.Errorf("nextUpdate %s has passed", nextUpdate)
+	}
+	if maxAge != 0 && now.Sub(thisUpdate) > maxAge {
+		return fmt.Errorf("thisUpdate %s is more than %s ago", thisUpdate, maxAge)
+	}
+	return nil
+}
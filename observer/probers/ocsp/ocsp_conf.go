@@ -0,0 +1,204 @@
+package probers
+
+import (
+	"crypto/x509"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/observer/probers"
+	"github.com/letsencrypt/boulder/strictyaml"
+)
+
+const (
+	nextUpdateName = "obs_ocsp_next_update"
+	thisUpdateName = "obs_ocsp_this_update"
+	horizonName    = "obs_ocsp_seconds_until_next_update"
+)
+
+// OCSPConf is exported to receive YAML configuration
+type OCSPConf struct {
+	URL      string          `yaml:"url"`
+	Issuer   string          `yaml:"issuer"`
+	Serial   string          `yaml:"serial"`
+	Response string          `yaml:"response"`
+	MaxAge   config.Duration `yaml:"max_age"`
+}
+
+// Kind returns a name that uniquely identifies the `Kind` of `Configurer`.
+func (c OCSPConf) Kind() string {
+	return "OCSP"
+}
+
+// UnmarshalSettings constructs an OCSPConf object from YAML as bytes.
+func (c OCSPConf) UnmarshalSettings(settings []byte) (probers.Configurer, error) {
+	var conf OCSPConf
+	err := strictyaml.Unmarshal(settings, &conf)
+	if err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+func (c OCSPConf) validateURL() error {
+	url, err := url.Parse(c.URL)
+	if err != nil {
+		return fmt.Errorf(
+			"invalid 'url', got: %q, expected a valid url", c.URL)
+	}
+	if url.Scheme != "http" && url.Scheme != "https" {
+		return fmt.Errorf(
+			"invalid 'url', got: %q, expected an http or https url", c.URL)
+	}
+	return nil
+}
+
+func (c OCSPConf) loadIssuer() (*x509.Certificate, error) {
+	issuer, err := core.LoadCert(c.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid 'issuer', got: %q, expected a PEM certificate: %s", c.Issuer, err)
+	}
+	return issuer, nil
+}
+
+func (c OCSPConf) parseSerial() (*big.Int, error) {
+	serial, ok := new(big.Int).SetString(strings.ReplaceAll(c.Serial, ":", ""), 16)
+	if !ok || serial.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid 'serial', got: %q, expected a positive hex serial number", c.Serial)
+	}
+	return serial, nil
+}
+
+func (c OCSPConf) parseResponse() (int, error) {
+	switch strings.ToLower(c.Response) {
+	case "", "good":
+		return ocsp.Good, nil
+	case "revoked":
+		return ocsp.Revoked, nil
+	default:
+		return 0, fmt.Errorf("invalid 'response', got: %q, expected one of good or revoked", c.Response)
+	}
+}
+
+func (c OCSPConf) validateMaxAge() error {
+	if c.MaxAge.Duration < 0 {
+		return fmt.Errorf("invalid 'max_age', got: %s, expected a non-negative duration", c.MaxAge.Duration)
+	}
+	return nil
+}
+
+// MakeProber constructs an `OCSPProbe` object from the contents of the
+// bound `OCSPConf` object. If the `OCSPConf` cannot be validated, an
+// error appropriate for end-user consumption is returned instead.
+func (c OCSPConf) MakeProber(collectors map[string]prometheus.Collector) (probers.Prober, error) {
+	// validate `url`
+	err := c.validateURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// validate `serial`
+	serial, err := c.parseSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	// validate `response`
+	response, err := c.parseResponse()
+	if err != nil {
+		return nil, err
+	}
+
+	// validate `max_age`
+	err = c.validateMaxAge()
+	if err != nil {
+		return nil, err
+	}
+
+	// load `issuer`
+	issuer, err := c.loadIssuer()
+	if err != nil {
+		return nil, err
+	}
+
+	// validate the prometheus collectors that were passed in
+	coll, ok := collectors[nextUpdateName]
+	if !ok {
+		return nil, fmt.Errorf("ocsp prober did not receive collector %q", nextUpdateName)
+	}
+	nextUpdateColl, ok := coll.(*prometheus.GaugeVec)
+	if !ok {
+		return nil, fmt.Errorf("ocsp prober received collector %q of wrong type, got: %T, expected *prometheus.GaugeVec", nextUpdateName, coll)
+	}
+
+	coll, ok = collectors[thisUpdateName]
+	if !ok {
+		return nil, fmt.Errorf("ocsp prober did not receive collector %q", thisUpdateName)
+	}
+	thisUpdateColl, ok := coll.(*prometheus.GaugeVec)
+	if !ok {
+		return nil, fmt.Errorf("ocsp prober received collector %q of wrong type, got: %T, expected *prometheus.GaugeVec", thisUpdateName, coll)
+	}
+
+	coll, ok = collectors[horizonName]
+	if !ok {
+		return nil, fmt.Errorf("ocsp prober did not receive collector %q", horizonName)
+	}
+	horizonColl, ok := coll.(*prometheus.GaugeVec)
+	if !ok {
+		return nil, fmt.Errorf("ocsp prober received collector %q of wrong type, got: %T, expected *prometheus.GaugeVec", horizonName, coll)
+	}
+
+	return OCSPProbe{
+		url:         strings.TrimSuffix(c.URL, "/"),
+		issuer:      issuer,
+		serial:      serial,
+		response:    response,
+		maxAge:      c.MaxAge.Duration,
+		cNextUpdate: nextUpdateColl,
+		cThisUpdate: thisUpdateColl,
+		cHorizon:    horizonColl,
+	}, nil
+}
+
+// Instrument constructs any `prometheus.Collector` objects the `OCSPProbe`
+// will need to report its own metrics. A map is returned containing the
+// constructed objects, indexed by the name of the prometheus metric. If no
+// objects were constructed, nil is returned.
+func (c OCSPConf) Instrument() map[string]prometheus.Collector {
+	nextUpdate := prometheus.Collector(prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: nextUpdateName,
+			Help: "OCSP response nextUpdate Unix timestamp in seconds",
+		}, []string{"url", "serial"},
+	))
+	thisUpdate := prometheus.Collector(prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: thisUpdateName,
+			Help: "OCSP response thisUpdate Unix timestamp in seconds",
+		}, []string{"url", "serial"},
+	))
+	horizon := prometheus.Collector(prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: horizonName,
+			Help: "seconds remaining until OCSP response nextUpdate, as of the last probe",
+		}, []string{"url", "serial"},
+	))
+	return map[string]prometheus.Collector{
+		nextUpdateName: nextUpdate,
+		thisUpdateName: thisUpdate,
+		horizonName:    horizon,
+	}
+}
+
+// init is called at runtime and registers `OCSPConf`, a `Prober`
+// `Configurer` type, as "OCSP".
+func init() {
+	probers.Register(OCSPConf{})
+}
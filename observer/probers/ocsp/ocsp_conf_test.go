@@ -0,0 +1,111 @@
+package probers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/observer/probers"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestOCSPConf_MakeProber(t *testing.T) {
+	_, _, issuerFile := newTestIssuer(t)
+	conf := OCSPConf{}
+	colls := conf.Instrument()
+	badColl := prometheus.Collector(prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "obs_ocsp_foo",
+			Help: "Hmmm, this shouldn't be here...",
+		},
+		[]string{},
+	))
+	type fields struct {
+		URL      string
+		Issuer   string
+		Serial   string
+		Response string
+		MaxAge   time.Duration
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		colls   map[string]prometheus.Collector
+		wantErr bool
+	}{
+		// valid
+		{"valid", fields{"http://ocsp.example.com", issuerFile, "03a1b2", "", 0}, colls, false},
+		{"valid revoked", fields{"http://ocsp.example.com/", issuerFile, "03:a1:b2", "revoked", time.Hour}, colls, false},
+		// invalid
+		{"bad url", fields{"ocsp.example.com", issuerFile, "03a1b2", "", 0}, colls, true},
+		{"missing issuer", fields{"http://ocsp.example.com", "nonexistent.pem", "03a1b2", "", 0}, colls, true},
+		{"missing serial", fields{"http://ocsp.example.com", issuerFile, "", "", 0}, colls, true},
+		{"bad serial", fields{"http://ocsp.example.com", issuerFile, "xyz", "", 0}, colls, true},
+		{"bad response", fields{"http://ocsp.example.com", issuerFile, "03a1b2", "unknown", 0}, colls, true},
+		{"negative max age", fields{"http://ocsp.example.com", issuerFile, "03a1b2", "", -time.Hour}, colls, true},
+		{
+			"unexpected collector",
+			fields{"http://ocsp.example.com", issuerFile, "03a1b2", "", 0},
+			map[string]prometheus.Collector{"obs_ocsp_foo": badColl},
+			true,
+		},
+		{
+			"missing collectors",
+			fields{"http://ocsp.example.com", issuerFile, "03a1b2", "", 0},
+			map[string]prometheus.Collector{},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := OCSPConf{
+				URL:      tt.fields.URL,
+				Issuer:   tt.fields.Issuer,
+				Serial:   tt.fields.Serial,
+				Response: tt.fields.Response,
+				MaxAge:   config.Duration{Duration: tt.fields.MaxAge},
+			}
+			p, err := c.MakeProber(tt.colls)
+			if tt.wantErr {
+				test.AssertError(t, err, "OCSPConf.MakeProber()")
+			} else {
+				test.AssertNotError(t, err, "OCSPConf.MakeProber()")
+
+				test.AssertNotNil(t, p, "OCSPConf.MakeProber(): nil prober")
+				prober := p.(OCSPProbe)
+				test.AssertEquals(t, prober.url, "http://ocsp.example.com")
+				test.AssertEquals(t, prober.Name(), "http://ocsp.example.com-00000000000000000000000000000003a1b2")
+			}
+		})
+	}
+}
+
+func TestOCSPConf_UnmarshalSettings(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  probers.Settings
+		want    probers.Configurer
+		wantErr bool
+	}{
+		{"valid", probers.Settings{"url": "http://ocsp.example.com", "issuer": "int.pem", "serial": "03a1b2"}, OCSPConf{URL: "http://ocsp.example.com", Issuer: "int.pem", Serial: "03a1b2"}, false},
+		{"valid with freshness", probers.Settings{"url": "http://ocsp.example.com", "issuer": "int.pem", "serial": "03a1b2", "response": "revoked", "max_age": "96h"}, OCSPConf{URL: "http://ocsp.example.com", Issuer: "int.pem", Serial: "03a1b2", Response: "revoked", MaxAge: config.Duration{Duration: 96 * time.Hour}}, false},
+		{"invalid (map)", probers.Settings{"url": make(map[string]interface{})}, nil, true},
+		{"invalid (list)", probers.Settings{"serial": make([]string, 0)}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settingsBytes, _ := yaml.Marshal(tt.fields)
+			c := OCSPConf{}
+			got, err := c.UnmarshalSettings(settingsBytes)
+			if tt.wantErr {
+				test.AssertError(t, err, "OCSPConf.UnmarshalSettings()")
+			} else {
+				test.AssertNotError(t, err, "OCSPConf.UnmarshalSettings()")
+			}
+			test.AssertDeepEquals(t, got, tt.want)
+		})
+	}
+}
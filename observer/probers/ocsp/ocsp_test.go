@@ -0,0 +1,110 @@
+package probers
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+// newTestIssuer returns a self-signed CA certificate, its key, and the path to
+// a PEM file containing it.
+func newTestIssuer(t *testing.T) (*x509.Certificate, *ecdsa.PrivateKey, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating issuer key")
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "observer test issuer"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	test.AssertNotError(t, err, "creating issuer certificate")
+	issuer, err := x509.ParseCertificate(der)
+	test.AssertNotError(t, err, "parsing issuer certificate")
+	file := path.Join(t.TempDir(), "issuer.pem")
+	err = os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	test.AssertNotError(t, err, "writing issuer certificate")
+	return issuer, key, file
+}
+
+func TestOCSPProbe(t *testing.T) {
+	t.Parallel()
+
+	issuer, key, _ := newTestIssuer(t)
+	_, otherKey, _ := newTestIssuer(t)
+	serial := big.NewInt(0xc0ffee)
+
+	now := time.Now().Truncate(time.Second)
+	tests := []struct {
+		name       string
+		serial     *big.Int
+		status     int
+		thisUpdate time.Time
+		nextUpdate time.Time
+		signer     *ecdsa.PrivateKey
+		response   int
+		maxAge     time.Duration
+		want       bool
+	}{
+		{"good", serial, ocsp.Good, now.Add(-time.Hour), now.Add(time.Hour), key, ocsp.Good, 0, true},
+		{"revoked", serial, ocsp.Revoked, now.Add(-time.Hour), now.Add(time.Hour), key, ocsp.Revoked, 2 * time.Hour, true},
+		{"unexpected status", serial, ocsp.Revoked, now.Add(-time.Hour), now.Add(time.Hour), key, ocsp.Good, 0, false},
+		{"wrong serial", big.NewInt(0xdecaf), ocsp.Good, now.Add(-time.Hour), now.Add(time.Hour), key, ocsp.Good, 0, false},
+		{"wrong signer", serial, ocsp.Good, now.Add(-time.Hour), now.Add(time.Hour), otherKey, ocsp.Good, 0, false},
+		{"expired", serial, ocsp.Good, now.Add(-2 * time.Hour), now.Add(-time.Hour), key, ocsp.Good, 0, false},
+		{"older than max age", serial, ocsp.Good, now.Add(-2 * time.Hour), now.Add(time.Hour), key, ocsp.Good, time.Hour, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			der, err := ocsp.CreateResponse(issuer, issuer, ocsp.Response{
+				Status:       tt.status,
+				SerialNumber: tt.serial,
+				ThisUpdate:   tt.thisUpdate,
+				NextUpdate:   tt.nextUpdate,
+				RevokedAt:    tt.thisUpdate,
+			}, tt.signer)
+			test.AssertNotError(t, err, "creating OCSP response")
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write(der)
+			}))
+			defer srv.Close()
+
+			colls := OCSPConf{}.Instrument()
+			p := OCSPProbe{
+				url:         srv.URL,
+				issuer:      issuer,
+				serial:      serial,
+				response:    tt.response,
+				maxAge:      tt.maxAge,
+				cNextUpdate: colls[nextUpdateName].(*prometheus.GaugeVec),
+				cThisUpdate: colls[thisUpdateName].(*prometheus.GaugeVec),
+				cHorizon:    colls[horizonName].(*prometheus.GaugeVec),
+			}
+			got, _ := p.Probe(time.Second)
+			test.AssertEquals(t, got, tt.want)
+			if tt.want {
+				test.AssertMetricWithLabelsEquals(t, p.cNextUpdate, prometheus.Labels{"url": srv.URL, "serial": "000000000000000000000000000000c0ffee"}, float64(tt.nextUpdate.Unix()))
+			}
+		})
+	}
+}
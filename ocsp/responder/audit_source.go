@@ -0,0 +1,207 @@
+package responder
+
+import (
+	"bytes"
+	"context"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/letsencrypt/boulder/core"
+	blog "github.com/letsencrypt/boulder/log"
+)
+
+// auditTimeout bounds the time spent regenerating a single response for an
+// audit, which happens after the audited response has already been served.
+const auditTimeout = 10 * time.Second
+
+// auditSource wraps a Source and, for a sampled fraction of the responses it
+// serves, independently regenerates the response from the authoritative
+// certificate status and compares the two. A cache which has diverged from the
+// database is reported by the ocsp_audit_results metric and in the audit log.
+type auditSource struct {
+	base        Source
+	regenerator Source
+	sampleRate  float64
+	// inflight holds a token for each audit in progress. Audits which would
+	// exceed its capacity are skipped, so that audits never queue up behind
+	// live signing.
+	inflight chan struct{}
+	results  *prometheus.CounterVec
+	log      blog.Logger
+	// wg tracks audits in progress, so that tests can wait for them.
+	wg sync.WaitGroup
+}
+
+var _ Source = (*auditSource)(nil)
+
+// NewAuditSource returns a Source which serves responses from base and audits
+// a fraction, sampleRate, of them against a fresh response from regenerator,
+// with at most maxInflight audits in progress at once. The regenerator should
+// sign responses from the database's status without caching them, as the
+// live.Source does.
+func NewAuditSource(base, regenerator Source, sampleRate float64, maxInflight int, stats prometheus.Registerer, log blog.Logger) (*auditSource, error) {
+	if sampleRate <= 0 || sampleRate > 1 {
+		return nil, fmt.Errorf("audit sample rate %g is not in (0, 1]", sampleRate)
+	}
+	if maxInflight < 1 {
+		return nil, fmt.Errorf("audit max inflight %d is less than 1", maxInflight)
+	}
+
+	results := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ocsp_audit_results",
+		Help: "Count of OCSP responses audited against a freshly regenerated response, by result",
+	}, []string{"result"})
+	stats.MustRegister(results)
+
+	return &auditSource{
+		base:        base,
+		regenerator: regenerator,
+		sampleRate:  sampleRate,
+		inflight:    make(chan struct{}, maxInflight),
+		results:     results,
+		log:         log,
+	}, nil
+}
+
+// Response implements the Source interface. It returns the base Source's
+// response, and if the request is sampled, audits that response in the
+// background.
+func (src *auditSource) Response(ctx context.Context, req *ocsp.Request) (*Response, error) {
+	resp, err := src.base.Response(ctx, req)
+	if err != nil || rand.Float64() >= src.sampleRate {
+		return resp, err
+	}
+
+	select {
+	case src.inflight <- struct{}{}:
+	default:
+		src.results.WithLabelValues("skipped").Inc()
+		return resp, nil
+	}
+	src.wg.Add(1)
+	go func() {
+		defer src.wg.Done()
+		defer func() { <-src.inflight }()
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), auditTimeout)
+		defer cancel()
+		src.audit(ctx, req, resp)
+	}()
+	return resp, nil
+}
+
+// audit regenerates the response to req and compares it to served.
+func (src *auditSource) audit(ctx context.Context, req *ocsp.Request, served *Response) {
+	serial := core.SerialToString(req.SerialNumber)
+
+	regenerated, err := src.regenerator.Response(ctx, req)
+	if err != nil {
+		src.results.WithLabelValues("regenerate_error").Inc()
+		src.log.Warningf("auditing OCSP response for serial %s: regenerating response: %s", serial, err)
+		return
+	}
+
+	diffs, err := diffResponses(served.Raw, regenerated.Raw)
+	if err != nil {
+		src.results.WithLabelValues("parse_error").Inc()
+		src.log.Errf("auditing OCSP response for serial %s: %s", serial, err)
+		return
+	}
+	if len(diffs) > 0 {
+		src.results.WithLabelValues("mismatch").Inc()
+		src.log.AuditErrf("OCSP response for serial %s diverges from the database in %v: served=[%x] regenerated=[%x]",
+			serial, diffs, served.Raw, regenerated.Raw)
+		return
+	}
+	src.results.WithLabelValues("match").Inc()
+}
+
+// responseFields are the encoded fields of an OCSP response which are
+// determined by the certificate's issuer and status, rather than by the time at
+// which the response was signed. Two responses for the same certificate should
+// have byte-for-byte identical responseFields, even though their producedAt,
+// thisUpdate, nextUpdate, and signature fields differ.
+type responseFields struct {
+	responderID []byte
+	certID      []byte
+	certStatus  []byte
+}
+
+// These mirror the ASN.1 structures of RFC 6960, Section 4.2.1, up to the
+// fields we compare. Trailing fields are ignored.
+type ocspResponseASN1 struct {
+	Status        asn1.Enumerated
+	ResponseBytes struct {
+		ResponseType asn1.ObjectIdentifier
+		Response     []byte
+	} `asn1:"explicit,tag:0"`
+}
+
+type basicResponseASN1 struct {
+	TBSResponseData struct {
+		Version     int `asn1:"optional,explicit,default:0,tag:0"`
+		ResponderID asn1.RawValue
+		ProducedAt  asn1.RawValue
+		Responses   []struct {
+			CertID     asn1.RawValue
+			CertStatus asn1.RawValue
+		}
+	}
+}
+
+// parseResponseFields extracts the responseFields of a DER-encoded OCSP
+// response containing exactly one SingleResponse.
+func parseResponseFields(der []byte) (*responseFields, error) {
+	var resp ocspResponseASN1
+	rest, err := asn1.Unmarshal(der, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("trailing data after OCSP response")
+	}
+	var basic basicResponseASN1
+	_, err = asn1.Unmarshal(resp.ResponseBytes.Response, &basic)
+	if err != nil {
+		return nil, err
+	}
+	if len(basic.TBSResponseData.Responses) != 1 {
+		return nil, fmt.Errorf("OCSP response contains %d responses, expected 1", len(basic.TBSResponseData.Responses))
+	}
+	single := basic.TBSResponseData.Responses[0]
+	return &responseFields{
+		responderID: basic.TBSResponseData.ResponderID.FullBytes,
+		certID:      single.CertID.FullBytes,
+		certStatus:  single.CertStatus.FullBytes,
+	}, nil
+}
+
+// diffResponses returns the names of the responseFields which differ between
+// two DER-encoded OCSP responses.
+func diffResponses(a, b []byte) ([]string, error) {
+	fa, err := parseResponseFields(a)
+	if err != nil {
+		return nil, fmt.Errorf("parsing served response: %w", err)
+	}
+	fb, err := parseResponseFields(b)
+	if err != nil {
+		return nil, fmt.Errorf("parsing regenerated response: %w", err)
+	}
+	var diffs []string
+	if !bytes.Equal(fa.responderID, fb.responderID) {
+		diffs = append(diffs, "responderID")
+	}
+	if !bytes.Equal(fa.certID, fb.certID) {
+		diffs = append(diffs, "certID")
+	}
+	if !bytes.Equal(fa.certStatus, fb.certStatus) {
+		diffs = append(diffs, "certStatus")
+	}
+	return diffs, nil
+}
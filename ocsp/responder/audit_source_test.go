@@ -0,0 +1,211 @@
+package responder
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/crypto/ocsp"
+
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// staticSource returns the same response, or error, for every request.
+type staticSource struct {
+	resp *Response
+	err  error
+}
+
+func (src staticSource) Response(context.Context, *ocsp.Request) (*Response, error) {
+	return src.resp, src.err
+}
+
+// auditTestSigner signs OCSP responses for certificates with serial 1.
+type auditTestSigner struct {
+	issuer *x509.Certificate
+	key    *ecdsa.PrivateKey
+}
+
+func newAuditTestSigner(t *testing.T) *auditTestSigner {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "audit test issuer"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	test.AssertNotError(t, err, "creating issuer")
+	issuer, err := x509.ParseCertificate(der)
+	test.AssertNotError(t, err, "parsing issuer")
+	return &auditTestSigner{issuer: issuer, key: key}
+}
+
+func (s *auditTestSigner) sign(t *testing.T, thisUpdate time.Time, status int) *Response {
+	t.Helper()
+	template := ocsp.Response{
+		SerialNumber: big.NewInt(1),
+		Status:       status,
+		ThisUpdate:   thisUpdate,
+		NextUpdate:   thisUpdate.Add(24 * time.Hour),
+	}
+	if status == ocsp.Revoked {
+		template.RevokedAt = thisUpdate.Add(-time.Hour).Truncate(time.Second)
+		template.RevocationReason = ocsp.KeyCompromise
+	}
+	der, err := ocsp.CreateResponse(s.issuer, s.issuer, template, s.key)
+	test.AssertNotError(t, err, "creating response")
+	parsed, err := ocsp.ParseResponse(der, nil)
+	test.AssertNotError(t, err, "parsing response")
+	return &Response{Response: parsed, Raw: der}
+}
+
+func TestDiffResponses(t *testing.T) {
+	t.Parallel()
+
+	s := newAuditTestSigner(t)
+	now := time.Now().Truncate(time.Second)
+	good := s.sign(t, now, ocsp.Good)
+
+	// Responses signed at different times, with different signatures, agree.
+	diffs, err := diffResponses(good.Raw, s.sign(t, now.Add(time.Hour), ocsp.Good).Raw)
+	test.AssertNotError(t, err, "diffResponses failed")
+	test.AssertEquals(t, len(diffs), 0)
+
+	diffs, err = diffResponses(good.Raw, s.sign(t, now, ocsp.Revoked).Raw)
+	test.AssertNotError(t, err, "diffResponses failed")
+	test.AssertDeepEquals(t, diffs, []string{"certStatus"})
+
+	// An issuer with the same name but a different key disagrees in the
+	// certID's issuerKeyHash.
+	diffs, err = diffResponses(good.Raw, newAuditTestSigner(t).sign(t, now, ocsp.Good).Raw)
+	test.AssertNotError(t, err, "diffResponses failed")
+	test.AssertDeepEquals(t, diffs, []string{"certID"})
+
+	_, err = diffResponses([]byte("not a response"), good.Raw)
+	test.AssertError(t, err, "diffResponses should fail on garbage")
+	test.AssertContains(t, err.Error(), "parsing served response")
+}
+
+func TestAuditSource(t *testing.T) {
+	t.Parallel()
+
+	s := newAuditTestSigner(t)
+	now := time.Now().Truncate(time.Second)
+	served := s.sign(t, now.Add(-time.Hour), ocsp.Good)
+	req := &ocsp.Request{SerialNumber: big.NewInt(1)}
+
+	testCases := []struct {
+		name        string
+		regenerator staticSource
+		result      string
+		logged      string
+	}{
+		{
+			name:        "match",
+			regenerator: staticSource{resp: s.sign(t, now, ocsp.Good)},
+			result:      "match",
+		},
+		{
+			name:        "mismatch",
+			regenerator: staticSource{resp: s.sign(t, now, ocsp.Revoked)},
+			result:      "mismatch",
+			logged:      `ERR: \[AUDIT\] OCSP response for serial 000000000000000000000000000000000001 diverges from the database in \[certStatus\]`,
+		},
+		{
+			name:        "regenerate error",
+			regenerator: staticSource{err: errors.New("RA unavailable")},
+			result:      "regenerate_error",
+			logged:      "WARNING: auditing OCSP response for serial .*: RA unavailable",
+		},
+		{
+			name:        "parse error",
+			regenerator: staticSource{resp: &Response{Raw: []byte("garbage")}},
+			result:      "parse_error",
+			logged:      "parsing regenerated response",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			log := blog.NewMock()
+			src, err := NewAuditSource(staticSource{resp: served}, tc.regenerator, 1, 1, metrics.NoopRegisterer, log)
+			test.AssertNotError(t, err, "NewAuditSource failed")
+
+			resp, err := src.Response(context.Background(), req)
+			test.AssertNotError(t, err, "Response failed")
+			test.AssertEquals(t, resp, served)
+			src.wg.Wait()
+
+			test.AssertMetricWithLabelsEquals(t, src.results, prometheus.Labels{"result": tc.result}, 1)
+			if tc.logged != "" {
+				test.AssertEquals(t, len(log.GetAllMatching(tc.logged)), 1)
+			}
+		})
+	}
+}
+
+// blockingSource blocks until its context is canceled or it is released.
+type blockingSource struct {
+	release chan struct{}
+}
+
+func (src blockingSource) Response(ctx context.Context, _ *ocsp.Request) (*Response, error) {
+	select {
+	case <-src.release:
+		return nil, errors.New("released")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestAuditSourceLimits(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewAuditSource(staticSource{}, staticSource{}, 0, 1, metrics.NoopRegisterer, blog.NewMock())
+	test.AssertError(t, err, "zero sample rate should be rejected")
+	_, err = NewAuditSource(staticSource{}, staticSource{}, 1.5, 1, metrics.NoopRegisterer, blog.NewMock())
+	test.AssertError(t, err, "sample rate over 1 should be rejected")
+	_, err = NewAuditSource(staticSource{}, staticSource{}, 1, 0, metrics.NoopRegisterer, blog.NewMock())
+	test.AssertError(t, err, "zero max inflight should be rejected")
+
+	s := newAuditTestSigner(t)
+	served := s.sign(t, time.Now(), ocsp.Good)
+	req := &ocsp.Request{SerialNumber: big.NewInt(1)}
+	regenerator := blockingSource{release: make(chan struct{})}
+	src, err := NewAuditSource(staticSource{resp: served}, regenerator, 1, 1, metrics.NoopRegisterer, blog.NewMock())
+	test.AssertNotError(t, err, "NewAuditSource failed")
+
+	// The first audit occupies the only slot, so the second is skipped, but
+	// both responses are served without waiting.
+	for range 2 {
+		_, err = src.Response(context.Background(), req)
+		test.AssertNotError(t, err, "Response failed")
+	}
+	test.AssertMetricWithLabelsEquals(t, src.results, prometheus.Labels{"result": "skipped"}, 1)
+	close(regenerator.release)
+	src.wg.Wait()
+
+	// Errors from the base source are returned without auditing.
+	src, err = NewAuditSource(staticSource{err: ErrNotFound}, staticSource{resp: served}, 1, 1, metrics.NoopRegisterer, blog.NewMock())
+	test.AssertNotError(t, err, "NewAuditSource failed")
+	_, err = src.Response(context.Background(), req)
+	test.AssertErrorIs(t, err, ErrNotFound)
+	src.wg.Wait()
+	test.AssertMetricWithLabelsEquals(t, src.results, prometheus.Labels{}, 0)
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math/big"
 	"strings"
 
 	"github.com/jmhodges/clock"
@@ -32,6 +33,12 @@ type responderID struct {
 	commonName string
 }
 
+// sha1OID is the AlgorithmIdentifier OID of SHA1, the only hash algorithm
+// accepted in a CertID. Some clients send SHA256 CertIDs, but the CA signs
+// every response with a SHA1 CertID, and RFC 6960 requires the response's
+// CertID to match the request's, so they can't be answered.
+var sha1OID = asn1.ObjectIdentifier([]int{1, 3, 14, 3, 2, 26})
+
 // computeLightweightResponderID builds a responderID from an issuer certificate.
 func computeLightweightResponderID(ic *issuance.Certificate) (responderID, error) {
 	// nameHash is the SHA1 hash over the DER encoding of the issuer certificate's
@@ -119,7 +126,7 @@ func (src *filterSource) Response(ctx context.Context, req *ocsp.Request) (*Resp
 		return nil, err
 	}
 
-	err = src.checkResponse(iss, resp)
+	err = src.checkResponse(req, iss, resp)
 	if err != nil {
 		src.log.Warningf("OCSP Response not sent for CA=%s, Serial=%s, err: %s", hex.EncodeToString(req.IssuerKeyHash), core.SerialToString(req.SerialNumber), err)
 		counter.WithLabelValues("response_filtered").Inc()
@@ -174,7 +181,7 @@ func (src *filterSource) checkRequest(req *ocsp.Request) (issuance.NameID, error
 // issuer as was identified in the request, or an error otherwise. This filters
 // out, for example, responses which are for a serial that we issued, but from a
 // different issuer than that contained in the request.
-func (src *filterSource) checkResponse(reqIssuerID issuance.NameID, resp *Response) error {
+func (src *filterSource) checkResponse(req *ocsp.Request, reqIssuerID issuance.NameID, resp *Response) error {
 	respIssuerID := issuance.ResponderNameID(resp.Response)
 	if reqIssuerID != respIssuerID {
 		// This would be allowed if we used delegated responders, but we don't.
@@ -186,12 +193,40 @@ func (src *filterSource) checkResponse(reqIssuerID issuance.NameID, resp *Respon
 		return err
 	}
 
-	// In an ideal world, we'd also compare the Issuer Key Hash from the request's
-	// CertID (equivalent to looking up the key hash in src.issuers) against the
-	// Issuer Key Hash contained in the response's CertID. However, the Go OCSP
-	// library does not provide access to the response's CertID, so we can't.
-	// Specifically, we want to compare `src.issuers[reqIssuerID].keyHash` against
-	// something like resp.CertID.IssuerKeyHash, but the latter does not exist.
+	return checkCertID(req, resp.Raw)
+}
+
+// certIDASN1 mirrors the CertID structure of RFC 6960, Section 4.1.1.
+type certIDASN1 struct {
+	HashAlgorithm  pkix.AlgorithmIdentifier
+	IssuerNameHash []byte
+	IssuerKeyHash  []byte
+	SerialNumber   *big.Int
+}
 
+// checkCertID returns an error unless the CertID of the DER-encoded response
+// is the same as the request's, as RFC 6960 Section 4.2.2.3 requires: computed
+// with SHA1, as checkRequest requires of the request, for the same issuer and
+// serial. The Go OCSP
+// library doesn't expose the response's CertID, so it's parsed here.
+func checkCertID(req *ocsp.Request, der []byte) error {
+	fields, err := parseResponseFields(der)
+	if err != nil {
+		return fmt.Errorf("parsing OCSP response: %w", err)
+	}
+	var certID certIDASN1
+	_, err = asn1.Unmarshal(fields.certID, &certID)
+	if err != nil {
+		return fmt.Errorf("parsing OCSP response CertID: %w", err)
+	}
+	if !certID.HashAlgorithm.Algorithm.Equal(sha1OID) {
+		return fmt.Errorf("response CertID hash algorithm %s is not SHA1", certID.HashAlgorithm.Algorithm)
+	}
+	if !bytes.Equal(certID.IssuerNameHash, req.IssuerNameHash) || !bytes.Equal(certID.IssuerKeyHash, req.IssuerKeyHash) {
+		return errors.New("response CertID issuer hashes do not match requested issuer")
+	}
+	if certID.SerialNumber.Cmp(req.SerialNumber) != 0 {
+		return errors.New("response CertID serial does not match requested serial")
+	}
 	return nil
 }
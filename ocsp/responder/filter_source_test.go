@@ -3,7 +3,11 @@ package responder
 import (
 	"context"
 	"crypto"
+	"crypto/sha256"
+	"crypto/x509/pkix"
+	"encoding/asn1"
 	"encoding/hex"
+	"math/big"
 	"os"
 	"testing"
 	"time"
@@ -136,3 +140,89 @@ func TestCheckResponse(t *testing.T) {
 	_, err = f.Response(context.Background(), req)
 	test.AssertError(t, err, "expected error")
 }
+
+func TestCheckRequestSHA256(t *testing.T) {
+	issuer, err := issuance.LoadCertificate("./testdata/test-ca.der.pem")
+	test.AssertNotError(t, err, "failed to load issuer cert")
+
+	reqBytes, err := os.ReadFile("./testdata/ocsp.req")
+	test.AssertNotError(t, err, "failed to read OCSP request")
+	respBytes, err := os.ReadFile("./testdata/ocsp.resp")
+	test.AssertNotError(t, err, "failed to read OCSP response")
+	resp, err := ocsp.ParseResponse(respBytes, nil)
+	test.AssertNotError(t, err, "failed to parse OCSP response")
+
+	source := &echoSource{&Response{resp, respBytes}}
+	f, err := NewFilterSource([]*issuance.Certificate{issuer}, []string{"00"}, source, metrics.NoopRegisterer, blog.NewMock(), clock.New())
+	test.AssertNotError(t, err, "errored when creating good filter")
+
+	// The SHA256 hashes of the issuer's name and key.
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	_, err = asn1.Unmarshal(issuer.RawSubjectPublicKeyInfo, &spki)
+	test.AssertNotError(t, err, "parsing issuer SPKI")
+	nameHash := sha256.Sum256(issuer.RawSubject)
+	keyHash := sha256.Sum256(spki.PublicKey.RightAlign())
+
+	// Responses have SHA1 CertIDs, so a request using SHA256 CertID hashes,
+	// even for a known issuer, can't be answered.
+	req, err := ocsp.ParseRequest(reqBytes)
+	test.AssertNotError(t, err, "failed to prepare fake ocsp request")
+	req.HashAlgorithm = crypto.SHA256
+	req.IssuerNameHash = nameHash[:]
+	req.IssuerKeyHash = keyHash[:]
+	_, err = f.Response(context.Background(), req)
+	test.AssertErrorIs(t, err, ErrNotFound)
+}
+
+func TestCheckResponseCertID(t *testing.T) {
+	issuer, err := issuance.LoadCertificate("./testdata/test-ca.der.pem")
+	test.AssertNotError(t, err, "failed to load issuer cert")
+
+	reqBytes, err := os.ReadFile("./testdata/ocsp.req")
+	test.AssertNotError(t, err, "failed to read OCSP request")
+	respBytes, err := os.ReadFile("./testdata/ocsp.resp")
+	test.AssertNotError(t, err, "failed to read OCSP response")
+	resp, err := ocsp.ParseResponse(respBytes, nil)
+	test.AssertNotError(t, err, "failed to parse OCSP response")
+
+	source := &echoSource{&Response{resp, respBytes}}
+	f, err := NewFilterSource([]*issuance.Certificate{issuer}, []string{"00"}, source, metrics.NoopRegisterer, blog.NewMock(), clock.New())
+	test.AssertNotError(t, err, "errored when creating good filter")
+
+	// The served response's CertID is the request's.
+	req, err := ocsp.ParseRequest(reqBytes)
+	test.AssertNotError(t, err, "failed to prepare fake ocsp request")
+	served, err := f.Response(context.Background(), req)
+	test.AssertNotError(t, err, "unexpected error")
+	fields, err := parseResponseFields(served.Raw)
+	test.AssertNotError(t, err, "parsing served response")
+	var certID certIDASN1
+	_, err = asn1.Unmarshal(fields.certID, &certID)
+	test.AssertNotError(t, err, "parsing served response's CertID")
+	test.Assert(t, certID.HashAlgorithm.Algorithm.Equal(sha1OID), "served CertID hash algorithm differs from request's")
+	test.AssertByteEquals(t, certID.IssuerNameHash, req.IssuerNameHash)
+	test.AssertByteEquals(t, certID.IssuerKeyHash, req.IssuerKeyHash)
+	test.Assert(t, certID.SerialNumber.Cmp(req.SerialNumber) == 0, "served CertID serial differs from request's")
+
+	// A response for another serial from the same issuer isn't served.
+	other, err := ocsp.ParseRequest(reqBytes)
+	test.AssertNotError(t, err, "failed to prepare fake ocsp request")
+	other.SerialNumber.Add(other.SerialNumber, big.NewInt(1))
+	_, err = f.Response(context.Background(), other)
+	test.AssertError(t, err, "served a response whose CertID has a different serial")
+	test.AssertContains(t, err.Error(), "serial does not match")
+}
+
+func TestNewFilterDuplicateIssuers(t *testing.T) {
+	issuer, err := issuance.LoadCertificate("./testdata/test-ca.der.pem")
+	test.AssertNotError(t, err, "failed to load issuer cert")
+
+	// Loading the same issuer twice (e.g. from both IssuerCerts and the CA's
+	// issuance config) is harmless.
+	f, err := NewFilterSource([]*issuance.Certificate{issuer, issuer}, nil, nil, metrics.NoopRegisterer, blog.NewMock(), clock.New())
+	test.AssertNotError(t, err, "errored when creating filter with duplicate issuers")
+	test.AssertEquals(t, len(f.issuers), 1)
+}
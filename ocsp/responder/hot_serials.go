@@ -0,0 +1,114 @@
+package responder
+
+import (
+	"container/heap"
+	"sort"
+	"sync"
+)
+
+// hotSerial is a serial number's approximate request count, as tracked by
+// hotSerials.
+type hotSerial struct {
+	Serial string `json:"serial"`
+	Count  int64  `json:"count"`
+	// Overcount is the most by which Count may exceed the true number of
+	// requests for Serial in the current period.
+	Overcount int64 `json:"overcount,omitempty"`
+
+	index int
+}
+
+// hotSerialHeap is a min-heap of hotSerials ordered by Count.
+type hotSerialHeap []*hotSerial
+
+func (h hotSerialHeap) Len() int           { return len(h) }
+func (h hotSerialHeap) Less(i, j int) bool { return h[i].Count < h[j].Count }
+func (h hotSerialHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *hotSerialHeap) Push(x any) {
+	hs := x.(*hotSerial)
+	hs.index = len(*h)
+	*h = append(*h, hs)
+}
+
+func (h *hotSerialHeap) Pop() any {
+	old := *h
+	hs := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return hs
+}
+
+// hotSerials finds the most requested serial numbers in a stream of requests
+// using the Space-Saving algorithm, in memory bounded by its capacity. Any
+// serial requested more than 1/capacity of the time is guaranteed to be
+// tracked. It is safe for concurrent use.
+type hotSerials struct {
+	sync.Mutex
+	capacity int
+	serials  map[string]*hotSerial
+	heap     hotSerialHeap
+}
+
+func newHotSerials(capacity int) *hotSerials {
+	return &hotSerials{
+		capacity: capacity,
+		serials:  make(map[string]*hotSerial, capacity),
+	}
+}
+
+// add counts one request for serial.
+func (h *hotSerials) add(serial string) {
+	h.Lock()
+	defer h.Unlock()
+
+	hs, ok := h.serials[serial]
+	if ok {
+		hs.Count++
+		heap.Fix(&h.heap, hs.index)
+		return
+	}
+	if len(h.heap) < h.capacity {
+		hs = &hotSerial{Serial: serial, Count: 1}
+		heap.Push(&h.heap, hs)
+		h.serials[serial] = hs
+		return
+	}
+	// Replace the least requested serial. The new serial inherits its count,
+	// which is the most it could have been requested without being tracked.
+	hs = h.heap[0]
+	delete(h.serials, hs.Serial)
+	hs.Serial = serial
+	hs.Overcount = hs.Count
+	hs.Count++
+	heap.Fix(&h.heap, 0)
+	h.serials[serial] = hs
+}
+
+// top returns the n most requested serials since the last reset, most
+// requested first, and resets the counts.
+func (h *hotSerials) top(n int) []hotSerial {
+	h.Lock()
+	tracked := h.heap
+	h.heap = nil
+	h.serials = make(map[string]*hotSerial, h.capacity)
+	h.Unlock()
+
+	sort.Slice(tracked, func(i, j int) bool {
+		if tracked[i].Count != tracked[j].Count {
+			return tracked[i].Count > tracked[j].Count
+		}
+		return tracked[i].Serial < tracked[j].Serial
+	})
+	if len(tracked) > n {
+		tracked = tracked[:n]
+	}
+	result := make([]hotSerial, 0, len(tracked))
+	for _, hs := range tracked {
+		result = append(result, hotSerial{Serial: hs.Serial, Count: hs.Count, Overcount: hs.Overcount})
+	}
+	return result
+}
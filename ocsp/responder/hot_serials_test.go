@@ -0,0 +1,40 @@
+package responder
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestHotSerials(t *testing.T) {
+	t.Parallel()
+
+	hs := newHotSerials(10)
+	test.AssertEquals(t, len(hs.top(3)), 0)
+
+	for i := range 10 {
+		hs.add("aa")
+		if i%2 == 0 {
+			hs.add("bb")
+		}
+		// Many serials requested only once, as by a scraper, must not push
+		// out the serials requested most.
+		hs.add(fmt.Sprintf("%02x", 100+i))
+		hs.add(fmt.Sprintf("%02x", 200+i))
+	}
+
+	top := hs.top(2)
+	test.AssertEquals(t, len(top), 2)
+	test.AssertEquals(t, top[0].Serial, "aa")
+	test.AssertEquals(t, top[0].Count, int64(10))
+	test.AssertEquals(t, top[1].Serial, "bb")
+	test.AssertEquals(t, top[1].Count, int64(5))
+	test.AssertEquals(t, len(hs.serials), 0)
+
+	// Counts start over after top is called.
+	hs.add("cc")
+	top = hs.top(2)
+	test.AssertEquals(t, len(top), 1)
+	test.AssertDeepEquals(t, top[0], hotSerial{Serial: "cc", Count: 1})
+}
@@ -34,8 +34,8 @@ package responder
 import (
 	"context"
 	"crypto"
-	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -43,6 +43,8 @@ import (
 	"math/rand/v2"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jmhodges/clock"
@@ -50,6 +52,7 @@ import (
 	"golang.org/x/crypto/ocsp"
 
 	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/issuance"
 	blog "github.com/letsencrypt/boulder/log"
 )
 
@@ -71,6 +74,12 @@ var responseTypeToString = map[ocsp.ResponseStatus]string{
 	ocsp.Unauthorized:      "Unauthorized",
 }
 
+var certStatusToString = map[int]string{
+	ocsp.Good:    "good",
+	ocsp.Revoked: "revoked",
+	ocsp.Unknown: "unknown",
+}
+
 // A Responder object provides an HTTP wrapper around a Source.
 type Responder struct {
 	Source        Source
@@ -81,6 +90,21 @@ type Responder struct {
 	sampleRate    int
 	clk           clock.Clock
 	log           blog.Logger
+
+	// redirectPrefix, if set, causes GET requests whose path isn't the
+	// canonical encoding of the OCSP request to be redirected to the canonical
+	// path under this prefix.
+	redirectPrefix string
+
+	// issuerResponses counts responses by issuer, certificate status, and
+	// HTTP status code. issuerNames maps the hex encoded SHA1 issuerKeyHash
+	// of each known issuer to the Common Name used as its issuer label.
+	issuerResponses *prometheus.CounterVec
+	issuerNames     map[string]string
+
+	// hotSerials, if set, tracks the most requested serials so that they can
+	// be periodically reported.
+	hotSerials *hotSerials
 }
 
 // NewResponder instantiates a Responder with the give Source.
@@ -115,16 +139,162 @@ func NewResponder(source Source, timeout time.Duration, stats prometheus.Registe
 	)
 	stats.MustRegister(responseTypes)
 
+	issuerResponses := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ocsp_responses_by_issuer",
+			Help: "Number of OCSP responses by issuer, status=[good|revoked|unknown|unauthorized|expired|error], and HTTP status code",
+		},
+		[]string{"issuer", "status", "code"},
+	)
+	stats.MustRegister(issuerResponses)
+
 	return &Responder{
-		Source:        source,
-		timeout:       timeout,
-		responseTypes: responseTypes,
-		responseAges:  responseAges,
-		requestSizes:  requestSizes,
-		clk:           clock.New(),
-		log:           logger,
-		sampleRate:    sampleRate,
+		Source:          source,
+		timeout:         timeout,
+		responseTypes:   responseTypes,
+		responseAges:    responseAges,
+		requestSizes:    requestSizes,
+		clk:             clock.New(),
+		log:             logger,
+		sampleRate:      sampleRate,
+		issuerResponses: issuerResponses,
+	}
+}
+
+// LabelIssuers causes responses for requests naming one of the given issuers
+// to be counted under that issuer's Common Name in the ocsp_responses_by_issuer
+// metric. Responses for requests naming any other issuer are counted under
+// "unknown".
+func (rs *Responder) LabelIssuers(issuerCerts []*issuance.Certificate) error {
+	issuerNames := make(map[string]string, len(issuerCerts))
+	for _, issuerCert := range issuerCerts {
+		rid, err := computeLightweightResponderID(issuerCert)
+		if err != nil {
+			return fmt.Errorf("computing lightweight OCSP responder ID: %w", err)
+		}
+		issuerNames[hex.EncodeToString(rid.keyHash)] = rid.commonName
+	}
+	rs.issuerNames = issuerNames
+	return nil
+}
+
+// ReportHotSerials causes the n most requested serials, and approximately how
+// often each was requested, to be logged once every period, to help find
+// responses worth caching longer and clients scraping the responder. Only
+// memory proportional to n is used.
+func (rs *Responder) ReportHotSerials(n int, period time.Duration) {
+	rs.hotSerials = newHotSerials(n * 10)
+	go func() {
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		for range ticker.C {
+			rs.reportHotSerials(n, period)
+		}
+	}()
+}
+
+// reportHotSerials logs the n most requested serials since it was last called.
+func (rs *Responder) reportHotSerials(n int, period time.Duration) {
+	top := rs.hotSerials.top(n)
+	if len(top) == 0 {
+		return
+	}
+	jb, err := json.Marshal(top)
+	if err != nil {
+		rs.log.Errf("failed to marshal hot serials: %s", err)
+		return
+	}
+	rs.log.Infof("Most requested serials over the last %s: %s", period, jb)
+}
+
+// countIssuerResponse increments the ocsp_responses_by_issuer metric.
+func (rs Responder) countIssuerResponse(issuerKeyHash []byte, status string, code int) {
+	if rs.issuerResponses == nil {
+		return
+	}
+	issuer, ok := rs.issuerNames[hex.EncodeToString(issuerKeyHash)]
+	if !ok {
+		issuer = "unknown"
 	}
+	rs.issuerResponses.With(prometheus.Labels{
+		"issuer": issuer,
+		"status": status,
+		"code":   strconv.Itoa(code),
+	}).Inc()
+}
+
+// RedirectNonCanonicalGETs causes GET requests whose path isn't the canonical
+// encoding of their OCSP request, as described in RFC 5019 Section 5, to be
+// permanently redirected to the canonical path under prefix, which must be the
+// prefix stripped from requests before they reach the Responder. This lets
+// HTTP caches in front of the Responder hold a single copy of each response,
+// however clients encode their requests.
+func (rs *Responder) RedirectNonCanonicalGETs(prefix string) {
+	rs.redirectPrefix = prefix
+}
+
+// decodeGETRequest decodes the OCSP request in the path of a GET request. RFC
+// 5019 calls for the standard base64 alphabet, with padding, URL escaped, but
+// clients variously send requests unescaped, unpadded, or in the URL-safe
+// alphabet, so all of those are accepted.
+func decodeGETRequest(path string) ([]byte, error) {
+	base64Request, err := url.QueryUnescape(path)
+	if err != nil {
+		return nil, err
+	}
+	// url.QueryUnescape not only unescapes %2B escaping, but it additionally
+	// turns the resulting '+' into a space, which makes base64 decoding fail.
+	// So we go back afterwards and turn ' ' back into '+'. This means we
+	// accept some malformed input that includes ' ' or %20, but that's fine.
+	// The URL-safe alphabet's '-' and '_' are translated to the standard
+	// alphabet at the same time.
+	base64RequestBytes := []byte(base64Request)
+	for i := range base64RequestBytes {
+		switch base64RequestBytes[i] {
+		case ' ', '-':
+			base64RequestBytes[i] = '+'
+		case '_':
+			base64RequestBytes[i] = '/'
+		}
+	}
+	// In certain situations a UA may construct a request that has a double
+	// slash between the host name and the base64 request body due to naively
+	// constructing the request URL. In that case strip the leading slash
+	// so that we can still decode the request.
+	if len(base64RequestBytes) > 0 && base64RequestBytes[0] == '/' {
+		base64RequestBytes = base64RequestBytes[1:]
+	}
+	return base64.RawStdEncoding.DecodeString(strings.TrimRight(string(base64RequestBytes), "="))
+}
+
+// canonicalGETPath returns the canonical GET path for req: the URL escaped,
+// standard base64 encoding of its DER encoding.
+func canonicalGETPath(req *ocsp.Request) (string, error) {
+	der, err := req.Marshal()
+	if err != nil {
+		return "", err
+	}
+	return url.QueryEscape(base64.StdEncoding.EncodeToString(der)), nil
+}
+
+// etag returns the entity tag for resp. It's derived from the fields which
+// change whenever a new response is signed, rather than from the response's
+// bytes, so that every responder serving the same response agrees on it even
+// if they hold differently signed copies. It's weak for the same reason.
+func etag(resp *Response) string {
+	return fmt.Sprintf("W/\"%x-%d-%d\"", resp.ThisUpdate.Unix(), resp.Status, resp.RevocationReason)
+}
+
+// etagMatches reports whether the value of an If-None-Match header matches the
+// given entity tag, using the weak comparison RFC 9110 requires for it.
+func etagMatches(ifNoneMatch string, tag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(tag, "W/") {
+			return true
+		}
+	}
+	return false
 }
 
 type logEvent struct {
@@ -171,7 +341,7 @@ func (rs Responder) sampledError(format string, a ...interface{}) {
 //	Cache-Control: "max-age=(response.NextUpdate-now), public, no-transform, must-revalidate",
 //	Last-Modified: response.ThisUpdate,
 //	Expires: response.NextUpdate,
-//	ETag: the SHA256 hash of the response, and
+//	ETag: derived from the response's thisUpdate and status, and
 //	Content-Type: application/ocsp-response.
 //
 // Note: The caller must use http.StripPrefix to strip any path components
@@ -223,33 +393,9 @@ func (rs Responder) ServeHTTP(response http.ResponseWriter, request *http.Reques
 	var err error
 	switch request.Method {
 	case "GET":
-		base64Request, err := url.QueryUnescape(request.URL.Path)
+		requestBody, err = decodeGETRequest(request.URL.Path)
 		if err != nil {
-			rs.log.Debugf("Error decoding URL: %s", request.URL.Path)
-			rs.responseTypes.With(prometheus.Labels{"type": responseTypeToString[ocsp.Malformed]}).Inc()
-			response.WriteHeader(http.StatusBadRequest)
-			return
-		}
-		// url.QueryUnescape not only unescapes %2B escaping, but it additionally
-		// turns the resulting '+' into a space, which makes base64 decoding fail.
-		// So we go back afterwards and turn ' ' back into '+'. This means we
-		// accept some malformed input that includes ' ' or %20, but that's fine.
-		base64RequestBytes := []byte(base64Request)
-		for i := range base64RequestBytes {
-			if base64RequestBytes[i] == ' ' {
-				base64RequestBytes[i] = '+'
-			}
-		}
-		// In certain situations a UA may construct a request that has a double
-		// slash between the host name and the base64 request body due to naively
-		// constructing the request URL. In that case strip the leading slash
-		// so that we can still decode the request.
-		if len(base64RequestBytes) > 0 && base64RequestBytes[0] == '/' {
-			base64RequestBytes = base64RequestBytes[1:]
-		}
-		requestBody, err = base64.StdEncoding.DecodeString(string(base64RequestBytes))
-		if err != nil {
-			rs.log.Debugf("Error decoding base64 from URL: %s", string(base64RequestBytes))
+			rs.log.Debugf("Error decoding request from URL: %s", request.URL.Path)
 			response.WriteHeader(http.StatusBadRequest)
 			rs.responseTypes.With(prometheus.Labels{"type": responseTypeToString[ocsp.Malformed]}).Inc()
 			return
@@ -294,6 +440,27 @@ func (rs Responder) ServeHTTP(response http.ResponseWriter, request *http.Reques
 	le.IssuerKeyHash = fmt.Sprintf("%x", ocspRequest.IssuerKeyHash)
 	le.IssuerNameHash = fmt.Sprintf("%x", ocspRequest.IssuerNameHash)
 	le.HashAlg = hashToString[ocspRequest.HashAlgorithm]
+	if rs.hotSerials != nil {
+		rs.hotSerials.add(core.SerialToString(ocspRequest.SerialNumber))
+	}
+
+	if request.Method == http.MethodGet && rs.redirectPrefix != "" {
+		canonical, err := canonicalGETPath(ocspRequest)
+		if err == nil {
+			path := request.URL.RawPath
+			if path == "" {
+				path = request.URL.Path
+			}
+			if strings.TrimPrefix(path, "/") != canonical {
+				response.Header().Del("Content-Type")
+				response.Header().Set("Location", rs.redirectPrefix+canonical)
+				// The canonical path for a request never changes.
+				response.Header().Set("Cache-Control", "max-age=86400, public")
+				response.WriteHeader(http.StatusMovedPermanently)
+				return
+			}
+		}
+	}
 
 	// Look up OCSP response from source
 	ocspResponse, err := rs.Source.Response(ctx, ocspRequest)
@@ -301,6 +468,7 @@ func (rs Responder) ServeHTTP(response http.ResponseWriter, request *http.Reques
 		if errors.Is(err, ErrNotFound) {
 			response.Write(ocsp.UnauthorizedErrorResponse)
 			rs.responseTypes.With(prometheus.Labels{"type": responseTypeToString[ocsp.Unauthorized]}).Inc()
+			rs.countIssuerResponse(ocspRequest.IssuerKeyHash, "unauthorized", http.StatusOK)
 			return
 		} else if errors.Is(err, errOCSPResponseExpired) {
 			rs.sampledError("Requested ocsp response is expired: serial %x, request body %s",
@@ -309,6 +477,7 @@ func (rs Responder) ServeHTTP(response http.ResponseWriter, request *http.Reques
 			response.WriteHeader(533)
 			response.Write(ocsp.InternalErrorErrorResponse)
 			rs.responseTypes.With(prometheus.Labels{"type": responseTypeToString[ocsp.Unauthorized]}).Inc()
+			rs.countIssuerResponse(ocspRequest.IssuerKeyHash, "expired", 533)
 			return
 		}
 		rs.sampledError("Error retrieving response for request: serial %x, request body %s, error: %s",
@@ -316,12 +485,13 @@ func (rs Responder) ServeHTTP(response http.ResponseWriter, request *http.Reques
 		response.WriteHeader(http.StatusInternalServerError)
 		response.Write(ocsp.InternalErrorErrorResponse)
 		rs.responseTypes.With(prometheus.Labels{"type": responseTypeToString[ocsp.InternalError]}).Inc()
+		rs.countIssuerResponse(ocspRequest.IssuerKeyHash, "error", http.StatusInternalServerError)
 		return
 	}
 
 	// Write OCSP response
-	response.Header().Add("Last-Modified", ocspResponse.ThisUpdate.Format(time.RFC1123))
-	response.Header().Add("Expires", ocspResponse.NextUpdate.Format(time.RFC1123))
+	response.Header().Add("Last-Modified", ocspResponse.ThisUpdate.UTC().Format(http.TimeFormat))
+	response.Header().Add("Expires", ocspResponse.NextUpdate.UTC().Format(http.TimeFormat))
 	now := rs.clk.Now()
 	var maxAge int
 	if now.Before(ocspResponse.NextUpdate) {
@@ -338,8 +508,8 @@ func (rs Responder) ServeHTTP(response http.ResponseWriter, request *http.Reques
 			maxAge,
 		),
 	)
-	responseHash := sha256.Sum256(ocspResponse.Raw)
-	response.Header().Add("ETag", fmt.Sprintf("\"%X\"", responseHash))
+	responseTag := etag(ocspResponse)
+	response.Header().Add("ETag", responseTag)
 
 	serialString := core.SerialToString(ocspResponse.SerialNumber)
 	if len(serialString) > 2 {
@@ -352,9 +522,10 @@ func (rs Responder) ServeHTTP(response http.ResponseWriter, request *http.Reques
 	// RFC 7232 says that a 304 response must contain the above
 	// headers if they would also be sent for a 200 for the same
 	// request, so we have to wait until here to do this
-	if etag := request.Header.Get("If-None-Match"); etag != "" {
-		if etag == fmt.Sprintf("\"%X\"", responseHash) {
+	if ifNoneMatch := request.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		if etagMatches(ifNoneMatch, responseTag) {
 			response.WriteHeader(http.StatusNotModified)
+			rs.countIssuerResponse(ocspRequest.IssuerKeyHash, certStatusToString[ocspResponse.Status], http.StatusNotModified)
 			return
 		}
 	}
@@ -362,4 +533,5 @@ func (rs Responder) ServeHTTP(response http.ResponseWriter, request *http.Reques
 	response.Write(ocspResponse.Raw)
 	rs.responseAges.Observe(rs.clk.Now().Sub(ocspResponse.ThisUpdate).Seconds())
 	rs.responseTypes.With(prometheus.Labels{"type": responseTypeToString[ocsp.Success]}).Inc()
+	rs.countIssuerResponse(ocspRequest.IssuerKeyHash, certStatusToString[ocspResponse.Status], http.StatusOK)
 }
@@ -32,11 +32,14 @@ package responder
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -45,7 +48,9 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/crypto/ocsp"
 
+	"github.com/letsencrypt/boulder/issuance"
 	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
 	"github.com/letsencrypt/boulder/test"
 )
 
@@ -249,10 +254,10 @@ func TestCacheHeaders(t *testing.T) {
 		header string
 		value  string
 	}{
-		{"Last-Modified", "Tue, 20 Oct 2015 00:00:00 UTC"},
-		{"Expires", "Sun, 20 Oct 2030 00:00:00 UTC"},
+		{"Last-Modified", "Tue, 20 Oct 2015 00:00:00 GMT"},
+		{"Expires", "Sun, 20 Oct 2030 00:00:00 GMT"},
 		{"Cache-Control", "max-age=471398400, public, no-transform, must-revalidate"},
-		{"Etag", "\"8169FB0843B081A76E9F6F13FD70C8411597BEACF8B182136FFDD19FBD26140A\""},
+		{"Etag", "W/\"56258400-0-0\""},
 	}
 	for _, tc := range testCases {
 		headers, ok := rw.Result().Header[tc.header]
@@ -270,19 +275,112 @@ func TestCacheHeaders(t *testing.T) {
 		}
 	}
 
-	rw = httptest.NewRecorder()
-	headers := http.Header{}
-	headers.Add("If-None-Match", "\"8169FB0843B081A76E9F6F13FD70C8411597BEACF8B182136FFDD19FBD26140A\"")
-	responder.ServeHTTP(rw, &http.Request{
-		Method: "GET",
-		URL: &url.URL{
-			Path: "MEMwQTA/MD0wOzAJBgUrDgMCGgUABBSwLsMRhyg1dJUwnXWk++D57lvgagQU6aQ/7p6l5vLV13lgPJOmLiSOl6oCAhJN",
-		},
-		Header: headers,
-	})
-	if rw.Code != http.StatusNotModified {
-		t.Fatalf("Got wrong status code: expected %d, got %d", http.StatusNotModified, rw.Code)
+	for _, tc := range []struct {
+		ifNoneMatch string
+		expected    int
+	}{
+		{"W/\"56258400-0-0\"", http.StatusNotModified},
+		// Entity tags are compared weakly, and If-None-Match may list several.
+		{"\"56258400-0-0\"", http.StatusNotModified},
+		{"\"abc\", W/\"56258400-0-0\"", http.StatusNotModified},
+		{"*", http.StatusNotModified},
+		{"W/\"56258400-1-0\"", http.StatusOK},
+	} {
+		rw = httptest.NewRecorder()
+		headers := http.Header{}
+		headers.Add("If-None-Match", tc.ifNoneMatch)
+		responder.ServeHTTP(rw, &http.Request{
+			Method: "GET",
+			URL: &url.URL{
+				Path: "MEMwQTA/MD0wOzAJBgUrDgMCGgUABBSwLsMRhyg1dJUwnXWk++D57lvgagQU6aQ/7p6l5vLV13lgPJOmLiSOl6oCAhJN",
+			},
+			Header: headers,
+		})
+		if rw.Code != tc.expected {
+			t.Errorf("If-None-Match %s: expected status %d, got %d", tc.ifNoneMatch, tc.expected, rw.Code)
+		}
+	}
+}
+
+func TestDecodeGETRequest(t *testing.T) {
+	want, err := base64.StdEncoding.DecodeString("MFQwUjBQME4wTDAJBgUrDgMCGgUABBQ55F6w46hhx/o6OXOHa+Yfe32YhgQU+3hPEvlgFYMsnxd/NBmzLjbqQYkCEwD6Wh0MaVKu9gJ3By9DI//xsd4=")
+	test.AssertNotError(t, err, "decoding test request")
+
+	for _, path := range []string{
+		// RFC 5019's canonical encoding.
+		"MFQwUjBQME4wTDAJBgUrDgMCGgUABBQ55F6w46hhx%2Fo6OXOHa%2BYfe32YhgQU%2B3hPEvlgFYMsnxd%2FNBmzLjbqQYkCEwD6Wh0MaVKu9gJ3By9DI%2F%2Fxsd4%3D",
+		// Unescaped.
+		"MFQwUjBQME4wTDAJBgUrDgMCGgUABBQ55F6w46hhx/o6OXOHa+Yfe32YhgQU+3hPEvlgFYMsnxd/NBmzLjbqQYkCEwD6Wh0MaVKu9gJ3By9DI//xsd4=",
+		// Unpadded.
+		"MFQwUjBQME4wTDAJBgUrDgMCGgUABBQ55F6w46hhx%2Fo6OXOHa%2BYfe32YhgQU%2B3hPEvlgFYMsnxd%2FNBmzLjbqQYkCEwD6Wh0MaVKu9gJ3By9DI%2F%2Fxsd4",
+		// The URL-safe alphabet, padded and unpadded.
+		"MFQwUjBQME4wTDAJBgUrDgMCGgUABBQ55F6w46hhx_o6OXOHa-Yfe32YhgQU-3hPEvlgFYMsnxd_NBmzLjbqQYkCEwD6Wh0MaVKu9gJ3By9DI__xsd4=",
+		"MFQwUjBQME4wTDAJBgUrDgMCGgUABBQ55F6w46hhx_o6OXOHa-Yfe32YhgQU-3hPEvlgFYMsnxd_NBmzLjbqQYkCEwD6Wh0MaVKu9gJ3By9DI__xsd4",
+		// A leading slash.
+		"/MFQwUjBQME4wTDAJBgUrDgMCGgUABBQ55F6w46hhx_o6OXOHa-Yfe32YhgQU-3hPEvlgFYMsnxd_NBmzLjbqQYkCEwD6Wh0MaVKu9gJ3By9DI__xsd4",
+	} {
+		got, err := decodeGETRequest(path)
+		test.AssertNotError(t, err, path)
+		test.AssertDeepEquals(t, got, want)
+	}
+}
+
+func TestRedirectNonCanonicalGETs(t *testing.T) {
+	responder := Responder{
+		Source: testSource{},
+		responseTypes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "ocspResponses-test",
+			},
+			[]string{"type"},
+		),
+		responseAges: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "ocspAges-test",
+				Buckets: []float64{43200},
+			},
+		),
+		requestSizes: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Name: "ocspSizes-test",
+			},
+		),
+		clk: clock.NewFake(),
+		log: blog.NewMock(),
 	}
+	responder.RedirectNonCanonicalGETs("/ocsp/")
+
+	canonical := "MFQwUjBQME4wTDAJBgUrDgMCGgUABBQ55F6w46hhx%2Fo6OXOHa%2BYfe32YhgQU%2B3hPEvlgFYMsnxd%2FNBmzLjbqQYkCEwD6Wh0MaVKu9gJ3By9DI%2F%2Fxsd4%3D"
+	for _, tc := range []struct {
+		url        *url.URL
+		expected   int
+		redirected bool
+	}{
+		{&url.URL{Path: canonical}, http.StatusOK, false},
+		{&url.URL{Path: "/" + canonical}, http.StatusOK, false},
+		// As http.StripPrefix would pass an escaped path from a real request.
+		{&url.URL{
+			Path:    "MFQwUjBQME4wTDAJBgUrDgMCGgUABBQ55F6w46hhx/o6OXOHa+Yfe32YhgQU+3hPEvlgFYMsnxd/NBmzLjbqQYkCEwD6Wh0MaVKu9gJ3By9DI//xsd4=",
+			RawPath: canonical,
+		}, http.StatusOK, false},
+		{&url.URL{Path: "MFQwUjBQME4wTDAJBgUrDgMCGgUABBQ55F6w46hhx/o6OXOHa+Yfe32YhgQU+3hPEvlgFYMsnxd/NBmzLjbqQYkCEwD6Wh0MaVKu9gJ3By9DI//xsd4="}, http.StatusMovedPermanently, true},
+		{&url.URL{Path: "MFQwUjBQME4wTDAJBgUrDgMCGgUABBQ55F6w46hhx_o6OXOHa-Yfe32YhgQU-3hPEvlgFYMsnxd_NBmzLjbqQYkCEwD6Wh0MaVKu9gJ3By9DI__xsd4"}, http.StatusMovedPermanently, true},
+	} {
+		rw := httptest.NewRecorder()
+		responder.ServeHTTP(rw, &http.Request{Method: "GET", URL: tc.url})
+		test.AssertEquals(t, rw.Code, tc.expected)
+		if tc.redirected {
+			test.AssertEquals(t, rw.Header().Get("Location"), "/ocsp/"+canonical)
+			test.AssertEquals(t, rw.Body.Len(), 0)
+		}
+	}
+
+	// POST requests are never redirected.
+	body, err := decodeGETRequest(canonical)
+	test.AssertNotError(t, err, "decoding test request")
+	rw := httptest.NewRecorder()
+	responder.ServeHTTP(rw, httptest.NewRequest("POST", "/", bytes.NewReader(body)))
+	test.AssertEquals(t, rw.Code, http.StatusOK)
 }
 
 func TestNewSourceFromFile(t *testing.T) {
@@ -316,3 +414,80 @@ func TestNewSourceFromFile(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestIssuerResponses(t *testing.T) {
+	issuer, err := issuance.LoadCertificate("./testdata/test-ca.der.pem")
+	test.AssertNotError(t, err, "failed to load issuer cert")
+	reqBytes, err := os.ReadFile("./testdata/ocsp.req")
+	test.AssertNotError(t, err, "failed to read OCSP request")
+	req, err := ocsp.ParseRequest(reqBytes)
+	test.AssertNotError(t, err, "failed to parse OCSP request")
+	respBytes, err := os.ReadFile("./testdata/ocsp.resp")
+	test.AssertNotError(t, err, "failed to read OCSP response")
+	resp, err := ocsp.ParseResponse(respBytes, nil)
+	test.AssertNotError(t, err, "failed to parse OCSP response")
+	resp.Status = ocsp.Revoked
+	src, err := NewMemorySource(map[string]*Response{
+		req.SerialNumber.String(): {Response: resp, Raw: respBytes},
+	}, blog.NewMock())
+	test.AssertNotError(t, err, "failed to create inMemorySource")
+
+	responder := NewResponder(src, time.Second, metrics.NoopRegisterer, blog.NewMock(), 1)
+	err = responder.LabelIssuers([]*issuance.Certificate{issuer})
+	test.AssertNotError(t, err, "failed to label issuers")
+
+	post := func(body []byte, ifNoneMatch string) int {
+		rw := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/", bytes.NewReader(body))
+		if ifNoneMatch != "" {
+			r.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		responder.ServeHTTP(rw, r)
+		return rw.Code
+	}
+	labels := func(issuer, status, code string) prometheus.Labels {
+		return prometheus.Labels{"issuer": issuer, "status": status, "code": code}
+	}
+
+	test.AssertEquals(t, post(reqBytes, ""), http.StatusOK)
+	test.AssertEquals(t, post(reqBytes, etag(&Response{Response: resp})), http.StatusNotModified)
+	test.AssertMetricWithLabelsEquals(t, responder.issuerResponses, labels(issuer.Subject.CommonName, "revoked", "200"), 1)
+	test.AssertMetricWithLabelsEquals(t, responder.issuerResponses, labels(issuer.Subject.CommonName, "revoked", "304"), 1)
+
+	// A serial the source doesn't know about.
+	req.SerialNumber.Add(req.SerialNumber, big.NewInt(1))
+	unknownSerial, err := req.Marshal()
+	test.AssertNotError(t, err, "failed to marshal OCSP request")
+	test.AssertEquals(t, post(unknownSerial, ""), http.StatusOK)
+	test.AssertMetricWithLabelsEquals(t, responder.issuerResponses, labels(issuer.Subject.CommonName, "unauthorized", "200"), 1)
+
+	// An issuer the responder doesn't know about.
+	req.IssuerKeyHash[0]++
+	unknownIssuer, err := req.Marshal()
+	test.AssertNotError(t, err, "failed to marshal OCSP request")
+	test.AssertEquals(t, post(unknownIssuer, ""), http.StatusOK)
+	test.AssertMetricWithLabelsEquals(t, responder.issuerResponses, labels("unknown", "unauthorized", "200"), 1)
+}
+
+func TestReportHotSerials(t *testing.T) {
+	log := blog.NewMock()
+	responder := NewResponder(testSource{}, time.Second, metrics.NoopRegisterer, log, 1)
+	responder.hotSerials = newHotSerials(10)
+
+	for range 3 {
+		responder.ServeHTTP(httptest.NewRecorder(), &http.Request{
+			Method: "GET",
+			URL: &url.URL{
+				Path: "MFQwUjBQME4wTDAJBgUrDgMCGgUABBQ55F6w46hhx%2Fo6OXOHa%2BYfe32YhgQU%2B3hPEvlgFYMsnxd%2FNBmzLjbqQYkCEwD6Wh0MaVKu9gJ3By9DI%2F%2Fxsd4%3D",
+			},
+		})
+	}
+	responder.reportHotSerials(5, time.Minute)
+	test.AssertEquals(t, len(log.GetAllMatching(`Most requested serials over the last 1m0s: \[{"serial":"fa5a1d0c6952aef60277072f4323fff1b1de","count":3}\]`)), 1)
+
+	// The counts are reset after each report, and nothing is logged if there
+	// were no requests.
+	log.Clear()
+	responder.reportHotSerials(5, time.Minute)
+	test.AssertEquals(t, len(log.GetAllMatching("Most requested serials")), 0)
+}
@@ -0,0 +1,141 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/idna"
+)
+
+// ConfusableIDNAction is what the PA does about a DNS identifier containing an
+// IDN label which could be mistaken for another name (a homograph).
+type ConfusableIDNAction string
+
+const (
+	// ConfusableIDNReject refuses to issue for confusable IDNs.
+	ConfusableIDNReject = ConfusableIDNAction("reject")
+	// ConfusableIDNDNS01Only only offers the DNS-01 challenge for confusable
+	// IDNs, so that control of the name must be proven through its DNS zone
+	// rather than through whatever server it happens to resolve to.
+	ConfusableIDNDNS01Only = ConfusableIDNAction("dns-01-only")
+	// ConfusableIDNLogOnly logs confusable IDNs, but otherwise treats them
+	// like any other name.
+	ConfusableIDNLogOnly = ConfusableIDNAction("log-only")
+)
+
+// SetConfusableIDNAction enables the detection of confusable IDNs by
+// WillingToIssue and ChallengeTypesFor, which respond as the action directs.
+// An empty action disables detection, which is the default. It must be called
+// before the PA is used.
+func (pa *AuthorityImpl) SetConfusableIDNAction(action ConfusableIDNAction) error {
+	switch action {
+	case "", ConfusableIDNReject, ConfusableIDNDNS01Only, ConfusableIDNLogOnly:
+		pa.confusableIDNAction = action
+		return nil
+	default:
+		return fmt.Errorf("unrecognized confusable IDN action %q", action)
+	}
+}
+
+// confusableLabel returns the first label of the given DNS identifier value,
+// in Unicode form, which is confusable, or an empty string if there is none.
+// Detection is disabled unless SetConfusableIDNAction has been called.
+func (pa *AuthorityImpl) confusableLabel(domain string) string {
+	if pa.confusableIDNAction == "" {
+		return ""
+	}
+	for label := range strings.SplitSeq(domain, ".") {
+		if !strings.HasPrefix(label, "xn--") {
+			continue
+		}
+		ulabel, err := idna.ToUnicode(label)
+		if err != nil {
+			// Malformed P-Labels are rejected by WellFormedIdentifiers.
+			continue
+		}
+		if isConfusableLabel(ulabel) {
+			return ulabel
+		}
+	}
+	return ""
+}
+
+// allowedScriptMixes are the combinations of scripts which may be mixed in a
+// single label, following the "Highly Restrictive" level of Unicode Technical
+// Standard #39, Section 5.2. Each of them is in everyday use for a language
+// written with more than one script.
+var allowedScriptMixes = []map[string]bool{
+	{"Latin": true, "Han": true, "Hiragana": true, "Katakana": true},
+	{"Latin": true, "Han": true, "Bopomofo": true},
+	{"Latin": true, "Han": true, "Hangul": true},
+}
+
+// latinLookalikes are the Cyrillic and Greek letters which are all but
+// indistinguishable from a lowercase Latin letter in most fonts. A label
+// written entirely with these, like "аррӏе" in Cyrillic, is a whole-script
+// confusable of a Latin label.
+var latinLookalikes = map[rune]bool{
+	// Cyrillic
+	'а': true, 'е': true, 'о': true, 'р': true, 'с': true, 'у': true,
+	'х': true, 'і': true, 'ј': true, 'ѕ': true, 'ԁ': true, 'ԛ': true,
+	'ԝ': true, 'һ': true, 'ӏ': true, 'ү': true,
+	// Greek
+	'α': true, 'ι': true, 'κ': true, 'ν': true, 'ο': true, 'ρ': true,
+	'υ': true,
+}
+
+// scriptOf returns the name of the Unicode script of the given rune, or an
+// empty string if it belongs to the Common or Inherited pseudo-scripts (such
+// as digits, hyphens and combining marks), which may appear alongside any
+// script.
+func scriptOf(r rune) string {
+	if unicode.In(r, unicode.Common, unicode.Inherited) {
+		return ""
+	}
+	for name, table := range unicode.Scripts {
+		if unicode.Is(table, r) {
+			return name
+		}
+	}
+	return ""
+}
+
+// isConfusableLabel returns true if the given Unicode label either mixes
+// scripts which aren't normally used together, or is written entirely in
+// Cyrillic or Greek letters which look like Latin ones.
+func isConfusableLabel(ulabel string) bool {
+	scripts := make(map[string]bool)
+	allLookalikes := true
+	for _, r := range ulabel {
+		script := scriptOf(r)
+		if script == "" {
+			continue
+		}
+		scripts[script] = true
+		if !latinLookalikes[r] {
+			allLookalikes = false
+		}
+	}
+
+	switch len(scripts) {
+	case 0:
+		return false
+	case 1:
+		return allLookalikes && (scripts["Cyrillic"] || scripts["Greek"])
+	}
+
+	for _, allowed := range allowedScriptMixes {
+		subset := true
+		for script := range scripts {
+			if !allowed[script] {
+				subset = false
+				break
+			}
+		}
+		if subset {
+			return false
+		}
+	}
+	return true
+}
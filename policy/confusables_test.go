@@ -0,0 +1,134 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/letsencrypt/boulder/core"
+	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/identifier"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestIsConfusableLabel(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		ulabel     string
+		confusable bool
+	}{
+		{"example", false},
+		{"münchen", false},
+		{"пример", false},
+		{"παράδειγμα", false},
+		{"рф", false},
+		{"例子", false},
+		{"日本語のテキスト", false},
+		{"abc日本", false},
+		{"한국abc", false},
+		{"123-456", false},
+		// Latin mixed with Cyrillic.
+		{"pаypal", true},
+		// Cyrillic or Greek letters which all look like Latin ones.
+		{"аррӏе", true},
+		{"ρο", true},
+		// Cyrillic mixed with Greek.
+		{"пρимер", true},
+		// Hangul mixed with Hiragana.
+		{"한국の", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.ulabel, func(t *testing.T) {
+			t.Parallel()
+			test.AssertEquals(t, isConfusableLabel(tc.ulabel), tc.confusable)
+		})
+	}
+}
+
+func TestSetConfusableIDNAction(t *testing.T) {
+	t.Parallel()
+	pa := paImpl(t)
+
+	err := pa.SetConfusableIDNAction("fnord")
+	test.AssertError(t, err, "unrecognized action should be rejected")
+
+	// Detection is disabled by default.
+	test.AssertEquals(t, pa.confusableLabel("xn--pypal-4ve.com"), "")
+
+	err = pa.SetConfusableIDNAction(ConfusableIDNLogOnly)
+	test.AssertNotError(t, err, "SetConfusableIDNAction failed")
+	test.AssertEquals(t, pa.confusableLabel("xn--pypal-4ve.com"), "pаypal")
+	test.AssertEquals(t, pa.confusableLabel("www.xn--80ak6aa92e.com"), "аррӏе")
+	test.AssertEquals(t, pa.confusableLabel("xn--mnchen-3ya.de"), "")
+	test.AssertEquals(t, pa.confusableLabel("xn--e1afmkfd.xn--p1ai"), "")
+	test.AssertEquals(t, pa.confusableLabel("*.xn--pypal-4ve.com"), "pаypal")
+}
+
+func TestWillingToIssue_ConfusableIDNs(t *testing.T) {
+	t.Parallel()
+
+	confusable := identifier.NewDNS("xn--pypal-4ve.com")
+	notConfusable := identifier.NewDNS("xn--mnchen-3ya.de")
+
+	testCases := []struct {
+		action     ConfusableIDNAction
+		wantErr    bool
+		wantLogged bool
+		wantChalls []core.AcmeChallenge
+	}{
+		{
+			action:     "",
+			wantChalls: []core.AcmeChallenge{core.ChallengeTypeHTTP01, core.ChallengeTypeDNS01, core.ChallengeTypeTLSALPN01},
+		},
+		{
+			action:     ConfusableIDNReject,
+			wantErr:    true,
+			wantChalls: []core.AcmeChallenge{core.ChallengeTypeHTTP01, core.ChallengeTypeDNS01, core.ChallengeTypeTLSALPN01},
+		},
+		{
+			action:     ConfusableIDNDNS01Only,
+			wantLogged: true,
+			wantChalls: []core.AcmeChallenge{core.ChallengeTypeDNS01},
+		},
+		{
+			action:     ConfusableIDNLogOnly,
+			wantLogged: true,
+			wantChalls: []core.AcmeChallenge{core.ChallengeTypeHTTP01, core.ChallengeTypeDNS01, core.ChallengeTypeTLSALPN01},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.action), func(t *testing.T) {
+			t.Parallel()
+			pa := paImpl(t)
+			log := blog.NewMock()
+			pa.log = log
+			err := pa.processIdentPolicy(blockedIdentsPolicy{})
+			test.AssertNotError(t, err, "Couldn't load rules")
+			err = pa.SetConfusableIDNAction(tc.action)
+			test.AssertNotError(t, err, "SetConfusableIDNAction failed")
+
+			err = pa.WillingToIssue(identifier.ACMEIdentifiers{notConfusable})
+			test.AssertNotError(t, err, "WillingToIssue failed on a name which isn't confusable")
+			challs, err := pa.ChallengeTypesFor(notConfusable)
+			test.AssertNotError(t, err, "ChallengeTypesFor failed")
+			test.AssertEquals(t, len(challs), 3)
+
+			err = pa.WillingToIssue(identifier.ACMEIdentifiers{confusable})
+			if tc.wantErr {
+				test.AssertError(t, err, "WillingToIssue didn't fail on a confusable name")
+				test.AssertErrorIs(t, err, berrors.RejectedIdentifier)
+				test.AssertContains(t, err.Error(), errConfusableIDN.Error())
+			} else {
+				test.AssertNotError(t, err, "WillingToIssue failed on a confusable name")
+			}
+			logged := log.GetAllMatching("Confusable IDN label")
+			test.AssertEquals(t, len(logged) == 1, tc.wantLogged)
+
+			challs, err = pa.ChallengeTypesFor(confusable)
+			test.AssertNotError(t, err, "ChallengeTypesFor failed")
+			test.AssertDeepEquals(t, challs, tc.wantChalls)
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/letsencrypt/boulder/core"
 	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/features"
 	"github.com/letsencrypt/boulder/iana"
 	"github.com/letsencrypt/boulder/identifier"
 	blog "github.com/letsencrypt/boulder/log"
@@ -36,6 +37,8 @@ type AuthorityImpl struct {
 
 	enabledChallenges  map[core.AcmeChallenge]bool
 	enabledIdentifiers map[identifier.IdentifierType]bool
+
+	confusableIDNAction ConfusableIDNAction
 }
 
 // New constructs a Policy Authority.
@@ -203,6 +206,9 @@ var (
 	errICANNTLDWildcard     = berrors.MalformedError("Domain name is a wildcard for an ICANN TLD")
 	errWildcardNotSupported = berrors.MalformedError("Wildcard domain names are not supported")
 	errUnsupportedIdent     = berrors.MalformedError("Invalid identifier type")
+	errEmailInvalid         = berrors.MalformedError("Email address is invalid")
+	errNotCanonical         = berrors.MalformedError("Identifier value is not in canonical form")
+	errConfusableIDN        = berrors.RejectedIdentifierError("Domain name contains an internationalized label which could be mistaken for another name")
 )
 
 // validNonWildcardDomain checks that a domain isn't:
@@ -367,6 +373,37 @@ func ValidIP(ip string) error {
 	return iana.IsReservedAddr(parsedIP)
 }
 
+// ValidEmailIdentifier checks that an email address identifier:
+//   - is a bare address, without a display name or angle brackets
+//   - has an unquoted local part
+//   - has a domain which is a valid non-wildcard DNS name
+//
+// It does NOT ensure that the domain is absent from any PA blocked lists.
+func ValidEmailIdentifier(address string) error {
+	if address == "" {
+		return errEmptyIdentifier
+	}
+
+	parsed, err := mail.ParseAddress(address)
+	if err != nil || parsed.Name != "" || parsed.Address != address {
+		return errEmailInvalid
+	}
+	at := strings.LastIndex(address, "@")
+	if at <= 0 {
+		return errEmailInvalid
+	}
+
+	err = validNonWildcardDomain(address[at+1:])
+	if err != nil {
+		var bErr *berrors.BoulderError
+		if errors.As(err, &bErr) {
+			return berrors.MalformedError("Email address has an invalid domain: %s", bErr.Detail)
+		}
+		return err
+	}
+	return nil
+}
+
 // forbiddenMailDomains is a map of domain names we do not allow after the
 // @ symbol in contact mailto addresses. These are frequently used when
 // copy-pasting example configurations and would not result in expiration
@@ -424,7 +461,8 @@ func subError(ident identifier.ACMEIdentifier, err error) berrors.SubBoulderErro
 // identifiers.
 //
 // It checks the criteria checked by `WellFormedIdentifiers`, and additionally
-// checks whether any identifier is on a blocklist.
+// checks whether any identifier is on a blocklist and, if configured to with
+// SetConfusableIDNAction, whether any DNS identifier is a confusable IDN.
 //
 // If multiple identifiers are invalid, the error will contain suberrors
 // specific to each identifier.
@@ -463,6 +501,17 @@ func (pa *AuthorityImpl) WillingToIssue(idents identifier.ACMEIdentifiers) error
 			subErrors = append(subErrors, subError(ident, err))
 			continue
 		}
+
+		if ident.Type == identifier.TypeDNS {
+			ulabel := pa.confusableLabel(ident.Value)
+			if ulabel != "" {
+				if pa.confusableIDNAction == ConfusableIDNReject {
+					subErrors = append(subErrors, subError(ident, errConfusableIDN))
+					continue
+				}
+				pa.log.Infof("Confusable IDN label %q in %q, action: %s", ulabel, ident.Value, pa.confusableIDNAction)
+			}
+		}
 	}
 	return combineSubErrors(subErrors)
 }
@@ -494,24 +543,46 @@ func (pa *AuthorityImpl) WillingToIssue(idents identifier.ACMEIdentifiers) error
 //   - MUST NOT contain a scope zone (RFC 4007)
 //   - MUST NOT be in an IANA special-purpose address registry
 //
+// For email identifiers, which are only accepted if the EmailIdentifiers
+// feature is enabled:
+//   - MUST be a bare address with a local part
+//   - MUST have a domain which meets the criteria for DNS identifiers, without
+//     a wildcard
+//
+// Every identifier MUST also already be in the canonical form returned by its
+// Canonicalize method. Identifiers which aren't are rejected rather than
+// rewritten, so that orders only ever hold the identifiers the client sent.
+//
 // If multiple identifiers are invalid, the error will contain suberrors
 // specific to each identifier.
 func WellFormedIdentifiers(idents identifier.ACMEIdentifiers) error {
 	var subErrors []berrors.SubBoulderError
 	for _, ident := range idents {
+		var err error
 		switch ident.Type {
 		case identifier.TypeDNS:
-			err := ValidDomain(ident.Value)
-			if err != nil {
-				subErrors = append(subErrors, subError(ident, err))
-			}
+			err = ValidDomain(ident.Value)
 		case identifier.TypeIP:
-			err := ValidIP(ident.Value)
-			if err != nil {
-				subErrors = append(subErrors, subError(ident, err))
+			err = ValidIP(ident.Value)
+		case identifier.TypeEmail:
+			if !features.Get().EmailIdentifiers {
+				err = errUnsupportedIdent
+				break
 			}
+			err = ValidEmailIdentifier(ident.Value)
 		default:
-			subErrors = append(subErrors, subError(ident, errUnsupportedIdent))
+			err = errUnsupportedIdent
+		}
+		if err == nil {
+			canonical, cErr := ident.Canonicalize()
+			if cErr != nil {
+				err = errNotCanonical
+			} else if canonical != ident {
+				err = berrors.MalformedError("%s, which is %q", errNotCanonical.Error(), canonical.Value)
+			}
+		}
+		if err != nil {
+			subErrors = append(subErrors, subError(ident, err))
 		}
 	}
 	return combineSubErrors(subErrors)
@@ -570,8 +641,14 @@ func (pa *AuthorityImpl) checkBlocklists(ident identifier.ACMEIdentifier) error
 	}
 
 	switch ident.Type {
-	case identifier.TypeDNS:
-		labels := strings.Split(ident.Value, ".")
+	case identifier.TypeDNS, identifier.TypeEmail:
+		domain := ident.Value
+		if ident.Type == identifier.TypeEmail {
+			// Addresses at a blocked domain are blocked too.
+			domain = domain[strings.LastIndex(domain, "@")+1:]
+		}
+
+		labels := strings.Split(domain, ".")
 		for i := range labels {
 			joined := strings.Join(labels[i:], ".")
 			if pa.domainBlocklist[joined] {
@@ -579,7 +656,7 @@ func (pa *AuthorityImpl) checkBlocklists(ident identifier.ACMEIdentifier) error
 			}
 		}
 
-		if pa.fqdnBlocklist[ident.Value] {
+		if pa.fqdnBlocklist[domain] {
 			return errPolicyForbidden
 		}
 	case identifier.TypeIP:
@@ -614,6 +691,13 @@ func (pa *AuthorityImpl) ChallengeTypesFor(ident identifier.ACMEIdentifier) ([]c
 			return []core.AcmeChallenge{core.ChallengeTypeDNS01}, nil
 		}
 
+		// If configured to, we only provide a DNS-01 challenge for names which
+		// could be mistaken for another, so that issuance requires control of
+		// the name's zone.
+		if pa.confusableIDNAction == ConfusableIDNDNS01Only && pa.confusableLabel(ident.Value) != "" {
+			return []core.AcmeChallenge{core.ChallengeTypeDNS01}, nil
+		}
+
 		// Return all challenge types we support for non-wildcard DNS identifiers.
 		return []core.AcmeChallenge{
 			core.ChallengeTypeHTTP01,
@@ -627,6 +711,8 @@ func (pa *AuthorityImpl) ChallengeTypesFor(ident identifier.ACMEIdentifier) ([]c
 			core.ChallengeTypeHTTP01,
 			core.ChallengeTypeTLSALPN01,
 		}, nil
+	case identifier.TypeEmail:
+		return []core.AcmeChallenge{core.ChallengeTypeEmailReply00}, nil
 	default:
 		// Otherwise return an error because we don't support any challenges for this
 		// identifier type.
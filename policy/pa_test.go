@@ -484,6 +484,13 @@ func TestChallengeTypesFor(t *testing.T) {
 				core.ChallengeTypeHTTP01, core.ChallengeTypeTLSALPN01,
 			},
 		},
+		{
+			name:  "email",
+			ident: identifier.NewEmail("alice@example.com"),
+			wantChalls: []core.AcmeChallenge{
+				core.ChallengeTypeEmailReply00,
+			},
+		},
 		{
 			name:    "invalid",
 			ident:   identifier.ACMEIdentifier{Type: "fnord", Value: "uh-oh, Spaghetti-Os[tm]"},
@@ -742,3 +749,60 @@ func TestWillingToIssue_IdentifierType(t *testing.T) {
 		})
 	}
 }
+
+func TestWillingToIssue_Email(t *testing.T) {
+	policy := blockedIdentsPolicy{
+		HighRiskBlockedNames: []string{"website2.com"},
+		ExactBlockedNames:    []string{"highvalue.website1.org"},
+	}
+	yamlPolicyBytes, err := yaml.Marshal(policy)
+	test.AssertNotError(t, err, "Couldn't YAML serialize blocklist")
+	yamlPolicyFile, _ := os.CreateTemp("", "test-blocklist.*.yaml")
+	defer os.Remove(yamlPolicyFile.Name())
+	err = os.WriteFile(yamlPolicyFile.Name(), yamlPolicyBytes, 0640)
+	test.AssertNotError(t, err, "Couldn't write YAML blocklist")
+
+	pa := paImpl(t)
+	err = pa.LoadIdentPolicyFile(yamlPolicyFile.Name())
+	test.AssertNotError(t, err, "Couldn't load rules")
+	pa.enabledIdentifiers[identifier.TypeEmail] = true
+
+	// Email identifiers aren't well-formed unless the feature is enabled.
+	err = pa.WillingToIssue(identifier.ACMEIdentifiers{identifier.NewEmail("alice@example.org")})
+	test.AssertError(t, err, "email identifier accepted with EmailIdentifiers disabled")
+	test.AssertContains(t, err.Error(), errUnsupportedIdent.Error())
+
+	features.Set(features.Config{EmailIdentifiers: true})
+	defer features.Reset()
+
+	testCases := []struct {
+		address string
+		wantErr error
+	}{
+		{address: "alice@example.org"},
+		{address: "alice.smith+tag@example.org"},
+		{address: `"alice smith"@example.org`, wantErr: errEmailInvalid},
+		{address: "", wantErr: errEmptyIdentifier},
+		{address: "example.org", wantErr: errEmailInvalid},
+		{address: "@example.org", wantErr: errEmailInvalid},
+		{address: "Alice <alice@example.org>", wantErr: errEmailInvalid},
+		{address: "alice@*.example.org", wantErr: errWildcardNotSupported},
+		{address: "alice@localhost", wantErr: errTooFewLabels},
+		{address: "alice@mail.website2.com", wantErr: errPolicyForbidden},
+		{address: "alice@highvalue.website1.org", wantErr: errPolicyForbidden},
+		{address: "Alice@example.org", wantErr: berrors.MalformedError(`Identifier value is not in canonical form, which is "alice@example.org"`)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.address, func(t *testing.T) {
+			err := pa.WillingToIssue(identifier.ACMEIdentifiers{identifier.NewEmail(tc.address)})
+			if tc.wantErr == nil {
+				test.AssertNotError(t, err, "email identifier was incorrectly rejected")
+				return
+			}
+			test.AssertError(t, err, "email identifier was incorrectly accepted")
+			var bErr *berrors.BoulderError
+			test.AssertErrorWraps(t, err, &bErr)
+			test.AssertContains(t, bErr.Detail, tc.wantErr.Error())
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package probs
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/go-jose/go-jose/v4"
+)
+
+// Code is a stable, machine-readable identifier for a kind of problem. Unlike
+// the Detail of a problem, which is written for humans and may change at any
+// time, a Code is never changed or reused once published, so that integrators
+// can key automation off of it. Several Codes may share a ProblemType, for
+// example when the same ACME error type is sent with different HTTP statuses.
+type Code string
+
+const (
+	CodeAccountDoesNotExist   = Code("accountDoesNotExist")
+	CodeAlreadyReplaced       = Code("alreadyReplaced")
+	CodeAlreadyRevoked        = Code("alreadyRevoked")
+	CodeBadCSR                = Code("badCSR")
+	CodeBadNonce              = Code("badNonce")
+	CodeBadPublicKey          = Code("badPublicKey")
+	CodeBadRevocationReason   = Code("badRevocationReason")
+	CodeBadSignatureAlgorithm = Code("badSignatureAlgorithm")
+	CodeCAA                   = Code("caa")
+	CodeCanceled              = Code("canceled")
+	CodeClientDeprecated      = Code("clientDeprecated")
+	CodeConflict              = Code("conflict")
+	CodeConnection            = Code("connection")
+	CodeDNS                   = Code("dns")
+	CodeInvalidContact        = Code("invalidContact")
+	CodeInvalidProfile        = Code("invalidProfile")
+	CodeMaintenance           = Code("maintenance")
+	CodeMalformed             = Code("malformed")
+	CodeMethodNotAllowed      = Code("methodNotAllowed")
+	CodeNotFound              = Code("notFound")
+	CodeOrderNotReady         = Code("orderNotReady")
+	CodePaused                = Code("paused")
+	CodeRateLimited           = Code("rateLimited")
+	CodeRejectedIdentifier    = Code("rejectedIdentifier")
+	CodeServerInternal        = Code("serverInternal")
+	CodeTLS                   = Code("tls")
+	CodeTooManyFailedAttempts = Code("tooManyFailedAttempts")
+	CodeUnauthorized          = Code("unauthorized")
+	CodeUnsupportedContact    = Code("unsupportedContact")
+	CodeUnsupportedIdentifier = Code("unsupportedIdentifier")
+)
+
+// CatalogEntry describes the problem document produced for a Code.
+type CatalogEntry struct {
+	Code        Code
+	Type        ProblemType
+	HTTPStatus  int
+	Description string
+}
+
+// catalog is the closed set of Codes which may appear in a problem document.
+// Entries may be added, but existing entries must not be removed or have their
+// Type or HTTPStatus changed.
+var catalog = map[Code]CatalogEntry{
+	CodeAccountDoesNotExist:   {Type: AccountDoesNotExistProblem, HTTPStatus: http.StatusBadRequest, Description: "The request specified an account that does not exist"},
+	CodeAlreadyReplaced:       {Type: AlreadyReplacedProblem, HTTPStatus: http.StatusConflict, Description: "The certificate named in the replaces field has already been replaced"},
+	CodeAlreadyRevoked:        {Type: AlreadyRevokedProblem, HTTPStatus: http.StatusBadRequest, Description: "The certificate is already revoked"},
+	CodeBadCSR:                {Type: BadCSRProblem, HTTPStatus: http.StatusBadRequest, Description: "The CSR is unacceptable"},
+	CodeBadNonce:              {Type: BadNonceProblem, HTTPStatus: http.StatusBadRequest, Description: "The request carried a missing, invalid, or already used nonce"},
+	CodeBadPublicKey:          {Type: BadPublicKeyProblem, HTTPStatus: http.StatusBadRequest, Description: "The public key is not supported or not allowed"},
+	CodeBadRevocationReason:   {Type: BadRevocationReasonProblem, HTTPStatus: http.StatusBadRequest, Description: "The revocation reason is not allowed"},
+	CodeBadSignatureAlgorithm: {Type: BadSignatureAlgorithmProblem, HTTPStatus: http.StatusBadRequest, Description: "The JWS was signed with an unsupported algorithm"},
+	CodeCAA:                   {Type: CAAProblem, HTTPStatus: http.StatusForbidden, Description: "CAA records forbid issuance"},
+	CodeCanceled:              {Type: MalformedProblem, HTTPStatus: http.StatusRequestTimeout, Description: "The request was canceled before it could be completed"},
+	CodeClientDeprecated:      {Type: UnauthorizedProblem, HTTPStatus: http.StatusForbidden, Description: "The ACME client version is no longer accepted"},
+	CodeConflict:              {Type: ConflictProblem, HTTPStatus: http.StatusConflict, Description: "The request conflicts with the current state of a resource"},
+	CodeConnection:            {Type: ConnectionProblem, HTTPStatus: http.StatusBadRequest, Description: "The server could not connect to the validation target"},
+	CodeDNS:                   {Type: DNSProblem, HTTPStatus: http.StatusBadRequest, Description: "There was a problem with a DNS query during validation"},
+	CodeInvalidContact:        {Type: InvalidContactProblem, HTTPStatus: http.StatusBadRequest, Description: "A contact URL is invalid"},
+	CodeInvalidProfile:        {Type: InvalidProfileProblem, HTTPStatus: http.StatusBadRequest, Description: "The requested profile is unknown"},
+	CodeMaintenance:           {Type: ServiceUnavailableProblem, HTTPStatus: http.StatusServiceUnavailable, Description: "The endpoint is temporarily out of service for maintenance"},
+	CodeMalformed:             {Type: MalformedProblem, HTTPStatus: http.StatusBadRequest, Description: "The request message was malformed"},
+	CodeMethodNotAllowed:      {Type: MalformedProblem, HTTPStatus: http.StatusMethodNotAllowed, Description: "The HTTP method is not allowed for this resource"},
+	CodeNotFound:              {Type: MalformedProblem, HTTPStatus: http.StatusNotFound, Description: "The requested resource does not exist"},
+	CodeOrderNotReady:         {Type: OrderNotReadyProblem, HTTPStatus: http.StatusForbidden, Description: "The order is not in the ready state"},
+	CodePaused:                {Type: PausedProblem, HTTPStatus: http.StatusTooManyRequests, Description: "Issuance for the account and identifier is paused"},
+	CodeRateLimited:           {Type: RateLimitedProblem, HTTPStatus: http.StatusTooManyRequests, Description: "The request exceeds a rate limit"},
+	CodeRejectedIdentifier:    {Type: RejectedIdentifierProblem, HTTPStatus: http.StatusBadRequest, Description: "The server will not issue for the identifier"},
+	CodeServerInternal:        {Type: ServerInternalProblem, HTTPStatus: http.StatusInternalServerError, Description: "The server experienced an internal error"},
+	CodeTLS:                   {Type: TLSProblem, HTTPStatus: http.StatusBadRequest, Description: "The server received a TLS error during validation"},
+	CodeTooManyFailedAttempts: {Type: RateLimitedProblem, HTTPStatus: http.StatusTooManyRequests, Description: "The authorization failed validation too many times"},
+	CodeUnauthorized:          {Type: UnauthorizedProblem, HTTPStatus: http.StatusForbidden, Description: "The client lacks sufficient authorization"},
+	CodeUnsupportedContact:    {Type: UnsupportedContactProblem, HTTPStatus: http.StatusBadRequest, Description: "A contact URL uses an unsupported scheme"},
+	CodeUnsupportedIdentifier: {Type: UnsupportedIdentifierProblem, HTTPStatus: http.StatusBadRequest, Description: "An identifier is of an unsupported type"},
+}
+
+// Lookup returns the CatalogEntry for the given Code, and false if the Code is
+// unknown.
+func Lookup(code Code) (CatalogEntry, bool) {
+	entry, ok := catalog[code]
+	if !ok {
+		return CatalogEntry{}, false
+	}
+	entry.Code = code
+	return entry, true
+}
+
+// Catalog returns every CatalogEntry, sorted by Code.
+func Catalog() []CatalogEntry {
+	entries := make([]CatalogEntry, 0, len(catalog))
+	for code := range catalog {
+		entry, _ := Lookup(code)
+		entries = append(entries, entry)
+	}
+	slices.SortFunc(entries, func(a, b CatalogEntry) int {
+		return strings.Compare(string(a.Code), string(b.Code))
+	})
+	return entries
+}
+
+// Builder constructs a ProblemDetails whose Type and HTTPStatus are determined
+// by its Code. The zero value is not usable; use New.
+type Builder struct {
+	pd ProblemDetails
+}
+
+// New returns a Builder for a problem with the given Code. An unknown Code is a
+// programming error; rather than panic in a request path, New falls back to
+// CodeServerInternal.
+func New(code Code) *Builder {
+	entry, ok := Lookup(code)
+	if !ok {
+		entry, _ = Lookup(CodeServerInternal)
+	}
+	return &Builder{pd: ProblemDetails{
+		Type:       entry.Type,
+		HTTPStatus: entry.HTTPStatus,
+		Code:       entry.Code,
+	}}
+}
+
+// Detail sets the human-readable detail of the problem. If any arguments are
+// provided, detail is used as a format string.
+func (b *Builder) Detail(detail string, a ...any) *Builder {
+	if len(a) > 0 {
+		detail = fmt.Sprintf(detail, a...)
+	}
+	b.pd.Detail = detail
+	return b
+}
+
+// SubProblems appends per-identifier problems to the problem.
+func (b *Builder) SubProblems(subProbs ...SubProblemDetails) *Builder {
+	b.pd.SubProblems = append(b.pd.SubProblems, subProbs...)
+	return b
+}
+
+// Algorithms sets the algorithms advertised by a badSignatureAlgorithm problem.
+func (b *Builder) Algorithms(algs ...jose.SignatureAlgorithm) *Builder {
+	b.pd.Algorithms = algs
+	return b
+}
+
+// Build returns the constructed ProblemDetails. Each call returns a new copy.
+func (b *Builder) Build() *ProblemDetails {
+	pd := b.pd
+	pd.SubProblems = slices.Clone(b.pd.SubProblems)
+	pd.Algorithms = slices.Clone(b.pd.Algorithms)
+	return &pd
+}
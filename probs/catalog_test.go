@@ -0,0 +1,92 @@
+package probs
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/go-jose/go-jose/v4"
+
+	"github.com/letsencrypt/boulder/identifier"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestCatalog(t *testing.T) {
+	entries := Catalog()
+	test.AssertEquals(t, len(entries), len(catalog))
+	for i, entry := range entries {
+		if i > 0 && entries[i-1].Code >= entry.Code {
+			t.Errorf("catalog not sorted: %q before %q", entries[i-1].Code, entry.Code)
+		}
+		if entry.Type == "" || entry.HTTPStatus == 0 || entry.Description == "" {
+			t.Errorf("incomplete catalog entry for %q: %+v", entry.Code, entry)
+		}
+	}
+
+	entry, ok := Lookup(CodePaused)
+	test.Assert(t, ok, "expected CodePaused to be in the catalog")
+	test.AssertEquals(t, entry.Code, CodePaused)
+	test.AssertEquals(t, entry.Type, RateLimitedProblem)
+	test.AssertEquals(t, entry.HTTPStatus, http.StatusTooManyRequests)
+
+	_, ok = Lookup(Code("bogus"))
+	test.Assert(t, !ok, "expected unknown code not to be in the catalog")
+}
+
+func TestBuilder(t *testing.T) {
+	sub := SubProblemDetails{
+		Identifier:     identifier.NewDNS("example.com"),
+		ProblemDetails: *New(CodeRejectedIdentifier).Detail("no").Build(),
+	}
+	b := New(CodeBadSignatureAlgorithm).
+		Detail("algorithm %q not allowed", "HS256").
+		Algorithms(jose.RS256, jose.ES256).
+		SubProblems(sub)
+	prob := b.Build()
+	test.AssertDeepEquals(t, prob, &ProblemDetails{
+		Type:        BadSignatureAlgorithmProblem,
+		Detail:      `algorithm "HS256" not allowed`,
+		HTTPStatus:  http.StatusBadRequest,
+		SubProblems: []SubProblemDetails{sub},
+		Algorithms:  []jose.SignatureAlgorithm{jose.RS256, jose.ES256},
+		Code:        CodeBadSignatureAlgorithm,
+	})
+
+	// Each call to Build returns an independent copy.
+	prob.SubProblems[0].Detail = "changed"
+	test.AssertEquals(t, b.Build().SubProblems[0].Detail, "no")
+
+	// Details without arguments are not treated as format strings.
+	test.AssertEquals(t, New(CodeMalformed).Detail("100%").Build().Detail, "100%")
+
+	// Unknown codes fall back to an internal server error.
+	prob = New(Code("bogus")).Detail("oops").Build()
+	test.AssertEquals(t, prob.Code, CodeServerInternal)
+	test.AssertEquals(t, prob.Type, ServerInternalProblem)
+	test.AssertEquals(t, prob.HTTPStatus, http.StatusInternalServerError)
+}
+
+func TestConvenienceCodes(t *testing.T) {
+	testCases := []struct {
+		pb   *ProblemDetails
+		code Code
+	}{
+		{Malformed("malformed detail"), CodeMalformed},
+		{NotFound("not found detail"), CodeNotFound},
+		{MethodNotAllowed(), CodeMethodNotAllowed},
+		{Canceled("canceled detail"), CodeCanceled},
+		{ClientDeprecated("client deprecated detail"), CodeClientDeprecated},
+		{Paused("paused detail"), CodePaused},
+		{RateLimited("rate limited detail"), CodeRateLimited},
+	}
+	for _, c := range testCases {
+		test.AssertEquals(t, c.pb.Code, c.code)
+		entry, ok := Lookup(c.code)
+		test.Assert(t, ok, "expected code to be in the catalog")
+		test.AssertEquals(t, c.pb.Type, entry.Type)
+		test.AssertEquals(t, c.pb.HTTPStatus, entry.HTTPStatus)
+	}
+
+	// Sub-problems keep the code of the problem they are added to.
+	prob := RateLimited("top").WithSubProblems(nil)
+	test.AssertEquals(t, prob.Code, CodeRateLimited)
+}
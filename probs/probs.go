@@ -2,7 +2,6 @@ package probs
 
 import (
 	"fmt"
-	"net/http"
 
 	"github.com/go-jose/go-jose/v4"
 
@@ -37,6 +36,7 @@ const (
 	RateLimitedProblem           = ProblemType("rateLimited")
 	RejectedIdentifierProblem    = ProblemType("rejectedIdentifier")
 	ServerInternalProblem        = ProblemType("serverInternal")
+	ServiceUnavailableProblem    = ProblemType("serviceUnavailable")
 	TLSProblem                   = ProblemType("tls")
 	UnauthorizedProblem          = ProblemType("unauthorized")
 	UnsupportedContactProblem    = ProblemType("unsupportedContact")
@@ -66,6 +66,15 @@ type ProblemDetails struct {
 	// badSignatureAlgorithm. See RFC 8555, Section 6.2:
 	// https://datatracker.ietf.org/doc/html/rfc8555#section-6.2
 	Algorithms []jose.SignatureAlgorithm `json:"algorithms,omitempty"`
+	// DeprecatedAlgorithm is an extension field naming the JWS signature
+	// algorithm of the request which caused the problem, if that algorithm is
+	// due to be disabled. It warns clients to switch to another algorithm while
+	// they still can.
+	DeprecatedAlgorithm jose.SignatureAlgorithm `json:"deprecatedAlgorithm,omitempty"`
+	// Code is a stable, machine-readable identifier for the problem, drawn from
+	// the catalog in this package. It is an extension field which clients may
+	// use in place of parsing the Detail.
+	Code Code `json:"code,omitempty"`
 }
 
 // SubProblemDetails represents sub-problems specific to an identifier that are
@@ -88,225 +97,142 @@ func (pd *ProblemDetails) WithSubProblems(subProbs []SubProblemDetails) *Problem
 		Detail:      pd.Detail,
 		HTTPStatus:  pd.HTTPStatus,
 		SubProblems: append(pd.SubProblems, subProbs...),
+		Code:        pd.Code,
 	}
 }
 
 // Helper functions which construct the basic RFC8555 Problem Documents, with
-// the Type already set and the Details supplied by the caller.
+// the Type, HTTPStatus, and Code taken from the catalog and the Details
+// supplied by the caller.
 
 // AccountDoesNotExist returns a ProblemDetails representing an
 // AccountDoesNotExistProblem error
 func AccountDoesNotExist(detail string) *ProblemDetails {
-	return &ProblemDetails{
-		Type:       AccountDoesNotExistProblem,
-		Detail:     detail,
-		HTTPStatus: http.StatusBadRequest,
-	}
+	return New(CodeAccountDoesNotExist).Detail(detail).Build()
 }
 
 // AlreadyReplaced returns a ProblemDetails with a AlreadyReplacedProblem and a
 // 409 Conflict status code.
 func AlreadyReplaced(detail string) *ProblemDetails {
-	return &ProblemDetails{
-		Type:       AlreadyReplacedProblem,
-		Detail:     detail,
-		HTTPStatus: http.StatusConflict,
-	}
+	return New(CodeAlreadyReplaced).Detail(detail).Build()
 }
 
 // AlreadyRevoked returns a ProblemDetails with a AlreadyRevokedProblem and a 400 Bad
 // Request status code.
 func AlreadyRevoked(detail string) *ProblemDetails {
-	return &ProblemDetails{
-		Type:       AlreadyRevokedProblem,
-		Detail:     detail,
-		HTTPStatus: http.StatusBadRequest,
-	}
+	return New(CodeAlreadyRevoked).Detail(detail).Build()
 }
 
 // BadCSR returns a ProblemDetails representing a BadCSRProblem.
 func BadCSR(detail string) *ProblemDetails {
-	return &ProblemDetails{
-		Type:       BadCSRProblem,
-		Detail:     detail,
-		HTTPStatus: http.StatusBadRequest,
-	}
+	return New(CodeBadCSR).Detail(detail).Build()
 }
 
 // BadNonce returns a ProblemDetails with a BadNonceProblem and a 400 Bad
 // Request status code.
 func BadNonce(detail string) *ProblemDetails {
-	return &ProblemDetails{
-		Type:       BadNonceProblem,
-		Detail:     detail,
-		HTTPStatus: http.StatusBadRequest,
-	}
+	return New(CodeBadNonce).Detail(detail).Build()
 }
 
 // BadPublicKey returns a ProblemDetails with a BadPublicKeyProblem and a 400 Bad
 // Request status code.
 func BadPublicKey(detail string) *ProblemDetails {
-	return &ProblemDetails{
-		Type:       BadPublicKeyProblem,
-		Detail:     detail,
-		HTTPStatus: http.StatusBadRequest,
-	}
+	return New(CodeBadPublicKey).Detail(detail).Build()
 }
 
 // BadRevocationReason returns a ProblemDetails representing
 // a BadRevocationReasonProblem
 func BadRevocationReason(detail string) *ProblemDetails {
-	return &ProblemDetails{
-		Type:       BadRevocationReasonProblem,
-		Detail:     detail,
-		HTTPStatus: http.StatusBadRequest,
-	}
+	return New(CodeBadRevocationReason).Detail(detail).Build()
 }
 
 // BadSignatureAlgorithm returns a ProblemDetails with a BadSignatureAlgorithmProblem
 // and a 400 Bad Request status code.
 func BadSignatureAlgorithm(detail string) *ProblemDetails {
-	return &ProblemDetails{
-		Type:       BadSignatureAlgorithmProblem,
-		Detail:     detail,
-		HTTPStatus: http.StatusBadRequest,
-	}
+	return New(CodeBadSignatureAlgorithm).Detail(detail).Build()
 }
 
 // CAA returns a ProblemDetails representing a CAAProblem
 func CAA(detail string) *ProblemDetails {
-	return &ProblemDetails{
-		Type:       CAAProblem,
-		Detail:     detail,
-		HTTPStatus: http.StatusForbidden,
-	}
+	return New(CodeCAA).Detail(detail).Build()
 }
 
 // Connection returns a ProblemDetails representing a ConnectionProblem
 // error
 func Connection(detail string) *ProblemDetails {
-	return &ProblemDetails{
-		Type:       ConnectionProblem,
-		Detail:     detail,
-		HTTPStatus: http.StatusBadRequest,
-	}
+	return New(CodeConnection).Detail(detail).Build()
 }
 
 // DNS returns a ProblemDetails representing a DNSProblem
 func DNS(detail string) *ProblemDetails {
-	return &ProblemDetails{
-		Type:       DNSProblem,
-		Detail:     detail,
-		HTTPStatus: http.StatusBadRequest,
-	}
+	return New(CodeDNS).Detail(detail).Build()
 }
 
 // InvalidContact returns a ProblemDetails representing an InvalidContactProblem.
 func InvalidContact(detail string) *ProblemDetails {
-	return &ProblemDetails{
-		Type:       InvalidContactProblem,
-		Detail:     detail,
-		HTTPStatus: http.StatusBadRequest,
-	}
+	return New(CodeInvalidContact).Detail(detail).Build()
 }
 
 // Malformed returns a ProblemDetails with a MalformedProblem and a 400 Bad
 // Request status code.
 func Malformed(detail string, a ...any) *ProblemDetails {
-	if len(a) > 0 {
-		detail = fmt.Sprintf(detail, a...)
-	}
-	return &ProblemDetails{
-		Type:       MalformedProblem,
-		Detail:     detail,
-		HTTPStatus: http.StatusBadRequest,
-	}
+	return New(CodeMalformed).Detail(detail, a...).Build()
 }
 
 // OrderNotReady returns a ProblemDetails representing a OrderNotReadyProblem
 func OrderNotReady(detail string) *ProblemDetails {
-	return &ProblemDetails{
-		Type:       OrderNotReadyProblem,
-		Detail:     detail,
-		HTTPStatus: http.StatusForbidden,
-	}
+	return New(CodeOrderNotReady).Detail(detail).Build()
 }
 
 // RateLimited returns a ProblemDetails representing a RateLimitedProblem error
 func RateLimited(detail string) *ProblemDetails {
-	return &ProblemDetails{
-		Type:       RateLimitedProblem,
-		Detail:     detail,
-		HTTPStatus: http.StatusTooManyRequests,
-	}
+	return New(CodeRateLimited).Detail(detail).Build()
 }
 
 // Paused returns a ProblemDetails representing a RateLimitedProblem error
 func Paused(detail string) *ProblemDetails {
-	return &ProblemDetails{
-		Type:       PausedProblem,
-		Detail:     detail,
-		HTTPStatus: http.StatusTooManyRequests,
-	}
+	return New(CodePaused).Detail(detail).Build()
 }
 
 // RejectedIdentifier returns a ProblemDetails with a RejectedIdentifierProblem and a 400 Bad
 // Request status code.
 func RejectedIdentifier(detail string) *ProblemDetails {
-	return &ProblemDetails{
-		Type:       RejectedIdentifierProblem,
-		Detail:     detail,
-		HTTPStatus: http.StatusBadRequest,
-	}
+	return New(CodeRejectedIdentifier).Detail(detail).Build()
 }
 
 // ServerInternal returns a ProblemDetails with a ServerInternalProblem and a
 // 500 Internal Server Failure status code.
 func ServerInternal(detail string) *ProblemDetails {
-	return &ProblemDetails{
-		Type:       ServerInternalProblem,
-		Detail:     detail,
-		HTTPStatus: http.StatusInternalServerError,
-	}
+	return New(CodeServerInternal).Detail(detail).Build()
+}
+
+// Maintenance returns a ProblemDetails with a ServiceUnavailableProblem, which
+// is not defined in RFC8555, and a 503 Service Unavailable status code.
+func Maintenance(detail string) *ProblemDetails {
+	return New(CodeMaintenance).Detail(detail).Build()
 }
 
 // TLS returns a ProblemDetails representing a TLSProblem error
 func TLS(detail string) *ProblemDetails {
-	return &ProblemDetails{
-		Type:       TLSProblem,
-		Detail:     detail,
-		HTTPStatus: http.StatusBadRequest,
-	}
+	return New(CodeTLS).Detail(detail).Build()
 }
 
 // Unauthorized returns a ProblemDetails with an UnauthorizedProblem and a 403
 // Forbidden status code.
 func Unauthorized(detail string) *ProblemDetails {
-	return &ProblemDetails{
-		Type:       UnauthorizedProblem,
-		Detail:     detail,
-		HTTPStatus: http.StatusForbidden,
-	}
+	return New(CodeUnauthorized).Detail(detail).Build()
 }
 
 // UnsupportedContact returns a ProblemDetails representing an
 // UnsupportedContactProblem
 func UnsupportedContact(detail string) *ProblemDetails {
-	return &ProblemDetails{
-		Type:       UnsupportedContactProblem,
-		Detail:     detail,
-		HTTPStatus: http.StatusBadRequest,
-	}
+	return New(CodeUnsupportedContact).Detail(detail).Build()
 }
 
 // UnsupportedIdentifier returns a ProblemDetails representing an
 // UnsupportedIdentifierProblem
 func UnsupportedIdentifier(detail string, a ...any) *ProblemDetails {
-	return &ProblemDetails{
-		Type:       UnsupportedIdentifierProblem,
-		Detail:     fmt.Sprintf(detail, a...),
-		HTTPStatus: http.StatusBadRequest,
-	}
+	return New(CodeUnsupportedIdentifier).Detail(fmt.Sprintf(detail, a...)).Build()
 }
 
 // Additional helper functions that return variations on MalformedProblem with
@@ -315,52 +241,36 @@ func UnsupportedIdentifier(detail string, a ...any) *ProblemDetails {
 // Canceled returns a ProblemDetails with a MalformedProblem and a 408 Request
 // Timeout status code.
 func Canceled(detail string, a ...any) *ProblemDetails {
-	if len(a) > 0 {
-		detail = fmt.Sprintf(detail, a...)
-	}
-	return &ProblemDetails{
-		Type:       MalformedProblem,
-		Detail:     detail,
-		HTTPStatus: http.StatusRequestTimeout,
-	}
+	return New(CodeCanceled).Detail(detail, a...).Build()
+}
+
+// ClientDeprecated returns a ProblemDetails with an UnauthorizedProblem and a
+// 403 Forbidden status code, for requests from a client version which is no
+// longer accepted.
+func ClientDeprecated(detail string) *ProblemDetails {
+	return New(CodeClientDeprecated).Detail(detail).Build()
 }
 
 // Conflict returns a ProblemDetails with a ConflictProblem and a 409 Conflict
 // status code.
 func Conflict(detail string) *ProblemDetails {
-	return &ProblemDetails{
-		Type:       ConflictProblem,
-		Detail:     detail,
-		HTTPStatus: http.StatusConflict,
-	}
+	return New(CodeConflict).Detail(detail).Build()
 }
 
 // MethodNotAllowed returns a ProblemDetails representing a disallowed HTTP
 // method error.
 func MethodNotAllowed() *ProblemDetails {
-	return &ProblemDetails{
-		Type:       MalformedProblem,
-		Detail:     "Method not allowed",
-		HTTPStatus: http.StatusMethodNotAllowed,
-	}
+	return New(CodeMethodNotAllowed).Detail("Method not allowed").Build()
 }
 
 // NotFound returns a ProblemDetails with a MalformedProblem and a 404 Not Found
 // status code.
 func NotFound(detail string) *ProblemDetails {
-	return &ProblemDetails{
-		Type:       MalformedProblem,
-		Detail:     detail,
-		HTTPStatus: http.StatusNotFound,
-	}
+	return New(CodeNotFound).Detail(detail).Build()
 }
 
 // InvalidProfile returns a ProblemDetails with type InvalidProfile, specified
 // in https://datatracker.ietf.org/doc/draft-aaron-acme-profiles/.
 func InvalidProfile(detail string) *ProblemDetails {
-	return &ProblemDetails{
-		Type:       InvalidProfileProblem,
-		Detail:     detail,
-		HTTPStatus: http.StatusBadRequest,
-	}
+	return New(CodeInvalidProfile).Detail(detail).Build()
 }
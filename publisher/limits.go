@@ -0,0 +1,181 @@
+package publisher
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+)
+
+// LogLimitsConfig limits the submissions made to a single CT log. The zero
+// value imposes no limits.
+type LogLimitsConfig struct {
+	// SubmissionsPerSecond is the rate at which submissions may be made to the
+	// log. If zero, submissions aren't rate limited.
+	SubmissionsPerSecond float64 `validate:"omitempty,min=0"`
+
+	// Burst is the number of submissions which may be made at once before
+	// SubmissionsPerSecond applies. If zero, a burst of one is allowed.
+	Burst int `validate:"omitempty,min=0"`
+
+	// MaxConcurrent is the number of submissions which may be in flight to the
+	// log at once. If zero, the number isn't limited.
+	MaxConcurrent int `validate:"omitempty,min=0"`
+
+	// MaxQueued is the number of submissions which may wait for the rate limit
+	// or for a concurrency slot at once. Submissions beyond it fail
+	// immediately, so that a slow log can't tie up the publisher's capacity to
+	// submit to other logs. If zero, the number isn't limited.
+	MaxQueued int `validate:"omitempty,min=0"`
+}
+
+// LimitsConfig limits the submissions made to each CT log. Every log has its
+// own rate limit and concurrency slots, so one log's slowness never delays
+// submissions to the others.
+type LimitsConfig struct {
+	// Default applies to every log without an override.
+	Default LogLimitsConfig
+
+	// Overrides replaces Default for the logs with the given submission URIs.
+	Overrides map[string]LogLimitsConfig `validate:"omitempty,dive"`
+}
+
+// logLimiter enforces a LogLimitsConfig for a single log.
+type logLimiter struct {
+	uri       string
+	tokens    *rate.Limiter
+	slots     chan struct{}
+	maxQueued int64
+	queued    atomic.Int64
+}
+
+func newLogLimiter(uri string, c LogLimitsConfig) *logLimiter {
+	l := &logLimiter{uri: uri, maxQueued: int64(c.MaxQueued)}
+	if c.SubmissionsPerSecond > 0 {
+		burst := c.Burst
+		if burst == 0 {
+			burst = 1
+		}
+		l.tokens = rate.NewLimiter(rate.Limit(c.SubmissionsPerSecond), burst)
+	}
+	if c.MaxConcurrent > 0 {
+		l.slots = make(chan struct{}, c.MaxConcurrent)
+	}
+	return l
+}
+
+// acquire waits for a concurrency slot and then for the rate limit, and
+// returns a function which releases the slot once the submission is done. It
+// fails immediately if too many submissions are already waiting, and fails
+// early if ctx would expire before the rate limit allows the submission.
+func (l *logLimiter) acquire(ctx context.Context, m *pubMetrics) (func(), error) {
+	if l.tokens == nil && l.slots == nil {
+		return func() {}, nil
+	}
+
+	queued := l.queued.Add(1)
+	if l.maxQueued > 0 && queued > l.maxQueued {
+		l.queued.Add(-1)
+		m.rejections.WithLabelValues(l.uri, "queue_full").Inc()
+		return nil, fmt.Errorf("too many submissions queued for CT log %s", l.uri)
+	}
+	depth := m.queueDepth.WithLabelValues(l.uri)
+	depth.Inc()
+	start := time.Now()
+	defer func() {
+		l.queued.Add(-1)
+		depth.Dec()
+		m.queueWait.WithLabelValues(l.uri).Observe(time.Since(start).Seconds())
+	}()
+
+	release := func() {}
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			m.rejections.WithLabelValues(l.uri, "canceled").Inc()
+			return nil, fmt.Errorf("waiting to submit to CT log %s: %w", l.uri, ctx.Err())
+		}
+		release = func() { <-l.slots }
+	}
+
+	if l.tokens != nil {
+		err := l.tokens.Wait(ctx)
+		if err != nil {
+			release()
+			m.rejections.WithLabelValues(l.uri, "rate_limited").Inc()
+			return nil, fmt.Errorf("waiting to submit to CT log %s: %w", l.uri, err)
+		}
+	}
+	return release, nil
+}
+
+// logLimiters holds a logLimiter for each log the publisher has submitted to,
+// keyed by submission URI.
+type logLimiters struct {
+	sync.Mutex
+	config   LimitsConfig
+	limiters map[string]*logLimiter
+}
+
+// get returns the logLimiter for the log with the given URI, creating it if
+// necessary.
+func (ls *logLimiters) get(uri string) *logLimiter {
+	ls.Lock()
+	defer ls.Unlock()
+	l, ok := ls.limiters[uri]
+	if !ok {
+		c, ok := ls.config.Overrides[uri]
+		if !ok {
+			c = ls.config.Default
+		}
+		l = newLogLimiter(uri, c)
+		ls.limiters[uri] = l
+	}
+	return l
+}
+
+// SetLogLimits configures the limits on submissions to each CT log. It must be
+// called before the publisher starts serving requests.
+func (pub *Impl) SetLogLimits(c LimitsConfig) {
+	pub.limiters.Lock()
+	defer pub.limiters.Unlock()
+	pub.limiters.config = c
+	pub.limiters.limiters = make(map[string]*logLimiter)
+}
+
+func initLimitMetrics(stats prometheus.Registerer) (*prometheus.GaugeVec, *prometheus.HistogramVec, *prometheus.CounterVec) {
+	queueDepth := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ct_submission_queue_depth",
+			Help: "Number of submissions waiting for a CT log's rate limit or concurrency limit",
+		},
+		[]string{"log"},
+	)
+	stats.MustRegister(queueDepth)
+
+	queueWait := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "ct_submission_queue_wait_seconds",
+			Help:    "Time submissions spent waiting for a CT log's rate limit or concurrency limit",
+			Buckets: []float64{0.001, 0.01, 0.05, 0.1, 0.5, 1, 2, 5, 10, 30, 60},
+		},
+		[]string{"log"},
+	)
+	stats.MustRegister(queueWait)
+
+	rejections := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ct_submission_rejections",
+			Help: "Count of submissions failed before reaching a CT log because of its limits, by reason",
+		},
+		[]string{"log", "reason"},
+	)
+	stats.MustRegister(rejections)
+
+	return queueDepth, queueWait, rejections
+}
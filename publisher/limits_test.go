@@ -0,0 +1,139 @@
+package publisher
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/metrics"
+	pubpb "github.com/letsencrypt/boulder/publisher/proto"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestLogLimiterUnlimited(t *testing.T) {
+	m := initMetrics(metrics.NoopRegisterer)
+	l := newLogLimiter("http://log.example.com", LogLimitsConfig{})
+	for range 10 {
+		release, err := l.acquire(context.Background(), m)
+		test.AssertNotError(t, err, "unlimited acquire failed")
+		defer release()
+	}
+}
+
+func TestLogLimiterConcurrency(t *testing.T) {
+	uri := "http://log.example.com"
+	m := initMetrics(metrics.NoopRegisterer)
+	l := newLogLimiter(uri, LogLimitsConfig{MaxConcurrent: 1, MaxQueued: 1})
+
+	release, err := l.acquire(context.Background(), m)
+	test.AssertNotError(t, err, "first acquire failed")
+	test.AssertMetricWithLabelsEquals(t, m.queueDepth, prometheus.Labels{"log": uri}, 0)
+
+	// The second submission waits for the first to finish.
+	acquired := make(chan error)
+	go func() {
+		release, err := l.acquire(context.Background(), m)
+		if err == nil {
+			release()
+		}
+		acquired <- err
+	}()
+	for l.queued.Load() != 1 {
+		time.Sleep(time.Millisecond)
+	}
+	test.AssertMetricWithLabelsEquals(t, m.queueDepth, prometheus.Labels{"log": uri}, 1)
+
+	// The third doesn't fit in the queue.
+	_, err = l.acquire(context.Background(), m)
+	test.AssertError(t, err, "acquire beyond maxQueued succeeded")
+	test.AssertContains(t, err.Error(), "too many submissions queued")
+	test.AssertMetricWithLabelsEquals(t, m.rejections, prometheus.Labels{"log": uri, "reason": "queue_full"}, 1)
+
+	release()
+	test.AssertNotError(t, <-acquired, "queued acquire failed")
+	test.AssertMetricWithLabelsEquals(t, m.queueDepth, prometheus.Labels{"log": uri}, 0)
+
+	// A submission whose context ends while it's waiting fails.
+	release, err = l.acquire(context.Background(), m)
+	test.AssertNotError(t, err, "acquire failed")
+	defer release()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = l.acquire(ctx, m)
+	test.AssertErrorIs(t, err, context.DeadlineExceeded)
+	test.AssertMetricWithLabelsEquals(t, m.rejections, prometheus.Labels{"log": uri, "reason": "canceled"}, 1)
+}
+
+func TestLogLimiterRate(t *testing.T) {
+	uri := "http://log.example.com"
+	m := initMetrics(metrics.NoopRegisterer)
+	l := newLogLimiter(uri, LogLimitsConfig{SubmissionsPerSecond: 0.1, MaxConcurrent: 1})
+
+	release, err := l.acquire(context.Background(), m)
+	test.AssertNotError(t, err, "first acquire failed")
+	release()
+
+	// The next token isn't available for ten seconds, so a submission which
+	// must finish sooner fails without waiting, and gives up its slot.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err = l.acquire(ctx, m)
+	test.AssertError(t, err, "rate limited acquire succeeded")
+	test.AssertMetricWithLabelsEquals(t, m.rejections, prometheus.Labels{"log": uri, "reason": "rate_limited"}, 1)
+	test.AssertEquals(t, len(l.slots), 0)
+}
+
+// TestSubmitLogLimits checks that a log whose limits are exhausted doesn't
+// delay submissions to other logs.
+func TestSubmitLogLimits(t *testing.T) {
+	pub, leaf, k := setup(t)
+
+	pkDER, err := x509.MarshalPKIXPublicKey(&k.PublicKey)
+	test.AssertNotError(t, err, "Failed to marshal key")
+	pkB64 := base64.StdEncoding.EncodeToString(pkDER)
+
+	workingSrv := logSrv(k)
+	defer workingSrv.Close()
+	port, err := getPort(workingSrv.URL)
+	test.AssertNotError(t, err, "Failed to get test server port")
+	workingURI := fmt.Sprintf("http://localhost:%d", port)
+	slowURI := "http://slow.example.com"
+
+	pub.SetLogLimits(LimitsConfig{
+		Default: LogLimitsConfig{MaxConcurrent: 2},
+		Overrides: map[string]LogLimitsConfig{
+			slowURI: {MaxConcurrent: 1, MaxQueued: 1},
+		},
+	})
+	test.AssertEquals(t, cap(pub.limiters.get(workingURI).slots), 2)
+
+	// Occupy the slow log's only slot, as a hanging submission would, and
+	// fill its queue.
+	slow := pub.limiters.get(slowURI)
+	test.AssertEquals(t, cap(slow.slots), 1)
+	slow.slots <- struct{}{}
+	slow.queued.Add(1)
+
+	_, err = pub.SubmitToSingleCTWithResult(context.Background(), &pubpb.Request{
+		LogURL:       slowURI,
+		LogPublicKey: pkB64,
+		Der:          leaf.Raw,
+		Kind:         pubpb.SubmissionType_final,
+	})
+	test.AssertError(t, err, "submission to the saturated log succeeded")
+	test.AssertMetricWithLabelsEquals(t, pub.metrics.rejections, prometheus.Labels{"log": slowURI, "reason": "queue_full"}, 1)
+
+	_, err = pub.SubmitToSingleCTWithResult(context.Background(), &pubpb.Request{
+		LogURL:       workingURI,
+		LogPublicKey: pkB64,
+		Der:          leaf.Raw,
+		Kind:         pubpb.SubmissionType_final,
+	})
+	test.AssertNotError(t, err, "submission to the healthy log failed")
+	test.AssertMetricWithLabelsEquals(t, pub.metrics.queueWait, prometheus.Labels{"log": workingURI}, 1)
+}
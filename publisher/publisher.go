@@ -168,6 +168,9 @@ type pubMetrics struct {
 	submissionLatency *prometheus.HistogramVec
 	probeLatency      *prometheus.HistogramVec
 	errorCount        *prometheus.CounterVec
+	queueDepth        *prometheus.GaugeVec
+	queueWait         *prometheus.HistogramVec
+	rejections        *prometheus.CounterVec
 }
 
 func initMetrics(stats prometheus.Registerer) *pubMetrics {
@@ -200,7 +203,9 @@ func initMetrics(stats prometheus.Registerer) *pubMetrics {
 	)
 	stats.MustRegister(errorCount)
 
-	return &pubMetrics{submissionLatency, probeLatency, errorCount}
+	queueDepth, queueWait, rejections := initLimitMetrics(stats)
+
+	return &pubMetrics{submissionLatency, probeLatency, errorCount, queueDepth, queueWait, rejections}
 }
 
 // Impl defines a Publisher
@@ -210,6 +215,7 @@ type Impl struct {
 	userAgent     string
 	issuerBundles map[issuance.NameID][]ct.ASN1Cert
 	ctLogsCache   logCache
+	limiters      logLimiters
 	metrics       *pubMetrics
 }
 
@@ -229,6 +235,9 @@ func New(
 		ctLogsCache: logCache{
 			logs: make(map[cacheKey]*Log),
 		},
+		limiters: logLimiters{
+			limiters: make(map[string]*logLimiter),
+		},
 		log:     logger,
 		metrics: initMetrics(stats),
 	}
@@ -267,7 +276,13 @@ func (pub *Impl) SubmitToSingleCTWithResult(ctx context.Context, req *pubpb.Requ
 		return nil, err
 	}
 
+	release, err := pub.limiters.get(ctLog.uri).acquire(ctx, pub.metrics)
+	if err != nil {
+		pub.log.Warningf("Not submitting certificate to CT log at %s: %s", ctLog.uri, err)
+		return nil, err
+	}
 	sct, err := pub.singleLogSubmit(ctx, chain, req.Kind, ctLog)
+	release()
 	if err != nil {
 		if core.IsCanceled(err) {
 			return nil, err
@@ -0,0 +1,81 @@
+package ra
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"fmt"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/core/jws"
+	berrors "github.com/letsencrypt/boulder/errors"
+	rapb "github.com/letsencrypt/boulder/ra/proto"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+)
+
+// describeAccountKey returns the type and size of an account key, and the JWS
+// signature algorithm which must be used with it.
+func describeAccountKey(key *jose.JSONWebKey) (*rapb.AccountKeyAlgorithm, error) {
+	alg, err := jws.AlgorithmForKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := &rapb.AccountKeyAlgorithm{Algorithm: string(alg)}
+	switch k := key.Key.(type) {
+	case *rsa.PublicKey:
+		desc.KeyType = "RSA"
+		desc.KeySize = int32(k.N.BitLen())
+	case *ecdsa.PublicKey:
+		desc.KeyType = "ECDSA"
+		desc.KeySize = int32(k.Curve.Params().BitSize)
+	case ed25519.PublicKey:
+		desc.KeyType = "Ed25519"
+		desc.KeySize = 256
+	default:
+		return nil, fmt.Errorf("unexpected account key type %T", key.Key)
+	}
+	return desc, nil
+}
+
+// countAccountKey counts an account key which was just stored, by the JWS
+// algorithm it must be used with, so that operators can see how accounts'
+// algorithms are changing while they plan deprecations. Keys which can't be
+// described are counted as "unknown".
+func (ra *RegistrationAuthorityImpl) countAccountKey(jwk []byte, operation string) {
+	alg := "unknown"
+	var key jose.JSONWebKey
+	err := key.UnmarshalJSON(jwk)
+	if err == nil {
+		desc, err := describeAccountKey(&key)
+		if err == nil {
+			alg = desc.Algorithm
+		}
+	}
+	ra.accountKeyAlgorithms.With(prometheus.Labels{"alg": alg, "operation": operation}).Inc()
+}
+
+// GetAccountKeyAlgorithm describes the key currently associated with an
+// account, and the JWS signature algorithm which requests signed by it must
+// use.
+func (ra *RegistrationAuthorityImpl) GetAccountKeyAlgorithm(ctx context.Context, req *rapb.GetAccountKeyAlgorithmRequest) (*rapb.AccountKeyAlgorithm, error) {
+	if core.IsAnyNilOrZero(req, req.RegistrationID) {
+		return nil, errIncompleteGRPCRequest
+	}
+
+	reg, err := ra.SA.GetRegistration(ctx, &sapb.RegistrationID{Id: req.RegistrationID})
+	if err != nil {
+		return nil, fmt.Errorf("getting account %d: %w", req.RegistrationID, err)
+	}
+
+	var key jose.JSONWebKey
+	err = key.UnmarshalJSON(reg.Key)
+	if err != nil {
+		return nil, berrors.InternalServerError("failed to unmarshal account key: %s", err)
+	}
+	return describeAccountKey(&key)
+}
@@ -0,0 +1,89 @@
+package ra
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/prometheus/client_golang/prometheus"
+
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	berrors "github.com/letsencrypt/boulder/errors"
+	rapb "github.com/letsencrypt/boulder/ra/proto"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestDescribeAccountKey(t *testing.T) {
+	t.Parallel()
+
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	ed, _, err := ed25519.GenerateKey(rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+
+	testCases := []struct {
+		key     interface{}
+		alg     string
+		keyType string
+		keySize int32
+	}{
+		{AccountKeyA.Key, "RS256", "RSA", 2048},
+		{p384.Public(), "ES384", "ECDSA", 384},
+		{ed, "EdDSA", "Ed25519", 256},
+	}
+	for _, tc := range testCases {
+		desc, err := describeAccountKey(&jose.JSONWebKey{Key: tc.key})
+		test.AssertNotError(t, err, "describing key")
+		test.AssertEquals(t, desc.Algorithm, tc.alg)
+		test.AssertEquals(t, desc.KeyType, tc.keyType)
+		test.AssertEquals(t, desc.KeySize, tc.keySize)
+	}
+
+	p224, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	_, err = describeAccountKey(&jose.JSONWebKey{Key: p224.Public()})
+	test.AssertErrorIs(t, err, berrors.BadPublicKey)
+}
+
+func TestGetAccountKeyAlgorithm(t *testing.T) {
+	_, _, ra, _, _, cleanUp := initAuthoritiesWithMemorySA(t)
+	defer cleanUp()
+
+	_, err := ra.GetAccountKeyAlgorithm(context.Background(), &rapb.GetAccountKeyAlgorithmRequest{})
+	test.AssertError(t, err, "request without an account ID succeeded")
+	test.Assert(t, errors.Is(err, errIncompleteGRPCRequest), "wrong error for an incomplete request")
+
+	desc, err := ra.GetAccountKeyAlgorithm(context.Background(), &rapb.GetAccountKeyAlgorithmRequest{RegistrationID: Registration.Id})
+	test.AssertNotError(t, err, "GetAccountKeyAlgorithm failed")
+	test.AssertEquals(t, desc.Algorithm, "RS256")
+	test.AssertEquals(t, desc.KeyType, "RSA")
+	test.AssertEquals(t, desc.KeySize, int32(2048))
+
+	_, err = ra.GetAccountKeyAlgorithm(context.Background(), &rapb.GetAccountKeyAlgorithmRequest{RegistrationID: 404})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+}
+
+func TestAccountKeyAlgorithmsMetric(t *testing.T) {
+	_, _, ra, _, _, cleanUp := initAuthoritiesWithMemorySA(t)
+	defer cleanUp()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	jwk, err := (&jose.JSONWebKey{Key: key.Public()}).MarshalJSON()
+	test.AssertNotError(t, err, "marshalling key")
+
+	reg, err := ra.NewRegistration(context.Background(), &corepb.Registration{Key: jwk})
+	test.AssertNotError(t, err, "NewRegistration failed")
+	test.AssertMetricWithLabelsEquals(t, ra.accountKeyAlgorithms, prometheus.Labels{"alg": "ES256", "operation": "new-registration"}, 1)
+
+	newKey, err := AccountKeyC.MarshalJSON()
+	test.AssertNotError(t, err, "marshalling key")
+	_, err = ra.UpdateRegistrationKey(context.Background(), &rapb.UpdateRegistrationKeyRequest{RegistrationID: reg.Id, Jwk: newKey})
+	test.AssertNotError(t, err, "UpdateRegistrationKey failed")
+	test.AssertMetricWithLabelsEquals(t, ra.accountKeyAlgorithms, prometheus.Labels{"alg": "RS256", "operation": "key-change"}, 1)
+}
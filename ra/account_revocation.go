@@ -0,0 +1,263 @@
+package ra
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/letsencrypt/boulder/config"
+	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/issuance"
+	"github.com/letsencrypt/boulder/revocation"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+)
+
+const (
+	// accountRevocationBatchSize is the number of certificates revoked between
+	// each update of an account's recorded revocation progress.
+	accountRevocationBatchSize = 100
+
+	// accountRevocationBatchTimeout bounds the time taken to revoke one batch.
+	accountRevocationBatchTimeout = 5 * time.Minute
+)
+
+// AccountRevocationConfig configures the background revocation of the
+// certificates of deactivated accounts which requested it.
+type AccountRevocationConfig struct {
+	// Interval is how often the RA looks for deactivated accounts whose
+	// certificates are to be revoked. If zero, this RA doesn't revoke them, and
+	// they're left for other RAs to.
+	Interval config.Duration `validate:"-"`
+
+	// LeaseDuration is how long the RA may spend revoking one account's
+	// certificates before another RA may take over. If it runs out, the
+	// revocation is started over by whichever RA leases it next. Defaults to
+	// 1h, and must be longer than the 5m taken to revoke each batch of 100.
+	LeaseDuration config.Duration `validate:"-"`
+}
+
+// accountRevocationEvent is logged once the certificates of a deactivated
+// account have all been tried.
+type accountRevocationEvent struct {
+	RegistrationID int64
+	Total          int64
+	Revoked        int64
+	Failed         int64
+}
+
+// accountRevoker revokes the certificates of deactivated accounts in the
+// background. The SA records each revocation request along with the
+// deactivation, and the revoker leases them one at a time, so that a
+// revocation interrupted by a restart is resumed by whichever RA leases it
+// next.
+type accountRevoker struct {
+	ra            *RegistrationAuthorityImpl
+	interval      time.Duration
+	leaseDuration time.Duration
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// EnableAccountRevocation starts revoking the certificates of deactivated
+// accounts in the background. It must be called after the RA's SA client has
+// been set. If c.Interval is zero this is a no-op.
+func (ra *RegistrationAuthorityImpl) EnableAccountRevocation(c AccountRevocationConfig) error {
+	if c.Interval.Duration <= 0 {
+		return nil
+	}
+	leaseDuration := c.LeaseDuration.Duration
+	if leaseDuration == 0 {
+		leaseDuration = time.Hour
+	}
+	if leaseDuration <= accountRevocationBatchTimeout {
+		return fmt.Errorf("account revocation lease duration %s must be longer than %s", leaseDuration, accountRevocationBatchTimeout)
+	}
+
+	ra.accountRevoker = &accountRevoker{
+		ra:            ra,
+		interval:      c.Interval.Duration,
+		leaseDuration: leaseDuration,
+		stop:          make(chan struct{}),
+	}
+	ra.accountRevoker.wg.Add(1)
+	go ra.accountRevoker.run()
+	return nil
+}
+
+// run revokes the certificates of every deactivated account waiting for it,
+// then waits for the interval before looking again, until shutdown.
+func (r *accountRevoker) run() {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		for r.running() {
+			found, err := r.revokeNext(context.Background())
+			if err != nil {
+				r.ra.log.AuditErrf("Failed to revoke certificates of deactivated account: %s", err)
+			}
+			if !found || err != nil {
+				break
+			}
+		}
+
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// running returns false once shutdown has begun.
+func (r *accountRevoker) running() bool {
+	select {
+	case <-r.stop:
+		return false
+	default:
+		return true
+	}
+}
+
+// revokeNext leases the revocation of the certificates of one deactivated
+// account and revokes them for cessationOfOperation, since the subscriber is
+// no longer operating them. It returns false if there was no revocation to
+// lease.
+//
+// Each lease starts the account's revocation over, recording its progress
+// from scratch: certificates which the previous lease already revoked are
+// cheaply counted as revoked again.
+func (r *accountRevoker) revokeNext(ctx context.Context) (bool, error) {
+	leasedUntil := r.ra.clk.Now().Add(r.leaseDuration)
+	progress, err := r.ra.SA.LeaseAccountRevocation(ctx, &sapb.LeaseAccountRevocationRequest{
+		Until: timestamppb.New(leasedUntil),
+	})
+	if err != nil {
+		if errors.Is(err, berrors.NotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("leasing certificate revocation: %w", err)
+	}
+
+	// The serials are collected after the account is deactivated, so that no
+	// more certificates can be issued to it, and before any of them are
+	// revoked, so that the SA's stream isn't held open for the duration of the
+	// revocations.
+	stream, err := r.ra.SA.GetSerialsByAccount(ctx, &sapb.RegistrationID{Id: progress.RegistrationID})
+	if err != nil {
+		return true, fmt.Errorf("getting serials for account %d: %w", progress.RegistrationID, err)
+	}
+	var serials []string
+	for {
+		serial, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return true, fmt.Errorf("streaming serials for account %d: %w", progress.RegistrationID, err)
+		}
+		serials = append(serials, serial.Serial)
+	}
+
+	progress.Total = int64(len(serials))
+	progress.Revoked = 0
+	progress.Failed = 0
+	if len(serials) == 0 {
+		progress.Completed = timestamppb.New(r.ra.clk.Now())
+	}
+	_, err = r.ra.SA.SetAccountRevocation(ctx, progress)
+	if err != nil {
+		return true, fmt.Errorf("recording certificate revocation of account %d: %w", progress.RegistrationID, err)
+	}
+	if len(serials) == 0 {
+		return true, nil
+	}
+
+	r.revokeAccountCertificates(ctx, progress, serials, leasedUntil)
+	return true, nil
+}
+
+// revokeAccountCertificates revokes each of the given serials in batches,
+// updating and recording progress after each batch. It stops early, leaving
+// the revocation incomplete, if the RA is shutting down or the next batch
+// might not finish before the lease runs out.
+func (r *accountRevoker) revokeAccountCertificates(ctx context.Context, progress *sapb.AccountRevocation, serials []string, leasedUntil time.Time) {
+	for start := 0; start < len(serials); start += accountRevocationBatchSize {
+		if !r.running() || r.ra.clk.Now().Add(accountRevocationBatchTimeout).After(leasedUntil) {
+			r.ra.log.Infof("Stopped revoking certificates of deactivated account %d after %d of %d", progress.RegistrationID, start, len(serials))
+			return
+		}
+
+		batch := serials[start:min(start+accountRevocationBatchSize, len(serials))]
+		batchCtx, cancel := context.WithTimeout(ctx, accountRevocationBatchTimeout)
+		for _, serial := range batch {
+			err := r.ra.revokeForAccountDeactivation(batchCtx, serial)
+			if err != nil {
+				progress.Failed++
+				r.ra.log.Warningf("Failed to revoke certificate %s of deactivated account %d: %s", serial, progress.RegistrationID, err)
+				continue
+			}
+			progress.Revoked++
+		}
+		cancel()
+
+		if start+len(batch) == len(serials) {
+			progress.Completed = timestamppb.New(r.ra.clk.Now())
+		}
+		_, err := r.ra.SA.SetAccountRevocation(ctx, progress)
+		if err != nil {
+			r.ra.log.AuditErrf("Failed to record certificate revocation progress of deactivated account %d: %s", progress.RegistrationID, err)
+		}
+	}
+
+	r.ra.log.AuditObject("Deactivated account certificates revoked:", accountRevocationEvent{
+		RegistrationID: progress.RegistrationID,
+		Total:          progress.Total,
+		Revoked:        progress.Revoked,
+		Failed:         progress.Failed,
+	})
+}
+
+// shutdown stops the revoker, waiting for the batch in progress, if any, to
+// finish. The account's revocation is left to be resumed by the next lease.
+func (r *accountRevoker) shutdown() {
+	close(r.stop)
+	r.wg.Wait()
+}
+
+// revokeForAccountDeactivation revokes the certificate with the given serial
+// for cessationOfOperation. A certificate which was already revoked counts as
+// revoked.
+func (ra *RegistrationAuthorityImpl) revokeForAccountDeactivation(ctx context.Context, serial string) error {
+	// Get the precertificate, rather than the final certificate, since it has
+	// the same serial, issuer and CRL shard, and is stored even if the final
+	// certificate never was.
+	certPB, err := ra.SA.GetLintPrecertificate(ctx, &sapb.Serial{Serial: serial})
+	if err != nil {
+		return fmt.Errorf("getting precertificate: %w", err)
+	}
+	cert, err := x509.ParseCertificate(certPB.Der)
+	if err != nil {
+		return fmt.Errorf("parsing precertificate: %w", err)
+	}
+
+	err = ra.revokeCertificate(ctx, cert, revocation.Reason(ocsp.CessationOfOperation))
+	if errors.Is(err, berrors.AlreadyRevoked) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Don't count purger errors as failures to revoke.
+	_ = ra.purgeOCSPCache(ctx, cert, issuance.IssuerNameID(cert))
+	return nil
+}
@@ -0,0 +1,187 @@
+package ra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/core"
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/mocks"
+	rapb "github.com/letsencrypt/boulder/ra/proto"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// mockSAAccountRevocation is a mockSARevocation which deactivates any
+// account, leases the revocations queued in it, returns a fixed set of serials
+// for each account, and records each update to its revocation progress.
+type mockSAAccountRevocation struct {
+	*mockSARevocation
+	deactivations []*sapb.DeactivateRegistrationRequest
+	queued        []*sapb.AccountRevocation
+	serials       []string
+	progress      []*sapb.AccountRevocation
+	serialsErr    error
+}
+
+func (msa *mockSAAccountRevocation) DeactivateRegistration(_ context.Context, req *sapb.DeactivateRegistrationRequest, _ ...grpc.CallOption) (*corepb.Registration, error) {
+	msa.deactivations = append(msa.deactivations, req)
+	return &corepb.Registration{Id: req.RegistrationID, Status: string(core.StatusDeactivated)}, nil
+}
+
+func (msa *mockSAAccountRevocation) LeaseAccountRevocation(_ context.Context, _ *sapb.LeaseAccountRevocationRequest, _ ...grpc.CallOption) (*sapb.AccountRevocation, error) {
+	if len(msa.queued) == 0 {
+		return nil, berrors.NotFoundError("no certificate revocation to lease")
+	}
+	leased := msa.queued[0]
+	msa.queued = msa.queued[1:]
+	return leased, nil
+}
+
+func (msa *mockSAAccountRevocation) GetSerialsByAccount(_ context.Context, _ *sapb.RegistrationID, _ ...grpc.CallOption) (grpc.ServerStreamingClient[sapb.Serial], error) {
+	if msa.serialsErr != nil {
+		return nil, msa.serialsErr
+	}
+	results := make([]*sapb.Serial, len(msa.serials))
+	for i, serial := range msa.serials {
+		results[i] = &sapb.Serial{Serial: serial}
+	}
+	return &mocks.ServerStreamClient[sapb.Serial]{Results: results}, nil
+}
+
+func (msa *mockSAAccountRevocation) SetAccountRevocation(_ context.Context, req *sapb.AccountRevocation, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	msa.progress = append(msa.progress, proto.Clone(req).(*sapb.AccountRevocation))
+	return &emptypb.Empty{}, nil
+}
+
+func TestDeactivateRegistrationRevokingCertificates(t *testing.T) {
+	_, _, ra, _, _, cleanUp := initAuthoritiesWithMemorySA(t)
+	defer cleanUp()
+
+	mockSA := &mockSAAccountRevocation{}
+	ra.SA = mockSA
+
+	// The request to revoke the account's certificates is passed to the SA,
+	// to be recorded along with the deactivation, and nothing is revoked yet.
+	_, err := ra.DeactivateRegistration(context.Background(), &rapb.DeactivateRegistrationRequest{RegistrationID: 1})
+	test.AssertNotError(t, err, "DeactivateRegistration failed")
+	_, err = ra.DeactivateRegistration(context.Background(), &rapb.DeactivateRegistrationRequest{
+		RegistrationID:     2,
+		RevokeCertificates: true,
+	})
+	test.AssertNotError(t, err, "DeactivateRegistration failed")
+	test.AssertEquals(t, len(mockSA.deactivations), 2)
+	test.AssertEquals(t, mockSA.deactivations[0].RevokeCertificates, false)
+	test.AssertEquals(t, mockSA.deactivations[1].RegistrationID, int64(2))
+	test.AssertEquals(t, mockSA.deactivations[1].RevokeCertificates, true)
+	test.AssertEquals(t, len(mockSA.progress), 0)
+}
+
+func TestAccountRevoker(t *testing.T) {
+	_, _, ra, _, clk, cleanUp := initAuthoritiesWithMemorySA(t)
+	defer cleanUp()
+
+	serial, cert := test.ThrowAwayCert(t, clk)
+	mockSA := &mockSAAccountRevocation{
+		mockSARevocation: newMockSARevocation(cert),
+		serials:          []string{serial, "unknown"},
+	}
+	ra.SA = mockSA
+	revoker := &accountRevoker{ra: ra, leaseDuration: time.Hour, stop: make(chan struct{})}
+	requested := timestamppb.New(clk.Now())
+
+	// Nothing is revoked until a revocation is leased.
+	found, err := revoker.revokeNext(context.Background())
+	test.AssertNotError(t, err, "revokeNext failed")
+	test.Assert(t, !found, "revokeNext shouldn't have found a revocation")
+
+	// The total is recorded before the certificates are revoked, and the
+	// progress recorded again once they've all been tried.
+	mockSA.queued = []*sapb.AccountRevocation{{RegistrationID: 1, Requested: requested}}
+	found, err = revoker.revokeNext(context.Background())
+	test.AssertNotError(t, err, "revokeNext failed")
+	test.Assert(t, found, "revokeNext should have found a revocation")
+	test.AssertEquals(t, len(mockSA.progress), 2)
+	test.AssertEquals(t, mockSA.progress[0].RegistrationID, int64(1))
+	test.AssertEquals(t, mockSA.progress[0].Total, int64(2))
+	test.AssertEquals(t, mockSA.progress[0].Revoked, int64(0))
+	test.AssertBoxedNil(t, mockSA.progress[0].Completed, "revocation shouldn't have completed")
+	test.AssertEquals(t, mockSA.progress[1].Revoked, int64(1))
+	test.AssertEquals(t, mockSA.progress[1].Failed, int64(1))
+	test.AssertEquals(t, mockSA.progress[1].Completed.AsTime(), clk.Now())
+	test.AssertEquals(t, mockSA.revoked[serial].RevokedReason, int64(ocsp.CessationOfOperation))
+
+	// A revocation interrupted by a restart is started over by the next lease,
+	// and a certificate which was already revoked counts as revoked.
+	mockSA.progress = nil
+	mockSA.serials = []string{serial}
+	mockSA.queued = []*sapb.AccountRevocation{{RegistrationID: 1, Requested: requested, Total: 2, Revoked: 1}}
+	_, err = revoker.revokeNext(context.Background())
+	test.AssertNotError(t, err, "revokeNext failed")
+	test.AssertEquals(t, len(mockSA.progress), 2)
+	test.AssertEquals(t, mockSA.progress[1].Total, int64(1))
+	test.AssertEquals(t, mockSA.progress[1].Revoked, int64(1))
+	test.AssertEquals(t, mockSA.progress[1].Failed, int64(0))
+	test.AssertEquals(t, mockSA.progress[1].Requested.AsTime(), requested.AsTime())
+
+	// An account without certificates has its revocation completed at once.
+	mockSA.progress = nil
+	mockSA.serials = nil
+	mockSA.queued = []*sapb.AccountRevocation{{RegistrationID: 1, Requested: requested}}
+	_, err = revoker.revokeNext(context.Background())
+	test.AssertNotError(t, err, "revokeNext failed")
+	test.AssertEquals(t, len(mockSA.progress), 1)
+	test.AssertEquals(t, mockSA.progress[0].Total, int64(0))
+	test.AssertEquals(t, mockSA.progress[0].Completed.AsTime(), clk.Now())
+
+	// Failing to find the account's certificates leaves its revocation to the
+	// next lease.
+	mockSA.progress = nil
+	mockSA.serialsErr = errors.New("SA is down")
+	mockSA.queued = []*sapb.AccountRevocation{{RegistrationID: 1, Requested: requested}}
+	_, err = revoker.revokeNext(context.Background())
+	test.AssertError(t, err, "revokeNext should have failed")
+	test.AssertContains(t, err.Error(), "SA is down")
+	test.AssertEquals(t, len(mockSA.progress), 0)
+
+	// Once shutdown has begun, a leased revocation is left incomplete.
+	mockSA.serialsErr = nil
+	mockSA.serials = []string{serial}
+	mockSA.queued = []*sapb.AccountRevocation{{RegistrationID: 1, Requested: requested}}
+	close(revoker.stop)
+	_, err = revoker.revokeNext(context.Background())
+	test.AssertNotError(t, err, "revokeNext failed")
+	test.AssertEquals(t, len(mockSA.progress), 1)
+	test.AssertBoxedNil(t, mockSA.progress[0].Completed, "revocation shouldn't have completed")
+}
+
+func TestEnableAccountRevocation(t *testing.T) {
+	_, _, ra, _, _, cleanUp := initAuthoritiesWithMemorySA(t)
+	defer cleanUp()
+
+	err := ra.EnableAccountRevocation(AccountRevocationConfig{})
+	test.AssertNotError(t, err, "EnableAccountRevocation failed")
+	test.AssertBoxedNil(t, ra.accountRevoker, "revoker shouldn't run without an interval")
+
+	err = ra.EnableAccountRevocation(AccountRevocationConfig{
+		Interval:      config.Duration{Duration: time.Minute},
+		LeaseDuration: config.Duration{Duration: time.Minute},
+	})
+	test.AssertError(t, err, "lease shorter than a batch should be rejected")
+
+	ra.SA = &mockSAAccountRevocation{}
+	err = ra.EnableAccountRevocation(AccountRevocationConfig{Interval: config.Duration{Duration: time.Minute}})
+	test.AssertNotError(t, err, "EnableAccountRevocation failed")
+	test.AssertEquals(t, ra.accountRevoker.leaseDuration, time.Hour)
+	ra.Drain()
+}
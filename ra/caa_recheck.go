@@ -0,0 +1,261 @@
+package ra
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/identifier"
+	blog "github.com/letsencrypt/boulder/log"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+	vapb "github.com/letsencrypt/boulder/va/proto"
+)
+
+// CAARecheckConfig configures the background CAA recheck scheduler. Rechecks
+// are recorded with the SA, which must have the authzCAARechecks table.
+type CAARecheckConfig struct {
+	// Workers is the number of goroutines performing background CAA rechecks.
+	// If zero, background rechecking is disabled and all CAA rechecks are
+	// performed synchronously at finalize time.
+	Workers int `validate:"min=0"`
+
+	// QueueSize is the maximum number of authorizations which may be waiting
+	// for a background CAA recheck. When the queue is full, newly scheduled
+	// authorizations are dropped and will be rechecked at finalize time.
+	QueueSize int `validate:"required_with=Workers"`
+
+	// Lookahead is how far ahead of the 7-hour CAA reuse window an
+	// authorization must be to be scheduled for a background recheck. For
+	// example, with a Lookahead of 2h, an authorization reused by a new order
+	// whose CAA check is more than 5 hours old will be rechecked in the
+	// background so that finalize doesn't have to.
+	Lookahead config.Duration `validate:"-"`
+
+	// Timeout is the deadline for each individual background CAA recheck.
+	Timeout config.Duration `validate:"-"`
+}
+
+// caaRecheckScheduler refreshes CAA state in the background for authorizations
+// whose CAA checks are about to become too old to be reused at finalize time.
+// Successful rechecks are recorded with the SA, so that checkAuthorizationsCAA
+// can skip the synchronous lookup no matter which RA instance handles the
+// finalize request. Failed rechecks are not recorded: finalize will perform its
+// own recheck and return a proper error to the client.
+type caaRecheckScheduler struct {
+	caa       vapb.CAAClient
+	sa        sapb.StorageAuthorityClient
+	clk       clock.Clock
+	log       blog.Logger
+	lookahead time.Duration
+	timeout   time.Duration
+
+	queue chan *core.Authorization
+	stop  chan struct{}
+	wg    sync.WaitGroup
+
+	mu sync.Mutex
+	// pending holds the IDs of authorizations which are queued or in flight,
+	// to avoid scheduling duplicate rechecks for the same authorization.
+	pending map[string]struct{}
+
+	scheduled *prometheus.CounterVec
+}
+
+func newCAARecheckScheduler(caa vapb.CAAClient, sa sapb.StorageAuthorityClient, clk clock.Clock, logger blog.Logger, stats prometheus.Registerer, c CAARecheckConfig) *caaRecheckScheduler {
+	scheduled := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "caa_recheck_scheduled",
+		Help: "A counter of background CAA rechecks, labeled by result=[queued|dropped|skipped|success|failure]",
+	}, []string{"result"})
+	stats.MustRegister(scheduled)
+
+	timeout := c.Timeout.Duration
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	s := &caaRecheckScheduler{
+		caa:       caa,
+		sa:        sa,
+		clk:       clk,
+		log:       logger,
+		lookahead: c.Lookahead.Duration,
+		timeout:   timeout,
+		queue:     make(chan *core.Authorization, c.QueueSize),
+		stop:      make(chan struct{}),
+		pending:   make(map[string]struct{}),
+		scheduled: scheduled,
+	}
+	for range c.Workers {
+		s.wg.Add(1)
+		go s.work()
+	}
+	return s
+}
+
+// EnableCAARecheckScheduler starts the background CAA recheck scheduler. It
+// must be called after the RA's VA clients and SA have been set. If c.Workers
+// is zero this is a no-op.
+func (ra *RegistrationAuthorityImpl) EnableCAARecheckScheduler(stats prometheus.Registerer, c CAARecheckConfig) {
+	if c.Workers <= 0 {
+		return
+	}
+	ra.caaRechecker = newCAARecheckScheduler(ra.VA.CAAClient, ra.SA, ra.clk, ra.log, stats, c)
+}
+
+// needsRecheckSoon returns true if the given valid DNS authorization's CAA
+// check at validation time will be too old to reuse within the scheduler's
+// lookahead window. It doesn't consult the SA, so it may return true for an
+// authorization which has already been rechecked; the recheck itself skips
+// those.
+func (s *caaRecheckScheduler) needsRecheckSoon(authz *core.Authorization, now time.Time) bool {
+	if authz.Status != core.StatusValid || authz.Identifier.Type != identifier.TypeDNS {
+		return false
+	}
+	if len(authz.Challenges) != 1 || authz.Challenges[0].Validated == nil {
+		return false
+	}
+	return authz.Challenges[0].Validated.Before(now.Add(caaRecheckDuration).Add(s.lookahead))
+}
+
+// schedule queues each of the given authorizations whose CAA check is close to
+// becoming stale for a background recheck. It never blocks: if the queue is
+// full, the authorization is dropped and will be rechecked at finalize time.
+func (s *caaRecheckScheduler) schedule(authzs []*core.Authorization) {
+	now := s.clk.Now()
+	for _, authz := range authzs {
+		if !s.needsRecheckSoon(authz, now) {
+			continue
+		}
+		s.mu.Lock()
+		_, inflight := s.pending[authz.ID]
+		if !inflight {
+			s.pending[authz.ID] = struct{}{}
+		}
+		s.mu.Unlock()
+		if inflight {
+			continue
+		}
+
+		select {
+		case <-s.stop:
+			return
+		case s.queue <- authz:
+			s.scheduled.WithLabelValues("queued").Inc()
+		default:
+			s.mu.Lock()
+			delete(s.pending, authz.ID)
+			s.mu.Unlock()
+			s.scheduled.WithLabelValues("dropped").Inc()
+		}
+	}
+}
+
+// lastRecheck returns the time of the given authorization's most recent
+// successful background CAA recheck, as recorded with the SA, or the zero time
+// if it has never been rechecked.
+func (s *caaRecheckScheduler) lastRecheck(ctx context.Context, authzID string) (time.Time, error) {
+	id, err := strconv.ParseInt(authzID, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	resp, err := s.sa.GetAuthzCAARecheck(ctx, &sapb.AuthorizationID2{Id: id})
+	if err != nil {
+		return time.Time{}, err
+	}
+	if resp.Rechecked == nil {
+		return time.Time{}, nil
+	}
+	return resp.Rechecked.AsTime(), nil
+}
+
+// freshSince returns true if the given authorization has had a successful
+// background CAA recheck after the given time. If the recheck time can't be
+// retrieved, it returns false, so that the caller performs its own recheck.
+func (s *caaRecheckScheduler) freshSince(ctx context.Context, authzID string, after time.Time) bool {
+	rechecked, err := s.lastRecheck(ctx, authzID)
+	if err != nil {
+		s.log.Warningf("Getting background CAA recheck of authorization ID %s: %s", authzID, err)
+		return false
+	}
+	return rechecked.After(after)
+}
+
+func (s *caaRecheckScheduler) work() {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case authz := <-s.queue:
+			s.recheck(authz)
+		}
+	}
+}
+
+func (s *caaRecheckScheduler) recheck(authz *core.Authorization) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.pending, authz.ID)
+		s.mu.Unlock()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	// Another RA instance may already have rechecked this authorization.
+	now := s.clk.Now()
+	rechecked, err := s.lastRecheck(ctx, authz.ID)
+	if err != nil {
+		s.log.Warningf("Getting background CAA recheck of authorization ID %s: %s", authz.ID, err)
+	} else if !rechecked.Before(now.Add(caaRecheckDuration).Add(s.lookahead)) {
+		s.scheduled.WithLabelValues("skipped").Inc()
+		return
+	}
+
+	// Take the time before performing the lookup, so that the recorded time is
+	// never later than the time at which the CAA records were actually checked.
+	checkedAt := s.clk.Now()
+	resp, err := s.caa.DoCAA(ctx, &vapb.IsCAAValidRequest{
+		Identifier:       authz.Identifier.ToProto(),
+		ValidationMethod: string(authz.Challenges[0].Type),
+		AccountURIID:     authz.RegistrationID,
+	})
+	if err != nil {
+		s.log.Warningf("Background CAA recheck for authorization ID %s (%s): %s", authz.ID, authz.Identifier.Value, err)
+		s.scheduled.WithLabelValues("failure").Inc()
+		return
+	}
+	if resp.Problem != nil {
+		s.log.Infof("Background CAA recheck for authorization ID %s (%s) failed: %s", authz.ID, authz.Identifier.Value, resp.Problem.Detail)
+		s.scheduled.WithLabelValues("failure").Inc()
+		return
+	}
+
+	id, err := strconv.ParseInt(authz.ID, 10, 64)
+	if err == nil {
+		_, err = s.sa.SetAuthzCAARecheck(ctx, &sapb.SetAuthzCAARecheckRequest{
+			Id:        id,
+			Rechecked: timestamppb.New(checkedAt),
+		})
+	}
+	if err != nil {
+		s.log.Warningf("Recording background CAA recheck for authorization ID %s (%s): %s", authz.ID, authz.Identifier.Value, err)
+		s.scheduled.WithLabelValues("failure").Inc()
+		return
+	}
+	s.scheduled.WithLabelValues("success").Inc()
+}
+
+// shutdown stops all background workers. Queued rechecks which have not yet
+// started are abandoned; they will be performed at finalize time if needed.
+func (s *caaRecheckScheduler) shutdown() {
+	close(s.stop)
+	s.wg.Wait()
+}
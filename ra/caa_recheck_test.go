@@ -0,0 +1,160 @@
+package ra
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/identifier"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+	"github.com/letsencrypt/boulder/test"
+	isa "github.com/letsencrypt/boulder/test/inmem/sa"
+	"github.com/letsencrypt/boulder/va"
+)
+
+func makeValidatedAuthz(id string, ident identifier.ACMEIdentifier, validated time.Time) *core.Authorization {
+	expires := validated.Add(24 * time.Hour)
+	return &core.Authorization{
+		ID:         id,
+		Identifier: ident,
+		Status:     core.StatusValid,
+		Expires:    &expires,
+		Challenges: []core.Challenge{
+			{
+				Status:    core.StatusValid,
+				Type:      core.ChallengeTypeHTTP01,
+				Token:     "exampleToken",
+				Validated: &validated,
+			},
+		},
+	}
+}
+
+func TestCAARecheckSchedulerNeedsRecheckSoon(t *testing.T) {
+	fc := clock.NewFake()
+	fc.Set(time.Date(2020, 3, 4, 5, 0, 0, 0, time.UTC))
+	sa := isa.SA{Impl: isa.NewMemoryStorageAuthority(fc)}
+	s := newCAARecheckScheduler(&noopCAA{}, sa, fc, blog.NewMock(), metrics.NoopRegisterer, CAARecheckConfig{
+		QueueSize: 1,
+		Lookahead: config.Duration{Duration: 2 * time.Hour},
+	})
+	defer s.shutdown()
+
+	// Validated one hour ago: nowhere near the 7 hour recheck window.
+	recent := makeValidatedAuthz("1", identifier.NewDNS("recent.com"), fc.Now().Add(-1*time.Hour))
+	test.Assert(t, !s.needsRecheckSoon(recent, fc.Now()), "recent authz should not need a recheck")
+
+	// Validated six hours ago: within the 2 hour lookahead of the window.
+	soon := makeValidatedAuthz("2", identifier.NewDNS("soon.com"), fc.Now().Add(-6*time.Hour))
+	test.Assert(t, s.needsRecheckSoon(soon, fc.Now()), "soon-to-be-stale authz should need a recheck")
+
+	// IP address identifiers are never subject to CAA.
+	ip := makeValidatedAuthz("3", identifier.NewIP(netip.MustParseAddr("127.0.0.1")), fc.Now().Add(-6*time.Hour))
+	test.Assert(t, !s.needsRecheckSoon(ip, fc.Now()), "IP authz should not need a recheck")
+}
+
+func TestCAARecheckSchedulerSchedule(t *testing.T) {
+	fc := clock.NewFake()
+	fc.Set(time.Date(2020, 3, 4, 5, 0, 0, 0, time.UTC))
+	recorder := &caaRecorder{names: make(map[string]bool)}
+	sa := isa.SA{Impl: isa.NewMemoryStorageAuthority(fc)}
+	s := newCAARecheckScheduler(recorder, sa, fc, blog.NewMock(), metrics.NoopRegisterer, CAARecheckConfig{
+		QueueSize: 10,
+		Lookahead: config.Duration{Duration: 2 * time.Hour},
+	})
+	defer s.shutdown()
+
+	s.schedule([]*core.Authorization{
+		makeValidatedAuthz("1", identifier.NewDNS("recent.com"), fc.Now().Add(-1*time.Hour)),
+		makeValidatedAuthz("2", identifier.NewDNS("soon.com"), fc.Now().Add(-6*time.Hour)),
+		// Scheduling the same authorization twice is deduplicated.
+		makeValidatedAuthz("2", identifier.NewDNS("soon.com"), fc.Now().Add(-6*time.Hour)),
+	})
+	test.AssertEquals(t, len(s.queue), 1)
+	test.AssertMetricWithLabelsEquals(t, s.scheduled, map[string]string{"result": "queued"}, 1)
+
+	// Run the queued recheck synchronously rather than starting a worker.
+	s.recheck(<-s.queue)
+	test.Assert(t, recorder.names["soon.com"], "expected CAA recheck for soon.com")
+	test.Assert(t, !recorder.names["recent.com"], "unexpected CAA recheck for recent.com")
+	test.Assert(t, s.freshSince(ctx, "2", fc.Now().Add(caaRecheckDuration)), "expected soon.com to be fresh")
+	test.Assert(t, !s.freshSince(ctx, "1", fc.Now().Add(caaRecheckDuration)), "expected recent.com to not be recorded")
+	test.AssertMetricWithLabelsEquals(t, s.scheduled, map[string]string{"result": "success"}, 1)
+
+	// The recheck is recorded with the SA, so a recheck scheduled again, by
+	// this or any other RA, is skipped.
+	delete(recorder.names, "soon.com")
+	s.schedule([]*core.Authorization{
+		makeValidatedAuthz("2", identifier.NewDNS("soon.com"), fc.Now().Add(-6*time.Hour)),
+	})
+	s.recheck(<-s.queue)
+	test.Assert(t, !recorder.names["soon.com"], "unexpected second CAA recheck for soon.com")
+	test.AssertMetricWithLabelsEquals(t, s.scheduled, map[string]string{"result": "skipped"}, 1)
+}
+
+func TestCAARecheckSchedulerDropsWhenFull(t *testing.T) {
+	fc := clock.NewFake()
+	s := newCAARecheckScheduler(&noopCAA{}, isa.SA{Impl: isa.NewMemoryStorageAuthority(fc)}, fc, blog.NewMock(), metrics.NoopRegisterer, CAARecheckConfig{
+		QueueSize: 1,
+	})
+	defer s.shutdown()
+
+	s.schedule([]*core.Authorization{
+		makeValidatedAuthz("1", identifier.NewDNS("a.com"), fc.Now().Add(-8*time.Hour)),
+		makeValidatedAuthz("2", identifier.NewDNS("b.com"), fc.Now().Add(-8*time.Hour)),
+	})
+	test.AssertMetricWithLabelsEquals(t, s.scheduled, map[string]string{"result": "queued"}, 1)
+	test.AssertMetricWithLabelsEquals(t, s.scheduled, map[string]string{"result": "dropped"}, 1)
+	_, pending := s.pending["2"]
+	test.Assert(t, !pending, "dropped authz should not remain pending")
+}
+
+func TestCAARecheckSchedulerFailureNotRecorded(t *testing.T) {
+	fc := clock.NewFake()
+	s := newCAARecheckScheduler(&caaFailer{}, isa.SA{Impl: isa.NewMemoryStorageAuthority(fc)}, fc, blog.NewMock(), metrics.NoopRegisterer, CAARecheckConfig{
+		QueueSize: 1,
+	})
+	defer s.shutdown()
+
+	// caaFailer returns a CAA problem for a.com.
+	s.recheck(makeValidatedAuthz("1", identifier.NewDNS("a.com"), fc.Now().Add(-8*time.Hour)))
+	test.Assert(t, !s.freshSince(ctx, "1", fc.Now().Add(caaRecheckDuration)), "failed recheck should not be recorded")
+	test.AssertMetricWithLabelsEquals(t, s.scheduled, map[string]string{"result": "failure"}, 1)
+}
+
+func TestCheckAuthorizationsCAAUsesBackgroundRecheck(t *testing.T) {
+	fc := clock.NewFake()
+	fc.Set(time.Date(2020, 3, 4, 5, 0, 0, 0, time.UTC))
+	recorder := &caaRecorder{names: make(map[string]bool)}
+	ra := &RegistrationAuthorityImpl{
+		clk:               fc,
+		log:               blog.NewMock(),
+		VA:                va.RemoteClients{CAAClient: recorder},
+		recheckCAACounter: prometheus.NewCounter(prometheus.CounterOpts{Name: "recheck_caa"}),
+	}
+	sa := isa.SA{Impl: isa.NewMemoryStorageAuthority(fc)}
+	ra.caaRechecker = newCAARecheckScheduler(recorder, sa, fc, ra.log, metrics.NoopRegisterer, CAARecheckConfig{QueueSize: 1})
+	defer ra.caaRechecker.shutdown()
+
+	older := makeValidatedAuthz("1", identifier.NewDNS("older.com"), fc.Now().Add(-8*time.Hour))
+	rechecked := makeValidatedAuthz("2", identifier.NewDNS("rechecked.com"), fc.Now().Add(-8*time.Hour))
+	_, err := sa.SetAuthzCAARecheck(ctx, &sapb.SetAuthzCAARecheckRequest{Id: 2, Rechecked: timestamppb.New(fc.Now().Add(-time.Hour))})
+	test.AssertNotError(t, err, "recording CAA recheck")
+
+	err = ra.checkAuthorizationsCAA(context.Background(), 1, map[identifier.ACMEIdentifier]*core.Authorization{
+		older.Identifier:     older,
+		rechecked.Identifier: rechecked,
+	}, fc.Now())
+	test.AssertNotError(t, err, "checking CAA")
+	test.Assert(t, recorder.names["older.com"], "expected synchronous CAA recheck for older.com")
+	test.Assert(t, !recorder.names["rechecked.com"], "unexpected synchronous CAA recheck for rechecked.com")
+}
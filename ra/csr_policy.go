@@ -0,0 +1,164 @@
+package ra
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/go-jose/go-jose/v4"
+
+	"github.com/letsencrypt/boulder/core"
+	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/identifier"
+)
+
+// CSRPolicyConfig configures the checks applied to CSRs at finalize time, in
+// addition to those always performed by csr.VerifyCSR.
+type CSRPolicyConfig struct {
+	// AllowedExtensions is a list of dotted-decimal OIDs of the extensions
+	// which a CSR may request. If empty, any extension may be requested (the
+	// CA ignores all of them regardless).
+	AllowedExtensions []string `validate:"omitempty,dive,required"`
+
+	// RequireCanonicalSANOrder requires that the subjectAltName extension of a
+	// CSR list each of the order's identifiers exactly once, lowercased, and
+	// in the same canonical order as the order itself: DNS names sorted
+	// alphabetically, followed by IP addresses sorted alphabetically.
+	RequireCanonicalSANOrder bool
+}
+
+// csrPolicy evaluates a CSRPolicyConfig. The zero value applies only the checks
+// which are always performed: that the CSR's identifiers match the order's, and
+// that the certificate key is not the account key.
+type csrPolicy struct {
+	allowedExtensions map[string]bool
+	canonicalSANOrder bool
+}
+
+// SetCSRPolicy configures the checks applied to CSRs at finalize time.
+func (ra *RegistrationAuthorityImpl) SetCSRPolicy(c CSRPolicyConfig) error {
+	policy, err := newCSRPolicy(c)
+	if err != nil {
+		return err
+	}
+	ra.csrPolicy = policy
+	return nil
+}
+
+func newCSRPolicy(c CSRPolicyConfig) (csrPolicy, error) {
+	policy := csrPolicy{canonicalSANOrder: c.RequireCanonicalSANOrder}
+	if len(c.AllowedExtensions) > 0 {
+		policy.allowedExtensions = make(map[string]bool, len(c.AllowedExtensions))
+		for _, s := range c.AllowedExtensions {
+			oid, err := x509.ParseOID(s)
+			if err != nil {
+				return csrPolicy{}, fmt.Errorf("parsing allowed CSR extension %q: %w", s, err)
+			}
+			policy.allowedExtensions[oid.String()] = true
+		}
+	}
+	return policy, nil
+}
+
+// checkExtensions returns a BadCSR error naming every extension requested by
+// the CSR which isn't on the allowlist.
+func (p csrPolicy) checkExtensions(csr *x509.CertificateRequest) error {
+	if p.allowedExtensions == nil {
+		return nil
+	}
+	var disallowed []string
+	for _, ext := range csr.Extensions {
+		if !p.allowedExtensions[ext.Id.String()] {
+			disallowed = append(disallowed, ext.Id.String())
+		}
+	}
+	if len(disallowed) > 0 {
+		return berrors.BadCSRError("CSR requests extensions which are not allowed: %s", strings.Join(disallowed, ", "))
+	}
+	return nil
+}
+
+// checkIdentifiers checks that the identifiers in the CSR are exactly those of
+// the order, which must already be normalized. Each identifier which is in
+// only one of the two is reported as a subproblem. If the policy requires
+// canonical ordering, each subjectAltName which is out of place is also
+// reported as a subproblem.
+func (p csrPolicy) checkIdentifiers(csr *x509.CertificateRequest, orderIdents identifier.ACMEIdentifiers) error {
+	csrIdents := identifier.FromCSR(csr)
+	if !slices.Equal(csrIdents, orderIdents) {
+		var subErrs []berrors.SubBoulderError
+		for _, ident := range csrIdents {
+			if !slices.Contains(orderIdents, ident) {
+				subErrs = append(subErrs, berrors.SubBoulderError{
+					Identifier:   ident,
+					BoulderError: &berrors.BoulderError{Type: berrors.Unauthorized, Detail: "identifier is in the CSR but not in the order"},
+				})
+			}
+		}
+		for _, ident := range orderIdents {
+			if !slices.Contains(csrIdents, ident) {
+				subErrs = append(subErrs, berrors.SubBoulderError{
+					Identifier:   ident,
+					BoulderError: &berrors.BoulderError{Type: berrors.BadCSR, Detail: "identifier is in the order but not in the CSR"},
+				})
+			}
+		}
+		return (&berrors.BoulderError{Type: berrors.Unauthorized, Detail: "CSR does not specify same identifiers as Order"}).WithSubErrors(subErrs)
+	}
+
+	if !p.canonicalSANOrder {
+		return nil
+	}
+	// The x509 package splits the subjectAltName extension by type, losing the
+	// relative order of DNS names and IP addresses, but canonical order puts
+	// all DNS names first anyway.
+	var sans identifier.ACMEIdentifiers
+	for _, name := range csr.DNSNames {
+		sans = append(sans, identifier.NewDNS(name))
+	}
+	for _, ip := range csr.IPAddresses {
+		addr, ok := netip.AddrFromSlice(ip)
+		if !ok {
+			return berrors.BadCSRError("CSR contains an invalid IP address")
+		}
+		sans = append(sans, identifier.NewIP(addr.Unmap()))
+	}
+	var subErrs []berrors.SubBoulderError
+	for i, san := range sans {
+		if i < len(orderIdents) && san == orderIdents[i] {
+			continue
+		}
+		detail := "subjectAltName is out of canonical order"
+		if slices.Contains(sans[:i], san) {
+			detail = "subjectAltName is duplicated"
+		}
+		subErrs = append(subErrs, berrors.SubBoulderError{
+			Identifier:   san,
+			BoulderError: &berrors.BoulderError{Type: berrors.BadCSR, Detail: fmt.Sprintf("%s at position %d", detail, i+1)},
+		})
+	}
+	if len(subErrs) == 0 && len(sans) < len(orderIdents) {
+		// Every SAN is in place but some identifiers only appear in the
+		// Subject Common Name.
+		for _, ident := range orderIdents[len(sans):] {
+			subErrs = append(subErrs, berrors.SubBoulderError{
+				Identifier:   ident,
+				BoulderError: &berrors.BoulderError{Type: berrors.BadCSR, Detail: "identifier is missing from subjectAltName"},
+			})
+		}
+	}
+	if len(subErrs) > 0 {
+		return (&berrors.BoulderError{Type: berrors.BadCSR, Detail: "CSR subjectAltNames must list the order's identifiers once each, in canonical order"}).WithSubErrors(subErrs)
+	}
+	return nil
+}
+
+// checkKeyReuse returns an error if the CSR's public key is the account key.
+func (p csrPolicy) checkKeyReuse(csr *x509.CertificateRequest, accountKey *jose.JSONWebKey) error {
+	if core.KeyDigestEquals(csr.PublicKey, accountKey) {
+		return berrors.MalformedError("certificate public key must be different than account key")
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package ra
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/go-jose/go-jose/v4"
+
+	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/identifier"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestNewCSRPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy, err := newCSRPolicy(CSRPolicyConfig{})
+	test.AssertNotError(t, err, "empty config")
+	test.AssertBoxedNil(t, policy.allowedExtensions, "empty config should allow all extensions")
+
+	policy, err = newCSRPolicy(CSRPolicyConfig{AllowedExtensions: []string{"2.5.29.17", "1.3.6.1.5.5.7.1.24"}})
+	test.AssertNotError(t, err, "valid OIDs")
+	test.Assert(t, policy.allowedExtensions["2.5.29.17"], "SAN should be allowed")
+	test.Assert(t, policy.allowedExtensions["1.3.6.1.5.5.7.1.24"], "TLS Feature should be allowed")
+
+	_, err = newCSRPolicy(CSRPolicyConfig{AllowedExtensions: []string{"subjectAltName"}})
+	test.AssertError(t, err, "non-numeric OID should be rejected")
+}
+
+func TestCSRPolicyCheckExtensions(t *testing.T) {
+	t.Parallel()
+
+	policy, err := newCSRPolicy(CSRPolicyConfig{AllowedExtensions: []string{"2.5.29.17"}})
+	test.AssertNotError(t, err, "creating policy")
+
+	csr := &x509.CertificateRequest{Extensions: []pkix.Extension{
+		{Id: asn1.ObjectIdentifier{2, 5, 29, 17}},
+	}}
+	test.AssertNotError(t, policy.checkExtensions(csr), "SAN should be allowed")
+
+	csr.Extensions = append(csr.Extensions,
+		pkix.Extension{Id: asn1.ObjectIdentifier{2, 5, 29, 19}},
+		pkix.Extension{Id: asn1.ObjectIdentifier{1, 2, 3, 4}},
+	)
+	err = policy.checkExtensions(csr)
+	test.AssertErrorIs(t, err, berrors.BadCSR)
+	test.AssertContains(t, err.Error(), "2.5.29.19, 1.2.3.4")
+
+	test.AssertNotError(t, csrPolicy{}.checkExtensions(csr), "zero policy should allow any extension")
+}
+
+func TestCSRPolicyCheckIdentifiers(t *testing.T) {
+	t.Parallel()
+
+	orderIdents := identifier.ACMEIdentifiers{
+		identifier.NewDNS("a.com"),
+		identifier.NewDNS("b.com"),
+		identifier.NewIP(netip.MustParseAddr("10.0.0.1")),
+	}
+
+	subErrs := func(err error) []berrors.SubBoulderError {
+		t.Helper()
+		var bErr *berrors.BoulderError
+		if !errors.As(err, &bErr) {
+			t.Fatalf("expected a BoulderError, got %#v", err)
+		}
+		return bErr.SubErrors
+	}
+
+	testCases := []struct {
+		name         string
+		canonical    bool
+		order        identifier.ACMEIdentifiers
+		csr          *x509.CertificateRequest
+		wantType     berrors.ErrorType
+		wantSubs     []string
+		wantSubIdent []identifier.ACMEIdentifier
+	}{
+		{
+			name:      "exact match in canonical order",
+			canonical: true,
+			csr: &x509.CertificateRequest{
+				DNSNames:    []string{"a.com", "b.com"},
+				IPAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+			},
+		},
+		{
+			name: "out of order without canonical ordering",
+			csr: &x509.CertificateRequest{
+				DNSNames:    []string{"b.com", "a.com"},
+				IPAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+			},
+		},
+		{
+			name: "extra and missing identifiers",
+			csr: &x509.CertificateRequest{
+				DNSNames:    []string{"a.com", "c.com"},
+				IPAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+			},
+			wantType: berrors.Unauthorized,
+			wantSubs: []string{
+				"identifier is in the CSR but not in the order",
+				"identifier is in the order but not in the CSR",
+			},
+			wantSubIdent: []identifier.ACMEIdentifier{identifier.NewDNS("c.com"), identifier.NewDNS("b.com")},
+		},
+		{
+			name:      "out of order",
+			canonical: true,
+			csr: &x509.CertificateRequest{
+				DNSNames:    []string{"b.com", "a.com"},
+				IPAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+			},
+			wantType: berrors.BadCSR,
+			wantSubs: []string{
+				"subjectAltName is out of canonical order at position 1",
+				"subjectAltName is out of canonical order at position 2",
+			},
+			wantSubIdent: []identifier.ACMEIdentifier{identifier.NewDNS("b.com"), identifier.NewDNS("a.com")},
+		},
+		{
+			name:      "duplicated",
+			canonical: true,
+			csr: &x509.CertificateRequest{
+				DNSNames:    []string{"a.com", "a.com", "b.com"},
+				IPAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+			},
+			wantType: berrors.BadCSR,
+			wantSubs: []string{
+				"subjectAltName is duplicated at position 2",
+				"subjectAltName is out of canonical order at position 3",
+				"subjectAltName is out of canonical order at position 4",
+			},
+			wantSubIdent: []identifier.ACMEIdentifier{
+				identifier.NewDNS("a.com"),
+				identifier.NewDNS("b.com"),
+				identifier.NewIP(netip.MustParseAddr("10.0.0.1")),
+			},
+		},
+		{
+			name:      "identifier only in common name",
+			canonical: true,
+			csr: &x509.CertificateRequest{
+				Subject:  pkix.Name{CommonName: "b.com"},
+				DNSNames: []string{"a.com"},
+				IPAddresses: []net.IP{
+					net.ParseIP("10.0.0.1"),
+				},
+			},
+			wantType: berrors.BadCSR,
+			wantSubs: []string{
+				"subjectAltName is out of canonical order at position 2",
+			},
+			wantSubIdent: []identifier.ACMEIdentifier{identifier.NewIP(netip.MustParseAddr("10.0.0.1"))},
+		},
+		{
+			name:      "trailing identifier only in common name",
+			canonical: true,
+			order:     identifier.ACMEIdentifiers{identifier.NewDNS("a.com"), identifier.NewDNS("b.com")},
+			csr: &x509.CertificateRequest{
+				Subject:  pkix.Name{CommonName: "b.com"},
+				DNSNames: []string{"a.com"},
+			},
+			wantType:     berrors.BadCSR,
+			wantSubs:     []string{"identifier is missing from subjectAltName"},
+			wantSubIdent: []identifier.ACMEIdentifier{identifier.NewDNS("b.com")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			order := orderIdents
+			if tc.order != nil {
+				order = tc.order
+			}
+			policy := csrPolicy{canonicalSANOrder: tc.canonical}
+			err := policy.checkIdentifiers(tc.csr, order)
+			if tc.wantSubs == nil {
+				test.AssertNotError(t, err, "checkIdentifiers")
+				return
+			}
+			test.AssertErrorIs(t, err, tc.wantType)
+			subs := subErrs(err)
+			test.AssertEquals(t, len(subs), len(tc.wantSubs))
+			for i, sub := range subs {
+				test.AssertEquals(t, sub.BoulderError.Detail, tc.wantSubs[i])
+				test.AssertEquals(t, sub.Identifier, tc.wantSubIdent[i])
+			}
+		})
+	}
+}
+
+func TestCSRPolicyCheckKeyReuse(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+
+	csr := &x509.CertificateRequest{PublicKey: key.Public()}
+	err = csrPolicy{}.checkKeyReuse(csr, &jose.JSONWebKey{Key: key.Public()})
+	test.AssertErrorIs(t, err, berrors.Malformed)
+	test.AssertNotError(t, csrPolicy{}.checkKeyReuse(csr, &jose.JSONWebKey{Key: other.Public()}), "distinct keys")
+}
@@ -0,0 +1,194 @@
+package ra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/ocsp"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/letsencrypt/boulder/core"
+	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/issuance"
+	rapb "github.com/letsencrypt/boulder/ra/proto"
+	"github.com/letsencrypt/boulder/revocation"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+)
+
+// keyCompromiseEvent is logged once per ReportKeyCompromise request, after
+// the key has been blocked and its certificates revoked.
+type keyCompromiseEvent struct {
+	KeyHash            string
+	AdminName          string
+	PossessionVerified bool
+	AlreadyBlocked     bool
+	Revoked            int
+	AlreadyRevoked     int
+	Failed             int
+	Error              string `json:",omitempty"`
+}
+
+// ReportKeyCompromise handles a report, by an administrator, that a key has
+// been compromised. If the report includes the key's SPKI, it must also
+// include a signature by the key over revocation.KeyCompromiseMessage,
+// proving possession of it; otherwise it identifies the key by its SPKI hash
+// alone. The key is added to the blockedKeys table, so that no further
+// certificates are issued for it, and then every unexpired certificate with
+// the key is revoked for keyCompromise, without waiting for bad-key-revoker.
+//
+// The first message sent on the stream describes the key, and each one after
+// it the revocation of one certificate. Failure to revoke a certificate is
+// reported on the stream rather than ending it. If the stream ends early, the
+// remaining certificates are left for bad-key-revoker, which finds them via
+// the blockedKeys row.
+func (ra *RegistrationAuthorityImpl) ReportKeyCompromise(req *rapb.ReportKeyCompromiseRequest, stream grpc.ServerStreamingServer[rapb.KeyCompromiseProgress]) error {
+	if req == nil || req.AdminName == "" {
+		return errIncompleteGRPCRequest
+	}
+	ctx := stream.Context()
+
+	var keyHash []byte
+	var possessionVerified bool
+	switch {
+	case len(req.Spki) != 0 && len(req.KeyHash) != 0:
+		return errors.New("key compromise report must not include both an SPKI and an SPKI hash")
+	case len(req.Spki) != 0:
+		var err error
+		keyHash, err = revocation.VerifyKeyCompromise(req.Spki, req.Signature)
+		if err != nil {
+			return berrors.UnauthorizedError("unable to verify possession of compromised key: %s", err)
+		}
+		possessionVerified = true
+	case len(req.KeyHash) == 32:
+		keyHash = req.KeyHash
+	case len(req.KeyHash) != 0:
+		return fmt.Errorf("SPKI hash has length %d, expected 32", len(req.KeyHash))
+	default:
+		return errIncompleteGRPCRequest
+	}
+
+	logEvent := keyCompromiseEvent{
+		KeyHash:            fmt.Sprintf("%x", keyHash),
+		AdminName:          req.AdminName,
+		PossessionVerified: possessionVerified,
+	}
+	var err error
+	defer func() {
+		if err != nil {
+			logEvent.Error = err.Error()
+		}
+		ra.log.AuditObject("Key compromise report:", logEvent)
+	}()
+
+	var exists *sapb.Exists
+	exists, err = ra.SA.KeyBlocked(ctx, &sapb.SPKIHash{KeyHash: keyHash})
+	if err != nil {
+		return fmt.Errorf("checking whether key is blocked: %w", err)
+	}
+	logEvent.AlreadyBlocked = exists.Exists
+	if !exists.Exists {
+		comment := fmt.Sprintf("reported by %s", req.AdminName)
+		if possessionVerified {
+			comment += " with proof of possession"
+		}
+		if req.Comment != "" {
+			comment += ": " + req.Comment
+		}
+		_, err = ra.SA.AddBlockedKey(ctx, &sapb.AddBlockedKeyRequest{
+			KeyHash: keyHash,
+			Added:   timestamppb.New(ra.clk.Now()),
+			Source:  "admin-revoker",
+			Comment: comment,
+		})
+		if err != nil {
+			return fmt.Errorf("blocking key: %w", err)
+		}
+	}
+
+	err = stream.Send(&rapb.KeyCompromiseProgress{
+		KeyHash:            keyHash,
+		PossessionVerified: possessionVerified,
+		AlreadyBlocked:     exists.Exists,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Collect the serials before revoking any of them, so that the SA's stream
+	// isn't held open for the duration of the revocations.
+	var serials grpc.ServerStreamingClient[sapb.Serial]
+	serials, err = ra.SA.GetSerialsByKey(ctx, &sapb.SPKIHash{KeyHash: keyHash})
+	if err != nil {
+		return fmt.Errorf("getting serials for key: %w", err)
+	}
+	var toRevoke []string
+	for {
+		var serial *sapb.Serial
+		serial, err = serials.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = nil
+				break
+			}
+			return fmt.Errorf("streaming serials for key: %w", err)
+		}
+		toRevoke = append(toRevoke, serial.Serial)
+	}
+
+	for _, serial := range toRevoke {
+		result, revokeErr := ra.revokeForKeyCompromise(ctx, serial, req.AdminName)
+		progress := &rapb.KeyCompromiseProgress{Serial: serial, Result: result}
+		switch {
+		case revokeErr != nil:
+			progress.Result = "failed"
+			progress.Error = revokeErr.Error()
+			logEvent.Failed++
+		case result == "alreadyRevoked":
+			logEvent.AlreadyRevoked++
+		default:
+			logEvent.Revoked++
+		}
+		err = stream.Send(progress)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// revokeForKeyCompromise revokes the certificate with the given serial for
+// keyCompromise, without blocking its key, and returns "revoked" or
+// "alreadyRevoked". A certificate already revoked for another reason has its
+// reason updated to keyCompromise, which counts as revoking it.
+func (ra *RegistrationAuthorityImpl) revokeForKeyCompromise(ctx context.Context, serial string, adminName string) (string, error) {
+	status, err := ra.SA.GetCertificateStatus(ctx, &sapb.Serial{Serial: serial})
+	if err != nil {
+		return "", fmt.Errorf("getting certificate status: %w", err)
+	}
+	if status.Status == string(core.OCSPStatusRevoked) {
+		err = ra.updateRevocationForKeyCompromise(ctx, serial, issuance.NameID(status.IssuerID))
+		if errors.Is(err, berrors.AlreadyRevoked) {
+			return "alreadyRevoked", nil
+		}
+		if err != nil {
+			return "", err
+		}
+		return "revoked", nil
+	}
+
+	// The key is already blocked, so revocation mustn't try to block it again.
+	_, err = ra.AdministrativelyRevokeCertificate(ctx, &rapb.AdministrativelyRevokeCertificateRequest{
+		Serial:       serial,
+		Code:         int64(ocsp.KeyCompromise),
+		AdminName:    adminName,
+		SkipBlockKey: true,
+	})
+	if err != nil {
+		return "", err
+	}
+	return "revoked", nil
+}
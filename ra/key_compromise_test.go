@@ -0,0 +1,144 @@
+package ra
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/jmhodges/clock"
+	"golang.org/x/crypto/ocsp"
+	"google.golang.org/grpc"
+
+	"github.com/letsencrypt/boulder/core"
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/mocks"
+	rapb "github.com/letsencrypt/boulder/ra/proto"
+	"github.com/letsencrypt/boulder/revocation"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// mockSAKeyCompromise is a mockSARevocation which also reports whether a key
+// is blocked and returns a fixed set of serials for every key.
+type mockSAKeyCompromise struct {
+	*mockSARevocation
+	serials []string
+}
+
+func (msa *mockSAKeyCompromise) KeyBlocked(_ context.Context, req *sapb.SPKIHash, _ ...grpc.CallOption) (*sapb.Exists, error) {
+	for _, b := range msa.blocked {
+		if string(b.KeyHash) == string(req.KeyHash) {
+			return &sapb.Exists{Exists: true}, nil
+		}
+	}
+	return &sapb.Exists{Exists: false}, nil
+}
+
+func (msa *mockSAKeyCompromise) GetSerialsByKey(_ context.Context, _ *sapb.SPKIHash, _ ...grpc.CallOption) (grpc.ServerStreamingClient[sapb.Serial], error) {
+	results := make([]*sapb.Serial, len(msa.serials))
+	for i, serial := range msa.serials {
+		results[i] = &sapb.Serial{Serial: serial}
+	}
+	return &mocks.ServerStreamClient[sapb.Serial]{Results: results}, nil
+}
+
+// recordingProgressStream is a server stream which records the messages sent
+// on it.
+type recordingProgressStream struct {
+	grpc.ServerStream
+	sent []*rapb.KeyCompromiseProgress
+}
+
+func (s *recordingProgressStream) Send(msg *rapb.KeyCompromiseProgress) error {
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+func (s *recordingProgressStream) Context() context.Context {
+	return context.Background()
+}
+
+func TestReportKeyCompromise(t *testing.T) {
+	t.Parallel()
+
+	clk := clock.NewFake()
+	serial, cert := test.ThrowAwayCert(t, clk)
+	mockSA := &mockSAKeyCompromise{
+		mockSARevocation: newMockSARevocation(cert),
+		serials:          []string{serial, "unknown"},
+	}
+	// The certificate has no OCSP URI, so there's nothing to purge.
+	ra := &RegistrationAuthorityImpl{SA: mockSA, clk: clk, log: blog.NewMock()}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	keyHash, err := core.KeyDigest(key.Public())
+	test.AssertNotError(t, err, "computing SPKI hash")
+	spki, sig, err := revocation.SignKeyCompromise(key)
+	test.AssertNotError(t, err, "signing key compromise message")
+
+	// Reports which are incomplete, or whose proof of possession is invalid,
+	// are rejected before anything is blocked.
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	_, otherSig, err := revocation.SignKeyCompromise(otherKey)
+	test.AssertNotError(t, err, "signing key compromise message")
+	for _, req := range []*rapb.ReportKeyCompromiseRequest{
+		{Spki: spki, Signature: sig},
+		{AdminName: "root"},
+		{AdminName: "root", Spki: spki, Signature: otherSig},
+		{AdminName: "root", Spki: spki, Signature: sig, KeyHash: keyHash[:]},
+		{AdminName: "root", KeyHash: keyHash[:16]},
+	} {
+		stream := &recordingProgressStream{}
+		err = ra.ReportKeyCompromise(req, stream)
+		test.AssertError(t, err, "ReportKeyCompromise should have failed")
+		test.AssertEquals(t, len(stream.sent), 0)
+		test.AssertEquals(t, len(mockSA.blocked), 0)
+	}
+
+	// A report with proof of possession blocks the key and revokes its
+	// certificates, reporting those which can't be revoked without failing.
+	stream := &recordingProgressStream{}
+	err = ra.ReportKeyCompromise(&rapb.ReportKeyCompromiseRequest{
+		AdminName: "root",
+		Spki:      spki,
+		Signature: sig,
+		Comment:   "posted to a pastebin",
+	}, stream)
+	test.AssertNotError(t, err, "ReportKeyCompromise failed")
+	test.AssertEquals(t, len(mockSA.blocked), 1)
+	test.AssertByteEquals(t, mockSA.blocked[0].KeyHash, keyHash[:])
+	test.AssertEquals(t, mockSA.blocked[0].Comment, "reported by root with proof of possession: posted to a pastebin")
+	test.AssertEquals(t, len(stream.sent), 3)
+	test.AssertByteEquals(t, stream.sent[0].KeyHash, keyHash[:])
+	test.Assert(t, stream.sent[0].PossessionVerified, "possession should have been verified")
+	test.Assert(t, !stream.sent[0].AlreadyBlocked, "key should not have been blocked already")
+	test.AssertEquals(t, stream.sent[1].Serial, serial)
+	test.AssertEquals(t, stream.sent[1].Result, "revoked")
+	test.AssertEquals(t, stream.sent[2].Serial, "unknown")
+	test.AssertEquals(t, stream.sent[2].Result, "failed")
+	test.AssertDeepEquals(t, mockSA.revoked[serial], &corepb.CertificateStatus{
+		Serial:        serial,
+		IssuerID:      mockSA.revoked[serial].IssuerID,
+		Status:        string(core.OCSPStatusRevoked),
+		RevokedReason: ocsp.KeyCompromise,
+	})
+
+	// Reporting the key again by its hash finds it already blocked, and its
+	// certificate already revoked.
+	stream = &recordingProgressStream{}
+	err = ra.ReportKeyCompromise(&rapb.ReportKeyCompromiseRequest{
+		AdminName: "root",
+		KeyHash:   keyHash[:],
+	}, stream)
+	test.AssertNotError(t, err, "ReportKeyCompromise failed")
+	test.AssertEquals(t, len(mockSA.blocked), 1)
+	test.AssertEquals(t, len(stream.sent), 3)
+	test.Assert(t, !stream.sent[0].PossessionVerified, "possession should not have been verified")
+	test.Assert(t, stream.sent[0].AlreadyBlocked, "key should have been blocked already")
+	test.AssertEquals(t, stream.sent[1].Result, "alreadyRevoked")
+}
@@ -0,0 +1,164 @@
+package ra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/letsencrypt/boulder/core"
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	berrors "github.com/letsencrypt/boulder/errors"
+	bgrpc "github.com/letsencrypt/boulder/grpc"
+	"github.com/letsencrypt/boulder/identifier"
+	rapb "github.com/letsencrypt/boulder/ra/proto"
+	vapb "github.com/letsencrypt/boulder/va/proto"
+	"github.com/letsencrypt/boulder/web"
+)
+
+// PreflightOrder reports whether an order for the requested identifiers would
+// be accepted, and whether CAA currently permits issuance for each of them. It
+// runs the same profile, policy, and rate limit checks as NewOrder, but doesn't
+// create the order, spend any rate limits, or validate control of the
+// identifiers. Problems with the order are returned in the response; an error
+// is only returned if a check couldn't be performed.
+func (ra *RegistrationAuthorityImpl) PreflightOrder(ctx context.Context, req *rapb.PreflightOrderRequest) (*rapb.PreflightOrderResponse, error) {
+	if req == nil || req.RegistrationID == 0 || len(req.Identifiers) == 0 {
+		return nil, errIncompleteGRPCRequest
+	}
+
+	var challType core.AcmeChallenge
+	if req.ChallengeType != "" {
+		challType = core.AcmeChallenge(req.ChallengeType)
+		if !challType.IsValid() || !ra.PA.ChallengeTypeEnabled(challType) {
+			return nil, berrors.MalformedError("challenge type %q is not supported", req.ChallengeType)
+		}
+	}
+
+	idents := identifier.Normalize(identifier.FromProtoSlice(req.Identifiers))
+	resp := &rapb.PreflightOrderResponse{}
+
+	profile, err := ra.checkOrderProfile(req.RegistrationID, req.CertificateProfileName, idents)
+	if err == nil {
+		err = wildcardOverlap(idents)
+	}
+	if err != nil {
+		prob, err := preflightProblem(err, "Order would be rejected")
+		if err != nil {
+			return nil, err
+		}
+		resp.Problems = append(resp.Problems, prob)
+	}
+
+	if profile != nil && profile.delegated {
+		err = ra.checkDelegatedLimit(ctx, idents)
+		if err != nil {
+			prob, err := preflightProblem(err, "Order would be rate limited")
+			if err != nil {
+				return nil, err
+			}
+			resp.Problems = append(resp.Problems, prob)
+		}
+	}
+
+	if ra.limiter != nil && ra.txnBuilder != nil {
+		txns, err := ra.txnBuilder.NewOrderLimitTransactions(req.RegistrationID, idents, req.IsRenewal)
+		if err != nil {
+			return nil, fmt.Errorf("building new order limit transactions: %w", err)
+		}
+		for _, txn := range txns {
+			// Check, unlike Spend, never changes the state of the bucket.
+			d, err := ra.limiter.Check(ctx, txn)
+			if err != nil {
+				return nil, fmt.Errorf("checking new order limits: %w", err)
+			}
+			err = d.Result(ra.clk.Now())
+			if err != nil {
+				prob, err := preflightProblem(err, "Order would be rate limited")
+				if err != nil {
+					return nil, err
+				}
+				resp.Problems = append(resp.Problems, prob)
+			}
+		}
+	}
+
+	type identResult struct {
+		i    int
+		prob *corepb.ProblemDetails
+		err  error
+	}
+	results := make(chan identResult, len(idents))
+	for i, ident := range idents {
+		go func() {
+			prob, err := ra.preflightIdentifier(ctx, req.RegistrationID, ident, challType)
+			results <- identResult{i, prob, err}
+		}()
+	}
+	resp.Identifiers = make([]*rapb.PreflightIdentifier, len(idents))
+	for range idents {
+		r := <-results
+		if r.err != nil {
+			return nil, r.err
+		}
+		resp.Identifiers[r.i] = &rapb.PreflightIdentifier{
+			Identifier: idents[r.i].ToProto(),
+			Problem:    r.prob,
+		}
+	}
+	return resp, nil
+}
+
+// preflightIdentifier returns a problem if policy forbids issuance for ident,
+// or if CAA doesn't permit validating it with challType or, if challType is
+// empty, any of the challenge types it could be validated with.
+func (ra *RegistrationAuthorityImpl) preflightIdentifier(ctx context.Context, regID int64, ident identifier.ACMEIdentifier, challType core.AcmeChallenge) (*corepb.ProblemDetails, error) {
+	err := ra.PA.WillingToIssue(identifier.ACMEIdentifiers{ident})
+	if err != nil {
+		return preflightProblem(err, "Identifier would be rejected")
+	}
+
+	allowed, err := ra.PA.ChallengeTypesFor(ident)
+	if err != nil {
+		return preflightProblem(berrors.RejectedIdentifierError("%s", err), "Identifier would be rejected")
+	}
+	var methods []core.AcmeChallenge
+	for _, t := range allowed {
+		if (challType == "" || t == challType) && ra.PA.ChallengeTypeEnabled(t) {
+			methods = append(methods, t)
+		}
+	}
+	if len(methods) == 0 {
+		return preflightProblem(berrors.RejectedIdentifierError(
+			"challenge type %q can't be used to validate %s identifier %q", challType, ident.Type, ident.Value),
+			"Identifier would be rejected")
+	}
+
+	var prob *corepb.ProblemDetails
+	for _, method := range methods {
+		resp, err := ra.VA.DoCAA(ctx, &vapb.IsCAAValidRequest{
+			Identifier:       ident.ToProto(),
+			ValidationMethod: string(method),
+			AccountURIID:     regID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("checking CAA for %q: %w", ident.Value, err)
+		}
+		if resp.Problem == nil {
+			return nil, nil
+		}
+		prob = resp.Problem
+	}
+	return prob, nil
+}
+
+// preflightProblem converts err, returned by a check which the order failed,
+// into a problem document. Errors which don't describe a problem with the
+// order, such as internal errors, are returned instead.
+func preflightProblem(err error, msg string) (*corepb.ProblemDetails, error) {
+	var bErr *berrors.BoulderError
+	if !errors.As(err, &bErr) || slices.Contains([]berrors.ErrorType{berrors.InternalServer, berrors.MissingSCTs}, bErr.Type) {
+		return nil, err
+	}
+	return bgrpc.ProblemDetailsToPB(web.ProblemDetailsForError(err, msg))
+}
@@ -0,0 +1,312 @@
+package ra
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"google.golang.org/grpc"
+
+	"github.com/letsencrypt/boulder/core"
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	"github.com/letsencrypt/boulder/goodkey"
+	"github.com/letsencrypt/boulder/identifier"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/policy"
+	"github.com/letsencrypt/boulder/probs"
+	rapb "github.com/letsencrypt/boulder/ra/proto"
+	"github.com/letsencrypt/boulder/ratelimits"
+	"github.com/letsencrypt/boulder/test"
+	"github.com/letsencrypt/boulder/va"
+	vapb "github.com/letsencrypt/boulder/va/proto"
+)
+
+// preflightCAA implements vapb.CAAClient. It forbids issuance for the
+// validation methods listed for each identifier value, and records the
+// methods it was asked about.
+type preflightCAA struct {
+	forbidden map[string][]string
+	err       error
+
+	mu      sync.Mutex
+	checked map[string][]string
+}
+
+func (p *preflightCAA) DoCAA(_ context.Context, in *vapb.IsCAAValidRequest, _ ...grpc.CallOption) (*vapb.IsCAAValidResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.checked[in.Identifier.Value] = append(p.checked[in.Identifier.Value], in.ValidationMethod)
+	if p.err != nil {
+		return nil, p.err
+	}
+	for _, method := range p.forbidden[in.Identifier.Value] {
+		if method == in.ValidationMethod {
+			return &vapb.IsCAAValidResponse{Problem: &corepb.ProblemDetails{
+				ProblemType: string(probs.CAAProblem),
+				Detail:      "CAA record for " + in.Identifier.Value + " prevents issuance",
+				HttpStatus:  403,
+			}}, nil
+		}
+	}
+	return &vapb.IsCAAValidResponse{}, nil
+}
+
+func setupPreflight(t *testing.T) (*RegistrationAuthorityImpl, *preflightCAA, clock.FakeClock) {
+	t.Helper()
+	fc := clock.NewFake()
+	fc.Set(time.Date(2020, 3, 4, 5, 0, 0, 0, time.UTC))
+
+	pa, err := policy.New(
+		map[identifier.IdentifierType]bool{
+			identifier.TypeDNS: true,
+			identifier.TypeIP:  true,
+		},
+		map[core.AcmeChallenge]bool{
+			core.ChallengeTypeHTTP01: true,
+			core.ChallengeTypeDNS01:  true,
+		},
+		blog.NewMock())
+	test.AssertNotError(t, err, "creating PA")
+	err = pa.LoadIdentPolicyFile("../test/ident-policy.yaml")
+	test.AssertNotError(t, err, "loading identifier policy")
+
+	limiter, err := ratelimits.NewLimiter(fc, ratelimits.NewInmemSource(), metrics.NoopRegisterer, blog.NewMock())
+	test.AssertNotError(t, err, "making limiter")
+	txnBuilder, err := ratelimits.NewTransactionBuilderFromFiles("../test/config-next/wfe2-ratelimit-defaults.yml", "", ratelimits.KeyConfig{})
+	test.AssertNotError(t, err, "making transaction builder")
+	keyPolicy, err := goodkey.NewPolicy(nil, nil)
+	test.AssertNotError(t, err, "making key policy")
+
+	profiles := &validationProfiles{
+		defaultName: "test",
+		byName: map[string]*validationProfile{"test": {
+			pendingAuthzLifetime: 7 * 24 * time.Hour,
+			validAuthzLifetime:   300 * 24 * time.Hour,
+			orderLifetime:        7 * 24 * time.Hour,
+			maxNames:             3,
+			identifierTypes:      []identifier.IdentifierType{identifier.TypeDNS},
+		}},
+	}
+
+	ra := NewRegistrationAuthorityImpl(
+		fc, blog.NewMock(), metrics.NoopRegisterer,
+		1, keyPolicy, limiter, txnBuilder, 100,
+		profiles, nil, 5*time.Minute, nil, nil, nil)
+	caa := &preflightCAA{checked: make(map[string][]string)}
+	ra.VA = va.RemoteClients{CAAClient: caa}
+	ra.PA = pa
+	return ra, caa, fc
+}
+
+func preflightIdents(values ...string) []*corepb.Identifier {
+	var idents []*corepb.Identifier
+	for _, v := range values {
+		idents = append(idents, identifier.NewDNS(v).ToProto())
+	}
+	return idents
+}
+
+func TestPreflightOrderAcceptable(t *testing.T) {
+	t.Parallel()
+	ra, caa, _ := setupPreflight(t)
+
+	resp, err := ra.PreflightOrder(context.Background(), &rapb.PreflightOrderRequest{
+		RegistrationID: 1,
+		Identifiers:    preflightIdents("B.com", "a.com"),
+	})
+	test.AssertNotError(t, err, "PreflightOrder failed")
+	test.AssertEquals(t, len(resp.Problems), 0)
+	test.AssertEquals(t, len(resp.Identifiers), 2)
+	// Identifiers are normalized, as they are for NewOrder.
+	test.AssertEquals(t, resp.Identifiers[0].Identifier.Value, "a.com")
+	test.AssertEquals(t, resp.Identifiers[1].Identifier.Value, "b.com")
+	for _, ident := range resp.Identifiers {
+		test.Assert(t, ident.Problem == nil, "unexpected problem for "+ident.Identifier.Value)
+	}
+	// CAA is only checked until one enabled method is permitted.
+	test.AssertDeepEquals(t, caa.checked["a.com"], []string{"http-01"})
+
+	_, err = ra.PreflightOrder(context.Background(), &rapb.PreflightOrderRequest{RegistrationID: 1})
+	test.AssertErrorIs(t, err, errIncompleteGRPCRequest)
+}
+
+func TestPreflightOrderProblems(t *testing.T) {
+	t.Parallel()
+	ra, _, _ := setupPreflight(t)
+
+	for _, tc := range []struct {
+		name    string
+		req     *rapb.PreflightOrderRequest
+		wantErr string
+	}{
+		{
+			name:    "unknown profile",
+			req:     &rapb.PreflightOrderRequest{CertificateProfileName: "unknown", Identifiers: preflightIdents("a.com")},
+			wantErr: "unknown",
+		},
+		{
+			name:    "too many identifiers",
+			req:     &rapb.PreflightOrderRequest{Identifiers: preflightIdents("a.com", "b.com", "c.com", "d.com")},
+			wantErr: "Order cannot contain more than 3 identifiers",
+		},
+		{
+			name:    "identifier type not in profile",
+			req:     &rapb.PreflightOrderRequest{Identifiers: []*corepb.Identifier{identifier.NewIP(netip.MustParseAddr("10.0.0.1")).ToProto()}},
+			wantErr: "does not permit ip type identifiers",
+		},
+		{
+			name:    "wildcard overlap",
+			req:     &rapb.PreflightOrderRequest{Identifiers: preflightIdents("*.a.com", "b.a.com")},
+			wantErr: "Domain name \"b.a.com\" is redundant with a wildcard domain in the same request",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.req.RegistrationID = 1
+			resp, err := ra.PreflightOrder(context.Background(), tc.req)
+			test.AssertNotError(t, err, "PreflightOrder failed")
+			test.AssertEquals(t, len(resp.Problems), 1)
+			test.AssertContains(t, resp.Problems[0].Detail, "Order would be rejected :: ")
+			test.AssertContains(t, resp.Problems[0].Detail, tc.wantErr)
+		})
+	}
+}
+
+func TestPreflightOrderIdentifiers(t *testing.T) {
+	t.Parallel()
+	ra, caa, _ := setupPreflight(t)
+	caa.forbidden = map[string][]string{
+		"http-only.com": {"dns-01"},
+		"forbidden.com": {"http-01", "dns-01"},
+	}
+
+	resp, err := ra.PreflightOrder(context.Background(), &rapb.PreflightOrderRequest{
+		RegistrationID: 1,
+		Identifiers:    preflightIdents("http-only.com", "forbidden.com", "example.org"),
+	})
+	test.AssertNotError(t, err, "PreflightOrder failed")
+	test.AssertEquals(t, len(resp.Problems), 0)
+	problems := make(map[string]*corepb.ProblemDetails)
+	for _, ident := range resp.Identifiers {
+		problems[ident.Identifier.Value] = ident.Problem
+	}
+	test.Assert(t, problems["http-only.com"] == nil, "CAA permitting http-01 reported as a problem")
+	test.AssertNotNil(t, problems["forbidden.com"], "CAA forbidding every method not reported")
+	test.AssertEquals(t, problems["forbidden.com"].ProblemType, string(probs.CAAProblem))
+	// example.org is blocked by the identifier policy, so CAA isn't checked.
+	test.AssertNotNil(t, problems["example.org"], "policy problem not reported")
+	test.AssertEquals(t, problems["example.org"].ProblemType, string(probs.RejectedIdentifierProblem))
+	test.AssertEquals(t, len(caa.checked["example.org"]), 0)
+
+	// With a challenge type, only that method is checked.
+	resp, err = ra.PreflightOrder(context.Background(), &rapb.PreflightOrderRequest{
+		RegistrationID: 1,
+		Identifiers:    preflightIdents("http-only.com"),
+		ChallengeType:  "dns-01",
+	})
+	test.AssertNotError(t, err, "PreflightOrder failed")
+	test.AssertNotNil(t, resp.Identifiers[0].Problem, "CAA forbidding the requested method not reported")
+
+	// Wildcards can't be validated with http-01.
+	resp, err = ra.PreflightOrder(context.Background(), &rapb.PreflightOrderRequest{
+		RegistrationID: 1,
+		Identifiers:    preflightIdents("*.example.com"),
+		ChallengeType:  "http-01",
+	})
+	test.AssertNotError(t, err, "PreflightOrder failed")
+	test.AssertNotNil(t, resp.Identifiers[0].Problem, "unusable challenge type not reported")
+	test.AssertContains(t, resp.Identifiers[0].Problem.Detail, "can't be used to validate")
+
+	_, err = ra.PreflightOrder(context.Background(), &rapb.PreflightOrderRequest{
+		RegistrationID: 1,
+		Identifiers:    preflightIdents("example.com"),
+		ChallengeType:  "tls-alpn-01",
+	})
+	test.AssertError(t, err, "disabled challenge type accepted")
+
+	// Failures to check CAA are errors, not problems with the order.
+	caa.err = errors.New("oops")
+	_, err = ra.PreflightOrder(context.Background(), &rapb.PreflightOrderRequest{
+		RegistrationID: 1,
+		Identifiers:    preflightIdents("example.com"),
+	})
+	test.AssertError(t, err, "CAA failure not returned")
+}
+
+func TestPreflightOrderRateLimits(t *testing.T) {
+	t.Parallel()
+	ra, _, _ := setupPreflight(t)
+	req := &rapb.PreflightOrderRequest{
+		RegistrationID: 1,
+		Identifiers:    preflightIdents("example.com"),
+	}
+
+	// Preflight checks don't spend rate limits, so they can be repeated
+	// beyond the CertificatesPerDomain limit of 2.
+	for range 3 {
+		resp, err := ra.PreflightOrder(context.Background(), req)
+		test.AssertNotError(t, err, "PreflightOrder failed")
+		test.AssertEquals(t, len(resp.Problems), 0)
+	}
+
+	txns, err := ra.txnBuilder.CertificatesPerDomainSpendOnlyTransactions(1, identifier.NewDNSSlice([]string{"example.com"}))
+	test.AssertNotError(t, err, "building transactions")
+	for range 2 {
+		_, err = ra.limiter.BatchSpend(context.Background(), txns)
+		test.AssertNotError(t, err, "spending CertificatesPerDomain")
+	}
+
+	resp, err := ra.PreflightOrder(context.Background(), req)
+	test.AssertNotError(t, err, "PreflightOrder failed")
+	test.AssertEquals(t, len(resp.Problems), 1)
+	test.AssertEquals(t, resp.Problems[0].ProblemType, string(probs.RateLimitedProblem))
+	test.AssertContains(t, resp.Problems[0].Detail, "Order would be rate limited :: ")
+
+	// Renewals are exempt from CertificatesPerDomain.
+	req.IsRenewal = true
+	resp, err = ra.PreflightOrder(context.Background(), req)
+	test.AssertNotError(t, err, "PreflightOrder failed")
+	test.AssertEquals(t, len(resp.Problems), 0)
+}
+
+func TestPreflightOrderDelegatedRateLimits(t *testing.T) {
+	t.Parallel()
+	ra, _, _ := setupPreflight(t)
+	ra.profiles.byName["delegated"] = &validationProfile{
+		maxNames:        3,
+		identifierTypes: []identifier.IdentifierType{identifier.TypeDNS},
+		delegated:       true,
+	}
+	req := &rapb.PreflightOrderRequest{
+		RegistrationID:         1,
+		Identifiers:            preflightIdents("example.com"),
+		CertificateProfileName: "delegated",
+	}
+
+	// Delegated certificates are counted separately from other certificates.
+	ra.countCertificateIssued(context.Background(), 1, identifier.NewDNSSlice([]string{"example.com"}), false, true)
+	ra.countCertificateIssued(context.Background(), 1, identifier.NewDNSSlice([]string{"example.com"}), false, true)
+
+	resp, err := ra.PreflightOrder(context.Background(), req)
+	test.AssertNotError(t, err, "PreflightOrder failed")
+	test.AssertEquals(t, len(resp.Problems), 1)
+	test.AssertEquals(t, resp.Problems[0].ProblemType, string(probs.RateLimitedProblem))
+	test.AssertContains(t, resp.Problems[0].Detail, "too many delegated certificates")
+
+	// Renewals aren't exempt from DelegatedCertificatesPerDomain.
+	req.IsRenewal = true
+	resp, err = ra.PreflightOrder(context.Background(), req)
+	test.AssertNotError(t, err, "PreflightOrder failed")
+	test.AssertEquals(t, len(resp.Problems), 1)
+
+	// Ordinary orders aren't affected.
+	req.CertificateProfileName = ""
+	req.IsRenewal = false
+	resp, err = ra.PreflightOrder(context.Background(), req)
+	test.AssertNotError(t, err, "PreflightOrder failed")
+	test.AssertEquals(t, len(resp.Problems), 0)
+}
@@ -70,8 +70,13 @@ func (x *SCTRequest) GetPrecertDER() []byte {
 }
 
 type SCTResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SctDER        [][]byte               `protobuf:"bytes,1,rep,name=sctDER,proto3" json:"sctDER,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	SctDER [][]byte               `protobuf:"bytes,1,rep,name=sctDER,proto3" json:"sctDER,omitempty"`
+	// sources describes the log which issued each SCT in sctDER, in the same
+	// order.
+	Sources []*SCTSource `protobuf:"bytes,2,rep,name=sources,proto3" json:"sources,omitempty"`
+	// policy describes the CT log policy which the SCTs together satisfy.
+	Policy        string `protobuf:"bytes,3,opt,name=policy,proto3" json:"policy,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -113,6 +118,105 @@ func (x *SCTResponse) GetSctDER() [][]byte {
 	return nil
 }
 
+func (x *SCTResponse) GetSources() []*SCTSource {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *SCTResponse) GetPolicy() string {
+	if x != nil {
+		return x.Policy
+	}
+	return ""
+}
+
+type SCTSource struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// logID is the base64-encoded ID of the log.
+	LogID             string               `protobuf:"bytes,1,opt,name=logID,proto3" json:"logID,omitempty"`
+	LogName           string               `protobuf:"bytes,2,opt,name=logName,proto3" json:"logName,omitempty"`
+	Operator          string               `protobuf:"bytes,3,opt,name=operator,proto3" json:"operator,omitempty"`
+	LogURL            string               `protobuf:"bytes,4,opt,name=logURL,proto3" json:"logURL,omitempty"`
+	Tiled             bool                 `protobuf:"varint,5,opt,name=tiled,proto3" json:"tiled,omitempty"`
+	SubmissionLatency *durationpb.Duration `protobuf:"bytes,6,opt,name=submissionLatency,proto3" json:"submissionLatency,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SCTSource) Reset() {
+	*x = SCTSource{}
+	mi := &file_ra_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SCTSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SCTSource) ProtoMessage() {}
+
+func (x *SCTSource) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SCTSource.ProtoReflect.Descriptor instead.
+func (*SCTSource) Descriptor() ([]byte, []int) {
+	return file_ra_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SCTSource) GetLogID() string {
+	if x != nil {
+		return x.LogID
+	}
+	return ""
+}
+
+func (x *SCTSource) GetLogName() string {
+	if x != nil {
+		return x.LogName
+	}
+	return ""
+}
+
+func (x *SCTSource) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *SCTSource) GetLogURL() string {
+	if x != nil {
+		return x.LogURL
+	}
+	return ""
+}
+
+func (x *SCTSource) GetTiled() bool {
+	if x != nil {
+		return x.Tiled
+	}
+	return false
+}
+
+func (x *SCTSource) GetSubmissionLatency() *durationpb.Duration {
+	if x != nil {
+		return x.SubmissionLatency
+	}
+	return nil
+}
+
 type GenerateOCSPRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Serial        string                 `protobuf:"bytes,1,opt,name=serial,proto3" json:"serial,omitempty"`
@@ -122,7 +226,7 @@ type GenerateOCSPRequest struct {
 
 func (x *GenerateOCSPRequest) Reset() {
 	*x = GenerateOCSPRequest{}
-	mi := &file_ra_proto_msgTypes[2]
+	mi := &file_ra_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -134,7 +238,7 @@ func (x *GenerateOCSPRequest) String() string {
 func (*GenerateOCSPRequest) ProtoMessage() {}
 
 func (x *GenerateOCSPRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ra_proto_msgTypes[2]
+	mi := &file_ra_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -147,7 +251,7 @@ func (x *GenerateOCSPRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerateOCSPRequest.ProtoReflect.Descriptor instead.
 func (*GenerateOCSPRequest) Descriptor() ([]byte, []int) {
-	return file_ra_proto_rawDescGZIP(), []int{2}
+	return file_ra_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GenerateOCSPRequest) GetSerial() string {
@@ -167,7 +271,7 @@ type UpdateRegistrationKeyRequest struct {
 
 func (x *UpdateRegistrationKeyRequest) Reset() {
 	*x = UpdateRegistrationKeyRequest{}
-	mi := &file_ra_proto_msgTypes[3]
+	mi := &file_ra_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -179,7 +283,7 @@ func (x *UpdateRegistrationKeyRequest) String() string {
 func (*UpdateRegistrationKeyRequest) ProtoMessage() {}
 
 func (x *UpdateRegistrationKeyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ra_proto_msgTypes[3]
+	mi := &file_ra_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -192,7 +296,7 @@ func (x *UpdateRegistrationKeyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateRegistrationKeyRequest.ProtoReflect.Descriptor instead.
 func (*UpdateRegistrationKeyRequest) Descriptor() ([]byte, []int) {
-	return file_ra_proto_rawDescGZIP(), []int{3}
+	return file_ra_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *UpdateRegistrationKeyRequest) GetRegistrationID() int64 {
@@ -212,13 +316,16 @@ func (x *UpdateRegistrationKeyRequest) GetJwk() []byte {
 type DeactivateRegistrationRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	RegistrationID int64                  `protobuf:"varint,1,opt,name=registrationID,proto3" json:"registrationID,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// If true, every unexpired certificate issued to the account is revoked
+	// after it's deactivated. Otherwise they're left valid until they expire.
+	RevokeCertificates bool `protobuf:"varint,2,opt,name=revokeCertificates,proto3" json:"revokeCertificates,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *DeactivateRegistrationRequest) Reset() {
 	*x = DeactivateRegistrationRequest{}
-	mi := &file_ra_proto_msgTypes[4]
+	mi := &file_ra_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -230,7 +337,7 @@ func (x *DeactivateRegistrationRequest) String() string {
 func (*DeactivateRegistrationRequest) ProtoMessage() {}
 
 func (x *DeactivateRegistrationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ra_proto_msgTypes[4]
+	mi := &file_ra_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -243,7 +350,7 @@ func (x *DeactivateRegistrationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeactivateRegistrationRequest.ProtoReflect.Descriptor instead.
 func (*DeactivateRegistrationRequest) Descriptor() ([]byte, []int) {
-	return file_ra_proto_rawDescGZIP(), []int{4}
+	return file_ra_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *DeactivateRegistrationRequest) GetRegistrationID() int64 {
@@ -253,6 +360,13 @@ func (x *DeactivateRegistrationRequest) GetRegistrationID() int64 {
 	return 0
 }
 
+func (x *DeactivateRegistrationRequest) GetRevokeCertificates() bool {
+	if x != nil {
+		return x.RevokeCertificates
+	}
+	return false
+}
+
 type UpdateAuthorizationRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Authz          *proto.Authorization   `protobuf:"bytes,1,opt,name=authz,proto3" json:"authz,omitempty"`
@@ -264,7 +378,7 @@ type UpdateAuthorizationRequest struct {
 
 func (x *UpdateAuthorizationRequest) Reset() {
 	*x = UpdateAuthorizationRequest{}
-	mi := &file_ra_proto_msgTypes[5]
+	mi := &file_ra_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -276,7 +390,7 @@ func (x *UpdateAuthorizationRequest) String() string {
 func (*UpdateAuthorizationRequest) ProtoMessage() {}
 
 func (x *UpdateAuthorizationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ra_proto_msgTypes[5]
+	mi := &file_ra_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -289,7 +403,7 @@ func (x *UpdateAuthorizationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateAuthorizationRequest.ProtoReflect.Descriptor instead.
 func (*UpdateAuthorizationRequest) Descriptor() ([]byte, []int) {
-	return file_ra_proto_rawDescGZIP(), []int{5}
+	return file_ra_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *UpdateAuthorizationRequest) GetAuthz() *proto.Authorization {
@@ -323,7 +437,7 @@ type PerformValidationRequest struct {
 
 func (x *PerformValidationRequest) Reset() {
 	*x = PerformValidationRequest{}
-	mi := &file_ra_proto_msgTypes[6]
+	mi := &file_ra_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -335,7 +449,7 @@ func (x *PerformValidationRequest) String() string {
 func (*PerformValidationRequest) ProtoMessage() {}
 
 func (x *PerformValidationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ra_proto_msgTypes[6]
+	mi := &file_ra_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -348,7 +462,7 @@ func (x *PerformValidationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PerformValidationRequest.ProtoReflect.Descriptor instead.
 func (*PerformValidationRequest) Descriptor() ([]byte, []int) {
-	return file_ra_proto_rawDescGZIP(), []int{6}
+	return file_ra_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *PerformValidationRequest) GetAuthz() *proto.Authorization {
@@ -376,7 +490,7 @@ type RevokeCertByApplicantRequest struct {
 
 func (x *RevokeCertByApplicantRequest) Reset() {
 	*x = RevokeCertByApplicantRequest{}
-	mi := &file_ra_proto_msgTypes[7]
+	mi := &file_ra_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -388,7 +502,7 @@ func (x *RevokeCertByApplicantRequest) String() string {
 func (*RevokeCertByApplicantRequest) ProtoMessage() {}
 
 func (x *RevokeCertByApplicantRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ra_proto_msgTypes[7]
+	mi := &file_ra_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -401,7 +515,7 @@ func (x *RevokeCertByApplicantRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RevokeCertByApplicantRequest.ProtoReflect.Descriptor instead.
 func (*RevokeCertByApplicantRequest) Descriptor() ([]byte, []int) {
-	return file_ra_proto_rawDescGZIP(), []int{7}
+	return file_ra_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *RevokeCertByApplicantRequest) GetCert() []byte {
@@ -434,7 +548,7 @@ type RevokeCertByKeyRequest struct {
 
 func (x *RevokeCertByKeyRequest) Reset() {
 	*x = RevokeCertByKeyRequest{}
-	mi := &file_ra_proto_msgTypes[8]
+	mi := &file_ra_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -446,7 +560,7 @@ func (x *RevokeCertByKeyRequest) String() string {
 func (*RevokeCertByKeyRequest) ProtoMessage() {}
 
 func (x *RevokeCertByKeyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ra_proto_msgTypes[8]
+	mi := &file_ra_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -459,7 +573,7 @@ func (x *RevokeCertByKeyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RevokeCertByKeyRequest.ProtoReflect.Descriptor instead.
 func (*RevokeCertByKeyRequest) Descriptor() ([]byte, []int) {
-	return file_ra_proto_rawDescGZIP(), []int{8}
+	return file_ra_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *RevokeCertByKeyRequest) GetCert() []byte {
@@ -496,7 +610,7 @@ type AdministrativelyRevokeCertificateRequest struct {
 
 func (x *AdministrativelyRevokeCertificateRequest) Reset() {
 	*x = AdministrativelyRevokeCertificateRequest{}
-	mi := &file_ra_proto_msgTypes[9]
+	mi := &file_ra_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -508,7 +622,7 @@ func (x *AdministrativelyRevokeCertificateRequest) String() string {
 func (*AdministrativelyRevokeCertificateRequest) ProtoMessage() {}
 
 func (x *AdministrativelyRevokeCertificateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ra_proto_msgTypes[9]
+	mi := &file_ra_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -521,7 +635,7 @@ func (x *AdministrativelyRevokeCertificateRequest) ProtoReflect() protoreflect.M
 
 // Deprecated: Use AdministrativelyRevokeCertificateRequest.ProtoReflect.Descriptor instead.
 func (*AdministrativelyRevokeCertificateRequest) Descriptor() ([]byte, []int) {
-	return file_ra_proto_rawDescGZIP(), []int{9}
+	return file_ra_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *AdministrativelyRevokeCertificateRequest) GetCert() []byte {
@@ -573,6 +687,177 @@ func (x *AdministrativelyRevokeCertificateRequest) GetCrlShard() int64 {
 	return 0
 }
 
+type ReportKeyCompromiseRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Exactly one of spki and keyHash must be set.
+	//
+	// The DER-encoded SubjectPublicKeyInfo of the compromised key. If set, the
+	// signature field is required.
+	Spki []byte `protobuf:"bytes,1,opt,name=spki,proto3" json:"spki,omitempty"`
+	// A signature by the compromised key over
+	// revocation.KeyCompromiseMessage(keyHash), demonstrating possession of it.
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	// The SHA-256 hash of the compromised key's SubjectPublicKeyInfo, for keys
+	// whose compromise has been established some other way.
+	KeyHash       []byte `protobuf:"bytes,3,opt,name=keyHash,proto3" json:"keyHash,omitempty"`
+	AdminName     string `protobuf:"bytes,4,opt,name=adminName,proto3" json:"adminName,omitempty"`
+	Comment       string `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportKeyCompromiseRequest) Reset() {
+	*x = ReportKeyCompromiseRequest{}
+	mi := &file_ra_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportKeyCompromiseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportKeyCompromiseRequest) ProtoMessage() {}
+
+func (x *ReportKeyCompromiseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportKeyCompromiseRequest.ProtoReflect.Descriptor instead.
+func (*ReportKeyCompromiseRequest) Descriptor() ([]byte, []int) {
+	return file_ra_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ReportKeyCompromiseRequest) GetSpki() []byte {
+	if x != nil {
+		return x.Spki
+	}
+	return nil
+}
+
+func (x *ReportKeyCompromiseRequest) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *ReportKeyCompromiseRequest) GetKeyHash() []byte {
+	if x != nil {
+		return x.KeyHash
+	}
+	return nil
+}
+
+func (x *ReportKeyCompromiseRequest) GetAdminName() string {
+	if x != nil {
+		return x.AdminName
+	}
+	return ""
+}
+
+func (x *ReportKeyCompromiseRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type KeyCompromiseProgress struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The first message describes the key, and has no serial. Each message
+	// after it describes one certificate with the key.
+	KeyHash            []byte `protobuf:"bytes,1,opt,name=keyHash,proto3" json:"keyHash,omitempty"`
+	PossessionVerified bool   `protobuf:"varint,2,opt,name=possessionVerified,proto3" json:"possessionVerified,omitempty"`
+	AlreadyBlocked     bool   `protobuf:"varint,3,opt,name=alreadyBlocked,proto3" json:"alreadyBlocked,omitempty"`
+	Serial             string `protobuf:"bytes,4,opt,name=serial,proto3" json:"serial,omitempty"`
+	// One of "revoked", "alreadyRevoked", or "failed".
+	Result        string `protobuf:"bytes,5,opt,name=result,proto3" json:"result,omitempty"`
+	Error         string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyCompromiseProgress) Reset() {
+	*x = KeyCompromiseProgress{}
+	mi := &file_ra_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyCompromiseProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyCompromiseProgress) ProtoMessage() {}
+
+func (x *KeyCompromiseProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyCompromiseProgress.ProtoReflect.Descriptor instead.
+func (*KeyCompromiseProgress) Descriptor() ([]byte, []int) {
+	return file_ra_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *KeyCompromiseProgress) GetKeyHash() []byte {
+	if x != nil {
+		return x.KeyHash
+	}
+	return nil
+}
+
+func (x *KeyCompromiseProgress) GetPossessionVerified() bool {
+	if x != nil {
+		return x.PossessionVerified
+	}
+	return false
+}
+
+func (x *KeyCompromiseProgress) GetAlreadyBlocked() bool {
+	if x != nil {
+		return x.AlreadyBlocked
+	}
+	return false
+}
+
+func (x *KeyCompromiseProgress) GetSerial() string {
+	if x != nil {
+		return x.Serial
+	}
+	return ""
+}
+
+func (x *KeyCompromiseProgress) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *KeyCompromiseProgress) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 type NewOrderRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Next unused field number: 9
@@ -589,7 +874,7 @@ type NewOrderRequest struct {
 
 func (x *NewOrderRequest) Reset() {
 	*x = NewOrderRequest{}
-	mi := &file_ra_proto_msgTypes[10]
+	mi := &file_ra_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -601,7 +886,7 @@ func (x *NewOrderRequest) String() string {
 func (*NewOrderRequest) ProtoMessage() {}
 
 func (x *NewOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ra_proto_msgTypes[10]
+	mi := &file_ra_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -614,7 +899,7 @@ func (x *NewOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewOrderRequest.ProtoReflect.Descriptor instead.
 func (*NewOrderRequest) Descriptor() ([]byte, []int) {
-	return file_ra_proto_rawDescGZIP(), []int{10}
+	return file_ra_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *NewOrderRequest) GetRegistrationID() int64 {
@@ -652,6 +937,306 @@ func (x *NewOrderRequest) GetReplacesSerial() string {
 	return ""
 }
 
+type PreflightOrderRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Next unused field number: 6
+	RegistrationID         int64               `protobuf:"varint,1,opt,name=registrationID,proto3" json:"registrationID,omitempty"`
+	Identifiers            []*proto.Identifier `protobuf:"bytes,2,rep,name=identifiers,proto3" json:"identifiers,omitempty"`
+	CertificateProfileName string              `protobuf:"bytes,3,opt,name=certificateProfileName,proto3" json:"certificateProfileName,omitempty"`
+	// isRenewal exempts the order from the limits which renewals are exempt
+	// from, as determined by the caller.
+	IsRenewal bool `protobuf:"varint,4,opt,name=isRenewal,proto3" json:"isRenewal,omitempty"`
+	// challengeType, if set, is the validation method for which CAA is
+	// checked. Otherwise, CAA must permit at least one of the methods by which
+	// each identifier could be validated.
+	ChallengeType string `protobuf:"bytes,5,opt,name=challengeType,proto3" json:"challengeType,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreflightOrderRequest) Reset() {
+	*x = PreflightOrderRequest{}
+	mi := &file_ra_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreflightOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreflightOrderRequest) ProtoMessage() {}
+
+func (x *PreflightOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreflightOrderRequest.ProtoReflect.Descriptor instead.
+func (*PreflightOrderRequest) Descriptor() ([]byte, []int) {
+	return file_ra_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PreflightOrderRequest) GetRegistrationID() int64 {
+	if x != nil {
+		return x.RegistrationID
+	}
+	return 0
+}
+
+func (x *PreflightOrderRequest) GetIdentifiers() []*proto.Identifier {
+	if x != nil {
+		return x.Identifiers
+	}
+	return nil
+}
+
+func (x *PreflightOrderRequest) GetCertificateProfileName() string {
+	if x != nil {
+		return x.CertificateProfileName
+	}
+	return ""
+}
+
+func (x *PreflightOrderRequest) GetIsRenewal() bool {
+	if x != nil {
+		return x.IsRenewal
+	}
+	return false
+}
+
+func (x *PreflightOrderRequest) GetChallengeType() string {
+	if x != nil {
+		return x.ChallengeType
+	}
+	return ""
+}
+
+type PreflightOrderResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// problems are the reasons the order as a whole would be rejected, such as
+	// an unknown profile or an exceeded rate limit.
+	Problems []*proto.ProblemDetails `protobuf:"bytes,1,rep,name=problems,proto3" json:"problems,omitempty"`
+	// identifiers holds the result of the policy and CAA checks for each
+	// identifier in the order.
+	Identifiers   []*PreflightIdentifier `protobuf:"bytes,2,rep,name=identifiers,proto3" json:"identifiers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreflightOrderResponse) Reset() {
+	*x = PreflightOrderResponse{}
+	mi := &file_ra_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreflightOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreflightOrderResponse) ProtoMessage() {}
+
+func (x *PreflightOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreflightOrderResponse.ProtoReflect.Descriptor instead.
+func (*PreflightOrderResponse) Descriptor() ([]byte, []int) {
+	return file_ra_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PreflightOrderResponse) GetProblems() []*proto.ProblemDetails {
+	if x != nil {
+		return x.Problems
+	}
+	return nil
+}
+
+func (x *PreflightOrderResponse) GetIdentifiers() []*PreflightIdentifier {
+	if x != nil {
+		return x.Identifiers
+	}
+	return nil
+}
+
+type PreflightIdentifier struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *proto.Identifier      `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	// problem is unset if the identifier passed every check.
+	Problem       *proto.ProblemDetails `protobuf:"bytes,2,opt,name=problem,proto3" json:"problem,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreflightIdentifier) Reset() {
+	*x = PreflightIdentifier{}
+	mi := &file_ra_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreflightIdentifier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreflightIdentifier) ProtoMessage() {}
+
+func (x *PreflightIdentifier) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreflightIdentifier.ProtoReflect.Descriptor instead.
+func (*PreflightIdentifier) Descriptor() ([]byte, []int) {
+	return file_ra_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PreflightIdentifier) GetIdentifier() *proto.Identifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *PreflightIdentifier) GetProblem() *proto.ProblemDetails {
+	if x != nil {
+		return x.Problem
+	}
+	return nil
+}
+
+type GetAccountKeyAlgorithmRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Next unused field number: 2
+	RegistrationID int64 `protobuf:"varint,1,opt,name=registrationID,proto3" json:"registrationID,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetAccountKeyAlgorithmRequest) Reset() {
+	*x = GetAccountKeyAlgorithmRequest{}
+	mi := &file_ra_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountKeyAlgorithmRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountKeyAlgorithmRequest) ProtoMessage() {}
+
+func (x *GetAccountKeyAlgorithmRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountKeyAlgorithmRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountKeyAlgorithmRequest) Descriptor() ([]byte, []int) {
+	return file_ra_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetAccountKeyAlgorithmRequest) GetRegistrationID() int64 {
+	if x != nil {
+		return x.RegistrationID
+	}
+	return 0
+}
+
+type AccountKeyAlgorithm struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Next unused field number: 4
+	// algorithm is the JWS signature algorithm used with the key, e.g. "ES256".
+	Algorithm string `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	// keyType is "RSA", "ECDSA", or "Ed25519".
+	KeyType string `protobuf:"bytes,2,opt,name=keyType,proto3" json:"keyType,omitempty"`
+	// keySize is the size of the RSA modulus, or of the curve, in bits.
+	KeySize       int32 `protobuf:"varint,3,opt,name=keySize,proto3" json:"keySize,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountKeyAlgorithm) Reset() {
+	*x = AccountKeyAlgorithm{}
+	mi := &file_ra_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountKeyAlgorithm) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountKeyAlgorithm) ProtoMessage() {}
+
+func (x *AccountKeyAlgorithm) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountKeyAlgorithm.ProtoReflect.Descriptor instead.
+func (*AccountKeyAlgorithm) Descriptor() ([]byte, []int) {
+	return file_ra_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AccountKeyAlgorithm) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *AccountKeyAlgorithm) GetKeyType() string {
+	if x != nil {
+		return x.KeyType
+	}
+	return ""
+}
+
+func (x *AccountKeyAlgorithm) GetKeySize() int32 {
+	if x != nil {
+		return x.KeySize
+	}
+	return 0
+}
+
 type GetAuthorizationRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -661,7 +1246,7 @@ type GetAuthorizationRequest struct {
 
 func (x *GetAuthorizationRequest) Reset() {
 	*x = GetAuthorizationRequest{}
-	mi := &file_ra_proto_msgTypes[11]
+	mi := &file_ra_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -673,7 +1258,7 @@ func (x *GetAuthorizationRequest) String() string {
 func (*GetAuthorizationRequest) ProtoMessage() {}
 
 func (x *GetAuthorizationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ra_proto_msgTypes[11]
+	mi := &file_ra_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -686,7 +1271,7 @@ func (x *GetAuthorizationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAuthorizationRequest.ProtoReflect.Descriptor instead.
 func (*GetAuthorizationRequest) Descriptor() ([]byte, []int) {
-	return file_ra_proto_rawDescGZIP(), []int{11}
+	return file_ra_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *GetAuthorizationRequest) GetId() int64 {
@@ -696,6 +1281,51 @@ func (x *GetAuthorizationRequest) GetId() int64 {
 	return 0
 }
 
+type AwaitValidationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Next unused field number: 2
+	AuthzID       int64 `protobuf:"varint,1,opt,name=authzID,proto3" json:"authzID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AwaitValidationRequest) Reset() {
+	*x = AwaitValidationRequest{}
+	mi := &file_ra_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AwaitValidationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AwaitValidationRequest) ProtoMessage() {}
+
+func (x *AwaitValidationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AwaitValidationRequest.ProtoReflect.Descriptor instead.
+func (*AwaitValidationRequest) Descriptor() ([]byte, []int) {
+	return file_ra_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AwaitValidationRequest) GetAuthzID() int64 {
+	if x != nil {
+		return x.AuthzID
+	}
+	return 0
+}
+
 type FinalizeOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Order         *proto.Order           `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
@@ -706,7 +1336,7 @@ type FinalizeOrderRequest struct {
 
 func (x *FinalizeOrderRequest) Reset() {
 	*x = FinalizeOrderRequest{}
-	mi := &file_ra_proto_msgTypes[12]
+	mi := &file_ra_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -718,7 +1348,7 @@ func (x *FinalizeOrderRequest) String() string {
 func (*FinalizeOrderRequest) ProtoMessage() {}
 
 func (x *FinalizeOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ra_proto_msgTypes[12]
+	mi := &file_ra_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -731,7 +1361,7 @@ func (x *FinalizeOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FinalizeOrderRequest.ProtoReflect.Descriptor instead.
 func (*FinalizeOrderRequest) Descriptor() ([]byte, []int) {
-	return file_ra_proto_rawDescGZIP(), []int{12}
+	return file_ra_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *FinalizeOrderRequest) GetOrder() *proto.Order {
@@ -758,7 +1388,7 @@ type UnpauseAccountRequest struct {
 
 func (x *UnpauseAccountRequest) Reset() {
 	*x = UnpauseAccountRequest{}
-	mi := &file_ra_proto_msgTypes[13]
+	mi := &file_ra_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -770,7 +1400,7 @@ func (x *UnpauseAccountRequest) String() string {
 func (*UnpauseAccountRequest) ProtoMessage() {}
 
 func (x *UnpauseAccountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ra_proto_msgTypes[13]
+	mi := &file_ra_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -783,7 +1413,7 @@ func (x *UnpauseAccountRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnpauseAccountRequest.ProtoReflect.Descriptor instead.
 func (*UnpauseAccountRequest) Descriptor() ([]byte, []int) {
-	return file_ra_proto_rawDescGZIP(), []int{13}
+	return file_ra_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *UnpauseAccountRequest) GetRegistrationID() int64 {
@@ -803,7 +1433,7 @@ type UnpauseAccountResponse struct {
 
 func (x *UnpauseAccountResponse) Reset() {
 	*x = UnpauseAccountResponse{}
-	mi := &file_ra_proto_msgTypes[14]
+	mi := &file_ra_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -815,7 +1445,7 @@ func (x *UnpauseAccountResponse) String() string {
 func (*UnpauseAccountResponse) ProtoMessage() {}
 
 func (x *UnpauseAccountResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ra_proto_msgTypes[14]
+	mi := &file_ra_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -828,7 +1458,7 @@ func (x *UnpauseAccountResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnpauseAccountResponse.ProtoReflect.Descriptor instead.
 func (*UnpauseAccountResponse) Descriptor() ([]byte, []int) {
-	return file_ra_proto_rawDescGZIP(), []int{14}
+	return file_ra_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *UnpauseAccountResponse) GetCount() int64 {
@@ -852,7 +1482,7 @@ type AddRateLimitOverrideRequest struct {
 
 func (x *AddRateLimitOverrideRequest) Reset() {
 	*x = AddRateLimitOverrideRequest{}
-	mi := &file_ra_proto_msgTypes[15]
+	mi := &file_ra_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -864,7 +1494,7 @@ func (x *AddRateLimitOverrideRequest) String() string {
 func (*AddRateLimitOverrideRequest) ProtoMessage() {}
 
 func (x *AddRateLimitOverrideRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ra_proto_msgTypes[15]
+	mi := &file_ra_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -877,7 +1507,7 @@ func (x *AddRateLimitOverrideRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddRateLimitOverrideRequest.ProtoReflect.Descriptor instead.
 func (*AddRateLimitOverrideRequest) Descriptor() ([]byte, []int) {
-	return file_ra_proto_rawDescGZIP(), []int{15}
+	return file_ra_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *AddRateLimitOverrideRequest) GetLimitEnum() int64 {
@@ -932,7 +1562,7 @@ type AddRateLimitOverrideResponse struct {
 
 func (x *AddRateLimitOverrideResponse) Reset() {
 	*x = AddRateLimitOverrideResponse{}
-	mi := &file_ra_proto_msgTypes[16]
+	mi := &file_ra_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -944,7 +1574,7 @@ func (x *AddRateLimitOverrideResponse) String() string {
 func (*AddRateLimitOverrideResponse) ProtoMessage() {}
 
 func (x *AddRateLimitOverrideResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ra_proto_msgTypes[16]
+	mi := &file_ra_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -957,7 +1587,7 @@ func (x *AddRateLimitOverrideResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddRateLimitOverrideResponse.ProtoReflect.Descriptor instead.
 func (*AddRateLimitOverrideResponse) Descriptor() ([]byte, []int) {
-	return file_ra_proto_rawDescGZIP(), []int{16}
+	return file_ra_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *AddRateLimitOverrideResponse) GetInserted() bool {
@@ -987,81 +1617,170 @@ var file_ra_proto_rawDesc = string([]byte{
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x2c, 0x0a, 0x0a, 0x53, 0x43, 0x54, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x65, 0x63, 0x65, 0x72, 0x74, 0x44, 0x45,
 	0x52, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x72, 0x65, 0x63, 0x65, 0x72, 0x74,
-	0x44, 0x45, 0x52, 0x22, 0x25, 0x0a, 0x0b, 0x53, 0x43, 0x54, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x44, 0x45, 0x52, 0x22, 0x66, 0x0a, 0x0b, 0x53, 0x43, 0x54, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
 	0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x74, 0x44, 0x45, 0x52, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0c, 0x52, 0x06, 0x73, 0x63, 0x74, 0x44, 0x45, 0x52, 0x22, 0x2d, 0x0a, 0x13, 0x47, 0x65,
-	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x4f, 0x43, 0x53, 0x50, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x22, 0x58, 0x0a, 0x1c, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4b,
-	0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x67,
-	0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x0e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
-	0x44, 0x12, 0x10, 0x0a, 0x03, 0x6a, 0x77, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03,
-	0x6a, 0x77, 0x6b, 0x22, 0x47, 0x0a, 0x1d, 0x44, 0x65, 0x61, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x28, 0x0c, 0x52, 0x06, 0x73, 0x63, 0x74, 0x44, 0x45, 0x52, 0x12, 0x27, 0x0a, 0x07, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x72, 0x61,
+	0x2e, 0x53, 0x43, 0x54, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x07, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x22, 0xce, 0x01, 0x0a, 0x09,
+	0x53, 0x43, 0x54, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x67,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x12,
+	0x18, 0x0a, 0x07, 0x6c, 0x6f, 0x67, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6c, 0x6f, 0x67, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x67, 0x55, 0x52, 0x4c, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x6f, 0x67, 0x55, 0x52, 0x4c, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x74, 0x69,
+	0x6c, 0x65, 0x64, 0x12, 0x47, 0x0a, 0x11, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x73, 0x75, 0x62, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x2d, 0x0a, 0x13,
+	0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x4f, 0x43, 0x53, 0x50, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x22, 0x58, 0x0a, 0x1c, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x72,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x44, 0x12, 0x10, 0x0a, 0x03, 0x6a, 0x77, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x03, 0x6a, 0x77, 0x6b, 0x22, 0x77, 0x0a, 0x1d, 0x44, 0x65, 0x61, 0x63, 0x74, 0x69, 0x76,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e,
+	0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x2e,
+	0x0a, 0x12, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x72, 0x65, 0x76, 0x6f,
+	0x6b, 0x65, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x22, 0x9c,
+	0x01, 0x0a, 0x1a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a,
+	0x05, 0x61, 0x75, 0x74, 0x68, 0x7a, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x05, 0x61, 0x75, 0x74, 0x68, 0x7a, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x68, 0x61, 0x6c,
+	0x6c, 0x65, 0x6e, 0x67, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0e, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x2b, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65,
+	0x6e, 0x67, 0x65, 0x52, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x6d, 0x0a,
+	0x18, 0x50, 0x65, 0x72, 0x66, 0x6f, 0x72, 0x6d, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x05, 0x61, 0x75, 0x74,
+	0x68, 0x7a, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e,
+	0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x61,
+	0x75, 0x74, 0x68, 0x7a, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67,
+	0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x63, 0x68,
+	0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x5c, 0x0a, 0x1c,
+	0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x43, 0x65, 0x72, 0x74, 0x42, 0x79, 0x41, 0x70, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x63, 0x65, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x63, 0x65, 0x72, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
+	0x63, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x67, 0x49, 0x44, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x72, 0x65, 0x67, 0x49, 0x44, 0x22, 0x32, 0x0a, 0x16, 0x52, 0x65,
+	0x76, 0x6f, 0x6b, 0x65, 0x43, 0x65, 0x72, 0x74, 0x42, 0x79, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x65, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x04, 0x63, 0x65, 0x72, 0x74, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x22, 0xe6,
+	0x01, 0x0a, 0x28, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x76,
+	0x65, 0x6c, 0x79, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63,
+	0x65, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x63, 0x65, 0x72, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x61, 0x64, 0x6d, 0x69, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x6b, 0x69,
+	0x70, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0c, 0x73, 0x6b, 0x69, 0x70, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a,
+	0x09, 0x6d, 0x61, 0x6c, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x6d, 0x61, 0x6c, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x63,
+	0x72, 0x6c, 0x53, 0x68, 0x61, 0x72, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x63,
+	0x72, 0x6c, 0x53, 0x68, 0x61, 0x72, 0x64, 0x22, 0xa0, 0x01, 0x0a, 0x1a, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x4b, 0x65, 0x79, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x6f, 0x6d, 0x69, 0x73, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x70, 0x6b, 0x69, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x70, 0x6b, 0x69, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6b, 0x65, 0x79, 0x48,
+	0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x48, 0x61,
+	0x73, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0xcf, 0x01, 0x0a, 0x15, 0x4b,
+	0x65, 0x79, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x6f, 0x6d, 0x69, 0x73, 0x65, 0x50, 0x72, 0x6f, 0x67,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6b, 0x65, 0x79, 0x48, 0x61, 0x73, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2e,
+	0x0a, 0x12, 0x70, 0x6f, 0x73, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x56, 0x65, 0x72, 0x69,
+	0x66, 0x69, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x70, 0x6f, 0x73, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x12, 0x26,
+	0x0a, 0x0e, 0x61, 0x6c, 0x72, 0x65, 0x61, 0x64, 0x79, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x61, 0x6c, 0x72, 0x65, 0x61, 0x64, 0x79, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xfb, 0x01, 0x0a,
+	0x0f, 0x4e, 0x65, 0x77, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x32, 0x0a, 0x0b, 0x69, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52,
+	0x0b, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73, 0x12, 0x36, 0x0a, 0x16,
+	0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x16, 0x63, 0x65,
+	0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x73,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x73,
+	0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x73, 0x53, 0x65, 0x72, 0x69,
+	0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63,
+	0x65, 0x73, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x4a, 0x04,
+	0x08, 0x04, 0x10, 0x05, 0x4a, 0x04, 0x08, 0x06, 0x10, 0x07, 0x22, 0xef, 0x01, 0x0a, 0x15, 0x50,
+	0x72, 0x65, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61,
 	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x72, 0x65,
-	0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x22, 0x9c, 0x01, 0x0a,
-	0x1a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x05, 0x61,
-	0x75, 0x74, 0x68, 0x7a, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x72,
-	0x65, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x05, 0x61, 0x75, 0x74, 0x68, 0x7a, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65,
-	0x6e, 0x67, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e,
-	0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x2b,
-	0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x0f, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67,
-	0x65, 0x52, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x6d, 0x0a, 0x18, 0x50,
-	0x65, 0x72, 0x66, 0x6f, 0x72, 0x6d, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x05, 0x61, 0x75, 0x74, 0x68, 0x7a,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x41, 0x75,
-	0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x61, 0x75, 0x74,
-	0x68, 0x7a, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x49,
-	0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x63, 0x68, 0x61, 0x6c,
-	0x6c, 0x65, 0x6e, 0x67, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x5c, 0x0a, 0x1c, 0x52, 0x65,
-	0x76, 0x6f, 0x6b, 0x65, 0x43, 0x65, 0x72, 0x74, 0x42, 0x79, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63,
-	0x61, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x65,
-	0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x63, 0x65, 0x72, 0x74, 0x12, 0x12,
-	0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x63, 0x6f,
-	0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x67, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x05, 0x72, 0x65, 0x67, 0x49, 0x44, 0x22, 0x32, 0x0a, 0x16, 0x52, 0x65, 0x76, 0x6f,
-	0x6b, 0x65, 0x43, 0x65, 0x72, 0x74, 0x42, 0x79, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x65, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x04, 0x63, 0x65, 0x72, 0x74, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x22, 0xe6, 0x01, 0x0a,
-	0x28, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x76, 0x65, 0x6c,
-	0x79, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x65, 0x72,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x63, 0x65, 0x72, 0x74, 0x12, 0x16, 0x0a,
-	0x06, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
-	0x65, 0x72, 0x69, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x6d,
-	0x69, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x64,
-	0x6d, 0x69, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x6b, 0x69, 0x70, 0x42,
-	0x6c, 0x6f, 0x63, 0x6b, 0x4b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x73,
-	0x6b, 0x69, 0x70, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x6d,
-	0x61, 0x6c, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09,
-	0x6d, 0x61, 0x6c, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x72, 0x6c,
-	0x53, 0x68, 0x61, 0x72, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x63, 0x72, 0x6c,
-	0x53, 0x68, 0x61, 0x72, 0x64, 0x22, 0xfb, 0x01, 0x0a, 0x0f, 0x4e, 0x65, 0x77, 0x4f, 0x72, 0x64,
-	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x67,
-	0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x0e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
-	0x44, 0x12, 0x32, 0x0a, 0x0b, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73,
-	0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x49, 0x64,
-	0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x0b, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69,
-	0x66, 0x69, 0x65, 0x72, 0x73, 0x12, 0x36, 0x0a, 0x16, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69,
-	0x63, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x16, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
-	0x74, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a,
-	0x08, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x70,
-	0x6c, 0x61, 0x63, 0x65, 0x73, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0e, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x73, 0x53, 0x65, 0x72, 0x69, 0x61,
-	0x6c, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x4a, 0x04, 0x08,
-	0x06, 0x10, 0x07, 0x22, 0x29, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72,
-	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x4b,
+	0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x32, 0x0a, 0x0b,
+	0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66,
+	0x69, 0x65, 0x72, 0x52, 0x0b, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73,
+	0x12, 0x36, 0x0a, 0x16, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x16, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x73, 0x52, 0x65,
+	0x6e, 0x65, 0x77, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x52,
+	0x65, 0x6e, 0x65, 0x77, 0x61, 0x6c, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65,
+	0x6e, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63,
+	0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x22, 0x85, 0x01, 0x0a,
+	0x16, 0x50, 0x72, 0x65, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x62, 0x6c,
+	0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x2e, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52,
+	0x08, 0x70, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x73, 0x12, 0x39, 0x0a, 0x0b, 0x69, 0x64, 0x65,
+	0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x72, 0x61, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x49, 0x64, 0x65,
+	0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x0b, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66,
+	0x69, 0x65, 0x72, 0x73, 0x22, 0x77, 0x0a, 0x13, 0x50, 0x72, 0x65, 0x66, 0x6c, 0x69, 0x67, 0x68,
+	0x74, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x30, 0x0a, 0x0a, 0x69,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65,
+	0x72, 0x52, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x2e, 0x0a,
+	0x07, 0x70, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x44, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x73, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x22, 0x47, 0x0a,
+	0x1d, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4b, 0x65, 0x79, 0x41, 0x6c,
+	0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26,
+	0x0a, 0x0e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x22, 0x67, 0x0a, 0x13, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x4b, 0x65, 0x79, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x1c, 0x0a,
+	0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x18, 0x0a, 0x07, 0x6b,
+	0x65, 0x79, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6b, 0x65,
+	0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6b, 0x65, 0x79, 0x53, 0x69, 0x7a, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x53, 0x69, 0x7a, 0x65, 0x22,
+	0x29, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x32, 0x0a, 0x16, 0x41, 0x77,
+	0x61, 0x69, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x75, 0x74, 0x68, 0x7a, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x61, 0x75, 0x74, 0x68, 0x7a, 0x49, 0x44, 0x22, 0x4b,
 	0x0a, 0x14, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x4f, 0x72, 0x64,
@@ -1092,7 +1811,7 @@ var file_ra_proto_rawDesc = string([]byte{
 	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a,
 	0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
-	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x32, 0xae, 0x08, 0x0a, 0x15, 0x52, 0x65, 0x67, 0x69,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x32, 0xed, 0x0a, 0x0a, 0x15, 0x52, 0x65, 0x67, 0x69,
 	0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74,
 	0x79, 0x12, 0x3b, 0x0a, 0x0f, 0x4e, 0x65, 0x77, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61,
 	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x52, 0x65, 0x67, 0x69,
@@ -1159,14 +1878,34 @@ var file_ra_proto_rawDesc = string([]byte{
 	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x72, 0x61, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x61, 0x74,
 	0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x3b, 0x0a, 0x0b, 0x53, 0x43, 0x54, 0x50,
-	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x2c, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x53, 0x43,
-	0x54, 0x73, 0x12, 0x0e, 0x2e, 0x72, 0x61, 0x2e, 0x53, 0x43, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x72, 0x61, 0x2e, 0x53, 0x43, 0x54, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x29, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x65, 0x74, 0x73, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x2f,
-	0x62, 0x6f, 0x75, 0x6c, 0x64, 0x65, 0x72, 0x2f, 0x72, 0x61, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x13, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x4b, 0x65, 0x79, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x6f, 0x6d, 0x69, 0x73, 0x65, 0x12,
+	0x1e, 0x2e, 0x72, 0x61, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x4b, 0x65, 0x79, 0x43, 0x6f,
+	0x6d, 0x70, 0x72, 0x6f, 0x6d, 0x69, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x19, 0x2e, 0x72, 0x61, 0x2e, 0x4b, 0x65, 0x79, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x6f, 0x6d, 0x69,
+	0x73, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x22, 0x00, 0x30, 0x01, 0x12, 0x49,
+	0x0a, 0x0e, 0x50, 0x72, 0x65, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x12, 0x19, 0x2e, 0x72, 0x61, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x72, 0x61,
+	0x2e, 0x50, 0x72, 0x65, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x56, 0x0a, 0x16, 0x47, 0x65, 0x74,
+	0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4b, 0x65, 0x79, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69,
+	0x74, 0x68, 0x6d, 0x12, 0x21, 0x2e, 0x72, 0x61, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x4b, 0x65, 0x79, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x72, 0x61, 0x2e, 0x41, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x4b, 0x65, 0x79, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x22,
+	0x00, 0x12, 0x44, 0x0a, 0x0f, 0x41, 0x77, 0x61, 0x69, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x2e, 0x72, 0x61, 0x2e, 0x41, 0x77, 0x61, 0x69, 0x74, 0x56,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x00, 0x32, 0x3b, 0x0a, 0x0b, 0x53, 0x43, 0x54, 0x50, 0x72,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x2c, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x53, 0x43, 0x54,
+	0x73, 0x12, 0x0e, 0x2e, 0x72, 0x61, 0x2e, 0x53, 0x43, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x0f, 0x2e, 0x72, 0x61, 0x2e, 0x53, 0x43, 0x54, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x42, 0x29, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x6c, 0x65, 0x74, 0x73, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x2f, 0x62,
+	0x6f, 0x75, 0x6c, 0x64, 0x65, 0x72, 0x2f, 0x72, 0x61, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
@@ -1181,76 +1920,101 @@ func file_ra_proto_rawDescGZIP() []byte {
 	return file_ra_proto_rawDescData
 }
 
-var file_ra_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_ra_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_ra_proto_goTypes = []any{
 	(*SCTRequest)(nil),                               // 0: ra.SCTRequest
 	(*SCTResponse)(nil),                              // 1: ra.SCTResponse
-	(*GenerateOCSPRequest)(nil),                      // 2: ra.GenerateOCSPRequest
-	(*UpdateRegistrationKeyRequest)(nil),             // 3: ra.UpdateRegistrationKeyRequest
-	(*DeactivateRegistrationRequest)(nil),            // 4: ra.DeactivateRegistrationRequest
-	(*UpdateAuthorizationRequest)(nil),               // 5: ra.UpdateAuthorizationRequest
-	(*PerformValidationRequest)(nil),                 // 6: ra.PerformValidationRequest
-	(*RevokeCertByApplicantRequest)(nil),             // 7: ra.RevokeCertByApplicantRequest
-	(*RevokeCertByKeyRequest)(nil),                   // 8: ra.RevokeCertByKeyRequest
-	(*AdministrativelyRevokeCertificateRequest)(nil), // 9: ra.AdministrativelyRevokeCertificateRequest
-	(*NewOrderRequest)(nil),                          // 10: ra.NewOrderRequest
-	(*GetAuthorizationRequest)(nil),                  // 11: ra.GetAuthorizationRequest
-	(*FinalizeOrderRequest)(nil),                     // 12: ra.FinalizeOrderRequest
-	(*UnpauseAccountRequest)(nil),                    // 13: ra.UnpauseAccountRequest
-	(*UnpauseAccountResponse)(nil),                   // 14: ra.UnpauseAccountResponse
-	(*AddRateLimitOverrideRequest)(nil),              // 15: ra.AddRateLimitOverrideRequest
-	(*AddRateLimitOverrideResponse)(nil),             // 16: ra.AddRateLimitOverrideResponse
-	(*proto.Authorization)(nil),                      // 17: core.Authorization
-	(*proto.Challenge)(nil),                          // 18: core.Challenge
-	(*proto.Identifier)(nil),                         // 19: core.Identifier
-	(*proto.Order)(nil),                              // 20: core.Order
-	(*durationpb.Duration)(nil),                      // 21: google.protobuf.Duration
-	(*proto.Registration)(nil),                       // 22: core.Registration
-	(*emptypb.Empty)(nil),                            // 23: google.protobuf.Empty
-	(*proto1.OCSPResponse)(nil),                      // 24: ca.OCSPResponse
+	(*SCTSource)(nil),                                // 2: ra.SCTSource
+	(*GenerateOCSPRequest)(nil),                      // 3: ra.GenerateOCSPRequest
+	(*UpdateRegistrationKeyRequest)(nil),             // 4: ra.UpdateRegistrationKeyRequest
+	(*DeactivateRegistrationRequest)(nil),            // 5: ra.DeactivateRegistrationRequest
+	(*UpdateAuthorizationRequest)(nil),               // 6: ra.UpdateAuthorizationRequest
+	(*PerformValidationRequest)(nil),                 // 7: ra.PerformValidationRequest
+	(*RevokeCertByApplicantRequest)(nil),             // 8: ra.RevokeCertByApplicantRequest
+	(*RevokeCertByKeyRequest)(nil),                   // 9: ra.RevokeCertByKeyRequest
+	(*AdministrativelyRevokeCertificateRequest)(nil), // 10: ra.AdministrativelyRevokeCertificateRequest
+	(*ReportKeyCompromiseRequest)(nil),               // 11: ra.ReportKeyCompromiseRequest
+	(*KeyCompromiseProgress)(nil),                    // 12: ra.KeyCompromiseProgress
+	(*NewOrderRequest)(nil),                          // 13: ra.NewOrderRequest
+	(*PreflightOrderRequest)(nil),                    // 14: ra.PreflightOrderRequest
+	(*PreflightOrderResponse)(nil),                   // 15: ra.PreflightOrderResponse
+	(*PreflightIdentifier)(nil),                      // 16: ra.PreflightIdentifier
+	(*GetAccountKeyAlgorithmRequest)(nil),            // 17: ra.GetAccountKeyAlgorithmRequest
+	(*AccountKeyAlgorithm)(nil),                      // 18: ra.AccountKeyAlgorithm
+	(*GetAuthorizationRequest)(nil),                  // 19: ra.GetAuthorizationRequest
+	(*AwaitValidationRequest)(nil),                   // 20: ra.AwaitValidationRequest
+	(*FinalizeOrderRequest)(nil),                     // 21: ra.FinalizeOrderRequest
+	(*UnpauseAccountRequest)(nil),                    // 22: ra.UnpauseAccountRequest
+	(*UnpauseAccountResponse)(nil),                   // 23: ra.UnpauseAccountResponse
+	(*AddRateLimitOverrideRequest)(nil),              // 24: ra.AddRateLimitOverrideRequest
+	(*AddRateLimitOverrideResponse)(nil),             // 25: ra.AddRateLimitOverrideResponse
+	(*durationpb.Duration)(nil),                      // 26: google.protobuf.Duration
+	(*proto.Authorization)(nil),                      // 27: core.Authorization
+	(*proto.Challenge)(nil),                          // 28: core.Challenge
+	(*proto.Identifier)(nil),                         // 29: core.Identifier
+	(*proto.ProblemDetails)(nil),                     // 30: core.ProblemDetails
+	(*proto.Order)(nil),                              // 31: core.Order
+	(*proto.Registration)(nil),                       // 32: core.Registration
+	(*emptypb.Empty)(nil),                            // 33: google.protobuf.Empty
+	(*proto1.OCSPResponse)(nil),                      // 34: ca.OCSPResponse
 }
 var file_ra_proto_depIdxs = []int32{
-	17, // 0: ra.UpdateAuthorizationRequest.authz:type_name -> core.Authorization
-	18, // 1: ra.UpdateAuthorizationRequest.response:type_name -> core.Challenge
-	17, // 2: ra.PerformValidationRequest.authz:type_name -> core.Authorization
-	19, // 3: ra.NewOrderRequest.identifiers:type_name -> core.Identifier
-	20, // 4: ra.FinalizeOrderRequest.order:type_name -> core.Order
-	21, // 5: ra.AddRateLimitOverrideRequest.period:type_name -> google.protobuf.Duration
-	22, // 6: ra.RegistrationAuthority.NewRegistration:input_type -> core.Registration
-	3,  // 7: ra.RegistrationAuthority.UpdateRegistrationKey:input_type -> ra.UpdateRegistrationKeyRequest
-	4,  // 8: ra.RegistrationAuthority.DeactivateRegistration:input_type -> ra.DeactivateRegistrationRequest
-	6,  // 9: ra.RegistrationAuthority.PerformValidation:input_type -> ra.PerformValidationRequest
-	17, // 10: ra.RegistrationAuthority.DeactivateAuthorization:input_type -> core.Authorization
-	7,  // 11: ra.RegistrationAuthority.RevokeCertByApplicant:input_type -> ra.RevokeCertByApplicantRequest
-	8,  // 12: ra.RegistrationAuthority.RevokeCertByKey:input_type -> ra.RevokeCertByKeyRequest
-	9,  // 13: ra.RegistrationAuthority.AdministrativelyRevokeCertificate:input_type -> ra.AdministrativelyRevokeCertificateRequest
-	10, // 14: ra.RegistrationAuthority.NewOrder:input_type -> ra.NewOrderRequest
-	11, // 15: ra.RegistrationAuthority.GetAuthorization:input_type -> ra.GetAuthorizationRequest
-	12, // 16: ra.RegistrationAuthority.FinalizeOrder:input_type -> ra.FinalizeOrderRequest
-	2,  // 17: ra.RegistrationAuthority.GenerateOCSP:input_type -> ra.GenerateOCSPRequest
-	13, // 18: ra.RegistrationAuthority.UnpauseAccount:input_type -> ra.UnpauseAccountRequest
-	15, // 19: ra.RegistrationAuthority.AddRateLimitOverride:input_type -> ra.AddRateLimitOverrideRequest
-	0,  // 20: ra.SCTProvider.GetSCTs:input_type -> ra.SCTRequest
-	22, // 21: ra.RegistrationAuthority.NewRegistration:output_type -> core.Registration
-	22, // 22: ra.RegistrationAuthority.UpdateRegistrationKey:output_type -> core.Registration
-	22, // 23: ra.RegistrationAuthority.DeactivateRegistration:output_type -> core.Registration
-	17, // 24: ra.RegistrationAuthority.PerformValidation:output_type -> core.Authorization
-	23, // 25: ra.RegistrationAuthority.DeactivateAuthorization:output_type -> google.protobuf.Empty
-	23, // 26: ra.RegistrationAuthority.RevokeCertByApplicant:output_type -> google.protobuf.Empty
-	23, // 27: ra.RegistrationAuthority.RevokeCertByKey:output_type -> google.protobuf.Empty
-	23, // 28: ra.RegistrationAuthority.AdministrativelyRevokeCertificate:output_type -> google.protobuf.Empty
-	20, // 29: ra.RegistrationAuthority.NewOrder:output_type -> core.Order
-	17, // 30: ra.RegistrationAuthority.GetAuthorization:output_type -> core.Authorization
-	20, // 31: ra.RegistrationAuthority.FinalizeOrder:output_type -> core.Order
-	24, // 32: ra.RegistrationAuthority.GenerateOCSP:output_type -> ca.OCSPResponse
-	14, // 33: ra.RegistrationAuthority.UnpauseAccount:output_type -> ra.UnpauseAccountResponse
-	16, // 34: ra.RegistrationAuthority.AddRateLimitOverride:output_type -> ra.AddRateLimitOverrideResponse
-	1,  // 35: ra.SCTProvider.GetSCTs:output_type -> ra.SCTResponse
-	21, // [21:36] is the sub-list for method output_type
-	6,  // [6:21] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	2,  // 0: ra.SCTResponse.sources:type_name -> ra.SCTSource
+	26, // 1: ra.SCTSource.submissionLatency:type_name -> google.protobuf.Duration
+	27, // 2: ra.UpdateAuthorizationRequest.authz:type_name -> core.Authorization
+	28, // 3: ra.UpdateAuthorizationRequest.response:type_name -> core.Challenge
+	27, // 4: ra.PerformValidationRequest.authz:type_name -> core.Authorization
+	29, // 5: ra.NewOrderRequest.identifiers:type_name -> core.Identifier
+	29, // 6: ra.PreflightOrderRequest.identifiers:type_name -> core.Identifier
+	30, // 7: ra.PreflightOrderResponse.problems:type_name -> core.ProblemDetails
+	16, // 8: ra.PreflightOrderResponse.identifiers:type_name -> ra.PreflightIdentifier
+	29, // 9: ra.PreflightIdentifier.identifier:type_name -> core.Identifier
+	30, // 10: ra.PreflightIdentifier.problem:type_name -> core.ProblemDetails
+	31, // 11: ra.FinalizeOrderRequest.order:type_name -> core.Order
+	26, // 12: ra.AddRateLimitOverrideRequest.period:type_name -> google.protobuf.Duration
+	32, // 13: ra.RegistrationAuthority.NewRegistration:input_type -> core.Registration
+	4,  // 14: ra.RegistrationAuthority.UpdateRegistrationKey:input_type -> ra.UpdateRegistrationKeyRequest
+	5,  // 15: ra.RegistrationAuthority.DeactivateRegistration:input_type -> ra.DeactivateRegistrationRequest
+	7,  // 16: ra.RegistrationAuthority.PerformValidation:input_type -> ra.PerformValidationRequest
+	27, // 17: ra.RegistrationAuthority.DeactivateAuthorization:input_type -> core.Authorization
+	8,  // 18: ra.RegistrationAuthority.RevokeCertByApplicant:input_type -> ra.RevokeCertByApplicantRequest
+	9,  // 19: ra.RegistrationAuthority.RevokeCertByKey:input_type -> ra.RevokeCertByKeyRequest
+	10, // 20: ra.RegistrationAuthority.AdministrativelyRevokeCertificate:input_type -> ra.AdministrativelyRevokeCertificateRequest
+	13, // 21: ra.RegistrationAuthority.NewOrder:input_type -> ra.NewOrderRequest
+	19, // 22: ra.RegistrationAuthority.GetAuthorization:input_type -> ra.GetAuthorizationRequest
+	21, // 23: ra.RegistrationAuthority.FinalizeOrder:input_type -> ra.FinalizeOrderRequest
+	3,  // 24: ra.RegistrationAuthority.GenerateOCSP:input_type -> ra.GenerateOCSPRequest
+	22, // 25: ra.RegistrationAuthority.UnpauseAccount:input_type -> ra.UnpauseAccountRequest
+	24, // 26: ra.RegistrationAuthority.AddRateLimitOverride:input_type -> ra.AddRateLimitOverrideRequest
+	11, // 27: ra.RegistrationAuthority.ReportKeyCompromise:input_type -> ra.ReportKeyCompromiseRequest
+	14, // 28: ra.RegistrationAuthority.PreflightOrder:input_type -> ra.PreflightOrderRequest
+	17, // 29: ra.RegistrationAuthority.GetAccountKeyAlgorithm:input_type -> ra.GetAccountKeyAlgorithmRequest
+	20, // 30: ra.RegistrationAuthority.AwaitValidation:input_type -> ra.AwaitValidationRequest
+	0,  // 31: ra.SCTProvider.GetSCTs:input_type -> ra.SCTRequest
+	32, // 32: ra.RegistrationAuthority.NewRegistration:output_type -> core.Registration
+	32, // 33: ra.RegistrationAuthority.UpdateRegistrationKey:output_type -> core.Registration
+	32, // 34: ra.RegistrationAuthority.DeactivateRegistration:output_type -> core.Registration
+	27, // 35: ra.RegistrationAuthority.PerformValidation:output_type -> core.Authorization
+	33, // 36: ra.RegistrationAuthority.DeactivateAuthorization:output_type -> google.protobuf.Empty
+	33, // 37: ra.RegistrationAuthority.RevokeCertByApplicant:output_type -> google.protobuf.Empty
+	33, // 38: ra.RegistrationAuthority.RevokeCertByKey:output_type -> google.protobuf.Empty
+	33, // 39: ra.RegistrationAuthority.AdministrativelyRevokeCertificate:output_type -> google.protobuf.Empty
+	31, // 40: ra.RegistrationAuthority.NewOrder:output_type -> core.Order
+	27, // 41: ra.RegistrationAuthority.GetAuthorization:output_type -> core.Authorization
+	31, // 42: ra.RegistrationAuthority.FinalizeOrder:output_type -> core.Order
+	34, // 43: ra.RegistrationAuthority.GenerateOCSP:output_type -> ca.OCSPResponse
+	23, // 44: ra.RegistrationAuthority.UnpauseAccount:output_type -> ra.UnpauseAccountResponse
+	25, // 45: ra.RegistrationAuthority.AddRateLimitOverride:output_type -> ra.AddRateLimitOverrideResponse
+	12, // 46: ra.RegistrationAuthority.ReportKeyCompromise:output_type -> ra.KeyCompromiseProgress
+	15, // 47: ra.RegistrationAuthority.PreflightOrder:output_type -> ra.PreflightOrderResponse
+	18, // 48: ra.RegistrationAuthority.GetAccountKeyAlgorithm:output_type -> ra.AccountKeyAlgorithm
+	27, // 49: ra.RegistrationAuthority.AwaitValidation:output_type -> core.Authorization
+	1,  // 50: ra.SCTProvider.GetSCTs:output_type -> ra.SCTResponse
+	32, // [32:51] is the sub-list for method output_type
+	13, // [13:32] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_ra_proto_init() }
@@ -1264,7 +2028,7 @@ func file_ra_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ra_proto_rawDesc), len(file_ra_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
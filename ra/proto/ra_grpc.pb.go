@@ -36,6 +36,10 @@ const (
 	RegistrationAuthority_GenerateOCSP_FullMethodName                      = "/ra.RegistrationAuthority/GenerateOCSP"
 	RegistrationAuthority_UnpauseAccount_FullMethodName                    = "/ra.RegistrationAuthority/UnpauseAccount"
 	RegistrationAuthority_AddRateLimitOverride_FullMethodName              = "/ra.RegistrationAuthority/AddRateLimitOverride"
+	RegistrationAuthority_ReportKeyCompromise_FullMethodName               = "/ra.RegistrationAuthority/ReportKeyCompromise"
+	RegistrationAuthority_PreflightOrder_FullMethodName                    = "/ra.RegistrationAuthority/PreflightOrder"
+	RegistrationAuthority_GetAccountKeyAlgorithm_FullMethodName            = "/ra.RegistrationAuthority/GetAccountKeyAlgorithm"
+	RegistrationAuthority_AwaitValidation_FullMethodName                   = "/ra.RegistrationAuthority/AwaitValidation"
 )
 
 // RegistrationAuthorityClient is the client API for RegistrationAuthority service.
@@ -57,6 +61,18 @@ type RegistrationAuthorityClient interface {
 	GenerateOCSP(ctx context.Context, in *GenerateOCSPRequest, opts ...grpc.CallOption) (*proto1.OCSPResponse, error)
 	UnpauseAccount(ctx context.Context, in *UnpauseAccountRequest, opts ...grpc.CallOption) (*UnpauseAccountResponse, error)
 	AddRateLimitOverride(ctx context.Context, in *AddRateLimitOverrideRequest, opts ...grpc.CallOption) (*AddRateLimitOverrideResponse, error)
+	ReportKeyCompromise(ctx context.Context, in *ReportKeyCompromiseRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[KeyCompromiseProgress], error)
+	// PreflightOrder runs the policy, rate limit, and CAA checks for a
+	// hypothetical order, without creating it, spending rate limits, or
+	// performing validation.
+	PreflightOrder(ctx context.Context, in *PreflightOrderRequest, opts ...grpc.CallOption) (*PreflightOrderResponse, error)
+	// GetAccountKeyAlgorithm describes the key of an account, and the JWS
+	// signature algorithm which requests signed by it must use.
+	GetAccountKeyAlgorithm(ctx context.Context, in *GetAccountKeyAlgorithmRequest, opts ...grpc.CallOption) (*AccountKeyAlgorithm, error)
+	// AwaitValidation waits for a validation of the authorization which this RA
+	// is performing to be recorded, and returns the authorization with its
+	// result. If this RA isn't performing one, it returns NotFound immediately.
+	AwaitValidation(ctx context.Context, in *AwaitValidationRequest, opts ...grpc.CallOption) (*proto.Authorization, error)
 }
 
 type registrationAuthorityClient struct {
@@ -207,6 +223,55 @@ func (c *registrationAuthorityClient) AddRateLimitOverride(ctx context.Context,
 	return out, nil
 }
 
+func (c *registrationAuthorityClient) ReportKeyCompromise(ctx context.Context, in *ReportKeyCompromiseRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[KeyCompromiseProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RegistrationAuthority_ServiceDesc.Streams[0], RegistrationAuthority_ReportKeyCompromise_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ReportKeyCompromiseRequest, KeyCompromiseProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RegistrationAuthority_ReportKeyCompromiseClient = grpc.ServerStreamingClient[KeyCompromiseProgress]
+
+func (c *registrationAuthorityClient) PreflightOrder(ctx context.Context, in *PreflightOrderRequest, opts ...grpc.CallOption) (*PreflightOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreflightOrderResponse)
+	err := c.cc.Invoke(ctx, RegistrationAuthority_PreflightOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationAuthorityClient) GetAccountKeyAlgorithm(ctx context.Context, in *GetAccountKeyAlgorithmRequest, opts ...grpc.CallOption) (*AccountKeyAlgorithm, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountKeyAlgorithm)
+	err := c.cc.Invoke(ctx, RegistrationAuthority_GetAccountKeyAlgorithm_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationAuthorityClient) AwaitValidation(ctx context.Context, in *AwaitValidationRequest, opts ...grpc.CallOption) (*proto.Authorization, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(proto.Authorization)
+	err := c.cc.Invoke(ctx, RegistrationAuthority_AwaitValidation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RegistrationAuthorityServer is the server API for RegistrationAuthority service.
 // All implementations must embed UnimplementedRegistrationAuthorityServer
 // for forward compatibility.
@@ -226,6 +291,18 @@ type RegistrationAuthorityServer interface {
 	GenerateOCSP(context.Context, *GenerateOCSPRequest) (*proto1.OCSPResponse, error)
 	UnpauseAccount(context.Context, *UnpauseAccountRequest) (*UnpauseAccountResponse, error)
 	AddRateLimitOverride(context.Context, *AddRateLimitOverrideRequest) (*AddRateLimitOverrideResponse, error)
+	ReportKeyCompromise(*ReportKeyCompromiseRequest, grpc.ServerStreamingServer[KeyCompromiseProgress]) error
+	// PreflightOrder runs the policy, rate limit, and CAA checks for a
+	// hypothetical order, without creating it, spending rate limits, or
+	// performing validation.
+	PreflightOrder(context.Context, *PreflightOrderRequest) (*PreflightOrderResponse, error)
+	// GetAccountKeyAlgorithm describes the key of an account, and the JWS
+	// signature algorithm which requests signed by it must use.
+	GetAccountKeyAlgorithm(context.Context, *GetAccountKeyAlgorithmRequest) (*AccountKeyAlgorithm, error)
+	// AwaitValidation waits for a validation of the authorization which this RA
+	// is performing to be recorded, and returns the authorization with its
+	// result. If this RA isn't performing one, it returns NotFound immediately.
+	AwaitValidation(context.Context, *AwaitValidationRequest) (*proto.Authorization, error)
 	mustEmbedUnimplementedRegistrationAuthorityServer()
 }
 
@@ -278,6 +355,18 @@ func (UnimplementedRegistrationAuthorityServer) UnpauseAccount(context.Context,
 func (UnimplementedRegistrationAuthorityServer) AddRateLimitOverride(context.Context, *AddRateLimitOverrideRequest) (*AddRateLimitOverrideResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddRateLimitOverride not implemented")
 }
+func (UnimplementedRegistrationAuthorityServer) ReportKeyCompromise(*ReportKeyCompromiseRequest, grpc.ServerStreamingServer[KeyCompromiseProgress]) error {
+	return status.Errorf(codes.Unimplemented, "method ReportKeyCompromise not implemented")
+}
+func (UnimplementedRegistrationAuthorityServer) PreflightOrder(context.Context, *PreflightOrderRequest) (*PreflightOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreflightOrder not implemented")
+}
+func (UnimplementedRegistrationAuthorityServer) GetAccountKeyAlgorithm(context.Context, *GetAccountKeyAlgorithmRequest) (*AccountKeyAlgorithm, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountKeyAlgorithm not implemented")
+}
+func (UnimplementedRegistrationAuthorityServer) AwaitValidation(context.Context, *AwaitValidationRequest) (*proto.Authorization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AwaitValidation not implemented")
+}
 func (UnimplementedRegistrationAuthorityServer) mustEmbedUnimplementedRegistrationAuthorityServer() {}
 func (UnimplementedRegistrationAuthorityServer) testEmbeddedByValue()                               {}
 
@@ -551,6 +640,71 @@ func _RegistrationAuthority_AddRateLimitOverride_Handler(srv interface{}, ctx co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RegistrationAuthority_ReportKeyCompromise_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReportKeyCompromiseRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RegistrationAuthorityServer).ReportKeyCompromise(m, &grpc.GenericServerStream[ReportKeyCompromiseRequest, KeyCompromiseProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RegistrationAuthority_ReportKeyCompromiseServer = grpc.ServerStreamingServer[KeyCompromiseProgress]
+
+func _RegistrationAuthority_PreflightOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreflightOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationAuthorityServer).PreflightOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RegistrationAuthority_PreflightOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationAuthorityServer).PreflightOrder(ctx, req.(*PreflightOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RegistrationAuthority_GetAccountKeyAlgorithm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountKeyAlgorithmRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationAuthorityServer).GetAccountKeyAlgorithm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RegistrationAuthority_GetAccountKeyAlgorithm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationAuthorityServer).GetAccountKeyAlgorithm(ctx, req.(*GetAccountKeyAlgorithmRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RegistrationAuthority_AwaitValidation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AwaitValidationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationAuthorityServer).AwaitValidation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RegistrationAuthority_AwaitValidation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationAuthorityServer).AwaitValidation(ctx, req.(*AwaitValidationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RegistrationAuthority_ServiceDesc is the grpc.ServiceDesc for RegistrationAuthority service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -614,8 +768,26 @@ var RegistrationAuthority_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddRateLimitOverride",
 			Handler:    _RegistrationAuthority_AddRateLimitOverride_Handler,
 		},
+		{
+			MethodName: "PreflightOrder",
+			Handler:    _RegistrationAuthority_PreflightOrder_Handler,
+		},
+		{
+			MethodName: "GetAccountKeyAlgorithm",
+			Handler:    _RegistrationAuthority_GetAccountKeyAlgorithm_Handler,
+		},
+		{
+			MethodName: "AwaitValidation",
+			Handler:    _RegistrationAuthority_AwaitValidation_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ReportKeyCompromise",
+			Handler:       _RegistrationAuthority_ReportKeyCompromise_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "ra.proto",
 }
 
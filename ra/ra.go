@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"crypto"
+	"crypto/sha256"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/asn1"
@@ -97,10 +98,26 @@ type RegistrationAuthorityImpl struct {
 
 	ctpolicy *ctpolicy.CTPolicy
 
+	// caaRechecker, if non-nil, refreshes CAA checks for reused authorizations
+	// in the background so that finalize doesn't have to.
+	caaRechecker *caaRecheckScheduler
+
+	// accountRevoker, if non-nil, revokes the certificates of deactivated
+	// accounts which requested it.
+	accountRevoker *accountRevoker
+
+	// csrPolicy holds the checks applied to CSRs at finalize time.
+	csrPolicy csrPolicy
+
+	// validations tracks the validations in flight, so that AwaitValidation
+	// can return their results as soon as they're recorded.
+	validations *validationWaiters
+
 	ctpolicyResults         *prometheus.HistogramVec
 	revocationReasonCounter *prometheus.CounterVec
 	namesPerCert            *prometheus.HistogramVec
 	newRegCounter           prometheus.Counter
+	accountKeyAlgorithms    *prometheus.CounterVec
 	recheckCAACounter       prometheus.Counter
 	newCertCounter          prometheus.Counter
 	authzAges               *prometheus.HistogramVec
@@ -111,6 +128,7 @@ type RegistrationAuthorityImpl struct {
 	// TODO(#8177): Remove once the rate of requests failing to finalize due to
 	// requesting Must-Staple has diminished.
 	mustStapleRequestsCounter *prometheus.CounterVec
+	repeatedFinalizations     *prometheus.CounterVec
 }
 
 var _ rapb.RegistrationAuthorityServer = (*RegistrationAuthorityImpl)(nil)
@@ -161,6 +179,12 @@ func NewRegistrationAuthorityImpl(
 	})
 	stats.MustRegister(newRegCounter)
 
+	accountKeyAlgorithms := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "account_key_algorithms",
+		Help: "Number of account keys stored by new registrations and key changes, labeled by the JWS alg they must be used with and operation=[new-registration|key-change]",
+	}, []string{"alg", "operation"})
+	stats.MustRegister(accountKeyAlgorithms)
+
 	recheckCAACounter := prometheus.NewCounter(prometheus.CounterOpts{
 		Name: "recheck_caa",
 		Help: "A counter of CAA rechecks",
@@ -227,6 +251,12 @@ func NewRegistrationAuthorityImpl(
 	}, []string{"allowlist"})
 	stats.MustRegister(mustStapleRequestsCounter)
 
+	repeatedFinalizations := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "repeated_finalizations",
+		Help: "Number of finalize requests for orders which were already finalized, labeled by csr=[same|different]",
+	}, []string{"csr"})
+	stats.MustRegister(repeatedFinalizations)
+
 	issuersByNameID := make(map[issuance.NameID]*issuance.Certificate)
 	for _, issuer := range issuers {
 		issuersByNameID[issuer.NameID()] = issuer
@@ -248,6 +278,7 @@ func NewRegistrationAuthorityImpl(
 		issuersByNameID:           issuersByNameID,
 		namesPerCert:              namesPerCert,
 		newRegCounter:             newRegCounter,
+		accountKeyAlgorithms:      accountKeyAlgorithms,
 		recheckCAACounter:         recheckCAACounter,
 		newCertCounter:            newCertCounter,
 		revocationReasonCounter:   revocationReasonCounter,
@@ -257,6 +288,8 @@ func NewRegistrationAuthorityImpl(
 		certCSRMismatch:           certCSRMismatch,
 		pauseCounter:              pauseCounter,
 		mustStapleRequestsCounter: mustStapleRequestsCounter,
+		repeatedFinalizations:     repeatedFinalizations,
+		validations:               newValidationWaiters(),
 	}
 	return ra
 }
@@ -292,7 +325,53 @@ type ValidationProfileConfig struct {
 	AllowList string `validate:"omitempty"`
 	// IdentifierTypes is a list of identifier types that may be issued under
 	// this profile.
-	IdentifierTypes []identifier.IdentifierType `validate:"required,dive,oneof=dns ip"`
+	IdentifierTypes []identifier.IdentifierType `validate:"required,dive,oneof=dns ip email"`
+	// Delegated marks this as a profile for short-lived certificates which
+	// subscribers use to sign delegated credentials. It must be set if, and
+	// only if, the CA profile of the same name sets IncludeDelegationUsage.
+	// Certificates issued under a delegated profile are counted against the
+	// DelegatedCertificatesPerDomain rate limit instead of the
+	// CertificatesPerDomain and CertificatesPerFQDNSet limits.
+	Delegated bool
+	// MaxValidationAge, if set, is the maximum time between the validation of
+	// an order's authorizations and the order's finalization. Unlike
+	// ValidAuthzLifetime, it applies to authorizations which were validated
+	// before it was configured or lowered, so that reuse periods can be
+	// shortened without waiting for existing authorizations to expire. Orders
+	// with authorizations validated longer ago than this must be revalidated.
+	MaxValidationAge config.Duration `validate:"-"`
+	// MaxValidationFailures, if greater than one, is the number of failed
+	// validation attempts an authorization may have before it becomes
+	// invalid. Until then, a failed attempt leaves the authorization pending
+	// so that the subscriber can fix their setup and try again. If unset, the
+	// first failed attempt invalidates the authorization.
+	MaxValidationFailures int `validate:"omitempty,min=1"`
+	// ValidationRetryBackoff is how long a subscriber must wait after a failed
+	// validation attempt before attempting to validate the same authorization
+	// again. Attempts made sooner are refused with a Retry-After. It has no
+	// effect unless MaxValidationFailures is greater than one.
+	ValidationRetryBackoff config.Duration `validate:"-"`
+	// AccountOverrides replace the lifetimes above for specific accounts,
+	// e.g. to give an account whose validation pipeline is slow longer to
+	// fulfill its orders. An account may appear in at most one override.
+	AccountOverrides []AccountLifetimesConfig `validate:"omitempty,dive"`
+}
+
+// AccountLifetimesConfig overrides a validation profile's lifetimes for a set
+// of accounts. Each lifetime which is unset is taken from the profile, and
+// each which is set is subject to the same bounds as the profile's own.
+type AccountLifetimesConfig struct {
+	// AccountIDs are the accounts to which this override applies.
+	AccountIDs []int64 `validate:"required,min=1,dive,min=1"`
+	// PendingAuthzLifetime, if set, replaces the profile's
+	// PendingAuthzLifetime for these accounts.
+	PendingAuthzLifetime config.Duration `validate:"-"`
+	// ValidAuthzLifetime, if set, replaces the profile's ValidAuthzLifetime
+	// for these accounts.
+	ValidAuthzLifetime config.Duration `validate:"-"`
+	// OrderLifetime, if set, replaces the profile's OrderLifetime for these
+	// accounts.
+	OrderLifetime config.Duration `validate:"-"`
 }
 
 // validationProfile holds the attributes of a given validation profile.
@@ -320,6 +399,33 @@ type validationProfile struct {
 	// identifierTypes is a list of identifier types that may be issued under
 	// this profile.
 	identifierTypes []identifier.IdentifierType
+	// delegated is true if certificates issued under this profile are counted
+	// against the DelegatedCertificatesPerDomain rate limit.
+	delegated bool
+	// maxValidationAge is the maximum time between the validation of an
+	// order's authorizations and the order's finalization. If zero, the
+	// validation may be as old as the authorization's lifetime allows.
+	maxValidationAge time.Duration
+	// maxValidationFailures is the number of failed validation attempts an
+	// authorization may have before it becomes invalid. If one or less, the
+	// first failed attempt invalidates it.
+	maxValidationFailures int
+	// validationRetryBackoff is the minimum time between a failed validation
+	// attempt and the next attempt to validate the same authorization.
+	validationRetryBackoff time.Duration
+	// accountOverrides holds, for each account with overridden lifetimes, a
+	// copy of this profile with those lifetimes.
+	accountOverrides map[int64]*validationProfile
+}
+
+// forAccount returns the profile as it applies to the given account, i.e.
+// with any lifetimes overridden for that account.
+func (p *validationProfile) forAccount(regID int64) *validationProfile {
+	override, ok := p.accountOverrides[regID]
+	if !ok {
+		return p
+	}
+	return override
 }
 
 // validationProfiles provides access to the set of configured profiles,
@@ -340,26 +446,27 @@ func NewValidationProfiles(defaultName string, configs map[string]*ValidationPro
 	profiles := make(map[string]*validationProfile, len(configs))
 
 	for name, config := range configs {
-		// The Baseline Requirements v1.8.1 state that validation tokens "MUST
-		// NOT be used for more than 30 days from its creation". If unconfigured
-		// or the configured value pendingAuthorizationLifetimeDays is greater
-		// than 29 days, bail out.
-		if config.PendingAuthzLifetime.Duration <= 0 || config.PendingAuthzLifetime.Duration > 29*(24*time.Hour) {
-			return nil, fmt.Errorf("PendingAuthzLifetime value must be greater than 0 and less than 30d, but got %q", config.PendingAuthzLifetime.Duration)
-		}
-
-		// Baseline Requirements v1.8.1 section 4.2.1: "any reused data, document,
-		// or completed validation MUST be obtained no more than 398 days prior
-		// to issuing the Certificate". If unconfigured or the configured value is
-		// greater than 397 days, bail out.
-		if config.ValidAuthzLifetime.Duration <= 0 || config.ValidAuthzLifetime.Duration > 397*(24*time.Hour) {
-			return nil, fmt.Errorf("ValidAuthzLifetime value must be greater than 0 and less than 398d, but got %q", config.ValidAuthzLifetime.Duration)
+		err := checkAuthzLifetimes(config.PendingAuthzLifetime.Duration, config.ValidAuthzLifetime.Duration)
+		if err != nil {
+			return nil, err
 		}
 
 		if config.MaxNames <= 0 || config.MaxNames > 100 {
 			return nil, fmt.Errorf("MaxNames must be greater than 0 and at most 100")
 		}
 
+		if config.MaxValidationAge.Duration < 0 {
+			return nil, fmt.Errorf("profile %q: MaxValidationAge must not be negative, but got %q", name, config.MaxValidationAge.Duration)
+		}
+
+		if config.MaxValidationFailures < 0 {
+			return nil, fmt.Errorf("profile %q: MaxValidationFailures must not be negative, but got %d", name, config.MaxValidationFailures)
+		}
+
+		if config.ValidationRetryBackoff.Duration < 0 {
+			return nil, fmt.Errorf("profile %q: ValidationRetryBackoff must not be negative, but got %q", name, config.ValidationRetryBackoff.Duration)
+		}
+
 		var allowList *allowlist.List[int64]
 		if config.AllowList != "" {
 			data, err := os.ReadFile(config.AllowList)
@@ -372,14 +479,55 @@ func NewValidationProfiles(defaultName string, configs map[string]*ValidationPro
 			}
 		}
 
-		profiles[name] = &validationProfile{
-			pendingAuthzLifetime: config.PendingAuthzLifetime.Duration,
-			validAuthzLifetime:   config.ValidAuthzLifetime.Duration,
-			orderLifetime:        config.OrderLifetime.Duration,
-			maxNames:             config.MaxNames,
-			allowList:            allowList,
-			identifierTypes:      config.IdentifierTypes,
+		profile := &validationProfile{
+			pendingAuthzLifetime:   config.PendingAuthzLifetime.Duration,
+			validAuthzLifetime:     config.ValidAuthzLifetime.Duration,
+			orderLifetime:          config.OrderLifetime.Duration,
+			maxNames:               config.MaxNames,
+			allowList:              allowList,
+			identifierTypes:        config.IdentifierTypes,
+			delegated:              config.Delegated,
+			maxValidationAge:       config.MaxValidationAge.Duration,
+			maxValidationFailures:  config.MaxValidationFailures,
+			validationRetryBackoff: config.ValidationRetryBackoff.Duration,
 		}
+
+		for _, oc := range config.AccountOverrides {
+			if len(oc.AccountIDs) == 0 {
+				return nil, fmt.Errorf("profile %q: account override has no account IDs", name)
+			}
+			override := *profile
+			override.accountOverrides = nil
+			if oc.PendingAuthzLifetime.Duration != 0 {
+				override.pendingAuthzLifetime = oc.PendingAuthzLifetime.Duration
+			}
+			if oc.ValidAuthzLifetime.Duration != 0 {
+				override.validAuthzLifetime = oc.ValidAuthzLifetime.Duration
+			}
+			if oc.OrderLifetime.Duration != 0 {
+				override.orderLifetime = oc.OrderLifetime.Duration
+			}
+			err := checkAuthzLifetimes(override.pendingAuthzLifetime, override.validAuthzLifetime)
+			if err != nil {
+				return nil, fmt.Errorf("profile %q: account override: %w", name, err)
+			}
+			if override.orderLifetime <= 0 {
+				return nil, fmt.Errorf("profile %q: account override: OrderLifetime must be greater than 0, but got %q", name, override.orderLifetime)
+			}
+
+			if profile.accountOverrides == nil {
+				profile.accountOverrides = make(map[int64]*validationProfile)
+			}
+			for _, regID := range oc.AccountIDs {
+				_, dup := profile.accountOverrides[regID]
+				if dup {
+					return nil, fmt.Errorf("profile %q: account ID %d appears in more than one account override", name, regID)
+				}
+				profile.accountOverrides[regID] = &override
+			}
+		}
+
+		profiles[name] = profile
 	}
 
 	_, ok := profiles[defaultName]
@@ -393,6 +541,27 @@ func NewValidationProfiles(defaultName string, configs map[string]*ValidationPro
 	}, nil
 }
 
+// checkAuthzLifetimes enforces that the given authorization lifetimes are
+// within the bounds mandated by the Baseline Requirements.
+func checkAuthzLifetimes(pending, valid time.Duration) error {
+	// The Baseline Requirements v1.8.1 state that validation tokens "MUST
+	// NOT be used for more than 30 days from its creation". If unconfigured
+	// or the configured value pendingAuthorizationLifetimeDays is greater
+	// than 29 days, bail out.
+	if pending <= 0 || pending > 29*(24*time.Hour) {
+		return fmt.Errorf("PendingAuthzLifetime value must be greater than 0 and less than 30d, but got %q", pending)
+	}
+
+	// Baseline Requirements v1.8.1 section 4.2.1: "any reused data, document,
+	// or completed validation MUST be obtained no more than 398 days prior
+	// to issuing the Certificate". If unconfigured or the configured value is
+	// greater than 397 days, bail out.
+	if valid <= 0 || valid > 397*(24*time.Hour) {
+		return fmt.Errorf("ValidAuthzLifetime value must be greater than 0 and less than 398d, but got %q", valid)
+	}
+	return nil
+}
+
 func (vp *validationProfiles) get(name string) (*validationProfile, error) {
 	if name == "" {
 		name = vp.defaultName
@@ -495,6 +664,10 @@ type finalizationCAACheckEvent struct {
 	// Rechecked is a count of Authz where a new CAA check was performed because
 	// the original check was older than 7 hours.
 	Rechecked int `json:",omitempty"`
+	// BackgroundRechecked is a count of Authz where the original check was
+	// older than 7 hours, but a background recheck performed in the last 7
+	// hours was reused.
+	BackgroundRechecked int `json:",omitempty"`
 }
 
 // NewRegistration constructs a new Registration from a request.
@@ -517,9 +690,10 @@ func (ra *RegistrationAuthorityImpl) NewRegistration(ctx context.Context, reques
 
 	// Don't populate ID or CreatedAt because those will be set by the SA.
 	req := &corepb.Registration{
-		Key:       request.Key,
-		Agreement: request.Agreement,
-		Status:    string(core.StatusValid),
+		Key:            request.Key,
+		Agreement:      request.Agreement,
+		Status:         string(core.StatusValid),
+		ClientIdentity: request.ClientIdentity,
 	}
 
 	// Store the registration object, then return the version that got stored.
@@ -529,6 +703,7 @@ func (ra *RegistrationAuthorityImpl) NewRegistration(ctx context.Context, reques
 	}
 
 	ra.newRegCounter.Inc()
+	ra.countAccountKey(res.Key, "new-registration")
 	return res, nil
 }
 
@@ -662,7 +837,8 @@ func (ra *RegistrationAuthorityImpl) matchesCSR(parsedCertificate *x509.Certific
 
 // checkOrderAuthorizations verifies that a provided set of names associated
 // with a specific order and account has all of the required valid, unexpired
-// authorizations to proceed with issuance. It returns the authorizations that
+// authorizations to proceed with issuance, and, if maxValidationAge is
+// non-zero, that none of them was validated longer ago than that. It returns the authorizations that
 // satisfied the set of names or it returns an error. If it returns an error, it
 // will be of type BoulderError.
 func (ra *RegistrationAuthorityImpl) checkOrderAuthorizations(
@@ -670,6 +846,7 @@ func (ra *RegistrationAuthorityImpl) checkOrderAuthorizations(
 	orderID orderID,
 	acctID accountID,
 	idents identifier.ACMEIdentifiers,
+	maxValidationAge time.Duration,
 	now time.Time) (map[identifier.ACMEIdentifier]*core.Authorization, error) {
 	// Get all of the valid authorizations for this account/order
 	req := &sapb.GetValidOrderAuthorizationsRequest{
@@ -689,6 +866,7 @@ func (ra *RegistrationAuthorityImpl) checkOrderAuthorizations(
 	var missing []string
 	var invalid []string
 	var expired []string
+	var stale []string
 	for _, ident := range idents {
 		authz, ok := authzs[ident]
 		if !ok || authz == nil {
@@ -708,6 +886,16 @@ func (ra *RegistrationAuthorityImpl) checkOrderAuthorizations(
 			invalid = append(invalid, ident.Value)
 			continue
 		}
+		if maxValidationAge != 0 {
+			tooOld, err := validatedBefore(authz, now.Add(-maxValidationAge))
+			if err != nil {
+				return nil, err
+			}
+			if tooOld {
+				stale = append(stale, ident.Value)
+				continue
+			}
+		}
 	}
 
 	if len(missing) > 0 {
@@ -729,6 +917,12 @@ func (ra *RegistrationAuthorityImpl) checkOrderAuthorizations(
 			strings.Join(expired, ", "),
 		)
 	}
+	if len(stale) > 0 {
+		return nil, berrors.UnauthorizedError(
+			"authorizations for these identifiers were validated too long ago and must be revalidated in a new order: %s",
+			strings.Join(stale, ", "),
+		)
+	}
 
 	// Even though this check is cheap, we do it after the more specific checks
 	// so that we can return more specific error messages.
@@ -759,6 +953,29 @@ func validatedBefore(authz *core.Authorization, caaRecheckTime time.Time) (bool,
 	return authz.Challenges[0].Validated.Before(caaRecheckTime), nil
 }
 
+// reusableUntil returns the time until which the given authorization can be
+// used to finalize an order: its expiry or, if it's valid and maxValidationAge
+// is non-zero, the time its validation becomes older than that, whichever is
+// sooner.
+func reusableUntil(authz *core.Authorization, maxValidationAge time.Duration) time.Time {
+	if authz.Expires == nil {
+		return time.Time{}
+	}
+	until := *authz.Expires
+	if maxValidationAge == 0 || authz.Status != core.StatusValid {
+		return until
+	}
+	for _, chall := range authz.Challenges {
+		if chall.Status == core.StatusValid && chall.Validated != nil {
+			fresh := chall.Validated.Add(maxValidationAge)
+			if fresh.Before(until) {
+				until = fresh
+			}
+		}
+	}
+	return until
+}
+
 // checkAuthorizationsCAA ensures that we have sufficiently-recent CAA checks
 // for every input identifier/authz. If any authz was validated too long ago, it
 // kicks off a CAA recheck for that identifier If it returns an error, it will
@@ -780,10 +997,17 @@ func (ra *RegistrationAuthorityImpl) checkAuthorizationsCAA(
 	// Set the recheck time to 7 hours ago.
 	caaRecheckAfter := now.Add(caaRecheckDuration)
 
+	var backgroundRechecked int
 	for _, authz := range authzs {
 		if staleCAA, err := validatedBefore(authz, caaRecheckAfter); err != nil {
 			return err
 		} else if staleCAA {
+			// If the background scheduler has already rechecked CAA for this
+			// authorization recently enough, there's no need to do it again.
+			if ra.caaRechecker != nil && ra.caaRechecker.freshSince(ctx, authz.ID, caaRecheckAfter) {
+				backgroundRechecked++
+				continue
+			}
 			switch authz.Identifier.Type {
 			case identifier.TypeDNS:
 				// Ensure that CAA is rechecked for this name
@@ -803,9 +1027,10 @@ func (ra *RegistrationAuthorityImpl) checkAuthorizationsCAA(
 	}
 
 	caaEvent := &finalizationCAACheckEvent{
-		Requester: acctID,
-		Reused:    len(authzs) - len(recheckAuthzs),
-		Rechecked: len(recheckAuthzs),
+		Requester:           acctID,
+		Reused:              len(authzs) - len(recheckAuthzs) - backgroundRechecked,
+		Rechecked:           len(recheckAuthzs),
+		BackgroundRechecked: backgroundRechecked,
 	}
 	ra.log.InfoObject("FinalizationCaaCheck", caaEvent)
 
@@ -964,6 +1189,15 @@ func (ra *RegistrationAuthorityImpl) FinalizeOrder(ctx context.Context, req *rap
 		RequestTime: ra.clk.Now(),
 		UserAgent:   web.UserAgent(ctx),
 	}
+
+	// A client which repeats a finalize request, for example after timing out
+	// waiting for the response to the first, is given the order as it stands
+	// if it resubmits the CSR with which the order was finalized.
+	csrHash := sha256.Sum256(req.Csr)
+	if features.Get().IdempotentFinalize && core.AcmeStatus(req.Order.Status) != core.StatusReady && len(req.Order.CsrHash) > 0 {
+		return ra.repeatedFinalization(req.Order, csrHash[:])
+	}
+
 	csr, err := ra.validateFinalizeRequest(ctx, req, &logEvent)
 	if err != nil {
 		return nil, err
@@ -984,8 +1218,16 @@ func (ra *RegistrationAuthorityImpl) FinalizeOrder(ctx context.Context, req *rap
 	// Otherwise the order will be "stuck" in processing state. It can not be
 	// finalized because it isn't pending, but we aren't going to process it
 	// further because we already did and encountered an error.
-	_, err = ra.SA.SetOrderProcessing(ctx, &sapb.OrderRequest{Id: req.Order.Id})
-	if err != nil {
+	_, err = ra.SA.SetOrderProcessing(ctx, &sapb.SetOrderProcessingRequest{Id: req.Order.Id, CsrHash: csrHash[:]})
+	if err != nil {
+		if features.Get().IdempotentFinalize && errors.Is(err, berrors.OrderNotReady) {
+			// A concurrent request began finalizing the order first. If it
+			// submitted the same CSR, this is a repeat of it.
+			current, getErr := ra.SA.GetOrder(ctx, &sapb.OrderRequest{Id: req.Order.Id})
+			if getErr == nil && len(current.CsrHash) > 0 {
+				return ra.repeatedFinalization(current, csrHash[:])
+			}
+		}
 		// Fail the order with a server internal error - we weren't able to set the
 		// status to processing and that's unexpected & weird.
 		ra.failOrder(ctx, req.Order, probs.ServerInternal("Error setting order processing"))
@@ -1028,6 +1270,22 @@ func (ra *RegistrationAuthorityImpl) FinalizeOrder(ctx context.Context, req *rap
 	}
 }
 
+// repeatedFinalization responds to a request to finalize an order which has
+// already begun finalization. If the request's CSR, whose SHA-256 hash is
+// given, is the one with which the order was finalized, the order is returned
+// unchanged: it carries the result of the original finalization, or shows that
+// it's still processing. Otherwise, the request is refused.
+func (ra *RegistrationAuthorityImpl) repeatedFinalization(order *corepb.Order, csrHash []byte) (*corepb.Order, error) {
+	if !bytes.Equal(order.CsrHash, csrHash) {
+		ra.repeatedFinalizations.WithLabelValues("different").Inc()
+		return nil, berrors.OrderNotReadyError(
+			"Order's status (%q) is not acceptable for finalization: it was already finalized with a different CSR",
+			order.Status)
+	}
+	ra.repeatedFinalizations.WithLabelValues("same").Inc()
+	return order, nil
+}
+
 // containsMustStaple returns true if the provided set of extensions includes
 // an entry whose OID and value both match the expected values for the OCSP
 // Must-Staple (a.k.a. id-pe-tlsFeature) extension.
@@ -1072,6 +1330,7 @@ func (ra *RegistrationAuthorityImpl) validateFinalizeRequest(
 	if err != nil {
 		return nil, err
 	}
+	profile = profile.forAccount(req.Order.RegistrationID)
 
 	orderIdents := identifier.Normalize(identifier.FromProtoSlice(req.Order.Identifiers))
 
@@ -1102,14 +1361,19 @@ func (ra *RegistrationAuthorityImpl) validateFinalizeRequest(
 		return nil, err
 	}
 
-	// Dedupe, lowercase and sort both the names from the CSR and the names in the
-	// order.
-	csrIdents := identifier.FromCSR(csr)
-	// Check that the order names and the CSR names are an exact match
-	if !slices.Equal(csrIdents, orderIdents) {
-		return nil, berrors.UnauthorizedError("CSR does not specify same identifiers as Order")
+	err = ra.csrPolicy.checkExtensions(csr)
+	if err != nil {
+		return nil, err
 	}
 
+	// Check that the order names and the CSR names are an exact match, once
+	// both are deduped, lowercased and sorted.
+	err = ra.csrPolicy.checkIdentifiers(csr, orderIdents)
+	if err != nil {
+		return nil, err
+	}
+	csrIdents := identifier.FromCSR(csr)
+
 	// Get the originating account for use in the next check.
 	regPB, err := ra.SA.GetRegistration(ctx, &sapb.RegistrationID{Id: req.Order.RegistrationID})
 	if err != nil {
@@ -1122,14 +1386,15 @@ func (ra *RegistrationAuthorityImpl) validateFinalizeRequest(
 	}
 
 	// Make sure they're not using their account key as the certificate key too.
-	if core.KeyDigestEquals(csr.PublicKey, account.Key) {
-		return nil, berrors.MalformedError("certificate public key must be different than account key")
+	err = ra.csrPolicy.checkKeyReuse(csr, account.Key)
+	if err != nil {
+		return nil, err
 	}
 
 	// Double-check that all authorizations on this order are valid, are also
 	// associated with the same account as the order itself, and have recent CAA.
 	authzs, err := ra.checkOrderAuthorizations(
-		ctx, orderID(req.Order.Id), accountID(req.Order.RegistrationID), csrIdents, ra.clk.Now())
+		ctx, orderID(req.Order.Id), accountID(req.Order.RegistrationID), csrIdents, profile.maxValidationAge, ra.clk.Now())
 	if err != nil {
 		// Pass through the error without wrapping it because the called functions
 		// return BoulderError and we don't want to lose the type.
@@ -1159,12 +1424,25 @@ func (ra *RegistrationAuthorityImpl) validateFinalizeRequest(
 }
 
 func (ra *RegistrationAuthorityImpl) GetSCTs(ctx context.Context, sctRequest *rapb.SCTRequest) (*rapb.SCTResponse, error) {
-	scts, err := ra.getSCTs(ctx, sctRequest.PrecertDER)
+	scts, sources, err := ra.getSCTs(ctx, sctRequest.PrecertDER)
 	if err != nil {
 		return nil, err
 	}
+	var sourcesPB []*rapb.SCTSource
+	for _, source := range sources {
+		sourcesPB = append(sourcesPB, &rapb.SCTSource{
+			LogID:             source.Log.Id,
+			LogName:           source.Log.Name,
+			Operator:          source.Log.Operator,
+			LogURL:            source.Log.Url,
+			Tiled:             source.Log.Tiled,
+			SubmissionLatency: durationpb.New(source.SubmissionLatency),
+		})
+	}
 	return &rapb.SCTResponse{
-		SctDER: scts,
+		SctDER:  scts,
+		Sources: sourcesPB,
+		Policy:  ctpolicy.Policy,
 	}, nil
 }
 
@@ -1247,26 +1525,35 @@ func (ra *RegistrationAuthorityImpl) issueCertificateOuter(
 }
 
 // countCertificateIssued increments the certificates (per domain and per
-// account) and duplicate certificate rate limits. There is no reason to surface
-// errors from this function to the Subscriber, spends against these limit are
-// best effort.
-func (ra *RegistrationAuthorityImpl) countCertificateIssued(ctx context.Context, regId int64, orderIdents identifier.ACMEIdentifiers, isRenewal bool) {
+// account) and duplicate certificate rate limits, or if delegated is true, the
+// delegated certificates per domain limit. There is no reason to surface errors
+// from this function to the Subscriber, spends against these limit are best
+// effort.
+func (ra *RegistrationAuthorityImpl) countCertificateIssued(ctx context.Context, regId int64, orderIdents identifier.ACMEIdentifiers, isRenewal bool, delegated bool) {
 	var transactions []ratelimits.Transaction
-	if !isRenewal {
-		txns, err := ra.txnBuilder.CertificatesPerDomainSpendOnlyTransactions(regId, orderIdents)
+	if delegated {
+		txns, err := ra.txnBuilder.DelegatedCertificatesPerDomainSpendOnlyTransactions(orderIdents)
 		if err != nil {
 			ra.log.Warningf("building rate limit transactions at finalize: %s", err)
 		}
 		transactions = append(transactions, txns...)
-	}
+	} else {
+		if !isRenewal {
+			txns, err := ra.txnBuilder.CertificatesPerDomainSpendOnlyTransactions(regId, orderIdents)
+			if err != nil {
+				ra.log.Warningf("building rate limit transactions at finalize: %s", err)
+			}
+			transactions = append(transactions, txns...)
+		}
 
-	txn, err := ra.txnBuilder.CertificatesPerFQDNSetSpendOnlyTransaction(orderIdents)
-	if err != nil {
-		ra.log.Warningf("building rate limit transaction at finalize: %s", err)
+		txn, err := ra.txnBuilder.CertificatesPerFQDNSetSpendOnlyTransaction(orderIdents)
+		if err != nil {
+			ra.log.Warningf("building rate limit transaction at finalize: %s", err)
+		}
+		transactions = append(transactions, txn)
 	}
-	transactions = append(transactions, txn)
 
-	_, err = ra.limiter.BatchSpend(ctx, transactions)
+	_, err := ra.limiter.BatchSpend(ctx, transactions)
 	if err != nil {
 		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
 			return
@@ -1327,7 +1614,14 @@ func (ra *RegistrationAuthorityImpl) issueCertificateInner(
 		return nil, wrapError(err, "parsing final certificate")
 	}
 
-	ra.countCertificateIssued(ctx, int64(acctID), identifier.FromCert(parsedCertificate), isRenewal)
+	// The profile was checked when the order was created, so if it has since
+	// been removed, count the certificate as an ordinary one.
+	var delegated bool
+	profile, err := ra.profiles.get(profileName)
+	if err == nil {
+		delegated = profile.delegated
+	}
+	ra.countCertificateIssued(ctx, int64(acctID), identifier.FromCert(parsedCertificate), isRenewal, delegated)
 
 	// Asynchronously submit the final certificate to any configured logs
 	go ra.ctpolicy.SubmitFinalCert(resp.DER, parsedCertificate.NotAfter)
@@ -1349,14 +1643,14 @@ func (ra *RegistrationAuthorityImpl) issueCertificateInner(
 	return parsedCertificate, nil
 }
 
-func (ra *RegistrationAuthorityImpl) getSCTs(ctx context.Context, precertDER []byte) (core.SCTDERs, error) {
+func (ra *RegistrationAuthorityImpl) getSCTs(ctx context.Context, precertDER []byte) (core.SCTDERs, []ctpolicy.SCTSource, error) {
 	started := ra.clk.Now()
 	precert, err := x509.ParseCertificate(precertDER)
 	if err != nil {
-		return nil, fmt.Errorf("parsing precertificate: %w", err)
+		return nil, nil, fmt.Errorf("parsing precertificate: %w", err)
 	}
 
-	scts, err := ra.ctpolicy.GetSCTs(ctx, precertDER, precert.NotAfter)
+	scts, sources, err := ra.ctpolicy.GetSCTs(ctx, precertDER, precert.NotAfter)
 	took := ra.clk.Since(started)
 	if err != nil {
 		state := "failure"
@@ -1368,10 +1662,10 @@ func (ra *RegistrationAuthorityImpl) getSCTs(ctx context.Context, precertDER []b
 		}
 		ra.log.Warningf("ctpolicy.GetSCTs failed: %s", err)
 		ra.ctpolicyResults.With(prometheus.Labels{"result": state}).Observe(took.Seconds())
-		return nil, err
+		return nil, nil, err
 	}
 	ra.ctpolicyResults.With(prometheus.Labels{"result": "success"}).Observe(took.Seconds())
-	return scts, nil
+	return scts, sources, nil
 }
 
 // UpdateRegistrationKey updates an existing Registration's key.
@@ -1388,12 +1682,13 @@ func (ra *RegistrationAuthorityImpl) UpdateRegistrationKey(ctx context.Context,
 		return nil, fmt.Errorf("failed to update registration key: %w", err)
 	}
 
+	ra.countAccountKey(update.Key, "key-change")
 	return update, nil
 }
 
 // recordValidation records an authorization validation event,
 // it should only be used on v2 style authorizations.
-func (ra *RegistrationAuthorityImpl) recordValidation(ctx context.Context, authID string, authExpires time.Time, challenge *core.Challenge) error {
+func (ra *RegistrationAuthorityImpl) recordValidation(ctx context.Context, authID string, authExpires time.Time, challenge *core.Challenge, perspectives []*corepb.ValidationPerspective) error {
 	authzID, err := strconv.ParseInt(authID, 10, 64)
 	if err != nil {
 		return err
@@ -1414,6 +1709,7 @@ func (ra *RegistrationAuthorityImpl) recordValidation(ctx context.Context, authI
 		AttemptedAt:       validated,
 		ValidationRecords: vr.Records,
 		ValidationError:   vr.Problem,
+		Perspectives:      perspectives,
 	})
 	return err
 }
@@ -1471,15 +1767,16 @@ func (ra *RegistrationAuthorityImpl) resetAccountPausingLimit(ctx context.Contex
 }
 
 // doDCVAndCAA performs DCV and CAA checks sequentially: DCV is performed first
-// and CAA is only checked if DCV is successful. Validation records from the DCV
-// check are returned even if the CAA check fails.
-func (ra *RegistrationAuthorityImpl) checkDCVAndCAA(ctx context.Context, dcvReq *vapb.PerformValidationRequest, caaReq *vapb.IsCAAValidRequest) (*corepb.ProblemDetails, []*corepb.ValidationRecord, error) {
+// and CAA is only checked if DCV is successful. The result of the DCV check,
+// including its validation records and perspectives, is returned even if the
+// CAA check fails.
+func (ra *RegistrationAuthorityImpl) checkDCVAndCAA(ctx context.Context, dcvReq *vapb.PerformValidationRequest, caaReq *vapb.IsCAAValidRequest) (*corepb.ProblemDetails, *vapb.ValidationResult, error) {
 	doDCVRes, err := ra.VA.DoDCV(ctx, dcvReq)
 	if err != nil {
 		return nil, nil, err
 	}
 	if doDCVRes.Problem != nil {
-		return doDCVRes.Problem, doDCVRes.Records, nil
+		return doDCVRes.Problem, doDCVRes, nil
 	}
 
 	switch identifier.FromProto(dcvReq.Identifier).Type {
@@ -1488,9 +1785,9 @@ func (ra *RegistrationAuthorityImpl) checkDCVAndCAA(ctx context.Context, dcvReq
 		if err != nil {
 			return nil, nil, err
 		}
-		return doCAAResp.Problem, doDCVRes.Records, nil
+		return doCAAResp.Problem, doDCVRes, nil
 	case identifier.TypeIP:
-		return nil, doDCVRes.Records, nil
+		return nil, doDCVRes, nil
 	default:
 		return nil, nil, berrors.MalformedError("invalid identifier type: %s", dcvReq.Identifier.Type)
 	}
@@ -1524,6 +1821,7 @@ func (ra *RegistrationAuthorityImpl) PerformValidation(
 	if err != nil {
 		return nil, err
 	}
+	profile = profile.forAccount(authz.RegistrationID)
 
 	challIndex := int(req.ChallengeIndex)
 	if challIndex >= len(authz.Challenges) {
@@ -1571,11 +1869,38 @@ func (ra *RegistrationAuthorityImpl) PerformValidation(
 		return nil, berrors.MalformedError("cannot validate challenge: %s", cErr.Error())
 	}
 
+	authzID, err := strconv.ParseInt(authz.ID, 10, 64)
+	if err != nil {
+		return nil, berrors.InternalServerError("parsing authorization ID: %s", err)
+	}
+
+	// If failed attempts leave the authorization pending, make the subscriber
+	// wait between them rather than hammer the VA.
+	if profile.maxValidationFailures > 1 && profile.validationRetryBackoff > 0 {
+		failures, err := ra.SA.GetValidationFailures(ctx, &sapb.AuthorizationID2{Id: authzID})
+		if err != nil {
+			return nil, berrors.InternalServerError("getting failed validations for authorization: %s", err)
+		}
+		if failures.Count > 0 {
+			retryAt := failures.LastFailure.AsTime().Add(profile.validationRetryBackoff)
+			if ra.clk.Now().Before(retryAt) {
+				return nil, berrors.RateLimitError(retryAt.Sub(ra.clk.Now()),
+					"authorization failed validation %d time(s), most recently at %s; retry after %s",
+					failures.Count, failures.LastFailure.AsTime().Format(time.RFC3339), retryAt.Format(time.RFC3339))
+			}
+		}
+	}
+
 	// Dispatch to the VA for service
 	ra.drainWG.Add(1)
+	ra.validations.start(authzID)
 	vaCtx := context.Background()
 	go func(authz core.Authorization) {
 		defer ra.drainWG.Done()
+		// recorded is the authorization with the result of the validation, if
+		// it's recorded, for any AwaitValidation requests.
+		var recorded *corepb.Authorization
+		defer func() { ra.validations.finish(authzID, recorded) }()
 
 		// We will mutate challenges later in this goroutine to change status and
 		// add error, but we also return a copy of authz immediately. To avoid a
@@ -1584,7 +1909,7 @@ func (ra *RegistrationAuthorityImpl) PerformValidation(
 		copy(challenges, authz.Challenges)
 		authz.Challenges = challenges
 		chall, _ := bgrpc.ChallengeToPB(authz.Challenges[challIndex])
-		checkProb, checkRecords, err := ra.checkDCVAndCAA(
+		checkProb, dcvRes, err := ra.checkDCVAndCAA(
 			vaCtx,
 			&vapb.PerformValidationRequest{
 				Identifier:               authz.Identifier.ToProto(),
@@ -1613,8 +1938,8 @@ func (ra *RegistrationAuthorityImpl) PerformValidation(
 				}
 			}
 			// Save the updated records
-			records := make([]core.ValidationRecord, len(checkRecords))
-			for i, r := range checkRecords {
+			records := make([]core.ValidationRecord, len(dcvRes.GetRecords()))
+			for i, r := range dcvRes.GetRecords() {
 				records[i], err = bgrpc.PBToValidationRecord(r)
 				if err != nil {
 					prob = probs.ServerInternal("Records for validation corrupt")
@@ -1626,6 +1951,44 @@ func (ra *RegistrationAuthorityImpl) PerformValidation(
 			prob = probs.ServerInternal("Records for validation failed sanity check")
 		}
 
+		if prob != nil && profile.maxValidationFailures > 1 {
+			var failures *sapb.ValidationFailures
+			probPB, err := bgrpc.ProblemDetailsToPB(prob)
+			if err == nil {
+				failures, err = ra.SA.AddValidationFailure(vaCtx, &sapb.AddValidationFailureRequest{
+					Id:        authzID,
+					Challenge: string(challenge.Type),
+					Problem:   probPB,
+				})
+			}
+			if err != nil {
+				// Without a count, fall back to invalidating the authorization.
+				ra.log.Warningf("recording failed validation: regID=[%d] authzID=[%s] err=[%s]",
+					authz.RegistrationID, authz.ID, err)
+			} else if failures.Count < int64(profile.maxValidationFailures) {
+				ra.log.Infof("Failed validation left authorization pending: regID=[%d] authzID=[%s] failures=[%d] problem=[%s]",
+					authz.RegistrationID, authz.ID, failures.Count, prob)
+				// The challenge stays pending, so that it can be retried, but
+				// carries the problem so that the subscriber can see why this
+				// attempt failed. GetAuthorization attaches it to the challenge
+				// from then on.
+				challenge.Status = core.StatusPending
+				challenge.Error = prob
+				challenge.Validated = &vStart
+				authz.Challenges[challIndex] = *challenge
+				recorded, err = bgrpc.AuthzToPB(authz)
+				if err != nil {
+					ra.log.Warningf("converting pending authorization: authzID=[%s] err=[%s]", authz.ID, err)
+					recorded = nil
+				}
+				return
+			} else {
+				prob = probs.New(probs.CodeTooManyFailedAttempts).Detail(
+					"Authorization failed validation %d times; the last attempt failed with %s: %s",
+					failures.Count, prob.Type, prob.Detail).Build()
+			}
+		}
+
 		expires := *authz.Expires
 		if prob != nil {
 			challenge.Status = core.StatusInvalid
@@ -1636,6 +1999,8 @@ func (ra *RegistrationAuthorityImpl) PerformValidation(
 			}
 		} else {
 			challenge.Status = core.StatusValid
+			// Clear the problem of any earlier failed attempt.
+			challenge.Error = nil
 			expires = ra.clk.Now().Add(profile.validAuthzLifetime)
 			if features.Get().AutomaticallyPauseZombieClients {
 				ra.resetAccountPausingLimit(vaCtx, authz.RegistrationID, authz.Identifier)
@@ -1644,7 +2009,7 @@ func (ra *RegistrationAuthorityImpl) PerformValidation(
 		challenge.Validated = &vStart
 		authz.Challenges[challIndex] = *challenge
 
-		err = ra.recordValidation(vaCtx, authz.ID, expires, challenge)
+		err = ra.recordValidation(vaCtx, authz.ID, expires, challenge, dcvRes.GetPerspectives())
 		if err != nil {
 			if errors.Is(err, berrors.NotFound) {
 				// We log NotFound at a lower level because this is largely due to a
@@ -1657,6 +2022,14 @@ func (ra *RegistrationAuthorityImpl) PerformValidation(
 				ra.log.AuditErrf("Failed to record validation: regID=[%d] authzID=[%s] err=[%s]",
 					authz.RegistrationID, authz.ID, err)
 			}
+			return
+		}
+		authz.Status = challenge.Status
+		authz.Expires = &expires
+		recorded, err = bgrpc.AuthzToPB(authz)
+		if err != nil {
+			ra.log.Warningf("converting recorded authorization: authzID=[%s] err=[%s]", authz.ID, err)
+			recorded = nil
 		}
 	}(authz)
 	return bgrpc.AuthzToPB(authz)
@@ -2159,13 +2532,19 @@ func (ra *RegistrationAuthorityImpl) AdministrativelyRevokeCertificate(ctx conte
 	return &emptypb.Empty{}, nil
 }
 
-// DeactivateRegistration deactivates a valid registration
+// DeactivateRegistration deactivates a valid registration, and, if requested,
+// records that all of its unexpired certificates are to be revoked.
 func (ra *RegistrationAuthorityImpl) DeactivateRegistration(ctx context.Context, req *rapb.DeactivateRegistrationRequest) (*corepb.Registration, error) {
 	if req == nil || req.RegistrationID == 0 {
 		return nil, errIncompleteGRPCRequest
 	}
 
-	updatedAcct, err := ra.SA.DeactivateRegistration(ctx, &sapb.RegistrationID{Id: req.RegistrationID})
+	// The revocation of the account's certificates is recorded along with its
+	// deactivation, and performed in the background by an accountRevoker.
+	updatedAcct, err := ra.SA.DeactivateRegistration(ctx, &sapb.DeactivateRegistrationRequest{
+		RegistrationID:     req.RegistrationID,
+		RevokeCertificates: req.RevokeCertificates,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -2240,23 +2619,19 @@ func (ra *RegistrationAuthorityImpl) GenerateOCSP(ctx context.Context, req *rapb
 	})
 }
 
-// NewOrder creates a new order object
-func (ra *RegistrationAuthorityImpl) NewOrder(ctx context.Context, req *rapb.NewOrderRequest) (*corepb.Order, error) {
-	if req == nil || req.RegistrationID == 0 {
-		return nil, errIncompleteGRPCRequest
-	}
-
-	idents := identifier.Normalize(identifier.FromProtoSlice(req.Identifiers))
-
-	profile, err := ra.profiles.get(req.CertificateProfileName)
+// checkOrderProfile returns the certificate profile named by an order, with
+// any lifetimes overridden for the account, or an error if the account may not
+// use it or the order's identifiers don't fit it.
+func (ra *RegistrationAuthorityImpl) checkOrderProfile(regID int64, profileName string, idents identifier.ACMEIdentifiers) (*validationProfile, error) {
+	profile, err := ra.profiles.get(profileName)
 	if err != nil {
 		return nil, err
 	}
 
-	if profile.allowList != nil && !profile.allowList.Contains(req.RegistrationID) {
+	if profile.allowList != nil && !profile.allowList.Contains(regID) {
 		return nil, berrors.UnauthorizedError("account ID %d is not permitted to use certificate profile %q",
-			req.RegistrationID,
-			req.CertificateProfileName,
+			regID,
+			profileName,
 		)
 	}
 
@@ -2267,9 +2642,43 @@ func (ra *RegistrationAuthorityImpl) NewOrder(ctx context.Context, req *rapb.New
 
 	for _, ident := range idents {
 		if !slices.Contains(profile.identifierTypes, ident.Type) {
-			return nil, berrors.RejectedIdentifierError("Profile %q does not permit %s type identifiers", req.CertificateProfileName, ident.Type)
+			return nil, berrors.RejectedIdentifierError("Profile %q does not permit %s type identifiers", profileName, ident.Type)
 		}
 	}
+	return profile.forAccount(regID), nil
+}
+
+// checkDelegatedLimit returns an error if issuing a delegated certificate for
+// idents would exceed the DelegatedCertificatesPerDomain limit. The WFE checks
+// the other new order limits, but only the RA knows which profiles are
+// delegated.
+func (ra *RegistrationAuthorityImpl) checkDelegatedLimit(ctx context.Context, idents identifier.ACMEIdentifiers) error {
+	if ra.limiter == nil || ra.txnBuilder == nil {
+		return nil
+	}
+	txns, err := ra.txnBuilder.DelegatedCertificatesPerDomainCheckOnlyTransactions(idents)
+	if err != nil {
+		return fmt.Errorf("building delegated certificate limit transactions: %w", err)
+	}
+	d, err := ra.limiter.BatchSpend(ctx, txns)
+	if err != nil {
+		return fmt.Errorf("checking delegated certificate limits: %w", err)
+	}
+	return d.Result(ra.clk.Now())
+}
+
+// NewOrder creates a new order object
+func (ra *RegistrationAuthorityImpl) NewOrder(ctx context.Context, req *rapb.NewOrderRequest) (*corepb.Order, error) {
+	if req == nil || req.RegistrationID == 0 {
+		return nil, errIncompleteGRPCRequest
+	}
+
+	idents := identifier.Normalize(identifier.FromProtoSlice(req.Identifiers))
+
+	profile, err := ra.checkOrderProfile(req.RegistrationID, req.CertificateProfileName, idents)
+	if err != nil {
+		return nil, err
+	}
 
 	// Validate that our policy allows issuing for each of the identifiers in
 	// the order
@@ -2283,6 +2692,13 @@ func (ra *RegistrationAuthorityImpl) NewOrder(ctx context.Context, req *rapb.New
 		return nil, err
 	}
 
+	if profile.delegated {
+		err = ra.checkDelegatedLimit(ctx, idents)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// See if there is an existing unexpired pending (or ready) order that can be reused
 	// for this account
 	existingOrder, err := ra.SA.GetOrderForNames(ctx, &sapb.GetOrderForNamesRequest{
@@ -2377,6 +2793,14 @@ func (ra *RegistrationAuthorityImpl) NewOrder(ctx context.Context, req *rapb.New
 			continue
 		}
 
+		// If the authz's validation will be too old to finalize with before
+		// the cutoff, don't reuse it.
+		if reusableUntil(authz, profile.maxValidationAge).Before(authzExpiryCutoff) {
+			missingAuthzIdents = append(missingAuthzIdents, ident)
+			delete(identToExistingAuthz, ident)
+			continue
+		}
+
 		// This is only used for our metrics.
 		authzAge := (profile.validAuthzLifetime - authz.Expires.Sub(ra.clk.Now())).Seconds()
 		if authz.Status == core.StatusPending {
@@ -2441,12 +2865,25 @@ func (ra *RegistrationAuthorityImpl) NewOrder(ctx context.Context, req *rapb.New
 				"SA.GetAuthorizations returned an authz (%s) with zero expiry",
 				authz.ID)
 		}
-		// If the reused authorization expires before the minExpiry, it's expiry
-		// is the new minExpiry.
-		if authz.Expires.Before(minExpiry) {
-			minExpiry = *authz.Expires
+		// If the reused authorization expires, or its validation becomes too
+		// old to finalize with, before the minExpiry, that is the new
+		// minExpiry.
+		reusable := reusableUntil(authz, profile.maxValidationAge)
+		if reusable.Before(minExpiry) {
+			minExpiry = reusable
+		}
+	}
+	// Reused valid authorizations may have CAA checks which will be too old to
+	// reuse by the time this order is finalized. Refresh them in the background
+	// so that finalize doesn't have to.
+	if ra.caaRechecker != nil && len(identToExistingAuthz) > 0 {
+		reused := make([]*core.Authorization, 0, len(identToExistingAuthz))
+		for _, authz := range identToExistingAuthz {
+			reused = append(reused, authz)
 		}
+		ra.caaRechecker.schedule(reused)
 	}
+
 	// If the newly created pending authz's have an expiry closer than the
 	// minExpiry the minExpiry is the pending authz expiry.
 	if len(newAuthzs) > 0 {
@@ -2538,6 +2975,10 @@ func (ra *RegistrationAuthorityImpl) GetAuthorization(ctx context.Context, req *
 		return nil, fmt.Errorf("getting authz from SA: %w", err)
 	}
 
+	if core.AcmeStatus(authz.Status) == core.StatusPending {
+		ra.addLastValidationProblem(ctx, req.Id, authz)
+	}
+
 	// Filter out any challenges which are currently disabled, so that the client
 	// doesn't attempt them.
 	challs := []*corepb.Challenge{}
@@ -2551,6 +2992,31 @@ func (ra *RegistrationAuthorityImpl) GetAuthorization(ctx context.Context, req *
 	return authz, nil
 }
 
+// addLastValidationProblem attaches the problem of the last failed validation
+// of a pending authorization, if its profile leaves it pending after a failed
+// validation, to the challenge which failed. Failures are logged, but otherwise
+// ignored, since the authorization is still correct without the problem.
+func (ra *RegistrationAuthorityImpl) addLastValidationProblem(ctx context.Context, authzID int64, authz *corepb.Authorization) {
+	profile, err := ra.profiles.get(authz.CertificateProfileName)
+	if err != nil || profile.maxValidationFailures <= 1 {
+		return
+	}
+	failures, err := ra.SA.GetValidationFailures(ctx, &sapb.AuthorizationID2{Id: authzID})
+	if err != nil {
+		ra.log.Warningf("getting failed validations for authorization: authzID=[%d] err=[%s]", authzID, err)
+		return
+	}
+	if failures.Count == 0 || failures.LastProblem == nil {
+		return
+	}
+	for _, chall := range authz.Challenges {
+		if chall.Type == failures.LastChallenge {
+			chall.Error = failures.LastProblem
+			chall.Validated = failures.LastFailure
+		}
+	}
+}
+
 // AddRateLimitOverride dispatches an SA RPC to add a rate limit override to the
 // database. If the override already exists, it will be updated. If the override
 // does not exist, it will be inserted and enabled. If the override exists but
@@ -2591,4 +3057,10 @@ func (ra *RegistrationAuthorityImpl) AddRateLimitOverride(ctx context.Context, r
 // being done in detached goroutines.
 func (ra *RegistrationAuthorityImpl) Drain() {
 	ra.drainWG.Wait()
+	if ra.caaRechecker != nil {
+		ra.caaRechecker.shutdown()
+	}
+	if ra.accountRevoker != nil {
+		ra.accountRevoker.shutdown()
+	}
 }
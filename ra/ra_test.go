@@ -7,6 +7,7 @@ import (
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/asn1"
@@ -55,6 +56,7 @@ import (
 	"github.com/letsencrypt/boulder/metrics"
 	"github.com/letsencrypt/boulder/mocks"
 	"github.com/letsencrypt/boulder/policy"
+	"github.com/letsencrypt/boulder/probs"
 	pubpb "github.com/letsencrypt/boulder/publisher/proto"
 	rapb "github.com/letsencrypt/boulder/ra/proto"
 	"github.com/letsencrypt/boulder/ratelimits"
@@ -289,6 +291,30 @@ var (
 var ctx = context.Background()
 
 func initAuthorities(t *testing.T) (*DummyValidationAuthority, sapb.StorageAuthorityClient, *RegistrationAuthorityImpl, ratelimits.Source, clock.FakeClock, func()) {
+	return initAuthoritiesWithSA(t, func(fc clock.FakeClock) (sapb.StorageAuthorityServer, func()) {
+		dbMap, err := sa.DBMapForTest(vars.DBConnSA)
+		if err != nil {
+			t.Fatalf("Failed to create dbMap: %s", err)
+		}
+		ssa, err := sa.NewSQLStorageAuthority(dbMap, dbMap, nil, 1, 0, fc, log, metrics.NoopRegisterer)
+		if err != nil {
+			t.Fatalf("Failed to create SA: %s", err)
+		}
+		return ssa, test.ResetBoulderTestDatabase(t)
+	})
+}
+
+// initAuthoritiesWithMemorySA is like initAuthorities, but backs the RA with
+// an in-memory SA rather than the test database.
+func initAuthoritiesWithMemorySA(t *testing.T) (*DummyValidationAuthority, sapb.StorageAuthorityClient, *RegistrationAuthorityImpl, ratelimits.Source, clock.FakeClock, func()) {
+	return initAuthoritiesWithSA(t, func(fc clock.FakeClock) (sapb.StorageAuthorityServer, func()) {
+		return isa.NewMemoryStorageAuthority(fc), func() {}
+	})
+}
+
+// initAuthoritiesWithSA sets up an RA, and the authorities around it, using
+// the SA and cleanup function returned by newSA.
+func initAuthoritiesWithSA(t *testing.T, newSA func(clock.FakeClock) (sapb.StorageAuthorityServer, func())) (*DummyValidationAuthority, sapb.StorageAuthorityClient, *RegistrationAuthorityImpl, ratelimits.Source, clock.FakeClock, func()) {
 	err := json.Unmarshal(AccountKeyJSONA, &AccountKeyA)
 	test.AssertNotError(t, err, "Failed to unmarshal public JWK")
 	err = json.Unmarshal(AccountKeyJSONB, &AccountKeyB)
@@ -306,18 +332,9 @@ func initAuthorities(t *testing.T) (*DummyValidationAuthority, sapb.StorageAutho
 	// Set to some non-zero time.
 	fc.Set(time.Date(2020, 3, 4, 5, 0, 0, 0, time.UTC))
 
-	dbMap, err := sa.DBMapForTest(vars.DBConnSA)
-	if err != nil {
-		t.Fatalf("Failed to create dbMap: %s", err)
-	}
-	ssa, err := sa.NewSQLStorageAuthority(dbMap, dbMap, nil, 1, 0, fc, log, metrics.NoopRegisterer)
-	if err != nil {
-		t.Fatalf("Failed to create SA: %s", err)
-	}
+	ssa, saCleanUp := newSA(fc)
 	sa := &isa.SA{Impl: ssa}
 
-	saDBCleanUp := test.ResetBoulderTestDatabase(t)
-
 	dummyVA := &DummyValidationAuthority{
 		doDCVRequest: make(chan *vapb.PerformValidationRequest, 1),
 		doCAARequest: make(chan *vapb.IsCAAValidRequest, 1),
@@ -344,7 +361,7 @@ func initAuthorities(t *testing.T) (*DummyValidationAuthority, sapb.StorageAutho
 		PEM: eeCertPEM,
 	}
 	cleanUp := func() {
-		saDBCleanUp()
+		saCleanUp()
 	}
 
 	block, _ := pem.Decode(CSRPEM)
@@ -362,9 +379,9 @@ func initAuthorities(t *testing.T) (*DummyValidationAuthority, sapb.StorageAutho
 	}, nil, nil, 0, log, metrics.NoopRegisterer)
 
 	rlSource := ratelimits.NewInmemSource()
-	limiter, err := ratelimits.NewLimiter(fc, rlSource, stats)
+	limiter, err := ratelimits.NewLimiter(fc, rlSource, stats, log)
 	test.AssertNotError(t, err, "making limiter")
-	txnBuilder, err := ratelimits.NewTransactionBuilderFromFiles("../test/config-next/wfe2-ratelimit-defaults.yml", "")
+	txnBuilder, err := ratelimits.NewTransactionBuilderFromFiles("../test/config-next/wfe2-ratelimit-defaults.yml", "", ratelimits.KeyConfig{})
 	test.AssertNotError(t, err, "making transaction composer")
 
 	testKeyPolicy, err := goodkey.NewPolicy(nil, nil)
@@ -674,6 +691,51 @@ func TestPerformValidationSuccess(t *testing.T) {
 	}
 }
 
+func TestPerformValidationRecordsPerspectives(t *testing.T) {
+	va, sa, ra, _, fc, cleanUp := initAuthoritiesWithMemorySA(t)
+	defer cleanUp()
+
+	authzPB := createPendingAuthorization(t, sa, identifier.NewDNS("example.com"), fc.Now().Add(12*time.Hour))
+	va.doDCVResult = &vapb.ValidationResult{
+		Records: []*corepb.ValidationRecord{
+			{
+				AddressUsed:   []byte("192.168.0.1"),
+				Hostname:      "example.com",
+				Port:          "8080",
+				Url:           "http://example.com/",
+				ResolverAddrs: []string{"rebound"},
+			},
+		},
+		Perspectives: []*corepb.ValidationPerspective{
+			{Perspective: "Primary", Passed: true, Latency: durationpb.New(time.Second)},
+			{Perspective: "dc-0-ARIN", Rir: "ARIN", Passed: true, Latency: durationpb.New(2 * time.Second)},
+			{Perspective: "dc-1-RIPE", Rir: "RIPE", Passed: false, Latency: durationpb.New(3 * time.Second)},
+		},
+	}
+	va.doCAAResponse = &vapb.IsCAAValidResponse{}
+
+	_, err := ra.PerformValidation(ctx, &rapb.PerformValidationRequest{
+		Authz:          authzPB,
+		ChallengeIndex: dnsChallIdx(t, authzPB.Challenges),
+	})
+	test.AssertNotError(t, err, "PerformValidation failed")
+	<-va.doDCVRequest
+	<-va.doCAARequest
+	ra.drainWG.Wait()
+
+	// The perspectives reported by the VA are recorded with the validation.
+	stats, err := sa.GetMPICComplianceStats(ctx, &sapb.GetMPICComplianceStatsRequest{
+		AttemptedAfter:  timestamppb.New(fc.Now().Add(-time.Hour)),
+		AttemptedBefore: timestamppb.New(fc.Now().Add(time.Hour)),
+	})
+	test.AssertNotError(t, err, "GetMPICComplianceStats failed")
+	test.AssertEquals(t, stats.Attempts, int64(1))
+	test.AssertEquals(t, len(stats.Perspectives), 3)
+	test.AssertEquals(t, stats.Perspectives[2].Perspective, "dc-1-RIPE")
+	test.AssertEquals(t, stats.Perspectives[2].Passed, int64(0))
+	test.AssertEquals(t, stats.Perspectives[2].MaxLatency.AsDuration(), 3*time.Second)
+}
+
 // mockSAWithSyncPause is a mock sapb.StorageAuthorityClient that forwards all
 // method calls to an inner SA, but also performs a blocking write to a channel
 // when PauseIdentifiers is called to allow the tests to synchronize.
@@ -786,7 +848,7 @@ func TestPerformValidation_FailedThenSuccessfulValidationResetsPauseIdentifiersR
 	limiter, err := ratelimits.NewLimiter(fc, mockRLSourceWithSyncDelete{
 		Source: rl,
 		out:    keyChan,
-	}, metrics.NoopRegisterer)
+	}, metrics.NoopRegisterer, blog.NewMock())
 	test.AssertNotError(t, err, "creating mock limiter")
 	ra.limiter = limiter
 
@@ -881,6 +943,88 @@ func TestPerformValidationVAError(t *testing.T) {
 	test.Assert(t, *challenge.Validated == expectedValidated, "Validated timestamp incorrect or missing")
 }
 
+func TestPerformValidationMaxValidationFailures(t *testing.T) {
+	va, sa, ra, _, fc, cleanUp := initAuthoritiesWithMemorySA(t)
+	defer cleanUp()
+
+	profiles, err := NewValidationProfiles("one", map[string]*ValidationProfileConfig{
+		"one": {
+			PendingAuthzLifetime:   config.Duration{Duration: 7 * 24 * time.Hour},
+			ValidAuthzLifetime:     config.Duration{Duration: 30 * 24 * time.Hour},
+			OrderLifetime:          config.Duration{Duration: 7 * 24 * time.Hour},
+			MaxValidationFailures:  2,
+			ValidationRetryBackoff: config.Duration{Duration: time.Minute},
+			MaxNames:               10,
+			IdentifierTypes:        []identifier.IdentifierType{identifier.TypeDNS},
+		},
+	})
+	test.AssertNotError(t, err, "creating profiles")
+	ra.profiles = profiles
+
+	authzPB := createPendingAuthorization(t, sa, identifier.NewDNS("example.com"), fc.Now().Add(12*time.Hour))
+	challIdx := dnsChallIdx(t, authzPB.Challenges)
+	va.doDCVResult = &vapb.ValidationResult{
+		Problem: &corepb.ProblemDetails{ProblemType: string(probs.ConnectionProblem), Detail: "timeout"},
+	}
+
+	validate := func() error {
+		t.Helper()
+		_, err := ra.PerformValidation(ctx, &rapb.PerformValidationRequest{
+			Authz:          authzPB,
+			ChallengeIndex: challIdx,
+		})
+		if err != nil {
+			return err
+		}
+		<-va.doDCVRequest
+		ra.drainWG.Wait()
+		return nil
+	}
+
+	// The first failure leaves the authorization pending.
+	err = validate()
+	test.AssertNotError(t, err, "PerformValidation failed")
+	dbAuthzPB := getAuthorization(t, authzPB.Id, sa)
+	test.AssertEquals(t, dbAuthzPB.Status, string(core.StatusPending))
+	test.AssertEquals(t, dbAuthzPB.Challenges[challIdx].Status, string(core.StatusPending))
+
+	// The RA attaches the problem to the pending challenge, which can still be
+	// retried.
+	authzID, err := strconv.ParseInt(authzPB.Id, 10, 64)
+	test.AssertNotError(t, err, "parsing authorization ID")
+	raAuthzPB, err := ra.GetAuthorization(ctx, &rapb.GetAuthorizationRequest{Id: authzID})
+	test.AssertNotError(t, err, "GetAuthorization failed")
+	test.AssertEquals(t, raAuthzPB.Status, string(core.StatusPending))
+	challenge, err := bgrpc.PBToChallenge(raAuthzPB.Challenges[dnsChallIdx(t, raAuthzPB.Challenges)])
+	test.AssertNotError(t, err, "converting challenge")
+	test.AssertEquals(t, challenge.Status, core.StatusPending)
+	test.AssertNotNil(t, challenge.Error, "pending challenge should carry the failed attempt's problem")
+	test.AssertEquals(t, challenge.Error.Type, probs.ConnectionProblem)
+	test.AssertEquals(t, challenge.Error.Detail, "timeout")
+
+	// Trying again before the backoff has passed is refused.
+	fc.Add(30 * time.Second)
+	err = validate()
+	test.AssertErrorIs(t, err, berrors.RateLimit)
+	var bErr *berrors.BoulderError
+	test.Assert(t, errors.As(err, &bErr), "expected a BoulderError")
+	test.AssertEquals(t, bErr.RetryAfter, 30*time.Second)
+
+	// The second failure exhausts the authorization's attempts.
+	fc.Add(30 * time.Second)
+	err = validate()
+	test.AssertNotError(t, err, "PerformValidation failed")
+	dbAuthzPB = getAuthorization(t, authzPB.Id, sa)
+	test.AssertEquals(t, dbAuthzPB.Status, string(core.StatusInvalid))
+	challenge, err = bgrpc.PBToChallenge(dbAuthzPB.Challenges[dnsChallIdx(t, dbAuthzPB.Challenges)])
+	test.AssertNotError(t, err, "converting challenge")
+	test.AssertEquals(t, challenge.Status, core.StatusInvalid)
+	test.AssertEquals(t, challenge.Error.Code, probs.CodeTooManyFailedAttempts)
+	test.AssertEquals(t, challenge.Error.Type, probs.RateLimitedProblem)
+	test.AssertContains(t, challenge.Error.Detail, "failed validation 2 times")
+	test.AssertContains(t, challenge.Error.Detail, "timeout")
+}
+
 func TestCertificateKeyNotEqualAccountKey(t *testing.T) {
 	_, sa, ra, _, _, cleanUp := initAuthorities(t)
 	defer cleanUp()
@@ -1878,6 +2022,257 @@ func TestNewOrder_ValidationProfiles(t *testing.T) {
 	}
 }
 
+func TestNewValidationProfilesAccountOverrides(t *testing.T) {
+	t.Parallel()
+
+	profile := func(overrides ...AccountLifetimesConfig) map[string]*ValidationProfileConfig {
+		return map[string]*ValidationProfileConfig{
+			"default": {
+				PendingAuthzLifetime: config.Duration{Duration: 7 * time.Hour},
+				ValidAuthzLifetime:   config.Duration{Duration: 7 * time.Hour},
+				OrderLifetime:        config.Duration{Duration: 7 * time.Hour},
+				MaxNames:             10,
+				IdentifierTypes:      []identifier.IdentifierType{identifier.TypeDNS},
+				AccountOverrides:     overrides,
+			},
+		}
+	}
+
+	profiles, err := NewValidationProfiles("default", profile(
+		AccountLifetimesConfig{AccountIDs: []int64{1, 2}, OrderLifetime: config.Duration{Duration: 48 * time.Hour}},
+		AccountLifetimesConfig{AccountIDs: []int64{3}, ValidAuthzLifetime: config.Duration{Duration: 30 * 24 * time.Hour}},
+	))
+	test.AssertNotError(t, err, "valid overrides")
+	def := profiles.def()
+	test.AssertEquals(t, def.forAccount(4), def)
+	test.AssertEquals(t, def.forAccount(1).orderLifetime, 48*time.Hour)
+	test.AssertEquals(t, def.forAccount(2).orderLifetime, 48*time.Hour)
+	test.AssertEquals(t, def.forAccount(2).pendingAuthzLifetime, 7*time.Hour)
+	test.AssertEquals(t, def.forAccount(3).validAuthzLifetime, 30*24*time.Hour)
+	test.AssertEquals(t, def.forAccount(3).orderLifetime, 7*time.Hour)
+	test.AssertEquals(t, def.forAccount(3).maxNames, 10)
+
+	_, err = NewValidationProfiles("default", profile(
+		AccountLifetimesConfig{AccountIDs: []int64{1}, PendingAuthzLifetime: config.Duration{Duration: 30 * 24 * time.Hour}},
+	))
+	test.AssertContains(t, err.Error(), "PendingAuthzLifetime value must be greater than 0 and less than 30d")
+	_, err = NewValidationProfiles("default", profile(
+		AccountLifetimesConfig{AccountIDs: []int64{1}, ValidAuthzLifetime: config.Duration{Duration: 400 * 24 * time.Hour}},
+	))
+	test.AssertContains(t, err.Error(), "ValidAuthzLifetime value must be greater than 0 and less than 398d")
+	_, err = NewValidationProfiles("default", profile(
+		AccountLifetimesConfig{AccountIDs: []int64{1}, OrderLifetime: config.Duration{Duration: time.Hour}},
+		AccountLifetimesConfig{AccountIDs: []int64{1}, OrderLifetime: config.Duration{Duration: 2 * time.Hour}},
+	))
+	test.AssertContains(t, err.Error(), "account ID 1 appears in more than one account override")
+	_, err = NewValidationProfiles("default", profile(
+		AccountLifetimesConfig{OrderLifetime: config.Duration{Duration: time.Hour}},
+	))
+	test.AssertContains(t, err.Error(), "account override has no account IDs")
+}
+
+func TestNewValidationProfilesMaxValidationAge(t *testing.T) {
+	t.Parallel()
+
+	profile := func(maxValidationAge time.Duration) map[string]*ValidationProfileConfig {
+		return map[string]*ValidationProfileConfig{
+			"default": {
+				PendingAuthzLifetime: config.Duration{Duration: 7 * time.Hour},
+				ValidAuthzLifetime:   config.Duration{Duration: 30 * 24 * time.Hour},
+				OrderLifetime:        config.Duration{Duration: 7 * time.Hour},
+				MaxValidationAge:     config.Duration{Duration: maxValidationAge},
+				MaxNames:             10,
+				IdentifierTypes:      []identifier.IdentifierType{identifier.TypeDNS},
+			},
+		}
+	}
+
+	profiles, err := NewValidationProfiles("default", profile(10*24*time.Hour))
+	test.AssertNotError(t, err, "valid maxValidationAge")
+	test.AssertEquals(t, profiles.def().maxValidationAge, 10*24*time.Hour)
+
+	_, err = NewValidationProfiles("default", profile(-time.Hour))
+	test.AssertContains(t, err.Error(), "MaxValidationAge must not be negative")
+}
+
+func TestNewValidationProfilesMaxValidationFailures(t *testing.T) {
+	t.Parallel()
+
+	profile := func(maxValidationFailures int, backoff time.Duration) map[string]*ValidationProfileConfig {
+		return map[string]*ValidationProfileConfig{
+			"default": {
+				PendingAuthzLifetime:   config.Duration{Duration: 7 * time.Hour},
+				ValidAuthzLifetime:     config.Duration{Duration: 30 * 24 * time.Hour},
+				OrderLifetime:          config.Duration{Duration: 7 * time.Hour},
+				MaxValidationFailures:  maxValidationFailures,
+				ValidationRetryBackoff: config.Duration{Duration: backoff},
+				MaxNames:               10,
+				IdentifierTypes:        []identifier.IdentifierType{identifier.TypeDNS},
+			},
+		}
+	}
+
+	profiles, err := NewValidationProfiles("default", profile(5, time.Minute))
+	test.AssertNotError(t, err, "valid maxValidationFailures")
+	test.AssertEquals(t, profiles.def().maxValidationFailures, 5)
+	test.AssertEquals(t, profiles.def().validationRetryBackoff, time.Minute)
+
+	_, err = NewValidationProfiles("default", profile(-1, time.Minute))
+	test.AssertContains(t, err.Error(), "MaxValidationFailures must not be negative")
+
+	_, err = NewValidationProfiles("default", profile(5, -time.Minute))
+	test.AssertContains(t, err.Error(), "ValidationRetryBackoff must not be negative")
+}
+
+func TestReusableUntil(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	expires := now.Add(30 * 24 * time.Hour)
+	validated := now.Add(-24 * time.Hour)
+	authz := &core.Authorization{
+		Status:  core.StatusValid,
+		Expires: &expires,
+		Challenges: []core.Challenge{
+			{Status: core.StatusValid, Validated: &validated},
+		},
+	}
+
+	test.AssertEquals(t, reusableUntil(authz, 0), expires)
+	test.AssertEquals(t, reusableUntil(authz, 10*24*time.Hour), validated.Add(10*24*time.Hour))
+	test.AssertEquals(t, reusableUntil(authz, 100*24*time.Hour), expires)
+
+	// Pending authorizations haven't been validated, so only their expiry
+	// matters.
+	authz.Status = core.StatusPending
+	authz.Challenges[0].Status = core.StatusPending
+	test.AssertEquals(t, reusableUntil(authz, 10*24*time.Hour), expires)
+}
+
+func TestMaxValidationAge(t *testing.T) {
+	va, sa, ra, _, fc, cleanUp := initAuthoritiesWithMemorySA(t)
+	defer cleanUp()
+	va.doCAAResponse = &vapb.IsCAAValidResponse{}
+
+	profiles, err := NewValidationProfiles("one", map[string]*ValidationProfileConfig{
+		"one": {
+			PendingAuthzLifetime: config.Duration{Duration: 7 * 24 * time.Hour},
+			ValidAuthzLifetime:   config.Duration{Duration: 30 * 24 * time.Hour},
+			OrderLifetime:        config.Duration{Duration: 7 * 24 * time.Hour},
+			MaxValidationAge:     config.Duration{Duration: 10 * 24 * time.Hour},
+			MaxNames:             10,
+			IdentifierTypes:      []identifier.IdentifierType{identifier.TypeDNS},
+		},
+	})
+	test.AssertNotError(t, err, "creating profiles")
+	ra.profiles = profiles
+
+	// Create an order whose authorization for ident was validated eight days
+	// ago, and is valid for another 22. It has a second identifier so that
+	// it's not reused by orders for ident alone.
+	ident := identifier.NewDNS(randomDomain())
+	other := identifier.NewDNS(randomDomain())
+	authzExpires := fc.Now().Add(22 * 24 * time.Hour)
+	var newAuthzs []*sapb.NewAuthzRequest
+	for _, i := range []identifier.ACMEIdentifier{ident, other} {
+		newAuthzs = append(newAuthzs, &sapb.NewAuthzRequest{
+			Identifier:     i.ToProto(),
+			RegistrationID: Registration.Id,
+			Expires:        timestamppb.New(authzExpires),
+			ChallengeTypes: []string{string(core.ChallengeTypeHTTP01)},
+			Token:          core.NewToken(),
+		})
+	}
+	extant, err := sa.NewOrderAndAuthzs(context.Background(), &sapb.NewOrderAndAuthzsRequest{
+		NewOrder: &sapb.NewOrderRequest{
+			RegistrationID: Registration.Id,
+			Expires:        timestamppb.New(fc.Now().Add(7 * 24 * time.Hour)),
+			Identifiers:    []*corepb.Identifier{ident.ToProto(), other.ToProto()},
+		},
+		NewAuthzs: newAuthzs,
+	})
+	test.AssertNotError(t, err, "creating order")
+	var authzID int64
+	for _, id := range extant.V2Authorizations {
+		authz := getAuthorization(t, fmt.Sprint(id), sa)
+		if authz.Identifier.Value == ident.Value {
+			authzID = id
+		}
+	}
+	_, err = sa.FinalizeAuthorization2(context.Background(), &sapb.FinalizeAuthorizationRequest{
+		Id:          authzID,
+		Status:      string(core.StatusValid),
+		Expires:     timestamppb.New(authzExpires),
+		Attempted:   string(core.ChallengeTypeHTTP01),
+		AttemptedAt: timestamppb.New(fc.Now().Add(-8 * 24 * time.Hour)),
+	})
+	test.AssertNotError(t, err, "finalizing authz")
+
+	// The authorization is reused by a new order, which expires when its
+	// validation becomes too old.
+	order, err := ra.NewOrder(context.Background(), &rapb.NewOrderRequest{
+		RegistrationID: Registration.Id,
+		Identifiers:    []*corepb.Identifier{ident.ToProto()},
+	})
+	test.AssertNotError(t, err, "creating order")
+	test.AssertEquals(t, order.V2Authorizations[0], authzID)
+	test.AssertEquals(t, order.Expires.AsTime(), fc.Now().Add(2*24*time.Hour))
+
+	_, err = ra.checkOrderAuthorizations(context.Background(), orderID(order.Id), accountID(Registration.Id),
+		identifier.ACMEIdentifiers{ident}, profiles.def().maxValidationAge, fc.Now())
+	test.AssertNotError(t, err, "checking fresh authorizations")
+
+	// Once the validation is too old, finalizing fails, and it's no longer
+	// reused.
+	fc.Add(3 * 24 * time.Hour)
+	_, err = ra.checkOrderAuthorizations(context.Background(), orderID(order.Id), accountID(Registration.Id),
+		identifier.ACMEIdentifiers{ident}, profiles.def().maxValidationAge, fc.Now())
+	test.AssertErrorIs(t, err, berrors.Unauthorized)
+	test.AssertContains(t, err.Error(), "must be revalidated")
+
+	order, err = ra.NewOrder(context.Background(), &rapb.NewOrderRequest{
+		RegistrationID: Registration.Id,
+		Identifiers:    []*corepb.Identifier{ident.ToProto()},
+	})
+	test.AssertNotError(t, err, "creating order")
+	test.AssertNotEquals(t, order.V2Authorizations[0], authzID)
+}
+
+func TestNewOrder_AccountLifetimeOverrides(t *testing.T) {
+	_, _, ra, _, _, cleanUp := initAuthoritiesWithMemorySA(t)
+	defer cleanUp()
+
+	profiles, err := NewValidationProfiles("one", map[string]*ValidationProfileConfig{
+		"one": {
+			PendingAuthzLifetime: config.Duration{Duration: 24 * time.Hour},
+			ValidAuthzLifetime:   config.Duration{Duration: 24 * time.Hour},
+			OrderLifetime:        config.Duration{Duration: 24 * time.Hour},
+			MaxNames:             10,
+			IdentifierTypes:      []identifier.IdentifierType{identifier.TypeDNS},
+			AccountOverrides: []AccountLifetimesConfig{{
+				AccountIDs:           []int64{Registration.Id},
+				PendingAuthzLifetime: config.Duration{Duration: 3 * 24 * time.Hour},
+				OrderLifetime:        config.Duration{Duration: 2 * 24 * time.Hour},
+			}},
+		},
+	})
+	test.AssertNotError(t, err, "creating profiles")
+	ra.profiles = profiles
+
+	order, err := ra.NewOrder(context.Background(), &rapb.NewOrderRequest{
+		RegistrationID: Registration.Id,
+		Identifiers:    []*corepb.Identifier{identifier.NewDNS(randomDomain()).ToProto()},
+	})
+	test.AssertNotError(t, err, "creating order")
+	test.AssertEquals(t, order.Expires.AsTime(), ra.clk.Now().Add(2*24*time.Hour))
+
+	authz, err := ra.GetAuthorization(context.Background(), &rapb.GetAuthorizationRequest{
+		Id: order.V2Authorizations[0],
+	})
+	test.AssertNotError(t, err, "fetching authz")
+	test.AssertEquals(t, authz.Expires.AsTime(), ra.clk.Now().Add(3*24*time.Hour))
+}
+
 func TestNewOrder_ProfileSelectionAllowList(t *testing.T) {
 	_, _, ra, _, _, cleanUp := initAuthorities(t)
 	defer cleanUp()
@@ -2923,6 +3318,59 @@ func TestFinalizeOrderDisabledChallenge(t *testing.T) {
 	test.AssertContains(t, err.Error(), "authorizations for these identifiers not valid")
 }
 
+func TestFinalizeOrderRepeated(t *testing.T) {
+	_, sa, ra, _, fc, cleanUp := initAuthoritiesWithMemorySA(t)
+	defer cleanUp()
+	features.Set(features.Config{IdempotentFinalize: true})
+	defer features.Reset()
+
+	domain := randomDomain()
+	ident := identifier.NewDNS(domain)
+	createFinalizedAuthorization(
+		t, sa, ident, fc.Now().Add(24*time.Hour), core.ChallengeTypeHTTP01, fc.Now().Add(-1*time.Hour))
+	order, err := ra.NewOrder(context.Background(), &rapb.NewOrderRequest{
+		RegistrationID: Registration.Id,
+		Identifiers:    []*corepb.Identifier{ident.ToProto()},
+	})
+	test.AssertNotError(t, err, "creating test order")
+
+	newCSR := func() []byte {
+		testKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		test.AssertNotError(t, err, "generating test key")
+		csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+			PublicKey: testKey.Public(),
+			DNSNames:  []string{domain},
+		}, testKey)
+		test.AssertNotError(t, err, "creating CSR")
+		return csr
+	}
+	csr := newCSR()
+
+	// Begin finalizing the order as though a first request with this CSR were
+	// still in flight.
+	csrHash := sha256.Sum256(csr)
+	_, err = sa.SetOrderProcessing(context.Background(), &sapb.SetOrderProcessingRequest{Id: order.Id, CsrHash: csrHash[:]})
+	test.AssertNotError(t, err, "SetOrderProcessing failed")
+
+	// A repeated request which raced the first, and so still saw the order as
+	// ready, gets the processing order back.
+	result, err := ra.FinalizeOrder(context.Background(), &rapb.FinalizeOrderRequest{Order: order, Csr: csr})
+	test.AssertNotError(t, err, "repeated finalization of a ready order failed")
+	test.AssertEquals(t, result.Id, order.Id)
+	test.AssertEquals(t, result.Status, string(core.StatusProcessing))
+
+	// As does one which saw the order as processing.
+	result, err = ra.FinalizeOrder(context.Background(), &rapb.FinalizeOrderRequest{Order: result, Csr: csr})
+	test.AssertNotError(t, err, "repeated finalization of a processing order failed")
+	test.AssertEquals(t, result.Status, string(core.StatusProcessing))
+	test.AssertMetricWithLabelsEquals(t, ra.repeatedFinalizations, prometheus.Labels{"csr": "same"}, 2)
+
+	// A request with a different CSR is refused.
+	_, err = ra.FinalizeOrder(context.Background(), &rapb.FinalizeOrderRequest{Order: result, Csr: newCSR()})
+	test.AssertErrorIs(t, err, berrors.OrderNotReady)
+	test.AssertMetricWithLabelsEquals(t, ra.repeatedFinalizations, prometheus.Labels{"csr": "different"}, 1)
+}
+
 func TestFinalizeWithMustStaple(t *testing.T) {
 	_, sa, ra, _, fc, cleanUp := initAuthorities(t)
 	defer cleanUp()
@@ -3240,11 +3688,11 @@ func TestUpdateMissingAuthorization(t *testing.T) {
 
 	// Twiddle the authz to pretend its been validated by the VA
 	authz.Challenges[0].Status = "valid"
-	err = ra.recordValidation(ctx, authz.ID, fc.Now().Add(24*time.Hour), &authz.Challenges[0])
+	err = ra.recordValidation(ctx, authz.ID, fc.Now().Add(24*time.Hour), &authz.Challenges[0], nil)
 	test.AssertNotError(t, err, "ra.recordValidation failed")
 
 	// Try to record the same validation a second time.
-	err = ra.recordValidation(ctx, authz.ID, fc.Now().Add(25*time.Hour), &authz.Challenges[0])
+	err = ra.recordValidation(ctx, authz.ID, fc.Now().Add(25*time.Hour), &authz.Challenges[0], nil)
 	test.AssertError(t, err, "ra.recordValidation didn't fail")
 	test.AssertErrorIs(t, err, berrors.NotFound)
 }
@@ -0,0 +1,118 @@
+package ra
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/letsencrypt/boulder/core"
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	berrors "github.com/letsencrypt/boulder/errors"
+	rapb "github.com/letsencrypt/boulder/ra/proto"
+)
+
+// validationWaiters tracks the validations which this RA is performing, and
+// the AwaitValidation requests waiting for them to be recorded. It lets the
+// WFE answer a client's poll with the result of its validation as soon as
+// it's known, rather than the client polling the SA until it is.
+type validationWaiters struct {
+	sync.Mutex
+	// inFlight counts the validations being performed of each authorization,
+	// by ID. There may be more than one if a client raced several challenges.
+	inFlight map[int64]int
+	// waiters are the channels on which the result of the next validation of
+	// each authorization, by ID, is sent. They're closed without a result if
+	// every validation completes without recording one.
+	waiters map[int64][]chan *corepb.Authorization
+}
+
+func newValidationWaiters() *validationWaiters {
+	return &validationWaiters{
+		inFlight: make(map[int64]int),
+		waiters:  make(map[int64][]chan *corepb.Authorization),
+	}
+}
+
+// start notes that a validation of the authorization has begun.
+func (vw *validationWaiters) start(authzID int64) {
+	vw.Lock()
+	defer vw.Unlock()
+	vw.inFlight[authzID]++
+}
+
+// finish notes that a validation of the authorization has completed. If it
+// recorded a result, authz is the authorization with that result, and it's
+// sent to every waiter. Otherwise authz is nil, and the waiters are released
+// once no validation of the authorization remains.
+func (vw *validationWaiters) finish(authzID int64, authz *corepb.Authorization) {
+	vw.Lock()
+	defer vw.Unlock()
+	vw.inFlight[authzID]--
+	remaining := vw.inFlight[authzID]
+	if remaining <= 0 {
+		delete(vw.inFlight, authzID)
+	}
+	if authz == nil && remaining > 0 {
+		return
+	}
+	for _, ch := range vw.waiters[authzID] {
+		if authz != nil {
+			ch <- proto.Clone(authz).(*corepb.Authorization)
+		}
+		close(ch)
+	}
+	delete(vw.waiters, authzID)
+}
+
+// wait returns the result of the next validation of the authorization to be
+// recorded. It returns a NotFound error if none is in flight, or every one in
+// flight completes without recording a result.
+func (vw *validationWaiters) wait(ctx context.Context, authzID int64) (*corepb.Authorization, error) {
+	vw.Lock()
+	if vw.inFlight[authzID] == 0 {
+		vw.Unlock()
+		return nil, berrors.NotFoundError("no validation of authorization %d in progress", authzID)
+	}
+	// The channel is buffered, so that finish never blocks on a waiter
+	// which has given up.
+	ch := make(chan *corepb.Authorization, 1)
+	vw.waiters[authzID] = append(vw.waiters[authzID], ch)
+	vw.Unlock()
+
+	select {
+	case authz, ok := <-ch:
+		if !ok {
+			return nil, berrors.NotFoundError("no validation result recorded for authorization %d", authzID)
+		}
+		return authz, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// AwaitValidation waits for a validation of the authorization, which this RA
+// is performing, to be recorded and returns the authorization with its result.
+// If this RA isn't performing one, it returns NotFound immediately, and the
+// caller should get the authorization from the SA instead.
+func (ra *RegistrationAuthorityImpl) AwaitValidation(ctx context.Context, req *rapb.AwaitValidationRequest) (*corepb.Authorization, error) {
+	if core.IsAnyNilOrZero(req, req.AuthzID) {
+		return nil, errIncompleteGRPCRequest
+	}
+
+	authz, err := ra.validations.wait(ctx, req.AuthzID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Filter out any challenges which are currently disabled, as
+	// GetAuthorization does.
+	challs := []*corepb.Challenge{}
+	for _, chall := range authz.Challenges {
+		if ra.PA.ChallengeTypeEnabled(core.AcmeChallenge(chall.Type)) {
+			challs = append(challs, chall)
+		}
+	}
+	authz.Challenges = challs
+	return authz, nil
+}
@@ -0,0 +1,140 @@
+package ra
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/identifier"
+	rapb "github.com/letsencrypt/boulder/ra/proto"
+	"github.com/letsencrypt/boulder/test"
+	vapb "github.com/letsencrypt/boulder/va/proto"
+)
+
+// waitForWaiters blocks until the given number of waiters are registered for
+// the authorization.
+func waitForWaiters(vw *validationWaiters, authzID int64, n int) {
+	for {
+		vw.Lock()
+		registered := len(vw.waiters[authzID])
+		vw.Unlock()
+		if registered == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestValidationWaiters(t *testing.T) {
+	vw := newValidationWaiters()
+	ctx := context.Background()
+
+	// Without a validation in flight, wait returns NotFound at once.
+	_, err := vw.wait(ctx, 1)
+	test.AssertErrorIs(t, err, berrors.NotFound)
+
+	// A waiter receives the result of the validation in flight.
+	vw.start(1)
+	results := make(chan *corepb.Authorization)
+	go func() {
+		authz, err := vw.wait(ctx, 1)
+		test.AssertNotError(t, err, "waiting for validation")
+		results <- authz
+	}()
+	waitForWaiters(vw, 1, 1)
+	vw.finish(1, &corepb.Authorization{Id: "1", Status: "valid"})
+	authz := <-results
+	test.AssertEquals(t, authz.Status, "valid")
+	test.AssertEquals(t, len(vw.inFlight), 0)
+
+	// If a validation completes without recording a result, waiters are
+	// released once no other validation of the authorization remains.
+	vw.start(2)
+	vw.start(2)
+	errs := make(chan error)
+	go func() {
+		_, err := vw.wait(ctx, 2)
+		errs <- err
+	}()
+	waitForWaiters(vw, 2, 1)
+	vw.finish(2, nil)
+	select {
+	case <-errs:
+		t.Fatal("waiter released while a validation remained in flight")
+	case <-time.After(10 * time.Millisecond):
+	}
+	vw.finish(2, nil)
+	test.AssertErrorIs(t, <-errs, berrors.NotFound)
+
+	// A waiter gives up when its context is done.
+	vw.start(3)
+	ctx, cancel := context.WithTimeout(ctx, time.Millisecond)
+	defer cancel()
+	_, err = vw.wait(ctx, 3)
+	test.Assert(t, errors.Is(err, context.DeadlineExceeded), "expected deadline exceeded")
+	vw.finish(3, &corepb.Authorization{Id: "3"})
+}
+
+func TestAwaitValidationIncompleteRequest(t *testing.T) {
+	_, _, ra, _, _, cleanUp := initAuthoritiesWithMemorySA(t)
+	defer cleanUp()
+
+	_, err := ra.AwaitValidation(context.Background(), &rapb.AwaitValidationRequest{})
+	test.AssertErrorIs(t, err, errIncompleteGRPCRequest)
+}
+
+func TestAwaitValidation(t *testing.T) {
+	va, sa, ra, _, fc, cleanUp := initAuthoritiesWithMemorySA(t)
+	defer cleanUp()
+
+	// Block the validation until the waiter is registered.
+	va.doDCVRequest = make(chan *vapb.PerformValidationRequest)
+	va.doDCVResult = &vapb.ValidationResult{
+		Records: []*corepb.ValidationRecord{
+			{
+				AddressUsed:   []byte("192.168.0.1"),
+				Hostname:      "example.com",
+				Port:          "8080",
+				Url:           "http://example.com/",
+				ResolverAddrs: []string{"rebound"},
+			},
+		},
+	}
+	va.doCAAResponse = &vapb.IsCAAValidResponse{}
+
+	authzPB := createPendingAuthorization(t, sa, identifier.NewDNS("example.com"), fc.Now().Add(12*time.Hour))
+	authzID, err := strconv.ParseInt(authzPB.Id, 10, 64)
+	test.AssertNotError(t, err, "parsing authorization ID")
+
+	_, err = ra.PerformValidation(ctx, &rapb.PerformValidationRequest{
+		Authz:          authzPB,
+		ChallengeIndex: dnsChallIdx(t, authzPB.Challenges),
+	})
+	test.AssertNotError(t, err, "PerformValidation failed")
+
+	results := make(chan *corepb.Authorization)
+	go func() {
+		authz, err := ra.AwaitValidation(ctx, &rapb.AwaitValidationRequest{AuthzID: authzID})
+		test.AssertNotError(t, err, "AwaitValidation failed")
+		results <- authz
+	}()
+	waitForWaiters(ra.validations, authzID, 1)
+	<-va.doDCVRequest
+
+	select {
+	case authz := <-results:
+		test.AssertEquals(t, authz.Id, authzPB.Id)
+		test.AssertEquals(t, authz.Status, "valid")
+		test.AssertEquals(t, authz.Challenges[dnsChallIdx(t, authz.Challenges)].Status, "valid")
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for AwaitValidation")
+	}
+
+	// Once it's recorded, no validation is in progress.
+	_, err = ra.AwaitValidation(ctx, &rapb.AwaitValidationRequest{AuthzID: authzID})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+}
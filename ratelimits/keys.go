@@ -0,0 +1,202 @@
+package ratelimits
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/weppos/publicsuffix-go/publicsuffix"
+
+	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/identifier"
+)
+
+// KeyConfig configures how the bucket keys of the limits which group requests
+// by address block or registered domain are derived. The RA and WFE must be
+// configured alike, as must the overrides, whose ids are validated against it.
+// Zero values select the defaults.
+type KeyConfig struct {
+	// IPv4PrefixLength is the length of the prefix by which IPv4 identifiers
+	// are grouped for the CertificatesPerDomain and
+	// CertificatesPerDomainPerAccount limits. The default is 32.
+	IPv4PrefixLength int `validate:"omitempty,min=8,max=32"`
+
+	// IPv6PrefixLength is the length of the prefix by which IPv6 identifiers
+	// are grouped for the CertificatesPerDomain and
+	// CertificatesPerDomainPerAccount limits. The default is 64.
+	IPv6PrefixLength int `validate:"omitempty,min=32,max=128"`
+
+	// RegistrationsIPv6PrefixLength is the length of the prefix by which
+	// subscriber IPv6 addresses are grouped for the
+	// NewRegistrationsPerIPv6Range limit. The default is 48.
+	RegistrationsIPv6PrefixLength int `validate:"omitempty,min=16,max=64"`
+}
+
+// A KeyTransform derives, from an identifier in a request made by the account
+// regId, the part of a bucket key which follows the limit's enum. It's how a
+// limit groups requests, e.g. by registered domain or by address block.
+type KeyTransform interface {
+	Transform(regId int64, ident identifier.ACMEIdentifier) (string, error)
+}
+
+// IPPrefix groups IP address identifiers by the prefix of the given length
+// which contains them, e.g. "2001:db8::/64". A length of zero means that
+// addresses of that family aren't accepted.
+type IPPrefix struct {
+	IPv4Bits int
+	IPv6Bits int
+}
+
+var _ KeyTransform = IPPrefix{}
+
+// Prefix returns the prefix which contains addr.
+func (t IPPrefix) Prefix(addr netip.Addr) (netip.Prefix, error) {
+	bits := t.IPv6Bits
+	if addr.Is4() {
+		bits = t.IPv4Bits
+	}
+	if bits == 0 {
+		return netip.Prefix{}, fmt.Errorf("unsupported address %s", addr)
+	}
+	prefix, err := addr.Prefix(bits)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("building covering prefix for %s: %w", addr, err)
+	}
+	return prefix, nil
+}
+
+// Transform implements KeyTransform.
+func (t IPPrefix) Transform(_ int64, ident identifier.ACMEIdentifier) (string, error) {
+	if ident.Type != identifier.TypeIP {
+		return "", fmt.Errorf("unsupported identifier type: %s", ident.Type)
+	}
+	addr, err := netip.ParseAddr(ident.Value)
+	if err != nil {
+		return "", err
+	}
+	prefix, err := t.Prefix(addr)
+	if err != nil {
+		return "", err
+	}
+	return prefix.String(), nil
+}
+
+// RegisteredDomain groups DNS identifiers by their registered domain, the
+// eTLD+1 as determined by the Public Suffix List, and IP identifiers by IP.
+// Names which are themselves public suffixes are grouped on their own.
+type RegisteredDomain struct {
+	IP IPPrefix
+}
+
+var _ KeyTransform = RegisteredDomain{}
+
+// Transform implements KeyTransform.
+func (t RegisteredDomain) Transform(regId int64, ident identifier.ACMEIdentifier) (string, error) {
+	switch ident.Type {
+	case identifier.TypeDNS:
+		domain, err := publicsuffix.Domain(ident.Value)
+		if err != nil {
+			if err.Error() == fmt.Sprintf("%s is a suffix", ident.Value) {
+				// If the public suffix is the domain itself, that's fine.
+				// Include the original name in the result.
+				return strings.ToLower(ident.Value), nil
+			}
+			return "", err
+		}
+		return strings.ToLower(domain), nil
+	case identifier.TypeIP:
+		return t.IP.Transform(regId, ident)
+	}
+	return "", fmt.Errorf("unsupported identifier type: %s", ident.Type)
+}
+
+// AccountBound scopes the groups of its Inner transform to a single account,
+// yielding keys of the form 'regId:key'.
+type AccountBound struct {
+	Inner KeyTransform
+}
+
+var _ KeyTransform = AccountBound{}
+
+// Transform implements KeyTransform.
+func (t AccountBound) Transform(regId int64, ident identifier.ACMEIdentifier) (string, error) {
+	key, err := t.Inner.Transform(regId, ident)
+	if err != nil {
+		return "", err
+	}
+	return accountBoundKey(regId, key), nil
+}
+
+// accountBoundKey returns the key for the given account's share of key.
+func accountBoundKey(regId int64, key string) string {
+	return joinWithColon(strconv.FormatInt(regId, 10), key)
+}
+
+// keyTransforms are the KeyTransforms used by the limits whose bucket keys are
+// configurable.
+type keyTransforms struct {
+	// domain is used by CertificatesPerDomain.
+	domain RegisteredDomain
+	// domainPerAccount is used by CertificatesPerDomainPerAccount.
+	domainPerAccount AccountBound
+	// ipv6Range is used by NewRegistrationsPerIPv6Range.
+	ipv6Range IPPrefix
+}
+
+func newKeyTransforms(c KeyConfig) keyTransforms {
+	ipv4Bits := c.IPv4PrefixLength
+	if ipv4Bits == 0 {
+		ipv4Bits = 32
+	}
+	ipv6Bits := c.IPv6PrefixLength
+	if ipv6Bits == 0 {
+		ipv6Bits = 64
+	}
+	rangeBits := c.RegistrationsIPv6PrefixLength
+	if rangeBits == 0 {
+		rangeBits = 48
+	}
+	domain := RegisteredDomain{IP: IPPrefix{IPv4Bits: ipv4Bits, IPv6Bits: ipv6Bits}}
+	return keyTransforms{
+		domain:           domain,
+		domainPerAccount: AccountBound{Inner: domain},
+		ipv6Range:        IPPrefix{IPv6Bits: rangeBits},
+	}
+}
+
+// defaultKeyTransforms are used where no KeyConfig is available.
+var defaultKeyTransforms = newKeyTransforms(KeyConfig{})
+
+// coveringIdentifiers returns the set of "covering" identifiers used to enforce
+// the CertificatesPerDomain rate limit: the keys to which the domain transform
+// maps each identifier. The result is deduplicated and lowercased.
+func (k keyTransforms) coveringIdentifiers(idents identifier.ACMEIdentifiers) ([]string, error) {
+	var covers []string
+	for _, ident := range idents {
+		cover, err := k.domain.Transform(0, ident)
+		if err != nil {
+			return nil, err
+		}
+		covers = append(covers, cover)
+	}
+	return core.UniqueLowerNames(covers), nil
+}
+
+// ipPrefix returns the "covering" IP prefix used to enforce the
+// CertificatesPerDomain, CertificatesPerDomainPerAccount,
+// DelegatedCertificatesPerDomain, and NewRegistrationsPerIPv6Range rate
+// limits. If the limit does not use a covering prefix, an error is returned.
+func (k keyTransforms) ipPrefix(limit Name, addr netip.Addr) (netip.Prefix, error) {
+	switch limit {
+	case CertificatesPerDomain, CertificatesPerDomainPerAccount, DelegatedCertificatesPerDomain:
+		return k.domain.IP.Prefix(addr)
+
+	case NewRegistrationsPerIPv6Range:
+		if !addr.Is6() {
+			return netip.Prefix{}, fmt.Errorf("limit %s requires an IPv6 address, got %s", limit, addr)
+		}
+		return k.ipv6Range.Prefix(addr)
+	}
+	return netip.Prefix{}, fmt.Errorf("limit %s does not require a covering prefix", limit)
+}
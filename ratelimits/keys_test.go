@@ -0,0 +1,125 @@
+package ratelimits
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/identifier"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestKeyTransforms(t *testing.T) {
+	t.Parallel()
+
+	ipv4 := identifier.NewIP(netip.MustParseAddr("192.0.2.77"))
+	ipv6 := identifier.NewIP(netip.MustParseAddr("2001:db8:aaaa:bbbb:cccc::1"))
+	domain := RegisteredDomain{IP: IPPrefix{IPv4Bits: 24, IPv6Bits: 56}}
+
+	testCases := []struct {
+		name      string
+		transform KeyTransform
+		ident     identifier.ACMEIdentifier
+		want      string
+		wantErr   string
+	}{
+		{"IPv4 prefix", IPPrefix{IPv4Bits: 24, IPv6Bits: 64}, ipv4, "192.0.2.0/24", ""},
+		{"IPv6 prefix", IPPrefix{IPv4Bits: 24, IPv6Bits: 64}, ipv6, "2001:db8:aaaa:bbbb::/64", ""},
+		{"IPv4 unsupported", IPPrefix{IPv6Bits: 48}, ipv4, "", "unsupported address 192.0.2.77"},
+		{"prefix of a DNS name", IPPrefix{IPv4Bits: 32}, identifier.NewDNS("example.com"), "", "unsupported identifier type: dns"},
+		{"registered domain", domain, identifier.NewDNS("WWW.Example.co.uk"), "example.co.uk", ""},
+		{"public suffix", domain, identifier.NewDNS("github.io"), "github.io", ""},
+		{"registered domain of an IP", domain, ipv6, "2001:db8:aaaa:bb00::/56", ""},
+		{"blank name", domain, identifier.NewDNS(""), "", "name is blank"},
+		{"account bound", AccountBound{Inner: domain}, identifier.NewDNS("www.example.com"), "1337:example.com", ""},
+		{"account bound IP", AccountBound{Inner: domain}, ipv4, "1337:192.0.2.0/24", ""},
+		{"account bound error", AccountBound{Inner: IPPrefix{}}, ipv4, "", "unsupported address"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tc.transform.Transform(1337, tc.ident)
+			if tc.wantErr != "" {
+				test.AssertError(t, err, "Transform should have failed")
+				test.AssertContains(t, err.Error(), tc.wantErr)
+				return
+			}
+			test.AssertNotError(t, err, "Transform failed")
+			test.AssertEquals(t, got, tc.want)
+		})
+	}
+}
+
+func TestNewKeyTransforms(t *testing.T) {
+	t.Parallel()
+
+	k := newKeyTransforms(KeyConfig{})
+	test.AssertEquals(t, k.domain.IP, IPPrefix{IPv4Bits: 32, IPv6Bits: 64})
+	test.AssertEquals(t, k.ipv6Range, IPPrefix{IPv6Bits: 48})
+
+	k = newKeyTransforms(KeyConfig{IPv4PrefixLength: 24, IPv6PrefixLength: 56, RegistrationsIPv6PrefixLength: 40})
+	test.AssertEquals(t, k.domain.IP, IPPrefix{IPv4Bits: 24, IPv6Bits: 56})
+	test.AssertEquals(t, k.domainPerAccount.Inner, KeyTransform(k.domain))
+	test.AssertEquals(t, k.ipv6Range, IPPrefix{IPv6Bits: 40})
+
+	_, err := k.ipPrefix(NewRegistrationsPerIPv6Range, netip.MustParseAddr("192.0.2.1"))
+	test.AssertError(t, err, "IPv4 address should be rejected for NewRegistrationsPerIPv6Range")
+	_, err = k.ipPrefix(NewOrdersPerAccount, netip.MustParseAddr("192.0.2.1"))
+	test.AssertError(t, err, "NewOrdersPerAccount doesn't use a prefix")
+}
+
+func TestTransactionBuilderKeyConfig(t *testing.T) {
+	t.Parallel()
+
+	keys := KeyConfig{IPv6PrefixLength: 56, RegistrationsIPv6PrefixLength: 40}
+	registry, err := newLimitRegistry(LimitConfigs{
+		NewRegistrationsPerIPv6Range.String(): &LimitConfig{Burst: 10, Count: 10, Period: config.Duration{Duration: 3 * time.Hour}},
+		CertificatesPerDomain.String():        &LimitConfig{Burst: 10, Count: 10, Period: config.Duration{Duration: 3 * time.Hour}},
+	}, overridesYAML{
+		{CertificatesPerDomain.String(): overrideYAML{
+			LimitConfig: LimitConfig{Burst: 20, Count: 20, Period: config.Duration{Duration: 3 * time.Hour}},
+			Ids: []struct {
+				Id      string `yaml:"id"`
+				Comment string `yaml:"comment,omitempty"`
+			}{{Id: "2602:80a:6000:ab00::"}},
+		}},
+		{NewRegistrationsPerIPv6Range.String(): overrideYAML{
+			LimitConfig: LimitConfig{Burst: 20, Count: 20, Period: config.Duration{Duration: 3 * time.Hour}},
+			Ids: []struct {
+				Id      string `yaml:"id"`
+				Comment string `yaml:"comment,omitempty"`
+			}{{Id: "2602:80a:6000::/40"}},
+		}},
+	}, newKeyTransforms(keys))
+	test.AssertNotError(t, err, "newLimitRegistry failed")
+	builder := &TransactionBuilder{registry}
+
+	// Bucket keys use the configured prefix lengths, and so do overrides.
+	txn, err := builder.registrationsPerIPv6RangeTransaction(netip.MustParseAddr("2602:80a:6000::1"))
+	test.AssertNotError(t, err, "registrationsPerIPv6RangeTransaction failed")
+	test.AssertEquals(t, txn.bucketKey, "2:2602:80a:6000::/40")
+	test.Assert(t, txn.limit.isOverride, "override should apply")
+
+	txns, err := builder.certificatesPerDomainCheckOnlyTransactions(1337, identifier.ACMEIdentifiers{
+		identifier.NewIP(netip.MustParseAddr("2602:80a:6000:ab12::1")),
+		identifier.NewIP(netip.MustParseAddr("2602:80a:6000:ab34::1")),
+	})
+	test.AssertNotError(t, err, "certificatesPerDomainCheckOnlyTransactions failed")
+	test.AssertEquals(t, len(txns), 1)
+	test.AssertEquals(t, txns[0].bucketKey, "5:2602:80a:6000:ab00::/56")
+	test.Assert(t, txns[0].limit.isOverride, "override should apply")
+
+	// Overrides which don't match the configured prefix lengths are rejected.
+	_, err = newLimitRegistry(nil, overridesYAML{
+		{NewRegistrationsPerIPv6Range.String(): overrideYAML{
+			LimitConfig: LimitConfig{Burst: 20, Count: 20, Period: config.Duration{Duration: 3 * time.Hour}},
+			Ids: []struct {
+				Id      string `yaml:"id"`
+				Comment string `yaml:"comment,omitempty"`
+			}{{Id: "2602:80a:6000::/48"}},
+		}},
+	}, newKeyTransforms(keys))
+	test.AssertError(t, err, "/48 override should be rejected when ranges are /40")
+	test.AssertContains(t, err.Error(), "must be /40")
+}
@@ -37,6 +37,12 @@ type LimitConfig struct {
 	// Period is the duration of time in which the count (of requests) is
 	// allowed. It must be greater than zero.
 	Period config.Duration
+
+	// Shadow, if true, means the limit is evaluated and its buckets spent, but
+	// requests which exceed it are allowed. Instead, each request which would
+	// have been denied is logged and counted. This allows a new or changed
+	// limit to be observed before it's enforced.
+	Shadow bool
 }
 
 type LimitConfigs map[string]*LimitConfig
@@ -68,6 +74,11 @@ type Limit struct {
 	// context for an override. It is not used for default limits.
 	Comment string
 
+	// Shadow, if true, means that requests which exceed the limit are allowed,
+	// but logged and counted. Overrides of a shadowed default limit are also
+	// shadowed.
+	Shadow bool
+
 	// emissionInterval is the interval, in nanoseconds, at which tokens are
 	// added to a bucket (period / count). This is also the steady-state rate at
 	// which requests can be made without being denied even once the burst has
@@ -202,7 +213,7 @@ func parseOverrideNameEnumId(key string) (Name, string, error) {
 // formatted as a list of maps, where each map has a single key representing the
 // limit name and a value that is a map containing the limit fields and an
 // additional 'ids' field that is a list of ids that this override applies to.
-func parseOverrideLimits(newOverridesYAML overridesYAML) (Limits, error) {
+func parseOverrideLimits(newOverridesYAML overridesYAML, keys keyTransforms) (Limits, error) {
 	parsed := make(Limits)
 
 	for _, ov := range newOverridesYAML {
@@ -214,7 +225,7 @@ func parseOverrideLimits(newOverridesYAML overridesYAML) (Limits, error) {
 
 			for _, entry := range v.Ids {
 				id := entry.Id
-				err := validateIdForName(name, id)
+				err := validateIdForName(name, id, keys)
 				if err != nil {
 					return nil, fmt.Errorf(
 						"validating name %s and id %q for override limit %q: %w", name, id, k, err)
@@ -223,12 +234,12 @@ func parseOverrideLimits(newOverridesYAML overridesYAML) (Limits, error) {
 				// We interpret and compute the override values for two rate
 				// limits, since they're not nice to ask for in a config file.
 				switch name {
-				case CertificatesPerDomain:
-					// Convert IP addresses to their covering /32 (IPv4) or /64
-					// (IPv6) prefixes in CIDR notation.
+				case CertificatesPerDomain, DelegatedCertificatesPerDomain:
+					// Convert IP addresses to their covering prefixes, /32
+					// (IPv4) or /64 (IPv6) by default, in CIDR notation.
 					ip, err := netip.ParseAddr(id)
 					if err == nil {
-						prefix, err := coveringIPPrefix(name, ip)
+						prefix, err := keys.ipPrefix(name, ip)
 						if err != nil {
 							return nil, fmt.Errorf(
 								"computing prefix for IP address %q: %w", id, err)
@@ -247,6 +258,7 @@ func parseOverrideLimits(newOverridesYAML overridesYAML) (Limits, error) {
 					Period:     v.Period,
 					Name:       name,
 					Comment:    entry.Comment,
+					Shadow:     v.Shadow,
 					isOverride: true,
 				}
 				lim.precompute()
@@ -278,6 +290,7 @@ func parseDefaultLimits(newDefaultLimits LimitConfigs) (Limits, error) {
 			Count:  v.Count,
 			Period: v.Period,
 			Name:   name,
+			Shadow: v.Shadow,
 		}
 
 		err := ValidateLimit(lim)
@@ -297,16 +310,20 @@ type limitRegistry struct {
 
 	// overrides stores override limits by 'name:id'.
 	overrides Limits
+
+	// keys derives the bucket keys of limits which group requests by address
+	// block or registered domain.
+	keys keyTransforms
 }
 
-func newLimitRegistryFromFiles(defaults, overrides string) (*limitRegistry, error) {
+func newLimitRegistryFromFiles(defaults, overrides string, keys keyTransforms) (*limitRegistry, error) {
 	defaultsData, err := loadDefaults(defaults)
 	if err != nil {
 		return nil, err
 	}
 
 	if overrides == "" {
-		return newLimitRegistry(defaultsData, nil)
+		return newLimitRegistry(defaultsData, nil, keys)
 	}
 
 	overridesData, err := loadOverrides(overrides)
@@ -314,23 +331,32 @@ func newLimitRegistryFromFiles(defaults, overrides string) (*limitRegistry, erro
 		return nil, err
 	}
 
-	return newLimitRegistry(defaultsData, overridesData)
+	return newLimitRegistry(defaultsData, overridesData, keys)
 }
 
-func newLimitRegistry(defaults LimitConfigs, overrides overridesYAML) (*limitRegistry, error) {
+func newLimitRegistry(defaults LimitConfigs, overrides overridesYAML, keys keyTransforms) (*limitRegistry, error) {
 	regDefaults, err := parseDefaultLimits(defaults)
 	if err != nil {
 		return nil, err
 	}
 
-	regOverrides, err := parseOverrideLimits(overrides)
+	regOverrides, err := parseOverrideLimits(overrides, keys)
 	if err != nil {
 		return nil, err
 	}
 
+	// An override mustn't enforce a limit which is still being rolled out.
+	for _, ol := range regOverrides {
+		dl, ok := regDefaults[ol.Name.EnumString()]
+		if ok && dl.Shadow {
+			ol.Shadow = true
+		}
+	}
+
 	return &limitRegistry{
 		defaults:  regDefaults,
 		overrides: regOverrides,
+		keys:      keys,
 	}, nil
 }
 
@@ -368,7 +394,7 @@ func LoadOverridesByBucketKey(path string) (Limits, error) {
 	if err != nil {
 		return nil, err
 	}
-	return parseOverrideLimits(ovs)
+	return parseOverrideLimits(ovs, defaultKeyTransforms)
 }
 
 // DumpOverrides writes the provided overrides to CSV at the supplied path. Each
@@ -36,7 +36,7 @@ func loadAndParseOverrideLimits(path string) (Limits, error) {
 		return nil, err
 	}
 
-	return parseOverrideLimits(fromFile)
+	return parseOverrideLimits(fromFile, defaultKeyTransforms)
 }
 
 func TestParseOverrideNameId(t *testing.T) {
@@ -437,3 +437,39 @@ NewRegistrationsPerIPAddress,11.22.33.44,200,200,3h0m0s,example.net (IN-1583)
 	test.AssertNotError(t, err, "reading dumped overrides file")
 	test.AssertEquals(t, strings.TrimLeft(string(dumped), "\n"), strings.TrimLeft(expectCSV, "\n"))
 }
+
+func TestNewLimitRegistryShadowedOverrides(t *testing.T) {
+	t.Parallel()
+
+	registry, err := newLimitRegistry(LimitConfigs{
+		NewOrdersPerAccount.String():   &LimitConfig{Burst: 10, Count: 10, Period: config.Duration{Duration: time.Hour}, Shadow: true},
+		CertificatesPerDomain.String(): &LimitConfig{Burst: 10, Count: 10, Period: config.Duration{Duration: time.Hour}},
+	}, overridesYAML{
+		{NewOrdersPerAccount.String(): overrideYAML{
+			LimitConfig: LimitConfig{Burst: 20, Count: 20, Period: config.Duration{Duration: time.Hour}},
+			Ids: []struct {
+				Id      string `yaml:"id"`
+				Comment string `yaml:"comment,omitempty"`
+			}{{Id: "1337"}},
+		}},
+		{CertificatesPerDomain.String(): overrideYAML{
+			LimitConfig: LimitConfig{Burst: 20, Count: 20, Period: config.Duration{Duration: time.Hour}},
+			Ids: []struct {
+				Id      string `yaml:"id"`
+				Comment string `yaml:"comment,omitempty"`
+			}{{Id: "example.com"}},
+		}},
+	}, defaultKeyTransforms)
+	test.AssertNotError(t, err, "newLimitRegistry failed")
+
+	// An override of a shadowed limit is shadowed too.
+	l, err := registry.getLimit(NewOrdersPerAccount, newRegIdBucketKey(NewOrdersPerAccount, 1337))
+	test.AssertNotError(t, err, "getLimit failed")
+	test.Assert(t, l.isOverride, "override should apply")
+	test.Assert(t, l.Shadow, "override of shadowed limit should be shadowed")
+
+	l, err = registry.getLimit(CertificatesPerDomain, newDomainOrCIDRBucketKey(CertificatesPerDomain, "example.com"))
+	test.AssertNotError(t, err, "getLimit failed")
+	test.Assert(t, l.isOverride, "override should apply")
+	test.Assert(t, !l.Shadow, "override of enforced limit shouldn't be shadowed")
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	berrors "github.com/letsencrypt/boulder/errors"
+	blog "github.com/letsencrypt/boulder/log"
 )
 
 const (
@@ -36,13 +37,15 @@ type Limiter struct {
 	// source is used to store buckets. It must be safe for concurrent use.
 	source Source
 	clk    clock.Clock
+	log    blog.Logger
 
-	spendLatency *prometheus.HistogramVec
+	spendLatency  *prometheus.HistogramVec
+	shadowDenials *prometheus.CounterVec
 }
 
 // NewLimiter returns a new *Limiter. The provided source must be safe for
 // concurrent use.
-func NewLimiter(clk clock.Clock, source Source, stats prometheus.Registerer) (*Limiter, error) {
+func NewLimiter(clk clock.Clock, source Source, stats prometheus.Registerer, logger blog.Logger) (*Limiter, error) {
 	spendLatency := prometheus.NewHistogramVec(prometheus.HistogramOpts{
 		Name: "ratelimits_spend_latency",
 		Help: fmt.Sprintf("Latency of ratelimit checks labeled by limit=[name] and decision=[%s|%s], in seconds", Allowed, Denied),
@@ -51,10 +54,18 @@ func NewLimiter(clk clock.Clock, source Source, stats prometheus.Registerer) (*L
 	}, []string{"limit", "decision"})
 	stats.MustRegister(spendLatency)
 
+	shadowDenials := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ratelimits_shadow_denials",
+		Help: "A counter of requests which shadowed limits would have denied, labeled by limit=[name]",
+	}, []string{"limit"})
+	stats.MustRegister(shadowDenials)
+
 	return &Limiter{
-		source:       source,
-		clk:          clk,
-		spendLatency: spendLatency,
+		source:        source,
+		clk:           clk,
+		log:           logger,
+		spendLatency:  spendLatency,
+		shadowDenials: shadowDenials,
 	}, nil
 }
 
@@ -86,6 +97,46 @@ type Decision struct {
 	// included for the production of verbose Subscriber-facing errors. It is
 	// set by the Limiter before returning the Decision.
 	transaction Transaction
+
+	// buckets holds the Decisions reached for each bucket consulted by
+	// BatchSpend, in the order their Transactions were provided. It is only
+	// set on the Decision returned by BatchSpend.
+	buckets []BucketDecision
+}
+
+// BucketDecision describes the Decision reached for a single bucket consulted
+// by BatchSpend. It's intended for debugging and must not be used to make
+// decisions; use the result of Decision.Result() for that.
+type BucketDecision struct {
+	// Limit is the name of the limit the bucket belongs to.
+	Limit Name
+
+	// BucketKey identifies the bucket, e.g. "enum:regId".
+	BucketKey string
+
+	// Allowed is true if the bucket possessed enough capacity to allow the
+	// request given the cost.
+	Allowed bool
+
+	// Enforced is false for spend-only Transactions and Transactions for
+	// shadowed limits, whose Decisions don't contribute to the batch's
+	// Decision.
+	Enforced bool
+
+	// Remaining is the number of requests the client may make before this
+	// bucket is exhausted.
+	Remaining int64
+
+	// RetryIn is the duration the client must wait before this bucket would
+	// allow the request, or zero if it was allowed.
+	RetryIn time.Duration
+}
+
+// Buckets returns the Decisions reached for each bucket consulted by the
+// BatchSpend call which returned d, or nil if d wasn't returned by BatchSpend.
+// Allow-only Transactions consult no bucket and are omitted.
+func (d *Decision) Buckets() []BucketDecision {
+	return d.buckets
 }
 
 // Result translates a denied *Decision into a berrors.RateLimitError for the
@@ -163,6 +214,22 @@ func (d *Decision) Result(now time.Time) error {
 			retryAfterTs,
 		)
 
+	case DelegatedCertificatesPerDomain:
+		// Uses bucket key 'enum:domainOrCIDR'.
+		idx := strings.LastIndex(d.transaction.bucketKey, ":")
+		if idx == -1 {
+			return berrors.InternalServerError("unrecognized bucket key while generating error")
+		}
+		domainOrCIDR := d.transaction.bucketKey[idx+1:]
+		return berrors.CertificatesPerDomainError(
+			retryAfter,
+			"too many delegated certificates (%d) already issued for %q in the last %s, retry after %s",
+			d.transaction.limit.Burst,
+			domainOrCIDR,
+			d.transaction.limit.Period.Duration,
+			retryAfterTs,
+		)
+
 	case CertificatesPerFQDNSet:
 		return berrors.CertificatesPerFQDNSetError(
 			retryAfter,
@@ -181,7 +248,8 @@ func (d *Decision) Result(now time.Time) error {
 // capacity. The returned *Decision indicates whether the capacity exists to
 // satisfy the cost and represents the hypothetical state of the bucket IF the
 // cost WERE to be deducted. If no bucket exists it will NOT be created. No
-// state is persisted to the underlying datastore.
+// state is persisted to the underlying datastore. If the limit is shadowed,
+// the returned *Decision always allows the request.
 func (l *Limiter) Check(ctx context.Context, txn Transaction) (*Decision, error) {
 	if txn.allowOnly() {
 		return allowedDecision, nil
@@ -197,9 +265,26 @@ func (l *Limiter) Check(ctx context.Context, txn Transaction) (*Decision, error)
 		// First request from this client. No need to initialize the bucket
 		// because this is a check, not a spend. A TAT of "now" is equivalent to
 		// a full bucket.
-		return maybeSpend(l.clk, txn, l.clk.Now()), nil
+		tat = l.clk.Now()
 	}
-	return maybeSpend(l.clk, txn, tat), nil
+	d := maybeSpend(l.clk, txn, tat)
+	if txn.limit.Shadow && !d.allowed {
+		l.shadowDenied(txn, d)
+		shadowed := *d
+		shadowed.allowed = true
+		shadowed.retryIn = 0
+		return &shadowed, nil
+	}
+	return d, nil
+}
+
+// shadowDenied counts and logs a request which the shadowed limit of txn
+// would have denied. The bucket key identifies the account, domain, or
+// address which exceeded the limit.
+func (l *Limiter) shadowDenied(txn Transaction, d *Decision) {
+	l.shadowDenials.WithLabelValues(txn.limit.Name.String()).Inc()
+	l.log.Infof("Shadowed rate limit would have denied request: limit=[%s] bucket=[%s] cost=[%d] retryIn=[%s]",
+		txn.limit.Name, txn.bucketKey, txn.cost, d.retryIn)
 }
 
 // Spend attempts to deduct the cost from the provided bucket's capacity. The
@@ -271,10 +356,20 @@ func (l *Limiter) BatchSpend(ctx context.Context, txns []Transaction) (*Decision
 	incrBuckets := make(map[string]increment)
 	staleBuckets := make(map[string]time.Time)
 	txnOutcomes := make(map[Transaction]string)
+	buckets := make([]BucketDecision, 0, len(batch))
 
 	for _, txn := range batch {
 		storedTAT, bucketExists := tats[txn.bucketKey]
 		d := maybeSpend(l.clk, txn, storedTAT)
+		enforced := !txn.spendOnly() && !txn.limit.Shadow
+		buckets = append(buckets, BucketDecision{
+			Limit:     txn.limit.Name,
+			BucketKey: txn.bucketKey,
+			Allowed:   d.allowed,
+			Enforced:  enforced,
+			Remaining: d.remaining,
+			RetryIn:   d.retryIn,
+		})
 
 		if d.allowed && (storedTAT != d.newTAT) && txn.spend {
 			if !bucketExists {
@@ -289,10 +384,12 @@ func (l *Limiter) BatchSpend(ctx context.Context, txns []Transaction) (*Decision
 			}
 		}
 
-		if !txn.spendOnly() {
-			// Spend-only Transactions are best-effort and do not contribute to
-			// the batchDecision.
+		if enforced {
+			// Spend-only Transactions are best-effort, and shadowed limits are
+			// only observed, so neither contributes to the batchDecision.
 			batchDecision = stricter(batchDecision, d)
+		} else if txn.limit.Shadow && !d.allowed {
+			l.shadowDenied(txn, d)
 		}
 
 		txnOutcomes[txn] = Denied
@@ -348,7 +445,12 @@ func (l *Limiter) BatchSpend(ctx context.Context, txns []Transaction) (*Decision
 	for txn, outcome := range txnOutcomes {
 		l.spendLatency.WithLabelValues(txn.limit.Name.String(), outcome).Observe(perTxnLatency.Seconds())
 	}
-	return batchDecision, nil
+
+	// Copy the batchDecision, which may be shared, before attaching the
+	// per-bucket Decisions.
+	result := *batchDecision
+	result.buckets = buckets
+	return &result, nil
 }
 
 // Refund attempts to refund all of the cost to the capacity of the specified
@@ -9,9 +9,11 @@ import (
 	"time"
 
 	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/letsencrypt/boulder/config"
 	berrors "github.com/letsencrypt/boulder/errors"
+	blog "github.com/letsencrypt/boulder/log"
 	"github.com/letsencrypt/boulder/metrics"
 	"github.com/letsencrypt/boulder/test"
 )
@@ -22,7 +24,7 @@ const overriddenIP = "64.112.117.1"
 
 // newTestLimiter constructs a new limiter.
 func newTestLimiter(t *testing.T, s Source, clk clock.FakeClock) *Limiter {
-	l, err := NewLimiter(clk, s, metrics.NoopRegisterer)
+	l, err := NewLimiter(clk, s, metrics.NoopRegisterer, blog.NewMock())
 	test.AssertNotError(t, err, "should not error")
 	return l
 }
@@ -32,7 +34,7 @@ func newTestLimiter(t *testing.T, s Source, clk clock.FakeClock) *Limiter {
 //   - 'NewRegistrationsPerIPAddress' burst: 20 count: 20 period: 1s
 //   - 'NewRegistrationsPerIPAddress:64.112.117.1' burst: 40 count: 40 period: 1s
 func newTestTransactionBuilder(t *testing.T) *TransactionBuilder {
-	c, err := NewTransactionBuilderFromFiles("testdata/working_default.yml", "testdata/working_override.yml")
+	c, err := NewTransactionBuilderFromFiles("testdata/working_default.yml", "testdata/working_override.yml", KeyConfig{})
 	test.AssertNotError(t, err, "should not error")
 	return c
 }
@@ -194,6 +196,13 @@ func TestLimiter_CheckWithLimitOverrides(t *testing.T) {
 			test.AssertEquals(t, d.retryIn, time.Duration(0))
 			test.AssertEquals(t, d.resetIn, time.Millisecond*50)
 
+			// Each bucket's Decision is reported, including the spend-only
+			// bucket's, which isn't enforced.
+			test.AssertDeepEquals(t, d.Buckets(), []BucketDecision{
+				{Limit: overriddenLimit.Name, BucketKey: overriddenBucketKey, Allowed: true, Enforced: true, Remaining: 38},
+				{Limit: normalLimit.Name, BucketKey: normalBucketKey, Allowed: true, Enforced: false, Remaining: 19},
+			})
+
 			// Check the remaining quota of the overridden bucket.
 			d, err = l.Check(testCtx, overriddenCheckOnlyTxn0)
 			test.AssertNotError(t, err, "should not error")
@@ -557,6 +566,23 @@ func TestRateLimitError(t *testing.T) {
 			expectedErr:     "too many certificates (3) already issued for \"example.net\" in the last 1h0m0s, retry after 1970-01-01 00:00:20 UTC: see https://letsencrypt.org/docs/rate-limits/#new-certificates-per-registered-domain",
 			expectedErrType: berrors.RateLimit,
 		},
+		{
+			name: "DelegatedCertificatesPerDomain limit reached",
+			decision: &Decision{
+				allowed: false,
+				retryIn: 20 * time.Second,
+				transaction: Transaction{
+					limit: &Limit{
+						Name:   DelegatedCertificatesPerDomain,
+						Burst:  3,
+						Period: config.Duration{Duration: time.Hour},
+					},
+					bucketKey: "9:example.org",
+				},
+			},
+			expectedErr:     "too many delegated certificates (3) already issued for \"example.org\" in the last 1h0m0s, retry after 1970-01-01 00:00:20 UTC: see https://letsencrypt.org/docs/rate-limits/#new-certificates-per-registered-domain",
+			expectedErrType: berrors.RateLimit,
+		},
 		{
 			name: "Unknown rate limit name",
 			decision: &Decision{
@@ -587,3 +613,60 @@ func TestRateLimitError(t *testing.T) {
 		})
 	}
 }
+
+func TestLimiter_ShadowedLimit(t *testing.T) {
+	t.Parallel()
+	testCtx := context.Background()
+	clk := clock.NewFake()
+	l, err := NewLimiter(clk, NewInmemSource(), metrics.NoopRegisterer, blog.NewMock())
+	test.AssertNotError(t, err, "should not error")
+
+	enforced := &Limit{Burst: 1, Count: 1, Period: config.Duration{Duration: time.Second}, Name: NewRegistrationsPerIPAddress}
+	enforced.precompute()
+	shadowed := &Limit{Burst: 1, Count: 1, Period: config.Duration{Duration: time.Second}, Name: NewOrdersPerAccount, Shadow: true}
+	shadowed.precompute()
+
+	enforcedKey := newIPAddressBucketKey(NewRegistrationsPerIPAddress, netip.MustParseAddr("10.0.0.1"))
+	shadowedKey := newRegIdBucketKey(NewOrdersPerAccount, 1337)
+	enforcedTxn, err := newTransaction(enforced, enforcedKey, 1)
+	test.AssertNotError(t, err, "txn should be valid")
+	shadowedTxn, err := newTransaction(shadowed, shadowedKey, 1)
+	test.AssertNotError(t, err, "txn should be valid")
+
+	// Spending the only token of the shadowed bucket is allowed, as usual.
+	d, err := l.Spend(testCtx, shadowedTxn)
+	test.AssertNotError(t, err, "should not error")
+	test.Assert(t, d.allowed, "should be allowed")
+
+	// Exceeding the shadowed limit is allowed, but counted.
+	d, err = l.Check(testCtx, shadowedTxn)
+	test.AssertNotError(t, err, "should not error")
+	test.Assert(t, d.allowed, "shadowed limit should allow")
+	test.AssertEquals(t, d.retryIn, time.Duration(0))
+	test.AssertMetricWithLabelsEquals(t, l.shadowDenials, prometheus.Labels{"limit": NewOrdersPerAccount.String()}, 1)
+
+	// In a batch, the shadowed bucket is still spent and its Decision reported,
+	// but only the enforced limit decides the outcome.
+	d, err = l.BatchSpend(testCtx, []Transaction{enforcedTxn, shadowedTxn})
+	test.AssertNotError(t, err, "should not error")
+	test.Assert(t, d.allowed, "shadowed limit should allow")
+	test.AssertMetricWithLabelsEquals(t, l.shadowDenials, prometheus.Labels{"limit": NewOrdersPerAccount.String()}, 2)
+	for _, b := range d.Buckets() {
+		switch b.BucketKey {
+		case enforcedKey:
+			test.Assert(t, b.Allowed, "enforced bucket should be allowed")
+			test.Assert(t, b.Enforced, "enforced bucket should be enforced")
+		case shadowedKey:
+			test.Assert(t, !b.Allowed, "shadowed bucket should be exceeded")
+			test.Assert(t, !b.Enforced, "shadowed bucket shouldn't be enforced")
+		default:
+			t.Errorf("unexpected bucket %q", b.BucketKey)
+		}
+	}
+
+	// Once the enforced limit is exceeded too, the batch is denied.
+	d, err = l.BatchSpend(testCtx, []Transaction{enforcedTxn, shadowedTxn})
+	test.AssertNotError(t, err, "should not error")
+	test.Assert(t, !d.allowed, "enforced limit should deny")
+	test.AssertEquals(t, d.transaction.limit.Name, NewRegistrationsPerIPAddress)
+}
@@ -99,6 +99,13 @@ const (
 	//    the account and identValue is the value of an identifier in the
 	//    certificate.
 	FailedAuthorizationsForPausingPerDomainPerAccount
+
+	// DelegatedCertificatesPerDomain uses bucket key 'enum:domainOrCIDR', in
+	// the same formats as CertificatesPerDomain. It's used in place of
+	// CertificatesPerDomain and CertificatesPerFQDNSet for certificates issued
+	// under a validation profile for delegated credentials, which are
+	// short-lived and so reissued much more often.
+	DelegatedCertificatesPerDomain
 )
 
 // nameToString is a map of Name values to string names.
@@ -112,6 +119,7 @@ var nameToString = map[Name]string{
 	CertificatesPerDomainPerAccount:                   "CertificatesPerDomainPerAccount",
 	CertificatesPerFQDNSet:                            "CertificatesPerFQDNSet",
 	FailedAuthorizationsForPausingPerDomainPerAccount: "FailedAuthorizationsForPausingPerDomainPerAccount",
+	DelegatedCertificatesPerDomain:                    "DelegatedCertificatesPerDomain",
 }
 
 // isValid returns true if the Name is a valid rate limit name.
@@ -151,18 +159,17 @@ func validIPAddress(id string) error {
 }
 
 // validIPv6RangeCIDR validates that the provided string is formatted as an IPv6
-// prefix in CIDR notation, with a /48 mask.
-func validIPv6RangeCIDR(id string) error {
+// prefix in CIDR notation, with the mask used by the NewRegistrationsPerIPv6Range
+// limit's key transform (/48 by default).
+func validIPv6RangeCIDR(id string, keys keyTransforms) error {
 	prefix, err := netip.ParsePrefix(id)
 	if err != nil {
 		return fmt.Errorf(
 			"invalid CIDR, %q must be an IPv6 CIDR range", id)
 	}
-	if prefix.Bits() != 48 {
-		// This also catches the case where the range is an IPv4 CIDR, since an
-		// IPv4 CIDR can't have a /48 subnet mask - the maximum is /32.
+	if !prefix.Addr().Is6() || prefix.Bits() != keys.ipv6Range.IPv6Bits {
 		return fmt.Errorf(
-			"invalid CIDR, %q must be /48", id)
+			"invalid CIDR, %q must be /%d", id, keys.ipv6Range.IPv6Bits)
 	}
 	canon := prefix.Masked().String()
 	if canon != id {
@@ -206,9 +213,10 @@ func validateRegIdIdentValue(id string) error {
 }
 
 // validateDomainOrCIDR validates that the provided string is either a domain
-// name or an IP address. IPv6 addresses must be the lowest address in their
-// /64, i.e. their last 64 bits must be zero.
-func validateDomainOrCIDR(limit Name, id string) error {
+// name or an IP address. IP addresses must be the lowest address in the prefix
+// used by the limit's key transform, e.g. for IPv6 addresses in a /64, their
+// last 64 bits must be zero.
+func validateDomainOrCIDR(limit Name, id string, keys keyTransforms) error {
 	domainErr := policy.ValidDomain(id)
 	if domainErr == nil {
 		// This is a valid domain.
@@ -224,7 +232,7 @@ func validateDomainOrCIDR(limit Name, id string) error {
 		return fmt.Errorf("invalid IP address %q, must be in canonical form (%q)", id, ip.String())
 	}
 
-	prefix, prefixErr := coveringIPPrefix(limit, ip)
+	prefix, prefixErr := keys.ipPrefix(limit, ip)
 	if prefixErr != nil {
 		return fmt.Errorf("invalid IP address %q, couldn't determine prefix: %w", id, prefixErr)
 	}
@@ -236,9 +244,9 @@ func validateDomainOrCIDR(limit Name, id string) error {
 
 // validateRegIdDomainOrCIDR validates that the provided string is formatted
 // 'regId:domainOrCIDR', where domainOrCIDR is either a domain name or an IP
-// address. IPv6 addresses must be the lowest address in their /64, i.e. their
-// last 64 bits must be zero.
-func validateRegIdDomainOrCIDR(limit Name, id string) error {
+// address. IP addresses must be the lowest address in the prefix used by the
+// limit's key transform.
+func validateRegIdDomainOrCIDR(limit Name, id string, keys keyTransforms) error {
 	regIdDomainOrCIDR := strings.Split(id, ":")
 	if len(regIdDomainOrCIDR) != 2 {
 		return fmt.Errorf(
@@ -249,7 +257,7 @@ func validateRegIdDomainOrCIDR(limit Name, id string) error {
 		return fmt.Errorf(
 			"invalid regId, %q must be formatted 'regId:domainOrCIDR'", id)
 	}
-	err = validateDomainOrCIDR(limit, regIdDomainOrCIDR[1])
+	err = validateDomainOrCIDR(limit, regIdDomainOrCIDR[1], keys)
 	if err != nil {
 		return fmt.Errorf("invalid domainOrCIDR, %q must be formatted 'regId:domainOrCIDR': %w", id, err)
 	}
@@ -276,7 +284,7 @@ func validateFQDNSet(id string) error {
 	return nil
 }
 
-func validateIdForName(name Name, id string) error {
+func validateIdForName(name Name, id string, keys keyTransforms) error {
 	switch name {
 	case NewRegistrationsPerIPAddress:
 		// 'enum:ipaddress'
@@ -284,7 +292,7 @@ func validateIdForName(name Name, id string) error {
 
 	case NewRegistrationsPerIPv6Range:
 		// 'enum:ipv6rangeCIDR'
-		return validIPv6RangeCIDR(id)
+		return validIPv6RangeCIDR(id, keys)
 
 	case NewOrdersPerAccount:
 		// 'enum:regId'
@@ -302,15 +310,15 @@ func validateIdForName(name Name, id string) error {
 	case CertificatesPerDomainPerAccount:
 		if strings.Contains(id, ":") {
 			// 'enum:regId:domainOrCIDR' for transaction
-			return validateRegIdDomainOrCIDR(name, id)
+			return validateRegIdDomainOrCIDR(name, id, keys)
 		} else {
 			// 'enum:regId' for overrides
 			return validateRegId(id)
 		}
 
-	case CertificatesPerDomain:
+	case CertificatesPerDomain, DelegatedCertificatesPerDomain:
 		// 'enum:domainOrCIDR'
-		return validateDomainOrCIDR(name, id)
+		return validateDomainOrCIDR(name, id, keys)
 
 	case CertificatesPerFQDNSet:
 		// 'enum:fqdnSet'
@@ -353,8 +361,8 @@ var LimitNames = func() []string {
 }()
 
 // BuildBucketKey builds a bucketKey for the given rate limit name from the
-// provided components. It returns an error if the name is not valid or if the
-// components are not valid for the given name.
+// provided components, using the default KeyConfig. It returns an error if the
+// name is not valid or if the components are not valid for the given name.
 func BuildBucketKey(name Name, regId int64, singleIdent identifier.ACMEIdentifier, setOfIdents identifier.ACMEIdentifiers, subscriberIP netip.Addr) (string, error) {
 	makeMissingErr := func(field string) error {
 		return fmt.Errorf("%s is required for limit %s (enum: %s)", field, name, name.EnumString())
@@ -371,7 +379,7 @@ func BuildBucketKey(name Name, regId int64, singleIdent identifier.ACMEIdentifie
 		if !subscriberIP.IsValid() {
 			return "", makeMissingErr("subscriberIP")
 		}
-		prefix, err := coveringIPPrefix(name, subscriberIP)
+		prefix, err := defaultKeyTransforms.ipPrefix(name, subscriberIP)
 		if err != nil {
 			return "", err
 		}
@@ -383,11 +391,11 @@ func BuildBucketKey(name Name, regId int64, singleIdent identifier.ACMEIdentifie
 		}
 		return newRegIdBucketKey(name, regId), nil
 
-	case CertificatesPerDomain:
+	case CertificatesPerDomain, DelegatedCertificatesPerDomain:
 		if singleIdent.Value == "" {
 			return "", makeMissingErr("singleIdent")
 		}
-		coveringIdent, err := coveringIdentifier(name, singleIdent)
+		coveringIdent, err := defaultKeyTransforms.domain.Transform(regId, singleIdent)
 		if err != nil {
 			return "", err
 		}
@@ -399,11 +407,11 @@ func BuildBucketKey(name Name, regId int64, singleIdent identifier.ACMEIdentifie
 				return "", makeMissingErr("regId")
 			}
 			// Default: use 'enum:regId:identValue' bucket key format.
-			coveringIdent, err := coveringIdentifier(name, singleIdent)
+			key, err := defaultKeyTransforms.domainPerAccount.Transform(regId, singleIdent)
 			if err != nil {
 				return "", err
 			}
-			return NewRegIdIdentValueBucketKey(name, regId, coveringIdent), nil
+			return joinWithColon(name.EnumString(), key), nil
 		}
 		if regId == 0 {
 			return "", makeMissingErr("regId")
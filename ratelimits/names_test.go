@@ -286,7 +286,7 @@ func TestValidateIdForName(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(fmt.Sprintf("%s/%s", tc.limit, tc.desc), func(t *testing.T) {
 			t.Parallel()
-			err := validateIdForName(tc.limit, tc.id)
+			err := validateIdForName(tc.limit, tc.id, defaultKeyTransforms)
 			if tc.err != "" {
 				test.AssertError(t, err, "should have failed")
 				test.AssertContains(t, err.Error(), tc.err)
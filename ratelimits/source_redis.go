@@ -14,16 +14,29 @@ import (
 // Compile-time check that RedisSource implements the source interface.
 var _ Source = (*RedisSource)(nil)
 
+// RedisClient is a Redis client which spreads keys across several Redis
+// servers: either a *redis.Ring, which shards them itself by consistent
+// hashing, or a *redis.ClusterClient, which uses a Redis Cluster.
+type RedisClient interface {
+	redis.Cmdable
+	ForEachShard(ctx context.Context, fn func(ctx context.Context, client *redis.Client) error) error
+}
+
+var (
+	_ RedisClient = (*redis.Ring)(nil)
+	_ RedisClient = (*redis.ClusterClient)(nil)
+)
+
 // RedisSource is a ratelimits source backed by sharded Redis.
 type RedisSource struct {
-	client  *redis.Ring
+	client  RedisClient
 	clk     clock.Clock
 	latency *prometheus.HistogramVec
 }
 
 // NewRedisSource returns a new Redis backed source using the provided
-// *redis.Ring client.
-func NewRedisSource(client *redis.Ring, clk clock.Clock, stats prometheus.Registerer) *RedisSource {
+// RedisClient.
+func NewRedisSource(client RedisClient, clk clock.Clock, stats prometheus.Registerer) *RedisSource {
 	latency := prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name: "ratelimits_latency",
@@ -245,7 +258,7 @@ func (r *RedisSource) Delete(ctx context.Context, bucketKey string) error {
 	return nil
 }
 
-// Ping checks that each shard of the *redis.Ring is reachable using the PING
+// Ping checks that each shard of the RedisClient is reachable using the PING
 // command.
 func (r *RedisSource) Ping(ctx context.Context) error {
 	start := r.clk.Now()
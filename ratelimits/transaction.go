@@ -152,9 +152,10 @@ type TransactionBuilder struct {
 // NewTransactionBuilderFromFiles returns a new *TransactionBuilder. The
 // provided defaults and overrides paths are expected to be paths to YAML files
 // that contain the default and override limits, respectively. Overrides is
-// optional, defaults is required.
-func NewTransactionBuilderFromFiles(defaults, overrides string) (*TransactionBuilder, error) {
-	registry, err := newLimitRegistryFromFiles(defaults, overrides)
+// optional, defaults is required. The keys config determines how bucket keys
+// are derived, and the ids of overrides are validated against it.
+func NewTransactionBuilderFromFiles(defaults, overrides string, keys KeyConfig) (*TransactionBuilder, error) {
+	registry, err := newLimitRegistryFromFiles(defaults, overrides, newKeyTransforms(keys))
 	if err != nil {
 		return nil, err
 	}
@@ -163,9 +164,10 @@ func NewTransactionBuilderFromFiles(defaults, overrides string) (*TransactionBui
 
 // NewTransactionBuilder returns a new *TransactionBuilder. The provided
 // defaults map is expected to contain default limit data. Overrides are not
-// supported. Defaults is required.
+// supported, and bucket keys are derived using the default KeyConfig. Defaults
+// is required.
 func NewTransactionBuilder(defaults LimitConfigs) (*TransactionBuilder, error) {
-	registry, err := newLimitRegistry(defaults, nil)
+	registry, err := newLimitRegistry(defaults, nil, defaultKeyTransforms)
 	if err != nil {
 		return nil, err
 	}
@@ -187,10 +189,10 @@ func (builder *TransactionBuilder) registrationsPerIPAddressTransaction(ip netip
 }
 
 // registrationsPerIPv6RangeTransaction returns a Transaction for the
-// NewRegistrationsPerIPv6Range limit for the /48 IPv6 range which contains the
-// provided IPv6 address.
+// NewRegistrationsPerIPv6Range limit for the IPv6 range, /48 by default, which
+// contains the provided IPv6 address.
 func (builder *TransactionBuilder) registrationsPerIPv6RangeTransaction(ip netip.Addr) (Transaction, error) {
-	prefix, err := coveringIPPrefix(NewRegistrationsPerIPv6Range, ip)
+	prefix, err := builder.keys.ipPrefix(NewRegistrationsPerIPv6Range, ip)
 	if err != nil {
 		return Transaction{}, fmt.Errorf("computing covering prefix for %q: %w", ip, err)
 	}
@@ -340,7 +342,7 @@ func (builder *TransactionBuilder) certificatesPerDomainCheckOnlyTransactions(re
 		}
 	}
 
-	coveringIdents, err := coveringIdentifiers(orderIdents)
+	coveringIdents, err := builder.keys.coveringIdentifiers(orderIdents)
 	if err != nil {
 		return nil, err
 	}
@@ -352,7 +354,7 @@ func (builder *TransactionBuilder) certificatesPerDomainCheckOnlyTransactions(re
 			if !perAccountLimit.isOverride {
 				return nil, fmt.Errorf("shouldn't happen: CertificatesPerDomainPerAccount limit is not an override")
 			}
-			perAccountPerDomainOrCIDRBucketKey := NewRegIdIdentValueBucketKey(CertificatesPerDomainPerAccount, regId, ident)
+			perAccountPerDomainOrCIDRBucketKey := joinWithColon(CertificatesPerDomainPerAccount.EnumString(), accountBoundKey(regId, ident))
 			// Add a check-only transaction for each per account per identValue
 			// bucket.
 			txn, err := newCheckOnlyTransaction(perAccountLimit, perAccountPerDomainOrCIDRBucketKey, 1)
@@ -419,7 +421,7 @@ func (builder *TransactionBuilder) CertificatesPerDomainSpendOnlyTransactions(re
 		}
 	}
 
-	coveringIdents, err := coveringIdentifiers(orderIdents)
+	coveringIdents, err := builder.keys.coveringIdentifiers(orderIdents)
 	if err != nil {
 		return nil, err
 	}
@@ -431,7 +433,7 @@ func (builder *TransactionBuilder) CertificatesPerDomainSpendOnlyTransactions(re
 			if !perAccountLimit.isOverride {
 				return nil, fmt.Errorf("shouldn't happen: CertificatesPerDomainPerAccount limit is not an override")
 			}
-			perAccountPerDomainOrCIDRBucketKey := NewRegIdIdentValueBucketKey(CertificatesPerDomainPerAccount, regId, ident)
+			perAccountPerDomainOrCIDRBucketKey := joinWithColon(CertificatesPerDomainPerAccount.EnumString(), accountBoundKey(regId, ident))
 			// Add a spend-only transaction for each per account per
 			// domainOrCIDR bucket.
 			txn, err := newSpendOnlyTransaction(perAccountLimit, perAccountPerDomainOrCIDRBucketKey, 1)
@@ -475,6 +477,55 @@ func (builder *TransactionBuilder) CertificatesPerDomainSpendOnlyTransactions(re
 	return txns, nil
 }
 
+// DelegatedCertificatesPerDomainCheckOnlyTransactions returns a check-only
+// Transaction for each per domainOrCIDR DelegatedCertificatesPerDomain bucket.
+// This method should be used for checking capacity, before allowing more
+// orders for delegated certificates to be created.
+//
+// Precondition: All orderIdents must comply with policy.WellFormedIdentifiers.
+func (builder *TransactionBuilder) DelegatedCertificatesPerDomainCheckOnlyTransactions(orderIdents identifier.ACMEIdentifiers) ([]Transaction, error) {
+	return builder.delegatedCertificatesPerDomainTransactions(orderIdents, newCheckOnlyTransaction)
+}
+
+// DelegatedCertificatesPerDomainSpendOnlyTransactions returns a spend-only
+// Transaction for each per domainOrCIDR DelegatedCertificatesPerDomain bucket.
+// This method should be used for spending capacity, when a delegated
+// certificate is issued.
+//
+// Precondition: All orderIdents must comply with policy.WellFormedIdentifiers.
+func (builder *TransactionBuilder) DelegatedCertificatesPerDomainSpendOnlyTransactions(orderIdents identifier.ACMEIdentifiers) ([]Transaction, error) {
+	return builder.delegatedCertificatesPerDomainTransactions(orderIdents, newSpendOnlyTransaction)
+}
+
+func (builder *TransactionBuilder) delegatedCertificatesPerDomainTransactions(orderIdents identifier.ACMEIdentifiers, newTxn func(*Limit, string, int64) (Transaction, error)) ([]Transaction, error) {
+	if len(orderIdents) > 100 {
+		return nil, fmt.Errorf("unwilling to process more than 100 rate limit transactions, got %d", len(orderIdents))
+	}
+
+	coveringIdents, err := builder.keys.coveringIdentifiers(orderIdents)
+	if err != nil {
+		return nil, err
+	}
+
+	var txns []Transaction
+	for _, ident := range coveringIdents {
+		bucketKey := newDomainOrCIDRBucketKey(DelegatedCertificatesPerDomain, ident)
+		limit, err := builder.getLimit(DelegatedCertificatesPerDomain, bucketKey)
+		if err != nil {
+			if errors.Is(err, errLimitDisabled) {
+				continue
+			}
+			return nil, err
+		}
+		txn, err := newTxn(limit, bucketKey, 1)
+		if err != nil {
+			return nil, err
+		}
+		txns = append(txns, txn)
+	}
+	return txns, nil
+}
+
 // certificatesPerFQDNSetCheckOnlyTransaction returns a check-only Transaction
 // for the provided order identifiers. This method should only be used for
 // checking capacity, before allowing more orders to be created.
@@ -15,10 +15,10 @@ import (
 
 func TestNewTransactionBuilderFromFiles_WithBadLimitsPath(t *testing.T) {
 	t.Parallel()
-	_, err := NewTransactionBuilderFromFiles("testdata/does-not-exist.yml", "")
+	_, err := NewTransactionBuilderFromFiles("testdata/does-not-exist.yml", "", KeyConfig{})
 	test.AssertError(t, err, "should error")
 
-	_, err = NewTransactionBuilderFromFiles("testdata/defaults.yml", "testdata/does-not-exist.yml")
+	_, err = NewTransactionBuilderFromFiles("testdata/defaults.yml", "testdata/does-not-exist.yml", KeyConfig{})
 	test.AssertError(t, err, "should error")
 }
 
@@ -32,7 +32,7 @@ func sortTransactions(txns []Transaction) []Transaction {
 func TestNewRegistrationsPerIPAddressTransactions(t *testing.T) {
 	t.Parallel()
 
-	tb, err := NewTransactionBuilderFromFiles("../test/config-next/wfe2-ratelimit-defaults.yml", "")
+	tb, err := NewTransactionBuilderFromFiles("../test/config-next/wfe2-ratelimit-defaults.yml", "", KeyConfig{})
 	test.AssertNotError(t, err, "creating TransactionBuilder")
 
 	// A check-and-spend transaction for the global limit.
@@ -45,7 +45,7 @@ func TestNewRegistrationsPerIPAddressTransactions(t *testing.T) {
 func TestNewRegistrationsPerIPv6AddressTransactions(t *testing.T) {
 	t.Parallel()
 
-	tb, err := NewTransactionBuilderFromFiles("../test/config-next/wfe2-ratelimit-defaults.yml", "")
+	tb, err := NewTransactionBuilderFromFiles("../test/config-next/wfe2-ratelimit-defaults.yml", "", KeyConfig{})
 	test.AssertNotError(t, err, "creating TransactionBuilder")
 
 	// A check-and-spend transaction for the global limit.
@@ -58,7 +58,7 @@ func TestNewRegistrationsPerIPv6AddressTransactions(t *testing.T) {
 func TestNewOrdersPerAccountTransactions(t *testing.T) {
 	t.Parallel()
 
-	tb, err := NewTransactionBuilderFromFiles("../test/config-next/wfe2-ratelimit-defaults.yml", "")
+	tb, err := NewTransactionBuilderFromFiles("../test/config-next/wfe2-ratelimit-defaults.yml", "", KeyConfig{})
 	test.AssertNotError(t, err, "creating TransactionBuilder")
 
 	// A check-and-spend transaction for the global limit.
@@ -71,7 +71,7 @@ func TestNewOrdersPerAccountTransactions(t *testing.T) {
 func TestFailedAuthorizationsPerDomainPerAccountTransactions(t *testing.T) {
 	t.Parallel()
 
-	tb, err := NewTransactionBuilderFromFiles("../test/config-next/wfe2-ratelimit-defaults.yml", "testdata/working_override_13371338.yml")
+	tb, err := NewTransactionBuilderFromFiles("../test/config-next/wfe2-ratelimit-defaults.yml", "testdata/working_override_13371338.yml", KeyConfig{})
 	test.AssertNotError(t, err, "creating TransactionBuilder")
 
 	// A check-only transaction for the default per-account limit.
@@ -108,7 +108,7 @@ func TestFailedAuthorizationsPerDomainPerAccountTransactions(t *testing.T) {
 func TestFailedAuthorizationsForPausingPerDomainPerAccountTransactions(t *testing.T) {
 	t.Parallel()
 
-	tb, err := NewTransactionBuilderFromFiles("../test/config-next/wfe2-ratelimit-defaults.yml", "testdata/working_override_13371338.yml")
+	tb, err := NewTransactionBuilderFromFiles("../test/config-next/wfe2-ratelimit-defaults.yml", "testdata/working_override_13371338.yml", KeyConfig{})
 	test.AssertNotError(t, err, "creating TransactionBuilder")
 
 	// A transaction for the per-account limit override.
@@ -122,7 +122,7 @@ func TestFailedAuthorizationsForPausingPerDomainPerAccountTransactions(t *testin
 func TestCertificatesPerDomainTransactions(t *testing.T) {
 	t.Parallel()
 
-	tb, err := NewTransactionBuilderFromFiles("../test/config-next/wfe2-ratelimit-defaults.yml", "")
+	tb, err := NewTransactionBuilderFromFiles("../test/config-next/wfe2-ratelimit-defaults.yml", "", KeyConfig{})
 	test.AssertNotError(t, err, "creating TransactionBuilder")
 
 	// One check-only transaction for the global limit.
@@ -143,7 +143,7 @@ func TestCertificatesPerDomainTransactions(t *testing.T) {
 func TestCertificatesPerDomainPerAccountTransactions(t *testing.T) {
 	t.Parallel()
 
-	tb, err := NewTransactionBuilderFromFiles("../test/config-next/wfe2-ratelimit-defaults.yml", "testdata/working_override_13371338.yml")
+	tb, err := NewTransactionBuilderFromFiles("../test/config-next/wfe2-ratelimit-defaults.yml", "testdata/working_override_13371338.yml", KeyConfig{})
 	test.AssertNotError(t, err, "creating TransactionBuilder")
 
 	// We only expect a single check-only transaction for the per-account limit
@@ -191,10 +191,41 @@ func TestCertificatesPerDomainPerAccountTransactions(t *testing.T) {
 	test.Assert(t, txns[1].limit.isOverride, "should be an override")
 }
 
+func TestDelegatedCertificatesPerDomainTransactions(t *testing.T) {
+	t.Parallel()
+
+	tb, err := NewTransactionBuilderFromFiles("../test/config-next/wfe2-ratelimit-defaults.yml", "", KeyConfig{})
+	test.AssertNotError(t, err, "creating TransactionBuilder")
+
+	// One check-only transaction per registered domain.
+	txns, err := tb.DelegatedCertificatesPerDomainCheckOnlyTransactions(identifier.NewDNSSlice([]string{"so.many.labels.here.example.com", "z.example.com", "example.net"}))
+	test.AssertNotError(t, err, "creating transactions")
+	txns = sortTransactions(txns)
+	test.AssertEquals(t, len(txns), 2)
+	test.AssertEquals(t, txns[0].bucketKey, "9:example.com")
+	test.Assert(t, txns[0].checkOnly(), "should be check-only")
+	test.AssertEquals(t, txns[1].bucketKey, "9:example.net")
+	test.Assert(t, txns[1].checkOnly(), "should be check-only")
+
+	// One spend-only transaction per registered domain.
+	txns, err = tb.DelegatedCertificatesPerDomainSpendOnlyTransactions(identifier.NewDNSSlice([]string{"so.many.labels.here.example.com"}))
+	test.AssertNotError(t, err, "creating transactions")
+	test.AssertEquals(t, len(txns), 1)
+	test.AssertEquals(t, txns[0].bucketKey, "9:example.com")
+	test.Assert(t, txns[0].spendOnly(), "should be spend-only")
+
+	// Without a configured limit, there are no transactions.
+	tb, err = NewTransactionBuilderFromFiles("testdata/working_default.yml", "", KeyConfig{})
+	test.AssertNotError(t, err, "creating TransactionBuilder")
+	txns, err = tb.DelegatedCertificatesPerDomainSpendOnlyTransactions(identifier.NewDNSSlice([]string{"example.com"}))
+	test.AssertNotError(t, err, "creating transactions")
+	test.AssertEquals(t, len(txns), 0)
+}
+
 func TestCertificatesPerFQDNSetTransactions(t *testing.T) {
 	t.Parallel()
 
-	tb, err := NewTransactionBuilderFromFiles("../test/config-next/wfe2-ratelimit-defaults.yml", "")
+	tb, err := NewTransactionBuilderFromFiles("../test/config-next/wfe2-ratelimit-defaults.yml", "", KeyConfig{})
 	test.AssertNotError(t, err, "creating TransactionBuilder")
 
 	// A single check-only transaction for the global limit.
@@ -78,7 +78,7 @@ func TestCoveringIdentifiers(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			got, err := coveringIdentifiers(tc.idents)
+			got, err := defaultKeyTransforms.coveringIdentifiers(tc.idents)
 			if err != nil && err.Error() != tc.wantErr {
 				t.Errorf("Got unwanted error %#v", err.Error())
 			}
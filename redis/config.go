@@ -1,6 +1,7 @@
 package redis
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -27,14 +28,14 @@ type Config struct {
 	// ShardAddrs is a map of shard names to IP address:port pairs. The go-redis
 	// `Ring` client will shard reads and writes across the provided Redis
 	// Servers based on a consistent hashing algorithm.
-	ShardAddrs map[string]string `validate:"omitempty,required_without=Lookups,min=1,dive,hostname_port"`
+	ShardAddrs map[string]string `validate:"omitempty,required_without_all=Lookups ClusterAddrs,min=1,dive,hostname_port"`
 
 	// Lookups each entry contains a service and domain name that will be used
 	// to construct a SRV DNS query to lookup Redis backends. For example: if
 	// the resource record is 'foo.service.consul', then the 'Service' is 'foo'
 	// and the 'Domain' is 'service.consul'. The expected dNSName to be
 	// authenticated in the server certificate would be 'foo.service.consul'.
-	Lookups []cmd.ServiceDomain `validate:"omitempty,required_without=ShardAddrs,min=1,dive"`
+	Lookups []cmd.ServiceDomain `validate:"omitempty,required_without_all=ShardAddrs ClusterAddrs,min=1,dive"`
 
 	// LookupFrequency is the frequency of periodic SRV lookups. Defaults to 30
 	// seconds.
@@ -48,7 +49,21 @@ type Config struct {
 	// the system DNS will be used for resolution.
 	LookupDNSAuthority string `validate:"excluded_without=Lookups,omitempty,ip|hostname|hostname_port"`
 
-	// Enables read-only commands on replicas.
+	// ClusterAddrs are the IP address:port pairs of some of the nodes of a
+	// Redis Cluster, from which the client discovers the rest. Instead of
+	// sharding keys itself, as it does across ShardAddrs or Lookups, the client
+	// sends each to the node which serves its hash slot, and follows the
+	// cluster as slots move between nodes and replicas are promoted. Only
+	// clients created with NewClusterFromConfig support this.
+	ClusterAddrs []string `validate:"omitempty,excluded_with=ShardAddrs Lookups,min=1,dive,hostname_port"`
+
+	// HeartbeatFrequency is how often each shard of ShardAddrs or Lookups is
+	// checked. A shard which fails several checks in a row is taken out of
+	// the ring, and its keys are spread across the others by consistent
+	// hashing, until it passes again. Defaults to 500 milliseconds.
+	HeartbeatFrequency config.Duration `validate:"-"`
+
+	// Enables read-only commands on replicas. Only used with ClusterAddrs.
 	ReadOnly bool
 	// Allows routing read-only commands to the closest primary or replica.
 	// It automatically enables ReadOnly.
@@ -122,6 +137,10 @@ type Ring struct {
 // Callers should defer a call to StopLookups() to ensure that this goroutine is
 // gracefully shutdown.
 func NewRingFromConfig(c Config, stats prometheus.Registerer, log blog.Logger) (*Ring, error) {
+	if len(c.ClusterAddrs) != 0 {
+		return nil, errors.New("ClusterAddrs requires a cluster client")
+	}
+
 	password, err := c.Pass()
 	if err != nil {
 		return nil, fmt.Errorf("loading password: %w", err)
@@ -137,6 +156,9 @@ func NewRingFromConfig(c Config, stats prometheus.Registerer, log blog.Logger) (
 		Username:  c.Username,
 		Password:  password,
 		TLSConfig: tlsConfig,
+		NewClient: newShardHealth(stats).newClient,
+
+		HeartbeatFrequency: c.HeartbeatFrequency.Duration,
 
 		MaxRetries:      c.MaxRetries,
 		MinRetryBackoff: c.MinRetryBackoff.Duration,
@@ -186,3 +208,62 @@ func (r *Ring) StopLookups() {
 	}
 	r.lookup.stop()
 }
+
+// NewClusterFromConfig returns a new *redis.ClusterClient for the Redis Cluster
+// whose nodes include the configured ClusterAddrs.
+func NewClusterFromConfig(c Config, stats prometheus.Registerer) (*redis.ClusterClient, error) {
+	if len(c.ClusterAddrs) == 0 {
+		return nil, errors.New("ClusterAddrs is required")
+	}
+	if len(c.ShardAddrs) != 0 || len(c.Lookups) != 0 {
+		return nil, errors.New("ShardAddrs and Lookups can't be used with ClusterAddrs")
+	}
+
+	password, err := c.Pass()
+	if err != nil {
+		return nil, fmt.Errorf("loading password: %w", err)
+	}
+
+	tlsConfig, err := c.TLS.Load(stats)
+	if err != nil {
+		return nil, fmt.Errorf("loading TLS config: %w", err)
+	}
+
+	client := redis.NewClusterClient(&redis.ClusterOptions{
+		Addrs:     c.ClusterAddrs,
+		Username:  c.Username,
+		Password:  password,
+		TLSConfig: tlsConfig,
+		NewClient: newShardHealth(stats).newClient,
+
+		ReadOnly:       c.ReadOnly,
+		RouteByLatency: c.RouteByLatency,
+		RouteRandomly:  c.RouteRandomly,
+
+		MaxRetries:      c.MaxRetries,
+		MinRetryBackoff: c.MinRetryBackoff.Duration,
+		MaxRetryBackoff: c.MaxRetryBackoff.Duration,
+		DialTimeout:     c.DialTimeout.Duration,
+		ReadTimeout:     c.ReadTimeout.Duration,
+		WriteTimeout:    c.WriteTimeout.Duration,
+
+		PoolFIFO:        c.PoolFIFO,
+		PoolSize:        c.PoolSize,
+		MinIdleConns:    c.MinIdleConns,
+		ConnMaxLifetime: c.MaxConnAge.Duration,
+		PoolTimeout:     c.PoolTimeout.Duration,
+		ConnMaxIdleTime: c.IdleTimeout.Duration,
+	})
+
+	addrs := make(map[string]string, len(c.ClusterAddrs))
+	for _, addr := range c.ClusterAddrs {
+		addrs[addr] = addr
+	}
+	MustRegisterClientMetricsCollector(client, stats, addrs, c.Username)
+
+	err = redisotel.InstrumentTracing(client)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
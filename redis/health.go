@@ -0,0 +1,110 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+)
+
+// shardHealth tracks the health of each shard, or cluster node, of a client
+// by observing the result of every command sent to it. A shard is healthy if
+// the last command sent to it got a reply, even an error reply, and unhealthy
+// if it couldn't be reached or didn't reply in time.
+type shardHealth struct {
+	healthy  *prometheus.GaugeVec
+	failures *prometheus.CounterVec
+}
+
+// newShardHealth returns a shardHealth whose metrics are registered with the
+// provided prometheus.Registerer. Clients in the same process share their
+// metrics, which are labelled by shard address.
+func newShardHealth(stats prometheus.Registerer) *shardHealth {
+	healthy := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "redis_shard_healthy",
+		Help: "Whether the last command sent to the Redis shard, by address, got a reply (1) or not (0)",
+	}, []string{"addr"})
+	err := stats.Register(healthy)
+	if err != nil {
+		are := prometheus.AlreadyRegisteredError{}
+		if !errors.As(err, &are) {
+			panic(err)
+		}
+		healthy = are.ExistingCollector.(*prometheus.GaugeVec)
+	}
+
+	failures := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "redis_shard_failures",
+		Help: "Number of commands sent to the Redis shard, by address, which got no reply",
+	}, []string{"addr"})
+	err = stats.Register(failures)
+	if err != nil {
+		are := prometheus.AlreadyRegisteredError{}
+		if !errors.As(err, &are) {
+			panic(err)
+		}
+		failures = are.ExistingCollector.(*prometheus.CounterVec)
+	}
+
+	return &shardHealth{healthy: healthy, failures: failures}
+}
+
+// newClient is used as the NewClient option of a *redis.Ring or
+// *redis.ClusterClient, so that the health of each of its shards is tracked.
+func (h *shardHealth) newClient(opt *redis.Options) *redis.Client {
+	client := redis.NewClient(opt)
+	client.AddHook(shardHealthHook{addr: opt.Addr, health: h})
+	return client
+}
+
+// observe records the result of a command sent to the shard at addr.
+func (h *shardHealth) observe(addr string, err error) {
+	var redisErr redis.Error
+	switch {
+	case err == nil, errors.As(err, &redisErr):
+		// The shard replied, even if it was with redis.Nil or an error.
+		h.healthy.WithLabelValues(addr).Set(1)
+	case errors.Is(err, context.Canceled):
+		// The caller gave up, which says nothing about the shard.
+	default:
+		h.healthy.WithLabelValues(addr).Set(0)
+		h.failures.WithLabelValues(addr).Inc()
+	}
+}
+
+// shardHealthHook is a redis.Hook which reports the result of every command
+// sent to one shard to its shardHealth.
+type shardHealthHook struct {
+	addr   string
+	health *shardHealth
+}
+
+var _ redis.Hook = shardHealthHook{}
+
+func (s shardHealthHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := next(ctx, network, addr)
+		if err != nil {
+			s.health.observe(s.addr, err)
+		}
+		return conn, err
+	}
+}
+
+func (s shardHealthHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		err := next(ctx, cmd)
+		s.health.observe(s.addr, err)
+		return err
+	}
+}
+
+func (s shardHealthHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		err := next(ctx, cmds)
+		s.health.observe(s.addr, err)
+		return err
+	}
+}
@@ -0,0 +1,82 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestShardHealthObserve(t *testing.T) {
+	t.Parallel()
+	h := newShardHealth(prometheus.NewRegistry())
+	addr := prometheus.Labels{"addr": "10.0.0.1:4218"}
+
+	h.observe("10.0.0.1:4218", nil)
+	test.AssertMetricWithLabelsEquals(t, h.healthy, addr, 1)
+
+	h.observe("10.0.0.1:4218", errors.New("connection refused"))
+	test.AssertMetricWithLabelsEquals(t, h.healthy, addr, 0)
+	test.AssertMetricWithLabelsEquals(t, h.failures, addr, 1)
+
+	// A reply, even one which is an error, means the shard is reachable.
+	h.observe("10.0.0.1:4218", redis.Nil)
+	test.AssertMetricWithLabelsEquals(t, h.healthy, addr, 1)
+	test.AssertMetricWithLabelsEquals(t, h.failures, addr, 1)
+
+	// A canceled command says nothing about the shard.
+	h.observe("10.0.0.1:4218", context.Canceled)
+	test.AssertMetricWithLabelsEquals(t, h.healthy, addr, 1)
+	test.AssertMetricWithLabelsEquals(t, h.failures, addr, 1)
+}
+
+func TestShardHealthSharedMetrics(t *testing.T) {
+	t.Parallel()
+	stats := prometheus.NewRegistry()
+	h1 := newShardHealth(stats)
+	h2 := newShardHealth(stats)
+
+	h1.observe("10.0.0.1:4218", errors.New("connection refused"))
+	h2.observe("10.0.0.1:4218", errors.New("connection refused"))
+	test.AssertMetricWithLabelsEquals(t, h1.failures, prometheus.Labels{"addr": "10.0.0.1:4218"}, 2)
+}
+
+func TestShardHealthHook(t *testing.T) {
+	t.Parallel()
+	h := newShardHealth(prometheus.NewRegistry())
+
+	// Nothing listens on port 1, so every command fails to dial.
+	client := h.newClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	defer client.Close()
+
+	err := client.Ping(context.Background()).Err()
+	test.AssertError(t, err, "ping of unreachable shard should fail")
+	test.AssertMetricWithLabelsEquals(t, h.healthy, prometheus.Labels{"addr": "127.0.0.1:1"}, 0)
+
+	pipeline := client.Pipeline()
+	pipeline.Get(context.Background(), "foo")
+	_, err = pipeline.Exec(context.Background())
+	test.AssertError(t, err, "pipeline to unreachable shard should fail")
+	test.AssertMetricWithLabelsEquals(t, h.healthy, prometheus.Labels{"addr": "127.0.0.1:1"}, 0)
+}
+
+func TestNewClientsRejectMixedConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewRingFromConfig(Config{ClusterAddrs: []string{"10.0.0.1:4218"}}, metrics.NoopRegisterer, nil)
+	test.AssertError(t, err, "ring with ClusterAddrs should be rejected")
+
+	_, err = NewClusterFromConfig(Config{ShardAddrs: map[string]string{"shard1": "10.0.0.1:4218"}}, metrics.NoopRegisterer)
+	test.AssertError(t, err, "cluster without ClusterAddrs should be rejected")
+
+	_, err = NewClusterFromConfig(Config{
+		ClusterAddrs: []string{"10.0.0.1:4218"},
+		ShardAddrs:   map[string]string{"shard1": "10.0.0.2:4218"},
+	}, metrics.NoopRegisterer)
+	test.AssertError(t, err, "cluster with ShardAddrs should be rejected")
+}
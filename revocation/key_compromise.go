@@ -0,0 +1,70 @@
+package revocation
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+)
+
+// KeyCompromiseMessage returns the message which is signed by a compromised
+// key to demonstrate possession of it when reporting its compromise. It's
+// bound to the key's SPKI hash, so that a signature can't be replayed to
+// report another key.
+func KeyCompromiseMessage(keyHash []byte) []byte {
+	return []byte(fmt.Sprintf("Boulder key compromise report for SPKI SHA-256 %x", keyHash))
+}
+
+// SignKeyCompromise returns the DER-encoded SubjectPublicKeyInfo of signer's
+// public key and a signature by it over its KeyCompromiseMessage. RSA keys
+// sign with PKCS #1 v1.5 and ECDSA keys with ASN.1 signatures, both over the
+// SHA-256 digest of the message.
+func SignKeyCompromise(signer crypto.Signer) ([]byte, []byte, error) {
+	spki, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshalling public key: %w", err)
+	}
+	keyHash := sha256.Sum256(spki)
+	digest := sha256.Sum256(KeyCompromiseMessage(keyHash[:]))
+	sig, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return nil, nil, fmt.Errorf("signing key compromise message: %w", err)
+	}
+	return spki, sig, nil
+}
+
+// VerifyKeyCompromise checks that sig is a signature by the key in spki over
+// its KeyCompromiseMessage, as produced by SignKeyCompromise, and returns the
+// key's SPKI hash. The hash is of the re-encoded key, as computed by
+// core.KeyDigest, rather than of spki as given.
+func VerifyKeyCompromise(spki []byte, sig []byte) ([]byte, error) {
+	pub, err := x509.ParsePKIXPublicKey(spki)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key: %w", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling public key: %w", err)
+	}
+	keyHash := sha256.Sum256(der)
+	digest := sha256.Sum256(KeyCompromiseMessage(keyHash[:]))
+
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		err = rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], sig)
+		if err != nil {
+			return nil, fmt.Errorf("verifying RSA signature: %w", err)
+		}
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(k, digest[:], sig) {
+			return nil, errors.New("verifying ECDSA signature: invalid signature")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", pub)
+	}
+	return keyHash[:], nil
+}
@@ -0,0 +1,53 @@
+package revocation
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestKeyCompromiseSignature(t *testing.T) {
+	t.Parallel()
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating ECDSA key")
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	test.AssertNotError(t, err, "generating RSA key")
+
+	for _, key := range []crypto.Signer{ecKey, rsaKey} {
+		spki, sig, err := SignKeyCompromise(key)
+		test.AssertNotError(t, err, "SignKeyCompromise failed")
+
+		keyHash, err := VerifyKeyCompromise(spki, sig)
+		test.AssertNotError(t, err, "VerifyKeyCompromise failed")
+		want := sha256.Sum256(spki)
+		test.AssertByteEquals(t, keyHash, want[:])
+
+		// A signature by one key doesn't prove possession of another.
+		for _, other := range []crypto.Signer{ecKey, rsaKey} {
+			if other == key {
+				continue
+			}
+			_, otherSig, err := SignKeyCompromise(other)
+			test.AssertNotError(t, err, "SignKeyCompromise failed")
+			_, err = VerifyKeyCompromise(spki, otherSig)
+			test.AssertError(t, err, "VerifyKeyCompromise should have rejected another key's signature")
+		}
+
+		// Nor does a signature over some other message.
+		digest := sha256.Sum256([]byte("hello world"))
+		wrongSig, err := key.Sign(rand.Reader, digest[:], crypto.SHA256)
+		test.AssertNotError(t, err, "signing")
+		_, err = VerifyKeyCompromise(spki, wrongSig)
+		test.AssertError(t, err, "VerifyKeyCompromise should have rejected a signature over another message")
+	}
+
+	_, err = VerifyKeyCompromise([]byte("not a key"), nil)
+	test.AssertError(t, err, "VerifyKeyCompromise should have rejected a malformed SPKI")
+}
@@ -0,0 +1,312 @@
+package sa
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/db"
+	blog "github.com/letsencrypt/boulder/log"
+)
+
+// The pendingAuthzCounts and invalidAuthzCounts tables hold counters which
+// replace the COUNT(*) queries behind CountPendingAuthorizations2 and
+// CountInvalidAuthorizations2. When the MaintainAuthzCounts feature is enabled
+// they're updated in the same transactions as the authz2 writes which change
+// them, and the UseAuthzCounts feature causes the counting RPCs to read them.
+//
+// The counters are approximate. A pending authorization stays counted after it
+// expires until the AuthzCountReconciler corrects its account's counter, and
+// invalid authorizations are counted in buckets by the hour in which they
+// expire.
+
+// addPendingAuthzCount adds delta, which may be negative, to the number of
+// pending authorizations counted for the given account. The count never falls
+// below zero.
+func addPendingAuthzCount(ctx context.Context, tx db.Execer, regID int64, delta int64) error {
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO pendingAuthzCounts (registrationID, count) VALUES (?, GREATEST(?, 0))
+		ON DUPLICATE KEY UPDATE count = GREATEST(count + ?, 0)`,
+		regID, delta, delta,
+	)
+	if err != nil {
+		return fmt.Errorf("updating pending authorization count: %w", err)
+	}
+	return nil
+}
+
+// invalidAuthzCountBucket returns the hour in which invalid authorizations with
+// the given expiry are counted.
+func invalidAuthzCountBucket(expires time.Time) time.Time {
+	return expires.UTC().Truncate(time.Hour)
+}
+
+// incrementInvalidAuthzCount counts an invalid authorization for the given
+// account and identifier which expires at the given time.
+func incrementInvalidAuthzCount(ctx context.Context, tx db.Execer, regID int64, identType uint8, identValue string, expires time.Time) error {
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO invalidAuthzCounts (registrationID, identifierType, identifierValue, expiresHour, count)
+		VALUES (?, ?, ?, ?, 1)
+		ON DUPLICATE KEY UPDATE count = count + 1`,
+		regID, identType, identValue, invalidAuthzCountBucket(expires),
+	)
+	if err != nil {
+		return fmt.Errorf("updating invalid authorization count: %w", err)
+	}
+	return nil
+}
+
+// finalizedAuthz is the part of an authz2 row needed to update the counters
+// when it's finalized.
+type finalizedAuthz struct {
+	RegistrationID  int64  `db:"registrationID"`
+	IdentifierType  uint8  `db:"identifierType"`
+	IdentifierValue string `db:"identifierValue"`
+}
+
+// updateFinalizedAuthzCounts updates the counters for the authorization with
+// the given ID, which tx has just moved from pending to the given status.
+func updateFinalizedAuthzCounts(ctx context.Context, tx db.Executor, id int64, status core.AcmeStatus, expires time.Time) error {
+	var authz finalizedAuthz
+	err := tx.SelectOne(ctx, &authz,
+		`SELECT registrationID, identifierType, identifierValue FROM authz2 WHERE id = ?`,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+	err = addPendingAuthzCount(ctx, tx, authz.RegistrationID, -1)
+	if err != nil {
+		return err
+	}
+	if status == core.StatusInvalid {
+		return incrementInvalidAuthzCount(ctx, tx, authz.RegistrationID, authz.IdentifierType, authz.IdentifierValue, expires)
+	}
+	return nil
+}
+
+// countPendingAuthzs returns the number of pending authorizations counted for
+// the given account.
+func countPendingAuthzs(ctx context.Context, dbMap db.OneSelector, regID int64) (int64, error) {
+	var count int64
+	err := dbMap.SelectOne(ctx, &count,
+		`SELECT count FROM pendingAuthzCounts WHERE registrationID = ?`,
+		regID,
+	)
+	if db.IsNoRows(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// countInvalidAuthzs returns the number of invalid authorizations counted for
+// the given account and identifier in the buckets between earliest and latest.
+// The bucket containing earliest is included in its entirety.
+func countInvalidAuthzs(ctx context.Context, dbMap db.OneSelector, regID int64, identType uint8, identValue string, earliest, latest time.Time) (int64, error) {
+	var count int64
+	err := dbMap.SelectOne(ctx, &count,
+		`SELECT COALESCE(SUM(count), 0) FROM invalidAuthzCounts WHERE
+		registrationID = ? AND
+		identifierType = ? AND
+		identifierValue = ? AND
+		expiresHour >= ? AND
+		expiresHour <= ?`,
+		regID, identType, identValue, invalidAuthzCountBucket(earliest), latest.UTC(),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// AuthzCountReconcileConfig configures an AuthzCountReconciler.
+type AuthzCountReconcileConfig struct {
+	// BatchSize is the maximum number of accounts reconciled, or invalid
+	// authorization buckets deleted, by each statement. If zero, a default of
+	// 1000 is used.
+	BatchSize int `validate:"omitempty,min=1,max=10000"`
+
+	// CheckInterval is how often the reconciler runs. If zero, a default of
+	// one hour is used.
+	CheckInterval config.Duration `validate:"-"`
+}
+
+// AuthzCountReconciler corrects the pending authorization counters, which drift
+// as authorizations expire and when writes are made by SAs without the
+// MaintainAuthzCounts feature, by recounting each account's pending
+// authorizations. It also deletes the invalid authorization buckets which have
+// expired. It should only run on one SA instance.
+type AuthzCountReconciler struct {
+	dbMap         db.SelectExecer
+	batchSize     int
+	checkInterval time.Duration
+	clk           clock.Clock
+	log           blog.Logger
+
+	corrected prometheus.Counter
+	deleted   prometheus.Counter
+}
+
+// NewAuthzCountReconciler returns an AuthzCountReconciler for the given config,
+// which reconciles the counters in the database behind dbMap. Since it
+// compares counters to the authorizations they count, dbMap must be the
+// primary.
+func NewAuthzCountReconciler(c AuthzCountReconcileConfig, dbMap db.SelectExecer, clk clock.Clock, stats prometheus.Registerer, logger blog.Logger) (*AuthzCountReconciler, error) {
+	batchSize := c.BatchSize
+	if batchSize == 0 {
+		batchSize = 1000
+	}
+	checkInterval := c.CheckInterval.Duration
+	if checkInterval < 0 {
+		return nil, fmt.Errorf("authz count reconcile checkInterval %s is negative", checkInterval)
+	}
+	if checkInterval == 0 {
+		checkInterval = time.Hour
+	}
+
+	r := &AuthzCountReconciler{
+		dbMap:         dbMap,
+		batchSize:     batchSize,
+		checkInterval: checkInterval,
+		clk:           clk,
+		log:           logger,
+		corrected: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "authz_count_reconcile_corrections",
+			Help: "number of pending authorization counters found to be wrong and corrected",
+		}),
+		deleted: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "authz_count_reconcile_deleted_buckets",
+			Help: "number of expired invalid authorization count buckets deleted",
+		}),
+	}
+	stats.MustRegister(r.corrected, r.deleted)
+	return r, nil
+}
+
+// Run reconciles every CheckInterval until ctx is canceled.
+func (r *AuthzCountReconciler) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.checkInterval)
+	defer ticker.Stop()
+	for {
+		err := r.Tick(ctx)
+		if err != nil {
+			r.log.Errf("reconciling authorization counts: %s", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// pendingAuthzCount is a row of the pendingAuthzCounts table, or the result of
+// recounting an account's pending authorizations.
+type pendingAuthzCount struct {
+	RegistrationID int64 `db:"registrationID"`
+	Count          int64 `db:"count"`
+}
+
+// Tick reconciles every pending authorization counter, one batch of accounts
+// at a time, and then deletes the invalid authorization buckets which expired
+// before the current hour.
+func (r *AuthzCountReconciler) Tick(ctx context.Context) error {
+	var after int64
+	for {
+		var counters []pendingAuthzCount
+		_, err := r.dbMap.Select(ctx, &counters,
+			`SELECT registrationID, count FROM pendingAuthzCounts
+			WHERE registrationID > ?
+			ORDER BY registrationID
+			LIMIT ?`,
+			after, r.batchSize,
+		)
+		if err != nil {
+			return fmt.Errorf("selecting pending authorization counts: %w", err)
+		}
+		if len(counters) == 0 {
+			break
+		}
+		err = r.reconcile(ctx, counters)
+		if err != nil {
+			return err
+		}
+		if len(counters) < r.batchSize {
+			break
+		}
+		after = counters[len(counters)-1].RegistrationID
+	}
+
+	cutoff := invalidAuthzCountBucket(r.clk.Now())
+	for {
+		res, err := r.dbMap.ExecContext(ctx,
+			`DELETE FROM invalidAuthzCounts WHERE expiresHour < ? LIMIT ?`,
+			cutoff, r.batchSize,
+		)
+		if err != nil {
+			return fmt.Errorf("deleting expired invalid authorization counts: %w", err)
+		}
+		deleted, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("deleting expired invalid authorization counts: %w", err)
+		}
+		r.deleted.Add(float64(deleted))
+		if deleted < int64(r.batchSize) {
+			return nil
+		}
+	}
+}
+
+// reconcile recounts the pending authorizations of the accounts whose counters
+// are given, and corrects the counters which are wrong. Each correction only
+// applies if the counter hasn't changed since it was selected, so that
+// authorizations created or finalized concurrently aren't lost; those
+// counters are left for the next pass.
+func (r *AuthzCountReconciler) reconcile(ctx context.Context, counters []pendingAuthzCount) error {
+	args := []interface{}{statusUint(core.StatusPending), r.clk.Now()}
+	for _, c := range counters {
+		args = append(args, c.RegistrationID)
+	}
+	var actual []pendingAuthzCount
+	_, err := r.dbMap.Select(ctx, &actual, fmt.Sprintf(
+		`SELECT registrationID, COUNT(*) AS count FROM authz2
+		WHERE status = ? AND expires > ? AND registrationID IN (%s)
+		GROUP BY registrationID`, db.QuestionMarks(len(counters))),
+		args...,
+	)
+	if err != nil {
+		return fmt.Errorf("counting pending authorizations: %w", err)
+	}
+	byRegID := make(map[int64]int64, len(actual))
+	for _, a := range actual {
+		byRegID[a.RegistrationID] = a.Count
+	}
+
+	for _, c := range counters {
+		want := byRegID[c.RegistrationID]
+		if want == c.Count {
+			continue
+		}
+		res, err := r.dbMap.ExecContext(ctx,
+			`UPDATE pendingAuthzCounts SET count = ? WHERE registrationID = ? AND count = ?`,
+			want, c.RegistrationID, c.Count,
+		)
+		if err != nil {
+			return fmt.Errorf("correcting pending authorization count: %w", err)
+		}
+		updated, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("correcting pending authorization count: %w", err)
+		}
+		r.corrected.Add(float64(updated))
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package sa
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/core"
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	"github.com/letsencrypt/boulder/features"
+	"github.com/letsencrypt/boulder/identifier"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// fakeCountsDB answers the AuthzCountReconciler's queries from maps of
+// counters and actual pending authorization counts by account, and of invalid
+// authorization buckets by expiry hour.
+type fakeCountsDB struct {
+	counters map[int64]int64
+	actual   map[int64]int64
+	buckets  map[time.Time]int
+	// changed, if set, is applied to counters just after they're selected, as
+	// if by a concurrent write.
+	changed map[int64]int64
+}
+
+func (f *fakeCountsDB) Select(_ context.Context, holder interface{}, _ string, args ...interface{}) ([]interface{}, error) {
+	h := holder.(*[]pendingAuthzCount)
+	if len(args) == 2 {
+		after, limit := args[0].(int64), args[1].(int)
+		for regID := after + 1; regID <= 100 && len(*h) < limit; regID++ {
+			count, ok := f.counters[regID]
+			if ok {
+				*h = append(*h, pendingAuthzCount{RegistrationID: regID, Count: count})
+			}
+		}
+		for _, c := range *h {
+			count, ok := f.changed[c.RegistrationID]
+			if ok {
+				f.counters[c.RegistrationID] = count
+			}
+		}
+		return nil, nil
+	}
+	for _, arg := range args[2:] {
+		regID := arg.(int64)
+		if f.actual[regID] > 0 {
+			*h = append(*h, pendingAuthzCount{RegistrationID: regID, Count: f.actual[regID]})
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeCountsDB) ExecContext(_ context.Context, _ string, args ...interface{}) (sql.Result, error) {
+	if len(args) == 2 {
+		cutoff, limit := args[0].(time.Time), args[1].(int)
+		var deleted int64
+		for hour, n := range f.buckets {
+			if hour.Before(cutoff) && deleted < int64(limit) {
+				delete(f.buckets, hour)
+				deleted += int64(n)
+			}
+		}
+		return driverResult(deleted), nil
+	}
+	count, regID, old := args[0].(int64), args[1].(int64), args[2].(int64)
+	if f.counters[regID] != old {
+		return driverResult(0), nil
+	}
+	f.counters[regID] = count
+	return driverResult(1), nil
+}
+
+func TestAuthzCountReconciler(t *testing.T) {
+	t.Parallel()
+
+	clk := clock.NewFake()
+	clk.Set(time.Date(2026, 10, 15, 12, 30, 0, 0, time.UTC))
+	hour := clk.Now().Truncate(time.Hour)
+	fake := &fakeCountsDB{
+		counters: map[int64]int64{1: 3, 2: 2, 3: 0, 4: 5, 5: 1},
+		// Account 1's counter is right, account 2's counts authorizations
+		// which have since expired, account 3's missed some, and account 5's
+		// is wrong but changes before it can be corrected.
+		actual:  map[int64]int64{1: 3, 2: 1, 3: 2, 4: 0, 5: 3},
+		changed: map[int64]int64{5: 2},
+		buckets: map[time.Time]int{
+			hour.Add(-2 * time.Hour): 1,
+			hour.Add(-time.Hour):     1,
+			hour:                     1,
+			hour.Add(time.Hour):      1,
+		},
+	}
+	r, err := NewAuthzCountReconciler(AuthzCountReconcileConfig{BatchSize: 2}, fake, clk, metrics.NoopRegisterer, blog.NewMock())
+	test.AssertNotError(t, err, "NewAuthzCountReconciler failed")
+
+	err = r.Tick(context.Background())
+	test.AssertNotError(t, err, "Tick failed")
+	test.AssertDeepEquals(t, fake.counters, map[int64]int64{1: 3, 2: 1, 3: 2, 4: 0, 5: 2})
+	test.AssertMetricWithLabelsEquals(t, r.corrected, prometheus.Labels{}, 3)
+
+	// Buckets which expired before the current hour are deleted.
+	test.AssertEquals(t, len(fake.buckets), 2)
+	test.AssertMetricWithLabelsEquals(t, r.deleted, prometheus.Labels{}, 2)
+
+	// The next pass corrects the counter which changed.
+	fake.changed = nil
+	err = r.Tick(context.Background())
+	test.AssertNotError(t, err, "Tick failed")
+	test.AssertEquals(t, fake.counters[5], int64(3))
+	test.AssertMetricWithLabelsEquals(t, r.corrected, prometheus.Labels{}, 4)
+}
+
+func TestAuthzCountReconcilerErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewAuthzCountReconciler(AuthzCountReconcileConfig{CheckInterval: config.Duration{Duration: -time.Hour}}, &fakeCountsDB{}, clock.NewFake(), metrics.NoopRegisterer, blog.NewMock())
+	test.AssertError(t, err, "negative checkInterval should be rejected")
+}
+
+func TestInvalidAuthzCountBucket(t *testing.T) {
+	t.Parallel()
+
+	expires := time.Date(2026, 10, 15, 12, 59, 59, 0, time.FixedZone("UTC+1", 3600))
+	test.AssertEquals(t, invalidAuthzCountBucket(expires), time.Date(2026, 10, 15, 11, 0, 0, 0, time.UTC))
+}
+
+func TestAuthzCounts(t *testing.T) {
+	if os.Getenv("BOULDER_CONFIG_DIR") != "test/config-next" {
+		t.Skip("skipping, the authorization count tables must exist for this test to run")
+	}
+
+	sa, fc, cleanUp := initSA(t)
+	defer cleanUp()
+	features.Set(features.Config{MaintainAuthzCounts: true, UseAuthzCounts: true})
+	defer features.Reset()
+
+	reg := createWorkingRegistration(t, sa)
+	expires := fc.Now().Add(time.Hour)
+	var newAuthzs []*sapb.NewAuthzRequest
+	for _, name := range []string{"a.com", "b.com", "c.com"} {
+		newAuthzs = append(newAuthzs, &sapb.NewAuthzRequest{
+			Identifier:     &corepb.Identifier{Type: "dns", Value: name},
+			RegistrationID: reg.Id,
+			Expires:        timestamppb.New(expires),
+			ChallengeTypes: []string{string(core.ChallengeTypeHTTP01)},
+			Token:          core.NewToken(),
+		})
+	}
+	order, err := sa.NewOrderAndAuthzs(context.Background(), &sapb.NewOrderAndAuthzsRequest{
+		NewOrder: &sapb.NewOrderRequest{
+			RegistrationID: reg.Id,
+			Expires:        timestamppb.New(expires),
+			Identifiers: []*corepb.Identifier{
+				identifier.NewDNS("a.com").ToProto(),
+				identifier.NewDNS("b.com").ToProto(),
+				identifier.NewDNS("c.com").ToProto(),
+			},
+		},
+		NewAuthzs: newAuthzs,
+	})
+	test.AssertNotError(t, err, "sa.NewOrderAndAuthzs failed")
+
+	countPending := func() int64 {
+		t.Helper()
+		count, err := sa.CountPendingAuthorizations2(context.Background(), &sapb.RegistrationID{Id: reg.Id})
+		test.AssertNotError(t, err, "sa.CountPendingAuthorizations2 failed")
+		return count.Count
+	}
+	test.AssertEquals(t, countPending(), int64(3))
+
+	// Finalizing an authorization as invalid moves it from the pending count
+	// to the invalid count.
+	invalidExpires := fc.Now().Add(2 * time.Hour)
+	_, err = sa.FinalizeAuthorization2(context.Background(), &sapb.FinalizeAuthorizationRequest{
+		Id:          order.V2Authorizations[0],
+		Status:      string(core.StatusInvalid),
+		Expires:     timestamppb.New(invalidExpires),
+		Attempted:   string(core.ChallengeTypeHTTP01),
+		AttemptedAt: timestamppb.New(fc.Now()),
+	})
+	test.AssertNotError(t, err, "sa.FinalizeAuthorization2 failed")
+	test.AssertEquals(t, countPending(), int64(2))
+	count, err := sa.CountInvalidAuthorizations2(context.Background(), &sapb.CountInvalidAuthorizationsRequest{
+		RegistrationID: reg.Id,
+		Identifier:     identifier.NewDNS("a.com").ToProto(),
+		Range: &sapb.Range{
+			Earliest: timestamppb.New(fc.Now()),
+			Latest:   timestamppb.New(fc.Now().Add(3 * time.Hour)),
+		},
+	})
+	test.AssertNotError(t, err, "sa.CountInvalidAuthorizations2 failed")
+	test.AssertEquals(t, count.Count, int64(1))
+
+	// Deactivating a pending authorization decrements the pending count, and
+	// deactivating it again doesn't.
+	for range 2 {
+		_, err = sa.DeactivateAuthorization2(context.Background(), &sapb.AuthorizationID2{Id: order.V2Authorizations[1]})
+		test.AssertNotError(t, err, "sa.DeactivateAuthorization2 failed")
+		test.AssertEquals(t, countPending(), int64(1))
+	}
+
+	// Once the last pending authorization has expired, the reconciler
+	// corrects the count.
+	fc.Add(90 * time.Minute)
+	test.AssertEquals(t, countPending(), int64(1))
+	r, err := NewAuthzCountReconciler(AuthzCountReconcileConfig{}, sa.dbMap, fc, metrics.NoopRegisterer, blog.NewMock())
+	test.AssertNotError(t, err, "NewAuthzCountReconciler failed")
+	err = r.Tick(context.Background())
+	test.AssertNotError(t, err, "Tick failed")
+	test.AssertEquals(t, countPending(), int64(0))
+}
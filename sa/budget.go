@@ -0,0 +1,128 @@
+package sa
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/db"
+)
+
+// QueryBudgetConfig configures latency budgets for the database queries made by
+// the SA, so that slow queries fail fast rather than tying up connections.
+type QueryBudgetConfig struct {
+	// Default is the budget for RPCs which aren't listed in RPCs. Zero means
+	// that such RPCs are not limited.
+	Default config.Duration `validate:"-"`
+
+	// RPCs maps the name of an SA RPC, such as "GetRegistration", to the time
+	// from the start of that RPC by which each of its queries must complete.
+	RPCs map[string]config.Duration `validate:"omitempty,dive,keys,required,endkeys"`
+
+	// Tables maps the name of a table, such as "certificateStatus", to the
+	// longest that any single query against it may take.
+	Tables map[string]config.Duration `validate:"omitempty,dive,keys,required,endkeys"`
+}
+
+// QueryBudgets enforces a QueryBudgetConfig. Its Unary method must be installed
+// as an interceptor on the SA's gRPC server to annotate each RPC with its
+// budget, and it must be given to each db.WrappedMap used by the SA, as a
+// db.QueryLimiter, to apply those budgets.
+type QueryBudgets struct {
+	defaultBudget time.Duration
+	rpcs          map[string]time.Duration
+	tables        map[string]time.Duration
+
+	exhausted *prometheus.CounterVec
+}
+
+var _ db.QueryLimiter = (*QueryBudgets)(nil)
+
+// NewQueryBudgets returns a QueryBudgets for the given config.
+func NewQueryBudgets(c QueryBudgetConfig, stats prometheus.Registerer) *QueryBudgets {
+	qb := &QueryBudgets{
+		defaultBudget: c.Default.Duration,
+		rpcs:          make(map[string]time.Duration, len(c.RPCs)),
+		tables:        make(map[string]time.Duration, len(c.Tables)),
+		exhausted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "query_budget_exhausted",
+			Help: "number of queries canceled for exceeding a latency budget, by RPC, table, and budget (rpc or table)",
+		}, []string{"rpc", "table", "budget"}),
+	}
+	stats.MustRegister(qb.exhausted)
+
+	for rpc, budget := range c.RPCs {
+		qb.rpcs[rpc] = budget.Duration
+	}
+	for table, budget := range c.Tables {
+		qb.tables[table] = budget.Duration
+	}
+	return qb
+}
+
+// rpcBudgetKey is the context key for an rpcBudget.
+type rpcBudgetKey struct{}
+
+// rpcBudget records the RPC on whose behalf a query is made, and the time by
+// which all of that RPC's queries must complete, if any.
+type rpcBudget struct {
+	rpc      string
+	budget   time.Duration
+	deadline time.Time
+}
+
+// Unary is a gRPC unary server interceptor which annotates the RPC's context
+// with its budget.
+func (qb *QueryBudgets) Unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	rb := rpcBudget{rpc: path.Base(info.FullMethod)}
+	budget, ok := qb.rpcs[rb.rpc]
+	if !ok {
+		budget = qb.defaultBudget
+	}
+	if budget > 0 {
+		rb.budget = budget
+		rb.deadline = time.Now().Add(budget)
+	}
+	return handler(context.WithValue(ctx, rpcBudgetKey{}, rb), req)
+}
+
+// Limit implements db.QueryLimiter. It bounds the query by the earlier of its
+// RPC's deadline and its table's budget, and reports which budget was exceeded
+// if the query is canceled as a result.
+func (qb *QueryBudgets) Limit(ctx context.Context, table string) (context.Context, func(error) error) {
+	rb, _ := ctx.Value(rpcBudgetKey{}).(rpcBudget)
+	deadline := rb.deadline
+	kind, budget := "rpc", rb.budget
+	tableBudget, ok := qb.tables[table]
+	if ok && tableBudget > 0 {
+		tableDeadline := time.Now().Add(tableBudget)
+		if deadline.IsZero() || tableDeadline.Before(deadline) {
+			deadline = tableDeadline
+			kind, budget = "table", tableBudget
+		}
+	}
+	if deadline.IsZero() {
+		return ctx, func(err error) error { return err }
+	}
+
+	queryCtx, cancel := context.WithDeadline(ctx, deadline)
+	return queryCtx, func(err error) error {
+		defer cancel()
+		// Only attribute the failure to the budget if it was the budget's
+		// deadline, and not the caller's, which canceled the query.
+		if err == nil || queryCtx.Err() == nil || ctx.Err() != nil {
+			return err
+		}
+		rpc := rb.rpc
+		if rpc == "" {
+			rpc = "unknown"
+		}
+		qb.exhausted.WithLabelValues(rpc, table, kind).Inc()
+		return fmt.Errorf("query against %q exceeded %s budget of %s: %w", table, kind, budget, err)
+	}
+}
@@ -0,0 +1,92 @@
+package sa
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// slowQuery simulates a query which runs until its context is canceled.
+func slowQuery(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestQueryBudgets(t *testing.T) {
+	t.Parallel()
+
+	qb := NewQueryBudgets(QueryBudgetConfig{
+		Default: config.Duration{Duration: time.Hour},
+		RPCs: map[string]config.Duration{
+			"GetRegistration": {Duration: 10 * time.Millisecond},
+		},
+		Tables: map[string]config.Duration{
+			"certificateStatus": {Duration: 10 * time.Millisecond},
+		},
+	}, metrics.NoopRegisterer)
+
+	run := func(method, table string, query func(context.Context) error) error {
+		t.Helper()
+		_, err := qb.Unary(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method},
+			func(ctx context.Context, _ any) (any, error) {
+				queryCtx, done := qb.Limit(ctx, table)
+				return nil, done(query(queryCtx))
+			})
+		return err
+	}
+
+	// The RPC budget applies to queries against any table.
+	err := run("/sa.StorageAuthorityReadOnly/GetRegistration", "registrations", slowQuery)
+	test.AssertErrorIs(t, err, context.DeadlineExceeded)
+	test.AssertContains(t, err.Error(), `query against "registrations" exceeded rpc budget of 10ms`)
+	test.AssertMetricWithLabelsEquals(t, qb.exhausted, prometheus.Labels{"rpc": "GetRegistration", "table": "registrations", "budget": "rpc"}, 1)
+
+	// The table budget applies when it's tighter than the RPC's.
+	err = run("/sa.StorageAuthorityReadOnly/GetCertificateStatus", "certificateStatus", slowQuery)
+	test.AssertErrorIs(t, err, context.DeadlineExceeded)
+	test.AssertContains(t, err.Error(), "exceeded table budget of 10ms")
+	test.AssertMetricWithLabelsEquals(t, qb.exhausted, prometheus.Labels{"rpc": "GetCertificateStatus", "table": "certificateStatus", "budget": "table"}, 1)
+
+	// Queries which complete within budget, or fail for other reasons, are
+	// unaffected.
+	err = run("/sa.StorageAuthorityReadOnly/GetCertificateStatus", "certificateStatus", func(context.Context) error { return nil })
+	test.AssertNotError(t, err, "fast query")
+	err = run("/sa.StorageAuthorityReadOnly/GetOrder", "orders", func(ctx context.Context) error {
+		deadline, ok := ctx.Deadline()
+		test.Assert(t, ok, "query should have the default deadline")
+		test.Assert(t, time.Until(deadline) > 10*time.Minute, "default deadline should be about an hour away")
+		return context.Canceled
+	})
+	test.AssertErrorIs(t, err, context.Canceled)
+	test.AssertNotContains(t, err.Error(), "budget")
+}
+
+func TestQueryBudgetsCallerCanceled(t *testing.T) {
+	t.Parallel()
+
+	qb := NewQueryBudgets(QueryBudgetConfig{
+		Tables: map[string]config.Duration{"orders": {Duration: time.Hour}},
+	}, metrics.NoopRegisterer)
+
+	// Queries made outside an RPC, with no budget, are not limited.
+	ctx, done := qb.Limit(context.Background(), "registrations")
+	_, ok := ctx.Deadline()
+	test.Assert(t, !ok, "query without a budget should have no deadline")
+	test.AssertNotError(t, done(nil), "done")
+
+	// A query canceled by its caller isn't counted against its budget.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	queryCtx, done := qb.Limit(ctx, "orders")
+	err := done(slowQuery(queryCtx))
+	test.AssertErrorIs(t, err, context.Canceled)
+	test.AssertNotContains(t, err.Error(), "budget")
+	test.AssertMetricWithLabelsEquals(t, qb.exhausted, prometheus.Labels{"table": "orders"}, 0)
+}
@@ -0,0 +1,190 @@
+package sa
+
+import (
+	"context"
+	"crypto/x509"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/db"
+	"github.com/letsencrypt/boulder/features"
+	blog "github.com/letsencrypt/boulder/log"
+)
+
+// CertScrubConfig configures a CertScrubber.
+type CertScrubConfig struct {
+	// BatchSize is the maximum number of rows of each table read by each
+	// tick. If zero, a default of 100 is used.
+	BatchSize int `validate:"omitempty,min=1,max=10000"`
+
+	// CheckInterval is how often the scrubber reads a batch. If zero, a
+	// default of ten seconds is used.
+	CheckInterval config.Duration `validate:"-"`
+}
+
+// scrubbedTables are the tables read by the CertScrubber.
+var scrubbedTables = []string{"certificates", "precertificates"}
+
+// CertScrubber repeatedly reads every row of the certificates and
+// precertificates tables, in batches, and checks that the DER of each still
+// matches its stored SHA-256 digest, still parses, and still has the serial the
+// row is indexed by. Corrupt rows are audit logged and counted, so that silent
+// storage corruption can be alerted on before it's served to anyone.
+//
+// Precertificates only have a digest if they were stored with the
+// StorePrecertificateDigests feature enabled. Those without one are only checked
+// for parseability and their serial.
+type CertScrubber struct {
+	dbMap         db.Selector
+	batchSize     int
+	checkInterval time.Duration
+	clk           clock.Clock
+	log           blog.Logger
+
+	// cursors holds, for each table, the highest ID scrubbed by the current
+	// pass.
+	cursors map[string]int64
+
+	rows       *prometheus.CounterVec
+	corrupt    *prometheus.CounterVec
+	passesDone *prometheus.GaugeVec
+}
+
+// NewCertScrubber returns a CertScrubber for the given config, which reads the
+// database behind dbMap.
+func NewCertScrubber(c CertScrubConfig, dbMap db.Selector, clk clock.Clock, stats prometheus.Registerer, logger blog.Logger) (*CertScrubber, error) {
+	if c.CheckInterval.Duration < 0 {
+		return nil, fmt.Errorf("cert scrub check interval %s is negative", c.CheckInterval.Duration)
+	}
+	batchSize := c.BatchSize
+	if batchSize == 0 {
+		batchSize = 100
+	}
+	checkInterval := c.CheckInterval.Duration
+	if checkInterval == 0 {
+		checkInterval = 10 * time.Second
+	}
+
+	s := &CertScrubber{
+		dbMap:         dbMap,
+		batchSize:     batchSize,
+		checkInterval: checkInterval,
+		clk:           clk,
+		log:           logger,
+		cursors:       make(map[string]int64),
+		rows: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cert_scrub_rows",
+			Help: "number of certificate rows checked by the scrubber, by table",
+		}, []string{"table"}),
+		corrupt: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cert_scrub_corrupt_rows",
+			Help: "number of corrupt certificate rows found by the scrubber, by table and problem",
+		}, []string{"table", "problem"}),
+		passesDone: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cert_scrub_pass_completed_timestamp_seconds",
+			Help: "when the scrubber last finished checking every row of a table",
+		}, []string{"table"}),
+	}
+	stats.MustRegister(s.rows, s.corrupt, s.passesDone)
+	return s, nil
+}
+
+// Run scrubs a batch of each table every CheckInterval until ctx is canceled.
+func (s *CertScrubber) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.checkInterval)
+	defer ticker.Stop()
+	for {
+		err := s.Tick(ctx)
+		if err != nil {
+			s.log.Errf("scrubbing certificates: %s", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// scrubbedCert is a row read by the CertScrubber.
+type scrubbedCert struct {
+	ID     int64          `db:"id"`
+	Serial string         `db:"serial"`
+	Digest sql.NullString `db:"digest"`
+	DER    []byte         `db:"der"`
+}
+
+// Tick checks the next batch of rows of each table. When a batch reaches the
+// end of a table, the next pass over that table starts from its beginning.
+func (s *CertScrubber) Tick(ctx context.Context) error {
+	for _, table := range scrubbedTables {
+		err := s.scrubBatch(ctx, table)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *CertScrubber) scrubBatch(ctx context.Context, table string) error {
+	digest := "digest"
+	if table == "precertificates" && !features.Get().StorePrecertificateDigests {
+		digest = "NULL AS digest"
+	}
+
+	var batch []scrubbedCert
+	_, err := s.dbMap.Select(ctx, &batch, fmt.Sprintf(
+		`SELECT id, serial, %s, der FROM %s
+		WHERE id > ?
+		ORDER BY id
+		LIMIT ?`, digest, table),
+		s.cursors[table], s.batchSize,
+	)
+	if err != nil {
+		return fmt.Errorf("selecting %s: %w", table, err)
+	}
+
+	for _, row := range batch {
+		problem, err := checkScrubbedCert(row)
+		if err != nil {
+			s.corrupt.WithLabelValues(table, problem).Inc()
+			s.log.AuditErrf("Corrupt row found by certificate scrubber: table=[%s] id=[%d] serial=[%s] problem=[%s]: %s",
+				table, row.ID, row.Serial, problem, err)
+		}
+		s.cursors[table] = row.ID
+	}
+	s.rows.WithLabelValues(table).Add(float64(len(batch)))
+
+	if len(batch) < s.batchSize {
+		s.cursors[table] = 0
+		s.passesDone.WithLabelValues(table).Set(float64(s.clk.Now().Unix()))
+	}
+	return nil
+}
+
+// checkScrubbedCert returns an error, and a short description of the problem
+// for use as a metric label, if row's DER doesn't match its digest, can't be
+// parsed, or has a different serial than the row.
+func checkScrubbedCert(row scrubbedCert) (string, error) {
+	if row.Digest.Valid {
+		digest := core.Fingerprint256(row.DER)
+		if digest != row.Digest.String {
+			return "digest", fmt.Errorf("DER has digest %q, but %q was stored", digest, row.Digest.String)
+		}
+	}
+	cert, err := x509.ParseCertificate(row.DER)
+	if err != nil {
+		return "parse", fmt.Errorf("parsing DER: %w", err)
+	}
+	serial := core.SerialToString(cert.SerialNumber)
+	if serial != row.Serial {
+		return "serial", fmt.Errorf("DER has serial %q", serial)
+	}
+	return "", nil
+}
@@ -0,0 +1,134 @@
+package sa
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/features"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// fakeScrubDB answers the CertScrubber's queries from rows by table, which
+// must be in ascending order of ID.
+type fakeScrubDB struct {
+	tables  map[string][]scrubbedCert
+	queries []string
+	err     error
+}
+
+func (f *fakeScrubDB) Select(_ context.Context, holder interface{}, query string, args ...interface{}) ([]interface{}, error) {
+	f.queries = append(f.queries, query)
+	if f.err != nil {
+		return nil, f.err
+	}
+	table := "certificates"
+	if strings.Contains(query, "FROM precertificates") {
+		table = "precertificates"
+	}
+	h := holder.(*[]scrubbedCert)
+	after, limit := args[0].(int64), args[1].(int)
+	for _, row := range f.tables[table] {
+		if row.ID > after && len(*h) < limit {
+			if strings.Contains(query, "NULL AS digest") {
+				row.Digest = sql.NullString{}
+			}
+			*h = append(*h, row)
+		}
+	}
+	return nil, nil
+}
+
+func scrubRow(t *testing.T, id int64) scrubbedCert {
+	t.Helper()
+	_, cert := test.ThrowAwayCert(t, clock.NewFake())
+	return scrubbedCert{
+		ID:     id,
+		Serial: core.SerialToString(cert.SerialNumber),
+		Digest: sql.NullString{String: core.Fingerprint256(cert.Raw), Valid: true},
+		DER:    cert.Raw,
+	}
+}
+
+func TestCertScrubber(t *testing.T) {
+	features.Set(features.Config{StorePrecertificateDigests: true})
+	defer features.Reset()
+
+	flipped := scrubRow(t, 2)
+	flipped.DER = append([]byte(nil), flipped.DER...)
+	flipped.DER[len(flipped.DER)-1] ^= 1
+
+	truncated := scrubRow(t, 3)
+	truncated.DER = truncated.DER[:len(truncated.DER)/2]
+	truncated.Digest = sql.NullString{String: core.Fingerprint256(truncated.DER), Valid: true}
+
+	misfiled := scrubRow(t, 5)
+	misfiled.Serial = "00000000000000000000000000000000000a"
+
+	fake := &fakeScrubDB{tables: map[string][]scrubbedCert{
+		"certificates":    {scrubRow(t, 1), flipped, truncated, scrubRow(t, 4), misfiled},
+		"precertificates": {scrubRow(t, 7)},
+	}}
+	clk := clock.NewFake()
+	log := blog.NewMock()
+	s, err := NewCertScrubber(CertScrubConfig{BatchSize: 3}, fake, clk, metrics.NoopRegisterer, log)
+	test.AssertNotError(t, err, "NewCertScrubber failed")
+
+	// The first tick reads the first batch of certificates, and every
+	// precertificate.
+	err = s.Tick(context.Background())
+	test.AssertNotError(t, err, "Tick failed")
+	test.AssertEquals(t, s.cursors["certificates"], int64(3))
+	test.AssertEquals(t, s.cursors["precertificates"], int64(0))
+	test.AssertMetricWithLabelsEquals(t, s.rows, prometheus.Labels{"table": "certificates"}, 3)
+	test.AssertMetricWithLabelsEquals(t, s.corrupt, prometheus.Labels{"table": "certificates", "problem": "digest"}, 1)
+	test.AssertMetricWithLabelsEquals(t, s.corrupt, prometheus.Labels{"table": "certificates", "problem": "parse"}, 1)
+	test.AssertMetricWithLabelsEquals(t, s.passesDone, prometheus.Labels{"table": "precertificates"}, float64(clk.Now().Unix()))
+	test.AssertEquals(t, len(log.GetAllMatching(`Corrupt row found by certificate scrubber: table=\[certificates\] id=\[2\] .* problem=\[digest\]`)), 1)
+	test.AssertEquals(t, len(log.GetAllMatching(`Corrupt row found by certificate scrubber: table=\[certificates\] id=\[3\] .* problem=\[parse\]`)), 1)
+
+	// The second tick finishes the pass over certificates, so the third
+	// starts another.
+	err = s.Tick(context.Background())
+	test.AssertNotError(t, err, "Tick failed")
+	test.AssertEquals(t, s.cursors["certificates"], int64(0))
+	test.AssertMetricWithLabelsEquals(t, s.corrupt, prometheus.Labels{"table": "certificates", "problem": "serial"}, 1)
+	test.AssertEquals(t, len(log.GetAllMatching(`id=\[5\] serial=\[00000000000000000000000000000000000a\] problem=\[serial\]`)), 1)
+
+	err = s.Tick(context.Background())
+	test.AssertNotError(t, err, "Tick failed")
+	test.AssertMetricWithLabelsEquals(t, s.rows, prometheus.Labels{"table": "certificates"}, 8)
+	test.AssertMetricWithLabelsEquals(t, s.corrupt, prometheus.Labels{"table": "certificates", "problem": "digest"}, 2)
+}
+
+func TestCertScrubberWithoutPrecertificateDigests(t *testing.T) {
+	precert := scrubRow(t, 1)
+	precert.DER = append([]byte(nil), precert.DER...)
+	precert.DER[len(precert.DER)-1] ^= 1
+
+	fake := &fakeScrubDB{tables: map[string][]scrubbedCert{
+		"precertificates": {precert},
+	}}
+	s, err := NewCertScrubber(CertScrubConfig{}, fake, clock.NewFake(), metrics.NoopRegisterer, blog.NewMock())
+	test.AssertNotError(t, err, "NewCertScrubber failed")
+
+	// Without digests, a change to the signature can't be detected.
+	err = s.Tick(context.Background())
+	test.AssertNotError(t, err, "Tick failed")
+	test.AssertContains(t, fake.queries[1], "NULL AS digest")
+	test.AssertMetricWithLabelsEquals(t, s.rows, prometheus.Labels{"table": "precertificates"}, 1)
+	test.AssertMetricWithLabelsEquals(t, s.corrupt, prometheus.Labels{"table": "precertificates"}, 0)
+
+	fake.err = errors.New("connection refused")
+	err = s.Tick(context.Background())
+	test.AssertError(t, err, "Tick should have failed")
+	test.AssertContains(t, err.Error(), "connection refused")
+}
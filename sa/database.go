@@ -276,13 +276,19 @@ func initTables(dbMap *borp.DbMap) {
 	if !features.Get().StoreARIReplacesInOrders {
 		tableMap.ColMap("Replaces").SetTransient(true)
 	}
+	if !features.Get().IdempotentFinalize {
+		tableMap.ColMap("CSRHash").SetTransient(true)
+	}
 
 	dbMap.AddTableWithName(orderToAuthzModel{}, "orderToAuthz").SetKeys(false, "OrderID", "AuthzID")
 	dbMap.AddTableWithName(orderFQDNSet{}, "orderFqdnSets").SetKeys(true, "ID")
 	dbMap.AddTableWithName(authzModel{}, "authz2").SetKeys(true, "ID")
 	dbMap.AddTableWithName(orderToAuthzModel{}, "orderToAuthz2").SetKeys(false, "OrderID", "AuthzID")
 	dbMap.AddTableWithName(recordedSerialModel{}, "serials").SetKeys(true, "ID")
-	dbMap.AddTableWithName(lintingCertModel{}, "precertificates").SetKeys(true, "ID")
+	precertTable := dbMap.AddTableWithName(lintingCertModel{}, "precertificates").SetKeys(true, "ID")
+	if !features.Get().StorePrecertificateDigests {
+		precertTable.ColMap("Digest").SetTransient(true)
+	}
 	dbMap.AddTableWithName(keyHashModel{}, "keyHashToSerial").SetKeys(true, "ID")
 	dbMap.AddTableWithName(incidentModel{}, "incidents").SetKeys(true, "ID")
 	dbMap.AddTable(incidentSerialModel{})
@@ -291,6 +297,12 @@ func initTables(dbMap *borp.DbMap) {
 	dbMap.AddTableWithName(replacementOrderModel{}, "replacementOrders").SetKeys(true, "ID")
 	dbMap.AddTableWithName(pausedModel{}, "paused")
 	dbMap.AddTableWithName(overrideModel{}, "overrides").SetKeys(false, "limitEnum", "bucketKey")
+	dbMap.AddTableWithName(contactVerificationModel{}, "contactVerifications").SetKeys(false, "contactHash")
+	dbMap.AddTableWithName(clientIdentityModel{}, "registrationClientIdentities").SetKeys(false, "registrationID")
+	dbMap.AddTableWithName(accountRevocationModel{}, "accountRevocations").SetKeys(false, "registrationID")
+	dbMap.AddTableWithName(exemptionRequestModel{}, "exemptionRequests").SetKeys(true, "ID")
+	dbMap.AddTableWithName(validationFailuresModel{}, "validationFailures").SetKeys(false, "authzID")
+	dbMap.AddTableWithName(authzCAARecheckModel{}, "authzCAARechecks").SetKeys(false, "authzID")
 
 	// Read-only maps used for selecting subsets of columns.
 	dbMap.AddTableWithName(CertStatusMetadata{}, "certificateStatus")
@@ -0,0 +1,15 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+CREATE TABLE `contactVerifications` (
+  `contactHash` binary(32) NOT NULL,
+  `status` tinyint(4) NOT NULL,
+  `reason` varchar(255) NOT NULL,
+  `updatedAt` datetime NOT NULL,
+  PRIMARY KEY (`contactHash`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+DROP TABLE IF EXISTS `contactVerifications`;
@@ -0,0 +1,14 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+CREATE TABLE `registrationClientIdentities` (
+  `registrationID` bigint(20) UNSIGNED NOT NULL,
+  `identity` varchar(255) NOT NULL,
+  `createdAt` datetime NOT NULL,
+  PRIMARY KEY (`registrationID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+DROP TABLE IF EXISTS `registrationClientIdentities`;
@@ -0,0 +1,24 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+CREATE TABLE `pendingAuthzCounts` (
+  `registrationID` bigint(20) NOT NULL,
+  `count` bigint(20) NOT NULL,
+  PRIMARY KEY (`registrationID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE `invalidAuthzCounts` (
+  `registrationID` bigint(20) NOT NULL,
+  `identifierType` tinyint(4) NOT NULL,
+  `identifierValue` varchar(255) NOT NULL,
+  `expiresHour` datetime NOT NULL,
+  `count` bigint(20) NOT NULL,
+  PRIMARY KEY (`registrationID`,`identifierType`,`identifierValue`,`expiresHour`),
+  KEY `expiresHour_idx` (`expiresHour`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+DROP TABLE IF EXISTS `pendingAuthzCounts`;
+DROP TABLE IF EXISTS `invalidAuthzCounts`;
@@ -0,0 +1,19 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+CREATE TABLE `accountRevocations` (
+  `registrationID` bigint(20) UNSIGNED NOT NULL,
+  `requested` datetime NOT NULL,
+  `total` bigint(20) NOT NULL,
+  `revoked` bigint(20) NOT NULL,
+  `failed` bigint(20) NOT NULL,
+  `completed` datetime DEFAULT NULL,
+  `leasedUntil` datetime DEFAULT NULL,
+  PRIMARY KEY (`registrationID`),
+  KEY `completed_requested_idx` (`completed`, `requested`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+DROP TABLE IF EXISTS `accountRevocations`;
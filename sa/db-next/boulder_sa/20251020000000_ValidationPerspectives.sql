@@ -0,0 +1,18 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+CREATE TABLE `validationPerspectives` (
+  `authzID` bigint(20) UNSIGNED NOT NULL,
+  `attemptedAt` datetime NOT NULL,
+  `perspective` varchar(255) NOT NULL,
+  `rir` varchar(32) NOT NULL,
+  `passed` tinyint(1) NOT NULL,
+  `latencyMS` bigint(20) NOT NULL,
+  PRIMARY KEY (`authzID`, `attemptedAt`, `perspective`),
+  KEY `attemptedAt_idx` (`attemptedAt`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+DROP TABLE IF EXISTS `validationPerspectives`;
@@ -0,0 +1,26 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+CREATE TABLE `exemptionRequests` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT,
+  `registrationID` bigint(20) UNSIGNED NOT NULL,
+  `limitEnum` tinyint(4) UNSIGNED NOT NULL,
+  `bucketKey` varchar(255) NOT NULL,
+  `periodNS` bigint(20) UNSIGNED NOT NULL,
+  `count` int UNSIGNED NOT NULL,
+  `burst` int UNSIGNED NOT NULL,
+  `justification` text NOT NULL,
+  `status` tinyint(4) NOT NULL,
+  `createdAt` datetime NOT NULL,
+  `reviewedAt` datetime DEFAULT NULL,
+  `reviewer` varchar(255) DEFAULT NULL,
+  `reviewComment` varchar(255) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `status_createdAt_idx` (`status`, `createdAt`),
+  KEY `registrationID_status_idx` (`registrationID`, `status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+DROP TABLE IF EXISTS `exemptionRequests`;
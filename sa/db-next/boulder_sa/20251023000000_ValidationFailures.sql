@@ -0,0 +1,16 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+CREATE TABLE `validationFailures` (
+  `authzID` bigint(20) UNSIGNED NOT NULL,
+  `count` int UNSIGNED NOT NULL,
+  `lastFailure` datetime NOT NULL,
+  `lastChallenge` tinyint(4) NOT NULL,
+  `lastProblem` mediumblob NOT NULL,
+  PRIMARY KEY (`authzID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+DROP TABLE IF EXISTS `validationFailures`;
@@ -0,0 +1,13 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+CREATE TABLE `authzCAARechecks` (
+  `authzID` bigint(20) UNSIGNED NOT NULL,
+  `rechecked` datetime NOT NULL,
+  PRIMARY KEY (`authzID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+DROP TABLE IF EXISTS `authzCAARechecks`;
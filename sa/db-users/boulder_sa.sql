@@ -33,6 +33,15 @@ GRANT SELECT,INSERT,UPDATE ON crlShards TO 'sa'@'localhost';
 GRANT SELECT,INSERT,UPDATE ON revokedCertificates TO 'sa'@'localhost';
 GRANT SELECT,INSERT,UPDATE ON replacementOrders TO 'sa'@'localhost';
 GRANT SELECT,INSERT,UPDATE ON overrides TO 'sa'@'localhost';
+GRANT SELECT,INSERT,UPDATE ON contactVerifications TO 'sa'@'localhost';
+GRANT SELECT,INSERT ON registrationClientIdentities TO 'sa'@'localhost';
+GRANT SELECT,INSERT,UPDATE ON pendingAuthzCounts TO 'sa'@'localhost';
+GRANT SELECT,INSERT,UPDATE,DELETE ON invalidAuthzCounts TO 'sa'@'localhost';
+GRANT SELECT,INSERT,UPDATE ON accountRevocations TO 'sa'@'localhost';
+GRANT SELECT,INSERT ON validationPerspectives TO 'sa'@'localhost';
+GRANT SELECT,INSERT,UPDATE ON exemptionRequests TO 'sa'@'localhost';
+GRANT SELECT,INSERT,UPDATE ON validationFailures TO 'sa'@'localhost';
+GRANT SELECT,INSERT,UPDATE ON authzCAARechecks TO 'sa'@'localhost';
 -- Tests need to be able to TRUNCATE this table, so DROP is necessary.
 GRANT SELECT,INSERT,UPDATE,DROP ON paused TO 'sa'@'localhost';
 
@@ -55,6 +64,15 @@ GRANT SELECT ON revokedCertificates TO 'sa_ro'@'localhost';
 GRANT SELECT ON replacementOrders TO 'sa_ro'@'localhost';
 GRANT SELECT ON paused TO 'sa_ro'@'localhost';
 GRANT SELECT ON overrides TO 'sa_ro'@'localhost';
+GRANT SELECT ON contactVerifications TO 'sa_ro'@'localhost';
+GRANT SELECT ON registrationClientIdentities TO 'sa_ro'@'localhost';
+GRANT SELECT ON pendingAuthzCounts TO 'sa_ro'@'localhost';
+GRANT SELECT ON invalidAuthzCounts TO 'sa_ro'@'localhost';
+GRANT SELECT ON accountRevocations TO 'sa_ro'@'localhost';
+GRANT SELECT ON validationPerspectives TO 'sa_ro'@'localhost';
+GRANT SELECT ON exemptionRequests TO 'sa_ro'@'localhost';
+GRANT SELECT ON validationFailures TO 'sa_ro'@'localhost';
+GRANT SELECT ON authzCAARechecks TO 'sa_ro'@'localhost';
 
 -- OCSP Responder
 GRANT SELECT ON certificateStatus TO 'ocsp_resp'@'localhost';
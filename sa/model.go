@@ -196,6 +196,47 @@ func SelectRevocationStatus(ctx context.Context, s db.OneSelector, serial string
 	}, nil
 }
 
+// SelectRevocationStatuses returns the authoritative revocation information
+// for each of the certificates with the given serials, keyed by serial.
+// Serials which are not found are omitted from the result.
+func SelectRevocationStatuses(ctx context.Context, s db.Selector, serials []string) (map[string]*sapb.RevocationStatus, error) {
+	if len(serials) == 0 {
+		return map[string]*sapb.RevocationStatus{}, nil
+	}
+	var params []interface{}
+	for _, serial := range serials {
+		params = append(params, serial)
+	}
+	var models []struct {
+		Serial string `db:"serial"`
+		RevocationStatusModel
+	}
+	_, err := s.Select(
+		ctx,
+		&models,
+		fmt.Sprintf("SELECT serial, status, revokedDate, revokedReason FROM certificateStatus WHERE serial IN (%s)",
+			db.QuestionMarks(len(serials))),
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[string]*sapb.RevocationStatus, len(models))
+	for _, model := range models {
+		statusInt, ok := core.OCSPStatusToInt[model.Status]
+		if !ok {
+			return nil, fmt.Errorf("got unrecognized status %q for serial %q", model.Status, model.Serial)
+		}
+		statuses[model.Serial] = &sapb.RevocationStatus{
+			Status:        int64(statusInt),
+			RevokedDate:   timestamppb.New(model.RevokedDate),
+			RevokedReason: int64(model.RevokedReason),
+		}
+	}
+	return statuses, nil
+}
+
 var mediumBlobSize = int(math.Pow(2, 24))
 
 type issuedNameModel struct {
@@ -215,6 +256,15 @@ type regModel struct {
 	Status    string    `db:"status"`
 }
 
+// clientIdentityModel represents one row in the registrationClientIdentities
+// table, which binds a registration to the identity in the TLS client
+// certificate with which it was created.
+type clientIdentityModel struct {
+	RegistrationID int64     `db:"registrationID"`
+	Identity       string    `db:"identity"`
+	CreatedAt      time.Time `db:"createdAt"`
+}
+
 func registrationPbToModel(reg *corepb.Registration) (*regModel, error) {
 	// Even though we don't need to convert from JSON to an in-memory JSONWebKey
 	// for the sake of the `Key` field, we do need to do the conversion in order
@@ -266,6 +316,15 @@ type recordedSerialModel struct {
 	Expires        time.Time
 }
 
+func newPBFromRecordedSerialModel(model *recordedSerialModel) *sapb.SerialMetadata {
+	return &sapb.SerialMetadata{
+		Serial:         model.Serial,
+		RegistrationID: model.RegistrationID,
+		Created:        timestamppb.New(model.Created),
+		Expires:        timestamppb.New(model.Expires),
+	}
+}
+
 type lintingCertModel struct {
 	ID             int64
 	Serial         string
@@ -273,6 +332,9 @@ type lintingCertModel struct {
 	DER            []byte
 	Issued         time.Time
 	Expires        time.Time
+	// Digest is only stored if the StorePrecertificateDigests feature is
+	// enabled.
+	Digest string
 }
 
 func (model lintingCertModel) toPb() *corepb.Certificate {
@@ -346,6 +408,8 @@ type orderModel struct {
 	BeganProcessing        bool
 	CertificateProfileName *string
 	Replaces               *string
+	// CSRHash is only stored if the IdempotentFinalize feature is enabled.
+	CSRHash []byte
 }
 
 type orderToAuthzModel struct {
@@ -400,6 +464,7 @@ func modelToOrder(om *orderModel) (*corepb.Order, error) {
 		BeganProcessing:        om.BeganProcessing,
 		CertificateProfileName: profile,
 		Replaces:               replaces,
+		CsrHash:                om.CSRHash,
 	}
 	if len(om.Error) > 0 {
 		var problem corepb.ProblemDetails
@@ -416,25 +481,29 @@ func modelToOrder(om *orderModel) (*corepb.Order, error) {
 }
 
 var challTypeToUint = map[string]uint8{
-	"http-01":     0,
-	"dns-01":      1,
-	"tls-alpn-01": 2,
+	"http-01":        0,
+	"dns-01":         1,
+	"tls-alpn-01":    2,
+	"email-reply-00": 3,
 }
 
 var uintToChallType = map[uint8]string{
 	0: "http-01",
 	1: "dns-01",
 	2: "tls-alpn-01",
+	3: "email-reply-00",
 }
 
 var identifierTypeToUint = map[string]uint8{
-	"dns": 0,
-	"ip":  1,
+	"dns":   0,
+	"ip":    1,
+	"email": 2,
 }
 
 var uintToIdentifierType = map[uint8]identifier.IdentifierType{
 	0: "dns",
 	1: "ip",
+	2: "email",
 }
 
 var statusToUint = map[core.AcmeStatus]uint8{
@@ -443,6 +512,7 @@ var statusToUint = map[core.AcmeStatus]uint8{
 	core.StatusInvalid:     2,
 	core.StatusDeactivated: 3,
 	core.StatusRevoked:     4,
+	core.StatusExpired:     5,
 }
 
 var uintToStatus = map[uint8]core.AcmeStatus{
@@ -451,6 +521,7 @@ var uintToStatus = map[uint8]core.AcmeStatus{
 	2: core.StatusInvalid,
 	3: core.StatusDeactivated,
 	4: core.StatusRevoked,
+	5: core.StatusExpired,
 }
 
 func statusUint(status core.AcmeStatus) uint8 {
@@ -1103,6 +1174,8 @@ func statusForOrder(order *corepb.Order, authzValidityInfo []authzValidity, now
 			otherAuthzs++
 		case core.StatusRevoked:
 			otherAuthzs++
+		case core.StatusExpired:
+			expiredAuthzs++
 		default:
 			return "", berrors.InternalServerError(
 				"Order is in an invalid state. Authz has invalid status %d",
@@ -1440,3 +1513,173 @@ func newPBFromOverrideModel(m *overrideModel) *sapb.RateLimitOverride {
 		Burst:     m.Burst,
 	}
 }
+
+// contactVerificationModel represents one row in the contactVerifications
+// table.
+type contactVerificationModel struct {
+	ContactHash []byte    `db:"contactHash"`
+	Status      uint8     `db:"status"`
+	Reason      string    `db:"reason"`
+	UpdatedAt   time.Time `db:"updatedAt"`
+}
+
+// contactVerificationStatuses are the statuses a contact verification may
+// have.
+var contactVerificationStatuses = []core.AcmeStatus{core.StatusPending, core.StatusValid, core.StatusInvalid}
+
+// accountRevocationModel represents one row in the accountRevocations table,
+// which tracks the revocation of a deactivated account's certificates.
+type accountRevocationModel struct {
+	RegistrationID int64      `db:"registrationID"`
+	Requested      time.Time  `db:"requested"`
+	Total          int64      `db:"total"`
+	Revoked        int64      `db:"revoked"`
+	Failed         int64      `db:"failed"`
+	Completed      *time.Time `db:"completed"`
+	// LeasedUntil is set while an RA is revoking the account's certificates.
+	LeasedUntil *time.Time `db:"leasedUntil"`
+}
+
+func newPBFromAccountRevocationModel(m *accountRevocationModel) *sapb.AccountRevocation {
+	pb := &sapb.AccountRevocation{
+		RegistrationID: m.RegistrationID,
+		Requested:      timestamppb.New(m.Requested),
+		Total:          m.Total,
+		Revoked:        m.Revoked,
+		Failed:         m.Failed,
+	}
+	if m.Completed != nil {
+		pb.Completed = timestamppb.New(*m.Completed)
+	}
+	return pb
+}
+
+// perspectiveStatsModel is a row of aggregate statistics about one network
+// perspective, computed from the validationPerspectives table.
+type perspectiveStatsModel struct {
+	Perspective   string  `db:"perspective"`
+	RIR           string  `db:"rir"`
+	Attempts      int64   `db:"attempts"`
+	Passed        int64   `db:"passed"`
+	MeanLatencyMS float64 `db:"meanLatencyMS"`
+	MaxLatencyMS  int64   `db:"maxLatencyMS"`
+}
+
+func newPBFromPerspectiveStatsModel(m *perspectiveStatsModel) *sapb.PerspectiveStats {
+	return &sapb.PerspectiveStats{
+		Perspective: m.Perspective,
+		Rir:         m.RIR,
+		Attempts:    m.Attempts,
+		Passed:      m.Passed,
+		MeanLatency: durationpb.New(time.Duration(m.MeanLatencyMS * float64(time.Millisecond))),
+		MaxLatency:  durationpb.New(time.Duration(m.MaxLatencyMS) * time.Millisecond),
+	}
+}
+
+func newPBFromContactVerificationModel(m *contactVerificationModel) *sapb.ContactVerification {
+	return &sapb.ContactVerification{
+		ContactHash: m.ContactHash,
+		Status:      string(uintToStatus[m.Status]),
+		Reason:      m.Reason,
+		Updated:     timestamppb.New(m.UpdatedAt),
+	}
+}
+
+// exemptionRequestModel represents one row in the exemptionRequests table,
+// which queues subscribers' requests for rate limit overrides for review.
+type exemptionRequestModel struct {
+	ID             int64      `db:"id"`
+	RegistrationID int64      `db:"registrationID"`
+	LimitEnum      int64      `db:"limitEnum"`
+	BucketKey      string     `db:"bucketKey"`
+	PeriodNS       int64      `db:"periodNS"`
+	Count          int64      `db:"count"`
+	Burst          int64      `db:"burst"`
+	Justification  string     `db:"justification"`
+	Status         uint8      `db:"status"`
+	CreatedAt      time.Time  `db:"createdAt"`
+	ReviewedAt     *time.Time `db:"reviewedAt"`
+	Reviewer       *string    `db:"reviewer"`
+	ReviewComment  *string    `db:"reviewComment"`
+}
+
+// exemptionRequestStatuses are the statuses an exemption request may have,
+// indexed by their value in the status column.
+var exemptionRequestStatuses = []string{"pending", "approved", "rejected"}
+
+// exemptionRequestStatusUint returns the status column value of the given
+// exemption request status.
+func exemptionRequestStatusUint(status string) (uint8, error) {
+	for i, s := range exemptionRequestStatuses {
+		if s == status {
+			return uint8(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown exemption request status %q", status)
+}
+
+func newPBFromExemptionRequestModel(m *exemptionRequestModel) *sapb.ExemptionRequest {
+	pb := &sapb.ExemptionRequest{
+		Id:             m.ID,
+		RegistrationID: m.RegistrationID,
+		Override: &sapb.RateLimitOverride{
+			LimitEnum: m.LimitEnum,
+			BucketKey: m.BucketKey,
+			Period:    durationpb.New(time.Duration(m.PeriodNS)),
+			Count:     m.Count,
+			Burst:     m.Burst,
+		},
+		Justification: m.Justification,
+		Created:       timestamppb.New(m.CreatedAt),
+	}
+	if int(m.Status) < len(exemptionRequestStatuses) {
+		pb.Status = exemptionRequestStatuses[m.Status]
+	}
+	if m.ReviewedAt != nil {
+		pb.Reviewed = timestamppb.New(*m.ReviewedAt)
+	}
+	if m.Reviewer != nil {
+		pb.Reviewer = *m.Reviewer
+	}
+	if m.ReviewComment != nil {
+		pb.ReviewComment = *m.ReviewComment
+	}
+	return pb
+}
+
+// validationFailuresModel represents one row in the validationFailures table,
+// which counts the failed validation attempts of a pending authorization and
+// holds the problem the last of them failed with.
+type validationFailuresModel struct {
+	AuthzID       int64     `db:"authzID"`
+	Count         int64     `db:"count"`
+	LastFailure   time.Time `db:"lastFailure"`
+	LastChallenge uint8     `db:"lastChallenge"`
+	LastProblem   []byte    `db:"lastProblem"`
+}
+
+// authzCAARecheckModel represents one row in the authzCAARechecks table, which
+// holds the time of a valid authorization's most recent successful
+// background CAA recheck.
+type authzCAARecheckModel struct {
+	AuthzID   int64     `db:"authzID"`
+	Rechecked time.Time `db:"rechecked"`
+}
+
+func newPBFromValidationFailuresModel(m *validationFailuresModel) (*sapb.ValidationFailures, error) {
+	var prob probs.ProblemDetails
+	err := json.Unmarshal(m.LastProblem, &prob)
+	if err != nil {
+		return nil, badJSONError("failed to unmarshal validation failure's problem", m.LastProblem, err)
+	}
+	lastProblem, err := grpc.ProblemDetailsToPB(&prob)
+	if err != nil {
+		return nil, err
+	}
+	return &sapb.ValidationFailures{
+		Count:         m.Count,
+		LastFailure:   timestamppb.New(m.LastFailure),
+		LastChallenge: uintToChallType[m.LastChallenge],
+		LastProblem:   lastProblem,
+	}, nil
+}
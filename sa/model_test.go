@@ -478,3 +478,23 @@ func TestSetReplacementOrderFinalized(t *testing.T) {
 	test.AssertNotError(t, err, "SELECT from replacementOrders failed")
 	test.Assert(t, replacementRow.Replaced, "replacement order should be marked as finalized")
 }
+
+func TestStatusForOrderSweptAuthz(t *testing.T) {
+	now := time.Now()
+	order := &corepb.Order{
+		Id:               1,
+		Expires:          timestamppb.New(now.Add(time.Hour)),
+		Identifiers:      []*corepb.Identifier{identifier.NewDNS("example.com").ToProto(), identifier.NewDNS("example.net").ToProto()},
+		V2Authorizations: []int64{1, 2},
+	}
+
+	// An authorization swept by the OrderSweeper makes its order invalid,
+	// whatever its recorded expiry.
+	status, err := statusForOrder(order, []authzValidity{
+		{Status: statusUint(core.StatusValid), Expires: now.Add(time.Hour)},
+		{Status: statusUint(core.StatusExpired), Expires: now.Add(time.Hour)},
+	}, now)
+	test.AssertNotError(t, err, "statusForOrder failed")
+	test.AssertEquals(t, status, string(core.StatusInvalid))
+	test.AssertEquals(t, uintToStatus[statusUint(core.StatusExpired)], core.StatusExpired)
+}
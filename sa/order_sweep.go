@@ -0,0 +1,216 @@
+package sa
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/db"
+	blog "github.com/letsencrypt/boulder/log"
+)
+
+// OrderSweepConfig configures an OrderSweeper.
+type OrderSweepConfig struct {
+	// Grace is how long after a pending authorization expires it's left alone
+	// before being swept. If zero, authorizations are swept as soon as the
+	// sweeper sees that they've expired.
+	Grace config.Duration `validate:"-"`
+
+	// BatchSize is the maximum number of authorizations updated by each
+	// statement. If zero, a default of 1000 is used.
+	BatchSize int `validate:"omitempty,min=1,max=10000"`
+
+	// CheckInterval is how often the sweeper runs. If zero, a default of one
+	// hour is used.
+	CheckInterval config.Duration `validate:"-"`
+}
+
+// OrderSweeper moves pending authorizations which have expired to the expired
+// status, so that they fall out of the pending ranges of authz2's indexes
+// instead of being filtered out of every read by their expiry. Orders have no
+// status column of their own: an order whose pending authorizations have been
+// swept is invalid, as it already was by virtue of its expiry. The sweeper
+// counts and logs those orders by account, so that subscribers can be told how
+// many of their orders expired without being completed.
+//
+// The expired status is only understood by SAs which include the sweeper, so
+// it must not be enabled until every SA has been updated.
+type OrderSweeper struct {
+	dbMap         db.SelectExecer
+	grace         time.Duration
+	batchSize     int
+	checkInterval time.Duration
+	clk           clock.Clock
+	log           blog.Logger
+
+	// swept is the expiry before which every pending authorization has been
+	// swept. It's zero until the first complete pass, which therefore covers
+	// every authorization.
+	swept time.Time
+
+	authzs prometheus.Counter
+	orders prometheus.Counter
+}
+
+// NewOrderSweeper returns an OrderSweeper for the given config, which sweeps
+// the database behind dbMap.
+func NewOrderSweeper(c OrderSweepConfig, dbMap db.SelectExecer, clk clock.Clock, stats prometheus.Registerer, logger blog.Logger) (*OrderSweeper, error) {
+	if c.Grace.Duration < 0 {
+		return nil, fmt.Errorf("order sweep grace %s is negative", c.Grace.Duration)
+	}
+	batchSize := c.BatchSize
+	if batchSize == 0 {
+		batchSize = 1000
+	}
+	checkInterval := c.CheckInterval.Duration
+	if checkInterval == 0 {
+		checkInterval = time.Hour
+	}
+
+	s := &OrderSweeper{
+		dbMap:         dbMap,
+		grace:         c.Grace.Duration,
+		batchSize:     batchSize,
+		checkInterval: checkInterval,
+		clk:           clk,
+		log:           logger,
+		authzs: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "order_sweep_authorizations",
+			Help: "number of expired pending authorizations moved to the expired status",
+		}),
+		orders: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "order_sweep_orders",
+			Help: "number of orders left incomplete when their pending authorizations expired",
+		}),
+	}
+	stats.MustRegister(s.authzs, s.orders)
+	return s, nil
+}
+
+// Run sweeps every CheckInterval until ctx is canceled.
+func (s *OrderSweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.checkInterval)
+	defer ticker.Stop()
+	for {
+		err := s.Tick(ctx)
+		if err != nil {
+			s.log.Errf("sweeping expired orders: %s", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sweptOrder is an order containing a swept authorization.
+type sweptOrder struct {
+	ID             int64 `db:"id"`
+	RegistrationID int64 `db:"registrationID"`
+}
+
+// expiredOrders is logged once per account with orders found by a pass.
+type expiredOrders struct {
+	RegistrationID int64
+	Orders         int64
+}
+
+// Tick sweeps, in batches, the pending authorizations which expired between
+// the end of the last complete pass and Grace ago, and logs the number of
+// orders containing them by account. Orders found by batches which completed
+// before an error are still logged.
+func (s *OrderSweeper) Tick(ctx context.Context) error {
+	cutoff := s.clk.Now().Add(-s.grace)
+	counts := make(map[int64]int64)
+	err := s.sweep(ctx, cutoff, counts)
+
+	regIDs := make([]int64, 0, len(counts))
+	var total int64
+	for regID, n := range counts {
+		regIDs = append(regIDs, regID)
+		total += n
+	}
+	slices.Sort(regIDs)
+	for _, regID := range regIDs {
+		s.log.InfoObject("Expired incomplete orders", expiredOrders{RegistrationID: regID, Orders: counts[regID]})
+	}
+	s.orders.Add(float64(total))
+
+	if err != nil {
+		return err
+	}
+	s.swept = cutoff
+	return nil
+}
+
+// sweep expires the pending authorizations which expired before cutoff, one
+// batch at a time, adding the orders containing them to counts by account.
+func (s *OrderSweeper) sweep(ctx context.Context, cutoff time.Time, counts map[int64]int64) error {
+	seen := make(map[int64]bool)
+	for {
+		var batch []int64
+		_, err := s.dbMap.Select(ctx, &batch,
+			`SELECT id FROM authz2
+			WHERE expires >= ? AND expires < ? AND status = ?
+			LIMIT ?`,
+			s.swept, cutoff, statusUint(core.StatusPending), s.batchSize,
+		)
+		if err != nil {
+			return fmt.Errorf("selecting expired pending authorizations: %w", err)
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+
+		ids := make([]interface{}, len(batch))
+		for i, id := range batch {
+			ids[i] = id
+		}
+
+		var orders []sweptOrder
+		_, err = s.dbMap.Select(ctx, &orders, fmt.Sprintf(
+			`SELECT DISTINCT o.id, o.registrationID
+			FROM orderToAuthz2 AS oa
+			JOIN orders AS o ON o.id = oa.orderID
+			WHERE oa.authzID IN (%s)`, db.QuestionMarks(len(ids))),
+			ids...,
+		)
+		if err != nil {
+			return fmt.Errorf("selecting orders of expired pending authorizations: %w", err)
+		}
+
+		args := append([]interface{}{statusUint(core.StatusExpired), statusUint(core.StatusPending)}, ids...)
+		res, err := s.dbMap.ExecContext(ctx, fmt.Sprintf(
+			`UPDATE authz2 SET status = ? WHERE status = ? AND id IN (%s)`,
+			db.QuestionMarks(len(ids))),
+			args...,
+		)
+		if err != nil {
+			return fmt.Errorf("expiring pending authorizations: %w", err)
+		}
+		updated, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("expiring pending authorizations: %w", err)
+		}
+		s.authzs.Add(float64(updated))
+
+		for _, o := range orders {
+			if seen[o.ID] {
+				continue
+			}
+			seen[o.ID] = true
+			counts[o.RegistrationID]++
+		}
+
+		if len(batch) < s.batchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package sa
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/core"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+)
+
+type fakeSweepAuthz struct {
+	expires time.Time
+	status  uint8
+	order   sweptOrder
+}
+
+// fakeSweepDB answers the OrderSweeper's queries from a map of authorizations
+// by id.
+type fakeSweepDB struct {
+	authzs map[int64]*fakeSweepAuthz
+	// selectErr, if set, is returned by every Select after the first
+	// failAfter.
+	selectErr error
+	failAfter int
+	selects   int
+}
+
+func (f *fakeSweepDB) Select(_ context.Context, holder interface{}, _ string, args ...interface{}) ([]interface{}, error) {
+	f.selects++
+	if f.selectErr != nil && f.selects > f.failAfter {
+		return nil, f.selectErr
+	}
+	switch h := holder.(type) {
+	case *[]int64:
+		from, to, status, limit := args[0].(time.Time), args[1].(time.Time), args[2].(uint8), args[3].(int)
+		for id := int64(1); id <= int64(len(f.authzs)) && len(*h) < limit; id++ {
+			a := f.authzs[id]
+			if a.status == status && !a.expires.Before(from) && a.expires.Before(to) {
+				*h = append(*h, id)
+			}
+		}
+	case *[]sweptOrder:
+		seen := make(map[int64]bool)
+		for _, arg := range args {
+			o := f.authzs[arg.(int64)].order
+			if !seen[o.ID] {
+				seen[o.ID] = true
+				*h = append(*h, o)
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeSweepDB) ExecContext(_ context.Context, _ string, args ...interface{}) (sql.Result, error) {
+	var updated int64
+	for _, arg := range args[2:] {
+		a := f.authzs[arg.(int64)]
+		if a.status == args[1].(uint8) {
+			a.status = args[0].(uint8)
+			updated++
+		}
+	}
+	return driverResult(updated), nil
+}
+
+type driverResult int64
+
+func (r driverResult) LastInsertId() (int64, error) { return 0, nil }
+func (r driverResult) RowsAffected() (int64, error) { return int64(r), nil }
+
+func TestOrderSweeper(t *testing.T) {
+	t.Parallel()
+
+	clk := clock.NewFake()
+	clk.Set(time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC))
+	now := clk.Now()
+	pending, valid, expired := statusUint(core.StatusPending), statusUint(core.StatusValid), statusUint(core.StatusExpired)
+	fake := &fakeSweepDB{authzs: map[int64]*fakeSweepAuthz{
+		// Order 10 of account 1 has two authorizations which expired long ago.
+		1: {expires: now.Add(-72 * time.Hour), status: pending, order: sweptOrder{ID: 10, RegistrationID: 1}},
+		2: {expires: now.Add(-72 * time.Hour), status: pending, order: sweptOrder{ID: 10, RegistrationID: 1}},
+		// Order 11 of account 1 has a valid authorization, which is left
+		// alone, and one which expired within the grace period.
+		3: {expires: now.Add(-72 * time.Hour), status: valid, order: sweptOrder{ID: 11, RegistrationID: 1}},
+		4: {expires: now.Add(-time.Hour), status: pending, order: sweptOrder{ID: 11, RegistrationID: 1}},
+		// Order 12 of account 2 expired long ago.
+		5: {expires: now.Add(-48 * time.Hour), status: pending, order: sweptOrder{ID: 12, RegistrationID: 2}},
+		// Order 13 of account 2 hasn't expired.
+		6: {expires: now.Add(time.Hour), status: pending, order: sweptOrder{ID: 13, RegistrationID: 2}},
+	}}
+	log := blog.NewMock()
+	s, err := NewOrderSweeper(OrderSweepConfig{
+		Grace:     config.Duration{Duration: 24 * time.Hour},
+		BatchSize: 2,
+	}, fake, clk, metrics.NoopRegisterer, log)
+	test.AssertNotError(t, err, "NewOrderSweeper failed")
+
+	err = s.Tick(context.Background())
+	test.AssertNotError(t, err, "Tick failed")
+	for id, want := range map[int64]uint8{1: expired, 2: expired, 3: valid, 4: pending, 5: expired, 6: pending} {
+		test.AssertEquals(t, fake.authzs[id].status, want)
+	}
+	test.AssertMetricWithLabelsEquals(t, s.authzs, prometheus.Labels{}, 3)
+	test.AssertMetricWithLabelsEquals(t, s.orders, prometheus.Labels{}, 2)
+	test.AssertEquals(t, len(log.GetAllMatching(`Expired incomplete orders JSON=\{"RegistrationID":1,"Orders":1\}`)), 1)
+	test.AssertEquals(t, len(log.GetAllMatching(`Expired incomplete orders JSON=\{"RegistrationID":2,"Orders":1\}`)), 1)
+	test.AssertEquals(t, s.swept, now.Add(-24*time.Hour))
+
+	// Once their grace period is over, the remaining authorizations are swept,
+	// and only those which expired since the last pass are considered.
+	log.Clear()
+	clk.Add(48 * time.Hour)
+	err = s.Tick(context.Background())
+	test.AssertNotError(t, err, "Tick failed")
+	test.AssertEquals(t, fake.authzs[4].status, expired)
+	test.AssertEquals(t, fake.authzs[6].status, expired)
+	test.AssertMetricWithLabelsEquals(t, s.authzs, prometheus.Labels{}, 5)
+	test.AssertEquals(t, len(log.GetAllMatching(`Expired incomplete orders`)), 2)
+}
+
+func TestOrderSweeperErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewOrderSweeper(OrderSweepConfig{Grace: config.Duration{Duration: -time.Hour}}, &fakeSweepDB{}, clock.NewFake(), metrics.NoopRegisterer, blog.NewMock())
+	test.AssertError(t, err, "negative grace should be rejected")
+
+	clk := clock.NewFake()
+	fake := &fakeSweepDB{
+		authzs: map[int64]*fakeSweepAuthz{
+			1: {expires: clk.Now().Add(-time.Hour), status: statusUint(core.StatusPending), order: sweptOrder{ID: 10, RegistrationID: 1}},
+			2: {expires: clk.Now().Add(-time.Hour), status: statusUint(core.StatusPending), order: sweptOrder{ID: 11, RegistrationID: 1}},
+		},
+		// The first batch's two queries succeed, and the second batch fails.
+		selectErr: errors.New("connection refused"),
+		failAfter: 2,
+	}
+	log := blog.NewMock()
+	s, err := NewOrderSweeper(OrderSweepConfig{BatchSize: 1}, fake, clk, metrics.NoopRegisterer, log)
+	test.AssertNotError(t, err, "NewOrderSweeper failed")
+
+	// The batch selected before the failure is still swept and logged, but
+	// the pass isn't complete, so the next one starts where it did.
+	err = s.Tick(context.Background())
+	test.AssertError(t, err, "Tick should have failed")
+	test.AssertContains(t, err.Error(), "connection refused")
+	test.AssertEquals(t, fake.authzs[1].status, statusUint(core.StatusExpired))
+	test.AssertEquals(t, fake.authzs[2].status, statusUint(core.StatusPending))
+	test.AssertEquals(t, len(log.GetAllMatching(`Expired incomplete orders JSON=\{"RegistrationID":1,"Orders":1\}`)), 1)
+	test.AssertEquals(t, s.swept, time.Time{})
+}
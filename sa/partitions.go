@@ -0,0 +1,315 @@
+package sa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/cmd"
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/db"
+	blog "github.com/letsencrypt/boulder/log"
+)
+
+// PartitionConfig configures a PartitionManager.
+type PartitionConfig struct {
+	// DB is the database to manage. Its user needs the SELECT, INSERT, CREATE,
+	// ALTER, and DROP privileges on the schema, and its readTimeout must allow
+	// for rewriting the largest partition.
+	DB cmd.DBConfig
+
+	// Tables are the id-partitioned tables to manage.
+	Tables []string `validate:"min=1,unique,dive,oneof=certificates certificateStatus precertificates"`
+
+	// Interval is how often a new partition is cut from the rows inserted since
+	// the last one. It must be at least 24h.
+	Interval config.Duration `validate:"required"`
+
+	// Retention is how long after a partition was cut it is detached from its
+	// table. It should be longer than the lifetime of any certificate, so that
+	// detached rows are no longer needed to serve OCSP or revocation.
+	Retention config.Duration `validate:"required"`
+
+	// CheckInterval is how often partitions are checked. If zero, a default of
+	// one hour is used.
+	CheckInterval config.Duration `validate:"-"`
+}
+
+// Partitions cut by a PartitionManager are named partitionPrefix followed by
+// the date, in UTC, on which they were cut.
+const (
+	partitionPrefix     = "p_"
+	partitionDateFormat = "20060102"
+)
+
+// PartitionManager keeps the working set of the SA's largest tables small
+// enough to fit in the buffer pool. Each of those tables is partitioned by
+// RANGE(id), ending with a partition of ids LESS THAN MAXVALUE to which new
+// rows are inserted. Every Interval the manager splits the rows in that
+// partition off into a new one, named for the date on which it was cut, and
+// once a dated partition is older than Retention it detaches the partition
+// into a table of its own named "<table>_<partition>", which can then be
+// archived to cold storage and dropped by an operator.
+//
+// Since it alters the schema, only one PartitionManager should run against a
+// database at a time.
+type PartitionManager struct {
+	dbMap         partitionDB
+	tables        []string
+	interval      time.Duration
+	retention     time.Duration
+	checkInterval time.Duration
+	clk           clock.Clock
+	log           blog.Logger
+
+	partitionRows  *prometheus.GaugeVec
+	partitionBytes *prometheus.GaugeVec
+	operations     *prometheus.CounterVec
+}
+
+// partitionDB is the subset of the database's methods used by the
+// PartitionManager.
+type partitionDB interface {
+	db.OneSelector
+	db.SelectExecer
+}
+
+// NewPartitionManager returns a PartitionManager for the given config, which
+// manages the partitions of the database behind dbMap.
+func NewPartitionManager(c PartitionConfig, dbMap partitionDB, clk clock.Clock, stats prometheus.Registerer, logger blog.Logger) (*PartitionManager, error) {
+	if c.Interval.Duration < 24*time.Hour {
+		return nil, fmt.Errorf("partition interval %s is less than 24h", c.Interval.Duration)
+	}
+	if c.Retention.Duration < c.Interval.Duration {
+		return nil, fmt.Errorf("partition retention %s is less than the interval %s", c.Retention.Duration, c.Interval.Duration)
+	}
+	checkInterval := c.CheckInterval.Duration
+	if checkInterval == 0 {
+		checkInterval = time.Hour
+	}
+
+	pm := &PartitionManager{
+		dbMap:         dbMap,
+		tables:        c.Tables,
+		interval:      c.Interval.Duration,
+		retention:     c.Retention.Duration,
+		checkInterval: checkInterval,
+		clk:           clk,
+		log:           logger,
+		partitionRows: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "partition_rows",
+			Help: "estimated number of rows in each partition of a managed table, by table and partition",
+		}, []string{"table", "partition"}),
+		partitionBytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "partition_bytes",
+			Help: "size in bytes of the data and indexes of each partition of a managed table, by table and partition",
+		}, []string{"table", "partition"}),
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "partition_operations",
+			Help: "number of partition maintenance operations, by table, operation (cut or detach), and result",
+		}, []string{"table", "operation", "result"}),
+	}
+	stats.MustRegister(pm.partitionRows, pm.partitionBytes, pm.operations)
+	return pm, nil
+}
+
+// partitionInfo describes one partition of a table, as reported by
+// information_schema.PARTITIONS.
+type partitionInfo struct {
+	Name string `db:"name"`
+	// Description is the partition's exclusive upper bound on id, or
+	// "MAXVALUE".
+	Description string `db:"description"`
+	Rows        int64  `db:"tableRows"`
+	Bytes       int64  `db:"bytes"`
+}
+
+// cutDate returns the date on which the partition was cut, or false if its
+// name doesn't carry one, as with the table's original partition.
+func (p partitionInfo) cutDate() (time.Time, bool) {
+	if len(p.Name) != len(partitionPrefix)+len(partitionDateFormat) || p.Name[:len(partitionPrefix)] != partitionPrefix {
+		return time.Time{}, false
+	}
+	date, err := time.Parse(partitionDateFormat, p.Name[len(partitionPrefix):])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// Run checks the managed tables' partitions every CheckInterval until ctx is
+// canceled.
+func (pm *PartitionManager) Run(ctx context.Context) {
+	ticker := time.NewTicker(pm.checkInterval)
+	defer ticker.Stop()
+	for {
+		err := pm.Tick(ctx)
+		if err != nil {
+			pm.log.Errf("checking partitions: %s", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick checks each managed table once: it updates the partition size metrics,
+// cuts a new partition if the last was cut at least Interval ago, and detaches
+// any partitions cut more than Retention ago.
+func (pm *PartitionManager) Tick(ctx context.Context) error {
+	var errs []error
+	for _, table := range pm.tables {
+		err := pm.checkTable(ctx, table)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("table %q: %w", table, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (pm *PartitionManager) checkTable(ctx context.Context, table string) error {
+	partitions, err := pm.partitions(ctx, table)
+	if err != nil {
+		return err
+	}
+	if len(partitions) == 0 || partitions[len(partitions)-1].Description != "MAXVALUE" {
+		return errors.New("table is not partitioned by range with a final MAXVALUE partition")
+	}
+
+	pm.partitionRows.DeletePartialMatch(prometheus.Labels{"table": table})
+	pm.partitionBytes.DeletePartialMatch(prometheus.Labels{"table": table})
+	for _, p := range partitions {
+		pm.partitionRows.WithLabelValues(table, p.Name).Set(float64(p.Rows))
+		pm.partitionBytes.WithLabelValues(table, p.Name).Set(float64(p.Bytes))
+	}
+
+	now := pm.clk.Now().UTC()
+	var errs []error
+
+	err = pm.maybeCut(ctx, table, partitions, now)
+	if !errors.Is(err, errNoOperation) {
+		pm.countOperation(table, "cut", err)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, p := range partitions {
+		date, ok := p.cutDate()
+		if !ok || now.Before(date.Add(pm.retention)) {
+			continue
+		}
+		err = pm.detach(ctx, table, p.Name)
+		pm.countOperation(table, "detach", err)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (pm *PartitionManager) countOperation(table, operation string, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	pm.operations.WithLabelValues(table, operation, result).Inc()
+}
+
+// errNoOperation is returned by maybeCut when no partition needed cutting.
+var errNoOperation = errors.New("no operation needed")
+
+// partitions returns the table's partitions in order.
+func (pm *PartitionManager) partitions(ctx context.Context, table string) ([]partitionInfo, error) {
+	var partitions []partitionInfo
+	_, err := pm.dbMap.Select(ctx, &partitions,
+		`SELECT PARTITION_NAME AS name,
+			PARTITION_DESCRIPTION AS description,
+			TABLE_ROWS AS tableRows,
+			DATA_LENGTH + INDEX_LENGTH AS bytes
+		FROM information_schema.PARTITIONS
+		WHERE TABLE_SCHEMA = DATABASE()
+			AND TABLE_NAME = ?
+			AND PARTITION_NAME IS NOT NULL
+		ORDER BY PARTITION_ORDINAL_POSITION`,
+		table,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing partitions: %w", err)
+	}
+	return partitions, nil
+}
+
+// maybeCut splits the rows in the table's final MAXVALUE partition off into a
+// new partition named for today, if no partition has been cut within the last
+// Interval and any rows have been inserted since the last cut. It returns
+// errNoOperation if there was nothing to do.
+func (pm *PartitionManager) maybeCut(ctx context.Context, table string, partitions []partitionInfo, now time.Time) error {
+	// Ids start at 1, so a bound of 1 would make an empty partition.
+	lowerBound := int64(1)
+	for _, p := range partitions[:len(partitions)-1] {
+		bound, err := strconv.ParseInt(p.Description, 10, 64)
+		if err != nil {
+			return fmt.Errorf("parsing bound %q of partition %q: %w", p.Description, p.Name, err)
+		}
+		lowerBound = max(lowerBound, bound)
+		date, ok := p.cutDate()
+		if ok && now.Before(date.Add(pm.interval)) {
+			return errNoOperation
+		}
+	}
+
+	name := partitionPrefix + now.Format(partitionDateFormat)
+	if slices.ContainsFunc(partitions, func(p partitionInfo) bool { return p.Name == name }) {
+		return errNoOperation
+	}
+
+	var maxID int64
+	err := pm.dbMap.SelectOne(ctx, &maxID, fmt.Sprintf("SELECT COALESCE(MAX(id), 0) FROM `%s`", table))
+	if err != nil {
+		return fmt.Errorf("finding maximum id: %w", err)
+	}
+	if maxID+1 <= lowerBound {
+		return errNoOperation
+	}
+
+	last := partitions[len(partitions)-1].Name
+	_, err = pm.dbMap.ExecContext(ctx, fmt.Sprintf(
+		"ALTER TABLE `%s` REORGANIZE PARTITION `%s` INTO (PARTITION `%s` VALUES LESS THAN (%d), PARTITION `%s` VALUES LESS THAN (MAXVALUE))",
+		table, last, name, maxID+1, last))
+	if err != nil {
+		return fmt.Errorf("cutting partition %q: %w", name, err)
+	}
+	pm.log.AuditInfof("Cut partition %q of table %q at id %d", name, table, maxID+1)
+	return nil
+}
+
+// detach moves the rows of the given partition into a new table of their own,
+// by exchanging the partition with an empty copy of the table, and then drops
+// the emptied partition. If a previous attempt failed partway, the new table
+// already exists and detach fails without changing anything, so that an
+// operator can inspect the tables and finish the job by hand.
+func (pm *PartitionManager) detach(ctx context.Context, table, partition string) error {
+	archive := table + "_" + partition
+	for _, stmt := range []string{
+		fmt.Sprintf("CREATE TABLE `%s` LIKE `%s`", archive, table),
+		fmt.Sprintf("ALTER TABLE `%s` REMOVE PARTITIONING", archive),
+		fmt.Sprintf("ALTER TABLE `%s` EXCHANGE PARTITION `%s` WITH TABLE `%s`", table, partition, archive),
+		fmt.Sprintf("ALTER TABLE `%s` DROP PARTITION `%s`", table, partition),
+	} {
+		_, err := pm.dbMap.ExecContext(ctx, stmt)
+		if err != nil {
+			return fmt.Errorf("detaching partition %q into %q: %w", partition, archive, err)
+		}
+	}
+	pm.log.AuditInfof("Detached partition %q of table %q into table %q", partition, table, archive)
+	return nil
+}
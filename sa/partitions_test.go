@@ -0,0 +1,170 @@
+package sa
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/config"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// fakePartitionDB serves a fixed list of partitions and maximum id for every
+// table, and records the statements executed against it.
+type fakePartitionDB struct {
+	partitions []partitionInfo
+	maxID      int64
+	execErr    error
+	execs      []string
+}
+
+func (f *fakePartitionDB) SelectOne(_ context.Context, holder interface{}, _ string, _ ...interface{}) error {
+	*holder.(*int64) = f.maxID
+	return nil
+}
+
+func (f *fakePartitionDB) Select(_ context.Context, holder interface{}, _ string, _ ...interface{}) ([]interface{}, error) {
+	*holder.(*[]partitionInfo) = f.partitions
+	return nil, nil
+}
+
+func (f *fakePartitionDB) ExecContext(_ context.Context, query string, _ ...interface{}) (sql.Result, error) {
+	f.execs = append(f.execs, query)
+	return nil, f.execErr
+}
+
+func setupPartitionManager(t *testing.T, fake *fakePartitionDB) (*PartitionManager, clock.FakeClock) {
+	t.Helper()
+	clk := clock.NewFake()
+	clk.Set(time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC))
+	pm, err := NewPartitionManager(PartitionConfig{
+		Tables:    []string{"certificates"},
+		Interval:  config.Duration{Duration: 7 * 24 * time.Hour},
+		Retention: config.Duration{Duration: 90 * 24 * time.Hour},
+	}, fake, clk, metrics.NoopRegisterer, blog.NewMock())
+	test.AssertNotError(t, err, "NewPartitionManager failed")
+	return pm, clk
+}
+
+func TestNewPartitionManagerErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewPartitionManager(PartitionConfig{
+		Tables:    []string{"certificates"},
+		Interval:  config.Duration{Duration: time.Hour},
+		Retention: config.Duration{Duration: 90 * 24 * time.Hour},
+	}, &fakePartitionDB{}, clock.NewFake(), metrics.NoopRegisterer, blog.NewMock())
+	test.AssertError(t, err, "interval under 24h should be rejected")
+
+	_, err = NewPartitionManager(PartitionConfig{
+		Tables:    []string{"certificates"},
+		Interval:  config.Duration{Duration: 7 * 24 * time.Hour},
+		Retention: config.Duration{Duration: 24 * time.Hour},
+	}, &fakePartitionDB{}, clock.NewFake(), metrics.NoopRegisterer, blog.NewMock())
+	test.AssertError(t, err, "retention under the interval should be rejected")
+}
+
+func TestPartitionManagerCut(t *testing.T) {
+	t.Parallel()
+
+	// A freshly created table is cut right away.
+	fake := &fakePartitionDB{
+		partitions: []partitionInfo{{Name: "p_start", Description: "MAXVALUE", Rows: 100, Bytes: 4096}},
+		maxID:      100,
+	}
+	pm, clk := setupPartitionManager(t, fake)
+	err := pm.Tick(context.Background())
+	test.AssertNotError(t, err, "Tick failed")
+	test.AssertDeepEquals(t, fake.execs, []string{
+		"ALTER TABLE `certificates` REORGANIZE PARTITION `p_start` INTO (PARTITION `p_20261015` VALUES LESS THAN (101), PARTITION `p_start` VALUES LESS THAN (MAXVALUE))",
+	})
+	test.AssertMetricWithLabelsEquals(t, pm.operations, prometheus.Labels{"table": "certificates", "operation": "cut", "result": "success"}, 1)
+	test.AssertMetricWithLabelsEquals(t, pm.partitionRows, prometheus.Labels{"table": "certificates", "partition": "p_start"}, 100)
+	test.AssertMetricWithLabelsEquals(t, pm.partitionBytes, prometheus.Labels{"table": "certificates", "partition": "p_start"}, 4096)
+
+	// Within the interval of the last cut, nothing happens.
+	fake.execs = nil
+	fake.partitions = []partitionInfo{
+		{Name: "p_20261015", Description: "101"},
+		{Name: "p_start", Description: "MAXVALUE"},
+	}
+	fake.maxID = 200
+	clk.Add(6 * 24 * time.Hour)
+	err = pm.Tick(context.Background())
+	test.AssertNotError(t, err, "Tick failed")
+	test.AssertEquals(t, len(fake.execs), 0)
+
+	// Once the interval has passed, the new rows are cut.
+	clk.Add(24 * time.Hour)
+	err = pm.Tick(context.Background())
+	test.AssertNotError(t, err, "Tick failed")
+	test.AssertDeepEquals(t, fake.execs, []string{
+		"ALTER TABLE `certificates` REORGANIZE PARTITION `p_start` INTO (PARTITION `p_20261022` VALUES LESS THAN (201), PARTITION `p_start` VALUES LESS THAN (MAXVALUE))",
+	})
+
+	// But not if there are no new rows.
+	fake.execs = nil
+	fake.maxID = 100
+	err = pm.Tick(context.Background())
+	test.AssertNotError(t, err, "Tick failed")
+	test.AssertEquals(t, len(fake.execs), 0)
+	test.AssertMetricWithLabelsEquals(t, pm.operations, prometheus.Labels{"table": "certificates", "operation": "cut", "result": "success"}, 2)
+
+	// Metrics for partitions which no longer exist are removed.
+	test.AssertMetricWithLabelsEquals(t, pm.partitionRows, prometheus.Labels{"table": "certificates", "partition": "p_20261015"}, 0)
+}
+
+func TestPartitionManagerDetach(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakePartitionDB{
+		partitions: []partitionInfo{
+			{Name: "p_old", Description: "1"},
+			{Name: "p_20260701", Description: "101"},
+			{Name: "p_20260801", Description: "201"},
+			{Name: "p_20261010", Description: "301"},
+			{Name: "p_start", Description: "MAXVALUE"},
+		},
+		maxID: 300,
+	}
+	pm, _ := setupPartitionManager(t, fake)
+	err := pm.Tick(context.Background())
+	test.AssertNotError(t, err, "Tick failed")
+
+	// Only the partition cut more than 90 days ago is detached, and the
+	// undated partition is left alone.
+	test.AssertDeepEquals(t, fake.execs, []string{
+		"CREATE TABLE `certificates_p_20260701` LIKE `certificates`",
+		"ALTER TABLE `certificates_p_20260701` REMOVE PARTITIONING",
+		"ALTER TABLE `certificates` EXCHANGE PARTITION `p_20260701` WITH TABLE `certificates_p_20260701`",
+		"ALTER TABLE `certificates` DROP PARTITION `p_20260701`",
+	})
+	test.AssertMetricWithLabelsEquals(t, pm.operations, prometheus.Labels{"table": "certificates", "operation": "detach", "result": "success"}, 1)
+
+	// Failures stop the detachment at the first failed statement.
+	fake.execs = nil
+	fake.execErr = errors.New("table already exists")
+	err = pm.Tick(context.Background())
+	test.AssertError(t, err, "Tick should have failed")
+	test.AssertContains(t, err.Error(), `detaching partition "p_20260701"`)
+	test.AssertEquals(t, len(fake.execs), 1)
+	test.AssertMetricWithLabelsEquals(t, pm.operations, prometheus.Labels{"table": "certificates", "operation": "detach", "result": "failure"}, 1)
+}
+
+func TestPartitionManagerUnpartitioned(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakePartitionDB{}
+	pm, _ := setupPartitionManager(t, fake)
+	err := pm.Tick(context.Background())
+	test.AssertError(t, err, "Tick should fail for an unpartitioned table")
+	test.AssertContains(t, err.Error(), `table "certificates"`)
+	test.AssertEquals(t, len(fake.execs), 0)
+}
@@ -0,0 +1,185 @@
+package sa
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+)
+
+// poolClass is a priority class of SA RPCs which share a partition of the
+// database connection pool.
+type poolClass string
+
+const (
+	// poolClassInteractive is for reads made on behalf of ACME clients,
+	// usually by the WFE or RA, which a subscriber is waiting on.
+	poolClassInteractive = poolClass("interactive")
+	// poolClassIssuance is for the writes which create and finalize orders,
+	// authorizations and certificates.
+	poolClassIssuance = poolClass("issuance")
+	// poolClassBatch is for bulk reads and writes by offline jobs such as the
+	// crl-updater, bad-key-revoker and admin tool.
+	poolClassBatch = poolClass("batch")
+)
+
+// batchRPCs are the unary RPCs which are classed as batch by default. All
+// streaming RPCs are also batch by default.
+var batchRPCs = map[string]bool{
+	"GetCRLShard":      true,
+	"GetMaxExpiration": true,
+	"LeaseCRLShard":    true,
+	"UpdateCRLShard":   true,
+}
+
+// PoolPartitionConfig divides the SA's database connections between priority
+// classes of RPCs, so that a flood of RPCs in one class, such as a runaway
+// batch job, can't leave the others waiting for a connection.
+//
+// By default, streaming RPCs and the RPCs used only by the crl-updater are
+// "batch", the other RPCs of the read-only StorageAuthorityReadOnly service are
+// "interactive", and the RPCs which only the read-write StorageAuthority
+// service offers are "issuance".
+type PoolPartitionConfig struct {
+	// Interactive, Issuance, and Batch are the most RPCs of each class which
+	// may run at once. Most RPCs use one connection at a time, though some use
+	// up to ParallelismPerRPC, so the limits should sum to somewhat less than
+	// the DB's MaxOpenConns. Zero means that the class is not limited.
+	Interactive int `validate:"min=0"`
+	Issuance    int `validate:"min=0"`
+	Batch       int `validate:"min=0"`
+
+	// Classes maps the name of an SA RPC, such as "GetRegistration", to the
+	// class it's assigned in place of its default.
+	Classes map[string]string `validate:"omitempty,dive,keys,required,endkeys,oneof=interactive issuance batch"`
+}
+
+// PoolPartitions enforces a PoolPartitionConfig. Its Unary and Stream methods
+// must be installed as interceptors on the SA's gRPC server.
+type PoolPartitions struct {
+	// slots holds a buffered channel per limited class, whose capacity is
+	// that class's limit. An RPC holds a slot for as long as it runs.
+	slots   map[poolClass]chan struct{}
+	classes map[string]poolClass
+
+	inUse   *prometheus.GaugeVec
+	waits   *prometheus.CounterVec
+	refused *prometheus.CounterVec
+}
+
+// NewPoolPartitions returns a PoolPartitions for the given config.
+func NewPoolPartitions(c PoolPartitionConfig, stats prometheus.Registerer) (*PoolPartitions, error) {
+	pp := &PoolPartitions{
+		slots:   make(map[poolClass]chan struct{}),
+		classes: make(map[string]poolClass),
+		inUse: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "pool_partition_in_use",
+			Help: "number of RPCs currently running in each database connection pool partition, by class",
+		}, []string{"class"}),
+		waits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "pool_partition_waits",
+			Help: "number of RPCs which waited for their database connection pool partition to have room, by class",
+		}, []string{"class"}),
+		refused: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "pool_partition_refused",
+			Help: "number of RPCs which were canceled while waiting for their database connection pool partition to have room, by class",
+		}, []string{"class"}),
+	}
+	stats.MustRegister(pp.inUse, pp.waits, pp.refused)
+
+	for class, limit := range map[poolClass]int{
+		poolClassInteractive: c.Interactive,
+		poolClassIssuance:    c.Issuance,
+		poolClassBatch:       c.Batch,
+	} {
+		if limit > 0 {
+			pp.slots[class] = make(chan struct{}, limit)
+		}
+	}
+
+	readOnly := make(map[string]bool)
+	for _, m := range sapb.StorageAuthorityReadOnly_ServiceDesc.Methods {
+		readOnly[m.MethodName] = true
+	}
+	for _, m := range sapb.StorageAuthority_ServiceDesc.Methods {
+		switch {
+		case batchRPCs[m.MethodName]:
+			pp.classes[m.MethodName] = poolClassBatch
+		case readOnly[m.MethodName]:
+			pp.classes[m.MethodName] = poolClassInteractive
+		default:
+			pp.classes[m.MethodName] = poolClassIssuance
+		}
+	}
+	for _, s := range sapb.StorageAuthority_ServiceDesc.Streams {
+		pp.classes[s.StreamName] = poolClassBatch
+	}
+
+	for rpc, class := range c.Classes {
+		_, ok := pp.classes[rpc]
+		if !ok {
+			return nil, fmt.Errorf("unrecognized SA RPC %q in pool partition classes", rpc)
+		}
+		pp.classes[rpc] = poolClass(class)
+	}
+	return pp, nil
+}
+
+// acquire waits for room in the partition of the class of the named RPC, and
+// returns a function which must be called to release it once the RPC returns.
+// It returns an error if the context is canceled first.
+func (pp *PoolPartitions) acquire(ctx context.Context, fullMethod string) (func(), error) {
+	class, ok := pp.classes[path.Base(fullMethod)]
+	if !ok {
+		// RPCs which aren't part of the SA's services, such as health checks,
+		// don't use the database.
+		return func() {}, nil
+	}
+	slots, ok := pp.slots[class]
+	if !ok {
+		return func() {}, nil
+	}
+
+	select {
+	case slots <- struct{}{}:
+	default:
+		pp.waits.WithLabelValues(string(class)).Inc()
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			pp.refused.WithLabelValues(string(class)).Inc()
+			return nil, fmt.Errorf("waiting for room in the %s database connection pool partition: %w", class, ctx.Err())
+		}
+	}
+	pp.inUse.WithLabelValues(string(class)).Inc()
+	return func() {
+		pp.inUse.WithLabelValues(string(class)).Dec()
+		<-slots
+	}, nil
+}
+
+// Unary is a gRPC unary server interceptor which runs each RPC within the
+// partition of its class.
+func (pp *PoolPartitions) Unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	release, err := pp.acquire(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return handler(ctx, req)
+}
+
+// Stream is a gRPC stream server interceptor which runs each RPC within the
+// partition of its class.
+func (pp *PoolPartitions) Stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	release, err := pp.acquire(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return handler(srv, ss)
+}
@@ -0,0 +1,82 @@
+package sa
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestPoolPartitionClasses(t *testing.T) {
+	t.Parallel()
+
+	pp, err := NewPoolPartitions(PoolPartitionConfig{
+		Classes: map[string]string{"GetRegistration": "batch"},
+	}, metrics.NoopRegisterer)
+	test.AssertNotError(t, err, "NewPoolPartitions failed")
+
+	test.AssertEquals(t, pp.classes["GetOrder"], poolClassInteractive)
+	test.AssertEquals(t, pp.classes["NewOrderAndAuthzs"], poolClassIssuance)
+	test.AssertEquals(t, pp.classes["FinalizeOrder"], poolClassIssuance)
+	test.AssertEquals(t, pp.classes["UpdateCRLShard"], poolClassBatch)
+	test.AssertEquals(t, pp.classes["SerialsForIncident"], poolClassBatch)
+	test.AssertEquals(t, pp.classes["GetRegistration"], poolClassBatch)
+
+	_, err = NewPoolPartitions(PoolPartitionConfig{
+		Classes: map[string]string{"GetFnord": "batch"},
+	}, metrics.NoopRegisterer)
+	test.AssertError(t, err, "unrecognized RPC accepted")
+}
+
+func TestPoolPartitions(t *testing.T) {
+	t.Parallel()
+
+	pp, err := NewPoolPartitions(PoolPartitionConfig{Batch: 1}, metrics.NoopRegisterer)
+	test.AssertNotError(t, err, "NewPoolPartitions failed")
+
+	// Occupy the batch partition with an RPC which runs until it's released.
+	running := make(chan struct{})
+	release := make(chan struct{})
+	go func() {
+		_, _ = pp.Unary(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/sa.StorageAuthority/UpdateCRLShard"},
+			func(ctx context.Context, _ any) (any, error) {
+				close(running)
+				<-release
+				return nil, nil
+			})
+	}()
+	<-running
+	test.AssertMetricWithLabelsEquals(t, pp.inUse, prometheus.Labels{"class": "batch"}, 1)
+
+	run := func(ctx context.Context, method string) error {
+		t.Helper()
+		_, err := pp.Unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method},
+			func(context.Context, any) (any, error) { return nil, nil })
+		return err
+	}
+
+	// Another batch RPC waits until its context is canceled.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = run(ctx, "/sa.StorageAuthority/LeaseCRLShard")
+	test.AssertErrorIs(t, err, context.DeadlineExceeded)
+	test.AssertContains(t, err.Error(), "batch database connection pool partition")
+	test.AssertMetricWithLabelsEquals(t, pp.waits, prometheus.Labels{"class": "batch"}, 1)
+	test.AssertMetricWithLabelsEquals(t, pp.refused, prometheus.Labels{"class": "batch"}, 1)
+
+	// RPCs in other, unlimited, classes are unaffected.
+	err = run(context.Background(), "/sa.StorageAuthority/NewOrderAndAuthzs")
+	test.AssertNotError(t, err, "issuance RPC")
+	err = run(context.Background(), "/sa.StorageAuthorityReadOnly/GetOrder")
+	test.AssertNotError(t, err, "interactive RPC")
+
+	// Once the first batch RPC returns, another may run.
+	close(release)
+	err = run(context.Background(), "/sa.StorageAuthority/LeaseCRLShard")
+	test.AssertNotError(t, err, "batch RPC after release")
+}
@@ -22,36 +22,48 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	StorageAuthorityReadOnly_CountInvalidAuthorizations2_FullMethodName  = "/sa.StorageAuthorityReadOnly/CountInvalidAuthorizations2"
-	StorageAuthorityReadOnly_CountPendingAuthorizations2_FullMethodName  = "/sa.StorageAuthorityReadOnly/CountPendingAuthorizations2"
-	StorageAuthorityReadOnly_FQDNSetExists_FullMethodName                = "/sa.StorageAuthorityReadOnly/FQDNSetExists"
-	StorageAuthorityReadOnly_FQDNSetTimestampsForWindow_FullMethodName   = "/sa.StorageAuthorityReadOnly/FQDNSetTimestampsForWindow"
-	StorageAuthorityReadOnly_GetAuthorization2_FullMethodName            = "/sa.StorageAuthorityReadOnly/GetAuthorization2"
-	StorageAuthorityReadOnly_GetAuthorizations2_FullMethodName           = "/sa.StorageAuthorityReadOnly/GetAuthorizations2"
-	StorageAuthorityReadOnly_GetCertificate_FullMethodName               = "/sa.StorageAuthorityReadOnly/GetCertificate"
-	StorageAuthorityReadOnly_GetLintPrecertificate_FullMethodName        = "/sa.StorageAuthorityReadOnly/GetLintPrecertificate"
-	StorageAuthorityReadOnly_GetCertificateStatus_FullMethodName         = "/sa.StorageAuthorityReadOnly/GetCertificateStatus"
-	StorageAuthorityReadOnly_GetMaxExpiration_FullMethodName             = "/sa.StorageAuthorityReadOnly/GetMaxExpiration"
-	StorageAuthorityReadOnly_GetOrder_FullMethodName                     = "/sa.StorageAuthorityReadOnly/GetOrder"
-	StorageAuthorityReadOnly_GetOrderForNames_FullMethodName             = "/sa.StorageAuthorityReadOnly/GetOrderForNames"
-	StorageAuthorityReadOnly_GetRegistration_FullMethodName              = "/sa.StorageAuthorityReadOnly/GetRegistration"
-	StorageAuthorityReadOnly_GetRegistrationByKey_FullMethodName         = "/sa.StorageAuthorityReadOnly/GetRegistrationByKey"
-	StorageAuthorityReadOnly_GetRevocationStatus_FullMethodName          = "/sa.StorageAuthorityReadOnly/GetRevocationStatus"
-	StorageAuthorityReadOnly_GetRevokedCerts_FullMethodName              = "/sa.StorageAuthorityReadOnly/GetRevokedCerts"
-	StorageAuthorityReadOnly_GetRevokedCertsByShard_FullMethodName       = "/sa.StorageAuthorityReadOnly/GetRevokedCertsByShard"
-	StorageAuthorityReadOnly_GetSerialMetadata_FullMethodName            = "/sa.StorageAuthorityReadOnly/GetSerialMetadata"
-	StorageAuthorityReadOnly_GetSerialsByAccount_FullMethodName          = "/sa.StorageAuthorityReadOnly/GetSerialsByAccount"
-	StorageAuthorityReadOnly_GetSerialsByKey_FullMethodName              = "/sa.StorageAuthorityReadOnly/GetSerialsByKey"
-	StorageAuthorityReadOnly_GetValidAuthorizations2_FullMethodName      = "/sa.StorageAuthorityReadOnly/GetValidAuthorizations2"
-	StorageAuthorityReadOnly_GetValidOrderAuthorizations2_FullMethodName = "/sa.StorageAuthorityReadOnly/GetValidOrderAuthorizations2"
-	StorageAuthorityReadOnly_IncidentsForSerial_FullMethodName           = "/sa.StorageAuthorityReadOnly/IncidentsForSerial"
-	StorageAuthorityReadOnly_KeyBlocked_FullMethodName                   = "/sa.StorageAuthorityReadOnly/KeyBlocked"
-	StorageAuthorityReadOnly_ReplacementOrderExists_FullMethodName       = "/sa.StorageAuthorityReadOnly/ReplacementOrderExists"
-	StorageAuthorityReadOnly_SerialsForIncident_FullMethodName           = "/sa.StorageAuthorityReadOnly/SerialsForIncident"
-	StorageAuthorityReadOnly_CheckIdentifiersPaused_FullMethodName       = "/sa.StorageAuthorityReadOnly/CheckIdentifiersPaused"
-	StorageAuthorityReadOnly_GetPausedIdentifiers_FullMethodName         = "/sa.StorageAuthorityReadOnly/GetPausedIdentifiers"
-	StorageAuthorityReadOnly_GetRateLimitOverride_FullMethodName         = "/sa.StorageAuthorityReadOnly/GetRateLimitOverride"
-	StorageAuthorityReadOnly_GetEnabledRateLimitOverrides_FullMethodName = "/sa.StorageAuthorityReadOnly/GetEnabledRateLimitOverrides"
+	StorageAuthorityReadOnly_CountInvalidAuthorizations2_FullMethodName   = "/sa.StorageAuthorityReadOnly/CountInvalidAuthorizations2"
+	StorageAuthorityReadOnly_CountPendingAuthorizations2_FullMethodName   = "/sa.StorageAuthorityReadOnly/CountPendingAuthorizations2"
+	StorageAuthorityReadOnly_FQDNSetExists_FullMethodName                 = "/sa.StorageAuthorityReadOnly/FQDNSetExists"
+	StorageAuthorityReadOnly_FQDNSetTimestampsForWindow_FullMethodName    = "/sa.StorageAuthorityReadOnly/FQDNSetTimestampsForWindow"
+	StorageAuthorityReadOnly_GetAuthorization2_FullMethodName             = "/sa.StorageAuthorityReadOnly/GetAuthorization2"
+	StorageAuthorityReadOnly_GetAuthorizations2_FullMethodName            = "/sa.StorageAuthorityReadOnly/GetAuthorizations2"
+	StorageAuthorityReadOnly_GetCertificate_FullMethodName                = "/sa.StorageAuthorityReadOnly/GetCertificate"
+	StorageAuthorityReadOnly_GetLintPrecertificate_FullMethodName         = "/sa.StorageAuthorityReadOnly/GetLintPrecertificate"
+	StorageAuthorityReadOnly_GetCertificateStatus_FullMethodName          = "/sa.StorageAuthorityReadOnly/GetCertificateStatus"
+	StorageAuthorityReadOnly_GetCertificatesByExpiry_FullMethodName       = "/sa.StorageAuthorityReadOnly/GetCertificatesByExpiry"
+	StorageAuthorityReadOnly_GetMaxExpiration_FullMethodName              = "/sa.StorageAuthorityReadOnly/GetMaxExpiration"
+	StorageAuthorityReadOnly_GetMPICComplianceStats_FullMethodName        = "/sa.StorageAuthorityReadOnly/GetMPICComplianceStats"
+	StorageAuthorityReadOnly_GetOrder_FullMethodName                      = "/sa.StorageAuthorityReadOnly/GetOrder"
+	StorageAuthorityReadOnly_GetOrderForNames_FullMethodName              = "/sa.StorageAuthorityReadOnly/GetOrderForNames"
+	StorageAuthorityReadOnly_GetRegistration_FullMethodName               = "/sa.StorageAuthorityReadOnly/GetRegistration"
+	StorageAuthorityReadOnly_GetRegistrationByKey_FullMethodName          = "/sa.StorageAuthorityReadOnly/GetRegistrationByKey"
+	StorageAuthorityReadOnly_GetRevocationStatus_FullMethodName           = "/sa.StorageAuthorityReadOnly/GetRevocationStatus"
+	StorageAuthorityReadOnly_GetRevocationStatuses_FullMethodName         = "/sa.StorageAuthorityReadOnly/GetRevocationStatuses"
+	StorageAuthorityReadOnly_GetRevokedCerts_FullMethodName               = "/sa.StorageAuthorityReadOnly/GetRevokedCerts"
+	StorageAuthorityReadOnly_GetRevokedCertsByShard_FullMethodName        = "/sa.StorageAuthorityReadOnly/GetRevokedCertsByShard"
+	StorageAuthorityReadOnly_GetSerialMetadata_FullMethodName             = "/sa.StorageAuthorityReadOnly/GetSerialMetadata"
+	StorageAuthorityReadOnly_GetSerialsByAccount_FullMethodName           = "/sa.StorageAuthorityReadOnly/GetSerialsByAccount"
+	StorageAuthorityReadOnly_GetSerialsByKey_FullMethodName               = "/sa.StorageAuthorityReadOnly/GetSerialsByKey"
+	StorageAuthorityReadOnly_SearchCertificates_FullMethodName            = "/sa.StorageAuthorityReadOnly/SearchCertificates"
+	StorageAuthorityReadOnly_GetValidAuthorizations2_FullMethodName       = "/sa.StorageAuthorityReadOnly/GetValidAuthorizations2"
+	StorageAuthorityReadOnly_GetValidOrderAuthorizations2_FullMethodName  = "/sa.StorageAuthorityReadOnly/GetValidOrderAuthorizations2"
+	StorageAuthorityReadOnly_IncidentsForSerial_FullMethodName            = "/sa.StorageAuthorityReadOnly/IncidentsForSerial"
+	StorageAuthorityReadOnly_KeyBlocked_FullMethodName                    = "/sa.StorageAuthorityReadOnly/KeyBlocked"
+	StorageAuthorityReadOnly_ReplacementOrderExists_FullMethodName        = "/sa.StorageAuthorityReadOnly/ReplacementOrderExists"
+	StorageAuthorityReadOnly_SerialsForIncident_FullMethodName            = "/sa.StorageAuthorityReadOnly/SerialsForIncident"
+	StorageAuthorityReadOnly_CheckIdentifiersPaused_FullMethodName        = "/sa.StorageAuthorityReadOnly/CheckIdentifiersPaused"
+	StorageAuthorityReadOnly_GetPausedIdentifiers_FullMethodName          = "/sa.StorageAuthorityReadOnly/GetPausedIdentifiers"
+	StorageAuthorityReadOnly_GetRateLimitOverride_FullMethodName          = "/sa.StorageAuthorityReadOnly/GetRateLimitOverride"
+	StorageAuthorityReadOnly_GetEnabledRateLimitOverrides_FullMethodName  = "/sa.StorageAuthorityReadOnly/GetEnabledRateLimitOverrides"
+	StorageAuthorityReadOnly_GetContactVerifications_FullMethodName       = "/sa.StorageAuthorityReadOnly/GetContactVerifications"
+	StorageAuthorityReadOnly_GetRegistrationClientIdentity_FullMethodName = "/sa.StorageAuthorityReadOnly/GetRegistrationClientIdentity"
+	StorageAuthorityReadOnly_GetAccountRevocation_FullMethodName          = "/sa.StorageAuthorityReadOnly/GetAccountRevocation"
+	StorageAuthorityReadOnly_GetExemptionRequest_FullMethodName           = "/sa.StorageAuthorityReadOnly/GetExemptionRequest"
+	StorageAuthorityReadOnly_GetExemptionRequests_FullMethodName          = "/sa.StorageAuthorityReadOnly/GetExemptionRequests"
+	StorageAuthorityReadOnly_GetValidationFailures_FullMethodName         = "/sa.StorageAuthorityReadOnly/GetValidationFailures"
+	StorageAuthorityReadOnly_GetAuthzCAARecheck_FullMethodName            = "/sa.StorageAuthorityReadOnly/GetAuthzCAARecheck"
+	StorageAuthorityReadOnly_GetCRLShard_FullMethodName                   = "/sa.StorageAuthorityReadOnly/GetCRLShard"
 )
 
 // StorageAuthorityReadOnlyClient is the client API for StorageAuthorityReadOnly service.
@@ -69,17 +81,21 @@ type StorageAuthorityReadOnlyClient interface {
 	GetCertificate(ctx context.Context, in *Serial, opts ...grpc.CallOption) (*proto.Certificate, error)
 	GetLintPrecertificate(ctx context.Context, in *Serial, opts ...grpc.CallOption) (*proto.Certificate, error)
 	GetCertificateStatus(ctx context.Context, in *Serial, opts ...grpc.CallOption) (*proto.CertificateStatus, error)
+	GetCertificatesByExpiry(ctx context.Context, in *GetCertificatesByExpiryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[proto.Certificate], error)
 	GetMaxExpiration(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*timestamppb.Timestamp, error)
+	GetMPICComplianceStats(ctx context.Context, in *GetMPICComplianceStatsRequest, opts ...grpc.CallOption) (*MPICComplianceStats, error)
 	GetOrder(ctx context.Context, in *OrderRequest, opts ...grpc.CallOption) (*proto.Order, error)
 	GetOrderForNames(ctx context.Context, in *GetOrderForNamesRequest, opts ...grpc.CallOption) (*proto.Order, error)
 	GetRegistration(ctx context.Context, in *RegistrationID, opts ...grpc.CallOption) (*proto.Registration, error)
 	GetRegistrationByKey(ctx context.Context, in *JSONWebKey, opts ...grpc.CallOption) (*proto.Registration, error)
 	GetRevocationStatus(ctx context.Context, in *Serial, opts ...grpc.CallOption) (*RevocationStatus, error)
+	GetRevocationStatuses(ctx context.Context, in *Serials, opts ...grpc.CallOption) (*RevocationStatuses, error)
 	GetRevokedCerts(ctx context.Context, in *GetRevokedCertsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[proto.CRLEntry], error)
 	GetRevokedCertsByShard(ctx context.Context, in *GetRevokedCertsByShardRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[proto.CRLEntry], error)
 	GetSerialMetadata(ctx context.Context, in *Serial, opts ...grpc.CallOption) (*SerialMetadata, error)
 	GetSerialsByAccount(ctx context.Context, in *RegistrationID, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Serial], error)
 	GetSerialsByKey(ctx context.Context, in *SPKIHash, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Serial], error)
+	SearchCertificates(ctx context.Context, in *SearchCertificatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SerialMetadata], error)
 	GetValidAuthorizations2(ctx context.Context, in *GetValidAuthorizationsRequest, opts ...grpc.CallOption) (*Authorizations, error)
 	GetValidOrderAuthorizations2(ctx context.Context, in *GetValidOrderAuthorizationsRequest, opts ...grpc.CallOption) (*Authorizations, error)
 	IncidentsForSerial(ctx context.Context, in *Serial, opts ...grpc.CallOption) (*Incidents, error)
@@ -90,6 +106,14 @@ type StorageAuthorityReadOnlyClient interface {
 	GetPausedIdentifiers(ctx context.Context, in *RegistrationID, opts ...grpc.CallOption) (*Identifiers, error)
 	GetRateLimitOverride(ctx context.Context, in *GetRateLimitOverrideRequest, opts ...grpc.CallOption) (*RateLimitOverrideResponse, error)
 	GetEnabledRateLimitOverrides(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RateLimitOverrideResponse], error)
+	GetContactVerifications(ctx context.Context, in *ContactHashes, opts ...grpc.CallOption) (*ContactVerifications, error)
+	GetRegistrationClientIdentity(ctx context.Context, in *RegistrationID, opts ...grpc.CallOption) (*ClientIdentity, error)
+	GetAccountRevocation(ctx context.Context, in *RegistrationID, opts ...grpc.CallOption) (*AccountRevocation, error)
+	GetExemptionRequest(ctx context.Context, in *ExemptionRequestID, opts ...grpc.CallOption) (*ExemptionRequest, error)
+	GetExemptionRequests(ctx context.Context, in *GetExemptionRequestsRequest, opts ...grpc.CallOption) (*ExemptionRequests, error)
+	GetValidationFailures(ctx context.Context, in *AuthorizationID2, opts ...grpc.CallOption) (*ValidationFailures, error)
+	GetAuthzCAARecheck(ctx context.Context, in *AuthorizationID2, opts ...grpc.CallOption) (*AuthzCAARecheck, error)
+	GetCRLShard(ctx context.Context, in *GetCRLShardRequest, opts ...grpc.CallOption) (*CRLShard, error)
 }
 
 type storageAuthorityReadOnlyClient struct {
@@ -190,6 +214,25 @@ func (c *storageAuthorityReadOnlyClient) GetCertificateStatus(ctx context.Contex
 	return out, nil
 }
 
+func (c *storageAuthorityReadOnlyClient) GetCertificatesByExpiry(ctx context.Context, in *GetCertificatesByExpiryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[proto.Certificate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StorageAuthorityReadOnly_ServiceDesc.Streams[0], StorageAuthorityReadOnly_GetCertificatesByExpiry_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetCertificatesByExpiryRequest, proto.Certificate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StorageAuthorityReadOnly_GetCertificatesByExpiryClient = grpc.ServerStreamingClient[proto.Certificate]
+
 func (c *storageAuthorityReadOnlyClient) GetMaxExpiration(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*timestamppb.Timestamp, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(timestamppb.Timestamp)
@@ -200,6 +243,16 @@ func (c *storageAuthorityReadOnlyClient) GetMaxExpiration(ctx context.Context, i
 	return out, nil
 }
 
+func (c *storageAuthorityReadOnlyClient) GetMPICComplianceStats(ctx context.Context, in *GetMPICComplianceStatsRequest, opts ...grpc.CallOption) (*MPICComplianceStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MPICComplianceStats)
+	err := c.cc.Invoke(ctx, StorageAuthorityReadOnly_GetMPICComplianceStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *storageAuthorityReadOnlyClient) GetOrder(ctx context.Context, in *OrderRequest, opts ...grpc.CallOption) (*proto.Order, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(proto.Order)
@@ -250,9 +303,19 @@ func (c *storageAuthorityReadOnlyClient) GetRevocationStatus(ctx context.Context
 	return out, nil
 }
 
+func (c *storageAuthorityReadOnlyClient) GetRevocationStatuses(ctx context.Context, in *Serials, opts ...grpc.CallOption) (*RevocationStatuses, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevocationStatuses)
+	err := c.cc.Invoke(ctx, StorageAuthorityReadOnly_GetRevocationStatuses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *storageAuthorityReadOnlyClient) GetRevokedCerts(ctx context.Context, in *GetRevokedCertsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[proto.CRLEntry], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &StorageAuthorityReadOnly_ServiceDesc.Streams[0], StorageAuthorityReadOnly_GetRevokedCerts_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &StorageAuthorityReadOnly_ServiceDesc.Streams[1], StorageAuthorityReadOnly_GetRevokedCerts_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -271,7 +334,7 @@ type StorageAuthorityReadOnly_GetRevokedCertsClient = grpc.ServerStreamingClient
 
 func (c *storageAuthorityReadOnlyClient) GetRevokedCertsByShard(ctx context.Context, in *GetRevokedCertsByShardRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[proto.CRLEntry], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &StorageAuthorityReadOnly_ServiceDesc.Streams[1], StorageAuthorityReadOnly_GetRevokedCertsByShard_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &StorageAuthorityReadOnly_ServiceDesc.Streams[2], StorageAuthorityReadOnly_GetRevokedCertsByShard_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -300,7 +363,7 @@ func (c *storageAuthorityReadOnlyClient) GetSerialMetadata(ctx context.Context,
 
 func (c *storageAuthorityReadOnlyClient) GetSerialsByAccount(ctx context.Context, in *RegistrationID, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Serial], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &StorageAuthorityReadOnly_ServiceDesc.Streams[2], StorageAuthorityReadOnly_GetSerialsByAccount_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &StorageAuthorityReadOnly_ServiceDesc.Streams[3], StorageAuthorityReadOnly_GetSerialsByAccount_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -319,7 +382,7 @@ type StorageAuthorityReadOnly_GetSerialsByAccountClient = grpc.ServerStreamingCl
 
 func (c *storageAuthorityReadOnlyClient) GetSerialsByKey(ctx context.Context, in *SPKIHash, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Serial], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &StorageAuthorityReadOnly_ServiceDesc.Streams[3], StorageAuthorityReadOnly_GetSerialsByKey_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &StorageAuthorityReadOnly_ServiceDesc.Streams[4], StorageAuthorityReadOnly_GetSerialsByKey_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -336,6 +399,25 @@ func (c *storageAuthorityReadOnlyClient) GetSerialsByKey(ctx context.Context, in
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type StorageAuthorityReadOnly_GetSerialsByKeyClient = grpc.ServerStreamingClient[Serial]
 
+func (c *storageAuthorityReadOnlyClient) SearchCertificates(ctx context.Context, in *SearchCertificatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SerialMetadata], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StorageAuthorityReadOnly_ServiceDesc.Streams[5], StorageAuthorityReadOnly_SearchCertificates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SearchCertificatesRequest, SerialMetadata]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StorageAuthorityReadOnly_SearchCertificatesClient = grpc.ServerStreamingClient[SerialMetadata]
+
 func (c *storageAuthorityReadOnlyClient) GetValidAuthorizations2(ctx context.Context, in *GetValidAuthorizationsRequest, opts ...grpc.CallOption) (*Authorizations, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Authorizations)
@@ -388,7 +470,7 @@ func (c *storageAuthorityReadOnlyClient) ReplacementOrderExists(ctx context.Cont
 
 func (c *storageAuthorityReadOnlyClient) SerialsForIncident(ctx context.Context, in *SerialsForIncidentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[IncidentSerial], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &StorageAuthorityReadOnly_ServiceDesc.Streams[4], StorageAuthorityReadOnly_SerialsForIncident_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &StorageAuthorityReadOnly_ServiceDesc.Streams[6], StorageAuthorityReadOnly_SerialsForIncident_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -437,7 +519,7 @@ func (c *storageAuthorityReadOnlyClient) GetRateLimitOverride(ctx context.Contex
 
 func (c *storageAuthorityReadOnlyClient) GetEnabledRateLimitOverrides(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RateLimitOverrideResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &StorageAuthorityReadOnly_ServiceDesc.Streams[5], StorageAuthorityReadOnly_GetEnabledRateLimitOverrides_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &StorageAuthorityReadOnly_ServiceDesc.Streams[7], StorageAuthorityReadOnly_GetEnabledRateLimitOverrides_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -454,6 +536,86 @@ func (c *storageAuthorityReadOnlyClient) GetEnabledRateLimitOverrides(ctx contex
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type StorageAuthorityReadOnly_GetEnabledRateLimitOverridesClient = grpc.ServerStreamingClient[RateLimitOverrideResponse]
 
+func (c *storageAuthorityReadOnlyClient) GetContactVerifications(ctx context.Context, in *ContactHashes, opts ...grpc.CallOption) (*ContactVerifications, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ContactVerifications)
+	err := c.cc.Invoke(ctx, StorageAuthorityReadOnly_GetContactVerifications_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityReadOnlyClient) GetRegistrationClientIdentity(ctx context.Context, in *RegistrationID, opts ...grpc.CallOption) (*ClientIdentity, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClientIdentity)
+	err := c.cc.Invoke(ctx, StorageAuthorityReadOnly_GetRegistrationClientIdentity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityReadOnlyClient) GetAccountRevocation(ctx context.Context, in *RegistrationID, opts ...grpc.CallOption) (*AccountRevocation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountRevocation)
+	err := c.cc.Invoke(ctx, StorageAuthorityReadOnly_GetAccountRevocation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityReadOnlyClient) GetExemptionRequest(ctx context.Context, in *ExemptionRequestID, opts ...grpc.CallOption) (*ExemptionRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExemptionRequest)
+	err := c.cc.Invoke(ctx, StorageAuthorityReadOnly_GetExemptionRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityReadOnlyClient) GetExemptionRequests(ctx context.Context, in *GetExemptionRequestsRequest, opts ...grpc.CallOption) (*ExemptionRequests, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExemptionRequests)
+	err := c.cc.Invoke(ctx, StorageAuthorityReadOnly_GetExemptionRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityReadOnlyClient) GetValidationFailures(ctx context.Context, in *AuthorizationID2, opts ...grpc.CallOption) (*ValidationFailures, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidationFailures)
+	err := c.cc.Invoke(ctx, StorageAuthorityReadOnly_GetValidationFailures_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityReadOnlyClient) GetAuthzCAARecheck(ctx context.Context, in *AuthorizationID2, opts ...grpc.CallOption) (*AuthzCAARecheck, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthzCAARecheck)
+	err := c.cc.Invoke(ctx, StorageAuthorityReadOnly_GetAuthzCAARecheck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityReadOnlyClient) GetCRLShard(ctx context.Context, in *GetCRLShardRequest, opts ...grpc.CallOption) (*CRLShard, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CRLShard)
+	err := c.cc.Invoke(ctx, StorageAuthorityReadOnly_GetCRLShard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StorageAuthorityReadOnlyServer is the server API for StorageAuthorityReadOnly service.
 // All implementations must embed UnimplementedStorageAuthorityReadOnlyServer
 // for forward compatibility.
@@ -469,17 +631,21 @@ type StorageAuthorityReadOnlyServer interface {
 	GetCertificate(context.Context, *Serial) (*proto.Certificate, error)
 	GetLintPrecertificate(context.Context, *Serial) (*proto.Certificate, error)
 	GetCertificateStatus(context.Context, *Serial) (*proto.CertificateStatus, error)
+	GetCertificatesByExpiry(*GetCertificatesByExpiryRequest, grpc.ServerStreamingServer[proto.Certificate]) error
 	GetMaxExpiration(context.Context, *emptypb.Empty) (*timestamppb.Timestamp, error)
+	GetMPICComplianceStats(context.Context, *GetMPICComplianceStatsRequest) (*MPICComplianceStats, error)
 	GetOrder(context.Context, *OrderRequest) (*proto.Order, error)
 	GetOrderForNames(context.Context, *GetOrderForNamesRequest) (*proto.Order, error)
 	GetRegistration(context.Context, *RegistrationID) (*proto.Registration, error)
 	GetRegistrationByKey(context.Context, *JSONWebKey) (*proto.Registration, error)
 	GetRevocationStatus(context.Context, *Serial) (*RevocationStatus, error)
+	GetRevocationStatuses(context.Context, *Serials) (*RevocationStatuses, error)
 	GetRevokedCerts(*GetRevokedCertsRequest, grpc.ServerStreamingServer[proto.CRLEntry]) error
 	GetRevokedCertsByShard(*GetRevokedCertsByShardRequest, grpc.ServerStreamingServer[proto.CRLEntry]) error
 	GetSerialMetadata(context.Context, *Serial) (*SerialMetadata, error)
 	GetSerialsByAccount(*RegistrationID, grpc.ServerStreamingServer[Serial]) error
 	GetSerialsByKey(*SPKIHash, grpc.ServerStreamingServer[Serial]) error
+	SearchCertificates(*SearchCertificatesRequest, grpc.ServerStreamingServer[SerialMetadata]) error
 	GetValidAuthorizations2(context.Context, *GetValidAuthorizationsRequest) (*Authorizations, error)
 	GetValidOrderAuthorizations2(context.Context, *GetValidOrderAuthorizationsRequest) (*Authorizations, error)
 	IncidentsForSerial(context.Context, *Serial) (*Incidents, error)
@@ -490,6 +656,14 @@ type StorageAuthorityReadOnlyServer interface {
 	GetPausedIdentifiers(context.Context, *RegistrationID) (*Identifiers, error)
 	GetRateLimitOverride(context.Context, *GetRateLimitOverrideRequest) (*RateLimitOverrideResponse, error)
 	GetEnabledRateLimitOverrides(*emptypb.Empty, grpc.ServerStreamingServer[RateLimitOverrideResponse]) error
+	GetContactVerifications(context.Context, *ContactHashes) (*ContactVerifications, error)
+	GetRegistrationClientIdentity(context.Context, *RegistrationID) (*ClientIdentity, error)
+	GetAccountRevocation(context.Context, *RegistrationID) (*AccountRevocation, error)
+	GetExemptionRequest(context.Context, *ExemptionRequestID) (*ExemptionRequest, error)
+	GetExemptionRequests(context.Context, *GetExemptionRequestsRequest) (*ExemptionRequests, error)
+	GetValidationFailures(context.Context, *AuthorizationID2) (*ValidationFailures, error)
+	GetAuthzCAARecheck(context.Context, *AuthorizationID2) (*AuthzCAARecheck, error)
+	GetCRLShard(context.Context, *GetCRLShardRequest) (*CRLShard, error)
 	mustEmbedUnimplementedStorageAuthorityReadOnlyServer()
 }
 
@@ -527,9 +701,15 @@ func (UnimplementedStorageAuthorityReadOnlyServer) GetLintPrecertificate(context
 func (UnimplementedStorageAuthorityReadOnlyServer) GetCertificateStatus(context.Context, *Serial) (*proto.CertificateStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCertificateStatus not implemented")
 }
+func (UnimplementedStorageAuthorityReadOnlyServer) GetCertificatesByExpiry(*GetCertificatesByExpiryRequest, grpc.ServerStreamingServer[proto.Certificate]) error {
+	return status.Errorf(codes.Unimplemented, "method GetCertificatesByExpiry not implemented")
+}
 func (UnimplementedStorageAuthorityReadOnlyServer) GetMaxExpiration(context.Context, *emptypb.Empty) (*timestamppb.Timestamp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMaxExpiration not implemented")
 }
+func (UnimplementedStorageAuthorityReadOnlyServer) GetMPICComplianceStats(context.Context, *GetMPICComplianceStatsRequest) (*MPICComplianceStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMPICComplianceStats not implemented")
+}
 func (UnimplementedStorageAuthorityReadOnlyServer) GetOrder(context.Context, *OrderRequest) (*proto.Order, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
 }
@@ -545,6 +725,9 @@ func (UnimplementedStorageAuthorityReadOnlyServer) GetRegistrationByKey(context.
 func (UnimplementedStorageAuthorityReadOnlyServer) GetRevocationStatus(context.Context, *Serial) (*RevocationStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRevocationStatus not implemented")
 }
+func (UnimplementedStorageAuthorityReadOnlyServer) GetRevocationStatuses(context.Context, *Serials) (*RevocationStatuses, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRevocationStatuses not implemented")
+}
 func (UnimplementedStorageAuthorityReadOnlyServer) GetRevokedCerts(*GetRevokedCertsRequest, grpc.ServerStreamingServer[proto.CRLEntry]) error {
 	return status.Errorf(codes.Unimplemented, "method GetRevokedCerts not implemented")
 }
@@ -560,6 +743,9 @@ func (UnimplementedStorageAuthorityReadOnlyServer) GetSerialsByAccount(*Registra
 func (UnimplementedStorageAuthorityReadOnlyServer) GetSerialsByKey(*SPKIHash, grpc.ServerStreamingServer[Serial]) error {
 	return status.Errorf(codes.Unimplemented, "method GetSerialsByKey not implemented")
 }
+func (UnimplementedStorageAuthorityReadOnlyServer) SearchCertificates(*SearchCertificatesRequest, grpc.ServerStreamingServer[SerialMetadata]) error {
+	return status.Errorf(codes.Unimplemented, "method SearchCertificates not implemented")
+}
 func (UnimplementedStorageAuthorityReadOnlyServer) GetValidAuthorizations2(context.Context, *GetValidAuthorizationsRequest) (*Authorizations, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetValidAuthorizations2 not implemented")
 }
@@ -590,6 +776,30 @@ func (UnimplementedStorageAuthorityReadOnlyServer) GetRateLimitOverride(context.
 func (UnimplementedStorageAuthorityReadOnlyServer) GetEnabledRateLimitOverrides(*emptypb.Empty, grpc.ServerStreamingServer[RateLimitOverrideResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method GetEnabledRateLimitOverrides not implemented")
 }
+func (UnimplementedStorageAuthorityReadOnlyServer) GetContactVerifications(context.Context, *ContactHashes) (*ContactVerifications, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContactVerifications not implemented")
+}
+func (UnimplementedStorageAuthorityReadOnlyServer) GetRegistrationClientIdentity(context.Context, *RegistrationID) (*ClientIdentity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRegistrationClientIdentity not implemented")
+}
+func (UnimplementedStorageAuthorityReadOnlyServer) GetAccountRevocation(context.Context, *RegistrationID) (*AccountRevocation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountRevocation not implemented")
+}
+func (UnimplementedStorageAuthorityReadOnlyServer) GetExemptionRequest(context.Context, *ExemptionRequestID) (*ExemptionRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExemptionRequest not implemented")
+}
+func (UnimplementedStorageAuthorityReadOnlyServer) GetExemptionRequests(context.Context, *GetExemptionRequestsRequest) (*ExemptionRequests, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExemptionRequests not implemented")
+}
+func (UnimplementedStorageAuthorityReadOnlyServer) GetValidationFailures(context.Context, *AuthorizationID2) (*ValidationFailures, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetValidationFailures not implemented")
+}
+func (UnimplementedStorageAuthorityReadOnlyServer) GetAuthzCAARecheck(context.Context, *AuthorizationID2) (*AuthzCAARecheck, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuthzCAARecheck not implemented")
+}
+func (UnimplementedStorageAuthorityReadOnlyServer) GetCRLShard(context.Context, *GetCRLShardRequest) (*CRLShard, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCRLShard not implemented")
+}
 func (UnimplementedStorageAuthorityReadOnlyServer) mustEmbedUnimplementedStorageAuthorityReadOnlyServer() {
 }
 func (UnimplementedStorageAuthorityReadOnlyServer) testEmbeddedByValue() {}
@@ -774,6 +984,17 @@ func _StorageAuthorityReadOnly_GetCertificateStatus_Handler(srv interface{}, ctx
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StorageAuthorityReadOnly_GetCertificatesByExpiry_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetCertificatesByExpiryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageAuthorityReadOnlyServer).GetCertificatesByExpiry(m, &grpc.GenericServerStream[GetCertificatesByExpiryRequest, proto.Certificate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StorageAuthorityReadOnly_GetCertificatesByExpiryServer = grpc.ServerStreamingServer[proto.Certificate]
+
 func _StorageAuthorityReadOnly_GetMaxExpiration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -792,6 +1013,24 @@ func _StorageAuthorityReadOnly_GetMaxExpiration_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StorageAuthorityReadOnly_GetMPICComplianceStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMPICComplianceStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityReadOnlyServer).GetMPICComplianceStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthorityReadOnly_GetMPICComplianceStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityReadOnlyServer).GetMPICComplianceStats(ctx, req.(*GetMPICComplianceStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StorageAuthorityReadOnly_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(OrderRequest)
 	if err := dec(in); err != nil {
@@ -882,6 +1121,24 @@ func _StorageAuthorityReadOnly_GetRevocationStatus_Handler(srv interface{}, ctx
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StorageAuthorityReadOnly_GetRevocationStatuses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Serials)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityReadOnlyServer).GetRevocationStatuses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthorityReadOnly_GetRevocationStatuses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityReadOnlyServer).GetRevocationStatuses(ctx, req.(*Serials))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StorageAuthorityReadOnly_GetRevokedCerts_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(GetRevokedCertsRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -944,6 +1201,17 @@ func _StorageAuthorityReadOnly_GetSerialsByKey_Handler(srv interface{}, stream g
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type StorageAuthorityReadOnly_GetSerialsByKeyServer = grpc.ServerStreamingServer[Serial]
 
+func _StorageAuthorityReadOnly_SearchCertificates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchCertificatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageAuthorityReadOnlyServer).SearchCertificates(m, &grpc.GenericServerStream[SearchCertificatesRequest, SerialMetadata]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StorageAuthorityReadOnly_SearchCertificatesServer = grpc.ServerStreamingServer[SerialMetadata]
+
 func _StorageAuthorityReadOnly_GetValidAuthorizations2_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetValidAuthorizationsRequest)
 	if err := dec(in); err != nil {
@@ -1110,111 +1378,300 @@ func _StorageAuthorityReadOnly_GetEnabledRateLimitOverrides_Handler(srv interfac
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type StorageAuthorityReadOnly_GetEnabledRateLimitOverridesServer = grpc.ServerStreamingServer[RateLimitOverrideResponse]
 
-// StorageAuthorityReadOnly_ServiceDesc is the grpc.ServiceDesc for StorageAuthorityReadOnly service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var StorageAuthorityReadOnly_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "sa.StorageAuthorityReadOnly",
-	HandlerType: (*StorageAuthorityReadOnlyServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CountInvalidAuthorizations2",
-			Handler:    _StorageAuthorityReadOnly_CountInvalidAuthorizations2_Handler,
-		},
-		{
-			MethodName: "CountPendingAuthorizations2",
-			Handler:    _StorageAuthorityReadOnly_CountPendingAuthorizations2_Handler,
-		},
-		{
-			MethodName: "FQDNSetExists",
-			Handler:    _StorageAuthorityReadOnly_FQDNSetExists_Handler,
-		},
-		{
-			MethodName: "FQDNSetTimestampsForWindow",
-			Handler:    _StorageAuthorityReadOnly_FQDNSetTimestampsForWindow_Handler,
-		},
-		{
-			MethodName: "GetAuthorization2",
-			Handler:    _StorageAuthorityReadOnly_GetAuthorization2_Handler,
-		},
-		{
-			MethodName: "GetAuthorizations2",
-			Handler:    _StorageAuthorityReadOnly_GetAuthorizations2_Handler,
-		},
-		{
-			MethodName: "GetCertificate",
-			Handler:    _StorageAuthorityReadOnly_GetCertificate_Handler,
-		},
-		{
-			MethodName: "GetLintPrecertificate",
-			Handler:    _StorageAuthorityReadOnly_GetLintPrecertificate_Handler,
-		},
-		{
-			MethodName: "GetCertificateStatus",
-			Handler:    _StorageAuthorityReadOnly_GetCertificateStatus_Handler,
-		},
-		{
-			MethodName: "GetMaxExpiration",
-			Handler:    _StorageAuthorityReadOnly_GetMaxExpiration_Handler,
-		},
-		{
-			MethodName: "GetOrder",
-			Handler:    _StorageAuthorityReadOnly_GetOrder_Handler,
-		},
-		{
-			MethodName: "GetOrderForNames",
-			Handler:    _StorageAuthorityReadOnly_GetOrderForNames_Handler,
-		},
-		{
-			MethodName: "GetRegistration",
-			Handler:    _StorageAuthorityReadOnly_GetRegistration_Handler,
-		},
-		{
-			MethodName: "GetRegistrationByKey",
-			Handler:    _StorageAuthorityReadOnly_GetRegistrationByKey_Handler,
-		},
-		{
-			MethodName: "GetRevocationStatus",
-			Handler:    _StorageAuthorityReadOnly_GetRevocationStatus_Handler,
-		},
-		{
-			MethodName: "GetSerialMetadata",
-			Handler:    _StorageAuthorityReadOnly_GetSerialMetadata_Handler,
-		},
-		{
-			MethodName: "GetValidAuthorizations2",
-			Handler:    _StorageAuthorityReadOnly_GetValidAuthorizations2_Handler,
-		},
-		{
-			MethodName: "GetValidOrderAuthorizations2",
-			Handler:    _StorageAuthorityReadOnly_GetValidOrderAuthorizations2_Handler,
-		},
-		{
-			MethodName: "IncidentsForSerial",
-			Handler:    _StorageAuthorityReadOnly_IncidentsForSerial_Handler,
-		},
-		{
-			MethodName: "KeyBlocked",
-			Handler:    _StorageAuthorityReadOnly_KeyBlocked_Handler,
-		},
-		{
-			MethodName: "ReplacementOrderExists",
-			Handler:    _StorageAuthorityReadOnly_ReplacementOrderExists_Handler,
-		},
-		{
-			MethodName: "CheckIdentifiersPaused",
-			Handler:    _StorageAuthorityReadOnly_CheckIdentifiersPaused_Handler,
-		},
-		{
-			MethodName: "GetPausedIdentifiers",
-			Handler:    _StorageAuthorityReadOnly_GetPausedIdentifiers_Handler,
-		},
-		{
-			MethodName: "GetRateLimitOverride",
-			Handler:    _StorageAuthorityReadOnly_GetRateLimitOverride_Handler,
-		},
-	},
+func _StorageAuthorityReadOnly_GetContactVerifications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContactHashes)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityReadOnlyServer).GetContactVerifications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthorityReadOnly_GetContactVerifications_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityReadOnlyServer).GetContactVerifications(ctx, req.(*ContactHashes))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthorityReadOnly_GetRegistrationClientIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegistrationID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityReadOnlyServer).GetRegistrationClientIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthorityReadOnly_GetRegistrationClientIdentity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityReadOnlyServer).GetRegistrationClientIdentity(ctx, req.(*RegistrationID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthorityReadOnly_GetAccountRevocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegistrationID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityReadOnlyServer).GetAccountRevocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthorityReadOnly_GetAccountRevocation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityReadOnlyServer).GetAccountRevocation(ctx, req.(*RegistrationID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthorityReadOnly_GetExemptionRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExemptionRequestID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityReadOnlyServer).GetExemptionRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthorityReadOnly_GetExemptionRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityReadOnlyServer).GetExemptionRequest(ctx, req.(*ExemptionRequestID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthorityReadOnly_GetExemptionRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExemptionRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityReadOnlyServer).GetExemptionRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthorityReadOnly_GetExemptionRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityReadOnlyServer).GetExemptionRequests(ctx, req.(*GetExemptionRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthorityReadOnly_GetValidationFailures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorizationID2)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityReadOnlyServer).GetValidationFailures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthorityReadOnly_GetValidationFailures_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityReadOnlyServer).GetValidationFailures(ctx, req.(*AuthorizationID2))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthorityReadOnly_GetAuthzCAARecheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorizationID2)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityReadOnlyServer).GetAuthzCAARecheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthorityReadOnly_GetAuthzCAARecheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityReadOnlyServer).GetAuthzCAARecheck(ctx, req.(*AuthorizationID2))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthorityReadOnly_GetCRLShard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCRLShardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityReadOnlyServer).GetCRLShard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthorityReadOnly_GetCRLShard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityReadOnlyServer).GetCRLShard(ctx, req.(*GetCRLShardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StorageAuthorityReadOnly_ServiceDesc is the grpc.ServiceDesc for StorageAuthorityReadOnly service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StorageAuthorityReadOnly_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sa.StorageAuthorityReadOnly",
+	HandlerType: (*StorageAuthorityReadOnlyServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CountInvalidAuthorizations2",
+			Handler:    _StorageAuthorityReadOnly_CountInvalidAuthorizations2_Handler,
+		},
+		{
+			MethodName: "CountPendingAuthorizations2",
+			Handler:    _StorageAuthorityReadOnly_CountPendingAuthorizations2_Handler,
+		},
+		{
+			MethodName: "FQDNSetExists",
+			Handler:    _StorageAuthorityReadOnly_FQDNSetExists_Handler,
+		},
+		{
+			MethodName: "FQDNSetTimestampsForWindow",
+			Handler:    _StorageAuthorityReadOnly_FQDNSetTimestampsForWindow_Handler,
+		},
+		{
+			MethodName: "GetAuthorization2",
+			Handler:    _StorageAuthorityReadOnly_GetAuthorization2_Handler,
+		},
+		{
+			MethodName: "GetAuthorizations2",
+			Handler:    _StorageAuthorityReadOnly_GetAuthorizations2_Handler,
+		},
+		{
+			MethodName: "GetCertificate",
+			Handler:    _StorageAuthorityReadOnly_GetCertificate_Handler,
+		},
+		{
+			MethodName: "GetLintPrecertificate",
+			Handler:    _StorageAuthorityReadOnly_GetLintPrecertificate_Handler,
+		},
+		{
+			MethodName: "GetCertificateStatus",
+			Handler:    _StorageAuthorityReadOnly_GetCertificateStatus_Handler,
+		},
+		{
+			MethodName: "GetMaxExpiration",
+			Handler:    _StorageAuthorityReadOnly_GetMaxExpiration_Handler,
+		},
+		{
+			MethodName: "GetMPICComplianceStats",
+			Handler:    _StorageAuthorityReadOnly_GetMPICComplianceStats_Handler,
+		},
+		{
+			MethodName: "GetOrder",
+			Handler:    _StorageAuthorityReadOnly_GetOrder_Handler,
+		},
+		{
+			MethodName: "GetOrderForNames",
+			Handler:    _StorageAuthorityReadOnly_GetOrderForNames_Handler,
+		},
+		{
+			MethodName: "GetRegistration",
+			Handler:    _StorageAuthorityReadOnly_GetRegistration_Handler,
+		},
+		{
+			MethodName: "GetRegistrationByKey",
+			Handler:    _StorageAuthorityReadOnly_GetRegistrationByKey_Handler,
+		},
+		{
+			MethodName: "GetRevocationStatus",
+			Handler:    _StorageAuthorityReadOnly_GetRevocationStatus_Handler,
+		},
+		{
+			MethodName: "GetRevocationStatuses",
+			Handler:    _StorageAuthorityReadOnly_GetRevocationStatuses_Handler,
+		},
+		{
+			MethodName: "GetSerialMetadata",
+			Handler:    _StorageAuthorityReadOnly_GetSerialMetadata_Handler,
+		},
+		{
+			MethodName: "GetValidAuthorizations2",
+			Handler:    _StorageAuthorityReadOnly_GetValidAuthorizations2_Handler,
+		},
+		{
+			MethodName: "GetValidOrderAuthorizations2",
+			Handler:    _StorageAuthorityReadOnly_GetValidOrderAuthorizations2_Handler,
+		},
+		{
+			MethodName: "IncidentsForSerial",
+			Handler:    _StorageAuthorityReadOnly_IncidentsForSerial_Handler,
+		},
+		{
+			MethodName: "KeyBlocked",
+			Handler:    _StorageAuthorityReadOnly_KeyBlocked_Handler,
+		},
+		{
+			MethodName: "ReplacementOrderExists",
+			Handler:    _StorageAuthorityReadOnly_ReplacementOrderExists_Handler,
+		},
+		{
+			MethodName: "CheckIdentifiersPaused",
+			Handler:    _StorageAuthorityReadOnly_CheckIdentifiersPaused_Handler,
+		},
+		{
+			MethodName: "GetPausedIdentifiers",
+			Handler:    _StorageAuthorityReadOnly_GetPausedIdentifiers_Handler,
+		},
+		{
+			MethodName: "GetRateLimitOverride",
+			Handler:    _StorageAuthorityReadOnly_GetRateLimitOverride_Handler,
+		},
+		{
+			MethodName: "GetContactVerifications",
+			Handler:    _StorageAuthorityReadOnly_GetContactVerifications_Handler,
+		},
+		{
+			MethodName: "GetRegistrationClientIdentity",
+			Handler:    _StorageAuthorityReadOnly_GetRegistrationClientIdentity_Handler,
+		},
+		{
+			MethodName: "GetAccountRevocation",
+			Handler:    _StorageAuthorityReadOnly_GetAccountRevocation_Handler,
+		},
+		{
+			MethodName: "GetExemptionRequest",
+			Handler:    _StorageAuthorityReadOnly_GetExemptionRequest_Handler,
+		},
+		{
+			MethodName: "GetExemptionRequests",
+			Handler:    _StorageAuthorityReadOnly_GetExemptionRequests_Handler,
+		},
+		{
+			MethodName: "GetValidationFailures",
+			Handler:    _StorageAuthorityReadOnly_GetValidationFailures_Handler,
+		},
+		{
+			MethodName: "GetAuthzCAARecheck",
+			Handler:    _StorageAuthorityReadOnly_GetAuthzCAARecheck_Handler,
+		},
+		{
+			MethodName: "GetCRLShard",
+			Handler:    _StorageAuthorityReadOnly_GetCRLShard_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetCertificatesByExpiry",
+			Handler:       _StorageAuthorityReadOnly_GetCertificatesByExpiry_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "GetRevokedCerts",
 			Handler:       _StorageAuthorityReadOnly_GetRevokedCerts_Handler,
@@ -1235,6 +1692,11 @@ var StorageAuthorityReadOnly_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _StorageAuthorityReadOnly_GetSerialsByKey_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SearchCertificates",
+			Handler:       _StorageAuthorityReadOnly_SearchCertificates_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "SerialsForIncident",
 			Handler:       _StorageAuthorityReadOnly_SerialsForIncident_Handler,
@@ -1250,59 +1712,78 @@ var StorageAuthorityReadOnly_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	StorageAuthority_CountInvalidAuthorizations2_FullMethodName  = "/sa.StorageAuthority/CountInvalidAuthorizations2"
-	StorageAuthority_CountPendingAuthorizations2_FullMethodName  = "/sa.StorageAuthority/CountPendingAuthorizations2"
-	StorageAuthority_FQDNSetExists_FullMethodName                = "/sa.StorageAuthority/FQDNSetExists"
-	StorageAuthority_FQDNSetTimestampsForWindow_FullMethodName   = "/sa.StorageAuthority/FQDNSetTimestampsForWindow"
-	StorageAuthority_GetAuthorization2_FullMethodName            = "/sa.StorageAuthority/GetAuthorization2"
-	StorageAuthority_GetAuthorizations2_FullMethodName           = "/sa.StorageAuthority/GetAuthorizations2"
-	StorageAuthority_GetCertificate_FullMethodName               = "/sa.StorageAuthority/GetCertificate"
-	StorageAuthority_GetLintPrecertificate_FullMethodName        = "/sa.StorageAuthority/GetLintPrecertificate"
-	StorageAuthority_GetCertificateStatus_FullMethodName         = "/sa.StorageAuthority/GetCertificateStatus"
-	StorageAuthority_GetMaxExpiration_FullMethodName             = "/sa.StorageAuthority/GetMaxExpiration"
-	StorageAuthority_GetOrder_FullMethodName                     = "/sa.StorageAuthority/GetOrder"
-	StorageAuthority_GetOrderForNames_FullMethodName             = "/sa.StorageAuthority/GetOrderForNames"
-	StorageAuthority_GetRegistration_FullMethodName              = "/sa.StorageAuthority/GetRegistration"
-	StorageAuthority_GetRegistrationByKey_FullMethodName         = "/sa.StorageAuthority/GetRegistrationByKey"
-	StorageAuthority_GetRevocationStatus_FullMethodName          = "/sa.StorageAuthority/GetRevocationStatus"
-	StorageAuthority_GetRevokedCerts_FullMethodName              = "/sa.StorageAuthority/GetRevokedCerts"
-	StorageAuthority_GetRevokedCertsByShard_FullMethodName       = "/sa.StorageAuthority/GetRevokedCertsByShard"
-	StorageAuthority_GetSerialMetadata_FullMethodName            = "/sa.StorageAuthority/GetSerialMetadata"
-	StorageAuthority_GetSerialsByAccount_FullMethodName          = "/sa.StorageAuthority/GetSerialsByAccount"
-	StorageAuthority_GetSerialsByKey_FullMethodName              = "/sa.StorageAuthority/GetSerialsByKey"
-	StorageAuthority_GetValidAuthorizations2_FullMethodName      = "/sa.StorageAuthority/GetValidAuthorizations2"
-	StorageAuthority_GetValidOrderAuthorizations2_FullMethodName = "/sa.StorageAuthority/GetValidOrderAuthorizations2"
-	StorageAuthority_IncidentsForSerial_FullMethodName           = "/sa.StorageAuthority/IncidentsForSerial"
-	StorageAuthority_KeyBlocked_FullMethodName                   = "/sa.StorageAuthority/KeyBlocked"
-	StorageAuthority_ReplacementOrderExists_FullMethodName       = "/sa.StorageAuthority/ReplacementOrderExists"
-	StorageAuthority_SerialsForIncident_FullMethodName           = "/sa.StorageAuthority/SerialsForIncident"
-	StorageAuthority_CheckIdentifiersPaused_FullMethodName       = "/sa.StorageAuthority/CheckIdentifiersPaused"
-	StorageAuthority_GetPausedIdentifiers_FullMethodName         = "/sa.StorageAuthority/GetPausedIdentifiers"
-	StorageAuthority_GetRateLimitOverride_FullMethodName         = "/sa.StorageAuthority/GetRateLimitOverride"
-	StorageAuthority_GetEnabledRateLimitOverrides_FullMethodName = "/sa.StorageAuthority/GetEnabledRateLimitOverrides"
-	StorageAuthority_AddBlockedKey_FullMethodName                = "/sa.StorageAuthority/AddBlockedKey"
-	StorageAuthority_AddCertificate_FullMethodName               = "/sa.StorageAuthority/AddCertificate"
-	StorageAuthority_AddPrecertificate_FullMethodName            = "/sa.StorageAuthority/AddPrecertificate"
-	StorageAuthority_SetCertificateStatusReady_FullMethodName    = "/sa.StorageAuthority/SetCertificateStatusReady"
-	StorageAuthority_AddSerial_FullMethodName                    = "/sa.StorageAuthority/AddSerial"
-	StorageAuthority_DeactivateAuthorization2_FullMethodName     = "/sa.StorageAuthority/DeactivateAuthorization2"
-	StorageAuthority_DeactivateRegistration_FullMethodName       = "/sa.StorageAuthority/DeactivateRegistration"
-	StorageAuthority_FinalizeAuthorization2_FullMethodName       = "/sa.StorageAuthority/FinalizeAuthorization2"
-	StorageAuthority_FinalizeOrder_FullMethodName                = "/sa.StorageAuthority/FinalizeOrder"
-	StorageAuthority_NewOrderAndAuthzs_FullMethodName            = "/sa.StorageAuthority/NewOrderAndAuthzs"
-	StorageAuthority_NewRegistration_FullMethodName              = "/sa.StorageAuthority/NewRegistration"
-	StorageAuthority_RevokeCertificate_FullMethodName            = "/sa.StorageAuthority/RevokeCertificate"
-	StorageAuthority_SetOrderError_FullMethodName                = "/sa.StorageAuthority/SetOrderError"
-	StorageAuthority_SetOrderProcessing_FullMethodName           = "/sa.StorageAuthority/SetOrderProcessing"
-	StorageAuthority_UpdateRegistrationKey_FullMethodName        = "/sa.StorageAuthority/UpdateRegistrationKey"
-	StorageAuthority_UpdateRevokedCertificate_FullMethodName     = "/sa.StorageAuthority/UpdateRevokedCertificate"
-	StorageAuthority_LeaseCRLShard_FullMethodName                = "/sa.StorageAuthority/LeaseCRLShard"
-	StorageAuthority_UpdateCRLShard_FullMethodName               = "/sa.StorageAuthority/UpdateCRLShard"
-	StorageAuthority_PauseIdentifiers_FullMethodName             = "/sa.StorageAuthority/PauseIdentifiers"
-	StorageAuthority_UnpauseAccount_FullMethodName               = "/sa.StorageAuthority/UnpauseAccount"
-	StorageAuthority_AddRateLimitOverride_FullMethodName         = "/sa.StorageAuthority/AddRateLimitOverride"
-	StorageAuthority_DisableRateLimitOverride_FullMethodName     = "/sa.StorageAuthority/DisableRateLimitOverride"
-	StorageAuthority_EnableRateLimitOverride_FullMethodName      = "/sa.StorageAuthority/EnableRateLimitOverride"
+	StorageAuthority_CountInvalidAuthorizations2_FullMethodName   = "/sa.StorageAuthority/CountInvalidAuthorizations2"
+	StorageAuthority_CountPendingAuthorizations2_FullMethodName   = "/sa.StorageAuthority/CountPendingAuthorizations2"
+	StorageAuthority_FQDNSetExists_FullMethodName                 = "/sa.StorageAuthority/FQDNSetExists"
+	StorageAuthority_FQDNSetTimestampsForWindow_FullMethodName    = "/sa.StorageAuthority/FQDNSetTimestampsForWindow"
+	StorageAuthority_GetAuthorization2_FullMethodName             = "/sa.StorageAuthority/GetAuthorization2"
+	StorageAuthority_GetAuthorizations2_FullMethodName            = "/sa.StorageAuthority/GetAuthorizations2"
+	StorageAuthority_GetCertificate_FullMethodName                = "/sa.StorageAuthority/GetCertificate"
+	StorageAuthority_GetLintPrecertificate_FullMethodName         = "/sa.StorageAuthority/GetLintPrecertificate"
+	StorageAuthority_GetCertificateStatus_FullMethodName          = "/sa.StorageAuthority/GetCertificateStatus"
+	StorageAuthority_GetCertificatesByExpiry_FullMethodName       = "/sa.StorageAuthority/GetCertificatesByExpiry"
+	StorageAuthority_GetMaxExpiration_FullMethodName              = "/sa.StorageAuthority/GetMaxExpiration"
+	StorageAuthority_GetMPICComplianceStats_FullMethodName        = "/sa.StorageAuthority/GetMPICComplianceStats"
+	StorageAuthority_GetOrder_FullMethodName                      = "/sa.StorageAuthority/GetOrder"
+	StorageAuthority_GetOrderForNames_FullMethodName              = "/sa.StorageAuthority/GetOrderForNames"
+	StorageAuthority_GetRegistration_FullMethodName               = "/sa.StorageAuthority/GetRegistration"
+	StorageAuthority_GetRegistrationByKey_FullMethodName          = "/sa.StorageAuthority/GetRegistrationByKey"
+	StorageAuthority_GetRevocationStatus_FullMethodName           = "/sa.StorageAuthority/GetRevocationStatus"
+	StorageAuthority_GetRevocationStatuses_FullMethodName         = "/sa.StorageAuthority/GetRevocationStatuses"
+	StorageAuthority_GetRevokedCerts_FullMethodName               = "/sa.StorageAuthority/GetRevokedCerts"
+	StorageAuthority_GetRevokedCertsByShard_FullMethodName        = "/sa.StorageAuthority/GetRevokedCertsByShard"
+	StorageAuthority_GetSerialMetadata_FullMethodName             = "/sa.StorageAuthority/GetSerialMetadata"
+	StorageAuthority_GetSerialsByAccount_FullMethodName           = "/sa.StorageAuthority/GetSerialsByAccount"
+	StorageAuthority_GetSerialsByKey_FullMethodName               = "/sa.StorageAuthority/GetSerialsByKey"
+	StorageAuthority_SearchCertificates_FullMethodName            = "/sa.StorageAuthority/SearchCertificates"
+	StorageAuthority_GetValidAuthorizations2_FullMethodName       = "/sa.StorageAuthority/GetValidAuthorizations2"
+	StorageAuthority_GetValidOrderAuthorizations2_FullMethodName  = "/sa.StorageAuthority/GetValidOrderAuthorizations2"
+	StorageAuthority_IncidentsForSerial_FullMethodName            = "/sa.StorageAuthority/IncidentsForSerial"
+	StorageAuthority_KeyBlocked_FullMethodName                    = "/sa.StorageAuthority/KeyBlocked"
+	StorageAuthority_ReplacementOrderExists_FullMethodName        = "/sa.StorageAuthority/ReplacementOrderExists"
+	StorageAuthority_SerialsForIncident_FullMethodName            = "/sa.StorageAuthority/SerialsForIncident"
+	StorageAuthority_CheckIdentifiersPaused_FullMethodName        = "/sa.StorageAuthority/CheckIdentifiersPaused"
+	StorageAuthority_GetPausedIdentifiers_FullMethodName          = "/sa.StorageAuthority/GetPausedIdentifiers"
+	StorageAuthority_GetRateLimitOverride_FullMethodName          = "/sa.StorageAuthority/GetRateLimitOverride"
+	StorageAuthority_GetEnabledRateLimitOverrides_FullMethodName  = "/sa.StorageAuthority/GetEnabledRateLimitOverrides"
+	StorageAuthority_GetContactVerifications_FullMethodName       = "/sa.StorageAuthority/GetContactVerifications"
+	StorageAuthority_GetRegistrationClientIdentity_FullMethodName = "/sa.StorageAuthority/GetRegistrationClientIdentity"
+	StorageAuthority_GetAccountRevocation_FullMethodName          = "/sa.StorageAuthority/GetAccountRevocation"
+	StorageAuthority_GetExemptionRequest_FullMethodName           = "/sa.StorageAuthority/GetExemptionRequest"
+	StorageAuthority_GetExemptionRequests_FullMethodName          = "/sa.StorageAuthority/GetExemptionRequests"
+	StorageAuthority_GetValidationFailures_FullMethodName         = "/sa.StorageAuthority/GetValidationFailures"
+	StorageAuthority_GetAuthzCAARecheck_FullMethodName            = "/sa.StorageAuthority/GetAuthzCAARecheck"
+	StorageAuthority_GetCRLShard_FullMethodName                   = "/sa.StorageAuthority/GetCRLShard"
+	StorageAuthority_AddBlockedKey_FullMethodName                 = "/sa.StorageAuthority/AddBlockedKey"
+	StorageAuthority_AddCertificate_FullMethodName                = "/sa.StorageAuthority/AddCertificate"
+	StorageAuthority_AddPrecertificate_FullMethodName             = "/sa.StorageAuthority/AddPrecertificate"
+	StorageAuthority_SetCertificateStatusReady_FullMethodName     = "/sa.StorageAuthority/SetCertificateStatusReady"
+	StorageAuthority_AddSerial_FullMethodName                     = "/sa.StorageAuthority/AddSerial"
+	StorageAuthority_DeactivateAuthorization2_FullMethodName      = "/sa.StorageAuthority/DeactivateAuthorization2"
+	StorageAuthority_DeactivateRegistration_FullMethodName        = "/sa.StorageAuthority/DeactivateRegistration"
+	StorageAuthority_FinalizeAuthorization2_FullMethodName        = "/sa.StorageAuthority/FinalizeAuthorization2"
+	StorageAuthority_FinalizeOrder_FullMethodName                 = "/sa.StorageAuthority/FinalizeOrder"
+	StorageAuthority_NewOrderAndAuthzs_FullMethodName             = "/sa.StorageAuthority/NewOrderAndAuthzs"
+	StorageAuthority_NewRegistration_FullMethodName               = "/sa.StorageAuthority/NewRegistration"
+	StorageAuthority_RevokeCertificate_FullMethodName             = "/sa.StorageAuthority/RevokeCertificate"
+	StorageAuthority_SetOrderError_FullMethodName                 = "/sa.StorageAuthority/SetOrderError"
+	StorageAuthority_SetOrderProcessing_FullMethodName            = "/sa.StorageAuthority/SetOrderProcessing"
+	StorageAuthority_UpdateRegistrationKey_FullMethodName         = "/sa.StorageAuthority/UpdateRegistrationKey"
+	StorageAuthority_UpdateRevokedCertificate_FullMethodName      = "/sa.StorageAuthority/UpdateRevokedCertificate"
+	StorageAuthority_LeaseCRLShard_FullMethodName                 = "/sa.StorageAuthority/LeaseCRLShard"
+	StorageAuthority_UpdateCRLShard_FullMethodName                = "/sa.StorageAuthority/UpdateCRLShard"
+	StorageAuthority_PauseIdentifiers_FullMethodName              = "/sa.StorageAuthority/PauseIdentifiers"
+	StorageAuthority_UnpauseAccount_FullMethodName                = "/sa.StorageAuthority/UnpauseAccount"
+	StorageAuthority_AddRateLimitOverride_FullMethodName          = "/sa.StorageAuthority/AddRateLimitOverride"
+	StorageAuthority_DisableRateLimitOverride_FullMethodName      = "/sa.StorageAuthority/DisableRateLimitOverride"
+	StorageAuthority_EnableRateLimitOverride_FullMethodName       = "/sa.StorageAuthority/EnableRateLimitOverride"
+	StorageAuthority_SetContactVerification_FullMethodName        = "/sa.StorageAuthority/SetContactVerification"
+	StorageAuthority_SetAccountRevocation_FullMethodName          = "/sa.StorageAuthority/SetAccountRevocation"
+	StorageAuthority_AddExemptionRequest_FullMethodName           = "/sa.StorageAuthority/AddExemptionRequest"
+	StorageAuthority_ReviewExemptionRequest_FullMethodName        = "/sa.StorageAuthority/ReviewExemptionRequest"
+	StorageAuthority_AddValidationFailure_FullMethodName          = "/sa.StorageAuthority/AddValidationFailure"
+	StorageAuthority_LeaseAccountRevocation_FullMethodName        = "/sa.StorageAuthority/LeaseAccountRevocation"
+	StorageAuthority_SetAuthzCAARecheck_FullMethodName            = "/sa.StorageAuthority/SetAuthzCAARecheck"
 )
 
 // StorageAuthorityClient is the client API for StorageAuthority service.
@@ -1321,17 +1802,21 @@ type StorageAuthorityClient interface {
 	GetCertificate(ctx context.Context, in *Serial, opts ...grpc.CallOption) (*proto.Certificate, error)
 	GetLintPrecertificate(ctx context.Context, in *Serial, opts ...grpc.CallOption) (*proto.Certificate, error)
 	GetCertificateStatus(ctx context.Context, in *Serial, opts ...grpc.CallOption) (*proto.CertificateStatus, error)
+	GetCertificatesByExpiry(ctx context.Context, in *GetCertificatesByExpiryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[proto.Certificate], error)
 	GetMaxExpiration(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*timestamppb.Timestamp, error)
+	GetMPICComplianceStats(ctx context.Context, in *GetMPICComplianceStatsRequest, opts ...grpc.CallOption) (*MPICComplianceStats, error)
 	GetOrder(ctx context.Context, in *OrderRequest, opts ...grpc.CallOption) (*proto.Order, error)
 	GetOrderForNames(ctx context.Context, in *GetOrderForNamesRequest, opts ...grpc.CallOption) (*proto.Order, error)
 	GetRegistration(ctx context.Context, in *RegistrationID, opts ...grpc.CallOption) (*proto.Registration, error)
 	GetRegistrationByKey(ctx context.Context, in *JSONWebKey, opts ...grpc.CallOption) (*proto.Registration, error)
 	GetRevocationStatus(ctx context.Context, in *Serial, opts ...grpc.CallOption) (*RevocationStatus, error)
+	GetRevocationStatuses(ctx context.Context, in *Serials, opts ...grpc.CallOption) (*RevocationStatuses, error)
 	GetRevokedCerts(ctx context.Context, in *GetRevokedCertsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[proto.CRLEntry], error)
 	GetRevokedCertsByShard(ctx context.Context, in *GetRevokedCertsByShardRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[proto.CRLEntry], error)
 	GetSerialMetadata(ctx context.Context, in *Serial, opts ...grpc.CallOption) (*SerialMetadata, error)
 	GetSerialsByAccount(ctx context.Context, in *RegistrationID, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Serial], error)
 	GetSerialsByKey(ctx context.Context, in *SPKIHash, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Serial], error)
+	SearchCertificates(ctx context.Context, in *SearchCertificatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SerialMetadata], error)
 	GetValidAuthorizations2(ctx context.Context, in *GetValidAuthorizationsRequest, opts ...grpc.CallOption) (*Authorizations, error)
 	GetValidOrderAuthorizations2(ctx context.Context, in *GetValidOrderAuthorizationsRequest, opts ...grpc.CallOption) (*Authorizations, error)
 	IncidentsForSerial(ctx context.Context, in *Serial, opts ...grpc.CallOption) (*Incidents, error)
@@ -1342,6 +1827,14 @@ type StorageAuthorityClient interface {
 	GetPausedIdentifiers(ctx context.Context, in *RegistrationID, opts ...grpc.CallOption) (*Identifiers, error)
 	GetRateLimitOverride(ctx context.Context, in *GetRateLimitOverrideRequest, opts ...grpc.CallOption) (*RateLimitOverrideResponse, error)
 	GetEnabledRateLimitOverrides(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RateLimitOverrideResponse], error)
+	GetContactVerifications(ctx context.Context, in *ContactHashes, opts ...grpc.CallOption) (*ContactVerifications, error)
+	GetRegistrationClientIdentity(ctx context.Context, in *RegistrationID, opts ...grpc.CallOption) (*ClientIdentity, error)
+	GetAccountRevocation(ctx context.Context, in *RegistrationID, opts ...grpc.CallOption) (*AccountRevocation, error)
+	GetExemptionRequest(ctx context.Context, in *ExemptionRequestID, opts ...grpc.CallOption) (*ExemptionRequest, error)
+	GetExemptionRequests(ctx context.Context, in *GetExemptionRequestsRequest, opts ...grpc.CallOption) (*ExemptionRequests, error)
+	GetValidationFailures(ctx context.Context, in *AuthorizationID2, opts ...grpc.CallOption) (*ValidationFailures, error)
+	GetAuthzCAARecheck(ctx context.Context, in *AuthorizationID2, opts ...grpc.CallOption) (*AuthzCAARecheck, error)
+	GetCRLShard(ctx context.Context, in *GetCRLShardRequest, opts ...grpc.CallOption) (*CRLShard, error)
 	// Adders
 	AddBlockedKey(ctx context.Context, in *AddBlockedKeyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	AddCertificate(ctx context.Context, in *AddCertificateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
@@ -1349,14 +1842,14 @@ type StorageAuthorityClient interface {
 	SetCertificateStatusReady(ctx context.Context, in *Serial, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	AddSerial(ctx context.Context, in *AddSerialRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	DeactivateAuthorization2(ctx context.Context, in *AuthorizationID2, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	DeactivateRegistration(ctx context.Context, in *RegistrationID, opts ...grpc.CallOption) (*proto.Registration, error)
+	DeactivateRegistration(ctx context.Context, in *DeactivateRegistrationRequest, opts ...grpc.CallOption) (*proto.Registration, error)
 	FinalizeAuthorization2(ctx context.Context, in *FinalizeAuthorizationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	FinalizeOrder(ctx context.Context, in *FinalizeOrderRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	NewOrderAndAuthzs(ctx context.Context, in *NewOrderAndAuthzsRequest, opts ...grpc.CallOption) (*proto.Order, error)
 	NewRegistration(ctx context.Context, in *proto.Registration, opts ...grpc.CallOption) (*proto.Registration, error)
 	RevokeCertificate(ctx context.Context, in *RevokeCertificateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	SetOrderError(ctx context.Context, in *SetOrderErrorRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	SetOrderProcessing(ctx context.Context, in *OrderRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SetOrderProcessing(ctx context.Context, in *SetOrderProcessingRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	UpdateRegistrationKey(ctx context.Context, in *UpdateRegistrationKeyRequest, opts ...grpc.CallOption) (*proto.Registration, error)
 	UpdateRevokedCertificate(ctx context.Context, in *RevokeCertificateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	LeaseCRLShard(ctx context.Context, in *LeaseCRLShardRequest, opts ...grpc.CallOption) (*LeaseCRLShardResponse, error)
@@ -1366,6 +1859,13 @@ type StorageAuthorityClient interface {
 	AddRateLimitOverride(ctx context.Context, in *AddRateLimitOverrideRequest, opts ...grpc.CallOption) (*AddRateLimitOverrideResponse, error)
 	DisableRateLimitOverride(ctx context.Context, in *DisableRateLimitOverrideRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	EnableRateLimitOverride(ctx context.Context, in *EnableRateLimitOverrideRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SetContactVerification(ctx context.Context, in *ContactVerification, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SetAccountRevocation(ctx context.Context, in *AccountRevocation, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	AddExemptionRequest(ctx context.Context, in *ExemptionRequest, opts ...grpc.CallOption) (*ExemptionRequest, error)
+	ReviewExemptionRequest(ctx context.Context, in *ReviewExemptionRequestRequest, opts ...grpc.CallOption) (*ExemptionRequest, error)
+	AddValidationFailure(ctx context.Context, in *AddValidationFailureRequest, opts ...grpc.CallOption) (*ValidationFailures, error)
+	LeaseAccountRevocation(ctx context.Context, in *LeaseAccountRevocationRequest, opts ...grpc.CallOption) (*AccountRevocation, error)
+	SetAuthzCAARecheck(ctx context.Context, in *SetAuthzCAARecheckRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type storageAuthorityClient struct {
@@ -1466,6 +1966,25 @@ func (c *storageAuthorityClient) GetCertificateStatus(ctx context.Context, in *S
 	return out, nil
 }
 
+func (c *storageAuthorityClient) GetCertificatesByExpiry(ctx context.Context, in *GetCertificatesByExpiryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[proto.Certificate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StorageAuthority_ServiceDesc.Streams[0], StorageAuthority_GetCertificatesByExpiry_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetCertificatesByExpiryRequest, proto.Certificate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StorageAuthority_GetCertificatesByExpiryClient = grpc.ServerStreamingClient[proto.Certificate]
+
 func (c *storageAuthorityClient) GetMaxExpiration(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*timestamppb.Timestamp, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(timestamppb.Timestamp)
@@ -1476,6 +1995,16 @@ func (c *storageAuthorityClient) GetMaxExpiration(ctx context.Context, in *empty
 	return out, nil
 }
 
+func (c *storageAuthorityClient) GetMPICComplianceStats(ctx context.Context, in *GetMPICComplianceStatsRequest, opts ...grpc.CallOption) (*MPICComplianceStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MPICComplianceStats)
+	err := c.cc.Invoke(ctx, StorageAuthority_GetMPICComplianceStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *storageAuthorityClient) GetOrder(ctx context.Context, in *OrderRequest, opts ...grpc.CallOption) (*proto.Order, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(proto.Order)
@@ -1526,9 +2055,19 @@ func (c *storageAuthorityClient) GetRevocationStatus(ctx context.Context, in *Se
 	return out, nil
 }
 
+func (c *storageAuthorityClient) GetRevocationStatuses(ctx context.Context, in *Serials, opts ...grpc.CallOption) (*RevocationStatuses, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevocationStatuses)
+	err := c.cc.Invoke(ctx, StorageAuthority_GetRevocationStatuses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *storageAuthorityClient) GetRevokedCerts(ctx context.Context, in *GetRevokedCertsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[proto.CRLEntry], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &StorageAuthority_ServiceDesc.Streams[0], StorageAuthority_GetRevokedCerts_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &StorageAuthority_ServiceDesc.Streams[1], StorageAuthority_GetRevokedCerts_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1547,7 +2086,7 @@ type StorageAuthority_GetRevokedCertsClient = grpc.ServerStreamingClient[proto.C
 
 func (c *storageAuthorityClient) GetRevokedCertsByShard(ctx context.Context, in *GetRevokedCertsByShardRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[proto.CRLEntry], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &StorageAuthority_ServiceDesc.Streams[1], StorageAuthority_GetRevokedCertsByShard_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &StorageAuthority_ServiceDesc.Streams[2], StorageAuthority_GetRevokedCertsByShard_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1576,7 +2115,7 @@ func (c *storageAuthorityClient) GetSerialMetadata(ctx context.Context, in *Seri
 
 func (c *storageAuthorityClient) GetSerialsByAccount(ctx context.Context, in *RegistrationID, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Serial], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &StorageAuthority_ServiceDesc.Streams[2], StorageAuthority_GetSerialsByAccount_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &StorageAuthority_ServiceDesc.Streams[3], StorageAuthority_GetSerialsByAccount_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1595,7 +2134,7 @@ type StorageAuthority_GetSerialsByAccountClient = grpc.ServerStreamingClient[Ser
 
 func (c *storageAuthorityClient) GetSerialsByKey(ctx context.Context, in *SPKIHash, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Serial], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &StorageAuthority_ServiceDesc.Streams[3], StorageAuthority_GetSerialsByKey_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &StorageAuthority_ServiceDesc.Streams[4], StorageAuthority_GetSerialsByKey_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1612,6 +2151,25 @@ func (c *storageAuthorityClient) GetSerialsByKey(ctx context.Context, in *SPKIHa
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type StorageAuthority_GetSerialsByKeyClient = grpc.ServerStreamingClient[Serial]
 
+func (c *storageAuthorityClient) SearchCertificates(ctx context.Context, in *SearchCertificatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SerialMetadata], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StorageAuthority_ServiceDesc.Streams[5], StorageAuthority_SearchCertificates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SearchCertificatesRequest, SerialMetadata]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StorageAuthority_SearchCertificatesClient = grpc.ServerStreamingClient[SerialMetadata]
+
 func (c *storageAuthorityClient) GetValidAuthorizations2(ctx context.Context, in *GetValidAuthorizationsRequest, opts ...grpc.CallOption) (*Authorizations, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Authorizations)
@@ -1664,7 +2222,7 @@ func (c *storageAuthorityClient) ReplacementOrderExists(ctx context.Context, in
 
 func (c *storageAuthorityClient) SerialsForIncident(ctx context.Context, in *SerialsForIncidentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[IncidentSerial], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &StorageAuthority_ServiceDesc.Streams[4], StorageAuthority_SerialsForIncident_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &StorageAuthority_ServiceDesc.Streams[6], StorageAuthority_SerialsForIncident_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1713,7 +2271,7 @@ func (c *storageAuthorityClient) GetRateLimitOverride(ctx context.Context, in *G
 
 func (c *storageAuthorityClient) GetEnabledRateLimitOverrides(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RateLimitOverrideResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &StorageAuthority_ServiceDesc.Streams[5], StorageAuthority_GetEnabledRateLimitOverrides_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &StorageAuthority_ServiceDesc.Streams[7], StorageAuthority_GetEnabledRateLimitOverrides_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1730,6 +2288,86 @@ func (c *storageAuthorityClient) GetEnabledRateLimitOverrides(ctx context.Contex
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type StorageAuthority_GetEnabledRateLimitOverridesClient = grpc.ServerStreamingClient[RateLimitOverrideResponse]
 
+func (c *storageAuthorityClient) GetContactVerifications(ctx context.Context, in *ContactHashes, opts ...grpc.CallOption) (*ContactVerifications, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ContactVerifications)
+	err := c.cc.Invoke(ctx, StorageAuthority_GetContactVerifications_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityClient) GetRegistrationClientIdentity(ctx context.Context, in *RegistrationID, opts ...grpc.CallOption) (*ClientIdentity, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClientIdentity)
+	err := c.cc.Invoke(ctx, StorageAuthority_GetRegistrationClientIdentity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityClient) GetAccountRevocation(ctx context.Context, in *RegistrationID, opts ...grpc.CallOption) (*AccountRevocation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountRevocation)
+	err := c.cc.Invoke(ctx, StorageAuthority_GetAccountRevocation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityClient) GetExemptionRequest(ctx context.Context, in *ExemptionRequestID, opts ...grpc.CallOption) (*ExemptionRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExemptionRequest)
+	err := c.cc.Invoke(ctx, StorageAuthority_GetExemptionRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityClient) GetExemptionRequests(ctx context.Context, in *GetExemptionRequestsRequest, opts ...grpc.CallOption) (*ExemptionRequests, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExemptionRequests)
+	err := c.cc.Invoke(ctx, StorageAuthority_GetExemptionRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityClient) GetValidationFailures(ctx context.Context, in *AuthorizationID2, opts ...grpc.CallOption) (*ValidationFailures, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidationFailures)
+	err := c.cc.Invoke(ctx, StorageAuthority_GetValidationFailures_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityClient) GetAuthzCAARecheck(ctx context.Context, in *AuthorizationID2, opts ...grpc.CallOption) (*AuthzCAARecheck, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthzCAARecheck)
+	err := c.cc.Invoke(ctx, StorageAuthority_GetAuthzCAARecheck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityClient) GetCRLShard(ctx context.Context, in *GetCRLShardRequest, opts ...grpc.CallOption) (*CRLShard, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CRLShard)
+	err := c.cc.Invoke(ctx, StorageAuthority_GetCRLShard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *storageAuthorityClient) AddBlockedKey(ctx context.Context, in *AddBlockedKeyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -1790,7 +2428,7 @@ func (c *storageAuthorityClient) DeactivateAuthorization2(ctx context.Context, i
 	return out, nil
 }
 
-func (c *storageAuthorityClient) DeactivateRegistration(ctx context.Context, in *RegistrationID, opts ...grpc.CallOption) (*proto.Registration, error) {
+func (c *storageAuthorityClient) DeactivateRegistration(ctx context.Context, in *DeactivateRegistrationRequest, opts ...grpc.CallOption) (*proto.Registration, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(proto.Registration)
 	err := c.cc.Invoke(ctx, StorageAuthority_DeactivateRegistration_FullMethodName, in, out, cOpts...)
@@ -1860,7 +2498,7 @@ func (c *storageAuthorityClient) SetOrderError(ctx context.Context, in *SetOrder
 	return out, nil
 }
 
-func (c *storageAuthorityClient) SetOrderProcessing(ctx context.Context, in *OrderRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *storageAuthorityClient) SetOrderProcessing(ctx context.Context, in *SetOrderProcessingRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, StorageAuthority_SetOrderProcessing_FullMethodName, in, out, cOpts...)
@@ -1960,6 +2598,76 @@ func (c *storageAuthorityClient) EnableRateLimitOverride(ctx context.Context, in
 	return out, nil
 }
 
+func (c *storageAuthorityClient) SetContactVerification(ctx context.Context, in *ContactVerification, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, StorageAuthority_SetContactVerification_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityClient) SetAccountRevocation(ctx context.Context, in *AccountRevocation, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, StorageAuthority_SetAccountRevocation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityClient) AddExemptionRequest(ctx context.Context, in *ExemptionRequest, opts ...grpc.CallOption) (*ExemptionRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExemptionRequest)
+	err := c.cc.Invoke(ctx, StorageAuthority_AddExemptionRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityClient) ReviewExemptionRequest(ctx context.Context, in *ReviewExemptionRequestRequest, opts ...grpc.CallOption) (*ExemptionRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExemptionRequest)
+	err := c.cc.Invoke(ctx, StorageAuthority_ReviewExemptionRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityClient) AddValidationFailure(ctx context.Context, in *AddValidationFailureRequest, opts ...grpc.CallOption) (*ValidationFailures, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidationFailures)
+	err := c.cc.Invoke(ctx, StorageAuthority_AddValidationFailure_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityClient) LeaseAccountRevocation(ctx context.Context, in *LeaseAccountRevocationRequest, opts ...grpc.CallOption) (*AccountRevocation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountRevocation)
+	err := c.cc.Invoke(ctx, StorageAuthority_LeaseAccountRevocation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityClient) SetAuthzCAARecheck(ctx context.Context, in *SetAuthzCAARecheckRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, StorageAuthority_SetAuthzCAARecheck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StorageAuthorityServer is the server API for StorageAuthority service.
 // All implementations must embed UnimplementedStorageAuthorityServer
 // for forward compatibility.
@@ -1976,17 +2684,21 @@ type StorageAuthorityServer interface {
 	GetCertificate(context.Context, *Serial) (*proto.Certificate, error)
 	GetLintPrecertificate(context.Context, *Serial) (*proto.Certificate, error)
 	GetCertificateStatus(context.Context, *Serial) (*proto.CertificateStatus, error)
+	GetCertificatesByExpiry(*GetCertificatesByExpiryRequest, grpc.ServerStreamingServer[proto.Certificate]) error
 	GetMaxExpiration(context.Context, *emptypb.Empty) (*timestamppb.Timestamp, error)
+	GetMPICComplianceStats(context.Context, *GetMPICComplianceStatsRequest) (*MPICComplianceStats, error)
 	GetOrder(context.Context, *OrderRequest) (*proto.Order, error)
 	GetOrderForNames(context.Context, *GetOrderForNamesRequest) (*proto.Order, error)
 	GetRegistration(context.Context, *RegistrationID) (*proto.Registration, error)
 	GetRegistrationByKey(context.Context, *JSONWebKey) (*proto.Registration, error)
 	GetRevocationStatus(context.Context, *Serial) (*RevocationStatus, error)
+	GetRevocationStatuses(context.Context, *Serials) (*RevocationStatuses, error)
 	GetRevokedCerts(*GetRevokedCertsRequest, grpc.ServerStreamingServer[proto.CRLEntry]) error
 	GetRevokedCertsByShard(*GetRevokedCertsByShardRequest, grpc.ServerStreamingServer[proto.CRLEntry]) error
 	GetSerialMetadata(context.Context, *Serial) (*SerialMetadata, error)
 	GetSerialsByAccount(*RegistrationID, grpc.ServerStreamingServer[Serial]) error
 	GetSerialsByKey(*SPKIHash, grpc.ServerStreamingServer[Serial]) error
+	SearchCertificates(*SearchCertificatesRequest, grpc.ServerStreamingServer[SerialMetadata]) error
 	GetValidAuthorizations2(context.Context, *GetValidAuthorizationsRequest) (*Authorizations, error)
 	GetValidOrderAuthorizations2(context.Context, *GetValidOrderAuthorizationsRequest) (*Authorizations, error)
 	IncidentsForSerial(context.Context, *Serial) (*Incidents, error)
@@ -1997,6 +2709,14 @@ type StorageAuthorityServer interface {
 	GetPausedIdentifiers(context.Context, *RegistrationID) (*Identifiers, error)
 	GetRateLimitOverride(context.Context, *GetRateLimitOverrideRequest) (*RateLimitOverrideResponse, error)
 	GetEnabledRateLimitOverrides(*emptypb.Empty, grpc.ServerStreamingServer[RateLimitOverrideResponse]) error
+	GetContactVerifications(context.Context, *ContactHashes) (*ContactVerifications, error)
+	GetRegistrationClientIdentity(context.Context, *RegistrationID) (*ClientIdentity, error)
+	GetAccountRevocation(context.Context, *RegistrationID) (*AccountRevocation, error)
+	GetExemptionRequest(context.Context, *ExemptionRequestID) (*ExemptionRequest, error)
+	GetExemptionRequests(context.Context, *GetExemptionRequestsRequest) (*ExemptionRequests, error)
+	GetValidationFailures(context.Context, *AuthorizationID2) (*ValidationFailures, error)
+	GetAuthzCAARecheck(context.Context, *AuthorizationID2) (*AuthzCAARecheck, error)
+	GetCRLShard(context.Context, *GetCRLShardRequest) (*CRLShard, error)
 	// Adders
 	AddBlockedKey(context.Context, *AddBlockedKeyRequest) (*emptypb.Empty, error)
 	AddCertificate(context.Context, *AddCertificateRequest) (*emptypb.Empty, error)
@@ -2004,14 +2724,14 @@ type StorageAuthorityServer interface {
 	SetCertificateStatusReady(context.Context, *Serial) (*emptypb.Empty, error)
 	AddSerial(context.Context, *AddSerialRequest) (*emptypb.Empty, error)
 	DeactivateAuthorization2(context.Context, *AuthorizationID2) (*emptypb.Empty, error)
-	DeactivateRegistration(context.Context, *RegistrationID) (*proto.Registration, error)
+	DeactivateRegistration(context.Context, *DeactivateRegistrationRequest) (*proto.Registration, error)
 	FinalizeAuthorization2(context.Context, *FinalizeAuthorizationRequest) (*emptypb.Empty, error)
 	FinalizeOrder(context.Context, *FinalizeOrderRequest) (*emptypb.Empty, error)
 	NewOrderAndAuthzs(context.Context, *NewOrderAndAuthzsRequest) (*proto.Order, error)
 	NewRegistration(context.Context, *proto.Registration) (*proto.Registration, error)
 	RevokeCertificate(context.Context, *RevokeCertificateRequest) (*emptypb.Empty, error)
 	SetOrderError(context.Context, *SetOrderErrorRequest) (*emptypb.Empty, error)
-	SetOrderProcessing(context.Context, *OrderRequest) (*emptypb.Empty, error)
+	SetOrderProcessing(context.Context, *SetOrderProcessingRequest) (*emptypb.Empty, error)
 	UpdateRegistrationKey(context.Context, *UpdateRegistrationKeyRequest) (*proto.Registration, error)
 	UpdateRevokedCertificate(context.Context, *RevokeCertificateRequest) (*emptypb.Empty, error)
 	LeaseCRLShard(context.Context, *LeaseCRLShardRequest) (*LeaseCRLShardResponse, error)
@@ -2021,6 +2741,13 @@ type StorageAuthorityServer interface {
 	AddRateLimitOverride(context.Context, *AddRateLimitOverrideRequest) (*AddRateLimitOverrideResponse, error)
 	DisableRateLimitOverride(context.Context, *DisableRateLimitOverrideRequest) (*emptypb.Empty, error)
 	EnableRateLimitOverride(context.Context, *EnableRateLimitOverrideRequest) (*emptypb.Empty, error)
+	SetContactVerification(context.Context, *ContactVerification) (*emptypb.Empty, error)
+	SetAccountRevocation(context.Context, *AccountRevocation) (*emptypb.Empty, error)
+	AddExemptionRequest(context.Context, *ExemptionRequest) (*ExemptionRequest, error)
+	ReviewExemptionRequest(context.Context, *ReviewExemptionRequestRequest) (*ExemptionRequest, error)
+	AddValidationFailure(context.Context, *AddValidationFailureRequest) (*ValidationFailures, error)
+	LeaseAccountRevocation(context.Context, *LeaseAccountRevocationRequest) (*AccountRevocation, error)
+	SetAuthzCAARecheck(context.Context, *SetAuthzCAARecheckRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedStorageAuthorityServer()
 }
 
@@ -2058,9 +2785,15 @@ func (UnimplementedStorageAuthorityServer) GetLintPrecertificate(context.Context
 func (UnimplementedStorageAuthorityServer) GetCertificateStatus(context.Context, *Serial) (*proto.CertificateStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCertificateStatus not implemented")
 }
+func (UnimplementedStorageAuthorityServer) GetCertificatesByExpiry(*GetCertificatesByExpiryRequest, grpc.ServerStreamingServer[proto.Certificate]) error {
+	return status.Errorf(codes.Unimplemented, "method GetCertificatesByExpiry not implemented")
+}
 func (UnimplementedStorageAuthorityServer) GetMaxExpiration(context.Context, *emptypb.Empty) (*timestamppb.Timestamp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMaxExpiration not implemented")
 }
+func (UnimplementedStorageAuthorityServer) GetMPICComplianceStats(context.Context, *GetMPICComplianceStatsRequest) (*MPICComplianceStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMPICComplianceStats not implemented")
+}
 func (UnimplementedStorageAuthorityServer) GetOrder(context.Context, *OrderRequest) (*proto.Order, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
 }
@@ -2076,6 +2809,9 @@ func (UnimplementedStorageAuthorityServer) GetRegistrationByKey(context.Context,
 func (UnimplementedStorageAuthorityServer) GetRevocationStatus(context.Context, *Serial) (*RevocationStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRevocationStatus not implemented")
 }
+func (UnimplementedStorageAuthorityServer) GetRevocationStatuses(context.Context, *Serials) (*RevocationStatuses, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRevocationStatuses not implemented")
+}
 func (UnimplementedStorageAuthorityServer) GetRevokedCerts(*GetRevokedCertsRequest, grpc.ServerStreamingServer[proto.CRLEntry]) error {
 	return status.Errorf(codes.Unimplemented, "method GetRevokedCerts not implemented")
 }
@@ -2091,6 +2827,9 @@ func (UnimplementedStorageAuthorityServer) GetSerialsByAccount(*RegistrationID,
 func (UnimplementedStorageAuthorityServer) GetSerialsByKey(*SPKIHash, grpc.ServerStreamingServer[Serial]) error {
 	return status.Errorf(codes.Unimplemented, "method GetSerialsByKey not implemented")
 }
+func (UnimplementedStorageAuthorityServer) SearchCertificates(*SearchCertificatesRequest, grpc.ServerStreamingServer[SerialMetadata]) error {
+	return status.Errorf(codes.Unimplemented, "method SearchCertificates not implemented")
+}
 func (UnimplementedStorageAuthorityServer) GetValidAuthorizations2(context.Context, *GetValidAuthorizationsRequest) (*Authorizations, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetValidAuthorizations2 not implemented")
 }
@@ -2121,6 +2860,30 @@ func (UnimplementedStorageAuthorityServer) GetRateLimitOverride(context.Context,
 func (UnimplementedStorageAuthorityServer) GetEnabledRateLimitOverrides(*emptypb.Empty, grpc.ServerStreamingServer[RateLimitOverrideResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method GetEnabledRateLimitOverrides not implemented")
 }
+func (UnimplementedStorageAuthorityServer) GetContactVerifications(context.Context, *ContactHashes) (*ContactVerifications, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContactVerifications not implemented")
+}
+func (UnimplementedStorageAuthorityServer) GetRegistrationClientIdentity(context.Context, *RegistrationID) (*ClientIdentity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRegistrationClientIdentity not implemented")
+}
+func (UnimplementedStorageAuthorityServer) GetAccountRevocation(context.Context, *RegistrationID) (*AccountRevocation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountRevocation not implemented")
+}
+func (UnimplementedStorageAuthorityServer) GetExemptionRequest(context.Context, *ExemptionRequestID) (*ExemptionRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExemptionRequest not implemented")
+}
+func (UnimplementedStorageAuthorityServer) GetExemptionRequests(context.Context, *GetExemptionRequestsRequest) (*ExemptionRequests, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExemptionRequests not implemented")
+}
+func (UnimplementedStorageAuthorityServer) GetValidationFailures(context.Context, *AuthorizationID2) (*ValidationFailures, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetValidationFailures not implemented")
+}
+func (UnimplementedStorageAuthorityServer) GetAuthzCAARecheck(context.Context, *AuthorizationID2) (*AuthzCAARecheck, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuthzCAARecheck not implemented")
+}
+func (UnimplementedStorageAuthorityServer) GetCRLShard(context.Context, *GetCRLShardRequest) (*CRLShard, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCRLShard not implemented")
+}
 func (UnimplementedStorageAuthorityServer) AddBlockedKey(context.Context, *AddBlockedKeyRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddBlockedKey not implemented")
 }
@@ -2139,7 +2902,7 @@ func (UnimplementedStorageAuthorityServer) AddSerial(context.Context, *AddSerial
 func (UnimplementedStorageAuthorityServer) DeactivateAuthorization2(context.Context, *AuthorizationID2) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeactivateAuthorization2 not implemented")
 }
-func (UnimplementedStorageAuthorityServer) DeactivateRegistration(context.Context, *RegistrationID) (*proto.Registration, error) {
+func (UnimplementedStorageAuthorityServer) DeactivateRegistration(context.Context, *DeactivateRegistrationRequest) (*proto.Registration, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeactivateRegistration not implemented")
 }
 func (UnimplementedStorageAuthorityServer) FinalizeAuthorization2(context.Context, *FinalizeAuthorizationRequest) (*emptypb.Empty, error) {
@@ -2160,7 +2923,7 @@ func (UnimplementedStorageAuthorityServer) RevokeCertificate(context.Context, *R
 func (UnimplementedStorageAuthorityServer) SetOrderError(context.Context, *SetOrderErrorRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetOrderError not implemented")
 }
-func (UnimplementedStorageAuthorityServer) SetOrderProcessing(context.Context, *OrderRequest) (*emptypb.Empty, error) {
+func (UnimplementedStorageAuthorityServer) SetOrderProcessing(context.Context, *SetOrderProcessingRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetOrderProcessing not implemented")
 }
 func (UnimplementedStorageAuthorityServer) UpdateRegistrationKey(context.Context, *UpdateRegistrationKeyRequest) (*proto.Registration, error) {
@@ -2190,6 +2953,27 @@ func (UnimplementedStorageAuthorityServer) DisableRateLimitOverride(context.Cont
 func (UnimplementedStorageAuthorityServer) EnableRateLimitOverride(context.Context, *EnableRateLimitOverrideRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EnableRateLimitOverride not implemented")
 }
+func (UnimplementedStorageAuthorityServer) SetContactVerification(context.Context, *ContactVerification) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetContactVerification not implemented")
+}
+func (UnimplementedStorageAuthorityServer) SetAccountRevocation(context.Context, *AccountRevocation) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAccountRevocation not implemented")
+}
+func (UnimplementedStorageAuthorityServer) AddExemptionRequest(context.Context, *ExemptionRequest) (*ExemptionRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddExemptionRequest not implemented")
+}
+func (UnimplementedStorageAuthorityServer) ReviewExemptionRequest(context.Context, *ReviewExemptionRequestRequest) (*ExemptionRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewExemptionRequest not implemented")
+}
+func (UnimplementedStorageAuthorityServer) AddValidationFailure(context.Context, *AddValidationFailureRequest) (*ValidationFailures, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddValidationFailure not implemented")
+}
+func (UnimplementedStorageAuthorityServer) LeaseAccountRevocation(context.Context, *LeaseAccountRevocationRequest) (*AccountRevocation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaseAccountRevocation not implemented")
+}
+func (UnimplementedStorageAuthorityServer) SetAuthzCAARecheck(context.Context, *SetAuthzCAARecheckRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAuthzCAARecheck not implemented")
+}
 func (UnimplementedStorageAuthorityServer) mustEmbedUnimplementedStorageAuthorityServer() {}
 func (UnimplementedStorageAuthorityServer) testEmbeddedByValue()                          {}
 
@@ -2373,6 +3157,17 @@ func _StorageAuthority_GetCertificateStatus_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StorageAuthority_GetCertificatesByExpiry_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetCertificatesByExpiryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageAuthorityServer).GetCertificatesByExpiry(m, &grpc.GenericServerStream[GetCertificatesByExpiryRequest, proto.Certificate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StorageAuthority_GetCertificatesByExpiryServer = grpc.ServerStreamingServer[proto.Certificate]
+
 func _StorageAuthority_GetMaxExpiration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -2391,6 +3186,24 @@ func _StorageAuthority_GetMaxExpiration_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StorageAuthority_GetMPICComplianceStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMPICComplianceStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).GetMPICComplianceStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthority_GetMPICComplianceStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).GetMPICComplianceStats(ctx, req.(*GetMPICComplianceStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StorageAuthority_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(OrderRequest)
 	if err := dec(in); err != nil {
@@ -2458,25 +3271,43 @@ func _StorageAuthority_GetRegistrationByKey_Handler(srv interface{}, ctx context
 		FullMethod: StorageAuthority_GetRegistrationByKey_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StorageAuthorityServer).GetRegistrationByKey(ctx, req.(*JSONWebKey))
+		return srv.(StorageAuthorityServer).GetRegistrationByKey(ctx, req.(*JSONWebKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthority_GetRevocationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Serial)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).GetRevocationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthority_GetRevocationStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).GetRevocationStatus(ctx, req.(*Serial))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _StorageAuthority_GetRevocationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Serial)
+func _StorageAuthority_GetRevocationStatuses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Serials)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(StorageAuthorityServer).GetRevocationStatus(ctx, in)
+		return srv.(StorageAuthorityServer).GetRevocationStatuses(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: StorageAuthority_GetRevocationStatus_FullMethodName,
+		FullMethod: StorageAuthority_GetRevocationStatuses_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StorageAuthorityServer).GetRevocationStatus(ctx, req.(*Serial))
+		return srv.(StorageAuthorityServer).GetRevocationStatuses(ctx, req.(*Serials))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -2543,6 +3374,17 @@ func _StorageAuthority_GetSerialsByKey_Handler(srv interface{}, stream grpc.Serv
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type StorageAuthority_GetSerialsByKeyServer = grpc.ServerStreamingServer[Serial]
 
+func _StorageAuthority_SearchCertificates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchCertificatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageAuthorityServer).SearchCertificates(m, &grpc.GenericServerStream[SearchCertificatesRequest, SerialMetadata]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StorageAuthority_SearchCertificatesServer = grpc.ServerStreamingServer[SerialMetadata]
+
 func _StorageAuthority_GetValidAuthorizations2_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetValidAuthorizationsRequest)
 	if err := dec(in); err != nil {
@@ -2709,6 +3551,150 @@ func _StorageAuthority_GetEnabledRateLimitOverrides_Handler(srv interface{}, str
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type StorageAuthority_GetEnabledRateLimitOverridesServer = grpc.ServerStreamingServer[RateLimitOverrideResponse]
 
+func _StorageAuthority_GetContactVerifications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContactHashes)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).GetContactVerifications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthority_GetContactVerifications_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).GetContactVerifications(ctx, req.(*ContactHashes))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthority_GetRegistrationClientIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegistrationID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).GetRegistrationClientIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthority_GetRegistrationClientIdentity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).GetRegistrationClientIdentity(ctx, req.(*RegistrationID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthority_GetAccountRevocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegistrationID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).GetAccountRevocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthority_GetAccountRevocation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).GetAccountRevocation(ctx, req.(*RegistrationID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthority_GetExemptionRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExemptionRequestID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).GetExemptionRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthority_GetExemptionRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).GetExemptionRequest(ctx, req.(*ExemptionRequestID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthority_GetExemptionRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExemptionRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).GetExemptionRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthority_GetExemptionRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).GetExemptionRequests(ctx, req.(*GetExemptionRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthority_GetValidationFailures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorizationID2)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).GetValidationFailures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthority_GetValidationFailures_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).GetValidationFailures(ctx, req.(*AuthorizationID2))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthority_GetAuthzCAARecheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorizationID2)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).GetAuthzCAARecheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthority_GetAuthzCAARecheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).GetAuthzCAARecheck(ctx, req.(*AuthorizationID2))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthority_GetCRLShard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCRLShardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).GetCRLShard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthority_GetCRLShard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).GetCRLShard(ctx, req.(*GetCRLShardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StorageAuthority_AddBlockedKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddBlockedKeyRequest)
 	if err := dec(in); err != nil {
@@ -2818,7 +3804,7 @@ func _StorageAuthority_DeactivateAuthorization2_Handler(srv interface{}, ctx con
 }
 
 func _StorageAuthority_DeactivateRegistration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RegistrationID)
+	in := new(DeactivateRegistrationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -2830,7 +3816,7 @@ func _StorageAuthority_DeactivateRegistration_Handler(srv interface{}, ctx conte
 		FullMethod: StorageAuthority_DeactivateRegistration_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StorageAuthorityServer).DeactivateRegistration(ctx, req.(*RegistrationID))
+		return srv.(StorageAuthorityServer).DeactivateRegistration(ctx, req.(*DeactivateRegistrationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -2944,7 +3930,7 @@ func _StorageAuthority_SetOrderError_Handler(srv interface{}, ctx context.Contex
 }
 
 func _StorageAuthority_SetOrderProcessing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OrderRequest)
+	in := new(SetOrderProcessingRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -2956,7 +3942,7 @@ func _StorageAuthority_SetOrderProcessing_Handler(srv interface{}, ctx context.C
 		FullMethod: StorageAuthority_SetOrderProcessing_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StorageAuthorityServer).SetOrderProcessing(ctx, req.(*OrderRequest))
+		return srv.(StorageAuthorityServer).SetOrderProcessing(ctx, req.(*SetOrderProcessingRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -3123,6 +4109,132 @@ func _StorageAuthority_EnableRateLimitOverride_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StorageAuthority_SetContactVerification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContactVerification)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).SetContactVerification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthority_SetContactVerification_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).SetContactVerification(ctx, req.(*ContactVerification))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthority_SetAccountRevocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRevocation)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).SetAccountRevocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthority_SetAccountRevocation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).SetAccountRevocation(ctx, req.(*AccountRevocation))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthority_AddExemptionRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExemptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).AddExemptionRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthority_AddExemptionRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).AddExemptionRequest(ctx, req.(*ExemptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthority_ReviewExemptionRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewExemptionRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).ReviewExemptionRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthority_ReviewExemptionRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).ReviewExemptionRequest(ctx, req.(*ReviewExemptionRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthority_AddValidationFailure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddValidationFailureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).AddValidationFailure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthority_AddValidationFailure_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).AddValidationFailure(ctx, req.(*AddValidationFailureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthority_LeaseAccountRevocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseAccountRevocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).LeaseAccountRevocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthority_LeaseAccountRevocation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).LeaseAccountRevocation(ctx, req.(*LeaseAccountRevocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthority_SetAuthzCAARecheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAuthzCAARecheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).SetAuthzCAARecheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageAuthority_SetAuthzCAARecheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).SetAuthzCAARecheck(ctx, req.(*SetAuthzCAARecheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StorageAuthority_ServiceDesc is the grpc.ServiceDesc for StorageAuthority service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -3170,6 +4282,10 @@ var StorageAuthority_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMaxExpiration",
 			Handler:    _StorageAuthority_GetMaxExpiration_Handler,
 		},
+		{
+			MethodName: "GetMPICComplianceStats",
+			Handler:    _StorageAuthority_GetMPICComplianceStats_Handler,
+		},
 		{
 			MethodName: "GetOrder",
 			Handler:    _StorageAuthority_GetOrder_Handler,
@@ -3190,6 +4306,10 @@ var StorageAuthority_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetRevocationStatus",
 			Handler:    _StorageAuthority_GetRevocationStatus_Handler,
 		},
+		{
+			MethodName: "GetRevocationStatuses",
+			Handler:    _StorageAuthority_GetRevocationStatuses_Handler,
+		},
 		{
 			MethodName: "GetSerialMetadata",
 			Handler:    _StorageAuthority_GetSerialMetadata_Handler,
@@ -3226,6 +4346,38 @@ var StorageAuthority_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetRateLimitOverride",
 			Handler:    _StorageAuthority_GetRateLimitOverride_Handler,
 		},
+		{
+			MethodName: "GetContactVerifications",
+			Handler:    _StorageAuthority_GetContactVerifications_Handler,
+		},
+		{
+			MethodName: "GetRegistrationClientIdentity",
+			Handler:    _StorageAuthority_GetRegistrationClientIdentity_Handler,
+		},
+		{
+			MethodName: "GetAccountRevocation",
+			Handler:    _StorageAuthority_GetAccountRevocation_Handler,
+		},
+		{
+			MethodName: "GetExemptionRequest",
+			Handler:    _StorageAuthority_GetExemptionRequest_Handler,
+		},
+		{
+			MethodName: "GetExemptionRequests",
+			Handler:    _StorageAuthority_GetExemptionRequests_Handler,
+		},
+		{
+			MethodName: "GetValidationFailures",
+			Handler:    _StorageAuthority_GetValidationFailures_Handler,
+		},
+		{
+			MethodName: "GetAuthzCAARecheck",
+			Handler:    _StorageAuthority_GetAuthzCAARecheck_Handler,
+		},
+		{
+			MethodName: "GetCRLShard",
+			Handler:    _StorageAuthority_GetCRLShard_Handler,
+		},
 		{
 			MethodName: "AddBlockedKey",
 			Handler:    _StorageAuthority_AddBlockedKey_Handler,
@@ -3318,8 +4470,41 @@ var StorageAuthority_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "EnableRateLimitOverride",
 			Handler:    _StorageAuthority_EnableRateLimitOverride_Handler,
 		},
+		{
+			MethodName: "SetContactVerification",
+			Handler:    _StorageAuthority_SetContactVerification_Handler,
+		},
+		{
+			MethodName: "SetAccountRevocation",
+			Handler:    _StorageAuthority_SetAccountRevocation_Handler,
+		},
+		{
+			MethodName: "AddExemptionRequest",
+			Handler:    _StorageAuthority_AddExemptionRequest_Handler,
+		},
+		{
+			MethodName: "ReviewExemptionRequest",
+			Handler:    _StorageAuthority_ReviewExemptionRequest_Handler,
+		},
+		{
+			MethodName: "AddValidationFailure",
+			Handler:    _StorageAuthority_AddValidationFailure_Handler,
+		},
+		{
+			MethodName: "LeaseAccountRevocation",
+			Handler:    _StorageAuthority_LeaseAccountRevocation_Handler,
+		},
+		{
+			MethodName: "SetAuthzCAARecheck",
+			Handler:    _StorageAuthority_SetAuthzCAARecheck_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetCertificatesByExpiry",
+			Handler:       _StorageAuthority_GetCertificatesByExpiry_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "GetRevokedCerts",
 			Handler:       _StorageAuthority_GetRevokedCerts_Handler,
@@ -3340,6 +4525,11 @@ var StorageAuthority_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _StorageAuthority_GetSerialsByKey_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SearchCertificates",
+			Handler:       _StorageAuthority_SearchCertificates_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "SerialsForIncident",
 			Handler:       _StorageAuthority_SerialsForIncident_Handler,
@@ -2,11 +2,13 @@ package sa
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/x509"
 	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -21,6 +23,7 @@ import (
 	corepb "github.com/letsencrypt/boulder/core/proto"
 	"github.com/letsencrypt/boulder/db"
 	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/features"
 	bgrpc "github.com/letsencrypt/boulder/grpc"
 	"github.com/letsencrypt/boulder/identifier"
 	blog "github.com/letsencrypt/boulder/log"
@@ -51,6 +54,10 @@ type SQLStorageAuthority struct {
 	// transactions fail and so use this stat to maintain visibility into the rate
 	// this occurs.
 	rateLimitWriteErrors prometheus.Counter
+
+	// txLockConflicts counts the transactions which failed with a deadlock or
+	// lock wait timeout, labelled by method and by whether they were retried.
+	txLockConflicts *prometheus.CounterVec
 }
 
 var _ sapb.StorageAuthorityServer = (*SQLStorageAuthority)(nil)
@@ -70,10 +77,17 @@ func NewSQLStorageAuthorityWrapping(
 	})
 	stats.MustRegister(rateLimitWriteErrors)
 
+	txLockConflicts := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "sa_tx_lock_conflicts",
+		Help: "A counter of transactions which failed with a deadlock or lock wait timeout, labelled by method and action=[retried|exhausted|unsafe]",
+	}, []string{"method", "action"})
+	stats.MustRegister(txLockConflicts)
+
 	ssa := &SQLStorageAuthority{
 		SQLStorageAuthorityRO: ssaro,
 		dbMap:                 dbMap,
 		rateLimitWriteErrors:  rateLimitWriteErrors,
+		txLockConflicts:       txLockConflicts,
 	}
 
 	return ssa, nil
@@ -113,7 +127,26 @@ func (ssa *SQLStorageAuthority) NewRegistration(ctx context.Context, req *corepb
 
 	reg.CreatedAt = ssa.clk.Now()
 
-	err = ssa.dbMap.Insert(ctx, reg)
+	if req.ClientIdentity == "" {
+		err = ssa.dbMap.Insert(ctx, reg)
+	} else {
+		if len(req.ClientIdentity) > 255 {
+			return nil, berrors.MalformedError("client identity is too long")
+		}
+		// Bind the registration to the identity atomically, so that it can
+		// never be used without it.
+		_, err = ssa.withTransaction(ctx, "NewRegistration", func(tx db.Executor) (any, error) {
+			err := tx.Insert(ctx, reg)
+			if err != nil {
+				return nil, err
+			}
+			return nil, tx.Insert(ctx, &clientIdentityModel{
+				RegistrationID: reg.ID,
+				Identity:       req.ClientIdentity,
+				CreatedAt:      reg.CreatedAt,
+			})
+		})
+	}
 	if err != nil {
 		if db.IsDuplicate(err) {
 			// duplicate entry error can only happen when jwk_sha256 collides, indicate
@@ -144,7 +177,7 @@ func (ssa *SQLStorageAuthority) UpdateRegistrationKey(ctx context.Context, req *
 		return nil, fmt.Errorf("computing key digest: %w", err)
 	}
 
-	result, overallError := db.WithTransaction(ctx, ssa.dbMap, func(tx db.Executor) (interface{}, error) {
+	result, overallError := ssa.withTransaction(ctx, "UpdateRegistrationKey", func(tx db.Executor) (interface{}, error) {
 		result, err := tx.ExecContext(ctx,
 			"UPDATE registrations SET jwk = ?, jwk_sha256 = ? WHERE id = ? LIMIT 1",
 			req.Jwk,
@@ -196,6 +229,9 @@ func (ssa *SQLStorageAuthority) AddSerial(ctx context.Context, req *sapb.AddSeri
 		Created:        req.Created.AsTime(),
 		Expires:        req.Expires.AsTime(),
 	})
+	if db.IsDuplicate(err) {
+		return nil, berrors.DuplicateError("serial %q is already in use", req.Serial)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -252,9 +288,10 @@ func (ssa *SQLStorageAuthority) AddPrecertificate(ctx context.Context, req *sapb
 		DER:            req.Der,
 		Issued:         req.Issued.AsTime(),
 		Expires:        parsed.NotAfter,
+		Digest:         core.Fingerprint256(req.Der),
 	}
 
-	_, overallError := db.WithTransaction(ctx, ssa.dbMap, func(tx db.Executor) (interface{}, error) {
+	_, overallError := ssa.withTransaction(ctx, "AddPrecertificate", func(tx db.Executor) (interface{}, error) {
 		// Select to see if precert exists
 		var row struct {
 			Count int64
@@ -334,16 +371,7 @@ func (ssa *SQLStorageAuthority) AddCertificate(ctx context.Context, req *sapb.Ad
 	digest := core.Fingerprint256(req.Der)
 	serial := core.SerialToString(parsedCertificate.SerialNumber)
 
-	cert := &core.Certificate{
-		RegistrationID: req.RegID,
-		Serial:         serial,
-		Digest:         digest,
-		DER:            req.Der,
-		Issued:         req.Issued.AsTime(),
-		Expires:        parsedCertificate.NotAfter,
-	}
-
-	_, overallError := db.WithTransaction(ctx, ssa.dbMap, func(tx db.Executor) (interface{}, error) {
+	_, overallError := ssa.withTransaction(ctx, "AddCertificate", func(tx db.Executor) (interface{}, error) {
 		// Select to see if cert exists
 		var row struct {
 			Count int64
@@ -356,8 +384,16 @@ func (ssa *SQLStorageAuthority) AddCertificate(ctx context.Context, req *sapb.Ad
 			return nil, berrors.DuplicateError("cannot add a duplicate cert")
 		}
 
-		// Save the final certificate
-		err = tx.Insert(ctx, cert)
+		// Save the final certificate. The model is built afresh on each
+		// attempt, since Insert sets its ID.
+		err = tx.Insert(ctx, &core.Certificate{
+			RegistrationID: req.RegID,
+			Serial:         serial,
+			Digest:         digest,
+			DER:            req.Der,
+			Issued:         req.Issued.AsTime(),
+			Expires:        parsedCertificate.NotAfter,
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -372,10 +408,10 @@ func (ssa *SQLStorageAuthority) AddCertificate(ctx context.Context, req *sapb.Ad
 	// used for order reuse. Since the effect of failing the write is just a
 	// missed opportunity to reuse an order, we choose to not fail the
 	// AddCertificate operation if this update transaction fails.
-	_, fqdnTransactionErr := db.WithTransaction(ctx, ssa.dbMap, func(tx db.Executor) (interface{}, error) {
+	_, fqdnTransactionErr := ssa.withTransaction(ctx, "AddCertificateFQDNSet", func(tx db.Executor) (interface{}, error) {
 		// Update the FQDN sets now that there is a final certificate to ensure
 		// reuse is determined correctly.
-		err = addFQDNSet(
+		err := addFQDNSet(
 			ctx,
 			tx,
 			identifier.FromCert(parsedCertificate),
@@ -399,38 +435,51 @@ func (ssa *SQLStorageAuthority) AddCertificate(ctx context.Context, req *sapb.Ad
 	return &emptypb.Empty{}, nil
 }
 
-// DeactivateRegistration deactivates a currently valid registration
-func (ssa *SQLStorageAuthority) DeactivateRegistration(ctx context.Context, req *sapb.RegistrationID) (*corepb.Registration, error) {
-	if req == nil || req.Id == 0 {
+// DeactivateRegistration deactivates a currently valid registration. If the
+// revocation of its certificates is requested, that's recorded in the same
+// transaction, for an RA to lease with LeaseAccountRevocation.
+func (ssa *SQLStorageAuthority) DeactivateRegistration(ctx context.Context, req *sapb.DeactivateRegistrationRequest) (*corepb.Registration, error) {
+	if req == nil || req.RegistrationID == 0 {
 		return nil, errIncompleteRequest
 	}
 
-	result, overallError := db.WithTransaction(ctx, ssa.dbMap, func(tx db.Executor) (any, error) {
+	result, overallError := ssa.withTransaction(ctx, "DeactivateRegistration", func(tx db.Executor) (any, error) {
 		result, err := tx.ExecContext(ctx,
 			"UPDATE registrations SET status = ? WHERE status = ? AND id = ? LIMIT 1",
 			string(core.StatusDeactivated),
 			string(core.StatusValid),
-			req.Id,
+			req.RegistrationID,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("deactivating account %d: %w", req.Id, err)
+			return nil, fmt.Errorf("deactivating account %d: %w", req.RegistrationID, err)
 		}
 		rowsAffected, err := result.RowsAffected()
 		if err != nil {
-			return nil, fmt.Errorf("deactivating account %d: %w", req.Id, err)
+			return nil, fmt.Errorf("deactivating account %d: %w", req.RegistrationID, err)
 		}
 		if rowsAffected == 0 {
-			return nil, berrors.NotFoundError("no active account with id %d", req.Id)
+			return nil, berrors.NotFoundError("no active account with id %d", req.RegistrationID)
 		} else if rowsAffected > 1 {
-			return nil, berrors.InternalServerError("unexpectedly deactivated multiple accounts with id %d", req.Id)
+			return nil, berrors.InternalServerError("unexpectedly deactivated multiple accounts with id %d", req.RegistrationID)
 		}
 
-		updatedRegistrationModel, err := selectRegistration(ctx, tx, "id", req.Id)
+		if req.RevokeCertificates {
+			_, err = tx.ExecContext(ctx,
+				"INSERT INTO accountRevocations (registrationID, requested, total, revoked, failed) VALUES (?, ?, 0, 0, 0)",
+				req.RegistrationID,
+				ssa.clk.Now(),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("requesting certificate revocation of account %d: %w", req.RegistrationID, err)
+			}
+		}
+
+		updatedRegistrationModel, err := selectRegistration(ctx, tx, "id", req.RegistrationID)
 		if err != nil {
 			if db.IsNoRows(err) {
-				return nil, berrors.NotFoundError("fetching account %d: no rows found", req.Id)
+				return nil, berrors.NotFoundError("fetching account %d: no rows found", req.RegistrationID)
 			}
-			return nil, fmt.Errorf("fetching account %d: %w", req.Id, err)
+			return nil, fmt.Errorf("fetching account %d: %w", req.RegistrationID, err)
 		}
 
 		updatedRegistration, err := registrationModelToPb(updatedRegistrationModel)
@@ -458,15 +507,54 @@ func (ssa *SQLStorageAuthority) DeactivateAuthorization2(ctx context.Context, re
 		return nil, errIncompleteRequest
 	}
 
-	_, err := ssa.dbMap.ExecContext(ctx,
-		`UPDATE authz2 SET status = :deactivated WHERE id = :id and status IN (:valid,:pending)`,
-		map[string]interface{}{
-			"deactivated": statusUint(core.StatusDeactivated),
-			"id":          req.Id,
-			"valid":       statusUint(core.StatusValid),
-			"pending":     statusUint(core.StatusPending),
-		},
-	)
+	deactivate := func(tx db.Execer) (int64, error) {
+		res, err := tx.ExecContext(ctx,
+			`UPDATE authz2 SET status = :deactivated WHERE id = :id and status IN (:valid,:pending)`,
+			map[string]interface{}{
+				"deactivated": statusUint(core.StatusDeactivated),
+				"id":          req.Id,
+				"valid":       statusUint(core.StatusValid),
+				"pending":     statusUint(core.StatusPending),
+			},
+		)
+		if err != nil {
+			return 0, err
+		}
+		return res.RowsAffected()
+	}
+
+	if !features.Get().MaintainAuthzCounts {
+		_, err := deactivate(ssa.dbMap)
+		if err != nil {
+			return nil, err
+		}
+		return &emptypb.Empty{}, nil
+	}
+
+	// Lock the authorization so that its status can't change between reading
+	// it and deactivating it, and decrement its account's pending count if it
+	// was pending.
+	_, err := ssa.withTransaction(ctx, "DeactivateAuthorization2", func(tx db.Executor) (interface{}, error) {
+		var authz struct {
+			RegistrationID int64 `db:"registrationID"`
+			Status         uint8 `db:"status"`
+		}
+		err := tx.SelectOne(ctx, &authz, `SELECT registrationID, status FROM authz2 WHERE id = ? FOR UPDATE`, req.Id)
+		if db.IsNoRows(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows, err := deactivate(tx)
+		if err != nil {
+			return nil, err
+		}
+		if rows == 1 && authz.Status == statusUint(core.StatusPending) {
+			return nil, addPendingAuthzCount(ctx, tx, authz.RegistrationID, -1)
+		}
+		return nil, nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -492,19 +580,50 @@ func (ssa *SQLStorageAuthority) NewOrderAndAuthzs(ctx context.Context, req *sapb
 		}
 	}
 
-	output, err := db.WithTransaction(ctx, ssa.dbMap, func(tx db.Executor) (interface{}, error) {
-		// First, insert all of the new authorizations and record their IDs.
-		newAuthzIDs := make([]int64, 0, len(req.NewAuthzs))
-		for _, authz := range req.NewAuthzs {
-			am, err := newAuthzReqToModel(authz, req.NewOrder.CertificateProfileName)
-			if err != nil {
-				return nil, err
-			}
-			err = tx.Insert(ctx, am)
+	// Convert the new authorizations to rows before starting the transaction,
+	// so that it's held open only for the database round trips: one each to
+	// insert the authorizations, the order, its authorization mappings, and its
+	// FQDN set, plus one to look up any reused authorizations.
+	authzInserter, err := db.NewMultiInserter("authz2", []string{
+		"identifierType", "identifierValue", "registrationID", "certificateProfileName",
+		"status", "expires", "challenges", "token",
+	})
+	if err != nil {
+		return nil, err
+	}
+	newAuthzValidities := make([]authzValidity, 0, len(req.NewAuthzs))
+	for _, authz := range req.NewAuthzs {
+		am, err := newAuthzReqToModel(authz, req.NewOrder.CertificateProfileName)
+		if err != nil {
+			return nil, err
+		}
+		err = authzInserter.Add([]interface{}{
+			am.IdentifierType, am.IdentifierValue, am.RegistrationID, am.CertificateProfileName,
+			am.Status, am.Expires, am.Challenges, am.Token,
+		})
+		if err != nil {
+			return nil, err
+		}
+		newAuthzValidities = append(newAuthzValidities, authzValidity{
+			IdentifierType:  am.IdentifierType,
+			IdentifierValue: am.IdentifierValue,
+			Status:          am.Status,
+			Expires:         am.Expires,
+		})
+	}
+
+	output, err := ssa.withTransaction(ctx, "NewOrderAndAuthzs", func(tx db.Executor) (interface{}, error) {
+		// First, insert all of the new authorizations in a single statement
+		// and record their IDs.
+		newAuthzIDs, err := authzInserter.InsertReturning(ctx, tx, "id")
+		if err != nil {
+			return nil, err
+		}
+		if features.Get().MaintainAuthzCounts && len(newAuthzIDs) > 0 {
+			err = addPendingAuthzCount(ctx, tx, req.NewOrder.RegistrationID, int64(len(newAuthzIDs)))
 			if err != nil {
 				return nil, err
 			}
-			newAuthzIDs = append(newAuthzIDs, am.ID)
 		}
 
 		// Second, insert the new order.
@@ -516,7 +635,7 @@ func (ssa *SQLStorageAuthority) NewOrderAndAuthzs(ctx context.Context, req *sapb
 			CertificateProfileName: &req.NewOrder.CertificateProfileName,
 			Replaces:               &req.NewOrder.Replaces,
 		}
-		err := tx.Insert(ctx, &om)
+		err = tx.Insert(ctx, &om)
 		if err != nil {
 			return nil, err
 		}
@@ -555,11 +674,17 @@ func (ssa *SQLStorageAuthority) NewOrderAndAuthzs(ctx context.Context, req *sapb
 			}
 		}
 
-		// Get the partial Authorization objects for the order
-		authzValidityInfo, err := getAuthorizationStatuses(ctx, tx, allAuthzIds)
-		// If there was an error getting the authorizations, return it immediately
-		if err != nil {
-			return nil, err
+		// Get the partial Authorization objects for the order. Only the reused
+		// authorizations need to be looked up, since the new ones are known to
+		// be pending.
+		authzValidityInfo := newAuthzValidities
+		if len(req.NewOrder.V2Authorizations) > 0 {
+			reused, err := getAuthorizationStatuses(ctx, tx, req.NewOrder.V2Authorizations)
+			// If there was an error getting the authorizations, return it immediately
+			if err != nil {
+				return nil, err
+			}
+			authzValidityInfo = append(reused, newAuthzValidities...)
 		}
 
 		// Finally, build the overall Order PB.
@@ -606,20 +731,35 @@ func (ssa *SQLStorageAuthority) NewOrderAndAuthzs(ctx context.Context, req *sapb
 
 // SetOrderProcessing updates an order from pending status to processing
 // status by updating the `beganProcessing` field of the corresponding
-// Order table row in the DB.
-func (ssa *SQLStorageAuthority) SetOrderProcessing(ctx context.Context, req *sapb.OrderRequest) (*emptypb.Empty, error) {
+// Order table row in the DB. If the IdempotentFinalize feature is enabled, it
+// also stores the hash of the CSR with which the order is being finalized.
+func (ssa *SQLStorageAuthority) SetOrderProcessing(ctx context.Context, req *sapb.SetOrderProcessingRequest) (*emptypb.Empty, error) {
 	if req.Id == 0 {
 		return nil, errIncompleteRequest
 	}
-	_, overallError := db.WithTransaction(ctx, ssa.dbMap, func(tx db.Executor) (interface{}, error) {
-		result, err := tx.ExecContext(ctx, `
-		UPDATE orders
-		SET beganProcessing = ?
-		WHERE id = ?
-		AND beganProcessing = ?`,
-			true,
-			req.Id,
-			false)
+	_, overallError := ssa.withTransaction(ctx, "SetOrderProcessing", func(tx db.Executor) (interface{}, error) {
+		var result sql.Result
+		var err error
+		if features.Get().IdempotentFinalize && len(req.CsrHash) > 0 {
+			result, err = tx.ExecContext(ctx, `
+			UPDATE orders
+			SET beganProcessing = ?, csrHash = ?
+			WHERE id = ?
+			AND beganProcessing = ?`,
+				true,
+				req.CsrHash,
+				req.Id,
+				false)
+		} else {
+			result, err = tx.ExecContext(ctx, `
+			UPDATE orders
+			SET beganProcessing = ?
+			WHERE id = ?
+			AND beganProcessing = ?`,
+				true,
+				req.Id,
+				false)
+		}
 		if err != nil {
 			return nil, berrors.InternalServerError("error updating order to beganProcessing status")
 		}
@@ -642,7 +782,7 @@ func (ssa *SQLStorageAuthority) SetOrderError(ctx context.Context, req *sapb.Set
 	if req.Id == 0 || req.Error == nil {
 		return nil, errIncompleteRequest
 	}
-	_, overallError := db.WithTransaction(ctx, ssa.dbMap, func(tx db.Executor) (interface{}, error) {
+	_, overallError := ssa.withTransaction(ctx, "SetOrderError", func(tx db.Executor) (interface{}, error) {
 		om, err := orderToModel(&corepb.Order{
 			Id:    req.Id,
 			Error: req.Error,
@@ -682,7 +822,7 @@ func (ssa *SQLStorageAuthority) FinalizeOrder(ctx context.Context, req *sapb.Fin
 	if req.Id == 0 || req.CertificateSerial == "" {
 		return nil, errIncompleteRequest
 	}
-	_, overallError := db.WithTransaction(ctx, ssa.dbMap, func(tx db.Executor) (interface{}, error) {
+	_, overallError := ssa.withTransaction(ctx, "FinalizeOrder", func(tx db.Executor) (interface{}, error) {
 		result, err := tx.ExecContext(ctx, `
 		UPDATE orders
 		SET certificateSerial = ?
@@ -789,20 +929,79 @@ func (ssa *SQLStorageAuthority) FinalizeAuthorization2(ctx context.Context, req
 		"validationError": veJSON,
 	}
 
-	res, err := ssa.dbMap.ExecContext(ctx, query, params)
+	finalize := func(tx db.Execer) error {
+		res, err := tx.ExecContext(ctx, query, params)
+		if err != nil {
+			return err
+		}
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return berrors.NotFoundError("no pending authorization with id %d", req.Id)
+		} else if rows > 1 {
+			return berrors.InternalServerError("multiple rows updated for authorization id %d", req.Id)
+		}
+		return nil
+	}
+
+	storePerspectives := features.Get().StoreValidationPerspectives && len(req.Perspectives) > 0 && attemptedTime != nil
+	if features.Get().MaintainAuthzCounts || storePerspectives {
+		_, err = ssa.withTransaction(ctx, "FinalizeAuthorization2", func(tx db.Executor) (interface{}, error) {
+			err := finalize(tx)
+			if err != nil {
+				return nil, err
+			}
+			if storePerspectives {
+				err = addValidationPerspectives(ctx, tx, req.Id, *attemptedTime, req.Perspectives)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if features.Get().MaintainAuthzCounts {
+				return nil, updateFinalizedAuthzCounts(ctx, tx, req.Id, core.AcmeStatus(req.Status), req.Expires.AsTime())
+			}
+			return nil, nil
+		})
+	} else {
+		err = finalize(ssa.dbMap)
+	}
 	if err != nil {
 		return nil, err
 	}
-	rows, err := res.RowsAffected()
+	return &emptypb.Empty{}, nil
+}
+
+// addValidationPerspectives records the outcome of each perspective which took
+// part in the validation attempt made at attemptedAt on the given
+// authorization.
+func addValidationPerspectives(ctx context.Context, tx db.Executor, authzID int64, attemptedAt time.Time, perspectives []*corepb.ValidationPerspective) error {
+	inserter, err := db.NewMultiInserter("validationPerspectives", []string{"authzID", "attemptedAt", "perspective", "rir", "passed", "latencyMS"})
 	if err != nil {
-		return nil, err
+		return err
 	}
-	if rows == 0 {
-		return nil, berrors.NotFoundError("no pending authorization with id %d", req.Id)
-	} else if rows > 1 {
-		return nil, berrors.InternalServerError("multiple rows updated for authorization id %d", req.Id)
+	for _, p := range perspectives {
+		if p.Perspective == "" {
+			return errIncompleteRequest
+		}
+		err = inserter.Add([]interface{}{
+			authzID,
+			attemptedAt,
+			p.Perspective,
+			p.Rir,
+			p.Passed,
+			p.Latency.AsDuration().Milliseconds(),
+		})
+		if err != nil {
+			return err
+		}
 	}
-	return &emptypb.Empty{}, nil
+	err = inserter.Insert(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("inserting validation perspectives: %w", err)
+	}
+	return nil
 }
 
 // addRevokedCertificate is a helper used by both RevokeCertificate and
@@ -852,7 +1051,7 @@ func (ssa *SQLStorageAuthority) RevokeCertificate(ctx context.Context, req *sapb
 		return nil, errIncompleteRequest
 	}
 
-	_, overallError := db.WithTransaction(ctx, ssa.dbMap, func(tx db.Executor) (interface{}, error) {
+	_, overallError := ssa.withTransaction(ctx, "RevokeCertificate", func(tx db.Executor) (interface{}, error) {
 		revokedDate := req.Date.AsTime()
 
 		res, err := tx.ExecContext(ctx,
@@ -908,7 +1107,7 @@ func (ssa *SQLStorageAuthority) UpdateRevokedCertificate(ctx context.Context, re
 		return nil, fmt.Errorf("cannot update revocation for any reason other than keyCompromise (1); got: %d", req.Reason)
 	}
 
-	_, overallError := db.WithTransaction(ctx, ssa.dbMap, func(tx db.Executor) (interface{}, error) {
+	_, overallError := ssa.withTransaction(ctx, "UpdateRevokedCertificate", func(tx db.Executor) (interface{}, error) {
 		thisUpdate := req.Date.AsTime()
 		revokedDate := req.Backdate.AsTime()
 
@@ -1054,7 +1253,7 @@ func (ssa *SQLStorageAuthority) LeaseCRLShard(ctx context.Context, req *sapb.Lea
 // leased or are previously-unknown indices are considered older than any other
 // shard. It returns an error if all shards for the issuer are already leased.
 func (ssa *SQLStorageAuthority) leaseOldestCRLShard(ctx context.Context, req *sapb.LeaseCRLShardRequest) (*sapb.LeaseCRLShardResponse, error) {
-	shardIdx, err := db.WithTransaction(ctx, ssa.dbMap, func(tx db.Executor) (interface{}, error) {
+	shardIdx, err := ssa.withTransaction(ctx, "LeaseCRLShard", func(tx db.Executor) (interface{}, error) {
 		var shards []*crlShardModel
 		_, err := tx.Select(
 			ctx,
@@ -1159,7 +1358,7 @@ func (ssa *SQLStorageAuthority) leaseSpecificCRLShard(ctx context.Context, req *
 		return nil, fmt.Errorf("request must identify a single shard index: %d != %d", req.MinShardIdx, req.MaxShardIdx)
 	}
 
-	_, err := db.WithTransaction(ctx, ssa.dbMap, func(tx db.Executor) (interface{}, error) {
+	_, err := ssa.withTransaction(ctx, "LeaseCRLShard", func(tx db.Executor) (interface{}, error) {
 		needToInsert := false
 		var shardModel crlShardModel
 		err := tx.SelectOne(ctx,
@@ -1229,7 +1428,7 @@ func (ssa *SQLStorageAuthority) leaseSpecificCRLShard(ctx context.Context, req *
 }
 
 // UpdateCRLShard updates the thisUpdate and nextUpdate timestamps of a CRL
-// shard. It rejects the update if it would cause the thisUpdate timestamp to
+// shard, and the digest of its entries if one is given. It rejects the update if it would cause the thisUpdate timestamp to
 // move backwards, but if thisUpdate would stay the same (for instance, multiple
 // CRL generations within a single second), it will succeed.
 //
@@ -1252,20 +1451,28 @@ func (ssa *SQLStorageAuthority) UpdateCRLShard(ctx context.Context, req *sapb.Up
 		nextUpdate = &nut
 	}
 
-	_, err := db.WithTransaction(ctx, ssa.dbMap, func(tx db.Executor) (interface{}, error) {
+	// Only set the entriesDigest if the crl-updater reported one, so that
+	// updaters which don't track digests work with databases which lack it.
+	setDigest := ""
+	params := []interface{}{req.ThisUpdate.AsTime(), nextUpdate, req.ThisUpdate.AsTime()}
+	if len(req.EntriesDigest) > 0 {
+		if len(req.EntriesDigest) != sha256.Size {
+			return nil, fmt.Errorf("invalid entries digest length %d", len(req.EntriesDigest))
+		}
+		setDigest = ", entriesDigest = ?"
+		params = append(params, req.EntriesDigest)
+	}
+	params = append(params, req.IssuerNameID, req.ShardIdx, req.ThisUpdate.AsTime())
+
+	_, err := ssa.withTransaction(ctx, "UpdateCRLShard", func(tx db.Executor) (interface{}, error) {
 		res, err := tx.ExecContext(ctx,
 			`UPDATE crlShards
-				SET thisUpdate = ?, nextUpdate = ?, leasedUntil = ?
+				SET thisUpdate = ?, nextUpdate = ?, leasedUntil = ?`+setDigest+`
 				WHERE issuerID = ?
 				AND idx = ?
 				AND (thisUpdate is NULL OR thisUpdate <= ?)
 				LIMIT 1`,
-			req.ThisUpdate.AsTime(),
-			nextUpdate,
-			req.ThisUpdate.AsTime(),
-			req.IssuerNameID,
-			req.ShardIdx,
-			req.ThisUpdate.AsTime(),
+			params...,
 		)
 		if err != nil {
 			return nil, err
@@ -1308,7 +1515,7 @@ func (ssa *SQLStorageAuthority) PauseIdentifiers(ctx context.Context, req *sapb.
 	}
 
 	response := &sapb.PauseIdentifiersResponse{}
-	_, err = db.WithTransaction(ctx, ssa.dbMap, func(tx db.Executor) (interface{}, error) {
+	_, err = ssa.withTransaction(ctx, "PauseIdentifiers", func(tx db.Executor) (interface{}, error) {
 		for _, ident := range idents {
 			pauseError := func(op string, err error) error {
 				return fmt.Errorf("while %s identifier %s for registration ID %d: %w",
@@ -1456,7 +1663,7 @@ func (ssa *SQLStorageAuthority) AddRateLimitOverride(ctx context.Context, req *s
 	var enabled bool
 	now := ssa.clk.Now()
 
-	_, err := db.WithTransaction(ctx, ssa.dbMap, func(tx db.Executor) (any, error) {
+	_, err := ssa.withTransaction(ctx, "AddRateLimitOverride", func(tx db.Executor) (any, error) {
 		var alreadyEnabled bool
 		err := tx.SelectOne(ctx, &alreadyEnabled, `
 			SELECT enabled
@@ -1524,7 +1731,7 @@ func (ssa *SQLStorageAuthority) setRateLimitOverride(ctx context.Context, limitE
 		return nil, fmt.Errorf("getting columns for override model: %w", err)
 	}
 	overrideColumns := strings.Join(overrideColumnsList, ", ")
-	_, err = db.WithTransaction(ctx, ssa.dbMap, func(tx db.Executor) (any, error) {
+	_, err = ssa.withTransaction(ctx, "setRateLimitOverride", func(tx db.Executor) (any, error) {
 		var existing overrideModel
 		err := tx.SelectOne(ctx, &existing,
 			// Use SELECT FOR UPDATE to both verify the row exists and lock it
@@ -1597,3 +1804,318 @@ func (ssa *SQLStorageAuthority) EnableRateLimitOverride(ctx context.Context, req
 	}
 	return ssa.setRateLimitOverride(ctx, req.LimitEnum, req.BucketKey, true)
 }
+
+// SetContactVerification records the verification state of the contact with
+// the given SHA-256 hash, replacing any previous state.
+func (ssa *SQLStorageAuthority) SetContactVerification(ctx context.Context, req *sapb.ContactVerification) (*emptypb.Empty, error) {
+	if core.IsAnyNilOrZero(req, req.ContactHash, req.Status) {
+		return nil, errIncompleteRequest
+	}
+	if len(req.ContactHash) != sha256.Size {
+		return nil, fmt.Errorf("invalid contact hash length %d", len(req.ContactHash))
+	}
+	status := core.AcmeStatus(req.Status)
+	if !slices.Contains(contactVerificationStatuses, status) {
+		return nil, fmt.Errorf("invalid contact verification status %q", req.Status)
+	}
+
+	// The reason column is limited to 255 characters.
+	reason := req.Reason
+	if len(reason) > 255 {
+		reason = reason[:255]
+	}
+
+	_, err := ssa.dbMap.ExecContext(ctx, `
+		INSERT INTO contactVerifications (contactHash, status, reason, updatedAt)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			status = VALUES(status),
+			reason = VALUES(reason),
+			updatedAt = VALUES(updatedAt)`,
+		req.ContactHash,
+		statusUint(status),
+		reason,
+		ssa.clk.Now(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("setting contact verification: %w", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// SetAccountRevocation records the progress of the revocation of a deactivated
+// account's certificates, replacing any progress already recorded. Only
+// deactivated accounts may have their certificates revoked this way.
+func (ssa *SQLStorageAuthority) SetAccountRevocation(ctx context.Context, req *sapb.AccountRevocation) (*emptypb.Empty, error) {
+	if core.IsAnyNilOrZero(req, req.RegistrationID, req.Requested) {
+		return nil, errIncompleteRequest
+	}
+	if req.Total < 0 || req.Revoked < 0 || req.Failed < 0 || req.Revoked+req.Failed > req.Total {
+		return nil, fmt.Errorf("invalid certificate revocation progress: %d revoked and %d failed of %d", req.Revoked, req.Failed, req.Total)
+	}
+
+	var completed *time.Time
+	if req.Completed != nil {
+		t := req.Completed.AsTime()
+		completed = &t
+	}
+
+	_, err := ssa.withTransaction(ctx, "SetAccountRevocation", func(tx db.Executor) (any, error) {
+		var status string
+		err := tx.SelectOne(ctx, &status, "SELECT status FROM registrations WHERE id = ?", req.RegistrationID)
+		if err != nil {
+			if db.IsNoRows(err) {
+				return nil, berrors.NotFoundError("registration with ID '%d' not found", req.RegistrationID)
+			}
+			return nil, err
+		}
+		if core.AcmeStatus(status) != core.StatusDeactivated {
+			return nil, berrors.MalformedError("registration %d is %s, not deactivated", req.RegistrationID, status)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO accountRevocations (registrationID, requested, total, revoked, failed, completed)
+			VALUES (?, ?, ?, ?, ?, ?)
+			ON DUPLICATE KEY UPDATE
+				requested = VALUES(requested),
+				total = VALUES(total),
+				revoked = VALUES(revoked),
+				failed = VALUES(failed),
+				completed = VALUES(completed)`,
+			req.RegistrationID,
+			req.Requested.AsTime(),
+			req.Total,
+			req.Revoked,
+			req.Failed,
+			completed,
+		)
+		return nil, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("setting certificate revocation of registration %d: %w", req.RegistrationID, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// LeaseAccountRevocation leases the longest-requested revocation of a
+// deactivated account's certificates which has neither completed nor is
+// already leased, until the given time. It returns a NotFound error if there
+// is none.
+func (ssa *SQLStorageAuthority) LeaseAccountRevocation(ctx context.Context, req *sapb.LeaseAccountRevocationRequest) (*sapb.AccountRevocation, error) {
+	if core.IsAnyNilOrZero(req, req.Until) {
+		return nil, errIncompleteRequest
+	}
+	now := ssa.clk.Now()
+	if req.Until.AsTime().Before(now) {
+		return nil, fmt.Errorf("lease timestamp must be in the future, got %q", req.Until.AsTime())
+	}
+
+	result, err := ssa.withTransaction(ctx, "LeaseAccountRevocation", func(tx db.Executor) (any, error) {
+		var model accountRevocationModel
+		err := tx.SelectOne(ctx, &model,
+			`SELECT registrationID, requested, total, revoked, failed, completed, leasedUntil
+				FROM accountRevocations
+				WHERE completed IS NULL
+				AND (leasedUntil IS NULL OR leasedUntil <= ?)
+				ORDER BY requested
+				LIMIT 1`,
+			now,
+		)
+		if err != nil {
+			if db.IsNoRows(err) {
+				return nil, berrors.NotFoundError("no certificate revocation to lease")
+			}
+			return nil, fmt.Errorf("selecting certificate revocation: %w", err)
+		}
+
+		res, err := tx.ExecContext(ctx,
+			`UPDATE accountRevocations
+				SET leasedUntil = ?
+				WHERE registrationID = ?
+				AND completed IS NULL
+				AND (leasedUntil IS NULL OR leasedUntil <= ?)`,
+			req.Until.AsTime(),
+			model.RegistrationID,
+			now,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("leasing certificate revocation: %w", err)
+		}
+		rowsAffected, err := res.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("confirming lease of certificate revocation: %w", err)
+		}
+		if rowsAffected != 1 {
+			return nil, errors.New("failed to lease certificate revocation")
+		}
+		return newPBFromAccountRevocationModel(&model), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*sapb.AccountRevocation), nil
+}
+
+// AddExemptionRequest queues a subscriber's request for a rate limit override
+// for review. It returns the request as stored, with its ID and status.
+func (ssa *SQLStorageAuthority) AddExemptionRequest(ctx context.Context, req *sapb.ExemptionRequest) (*sapb.ExemptionRequest, error) {
+	if core.IsAnyNilOrZero(req, req.RegistrationID, req.Override, req.Justification) ||
+		core.IsAnyNilOrZero(req.Override.LimitEnum, req.Override.BucketKey, req.Override.Period, req.Override.Count, req.Override.Burst) {
+		return nil, errIncompleteRequest
+	}
+
+	model := exemptionRequestModel{
+		RegistrationID: req.RegistrationID,
+		LimitEnum:      req.Override.LimitEnum,
+		BucketKey:      req.Override.BucketKey,
+		PeriodNS:       req.Override.Period.AsDuration().Nanoseconds(),
+		Count:          req.Override.Count,
+		Burst:          req.Override.Burst,
+		Justification:  req.Justification,
+		CreatedAt:      ssa.clk.Now().Truncate(time.Second),
+	}
+	err := ssa.dbMap.Insert(ctx, &model)
+	if err != nil {
+		return nil, fmt.Errorf("inserting exemption request for registration %d: %w", req.RegistrationID, err)
+	}
+	return newPBFromExemptionRequestModel(&model), nil
+}
+
+// AddValidationFailure records a failed validation attempt for the given
+// authorization, and the problem it failed with, and returns the number
+// recorded so far.
+func (ssa *SQLStorageAuthority) AddValidationFailure(ctx context.Context, req *sapb.AddValidationFailureRequest) (*sapb.ValidationFailures, error) {
+	if core.IsAnyNilOrZero(req, req.Id, req.Challenge, req.Problem) {
+		return nil, errIncompleteRequest
+	}
+	challenge, ok := challTypeToUint[req.Challenge]
+	if !ok {
+		return nil, fmt.Errorf("unrecognized challenge type %q", req.Challenge)
+	}
+	prob, err := bgrpc.PBToProblemDetails(req.Problem)
+	if err != nil {
+		return nil, err
+	}
+	problem, err := json.Marshal(prob)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := ssa.withTransaction(ctx, "AddValidationFailure", func(tx db.Executor) (any, error) {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO validationFailures (authzID, count, lastFailure, lastChallenge, lastProblem)
+			VALUES (?, 1, ?, ?, ?)
+			ON DUPLICATE KEY UPDATE
+				count = count + 1,
+				lastFailure = VALUES(lastFailure),
+				lastChallenge = VALUES(lastChallenge),
+				lastProblem = VALUES(lastProblem)`,
+			req.Id,
+			ssa.clk.Now().Truncate(time.Second),
+			challenge,
+			problem,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		var model validationFailuresModel
+		err = tx.SelectOne(ctx, &model,
+			"SELECT authzID, count, lastFailure, lastChallenge, lastProblem FROM validationFailures WHERE authzID = ?",
+			req.Id,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return newPBFromValidationFailuresModel(&model)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("recording validation failure of authorization %d: %w", req.Id, err)
+	}
+	return result.(*sapb.ValidationFailures), nil
+}
+
+// SetAuthzCAARecheck records the time of a successful background CAA recheck
+// of the given authorization. An earlier time never replaces a later one, so
+// rechecks which complete out of order can't make the authorization look
+// staler than it is.
+func (ssa *SQLStorageAuthority) SetAuthzCAARecheck(ctx context.Context, req *sapb.SetAuthzCAARecheckRequest) (*emptypb.Empty, error) {
+	if core.IsAnyNilOrZero(req, req.Id, req.Rechecked) {
+		return nil, errIncompleteRequest
+	}
+
+	_, err := ssa.dbMap.ExecContext(ctx, `
+		INSERT INTO authzCAARechecks (authzID, rechecked)
+		VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE
+			rechecked = GREATEST(rechecked, VALUES(rechecked))`,
+		req.Id,
+		req.Rechecked.AsTime().Truncate(time.Second),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("recording CAA recheck of authorization %d: %w", req.Id, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ReviewExemptionRequest records the approval or rejection of a pending rate
+// limit exemption request, and returns the reviewed request. It returns a
+// Duplicate error if the request has already been reviewed. Approving a request
+// does not create the override it asks for; the reviewer does that separately.
+func (ssa *SQLStorageAuthority) ReviewExemptionRequest(ctx context.Context, req *sapb.ReviewExemptionRequestRequest) (*sapb.ExemptionRequest, error) {
+	if core.IsAnyNilOrZero(req, req.Id, req.Status, req.Reviewer) {
+		return nil, errIncompleteRequest
+	}
+	if req.Status != "approved" && req.Status != "rejected" {
+		return nil, fmt.Errorf("exemption request status %q is not a review outcome", req.Status)
+	}
+	status, err := exemptionRequestStatusUint(req.Status)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := exemptionRequestStatusUint("pending")
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := ssa.withTransaction(ctx, "ReviewExemptionRequest", func(tx db.Executor) (any, error) {
+		res, err := tx.ExecContext(ctx, `
+			UPDATE exemptionRequests
+			SET status = ?, reviewedAt = ?, reviewer = ?, reviewComment = ?
+			WHERE id = ? AND status = ?`,
+			status,
+			ssa.clk.Now().Truncate(time.Second),
+			req.Reviewer,
+			req.Comment,
+			req.Id,
+			pending,
+		)
+		if err != nil {
+			return nil, err
+		}
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+
+		var model exemptionRequestModel
+		err = tx.SelectOne(ctx, &model,
+			"SELECT "+exemptionRequestFields+" FROM exemptionRequests WHERE id = ?",
+			req.Id,
+		)
+		if err != nil {
+			if db.IsNoRows(err) {
+				return nil, berrors.NotFoundError("no exemption request with ID %d", req.Id)
+			}
+			return nil, err
+		}
+		if rows == 0 {
+			return nil, berrors.DuplicateError("exemption request %d has already been %s", req.Id, exemptionRequestStatuses[model.Status])
+		}
+		return newPBFromExemptionRequestModel(&model), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reviewing exemption request %d: %w", req.Id, err)
+	}
+	return result.(*sapb.ExemptionRequest), nil
+}
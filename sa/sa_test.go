@@ -360,6 +360,14 @@ func TestAddSerial(t *testing.T) {
 		Expires: timestamppb.New(testCert.NotAfter),
 	})
 	test.AssertNotError(t, err, "adding serial should have succeeded")
+
+	_, err = sa.AddSerial(context.Background(), &sapb.AddSerialRequest{
+		Serial:  serial,
+		RegID:   reg.Id,
+		Created: timestamppb.New(testCert.NotBefore),
+		Expires: timestamppb.New(testCert.NotAfter),
+	})
+	test.AssertErrorIs(t, err, berrors.Duplicate)
 }
 
 func TestGetSerialMetadata(t *testing.T) {
@@ -869,13 +877,13 @@ func TestDeactivateAccount(t *testing.T) {
 	reg := createWorkingRegistration(t, sa)
 
 	// An incomplete request should be rejected.
-	_, err := sa.DeactivateRegistration(context.Background(), &sapb.RegistrationID{})
+	_, err := sa.DeactivateRegistration(context.Background(), &sapb.DeactivateRegistrationRequest{})
 	test.AssertError(t, err, "Incomplete request should fail")
 	test.AssertContains(t, err.Error(), "incomplete")
 
 	// Deactivating should work, and return the same account but with updated
 	// status and cleared contacts.
-	got, err := sa.DeactivateRegistration(context.Background(), &sapb.RegistrationID{Id: reg.Id})
+	got, err := sa.DeactivateRegistration(context.Background(), &sapb.DeactivateRegistrationRequest{RegistrationID: reg.Id})
 	test.AssertNotError(t, err, "DeactivateRegistration failed")
 	test.AssertEquals(t, got.Id, reg.Id)
 	test.AssertEquals(t, core.AcmeStatus(got.Status), core.StatusDeactivated)
@@ -889,7 +897,7 @@ func TestDeactivateAccount(t *testing.T) {
 
 	// Attempting to deactivate it a second time should fail, since it is already
 	// deactivated.
-	_, err = sa.DeactivateRegistration(context.Background(), &sapb.RegistrationID{Id: reg.Id})
+	_, err = sa.DeactivateRegistration(context.Background(), &sapb.DeactivateRegistrationRequest{RegistrationID: reg.Id})
 	test.AssertError(t, err, "Deactivating an already-deactivated account should fail")
 }
 
@@ -1000,6 +1008,7 @@ func TestNewOrderAndAuthzs(t *testing.T) {
 	test.AssertNotError(t, err, "sa.NewOrderAndAuthzs failed")
 	test.AssertEquals(t, order.Id, int64(1))
 	test.AssertDeepEquals(t, order.V2Authorizations, []int64{1, 2, 3, 4})
+	test.AssertEquals(t, order.Status, string(core.StatusPending))
 
 	var authzIDs []int64
 	_, err = sa.dbMap.Select(ctx, &authzIDs, "SELECT authzID FROM orderToAuthz2 WHERE orderID = ?;", order.Id)
@@ -1174,7 +1183,7 @@ func TestSetOrderProcessing(t *testing.T) {
 	test.AssertNotError(t, err, "NewOrderAndAuthzs failed")
 
 	// Set the order to be processing
-	_, err = sa.SetOrderProcessing(context.Background(), &sapb.OrderRequest{Id: order.Id})
+	_, err = sa.SetOrderProcessing(context.Background(), &sapb.SetOrderProcessingRequest{Id: order.Id})
 	test.AssertNotError(t, err, "SetOrderProcessing failed")
 
 	// Read the order by ID from the DB to check the status was correctly updated
@@ -1187,11 +1196,51 @@ func TestSetOrderProcessing(t *testing.T) {
 	test.AssertEquals(t, updatedOrder.BeganProcessing, true)
 
 	// Try to set the same order to be processing again. We should get an error.
-	_, err = sa.SetOrderProcessing(context.Background(), &sapb.OrderRequest{Id: order.Id})
+	_, err = sa.SetOrderProcessing(context.Background(), &sapb.SetOrderProcessingRequest{Id: order.Id})
 	test.AssertError(t, err, "Set the same order processing twice. This should have been an error.")
 	test.AssertErrorIs(t, err, berrors.OrderNotReady)
 }
 
+func TestSetOrderProcessingCSRHash(t *testing.T) {
+	if os.Getenv("BOULDER_CONFIG_DIR") != "test/config-next" {
+		t.Skip("skipping, the orders.csrHash column must exist for this test to run")
+	}
+
+	sa, fc, cleanUp := initSA(t)
+	defer cleanUp()
+	features.Set(features.Config{IdempotentFinalize: true})
+	defer features.Reset()
+
+	reg := createWorkingRegistration(t, sa)
+	authzID := createFinalizedAuthorization(t, sa, identifier.NewDNS("example.com"), fc.Now().Add(time.Hour), "valid", fc.Now())
+	order, err := sa.NewOrderAndAuthzs(context.Background(), &sapb.NewOrderAndAuthzsRequest{
+		NewOrder: &sapb.NewOrderRequest{
+			RegistrationID:   reg.Id,
+			Expires:          timestamppb.New(fc.Now().Add(365 * 24 * time.Hour)),
+			Identifiers:      []*corepb.Identifier{identifier.NewDNS("example.com").ToProto()},
+			V2Authorizations: []int64{authzID},
+		},
+	})
+	test.AssertNotError(t, err, "NewOrderAndAuthzs failed")
+
+	csrHash := sha256.Sum256([]byte("csr"))
+	_, err = sa.SetOrderProcessing(context.Background(), &sapb.SetOrderProcessingRequest{Id: order.Id, CsrHash: csrHash[:]})
+	test.AssertNotError(t, err, "SetOrderProcessing failed")
+
+	updatedOrder, err := sa.GetOrder(context.Background(), &sapb.OrderRequest{Id: order.Id})
+	test.AssertNotError(t, err, "GetOrder failed")
+	test.AssertEquals(t, updatedOrder.Status, string(core.StatusProcessing))
+	test.AssertByteEquals(t, updatedOrder.CsrHash, csrHash[:])
+
+	// A second attempt doesn't overwrite the hash.
+	otherHash := sha256.Sum256([]byte("other csr"))
+	_, err = sa.SetOrderProcessing(context.Background(), &sapb.SetOrderProcessingRequest{Id: order.Id, CsrHash: otherHash[:]})
+	test.AssertErrorIs(t, err, berrors.OrderNotReady)
+	updatedOrder, err = sa.GetOrder(context.Background(), &sapb.OrderRequest{Id: order.Id})
+	test.AssertNotError(t, err, "GetOrder failed")
+	test.AssertByteEquals(t, updatedOrder.CsrHash, csrHash[:])
+}
+
 func TestFinalizeOrder(t *testing.T) {
 	sa, fc, cleanup := initSA(t)
 	defer cleanup()
@@ -1214,7 +1263,7 @@ func TestFinalizeOrder(t *testing.T) {
 	test.AssertNotError(t, err, "NewOrderAndAuthzs failed")
 
 	// Set the order to processing so it can be finalized
-	_, err = sa.SetOrderProcessing(ctx, &sapb.OrderRequest{Id: order.Id})
+	_, err = sa.SetOrderProcessing(ctx, &sapb.SetOrderProcessingRequest{Id: order.Id})
 	test.AssertNotError(t, err, "SetOrderProcessing failed")
 
 	// Finalize the order with a certificate serial
@@ -1587,7 +1636,7 @@ func TestGetOrderForNames(t *testing.T) {
 	test.AssertEquals(t, result.Id, order.Id)
 
 	// Set the order processing so it can be finalized
-	_, err = sa.SetOrderProcessing(ctx, &sapb.OrderRequest{Id: order.Id})
+	_, err = sa.SetOrderProcessing(ctx, &sapb.SetOrderProcessingRequest{Id: order.Id})
 	test.AssertNotError(t, err, "sa.SetOrderProcessing failed")
 
 	// Finalize the order
@@ -1738,7 +1787,7 @@ func TestStatusForOrder(t *testing.T) {
 			test.AssertNotError(t, err, "NewOrderAndAuthzs errored unexpectedly")
 			// If requested, set the order to processing
 			if tc.SetProcessing {
-				_, err := sa.SetOrderProcessing(ctx, &sapb.OrderRequest{Id: newOrder.Id})
+				_, err := sa.SetOrderProcessing(ctx, &sapb.SetOrderProcessingRequest{Id: newOrder.Id})
 				test.AssertNotError(t, err, "Error setting order to processing status")
 			}
 			// If requested, finalize the order
@@ -1833,6 +1882,138 @@ func TestRevokeCertificate(t *testing.T) {
 	test.AssertError(t, err, "RevokeCertificate should've failed when certificate already revoked")
 }
 
+func TestGetRevocationStatuses(t *testing.T) {
+	sa, fc, cleanUp := initSA(t)
+	defer cleanUp()
+
+	reg := createWorkingRegistration(t, sa)
+	var serials []string
+	for range 3 {
+		serial, testCert := test.ThrowAwayCert(t, fc)
+		_, err := sa.AddPrecertificate(ctx, &sapb.AddCertificateRequest{
+			Der:          testCert.Raw,
+			RegID:        reg.Id,
+			Issued:       timestamppb.New(sa.clk.Now()),
+			IssuerNameID: 1,
+		})
+		test.AssertNotError(t, err, "Couldn't add test cert")
+		serials = append(serials, serial)
+	}
+
+	fc.Add(1 * time.Hour)
+	now := fc.Now()
+	_, err := sa.RevokeCertificate(context.Background(), &sapb.RevokeCertificateRequest{
+		IssuerID: 1,
+		Serial:   serials[1],
+		Date:     timestamppb.New(now),
+		Reason:   1,
+	})
+	test.AssertNotError(t, err, "RevokeCertificate failed")
+
+	// Include a duplicate and an unknown serial.
+	unknown := "0000000000000000000000000000000000ff"
+	resp, err := sa.GetRevocationStatuses(ctx, &sapb.Serials{Serials: append(serials, serials[0], unknown)})
+	test.AssertNotError(t, err, "GetRevocationStatuses failed")
+	test.AssertEquals(t, len(resp.Statuses), 3)
+	test.AssertEquals(t, resp.Statuses[serials[0]].Status, int64(ocsp.Good))
+	test.AssertEquals(t, resp.Statuses[serials[1]].Status, int64(ocsp.Revoked))
+	test.AssertEquals(t, resp.Statuses[serials[1]].RevokedReason, int64(1))
+	test.AssertEquals(t, resp.Statuses[serials[1]].RevokedDate.AsTime(), now)
+	test.AssertEquals(t, resp.Statuses[serials[2]].Status, int64(ocsp.Good))
+	_, ok := resp.Statuses[unknown]
+	test.Assert(t, !ok, "unknown serial should be omitted")
+
+	_, err = sa.GetRevocationStatuses(ctx, &sapb.Serials{})
+	test.AssertError(t, err, "GetRevocationStatuses with no serials should fail")
+
+	_, err = sa.GetRevocationStatuses(ctx, &sapb.Serials{Serials: []string{"not-a-serial"}})
+	test.AssertError(t, err, "GetRevocationStatuses with an invalid serial should fail")
+
+	tooMany := make([]string, maxRevocationStatusBatchSize+1)
+	for i := range tooMany {
+		tooMany[i] = serials[0]
+	}
+	_, err = sa.GetRevocationStatuses(ctx, &sapb.Serials{Serials: tooMany})
+	test.AssertError(t, err, "GetRevocationStatuses with too many serials should fail")
+}
+
+func TestSearchCertificates(t *testing.T) {
+	sa, fc, cleanUp := initSA(t)
+	defer cleanUp()
+
+	reg := createWorkingRegistration(t, sa)
+	var serials []string
+	var certs []*x509.Certificate
+	for range 2 {
+		serial, testCert := test.ThrowAwayCert(t, fc)
+		_, err := sa.AddSerial(ctx, &sapb.AddSerialRequest{
+			RegID:   reg.Id,
+			Serial:  serial,
+			Created: timestamppb.New(fc.Now()),
+			Expires: timestamppb.New(testCert.NotAfter),
+		})
+		test.AssertNotError(t, err, "AddSerial failed")
+		_, err = sa.AddPrecertificate(ctx, &sapb.AddCertificateRequest{
+			Der:          testCert.Raw,
+			RegID:        reg.Id,
+			Issued:       timestamppb.New(fc.Now()),
+			IssuerNameID: 1,
+		})
+		test.AssertNotError(t, err, "AddPrecertificate failed")
+		serials = append(serials, serial)
+		certs = append(certs, testCert)
+	}
+
+	// Expired certificates are found too.
+	fc.Add(7 * 24 * time.Hour)
+
+	search := func(req *sapb.SearchCertificatesRequest) ([]*sapb.SerialMetadata, error) {
+		output := make(chan *sapb.SerialMetadata, maxCertificateSearchResults)
+		err := sa.SearchCertificates(req, &fakeServerStream[sapb.SerialMetadata]{output: output})
+		close(output)
+		var results []*sapb.SerialMetadata
+		for result := range output {
+			results = append(results, result)
+		}
+		return results, err
+	}
+
+	results, err := search(&sapb.SearchCertificatesRequest{San: certs[0].DNSNames[0]})
+	test.AssertNotError(t, err, "SearchCertificates by SAN failed")
+	test.AssertEquals(t, len(results), 1)
+	test.AssertEquals(t, results[0].Serial, serials[0])
+	test.AssertEquals(t, results[0].RegistrationID, reg.Id)
+
+	results, err = search(&sapb.SearchCertificatesRequest{San: certs[1].IPAddresses[0].String()})
+	test.AssertNotError(t, err, "SearchCertificates by IP SAN failed")
+	test.AssertEquals(t, len(results), 1)
+	test.AssertEquals(t, results[0].Serial, serials[1])
+
+	keyHash := sha256.Sum256(certs[1].RawSubjectPublicKeyInfo)
+	results, err = search(&sapb.SearchCertificatesRequest{KeyHash: keyHash[:]})
+	test.AssertNotError(t, err, "SearchCertificates by key hash failed")
+	test.AssertEquals(t, len(results), 1)
+	test.AssertEquals(t, results[0].Serial, serials[1])
+
+	results, err = search(&sapb.SearchCertificatesRequest{SerialPrefix: serials[0][:12]})
+	test.AssertNotError(t, err, "SearchCertificates by serial prefix failed")
+	test.AssertEquals(t, len(results), 1)
+	test.AssertEquals(t, results[0].Serial, serials[0])
+
+	results, err = search(&sapb.SearchCertificatesRequest{SerialPrefix: "0", Limit: 1})
+	test.AssertNotError(t, err, "SearchCertificates with a limit failed")
+	test.AssertEquals(t, len(results), 1)
+
+	_, err = search(&sapb.SearchCertificatesRequest{})
+	test.AssertError(t, err, "SearchCertificates without a search term should fail")
+	_, err = search(&sapb.SearchCertificatesRequest{San: "example.com", SerialPrefix: "00"})
+	test.AssertError(t, err, "SearchCertificates with two search terms should fail")
+	_, err = search(&sapb.SearchCertificatesRequest{SerialPrefix: "00%"})
+	test.AssertError(t, err, "SearchCertificates with a wildcard should fail")
+	_, err = search(&sapb.SearchCertificatesRequest{SerialPrefix: "00", Limit: maxCertificateSearchResults + 1})
+	test.AssertError(t, err, "SearchCertificates with too large a limit should fail")
+}
+
 func TestRevokeCertificateWithShard(t *testing.T) {
 	sa, fc, cleanUp := initSA(t)
 	defer cleanUp()
@@ -3637,6 +3818,65 @@ func TestUpdateCRLShard(t *testing.T) {
 	test.AssertError(t, err, "updating an unknown shard")
 }
 
+func TestGetCRLShard(t *testing.T) {
+	if os.Getenv("BOULDER_CONFIG_DIR") != "test/config-next" {
+		t.Skip("skipping, the crlShards.entriesDigest column must exist for this test to run")
+	}
+
+	sa, clk, cleanUp := initSA(t)
+	defer cleanUp()
+
+	_, err := sa.GetCRLShard(ctx, &sapb.GetCRLShardRequest{IssuerNameID: 1, ShardIdx: 0})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+
+	_, err = sa.LeaseCRLShard(ctx, &sapb.LeaseCRLShardRequest{
+		IssuerNameID: 1,
+		MinShardIdx:  0,
+		MaxShardIdx:  0,
+		Until:        timestamppb.New(clk.Now().Add(time.Hour)),
+	})
+	test.AssertNotError(t, err, "leasing shard")
+
+	shard, err := sa.GetCRLShard(ctx, &sapb.GetCRLShardRequest{IssuerNameID: 1, ShardIdx: 0})
+	test.AssertNotError(t, err, "getting new shard")
+	test.Assert(t, shard.ThisUpdate == nil, "unexpected thisUpdate for a shard never generated")
+	test.AssertEquals(t, len(shard.EntriesDigest), 0)
+
+	thisUpdate := clk.Now().Truncate(time.Second).UTC()
+	digest := sha256.Sum256([]byte("entries"))
+	_, err = sa.UpdateCRLShard(ctx, &sapb.UpdateCRLShardRequest{
+		IssuerNameID:  1,
+		ShardIdx:      0,
+		ThisUpdate:    timestamppb.New(thisUpdate),
+		EntriesDigest: digest[:],
+	})
+	test.AssertNotError(t, err, "updating shard with digest")
+
+	shard, err = sa.GetCRLShard(ctx, &sapb.GetCRLShardRequest{IssuerNameID: 1, ShardIdx: 0})
+	test.AssertNotError(t, err, "getting updated shard")
+	test.AssertEquals(t, shard.ThisUpdate.AsTime(), thisUpdate)
+	test.AssertByteEquals(t, shard.EntriesDigest, digest[:])
+
+	// An update without a digest leaves the stored digest alone.
+	_, err = sa.UpdateCRLShard(ctx, &sapb.UpdateCRLShardRequest{
+		IssuerNameID: 1,
+		ShardIdx:     0,
+		ThisUpdate:   timestamppb.New(thisUpdate),
+	})
+	test.AssertNotError(t, err, "updating shard without digest")
+	shard, err = sa.GetCRLShard(ctx, &sapb.GetCRLShardRequest{IssuerNameID: 1, ShardIdx: 0})
+	test.AssertNotError(t, err, "getting updated shard")
+	test.AssertByteEquals(t, shard.EntriesDigest, digest[:])
+
+	_, err = sa.UpdateCRLShard(ctx, &sapb.UpdateCRLShardRequest{
+		IssuerNameID:  1,
+		ShardIdx:      0,
+		ThisUpdate:    timestamppb.New(thisUpdate),
+		EntriesDigest: []byte("short"),
+	})
+	test.AssertError(t, err, "updating shard with a malformed digest")
+}
+
 func TestReplacementOrderExists(t *testing.T) {
 	sa, fc, cleanUp := initSA(t)
 	defer cleanUp()
@@ -3669,7 +3909,7 @@ func TestReplacementOrderExists(t *testing.T) {
 	test.AssertNotError(t, err, "NewOrderAndAuthzs failed")
 
 	// Set the order to processing so it can be finalized
-	_, err = sa.SetOrderProcessing(ctx, &sapb.OrderRequest{Id: order.Id})
+	_, err = sa.SetOrderProcessing(ctx, &sapb.SetOrderProcessingRequest{Id: order.Id})
 	test.AssertNotError(t, err, "SetOrderProcessing failed")
 
 	// Finalize the order with a certificate oldCertSerial.
@@ -3695,7 +3935,7 @@ func TestReplacementOrderExists(t *testing.T) {
 	test.Assert(t, exists.Exists, "replacement order should exist")
 
 	// Set the order to processing so it can be finalized.
-	_, err = sa.SetOrderProcessing(ctx, &sapb.OrderRequest{Id: order.Id})
+	_, err = sa.SetOrderProcessing(ctx, &sapb.SetOrderProcessingRequest{Id: order.Id})
 	test.AssertNotError(t, err, "SetOrderProcessing failed")
 
 	// Check that a replacement order in processing still exists.
@@ -3860,6 +4100,132 @@ func TestGetSerialsByAccount(t *testing.T) {
 	test.AssertEquals(t, len(seen), 2)
 }
 
+func TestGetCertificatesByExpiry(t *testing.T) {
+	sa, _, cleanUp := initSA(t)
+	defer cleanUp()
+
+	// Add a final cert to the DB to test with. This particular cert has a
+	// notAfter date of Mar 6 2023.
+	reg := createWorkingRegistration(t, sa)
+	eeCert, err := core.LoadCert("../test/hierarchy/ee-e1.cert.pem")
+	test.AssertNotError(t, err, "failed to load test cert")
+	serial := core.SerialToString(eeCert.SerialNumber)
+	_, err = sa.AddSerial(ctx, &sapb.AddSerialRequest{
+		RegID:   reg.Id,
+		Serial:  serial,
+		Created: timestamppb.New(eeCert.NotBefore),
+		Expires: timestamppb.New(eeCert.NotAfter),
+	})
+	test.AssertNotError(t, err, "failed to add test serial")
+	req := &sapb.AddCertificateRequest{
+		Der:          eeCert.Raw,
+		RegID:        reg.Id,
+		Issued:       timestamppb.New(eeCert.NotBefore),
+		IssuerNameID: 1,
+	}
+	_, err = sa.AddPrecertificate(ctx, req)
+	test.AssertNotError(t, err, "failed to add test precert")
+	_, err = sa.AddCertificate(ctx, req)
+	test.AssertNotError(t, err, "failed to add test cert")
+
+	getCerts := func(req *sapb.GetCertificatesByExpiryRequest) ([]*corepb.Certificate, error) {
+		stream := make(chan *corepb.Certificate)
+		mockServerStream := &fakeServerStream[corepb.Certificate]{output: stream}
+		var err error
+		go func() {
+			err = sa.GetCertificatesByExpiry(req, mockServerStream)
+			close(stream)
+		}()
+		var certs []*corepb.Certificate
+		for cert := range stream {
+			certs = append(certs, cert)
+		}
+		return certs, err
+	}
+
+	certs, err := getCerts(&sapb.GetCertificatesByExpiryRequest{
+		ExpiresAfter:  mustTimestamp("2023-03-01 00:00"),
+		ExpiresBefore: mustTimestamp("2023-04-01 00:00"),
+	})
+	test.AssertNotError(t, err, "GetCertificatesByExpiry failed")
+	test.AssertEquals(t, len(certs), 1)
+	test.AssertEquals(t, certs[0].Serial, serial)
+	test.AssertByteEquals(t, certs[0].Der, eeCert.Raw)
+	test.AssertEquals(t, certs[0].RegistrationID, reg.Id)
+
+	// A window ending before the cert expires doesn't include it.
+	certs, err = getCerts(&sapb.GetCertificatesByExpiryRequest{
+		ExpiresAfter:  mustTimestamp("2023-02-01 00:00"),
+		ExpiresBefore: mustTimestamp("2023-03-01 00:00"),
+	})
+	test.AssertNotError(t, err, "GetCertificatesByExpiry failed")
+	test.AssertEquals(t, len(certs), 0)
+
+	// An empty window is rejected.
+	_, err = getCerts(&sapb.GetCertificatesByExpiryRequest{
+		ExpiresAfter:  mustTimestamp("2023-04-01 00:00"),
+		ExpiresBefore: mustTimestamp("2023-03-01 00:00"),
+	})
+	test.AssertErrorIs(t, err, berrors.Malformed)
+}
+
+func TestGetMPICComplianceStats(t *testing.T) {
+	if os.Getenv("BOULDER_CONFIG_DIR") != "test/config-next" {
+		t.Skip("skipping, the validationPerspectives table must exist for this test to run")
+	}
+
+	sa, fc, cleanUp := initSA(t)
+	defer cleanUp()
+	features.Set(features.Config{StoreValidationPerspectives: true})
+	defer features.Reset()
+
+	fc.Set(mustTime("2021-01-01 00:00"))
+	finalize := func(passed bool, latency time.Duration) {
+		t.Helper()
+		authzID := createPendingAuthorization(t, sa, identifier.NewDNS("aaa"), fc.Now().Add(time.Hour))
+		_, err := sa.FinalizeAuthorization2(ctx, &sapb.FinalizeAuthorizationRequest{
+			Id:          authzID,
+			Status:      string(core.StatusValid),
+			Expires:     timestamppb.New(fc.Now().Add(2 * time.Hour)),
+			Attempted:   string(core.ChallengeTypeHTTP01),
+			AttemptedAt: timestamppb.New(fc.Now()),
+			Perspectives: []*corepb.ValidationPerspective{
+				{Perspective: "Primary", Passed: true, Latency: durationpb.New(latency)},
+				{Perspective: "dc-0-ARIN", Rir: "ARIN", Passed: passed, Latency: durationpb.New(2 * latency)},
+			},
+		})
+		test.AssertNotError(t, err, "FinalizeAuthorization2 failed")
+	}
+	finalize(true, 100*time.Millisecond)
+	finalize(false, 300*time.Millisecond)
+	fc.Add(48 * time.Hour)
+	finalize(true, time.Second)
+
+	// Only the first day's two attempts are counted.
+	stats, err := sa.GetMPICComplianceStats(ctx, &sapb.GetMPICComplianceStatsRequest{
+		AttemptedAfter:  mustTimestamp("2021-01-01 00:00"),
+		AttemptedBefore: mustTimestamp("2021-01-02 00:00"),
+	})
+	test.AssertNotError(t, err, "GetMPICComplianceStats failed")
+	test.AssertEquals(t, stats.Attempts, int64(2))
+	test.AssertEquals(t, len(stats.Perspectives), 2)
+	test.AssertEquals(t, stats.Perspectives[0].Perspective, "Primary")
+	test.AssertEquals(t, stats.Perspectives[0].Passed, int64(2))
+	test.AssertEquals(t, stats.Perspectives[1].Perspective, "dc-0-ARIN")
+	test.AssertEquals(t, stats.Perspectives[1].Rir, "ARIN")
+	test.AssertEquals(t, stats.Perspectives[1].Attempts, int64(2))
+	test.AssertEquals(t, stats.Perspectives[1].Passed, int64(1))
+	test.AssertEquals(t, stats.Perspectives[1].MeanLatency.AsDuration(), 400*time.Millisecond)
+	test.AssertEquals(t, stats.Perspectives[1].MaxLatency.AsDuration(), 600*time.Millisecond)
+
+	// An empty window is rejected.
+	_, err = sa.GetMPICComplianceStats(ctx, &sapb.GetMPICComplianceStatsRequest{
+		AttemptedAfter:  mustTimestamp("2021-01-02 00:00"),
+		AttemptedBefore: mustTimestamp("2021-01-01 00:00"),
+	})
+	test.AssertErrorIs(t, err, berrors.Malformed)
+}
+
 func TestUnpauseAccount(t *testing.T) {
 	sa, _, cleanUp := initSA(t)
 	defer cleanUp()
@@ -4694,3 +5060,294 @@ func TestGetEnabledRateLimitOverrides(t *testing.T) {
 	test.AssertEquals(t, len(stream.sent), 1)
 	test.AssertEquals(t, stream.sent[0].Override.BucketKey, "on")
 }
+
+func TestContactVerifications(t *testing.T) {
+	if os.Getenv("BOULDER_CONFIG_DIR") != "test/config-next" {
+		t.Skip("skipping, the contactVerifications table must exist for this test to run")
+	}
+
+	sa, fc, cleanup := initSA(t)
+	defer cleanup()
+
+	hashA := sha256.Sum256([]byte("a@example.com"))
+	hashB := sha256.Sum256([]byte("b@example.com"))
+	unknown := sha256.Sum256([]byte("unknown@example.com"))
+
+	_, err := sa.SetContactVerification(ctx, &sapb.ContactVerification{
+		ContactHash: hashA[:],
+		Status:      string(core.StatusPending),
+		Reason:      "confirmation email sent",
+	})
+	test.AssertNotError(t, err, "SetContactVerification failed")
+	_, err = sa.SetContactVerification(ctx, &sapb.ContactVerification{
+		ContactHash: hashB[:],
+		Status:      string(core.StatusInvalid),
+		Reason:      "no MX or address records for example.com",
+	})
+	test.AssertNotError(t, err, "SetContactVerification failed")
+
+	// Setting a contact's verification again replaces it.
+	fc.Add(time.Hour)
+	_, err = sa.SetContactVerification(ctx, &sapb.ContactVerification{
+		ContactHash: hashA[:],
+		Status:      string(core.StatusValid),
+		Reason:      "confirmed by recipient",
+	})
+	test.AssertNotError(t, err, "SetContactVerification failed")
+
+	resp, err := sa.GetContactVerifications(ctx, &sapb.ContactHashes{ContactHashes: [][]byte{hashA[:], hashB[:], unknown[:]}})
+	test.AssertNotError(t, err, "GetContactVerifications failed")
+	test.AssertEquals(t, len(resp.Verifications), 2)
+	got := make(map[string]*sapb.ContactVerification)
+	for _, v := range resp.Verifications {
+		got[string(v.ContactHash)] = v
+	}
+	test.AssertEquals(t, got[string(hashA[:])].Status, string(core.StatusValid))
+	test.AssertEquals(t, got[string(hashA[:])].Reason, "confirmed by recipient")
+	test.AssertEquals(t, got[string(hashA[:])].Updated.AsTime(), fc.Now())
+	test.AssertEquals(t, got[string(hashB[:])].Status, string(core.StatusInvalid))
+
+	_, err = sa.SetContactVerification(ctx, &sapb.ContactVerification{ContactHash: hashA[:], Status: string(core.StatusRevoked)})
+	test.AssertError(t, err, "SetContactVerification with an invalid status should fail")
+	_, err = sa.SetContactVerification(ctx, &sapb.ContactVerification{ContactHash: []byte("short"), Status: string(core.StatusValid)})
+	test.AssertError(t, err, "SetContactVerification with an invalid hash should fail")
+	_, err = sa.GetContactVerifications(ctx, &sapb.ContactHashes{})
+	test.AssertError(t, err, "GetContactVerifications with no hashes should fail")
+}
+
+func TestRegistrationClientIdentity(t *testing.T) {
+	if os.Getenv("BOULDER_CONFIG_DIR") != "test/config-next" {
+		t.Skip("skipping, the registrationClientIdentities table must exist for this test to run")
+	}
+
+	sa, _, cleanup := initSA(t)
+	defer cleanup()
+
+	jwkJSON, _ := goodTestJWK().MarshalJSON()
+	reg, err := sa.NewRegistration(ctx, &corepb.Registration{
+		Key:            jwkJSON,
+		ClientIdentity: "spiffe://example.com/device/1",
+	})
+	test.AssertNotError(t, err, "NewRegistration failed")
+
+	identity, err := sa.GetRegistrationClientIdentity(ctx, &sapb.RegistrationID{Id: reg.Id})
+	test.AssertNotError(t, err, "GetRegistrationClientIdentity failed")
+	test.AssertEquals(t, identity.Identity, "spiffe://example.com/device/1")
+
+	// Registrations created without an identity aren't bound to one.
+	unbound := createWorkingRegistration(t, sa)
+	identity, err = sa.GetRegistrationClientIdentity(ctx, &sapb.RegistrationID{Id: unbound.Id})
+	test.AssertNotError(t, err, "GetRegistrationClientIdentity failed")
+	test.AssertEquals(t, identity.Identity, "")
+
+	// The identity's length is checked before anything is inserted, so reusing
+	// the key here doesn't produce a duplicate error.
+	_, err = sa.NewRegistration(ctx, &corepb.Registration{
+		Key:            jwkJSON,
+		ClientIdentity: strings.Repeat("a", 256),
+	})
+	test.AssertErrorIs(t, err, berrors.Malformed)
+}
+
+func TestAccountRevocation(t *testing.T) {
+	if os.Getenv("BOULDER_CONFIG_DIR") != "test/config-next" {
+		t.Skip("skipping, the accountRevocations table must exist for this test to run")
+	}
+
+	sa, fc, cleanup := initSA(t)
+	defer cleanup()
+
+	reg := createWorkingRegistration(t, sa)
+	_, err := sa.GetAccountRevocation(ctx, &sapb.RegistrationID{Id: reg.Id})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+
+	// Only deactivated registrations may have their certificates revoked.
+	requested := fc.Now().Truncate(time.Second)
+	progress := &sapb.AccountRevocation{
+		RegistrationID: reg.Id,
+		Requested:      timestamppb.New(requested),
+		Total:          3,
+	}
+	_, err = sa.SetAccountRevocation(ctx, progress)
+	test.AssertErrorIs(t, err, berrors.Malformed)
+
+	// The revocation is requested along with the deactivation, and can then be
+	// leased by one RA at a time until it's completed.
+	_, err = sa.DeactivateRegistration(ctx, &sapb.DeactivateRegistrationRequest{RegistrationID: reg.Id, RevokeCertificates: true})
+	test.AssertNotError(t, err, "DeactivateRegistration failed")
+	got, err := sa.GetAccountRevocation(ctx, &sapb.RegistrationID{Id: reg.Id})
+	test.AssertNotError(t, err, "GetAccountRevocation failed")
+	test.AssertEquals(t, got.Requested.AsTime(), requested)
+	test.AssertEquals(t, got.Total, int64(0))
+	test.AssertBoxedNil(t, got.Completed, "revocation shouldn't have completed")
+
+	leased, err := sa.LeaseAccountRevocation(ctx, &sapb.LeaseAccountRevocationRequest{Until: timestamppb.New(fc.Now().Add(time.Hour))})
+	test.AssertNotError(t, err, "LeaseAccountRevocation failed")
+	test.AssertEquals(t, leased.RegistrationID, reg.Id)
+	_, err = sa.LeaseAccountRevocation(ctx, &sapb.LeaseAccountRevocationRequest{Until: timestamppb.New(fc.Now().Add(time.Hour))})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+	fc.Add(time.Hour)
+	leased, err = sa.LeaseAccountRevocation(ctx, &sapb.LeaseAccountRevocationRequest{Until: timestamppb.New(fc.Now().Add(time.Hour))})
+	test.AssertNotError(t, err, "LeaseAccountRevocation of expired lease failed")
+	test.AssertEquals(t, leased.RegistrationID, reg.Id)
+
+	_, err = sa.SetAccountRevocation(ctx, progress)
+	test.AssertNotError(t, err, "SetAccountRevocation failed")
+	got, err = sa.GetAccountRevocation(ctx, &sapb.RegistrationID{Id: reg.Id})
+	test.AssertNotError(t, err, "GetAccountRevocation failed")
+	test.AssertEquals(t, got.Total, int64(3))
+	test.AssertEquals(t, got.Requested.AsTime(), requested)
+	test.AssertBoxedNil(t, got.Completed, "revocation shouldn't have completed")
+
+	// Later progress replaces earlier progress.
+	progress.Revoked = 2
+	progress.Failed = 1
+	progress.Completed = timestamppb.New(requested.Add(time.Minute))
+	_, err = sa.SetAccountRevocation(ctx, progress)
+	test.AssertNotError(t, err, "SetAccountRevocation failed")
+	got, err = sa.GetAccountRevocation(ctx, &sapb.RegistrationID{Id: reg.Id})
+	test.AssertNotError(t, err, "GetAccountRevocation failed")
+	test.AssertEquals(t, got.Revoked, int64(2))
+	test.AssertEquals(t, got.Failed, int64(1))
+	test.AssertEquals(t, got.Completed.AsTime(), requested.Add(time.Minute))
+
+	// A completed revocation is never leased again.
+	fc.Add(time.Hour)
+	_, err = sa.LeaseAccountRevocation(ctx, &sapb.LeaseAccountRevocationRequest{Until: timestamppb.New(fc.Now().Add(time.Hour))})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+
+	// More certificates can't be revoked than the account had.
+	progress.Revoked = 3
+	_, err = sa.SetAccountRevocation(ctx, progress)
+	test.AssertError(t, err, "SetAccountRevocation should have failed")
+}
+
+func TestExemptionRequests(t *testing.T) {
+	if os.Getenv("BOULDER_CONFIG_DIR") != "test/config-next" {
+		t.Skip("skipping, the exemptionRequests table must exist for this test to run")
+	}
+
+	sa, fc, cleanup := initSA(t)
+	defer cleanup()
+
+	override := &sapb.RateLimitOverride{
+		LimitEnum: 3,
+		BucketKey: "3:1234",
+		Period:    durationpb.New(time.Hour),
+		Count:     100,
+		Burst:     100,
+	}
+	first, err := sa.AddExemptionRequest(ctx, &sapb.ExemptionRequest{RegistrationID: 1234, Override: override, Justification: "first"})
+	test.AssertNotError(t, err, "AddExemptionRequest failed")
+	test.AssertEquals(t, first.Status, "pending")
+	fc.Add(time.Minute)
+	second, err := sa.AddExemptionRequest(ctx, &sapb.ExemptionRequest{RegistrationID: 5678, Override: override, Justification: "second"})
+	test.AssertNotError(t, err, "AddExemptionRequest failed")
+
+	got, err := sa.GetExemptionRequest(ctx, &sapb.ExemptionRequestID{Id: first.Id})
+	test.AssertNotError(t, err, "GetExemptionRequest failed")
+	test.AssertEquals(t, got.Override.BucketKey, "3:1234")
+	test.AssertEquals(t, got.Override.Period.AsDuration(), time.Hour)
+	test.AssertBoxedNil(t, got.Reviewed, "request shouldn't have been reviewed")
+	_, err = sa.GetExemptionRequest(ctx, &sapb.ExemptionRequestID{Id: second.Id + 1})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+
+	pending, err := sa.GetExemptionRequests(ctx, &sapb.GetExemptionRequestsRequest{Status: "pending", Limit: 10})
+	test.AssertNotError(t, err, "GetExemptionRequests failed")
+	test.AssertEquals(t, len(pending.Requests), 2)
+	test.AssertEquals(t, pending.Requests[0].Id, first.Id)
+	pending, err = sa.GetExemptionRequests(ctx, &sapb.GetExemptionRequestsRequest{Status: "pending", RegistrationID: 5678, Limit: 10})
+	test.AssertNotError(t, err, "GetExemptionRequests failed")
+	test.AssertEquals(t, len(pending.Requests), 1)
+	test.AssertEquals(t, pending.Requests[0].Id, second.Id)
+
+	reviewed, err := sa.ReviewExemptionRequest(ctx, &sapb.ReviewExemptionRequestRequest{Id: first.Id, Status: "approved", Reviewer: "admin", Comment: "ok"})
+	test.AssertNotError(t, err, "ReviewExemptionRequest failed")
+	test.AssertEquals(t, reviewed.Status, "approved")
+	test.AssertEquals(t, reviewed.Reviewer, "admin")
+	test.AssertEquals(t, reviewed.ReviewComment, "ok")
+
+	// A request can only be reviewed once.
+	_, err = sa.ReviewExemptionRequest(ctx, &sapb.ReviewExemptionRequestRequest{Id: first.Id, Status: "rejected", Reviewer: "admin"})
+	test.AssertErrorIs(t, err, berrors.Duplicate)
+	_, err = sa.ReviewExemptionRequest(ctx, &sapb.ReviewExemptionRequestRequest{Id: second.Id, Status: "pending", Reviewer: "admin"})
+	test.AssertError(t, err, "pending isn't a review outcome")
+	_, err = sa.ReviewExemptionRequest(ctx, &sapb.ReviewExemptionRequestRequest{Id: second.Id + 1, Status: "rejected", Reviewer: "admin"})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+
+	approved, err := sa.GetExemptionRequests(ctx, &sapb.GetExemptionRequestsRequest{Status: "approved", Limit: 10})
+	test.AssertNotError(t, err, "GetExemptionRequests failed")
+	test.AssertEquals(t, len(approved.Requests), 1)
+}
+
+func TestValidationFailures(t *testing.T) {
+	if os.Getenv("BOULDER_CONFIG_DIR") != "test/config-next" {
+		t.Skip("skipping, the validationFailures table must exist for this test to run")
+	}
+
+	sa, fc, cleanup := initSA(t)
+	defer cleanup()
+
+	failures, err := sa.GetValidationFailures(ctx, &sapb.AuthorizationID2{Id: 1})
+	test.AssertNotError(t, err, "GetValidationFailures failed")
+	test.AssertEquals(t, failures.Count, int64(0))
+
+	failures, err = sa.AddValidationFailure(ctx, &sapb.AddValidationFailureRequest{
+		Id:        1,
+		Challenge: string(core.ChallengeTypeHTTP01),
+		Problem:   &corepb.ProblemDetails{ProblemType: string(probs.ConnectionProblem), Detail: "connection refused", HttpStatus: 400},
+	})
+	test.AssertNotError(t, err, "AddValidationFailure failed")
+	test.AssertEquals(t, failures.Count, int64(1))
+
+	fc.Add(time.Minute)
+	failures, err = sa.AddValidationFailure(ctx, &sapb.AddValidationFailureRequest{
+		Id:        1,
+		Challenge: string(core.ChallengeTypeDNS01),
+		Problem:   &corepb.ProblemDetails{ProblemType: string(probs.DNSProblem), Detail: "NXDOMAIN", HttpStatus: 400},
+	})
+	test.AssertNotError(t, err, "AddValidationFailure failed")
+	test.AssertEquals(t, failures.Count, int64(2))
+	test.AssertEquals(t, failures.LastFailure.AsTime(), fc.Now().Truncate(time.Second))
+
+	failures, err = sa.GetValidationFailures(ctx, &sapb.AuthorizationID2{Id: 1})
+	test.AssertNotError(t, err, "GetValidationFailures failed")
+	test.AssertEquals(t, failures.Count, int64(2))
+	test.AssertEquals(t, failures.LastChallenge, string(core.ChallengeTypeDNS01))
+	test.AssertEquals(t, failures.LastProblem.Detail, "NXDOMAIN")
+
+	_, err = sa.AddValidationFailure(ctx, &sapb.AddValidationFailureRequest{Id: 1})
+	test.AssertError(t, err, "AddValidationFailure without a problem should fail")
+
+	failures, err = sa.GetValidationFailures(ctx, &sapb.AuthorizationID2{Id: 2})
+	test.AssertNotError(t, err, "GetValidationFailures failed")
+	test.AssertEquals(t, failures.Count, int64(0))
+}
+
+func TestAuthzCAARecheck(t *testing.T) {
+	if os.Getenv("BOULDER_CONFIG_DIR") != "test/config-next" {
+		t.Skip("skipping, the authzCAARechecks table must exist for this test to run")
+	}
+
+	sa, fc, cleanup := initSA(t)
+	defer cleanup()
+
+	recheck, err := sa.GetAuthzCAARecheck(ctx, &sapb.AuthorizationID2{Id: 1})
+	test.AssertNotError(t, err, "GetAuthzCAARecheck failed")
+	test.Assert(t, recheck.Rechecked == nil, "unexpected recheck time for an authorization never rechecked")
+
+	later := fc.Now().Add(time.Hour)
+	_, err = sa.SetAuthzCAARecheck(ctx, &sapb.SetAuthzCAARecheckRequest{Id: 1, Rechecked: timestamppb.New(later)})
+	test.AssertNotError(t, err, "SetAuthzCAARecheck failed")
+
+	// An earlier recheck, completing out of order, doesn't replace the later one.
+	_, err = sa.SetAuthzCAARecheck(ctx, &sapb.SetAuthzCAARecheckRequest{Id: 1, Rechecked: timestamppb.New(fc.Now())})
+	test.AssertNotError(t, err, "SetAuthzCAARecheck failed")
+
+	recheck, err = sa.GetAuthzCAARecheck(ctx, &sapb.AuthorizationID2{Id: 1})
+	test.AssertNotError(t, err, "GetAuthzCAARecheck failed")
+	test.AssertEquals(t, recheck.Rechecked.AsTime(), later.Truncate(time.Second))
+
+	_, err = sa.SetAuthzCAARecheck(ctx, &sapb.SetAuthzCAARecheckRequest{Id: 1})
+	test.AssertError(t, err, "SetAuthzCAARecheck without a time should fail")
+}
@@ -2,6 +2,7 @@ package sa
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"math"
@@ -20,6 +21,7 @@ import (
 	corepb "github.com/letsencrypt/boulder/core/proto"
 	"github.com/letsencrypt/boulder/db"
 	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/features"
 	"github.com/letsencrypt/boulder/identifier"
 	blog "github.com/letsencrypt/boulder/log"
 	sapb "github.com/letsencrypt/boulder/sa/proto"
@@ -29,6 +31,18 @@ var (
 	validIncidentTableRegexp = regexp.MustCompile(`^incident_[0-9a-zA-Z_]{1,100}$`)
 )
 
+// maxRevocationStatusBatchSize is the maximum number of serials which may be
+// requested in a single call to GetRevocationStatuses.
+const maxRevocationStatusBatchSize = 1000
+
+// maxContactVerificationBatchSize is the maximum number of contacts which may be
+// requested in a single call to GetContactVerifications.
+const maxContactVerificationBatchSize = 100
+
+// maxCertificateSearchResults is the maximum number of results which may be
+// returned by a single call to SearchCertificates.
+const maxCertificateSearchResults = 1000
+
 // SQLStorageAuthorityRO defines a read-only subset of a Storage Authority
 type SQLStorageAuthorityRO struct {
 	sapb.UnsafeStorageAuthorityReadOnlyServer
@@ -179,12 +193,7 @@ func (ssa *SQLStorageAuthorityRO) GetSerialMetadata(ctx context.Context, req *sa
 		return nil, err
 	}
 
-	return &sapb.SerialMetadata{
-		Serial:         recordedSerial.Serial,
-		RegistrationID: recordedSerial.RegistrationID,
-		Created:        timestamppb.New(recordedSerial.Created),
-		Expires:        timestamppb.New(recordedSerial.Expires),
-	}, nil
+	return newPBFromRecordedSerialModel(&recordedSerial), nil
 }
 
 // GetCertificate takes a serial number and returns the corresponding
@@ -274,6 +283,40 @@ func (ssa *SQLStorageAuthorityRO) GetRevocationStatus(ctx context.Context, req *
 	return status, nil
 }
 
+// GetRevocationStatuses is the batch form of GetRevocationStatus. It takes up
+// to maxRevocationStatusBatchSize hexadecimal serials and returns the revocation
+// status, reason, and revocation date of each, keyed by serial. Serials which
+// are not found are omitted from the response rather than causing an error.
+func (ssa *SQLStorageAuthorityRO) GetRevocationStatuses(ctx context.Context, req *sapb.Serials) (*sapb.RevocationStatuses, error) {
+	if req == nil || len(req.Serials) == 0 {
+		return nil, errIncompleteRequest
+	}
+	if len(req.Serials) > maxRevocationStatusBatchSize {
+		return nil, fmt.Errorf("too many serials: got %d, max %d", len(req.Serials), maxRevocationStatusBatchSize)
+	}
+
+	// Deduplicate, since callers may not have.
+	serials := make([]string, 0, len(req.Serials))
+	seen := make(map[string]bool, len(req.Serials))
+	for _, serial := range req.Serials {
+		if !core.ValidSerial(serial) {
+			return nil, fmt.Errorf("invalid certificate serial %s", serial)
+		}
+		if seen[serial] {
+			continue
+		}
+		seen[serial] = true
+		serials = append(serials, serial)
+	}
+
+	statuses, err := SelectRevocationStatuses(ctx, ssa.dbReadOnlyMap, serials)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sapb.RevocationStatuses{Statuses: statuses}, nil
+}
+
 // FQDNSetTimestampsForWindow returns the issuance timestamps for each
 // certificate, issued for a set of identifiers, during a given window of time,
 // starting from the most recent issuance.
@@ -624,6 +667,14 @@ func (ssa *SQLStorageAuthorityRO) CountPendingAuthorizations2(ctx context.Contex
 		return nil, errIncompleteRequest
 	}
 
+	if features.Get().UseAuthzCounts {
+		count, err := countPendingAuthzs(ctx, ssa.dbReadOnlyMap, req.Id)
+		if err != nil {
+			return nil, err
+		}
+		return &sapb.Count{Count: count}, nil
+	}
+
 	var count int64
 	err := ssa.dbReadOnlyMap.SelectOne(ctx, &count,
 		`SELECT COUNT(*) FROM authz2 WHERE
@@ -715,6 +766,14 @@ func (ssa *SQLStorageAuthorityRO) CountInvalidAuthorizations2(ctx context.Contex
 		return nil, fmt.Errorf("unsupported identifier type %q", ident.ToProto().Type)
 	}
 
+	if features.Get().UseAuthzCounts {
+		count, err := countInvalidAuthzs(ctx, ssa.dbReadOnlyMap, req.RegistrationID, idType, ident.Value, req.Range.Earliest.AsTime(), req.Range.Latest.AsTime())
+		if err != nil {
+			return nil, err
+		}
+		return &sapb.Count{Count: count}, nil
+	}
+
 	var count int64
 	err := ssa.dbReadOnlyMap.SelectOne(
 		ctx,
@@ -1169,6 +1228,182 @@ func (ssa *SQLStorageAuthorityRO) GetSerialsByAccount(req *sapb.RegistrationID,
 	})
 }
 
+// SearchCertificates returns a stream of metadata for the certificates
+// matching exactly one of the request's search terms: a subject alternative
+// name, the SHA-256 hash of a subject public key info, or a prefix of the
+// serial. Unlike GetSerialsByKey and GetSerialsByAccount, expired certificates
+// are included. Each search is answered from an index: issuedNames for names,
+// keyHashToSerial for key hashes, and the serials table for serial prefixes.
+// Names and key hashes return the newest certificates first; serial prefixes
+// return serials in ascending order.
+func (ssa *SQLStorageAuthorityRO) SearchCertificates(req *sapb.SearchCertificatesRequest, stream grpc.ServerStreamingServer[sapb.SerialMetadata]) error {
+	limit, err := certificateSearchLimit(req)
+	if err != nil {
+		return err
+	}
+
+	if req.SerialPrefix != "" {
+		selector, err := db.NewMappedSelector[recordedSerialModel](ssa.dbReadOnlyMap)
+		if err != nil {
+			return fmt.Errorf("initializing db map: %w", err)
+		}
+		// The prefix is known to be hex, so needs no escaping for LIKE.
+		rows, err := selector.QueryContext(stream.Context(), `
+			WHERE serial LIKE ?
+			ORDER BY serial
+			LIMIT ?`,
+			req.SerialPrefix+"%",
+			limit,
+		)
+		if err != nil {
+			return fmt.Errorf("reading db: %w", err)
+		}
+		return rows.ForEach(func(row *recordedSerialModel) error {
+			return stream.Send(newPBFromRecordedSerialModel(row))
+		})
+	}
+
+	var matches []struct {
+		Serial string `db:"serial"`
+	}
+	if req.San != "" {
+		_, err = ssa.dbReadOnlyMap.Select(stream.Context(), &matches, `
+			SELECT serial
+			FROM issuedNames
+			WHERE reversedName = ?
+			ORDER BY notBefore DESC
+			LIMIT ?`,
+			EncodeIssuedName(strings.ToLower(req.San)),
+			limit,
+		)
+	} else {
+		_, err = ssa.dbReadOnlyMap.Select(stream.Context(), &matches, `
+			SELECT certSerial AS serial
+			FROM keyHashToSerial
+			WHERE keyHash = ?
+			ORDER BY certNotAfter DESC
+			LIMIT ?`,
+			req.KeyHash,
+			limit,
+		)
+	}
+	if err != nil {
+		return fmt.Errorf("reading db: %w", err)
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+
+	var serials []string
+	var params []interface{}
+	seen := make(map[string]bool, len(matches))
+	for _, match := range matches {
+		if seen[match.Serial] {
+			continue
+		}
+		seen[match.Serial] = true
+		serials = append(serials, match.Serial)
+		params = append(params, match.Serial)
+	}
+
+	var models []recordedSerialModel
+	_, err = ssa.dbReadOnlyMap.Select(
+		stream.Context(),
+		&models,
+		fmt.Sprintf("SELECT * FROM serials WHERE serial IN (%s)", db.QuestionMarks(len(serials))),
+		params...,
+	)
+	if err != nil {
+		return fmt.Errorf("reading db: %w", err)
+	}
+	bySerial := make(map[string]*recordedSerialModel, len(models))
+	for i := range models {
+		bySerial[models[i].Serial] = &models[i]
+	}
+
+	// Send results in the order in which the index returned them.
+	for _, serial := range serials {
+		model, ok := bySerial[serial]
+		if !ok {
+			continue
+		}
+		err = stream.Send(newPBFromRecordedSerialModel(model))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// certificateSearchLimit checks that exactly one of req's search terms is set
+// and well-formed, and returns the maximum number of results to return.
+func certificateSearchLimit(req *sapb.SearchCertificatesRequest) (int64, error) {
+	terms := 0
+	for _, set := range []bool{req.San != "", len(req.KeyHash) != 0, req.SerialPrefix != ""} {
+		if set {
+			terms++
+		}
+	}
+	if terms == 0 {
+		return 0, errIncompleteRequest
+	}
+	if terms > 1 {
+		return 0, errors.New("exactly one of san, keyHash, and serialPrefix must be set")
+	}
+	if req.KeyHash != nil && len(req.KeyHash) != sha256.Size {
+		return 0, fmt.Errorf("invalid key hash length %d", len(req.KeyHash))
+	}
+	if req.SerialPrefix != "" && !core.ValidSerialPrefix(req.SerialPrefix) {
+		return 0, fmt.Errorf("invalid serial prefix %q", req.SerialPrefix)
+	}
+	if req.Limit < 0 || req.Limit > maxCertificateSearchResults {
+		return 0, fmt.Errorf("invalid limit %d: must be between 0 and %d", req.Limit, maxCertificateSearchResults)
+	}
+	if req.Limit == 0 {
+		return maxCertificateSearchResults, nil
+	}
+	return req.Limit, nil
+}
+
+// GetCertificatesByExpiry returns a stream of all final certificates which
+// expire in the given window. The start of the window is inclusive, and the
+// end exclusive. The window is found using the certificateStatus table's index
+// of notAfter, so that it's never necessary to page through the certificates
+// table.
+func (ssa *SQLStorageAuthorityRO) GetCertificatesByExpiry(req *sapb.GetCertificatesByExpiryRequest, stream grpc.ServerStreamingServer[corepb.Certificate]) error {
+	if core.IsAnyNilOrZero(req.ExpiresAfter, req.ExpiresBefore) {
+		return errIncompleteRequest
+	}
+	if !req.ExpiresAfter.AsTime().Before(req.ExpiresBefore.AsTime()) {
+		return berrors.MalformedError("expiresAfter %s is not before expiresBefore %s", req.ExpiresAfter.AsTime(), req.ExpiresBefore.AsTime())
+	}
+
+	clauses := `
+		WHERE serial IN (
+			SELECT serial FROM certificateStatus
+			WHERE notAfter >= ?
+			AND notAfter < ?
+		)`
+	params := []interface{}{
+		req.ExpiresAfter.AsTime(),
+		req.ExpiresBefore.AsTime(),
+	}
+
+	selector, err := db.NewMappedSelector[certificateModel](ssa.dbReadOnlyMap)
+	if err != nil {
+		return fmt.Errorf("initializing db map: %w", err)
+	}
+
+	rows, err := selector.QueryFrom(stream.Context(), "certificates", clauses, params...)
+	if err != nil {
+		return fmt.Errorf("reading db: %w", err)
+	}
+
+	return rows.ForEach(func(row *certificateModel) error {
+		return stream.Send(row.toPb())
+	})
+}
+
 // CheckIdentifiersPaused takes a slice of identifiers and returns a slice of
 // the first 15 identifier values which are currently paused for the provided
 // account. If no matches are found, an empty slice is returned.
@@ -1311,3 +1546,268 @@ func (ssa *SQLStorageAuthorityRO) GetEnabledRateLimitOverrides(_ *emptypb.Empty,
 		})
 	})
 }
+
+// GetContactVerifications returns the verification state of each of the
+// contacts with the given SHA-256 hashes. Contacts which have never been
+// verified are omitted from the response rather than causing an error.
+func (ssa *SQLStorageAuthorityRO) GetContactVerifications(ctx context.Context, req *sapb.ContactHashes) (*sapb.ContactVerifications, error) {
+	if req == nil || len(req.ContactHashes) == 0 {
+		return nil, errIncompleteRequest
+	}
+	if len(req.ContactHashes) > maxContactVerificationBatchSize {
+		return nil, fmt.Errorf("too many contacts: got %d, max %d", len(req.ContactHashes), maxContactVerificationBatchSize)
+	}
+
+	var params []interface{}
+	for _, hash := range req.ContactHashes {
+		if len(hash) != sha256.Size {
+			return nil, fmt.Errorf("invalid contact hash length %d", len(hash))
+		}
+		params = append(params, hash)
+	}
+
+	selector, err := db.NewMappedSelector[contactVerificationModel](ssa.dbReadOnlyMap)
+	if err != nil {
+		return nil, fmt.Errorf("initializing selector: %w", err)
+	}
+	rows, err := selector.QueryContext(ctx, fmt.Sprintf("WHERE contactHash IN (%s)", db.QuestionMarks(len(params))), params...)
+	if err != nil {
+		return nil, fmt.Errorf("querying contact verifications: %w", err)
+	}
+
+	var verifications []*sapb.ContactVerification
+	err = rows.ForEach(func(m *contactVerificationModel) error {
+		verifications = append(verifications, newPBFromContactVerificationModel(m))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sapb.ContactVerifications{Verifications: verifications}, nil
+}
+
+// GetRegistrationClientIdentity returns the TLS client certificate identity to
+// which the given registration was bound at creation. Registrations which are
+// not bound to an identity have an empty one, rather than causing an error.
+func (ssa *SQLStorageAuthorityRO) GetRegistrationClientIdentity(ctx context.Context, req *sapb.RegistrationID) (*sapb.ClientIdentity, error) {
+	if req == nil || req.Id == 0 {
+		return nil, errIncompleteRequest
+	}
+
+	var identity string
+	err := ssa.dbReadOnlyMap.SelectOne(ctx, &identity,
+		"SELECT identity FROM registrationClientIdentities WHERE registrationID = ?",
+		req.Id,
+	)
+	if err != nil && !db.IsNoRows(err) {
+		return nil, fmt.Errorf("querying client identity of registration %d: %w", req.Id, err)
+	}
+	return &sapb.ClientIdentity{Identity: identity}, nil
+}
+
+// GetAccountRevocation returns the progress of the revocation of a deactivated
+// account's certificates, or a NotFound error if the account never requested
+// it.
+func (ssa *SQLStorageAuthorityRO) GetAccountRevocation(ctx context.Context, req *sapb.RegistrationID) (*sapb.AccountRevocation, error) {
+	if req == nil || req.Id == 0 {
+		return nil, errIncompleteRequest
+	}
+
+	var model accountRevocationModel
+	err := ssa.dbReadOnlyMap.SelectOne(ctx, &model,
+		"SELECT registrationID, requested, total, revoked, failed, completed FROM accountRevocations WHERE registrationID = ?",
+		req.Id,
+	)
+	if err != nil {
+		if db.IsNoRows(err) {
+			return nil, berrors.NotFoundError("no certificate revocation for registration %d", req.Id)
+		}
+		return nil, fmt.Errorf("querying certificate revocation of registration %d: %w", req.Id, err)
+	}
+	return newPBFromAccountRevocationModel(&model), nil
+}
+
+// exemptionRequestFields are the columns of the exemptionRequests table, in
+// the order of exemptionRequestModel's fields.
+const exemptionRequestFields = "id, registrationID, limitEnum, bucketKey, periodNS, count, burst, justification, status, createdAt, reviewedAt, reviewer, reviewComment"
+
+// GetExemptionRequest returns the rate limit exemption request with the given
+// ID, or a NotFound error if there is none.
+func (ssa *SQLStorageAuthorityRO) GetExemptionRequest(ctx context.Context, req *sapb.ExemptionRequestID) (*sapb.ExemptionRequest, error) {
+	if req == nil || req.Id == 0 {
+		return nil, errIncompleteRequest
+	}
+
+	var model exemptionRequestModel
+	err := ssa.dbReadOnlyMap.SelectOne(ctx, &model,
+		"SELECT "+exemptionRequestFields+" FROM exemptionRequests WHERE id = ?",
+		req.Id,
+	)
+	if err != nil {
+		if db.IsNoRows(err) {
+			return nil, berrors.NotFoundError("no exemption request with ID %d", req.Id)
+		}
+		return nil, fmt.Errorf("querying exemption request %d: %w", req.Id, err)
+	}
+	return newPBFromExemptionRequestModel(&model), nil
+}
+
+// GetExemptionRequests returns up to the given number of rate limit exemption
+// requests with the given status, oldest first, optionally only those of the
+// given account.
+func (ssa *SQLStorageAuthorityRO) GetExemptionRequests(ctx context.Context, req *sapb.GetExemptionRequestsRequest) (*sapb.ExemptionRequests, error) {
+	if core.IsAnyNilOrZero(req, req.Status, req.Limit) {
+		return nil, errIncompleteRequest
+	}
+	status, err := exemptionRequestStatusUint(req.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + exemptionRequestFields + " FROM exemptionRequests WHERE status = ?"
+	args := []any{status}
+	if req.RegistrationID != 0 {
+		query += " AND registrationID = ?"
+		args = append(args, req.RegistrationID)
+	}
+	query += " ORDER BY createdAt, id LIMIT ?"
+	args = append(args, req.Limit)
+
+	var models []exemptionRequestModel
+	_, err = ssa.dbReadOnlyMap.Select(ctx, &models, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying %s exemption requests: %w", req.Status, err)
+	}
+	resp := &sapb.ExemptionRequests{}
+	for i := range models {
+		resp.Requests = append(resp.Requests, newPBFromExemptionRequestModel(&models[i]))
+	}
+	return resp, nil
+}
+
+// GetValidationFailures returns the number of failed validation attempts
+// recorded for the given authorization, when the last of them was made, and
+// the problem it failed with.
+func (ssa *SQLStorageAuthorityRO) GetValidationFailures(ctx context.Context, req *sapb.AuthorizationID2) (*sapb.ValidationFailures, error) {
+	if req == nil || req.Id == 0 {
+		return nil, errIncompleteRequest
+	}
+
+	var model validationFailuresModel
+	err := ssa.dbReadOnlyMap.SelectOne(ctx, &model,
+		"SELECT authzID, count, lastFailure, lastChallenge, lastProblem FROM validationFailures WHERE authzID = ?",
+		req.Id,
+	)
+	if err != nil {
+		if db.IsNoRows(err) {
+			return &sapb.ValidationFailures{}, nil
+		}
+		return nil, fmt.Errorf("querying validation failures of authorization %d: %w", req.Id, err)
+	}
+	return newPBFromValidationFailuresModel(&model)
+}
+
+// GetAuthzCAARecheck returns the time of the given authorization's most recent
+// successful background CAA recheck, if it has had one.
+func (ssa *SQLStorageAuthorityRO) GetAuthzCAARecheck(ctx context.Context, req *sapb.AuthorizationID2) (*sapb.AuthzCAARecheck, error) {
+	if req == nil || req.Id == 0 {
+		return nil, errIncompleteRequest
+	}
+
+	var model authzCAARecheckModel
+	err := ssa.dbReadOnlyMap.SelectOne(ctx, &model,
+		"SELECT authzID, rechecked FROM authzCAARechecks WHERE authzID = ?",
+		req.Id,
+	)
+	if err != nil {
+		if db.IsNoRows(err) {
+			return &sapb.AuthzCAARecheck{}, nil
+		}
+		return nil, fmt.Errorf("querying CAA recheck of authorization %d: %w", req.Id, err)
+	}
+	return &sapb.AuthzCAARecheck{Rechecked: timestamppb.New(model.Rechecked)}, nil
+}
+
+// GetCRLShard returns the thisUpdate and nextUpdate of the given CRL shard's
+// current CRL, and the digest of its entries, as last reported to
+// UpdateCRLShard.
+func (ssa *SQLStorageAuthorityRO) GetCRLShard(ctx context.Context, req *sapb.GetCRLShardRequest) (*sapb.CRLShard, error) {
+	if core.IsAnyNilOrZero(req, req.IssuerNameID) {
+		return nil, errIncompleteRequest
+	}
+
+	var model struct {
+		ThisUpdate    *time.Time `db:"thisUpdate"`
+		NextUpdate    *time.Time `db:"nextUpdate"`
+		EntriesDigest []byte     `db:"entriesDigest"`
+	}
+	err := ssa.dbReadOnlyMap.SelectOne(ctx, &model,
+		`SELECT thisUpdate, nextUpdate, entriesDigest
+			FROM crlShards
+			WHERE issuerID = ?
+			AND idx = ?`,
+		req.IssuerNameID,
+		req.ShardIdx,
+	)
+	if err != nil {
+		if db.IsNoRows(err) {
+			return nil, berrors.NotFoundError("no CRL shard %d for issuer %d", req.ShardIdx, req.IssuerNameID)
+		}
+		return nil, fmt.Errorf("querying CRL shard %d for issuer %d: %w", req.ShardIdx, req.IssuerNameID, err)
+	}
+
+	shard := &sapb.CRLShard{EntriesDigest: model.EntriesDigest}
+	if model.ThisUpdate != nil {
+		shard.ThisUpdate = timestamppb.New(*model.ThisUpdate)
+	}
+	if model.NextUpdate != nil {
+		shard.NextUpdate = timestamppb.New(*model.NextUpdate)
+	}
+	return shard, nil
+}
+
+// GetMPICComplianceStats summarizes the outcomes of the network perspectives
+// recorded for validation attempts made in the given window, per perspective,
+// for Multi-Perspective Issuance Corroboration compliance reporting.
+func (ssa *SQLStorageAuthorityRO) GetMPICComplianceStats(ctx context.Context, req *sapb.GetMPICComplianceStatsRequest) (*sapb.MPICComplianceStats, error) {
+	if core.IsAnyNilOrZero(req.AttemptedAfter, req.AttemptedBefore) {
+		return nil, errIncompleteRequest
+	}
+	if !req.AttemptedAfter.AsTime().Before(req.AttemptedBefore.AsTime()) {
+		return nil, berrors.MalformedError("attemptedAfter %s is not before attemptedBefore %s", req.AttemptedAfter.AsTime(), req.AttemptedBefore.AsTime())
+	}
+	after, before := req.AttemptedAfter.AsTime(), req.AttemptedBefore.AsTime()
+
+	var attempts int64
+	err := ssa.dbReadOnlyMap.SelectOne(ctx, &attempts,
+		`SELECT COUNT(DISTINCT authzID, attemptedAt) FROM validationPerspectives
+		WHERE attemptedAt >= ? AND attemptedAt < ?`,
+		after, before,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("counting validation attempts: %w", err)
+	}
+
+	var rows []perspectiveStatsModel
+	_, err = ssa.dbReadOnlyMap.Select(ctx, &rows,
+		`SELECT perspective, rir,
+			COUNT(*) AS attempts,
+			CAST(SUM(passed) AS SIGNED) AS passed,
+			AVG(latencyMS) AS meanLatencyMS,
+			MAX(latencyMS) AS maxLatencyMS
+		FROM validationPerspectives
+		WHERE attemptedAt >= ? AND attemptedAt < ?
+		GROUP BY perspective, rir
+		ORDER BY perspective, rir`,
+		after, before,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("summarizing validation perspectives: %w", err)
+	}
+
+	stats := &sapb.MPICComplianceStats{Attempts: attempts}
+	for _, row := range rows {
+		stats.Perspectives = append(stats.Perspectives, newPBFromPerspectiveStatsModel(&row))
+	}
+	return stats, nil
+}
@@ -0,0 +1,93 @@
+package sa
+
+import (
+	"context"
+	"time"
+
+	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/db"
+)
+
+const (
+	// maxTxAttempts is the number of times a transaction which is safe to
+	// retry is attempted before its deadlock or lock wait timeout is returned
+	// to the caller.
+	maxTxAttempts = 3
+
+	// txRetryBaseDelay and txRetryMaxDelay bound the jittered backoff between
+	// attempts. They're short, because the transaction we deadlocked with has
+	// usually committed by the time MySQL reports the deadlock.
+	txRetryBaseDelay = 10 * time.Millisecond
+	txRetryMaxDelay  = 100 * time.Millisecond
+)
+
+// txRetrySafe annotates each method which writes in a transaction with whether
+// the transaction may be retried after a deadlock or lock wait timeout. Either
+// error rolls the whole transaction back, so a retry is safe unless the
+// transaction's function has effects outside the transaction which a second
+// run would repeat or be confused by. Methods which aren't listed aren't
+// retried.
+var txRetrySafe = map[string]bool{
+	// Inserts the captured registration model, which sets its ID.
+	"NewRegistration":       false,
+	"UpdateRegistrationKey": true,
+	// Inserts the certificateStatus row outside the transaction.
+	"AddPrecertificate":        false,
+	"AddCertificate":           true,
+	"AddCertificateFQDNSet":    true,
+	"DeactivateRegistration":   true,
+	"DeactivateAuthorization2": true,
+	"NewOrderAndAuthzs":        true,
+	"SetOrderProcessing":       true,
+	"SetOrderError":            true,
+	"FinalizeOrder":            true,
+	"FinalizeAuthorization2":   true,
+	"RevokeCertificate":        true,
+	"UpdateRevokedCertificate": true,
+	"LeaseCRLShard":            true,
+	"UpdateCRLShard":           true,
+	// Accumulates its counts of paused and repaused identifiers in the
+	// response as it goes.
+	"PauseIdentifiers":       false,
+	"AddRateLimitOverride":   true,
+	"setRateLimitOverride":   true,
+	"SetAccountRevocation":   true,
+	"LeaseAccountRevocation": true,
+	"AddValidationFailure":   true,
+	"ReviewExemptionRequest": true,
+}
+
+// withTransaction runs f in a transaction on behalf of the named method, as
+// db.WithTransaction does. If the transaction fails with a deadlock or lock
+// wait timeout, and txRetrySafe says that the method's transaction may be
+// retried, it's retried after a jittered backoff, up to maxTxAttempts times in
+// all.
+func (ssa *SQLStorageAuthority) withTransaction(ctx context.Context, method string, f func(tx db.Executor) (any, error)) (any, error) {
+	return ssa.retryOnDeadlock(ctx, method, func() (any, error) {
+		return db.WithTransaction(ctx, ssa.dbMap, f)
+	})
+}
+
+// retryOnDeadlock implements the retries of withTransaction, with txn running
+// a single attempt of the transaction.
+func (ssa *SQLStorageAuthority) retryOnDeadlock(ctx context.Context, method string, txn func() (any, error)) (any, error) {
+	for attempt := 1; ; attempt++ {
+		result, err := txn()
+		if err == nil || !db.IsDeadlock(err) {
+			return result, err
+		}
+		if !txRetrySafe[method] {
+			ssa.txLockConflicts.WithLabelValues(method, "unsafe").Inc()
+			return nil, err
+		}
+		if attempt >= maxTxAttempts {
+			ssa.txLockConflicts.WithLabelValues(method, "exhausted").Inc()
+			return nil, err
+		}
+		ssa.txLockConflicts.WithLabelValues(method, "retried").Inc()
+		ssa.clk.Sleep(core.RetryBackoff(attempt, txRetryBaseDelay, txRetryMaxDelay, 2))
+		if ctx.Err() != nil {
+			return nil, err
+		}
+	}
+}
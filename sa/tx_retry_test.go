@@ -0,0 +1,85 @@
+package sa
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// deadlockingTxn returns a transaction attempt function which fails with a
+// deadlock the given number of times before succeeding, and a pointer to the
+// number of attempts made.
+func deadlockingTxn(deadlocks int) (func() (any, error), *int) {
+	attempts := 0
+	return func() (any, error) {
+		attempts++
+		if attempts <= deadlocks {
+			return nil, &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}
+		}
+		return "committed", nil
+	}, &attempts
+}
+
+func TestRetryOnDeadlock(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	fc := clock.NewFake()
+	saro, err := NewSQLStorageAuthorityRO(nil, nil, metrics.NoopRegisterer, 1, 0, fc, blog.NewMock())
+	test.AssertNotError(t, err, "creating SA")
+	ssa, err := NewSQLStorageAuthorityWrapping(saro, nil, metrics.NoopRegisterer)
+	test.AssertNotError(t, err, "creating SA")
+
+	// A safe transaction which deadlocks once is retried and succeeds.
+	txn, attempts := deadlockingTxn(1)
+	start := fc.Now()
+	result, err := ssa.retryOnDeadlock(ctx, "FinalizeOrder", txn)
+	test.AssertNotError(t, err, "retried transaction failed")
+	test.AssertEquals(t, result, "committed")
+	test.AssertEquals(t, *attempts, 2)
+	test.Assert(t, fc.Since(start) > 0, "retry should have backed off")
+	test.Assert(t, fc.Since(start) <= time.Duration(float64(txRetryMaxDelay)*1.5), "backoff exceeded its bound")
+	test.AssertMetricWithLabelsEquals(t, ssa.txLockConflicts, prometheus.Labels{"method": "FinalizeOrder", "action": "retried"}, 1)
+
+	// A safe transaction which keeps deadlocking gives up after maxTxAttempts.
+	txn, attempts = deadlockingTxn(maxTxAttempts)
+	_, err = ssa.retryOnDeadlock(ctx, "NewOrderAndAuthzs", txn)
+	test.AssertError(t, err, "transaction which always deadlocks succeeded")
+	test.AssertEquals(t, *attempts, maxTxAttempts)
+	test.AssertMetricWithLabelsEquals(t, ssa.txLockConflicts, prometheus.Labels{"method": "NewOrderAndAuthzs", "action": "exhausted"}, 1)
+
+	// Unsafe and unannotated transactions aren't retried.
+	for _, method := range []string{"PauseIdentifiers", "NotAnRPC"} {
+		txn, attempts = deadlockingTxn(1)
+		_, err = ssa.retryOnDeadlock(ctx, method, txn)
+		test.AssertError(t, err, "unsafe transaction was retried")
+		test.AssertEquals(t, *attempts, 1)
+		test.AssertMetricWithLabelsEquals(t, ssa.txLockConflicts, prometheus.Labels{"method": method, "action": "unsafe"}, 1)
+	}
+
+	// Errors other than deadlocks aren't retried.
+	attempts = new(int)
+	_, err = ssa.retryOnDeadlock(ctx, "FinalizeOrder", func() (any, error) {
+		*attempts++
+		return nil, &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}
+	})
+	test.AssertError(t, err, "duplicate entry was swallowed")
+	test.AssertEquals(t, *attempts, 1)
+
+	// Nor are transactions whose context is done.
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	txn, attempts = deadlockingTxn(1)
+	_, err = ssa.retryOnDeadlock(cancelCtx, "FinalizeOrder", txn)
+	var mysqlErr *mysql.MySQLError
+	test.Assert(t, errors.As(err, &mysqlErr), "expected the deadlock to be returned")
+	test.AssertEquals(t, *attempts, 1)
+}
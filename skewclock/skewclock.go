@@ -0,0 +1,170 @@
+// Package skewclock provides a clock for services which put the time into
+// things they sign. It never goes backwards, and it notices when the host's
+// wall clock jumps, as it does when NTP steps it, so that those services can
+// refuse to sign until the clock has settled.
+package skewclock
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/config"
+	blog "github.com/letsencrypt/boulder/log"
+)
+
+const (
+	defaultTolerance = time.Second
+	defaultHoldDown  = time.Minute
+
+	// maxSlewRate is the fastest rate at which NTP slews a clock, rather than
+	// stepping it. Gradual corrections at or below this rate aren't jumps.
+	maxSlewRate = 500e-6
+)
+
+// ErrSkew is returned by Check while a Clock is recovering from a jump of the
+// host's wall clock.
+var ErrSkew = errors.New("host clock jumped recently")
+
+// Config configures the detection of host clock jumps.
+type Config struct {
+	// Tolerance is the largest change in the difference between the wall
+	// clock and the monotonic clock, beyond that expected of NTP slewing,
+	// which is not treated as a jump. If unset, it defaults to one second.
+	Tolerance config.Duration `validate:"-"`
+	// HoldDown is how long after a jump Check reports the clock as untrusted.
+	// If unset, it defaults to one minute.
+	HoldDown config.Duration `validate:"-"`
+}
+
+// Clock is a clock.Clock whose Now never returns a time before one it has
+// already returned, and which compares each wall clock reading to the
+// process's monotonic clock to detect jumps. After a backwards jump, Now
+// returns the latest time it has returned until the wall clock catches up.
+// Clocks without monotonic readings, such as fake clocks, never jump.
+type Clock struct {
+	clock.Clock
+	tolerance time.Duration
+	holdDown  time.Duration
+	log       blog.Logger
+	jumps     *prometheus.CounterVec
+
+	// start is the wall clock reading from which readings are measured.
+	start time.Time
+	// readings returns the current wall clock reading, without its monotonic
+	// component, and the monotonic time elapsed since start.
+	readings func() (time.Time, time.Duration)
+
+	mu        sync.Mutex
+	latest    time.Time
+	offset    time.Duration
+	lastMono  time.Duration
+	skewUntil time.Duration
+	skewed    bool
+}
+
+// New returns a Clock which reads the time from clk.
+func New(clk clock.Clock, c Config, stats prometheus.Registerer, logger blog.Logger) *Clock {
+	start := clk.Now()
+	return newClock(clk, c, stats, logger, start.Round(0), func() (time.Time, time.Duration) {
+		now := clk.Now()
+		return now.Round(0), now.Sub(start)
+	})
+}
+
+func newClock(clk clock.Clock, c Config, stats prometheus.Registerer, logger blog.Logger, start time.Time, readings func() (time.Time, time.Duration)) *Clock {
+	tolerance := c.Tolerance.Duration
+	if tolerance <= 0 {
+		tolerance = defaultTolerance
+	}
+	holdDown := c.HoldDown.Duration
+	if holdDown <= 0 {
+		holdDown = defaultHoldDown
+	}
+
+	jumps := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "clock_jumps",
+		Help: "A counter of detected jumps of the host's wall clock, labelled by direction",
+	}, []string{"direction"})
+	stats.MustRegister(jumps)
+
+	return &Clock{
+		Clock:     clk,
+		tolerance: tolerance,
+		holdDown:  holdDown,
+		log:       logger,
+		jumps:     jumps,
+		start:     start,
+		readings:  readings,
+	}
+}
+
+// observe takes a reading and notes whether the wall clock has jumped since
+// the last one. The caller must hold the lock.
+func (c *Clock) observe() (time.Time, time.Duration) {
+	wall, mono := c.readings()
+	offset := wall.Sub(c.start) - mono
+	jump := offset - c.offset
+	allowed := c.tolerance + time.Duration(float64(mono-c.lastMono)*maxSlewRate)
+	if jump > allowed || jump < -allowed {
+		direction := "forward"
+		if jump < 0 {
+			direction = "backward"
+		}
+		c.jumps.WithLabelValues(direction).Inc()
+		c.skewed = true
+		c.skewUntil = mono + c.holdDown
+		c.log.AuditErrf("Host clock jumped %s by %s; refusing to sign time-sensitive artifacts for %s", direction, jump.Abs(), c.holdDown)
+	}
+	c.offset = offset
+	c.lastMono = mono
+	return wall, mono
+}
+
+// Now returns the current time, or the latest time it has returned if the wall
+// clock has since gone backwards.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	wall, _ := c.observe()
+	if wall.After(c.latest) {
+		c.latest = wall
+	}
+	return c.latest
+}
+
+// Since returns the time elapsed since t, according to Now.
+func (c *Clock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// Check returns an error wrapping ErrSkew if the wall clock has jumped within
+// the hold-down period, or if Now is holding at a time ahead of the wall clock
+// because the wall clock went backwards, and nil otherwise.
+func (c *Clock) Check() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	wall, mono := c.observe()
+	if c.skewed && mono < c.skewUntil {
+		return fmt.Errorf("%w: trusted again in %s", ErrSkew, (c.skewUntil - mono).Round(time.Second))
+	}
+	c.skewed = false
+	if c.latest.After(wall) {
+		return fmt.Errorf("%w: holding at %s until the wall clock catches up in %s", ErrSkew, c.latest.Format(time.RFC3339), c.latest.Sub(wall).Round(time.Second))
+	}
+	return nil
+}
+
+// Check returns the result of clk's Check method, if it has one, and nil
+// otherwise, so that services can be given any clock.Clock.
+func Check(clk clock.Clock) error {
+	checker, ok := clk.(interface{ Check() error })
+	if !ok {
+		return nil
+	}
+	return checker.Check()
+}
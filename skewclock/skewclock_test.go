@@ -0,0 +1,139 @@
+package skewclock
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/config"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// newTestClock returns a Clock whose wall and monotonic readings are
+// controlled separately, by advancing wall and mono.
+func newTestClock(t *testing.T) (*Clock, clock.FakeClock, *time.Duration) {
+	t.Helper()
+	wall := clock.NewFake()
+	start := wall.Now()
+	var mono time.Duration
+	c := newClock(wall, Config{
+		Tolerance: config.Duration{Duration: time.Second},
+		HoldDown:  config.Duration{Duration: time.Minute},
+	}, metrics.NoopRegisterer, blog.NewMock(), start, func() (time.Time, time.Duration) {
+		return wall.Now(), mono
+	})
+	return c, wall, &mono
+}
+
+func TestNoJump(t *testing.T) {
+	t.Parallel()
+	c, wall, mono := newTestClock(t)
+
+	for range 10 {
+		wall.Add(time.Hour)
+		*mono += time.Hour
+		test.AssertEquals(t, c.Now(), wall.Now())
+		test.AssertNotError(t, c.Check(), "clock without jumps should be trusted")
+	}
+
+	// Slewing of a few hundred milliseconds over an hour isn't a jump.
+	wall.Add(time.Hour + 900*time.Millisecond)
+	*mono += time.Hour
+	test.AssertNotError(t, c.Check(), "slewed clock should be trusted")
+	test.AssertMetricWithLabelsEquals(t, c.jumps, prometheus.Labels{}, 0)
+}
+
+func TestForwardJump(t *testing.T) {
+	t.Parallel()
+	c, wall, mono := newTestClock(t)
+	test.AssertNotError(t, c.Check(), "new clock should be trusted")
+
+	wall.Add(time.Hour)
+	*mono += time.Second
+	err := c.Check()
+	test.AssertErrorIs(t, err, ErrSkew)
+	test.AssertEquals(t, c.Now(), wall.Now())
+	test.AssertMetricWithLabelsEquals(t, c.jumps, prometheus.Labels{"direction": "forward"}, 1)
+
+	// The clock is trusted again once the hold-down period has passed without
+	// another jump.
+	wall.Add(30 * time.Second)
+	*mono += 30 * time.Second
+	test.AssertErrorIs(t, c.Check(), ErrSkew)
+	wall.Add(30 * time.Second)
+	*mono += 30 * time.Second
+	test.AssertNotError(t, c.Check(), "clock should be trusted after hold-down")
+	test.AssertMetricWithLabelsEquals(t, c.jumps, prometheus.Labels{"direction": "forward"}, 1)
+}
+
+func TestBackwardJump(t *testing.T) {
+	t.Parallel()
+	c, wall, mono := newTestClock(t)
+
+	latest := c.Now()
+	wall.Add(-time.Hour)
+	*mono += time.Second
+	test.AssertErrorIs(t, c.Check(), ErrSkew)
+	test.AssertMetricWithLabelsEquals(t, c.jumps, prometheus.Labels{"direction": "backward"}, 1)
+
+	// Now holds at the latest time it returned until the wall clock catches
+	// up with it.
+	test.AssertEquals(t, c.Now(), latest)
+	test.AssertEquals(t, c.Since(latest), time.Duration(0))
+	wall.Add(2 * time.Hour)
+	*mono += 2 * time.Hour
+	test.AssertEquals(t, c.Now(), latest.Add(time.Hour))
+}
+
+func TestForwardJumpCorrected(t *testing.T) {
+	t.Parallel()
+	c, wall, mono := newTestClock(t)
+
+	// The wall clock jumps an hour forward, and Now returns the bad time.
+	wall.Add(time.Hour)
+	*mono += time.Second
+	bad := c.Now()
+	test.AssertErrorIs(t, c.Check(), ErrSkew)
+
+	// NTP steps it back, and then the hold-down period passes. Now is still
+	// holding at the bad time, so the clock is still untrusted.
+	wall.Add(-time.Hour)
+	*mono += time.Second
+	test.AssertErrorIs(t, c.Check(), ErrSkew)
+	wall.Add(2 * time.Minute)
+	*mono += 2 * time.Minute
+	test.AssertEquals(t, c.Now(), bad)
+	err := c.Check()
+	test.AssertErrorIs(t, err, ErrSkew)
+	test.AssertContains(t, err.Error(), "catches up")
+
+	// Once the wall clock catches up with the bad time, it is trusted again.
+	wall.Add(time.Hour)
+	*mono += time.Hour
+	test.AssertNotError(t, c.Check(), "clock should be trusted once it catches up")
+	test.AssertEquals(t, c.Now(), wall.Now())
+}
+
+func TestRealClock(t *testing.T) {
+	t.Parallel()
+	c := New(clock.New(), Config{}, metrics.NoopRegisterer, blog.NewMock())
+	first := c.Now()
+	time.Sleep(10 * time.Millisecond)
+	test.Assert(t, c.Now().After(first), "real clock should advance")
+	test.AssertNotError(t, c.Check(), "real clock should be trusted")
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+	test.AssertNotError(t, Check(clock.NewFake()), "plain clocks should always be trusted")
+
+	c, wall, _ := newTestClock(t)
+	wall.Add(time.Hour)
+	err := Check(c)
+	test.Assert(t, errors.Is(err, ErrSkew), "expected Check to use the Clock's Check")
+}
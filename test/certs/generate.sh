@@ -42,7 +42,8 @@ ipki() (
   # Used by Boulder gRPC services as both server and client mTLS certificates.
   for SERVICE in admin ocsp-responder consul \
     wfe akamai-purger bad-key-revoker crl-updater crl-storer \
-    health-checker rocsp-tool sfe email-exporter; do
+    health-checker rocsp-tool sfe email-exporter caa-checker contact-verifier \
+    issuance-reconciler exemption-queue api-gateway; do
     minica -domains "${SERVICE}.boulder" &
   done
 
@@ -33,6 +33,14 @@ services {
   tags    = ["tcp"] // Required for SRV RR support in gRPC DNS resolution.
 }
 
+services {
+  id      = "contact-verifier-a"
+  name    = "contact-verifier"
+  address = "10.77.77.77"
+  port    = 9604
+  tags    = ["tcp"] // Required for SRV RR support in gRPC DNS resolution.
+}
+
 services {
   id      = "email-exporter-a"
   name    = "email-exporter"
@@ -41,6 +49,14 @@ services {
   tags    = ["tcp"] // Required for SRV RR support in gRPC DNS resolution.
 }
 
+services {
+  id      = "exemption-queue-a"
+  name    = "exemption-queue"
+  address = "10.77.77.77"
+  port    = 9605
+  tags    = ["tcp"] // Required for SRV RR support in gRPC DNS resolution.
+}
+
 services {
   id      = "boulder-a"
   name    = "boulder"
@@ -225,6 +241,14 @@ services {
   tags    = ["tcp"] // Required for SRV RR support in gRPC DNS resolution.
 }
 
+services {
+  id      = "caa-checker"
+  name    = "caa-checker"
+  address = "10.77.77.77"
+  port    = 9396
+  tags    = ["tcp"] // Required for SRV RR support in gRPC DNS resolution.
+}
+
 services {
   id      = "sa-a"
   name    = "sa"
@@ -0,0 +1,2566 @@
+package sa
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/jmhodges/clock"
+	"golang.org/x/crypto/ocsp"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/letsencrypt/boulder/core"
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	berrors "github.com/letsencrypt/boulder/errors"
+	bgrpc "github.com/letsencrypt/boulder/grpc"
+	"github.com/letsencrypt/boulder/identifier"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+	"github.com/letsencrypt/boulder/unpause"
+)
+
+var (
+	errIncompleteRequest = errors.New("incomplete gRPC request message")
+
+	validIncidentTableRegexp = regexp.MustCompile(`^incident_[0-9a-zA-Z_]{1,100}$`)
+)
+
+const (
+	maxRevocationStatusBatchSize    = 1000
+	maxContactVerificationBatchSize = 100
+	maxCertificateSearchResults     = 1000
+	maxPausedIdentifiers            = 15
+)
+
+// challTypes are the challenge types an authorization can offer, in the order
+// in which the SQL SA returns them.
+var challTypes = []string{
+	string(core.ChallengeTypeHTTP01),
+	string(core.ChallengeTypeDNS01),
+	string(core.ChallengeTypeTLSALPN01),
+	string(core.ChallengeTypeEmailReply00),
+}
+
+// identifierTypes are the identifier types the SQL SA can store.
+var identifierTypes = []string{"dns", "ip", "email"}
+
+// blockedKeySources are the sources from which a blocked key can be added.
+var blockedKeySources = []string{"API", "admin-revoker"}
+
+// contactVerificationStatuses are the statuses a contact verification may
+// have.
+var contactVerificationStatuses = []string{
+	string(core.StatusPending),
+	string(core.StatusValid),
+	string(core.StatusInvalid),
+}
+
+// exemptionRequestStatuses are the statuses a rate limit exemption request may
+// have.
+var exemptionRequestStatuses = []string{"pending", "approved", "rejected"}
+
+// MemoryStorageAuthority is an in-memory implementation of the
+// `sapb.StorageAuthorityServer` interface, for unit tests which would
+// otherwise need a database. It follows `sa.SQLStorageAuthority`, including
+// the errors it returns, but doesn't reproduce anything which depends on the
+// database itself, such as replication lag or the precision of timestamps.
+// Wrap it in an SA to use it as a `sapb.StorageAuthorityClient`.
+type MemoryStorageAuthority struct {
+	sapb.UnsafeStorageAuthorityServer
+
+	clk clock.Clock
+
+	mu                     sync.Mutex
+	lastRegID              int64
+	lastAuthzID            int64
+	lastOrderID            int64
+	lastExemptionRequestID int64
+
+	registrations        map[int64]*memRegistration
+	authzs               map[int64]*memAuthz
+	orders               map[int64]*memOrder
+	orderFQDNSets        []*memOrderFQDNSet
+	fqdnSets             []*memFQDNSet
+	serials              map[string]*memSerial
+	precertificates      map[string]*corepb.Certificate
+	certificates         map[string]*corepb.Certificate
+	certificateStatuses  map[string]*corepb.CertificateStatus
+	keyHashes            []*memKeyHash
+	blockedKeys          map[string]bool
+	revokedCerts         map[string]*memRevokedCert
+	crlShards            map[memCRLShardKey]*memCRLShard
+	replacementOrders    map[string]*memReplacementOrder
+	paused               []*memPaused
+	overrides            map[memOverrideKey]*memOverride
+	contactVerifications map[string]*sapb.ContactVerification
+	accountRevocations   map[int64]*sapb.AccountRevocation
+	// accountRevocationLeases holds the leasedUntil of each account revocation
+	// which has been leased.
+	accountRevocationLeases map[int64]time.Time
+	exemptionRequests       map[int64]*sapb.ExemptionRequest
+	validationFailures      map[int64]*sapb.ValidationFailures
+	authzCAARechecks        map[int64]time.Time
+	perspectives            []*memValidationPerspective
+	incidents               []*memIncident
+}
+
+var _ sapb.StorageAuthorityServer = (*MemoryStorageAuthority)(nil)
+
+// NewMemoryStorageAuthority returns an empty MemoryStorageAuthority which
+// uses clk wherever the SQL SA would use its own clock.
+func NewMemoryStorageAuthority(clk clock.Clock) *MemoryStorageAuthority {
+	return &MemoryStorageAuthority{
+		clk:                     clk,
+		registrations:           make(map[int64]*memRegistration),
+		authzs:                  make(map[int64]*memAuthz),
+		orders:                  make(map[int64]*memOrder),
+		serials:                 make(map[string]*memSerial),
+		precertificates:         make(map[string]*corepb.Certificate),
+		certificates:            make(map[string]*corepb.Certificate),
+		certificateStatuses:     make(map[string]*corepb.CertificateStatus),
+		blockedKeys:             make(map[string]bool),
+		revokedCerts:            make(map[string]*memRevokedCert),
+		crlShards:               make(map[memCRLShardKey]*memCRLShard),
+		replacementOrders:       make(map[string]*memReplacementOrder),
+		overrides:               make(map[memOverrideKey]*memOverride),
+		contactVerifications:    make(map[string]*sapb.ContactVerification),
+		accountRevocations:      make(map[int64]*sapb.AccountRevocation),
+		accountRevocationLeases: make(map[int64]time.Time),
+		exemptionRequests:       make(map[int64]*sapb.ExemptionRequest),
+		validationFailures:      make(map[int64]*sapb.ValidationFailures),
+		authzCAARechecks:        make(map[int64]time.Time),
+	}
+}
+
+// AddIncident adds an incident, and the serials it affects, as the incident
+// tooling would. The incident's SerialTable names the table which holds the
+// serials.
+func (m *MemoryStorageAuthority) AddIncident(incident *sapb.Incident, serials []*sapb.IncidentSerial) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	inc := &memIncident{incident: proto.Clone(incident).(*sapb.Incident)}
+	for _, serial := range serials {
+		inc.serials = append(inc.serials, proto.Clone(serial).(*sapb.IncidentSerial))
+	}
+	m.incidents = append(m.incidents, inc)
+}
+
+type memRegistration struct {
+	reg            *corepb.Registration
+	keyDigest      string
+	clientIdentity string
+}
+
+type memAuthz struct {
+	id                int64
+	ident             identifier.ACMEIdentifier
+	regID             int64
+	profile           string
+	status            core.AcmeStatus
+	expires           time.Time
+	challTypes        []string
+	token             string
+	attempted         string
+	attemptedAt       time.Time
+	validationError   *corepb.ProblemDetails
+	validationRecords []*corepb.ValidationRecord
+}
+
+// toPB returns the authorization as the SQL SA would: with one pending
+// challenge of each type until a challenge is attempted, and then with only
+// the attempted challenge.
+func (a *memAuthz) toPB() *corepb.Authorization {
+	pb := &corepb.Authorization{
+		Id:                     strconv.FormatInt(a.id, 10),
+		Status:                 string(a.status),
+		Identifier:             a.ident.ToProto(),
+		RegistrationID:         a.regID,
+		Expires:                timestamppb.New(a.expires),
+		CertificateProfileName: a.profile,
+	}
+	for _, challType := range a.challTypes {
+		chall := &corepb.Challenge{
+			Type:   challType,
+			Status: string(core.StatusPending),
+			Token:  a.token,
+		}
+		if a.attempted != "" {
+			if a.attempted != challType {
+				continue
+			}
+			chall.Status = string(core.StatusValid)
+			if a.validationError != nil {
+				chall.Status = string(core.StatusInvalid)
+				chall.Error = proto.Clone(a.validationError).(*corepb.ProblemDetails)
+			}
+			for _, record := range a.validationRecords {
+				chall.Validationrecords = append(chall.Validationrecords, proto.Clone(record).(*corepb.ValidationRecord))
+			}
+			if !a.attemptedAt.IsZero() {
+				chall.Validated = timestamppb.New(a.attemptedAt)
+			}
+		}
+		pb.Challenges = append(pb.Challenges, chall)
+	}
+	return pb
+}
+
+type memOrder struct {
+	id              int64
+	regID           int64
+	expires         time.Time
+	created         time.Time
+	err             *corepb.ProblemDetails
+	certSerial      string
+	beganProcessing bool
+	profile         string
+	replaces        string
+	csrHash         []byte
+	authzIDs        []int64
+}
+
+type memOrderFQDNSet struct {
+	setHash []byte
+	orderID int64
+	regID   int64
+	expires time.Time
+}
+
+type memFQDNSet struct {
+	setHash []byte
+	serial  string
+	issued  time.Time
+	expires time.Time
+}
+
+type memSerial struct {
+	regID   int64
+	created time.Time
+	expires time.Time
+}
+
+type memKeyHash struct {
+	keyHash  []byte
+	notAfter time.Time
+	serial   string
+}
+
+type memRevokedCert struct {
+	issuerID     int64
+	notAfterHour time.Time
+	shardIdx     int64
+	revokedDate  time.Time
+	reason       int64
+}
+
+type memCRLShardKey struct {
+	issuerID int64
+	idx      int64
+}
+
+type memCRLShard struct {
+	thisUpdate    *time.Time
+	nextUpdate    *time.Time
+	leasedUntil   time.Time
+	entriesDigest []byte
+}
+
+type memReplacementOrder struct {
+	orderID      int64
+	orderExpires time.Time
+	replaced     bool
+}
+
+type memPaused struct {
+	regID      int64
+	ident      identifier.ACMEIdentifier
+	pausedAt   time.Time
+	unpausedAt *time.Time
+}
+
+type memOverrideKey struct {
+	limitEnum int64
+	bucketKey string
+}
+
+type memOverride struct {
+	override  *sapb.RateLimitOverride
+	enabled   bool
+	updatedAt time.Time
+}
+
+func (o *memOverride) toPB() *sapb.RateLimitOverrideResponse {
+	return &sapb.RateLimitOverrideResponse{
+		Override:  proto.Clone(o.override).(*sapb.RateLimitOverride),
+		Enabled:   o.enabled,
+		UpdatedAt: timestamppb.New(o.updatedAt),
+	}
+}
+
+type memValidationPerspective struct {
+	authzID     int64
+	attemptedAt time.Time
+	perspective string
+	rir         string
+	passed      bool
+	latency     time.Duration
+}
+
+type memIncident struct {
+	incident *sapb.Incident
+	serials  []*sapb.IncidentSerial
+}
+
+// keyDigest returns the digest by which registrations are looked up by key.
+func keyDigest(jwkJSON []byte) (string, error) {
+	var jwk jose.JSONWebKey
+	err := jwk.UnmarshalJSON(jwkJSON)
+	if err != nil {
+		return "", err
+	}
+	return core.KeyDigestB64(jwk.Key)
+}
+
+// checkIdentifierTypes returns an error if any of idents has a type which the
+// SQL SA can't store.
+func checkIdentifierTypes(idents []*corepb.Identifier) error {
+	for _, ident := range idents {
+		if !slices.Contains(identifierTypes, ident.Type) {
+			return fmt.Errorf("unsupported identifier type %q", ident.Type)
+		}
+	}
+	return nil
+}
+
+// orderedChallTypes returns the distinct challenge types of types, in the
+// order in which the SQL SA returns them. Like the SQL SA, it treats unknown
+// types as http-01.
+func orderedChallTypes(types []string) []string {
+	var ordered []string
+	for _, challType := range challTypes {
+		for _, t := range types {
+			if t == challType || (challType == string(core.ChallengeTypeHTTP01) && !slices.Contains(challTypes, t)) {
+				ordered = append(ordered, challType)
+				break
+			}
+		}
+	}
+	return ordered
+}
+
+// statusForOrder determines the status of order from the statuses of its
+// authorizations, exactly as the SQL SA does.
+func statusForOrder(order *corepb.Order, authzs []*memAuthz, now time.Time) (string, error) {
+	if order.Error != nil {
+		return string(core.StatusInvalid), nil
+	}
+	if order.Expires.AsTime().Before(now) {
+		return string(core.StatusInvalid), nil
+	}
+	if len(authzs) != len(order.V2Authorizations) {
+		return "", berrors.InternalServerError(
+			"getAuthorizationStatuses returned the wrong number of authorization statuses "+
+				"(%d vs expected %d) for order %d",
+			len(authzs), len(order.V2Authorizations), order.Id)
+	}
+
+	pendingAuthzs := 0
+	validAuthzs := 0
+	otherAuthzs := 0
+	expiredAuthzs := 0
+	for _, authz := range authzs {
+		switch authz.status {
+		case core.StatusPending:
+			pendingAuthzs++
+		case core.StatusValid:
+			validAuthzs++
+		case core.StatusInvalid, core.StatusDeactivated, core.StatusRevoked:
+			otherAuthzs++
+		case core.StatusExpired:
+			expiredAuthzs++
+		default:
+			return "", berrors.InternalServerError(
+				"Order is in an invalid state. Authz has invalid status %s",
+				authz.status)
+		}
+		if authz.expires.Before(now) {
+			expiredAuthzs++
+		}
+	}
+
+	if otherAuthzs > 0 || expiredAuthzs > 0 {
+		return string(core.StatusInvalid), nil
+	}
+	if pendingAuthzs > 0 {
+		return string(core.StatusPending), nil
+	}
+	if len(order.Identifiers) != validAuthzs {
+		return "", berrors.InternalServerError(
+			"Order has the incorrect number of valid authorizations & no pending, " +
+				"deactivated or invalid authorizations")
+	}
+	if order.CertificateSerial != "" {
+		return string(core.StatusValid), nil
+	}
+	if order.BeganProcessing {
+		return string(core.StatusProcessing), nil
+	}
+	return string(core.StatusReady), nil
+}
+
+// authzsByID returns those of the authorizations with the given IDs which
+// exist, in the order given. Callers must hold the lock.
+func (m *MemoryStorageAuthority) authzsByID(ids []int64) []*memAuthz {
+	var authzs []*memAuthz
+	for _, id := range ids {
+		authz, ok := m.authzs[id]
+		if ok {
+			authzs = append(authzs, authz)
+		}
+	}
+	return authzs
+}
+
+// getOrder implements GetOrder. Callers must hold the lock.
+func (m *MemoryStorageAuthority) getOrder(id int64) (*corepb.Order, error) {
+	o, ok := m.orders[id]
+	if !ok || o.expires.Before(m.clk.Now()) {
+		return nil, berrors.NotFoundError("no order found for ID %d", id)
+	}
+	order := &corepb.Order{
+		Id:                     o.id,
+		RegistrationID:         o.regID,
+		Expires:                timestamppb.New(o.expires),
+		Created:                timestamppb.New(o.created),
+		CertificateSerial:      o.certSerial,
+		BeganProcessing:        o.beganProcessing,
+		CertificateProfileName: o.profile,
+		Replaces:               o.replaces,
+		CsrHash:                slices.Clone(o.csrHash),
+		V2Authorizations:       slices.Clone(o.authzIDs),
+	}
+	if o.err != nil {
+		order.Error = proto.Clone(o.err).(*corepb.ProblemDetails)
+	}
+	authzs := m.authzsByID(o.authzIDs)
+	for _, authz := range authzs {
+		order.Identifiers = append(order.Identifiers, authz.ident.ToProto())
+	}
+	status, err := statusForOrder(order, authzs, m.clk.Now())
+	if err != nil {
+		return nil, err
+	}
+	order.Status = status
+	return order, nil
+}
+
+// CountInvalidAuthorizations2 counts invalid authorizations for a user expiring
+// in a given time range.
+func (m *MemoryStorageAuthority) CountInvalidAuthorizations2(ctx context.Context, req *sapb.CountInvalidAuthorizationsRequest) (*sapb.Count, error) {
+	ident := identifier.FromProto(req.Identifier)
+	if core.IsAnyNilOrZero(req.RegistrationID, ident, req.Range.Earliest, req.Range.Latest) {
+		return nil, errIncompleteRequest
+	}
+	err := checkIdentifierTypes([]*corepb.Identifier{req.Identifier})
+	if err != nil {
+		return nil, err
+	}
+	earliest := req.Range.Earliest.AsTime()
+	latest := req.Range.Latest.AsTime()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var count int64
+	for _, authz := range m.authzs {
+		if authz.regID == req.RegistrationID && authz.status == core.StatusInvalid && authz.ident == ident &&
+			authz.expires.After(earliest) && !authz.expires.After(latest) {
+			count++
+		}
+	}
+	return &sapb.Count{Count: count}, nil
+}
+
+// CountPendingAuthorizations2 returns the number of pending, unexpired
+// authorizations for the given registration.
+func (m *MemoryStorageAuthority) CountPendingAuthorizations2(ctx context.Context, req *sapb.RegistrationID) (*sapb.Count, error) {
+	if req.Id == 0 {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var count int64
+	for _, authz := range m.authzs {
+		if authz.regID == req.Id && authz.status == core.StatusPending && authz.expires.After(m.clk.Now()) {
+			count++
+		}
+	}
+	return &sapb.Count{Count: count}, nil
+}
+
+// FQDNSetTimestampsForWindow returns the issuance timestamps for each
+// certificate issued for a set of identifiers during a given window of time,
+// starting from the most recent issuance. If req.Limit is nonzero, it returns
+// only the most recent `Limit` results.
+func (m *MemoryStorageAuthority) FQDNSetTimestampsForWindow(ctx context.Context, req *sapb.CountFQDNSetsRequest) (*sapb.Timestamps, error) {
+	idents := identifier.FromProtoSlice(req.Identifiers)
+	if core.IsAnyNilOrZero(req.Window) || len(idents) == 0 {
+		return nil, errIncompleteRequest
+	}
+	setHash := core.HashIdentifiers(idents)
+	since := m.clk.Now().Add(-req.Window.AsDuration())
+
+	m.mu.Lock()
+	var issued []time.Time
+	for _, set := range m.fqdnSets {
+		if bytes.Equal(set.setHash, setHash) && set.issued.After(since) {
+			issued = append(issued, set.issued)
+		}
+	}
+	m.mu.Unlock()
+
+	slices.SortFunc(issued, func(a, b time.Time) int { return b.Compare(a) })
+	if req.Limit != 0 && int64(len(issued)) > req.Limit {
+		issued = issued[:req.Limit]
+	}
+	var results []*timestamppb.Timestamp
+	for _, t := range issued {
+		results = append(results, timestamppb.New(t))
+	}
+	return &sapb.Timestamps{Timestamps: results}, nil
+}
+
+// FQDNSetExists returns whether a certificate has been issued for exactly the
+// given set of identifiers.
+func (m *MemoryStorageAuthority) FQDNSetExists(ctx context.Context, req *sapb.FQDNSetExistsRequest) (*sapb.Exists, error) {
+	idents := identifier.FromProtoSlice(req.Identifiers)
+	if len(idents) == 0 {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return &sapb.Exists{Exists: m.fqdnSetExists(idents)}, nil
+}
+
+// fqdnSetExists implements FQDNSetExists. Callers must hold the lock.
+func (m *MemoryStorageAuthority) fqdnSetExists(idents identifier.ACMEIdentifiers) bool {
+	setHash := core.HashIdentifiers(idents)
+	for _, set := range m.fqdnSets {
+		if bytes.Equal(set.setHash, setHash) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetAuthorization2 returns the authorization with the given ID, or a NotFound
+// error if there isn't one.
+func (m *MemoryStorageAuthority) GetAuthorization2(ctx context.Context, req *sapb.AuthorizationID2) (*corepb.Authorization, error) {
+	if req.Id == 0 {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	authz, ok := m.authzs[req.Id]
+	if !ok {
+		return nil, berrors.NotFoundError("authorization %d not found", req.Id)
+	}
+	return authz.toPB(), nil
+}
+
+// matchingAuthzs returns, for each of idents, the authorization which best
+// matches, in order of authorization ID. An authorization matches if it
+// belongs to regID, has one of statuses, expires after validUntil, and, if
+// profile is set, has that profile. better reports whether one matching
+// authorization is better than another. Callers must hold the lock.
+func (m *MemoryStorageAuthority) matchingAuthzs(
+	regID int64,
+	idents identifier.ACMEIdentifiers,
+	statuses []core.AcmeStatus,
+	validUntil time.Time,
+	profile string,
+	better func(candidate, existing *memAuthz) bool,
+) *sapb.Authorizations {
+	best := make(map[identifier.ACMEIdentifier]*memAuthz)
+	for _, authz := range m.authzs {
+		if authz.regID != regID ||
+			!slices.Contains(statuses, authz.status) ||
+			!authz.expires.After(validUntil) ||
+			!slices.Contains(idents, authz.ident) ||
+			(profile != "" && authz.profile != profile) {
+			continue
+		}
+		existing, ok := best[authz.ident]
+		if !ok || better(authz, existing) {
+			best[authz.ident] = authz
+		}
+	}
+	return authzsToPB(best)
+}
+
+// authzsToPB returns the authorizations in byIdent, in order of authorization
+// ID.
+func authzsToPB(byIdent map[identifier.ACMEIdentifier]*memAuthz) *sapb.Authorizations {
+	var authzs []*memAuthz
+	for _, authz := range byIdent {
+		authzs = append(authzs, authz)
+	}
+	slices.SortFunc(authzs, func(a, b *memAuthz) int { return int(a.id - b.id) })
+	resp := &sapb.Authorizations{}
+	for _, authz := range authzs {
+		resp.Authzs = append(resp.Authzs, authz.toPB())
+	}
+	return resp
+}
+
+// GetAuthorizations2 returns a single pending or valid authorization owned by
+// the given account for each of the given identifiers. If both a valid and a
+// pending authorization exist, only the valid one is returned.
+func (m *MemoryStorageAuthority) GetAuthorizations2(ctx context.Context, req *sapb.GetAuthorizationsRequest) (*sapb.Authorizations, error) {
+	idents := identifier.FromProtoSlice(req.Identifiers)
+	if core.IsAnyNilOrZero(req, req.RegistrationID, idents, req.ValidUntil) {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.matchingAuthzs(
+		req.RegistrationID,
+		idents,
+		[]core.AcmeStatus{core.StatusValid, core.StatusPending},
+		req.ValidUntil.AsTime(),
+		req.Profile,
+		func(candidate, existing *memAuthz) bool {
+			return existing.status == core.StatusPending && candidate.status == core.StatusValid ||
+				existing.status == candidate.status && candidate.id < existing.id
+		},
+	), nil
+}
+
+// GetCertificate returns the certificate with the given serial, or a NotFound
+// error if there isn't one.
+func (m *MemoryStorageAuthority) GetCertificate(ctx context.Context, req *sapb.Serial) (*corepb.Certificate, error) {
+	if req == nil || req.Serial == "" {
+		return nil, errIncompleteRequest
+	}
+	if !core.ValidSerial(req.Serial) {
+		return nil, fmt.Errorf("invalid certificate serial %s", req.Serial)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cert, ok := m.certificates[req.Serial]
+	if !ok {
+		return nil, berrors.NotFoundError("certificate with serial %q not found", req.Serial)
+	}
+	return proto.Clone(cert).(*corepb.Certificate), nil
+}
+
+// GetLintPrecertificate returns the linting precertificate with the given
+// serial, or a NotFound error if there isn't one.
+func (m *MemoryStorageAuthority) GetLintPrecertificate(ctx context.Context, req *sapb.Serial) (*corepb.Certificate, error) {
+	if req == nil || req.Serial == "" {
+		return nil, errIncompleteRequest
+	}
+	if !core.ValidSerial(req.Serial) {
+		return nil, fmt.Errorf("invalid precertificate serial %s", req.Serial)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cert, ok := m.precertificates[req.Serial]
+	if !ok {
+		return nil, berrors.NotFoundError("precertificate with serial %q not found", req.Serial)
+	}
+	return proto.Clone(cert).(*corepb.Certificate), nil
+}
+
+// GetCertificateStatus returns the status of the certificate with the given
+// serial, or a NotFound error if there isn't one.
+func (m *MemoryStorageAuthority) GetCertificateStatus(ctx context.Context, req *sapb.Serial) (*corepb.CertificateStatus, error) {
+	if req.Serial == "" {
+		return nil, errIncompleteRequest
+	}
+	if !core.ValidSerial(req.Serial) {
+		return nil, fmt.Errorf("invalid certificate serial %s", req.Serial)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	status, ok := m.certificateStatuses[req.Serial]
+	if !ok {
+		return nil, berrors.NotFoundError("certificate status with serial %q not found", req.Serial)
+	}
+	return proto.Clone(status).(*corepb.CertificateStatus), nil
+}
+
+// GetMaxExpiration returns the latest notAfter date of any certificate.
+func (m *MemoryStorageAuthority) GetMaxExpiration(ctx context.Context, req *emptypb.Empty) (*timestamppb.Timestamp, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var maxNotAfter *timestamppb.Timestamp
+	for _, status := range m.certificateStatuses {
+		if maxNotAfter == nil || status.NotAfter.AsTime().After(maxNotAfter.AsTime()) {
+			maxNotAfter = status.NotAfter
+		}
+	}
+	if maxNotAfter == nil {
+		return nil, errors.New("certificateStatus table notAfter column is empty")
+	}
+	return timestamppb.New(maxNotAfter.AsTime()), nil
+}
+
+// GetOrder returns the unexpired order with the given ID, or a NotFound error
+// if there isn't one.
+func (m *MemoryStorageAuthority) GetOrder(ctx context.Context, req *sapb.OrderRequest) (*corepb.Order, error) {
+	if req == nil || req.Id == 0 {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.getOrder(req.Id)
+}
+
+// GetOrderForNames returns the pending or ready order for exactly the given
+// identifiers which expires soonest, if it belongs to the given account.
+// Otherwise it returns a NotFound error.
+func (m *MemoryStorageAuthority) GetOrderForNames(ctx context.Context, req *sapb.GetOrderForNamesRequest) (*corepb.Order, error) {
+	idents := identifier.FromProtoSlice(req.Identifiers)
+	if req.AcctID == 0 || len(idents) == 0 {
+		return nil, errIncompleteRequest
+	}
+	setHash := core.HashIdentifiers(idents)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var soonest *memOrderFQDNSet
+	for _, set := range m.orderFQDNSets {
+		if bytes.Equal(set.setHash, setHash) && set.expires.After(m.clk.Now()) &&
+			(soonest == nil || set.expires.Before(soonest.expires)) {
+			soonest = set
+		}
+	}
+	if soonest == nil || soonest.regID != req.AcctID {
+		return nil, berrors.NotFoundError("no order matching request found")
+	}
+
+	order, err := m.getOrder(soonest.orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != string(core.StatusPending) && order.Status != string(core.StatusReady) {
+		return nil, berrors.NotFoundError("no order matching request found")
+	}
+	return order, nil
+}
+
+// GetRegistration returns the registration with the given ID, or a NotFound
+// error if there isn't one.
+func (m *MemoryStorageAuthority) GetRegistration(ctx context.Context, req *sapb.RegistrationID) (*corepb.Registration, error) {
+	if req == nil || req.Id == 0 {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	reg, ok := m.registrations[req.Id]
+	if !ok {
+		return nil, berrors.NotFoundError("registration with ID '%d' not found", req.Id)
+	}
+	return proto.Clone(reg.reg).(*corepb.Registration), nil
+}
+
+// GetRegistrationByKey returns the registration with the given key, or a
+// NotFound error if there isn't one.
+func (m *MemoryStorageAuthority) GetRegistrationByKey(ctx context.Context, req *sapb.JSONWebKey) (*corepb.Registration, error) {
+	if req == nil || len(req.Jwk) == 0 {
+		return nil, errIncompleteRequest
+	}
+	sha, err := keyDigest(req.Jwk)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, reg := range m.registrations {
+		if reg.keyDigest == sha {
+			return proto.Clone(reg.reg).(*corepb.Registration), nil
+		}
+	}
+	return nil, berrors.NotFoundError("no registrations with public key sha256 %q", sha)
+}
+
+// revocationStatus returns the revocation status recorded in status.
+func revocationStatus(status *corepb.CertificateStatus) (*sapb.RevocationStatus, error) {
+	statusInt, ok := core.OCSPStatusToInt[core.OCSPStatus(status.Status)]
+	if !ok {
+		return nil, fmt.Errorf("got unrecognized status %q", status.Status)
+	}
+	return &sapb.RevocationStatus{
+		Status:        int64(statusInt),
+		RevokedDate:   timestamppb.New(status.RevokedDate.AsTime()),
+		RevokedReason: status.RevokedReason,
+	}, nil
+}
+
+// GetRevocationStatus returns the revocation status of the certificate with
+// the given serial, or a NotFound error if there isn't one.
+func (m *MemoryStorageAuthority) GetRevocationStatus(ctx context.Context, req *sapb.Serial) (*sapb.RevocationStatus, error) {
+	if req.Serial == "" {
+		return nil, errIncompleteRequest
+	}
+	if !core.ValidSerial(req.Serial) {
+		return nil, fmt.Errorf("invalid certificate serial %s", req.Serial)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	status, ok := m.certificateStatuses[req.Serial]
+	if !ok {
+		return nil, berrors.NotFoundError("certificate status with serial %q not found", req.Serial)
+	}
+	return revocationStatus(status)
+}
+
+// GetRevocationStatuses is the batch form of GetRevocationStatus. Serials which
+// are not found are omitted from the response rather than causing an error.
+func (m *MemoryStorageAuthority) GetRevocationStatuses(ctx context.Context, req *sapb.Serials) (*sapb.RevocationStatuses, error) {
+	if req == nil || len(req.Serials) == 0 {
+		return nil, errIncompleteRequest
+	}
+	if len(req.Serials) > maxRevocationStatusBatchSize {
+		return nil, fmt.Errorf("too many serials: got %d, max %d", len(req.Serials), maxRevocationStatusBatchSize)
+	}
+	for _, serial := range req.Serials {
+		if !core.ValidSerial(serial) {
+			return nil, fmt.Errorf("invalid certificate serial %s", serial)
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	statuses := make(map[string]*sapb.RevocationStatus)
+	for _, serial := range req.Serials {
+		status, ok := m.certificateStatuses[serial]
+		if !ok {
+			continue
+		}
+		revStatus, err := revocationStatus(status)
+		if err != nil {
+			return nil, err
+		}
+		statuses[serial] = revStatus
+	}
+	return &sapb.RevocationStatuses{Statuses: statuses}, nil
+}
+
+// sendAll sends each of msgs on stream, stopping at the first error.
+func sendAll[T any](stream grpc.ServerStreamingServer[T], msgs []*T) error {
+	for _, msg := range msgs {
+		err := stream.Send(msg)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetRevokedCerts streams the revoked certificates issued by the given issuer
+// which expire in the given period, and were revoked before the given time.
+func (m *MemoryStorageAuthority) GetRevokedCerts(req *sapb.GetRevokedCertsRequest, stream grpc.ServerStreamingServer[corepb.CRLEntry]) error {
+	if core.IsAnyNilOrZero(req.IssuerNameID, req.RevokedBefore, req.ExpiresAfter, req.ExpiresBefore) {
+		return errIncompleteRequest
+	}
+	atTime := req.RevokedBefore.AsTime()
+	expiresAfter := req.ExpiresAfter.AsTime()
+	expiresBefore := req.ExpiresBefore.AsTime()
+
+	m.mu.Lock()
+	var entries []*corepb.CRLEntry
+	for _, status := range m.sortedCertificateStatuses() {
+		notAfter := status.NotAfter.AsTime()
+		if status.IssuerID != req.IssuerNameID || status.Status != string(core.OCSPStatusRevoked) ||
+			notAfter.Before(expiresAfter) || !notAfter.Before(expiresBefore) ||
+			!status.RevokedDate.AsTime().Before(atTime) {
+			continue
+		}
+		entries = append(entries, &corepb.CRLEntry{
+			Serial:    status.Serial,
+			Reason:    int32(status.RevokedReason), //nolint: gosec // Revocation reasons are guaranteed to be small, no risk of overflow.
+			RevokedAt: timestamppb.New(status.RevokedDate.AsTime()),
+		})
+	}
+	m.mu.Unlock()
+
+	return sendAll(stream, entries)
+}
+
+// sortedCertificateStatuses returns the certificate statuses in order of
+// serial. Callers must hold the lock.
+func (m *MemoryStorageAuthority) sortedCertificateStatuses() []*corepb.CertificateStatus {
+	var statuses []*corepb.CertificateStatus
+	for _, status := range m.certificateStatuses {
+		statuses = append(statuses, status)
+	}
+	slices.SortFunc(statuses, func(a, b *corepb.CertificateStatus) int {
+		return strings.Compare(a.Serial, b.Serial)
+	})
+	return statuses
+}
+
+// GetRevokedCertsByShard streams the unexpired revoked certificates in the
+// given shard which were revoked before the given time.
+func (m *MemoryStorageAuthority) GetRevokedCertsByShard(req *sapb.GetRevokedCertsByShardRequest, stream grpc.ServerStreamingServer[corepb.CRLEntry]) error {
+	if core.IsAnyNilOrZero(req.ShardIdx, req.IssuerNameID, req.RevokedBefore, req.ExpiresAfter) {
+		return errIncompleteRequest
+	}
+	atTime := req.RevokedBefore.AsTime()
+	expiresAfter := req.ExpiresAfter.AsTime().Truncate(time.Hour)
+
+	m.mu.Lock()
+	var serials []string
+	for serial := range m.revokedCerts {
+		serials = append(serials, serial)
+	}
+	slices.Sort(serials)
+	var entries []*corepb.CRLEntry
+	for _, serial := range serials {
+		rc := m.revokedCerts[serial]
+		if rc.issuerID != req.IssuerNameID || rc.shardIdx != req.ShardIdx ||
+			rc.notAfterHour.Before(expiresAfter) || !rc.revokedDate.Before(atTime) {
+			continue
+		}
+		entries = append(entries, &corepb.CRLEntry{
+			Serial:    serial,
+			Reason:    int32(rc.reason), //nolint: gosec // Revocation reasons are guaranteed to be small, no risk of overflow.
+			RevokedAt: timestamppb.New(rc.revokedDate),
+		})
+	}
+	m.mu.Unlock()
+
+	return sendAll(stream, entries)
+}
+
+// GetSerialMetadata returns the metadata recorded with the given serial by
+// AddSerial.
+func (m *MemoryStorageAuthority) GetSerialMetadata(ctx context.Context, req *sapb.Serial) (*sapb.SerialMetadata, error) {
+	if req == nil || req.Serial == "" {
+		return nil, errIncompleteRequest
+	}
+	if !core.ValidSerial(req.Serial) {
+		return nil, fmt.Errorf("invalid serial %q", req.Serial)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	serial, ok := m.serials[req.Serial]
+	if !ok {
+		return nil, berrors.NotFoundError("serial %q not found", req.Serial)
+	}
+	return &sapb.SerialMetadata{
+		Serial:         req.Serial,
+		RegistrationID: serial.regID,
+		Created:        timestamppb.New(serial.created),
+		Expires:        timestamppb.New(serial.expires),
+	}, nil
+}
+
+// GetSerialsByAccount streams the serials of the given account's unexpired
+// certificates.
+func (m *MemoryStorageAuthority) GetSerialsByAccount(req *sapb.RegistrationID, stream grpc.ServerStreamingServer[sapb.Serial]) error {
+	m.mu.Lock()
+	var serials []string
+	for serial, metadata := range m.serials {
+		if metadata.regID == req.Id && metadata.expires.After(m.clk.Now()) {
+			serials = append(serials, serial)
+		}
+	}
+	m.mu.Unlock()
+
+	slices.Sort(serials)
+	var msgs []*sapb.Serial
+	for _, serial := range serials {
+		msgs = append(msgs, &sapb.Serial{Serial: serial})
+	}
+	return sendAll(stream, msgs)
+}
+
+// GetCRLShard returns the thisUpdate and nextUpdate of the given CRL shard's
+// current CRL, and the digest of its entries, as last reported to
+// UpdateCRLShard.
+func (m *MemoryStorageAuthority) GetCRLShard(ctx context.Context, req *sapb.GetCRLShardRequest) (*sapb.CRLShard, error) {
+	if core.IsAnyNilOrZero(req, req.IssuerNameID) {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	shard, ok := m.crlShards[memCRLShardKey{req.IssuerNameID, req.ShardIdx}]
+	if !ok {
+		return nil, berrors.NotFoundError("no CRL shard %d for issuer %d", req.ShardIdx, req.IssuerNameID)
+	}
+	resp := &sapb.CRLShard{EntriesDigest: slices.Clone(shard.entriesDigest)}
+	if shard.thisUpdate != nil {
+		resp.ThisUpdate = timestamppb.New(*shard.thisUpdate)
+	}
+	if shard.nextUpdate != nil {
+		resp.NextUpdate = timestamppb.New(*shard.nextUpdate)
+	}
+	return resp, nil
+}
+
+// GetMPICComplianceStats summarizes the outcomes of the network perspectives
+// recorded for validation attempts made in the given window, per perspective.
+func (m *MemoryStorageAuthority) GetMPICComplianceStats(ctx context.Context, req *sapb.GetMPICComplianceStatsRequest) (*sapb.MPICComplianceStats, error) {
+	if core.IsAnyNilOrZero(req.AttemptedAfter, req.AttemptedBefore) {
+		return nil, errIncompleteRequest
+	}
+	after, before := req.AttemptedAfter.AsTime(), req.AttemptedBefore.AsTime()
+	if !after.Before(before) {
+		return nil, berrors.MalformedError("attemptedAfter %s is not before attemptedBefore %s", after, before)
+	}
+
+	type attemptKey struct {
+		authzID     int64
+		attemptedAt time.Time
+	}
+	type perspectiveKey struct {
+		perspective string
+		rir         string
+	}
+	type perspectiveTotals struct {
+		attempts, passed int64
+		total, max       time.Duration
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	attempts := make(map[attemptKey]bool)
+	totals := make(map[perspectiveKey]*perspectiveTotals)
+	for _, p := range m.perspectives {
+		if p.attemptedAt.Before(after) || !p.attemptedAt.Before(before) {
+			continue
+		}
+		attempts[attemptKey{p.authzID, p.attemptedAt}] = true
+		key := perspectiveKey{p.perspective, p.rir}
+		t, ok := totals[key]
+		if !ok {
+			t = &perspectiveTotals{}
+			totals[key] = t
+		}
+		t.attempts++
+		if p.passed {
+			t.passed++
+		}
+		t.total += p.latency
+		t.max = max(t.max, p.latency)
+	}
+
+	stats := &sapb.MPICComplianceStats{Attempts: int64(len(attempts))}
+	for key, t := range totals {
+		stats.Perspectives = append(stats.Perspectives, &sapb.PerspectiveStats{
+			Perspective: key.perspective,
+			Rir:         key.rir,
+			Attempts:    t.attempts,
+			Passed:      t.passed,
+			MeanLatency: durationpb.New(t.total / time.Duration(t.attempts)),
+			MaxLatency:  durationpb.New(t.max),
+		})
+	}
+	slices.SortFunc(stats.Perspectives, func(a, b *sapb.PerspectiveStats) int {
+		return cmp.Or(strings.Compare(a.Perspective, b.Perspective), strings.Compare(a.Rir, b.Rir))
+	})
+	return stats, nil
+}
+
+// GetCertificatesByExpiry streams the final certificates which expire in the
+// given window, ordered by serial.
+func (m *MemoryStorageAuthority) GetCertificatesByExpiry(req *sapb.GetCertificatesByExpiryRequest, stream grpc.ServerStreamingServer[corepb.Certificate]) error {
+	if core.IsAnyNilOrZero(req.ExpiresAfter, req.ExpiresBefore) {
+		return errIncompleteRequest
+	}
+	after, before := req.ExpiresAfter.AsTime(), req.ExpiresBefore.AsTime()
+	if !after.Before(before) {
+		return berrors.MalformedError("expiresAfter %s is not before expiresBefore %s", after, before)
+	}
+
+	m.mu.Lock()
+	var msgs []*corepb.Certificate
+	for _, cert := range m.certificates {
+		expires := cert.Expires.AsTime()
+		if !expires.Before(after) && expires.Before(before) {
+			msgs = append(msgs, proto.Clone(cert).(*corepb.Certificate))
+		}
+	}
+	m.mu.Unlock()
+
+	slices.SortFunc(msgs, func(a, b *corepb.Certificate) int {
+		return strings.Compare(a.Serial, b.Serial)
+	})
+	return sendAll(stream, msgs)
+}
+
+// GetSerialsByKey streams the serials of the unexpired certificates whose
+// public key has the given hash.
+func (m *MemoryStorageAuthority) GetSerialsByKey(req *sapb.SPKIHash, stream grpc.ServerStreamingServer[sapb.Serial]) error {
+	m.mu.Lock()
+	var msgs []*sapb.Serial
+	for _, kh := range m.keyHashes {
+		if bytes.Equal(kh.keyHash, req.KeyHash) && kh.notAfter.After(m.clk.Now()) {
+			msgs = append(msgs, &sapb.Serial{Serial: kh.serial})
+		}
+	}
+	m.mu.Unlock()
+
+	return sendAll(stream, msgs)
+}
+
+// SearchCertificates streams the metadata of the certificates matching the
+// request's one search term, including expired certificates. Names and key
+// hashes return the newest certificates first; serial prefixes return serials
+// in ascending order.
+func (m *MemoryStorageAuthority) SearchCertificates(req *sapb.SearchCertificatesRequest, stream grpc.ServerStreamingServer[sapb.SerialMetadata]) error {
+	limit, err := certificateSearchLimit(req)
+	if err != nil {
+		return err
+	}
+
+	type match struct {
+		serial string
+		order  time.Time
+	}
+	var matches []match
+
+	m.mu.Lock()
+	switch {
+	case req.SerialPrefix != "":
+		for serial := range m.serials {
+			if strings.HasPrefix(serial, req.SerialPrefix) {
+				matches = append(matches, match{serial: serial})
+			}
+		}
+		slices.SortFunc(matches, func(a, b match) int { return strings.Compare(a.serial, b.serial) })
+	case req.San != "":
+		san := strings.ToLower(req.San)
+		for serial, precert := range m.precertificates {
+			parsed, err := x509.ParseCertificate(precert.Der)
+			if err != nil {
+				m.mu.Unlock()
+				return err
+			}
+			for _, ident := range identifier.FromCert(parsed) {
+				if ident.Value == san {
+					matches = append(matches, match{serial: serial, order: parsed.NotBefore})
+					break
+				}
+			}
+		}
+	default:
+		for _, kh := range m.keyHashes {
+			if bytes.Equal(kh.keyHash, req.KeyHash) {
+				matches = append(matches, match{serial: kh.serial, order: kh.notAfter})
+			}
+		}
+	}
+	if req.SerialPrefix == "" {
+		slices.SortStableFunc(matches, func(a, b match) int {
+			return cmp.Or(b.order.Compare(a.order), strings.Compare(a.serial, b.serial))
+		})
+	}
+
+	var msgs []*sapb.SerialMetadata
+	for _, match := range matches {
+		if int64(len(msgs)) == limit {
+			break
+		}
+		serial, ok := m.serials[match.serial]
+		if !ok {
+			continue
+		}
+		msgs = append(msgs, &sapb.SerialMetadata{
+			Serial:         match.serial,
+			RegistrationID: serial.regID,
+			Created:        timestamppb.New(serial.created),
+			Expires:        timestamppb.New(serial.expires),
+		})
+	}
+	m.mu.Unlock()
+
+	return sendAll(stream, msgs)
+}
+
+// certificateSearchLimit checks that exactly one of req's search terms is set
+// and well-formed, and returns the maximum number of results to return.
+func certificateSearchLimit(req *sapb.SearchCertificatesRequest) (int64, error) {
+	terms := 0
+	for _, set := range []bool{req.San != "", len(req.KeyHash) != 0, req.SerialPrefix != ""} {
+		if set {
+			terms++
+		}
+	}
+	if terms == 0 {
+		return 0, errIncompleteRequest
+	}
+	if terms > 1 {
+		return 0, errors.New("exactly one of san, keyHash, and serialPrefix must be set")
+	}
+	if req.KeyHash != nil && len(req.KeyHash) != sha256.Size {
+		return 0, fmt.Errorf("invalid key hash length %d", len(req.KeyHash))
+	}
+	if req.SerialPrefix != "" && !core.ValidSerialPrefix(req.SerialPrefix) {
+		return 0, fmt.Errorf("invalid serial prefix %q", req.SerialPrefix)
+	}
+	if req.Limit < 0 || req.Limit > maxCertificateSearchResults {
+		return 0, fmt.Errorf("invalid limit %d: must be between 0 and %d", req.Limit, maxCertificateSearchResults)
+	}
+	if req.Limit == 0 {
+		return maxCertificateSearchResults, nil
+	}
+	return req.Limit, nil
+}
+
+// GetValidAuthorizations2 returns a single valid authorization owned by the
+// given account for each of the given identifiers. If more than one valid
+// authorization exists, only the one with the latest expiry is returned.
+func (m *MemoryStorageAuthority) GetValidAuthorizations2(ctx context.Context, req *sapb.GetValidAuthorizationsRequest) (*sapb.Authorizations, error) {
+	idents := identifier.FromProtoSlice(req.Identifiers)
+	if core.IsAnyNilOrZero(req, req.RegistrationID, idents, req.ValidUntil) {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.matchingAuthzs(
+		req.RegistrationID,
+		idents,
+		[]core.AcmeStatus{core.StatusValid},
+		req.ValidUntil.AsTime(),
+		req.Profile,
+		func(candidate, existing *memAuthz) bool {
+			return candidate.expires.After(existing.expires) ||
+				candidate.expires.Equal(existing.expires) && candidate.id < existing.id
+		},
+	), nil
+}
+
+// GetValidOrderAuthorizations2 returns all of the authorizations of the given
+// order, whatever their status. Like the SQL SA, it leaves checking them to
+// the caller.
+func (m *MemoryStorageAuthority) GetValidOrderAuthorizations2(ctx context.Context, req *sapb.GetValidOrderAuthorizationsRequest) (*sapb.Authorizations, error) {
+	if core.IsAnyNilOrZero(req.Id) {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	order, ok := m.orders[req.Id]
+	if !ok {
+		return &sapb.Authorizations{}, nil
+	}
+	byIdent := make(map[identifier.ACMEIdentifier]*memAuthz)
+	for _, authz := range m.authzsByID(order.authzIDs) {
+		_, present := byIdent[authz.ident]
+		if present {
+			return nil, fmt.Errorf("identifier %q appears twice in authzs for order %d", authz.ident.Value, req.Id)
+		}
+		byIdent[authz.ident] = authz
+	}
+	return authzsToPB(byIdent), nil
+}
+
+// IncidentsForSerial returns every enabled incident which affects the given
+// serial.
+func (m *MemoryStorageAuthority) IncidentsForSerial(ctx context.Context, req *sapb.Serial) (*sapb.Incidents, error) {
+	if req == nil {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var incidents []*sapb.Incident
+	for _, inc := range m.incidents {
+		if !inc.incident.Enabled {
+			continue
+		}
+		for _, serial := range inc.serials {
+			if serial.Serial == req.Serial {
+				incidents = append(incidents, proto.Clone(inc.incident).(*sapb.Incident))
+				break
+			}
+		}
+	}
+	return &sapb.Incidents{Incidents: incidents}, nil
+}
+
+// KeyBlocked returns whether the key with the given hash is blocked.
+func (m *MemoryStorageAuthority) KeyBlocked(ctx context.Context, req *sapb.SPKIHash) (*sapb.Exists, error) {
+	if req == nil || req.KeyHash == nil {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return &sapb.Exists{Exists: m.blockedKeys[string(req.KeyHash)]}, nil
+}
+
+// ReplacementOrderExists returns whether a replacement order which is still
+// being worked on, or has been finalized, exists for the given serial.
+func (m *MemoryStorageAuthority) ReplacementOrderExists(ctx context.Context, req *sapb.Serial) (*sapb.Exists, error) {
+	if req == nil || req.Serial == "" {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	replacement, ok := m.replacementOrders[req.Serial]
+	if !ok {
+		return &sapb.Exists{Exists: false}, nil
+	}
+	if replacement.replaced {
+		return &sapb.Exists{Exists: true}, nil
+	}
+	if replacement.orderExpires.Before(m.clk.Now()) {
+		return &sapb.Exists{Exists: false}, nil
+	}
+
+	order, err := m.getOrder(replacement.orderID)
+	if err != nil {
+		if errors.Is(err, berrors.NotFound) {
+			return &sapb.Exists{Exists: false}, nil
+		}
+		return nil, err
+	}
+	switch order.Status {
+	case string(core.StatusPending), string(core.StatusReady), string(core.StatusProcessing), string(core.StatusValid):
+		return &sapb.Exists{Exists: true}, nil
+	case string(core.StatusInvalid):
+		return &sapb.Exists{Exists: false}, nil
+	default:
+		return nil, fmt.Errorf("unknown replacement order status: %q", order.Status)
+	}
+}
+
+// SerialsForIncident streams the serials in the given incident table.
+func (m *MemoryStorageAuthority) SerialsForIncident(req *sapb.SerialsForIncidentRequest, stream grpc.ServerStreamingServer[sapb.IncidentSerial]) error {
+	if req.IncidentTable == "" {
+		return errIncompleteRequest
+	}
+	if !validIncidentTableRegexp.MatchString(req.IncidentTable) {
+		return fmt.Errorf("malformed table name %q", req.IncidentTable)
+	}
+
+	m.mu.Lock()
+	var serials []*sapb.IncidentSerial
+	found := false
+	for _, inc := range m.incidents {
+		if inc.incident.SerialTable != req.IncidentTable {
+			continue
+		}
+		found = true
+		for _, serial := range inc.serials {
+			serials = append(serials, proto.Clone(serial).(*sapb.IncidentSerial))
+		}
+	}
+	m.mu.Unlock()
+
+	if !found {
+		return fmt.Errorf("starting db query: incident table %q doesn't exist", req.IncidentTable)
+	}
+	return sendAll(stream, serials)
+}
+
+// pausedIdentifiers returns up to maxPausedIdentifiers of the identifiers
+// currently paused for regID which match. Callers must hold the lock.
+func (m *MemoryStorageAuthority) pausedIdentifiers(regID int64, match func(identifier.ACMEIdentifier) bool) *sapb.Identifiers {
+	resp := &sapb.Identifiers{Identifiers: []*corepb.Identifier{}}
+	for _, p := range m.paused {
+		if len(resp.Identifiers) == maxPausedIdentifiers {
+			break
+		}
+		if p.regID == regID && p.unpausedAt == nil && match(p.ident) {
+			resp.Identifiers = append(resp.Identifiers, p.ident.ToProto())
+		}
+	}
+	return resp
+}
+
+// CheckIdentifiersPaused returns up to 15 of the given identifiers which are
+// currently paused for the given account.
+func (m *MemoryStorageAuthority) CheckIdentifiersPaused(ctx context.Context, req *sapb.PauseRequest) (*sapb.Identifiers, error) {
+	if core.IsAnyNilOrZero(req.RegistrationID, req.Identifiers) {
+		return nil, errIncompleteRequest
+	}
+	err := checkIdentifierTypes(req.Identifiers)
+	if err != nil {
+		return nil, err
+	}
+	idents := identifier.FromProtoSlice(req.Identifiers)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.pausedIdentifiers(req.RegistrationID, func(ident identifier.ACMEIdentifier) bool {
+		return slices.Contains(idents, ident)
+	}), nil
+}
+
+// GetPausedIdentifiers returns up to 15 of the identifiers currently paused for
+// the given account.
+func (m *MemoryStorageAuthority) GetPausedIdentifiers(ctx context.Context, req *sapb.RegistrationID) (*sapb.Identifiers, error) {
+	if core.IsAnyNilOrZero(req.Id) {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.pausedIdentifiers(req.Id, func(identifier.ACMEIdentifier) bool { return true }), nil
+}
+
+// GetRateLimitOverride returns the override of the given limit for the given
+// bucket key, or a NotFound error if there isn't one.
+func (m *MemoryStorageAuthority) GetRateLimitOverride(ctx context.Context, req *sapb.GetRateLimitOverrideRequest) (*sapb.RateLimitOverrideResponse, error) {
+	if core.IsAnyNilOrZero(req, req.LimitEnum, req.BucketKey) {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	override, ok := m.overrides[memOverrideKey{req.LimitEnum, req.BucketKey}]
+	if !ok {
+		return nil, berrors.NotFoundError(
+			"no rate limit override found for limit %d and bucket key %s",
+			req.LimitEnum,
+			req.BucketKey,
+		)
+	}
+	return override.toPB(), nil
+}
+
+// GetEnabledRateLimitOverrides streams all enabled rate limit overrides.
+func (m *MemoryStorageAuthority) GetEnabledRateLimitOverrides(_ *emptypb.Empty, stream grpc.ServerStreamingServer[sapb.RateLimitOverrideResponse]) error {
+	m.mu.Lock()
+	var keys []memOverrideKey
+	for key, override := range m.overrides {
+		if override.enabled {
+			keys = append(keys, key)
+		}
+	}
+	slices.SortFunc(keys, func(a, b memOverrideKey) int {
+		if a.limitEnum != b.limitEnum {
+			return int(a.limitEnum - b.limitEnum)
+		}
+		return strings.Compare(a.bucketKey, b.bucketKey)
+	})
+	var msgs []*sapb.RateLimitOverrideResponse
+	for _, key := range keys {
+		msgs = append(msgs, m.overrides[key].toPB())
+	}
+	m.mu.Unlock()
+
+	return sendAll(stream, msgs)
+}
+
+// GetContactVerifications returns the verification state of each of the
+// contacts with the given SHA-256 hashes. Contacts which have never been
+// verified are omitted from the response.
+func (m *MemoryStorageAuthority) GetContactVerifications(ctx context.Context, req *sapb.ContactHashes) (*sapb.ContactVerifications, error) {
+	if req == nil || len(req.ContactHashes) == 0 {
+		return nil, errIncompleteRequest
+	}
+	if len(req.ContactHashes) > maxContactVerificationBatchSize {
+		return nil, fmt.Errorf("too many contacts: got %d, max %d", len(req.ContactHashes), maxContactVerificationBatchSize)
+	}
+	for _, hash := range req.ContactHashes {
+		if len(hash) != sha256.Size {
+			return nil, fmt.Errorf("invalid contact hash length %d", len(hash))
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var verifications []*sapb.ContactVerification
+	seen := make(map[string]bool)
+	for _, hash := range req.ContactHashes {
+		verification, ok := m.contactVerifications[string(hash)]
+		if !ok || seen[string(hash)] {
+			continue
+		}
+		seen[string(hash)] = true
+		verifications = append(verifications, proto.Clone(verification).(*sapb.ContactVerification))
+	}
+	return &sapb.ContactVerifications{Verifications: verifications}, nil
+}
+
+// GetRegistrationClientIdentity returns the TLS client certificate identity to
+// which the given registration was bound at creation, if any.
+func (m *MemoryStorageAuthority) GetRegistrationClientIdentity(ctx context.Context, req *sapb.RegistrationID) (*sapb.ClientIdentity, error) {
+	if req == nil || req.Id == 0 {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var identity string
+	reg, ok := m.registrations[req.Id]
+	if ok {
+		identity = reg.clientIdentity
+	}
+	return &sapb.ClientIdentity{Identity: identity}, nil
+}
+
+// GetAccountRevocation returns the progress of the revocation of a deactivated
+// account's certificates, or a NotFound error if the account never requested
+// it.
+func (m *MemoryStorageAuthority) GetAccountRevocation(ctx context.Context, req *sapb.RegistrationID) (*sapb.AccountRevocation, error) {
+	if req == nil || req.Id == 0 {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	revocation, ok := m.accountRevocations[req.Id]
+	if !ok {
+		return nil, berrors.NotFoundError("no certificate revocation for registration %d", req.Id)
+	}
+	return proto.Clone(revocation).(*sapb.AccountRevocation), nil
+}
+
+// GetExemptionRequest returns the rate limit exemption request with the given
+// ID, or a NotFound error if there isn't one.
+func (m *MemoryStorageAuthority) GetExemptionRequest(ctx context.Context, req *sapb.ExemptionRequestID) (*sapb.ExemptionRequest, error) {
+	if req == nil || req.Id == 0 {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	er, ok := m.exemptionRequests[req.Id]
+	if !ok {
+		return nil, berrors.NotFoundError("no exemption request with ID %d", req.Id)
+	}
+	return proto.Clone(er).(*sapb.ExemptionRequest), nil
+}
+
+// GetExemptionRequests returns up to the given number of rate limit exemption
+// requests with the given status, oldest first, optionally only those of the
+// given account.
+func (m *MemoryStorageAuthority) GetExemptionRequests(ctx context.Context, req *sapb.GetExemptionRequestsRequest) (*sapb.ExemptionRequests, error) {
+	if core.IsAnyNilOrZero(req, req.Status, req.Limit) {
+		return nil, errIncompleteRequest
+	}
+	if !slices.Contains(exemptionRequestStatuses, req.Status) {
+		return nil, fmt.Errorf("unknown exemption request status %q", req.Status)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var matches []*sapb.ExemptionRequest
+	for _, er := range m.exemptionRequests {
+		if er.Status != req.Status {
+			continue
+		}
+		if req.RegistrationID != 0 && er.RegistrationID != req.RegistrationID {
+			continue
+		}
+		matches = append(matches, er)
+	}
+	slices.SortFunc(matches, func(a, b *sapb.ExemptionRequest) int {
+		if c := a.Created.AsTime().Compare(b.Created.AsTime()); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Id, b.Id)
+	})
+	resp := &sapb.ExemptionRequests{}
+	for _, er := range matches {
+		if int64(len(resp.Requests)) >= req.Limit {
+			break
+		}
+		resp.Requests = append(resp.Requests, proto.Clone(er).(*sapb.ExemptionRequest))
+	}
+	return resp, nil
+}
+
+// GetValidationFailures returns the number of failed validation attempts
+// recorded for the given authorization, and when the last of them was made.
+func (m *MemoryStorageAuthority) GetValidationFailures(ctx context.Context, req *sapb.AuthorizationID2) (*sapb.ValidationFailures, error) {
+	if req == nil || req.Id == 0 {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	failures, ok := m.validationFailures[req.Id]
+	if !ok {
+		return &sapb.ValidationFailures{}, nil
+	}
+	return proto.Clone(failures).(*sapb.ValidationFailures), nil
+}
+
+// GetAuthzCAARecheck returns the time of the given authorization's most recent
+// successful background CAA recheck, if it has had one.
+func (m *MemoryStorageAuthority) GetAuthzCAARecheck(ctx context.Context, req *sapb.AuthorizationID2) (*sapb.AuthzCAARecheck, error) {
+	if req == nil || req.Id == 0 {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rechecked, ok := m.authzCAARechecks[req.Id]
+	if !ok {
+		return &sapb.AuthzCAARecheck{}, nil
+	}
+	return &sapb.AuthzCAARecheck{Rechecked: timestamppb.New(rechecked)}, nil
+}
+
+// AddBlockedKey blocks the key with the given hash. Blocking a key which is
+// already blocked succeeds.
+func (m *MemoryStorageAuthority) AddBlockedKey(ctx context.Context, req *sapb.AddBlockedKeyRequest) (*emptypb.Empty, error) {
+	if core.IsAnyNilOrZero(req.KeyHash, req.Added, req.Source) {
+		return nil, errIncompleteRequest
+	}
+	if !slices.Contains(blockedKeySources, req.Source) {
+		return nil, errors.New("unknown source")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.blockedKeys[string(req.KeyHash)] = true
+	return &emptypb.Empty{}, nil
+}
+
+// AddCertificate stores an issued certificate, returning an error if it is a
+// duplicate.
+func (m *MemoryStorageAuthority) AddCertificate(ctx context.Context, req *sapb.AddCertificateRequest) (*emptypb.Empty, error) {
+	if core.IsAnyNilOrZero(req.Der, req.RegID, req.Issued) {
+		return nil, errIncompleteRequest
+	}
+	parsed, err := x509.ParseCertificate(req.Der)
+	if err != nil {
+		return nil, err
+	}
+	serial := core.SerialToString(parsed.SerialNumber)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.certificates[serial]
+	if ok {
+		return nil, berrors.DuplicateError("cannot add a duplicate cert")
+	}
+	m.certificates[serial] = &corepb.Certificate{
+		RegistrationID: req.RegID,
+		Serial:         serial,
+		Digest:         core.Fingerprint256(req.Der),
+		Der:            slices.Clone(req.Der),
+		Issued:         timestamppb.New(req.Issued.AsTime()),
+		Expires:        timestamppb.New(parsed.NotAfter),
+	}
+	m.fqdnSets = append(m.fqdnSets, &memFQDNSet{
+		setHash: core.HashIdentifiers(identifier.FromCert(parsed)),
+		serial:  serial,
+		issued:  parsed.NotBefore,
+		expires: parsed.NotAfter,
+	})
+	return &emptypb.Empty{}, nil
+}
+
+// AddPrecertificate stores a linting precertificate, along with a status for
+// it and the hash of its public key, returning an error if it is a duplicate.
+func (m *MemoryStorageAuthority) AddPrecertificate(ctx context.Context, req *sapb.AddCertificateRequest) (*emptypb.Empty, error) {
+	if core.IsAnyNilOrZero(req.Der, req.RegID, req.IssuerNameID, req.Issued) {
+		return nil, errIncompleteRequest
+	}
+	parsed, err := x509.ParseCertificate(req.Der)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.RawSubjectPublicKeyInfo == nil {
+		return nil, errors.New("certificate has a nil RawSubjectPublicKeyInfo")
+	}
+	serial := core.SerialToString(parsed.SerialNumber)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.precertificates[serial]
+	if ok {
+		return nil, berrors.DuplicateError("cannot add a duplicate cert")
+	}
+	_, ok = m.certificateStatuses[serial]
+	if ok {
+		return nil, fmt.Errorf("certificate status with serial %q already exists", serial)
+	}
+	m.precertificates[serial] = &corepb.Certificate{
+		RegistrationID: req.RegID,
+		Serial:         serial,
+		Der:            slices.Clone(req.Der),
+		Issued:         timestamppb.New(req.Issued.AsTime()),
+		Expires:        timestamppb.New(parsed.NotAfter),
+	}
+	status := core.OCSPStatusGood
+	if req.OcspNotReady {
+		status = core.OCSPStatusNotReady
+	}
+	m.certificateStatuses[serial] = &corepb.CertificateStatus{
+		Serial:                serial,
+		Status:                string(status),
+		OcspLastUpdated:       timestamppb.New(m.clk.Now()),
+		RevokedDate:           timestamppb.New(time.Time{}),
+		LastExpirationNagSent: timestamppb.New(time.Time{}),
+		NotAfter:              timestamppb.New(parsed.NotAfter),
+		IssuerID:              req.IssuerNameID,
+	}
+	keyHash := sha256.Sum256(parsed.RawSubjectPublicKeyInfo)
+	m.keyHashes = append(m.keyHashes, &memKeyHash{
+		keyHash:  keyHash[:],
+		notAfter: parsed.NotAfter,
+		serial:   serial,
+	})
+	return &emptypb.Empty{}, nil
+}
+
+// SetCertificateStatusReady changes the status of the certificate with the
+// given serial from not ready to good, returning an error if it isn't
+// currently not ready.
+func (m *MemoryStorageAuthority) SetCertificateStatusReady(ctx context.Context, req *sapb.Serial) (*emptypb.Empty, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	status, ok := m.certificateStatuses[req.Serial]
+	if !ok || status.Status != string(core.OCSPStatusNotReady) {
+		return nil, errors.New("failed to set certificate status to ready")
+	}
+	status.Status = string(core.OCSPStatusGood)
+	return &emptypb.Empty{}, nil
+}
+
+// AddSerial records the generation of a serial number.
+func (m *MemoryStorageAuthority) AddSerial(ctx context.Context, req *sapb.AddSerialRequest) (*emptypb.Empty, error) {
+	if core.IsAnyNilOrZero(req.Serial, req.RegID, req.Created, req.Expires) {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.serials[req.Serial]
+	if ok {
+		return nil, berrors.DuplicateError("serial %q already exists", req.Serial)
+	}
+	m.serials[req.Serial] = &memSerial{
+		regID:   req.RegID,
+		created: req.Created.AsTime(),
+		expires: req.Expires.AsTime(),
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// DeactivateAuthorization2 deactivates a currently valid or pending
+// authorization. Deactivating any other authorization is a no-op.
+func (m *MemoryStorageAuthority) DeactivateAuthorization2(ctx context.Context, req *sapb.AuthorizationID2) (*emptypb.Empty, error) {
+	if req.Id == 0 {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	authz, ok := m.authzs[req.Id]
+	if ok && (authz.status == core.StatusValid || authz.status == core.StatusPending) {
+		authz.status = core.StatusDeactivated
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// DeactivateRegistration deactivates a currently valid registration.
+func (m *MemoryStorageAuthority) DeactivateRegistration(ctx context.Context, req *sapb.DeactivateRegistrationRequest) (*corepb.Registration, error) {
+	if req == nil || req.RegistrationID == 0 {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	reg, ok := m.registrations[req.RegistrationID]
+	if !ok || reg.reg.Status != string(core.StatusValid) {
+		return nil, berrors.NotFoundError("no active account with id %d", req.RegistrationID)
+	}
+	reg.reg.Status = string(core.StatusDeactivated)
+	if req.RevokeCertificates {
+		m.accountRevocations[req.RegistrationID] = &sapb.AccountRevocation{
+			RegistrationID: req.RegistrationID,
+			Requested:      timestamppb.New(m.clk.Now()),
+		}
+	}
+	return proto.Clone(reg.reg).(*corepb.Registration), nil
+}
+
+// FinalizeAuthorization2 moves a pending authorization to either the valid or
+// invalid status.
+func (m *MemoryStorageAuthority) FinalizeAuthorization2(ctx context.Context, req *sapb.FinalizeAuthorizationRequest) (*emptypb.Empty, error) {
+	if core.IsAnyNilOrZero(req.Status, req.Attempted, req.Id, req.Expires) {
+		return nil, errIncompleteRequest
+	}
+	if req.Status != string(core.StatusValid) && req.Status != string(core.StatusInvalid) {
+		return nil, berrors.InternalServerError("authorization must have status valid or invalid")
+	}
+
+	// Round-trip the validation records and error through their in-memory
+	// types, as the SQL SA does on its way to and from JSON.
+	var records []*corepb.ValidationRecord
+	for _, recordPB := range req.ValidationRecords {
+		record, err := bgrpc.PBToValidationRecord(recordPB)
+		if err != nil {
+			return nil, err
+		}
+		recordPB, err = bgrpc.ValidationRecordToPB(record)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, recordPB)
+	}
+	var validationError *corepb.ProblemDetails
+	if req.ValidationError != nil {
+		prob, err := bgrpc.PBToProblemDetails(req.ValidationError)
+		if err != nil {
+			return nil, err
+		}
+		validationError, err = bgrpc.ProblemDetailsToPB(prob)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	authz, ok := m.authzs[req.Id]
+	if !ok || authz.status != core.StatusPending {
+		return nil, berrors.NotFoundError("no pending authorization with id %d", req.Id)
+	}
+	authz.status = core.AcmeStatus(req.Status)
+	authz.attempted = req.Attempted
+	authz.attemptedAt = time.Time{}
+	if !core.IsAnyNilOrZero(req.AttemptedAt) {
+		authz.attemptedAt = req.AttemptedAt.AsTime()
+	}
+	authz.validationRecords = records
+	authz.validationError = validationError
+	authz.expires = req.Expires.AsTime()
+	if !authz.attemptedAt.IsZero() {
+		for _, p := range req.Perspectives {
+			m.perspectives = append(m.perspectives, &memValidationPerspective{
+				authzID:     req.Id,
+				attemptedAt: authz.attemptedAt,
+				perspective: p.Perspective,
+				rir:         p.Rir,
+				passed:      p.Passed,
+				latency:     p.Latency.AsDuration().Truncate(time.Millisecond),
+			})
+		}
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// SetOrderProcessing marks an order as having begun processing, and records
+// the hash of its CSR, returning an OrderNotReady error if it already has.
+func (m *MemoryStorageAuthority) SetOrderProcessing(ctx context.Context, req *sapb.SetOrderProcessingRequest) (*emptypb.Empty, error) {
+	if req.Id == 0 {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	order, ok := m.orders[req.Id]
+	if !ok || order.beganProcessing {
+		return nil, berrors.OrderNotReadyError("Order was already processing. This may indicate your client finalized the same order multiple times, possibly due to a client bug.")
+	}
+	order.beganProcessing = true
+	order.csrHash = slices.Clone(req.CsrHash)
+	return &emptypb.Empty{}, nil
+}
+
+// SetOrderError sets the error of an order.
+func (m *MemoryStorageAuthority) SetOrderError(ctx context.Context, req *sapb.SetOrderErrorRequest) (*emptypb.Empty, error) {
+	if req.Id == 0 || req.Error == nil {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	order, ok := m.orders[req.Id]
+	if !ok {
+		return nil, berrors.InternalServerError("no order updated with new error field")
+	}
+	order.err = proto.Clone(req.Error).(*corepb.ProblemDetails)
+	return &emptypb.Empty{}, nil
+}
+
+// FinalizeOrder records the serial of the certificate issued for an order
+// which has begun processing, and stops it from being reused.
+func (m *MemoryStorageAuthority) FinalizeOrder(ctx context.Context, req *sapb.FinalizeOrderRequest) (*emptypb.Empty, error) {
+	if req.Id == 0 || req.CertificateSerial == "" {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	order, ok := m.orders[req.Id]
+	if !ok || !order.beganProcessing {
+		return nil, berrors.InternalServerError("no order updated for finalization")
+	}
+	remaining := slices.DeleteFunc(slices.Clone(m.orderFQDNSets), func(set *memOrderFQDNSet) bool {
+		return set.orderID == req.Id
+	})
+	if len(remaining) == len(m.orderFQDNSets) {
+		return nil, berrors.InternalServerError("No orderFQDNSet exists to delete")
+	}
+	m.orderFQDNSets = remaining
+	order.certSerial = req.CertificateSerial
+	for _, replacement := range m.replacementOrders {
+		if replacement.orderID == req.Id {
+			replacement.replaced = true
+			break
+		}
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// NewOrderAndAuthzs adds the given authorizations, and then an order which has
+// both them and the given existing authorizations.
+func (m *MemoryStorageAuthority) NewOrderAndAuthzs(ctx context.Context, req *sapb.NewOrderAndAuthzsRequest) (*corepb.Order, error) {
+	if req.NewOrder == nil {
+		return nil, errIncompleteRequest
+	}
+	for _, authz := range req.NewAuthzs {
+		if authz.RegistrationID != req.NewOrder.RegistrationID {
+			return nil, errors.New("new order and authzs must all be associated with same account")
+		}
+	}
+
+	var newAuthzs []*memAuthz
+	for _, authz := range req.NewAuthzs {
+		_, err := base64.RawURLEncoding.DecodeString(authz.Token)
+		if err != nil {
+			return nil, err
+		}
+		newAuthzs = append(newAuthzs, &memAuthz{
+			ident:      identifier.FromProto(authz.Identifier),
+			regID:      authz.RegistrationID,
+			profile:    req.NewOrder.CertificateProfileName,
+			status:     core.StatusPending,
+			expires:    authz.Expires.AsTime(),
+			challTypes: orderedChallTypes(authz.ChallengeTypes),
+			token:      authz.Token,
+		})
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	allAuthzIDs := slices.Clone(req.NewOrder.V2Authorizations)
+	for i, authz := range newAuthzs {
+		authz.id = m.lastAuthzID + int64(i) + 1
+		allAuthzIDs = append(allAuthzIDs, authz.id)
+	}
+	created := m.clk.Now()
+	res := &corepb.Order{
+		Id:                     m.lastOrderID + 1,
+		Created:                timestamppb.New(created),
+		RegistrationID:         req.NewOrder.RegistrationID,
+		Expires:                req.NewOrder.Expires,
+		Identifiers:            req.NewOrder.Identifiers,
+		V2Authorizations:       allAuthzIDs,
+		BeganProcessing:        false,
+		CertificateProfileName: req.NewOrder.CertificateProfileName,
+		Replaces:               req.NewOrder.Replaces,
+	}
+
+	// Work out the order's status before storing anything, so that nothing is
+	// stored if it can't be.
+	status, err := statusForOrder(res, append(m.authzsByID(req.NewOrder.V2Authorizations), newAuthzs...), m.clk.Now())
+	if err != nil {
+		return nil, err
+	}
+	res.Status = status
+
+	for _, authz := range newAuthzs {
+		m.authzs[authz.id] = authz
+	}
+	m.lastAuthzID += int64(len(newAuthzs))
+	m.lastOrderID++
+	m.orders[res.Id] = &memOrder{
+		id:       res.Id,
+		regID:    req.NewOrder.RegistrationID,
+		expires:  req.NewOrder.Expires.AsTime(),
+		created:  created,
+		profile:  req.NewOrder.CertificateProfileName,
+		replaces: req.NewOrder.Replaces,
+		authzIDs: slices.Clone(allAuthzIDs),
+	}
+	m.orderFQDNSets = append(m.orderFQDNSets, &memOrderFQDNSet{
+		setHash: core.HashIdentifiers(identifier.FromProtoSlice(req.NewOrder.Identifiers)),
+		orderID: res.Id,
+		regID:   req.NewOrder.RegistrationID,
+		expires: req.NewOrder.Expires.AsTime(),
+	})
+	if req.NewOrder.ReplacesSerial != "" {
+		m.replacementOrders[req.NewOrder.ReplacesSerial] = &memReplacementOrder{
+			orderID:      res.Id,
+			orderExpires: req.NewOrder.Expires.AsTime(),
+		}
+	}
+	return res, nil
+}
+
+// NewRegistration stores a new registration.
+func (m *MemoryStorageAuthority) NewRegistration(ctx context.Context, req *corepb.Registration) (*corepb.Registration, error) {
+	if len(req.Key) == 0 {
+		return nil, errIncompleteRequest
+	}
+	sha, err := keyDigest(req.Key)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.ClientIdentity) > 255 {
+		return nil, berrors.MalformedError("client identity is too long")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, reg := range m.registrations {
+		if reg.keyDigest == sha {
+			return nil, berrors.DuplicateError("key is already in use for a different account")
+		}
+	}
+	m.lastRegID++
+	reg := &memRegistration{
+		reg: &corepb.Registration{
+			Id:        m.lastRegID,
+			Key:       slices.Clone(req.Key),
+			Agreement: req.Agreement,
+			CreatedAt: timestamppb.New(m.clk.Now().UTC()),
+			Status:    req.Status,
+		},
+		keyDigest:      sha,
+		clientIdentity: req.ClientIdentity,
+	}
+	m.registrations[reg.reg.Id] = reg
+	return proto.Clone(reg.reg).(*corepb.Registration), nil
+}
+
+// revokedCertificate returns the revokedCertificates entry for the
+// revocation requested by req, which must specify a shard. Callers must hold
+// the lock.
+func (m *MemoryStorageAuthority) revokedCertificate(req *sapb.RevokeCertificateRequest, revokedDate time.Time) (*memRevokedCert, error) {
+	serial, ok := m.serials[req.Serial]
+	if !ok {
+		return nil, fmt.Errorf("retrieving revoked certificate expiration: serial %q not found", req.Serial)
+	}
+	return &memRevokedCert{
+		issuerID:     req.IssuerID,
+		shardIdx:     req.ShardIdx,
+		revokedDate:  revokedDate,
+		reason:       req.Reason,
+		notAfterHour: serial.expires.Add(time.Hour).Truncate(time.Hour),
+	}, nil
+}
+
+// RevokeCertificate revokes the certificate with the given serial, if it isn't
+// already revoked. If the request specifies a CRL shard, it also adds the
+// certificate to that shard.
+func (m *MemoryStorageAuthority) RevokeCertificate(ctx context.Context, req *sapb.RevokeCertificateRequest) (*emptypb.Empty, error) {
+	if core.IsAnyNilOrZero(req.Serial, req.IssuerID, req.Date) {
+		return nil, errIncompleteRequest
+	}
+	revokedDate := req.Date.AsTime()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	status, ok := m.certificateStatuses[req.Serial]
+	if !ok || status.Status == string(core.OCSPStatusRevoked) {
+		return nil, berrors.AlreadyRevokedError("no certificate with serial %s and status other than %s", req.Serial, string(core.OCSPStatusRevoked))
+	}
+	var rc *memRevokedCert
+	if req.ShardIdx != 0 {
+		var err error
+		rc, err = m.revokedCertificate(req, revokedDate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	status.Status = string(core.OCSPStatusRevoked)
+	status.RevokedReason = req.Reason
+	status.RevokedDate = timestamppb.New(revokedDate)
+	status.OcspLastUpdated = timestamppb.New(revokedDate)
+	if rc != nil {
+		m.revokedCerts[req.Serial] = rc
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// UpdateRevokedCertificate changes the revocation reason of an already revoked
+// certificate to keyCompromise, if it was revoked at the given backdate for a
+// different reason.
+func (m *MemoryStorageAuthority) UpdateRevokedCertificate(ctx context.Context, req *sapb.RevokeCertificateRequest) (*emptypb.Empty, error) {
+	if core.IsAnyNilOrZero(req.Serial, req.IssuerID, req.Date, req.Backdate) {
+		return nil, errIncompleteRequest
+	}
+	if req.Reason != ocsp.KeyCompromise {
+		return nil, fmt.Errorf("cannot update revocation for any reason other than keyCompromise (1); got: %d", req.Reason)
+	}
+	revokedDate := req.Backdate.AsTime()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	status, ok := m.certificateStatuses[req.Serial]
+	if !ok || status.Status != string(core.OCSPStatusRevoked) || status.RevokedReason == ocsp.KeyCompromise ||
+		!status.RevokedDate.AsTime().Equal(revokedDate) {
+		return nil, berrors.InternalServerError("no certificate with serial %s and revoked reason other than keyCompromise", req.Serial)
+	}
+	rc, ok := m.revokedCerts[req.Serial]
+	if req.ShardIdx != 0 && !ok {
+		var err error
+		rc, err = m.revokedCertificate(req, revokedDate)
+		if err != nil {
+			return nil, err
+		}
+		m.revokedCerts[req.Serial] = rc
+	}
+
+	status.RevokedReason = ocsp.KeyCompromise
+	status.OcspLastUpdated = timestamppb.New(req.Date.AsTime())
+	if req.ShardIdx != 0 {
+		rc.reason = ocsp.KeyCompromise
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// LeaseCRLShard leases a CRL shard of the given issuer until the given time. If
+// the request names a specific shard, it returns an error if that shard is
+// already leased. Otherwise, it leases the oldest unleased shard in the range.
+func (m *MemoryStorageAuthority) LeaseCRLShard(ctx context.Context, req *sapb.LeaseCRLShardRequest) (*sapb.LeaseCRLShardResponse, error) {
+	if core.IsAnyNilOrZero(req.Until, req.IssuerNameID) {
+		return nil, errIncompleteRequest
+	}
+	if req.Until.AsTime().Before(m.clk.Now()) {
+		return nil, fmt.Errorf("lease timestamp must be in the future, got %q", req.Until.AsTime())
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if req.MinShardIdx == req.MaxShardIdx {
+		key := memCRLShardKey{req.IssuerNameID, req.MinShardIdx}
+		shard, ok := m.crlShards[key]
+		if ok && shard.leasedUntil.After(m.clk.Now()) {
+			return nil, fmt.Errorf("leasing specific shard: shard %d for issuer %d already leased", req.MinShardIdx, req.IssuerNameID)
+		}
+		if !ok {
+			shard = &memCRLShard{}
+			m.crlShards[key] = shard
+		}
+		shard.leasedUntil = req.Until.AsTime()
+		return &sapb.LeaseCRLShardResponse{IssuerNameID: req.IssuerNameID, ShardIdx: req.MinShardIdx}, nil
+	}
+
+	// Shards which have never been leased are older than any other, so lease
+	// one of them, chosen at random, if there are any.
+	var missing []int64
+	for idx := req.MinShardIdx; idx <= req.MaxShardIdx; idx++ {
+		_, ok := m.crlShards[memCRLShardKey{req.IssuerNameID, idx}]
+		if !ok {
+			missing = append(missing, idx)
+		}
+	}
+	if len(missing) > 0 {
+		idx := missing[rand.IntN(len(missing))]
+		m.crlShards[memCRLShardKey{req.IssuerNameID, idx}] = &memCRLShard{leasedUntil: req.Until.AsTime()}
+		return &sapb.LeaseCRLShardResponse{IssuerNameID: req.IssuerNameID, ShardIdx: idx}, nil
+	}
+
+	var oldest *memCRLShard
+	var oldestIdx int64
+	for idx := req.MinShardIdx; idx <= req.MaxShardIdx; idx++ {
+		shard := m.crlShards[memCRLShardKey{req.IssuerNameID, idx}]
+		if shard.leasedUntil.After(m.clk.Now()) {
+			continue
+		}
+		if oldest == nil ||
+			(oldest.thisUpdate != nil && shard.thisUpdate == nil) ||
+			(oldest.thisUpdate != nil && shard.thisUpdate.Before(*oldest.thisUpdate)) {
+			oldest = shard
+			oldestIdx = idx
+		}
+	}
+	if oldest == nil {
+		return nil, fmt.Errorf("leasing oldest shard: issuer %d has no unleased shards in range %d-%d", req.IssuerNameID, req.MinShardIdx, req.MaxShardIdx)
+	}
+	oldest.leasedUntil = req.Until.AsTime()
+	return &sapb.LeaseCRLShardResponse{IssuerNameID: req.IssuerNameID, ShardIdx: oldestIdx}, nil
+}
+
+// UpdateCRLShard updates the thisUpdate and nextUpdate timestamps of a CRL
+// shard, and the digest of its entries if one is given, and ends its lease. It rejects the update if it would move thisUpdate
+// backwards.
+func (m *MemoryStorageAuthority) UpdateCRLShard(ctx context.Context, req *sapb.UpdateCRLShardRequest) (*emptypb.Empty, error) {
+	if core.IsAnyNilOrZero(req.IssuerNameID, req.ThisUpdate) {
+		return nil, errIncompleteRequest
+	}
+	if len(req.EntriesDigest) > 0 && len(req.EntriesDigest) != sha256.Size {
+		return nil, fmt.Errorf("invalid entries digest length %d", len(req.EntriesDigest))
+	}
+	thisUpdate := req.ThisUpdate.AsTime()
+	var nextUpdate *time.Time
+	if req.NextUpdate != nil {
+		nut := req.NextUpdate.AsTime()
+		nextUpdate = &nut
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	shard, ok := m.crlShards[memCRLShardKey{req.IssuerNameID, req.ShardIdx}]
+	if !ok || (shard.thisUpdate != nil && shard.thisUpdate.After(thisUpdate)) {
+		return nil, fmt.Errorf("unable to update shard %d for issuer %d; possibly because shard exists", req.ShardIdx, req.IssuerNameID)
+	}
+	shard.thisUpdate = &thisUpdate
+	shard.nextUpdate = nextUpdate
+	shard.leasedUntil = thisUpdate
+	if len(req.EntriesDigest) > 0 {
+		shard.entriesDigest = slices.Clone(req.EntriesDigest)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// PauseIdentifiers pauses the given identifiers for the given account. An
+// identifier which is already paused is left alone, and one which was unpaused
+// is paused again unless it was unpaused less than two weeks ago.
+func (m *MemoryStorageAuthority) PauseIdentifiers(ctx context.Context, req *sapb.PauseRequest) (*sapb.PauseIdentifiersResponse, error) {
+	if core.IsAnyNilOrZero(req.RegistrationID, req.Identifiers) {
+		return nil, errIncompleteRequest
+	}
+	err := checkIdentifierTypes(req.Identifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.clk.Now()
+	response := &sapb.PauseIdentifiersResponse{}
+	for _, ident := range identifier.FromProtoSlice(req.Identifiers) {
+		i := slices.IndexFunc(m.paused, func(p *memPaused) bool {
+			return p.regID == req.RegistrationID && p.ident == ident
+		})
+		switch {
+		case i == -1:
+			m.paused = append(m.paused, &memPaused{
+				regID:    req.RegistrationID,
+				ident:    ident,
+				pausedAt: now.Truncate(time.Second),
+			})
+			response.Paused++
+
+		case m.paused[i].unpausedAt == nil || m.paused[i].pausedAt.After(*m.paused[i].unpausedAt):
+			// Already paused.
+
+		case m.paused[i].unpausedAt.After(now.Add(-14 * 24 * time.Hour)):
+			// Unpaused less than two weeks ago.
+
+		default:
+			m.paused[i].pausedAt = now.Truncate(time.Second)
+			m.paused[i].unpausedAt = nil
+			response.Repaused++
+		}
+	}
+	return response, nil
+}
+
+// UnpauseAccount unpauses up to 50,000 of the identifiers paused for the given
+// account, and returns how many it unpaused.
+func (m *MemoryStorageAuthority) UnpauseAccount(ctx context.Context, req *sapb.RegistrationID) (*sapb.Count, error) {
+	if core.IsAnyNilOrZero(req.Id) {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.clk.Now()
+	total := &sapb.Count{}
+	for _, p := range m.paused {
+		if total.Count == unpause.MaxBatches*unpause.BatchSize {
+			break
+		}
+		if p.regID == req.Id && p.unpausedAt == nil {
+			p.unpausedAt = &now
+			total.Count++
+		}
+	}
+	return total, nil
+}
+
+// AddRateLimitOverride adds or updates a rate limit override. A new override
+// is enabled, and an updated one keeps its existing state.
+func (m *MemoryStorageAuthority) AddRateLimitOverride(ctx context.Context, req *sapb.AddRateLimitOverrideRequest) (*sapb.AddRateLimitOverrideResponse, error) {
+	if core.IsAnyNilOrZero(req, req.Override, req.Override.LimitEnum, req.Override.BucketKey, req.Override.Count, req.Override.Burst, req.Override.Period, req.Override.Comment) {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := memOverrideKey{req.Override.LimitEnum, req.Override.BucketKey}
+	existing, ok := m.overrides[key]
+	enabled := !ok || existing.enabled
+	m.overrides[key] = &memOverride{
+		override:  proto.Clone(req.Override).(*sapb.RateLimitOverride),
+		enabled:   enabled,
+		updatedAt: m.clk.Now(),
+	}
+	return &sapb.AddRateLimitOverrideResponse{Inserted: !ok, Enabled: enabled}, nil
+}
+
+// setRateLimitOverride enables or disables a rate limit override, returning a
+// NotFound error if there isn't one.
+func (m *MemoryStorageAuthority) setRateLimitOverride(limitEnum int64, bucketKey string, enabled bool) (*emptypb.Empty, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	override, ok := m.overrides[memOverrideKey{limitEnum, bucketKey}]
+	if !ok {
+		return nil, berrors.NotFoundError(
+			"no rate limit override found for limit %d and bucket key %s",
+			limitEnum,
+			bucketKey,
+		)
+	}
+	if override.enabled != enabled {
+		override.enabled = enabled
+		override.updatedAt = m.clk.Now()
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// DisableRateLimitOverride disables a rate limit override.
+func (m *MemoryStorageAuthority) DisableRateLimitOverride(ctx context.Context, req *sapb.DisableRateLimitOverrideRequest) (*emptypb.Empty, error) {
+	if core.IsAnyNilOrZero(req, req.LimitEnum, req.BucketKey) {
+		return nil, errIncompleteRequest
+	}
+	return m.setRateLimitOverride(req.LimitEnum, req.BucketKey, false)
+}
+
+// EnableRateLimitOverride enables a rate limit override.
+func (m *MemoryStorageAuthority) EnableRateLimitOverride(ctx context.Context, req *sapb.EnableRateLimitOverrideRequest) (*emptypb.Empty, error) {
+	if core.IsAnyNilOrZero(req, req.LimitEnum, req.BucketKey) {
+		return nil, errIncompleteRequest
+	}
+	return m.setRateLimitOverride(req.LimitEnum, req.BucketKey, true)
+}
+
+// SetContactVerification records the verification state of the contact with
+// the given SHA-256 hash, replacing any previous state.
+func (m *MemoryStorageAuthority) SetContactVerification(ctx context.Context, req *sapb.ContactVerification) (*emptypb.Empty, error) {
+	if core.IsAnyNilOrZero(req, req.ContactHash, req.Status) {
+		return nil, errIncompleteRequest
+	}
+	if len(req.ContactHash) != sha256.Size {
+		return nil, fmt.Errorf("invalid contact hash length %d", len(req.ContactHash))
+	}
+	if !slices.Contains(contactVerificationStatuses, req.Status) {
+		return nil, fmt.Errorf("invalid contact verification status %q", req.Status)
+	}
+	reason := req.Reason
+	if len(reason) > 255 {
+		reason = reason[:255]
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.contactVerifications[string(req.ContactHash)] = &sapb.ContactVerification{
+		ContactHash: slices.Clone(req.ContactHash),
+		Status:      req.Status,
+		Reason:      reason,
+		Updated:     timestamppb.New(m.clk.Now()),
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// SetAccountRevocation records the progress of the revocation of a deactivated
+// account's certificates, replacing any progress already recorded.
+func (m *MemoryStorageAuthority) SetAccountRevocation(ctx context.Context, req *sapb.AccountRevocation) (*emptypb.Empty, error) {
+	if core.IsAnyNilOrZero(req, req.RegistrationID, req.Requested) {
+		return nil, errIncompleteRequest
+	}
+	if req.Total < 0 || req.Revoked < 0 || req.Failed < 0 || req.Revoked+req.Failed > req.Total {
+		return nil, fmt.Errorf("invalid certificate revocation progress: %d revoked and %d failed of %d", req.Revoked, req.Failed, req.Total)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	reg, ok := m.registrations[req.RegistrationID]
+	if !ok {
+		return nil, berrors.NotFoundError("registration with ID '%d' not found", req.RegistrationID)
+	}
+	if core.AcmeStatus(reg.reg.Status) != core.StatusDeactivated {
+		return nil, berrors.MalformedError("registration %d is %s, not deactivated", req.RegistrationID, reg.reg.Status)
+	}
+	m.accountRevocations[req.RegistrationID] = proto.Clone(req).(*sapb.AccountRevocation)
+	return &emptypb.Empty{}, nil
+}
+
+// LeaseAccountRevocation leases the longest-requested revocation of a
+// deactivated account's certificates which has neither completed nor is
+// already leased, until the given time. It returns a NotFound error if there
+// is none.
+func (m *MemoryStorageAuthority) LeaseAccountRevocation(ctx context.Context, req *sapb.LeaseAccountRevocationRequest) (*sapb.AccountRevocation, error) {
+	if core.IsAnyNilOrZero(req, req.Until) {
+		return nil, errIncompleteRequest
+	}
+	now := m.clk.Now()
+	if req.Until.AsTime().Before(now) {
+		return nil, fmt.Errorf("lease timestamp must be in the future, got %q", req.Until.AsTime())
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var oldest *sapb.AccountRevocation
+	for regID, revocation := range m.accountRevocations {
+		if revocation.Completed != nil || m.accountRevocationLeases[regID].After(now) {
+			continue
+		}
+		if oldest == nil || revocation.Requested.AsTime().Before(oldest.Requested.AsTime()) {
+			oldest = revocation
+		}
+	}
+	if oldest == nil {
+		return nil, berrors.NotFoundError("no certificate revocation to lease")
+	}
+	m.accountRevocationLeases[oldest.RegistrationID] = req.Until.AsTime()
+	return proto.Clone(oldest).(*sapb.AccountRevocation), nil
+}
+
+// AddExemptionRequest queues a subscriber's request for a rate limit override
+// for review. It returns the request as stored, with its ID and status.
+func (m *MemoryStorageAuthority) AddExemptionRequest(ctx context.Context, req *sapb.ExemptionRequest) (*sapb.ExemptionRequest, error) {
+	if core.IsAnyNilOrZero(req, req.RegistrationID, req.Override, req.Justification) ||
+		core.IsAnyNilOrZero(req.Override.LimitEnum, req.Override.BucketKey, req.Override.Period, req.Override.Count, req.Override.Burst) {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastExemptionRequestID++
+	override := proto.Clone(req.Override).(*sapb.RateLimitOverride)
+	override.Comment = ""
+	er := &sapb.ExemptionRequest{
+		Id:             m.lastExemptionRequestID,
+		RegistrationID: req.RegistrationID,
+		Override:       override,
+		Justification:  req.Justification,
+		Status:         "pending",
+		Created:        timestamppb.New(m.clk.Now().Truncate(time.Second)),
+	}
+	m.exemptionRequests[er.Id] = er
+	return proto.Clone(er).(*sapb.ExemptionRequest), nil
+}
+
+// AddValidationFailure records a failed validation attempt for the given
+// authorization, and the problem it failed with, and returns the number
+// recorded so far.
+func (m *MemoryStorageAuthority) AddValidationFailure(ctx context.Context, req *sapb.AddValidationFailureRequest) (*sapb.ValidationFailures, error) {
+	if core.IsAnyNilOrZero(req, req.Id, req.Challenge, req.Problem) {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	failures, ok := m.validationFailures[req.Id]
+	if !ok {
+		failures = &sapb.ValidationFailures{}
+		m.validationFailures[req.Id] = failures
+	}
+	failures.Count++
+	failures.LastFailure = timestamppb.New(m.clk.Now().Truncate(time.Second))
+	failures.LastChallenge = req.Challenge
+	failures.LastProblem = req.Problem
+	return proto.Clone(failures).(*sapb.ValidationFailures), nil
+}
+
+// SetAuthzCAARecheck records the time of a successful background CAA recheck
+// of the given authorization. An earlier time never replaces a later one.
+func (m *MemoryStorageAuthority) SetAuthzCAARecheck(ctx context.Context, req *sapb.SetAuthzCAARecheckRequest) (*emptypb.Empty, error) {
+	if core.IsAnyNilOrZero(req, req.Id, req.Rechecked) {
+		return nil, errIncompleteRequest
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rechecked := req.Rechecked.AsTime().Truncate(time.Second)
+	if rechecked.After(m.authzCAARechecks[req.Id]) {
+		m.authzCAARechecks[req.Id] = rechecked
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ReviewExemptionRequest records the approval or rejection of a pending rate
+// limit exemption request, and returns the reviewed request. It returns a
+// Duplicate error if the request has already been reviewed.
+func (m *MemoryStorageAuthority) ReviewExemptionRequest(ctx context.Context, req *sapb.ReviewExemptionRequestRequest) (*sapb.ExemptionRequest, error) {
+	if core.IsAnyNilOrZero(req, req.Id, req.Status, req.Reviewer) {
+		return nil, errIncompleteRequest
+	}
+	if req.Status != "approved" && req.Status != "rejected" {
+		return nil, fmt.Errorf("exemption request status %q is not a review outcome", req.Status)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	er, ok := m.exemptionRequests[req.Id]
+	if !ok {
+		return nil, berrors.NotFoundError("no exemption request with ID %d", req.Id)
+	}
+	if er.Status != "pending" {
+		return nil, berrors.DuplicateError("exemption request %d has already been %s", req.Id, er.Status)
+	}
+	er.Status = req.Status
+	er.Reviewed = timestamppb.New(m.clk.Now().Truncate(time.Second))
+	er.Reviewer = req.Reviewer
+	er.ReviewComment = req.Comment
+	return proto.Clone(er).(*sapb.ExemptionRequest), nil
+}
+
+// UpdateRegistrationKey replaces the key of a registration, returning a
+// Duplicate error if the new key belongs to a different registration.
+func (m *MemoryStorageAuthority) UpdateRegistrationKey(ctx context.Context, req *sapb.UpdateRegistrationKeyRequest) (*corepb.Registration, error) {
+	if core.IsAnyNilOrZero(req.RegistrationID, req.Jwk) {
+		return nil, errIncompleteRequest
+	}
+	sha, err := keyDigest(req.Jwk)
+	if err != nil {
+		return nil, fmt.Errorf("computing key digest: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, reg := range m.registrations {
+		if reg.keyDigest == sha && id != req.RegistrationID {
+			return nil, berrors.DuplicateError("key is already in use for a different account")
+		}
+	}
+	reg, ok := m.registrations[req.RegistrationID]
+	if !ok {
+		return nil, berrors.InternalServerError("no registration ID '%d' updated with new jwk", req.RegistrationID)
+	}
+	reg.reg.Key = slices.Clone(req.Jwk)
+	reg.keyDigest = sha
+	return proto.Clone(reg.reg).(*corepb.Registration), nil
+}
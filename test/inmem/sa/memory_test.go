@@ -0,0 +1,725 @@
+package sa
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/jmhodges/clock"
+	"golang.org/x/crypto/ocsp"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/letsencrypt/boulder/core"
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/identifier"
+	"github.com/letsencrypt/boulder/probs"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func setup(t *testing.T) (SA, clock.FakeClock) {
+	fc := clock.NewFake()
+	fc.Set(time.Date(2020, 3, 4, 5, 0, 0, 0, time.UTC))
+	return SA{Impl: NewMemoryStorageAuthority(fc)}, fc
+}
+
+func newJWK(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	jwk, err := jose.JSONWebKey{Key: key.Public()}.MarshalJSON()
+	test.AssertNotError(t, err, "marshaling JWK")
+	return jwk
+}
+
+func newRegistration(t *testing.T, ssa SA) *corepb.Registration {
+	reg, err := ssa.NewRegistration(context.Background(), &corepb.Registration{
+		Key:    newJWK(t),
+		Status: string(core.StatusValid),
+	})
+	test.AssertNotError(t, err, "NewRegistration failed")
+	return reg
+}
+
+// collect receives everything from stream until it ends.
+func collect[T any](stream grpc.ServerStreamingClient[T], err error) ([]*T, error) {
+	if err != nil {
+		return nil, err
+	}
+	var msgs []*T
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return msgs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+}
+
+func TestRegistrations(t *testing.T) {
+	t.Parallel()
+	ssa, _ := setup(t)
+	ctx := context.Background()
+
+	jwk := newJWK(t)
+	reg, err := ssa.NewRegistration(ctx, &corepb.Registration{Key: jwk, Status: string(core.StatusValid)})
+	test.AssertNotError(t, err, "NewRegistration failed")
+	test.AssertEquals(t, reg.Id, int64(1))
+
+	_, err = ssa.NewRegistration(ctx, &corepb.Registration{Key: jwk, Status: string(core.StatusValid)})
+	test.AssertErrorIs(t, err, berrors.Duplicate)
+
+	got, err := ssa.GetRegistrationByKey(ctx, &sapb.JSONWebKey{Jwk: jwk})
+	test.AssertNotError(t, err, "GetRegistrationByKey failed")
+	test.AssertEquals(t, got.Id, reg.Id)
+
+	other := newRegistration(t, ssa)
+	_, err = ssa.UpdateRegistrationKey(ctx, &sapb.UpdateRegistrationKeyRequest{RegistrationID: other.Id, Jwk: jwk})
+	test.AssertErrorIs(t, err, berrors.Duplicate)
+
+	newKey := newJWK(t)
+	_, err = ssa.UpdateRegistrationKey(ctx, &sapb.UpdateRegistrationKeyRequest{RegistrationID: reg.Id, Jwk: newKey})
+	test.AssertNotError(t, err, "UpdateRegistrationKey failed")
+	_, err = ssa.GetRegistrationByKey(ctx, &sapb.JSONWebKey{Jwk: jwk})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+
+	deactivated, err := ssa.DeactivateRegistration(ctx, &sapb.DeactivateRegistrationRequest{RegistrationID: reg.Id})
+	test.AssertNotError(t, err, "DeactivateRegistration failed")
+	test.AssertEquals(t, deactivated.Status, string(core.StatusDeactivated))
+	_, err = ssa.DeactivateRegistration(ctx, &sapb.DeactivateRegistrationRequest{RegistrationID: reg.Id})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+
+	// Only deactivated registrations may have their certificates revoked.
+	_, err = ssa.GetAccountRevocation(ctx, &sapb.RegistrationID{Id: reg.Id})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+	_, err = ssa.LeaseAccountRevocation(ctx, &sapb.LeaseAccountRevocationRequest{Until: timestamppb.New(time.Now().Add(time.Hour))})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+	_, err = ssa.SetAccountRevocation(ctx, &sapb.AccountRevocation{RegistrationID: other.Id, Requested: timestamppb.New(time.Now()), Total: 1})
+	test.AssertErrorIs(t, err, berrors.Malformed)
+	_, err = ssa.SetAccountRevocation(ctx, &sapb.AccountRevocation{RegistrationID: reg.Id, Requested: timestamppb.New(time.Now()), Total: 1, Revoked: 1, Completed: timestamppb.New(time.Now())})
+	test.AssertNotError(t, err, "SetAccountRevocation failed")
+	revocation, err := ssa.GetAccountRevocation(ctx, &sapb.RegistrationID{Id: reg.Id})
+	test.AssertNotError(t, err, "GetAccountRevocation failed")
+	test.AssertEquals(t, revocation.Revoked, int64(1))
+
+	// A revocation requested along with the deactivation is leased by one RA
+	// at a time.
+	revoking := newRegistration(t, ssa)
+	_, err = ssa.DeactivateRegistration(ctx, &sapb.DeactivateRegistrationRequest{RegistrationID: revoking.Id, RevokeCertificates: true})
+	test.AssertNotError(t, err, "DeactivateRegistration failed")
+	leased, err := ssa.LeaseAccountRevocation(ctx, &sapb.LeaseAccountRevocationRequest{Until: timestamppb.New(time.Now().Add(time.Hour))})
+	test.AssertNotError(t, err, "LeaseAccountRevocation failed")
+	test.AssertEquals(t, leased.RegistrationID, revoking.Id)
+	_, err = ssa.LeaseAccountRevocation(ctx, &sapb.LeaseAccountRevocationRequest{Until: timestamppb.New(time.Now().Add(time.Hour))})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+
+	_, err = ssa.GetRegistration(ctx, &sapb.RegistrationID{Id: 100})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+}
+
+func TestOrderLifecycle(t *testing.T) {
+	t.Parallel()
+	ssa, fc := setup(t)
+	ctx := context.Background()
+	reg := newRegistration(t, ssa)
+	expires := fc.Now().Add(time.Hour)
+	idents := identifier.ACMEIdentifiers{identifier.NewDNS("example.com"), identifier.NewDNS("example.net")}
+
+	var newAuthzs []*sapb.NewAuthzRequest
+	for _, ident := range idents {
+		newAuthzs = append(newAuthzs, &sapb.NewAuthzRequest{
+			Identifier:     ident.ToProto(),
+			RegistrationID: reg.Id,
+			Expires:        timestamppb.New(expires),
+			ChallengeTypes: []string{string(core.ChallengeTypeDNS01), string(core.ChallengeTypeHTTP01)},
+			Token:          core.NewToken(),
+		})
+	}
+	order, err := ssa.NewOrderAndAuthzs(ctx, &sapb.NewOrderAndAuthzsRequest{
+		NewOrder: &sapb.NewOrderRequest{
+			RegistrationID: reg.Id,
+			Expires:        timestamppb.New(expires),
+			Identifiers:    idents.ToProtoSlice(),
+			ReplacesSerial: "1337",
+		},
+		NewAuthzs: newAuthzs,
+	})
+	test.AssertNotError(t, err, "NewOrderAndAuthzs failed")
+	test.AssertEquals(t, order.Status, string(core.StatusPending))
+	test.AssertDeepEquals(t, order.V2Authorizations, []int64{1, 2})
+
+	authz, err := ssa.GetAuthorization2(ctx, &sapb.AuthorizationID2{Id: 1})
+	test.AssertNotError(t, err, "GetAuthorization2 failed")
+	test.AssertEquals(t, len(authz.Challenges), 2)
+	test.AssertEquals(t, authz.Challenges[0].Type, string(core.ChallengeTypeHTTP01))
+
+	count, err := ssa.CountPendingAuthorizations2(ctx, &sapb.RegistrationID{Id: reg.Id})
+	test.AssertNotError(t, err, "CountPendingAuthorizations2 failed")
+	test.AssertEquals(t, count.Count, int64(2))
+
+	found, err := ssa.GetOrderForNames(ctx, &sapb.GetOrderForNamesRequest{AcctID: reg.Id, Identifiers: idents.ToProtoSlice()})
+	test.AssertNotError(t, err, "GetOrderForNames failed")
+	test.AssertEquals(t, found.Id, order.Id)
+
+	_, err = ssa.SetOrderProcessing(ctx, &sapb.SetOrderProcessingRequest{Id: order.Id, CsrHash: []byte{1, 2, 3}})
+	test.AssertNotError(t, err, "SetOrderProcessing of a pending order failed")
+	processing, err := ssa.GetOrder(ctx, &sapb.OrderRequest{Id: order.Id})
+	test.AssertNotError(t, err, "GetOrder failed")
+	test.AssertByteEquals(t, processing.CsrHash, []byte{1, 2, 3})
+	_, err = ssa.SetOrderProcessing(ctx, &sapb.SetOrderProcessingRequest{Id: order.Id})
+	test.AssertErrorIs(t, err, berrors.OrderNotReady)
+
+	for _, id := range order.V2Authorizations {
+		_, err = ssa.FinalizeAuthorization2(ctx, &sapb.FinalizeAuthorizationRequest{
+			Id:          id,
+			Status:      string(core.StatusValid),
+			Expires:     timestamppb.New(expires),
+			Attempted:   string(core.ChallengeTypeDNS01),
+			AttemptedAt: timestamppb.New(fc.Now()),
+			Perspectives: []*corepb.ValidationPerspective{
+				{Perspective: "Primary", Passed: true, Latency: durationpb.New(time.Duration(id) * time.Second)},
+				{Perspective: "dc-0-ARIN", Rir: "ARIN", Passed: id == 1, Latency: durationpb.New(time.Second)},
+			},
+		})
+		test.AssertNotError(t, err, "FinalizeAuthorization2 failed")
+	}
+	_, err = ssa.FinalizeAuthorization2(ctx, &sapb.FinalizeAuthorizationRequest{
+		Id:        1,
+		Status:    string(core.StatusValid),
+		Expires:   timestamppb.New(expires),
+		Attempted: string(core.ChallengeTypeDNS01),
+	})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+
+	stats, err := ssa.GetMPICComplianceStats(ctx, &sapb.GetMPICComplianceStatsRequest{
+		AttemptedAfter:  timestamppb.New(fc.Now()),
+		AttemptedBefore: timestamppb.New(fc.Now().Add(time.Second)),
+	})
+	test.AssertNotError(t, err, "GetMPICComplianceStats failed")
+	test.AssertEquals(t, stats.Attempts, int64(2))
+	test.AssertEquals(t, len(stats.Perspectives), 2)
+	test.AssertEquals(t, stats.Perspectives[0].Perspective, "Primary")
+	test.AssertEquals(t, stats.Perspectives[0].Passed, int64(2))
+	test.AssertEquals(t, stats.Perspectives[0].MeanLatency.AsDuration(), 1500*time.Millisecond)
+	test.AssertEquals(t, stats.Perspectives[0].MaxLatency.AsDuration(), 2*time.Second)
+	test.AssertEquals(t, stats.Perspectives[1].Perspective, "dc-0-ARIN")
+	test.AssertEquals(t, stats.Perspectives[1].Passed, int64(1))
+	stats, err = ssa.GetMPICComplianceStats(ctx, &sapb.GetMPICComplianceStatsRequest{
+		AttemptedAfter:  timestamppb.New(fc.Now().Add(time.Second)),
+		AttemptedBefore: timestamppb.New(fc.Now().Add(time.Hour)),
+	})
+	test.AssertNotError(t, err, "GetMPICComplianceStats failed")
+	test.AssertEquals(t, stats.Attempts, int64(0))
+
+	authz, err = ssa.GetAuthorization2(ctx, &sapb.AuthorizationID2{Id: 1})
+	test.AssertNotError(t, err, "GetAuthorization2 failed")
+	test.AssertEquals(t, authz.Status, string(core.StatusValid))
+	test.AssertEquals(t, len(authz.Challenges), 1)
+	test.AssertEquals(t, authz.Challenges[0].Status, string(core.StatusValid))
+
+	valid, err := ssa.GetValidAuthorizations2(ctx, &sapb.GetValidAuthorizationsRequest{
+		RegistrationID: reg.Id,
+		Identifiers:    idents.ToProtoSlice(),
+		ValidUntil:     timestamppb.New(fc.Now()),
+	})
+	test.AssertNotError(t, err, "GetValidAuthorizations2 failed")
+	test.AssertEquals(t, len(valid.Authzs), 2)
+
+	got, err := ssa.GetOrder(ctx, &sapb.OrderRequest{Id: order.Id})
+	test.AssertNotError(t, err, "GetOrder failed")
+	test.AssertEquals(t, got.Status, string(core.StatusProcessing))
+
+	exists, err := ssa.ReplacementOrderExists(ctx, &sapb.Serial{Serial: "1337"})
+	test.AssertNotError(t, err, "ReplacementOrderExists failed")
+	test.Assert(t, exists.Exists, "replacement order should exist")
+
+	_, err = ssa.FinalizeOrder(ctx, &sapb.FinalizeOrderRequest{Id: order.Id, CertificateSerial: "1234"})
+	test.AssertNotError(t, err, "FinalizeOrder failed")
+	got, err = ssa.GetOrder(ctx, &sapb.OrderRequest{Id: order.Id})
+	test.AssertNotError(t, err, "GetOrder failed")
+	test.AssertEquals(t, got.Status, string(core.StatusValid))
+	_, err = ssa.GetOrderForNames(ctx, &sapb.GetOrderForNamesRequest{AcctID: reg.Id, Identifiers: idents.ToProtoSlice()})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+
+	fc.Add(2 * time.Hour)
+	_, err = ssa.GetOrder(ctx, &sapb.OrderRequest{Id: order.Id})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+}
+
+func TestNewOrderAndAuthzsRollback(t *testing.T) {
+	t.Parallel()
+	ssa, fc := setup(t)
+	ctx := context.Background()
+	reg := newRegistration(t, ssa)
+	expires := fc.Now().Add(time.Hour)
+
+	// The order names two identifiers but has only one authorization, so its
+	// status can't be computed and nothing should be stored.
+	_, err := ssa.NewOrderAndAuthzs(ctx, &sapb.NewOrderAndAuthzsRequest{
+		NewOrder: &sapb.NewOrderRequest{
+			RegistrationID: reg.Id,
+			Expires:        timestamppb.New(expires),
+			Identifiers: identifier.ACMEIdentifiers{
+				identifier.NewDNS("example.com"),
+				identifier.NewDNS("example.net"),
+			}.ToProtoSlice(),
+		},
+		NewAuthzs: []*sapb.NewAuthzRequest{{
+			Identifier:     identifier.NewDNS("example.com").ToProto(),
+			RegistrationID: reg.Id,
+			Expires:        timestamppb.New(expires),
+			ChallengeTypes: []string{string(core.ChallengeTypeHTTP01)},
+			Token:          core.NewToken(),
+		}},
+	})
+	test.AssertNotError(t, err, "NewOrderAndAuthzs failed")
+
+	_, err = ssa.NewOrderAndAuthzs(ctx, &sapb.NewOrderAndAuthzsRequest{
+		NewOrder: &sapb.NewOrderRequest{
+			RegistrationID:   reg.Id,
+			Expires:          timestamppb.New(expires),
+			Identifiers:      identifier.ACMEIdentifiers{identifier.NewDNS("example.org")}.ToProtoSlice(),
+			V2Authorizations: []int64{100},
+		},
+		NewAuthzs: []*sapb.NewAuthzRequest{{
+			Identifier:     identifier.NewDNS("example.org").ToProto(),
+			RegistrationID: reg.Id,
+			Expires:        timestamppb.New(expires),
+			ChallengeTypes: []string{string(core.ChallengeTypeHTTP01)},
+			Token:          core.NewToken(),
+		}},
+	})
+	test.AssertErrorIs(t, err, berrors.InternalServer)
+
+	count, err := ssa.CountPendingAuthorizations2(ctx, &sapb.RegistrationID{Id: reg.Id})
+	test.AssertNotError(t, err, "CountPendingAuthorizations2 failed")
+	test.AssertEquals(t, count.Count, int64(1))
+}
+
+func TestCertificatesAndRevocation(t *testing.T) {
+	t.Parallel()
+	ssa, fc := setup(t)
+	ctx := context.Background()
+	reg := newRegistration(t, ssa)
+	serial, cert := test.ThrowAwayCert(t, fc)
+
+	_, err := ssa.AddSerial(ctx, &sapb.AddSerialRequest{
+		RegID:   reg.Id,
+		Serial:  serial,
+		Created: timestamppb.New(fc.Now()),
+		Expires: timestamppb.New(cert.NotAfter),
+	})
+	test.AssertNotError(t, err, "AddSerial failed")
+
+	req := &sapb.AddCertificateRequest{
+		Der:          cert.Raw,
+		RegID:        reg.Id,
+		Issued:       timestamppb.New(fc.Now()),
+		IssuerNameID: 1,
+		OcspNotReady: true,
+	}
+	_, err = ssa.AddPrecertificate(ctx, req)
+	test.AssertNotError(t, err, "AddPrecertificate failed")
+	_, err = ssa.AddPrecertificate(ctx, req)
+	test.AssertErrorIs(t, err, berrors.Duplicate)
+
+	status, err := ssa.GetCertificateStatus(ctx, &sapb.Serial{Serial: serial})
+	test.AssertNotError(t, err, "GetCertificateStatus failed")
+	test.AssertEquals(t, status.Status, string(core.OCSPStatusNotReady))
+	_, err = ssa.SetCertificateStatusReady(ctx, &sapb.Serial{Serial: serial})
+	test.AssertNotError(t, err, "SetCertificateStatusReady failed")
+	_, err = ssa.SetCertificateStatusReady(ctx, &sapb.Serial{Serial: serial})
+	test.AssertError(t, err, "SetCertificateStatusReady of a ready certificate should fail")
+
+	_, err = ssa.AddCertificate(ctx, req)
+	test.AssertNotError(t, err, "AddCertificate failed")
+	got, err := ssa.GetCertificate(ctx, &sapb.Serial{Serial: serial})
+	test.AssertNotError(t, err, "GetCertificate failed")
+	test.AssertEquals(t, got.Digest, core.Fingerprint256(cert.Raw))
+
+	exists, err := ssa.FQDNSetExists(ctx, &sapb.FQDNSetExistsRequest{Identifiers: identifier.FromCert(cert).ToProtoSlice()})
+	test.AssertNotError(t, err, "FQDNSetExists failed")
+	test.Assert(t, exists.Exists, "FQDN set should exist")
+	timestamps, err := ssa.FQDNSetTimestampsForWindow(ctx, &sapb.CountFQDNSetsRequest{
+		Identifiers: identifier.FromCert(cert).ToProtoSlice(),
+		Window:      durationpb.New(time.Hour),
+	})
+	test.AssertNotError(t, err, "FQDNSetTimestampsForWindow failed")
+	test.AssertEquals(t, len(timestamps.Timestamps), 1)
+	fc.Add(2 * time.Hour)
+	timestamps, err = ssa.FQDNSetTimestampsForWindow(ctx, &sapb.CountFQDNSetsRequest{
+		Identifiers: identifier.FromCert(cert).ToProtoSlice(),
+		Window:      durationpb.New(time.Hour),
+	})
+	test.AssertNotError(t, err, "FQDNSetTimestampsForWindow failed")
+	test.AssertEquals(t, len(timestamps.Timestamps), 0)
+
+	serials, err := collect(ssa.GetSerialsByAccount(ctx, &sapb.RegistrationID{Id: reg.Id}))
+	test.AssertNotError(t, err, "GetSerialsByAccount failed")
+	test.AssertEquals(t, len(serials), 1)
+	test.AssertEquals(t, serials[0].Serial, serial)
+
+	// The window includes its start, but not its end.
+	certs, err := collect(ssa.GetCertificatesByExpiry(ctx, &sapb.GetCertificatesByExpiryRequest{
+		ExpiresAfter:  timestamppb.New(cert.NotAfter),
+		ExpiresBefore: timestamppb.New(cert.NotAfter.Add(time.Hour)),
+	}))
+	test.AssertNotError(t, err, "GetCertificatesByExpiry failed")
+	test.AssertEquals(t, len(certs), 1)
+	test.AssertEquals(t, certs[0].Serial, serial)
+	certs, err = collect(ssa.GetCertificatesByExpiry(ctx, &sapb.GetCertificatesByExpiryRequest{
+		ExpiresAfter:  timestamppb.New(cert.NotAfter.Add(-time.Hour)),
+		ExpiresBefore: timestamppb.New(cert.NotAfter),
+	}))
+	test.AssertNotError(t, err, "GetCertificatesByExpiry failed")
+	test.AssertEquals(t, len(certs), 0)
+	_, err = collect(ssa.GetCertificatesByExpiry(ctx, &sapb.GetCertificatesByExpiryRequest{
+		ExpiresAfter:  timestamppb.New(cert.NotAfter),
+		ExpiresBefore: timestamppb.New(cert.NotAfter),
+	}))
+	test.AssertErrorIs(t, err, berrors.Malformed)
+
+	revokedAt := fc.Now()
+	_, err = ssa.RevokeCertificate(ctx, &sapb.RevokeCertificateRequest{
+		Serial:   serial,
+		Reason:   ocsp.Superseded,
+		Date:     timestamppb.New(revokedAt),
+		IssuerID: 1,
+		ShardIdx: 2,
+	})
+	test.AssertNotError(t, err, "RevokeCertificate failed")
+	_, err = ssa.RevokeCertificate(ctx, &sapb.RevokeCertificateRequest{
+		Serial:   serial,
+		Reason:   ocsp.Superseded,
+		Date:     timestamppb.New(revokedAt),
+		IssuerID: 1,
+	})
+	test.AssertErrorIs(t, err, berrors.AlreadyRevoked)
+
+	_, err = ssa.UpdateRevokedCertificate(ctx, &sapb.RevokeCertificateRequest{
+		Serial:   serial,
+		Reason:   ocsp.KeyCompromise,
+		Date:     timestamppb.New(fc.Now()),
+		Backdate: timestamppb.New(revokedAt),
+		IssuerID: 1,
+		ShardIdx: 2,
+	})
+	test.AssertNotError(t, err, "UpdateRevokedCertificate failed")
+
+	revStatus, err := ssa.GetRevocationStatus(ctx, &sapb.Serial{Serial: serial})
+	test.AssertNotError(t, err, "GetRevocationStatus failed")
+	test.AssertEquals(t, revStatus.Status, int64(ocsp.Revoked))
+	test.AssertEquals(t, revStatus.RevokedReason, int64(ocsp.KeyCompromise))
+
+	entries, err := collect(ssa.GetRevokedCertsByShard(ctx, &sapb.GetRevokedCertsByShardRequest{
+		IssuerNameID:  1,
+		ShardIdx:      2,
+		ExpiresAfter:  timestamppb.New(fc.Now()),
+		RevokedBefore: timestamppb.New(fc.Now().Add(time.Second)),
+	}))
+	test.AssertNotError(t, err, "GetRevokedCertsByShard failed")
+	test.AssertEquals(t, len(entries), 1)
+	test.AssertEquals(t, entries[0].Reason, int32(ocsp.KeyCompromise))
+}
+
+func TestPauseIdentifiers(t *testing.T) {
+	t.Parallel()
+	ssa, fc := setup(t)
+	ctx := context.Background()
+	idents := identifier.ACMEIdentifiers{identifier.NewDNS("example.com")}.ToProtoSlice()
+
+	resp, err := ssa.PauseIdentifiers(ctx, &sapb.PauseRequest{RegistrationID: 1, Identifiers: idents})
+	test.AssertNotError(t, err, "PauseIdentifiers failed")
+	test.AssertEquals(t, resp.Paused, int64(1))
+
+	paused, err := ssa.CheckIdentifiersPaused(ctx, &sapb.PauseRequest{RegistrationID: 1, Identifiers: idents})
+	test.AssertNotError(t, err, "CheckIdentifiersPaused failed")
+	test.AssertEquals(t, len(paused.Identifiers), 1)
+
+	count, err := ssa.UnpauseAccount(ctx, &sapb.RegistrationID{Id: 1})
+	test.AssertNotError(t, err, "UnpauseAccount failed")
+	test.AssertEquals(t, count.Count, int64(1))
+
+	// An identifier unpaused less than two weeks ago isn't paused again.
+	resp, err = ssa.PauseIdentifiers(ctx, &sapb.PauseRequest{RegistrationID: 1, Identifiers: idents})
+	test.AssertNotError(t, err, "PauseIdentifiers failed")
+	test.AssertEquals(t, resp.Paused+resp.Repaused, int64(0))
+
+	fc.Add(15 * 24 * time.Hour)
+	resp, err = ssa.PauseIdentifiers(ctx, &sapb.PauseRequest{RegistrationID: 1, Identifiers: idents})
+	test.AssertNotError(t, err, "PauseIdentifiers failed")
+	test.AssertEquals(t, resp.Repaused, int64(1))
+}
+
+func TestRateLimitOverrides(t *testing.T) {
+	t.Parallel()
+	ssa, _ := setup(t)
+	ctx := context.Background()
+	override := &sapb.RateLimitOverride{
+		LimitEnum: 1,
+		BucketKey: "1:1234",
+		Comment:   "test",
+		Period:    durationpb.New(time.Hour),
+		Count:     10,
+		Burst:     10,
+	}
+
+	added, err := ssa.AddRateLimitOverride(ctx, &sapb.AddRateLimitOverrideRequest{Override: override})
+	test.AssertNotError(t, err, "AddRateLimitOverride failed")
+	test.Assert(t, added.Inserted && added.Enabled, "new override should be inserted and enabled")
+
+	_, err = ssa.DisableRateLimitOverride(ctx, &sapb.DisableRateLimitOverrideRequest{LimitEnum: 1, BucketKey: "1:1234"})
+	test.AssertNotError(t, err, "DisableRateLimitOverride failed")
+	added, err = ssa.AddRateLimitOverride(ctx, &sapb.AddRateLimitOverrideRequest{Override: override})
+	test.AssertNotError(t, err, "AddRateLimitOverride failed")
+	test.Assert(t, !added.Inserted && !added.Enabled, "updated override should keep its state")
+	overrides, err := collect(ssa.GetEnabledRateLimitOverrides(ctx, nil))
+	test.AssertNotError(t, err, "GetEnabledRateLimitOverrides failed")
+	test.AssertEquals(t, len(overrides), 0)
+
+	_, err = ssa.EnableRateLimitOverride(ctx, &sapb.EnableRateLimitOverrideRequest{LimitEnum: 1, BucketKey: "1:1234"})
+	test.AssertNotError(t, err, "EnableRateLimitOverride failed")
+	overrides, err = collect(ssa.GetEnabledRateLimitOverrides(ctx, nil))
+	test.AssertNotError(t, err, "GetEnabledRateLimitOverrides failed")
+	test.AssertEquals(t, len(overrides), 1)
+
+	_, err = ssa.GetRateLimitOverride(ctx, &sapb.GetRateLimitOverrideRequest{LimitEnum: 2, BucketKey: "1:1234"})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+}
+
+func TestExemptionRequests(t *testing.T) {
+	t.Parallel()
+	ssa, fc := setup(t)
+	ctx := context.Background()
+	override := &sapb.RateLimitOverride{
+		LimitEnum: 1,
+		BucketKey: "1:1234",
+		Period:    durationpb.New(time.Hour),
+		Count:     10,
+		Burst:     10,
+	}
+
+	first, err := ssa.AddExemptionRequest(ctx, &sapb.ExemptionRequest{RegistrationID: 1, Override: override, Justification: "first"})
+	test.AssertNotError(t, err, "AddExemptionRequest failed")
+	test.AssertEquals(t, first.Status, "pending")
+	fc.Add(time.Minute)
+	_, err = ssa.AddExemptionRequest(ctx, &sapb.ExemptionRequest{RegistrationID: 2, Override: override, Justification: "second"})
+	test.AssertNotError(t, err, "AddExemptionRequest failed")
+
+	pending, err := ssa.GetExemptionRequests(ctx, &sapb.GetExemptionRequestsRequest{Status: "pending", Limit: 10})
+	test.AssertNotError(t, err, "GetExemptionRequests failed")
+	test.AssertEquals(t, len(pending.Requests), 2)
+	test.AssertEquals(t, pending.Requests[0].Justification, "first")
+	pending, err = ssa.GetExemptionRequests(ctx, &sapb.GetExemptionRequestsRequest{Status: "pending", RegistrationID: 2, Limit: 10})
+	test.AssertNotError(t, err, "GetExemptionRequests failed")
+	test.AssertEquals(t, len(pending.Requests), 1)
+	test.AssertEquals(t, pending.Requests[0].Justification, "second")
+
+	reviewed, err := ssa.ReviewExemptionRequest(ctx, &sapb.ReviewExemptionRequestRequest{Id: first.Id, Status: "approved", Reviewer: "admin"})
+	test.AssertNotError(t, err, "ReviewExemptionRequest failed")
+	test.AssertEquals(t, reviewed.Status, "approved")
+	test.AssertEquals(t, reviewed.Reviewer, "admin")
+	_, err = ssa.ReviewExemptionRequest(ctx, &sapb.ReviewExemptionRequestRequest{Id: first.Id, Status: "rejected", Reviewer: "admin"})
+	test.AssertErrorIs(t, err, berrors.Duplicate)
+	_, err = ssa.ReviewExemptionRequest(ctx, &sapb.ReviewExemptionRequestRequest{Id: 99, Status: "rejected", Reviewer: "admin"})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+
+	got, err := ssa.GetExemptionRequest(ctx, &sapb.ExemptionRequestID{Id: first.Id})
+	test.AssertNotError(t, err, "GetExemptionRequest failed")
+	test.AssertEquals(t, got.Status, "approved")
+	pending, err = ssa.GetExemptionRequests(ctx, &sapb.GetExemptionRequestsRequest{Status: "pending", Limit: 10})
+	test.AssertNotError(t, err, "GetExemptionRequests failed")
+	test.AssertEquals(t, len(pending.Requests), 1)
+}
+
+func TestValidationFailures(t *testing.T) {
+	t.Parallel()
+	ssa, fc := setup(t)
+	ctx := context.Background()
+
+	failures, err := ssa.GetValidationFailures(ctx, &sapb.AuthorizationID2{Id: 1})
+	test.AssertNotError(t, err, "GetValidationFailures failed")
+	test.AssertEquals(t, failures.Count, int64(0))
+
+	req := &sapb.AddValidationFailureRequest{
+		Id:        1,
+		Challenge: string(core.ChallengeTypeHTTP01),
+		Problem:   &corepb.ProblemDetails{ProblemType: string(probs.ConnectionProblem), Detail: "connection refused", HttpStatus: 400},
+	}
+	_, err = ssa.AddValidationFailure(ctx, req)
+	test.AssertNotError(t, err, "AddValidationFailure failed")
+	fc.Add(time.Minute)
+	failures, err = ssa.AddValidationFailure(ctx, req)
+	test.AssertNotError(t, err, "AddValidationFailure failed")
+	test.AssertEquals(t, failures.Count, int64(2))
+	test.AssertEquals(t, failures.LastFailure.AsTime(), fc.Now().Truncate(time.Second))
+	test.AssertEquals(t, failures.LastChallenge, string(core.ChallengeTypeHTTP01))
+	test.AssertEquals(t, failures.LastProblem.Detail, "connection refused")
+
+	failures, err = ssa.GetValidationFailures(ctx, &sapb.AuthorizationID2{Id: 2})
+	test.AssertNotError(t, err, "GetValidationFailures failed")
+	test.AssertEquals(t, failures.Count, int64(0))
+}
+
+func TestAuthzCAARecheck(t *testing.T) {
+	t.Parallel()
+	ssa, fc := setup(t)
+	ctx := context.Background()
+
+	recheck, err := ssa.GetAuthzCAARecheck(ctx, &sapb.AuthorizationID2{Id: 1})
+	test.AssertNotError(t, err, "GetAuthzCAARecheck failed")
+	test.Assert(t, recheck.Rechecked == nil, "unexpected recheck time for an authorization never rechecked")
+
+	later := fc.Now().Add(time.Hour)
+	_, err = ssa.SetAuthzCAARecheck(ctx, &sapb.SetAuthzCAARecheckRequest{Id: 1, Rechecked: timestamppb.New(later)})
+	test.AssertNotError(t, err, "SetAuthzCAARecheck failed")
+	_, err = ssa.SetAuthzCAARecheck(ctx, &sapb.SetAuthzCAARecheckRequest{Id: 1, Rechecked: timestamppb.New(fc.Now())})
+	test.AssertNotError(t, err, "SetAuthzCAARecheck failed")
+
+	recheck, err = ssa.GetAuthzCAARecheck(ctx, &sapb.AuthorizationID2{Id: 1})
+	test.AssertNotError(t, err, "GetAuthzCAARecheck failed")
+	test.AssertEquals(t, recheck.Rechecked.AsTime(), later.Truncate(time.Second))
+
+	_, err = ssa.SetAuthzCAARecheck(ctx, &sapb.SetAuthzCAARecheckRequest{Id: 1})
+	test.AssertError(t, err, "SetAuthzCAARecheck without a time should fail")
+}
+
+func TestCRLShards(t *testing.T) {
+	t.Parallel()
+	ssa, fc := setup(t)
+	ctx := context.Background()
+
+	_, err := ssa.GetCRLShard(ctx, &sapb.GetCRLShardRequest{IssuerNameID: 1, ShardIdx: 1})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+
+	_, err = ssa.LeaseCRLShard(ctx, &sapb.LeaseCRLShardRequest{
+		IssuerNameID: 1,
+		MinShardIdx:  1,
+		MaxShardIdx:  1,
+		Until:        timestamppb.New(fc.Now().Add(time.Hour)),
+	})
+	test.AssertNotError(t, err, "LeaseCRLShard failed")
+	shard, err := ssa.GetCRLShard(ctx, &sapb.GetCRLShardRequest{IssuerNameID: 1, ShardIdx: 1})
+	test.AssertNotError(t, err, "GetCRLShard failed")
+	test.Assert(t, shard.ThisUpdate == nil, "unexpected thisUpdate for a shard never generated")
+
+	digest := []byte("0123456789abcdef0123456789abcdef")
+	_, err = ssa.UpdateCRLShard(ctx, &sapb.UpdateCRLShardRequest{
+		IssuerNameID:  1,
+		ShardIdx:      1,
+		ThisUpdate:    timestamppb.New(fc.Now()),
+		EntriesDigest: digest,
+	})
+	test.AssertNotError(t, err, "UpdateCRLShard failed")
+	// An update without a digest leaves the stored digest alone.
+	_, err = ssa.UpdateCRLShard(ctx, &sapb.UpdateCRLShardRequest{
+		IssuerNameID: 1,
+		ShardIdx:     1,
+		ThisUpdate:   timestamppb.New(fc.Now()),
+	})
+	test.AssertNotError(t, err, "UpdateCRLShard failed")
+
+	shard, err = ssa.GetCRLShard(ctx, &sapb.GetCRLShardRequest{IssuerNameID: 1, ShardIdx: 1})
+	test.AssertNotError(t, err, "GetCRLShard failed")
+	test.AssertEquals(t, shard.ThisUpdate.AsTime(), fc.Now())
+	test.AssertByteEquals(t, shard.EntriesDigest, digest)
+}
+
+func TestSearchCertificates(t *testing.T) {
+	t.Parallel()
+	ssa, fc := setup(t)
+	ctx := context.Background()
+	reg := newRegistration(t, ssa)
+
+	var serials []string
+	var certs []*x509.Certificate
+	for range 2 {
+		serial, cert := test.ThrowAwayCert(t, fc)
+		_, err := ssa.AddSerial(ctx, &sapb.AddSerialRequest{
+			RegID:   reg.Id,
+			Serial:  serial,
+			Created: timestamppb.New(fc.Now()),
+			Expires: timestamppb.New(cert.NotAfter),
+		})
+		test.AssertNotError(t, err, "AddSerial failed")
+		_, err = ssa.AddPrecertificate(ctx, &sapb.AddCertificateRequest{
+			Der:          cert.Raw,
+			RegID:        reg.Id,
+			Issued:       timestamppb.New(fc.Now()),
+			IssuerNameID: 1,
+		})
+		test.AssertNotError(t, err, "AddPrecertificate failed")
+		serials = append(serials, serial)
+		certs = append(certs, cert)
+	}
+
+	// Expired certificates are found too.
+	fc.Add(7 * 24 * time.Hour)
+
+	results, err := collect(ssa.SearchCertificates(ctx, &sapb.SearchCertificatesRequest{San: certs[0].DNSNames[0]}))
+	test.AssertNotError(t, err, "SearchCertificates by SAN failed")
+	test.AssertEquals(t, len(results), 1)
+	test.AssertEquals(t, results[0].Serial, serials[0])
+	test.AssertEquals(t, results[0].RegistrationID, reg.Id)
+
+	results, err = collect(ssa.SearchCertificates(ctx, &sapb.SearchCertificatesRequest{San: certs[1].IPAddresses[0].String()}))
+	test.AssertNotError(t, err, "SearchCertificates by IP SAN failed")
+	test.AssertEquals(t, len(results), 1)
+	test.AssertEquals(t, results[0].Serial, serials[1])
+
+	keyHash := sha256.Sum256(certs[1].RawSubjectPublicKeyInfo)
+	results, err = collect(ssa.SearchCertificates(ctx, &sapb.SearchCertificatesRequest{KeyHash: keyHash[:]}))
+	test.AssertNotError(t, err, "SearchCertificates by key hash failed")
+	test.AssertEquals(t, len(results), 1)
+	test.AssertEquals(t, results[0].Serial, serials[1])
+
+	results, err = collect(ssa.SearchCertificates(ctx, &sapb.SearchCertificatesRequest{SerialPrefix: serials[0][:8]}))
+	test.AssertNotError(t, err, "SearchCertificates by serial prefix failed")
+	test.AssertEquals(t, len(results), 1)
+	test.AssertEquals(t, results[0].Serial, serials[0])
+
+	results, err = collect(ssa.SearchCertificates(ctx, &sapb.SearchCertificatesRequest{SerialPrefix: "0", Limit: 1}))
+	test.AssertNotError(t, err, "SearchCertificates with a limit failed")
+	test.AssertEquals(t, len(results), 1)
+
+	_, err = collect(ssa.SearchCertificates(ctx, &sapb.SearchCertificatesRequest{}))
+	test.AssertError(t, err, "SearchCertificates without a search term should fail")
+	_, err = collect(ssa.SearchCertificates(ctx, &sapb.SearchCertificatesRequest{San: "example.com", SerialPrefix: "00"}))
+	test.AssertError(t, err, "SearchCertificates with two search terms should fail")
+	_, err = collect(ssa.SearchCertificates(ctx, &sapb.SearchCertificatesRequest{SerialPrefix: "00%"}))
+	test.AssertError(t, err, "SearchCertificates with a wildcard should fail")
+}
+
+func TestSerialsForIncident(t *testing.T) {
+	t.Parallel()
+	ssa, _ := setup(t)
+	ctx := context.Background()
+	ssa.Impl.(*MemoryStorageAuthority).AddIncident(
+		&sapb.Incident{Id: 1, SerialTable: "incident_foo", Enabled: true},
+		[]*sapb.IncidentSerial{{Serial: "1337"}},
+	)
+
+	serials, err := collect(ssa.SerialsForIncident(ctx, &sapb.SerialsForIncidentRequest{IncidentTable: "incident_foo"}))
+	test.AssertNotError(t, err, "SerialsForIncident failed")
+	test.AssertEquals(t, len(serials), 1)
+
+	incidents, err := ssa.IncidentsForSerial(ctx, &sapb.Serial{Serial: "1337"})
+	test.AssertNotError(t, err, "IncidentsForSerial failed")
+	test.AssertEquals(t, len(incidents.Incidents), 1)
+
+	_, err = collect(ssa.SerialsForIncident(ctx, &sapb.SerialsForIncidentRequest{IncidentTable: "incident_bar"}))
+	test.AssertError(t, err, "nonexistent incident table should fail")
+}
@@ -4,121 +4,381 @@ import (
 	"context"
 	"io"
 
-	corepb "github.com/letsencrypt/boulder/core/proto"
-	"github.com/letsencrypt/boulder/sa"
-	sapb "github.com/letsencrypt/boulder/sa/proto"
-
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
 )
 
-// SA meets the `sapb.StorageAuthorityClient` interface and acts as a
-// wrapper for an inner `sa.SQLStorageAuthority` (which in turn meets
-// the `sapb.StorageAuthorityServer` interface). Only methods used by
-// unit tests need to be implemented.
+// SA meets the `sapb.StorageAuthorityClient` interface and acts as a wrapper
+// for an inner `sapb.StorageAuthorityServer`, such as a
+// `sa.SQLStorageAuthority` or a MemoryStorageAuthority.
 type SA struct {
-	sapb.StorageAuthorityClient
-	Impl *sa.SQLStorageAuthority
+	Impl sapb.StorageAuthorityServer
 }
 
-func (sa SA) NewRegistration(ctx context.Context, req *corepb.Registration, _ ...grpc.CallOption) (*corepb.Registration, error) {
-	return sa.Impl.NewRegistration(ctx, req)
+var _ sapb.StorageAuthorityClient = SA{}
+
+// CountInvalidAuthorizations2 is a wrapper for `sapb.StorageAuthorityServer.CountInvalidAuthorizations2`.
+func (sa SA) CountInvalidAuthorizations2(ctx context.Context, req *sapb.CountInvalidAuthorizationsRequest, _ ...grpc.CallOption) (*sapb.Count, error) {
+	return sa.Impl.CountInvalidAuthorizations2(ctx, req)
 }
 
-func (sa SA) GetRegistration(ctx context.Context, req *sapb.RegistrationID, _ ...grpc.CallOption) (*corepb.Registration, error) {
-	return sa.Impl.GetRegistration(ctx, req)
+// CountPendingAuthorizations2 is a wrapper for `sapb.StorageAuthorityServer.CountPendingAuthorizations2`.
+func (sa SA) CountPendingAuthorizations2(ctx context.Context, req *sapb.RegistrationID, _ ...grpc.CallOption) (*sapb.Count, error) {
+	return sa.Impl.CountPendingAuthorizations2(ctx, req)
 }
 
-func (sa SA) DeactivateRegistration(ctx context.Context, req *sapb.RegistrationID, _ ...grpc.CallOption) (*corepb.Registration, error) {
-	return sa.Impl.DeactivateRegistration(ctx, req)
+// FQDNSetExists is a wrapper for `sapb.StorageAuthorityServer.FQDNSetExists`.
+func (sa SA) FQDNSetExists(ctx context.Context, req *sapb.FQDNSetExistsRequest, _ ...grpc.CallOption) (*sapb.Exists, error) {
+	return sa.Impl.FQDNSetExists(ctx, req)
 }
 
+// FQDNSetTimestampsForWindow is a wrapper for `sapb.StorageAuthorityServer.FQDNSetTimestampsForWindow`.
+func (sa SA) FQDNSetTimestampsForWindow(ctx context.Context, req *sapb.CountFQDNSetsRequest, _ ...grpc.CallOption) (*sapb.Timestamps, error) {
+	return sa.Impl.FQDNSetTimestampsForWindow(ctx, req)
+}
+
+// GetAuthorization2 is a wrapper for `sapb.StorageAuthorityServer.GetAuthorization2`.
 func (sa SA) GetAuthorization2(ctx context.Context, req *sapb.AuthorizationID2, _ ...grpc.CallOption) (*corepb.Authorization, error) {
 	return sa.Impl.GetAuthorization2(ctx, req)
 }
 
+// GetAuthorizations2 is a wrapper for `sapb.StorageAuthorityServer.GetAuthorizations2`.
 func (sa SA) GetAuthorizations2(ctx context.Context, req *sapb.GetAuthorizationsRequest, _ ...grpc.CallOption) (*sapb.Authorizations, error) {
 	return sa.Impl.GetAuthorizations2(ctx, req)
 }
 
+// GetCertificate is a wrapper for `sapb.StorageAuthorityServer.GetCertificate`.
+func (sa SA) GetCertificate(ctx context.Context, req *sapb.Serial, _ ...grpc.CallOption) (*corepb.Certificate, error) {
+	return sa.Impl.GetCertificate(ctx, req)
+}
+
+// GetLintPrecertificate is a wrapper for `sapb.StorageAuthorityServer.GetLintPrecertificate`.
+func (sa SA) GetLintPrecertificate(ctx context.Context, req *sapb.Serial, _ ...grpc.CallOption) (*corepb.Certificate, error) {
+	return sa.Impl.GetLintPrecertificate(ctx, req)
+}
+
+// GetCertificateStatus is a wrapper for `sapb.StorageAuthorityServer.GetCertificateStatus`.
+func (sa SA) GetCertificateStatus(ctx context.Context, req *sapb.Serial, _ ...grpc.CallOption) (*corepb.CertificateStatus, error) {
+	return sa.Impl.GetCertificateStatus(ctx, req)
+}
+
+// GetMPICComplianceStats is a wrapper for `sapb.StorageAuthorityServer.GetMPICComplianceStats`.
+func (sa SA) GetMPICComplianceStats(ctx context.Context, req *sapb.GetMPICComplianceStatsRequest, _ ...grpc.CallOption) (*sapb.MPICComplianceStats, error) {
+	return sa.Impl.GetMPICComplianceStats(ctx, req)
+}
+
+// GetCertificatesByExpiry is a wrapper for `sapb.StorageAuthorityServer.GetCertificatesByExpiry`.
+func (sa SA) GetCertificatesByExpiry(ctx context.Context, req *sapb.GetCertificatesByExpiryRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[corepb.Certificate], error) {
+	return serverStream(ctx, req, sa.Impl.GetCertificatesByExpiry)
+}
+
+// GetMaxExpiration is a wrapper for `sapb.StorageAuthorityServer.GetMaxExpiration`.
+func (sa SA) GetMaxExpiration(ctx context.Context, req *emptypb.Empty, _ ...grpc.CallOption) (*timestamppb.Timestamp, error) {
+	return sa.Impl.GetMaxExpiration(ctx, req)
+}
+
+// GetOrder is a wrapper for `sapb.StorageAuthorityServer.GetOrder`.
+func (sa SA) GetOrder(ctx context.Context, req *sapb.OrderRequest, _ ...grpc.CallOption) (*corepb.Order, error) {
+	return sa.Impl.GetOrder(ctx, req)
+}
+
+// GetOrderForNames is a wrapper for `sapb.StorageAuthorityServer.GetOrderForNames`.
+func (sa SA) GetOrderForNames(ctx context.Context, req *sapb.GetOrderForNamesRequest, _ ...grpc.CallOption) (*corepb.Order, error) {
+	return sa.Impl.GetOrderForNames(ctx, req)
+}
+
+// GetRegistration is a wrapper for `sapb.StorageAuthorityServer.GetRegistration`.
+func (sa SA) GetRegistration(ctx context.Context, req *sapb.RegistrationID, _ ...grpc.CallOption) (*corepb.Registration, error) {
+	return sa.Impl.GetRegistration(ctx, req)
+}
+
+// GetRegistrationByKey is a wrapper for `sapb.StorageAuthorityServer.GetRegistrationByKey`.
+func (sa SA) GetRegistrationByKey(ctx context.Context, req *sapb.JSONWebKey, _ ...grpc.CallOption) (*corepb.Registration, error) {
+	return sa.Impl.GetRegistrationByKey(ctx, req)
+}
+
+// GetRevocationStatus is a wrapper for `sapb.StorageAuthorityServer.GetRevocationStatus`.
+func (sa SA) GetRevocationStatus(ctx context.Context, req *sapb.Serial, _ ...grpc.CallOption) (*sapb.RevocationStatus, error) {
+	return sa.Impl.GetRevocationStatus(ctx, req)
+}
+
+// GetRevocationStatuses is a wrapper for `sapb.StorageAuthorityServer.GetRevocationStatuses`.
+func (sa SA) GetRevocationStatuses(ctx context.Context, req *sapb.Serials, _ ...grpc.CallOption) (*sapb.RevocationStatuses, error) {
+	return sa.Impl.GetRevocationStatuses(ctx, req)
+}
+
+// GetRevokedCerts is a wrapper for `sapb.StorageAuthorityServer.GetRevokedCerts`.
+func (sa SA) GetRevokedCerts(ctx context.Context, req *sapb.GetRevokedCertsRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[corepb.CRLEntry], error) {
+	return serverStream(ctx, req, sa.Impl.GetRevokedCerts)
+}
+
+// GetRevokedCertsByShard is a wrapper for `sapb.StorageAuthorityServer.GetRevokedCertsByShard`.
+func (sa SA) GetRevokedCertsByShard(ctx context.Context, req *sapb.GetRevokedCertsByShardRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[corepb.CRLEntry], error) {
+	return serverStream(ctx, req, sa.Impl.GetRevokedCertsByShard)
+}
+
+// GetSerialMetadata is a wrapper for `sapb.StorageAuthorityServer.GetSerialMetadata`.
+func (sa SA) GetSerialMetadata(ctx context.Context, req *sapb.Serial, _ ...grpc.CallOption) (*sapb.SerialMetadata, error) {
+	return sa.Impl.GetSerialMetadata(ctx, req)
+}
+
+// GetSerialsByAccount is a wrapper for `sapb.StorageAuthorityServer.GetSerialsByAccount`.
+func (sa SA) GetSerialsByAccount(ctx context.Context, req *sapb.RegistrationID, _ ...grpc.CallOption) (grpc.ServerStreamingClient[sapb.Serial], error) {
+	return serverStream(ctx, req, sa.Impl.GetSerialsByAccount)
+}
+
+// GetSerialsByKey is a wrapper for `sapb.StorageAuthorityServer.GetSerialsByKey`.
+func (sa SA) GetSerialsByKey(ctx context.Context, req *sapb.SPKIHash, _ ...grpc.CallOption) (grpc.ServerStreamingClient[sapb.Serial], error) {
+	return serverStream(ctx, req, sa.Impl.GetSerialsByKey)
+}
+
+// SearchCertificates is a wrapper for `sapb.StorageAuthorityServer.SearchCertificates`.
+func (sa SA) SearchCertificates(ctx context.Context, req *sapb.SearchCertificatesRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[sapb.SerialMetadata], error) {
+	return serverStream(ctx, req, sa.Impl.SearchCertificates)
+}
+
+// GetValidAuthorizations2 is a wrapper for `sapb.StorageAuthorityServer.GetValidAuthorizations2`.
 func (sa SA) GetValidAuthorizations2(ctx context.Context, req *sapb.GetValidAuthorizationsRequest, _ ...grpc.CallOption) (*sapb.Authorizations, error) {
 	return sa.Impl.GetValidAuthorizations2(ctx, req)
 }
 
+// GetValidOrderAuthorizations2 is a wrapper for `sapb.StorageAuthorityServer.GetValidOrderAuthorizations2`.
 func (sa SA) GetValidOrderAuthorizations2(ctx context.Context, req *sapb.GetValidOrderAuthorizationsRequest, _ ...grpc.CallOption) (*sapb.Authorizations, error) {
 	return sa.Impl.GetValidOrderAuthorizations2(ctx, req)
 }
 
-func (sa SA) CountPendingAuthorizations2(ctx context.Context, req *sapb.RegistrationID, _ ...grpc.CallOption) (*sapb.Count, error) {
-	return sa.Impl.CountPendingAuthorizations2(ctx, req)
+// IncidentsForSerial is a wrapper for `sapb.StorageAuthorityServer.IncidentsForSerial`.
+func (sa SA) IncidentsForSerial(ctx context.Context, req *sapb.Serial, _ ...grpc.CallOption) (*sapb.Incidents, error) {
+	return sa.Impl.IncidentsForSerial(ctx, req)
+}
+
+// KeyBlocked is a wrapper for `sapb.StorageAuthorityServer.KeyBlocked`.
+func (sa SA) KeyBlocked(ctx context.Context, req *sapb.SPKIHash, _ ...grpc.CallOption) (*sapb.Exists, error) {
+	return sa.Impl.KeyBlocked(ctx, req)
+}
+
+// ReplacementOrderExists is a wrapper for `sapb.StorageAuthorityServer.ReplacementOrderExists`.
+func (sa SA) ReplacementOrderExists(ctx context.Context, req *sapb.Serial, _ ...grpc.CallOption) (*sapb.Exists, error) {
+	return sa.Impl.ReplacementOrderExists(ctx, req)
+}
+
+// SerialsForIncident is a wrapper for `sapb.StorageAuthorityServer.SerialsForIncident`.
+func (sa SA) SerialsForIncident(ctx context.Context, req *sapb.SerialsForIncidentRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[sapb.IncidentSerial], error) {
+	return serverStream(ctx, req, sa.Impl.SerialsForIncident)
+}
+
+// CheckIdentifiersPaused is a wrapper for `sapb.StorageAuthorityServer.CheckIdentifiersPaused`.
+func (sa SA) CheckIdentifiersPaused(ctx context.Context, req *sapb.PauseRequest, _ ...grpc.CallOption) (*sapb.Identifiers, error) {
+	return sa.Impl.CheckIdentifiersPaused(ctx, req)
+}
+
+// GetPausedIdentifiers is a wrapper for `sapb.StorageAuthorityServer.GetPausedIdentifiers`.
+func (sa SA) GetPausedIdentifiers(ctx context.Context, req *sapb.RegistrationID, _ ...grpc.CallOption) (*sapb.Identifiers, error) {
+	return sa.Impl.GetPausedIdentifiers(ctx, req)
+}
+
+// GetRateLimitOverride is a wrapper for `sapb.StorageAuthorityServer.GetRateLimitOverride`.
+func (sa SA) GetRateLimitOverride(ctx context.Context, req *sapb.GetRateLimitOverrideRequest, _ ...grpc.CallOption) (*sapb.RateLimitOverrideResponse, error) {
+	return sa.Impl.GetRateLimitOverride(ctx, req)
+}
+
+// GetEnabledRateLimitOverrides is a wrapper for `sapb.StorageAuthorityServer.GetEnabledRateLimitOverrides`.
+func (sa SA) GetEnabledRateLimitOverrides(ctx context.Context, req *emptypb.Empty, _ ...grpc.CallOption) (grpc.ServerStreamingClient[sapb.RateLimitOverrideResponse], error) {
+	return serverStream(ctx, req, sa.Impl.GetEnabledRateLimitOverrides)
 }
 
+// GetContactVerifications is a wrapper for `sapb.StorageAuthorityServer.GetContactVerifications`.
+func (sa SA) GetContactVerifications(ctx context.Context, req *sapb.ContactHashes, _ ...grpc.CallOption) (*sapb.ContactVerifications, error) {
+	return sa.Impl.GetContactVerifications(ctx, req)
+}
+
+// GetRegistrationClientIdentity is a wrapper for `sapb.StorageAuthorityServer.GetRegistrationClientIdentity`.
+func (sa SA) GetRegistrationClientIdentity(ctx context.Context, req *sapb.RegistrationID, _ ...grpc.CallOption) (*sapb.ClientIdentity, error) {
+	return sa.Impl.GetRegistrationClientIdentity(ctx, req)
+}
+
+// GetAccountRevocation is a wrapper for `sapb.StorageAuthorityServer.GetAccountRevocation`.
+func (sa SA) GetAccountRevocation(ctx context.Context, req *sapb.RegistrationID, _ ...grpc.CallOption) (*sapb.AccountRevocation, error) {
+	return sa.Impl.GetAccountRevocation(ctx, req)
+}
+
+// GetExemptionRequest is a wrapper for `sapb.StorageAuthorityServer.GetExemptionRequest`.
+func (sa SA) GetExemptionRequest(ctx context.Context, req *sapb.ExemptionRequestID, _ ...grpc.CallOption) (*sapb.ExemptionRequest, error) {
+	return sa.Impl.GetExemptionRequest(ctx, req)
+}
+
+// GetExemptionRequests is a wrapper for `sapb.StorageAuthorityServer.GetExemptionRequests`.
+func (sa SA) GetExemptionRequests(ctx context.Context, req *sapb.GetExemptionRequestsRequest, _ ...grpc.CallOption) (*sapb.ExemptionRequests, error) {
+	return sa.Impl.GetExemptionRequests(ctx, req)
+}
+
+// GetValidationFailures is a wrapper for `sapb.StorageAuthorityServer.GetValidationFailures`.
+func (sa SA) GetValidationFailures(ctx context.Context, req *sapb.AuthorizationID2, _ ...grpc.CallOption) (*sapb.ValidationFailures, error) {
+	return sa.Impl.GetValidationFailures(ctx, req)
+}
+
+// GetAuthzCAARecheck is a wrapper for `sapb.StorageAuthorityServer.GetAuthzCAARecheck`.
+func (sa SA) GetAuthzCAARecheck(ctx context.Context, req *sapb.AuthorizationID2, _ ...grpc.CallOption) (*sapb.AuthzCAARecheck, error) {
+	return sa.Impl.GetAuthzCAARecheck(ctx, req)
+}
+
+// GetCRLShard is a wrapper for `sapb.StorageAuthorityServer.GetCRLShard`.
+func (sa SA) GetCRLShard(ctx context.Context, req *sapb.GetCRLShardRequest, _ ...grpc.CallOption) (*sapb.CRLShard, error) {
+	return sa.Impl.GetCRLShard(ctx, req)
+}
+
+// AddBlockedKey is a wrapper for `sapb.StorageAuthorityServer.AddBlockedKey`.
+func (sa SA) AddBlockedKey(ctx context.Context, req *sapb.AddBlockedKeyRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	return sa.Impl.AddBlockedKey(ctx, req)
+}
+
+// AddCertificate is a wrapper for `sapb.StorageAuthorityServer.AddCertificate`.
+func (sa SA) AddCertificate(ctx context.Context, req *sapb.AddCertificateRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	return sa.Impl.AddCertificate(ctx, req)
+}
+
+// AddPrecertificate is a wrapper for `sapb.StorageAuthorityServer.AddPrecertificate`.
+func (sa SA) AddPrecertificate(ctx context.Context, req *sapb.AddCertificateRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	return sa.Impl.AddPrecertificate(ctx, req)
+}
+
+// SetCertificateStatusReady is a wrapper for `sapb.StorageAuthorityServer.SetCertificateStatusReady`.
+func (sa SA) SetCertificateStatusReady(ctx context.Context, req *sapb.Serial, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	return sa.Impl.SetCertificateStatusReady(ctx, req)
+}
+
+// AddSerial is a wrapper for `sapb.StorageAuthorityServer.AddSerial`.
+func (sa SA) AddSerial(ctx context.Context, req *sapb.AddSerialRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	return sa.Impl.AddSerial(ctx, req)
+}
+
+// DeactivateAuthorization2 is a wrapper for `sapb.StorageAuthorityServer.DeactivateAuthorization2`.
 func (sa SA) DeactivateAuthorization2(ctx context.Context, req *sapb.AuthorizationID2, _ ...grpc.CallOption) (*emptypb.Empty, error) {
 	return sa.Impl.DeactivateAuthorization2(ctx, req)
 }
 
+// DeactivateRegistration is a wrapper for `sapb.StorageAuthorityServer.DeactivateRegistration`.
+func (sa SA) DeactivateRegistration(ctx context.Context, req *sapb.DeactivateRegistrationRequest, _ ...grpc.CallOption) (*corepb.Registration, error) {
+	return sa.Impl.DeactivateRegistration(ctx, req)
+}
+
+// FinalizeAuthorization2 is a wrapper for `sapb.StorageAuthorityServer.FinalizeAuthorization2`.
 func (sa SA) FinalizeAuthorization2(ctx context.Context, req *sapb.FinalizeAuthorizationRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
 	return sa.Impl.FinalizeAuthorization2(ctx, req)
 }
 
+// FinalizeOrder is a wrapper for `sapb.StorageAuthorityServer.FinalizeOrder`.
+func (sa SA) FinalizeOrder(ctx context.Context, req *sapb.FinalizeOrderRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	return sa.Impl.FinalizeOrder(ctx, req)
+}
+
+// NewOrderAndAuthzs is a wrapper for `sapb.StorageAuthorityServer.NewOrderAndAuthzs`.
 func (sa SA) NewOrderAndAuthzs(ctx context.Context, req *sapb.NewOrderAndAuthzsRequest, _ ...grpc.CallOption) (*corepb.Order, error) {
 	return sa.Impl.NewOrderAndAuthzs(ctx, req)
 }
 
-func (sa SA) GetOrder(ctx context.Context, req *sapb.OrderRequest, _ ...grpc.CallOption) (*corepb.Order, error) {
-	return sa.Impl.GetOrder(ctx, req)
+// NewRegistration is a wrapper for `sapb.StorageAuthorityServer.NewRegistration`.
+func (sa SA) NewRegistration(ctx context.Context, req *corepb.Registration, _ ...grpc.CallOption) (*corepb.Registration, error) {
+	return sa.Impl.NewRegistration(ctx, req)
 }
 
-func (sa SA) GetOrderForNames(ctx context.Context, req *sapb.GetOrderForNamesRequest, _ ...grpc.CallOption) (*corepb.Order, error) {
-	return sa.Impl.GetOrderForNames(ctx, req)
+// RevokeCertificate is a wrapper for `sapb.StorageAuthorityServer.RevokeCertificate`.
+func (sa SA) RevokeCertificate(ctx context.Context, req *sapb.RevokeCertificateRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	return sa.Impl.RevokeCertificate(ctx, req)
 }
 
+// SetOrderError is a wrapper for `sapb.StorageAuthorityServer.SetOrderError`.
 func (sa SA) SetOrderError(ctx context.Context, req *sapb.SetOrderErrorRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
 	return sa.Impl.SetOrderError(ctx, req)
 }
 
-func (sa SA) SetOrderProcessing(ctx context.Context, req *sapb.OrderRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+// SetOrderProcessing is a wrapper for `sapb.StorageAuthorityServer.SetOrderProcessing`.
+func (sa SA) SetOrderProcessing(ctx context.Context, req *sapb.SetOrderProcessingRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
 	return sa.Impl.SetOrderProcessing(ctx, req)
 }
 
-func (sa SA) FinalizeOrder(ctx context.Context, req *sapb.FinalizeOrderRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
-	return sa.Impl.FinalizeOrder(ctx, req)
+// UpdateRegistrationKey is a wrapper for `sapb.StorageAuthorityServer.UpdateRegistrationKey`.
+func (sa SA) UpdateRegistrationKey(ctx context.Context, req *sapb.UpdateRegistrationKeyRequest, _ ...grpc.CallOption) (*corepb.Registration, error) {
+	return sa.Impl.UpdateRegistrationKey(ctx, req)
 }
 
-func (sa SA) AddPrecertificate(ctx context.Context, req *sapb.AddCertificateRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
-	return sa.Impl.AddPrecertificate(ctx, req)
+// UpdateRevokedCertificate is a wrapper for `sapb.StorageAuthorityServer.UpdateRevokedCertificate`.
+func (sa SA) UpdateRevokedCertificate(ctx context.Context, req *sapb.RevokeCertificateRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	return sa.Impl.UpdateRevokedCertificate(ctx, req)
 }
 
-func (sa SA) AddCertificate(ctx context.Context, req *sapb.AddCertificateRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
-	return sa.Impl.AddCertificate(ctx, req)
+// LeaseCRLShard is a wrapper for `sapb.StorageAuthorityServer.LeaseCRLShard`.
+func (sa SA) LeaseCRLShard(ctx context.Context, req *sapb.LeaseCRLShardRequest, _ ...grpc.CallOption) (*sapb.LeaseCRLShardResponse, error) {
+	return sa.Impl.LeaseCRLShard(ctx, req)
 }
 
-func (sa SA) RevokeCertificate(ctx context.Context, req *sapb.RevokeCertificateRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
-	return sa.Impl.RevokeCertificate(ctx, req)
+// UpdateCRLShard is a wrapper for `sapb.StorageAuthorityServer.UpdateCRLShard`.
+func (sa SA) UpdateCRLShard(ctx context.Context, req *sapb.UpdateCRLShardRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	return sa.Impl.UpdateCRLShard(ctx, req)
 }
 
-func (sa SA) GetLintPrecertificate(ctx context.Context, req *sapb.Serial, _ ...grpc.CallOption) (*corepb.Certificate, error) {
-	return sa.Impl.GetLintPrecertificate(ctx, req)
+// PauseIdentifiers is a wrapper for `sapb.StorageAuthorityServer.PauseIdentifiers`.
+func (sa SA) PauseIdentifiers(ctx context.Context, req *sapb.PauseRequest, _ ...grpc.CallOption) (*sapb.PauseIdentifiersResponse, error) {
+	return sa.Impl.PauseIdentifiers(ctx, req)
 }
 
-func (sa SA) GetCertificateStatus(ctx context.Context, req *sapb.Serial, _ ...grpc.CallOption) (*corepb.CertificateStatus, error) {
-	return sa.Impl.GetCertificateStatus(ctx, req)
+// UnpauseAccount is a wrapper for `sapb.StorageAuthorityServer.UnpauseAccount`.
+func (sa SA) UnpauseAccount(ctx context.Context, req *sapb.RegistrationID, _ ...grpc.CallOption) (*sapb.Count, error) {
+	return sa.Impl.UnpauseAccount(ctx, req)
 }
 
-func (sa SA) AddBlockedKey(ctx context.Context, req *sapb.AddBlockedKeyRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
-	return sa.Impl.AddBlockedKey(ctx, req)
+// AddRateLimitOverride is a wrapper for `sapb.StorageAuthorityServer.AddRateLimitOverride`.
+func (sa SA) AddRateLimitOverride(ctx context.Context, req *sapb.AddRateLimitOverrideRequest, _ ...grpc.CallOption) (*sapb.AddRateLimitOverrideResponse, error) {
+	return sa.Impl.AddRateLimitOverride(ctx, req)
 }
 
-func (sa SA) FQDNSetExists(ctx context.Context, req *sapb.FQDNSetExistsRequest, _ ...grpc.CallOption) (*sapb.Exists, error) {
-	return sa.Impl.FQDNSetExists(ctx, req)
+// DisableRateLimitOverride is a wrapper for `sapb.StorageAuthorityServer.DisableRateLimitOverride`.
+func (sa SA) DisableRateLimitOverride(ctx context.Context, req *sapb.DisableRateLimitOverrideRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	return sa.Impl.DisableRateLimitOverride(ctx, req)
 }
 
-func (sa SA) FQDNSetTimestampsForWindow(ctx context.Context, req *sapb.CountFQDNSetsRequest, _ ...grpc.CallOption) (*sapb.Timestamps, error) {
-	return sa.Impl.FQDNSetTimestampsForWindow(ctx, req)
+// EnableRateLimitOverride is a wrapper for `sapb.StorageAuthorityServer.EnableRateLimitOverride`.
+func (sa SA) EnableRateLimitOverride(ctx context.Context, req *sapb.EnableRateLimitOverrideRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	return sa.Impl.EnableRateLimitOverride(ctx, req)
 }
 
-func (sa SA) PauseIdentifiers(ctx context.Context, req *sapb.PauseRequest, _ ...grpc.CallOption) (*sapb.PauseIdentifiersResponse, error) {
-	return sa.Impl.PauseIdentifiers(ctx, req)
+// AddExemptionRequest is a wrapper for `sapb.StorageAuthorityServer.AddExemptionRequest`.
+func (sa SA) AddExemptionRequest(ctx context.Context, req *sapb.ExemptionRequest, _ ...grpc.CallOption) (*sapb.ExemptionRequest, error) {
+	return sa.Impl.AddExemptionRequest(ctx, req)
+}
+
+// AddValidationFailure is a wrapper for `sapb.StorageAuthorityServer.AddValidationFailure`.
+func (sa SA) AddValidationFailure(ctx context.Context, req *sapb.AddValidationFailureRequest, _ ...grpc.CallOption) (*sapb.ValidationFailures, error) {
+	return sa.Impl.AddValidationFailure(ctx, req)
+}
+
+// LeaseAccountRevocation is a wrapper for `sapb.StorageAuthorityServer.LeaseAccountRevocation`.
+func (sa SA) LeaseAccountRevocation(ctx context.Context, req *sapb.LeaseAccountRevocationRequest, _ ...grpc.CallOption) (*sapb.AccountRevocation, error) {
+	return sa.Impl.LeaseAccountRevocation(ctx, req)
+}
+
+// SetAuthzCAARecheck is a wrapper for `sapb.StorageAuthorityServer.SetAuthzCAARecheck`.
+func (sa SA) SetAuthzCAARecheck(ctx context.Context, req *sapb.SetAuthzCAARecheckRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	return sa.Impl.SetAuthzCAARecheck(ctx, req)
+}
+
+// ReviewExemptionRequest is a wrapper for `sapb.StorageAuthorityServer.ReviewExemptionRequest`.
+func (sa SA) ReviewExemptionRequest(ctx context.Context, req *sapb.ReviewExemptionRequestRequest, _ ...grpc.CallOption) (*sapb.ExemptionRequest, error) {
+	return sa.Impl.ReviewExemptionRequest(ctx, req)
+}
+
+// SetAccountRevocation is a wrapper for `sapb.StorageAuthorityServer.SetAccountRevocation`.
+func (sa SA) SetAccountRevocation(ctx context.Context, req *sapb.AccountRevocation, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	return sa.Impl.SetAccountRevocation(ctx, req)
+}
+
+// SetContactVerification is a wrapper for `sapb.StorageAuthorityServer.SetContactVerification`.
+func (sa SA) SetContactVerification(ctx context.Context, req *sapb.ContactVerification, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	return sa.Impl.SetContactVerification(ctx, req)
 }
 
 type mockStreamResult[T any] struct {
@@ -132,7 +392,11 @@ type mockClientStream[T any] struct {
 }
 
 func (c mockClientStream[T]) Recv() (T, error) {
-	result := <-c.stream
+	result, ok := <-c.stream
+	if !ok {
+		var zero T
+		return zero, io.EOF
+	}
 	return result.val, result.err
 }
 
@@ -143,25 +407,35 @@ type mockServerStream[T any] struct {
 }
 
 func (s mockServerStream[T]) Send(val T) error {
-	s.stream <- mockStreamResult[T]{val: val, err: nil}
-	return nil
+	select {
+	case s.stream <- mockStreamResult[T]{val: val, err: nil}:
+		return nil
+	case <-s.context.Done():
+		return s.context.Err()
+	}
 }
 
 func (s mockServerStream[T]) Context() context.Context {
 	return s.context
 }
 
-func (sa SA) SerialsForIncident(ctx context.Context, req *sapb.SerialsForIncidentRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[sapb.IncidentSerial], error) {
-	streamChan := make(chan mockStreamResult[*sapb.IncidentSerial])
-	client := mockClientStream[*sapb.IncidentSerial]{stream: streamChan}
-	server := mockServerStream[*sapb.IncidentSerial]{context: ctx, stream: streamChan}
+// serverStream calls the server-streaming method impl in a goroutine, and
+// returns a client stream which receives what it sends, followed by either the
+// error it returns or io.EOF.
+func serverStream[Req, Res any](ctx context.Context, req *Req, impl func(*Req, grpc.ServerStreamingServer[Res]) error) (grpc.ServerStreamingClient[Res], error) {
+	streamChan := make(chan mockStreamResult[*Res])
+	client := mockClientStream[*Res]{stream: streamChan}
+	server := mockServerStream[*Res]{context: ctx, stream: streamChan}
 	go func() {
-		err := sa.Impl.SerialsForIncident(req, server)
-		if err != nil {
-			streamChan <- mockStreamResult[*sapb.IncidentSerial]{nil, err}
+		defer close(streamChan)
+		err := impl(req, server)
+		if err == nil {
+			err = io.EOF
+		}
+		select {
+		case streamChan <- mockStreamResult[*Res]{nil, err}:
+		case <-ctx.Done():
 		}
-		streamChan <- mockStreamResult[*sapb.IncidentSerial]{nil, io.EOF}
-		close(streamChan)
 	}()
 	return client, nil
 }
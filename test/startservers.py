@@ -54,14 +54,18 @@ SERVICES = (
         8005, None, None,
         ('./bin/boulder', 'ocsp-responder', '--config', os.path.join(config_dir, 'ocsp-responder.json'), '--addr', ':4002', '--debug-addr', ':8005'),
         ('boulder-ra-1', 'boulder-ra-2')),
+    Service('caa-checker',
+        8027, 9396, 'caa-checker.boulder',
+        ('./bin/boulder', 'caa-checker', '--config', os.path.join(config_dir, 'caa-checker.json'), '--addr', ':9396', '--debug-addr', ':8027'),
+        None),
     Service('boulder-va-1',
         8004, 9392, 'va.boulder',
         ('./bin/boulder', 'boulder-va', '--config', os.path.join(config_dir, 'va.json'), '--addr', ':9392', '--debug-addr', ':8004'),
-        ('remoteva-a', 'remoteva-b')),
+        ('remoteva-a', 'remoteva-b', 'caa-checker')),
     Service('boulder-va-2',
         8104, 9492, 'va.boulder',
         ('./bin/boulder', 'boulder-va', '--config', os.path.join(config_dir, 'va.json'), '--addr', ':9492', '--debug-addr', ':8104'),
-        ('remoteva-a', 'remoteva-b')),
+        ('remoteva-a', 'remoteva-b', 'caa-checker')),
     Service('boulder-ca-1',
         8001, 9393, 'ca.boulder',
         ('./bin/boulder', 'boulder-ca', '--config', os.path.join(config_dir, 'ca.json'), '--addr', ':9393', '--debug-addr', ':8001'),
@@ -142,14 +146,26 @@ SERVICES = (
         8114, None, None,
         ('./bin/boulder', 'email-exporter', '--config', os.path.join(config_dir, 'email-exporter.json'), '--addr', ':9603', '--debug-addr', ':8114'),
         ('pardot-test-srv',)),
+    Service('contact-verifier',
+        8115, 9604, 'contact-verifier.boulder',
+        ('./bin/boulder', 'contact-verifier', '--config', os.path.join(config_dir, 'contact-verifier.json'), '--addr', ':9604', '--debug-addr', ':8115'),
+        ('boulder-sa-1', 'boulder-sa-2')),
+    Service('exemption-queue',
+        8116, 9605, 'exemption-queue.boulder',
+        ('./bin/boulder', 'exemption-queue', '--config', os.path.join(config_dir, 'exemption-queue.json'), '--addr', ':9605', '--debug-addr', ':8116'),
+        ('boulder-sa-1', 'boulder-sa-2')),
     Service('boulder-wfe2',
         4001, None, None,
         ('./bin/boulder', 'boulder-wfe2', '--config', os.path.join(config_dir, 'wfe2.json'), '--addr', ':4001', '--tls-addr', ':4431', '--debug-addr', ':8013'),
-        ('boulder-ra-1', 'boulder-ra-2', 'boulder-sa-1', 'boulder-sa-2', 'nonce-service-taro-1', 'nonce-service-taro-2', 'nonce-service-zinc-1', 'email-exporter')),
+        ('boulder-ra-1', 'boulder-ra-2', 'boulder-sa-1', 'boulder-sa-2', 'nonce-service-taro-1', 'nonce-service-taro-2', 'nonce-service-zinc-1', 'email-exporter', 'contact-verifier', 'exemption-queue')),
     Service('sfe',
         4003, None, None,
         ('./bin/boulder', 'sfe', '--config', os.path.join(config_dir, 'sfe.json'), '--addr', ':4003', '--debug-addr', ':8015'),
         ('boulder-ra-1', 'boulder-ra-2', 'boulder-sa-1', 'boulder-sa-2',)),
+    Service('api-gateway',
+        8117, None, None,
+        ('./bin/boulder', 'api-gateway', '--config', os.path.join(config_dir, 'api-gateway.json'), '--addr', ':4005', '--debug-addr', ':8117'),
+        ('boulder-sa-1', 'boulder-sa-2')),
     Service('log-validator',
         8016, None, None,
         ('./bin/boulder', 'log-validator', '--config', os.path.join(config_dir, 'log-validator.json'), '--debug-addr', ':8016'),
@@ -182,7 +198,7 @@ processes = []
 
 # NOTE(@cpu): We manage the challSrvProcess separately from the other global
 # processes because we want integration tests to be able to stop/start it (e.g.
-# to run the load-generator).
+# to run boulder-loadgen).
 challSrvProcess = None
 
 def install(race_detection):
@@ -0,0 +1,292 @@
+package va
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/letsencrypt/boulder/config"
+	berrors "github.com/letsencrypt/boulder/errors"
+	vapb "github.com/letsencrypt/boulder/va/proto"
+)
+
+// defaultMaxArchivedBodySize is the number of bytes of each response body
+// which are archived if no limit is configured.
+const defaultMaxArchivedBodySize = 4096
+
+// HTTPResponseArchiveConfig configures the archival of the final HTTP response
+// received during each failed HTTP-01 validation, so that disputes about what
+// a server returned can be resolved after the fact. Archived responses are
+// held in memory, compressed, and can be retrieved by authorization ID using
+// the HTTPResponseArchive gRPC service.
+type HTTPResponseArchiveConfig struct {
+	// Retention is how long each response is kept.
+	Retention config.Duration `validate:"-"`
+
+	// MaxSize is the maximum number of compressed bytes held by the archive.
+	// When it's reached, the oldest responses are discarded first.
+	MaxSize int `validate:"required,min=1"`
+
+	// MaxBodySize is the number of bytes of each response body which are
+	// archived. Longer bodies are truncated. If zero, it's 4096.
+	MaxBodySize int `validate:"omitempty,min=1"`
+}
+
+// archivedHTTPResponse is a response held by an httpResponseArchive. Its
+// header and body are gzip compressed.
+type archivedHTTPResponse struct {
+	authzID       string
+	archived      time.Time
+	url           string
+	addressUsed   string
+	statusCode    int
+	header        []byte
+	body          []byte
+	bodyTruncated bool
+	problem       string
+}
+
+func (r *archivedHTTPResponse) size() int {
+	return len(r.header) + len(r.body)
+}
+
+// httpResponseArchive holds archived responses in the order they were
+// archived, which is also the order in which they expire.
+type httpResponseArchive struct {
+	retention   time.Duration
+	maxSize     int
+	maxBodySize int
+
+	mu        sync.Mutex
+	responses []*archivedHTTPResponse
+	size      int
+}
+
+// capturedHTTPResponse is an HTTP-01 response which has not been archived yet.
+type capturedHTTPResponse struct {
+	url           string
+	addressUsed   string
+	statusCode    int
+	header        http.Header
+	body          []byte
+	bodyTruncated bool
+}
+
+type httpResponseRecorderKey struct{}
+
+// httpResponseRecorder holds the last response captured during a validation.
+type httpResponseRecorder struct {
+	maxBodySize int
+	last        *capturedHTTPResponse
+}
+
+// withHTTPResponseRecorder returns a context which causes processHTTPValidation
+// to capture the final response it receives in the returned recorder.
+func withHTTPResponseRecorder(ctx context.Context, maxBodySize int) (context.Context, *httpResponseRecorder) {
+	recorder := &httpResponseRecorder{maxBodySize: maxBodySize}
+	return context.WithValue(ctx, httpResponseRecorderKey{}, recorder), recorder
+}
+
+// httpResponseRecorderFrom returns the recorder added to ctx by
+// withHTTPResponseRecorder, or nil if there is none.
+func httpResponseRecorderFrom(ctx context.Context) *httpResponseRecorder {
+	recorder, _ := ctx.Value(httpResponseRecorderKey{}).(*httpResponseRecorder)
+	return recorder
+}
+
+// record captures resp, whose body has been read into body. If the body was
+// truncated before it was read, truncated should be true.
+func (r *httpResponseRecorder) record(url, addressUsed string, resp *http.Response, body []byte, truncated bool) {
+	if len(body) > r.maxBodySize {
+		body = body[:r.maxBodySize]
+		truncated = true
+	}
+	r.last = &capturedHTTPResponse{
+		url:           url,
+		addressUsed:   addressUsed,
+		statusCode:    resp.StatusCode,
+		header:        resp.Header.Clone(),
+		body:          bytes.Clone(body),
+		bodyTruncated: truncated,
+	}
+}
+
+// SetHTTPResponseArchive configures the VA to archive the final HTTP response
+// received during each failed HTTP-01 validation.
+func (va *ValidationAuthorityImpl) SetHTTPResponseArchive(c HTTPResponseArchiveConfig) error {
+	if c.Retention.Duration <= 0 {
+		return errors.New("HTTP response archive retention must be positive")
+	}
+	if c.MaxSize <= 0 {
+		return errors.New("HTTP response archive maxSize must be positive")
+	}
+	if c.MaxBodySize < 0 {
+		return errors.New("HTTP response archive maxBodySize must not be negative")
+	}
+	maxBodySize := c.MaxBodySize
+	if maxBodySize == 0 {
+		maxBodySize = defaultMaxArchivedBodySize
+	}
+	va.httpResponseArchive = &httpResponseArchive{
+		retention:   c.Retention.Duration,
+		maxSize:     c.MaxSize,
+		maxBodySize: maxBodySize,
+	}
+	return nil
+}
+
+// archiveHTTPResponse archives the response captured by recorder, if any, for
+// the authorization with the given ID.
+func (va *ValidationAuthorityImpl) archiveHTTPResponse(authzID string, recorder *httpResponseRecorder, problem string) {
+	if va.httpResponseArchive == nil || recorder == nil || recorder.last == nil {
+		return
+	}
+	captured := recorder.last
+
+	var header bytes.Buffer
+	err := captured.header.Write(&header)
+	if err != nil {
+		va.log.Warningf("Unable to archive HTTP response for authz %q: %s", authzID, err)
+		return
+	}
+	compressedHeader, err := gzipBytes(header.Bytes())
+	if err != nil {
+		va.log.Warningf("Unable to archive HTTP response for authz %q: %s", authzID, err)
+		return
+	}
+	compressedBody, err := gzipBytes(captured.body)
+	if err != nil {
+		va.log.Warningf("Unable to archive HTTP response for authz %q: %s", authzID, err)
+		return
+	}
+
+	va.httpResponseArchive.add(va.clk.Now(), &archivedHTTPResponse{
+		authzID:       authzID,
+		archived:      va.clk.Now(),
+		url:           captured.url,
+		addressUsed:   captured.addressUsed,
+		statusCode:    captured.statusCode,
+		header:        compressedHeader,
+		body:          compressedBody,
+		bodyTruncated: captured.bodyTruncated,
+		problem:       problem,
+	})
+	va.metrics.http01ArchivedResponses.Inc()
+	va.metrics.http01ArchiveBytes.Set(float64(va.httpResponseArchive.bytes()))
+}
+
+// add archives r, then discards expired responses and, if the archive is too
+// large, the oldest ones.
+func (a *httpResponseArchive) add(now time.Time, r *archivedHTTPResponse) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.responses = append(a.responses, r)
+	a.size += r.size()
+	a.evict(now)
+}
+
+// evict discards expired responses, and then the oldest responses until the
+// archive is no larger than its maximum size. The caller must hold a.mu.
+func (a *httpResponseArchive) evict(now time.Time) {
+	i := 0
+	for ; i < len(a.responses); i++ {
+		r := a.responses[i]
+		if now.Sub(r.archived) < a.retention && a.size <= a.maxSize {
+			break
+		}
+		a.size -= r.size()
+	}
+	clear(a.responses[:i])
+	a.responses = a.responses[i:]
+}
+
+// bytes returns the number of compressed bytes held by the archive.
+func (a *httpResponseArchive) bytes() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.size
+}
+
+// get returns the unexpired responses archived for the given authorization,
+// oldest first.
+func (a *httpResponseArchive) get(now time.Time, authzID string) []*archivedHTTPResponse {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.evict(now)
+	var found []*archivedHTTPResponse
+	for _, r := range a.responses {
+		if r.authzID == authzID {
+			found = append(found, r)
+		}
+	}
+	return found
+}
+
+// GetArchivedHTTPResponses returns the HTTP responses archived during failed
+// HTTP-01 validations of the given authorization, oldest first.
+func (va *ValidationAuthorityImpl) GetArchivedHTTPResponses(_ context.Context, req *vapb.GetArchivedHTTPResponsesRequest) (*vapb.ArchivedHTTPResponses, error) {
+	if req.AuthzID == "" {
+		return nil, berrors.InternalServerError("Incomplete archived HTTP responses request")
+	}
+	if va.httpResponseArchive == nil {
+		return nil, berrors.NotFoundError("HTTP response archival is not enabled")
+	}
+
+	found := va.httpResponseArchive.get(va.clk.Now(), req.AuthzID)
+	if len(found) == 0 {
+		return nil, berrors.NotFoundError("no archived HTTP responses for authorization %q", req.AuthzID)
+	}
+	resp := &vapb.ArchivedHTTPResponses{}
+	for _, r := range found {
+		header, err := gunzipBytes(r.header)
+		if err != nil {
+			return nil, err
+		}
+		body, err := gunzipBytes(r.body)
+		if err != nil {
+			return nil, err
+		}
+		resp.Responses = append(resp.Responses, &vapb.ArchivedHTTPResponse{
+			Archived:      timestamppb.New(r.archived),
+			Perspective:   va.perspective,
+			Url:           r.url,
+			AddressUsed:   r.addressUsed,
+			StatusCode:    int32(r.statusCode), //nolint: gosec // HTTP status codes are three digits.
+			Header:        header,
+			Body:          body,
+			BodyTruncated: r.bodyTruncated,
+			Problem:       r.problem,
+		})
+	}
+	return resp, nil
+}
+
+func gzipBytes(b []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write(b)
+	if err != nil {
+		return nil, err
+	}
+	err = w.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func gunzipBytes(b []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
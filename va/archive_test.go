@@ -0,0 +1,124 @@
+package va
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/core"
+	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/identifier"
+	"github.com/letsencrypt/boulder/test"
+	vapb "github.com/letsencrypt/boulder/va/proto"
+)
+
+func TestSetHTTPResponseArchiveErrors(t *testing.T) {
+	t.Parallel()
+	va, _ := setup(nil, "", nil, nil)
+
+	for _, c := range []HTTPResponseArchiveConfig{
+		{MaxSize: 1024},
+		{Retention: config.Duration{Duration: time.Hour}},
+		{Retention: config.Duration{Duration: time.Hour}, MaxSize: 1024, MaxBodySize: -1},
+	} {
+		err := va.SetHTTPResponseArchive(c)
+		test.AssertError(t, err, "invalid config accepted")
+	}
+}
+
+func TestHTTPResponseArchive(t *testing.T) {
+	t.Parallel()
+
+	// The server answers every challenge with the wrong key authorization,
+	// except for the one for pathWrongToken, which is not found.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Dispute", "evidence")
+		if strings.HasSuffix(r.URL.Path, pathWrongToken) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(strings.Repeat("a", 100)))
+			return
+		}
+		_, _ = w.Write([]byte("wrong key authorization"))
+	}))
+	defer srv.Close()
+
+	va, _ := setup(srv, "", nil, nil)
+	err := va.SetHTTPResponseArchive(HTTPResponseArchiveConfig{
+		Retention:   config.Duration{Duration: time.Hour},
+		MaxSize:     1 << 20,
+		MaxBodySize: 64,
+	})
+	test.AssertNotError(t, err, "configuring archive")
+
+	validate := func(authzID, token string) {
+		t.Helper()
+		req := createValidationRequest(identifier.NewDNS("localhost"), core.ChallengeTypeHTTP01)
+		req.Authz.Id = authzID
+		req.Challenge.Token = token
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		res, err := va.DoDCV(ctx, req)
+		test.AssertNotError(t, err, "DoDCV")
+		test.Assert(t, res.Problem != nil, "validation succeeded")
+	}
+	validate("1", expectedToken)
+	validate("2", pathWrongToken)
+	validate("2", expectedToken)
+
+	resp, err := va.GetArchivedHTTPResponses(context.Background(), &vapb.GetArchivedHTTPResponsesRequest{AuthzID: "1"})
+	test.AssertNotError(t, err, "getting archived responses")
+	test.AssertEquals(t, len(resp.Responses), 1)
+	r := resp.Responses[0]
+	test.AssertEquals(t, r.StatusCode, int32(http.StatusOK))
+	test.AssertEquals(t, string(r.Body), "wrong key authorization")
+	test.Assert(t, !r.BodyTruncated, "short body truncated")
+	test.AssertContains(t, string(r.Header), "X-Dispute: evidence\r\n")
+	test.AssertContains(t, r.Url, expectedToken)
+	test.AssertEquals(t, r.AddressUsed, "127.0.0.1")
+	test.AssertEquals(t, r.Perspective, va.perspective)
+	test.AssertContains(t, r.Problem, "did not match this challenge")
+
+	resp, err = va.GetArchivedHTTPResponses(context.Background(), &vapb.GetArchivedHTTPResponsesRequest{AuthzID: "2"})
+	test.AssertNotError(t, err, "getting archived responses")
+	test.AssertEquals(t, len(resp.Responses), 2)
+	test.AssertEquals(t, resp.Responses[0].StatusCode, int32(http.StatusNotFound))
+	test.AssertEquals(t, string(resp.Responses[0].Body), strings.Repeat("a", 64))
+	test.Assert(t, resp.Responses[0].BodyTruncated, "long body not truncated")
+	test.AssertEquals(t, resp.Responses[1].StatusCode, int32(http.StatusOK))
+
+	// Responses are only kept for the retention period.
+	va.clk.(clock.FakeClock).Add(time.Hour)
+	_, err = va.GetArchivedHTTPResponses(context.Background(), &vapb.GetArchivedHTTPResponsesRequest{AuthzID: "1"})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+	test.AssertEquals(t, va.httpResponseArchive.bytes(), 0)
+}
+
+func TestHTTPResponseArchiveNotEnabled(t *testing.T) {
+	t.Parallel()
+	va, _ := setup(nil, "", nil, nil)
+
+	_, err := va.GetArchivedHTTPResponses(context.Background(), &vapb.GetArchivedHTTPResponsesRequest{AuthzID: "1"})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+}
+
+func TestHTTPResponseArchiveMaxSize(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	a := &httpResponseArchive{retention: time.Hour, maxSize: 25}
+	for _, id := range []string{"1", "2", "3"} {
+		a.add(now, &archivedHTTPResponse{authzID: id, archived: now, header: make([]byte, 5), body: make([]byte, 5)})
+	}
+
+	// The oldest response is discarded to make room for the newest.
+	test.AssertEquals(t, len(a.get(now, "1")), 0)
+	test.AssertEquals(t, len(a.get(now, "2")), 1)
+	test.AssertEquals(t, len(a.get(now, "3")), 1)
+	test.AssertEquals(t, a.bytes(), 20)
+}
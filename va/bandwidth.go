@@ -0,0 +1,198 @@
+package va
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+)
+
+// HTTPBandwidthConfig limits how quickly the VA reads from the servers it
+// connects to for HTTP-01 validations, both in total and from each server, so
+// that a few servers sending large or never-ending responses can't consume the
+// VA's bandwidth at the expense of other validations.
+type HTTPBandwidthConfig struct {
+	// GlobalBytesPerSecond is the most bytes per second which may be read from
+	// all HTTP-01 connections combined. If zero, it's unlimited.
+	GlobalBytesPerSecond int `validate:"omitempty,min=1"`
+
+	// PerTargetBytesPerSecond is the most bytes per second which may be read
+	// from all HTTP-01 connections to the same IP address combined. If zero,
+	// it's unlimited.
+	PerTargetBytesPerSecond int `validate:"omitempty,min=1"`
+
+	// MaxResponseHeaderBytes is the most bytes of response headers which are
+	// read before a response is rejected, so that a server can't send an
+	// endless stream of headers before the body size cap takes effect. If
+	// zero, Go's default of 1MiB applies.
+	MaxResponseHeaderBytes int `validate:"omitempty,min=1024"`
+}
+
+// bandwidthLimiter holds the rate limiters which reads from HTTP-01
+// connections must wait on.
+type bandwidthLimiter struct {
+	// global is nil if the total bandwidth is unlimited.
+	global *rate.Limiter
+	// perTarget is zero if the bandwidth per IP address is unlimited.
+	perTarget      int
+	maxHeaderBytes int64
+
+	mu      sync.Mutex
+	targets map[netip.Addr]*refCountedLimiter
+}
+
+// refCountedLimiter is the rate limiter for a single IP address, and the
+// number of open connections to it. It's removed from its bandwidthLimiter
+// when there are none.
+type refCountedLimiter struct {
+	limiter *rate.Limiter
+	refs    int
+}
+
+// SetHTTPBandwidth configures the VA to limit the rate at which it reads from
+// HTTP-01 connections in total and to each IP address.
+func (va *ValidationAuthorityImpl) SetHTTPBandwidth(c HTTPBandwidthConfig) error {
+	if c.GlobalBytesPerSecond < 0 || c.PerTargetBytesPerSecond < 0 || c.MaxResponseHeaderBytes < 0 {
+		return errors.New("HTTP bandwidth limits must not be negative")
+	}
+	if c.GlobalBytesPerSecond == 0 && c.PerTargetBytesPerSecond == 0 && c.MaxResponseHeaderBytes == 0 {
+		return errors.New("no HTTP bandwidth limits configured")
+	}
+	l := &bandwidthLimiter{
+		perTarget:      c.PerTargetBytesPerSecond,
+		maxHeaderBytes: int64(c.MaxResponseHeaderBytes),
+		targets:        make(map[netip.Addr]*refCountedLimiter),
+	}
+	if c.GlobalBytesPerSecond > 0 {
+		l.global = rate.NewLimiter(rate.Limit(c.GlobalBytesPerSecond), c.GlobalBytesPerSecond)
+	}
+	va.httpBandwidth = l
+	return nil
+}
+
+// acquireTarget returns the rate limiter for the given IP address, or nil if
+// the bandwidth per IP address is unlimited, and a function which must be
+// called once the connection to it is closed.
+func (l *bandwidthLimiter) acquireTarget(ip netip.Addr) (*rate.Limiter, func()) {
+	if l.perTarget == 0 {
+		return nil, func() {}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	t, ok := l.targets[ip]
+	if !ok {
+		t = &refCountedLimiter{limiter: rate.NewLimiter(rate.Limit(l.perTarget), l.perTarget)}
+		l.targets[ip] = t
+	}
+	t.refs++
+	return t.limiter, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		t.refs--
+		if t.refs == 0 {
+			delete(l.targets, ip)
+		}
+	}
+}
+
+// shapeDialer wraps df so that reads from the connections it dials wait on the
+// VA's bandwidth limits, if any are configured.
+func (va *ValidationAuthorityImpl) shapeDialer(df dialerFunc) dialerFunc {
+	l := va.httpBandwidth
+	if l == nil || (l.global == nil && l.perTarget == 0) {
+		return df
+	}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := df(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		remote, err := netip.ParseAddrPort(conn.RemoteAddr().String())
+		if err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+		target, release := l.acquireTarget(remote.Addr().Unmap())
+		// The connection may outlive ctx, so its reads wait on a context of
+		// their own which is canceled when it's closed.
+		connCtx, cancel := context.WithCancel(context.Background())
+		return &shapedConn{
+			Conn:    conn,
+			ctx:     connCtx,
+			cancel:  cancel,
+			release: release,
+			global:  l.global,
+			target:  target,
+			metrics: va.metrics,
+		}, nil
+	}
+}
+
+// shapedConn is a net.Conn whose reads wait until the global and per-target
+// rate limiters allow the bytes read.
+type shapedConn struct {
+	net.Conn
+	ctx     context.Context
+	cancel  context.CancelFunc
+	release func()
+	closed  sync.Once
+
+	// global and target are nil if their bandwidth is unlimited.
+	global  *rate.Limiter
+	target  *rate.Limiter
+	metrics *vaMetrics
+}
+
+func (c *shapedConn) Read(p []byte) (int, error) {
+	// Never read more than a limiter's burst at once, since it could never
+	// allow that many bytes.
+	for _, l := range []*rate.Limiter{c.global, c.target} {
+		if l != nil && len(p) > l.Burst() {
+			p = p[:l.Burst()]
+		}
+	}
+	n, err := c.Conn.Read(p)
+	if n == 0 {
+		return n, err
+	}
+	for _, l := range []struct {
+		name    string
+		limiter *rate.Limiter
+	}{
+		{"global", c.global},
+		{"target", c.target},
+	} {
+		if l.limiter == nil {
+			continue
+		}
+		r := l.limiter.ReserveN(time.Now(), n)
+		delay := r.Delay()
+		if delay == 0 {
+			continue
+		}
+		c.metrics.http01BandwidthWait.With(prometheus.Labels{"limit": l.name}).Add(delay.Seconds())
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-c.ctx.Done():
+			// The connection was closed while waiting.
+			timer.Stop()
+			r.Cancel()
+			return n, net.ErrClosed
+		}
+	}
+	return n, err
+}
+
+func (c *shapedConn) Close() error {
+	c.closed.Do(func() {
+		c.cancel()
+		c.release()
+	})
+	return c.Conn.Close()
+}
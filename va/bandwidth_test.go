@@ -0,0 +1,112 @@
+package va
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/identifier"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestSetHTTPBandwidth(t *testing.T) {
+	t.Parallel()
+
+	va, _ := setup(nil, "", nil, nil)
+	err := va.SetHTTPBandwidth(HTTPBandwidthConfig{})
+	test.AssertError(t, err, "config without limits accepted")
+	err = va.SetHTTPBandwidth(HTTPBandwidthConfig{GlobalBytesPerSecond: -1})
+	test.AssertError(t, err, "negative limit accepted")
+	err = va.SetHTTPBandwidth(HTTPBandwidthConfig{PerTargetBytesPerSecond: 1000})
+	test.AssertNotError(t, err, "valid config rejected")
+	test.Assert(t, va.httpBandwidth.global == nil, "unconfigured global limit was set")
+}
+
+func TestShapedConn(t *testing.T) {
+	t.Parallel()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	test.AssertNotError(t, err, "listening")
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			_, _ = conn.Write(make([]byte, 1500))
+			_ = conn.Close()
+		}
+	}()
+
+	va, _ := setup(nil, "", nil, nil)
+	err = va.SetHTTPBandwidth(HTTPBandwidthConfig{GlobalBytesPerSecond: 1 << 20, PerTargetBytesPerSecond: 1000})
+	test.AssertNotError(t, err, "configuring bandwidth limits")
+	var d net.Dialer
+	dial := va.shapeDialer(d.DialContext)
+
+	// The first 1000 bytes are read at once, but the rest must wait for the
+	// per-target limit.
+	conn, err := dial(context.Background(), "tcp", ln.Addr().String())
+	test.AssertNotError(t, err, "dialing")
+	test.AssertEquals(t, len(va.httpBandwidth.targets), 1)
+	start := time.Now()
+	n, err := io.Copy(io.Discard, conn)
+	test.AssertNotError(t, err, "reading")
+	test.AssertEquals(t, n, int64(1500))
+	test.Assert(t, time.Since(start) >= 400*time.Millisecond, "read wasn't limited")
+	test.AssertNotError(t, conn.Close(), "closing")
+	test.AssertEquals(t, len(va.httpBandwidth.targets), 0)
+	test.AssertMetricWithLabelsEquals(t, va.metrics.http01BandwidthWait, prometheus.Labels{"limit": "global"}, 0)
+
+	// A connection closed while a read is waiting is read no further.
+	conn, err = dial(context.Background(), "tcp", ln.Addr().String())
+	test.AssertNotError(t, err, "dialing")
+	done := make(chan error)
+	go func() {
+		_, err := io.Copy(io.Discard, conn)
+		done <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	_ = conn.Close()
+	select {
+	case err := <-done:
+		test.AssertErrorIs(t, err, net.ErrClosed)
+	case <-time.After(time.Second):
+		t.Fatal("read didn't stop when the connection was closed")
+	}
+}
+
+func TestHTTPBandwidthValidation(t *testing.T) {
+	t.Parallel()
+
+	token := core.NewToken()
+	hs := httpSrv(t, token, false)
+	defer hs.Close()
+
+	va, _ := setup(hs, "", nil, nil)
+	err := va.SetHTTPBandwidth(HTTPBandwidthConfig{PerTargetBytesPerSecond: 1 << 16, MaxResponseHeaderBytes: 1024})
+	test.AssertNotError(t, err, "configuring bandwidth limits")
+	_, err = va.validateHTTP01(ctx, identifier.NewDNS("localhost"), token, ka(token))
+	test.AssertNotError(t, err, "validation failed")
+
+	// A response whose headers are too large is rejected.
+	big := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Padding", strings.Repeat("a", 4096))
+		_, _ = w.Write([]byte(ka(token)))
+	}))
+	defer big.Close()
+	va, _ = setup(big, "", nil, nil)
+	err = va.SetHTTPBandwidth(HTTPBandwidthConfig{MaxResponseHeaderBytes: 1024})
+	test.AssertNotError(t, err, "configuring bandwidth limits")
+	_, err = va.validateHTTP01(ctx, identifier.NewDNS("localhost"), token, ka(token))
+	test.AssertError(t, err, "response with oversized headers accepted")
+}
@@ -14,6 +14,8 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/letsencrypt/boulder/bdns"
+	"github.com/letsencrypt/boulder/caa"
+	caapb "github.com/letsencrypt/boulder/caa/proto"
 	"github.com/letsencrypt/boulder/core"
 	corepb "github.com/letsencrypt/boulder/core/proto"
 	berrors "github.com/letsencrypt/boulder/errors"
@@ -82,10 +84,10 @@ func (va *ValidationAuthorityImpl) DoCAA(ctx context.Context, req *vapb.IsCAAVal
 			outcome = pass
 		}
 		// Observe local check latency (primary|remote).
-		va.observeLatency(opCAA, va.perspective, string(challType), probType, outcome, localLatency)
+		va.observeLatency(ctx, opCAA, va.perspective, string(challType), probType, outcome, localLatency)
 		if va.isPrimaryVA() {
 			// Observe total check latency (primary+remote).
-			va.observeLatency(opCAA, allPerspectives, string(challType), probType, outcome, va.clk.Since(start))
+			va.observeLatency(ctx, opCAA, allPerspectives, string(challType), probType, outcome, va.clk.Since(start))
 			logEvent.Summary = summary
 		}
 		// Log the total check latency.
@@ -252,6 +254,38 @@ func (va *ValidationAuthorityImpl) parallelCAALookup(ctx context.Context, name s
 	return results
 }
 
+// SetCAAChecker configures the VA to look up CAA records through the given
+// caa-checker service, which must be in the same perspective as the VA.
+func (va *ValidationAuthorityImpl) SetCAAChecker(client caapb.CAACheckerClient) {
+	va.caaChecker = client
+}
+
+// checkerCAALookup is like parallelCAALookup, but asks the caa-checker service
+// to perform the lookups. If the caa-checker can't be reached, the error is
+// attributed to the FQDN, so that the check fails.
+func (va *ValidationAuthorityImpl) checkerCAALookup(ctx context.Context, name string) []caaResult {
+	resp, err := va.caaChecker.LookupCAA(ctx, &caapb.LookupCAARequest{Hostname: name})
+	if err != nil {
+		return []caaResult{{name: name, err: fmt.Errorf("looking up CAA for %s: %w", name, err)}}
+	}
+	results := make([]caaResult, len(resp.Sets))
+	for i, set := range resp.Sets {
+		r := &results[i]
+		r.name = set.Name
+		r.dig = set.Dig
+		var records []*dns.CAA
+		records, r.resolvers, r.err = caa.SetToRecords(set)
+		if len(records) > 0 {
+			r.present = true
+		}
+		r.issue, r.issuewild, r.criticalUnknown = filterCAA(records)
+		if set.Cached {
+			va.metrics.caaCacheHits.Inc()
+		}
+	}
+	return results
+}
+
 // selectCAA picks the relevant CAA resource record set to be used, i.e. the set
 // for the "closest parent" of the FQDN in question, including the domain
 // itself. If we encountered an error for a lookup before we found a successful,
@@ -288,7 +322,12 @@ func (va *ValidationAuthorityImpl) getCAA(ctx context.Context, hostname string)
 	// the RPC call.
 	//
 	// We depend on our resolver to snap CNAME and DNAME records.
-	results := va.parallelCAALookup(ctx, hostname)
+	var results []caaResult
+	if va.caaChecker != nil {
+		results = va.checkerCAALookup(ctx, hostname)
+	} else {
+		results = va.parallelCAALookup(ctx, hostname)
+	}
 	return selectCAA(results)
 }
 
@@ -10,18 +10,23 @@ import (
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/miekg/dns"
 	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
 
 	"github.com/letsencrypt/boulder/bdns"
+	"github.com/letsencrypt/boulder/caa"
 	"github.com/letsencrypt/boulder/core"
 	berrors "github.com/letsencrypt/boulder/errors"
 	"github.com/letsencrypt/boulder/features"
 	"github.com/letsencrypt/boulder/identifier"
+	"github.com/letsencrypt/boulder/metrics"
 	"github.com/letsencrypt/boulder/probs"
 	"github.com/letsencrypt/boulder/test"
 
+	caapb "github.com/letsencrypt/boulder/caa/proto"
 	blog "github.com/letsencrypt/boulder/log"
 	vapb "github.com/letsencrypt/boulder/va/proto"
 )
@@ -30,8 +35,8 @@ import (
 // answers for CAA queries.
 type caaMockDNS struct{}
 
-func (mock caaMockDNS) LookupTXT(_ context.Context, hostname string) ([]string, bdns.ResolverAddrs, error) {
-	return nil, bdns.ResolverAddrs{"caaMockDNS"}, nil
+func (mock caaMockDNS) LookupTXT(_ context.Context, hostname string) ([]string, []string, bdns.ResolverAddrs, error) {
+	return nil, nil, bdns.ResolverAddrs{"caaMockDNS"}, nil
 }
 
 func (mock caaMockDNS) LookupHost(_ context.Context, hostname string) ([]netip.Addr, bdns.ResolverAddrs, error) {
@@ -595,8 +600,8 @@ var errCAABrokenDNSClient = errors.New("dnsClient is broken")
 // errors.
 type caaBrokenDNS struct{}
 
-func (b caaBrokenDNS) LookupTXT(_ context.Context, hostname string) ([]string, bdns.ResolverAddrs, error) {
-	return nil, bdns.ResolverAddrs{"caaBrokenDNS"}, errCAABrokenDNSClient
+func (b caaBrokenDNS) LookupTXT(_ context.Context, hostname string) ([]string, []string, bdns.ResolverAddrs, error) {
+	return nil, nil, bdns.ResolverAddrs{"caaBrokenDNS"}, errCAABrokenDNSClient
 }
 
 func (b caaBrokenDNS) LookupHost(_ context.Context, hostname string) ([]netip.Addr, bdns.ResolverAddrs, error) {
@@ -613,8 +618,8 @@ func (b caaBrokenDNS) LookupCAA(_ context.Context, domain string) ([]*dns.CAA, s
 // changed while queries were inflight.
 type caaHijackedDNS struct{}
 
-func (h caaHijackedDNS) LookupTXT(_ context.Context, hostname string) ([]string, bdns.ResolverAddrs, error) {
-	return nil, bdns.ResolverAddrs{"caaHijackedDNS"}, nil
+func (h caaHijackedDNS) LookupTXT(_ context.Context, hostname string) ([]string, []string, bdns.ResolverAddrs, error) {
+	return nil, nil, bdns.ResolverAddrs{"caaHijackedDNS"}, nil
 }
 
 func (h caaHijackedDNS) LookupHost(_ context.Context, hostname string) ([]netip.Addr, bdns.ResolverAddrs, error) {
@@ -1725,3 +1730,85 @@ func TestExtractIssuerDomainAndParameters(t *testing.T) {
 		})
 	}
 }
+
+// inProcessCAAChecker is a caapb.CAACheckerClient which calls a caa.Checker
+// directly, or fails every call with err if it's set.
+type inProcessCAAChecker struct {
+	checker *caa.Checker
+	err     error
+}
+
+func (c inProcessCAAChecker) LookupCAA(ctx context.Context, req *caapb.LookupCAARequest, _ ...grpc.CallOption) (*caapb.LookupCAAResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.checker.LookupCAA(ctx, req)
+}
+
+func TestCAACheckingWithChecker(t *testing.T) {
+	testCases := []struct {
+		Name    string
+		Domain  string
+		FoundAt string
+		Valid   bool
+	}{
+		{
+			Name:    "Bad (Reserved)",
+			Domain:  "reserved.com",
+			FoundAt: "reserved.com",
+			Valid:   false,
+		},
+		{
+			Name:    "Bad (NX Critical)",
+			Domain:  "nx.critical.com",
+			FoundAt: "critical.com",
+			Valid:   false,
+		},
+		{
+			Name:    "Good (absent)",
+			Domain:  "absent.com",
+			FoundAt: "",
+			Valid:   true,
+		},
+		{
+			Name:    "Good (present)",
+			Domain:  "www.present.com",
+			FoundAt: "present.com",
+			Valid:   true,
+		},
+		{
+			Name:    "Good (wildcard, present)",
+			Domain:  "*.present.com",
+			FoundAt: "present.com",
+			Valid:   true,
+		},
+	}
+
+	params := &caaParams{accountURIID: 123, validationMethod: core.ChallengeTypeHTTP01}
+
+	// The VA's own DNS client would find no CAA records at all, so any
+	// records found must have come from the caa-checker.
+	va, _ := setup(nil, "", nil, &bdns.MockClient{Log: blog.NewMock()})
+	checker := caa.NewChecker(caaMockDNS{}, nil, time.Hour, time.Hour, va.clk, metrics.NoopRegisterer, blog.NewMock())
+	va.SetCAAChecker(inProcessCAAChecker{checker: checker})
+
+	for _, caaTest := range testCases {
+		t.Run(caaTest.Name, func(t *testing.T) {
+			ident := identifier.NewDNS(caaTest.Domain)
+			foundAt, valid, _, err := va.checkCAARecords(ctx, ident, params)
+			test.AssertNotError(t, err, "checkCAARecords failed")
+			test.AssertEquals(t, foundAt, caaTest.FoundAt)
+			test.AssertEquals(t, valid, caaTest.Valid)
+		})
+	}
+
+	// Lookup failures reported by the caa-checker fail the check.
+	err := va.checkCAA(ctx, identifier.NewDNS("caa-timeout.com"), params)
+	test.AssertErrorIs(t, err, berrors.DNS)
+
+	// As does failing to reach the caa-checker at all.
+	va.SetCAAChecker(inProcessCAAChecker{err: errors.New("connection refused")})
+	err = va.checkCAA(ctx, identifier.NewDNS("present.com"), params)
+	test.AssertErrorIs(t, err, berrors.DNS)
+	test.AssertContains(t, err.Error(), "connection refused")
+}
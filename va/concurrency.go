@@ -0,0 +1,174 @@
+package va
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/identifier"
+)
+
+// ValidationConcurrencyConfig limits how many validations the VA performs at
+// once for any one identifier or account, so that a single large order or a
+// single abusive account can't monopolize its outbound connections.
+// Validations beyond a limit wait for an earlier one to finish.
+type ValidationConcurrencyConfig struct {
+	// PerIdentifier is the maximum number of concurrent validations of the
+	// same identifier. If zero, it's unlimited.
+	PerIdentifier int `validate:"omitempty,min=1"`
+
+	// PerAccount is the maximum number of concurrent validations requested
+	// by the same account. If zero, it's unlimited.
+	PerAccount int `validate:"omitempty,min=1"`
+
+	// MaxWait is how long a validation may wait for a slot before it fails.
+	// If zero, it waits until the request's deadline.
+	MaxWait config.Duration `validate:"-"`
+}
+
+// keyedSemaphore limits the number of concurrent holders of each key.
+type keyedSemaphore struct {
+	limit int
+
+	mu   sync.Mutex
+	sems map[string]*refCountedSemaphore
+}
+
+// refCountedSemaphore is the semaphore for a single key, and the number of
+// holders and waiters which refer to it. It's removed from its keyedSemaphore
+// when there are none.
+type refCountedSemaphore struct {
+	slots chan struct{}
+	refs  int
+}
+
+func newKeyedSemaphore(limit int) *keyedSemaphore {
+	return &keyedSemaphore{limit: limit, sems: make(map[string]*refCountedSemaphore)}
+}
+
+// acquire waits until a slot for key is available or ctx is done. On success,
+// it returns a function which must be called to release the slot.
+func (k *keyedSemaphore) acquire(ctx context.Context, key string) (func(), error) {
+	k.mu.Lock()
+	s, ok := k.sems[key]
+	if !ok {
+		s = &refCountedSemaphore{slots: make(chan struct{}, k.limit)}
+		k.sems[key] = s
+	}
+	s.refs++
+	k.mu.Unlock()
+
+	select {
+	case s.slots <- struct{}{}:
+		return func() {
+			<-s.slots
+			k.unref(key, s)
+		}, nil
+	case <-ctx.Done():
+		k.unref(key, s)
+		return nil, ctx.Err()
+	}
+}
+
+func (k *keyedSemaphore) unref(key string, s *refCountedSemaphore) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	s.refs--
+	if s.refs == 0 {
+		delete(k.sems, key)
+	}
+}
+
+// validationLimiter holds the per-identifier and per-account semaphores which
+// validations must acquire.
+type validationLimiter struct {
+	perIdentifier *keyedSemaphore
+	perAccount    *keyedSemaphore
+	maxWait       time.Duration
+}
+
+// errValidationConcurrency is returned when a validation gives up waiting for
+// a slot.
+var errValidationConcurrency = errors.New("too many concurrent validations")
+
+// SetValidationConcurrency configures the VA to limit the number of
+// validations it performs at once for each identifier and each account.
+func (va *ValidationAuthorityImpl) SetValidationConcurrency(c ValidationConcurrencyConfig) error {
+	if c.PerIdentifier < 0 || c.PerAccount < 0 {
+		return errors.New("validation concurrency limits must not be negative")
+	}
+	if c.PerIdentifier == 0 && c.PerAccount == 0 {
+		return errors.New("no validation concurrency limits configured")
+	}
+	if c.MaxWait.Duration < 0 {
+		return errors.New("validation concurrency maxWait must not be negative")
+	}
+	limiter := &validationLimiter{maxWait: c.MaxWait.Duration}
+	if c.PerIdentifier > 0 {
+		limiter.perIdentifier = newKeyedSemaphore(c.PerIdentifier)
+	}
+	if c.PerAccount > 0 {
+		limiter.perAccount = newKeyedSemaphore(c.PerAccount)
+	}
+	va.validationLimiter = limiter
+	return nil
+}
+
+// acquireValidationSlots waits until the validation of ident for the given
+// account may proceed, reporting how long it waited. On success, it returns a
+// function which must be called when the validation is complete. If no limits
+// are configured, it returns immediately.
+func (va *ValidationAuthorityImpl) acquireValidationSlots(ctx context.Context, ident identifier.ACMEIdentifier, regID int64) (func(), error) {
+	if va.validationLimiter == nil {
+		return func() {}, nil
+	}
+	l := va.validationLimiter
+	if l.maxWait > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.maxWait)
+		defer cancel()
+	}
+
+	var releases []func()
+	release := func() {
+		for _, r := range releases {
+			r()
+		}
+	}
+	for _, sem := range []struct {
+		name string
+		sem  *keyedSemaphore
+		key  string
+	}{
+		// Accounts are always acquired before identifiers, so that waiters
+		// can't deadlock each other.
+		{"account", l.perAccount, strconv.FormatInt(regID, 10)},
+		{"identifier", l.perIdentifier, fmt.Sprintf("%s:%s", ident.Type, ident.Value)},
+	} {
+		if sem.sem == nil {
+			continue
+		}
+		start := va.clk.Now()
+		r, err := sem.sem.acquire(ctx, sem.key)
+		result := "acquired"
+		if err != nil {
+			result = "timeout"
+		}
+		va.metrics.validationConcurrencyWait.With(prometheus.Labels{
+			"limit":  sem.name,
+			"result": result,
+		}).Observe(va.clk.Since(start).Seconds())
+		if err != nil {
+			release()
+			return nil, fmt.Errorf("%w for this %s", errValidationConcurrency, sem.name)
+		}
+		releases = append(releases, r)
+	}
+	return release, nil
+}
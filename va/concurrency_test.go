@@ -0,0 +1,137 @@
+package va
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/identifier"
+	"github.com/letsencrypt/boulder/probs"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestKeyedSemaphore(t *testing.T) {
+	t.Parallel()
+
+	sem := newKeyedSemaphore(2)
+	release1, err := sem.acquire(context.Background(), "a")
+	test.AssertNotError(t, err, "acquiring first slot")
+	release2, err := sem.acquire(context.Background(), "a")
+	test.AssertNotError(t, err, "acquiring second slot")
+
+	// Other keys are unaffected.
+	releaseB, err := sem.acquire(context.Background(), "b")
+	test.AssertNotError(t, err, "acquiring slot for another key")
+	releaseB()
+
+	// A third holder of the same key waits until the context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = sem.acquire(ctx, "a")
+	test.AssertErrorIs(t, err, context.DeadlineExceeded)
+
+	// ...or until a slot is released.
+	acquired := make(chan func())
+	go func() {
+		release, err := sem.acquire(context.Background(), "a")
+		if err == nil {
+			acquired <- release
+		}
+	}()
+	release1()
+	release3 := <-acquired
+
+	release2()
+	release3()
+	sem.mu.Lock()
+	test.AssertEquals(t, len(sem.sems), 0)
+	sem.mu.Unlock()
+}
+
+func TestSetValidationConcurrency(t *testing.T) {
+	t.Parallel()
+
+	va, _ := setup(nil, "", nil, nil)
+	err := va.SetValidationConcurrency(ValidationConcurrencyConfig{})
+	test.AssertError(t, err, "config without limits accepted")
+	err = va.SetValidationConcurrency(ValidationConcurrencyConfig{PerIdentifier: -1, PerAccount: 1})
+	test.AssertError(t, err, "negative limit accepted")
+	err = va.SetValidationConcurrency(ValidationConcurrencyConfig{PerAccount: 1, MaxWait: config.Duration{Duration: -time.Second}})
+	test.AssertError(t, err, "negative maxWait accepted")
+	err = va.SetValidationConcurrency(ValidationConcurrencyConfig{PerAccount: 1})
+	test.AssertNotError(t, err, "valid config rejected")
+	test.Assert(t, va.validationLimiter.perIdentifier == nil, "unconfigured identifier limit was set")
+}
+
+func TestAcquireValidationSlots(t *testing.T) {
+	t.Parallel()
+
+	va, _ := setup(nil, "", nil, nil)
+	release, err := va.acquireValidationSlots(context.Background(), identifier.NewDNS("example.com"), 1)
+	test.AssertNotError(t, err, "acquiring without limits")
+	release()
+
+	err = va.SetValidationConcurrency(ValidationConcurrencyConfig{
+		PerIdentifier: 1,
+		PerAccount:    2,
+		MaxWait:       config.Duration{Duration: 10 * time.Millisecond},
+	})
+	test.AssertNotError(t, err, "configuring validation concurrency")
+
+	release1, err := va.acquireValidationSlots(context.Background(), identifier.NewDNS("a.example.com"), 1)
+	test.AssertNotError(t, err, "acquiring first slot")
+
+	// The same identifier for another account exceeds the identifier limit.
+	_, err = va.acquireValidationSlots(context.Background(), identifier.NewDNS("a.example.com"), 2)
+	test.AssertErrorIs(t, err, errValidationConcurrency)
+	test.AssertContains(t, err.Error(), "for this identifier")
+
+	// The same account may validate another identifier, up to its limit.
+	release2, err := va.acquireValidationSlots(context.Background(), identifier.NewDNS("b.example.com"), 1)
+	test.AssertNotError(t, err, "acquiring second slot")
+	_, err = va.acquireValidationSlots(context.Background(), identifier.NewDNS("c.example.com"), 1)
+	test.AssertErrorIs(t, err, errValidationConcurrency)
+	test.AssertContains(t, err.Error(), "for this account")
+
+	// Failing to acquire the identifier slot released the account slot.
+	release2()
+	release2, err = va.acquireValidationSlots(context.Background(), identifier.NewDNS("b.example.com"), 1)
+	test.AssertNotError(t, err, "acquiring slot after release")
+	release1()
+	release2()
+
+	test.AssertMetricWithLabelsEquals(t, va.metrics.validationConcurrencyWait, prometheus.Labels{"limit": "account", "result": "acquired"}, 4)
+	test.AssertMetricWithLabelsEquals(t, va.metrics.validationConcurrencyWait, prometheus.Labels{"limit": "account", "result": "timeout"}, 1)
+	test.AssertMetricWithLabelsEquals(t, va.metrics.validationConcurrencyWait, prometheus.Labels{"limit": "identifier", "result": "acquired"}, 3)
+	test.AssertMetricWithLabelsEquals(t, va.metrics.validationConcurrencyWait, prometheus.Labels{"limit": "identifier", "result": "timeout"}, 1)
+}
+
+func TestDoDCVValidationConcurrency(t *testing.T) {
+	t.Parallel()
+
+	va, _ := setup(nil, "", nil, nil)
+	err := va.SetValidationConcurrency(ValidationConcurrencyConfig{
+		PerIdentifier: 1,
+		MaxWait:       config.Duration{Duration: 10 * time.Millisecond},
+	})
+	test.AssertNotError(t, err, "configuring validation concurrency")
+
+	ident := identifier.NewDNS("good-dns01.com")
+	release, err := va.acquireValidationSlots(context.Background(), ident, 2)
+	test.AssertNotError(t, err, "acquiring slot")
+
+	res, err := va.DoDCV(context.Background(), createValidationRequest(ident, core.ChallengeTypeDNS01))
+	test.AssertNotError(t, err, "DoDCV failed")
+	test.AssertNotNil(t, res.Problem, "validation beyond concurrency limit succeeded")
+	test.AssertEquals(t, res.Problem.ProblemType, string(probs.RateLimitedProblem))
+	test.AssertContains(t, res.Problem.Detail, "too many concurrent validations for this identifier")
+
+	release()
+	res, err = va.DoDCV(context.Background(), createValidationRequest(ident, core.ChallengeTypeDNS01))
+	test.AssertNotError(t, err, "DoDCV failed")
+	test.Assert(t, res.Problem == nil, "validation within concurrency limit failed")
+}
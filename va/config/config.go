@@ -5,6 +5,7 @@ import (
 
 	"github.com/letsencrypt/boulder/cmd"
 	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/va"
 )
 
 // Common contains all of the shared fields for a VA and a Remote VA (RVA).
@@ -27,8 +28,63 @@ type Common struct {
 	DNSStaticResolvers        []string        `validate:"required_without=DNSProvider,dive,hostname_port"`
 	DNSTimeout                config.Duration `validate:"required"`
 	DNSAllowLoopbackAddresses bool
+	// DNSTXTProvider or DNSTXTStaticResolvers, if set, selects the DNS
+	// resolvers used for the TXT lookups of DNS-01 validation, in the same way
+	// as DNSProvider or DNSStaticResolvers selects those used for every other
+	// lookup. This allows TXT lookups to be routed only through resolvers
+	// which validate DNSSEC, for example. If neither is set, TXT lookups use
+	// the same resolvers as every other lookup.
+	DNSTXTProvider        *cmd.DNSProvider `validate:"excluded_with=DNSTXTStaticResolvers"`
+	DNSTXTStaticResolvers []string         `validate:"omitempty,dive,hostname_port"`
+	// DNSMaxAliasDepth is the maximum number of CNAME or DNAME aliases which
+	// will be followed from the _acme-challenge name during DNS-01
+	// validation. If zero, a default of 8 is used.
+	DNSMaxAliasDepth int `validate:"omitempty,min=1"`
 
 	AccountURIPrefixes []string `validate:"min=1,dive,required,url"`
+
+	// ChallengePorts, if set, overrides the ports to which this VA connects
+	// when validating HTTP-01 and TLS-ALPN-01 challenges. A primary VA checks
+	// at startup that its remote VAs use the same ports, and refuses to start
+	// if any don't. If unset, ports 80 and 443 are used. The ports of the DNS
+	// resolvers are given by DNSProvider or DNSStaticResolvers.
+	ChallengePorts *va.ChallengePortsConfig
+
+	// CAAChecker, if set, configures a client for the caa-checker service,
+	// which then performs this VA's CAA lookups and shares their results with
+	// the other VAs which use it. The caa-checker must run in the same
+	// perspective as this VA. If unset, the VA looks up CAA records itself.
+	CAAChecker *cmd.GRPCClientConfig
+
+	// HTTPFingerprints, if set, are the combinations of User-Agent and TLS
+	// client parameters which the VA rotates among for successive HTTP-01
+	// validations, so that validations aren't all blocked by middleboxes
+	// which reject a particular fingerprint. The fingerprint used is recorded
+	// in each validation record. If unset, every validation uses UserAgent
+	// and Go's default TLS parameters.
+	HTTPFingerprints []va.HTTPFingerprintConfig `validate:"omitempty,dive"`
+
+	// HTTPRedirectPolicy, if set, restricts the scheme and port combinations
+	// to which HTTP-01 redirects are followed. If unset, redirects to port 80
+	// or 443 are followed, whatever their scheme.
+	HTTPRedirectPolicy *va.HTTPRedirectPolicyConfig
+
+	// ValidationConcurrency, if set, limits the number of validations this
+	// VA performs at once for each identifier and each account. Validations
+	// beyond a limit wait for a slot, and fail if none frees up in time. If
+	// unset, validations are limited only by the gRPC server.
+	ValidationConcurrency *va.ValidationConcurrencyConfig
+
+	// HTTPResponseArchive, if set, causes this VA to archive the final HTTP
+	// response received during each failed HTTP-01 validation, and to serve
+	// them to administrators by authorization ID using the
+	// va.HTTPResponseArchive gRPC service.
+	HTTPResponseArchive *va.HTTPResponseArchiveConfig
+
+	// HTTPBandwidth, if set, limits the rate at which this VA reads HTTP-01
+	// responses, in total and from each IP address, and the size of their
+	// headers. If unset, responses are read as fast as they arrive.
+	HTTPBandwidth *va.HTTPBandwidthConfig
 }
 
 // SetDefaultsAndValidate performs some basic sanity checks on fields stored in
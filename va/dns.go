@@ -7,6 +7,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"net/netip"
+	"strings"
 
 	"github.com/letsencrypt/boulder/bdns"
 	"github.com/letsencrypt/boulder/core"
@@ -59,24 +60,29 @@ func (va *ValidationAuthorityImpl) validateDNS01(ctx context.Context, ident iden
 	h.Write([]byte(keyAuthorization))
 	authorizedKeysDigest := base64.RawURLEncoding.EncodeToString(h.Sum(nil))
 
-	// Look for the required record in the DNS
+	// Look for the required record in the DNS, following any aliases.
 	challengeSubdomain := fmt.Sprintf("%s.%s", core.DNSPrefix, ident.Value)
-	txts, resolvers, err := va.dnsClient.LookupTXT(ctx, challengeSubdomain)
+	txts, chain, resolvers, err := va.lookupTXTFollowingAliases(ctx, challengeSubdomain)
 	if err != nil {
-		return nil, berrors.DNSError("%s", err)
+		return nil, err
 	}
+	via := describeAliasChain(chain)
 
 	// If there weren't any TXT records return a distinct error message to allow
 	// troubleshooters to differentiate between no TXT records and
 	// invalid/incorrect TXT records.
 	if len(txts) == 0 {
-		return nil, berrors.UnauthorizedError("No TXT record found at %s", challengeSubdomain)
+		return nil, berrors.UnauthorizedError("No TXT record found at %s%s", challengeSubdomain, via)
 	}
 
 	for _, element := range txts {
 		if subtle.ConstantTimeCompare([]byte(element), []byte(authorizedKeysDigest)) == 1 {
 			// Successful challenge validation
-			return []core.ValidationRecord{{Hostname: ident.Value, ResolverAddrs: resolvers}}, nil
+			record := core.ValidationRecord{Hostname: ident.Value, ResolverAddrs: resolvers}
+			if len(chain) > 1 {
+				record.AliasChain = chain
+			}
+			return []core.ValidationRecord{record}, nil
 		}
 	}
 
@@ -88,6 +94,53 @@ func (va *ValidationAuthorityImpl) validateDNS01(ctx context.Context, ident iden
 	if len(txts) > 1 {
 		andMore = fmt.Sprintf(" (and %d more)", len(txts)-1)
 	}
-	return nil, berrors.UnauthorizedError("Incorrect TXT record %q%s found at %s",
-		invalidRecord, andMore, challengeSubdomain)
+	return nil, berrors.UnauthorizedError("Incorrect TXT record %q%s found at %s%s",
+		invalidRecord, andMore, challengeSubdomain, via)
+}
+
+// lookupTXTFollowingAliases looks up the TXT records for name. If name is an
+// alias, the chain of names followed is returned, starting with name itself.
+// Aliases are usually followed by the resolver, which returns them along with
+// the TXT records of the final name, but if it returns aliases without any
+// TXT records the final name is queried again. An error is returned if the
+// aliases loop or there are more than va.maxDNSAliasDepth of them.
+func (va *ValidationAuthorityImpl) lookupTXTFollowingAliases(ctx context.Context, name string) ([]string, []string, bdns.ResolverAddrs, error) {
+	chain := []string{name}
+	seen := map[string]bool{strings.ToLower(name): true}
+	var allResolvers bdns.ResolverAddrs
+	for {
+		txts, aliases, resolvers, err := va.dnsClient.LookupTXT(ctx, chain[len(chain)-1])
+		allResolvers = append(allResolvers, resolvers...)
+		if err != nil {
+			return nil, nil, allResolvers, berrors.DNSError("%s%s", err, describeAliasChain(chain))
+		}
+		for _, alias := range aliases {
+			chain = append(chain, alias)
+			if seen[strings.ToLower(alias)] {
+				va.log.Infof("DNS alias loop for %s: %s", name, strings.Join(chain, " -> "))
+				return nil, nil, allResolvers, berrors.DNSError("Loop in CNAME/DNAME aliases for %s: %s", name, strings.Join(chain, " -> "))
+			}
+			seen[strings.ToLower(alias)] = true
+			if len(chain)-1 > va.maxDNSAliasDepth {
+				va.log.Infof("Too many DNS aliases for %s: %s", name, strings.Join(chain, " -> "))
+				return nil, nil, allResolvers, berrors.DNSError("More than %d CNAME/DNAME aliases for %s%s", va.maxDNSAliasDepth, name, describeAliasChain(chain))
+			}
+		}
+		if len(txts) > 0 || len(aliases) == 0 {
+			if len(chain) > 1 {
+				va.log.Debugf("Followed DNS aliases for %s: %s", name, strings.Join(chain, " -> "))
+			}
+			return txts, chain, allResolvers, nil
+		}
+	}
+}
+
+// describeAliasChain returns a suffix for error messages which describes the
+// aliases followed from the first name in chain, or the empty string if there
+// were none.
+func describeAliasChain(chain []string) string {
+	if len(chain) < 2 {
+		return ""
+	}
+	return fmt.Sprintf(" (via %s)", strings.Join(chain, " -> "))
 }
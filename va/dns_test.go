@@ -125,6 +125,44 @@ func TestDNSValidationNoAuthorityOK(t *testing.T) {
 	test.Assert(t, prob == nil, "Should be valid.")
 }
 
+func TestDNSValidationAliases(t *testing.T) {
+	va, _ := setup(nil, "", nil, nil)
+
+	// The resolver followed the alias and returned the TXT record.
+	records, err := va.validateDNS01(ctx, identifier.NewDNS("good-cname-dns01.com"), expectedKeyAuthorization)
+	test.AssertNotError(t, err, "Should be valid")
+	test.AssertDeepEquals(t, records[0].AliasChain, []string{"_acme-challenge.good-cname-dns01.com", "good-dns01.delegated.com"})
+
+	// The resolver returned aliases without a TXT record, so the final name
+	// was queried explicitly.
+	records, err = va.validateDNS01(ctx, identifier.NewDNS("chase-cname-dns01.com"), expectedKeyAuthorization)
+	test.AssertNotError(t, err, "Should be valid")
+	test.AssertDeepEquals(t, records[0].AliasChain, []string{"_acme-challenge.chase-cname-dns01.com", "one.delegated.com", "_acme-challenge.good-dns01.com"})
+	test.AssertEquals(t, len(records[0].ResolverAddrs), 2)
+
+	// Names which aren't aliases have no chain recorded.
+	records, err = va.validateDNS01(ctx, identifier.NewDNS("good-dns01.com"), expectedKeyAuthorization)
+	test.AssertNotError(t, err, "Should be valid")
+	test.AssertEquals(t, len(records[0].AliasChain), 0)
+
+	_, err = va.validateDNS01(ctx, identifier.NewDNS("loop-cname-dns01.com"), expectedKeyAuthorization)
+	prob := detailedError(err)
+	test.AssertEquals(t, prob.Type, probs.DNSProblem)
+	test.AssertEquals(t, prob.Detail, "Loop in CNAME/DNAME aliases for _acme-challenge.loop-cname-dns01.com: _acme-challenge.loop-cname-dns01.com -> loop.delegated.com -> _acme-challenge.loop-cname-dns01.com")
+
+	_, err = va.validateDNS01(ctx, identifier.NewDNS("deep-cname-dns01.com"), expectedKeyAuthorization)
+	prob = detailedError(err)
+	test.AssertEquals(t, prob.Type, probs.DNSProblem)
+	test.AssertContains(t, prob.Detail, "More than 8 CNAME/DNAME aliases for _acme-challenge.deep-cname-dns01.com")
+
+	// A higher limit allows the longer chain to be followed to its end.
+	va.maxDNSAliasDepth = 9
+	_, err = va.validateDNS01(ctx, identifier.NewDNS("deep-cname-dns01.com"), expectedKeyAuthorization)
+	prob = detailedError(err)
+	test.AssertEquals(t, prob.Type, probs.UnauthorizedProblem)
+	test.AssertEquals(t, prob.Detail, "Incorrect TXT record \"hostname\" found at _acme-challenge.deep-cname-dns01.com (via _acme-challenge.deep-cname-dns01.com -> 1.delegated.com -> 2.delegated.com -> 3.delegated.com -> 4.delegated.com -> 5.delegated.com -> 6.delegated.com -> 7.delegated.com -> 8.delegated.com -> 9.delegated.com)")
+}
+
 func TestAvailableAddresses(t *testing.T) {
 	v6a := netip.MustParseAddr("::1")
 	v6b := netip.MustParseAddr("2001:db8::2:1") // 2001:DB8 is reserved for docs (RFC 3849)
@@ -0,0 +1,41 @@
+package va
+
+import (
+	"context"
+
+	"github.com/letsencrypt/boulder/core"
+	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/identifier"
+)
+
+// EmailReplyValidator performs the email-reply-00 challenge specified in RFC
+// 8823. Unlike the other challenges, it can't be completed by a single
+// outbound request: the CA sends a challenge email to the address, and
+// validation succeeds once a reply containing the key authorization has been
+// received from it. Implementations are responsible for sending the challenge
+// email and for authenticating the reply.
+type EmailReplyValidator interface {
+	// ValidateEmailReply returns a validation record, whose Hostname is the
+	// domain of the address from which the reply was received, if the
+	// expected reply to the challenge identified by token has been received.
+	// Otherwise it returns an error, which should be a berrors.BoulderError
+	// if it describes a problem with the reply.
+	ValidateEmailReply(ctx context.Context, ident identifier.ACMEIdentifier, token string, keyAuthorization string) ([]core.ValidationRecord, error)
+}
+
+// SetEmailReplyValidator configures the VA to validate email-reply-00
+// challenges with v. Without one, all such validations fail.
+func (va *ValidationAuthorityImpl) SetEmailReplyValidator(v EmailReplyValidator) {
+	va.emailReplyValidator = v
+}
+
+func (va *ValidationAuthorityImpl) validateEmailReply00(ctx context.Context, ident identifier.ACMEIdentifier, token string, keyAuthorization string) ([]core.ValidationRecord, error) {
+	if ident.Type != identifier.TypeEmail {
+		va.log.Infof("Identifier type for email-reply-00 challenge was not email: %s", ident)
+		return nil, berrors.MalformedError("Identifier type for email-reply-00 challenge was not email")
+	}
+	if va.emailReplyValidator == nil {
+		return nil, berrors.MalformedError("email-reply-00 challenges are not supported by this VA")
+	}
+	return va.emailReplyValidator.ValidateEmailReply(ctx, ident, token, keyAuthorization)
+}
@@ -0,0 +1,45 @@
+package va
+
+import (
+	"context"
+	"testing"
+
+	"github.com/letsencrypt/boulder/core"
+	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/identifier"
+	"github.com/letsencrypt/boulder/probs"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// fakeEmailReplyValidator accepts replies to the challenge with token
+// expectedToken.
+type fakeEmailReplyValidator struct{}
+
+func (fakeEmailReplyValidator) ValidateEmailReply(_ context.Context, ident identifier.ACMEIdentifier, token string, keyAuthorization string) ([]core.ValidationRecord, error) {
+	if token != expectedToken || keyAuthorization != expectedKeyAuthorization {
+		return nil, berrors.UnauthorizedError("no reply received for %s", ident.Value)
+	}
+	return []core.ValidationRecord{{Hostname: "example.com"}}, nil
+}
+
+func TestValidateEmailReply00(t *testing.T) {
+	t.Parallel()
+	va, _ := setup(nil, "", nil, nil)
+	email := identifier.NewEmail("alice@example.com")
+
+	// Without a validator, email-reply-00 isn't supported.
+	_, err := va.validateChallenge(ctx, email, core.ChallengeTypeEmailReply00, expectedToken, expectedKeyAuthorization)
+	test.AssertEquals(t, detailedError(err).Type, probs.MalformedProblem)
+
+	va.SetEmailReplyValidator(fakeEmailReplyValidator{})
+
+	_, err = va.validateChallenge(ctx, identifier.NewDNS("example.com"), core.ChallengeTypeEmailReply00, expectedToken, expectedKeyAuthorization)
+	test.AssertEquals(t, detailedError(err).Type, probs.MalformedProblem)
+
+	_, err = va.validateChallenge(ctx, email, core.ChallengeTypeEmailReply00, "wrong-token", expectedKeyAuthorization)
+	test.AssertEquals(t, detailedError(err).Type, probs.UnauthorizedProblem)
+
+	records, err := va.validateChallenge(ctx, email, core.ChallengeTypeEmailReply00, expectedToken, expectedKeyAuthorization)
+	test.AssertNotError(t, err, "validating email-reply-00")
+	test.Assert(t, core.Challenge{Type: core.ChallengeTypeEmailReply00, ValidationRecord: records}.RecordsSane(), "records not sane")
+}
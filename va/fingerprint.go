@@ -0,0 +1,145 @@
+package va
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"slices"
+	"sync/atomic"
+)
+
+// HTTPFingerprintConfig describes one combination of User-Agent and TLS client
+// parameters with which the VA may make HTTP-01 requests. The TLS parameters
+// only matter when a validation is redirected to HTTPS.
+type HTTPFingerprintConfig struct {
+	// Name identifies the fingerprint in validation records, logs, and
+	// metrics.
+	Name string `validate:"required,printascii,max=64"`
+
+	// UserAgent is the "User-Agent" header sent with requests. If empty, the
+	// VA's UserAgent is used.
+	UserAgent string
+
+	// ALPN is the list of protocols offered by ALPN, in order of preference.
+	// If empty, none are offered.
+	ALPN []string `validate:"omitempty,dive,oneof=h2 http/1.1"`
+
+	// CipherSuites is the list of TLS 1.2 cipher suites offered, by their
+	// IANA names, e.g. "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256". TLS 1.3
+	// cipher suites aren't configurable. If empty, Go's defaults are offered.
+	CipherSuites []string
+
+	// Curves is the list of key exchange mechanisms offered, in order of
+	// preference, by their Go names, e.g. "X25519" or "CurveP256". If empty,
+	// Go's defaults are offered.
+	Curves []string
+
+	// MaxTLSVersion is the highest version of TLS offered, either "1.2" or
+	// "1.3". If empty, it's TLS 1.3.
+	MaxTLSVersion string `validate:"omitempty,oneof=1.2 1.3"`
+}
+
+// httpFingerprint is a parsed HTTPFingerprintConfig.
+type httpFingerprint struct {
+	name      string
+	userAgent string
+	tlsConfig *tls.Config
+	// h2 is true if HTTP/2 is offered by ALPN, in which case the transport
+	// must be prepared to speak it.
+	h2 bool
+}
+
+// httpFingerprintRotation hands out a list of fingerprints in turn.
+type httpFingerprintRotation struct {
+	fingerprints []*httpFingerprint
+	next         atomic.Uint64
+}
+
+var curvesByName = func() map[string]tls.CurveID {
+	curves := make(map[string]tls.CurveID)
+	for _, c := range []tls.CurveID{tls.X25519MLKEM768, tls.X25519, tls.CurveP256, tls.CurveP384, tls.CurveP521} {
+		curves[c.String()] = c
+	}
+	return curves
+}()
+
+func newHTTPFingerprint(c HTTPFingerprintConfig) (*httpFingerprint, error) {
+	tlsConfig := &tls.Config{
+		// We are talking to a client that does not yet have a certificate,
+		// so we accept a temporary, invalid one.
+		InsecureSkipVerify: true,
+		NextProtos:         c.ALPN,
+	}
+
+	for _, name := range c.CipherSuites {
+		i := slices.IndexFunc(tls.CipherSuites(), func(cs *tls.CipherSuite) bool { return cs.Name == name })
+		if i < 0 {
+			return nil, fmt.Errorf("fingerprint %q: unsupported cipher suite %q", c.Name, name)
+		}
+		tlsConfig.CipherSuites = append(tlsConfig.CipherSuites, tls.CipherSuites()[i].ID)
+	}
+
+	for _, name := range c.Curves {
+		curve, ok := curvesByName[name]
+		if !ok {
+			return nil, fmt.Errorf("fingerprint %q: unsupported curve %q", c.Name, name)
+		}
+		tlsConfig.CurvePreferences = append(tlsConfig.CurvePreferences, curve)
+	}
+
+	switch c.MaxTLSVersion {
+	case "1.2":
+		tlsConfig.MaxVersion = tls.VersionTLS12
+	case "", "1.3":
+		tlsConfig.MaxVersion = tls.VersionTLS13
+	default:
+		return nil, fmt.Errorf("fingerprint %q: unsupported TLS version %q", c.Name, c.MaxTLSVersion)
+	}
+
+	return &httpFingerprint{
+		name:      c.Name,
+		userAgent: c.UserAgent,
+		tlsConfig: tlsConfig,
+		h2:        slices.Contains(c.ALPN, "h2"),
+	}, nil
+}
+
+// SetHTTPFingerprints configures the VA to rotate among the given fingerprints
+// for successive HTTP-01 validations, recording the name of the one used in
+// each validation record. This allows validations to get past middleboxes
+// which block requests with a fixed fingerprint, and makes those which do
+// identifiable by their failure rates.
+func (va *ValidationAuthorityImpl) SetHTTPFingerprints(configs []HTTPFingerprintConfig) error {
+	if len(configs) == 0 {
+		return errors.New("no HTTP fingerprints configured")
+	}
+	var fingerprints []*httpFingerprint
+	for _, c := range configs {
+		for _, fp := range fingerprints {
+			if fp.name == c.Name {
+				return fmt.Errorf("duplicate HTTP fingerprint %q", c.Name)
+			}
+		}
+		fp, err := newHTTPFingerprint(c)
+		if err != nil {
+			return err
+		}
+		if fp.userAgent == "" {
+			fp.userAgent = va.userAgent
+		}
+		fingerprints = append(fingerprints, fp)
+	}
+	va.httpFingerprints = &httpFingerprintRotation{fingerprints: fingerprints}
+	return nil
+}
+
+// nextHTTPFingerprint returns the fingerprint to use for the next HTTP-01
+// validation, or nil if none are configured.
+func (va *ValidationAuthorityImpl) nextHTTPFingerprint() *httpFingerprint {
+	if va.httpFingerprints == nil {
+		return nil
+	}
+	fingerprints := va.httpFingerprints.fingerprints
+	i := va.httpFingerprints.next.Add(1) - 1
+	return fingerprints[i%uint64(len(fingerprints))]
+}
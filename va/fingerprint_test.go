@@ -0,0 +1,153 @@
+package va
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/identifier"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestNewHTTPFingerprint(t *testing.T) {
+	t.Parallel()
+
+	fp, err := newHTTPFingerprint(HTTPFingerprintConfig{
+		Name:          "browser",
+		UserAgent:     "Mozilla/5.0",
+		ALPN:          []string{"h2", "http/1.1"},
+		CipherSuites:  []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+		Curves:        []string{"X25519", "CurveP256"},
+		MaxTLSVersion: "1.2",
+	})
+	test.AssertNotError(t, err, "newHTTPFingerprint failed")
+	test.AssertEquals(t, fp.name, "browser")
+	test.AssertEquals(t, fp.userAgent, "Mozilla/5.0")
+	test.Assert(t, fp.h2, "h2 should be offered")
+	test.AssertDeepEquals(t, fp.tlsConfig.NextProtos, []string{"h2", "http/1.1"})
+	test.AssertDeepEquals(t, fp.tlsConfig.CipherSuites, []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256})
+	test.AssertDeepEquals(t, fp.tlsConfig.CurvePreferences, []tls.CurveID{tls.X25519, tls.CurveP256})
+	test.AssertEquals(t, fp.tlsConfig.MaxVersion, uint16(tls.VersionTLS12))
+	test.Assert(t, fp.tlsConfig.InsecureSkipVerify, "certificates should not be verified")
+
+	fp, err = newHTTPFingerprint(HTTPFingerprintConfig{Name: "defaults"})
+	test.AssertNotError(t, err, "newHTTPFingerprint failed")
+	test.Assert(t, !fp.h2, "h2 should not be offered")
+	test.AssertEquals(t, fp.tlsConfig.MaxVersion, uint16(tls.VersionTLS13))
+
+	_, err = newHTTPFingerprint(HTTPFingerprintConfig{Name: "bad", CipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}})
+	test.AssertError(t, err, "insecure cipher suite should be rejected")
+
+	_, err = newHTTPFingerprint(HTTPFingerprintConfig{Name: "bad", Curves: []string{"P-256"}})
+	test.AssertError(t, err, "unknown curve should be rejected")
+
+	_, err = newHTTPFingerprint(HTTPFingerprintConfig{Name: "bad", MaxTLSVersion: "1.1"})
+	test.AssertError(t, err, "unsupported TLS version should be rejected")
+}
+
+func TestSetHTTPFingerprints(t *testing.T) {
+	t.Parallel()
+
+	va, _ := setup(nil, "", nil, nil)
+
+	err := va.SetHTTPFingerprints(nil)
+	test.AssertError(t, err, "empty fingerprints should be rejected")
+
+	err = va.SetHTTPFingerprints([]HTTPFingerprintConfig{{Name: "a"}, {Name: "a"}})
+	test.AssertError(t, err, "duplicate fingerprints should be rejected")
+
+	err = va.SetHTTPFingerprints([]HTTPFingerprintConfig{{Name: "a", UserAgent: "agent a"}, {Name: "b"}})
+	test.AssertNotError(t, err, "SetHTTPFingerprints failed")
+
+	// Fingerprints without a User-Agent use the VA's.
+	var names, userAgents []string
+	for range 3 {
+		fp := va.nextHTTPFingerprint()
+		names = append(names, fp.name)
+		userAgents = append(userAgents, fp.userAgent)
+	}
+	test.AssertDeepEquals(t, names, []string{"a", "b", "a"})
+	test.AssertDeepEquals(t, userAgents, []string{"agent a", va.userAgent, "agent a"})
+}
+
+func TestHTTPFingerprintValidation(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var userAgents []string
+	hs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		userAgents = append(userAgents, r.UserAgent())
+		mu.Unlock()
+		if r.UserAgent() == "blocked agent" {
+			http.Error(w, "go away", http.StatusForbidden)
+			return
+		}
+		fmt.Fprint(w, expectedKeyAuthorization)
+	}))
+	defer hs.Close()
+
+	va, log := setup(hs, "", nil, nil)
+	err := va.SetHTTPFingerprints([]HTTPFingerprintConfig{
+		{Name: "default"},
+		{Name: "blocked", UserAgent: "blocked agent"},
+	})
+	test.AssertNotError(t, err, "SetHTTPFingerprints failed")
+
+	records, err := va.validateHTTP01(ctx, identifier.NewDNS("localhost.com"), expectedToken, expectedKeyAuthorization)
+	test.AssertNotError(t, err, "validation with default fingerprint failed")
+	test.AssertEquals(t, records[0].Fingerprint, "default")
+	test.AssertEquals(t, len(log.GetAllMatching(`\[AUDIT\] .* using fingerprint "default"`)), 1)
+
+	records, err = va.validateHTTP01(ctx, identifier.NewDNS("localhost.com"), expectedToken, expectedKeyAuthorization)
+	test.AssertError(t, err, "validation with blocked fingerprint should have failed")
+	test.AssertEquals(t, records[0].Fingerprint, "blocked")
+
+	test.AssertDeepEquals(t, userAgents, []string{va.userAgent, "blocked agent"})
+	test.AssertMetricWithLabelsEquals(t, va.metrics.http01FingerprintResults, prometheus.Labels{"fingerprint": "default", "result": pass}, 1)
+	test.AssertMetricWithLabelsEquals(t, va.metrics.http01FingerprintResults, prometheus.Labels{"fingerprint": "blocked", "result": fail}, 1)
+}
+
+func TestHTTPFingerprintHTTPSRedirect(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var protos []string
+	hss := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		protos = append(protos, r.Proto)
+		mu.Unlock()
+		fmt.Fprint(w, expectedKeyAuthorization)
+	}))
+	hss.EnableHTTP2 = true
+	hss.StartTLS()
+	defer hss.Close()
+
+	hs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, fmt.Sprintf("https://localhost.com:%d%s", getPort(hss), r.URL.Path), http.StatusMovedPermanently)
+	}))
+	defer hs.Close()
+
+	va, _ := setup(hs, "", nil, nil)
+	va.httpsPort = getPort(hss)
+	err := va.SetHTTPFingerprints([]HTTPFingerprintConfig{
+		{Name: "h2", ALPN: []string{"h2", "http/1.1"}},
+		{Name: "http1", ALPN: []string{"http/1.1"}, MaxTLSVersion: "1.2"},
+	})
+	test.AssertNotError(t, err, "SetHTTPFingerprints failed")
+
+	for _, name := range []string{"h2", "http1"} {
+		records, err := va.validateHTTP01(ctx, identifier.NewDNS("localhost.com"), expectedToken, expectedKeyAuthorization)
+		test.AssertNotError(t, err, fmt.Sprintf("validation with fingerprint %q failed", name))
+		test.AssertEquals(t, len(records), 2)
+		for _, record := range records {
+			test.AssertEquals(t, record.Fingerprint, name)
+		}
+	}
+	test.AssertDeepEquals(t, protos, []string{"HTTP/2.0", "HTTP/1.1"})
+}
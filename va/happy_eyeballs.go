@@ -0,0 +1,149 @@
+package va
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// defaultHappyEyeballsDelay is how long the VA waits for a connection to a
+// host's preferred address before also trying its fallback address. It's the
+// "Connection Attempt Delay" recommended by RFC 8305, Section 5.
+const defaultHappyEyeballsDelay = 250 * time.Millisecond
+
+// happyEyeballsDialer connects to a validation target's preferred (IPv6)
+// address and, if that attempt hasn't succeeded within delay or fails sooner,
+// races it against an attempt to the target's fallback (IPv4) address. The
+// first connection to be established is used, and the other is closed. This
+// is the "Happy Eyeballs" algorithm of RFC 8305, simplified for targets which
+// have at most one address of each family.
+//
+// The dialer remembers which address its most recent dial connected to, and
+// which it tried and abandoned first, so that they can be reported in the
+// validation record for the request it was dialed for.
+type happyEyeballsDialer struct {
+	primary *preresolvedDialer
+	// fallback is nil if the target has addresses of only one family.
+	fallback *preresolvedDialer
+	delay    time.Duration
+	// fallbacks counts dials to the fallback address.
+	fallbacks prometheus.Counter
+
+	mu    sync.Mutex
+	used  netip.Addr
+	tried []netip.Addr
+}
+
+// dialResult is the outcome of a single connection attempt made by a
+// happyEyeballsDialer.
+type dialResult struct {
+	ip   netip.Addr
+	conn net.Conn
+	err  error
+}
+
+// DialContext meets the dialerFunc signature. Both connection attempts are
+// made using the dialers' pre-resolved addresses, so origAddr is only used to
+// check that the dialer isn't being used for the wrong host.
+func (d *happyEyeballsDialer) DialContext(ctx context.Context, network, origAddr string) (net.Conn, error) {
+	if d.fallback == nil {
+		conn, err := d.primary.DialContext(ctx, network, origAddr)
+		d.setResult(d.primary.ip, nil)
+		return conn, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Both sends are buffered so that an abandoned attempt can always finish.
+	results := make(chan dialResult, 2)
+	dial := func(pd *preresolvedDialer) {
+		conn, err := pd.DialContext(ctx, network, origAddr)
+		results <- dialResult{ip: pd.ip, conn: conn, err: err}
+	}
+
+	go dial(d.primary)
+	pending := 1
+	fallbackStarted := false
+	startFallback := func() {
+		fallbackStarted = true
+		pending++
+		d.fallbacks.Inc()
+		go dial(d.fallback)
+	}
+	defer func(pending *int) {
+		// Close the connection made by an abandoned attempt, if it's
+		// established before the cancellation of ctx is noticed.
+		for range *pending {
+			go func() {
+				abandoned := <-results
+				if abandoned.conn != nil {
+					_ = abandoned.conn.Close()
+				}
+			}()
+		}
+	}(&pending)
+
+	timer := time.NewTimer(d.delay)
+	defer timer.Stop()
+
+	var lastErr error
+	for pending > 0 {
+		select {
+		case <-timer.C:
+			if !fallbackStarted {
+				startFallback()
+			}
+		case res := <-results:
+			pending--
+			if res.err == nil {
+				if res.ip == d.primary.ip {
+					d.setResult(res.ip, nil)
+				} else {
+					d.setResult(res.ip, []netip.Addr{d.primary.ip})
+				}
+				return res.conn, nil
+			}
+
+			if res.ip == d.fallback.ip {
+				lastErr = res.err
+				continue
+			}
+			// By policy, only dial errors (not, for instance, a
+			// dialerMismatchError) are eligible for fallback.
+			if !fallbackErr(res.err) {
+				d.setResult(d.primary.ip, nil)
+				return nil, res.err
+			}
+			if !fallbackStarted {
+				startFallback()
+			}
+		}
+	}
+
+	// Both attempts failed. Report the fallback's error, since it's the last
+	// address which was tried.
+	d.setResult(d.fallback.ip, []netip.Addr{d.primary.ip})
+	return nil, lastErr
+}
+
+// setResult records the outcome of a dial.
+func (d *happyEyeballsDialer) setResult(used netip.Addr, tried []netip.Addr) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.used = used
+	d.tried = tried
+}
+
+// result returns the address which the most recent dial connected to, or
+// tried last, and any addresses which were tried and abandoned before it. The
+// returned address is the zero value if the dialer hasn't been used.
+func (d *happyEyeballsDialer) result() (netip.Addr, []netip.Addr) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.used, d.tried
+}
@@ -0,0 +1,129 @@
+package va
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+// closedPort returns a port on which nothing is listening, on either of the
+// loopback addresses.
+func closedPort(t *testing.T) int {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	test.AssertNotError(t, err, "listening")
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+	return port
+}
+
+// loopbackListener accepts connections on 127.0.0.1 until the test ends, and
+// returns its port.
+func loopbackListener(t *testing.T) int {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	test.AssertNotError(t, err, "listening")
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+func newTestHappyEyeballsDialer(primary, fallback string, port int) *happyEyeballsDialer {
+	d := &happyEyeballsDialer{
+		primary: &preresolvedDialer{
+			ip:       netip.MustParseAddr(primary),
+			port:     port,
+			hostname: "example.com",
+			timeout:  time.Second,
+		},
+		delay:     time.Minute,
+		fallbacks: prometheus.NewCounter(prometheus.CounterOpts{Name: "fallbacks"}),
+	}
+	if fallback != "" {
+		d.fallback = &preresolvedDialer{
+			ip:       netip.MustParseAddr(fallback),
+			port:     port,
+			hostname: "example.com",
+			timeout:  time.Second,
+		}
+	}
+	return d
+}
+
+func TestHappyEyeballsDialer(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	port := loopbackListener(t)
+
+	// The preferred address works, so the fallback is never tried.
+	d := newTestHappyEyeballsDialer("127.0.0.1", "::1", port)
+	conn, err := d.DialContext(ctx, "tcp", "example.com:80")
+	test.AssertNotError(t, err, "dialing a working address")
+	conn.Close()
+	used, tried := d.result()
+	test.AssertEquals(t, used, netip.MustParseAddr("127.0.0.1"))
+	test.AssertEquals(t, len(tried), 0)
+	test.AssertMetricWithLabelsEquals(t, d.fallbacks, prometheus.Labels{}, 0)
+
+	// The preferred address refuses the connection, so the fallback is tried
+	// straight away, without waiting for the delay.
+	d = newTestHappyEyeballsDialer("::1", "127.0.0.1", port)
+	started := time.Now()
+	conn, err = d.DialContext(ctx, "tcp", "example.com:80")
+	test.AssertNotError(t, err, "dialing with a broken preferred address")
+	conn.Close()
+	test.Assert(t, time.Since(started) < d.delay, "fallback waited for the delay")
+	used, tried = d.result()
+	test.AssertEquals(t, used, netip.MustParseAddr("127.0.0.1"))
+	test.AssertDeepEquals(t, tried, []netip.Addr{netip.MustParseAddr("::1")})
+	test.AssertMetricWithLabelsEquals(t, d.fallbacks, prometheus.Labels{}, 1)
+
+	// The preferred address doesn't connect within the delay, so the fallback
+	// is raced against it.
+	d = newTestHappyEyeballsDialer("::1", "127.0.0.1", port)
+	d.delay = 0
+	conn, err = d.DialContext(ctx, "tcp", "example.com:80")
+	test.AssertNotError(t, err, "dialing with no delay")
+	conn.Close()
+	test.AssertMetricWithLabelsEquals(t, d.fallbacks, prometheus.Labels{}, 1)
+
+	// Neither address works, so the fallback's error is returned.
+	d = newTestHappyEyeballsDialer("::1", "127.0.0.1", closedPort(t))
+	_, err = d.DialContext(ctx, "tcp", "example.com:80")
+	test.AssertError(t, err, "dialing two broken addresses")
+	test.Assert(t, fallbackErr(err), "expected a dial error")
+	used, tried = d.result()
+	test.AssertEquals(t, used, netip.MustParseAddr("127.0.0.1"))
+	test.AssertDeepEquals(t, tried, []netip.Addr{netip.MustParseAddr("::1")})
+
+	// Errors other than dial errors aren't eligible for fallback.
+	d = newTestHappyEyeballsDialer("::1", "127.0.0.1", port)
+	_, err = d.DialContext(ctx, "tcp", "lettuceencrypt.org:80")
+	var mismatch *dialerMismatchError
+	test.AssertErrorWraps(t, err, &mismatch)
+	test.AssertMetricWithLabelsEquals(t, d.fallbacks, prometheus.Labels{}, 0)
+
+	// A dialer with no fallback reports its only address.
+	d = newTestHappyEyeballsDialer("127.0.0.1", "", closedPort(t))
+	_, err = d.DialContext(ctx, "tcp", "example.com:80")
+	test.AssertError(t, err, "dialing a broken address")
+	used, tried = d.result()
+	test.AssertEquals(t, used, netip.MustParseAddr("127.0.0.1"))
+	test.AssertEquals(t, len(tried), 0)
+}
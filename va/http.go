@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/netip"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -147,10 +148,9 @@ func httpTransport(df dialerFunc) *http.Transport {
 		// We are talking to a client that does not yet have a certificate,
 		// so we accept a temporary, invalid one.
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		// We don't expect to make multiple requests to a client, so close
-		// connection immediately.
-		DisableKeepAlives: true,
-		// We don't want idle connections, but 0 means "unlimited," so we pick 1.
+		// A transport is only used for a single validation, so the only
+		// connection worth keeping is one which a redirect to the same host
+		// can reuse. 0 means "unlimited," so we pick 1.
 		MaxIdleConns:        1,
 		IdleConnTimeout:     time.Second,
 		TLSHandshakeTimeout: 10 * time.Second,
@@ -267,8 +267,8 @@ func (va *ValidationAuthorityImpl) newHTTPValidationTarget(
 
 // extractRequestTarget extracts the host and port specified in the provided
 // HTTP redirect request. If the request's URL's protocol schema is not HTTP or
-// HTTPS an error is returned. If an explicit port is specified in the request's
-// URL and it isn't the VA's HTTP or HTTPS port, an error is returned.
+// HTTPS an error is returned. If the combination of scheme and port, explicit or
+// implied, isn't allowed by the VA's redirect policy, an error is returned.
 func (va *ValidationAuthorityImpl) extractRequestTarget(req *http.Request) (identifier.ACMEIdentifier, int, error) {
 	// A nil request is certainly not a valid redirect and has no port to extract.
 	if req == nil {
@@ -297,14 +297,6 @@ func (va *ValidationAuthorityImpl) extractRequestTarget(req *http.Request) (iden
 		if err != nil {
 			return identifier.ACMEIdentifier{}, 0, err
 		}
-
-		// The explicit port must match the VA's configured HTTP or HTTPS port.
-		if parsedPort != va.httpPort && parsedPort != va.httpsPort {
-			return identifier.ACMEIdentifier{}, 0, berrors.ConnectionFailureError(
-				"Invalid port in redirect target. Only ports %d and %d are supported, not %d",
-				va.httpPort, va.httpsPort, parsedPort)
-		}
-
 		reqPort = parsedPort
 	} else if reqScheme == "http" {
 		reqPort = va.httpPort
@@ -316,6 +308,12 @@ func (va *ValidationAuthorityImpl) extractRequestTarget(req *http.Request) (iden
 		return identifier.ACMEIdentifier{}, 0, fmt.Errorf("unable to determine redirect HTTP request port")
 	}
 
+	// The scheme and port must be allowed by the VA's redirect policy.
+	err := va.checkRedirectTarget(reqScheme, reqPort)
+	if err != nil {
+		return identifier.ACMEIdentifier{}, 0, err
+	}
+
 	if reqHost == "" {
 		return identifier.ACMEIdentifier{}, 0, berrors.ConnectionFailureError("Invalid empty host in redirect target")
 	}
@@ -356,13 +354,14 @@ func (va *ValidationAuthorityImpl) extractRequestTarget(req *http.Request) (iden
 	return identifier.NewDNS(reqHost), reqPort, nil
 }
 
-// setupHTTPValidation sets up a preresolvedDialer and a validation record for
-// the given request URL and httpValidationTarget. If the req URL is empty, or
-// the validation target is nil or has no available IP addresses, an error will
-// be returned.
+// setupHTTPValidation sets up a happyEyeballsDialer and a validation record for
+// the given request URL and httpValidationTarget. The dialer prefers the
+// target's current IP, and falls back to the next one, if any. If the req URL
+// is empty, or the validation target is nil or has no available IP addresses,
+// an error will be returned.
 func (va *ValidationAuthorityImpl) setupHTTPValidation(
 	reqURL string,
-	target *httpValidationTarget) (*preresolvedDialer, core.ValidationRecord, error) {
+	target *httpValidationTarget) (*happyEyeballsDialer, core.ValidationRecord, error) {
 	if reqURL == "" {
 		return nil,
 			core.ValidationRecord{},
@@ -405,19 +404,51 @@ func (va *ValidationAuthorityImpl) setupHTTPValidation(
 
 	record.AddressUsed = targetIP
 
-	dialer := &preresolvedDialer{
-		ip:       targetIP,
-		port:     target.port,
-		hostname: target.host,
-		timeout:  va.singleDialTimeout,
+	dialer := &happyEyeballsDialer{
+		primary: &preresolvedDialer{
+			ip:       targetIP,
+			port:     target.port,
+			hostname: target.host,
+			timeout:  va.singleDialTimeout,
+		},
+		delay:     va.happyEyeballsDelay,
+		fallbacks: va.metrics.http01Fallbacks,
+	}
+	if len(target.next) > 0 {
+		fallbackIP := target.next[0]
+		err = va.isReservedIPFunc(fallbackIP)
+		if err != nil {
+			return nil, record, err
+		}
+		dialer.fallback = &preresolvedDialer{
+			ip:       fallbackIP,
+			port:     target.port,
+			hostname: target.host,
+			timeout:  va.singleDialTimeout,
+		}
 	}
 	return dialer, record, nil
 }
 
+// connectionKey returns the key under which an http.Transport pools
+// connections made for requests to u, so that requests which might reuse each
+// other's connections can be identified.
+func connectionKey(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+	return u.Scheme + "://" + net.JoinHostPort(u.Hostname(), port)
+}
+
 // fallbackErr returns true only for net.OpError instances where the op is equal
 // to "dial", or url.Error instances wrapping such an error. fallbackErr returns
 // false for all other errors. By policy, only dial errors (not read or write
-// errors) are eligible for fallback from an IPv6 to an IPv4 address.
+// errors) are eligible for fallback from an IPv6 to an IPv4 address, which a
+// happyEyeballsDialer makes without waiting for its delay to elapse.
 func fallbackErr(err error) bool {
 	// Err shouldn't ever be nil if we're considering it for fallback
 	if err == nil {
@@ -484,8 +515,13 @@ func (va *ValidationAuthorityImpl) processHTTPValidation(
 	ctx, cancel := context.WithDeadline(ctx, deadline)
 	defer cancel()
 	initialReq = initialReq.WithContext(ctx)
-	if va.userAgent != "" {
-		initialReq.Header.Set("User-Agent", va.userAgent)
+	userAgent := va.userAgent
+	fingerprint := va.nextHTTPFingerprint()
+	if fingerprint != nil {
+		userAgent = fingerprint.userAgent
+	}
+	if userAgent != "" {
+		initialReq.Header.Set("User-Agent", userAgent)
 	}
 	// Some of our users use mod_security. Mod_security sees a lack of Accept
 	// headers as bot behavior and rejects requests. While this is a bug in
@@ -504,20 +540,60 @@ func (va *ValidationAuthorityImpl) processHTTPValidation(
 		return nil, []core.ValidationRecord{}, newIPError(target.cur, err)
 	}
 
-	// Build a transport for this validation that will use the preresolvedDialer's
-	// DialContext function
-	transport := httpTransport(dialer.DialContext)
+	// Build a transport for this validation that will use the
+	// happyEyeballsDialer's DialContext function. Idle connections are kept
+	// only for as long as the validation.
+	transport := httpTransport(va.shapeDialer(dialer.DialContext))
+	defer transport.CloseIdleConnections()
+	if va.httpBandwidth != nil && va.httpBandwidth.maxHeaderBytes > 0 {
+		transport.MaxResponseHeaderBytes = va.httpBandwidth.maxHeaderBytes
+	}
 
-	va.log.AuditInfof("Attempting to validate HTTP-01 for %q with GET to %q",
-		initialReq.Host, initialReq.URL.String())
+	// recordFingerprint notes the fingerprint, if any, in each validation
+	// record created for this validation.
+	recordFingerprint := func(record *core.ValidationRecord) {}
+	if fingerprint != nil {
+		transport.TLSClientConfig = fingerprint.tlsConfig.Clone()
+		transport.ForceAttemptHTTP2 = fingerprint.h2
+		recordFingerprint = func(record *core.ValidationRecord) {
+			record.Fingerprint = fingerprint.name
+		}
+		va.log.AuditInfof("Attempting to validate HTTP-01 for %q with GET to %q using fingerprint %q",
+			initialReq.Host, initialReq.URL.String(), fingerprint.name)
+	} else {
+		va.log.AuditInfof("Attempting to validate HTTP-01 for %q with GET to %q",
+			initialReq.Host, initialReq.URL.String())
+	}
+	recordFingerprint(&baseRecord)
+
+	// Each validation record has the dialer which was set up for its request.
+	// Once the request has been made, recordDial updates the record with the
+	// address that dialer actually connected to, and any it abandoned first.
+	// A record's request may instead reuse a connection dialed for an earlier
+	// request to the same host, in which case its dialer starts out with that
+	// connection's address.
+	records := []core.ValidationRecord{baseRecord}
+	recordDialers := []*happyEyeballsDialer{dialer}
+	recordDial := func(i int) {
+		if recordDialers[i] == nil {
+			return
+		}
+		used, tried := recordDialers[i].result()
+		if used.IsValid() {
+			records[i].AddressUsed = used
+			records[i].AddressesTried = tried
+		}
+	}
+	// connDialers holds the most recent dialer for each connection key.
+	connDialers := map[string]*happyEyeballsDialer{connectionKey(initialReq.URL): dialer}
 
 	// Create a closure around records & numRedirects we can use with a HTTP
 	// client to process redirects per our own policy (e.g. resolving IP
 	// addresses explicitly, not following redirects to ports != [80,443], etc)
-	records := []core.ValidationRecord{baseRecord}
 	numRedirects := 0
 	processRedirect := func(req *http.Request, via []*http.Request) error {
 		va.log.Debugf("processing a HTTP redirect from the server to %q", req.URL.String())
+		recordDial(len(records) - 1)
 		// Only process up to maxRedirect redirects
 		if numRedirects > maxRedirect {
 			return berrors.ConnectionFailureError("Too many redirects")
@@ -587,15 +663,35 @@ func (va *ValidationAuthorityImpl) processHTTPValidation(
 		// assign to the client transport in order to connect to the redirect target using
 		// the IP address we selected.
 		redirDialer, redirRecord, err := va.setupHTTPValidation(req.URL.String(), redirTarget)
+		recordFingerprint(&redirRecord)
 		records = append(records, redirRecord)
+		recordDialers = append(recordDialers, redirDialer)
 		if err != nil {
 			return err
 		}
 
+		// The transport will reuse an idle connection to the same host, if it
+		// has one, rather than dialing. That's only acceptable if the
+		// connection is to one of the addresses the host resolves to now.
+		key := connectionKey(req.URL)
+		prevDialer, ok := connDialers[key]
+		if ok {
+			prevUsed, _ := prevDialer.result()
+			if slices.Contains(redirTarget.available, prevUsed) {
+				redirDialer.setResult(prevUsed, nil)
+			} else {
+				// The previous response's body hasn't been closed yet, so its
+				// connection isn't idle. Close it first so that it's discarded.
+				_ = req.Response.Body.Close()
+				transport.CloseIdleConnections()
+			}
+		}
+		connDialers[key] = redirDialer
+
 		va.log.Debugf("following redirect to host %q url %q", req.Host, req.URL.String())
-		// Replace the transport's DialContext with the new preresolvedDialer for
-		// the redirect.
-		transport.DialContext = redirDialer.DialContext
+		// Replace the transport's DialContext with the new happyEyeballsDialer
+		// for the redirect.
+		transport.DialContext = va.shapeDialer(redirDialer.DialContext)
 		return nil
 	}
 
@@ -607,44 +703,24 @@ func (va *ValidationAuthorityImpl) processHTTPValidation(
 	}
 
 	// Make the initial validation request. This may result in redirects being
-	// followed.
+	// followed. If the target has both IPv6 and IPv4 addresses the dialer will
+	// fall back from one to the other, so there's nothing to retry here.
 	httpResponse, err := client.Do(initialReq)
-	// If there was an error and its a kind of error we consider a fallback error,
-	// then try to fallback.
-	if err != nil && fallbackErr(err) {
-		// Try to advance to another IP. If there was an error advancing we don't
-		// have a fallback address to use and must return the original error.
-		advanceTargetIPErr := target.nextIP()
-		if advanceTargetIPErr != nil {
-			return nil, records, newIPError(records[len(records)-1].AddressUsed, err)
-		}
-
-		// setup another validation to retry the target with the new IP and append
-		// the retry record.
-		retryDialer, retryRecord, err := va.setupHTTPValidation(initialReq.URL.String(), target)
-		if err != nil {
-			return nil, records, newIPError(records[len(records)-1].AddressUsed, err)
-		}
-
-		records = append(records, retryRecord)
-		va.metrics.http01Fallbacks.Inc()
-		// Replace the transport's dialer with the preresolvedDialer for the retry
-		// host.
-		transport.DialContext = retryDialer.DialContext
-
-		// Perform the retry
-		httpResponse, err = client.Do(initialReq)
-		// If the retry still failed there isn't anything more to do, return the
-		// error immediately.
-		if err != nil {
-			return nil, records, newIPError(records[len(records)-1].AddressUsed, err)
-		}
-	} else if err != nil {
-		// if the error was not a fallbackErr then return immediately.
+	recordDial(len(records) - 1)
+	if err != nil {
 		return nil, records, newIPError(records[len(records)-1].AddressUsed, err)
 	}
 
+	// If the response is being captured for archival, note where it came from.
+	recorder := httpResponseRecorderFrom(ctx)
+	finalRecord := records[len(records)-1]
+
 	if httpResponse.StatusCode != 200 {
+		if recorder != nil {
+			body, _ := io.ReadAll(&io.LimitedReader{R: httpResponse.Body, N: int64(recorder.maxBodySize) + 1})
+			_ = httpResponse.Body.Close()
+			recorder.record(finalRecord.URL, finalRecord.AddressUsed.String(), httpResponse, body, false)
+		}
 		return nil, records, newIPError(records[len(records)-1].AddressUsed, berrors.UnauthorizedError("Invalid response from %s: %d",
 			records[len(records)-1].URL, httpResponse.StatusCode))
 	}
@@ -656,6 +732,9 @@ func (va *ValidationAuthorityImpl) processHTTPValidation(
 	if err == nil {
 		err = closeErr
 	}
+	if recorder != nil {
+		recorder.record(finalRecord.URL, finalRecord.AddressUsed.String(), httpResponse, body, len(body) >= maxResponseSize)
+	}
 	if err != nil {
 		return nil, records, newIPError(records[len(records)-1].AddressUsed, berrors.UnauthorizedError("Error reading HTTP response body: %v", err))
 	}
@@ -679,17 +758,21 @@ func (va *ValidationAuthorityImpl) validateHTTP01(ctx context.Context, ident ide
 	// Perform the fetch
 	path := fmt.Sprintf(".well-known/acme-challenge/%s", token)
 	body, validationRecords, err := va.processHTTPValidation(ctx, ident, "/"+path)
-	if err != nil {
-		return validationRecords, err
+	if err == nil {
+		payload := strings.TrimRightFunc(string(body), unicode.IsSpace)
+		if payload != keyAuthorization {
+			err = berrors.UnauthorizedError("The key authorization file from the server did not match this challenge. Expected %q (got %q)",
+				keyAuthorization, payload)
+			va.log.Infof("%s for %s", err, ident)
+		}
 	}
-	payload := strings.TrimRightFunc(string(body), unicode.IsSpace)
 
-	if payload != keyAuthorization {
-		problem := berrors.UnauthorizedError("The key authorization file from the server did not match this challenge. Expected %q (got %q)",
-			keyAuthorization, payload)
-		va.log.Infof("%s for %s", problem, ident)
-		return validationRecords, problem
+	if len(validationRecords) > 0 && validationRecords[0].Fingerprint != "" {
+		result := pass
+		if err != nil {
+			result = fail
+		}
+		va.metrics.http01FingerprintResults.WithLabelValues(validationRecords[0].Fingerprint, result).Inc()
 	}
-
-	return validationRecords, nil
+	return validationRecords, err
 }
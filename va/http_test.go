@@ -15,6 +15,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 	"unicode/utf8"
 
@@ -122,8 +123,8 @@ func TestHTTPTransport(t *testing.T) {
 	// The HTTP Transport should have a TLS config that skips verifying
 	// certificates.
 	test.AssertEquals(t, transport.TLSClientConfig.InsecureSkipVerify, true)
-	// Keep alives should be disabled
-	test.AssertEquals(t, transport.DisableKeepAlives, true)
+	// Keep alives should be enabled, so that redirects can reuse connections
+	test.AssertEquals(t, transport.DisableKeepAlives, false)
 	test.AssertEquals(t, transport.MaxIdleConns, 1)
 	test.AssertEquals(t, transport.IdleConnTimeout.String(), "1s")
 	test.AssertEquals(t, transport.TLSHandshakeTimeout.String(), "10s")
@@ -503,7 +504,7 @@ func TestSetupHTTPValidation(t *testing.T) {
 		InputURL       string
 		InputTarget    *httpValidationTarget
 		ExpectedRecord core.ValidationRecord
-		ExpectedDialer *preresolvedDialer
+		ExpectedDialer *happyEyeballsDialer
 		ExpectedError  error
 	}{
 		{
@@ -543,10 +544,19 @@ func TestSetupHTTPValidation(t *testing.T) {
 				AddressUsed:       netip.MustParseAddr("::1"),
 				ResolverAddrs:     []string{"MockClient"},
 			},
-			ExpectedDialer: &preresolvedDialer{
-				ip:      netip.MustParseAddr("::1"),
-				port:    va.httpPort,
-				timeout: va.singleDialTimeout,
+			ExpectedDialer: &happyEyeballsDialer{
+				primary: &preresolvedDialer{
+					ip:       netip.MustParseAddr("::1"),
+					port:     va.httpPort,
+					hostname: "ipv4.and.ipv6.localhost",
+					timeout:  va.singleDialTimeout,
+				},
+				fallback: &preresolvedDialer{
+					ip:       netip.MustParseAddr("127.0.0.1"),
+					port:     va.httpPort,
+					hostname: "ipv4.and.ipv6.localhost",
+					timeout:  va.singleDialTimeout,
+				},
 			},
 		},
 		{
@@ -561,10 +571,19 @@ func TestSetupHTTPValidation(t *testing.T) {
 				AddressUsed:       netip.MustParseAddr("::1"),
 				ResolverAddrs:     []string{"MockClient"},
 			},
-			ExpectedDialer: &preresolvedDialer{
-				ip:      netip.MustParseAddr("::1"),
-				port:    va.httpsPort,
-				timeout: va.singleDialTimeout,
+			ExpectedDialer: &happyEyeballsDialer{
+				primary: &preresolvedDialer{
+					ip:       netip.MustParseAddr("::1"),
+					port:     va.httpsPort,
+					hostname: "ipv4.and.ipv6.localhost",
+					timeout:  va.singleDialTimeout,
+				},
+				fallback: &preresolvedDialer{
+					ip:       netip.MustParseAddr("127.0.0.1"),
+					port:     va.httpsPort,
+					hostname: "ipv4.and.ipv6.localhost",
+					timeout:  va.singleDialTimeout,
+				},
 			},
 		},
 	}
@@ -582,7 +601,8 @@ func TestSetupHTTPValidation(t *testing.T) {
 			if tc.ExpectedDialer == nil && outDialer != nil {
 				t.Errorf("Expected nil dialer, got %v", outDialer)
 			} else if tc.ExpectedDialer != nil {
-				test.AssertMarshaledEquals(t, outDialer, tc.ExpectedDialer)
+				test.AssertDeepEquals(t, outDialer.primary, tc.ExpectedDialer.primary)
+				test.AssertDeepEquals(t, outDialer.fallback, tc.ExpectedDialer.fallback)
 			}
 			// In all cases we expect there to have been a validation record
 			test.AssertMarshaledEquals(t, outRecord, tc.ExpectedRecord)
@@ -1159,18 +1179,11 @@ func TestFetchHTTP(t *testing.T) {
 					Port:              strconv.Itoa(httpPortIPv4),
 					URL:               "http://ipv4.and.ipv6.localhost/ok",
 					AddressesResolved: []netip.Addr{netip.MustParseAddr("::1"), netip.MustParseAddr("127.0.0.1")},
-					// The first validation record should have used the IPv6 addr
-					AddressUsed:   netip.MustParseAddr("::1"),
-					ResolverAddrs: []string{"MockClient"},
-				},
-				{
-					Hostname:          "ipv4.and.ipv6.localhost",
-					Port:              strconv.Itoa(httpPortIPv4),
-					URL:               "http://ipv4.and.ipv6.localhost/ok",
-					AddressesResolved: []netip.Addr{netip.MustParseAddr("::1"), netip.MustParseAddr("127.0.0.1")},
-					// The second validation record should have used the IPv4 addr as a fallback
-					AddressUsed:   netip.MustParseAddr("127.0.0.1"),
-					ResolverAddrs: []string{"MockClient"},
+					// The IPv4 addr should have been used as a fallback, after
+					// the IPv6 addr was tried.
+					AddressUsed:    netip.MustParseAddr("127.0.0.1"),
+					AddressesTried: []netip.Addr{netip.MustParseAddr("::1")},
+					ResolverAddrs:  []string{"MockClient"},
 				},
 			},
 		},
@@ -1218,12 +1231,8 @@ func TestFetchHTTP(t *testing.T) {
 			Name:  "Reflected response body containing printf verbs",
 			Ident: identifier.NewDNS("example.com"),
 			Path:  "/printf-verbs",
-			ExpectedProblem: &probs.ProblemDetails{
-				Type: probs.UnauthorizedProblem,
-				Detail: fmt.Sprintf("127.0.0.1: Invalid response from http://example.com/printf-verbs: %q",
-					("%2F.well-known%2F" + expectedTruncatedResp.String())[:maxResponseSize]),
-				HTTPStatus: http.StatusForbidden,
-			},
+			ExpectedProblem: probs.Unauthorized(fmt.Sprintf("127.0.0.1: Invalid response from http://example.com/printf-verbs: %q",
+				("%2F.well-known%2F" + expectedTruncatedResp.String())[:maxResponseSize])),
 			ExpectedRecords: []core.ValidationRecord{
 				{
 					Hostname:          "example.com",
@@ -1592,6 +1601,37 @@ func TestHTTPRedirectLookup(t *testing.T) {
 				va.httpPort)))
 }
 
+func TestHTTPRedirectReusesConnection(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/redirect", func(resp http.ResponseWriter, req *http.Request) {
+		http.Redirect(resp, req, "/ok", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/ok", func(resp http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(resp, "ok")
+	})
+	hs := httptest.NewUnstartedServer(mux)
+	var conns atomic.Int32
+	hs.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	hs.Start()
+	defer hs.Close()
+	va, _ := setup(hs, "", nil, nil)
+
+	body, records, err := va.processHTTPValidation(ctx, identifier.NewDNS("example.com"), "/redirect")
+	test.AssertNotError(t, err, "validation with a redirect failed")
+	test.AssertEquals(t, string(body), "ok")
+	test.AssertEquals(t, len(records), 2)
+	for _, record := range records {
+		test.AssertEquals(t, record.AddressUsed, netip.MustParseAddr("127.0.0.1"))
+	}
+	// The redirect to the same host should have been followed over the
+	// connection made for the initial request.
+	test.AssertEquals(t, conns.Load(), int32(1))
+}
+
 func TestHTTPRedirectLoop(t *testing.T) {
 	hs := httpSrv(t, expectedToken, false)
 	defer hs.Close()
@@ -0,0 +1,107 @@
+package va
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	vapb "github.com/letsencrypt/boulder/va/proto"
+)
+
+// ChallengePortsConfig overrides the ports to which the VA connects when
+// validating challenges. Each port which is zero keeps its default.
+type ChallengePortsConfig struct {
+	// HTTPPort is the port to which HTTP-01 requests, and redirects to
+	// "http" URLs without an explicit port, are made. If zero, it's 80.
+	HTTPPort int `validate:"omitempty,min=1,max=65535"`
+
+	// HTTPSPort is the port to which redirects to "https" URLs without an
+	// explicit port are made. If zero, it's 443.
+	HTTPSPort int `validate:"omitempty,min=1,max=65535"`
+
+	// TLSALPNPort is the port to which TLS-ALPN-01 connections are made. If
+	// zero, it's 443.
+	TLSALPNPort int `validate:"omitempty,min=1,max=65535"`
+
+	// AllowNonstandardPorts must be set for any port to differ from its
+	// default. RFC 8555 and RFC 8737 require the defaults, so this is only
+	// for test and internal environments.
+	AllowNonstandardPorts bool
+}
+
+// SetChallengePorts configures the ports to which the VA connects when
+// validating challenges. Without it, the ports required by RFC 8555 and RFC
+// 8737 are used.
+func (va *ValidationAuthorityImpl) SetChallengePorts(c ChallengePortsConfig) error {
+	pc := newDefaultPortConfig()
+	for _, p := range []struct {
+		name     string
+		port     int
+		standard int
+		dest     *int
+	}{
+		{"httpPort", c.HTTPPort, pc.HTTPPort, &pc.HTTPPort},
+		{"httpsPort", c.HTTPSPort, pc.HTTPSPort, &pc.HTTPSPort},
+		{"tlsALPNPort", c.TLSALPNPort, pc.TLSPort, &pc.TLSPort},
+	} {
+		if p.port == 0 {
+			continue
+		}
+		if p.port < 1 || p.port > 65535 {
+			return fmt.Errorf("%s: invalid port %d", p.name, p.port)
+		}
+		if p.port != p.standard && !c.AllowNonstandardPorts {
+			return fmt.Errorf("%s: port %d requires allowNonstandardPorts", p.name, p.port)
+		}
+		*p.dest = p.port
+	}
+	va.httpPort = pc.HTTPPort
+	va.httpsPort = pc.HTTPSPort
+	va.tlsPort = pc.TLSPort
+	return nil
+}
+
+// GetChallengePorts returns the ports to which this VA connects when
+// validating challenges.
+func (va *ValidationAuthorityImpl) GetChallengePorts(_ context.Context, _ *emptypb.Empty) (*vapb.ChallengePortsResponse, error) {
+	return &vapb.ChallengePortsResponse{
+		HttpPort:    int32(va.httpPort),
+		HttpsPort:   int32(va.httpsPort),
+		TlsALPNPort: int32(va.tlsPort),
+		Perspective: va.perspective,
+	}, nil
+}
+
+// CheckRemoteChallengePorts asks each remote VA which ports it connects to
+// when validating challenges, and returns an error naming those which differ
+// from this VA's, since a remote VA on different ports would corroborate, or
+// fail to corroborate, a different validation than this VA performed. Remote
+// VAs which can't be reached before ctx is done are logged, but aren't an
+// error, so that an outage of one perspective doesn't prevent the VA from
+// starting.
+func (va *ValidationAuthorityImpl) CheckRemoteChallengePorts(ctx context.Context) error {
+	var conflicts []string
+	for _, rva := range va.remoteVAs {
+		if rva.ChallengePortsClient == nil {
+			return fmt.Errorf("remote VA %q (%s) has no ChallengePorts client", rva.Perspective, rva.Address)
+		}
+		resp, err := rva.GetChallengePorts(ctx, &emptypb.Empty{}, grpc.WaitForReady(true))
+		if err != nil {
+			va.log.Warningf("Unable to check challenge ports of remote VA %q (%s): %s", rva.Perspective, rva.Address, err)
+			continue
+		}
+		if int(resp.HttpPort) != va.httpPort || int(resp.HttpsPort) != va.httpsPort || int(resp.TlsALPNPort) != va.tlsPort {
+			conflicts = append(conflicts, fmt.Sprintf(
+				"remote VA %q (%s) uses http:%d https:%d tls-alpn:%d",
+				rva.Perspective, rva.Address, resp.HttpPort, resp.HttpsPort, resp.TlsALPNPort))
+		}
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("challenge ports differ from this VA's http:%d https:%d tls-alpn:%d: %s",
+			va.httpPort, va.httpsPort, va.tlsPort, strings.Join(conflicts, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package va
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestSetChallengePorts(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		config    ChallengePortsConfig
+		wantErr   string
+		wantHTTP  int
+		wantHTTPS int
+		wantTLS   int
+	}{
+		{
+			name:      "defaults",
+			config:    ChallengePortsConfig{},
+			wantHTTP:  80,
+			wantHTTPS: 443,
+			wantTLS:   443,
+		},
+		{
+			name:      "standard ports",
+			config:    ChallengePortsConfig{HTTPPort: 80, HTTPSPort: 443, TLSALPNPort: 443},
+			wantHTTP:  80,
+			wantHTTPS: 443,
+			wantTLS:   443,
+		},
+		{
+			name:    "bad port",
+			config:  ChallengePortsConfig{TLSALPNPort: 70000, AllowNonstandardPorts: true},
+			wantErr: "tlsALPNPort: invalid port 70000",
+		},
+		{
+			name:    "nonstandard port without flag",
+			config:  ChallengePortsConfig{HTTPPort: 5002},
+			wantErr: "httpPort: port 5002 requires allowNonstandardPorts",
+		},
+		{
+			name:      "nonstandard ports with flag",
+			config:    ChallengePortsConfig{HTTPPort: 5002, TLSALPNPort: 5001, AllowNonstandardPorts: true},
+			wantHTTP:  5002,
+			wantHTTPS: 443,
+			wantTLS:   5001,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			va, _ := setup(nil, "", nil, nil)
+			err := va.SetChallengePorts(tc.config)
+			if tc.wantErr != "" {
+				test.AssertError(t, err, "SetChallengePorts should have failed")
+				test.AssertContains(t, err.Error(), tc.wantErr)
+				return
+			}
+			test.AssertNotError(t, err, "SetChallengePorts failed")
+
+			resp, err := va.GetChallengePorts(context.Background(), &emptypb.Empty{})
+			test.AssertNotError(t, err, "GetChallengePorts failed")
+			test.AssertEquals(t, int(resp.HttpPort), tc.wantHTTP)
+			test.AssertEquals(t, int(resp.HttpsPort), tc.wantHTTPS)
+			test.AssertEquals(t, int(resp.TlsALPNPort), tc.wantTLS)
+			test.AssertEquals(t, resp.Perspective, va.perspective)
+		})
+	}
+}
+
+func TestCheckRemoteChallengePorts(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	va, _ := setupWithRemotes(nil, "", []remoteConf{{rir: arin}, {rir: ripe}}, nil)
+	err := va.CheckRemoteChallengePorts(ctx)
+	test.AssertNotError(t, err, "remote VAs with the same ports should agree")
+
+	// Move the first remote VA to a different HTTP-01 port.
+	rva := va.remoteVAs[0].ChallengePortsClient.(*inMemVA).rva
+	err = rva.SetChallengePorts(ChallengePortsConfig{HTTPPort: 5002, AllowNonstandardPorts: true})
+	test.AssertNotError(t, err, "SetChallengePorts failed")
+	err = va.CheckRemoteChallengePorts(ctx)
+	test.AssertError(t, err, "remote VA with a different HTTP port should conflict")
+	test.AssertContains(t, err.Error(), `remote VA "dc-0-ARIN" () uses http:5002 https:443 tls-alpn:443`)
+	test.AssertNotContains(t, err.Error(), "dc-1-RIPE")
+
+	// When the primary VA moves too, the second remote VA conflicts until it
+	// also moves.
+	err = va.SetChallengePorts(ChallengePortsConfig{HTTPPort: 5002, AllowNonstandardPorts: true})
+	test.AssertNotError(t, err, "SetChallengePorts failed")
+	err = va.CheckRemoteChallengePorts(ctx)
+	test.AssertError(t, err, "remote VA with the default HTTP port should conflict")
+	test.AssertContains(t, err.Error(), "dc-1-RIPE")
+	err = va.remoteVAs[1].ChallengePortsClient.(*inMemVA).rva.SetChallengePorts(ChallengePortsConfig{HTTPPort: 5002, AllowNonstandardPorts: true})
+	test.AssertNotError(t, err, "SetChallengePorts failed")
+	err = va.CheckRemoteChallengePorts(ctx)
+	test.AssertNotError(t, err, "remote VAs with the same nonstandard ports should agree")
+
+	// A remote VA which can't be reached isn't a conflict.
+	va.remoteVAs[0].RemoteClients = RemoteClients{VAClient: brokenRemoteVA{}, CAAClient: brokenRemoteVA{}, ChallengePortsClient: brokenRemoteVA{}}
+	err = va.CheckRemoteChallengePorts(ctx)
+	test.AssertNotError(t, err, "unreachable remote VA should not conflict")
+}
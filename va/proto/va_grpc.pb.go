@@ -11,6 +11,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -221,3 +222,219 @@ var CAA_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "va.proto",
 }
+
+const (
+	HTTPResponseArchive_GetArchivedHTTPResponses_FullMethodName = "/va.HTTPResponseArchive/GetArchivedHTTPResponses"
+)
+
+// HTTPResponseArchiveClient is the client API for HTTPResponseArchive service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// HTTPResponseArchive serves the HTTP responses received during failed HTTP-01
+// validations, for use by administrators resolving disputes.
+type HTTPResponseArchiveClient interface {
+	GetArchivedHTTPResponses(ctx context.Context, in *GetArchivedHTTPResponsesRequest, opts ...grpc.CallOption) (*ArchivedHTTPResponses, error)
+}
+
+type hTTPResponseArchiveClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHTTPResponseArchiveClient(cc grpc.ClientConnInterface) HTTPResponseArchiveClient {
+	return &hTTPResponseArchiveClient{cc}
+}
+
+func (c *hTTPResponseArchiveClient) GetArchivedHTTPResponses(ctx context.Context, in *GetArchivedHTTPResponsesRequest, opts ...grpc.CallOption) (*ArchivedHTTPResponses, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ArchivedHTTPResponses)
+	err := c.cc.Invoke(ctx, HTTPResponseArchive_GetArchivedHTTPResponses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HTTPResponseArchiveServer is the server API for HTTPResponseArchive service.
+// All implementations must embed UnimplementedHTTPResponseArchiveServer
+// for forward compatibility.
+//
+// HTTPResponseArchive serves the HTTP responses received during failed HTTP-01
+// validations, for use by administrators resolving disputes.
+type HTTPResponseArchiveServer interface {
+	GetArchivedHTTPResponses(context.Context, *GetArchivedHTTPResponsesRequest) (*ArchivedHTTPResponses, error)
+	mustEmbedUnimplementedHTTPResponseArchiveServer()
+}
+
+// UnimplementedHTTPResponseArchiveServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedHTTPResponseArchiveServer struct{}
+
+func (UnimplementedHTTPResponseArchiveServer) GetArchivedHTTPResponses(context.Context, *GetArchivedHTTPResponsesRequest) (*ArchivedHTTPResponses, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetArchivedHTTPResponses not implemented")
+}
+func (UnimplementedHTTPResponseArchiveServer) mustEmbedUnimplementedHTTPResponseArchiveServer() {}
+func (UnimplementedHTTPResponseArchiveServer) testEmbeddedByValue()                             {}
+
+// UnsafeHTTPResponseArchiveServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HTTPResponseArchiveServer will
+// result in compilation errors.
+type UnsafeHTTPResponseArchiveServer interface {
+	mustEmbedUnimplementedHTTPResponseArchiveServer()
+}
+
+func RegisterHTTPResponseArchiveServer(s grpc.ServiceRegistrar, srv HTTPResponseArchiveServer) {
+	// If the following call pancis, it indicates UnimplementedHTTPResponseArchiveServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&HTTPResponseArchive_ServiceDesc, srv)
+}
+
+func _HTTPResponseArchive_GetArchivedHTTPResponses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetArchivedHTTPResponsesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HTTPResponseArchiveServer).GetArchivedHTTPResponses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HTTPResponseArchive_GetArchivedHTTPResponses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HTTPResponseArchiveServer).GetArchivedHTTPResponses(ctx, req.(*GetArchivedHTTPResponsesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// HTTPResponseArchive_ServiceDesc is the grpc.ServiceDesc for HTTPResponseArchive service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HTTPResponseArchive_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "va.HTTPResponseArchive",
+	HandlerType: (*HTTPResponseArchiveServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetArchivedHTTPResponses",
+			Handler:    _HTTPResponseArchive_GetArchivedHTTPResponses_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "va.proto",
+}
+
+const (
+	ChallengePorts_GetChallengePorts_FullMethodName = "/va.ChallengePorts/GetChallengePorts"
+)
+
+// ChallengePortsClient is the client API for ChallengePorts service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ChallengePorts reports the ports to which a VA connects when validating
+// challenges, so that a primary VA can check that its remote VAs agree.
+type ChallengePortsClient interface {
+	GetChallengePorts(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ChallengePortsResponse, error)
+}
+
+type challengePortsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewChallengePortsClient(cc grpc.ClientConnInterface) ChallengePortsClient {
+	return &challengePortsClient{cc}
+}
+
+func (c *challengePortsClient) GetChallengePorts(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ChallengePortsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChallengePortsResponse)
+	err := c.cc.Invoke(ctx, ChallengePorts_GetChallengePorts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ChallengePortsServer is the server API for ChallengePorts service.
+// All implementations must embed UnimplementedChallengePortsServer
+// for forward compatibility.
+//
+// ChallengePorts reports the ports to which a VA connects when validating
+// challenges, so that a primary VA can check that its remote VAs agree.
+type ChallengePortsServer interface {
+	GetChallengePorts(context.Context, *emptypb.Empty) (*ChallengePortsResponse, error)
+	mustEmbedUnimplementedChallengePortsServer()
+}
+
+// UnimplementedChallengePortsServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedChallengePortsServer struct{}
+
+func (UnimplementedChallengePortsServer) GetChallengePorts(context.Context, *emptypb.Empty) (*ChallengePortsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChallengePorts not implemented")
+}
+func (UnimplementedChallengePortsServer) mustEmbedUnimplementedChallengePortsServer() {}
+func (UnimplementedChallengePortsServer) testEmbeddedByValue()                        {}
+
+// UnsafeChallengePortsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ChallengePortsServer will
+// result in compilation errors.
+type UnsafeChallengePortsServer interface {
+	mustEmbedUnimplementedChallengePortsServer()
+}
+
+func RegisterChallengePortsServer(s grpc.ServiceRegistrar, srv ChallengePortsServer) {
+	// If the following call pancis, it indicates UnimplementedChallengePortsServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ChallengePorts_ServiceDesc, srv)
+}
+
+func _ChallengePorts_GetChallengePorts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChallengePortsServer).GetChallengePorts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChallengePorts_GetChallengePorts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChallengePortsServer).GetChallengePorts(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ChallengePorts_ServiceDesc is the grpc.ServiceDesc for ChallengePorts service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ChallengePorts_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "va.ChallengePorts",
+	HandlerType: (*ChallengePortsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetChallengePorts",
+			Handler:    _ChallengePorts_GetChallengePorts_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "va.proto",
+}
@@ -0,0 +1,102 @@
+package va
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	berrors "github.com/letsencrypt/boulder/errors"
+)
+
+// HTTPRedirectTarget is a combination of URL scheme and TCP port to which an
+// HTTP-01 redirect may lead.
+type HTTPRedirectTarget struct {
+	Scheme string `validate:"required,oneof=http https"`
+	Port   int    `validate:"required,min=1,max=65535"`
+}
+
+func (t HTTPRedirectTarget) String() string {
+	return fmt.Sprintf("%s:%d", t.Scheme, t.Port)
+}
+
+// HTTPRedirectPolicyConfig restricts the scheme and port combinations to which
+// the VA follows HTTP-01 redirects. It's applied alike at every hop.
+type HTTPRedirectPolicyConfig struct {
+	// Allowed lists the combinations which redirects may use. A redirect to a
+	// URL without an explicit port is to the scheme's default port, 80 or 443.
+	Allowed []HTTPRedirectTarget `validate:"min=1,dive"`
+
+	// AllowNonstandardPorts must be set for Allowed to contain ports other
+	// than 80 and 443, e.g. "https:8443". The Baseline Requirements don't
+	// permit validation on other ports, so this is only for test
+	// environments.
+	AllowNonstandardPorts bool
+}
+
+// SetHTTPRedirectPolicy configures the VA to follow HTTP-01 redirects only to
+// the scheme and port combinations allowed by c. Without a policy, redirects
+// to either the HTTP or HTTPS port are followed, whatever their scheme.
+func (va *ValidationAuthorityImpl) SetHTTPRedirectPolicy(c HTTPRedirectPolicyConfig) error {
+	if len(c.Allowed) == 0 {
+		return errors.New("no HTTP redirect targets allowed")
+	}
+	targets := make(map[HTTPRedirectTarget]bool)
+	for _, t := range c.Allowed {
+		if t.Scheme != "http" && t.Scheme != "https" {
+			return fmt.Errorf("HTTP redirect target %s: unsupported scheme %q", t, t.Scheme)
+		}
+		if t.Port < 1 || t.Port > 65535 {
+			return fmt.Errorf("HTTP redirect target %s: invalid port %d", t, t.Port)
+		}
+		if t.Port != 80 && t.Port != 443 && !c.AllowNonstandardPorts {
+			return fmt.Errorf("HTTP redirect target %s: port %d requires allowNonstandardPorts", t, t.Port)
+		}
+		targets[t] = true
+	}
+	va.httpRedirectTargets = targets
+	return nil
+}
+
+// checkRedirectTarget returns an error if an HTTP-01 redirect to the given
+// scheme and port isn't allowed, counting the rejection.
+func (va *ValidationAuthorityImpl) checkRedirectTarget(scheme string, port int) error {
+	if va.httpRedirectTargets == nil {
+		if port == va.httpPort || port == va.httpsPort {
+			return nil
+		}
+		va.countRedirectRejection(scheme, port)
+		return berrors.ConnectionFailureError(
+			"Invalid port in redirect target. Only ports %d and %d are supported, not %d",
+			va.httpPort, va.httpsPort, port)
+	}
+
+	if va.httpRedirectTargets[HTTPRedirectTarget{Scheme: scheme, Port: port}] {
+		return nil
+	}
+	va.countRedirectRejection(scheme, port)
+	var allowed []string
+	for t := range va.httpRedirectTargets {
+		allowed = append(allowed, t.String())
+	}
+	slices.Sort(allowed)
+	return berrors.ConnectionFailureError(
+		"Invalid scheme and port in redirect target. Only %s are supported, not %s:%d",
+		strings.Join(allowed, ", "), scheme, port)
+}
+
+// countRedirectRejection increments the rejection counter for the given
+// scheme and port. Ports which are neither the VA's nor mentioned by its
+// policy are counted together as "other", to bound the metric's cardinality.
+func (va *ValidationAuthorityImpl) countRedirectRejection(scheme string, port int) {
+	label := "other"
+	known := port == va.httpPort || port == va.httpsPort
+	for t := range va.httpRedirectTargets {
+		known = known || t.Port == port
+	}
+	if known {
+		label = strconv.Itoa(port)
+	}
+	va.metrics.http01RedirectRejections.WithLabelValues(scheme, label).Inc()
+}
@@ -0,0 +1,137 @@
+package va
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/identifier"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestSetHTTPRedirectPolicy(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		config  HTTPRedirectPolicyConfig
+		wantErr string
+	}{
+		{
+			name:    "empty",
+			config:  HTTPRedirectPolicyConfig{},
+			wantErr: "no HTTP redirect targets allowed",
+		},
+		{
+			name:    "bad scheme",
+			config:  HTTPRedirectPolicyConfig{Allowed: []HTTPRedirectTarget{{Scheme: "ftp", Port: 80}}},
+			wantErr: `unsupported scheme "ftp"`,
+		},
+		{
+			name:    "bad port",
+			config:  HTTPRedirectPolicyConfig{Allowed: []HTTPRedirectTarget{{Scheme: "http", Port: 0}}},
+			wantErr: "invalid port 0",
+		},
+		{
+			name:    "nonstandard port without flag",
+			config:  HTTPRedirectPolicyConfig{Allowed: []HTTPRedirectTarget{{Scheme: "https", Port: 8443}}},
+			wantErr: "port 8443 requires allowNonstandardPorts",
+		},
+		{
+			name: "nonstandard port with flag",
+			config: HTTPRedirectPolicyConfig{
+				Allowed:               []HTTPRedirectTarget{{Scheme: "https", Port: 8443}},
+				AllowNonstandardPorts: true,
+			},
+		},
+		{
+			name:   "standard ports",
+			config: HTTPRedirectPolicyConfig{Allowed: []HTTPRedirectTarget{{Scheme: "http", Port: 80}, {Scheme: "https", Port: 443}}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			va, _ := setup(nil, "", nil, nil)
+			err := va.SetHTTPRedirectPolicy(tc.config)
+			if tc.wantErr != "" {
+				test.AssertError(t, err, "SetHTTPRedirectPolicy should have failed")
+				test.AssertContains(t, err.Error(), tc.wantErr)
+				test.Assert(t, va.httpRedirectTargets == nil, "policy should not have been set")
+				return
+			}
+			test.AssertNotError(t, err, "SetHTTPRedirectPolicy failed")
+			test.AssertEquals(t, len(va.httpRedirectTargets), len(tc.config.Allowed))
+		})
+	}
+}
+
+func TestExtractRequestTargetWithPolicy(t *testing.T) {
+	t.Parallel()
+
+	va, _ := setup(nil, "", nil, nil)
+	err := va.SetHTTPRedirectPolicy(HTTPRedirectPolicyConfig{
+		Allowed: []HTTPRedirectTarget{
+			{Scheme: "http", Port: 80},
+			{Scheme: "https", Port: 443},
+			{Scheme: "https", Port: 8443},
+		},
+		AllowNonstandardPorts: true,
+	})
+	test.AssertNotError(t, err, "SetHTTPRedirectPolicy failed")
+
+	testCases := []struct {
+		url       string
+		wantPort  int
+		wantErr   string
+		wantLabel prometheus.Labels
+	}{
+		{url: "http://example.com/path", wantPort: 80},
+		{url: "https://example.com/path", wantPort: 443},
+		{url: "https://example.com:8443/path", wantPort: 8443},
+		{
+			// Without a policy this would be followed, since 443 is the VA's
+			// HTTPS port, but the policy only allows it for https.
+			url:       "http://example.com:443/path",
+			wantErr:   "Invalid scheme and port in redirect target. Only http:80, https:443, https:8443 are supported, not http:443",
+			wantLabel: prometheus.Labels{"scheme": "http", "port": "443"},
+		},
+		{
+			url:       "http://example.com:8443/path",
+			wantErr:   "not http:8443",
+			wantLabel: prometheus.Labels{"scheme": "http", "port": "8443"},
+		},
+		{
+			url:       "https://example.com:9999/path",
+			wantErr:   "not https:9999",
+			wantLabel: prometheus.Labels{"scheme": "https", "port": "other"},
+		},
+	}
+	for _, tc := range testCases {
+		u, err := url.Parse(tc.url)
+		test.AssertNotError(t, err, "parsing URL")
+		ident, port, err := va.extractRequestTarget(&http.Request{URL: u})
+		if tc.wantErr != "" {
+			test.AssertError(t, err, "extractRequestTarget should have failed for "+tc.url)
+			test.AssertContains(t, err.Error(), tc.wantErr)
+			test.AssertMetricWithLabelsEquals(t, va.metrics.http01RedirectRejections, tc.wantLabel, 1)
+			continue
+		}
+		test.AssertNotError(t, err, "extractRequestTarget failed for "+tc.url)
+		test.AssertEquals(t, ident, identifier.NewDNS("example.com"))
+		test.AssertEquals(t, port, tc.wantPort)
+	}
+}
+
+func TestRedirectRejectionMetricWithoutPolicy(t *testing.T) {
+	t.Parallel()
+
+	va, _ := setup(nil, "", nil, nil)
+	u, err := url.Parse("https://example.com:9999/path")
+	test.AssertNotError(t, err, "parsing URL")
+	_, _, err = va.extractRequestTarget(&http.Request{URL: u})
+	test.AssertError(t, err, "extractRequestTarget should have failed")
+	test.AssertMetricWithLabelsEquals(t, va.metrics.http01RedirectRejections, prometheus.Labels{"scheme": "https", "port": "other"}, 1)
+}
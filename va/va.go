@@ -21,8 +21,10 @@ import (
 	"github.com/jmhodges/clock"
 	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/letsencrypt/boulder/bdns"
+	caapb "github.com/letsencrypt/boulder/caa/proto"
 	"github.com/letsencrypt/boulder/core"
 	corepb "github.com/letsencrypt/boulder/core/proto"
 	berrors "github.com/letsencrypt/boulder/errors"
@@ -44,6 +46,10 @@ const (
 
 	pass = "pass"
 	fail = "fail"
+
+	// defaultMaxDNSAliasDepth is the number of CNAME or DNAME aliases which
+	// will be followed during DNS-01 validation if no limit is configured.
+	defaultMaxDNSAliasDepth = 8
 )
 
 var (
@@ -80,11 +86,13 @@ var (
 	h2SettingsFrameErrRegex = regexp.MustCompile(`(?:net\/http\: HTTP\/1\.x transport connection broken: )?malformed HTTP response \"\\x00\\x00\\x[a-f0-9]{2}\\x04\\x00\\x00\\x00\\x00\\x00.*"`)
 )
 
-// RemoteClients wraps the vapb.VAClient and vapb.CAAClient interfaces to aid in
-// mocking remote VAs for testing.
+// RemoteClients wraps the vapb.VAClient, vapb.CAAClient, and
+// vapb.ChallengePortsClient interfaces to aid in mocking remote VAs for
+// testing.
 type RemoteClients struct {
 	vapb.VAClient
 	vapb.CAAClient
+	vapb.ChallengePortsClient
 }
 
 // RemoteVA embeds RemoteClients and adds a field containing the address of the
@@ -110,8 +118,15 @@ type vaMetrics struct {
 	tlsALPNOIDCounter                 *prometheus.CounterVec
 	http01Fallbacks                   prometheus.Counter
 	http01Redirects                   prometheus.Counter
+	http01RedirectRejections          *prometheus.CounterVec
+	http01FingerprintResults          *prometheus.CounterVec
+	http01ArchivedResponses           prometheus.Counter
+	http01ArchiveBytes                prometheus.Gauge
 	caaCounter                        *prometheus.CounterVec
+	caaCacheHits                      prometheus.Counter
 	ipv4FallbackCounter               prometheus.Counter
+	validationConcurrencyWait         *prometheus.HistogramVec
+	http01BandwidthWait               *prometheus.CounterVec
 }
 
 func initMetrics(stats prometheus.Registerer) *vaMetrics {
@@ -141,7 +156,7 @@ func initMetrics(stats prometheus.Registerer) *vaMetrics {
 	http01Fallbacks := prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Name: "http01_fallbacks",
-			Help: "Number of IPv6 to IPv4 HTTP-01 fallback requests made",
+			Help: "Number of IPv6 to IPv4 HTTP-01 fallback connection attempts made",
 		})
 	stats.MustRegister(http01Fallbacks)
 	http01Redirects := prometheus.NewCounter(
@@ -150,16 +165,58 @@ func initMetrics(stats prometheus.Registerer) *vaMetrics {
 			Help: "Number of HTTP-01 redirects followed",
 		})
 	stats.MustRegister(http01Redirects)
+	http01RedirectRejections := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http01_redirect_rejections",
+			Help: "Number of HTTP-01 redirects not followed because their scheme and port combination isn't allowed",
+		},
+		[]string{"scheme", "port"},
+	)
+	stats.MustRegister(http01RedirectRejections)
+	http01FingerprintResults := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http01_fingerprint_results",
+			Help: "Number of HTTP-01 validations made with each configured fingerprint, by result=[pass|fail]",
+		},
+		[]string{"fingerprint", "result"},
+	)
+	stats.MustRegister(http01FingerprintResults)
+	http01ArchivedResponses := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "http01_archived_responses",
+		Help: "A counter of HTTP responses archived after failed HTTP-01 validations",
+	})
+	stats.MustRegister(http01ArchivedResponses)
+	http01ArchiveBytes := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "http01_archive_bytes",
+		Help: "The number of compressed bytes of HTTP responses held in the HTTP-01 response archive",
+	})
+	stats.MustRegister(http01ArchiveBytes)
 	caaCounter := prometheus.NewCounterVec(prometheus.CounterOpts{
 		Name: "caa_sets_processed",
 		Help: "A counter of CAA sets processed labelled by result",
 	}, []string{"result"})
 	stats.MustRegister(caaCounter)
+	caaCacheHits := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "caa_cache_hits",
+		Help: "A counter of CAA lookups served from the caa-checker's shared cache",
+	})
+	stats.MustRegister(caaCacheHits)
 	ipv4FallbackCounter := prometheus.NewCounter(prometheus.CounterOpts{
 		Name: "tls_alpn_ipv4_fallback",
 		Help: "A counter of IPv4 fallbacks during TLS ALPN validation",
 	})
 	stats.MustRegister(ipv4FallbackCounter)
+	validationConcurrencyWait := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "validation_concurrency_wait_seconds",
+		Help:    "Time validations spent waiting for a concurrency slot, labelled by limit=[identifier|account] and result=[acquired|timeout]",
+		Buckets: metrics.InternetFacingBuckets,
+	}, []string{"limit", "result"})
+	stats.MustRegister(validationConcurrencyWait)
+	http01BandwidthWait := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http01_bandwidth_wait_seconds",
+		Help: "Total time HTTP-01 connection reads spent waiting for a bandwidth limit, labelled by limit=[global|target]",
+	}, []string{"limit"})
+	stats.MustRegister(http01BandwidthWait)
 
 	return &vaMetrics{
 		validationLatency:                 validationLatency,
@@ -167,12 +224,19 @@ func initMetrics(stats prometheus.Registerer) *vaMetrics {
 		tlsALPNOIDCounter:                 tlsALPNOIDCounter,
 		http01Fallbacks:                   http01Fallbacks,
 		http01Redirects:                   http01Redirects,
+		http01RedirectRejections:          http01RedirectRejections,
+		http01FingerprintResults:          http01FingerprintResults,
+		http01ArchivedResponses:           http01ArchivedResponses,
+		http01ArchiveBytes:                http01ArchiveBytes,
 		caaCounter:                        caaCounter,
+		caaCacheHits:                      caaCacheHits,
 		ipv4FallbackCounter:               ipv4FallbackCounter,
+		validationConcurrencyWait:         validationConcurrencyWait,
+		http01BandwidthWait:               http01BandwidthWait,
 	}
 }
 
-// PortConfig specifies what ports the VA should call to on the remote
+// portConfig specifies what ports the VA should call to on the remote
 // host when performing its checks.
 type portConfig struct {
 	HTTPPort  int
@@ -203,6 +267,8 @@ func newDefaultPortConfig() *portConfig {
 type ValidationAuthorityImpl struct {
 	vapb.UnsafeVAServer
 	vapb.UnsafeCAAServer
+	vapb.UnsafeHTTPResponseArchiveServer
+	vapb.UnsafeChallengePortsServer
 	log                blog.Logger
 	dnsClient          bdns.Client
 	issuerDomain       string
@@ -215,15 +281,40 @@ type ValidationAuthorityImpl struct {
 	maxRemoteFailures  int
 	accountURIPrefixes []string
 	singleDialTimeout  time.Duration
+	happyEyeballsDelay time.Duration
 	perspective        string
 	rir                string
 	isReservedIPFunc   func(netip.Addr) error
+	maxDNSAliasDepth   int
+	// caaChecker, if non-nil, performs the CAA tree-climb lookups in place of
+	// dnsClient, so that they're shared with other VAs in this perspective.
+	caaChecker caapb.CAACheckerClient
+	// httpFingerprints, if non-nil, are rotated among for HTTP-01
+	// validations in place of userAgent and Go's default TLS parameters.
+	httpFingerprints *httpFingerprintRotation
+	// httpRedirectTargets, if non-nil, are the only scheme and port
+	// combinations to which HTTP-01 redirects are followed. Otherwise any
+	// redirect to httpPort or httpsPort is followed, whatever its scheme.
+	httpRedirectTargets map[HTTPRedirectTarget]bool
+	// validationLimiter, if non-nil, limits the number of concurrent
+	// validations of each identifier and for each account.
+	validationLimiter *validationLimiter
+	// emailReplyValidator, if non-nil, performs email-reply-00 validations.
+	emailReplyValidator EmailReplyValidator
+	// httpResponseArchive, if non-nil, holds the final HTTP response received
+	// during each failed HTTP-01 validation.
+	httpResponseArchive *httpResponseArchive
+	// httpBandwidth, if non-nil, limits the rate at which HTTP-01 responses
+	// are read, and the size of their headers.
+	httpBandwidth *bandwidthLimiter
 
 	metrics *vaMetrics
 }
 
 var _ vapb.VAServer = (*ValidationAuthorityImpl)(nil)
 var _ vapb.CAAServer = (*ValidationAuthorityImpl)(nil)
+var _ vapb.HTTPResponseArchiveServer = (*ValidationAuthorityImpl)(nil)
+var _ vapb.ChallengePortsServer = (*ValidationAuthorityImpl)(nil)
 
 // NewValidationAuthorityImpl constructs a new VA
 func NewValidationAuthorityImpl(
@@ -238,6 +329,7 @@ func NewValidationAuthorityImpl(
 	perspective string,
 	rir string,
 	reservedIPChecker func(netip.Addr) error,
+	maxDNSAliasDepth int,
 ) (*ValidationAuthorityImpl, error) {
 
 	if len(accountURIPrefixes) == 0 {
@@ -252,6 +344,10 @@ func NewValidationAuthorityImpl(
 		}
 	}
 
+	if maxDNSAliasDepth <= 0 {
+		maxDNSAliasDepth = defaultMaxDNSAliasDepth
+	}
+
 	pc := newDefaultPortConfig()
 
 	va := &ValidationAuthorityImpl{
@@ -271,10 +367,12 @@ func NewValidationAuthorityImpl(
 		// before timing out. This timeout ignores the base RPC timeout and is strictly
 		// used for the DialContext operations that take place during an
 		// HTTP-01 challenge validation.
-		singleDialTimeout: 10 * time.Second,
-		perspective:       perspective,
-		rir:               rir,
-		isReservedIPFunc:  reservedIPChecker,
+		singleDialTimeout:  10 * time.Second,
+		happyEyeballsDelay: defaultHappyEyeballsDelay,
+		perspective:        perspective,
+		rir:                rir,
+		isReservedIPFunc:   reservedIPChecker,
+		maxDNSAliasDepth:   maxDNSAliasDepth,
 	}
 
 	return va, nil
@@ -423,6 +521,8 @@ func (va *ValidationAuthorityImpl) validateChallenge(
 		return va.validateDNS01(ctx, ident, keyAuthorization)
 	case core.ChallengeTypeTLSALPN01:
 		return va.validateTLSALPN01(ctx, ident, keyAuthorization)
+	case core.ChallengeTypeEmailReply00:
+		return va.validateEmailReply00(ctx, ident, token, keyAuthorization)
 	}
 	return nil, berrors.MalformedError("invalid challenge type %s", kind)
 }
@@ -435,7 +535,9 @@ func (va *ValidationAuthorityImpl) validateChallenge(
 //   - challenge_type: core.Challenge.Type
 //   - problem_type: probs.ProblemType
 //   - result: the result of the validation as [pass|fail]
-func (va *ValidationAuthorityImpl) observeLatency(op, perspective, challType, probType, result string, latency time.Duration) {
+//
+// If ctx carries a sampled trace, its ID is attached as an exemplar.
+func (va *ValidationAuthorityImpl) observeLatency(ctx context.Context, op, perspective, challType, probType, result string, latency time.Duration) {
 	labels := prometheus.Labels{
 		"operation":      op,
 		"perspective":    perspective,
@@ -443,7 +545,7 @@ func (va *ValidationAuthorityImpl) observeLatency(op, perspective, challType, pr
 		"problem_type":   probType,
 		"result":         result,
 	}
-	va.metrics.validationLatency.With(labels).Observe(latency.Seconds())
+	metrics.ObserveWithTraceID(ctx, va.metrics.validationLatency.With(labels), latency.Seconds())
 }
 
 // remoteOperation is a func type that encapsulates the operation and request
@@ -491,11 +593,15 @@ type mpicSummary struct {
 	// Perspectives corroborated the determinations made by the Primary Network
 	// Perspective".
 	QuorumResult string `json:"quorumResult"`
+
+	// Perspectives are the outcome and latency of each remote perspective,
+	// sorted by name, for the primary VA to report to the RA.
+	Perspectives []*corepb.ValidationPerspective `json:"-"`
 }
 
 // summarizeMPIC prepares an *mpicSummary for logging, ensuring there are no nil
 // slices and output is deterministic.
-func summarizeMPIC(passed, failed []string, passedRIRSet map[string]struct{}) *mpicSummary {
+func summarizeMPIC(passed, failed []string, passedRIRSet map[string]struct{}, perspectives []*corepb.ValidationPerspective) *mpicSummary {
 	if passed == nil {
 		passed = []string{}
 	}
@@ -513,11 +619,16 @@ func summarizeMPIC(passed, failed []string, passedRIRSet map[string]struct{}) *m
 	}
 	slices.Sort(passedRIRs)
 
+	slices.SortFunc(perspectives, func(a, b *corepb.ValidationPerspective) int {
+		return strings.Compare(a.Perspective, b.Perspective)
+	})
+
 	return &mpicSummary{
 		Passed:       passed,
 		Failed:       failed,
 		PassedRIRs:   passedRIRs,
 		QuorumResult: fmt.Sprintf("%d/%d", len(passed), len(passed)+len(failed)),
+		Perspectives: perspectives,
 	}
 }
 
@@ -545,6 +656,7 @@ func (va *ValidationAuthorityImpl) doRemoteOperation(ctx context.Context, op rem
 		perspective string
 		rir         string
 		result      remoteResult
+		latency     time.Duration
 		err         error
 	}
 
@@ -554,19 +666,21 @@ func (va *ValidationAuthorityImpl) doRemoteOperation(ctx context.Context, op rem
 	responses := make(chan *response, remoteVACount)
 	for _, i := range rand.Perm(remoteVACount) {
 		go func(rva RemoteVA) {
+			start := va.clk.Now()
 			res, err := op(subCtx, rva, req)
+			latency := va.clk.Since(start)
 			if err != nil {
-				responses <- &response{rva.Address, rva.Perspective, rva.RIR, res, err}
+				responses <- &response{rva.Address, rva.Perspective, rva.RIR, res, latency, err}
 				return
 			}
 			if res.GetPerspective() != rva.Perspective || res.GetRir() != rva.RIR {
 				err = fmt.Errorf(
 					"Expected perspective %q (%q) but got reply from %q (%q) - misconfiguration likely", rva.Perspective, rva.RIR, res.GetPerspective(), res.GetRir(),
 				)
-				responses <- &response{rva.Address, rva.Perspective, rva.RIR, res, err}
+				responses <- &response{rva.Address, rva.Perspective, rva.RIR, res, latency, err}
 				return
 			}
-			responses <- &response{rva.Address, rva.Perspective, rva.RIR, res, err}
+			responses <- &response{rva.Address, rva.Perspective, rva.RIR, res, latency, err}
 		}(va.remoteVAs[i])
 	}
 
@@ -574,6 +688,7 @@ func (va *ValidationAuthorityImpl) doRemoteOperation(ctx context.Context, op rem
 	var passed []string
 	var failed []string
 	var passedRIRs = map[string]struct{}{}
+	var perspectives []*corepb.ValidationPerspective
 	var firstProb *probs.ProblemDetails
 
 	for resp := range responses {
@@ -605,6 +720,13 @@ func (va *ValidationAuthorityImpl) doRemoteOperation(ctx context.Context, op rem
 			passedRIRs[resp.rir] = struct{}{}
 		}
 
+		perspectives = append(perspectives, &corepb.ValidationPerspective{
+			Perspective: resp.perspective,
+			Rir:         resp.rir,
+			Passed:      currProb == nil,
+			Latency:     durationpb.New(resp.latency),
+		})
+
 		if firstProb == nil && currProb != nil {
 			// A problem was encountered for the first time.
 			firstProb = currProb
@@ -616,16 +738,16 @@ func (va *ValidationAuthorityImpl) doRemoteOperation(ctx context.Context, op rem
 		}
 	}
 	if len(passed) >= required && len(passedRIRs) >= requiredRIRs {
-		return summarizeMPIC(passed, failed, passedRIRs), nil
+		return summarizeMPIC(passed, failed, passedRIRs, perspectives), nil
 	}
 	if firstProb == nil {
 		// This should never happen. If we didn't meet the thresholds above we
 		// should have seen at least one error.
-		return summarizeMPIC(passed, failed, passedRIRs), probs.ServerInternal(
+		return summarizeMPIC(passed, failed, passedRIRs, perspectives), probs.ServerInternal(
 			"During secondary validation: validation failed but the problem is unavailable")
 	}
 	firstProb.Detail = fmt.Sprintf("During secondary validation: %s", firstProb.Detail)
-	return summarizeMPIC(passed, failed, passedRIRs), firstProb
+	return summarizeMPIC(passed, failed, passedRIRs, perspectives), firstProb
 }
 
 // validationLogEvent is a struct that contains the information needed to log
@@ -694,10 +816,10 @@ func (va *ValidationAuthorityImpl) DoDCV(ctx context.Context, req *vapb.PerformV
 			outcome = pass
 		}
 		// Observe local validation latency (primary|remote).
-		va.observeLatency(opDCV, va.perspective, string(chall.Type), probType, outcome, localLatency)
+		va.observeLatency(ctx, opDCV, va.perspective, string(chall.Type), probType, outcome, localLatency)
 		if va.isPrimaryVA() {
 			// Observe total validation latency (primary+remote).
-			va.observeLatency(opDCV, allPerspectives, string(chall.Type), probType, outcome, va.clk.Since(start))
+			va.observeLatency(ctx, opDCV, allPerspectives, string(chall.Type), probType, outcome, va.clk.Since(start))
 			logEvent.Summary = summary
 		}
 
@@ -706,17 +828,36 @@ func (va *ValidationAuthorityImpl) DoDCV(ctx context.Context, req *vapb.PerformV
 		va.log.AuditObject("Validation result", logEvent)
 	}()
 
+	// Wait until this validation is within the identifier and account
+	// concurrency limits, if any. The wait counts towards local latency.
+	release, err := va.acquireValidationSlots(ctx, ident, req.Authz.RegID)
+	if err != nil {
+		localLatency = va.clk.Since(start)
+		logEvent.InternalError = err.Error()
+		prob = probs.RateLimited(fmt.Sprintf("Unable to start validation: %s", err))
+		return bgrpc.ValidationResultToPB(nil, filterProblemDetails(prob), va.perspective, va.rir)
+	}
+
+	// If HTTP-01 responses are being archived, capture the final response
+	// received so that it can be archived if validation fails.
+	validationCtx := ctx
+	var recorder *httpResponseRecorder
+	if va.httpResponseArchive != nil && chall.Type == core.ChallengeTypeHTTP01 {
+		validationCtx, recorder = withHTTPResponseRecorder(ctx, va.httpResponseArchive.maxBodySize)
+	}
+
 	// Do local validation. Note that we process the result in a couple ways
 	// *before* checking whether it returned an error. These few checks are
 	// carefully written to ensure that they work whether the local validation
 	// was successful or not, and cannot themselves fail.
 	records, err := va.validateChallenge(
-		ctx,
+		validationCtx,
 		ident,
 		chall.Type,
 		chall.Token,
 		req.ExpectedKeyAuthorization,
 	)
+	release()
 
 	// Stop the clock for local validation latency.
 	localLatency = va.clk.Since(start)
@@ -730,7 +871,8 @@ func (va *ValidationAuthorityImpl) DoDCV(ctx context.Context, req *vapb.PerformV
 	if err != nil {
 		logEvent.InternalError = err.Error()
 		prob = detailedError(err)
-		return bgrpc.ValidationResultToPB(records, filterProblemDetails(prob), va.perspective, va.rir)
+		va.archiveHTTPResponse(req.Authz.Id, recorder, prob.Detail)
+		return va.dcvResultToPB(records, prob, false, localLatency, nil)
 	}
 
 	if va.isPrimaryVA() {
@@ -748,5 +890,25 @@ func (va *ValidationAuthorityImpl) DoDCV(ctx context.Context, req *vapb.PerformV
 		}
 		summary, prob = va.doRemoteOperation(ctx, op, req)
 	}
-	return bgrpc.ValidationResultToPB(records, filterProblemDetails(prob), va.perspective, va.rir)
+	return va.dcvResultToPB(records, prob, true, localLatency, summary)
+}
+
+// dcvResultToPB converts the result of DoDCV to a *vapb.ValidationResult. The
+// primary VA also includes the outcome of its own validation, followed by
+// those of the remote perspectives in summary, for MPIC compliance reporting.
+func (va *ValidationAuthorityImpl) dcvResultToPB(records []core.ValidationRecord, prob *probs.ProblemDetails, localPassed bool, localLatency time.Duration, summary *mpicSummary) (*vapb.ValidationResult, error) {
+	res, err := bgrpc.ValidationResultToPB(records, filterProblemDetails(prob), va.perspective, va.rir)
+	if err != nil || !va.isPrimaryVA() {
+		return res, err
+	}
+	res.Perspectives = append(res.Perspectives, &corepb.ValidationPerspective{
+		Perspective: va.perspective,
+		Rir:         va.rir,
+		Passed:      localPassed,
+		Latency:     durationpb.New(localLatency),
+	})
+	if summary != nil {
+		res.Perspectives = append(res.Perspectives, summary.Perspectives...)
+	}
+	return res, nil
 }
@@ -22,6 +22,7 @@ import (
 	"github.com/jmhodges/clock"
 	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/letsencrypt/boulder/bdns"
 	"github.com/letsencrypt/boulder/core"
@@ -145,6 +146,7 @@ func setup(srv *httptest.Server, userAgent string, remoteVAs []RemoteVA, mockDNS
 		perspective,
 		"",
 		isNonLoopbackReservedIP,
+		0,
 	)
 	if err != nil {
 		panic(fmt.Sprintf("Failed to create validation authority: %v", err))
@@ -170,7 +172,7 @@ func setupRemote(srv *httptest.Server, userAgent string, mockDNSClientOverride b
 	rva.perspective = perspective
 	rva.rir = rir
 
-	return RemoteClients{VAClient: &inMemVA{rva}, CAAClient: &inMemVA{rva}}
+	return RemoteClients{VAClient: &inMemVA{rva}, CAAClient: &inMemVA{rva}, ChallengePortsClient: &inMemVA{rva}}
 }
 
 // RIRs
@@ -285,6 +287,10 @@ func (b brokenRemoteVA) DoCAA(_ context.Context, _ *vapb.IsCAAValidRequest, _ ..
 	return nil, errBrokenRemoteVA
 }
 
+func (b brokenRemoteVA) GetChallengePorts(_ context.Context, _ *emptypb.Empty, _ ...grpc.CallOption) (*vapb.ChallengePortsResponse, error) {
+	return nil, errBrokenRemoteVA
+}
+
 // inMemVA is a wrapper which fulfills the VAClient and CAAClient
 // interfaces, but then forwards requests directly to its inner
 // ValidationAuthorityImpl rather than over the network. This lets a local
@@ -301,6 +307,10 @@ func (inmem *inMemVA) DoCAA(ctx context.Context, req *vapb.IsCAAValidRequest, _
 	return inmem.rva.DoCAA(ctx, req)
 }
 
+func (inmem *inMemVA) GetChallengePorts(ctx context.Context, req *emptypb.Empty, _ ...grpc.CallOption) (*vapb.ChallengePortsResponse, error) {
+	return inmem.rva.GetChallengePorts(ctx, req)
+}
+
 func TestNewValidationAuthorityImplWithDuplicateRemotes(t *testing.T) {
 	var remoteVAs []RemoteVA
 	for i := 0; i < 3; i++ {
@@ -323,6 +333,7 @@ func TestNewValidationAuthorityImplWithDuplicateRemotes(t *testing.T) {
 		"example perspective",
 		"",
 		isNonLoopbackReservedIP,
+		0,
 	)
 	test.AssertError(t, err, "NewValidationAuthorityImpl allowed duplicate remote perspectives")
 	test.AssertContains(t, err.Error(), "duplicate remote VA perspective \"dadaist\"")
@@ -720,6 +731,58 @@ func TestMultiVALogging(t *testing.T) {
 	test.AssertNotError(t, err, "performing validation")
 }
 
+func TestMultiVAPerspectives(t *testing.T) {
+	t.Parallel()
+
+	remoteConfs := []remoteConf{
+		{ua: pass, rir: arin},
+		{ua: fail, rir: ripe},
+		{ua: pass, rir: apnic},
+	}
+
+	ms := httpMultiSrv(t, expectedToken, map[string]bool{pass: true, fail: false})
+	defer ms.Close()
+
+	// The primary VA reports its own outcome first, followed by those of the
+	// remote perspectives, by name.
+	va, _ := setupWithRemotes(ms.Server, pass, remoteConfs, nil)
+	req := createValidationRequest(identifier.NewDNS("letsencrypt.org"), core.ChallengeTypeHTTP01)
+	res, err := va.DoDCV(ctx, req)
+	test.AssertNotError(t, err, "performing validation")
+	test.Assert(t, res.Problem == nil, fmt.Sprintf("validation failed with: %#v", res.Problem))
+	test.AssertEquals(t, len(res.Perspectives), 4)
+	type outcome struct {
+		perspective, rir string
+		passed           bool
+	}
+	var got []outcome
+	for _, p := range res.Perspectives {
+		test.AssertNotNil(t, p.Latency, "perspective latency should be set")
+		got = append(got, outcome{p.Perspective, p.Rir, p.Passed})
+	}
+	test.AssertDeepEquals(t, got, []outcome{
+		{PrimaryPerspective, "", true},
+		{"dc-0-ARIN", arin, true},
+		{"dc-1-RIPE", ripe, false},
+		{"dc-2-APNIC", apnic, true},
+	})
+
+	// If the primary's own validation fails, no remote is consulted.
+	va, _ = setupWithRemotes(ms.Server, fail, remoteConfs, nil)
+	res, err = va.DoDCV(ctx, req)
+	test.AssertNotError(t, err, "performing validation")
+	test.AssertNotNil(t, res.Problem, "validation should have failed")
+	test.AssertEquals(t, len(res.Perspectives), 1)
+	test.AssertEquals(t, res.Perspectives[0].Perspective, PrimaryPerspective)
+	test.Assert(t, !res.Perspectives[0].Passed, "primary perspective should have failed")
+
+	// Remote VAs don't report perspectives of their own.
+	remote, _ := setup(ms.Server, pass, nil, nil)
+	res, err = remote.DoDCV(ctx, req)
+	test.AssertNotError(t, err, "performing validation")
+	test.AssertEquals(t, len(res.Perspectives), 0)
+}
+
 func TestDetailedError(t *testing.T) {
 	cases := []struct {
 		err      error
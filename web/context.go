@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/netip"
+	"strconv"
 	"strings"
 	"time"
 
@@ -33,6 +34,25 @@ func WithUserAgent(ctx context.Context, ua string) context.Context {
 	return context.WithValue(ctx, userAgentContextKey{}, ua)
 }
 
+type pathPrefixContextKey struct{}
+
+// PathPrefix returns the path prefix under which the request's ACME directory
+// is served, or "" if it's served at the root.
+func PathPrefix(ctx context.Context) string {
+	val, ok := ctx.Value(pathPrefixContextKey{}).(string)
+	if !ok {
+		return ""
+	}
+	return val
+}
+
+// WithPathPrefix returns a context carrying the path prefix under which the
+// request's ACME directory is served. RelativeEndpoint prepends it to the
+// endpoints it constructs.
+func WithPathPrefix(ctx context.Context, prefix string) context.Context {
+	return context.WithValue(ctx, pathPrefixContextKey{}, prefix)
+}
+
 // RequestEvent is a structured record of the metadata we care about for a
 // single web request. It is generated when a request is received, passed to
 // the request handler which can populate its fields as appropriate, and then
@@ -75,6 +95,13 @@ type RequestEvent struct {
 	// For challenge POSTs, the challenge type.
 	ChallengeType string `json:",omitempty"`
 
+	// For POSTs authenticated by a JWS, the JWS signature algorithm.
+	JWSAlgorithm string `json:",omitempty"`
+
+	// AcceptLanguage is the request's Accept-Language header, used to choose
+	// the language of problem documents. It isn't logged.
+	AcceptLanguage string `json:"-"`
+
 	// suppressed controls whether this event will be logged when the request
 	// completes. If true, no log line will be emitted. Can only be set by
 	// calling .Suppress(); automatically unset by adding an internal error.
@@ -109,8 +136,9 @@ type wfeHandler interface {
 }
 
 type TopHandler struct {
-	wfe wfeHandler
-	log blog.Logger
+	wfe    wfeHandler
+	log    blog.Logger
+	hasher *IdentifierHasher
 }
 
 func NewTopHandler(log blog.Logger, wfe wfeHandler) *TopHandler {
@@ -127,12 +155,28 @@ type responseWriterWithStatus struct {
 	code int
 }
 
-// WriteHeader stores a status code for generating stats.
+// WriteHeader stores a status code for generating stats. Interim (1xx)
+// responses are not recorded, since they are followed by a final response.
 func (r *responseWriterWithStatus) WriteHeader(code int) {
-	r.code = code
+	if code >= 200 {
+		r.code = code
+	}
 	r.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap allows http.ResponseController to reach the underlying writer.
+func (r *responseWriterWithStatus) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// WithIdentifierHasher causes the TopHandler to log keyed hashes in place of
+// the identifiers and requesting account ID of each request. It returns the
+// TopHandler so that calls can be chained. A nil hasher disables hashing.
+func (th *TopHandler) WithIdentifierHasher(hasher *IdentifierHasher) *TopHandler {
+	th.hasher = hasher
+	return th
+}
+
 func (th *TopHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Check that this header is well-formed, since we assume it is when logging.
 	realIP := r.Header.Get("X-Real-IP")
@@ -144,11 +188,12 @@ func (th *TopHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	userAgent := r.Header.Get("User-Agent")
 
 	logEvent := &RequestEvent{
-		RealIP:    realIP,
-		Method:    r.Method,
-		UserAgent: userAgent,
-		Origin:    r.Header.Get("Origin"),
-		Extra:     make(map[string]interface{}),
+		RealIP:         realIP,
+		Method:         r.Method,
+		UserAgent:      userAgent,
+		Origin:         r.Header.Get("Origin"),
+		AcceptLanguage: r.Header.Get("Accept-Language"),
+		Extra:          make(map[string]interface{}),
 	}
 
 	ctx := WithUserAgent(r.Context(), userAgent)
@@ -197,14 +242,31 @@ func (th *TopHandler) logEvent(logEvent *RequestEvent) {
 	if logEvent.suppressed {
 		return
 	}
+	requester := strconv.FormatInt(logEvent.Requester, 10)
+	if th.hasher != nil {
+		now := time.Now()
+		if logEvent.Requester != 0 {
+			requester = th.hasher.HashAccount(logEvent.Requester, now)
+		}
+		// Hash a copy, since the handler may still hold the original.
+		hashed := *logEvent
+		hashed.Identifiers = make(identifier.ACMEIdentifiers, len(logEvent.Identifiers))
+		for i, ident := range logEvent.Identifiers {
+			hashed.Identifiers[i] = identifier.ACMEIdentifier{
+				Type:  ident.Type,
+				Value: th.hasher.HashIdentifier(ident, now),
+			}
+		}
+		logEvent = &hashed
+	}
 	var msg string
 	jsonEvent, err := json.Marshal(logEvent)
 	if err != nil {
 		th.log.AuditErrf("failed to marshal logEvent - %s - %#v", msg, err)
 		return
 	}
-	th.log.Infof("%s %s %d %d %d %s JSON=%s",
-		logEvent.Method, logEvent.Endpoint, logEvent.Requester, logEvent.Code,
+	th.log.Infof("%s %s %s %d %d %s JSON=%s",
+		logEvent.Method, logEvent.Endpoint, requester, logEvent.Code,
 		int(logEvent.Latency*1000), logEvent.RealIP, jsonEvent)
 }
 
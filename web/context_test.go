@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/letsencrypt/boulder/features"
+	"github.com/letsencrypt/boulder/identifier"
 	blog "github.com/letsencrypt/boulder/log"
 	"github.com/letsencrypt/boulder/test"
 )
@@ -77,6 +78,43 @@ func TestOrigin(t *testing.T) {
 	}
 }
 
+type identifiersHandler struct{}
+
+func (ih identifiersHandler) ServeHTTP(e *RequestEvent, w http.ResponseWriter, r *http.Request) {
+	e.Endpoint = "/endpoint"
+	e.Requester = 1234
+	e.Identifiers = identifier.ACMEIdentifiers{identifier.NewDNS("example.com")}
+	_, _ = w.Write([]byte("hi"))
+}
+
+func TestIdentifierHashing(t *testing.T) {
+	mockLog := blog.UseMock()
+	hasher, err := NewIdentifierHasher([]byte("secret"), 24*time.Hour)
+	test.AssertNotError(t, err, "NewIdentifierHasher failed")
+	th := NewTopHandler(mockLog, identifiersHandler{}).WithIdentifierHasher(hasher)
+	req, err := http.NewRequest("GET", "/thisisignored", &bytes.Reader{})
+	test.AssertNotError(t, err, "http.NewRequest failed")
+	th.ServeHTTP(httptest.NewRecorder(), req)
+
+	hash := `\d{8}T000000Z\.[A-Za-z0-9_-]{22}`
+	expected := `INFO: GET /endpoint ` + hash + ` 200 0 0.0.0.0 JSON={"Identifiers":\[{"type":"dns","value":"` + hash + `"}\]}`
+	if len(mockLog.GetAllMatching(expected)) != 1 {
+		t.Errorf("Expected exactly one log line matching %q. Got \n%s",
+			expected, strings.Join(mockLog.GetAllMatching(".*"), "\n"))
+	}
+	test.AssertEquals(t, len(mockLog.GetAllMatching("example.com|1234")), 0)
+
+	// Without a hasher, the identifiers and account ID are logged as-is.
+	mockLog.Clear()
+	th = NewTopHandler(mockLog, identifiersHandler{})
+	th.ServeHTTP(httptest.NewRecorder(), req)
+	expected = `INFO: GET /endpoint 1234 200 0 0.0.0.0 JSON={"Identifiers":\[{"type":"dns","value":"example.com"}\]}`
+	if len(mockLog.GetAllMatching(expected)) != 1 {
+		t.Errorf("Expected exactly one log line matching %q. Got \n%s",
+			expected, strings.Join(mockLog.GetAllMatching(".*"), "\n"))
+	}
+}
+
 type hostHeaderHandler struct {
 	f func(*RequestEvent, http.ResponseWriter, *http.Request)
 }
@@ -0,0 +1,70 @@
+package web
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/letsencrypt/boulder/identifier"
+)
+
+// keyIDFormat formats the start of a rotation period as the ID of the key used
+// during it.
+const keyIDFormat = "20060102T150405Z"
+
+// IdentifierHasher replaces the identifiers and account IDs in request logs
+// with keyed hashes, so that the logs can be retained without recording who
+// requested what. The key is rotated every period, so that hashes can't be
+// correlated across periods, and each hash is prefixed with the ID of the key
+// which made it. Anyone holding the secret from which the keys are derived can
+// compute the hash of a given identifier or account ID for any period, and so
+// find the requests which involved it; see the admin tool's
+// "hash-log-identifiers" subcommand.
+type IdentifierHasher struct {
+	secret []byte
+	period time.Duration
+}
+
+// NewIdentifierHasher returns an IdentifierHasher which derives a key for each
+// rotation period of the given length from the given secret.
+func NewIdentifierHasher(secret []byte, period time.Duration) (*IdentifierHasher, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("identifier hashing secret must not be empty")
+	}
+	if period < time.Hour {
+		return nil, errors.New("identifier hashing rotation period must be at least an hour")
+	}
+	return &IdentifierHasher{secret: secret, period: period}, nil
+}
+
+// KeyID returns the ID of the key in use at the given time, which is the start
+// of its rotation period.
+func (h *IdentifierHasher) KeyID(at time.Time) string {
+	return at.UTC().Truncate(h.period).Format(keyIDFormat)
+}
+
+// hash returns the hash of the given value under the key in use at the given
+// time, prefixed with the key's ID.
+func (h *IdentifierHasher) hash(value string, at time.Time) string {
+	keyID := h.KeyID(at)
+	derive := hmac.New(sha256.New, h.secret)
+	derive.Write([]byte(keyID))
+	mac := hmac.New(sha256.New, derive.Sum(nil))
+	mac.Write([]byte(value))
+	return keyID + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// HashIdentifier returns the hash which replaces the given identifier in
+// request logs at the given time.
+func (h *IdentifierHasher) HashIdentifier(ident identifier.ACMEIdentifier, at time.Time) string {
+	return h.hash(string(ident.Type)+":"+ident.Value, at)
+}
+
+// HashAccount returns the hash which replaces the given account ID in request
+// logs at the given time.
+func (h *IdentifierHasher) HashAccount(id int64, at time.Time) string {
+	return h.hash("account:"+strconv.FormatInt(id, 10), at)
+}
@@ -0,0 +1,53 @@
+package web
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/letsencrypt/boulder/identifier"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestNewIdentifierHasher(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewIdentifierHasher(nil, 24*time.Hour)
+	test.AssertError(t, err, "empty secret accepted")
+	_, err = NewIdentifierHasher([]byte("secret"), time.Minute)
+	test.AssertError(t, err, "sub-hour rotation period accepted")
+	_, err = NewIdentifierHasher([]byte("secret"), time.Hour)
+	test.AssertNotError(t, err, "valid config rejected")
+}
+
+func TestIdentifierHasher(t *testing.T) {
+	t.Parallel()
+
+	h, err := NewIdentifierHasher([]byte("secret"), 24*time.Hour)
+	test.AssertNotError(t, err, "NewIdentifierHasher failed")
+
+	morning := time.Date(2025, 3, 4, 1, 0, 0, 0, time.UTC)
+	evening := time.Date(2025, 3, 4, 23, 0, 0, 0, time.UTC)
+	tomorrow := time.Date(2025, 3, 5, 1, 0, 0, 0, time.UTC)
+	test.AssertEquals(t, h.KeyID(morning), "20250304T000000Z")
+	test.AssertEquals(t, h.KeyID(tomorrow), "20250305T000000Z")
+
+	dns := identifier.NewDNS("example.com")
+	hashed := h.HashIdentifier(dns, morning)
+	test.Assert(t, strings.HasPrefix(hashed, "20250304T000000Z."), "hash lacks key ID prefix")
+	test.AssertEquals(t, len(hashed), len("20250304T000000Z.")+22)
+
+	// Hashes are stable within a period, and differ between periods,
+	// identifier types, and secrets.
+	test.AssertEquals(t, h.HashIdentifier(dns, evening), hashed)
+	test.AssertNotEquals(t, h.HashIdentifier(dns, tomorrow), hashed)
+	test.AssertNotEquals(t, h.HashIdentifier(identifier.NewDNS("example.net"), morning), hashed)
+	test.AssertNotEquals(t, h.HashIdentifier(identifier.NewIP(netip.MustParseAddr("10.0.0.1")), morning),
+		h.HashIdentifier(identifier.ACMEIdentifier{Type: identifier.TypeDNS, Value: "10.0.0.1"}, morning))
+	test.AssertNotEquals(t, h.HashAccount(1, morning), h.HashAccount(2, morning))
+
+	other, err := NewIdentifierHasher([]byte("other secret"), 24*time.Hour)
+	test.AssertNotError(t, err, "NewIdentifierHasher failed")
+	test.AssertNotEquals(t, other.HashIdentifier(dns, morning), hashed)
+}
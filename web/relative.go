@@ -6,7 +6,8 @@ import (
 )
 
 // RelativeEndpoint takes a path component of URL and constructs a new URL using
-// the host and port from the request combined the provided path.
+// the host and port from the request combined the provided path. If the
+// request's context carries a path prefix, it's prepended to the path.
 func RelativeEndpoint(request *http.Request, endpoint string) string {
 	var result string
 	proto := "http"
@@ -29,7 +30,7 @@ func RelativeEndpoint(request *http.Request, endpoint string) string {
 		host = "localhost"
 	}
 
-	resultUrl := url.URL{Scheme: proto, Host: host, Path: endpoint}
+	resultUrl := url.URL{Scheme: proto, Host: host, Path: PathPrefix(request.Context()) + endpoint}
 	result = resultUrl.String()
 
 	return result
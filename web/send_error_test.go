@@ -43,11 +43,13 @@ func TestSendErrorSubProblemNamespace(t *testing.T) {
 		"type": "urn:ietf:params:acme:error:malformed",
 		"detail": "dfoop :: bad",
 		"status": 400,
+		"code": "malformed",
 		"subproblems": [
 		  {
 			"type": "urn:ietf:params:acme:error:malformed",
 			"detail": "dfoop :: nop",
 			"status": 400,
+			"code": "malformed",
 			"identifier": {
 			  "type": "dns",
 			  "value": "example.com"
@@ -57,6 +59,7 @@ func TestSendErrorSubProblemNamespace(t *testing.T) {
 			"type": "urn:ietf:params:acme:error:malformed",
 			"detail": "dfoop :: nah",
 			"status": 400,
+			"code": "malformed",
 			"identifier": {
 			  "type": "dns",
 			  "value": "what about example.com"
@@ -0,0 +1,136 @@
+package wfe2
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/probs"
+	rapb "github.com/letsencrypt/boulder/ra/proto"
+	"github.com/letsencrypt/boulder/web"
+)
+
+// accountKeyAlgorithmJSON is the response to an account-key-algorithm request.
+type accountKeyAlgorithmJSON struct {
+	// Algorithm is the JWS signature algorithm which requests signed by the
+	// account's key must use, e.g. "ES256".
+	Algorithm jose.SignatureAlgorithm `json:"algorithm"`
+
+	// KeyType is "RSA", "ECDSA", or "Ed25519".
+	KeyType string `json:"keyType"`
+
+	// KeySize is the size of the RSA modulus, or of the curve, in bits.
+	KeySize int32 `json:"keySize"`
+
+	// Status is how the WFE treats Algorithm: "allowed", "deprecated", or
+	// "disabled".
+	Status string `json:"status"`
+
+	// Recommended are the algorithms which aren't deprecated, to one of
+	// which the account should roll over its key if Status isn't "allowed".
+	Recommended []jose.SignatureAlgorithm `json:"recommended,omitempty"`
+}
+
+// AccountKeyAlgorithm is a Boulder-specific endpoint which reports the
+// algorithm of the requesting account's key, and whether it's due to be
+// disabled, so that integrators can find the accounts which need to roll over
+// to a new key before an algorithm is removed.
+func (wfe *WebFrontEndImpl) AccountKeyAlgorithm(
+	ctx context.Context,
+	logEvent *web.RequestEvent,
+	response http.ResponseWriter,
+	request *http.Request) {
+	acct, err := wfe.validPOSTAsGETForAccount(request, ctx, logEvent)
+	addRequesterHeader(response, logEvent.Requester)
+	if err != nil {
+		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Unable to validate JWS"), err)
+		return
+	}
+
+	resp, err := wfe.ra.GetAccountKeyAlgorithm(ctx, &rapb.GetAccountKeyAlgorithmRequest{RegistrationID: acct.ID})
+	if err != nil {
+		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Error getting account key algorithm"), err)
+		return
+	}
+
+	alg := jose.SignatureAlgorithm(resp.Algorithm)
+	_, status := wfe.jwsAlgorithms().status(alg)
+	result := accountKeyAlgorithmJSON{
+		Algorithm: alg,
+		KeyType:   resp.KeyType,
+		KeySize:   resp.KeySize,
+		Status:    status,
+	}
+	if status != "allowed" {
+		result.Recommended = wfe.jwsAlgorithms().recommended()
+	}
+
+	err = wfe.writeJsonResponse(response, logEvent, http.StatusOK, result)
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.ServerInternal("Error marshaling account key algorithm"), err)
+		return
+	}
+}
+
+// deprecationWarning returns the value of the Warning header sent in response
+// to requests signed with alg, or "" if alg isn't deprecated.
+func (wfe *WebFrontEndImpl) deprecationWarning(alg jose.SignatureAlgorithm) string {
+	policy := wfe.jwsAlgorithms()
+	if !policy.isDeprecated(alg) {
+		return ""
+	}
+	var recommended []string
+	for _, r := range policy.recommended() {
+		recommended = append(recommended, string(r))
+	}
+	// RFC 9111 Section 5.5: 299 is a persistent warning, with no agent.
+	return fmt.Sprintf(`299 - "JWS algorithm %s is deprecated and will be disabled; roll over the account key to one for %s"`,
+		alg, strings.Join(recommended, ", "))
+}
+
+// deprecationWarningWriter adds a Warning header to the response to a request
+// signed with a deprecated JWS algorithm. The algorithm is only known once the
+// handler has verified the request's JWS, so the header is added just before
+// the final response header is written.
+type deprecationWarningWriter struct {
+	http.ResponseWriter
+	wfe      *WebFrontEndImpl
+	logEvent *web.RequestEvent
+	checked  bool
+}
+
+func (w *deprecationWarningWriter) addWarning() {
+	if w.checked {
+		return
+	}
+	w.checked = true
+	alg := jose.SignatureAlgorithm(w.logEvent.JWSAlgorithm)
+	warning := w.wfe.deprecationWarning(alg)
+	if warning != "" {
+		w.Header().Add("Warning", warning)
+		w.wfe.stats.deprecationWarnings.With(prometheus.Labels{"alg": string(alg)}).Inc()
+	}
+}
+
+func (w *deprecationWarningWriter) WriteHeader(code int) {
+	// Interim responses may be sent by another goroutine while the handler is
+	// still running, so don't look at the logEvent until the final one.
+	if code >= 200 {
+		w.addWarning()
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *deprecationWarningWriter) Write(b []byte) (int, error) {
+	w.addWarning()
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap allows http.ResponseController to reach the underlying writer.
+func (w *deprecationWarningWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,88 @@
+package wfe2
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+
+	"github.com/letsencrypt/boulder/metrics"
+	rapb "github.com/letsencrypt/boulder/ra/proto"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// mockAccountKeyRA describes every account's key as a 2048-bit RSA key.
+type mockAccountKeyRA struct {
+	MockRegistrationAuthority
+	lastReq *rapb.GetAccountKeyAlgorithmRequest
+}
+
+func (ra *mockAccountKeyRA) GetAccountKeyAlgorithm(_ context.Context, in *rapb.GetAccountKeyAlgorithmRequest, _ ...grpc.CallOption) (*rapb.AccountKeyAlgorithm, error) {
+	ra.lastReq = in
+	return &rapb.AccountKeyAlgorithm{Algorithm: "RS256", KeyType: "RSA", KeySize: 2048}, nil
+}
+
+func TestAccountKeyAlgorithm(t *testing.T) {
+	wfe, fc, signer := setupWFE(t)
+	ra := &mockAccountKeyRA{MockRegistrationAuthority: MockRegistrationAuthority{clk: fc}}
+	wfe.ra = ra
+
+	targetPath := "debug/account-key-algorithm"
+	signedURL := "http://localhost/" + targetPath
+
+	responseWriter := httptest.NewRecorder()
+	wfe.AccountKeyAlgorithm(ctx, newRequestEvent(), responseWriter, signAndPost(signer, targetPath, signedURL, ""))
+	test.AssertUnmarshaledEquals(t, responseWriter.Body.String(), `{
+		"algorithm": "RS256",
+		"keyType": "RSA",
+		"keySize": 2048,
+		"status": "allowed"
+	}`)
+	test.AssertEquals(t, ra.lastReq.RegistrationID, int64(1))
+	test.AssertEquals(t, responseWriter.Header().Get("Warning"), "")
+
+	// The endpoint only accepts POST-as-GET requests.
+	responseWriter = httptest.NewRecorder()
+	wfe.AccountKeyAlgorithm(ctx, newRequestEvent(), responseWriter, signAndPost(signer, targetPath, signedURL, "{}"))
+	test.AssertContains(t, responseWriter.Body.String(), "POST-as-GET requests must have an empty payload")
+}
+
+func TestDeprecationWarning(t *testing.T) {
+	wfe, fc, signer := setupWFE(t)
+	wfe.ra = &mockAccountKeyRA{MockRegistrationAuthority: MockRegistrationAuthority{clk: fc}}
+	wfe.AccountKeyAlgorithms = true
+	var err error
+	wfe.JWSAlgorithms, err = NewJWSAlgorithmPolicy(JWSAlgorithmPolicyConfig{Deprecated: []string{"RS256"}})
+	test.AssertNotError(t, err, "valid config")
+	mux := wfe.Handler(metrics.NoopRegisterer)
+
+	// The default test key is an RSA key, so requests are signed with RS256.
+	responseWriter := httptest.NewRecorder()
+	mux.ServeHTTP(responseWriter, signAndPost(signer, accountKeyAlgorithmPath, "http://localhost"+accountKeyAlgorithmPath, ""))
+	test.AssertEquals(t, responseWriter.Code, 200)
+	test.AssertEquals(t, responseWriter.Header().Get("Warning"),
+		`299 - "JWS algorithm RS256 is deprecated and will be disabled; roll over the account key to one for ES256, ES384, ES512"`)
+	test.AssertUnmarshaledEquals(t, responseWriter.Body.String(), `{
+		"algorithm": "RS256",
+		"keyType": "RSA",
+		"keySize": 2048,
+		"status": "deprecated",
+		"recommended": ["ES256", "ES384", "ES512"]
+	}`)
+	test.AssertMetricWithLabelsEquals(t, wfe.stats.deprecationWarnings, prometheus.Labels{"alg": "RS256"}, 1)
+
+	// Error responses carry the warning too.
+	responseWriter = httptest.NewRecorder()
+	mux.ServeHTTP(responseWriter, signAndPost(signer, accountKeyAlgorithmPath, "http://localhost"+accountKeyAlgorithmPath, "{}"))
+	test.AssertEquals(t, responseWriter.Code, 400)
+	test.AssertContains(t, responseWriter.Header().Get("Warning"), "JWS algorithm RS256 is deprecated")
+	test.AssertMetricWithLabelsEquals(t, wfe.stats.deprecationWarnings, prometheus.Labels{"alg": "RS256"}, 2)
+
+	// Requests which aren't signed don't.
+	responseWriter = httptest.NewRecorder()
+	mux.ServeHTTP(responseWriter, httptest.NewRequest("GET", directoryPath, nil))
+	test.AssertEquals(t, responseWriter.Code, 200)
+	test.AssertEquals(t, responseWriter.Header().Get("Warning"), "")
+}
@@ -0,0 +1,208 @@
+package wfe2
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"os"
+
+	berrors "github.com/letsencrypt/boulder/errors"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+)
+
+// ClientIdentityConfig configures binding new ACME accounts to the identity in
+// the TLS client certificate with which they're created, for private PKI
+// deployments which want each account to be usable only by a single device.
+type ClientIdentityConfig struct {
+	// CACertFile is a PEM file of the CA certificates which issue client
+	// certificates. Certificates which don't chain to one of them, or which
+	// aren't valid for client authentication, are rejected.
+	CACertFile string `validate:"required"`
+
+	// Header, if set, names a request header from which to read the client
+	// certificate when TLS is terminated by a load balancer in front of the
+	// WFE. Its value must be a URL-escaped PEM certificate, optionally
+	// followed by intermediates, as produced by nginx's
+	// $ssl_client_escaped_cert.
+	Header string
+
+	// TrustedProxies are the networks, in CIDR notation, from which Header is
+	// honored. It is ignored on requests from anywhere else.
+	TrustedProxies []string `validate:"required_with=Header,dive,cidr"`
+
+	// Required, if true, rejects new accounts and account-authenticated
+	// requests which don't present a client certificate. Otherwise, accounts
+	// created without one are not bound to an identity.
+	Required bool
+}
+
+// ClientIdentifier extracts the identity of the client from the TLS client
+// certificate presented with a request.
+type ClientIdentifier struct {
+	roots          *x509.CertPool
+	header         string
+	trustedProxies []netip.Prefix
+	required       bool
+}
+
+// NewClientIdentifier returns a ClientIdentifier for the given config.
+func NewClientIdentifier(c ClientIdentityConfig) (*ClientIdentifier, error) {
+	pemBytes, err := os.ReadFile(c.CACertFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading client CA certificates: %w", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(pemBytes) {
+		return nil, fmt.Errorf("no certificates found in %q", c.CACertFile)
+	}
+
+	var trustedProxies []netip.Prefix
+	for _, cidr := range c.TrustedProxies {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("parsing trusted proxy %q: %w", cidr, err)
+		}
+		trustedProxies = append(trustedProxies, prefix)
+	}
+
+	return &ClientIdentifier{
+		roots:          roots,
+		header:         c.Header,
+		trustedProxies: trustedProxies,
+		required:       c.Required,
+	}, nil
+}
+
+// ClientCAs returns the pool of CA certificates which issue client
+// certificates, for use in the WFE's TLS server configuration.
+func (ci *ClientIdentifier) ClientCAs() *x509.CertPool {
+	return ci.roots
+}
+
+// fromTrustedProxy returns true if the request was received from one of the
+// trusted proxies.
+func (ci *ClientIdentifier) fromTrustedProxy(request *http.Request) bool {
+	host, _, err := net.SplitHostPort(request.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range ci.trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// peerCertificates returns the client certificate presented with the request,
+// followed by any intermediates, or nil if none was presented.
+func (ci *ClientIdentifier) peerCertificates(request *http.Request) ([]*x509.Certificate, error) {
+	if request.TLS != nil && len(request.TLS.PeerCertificates) > 0 {
+		return request.TLS.PeerCertificates, nil
+	}
+	if ci.header == "" || !ci.fromTrustedProxy(request) {
+		return nil, nil
+	}
+	value := request.Header.Get(ci.header)
+	if value == "" {
+		return nil, nil
+	}
+
+	pemBytes, err := url.QueryUnescape(value)
+	if err != nil {
+		return nil, berrors.UnauthorizedError("malformed client certificate header")
+	}
+	var certs []*x509.Certificate
+	rest := []byte(pemBytes)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, berrors.UnauthorizedError("malformed client certificate")
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, berrors.UnauthorizedError("malformed client certificate header")
+	}
+	return certs, nil
+}
+
+// identify returns the identity from the client certificate presented with
+// the request, or the empty string if none was presented and one isn't
+// required. The identity is the certificate's first URI SAN, if it has one,
+// then its first DNS SAN, and finally its Subject Common Name.
+func (ci *ClientIdentifier) identify(request *http.Request) (string, error) {
+	certs, err := ci.peerCertificates(request)
+	if err != nil {
+		return "", err
+	}
+	if len(certs) == 0 {
+		if ci.required {
+			return "", berrors.UnauthorizedError("a TLS client certificate is required")
+		}
+		return "", nil
+	}
+
+	leaf := certs[0]
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err = leaf.Verify(x509.VerifyOptions{
+		Roots:         ci.roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		return "", berrors.UnauthorizedError("invalid TLS client certificate: %s", err)
+	}
+
+	switch {
+	case len(leaf.URIs) > 0:
+		return leaf.URIs[0].String(), nil
+	case len(leaf.DNSNames) > 0:
+		return leaf.DNSNames[0], nil
+	case leaf.Subject.CommonName != "":
+		return leaf.Subject.CommonName, nil
+	}
+	return "", berrors.UnauthorizedError("TLS client certificate has no identity")
+}
+
+// checkClientIdentity returns an error if the given account is bound to a
+// client identity other than the one presented with the request. It does
+// nothing unless the WFE has a ClientIdentifier.
+func (wfe *WebFrontEndImpl) checkClientIdentity(ctx context.Context, request *http.Request, regID int64) error {
+	if wfe.ClientIdentifier == nil {
+		return nil
+	}
+	presented, err := wfe.ClientIdentifier.identify(request)
+	if err != nil {
+		return err
+	}
+	bound, err := wfe.sa.GetRegistrationClientIdentity(ctx, &sapb.RegistrationID{Id: regID})
+	if err != nil {
+		return berrors.InternalServerError("failed to retrieve account's client identity: %s", err)
+	}
+	if bound.Identity != "" && bound.Identity != presented {
+		return berrors.UnauthorizedError("account is bound to a different TLS client certificate")
+	}
+	return nil
+}
@@ -0,0 +1,318 @@
+package wfe2
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	berrors "github.com/letsencrypt/boulder/errors"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// testClientCA is a CA which issues client certificates for tests.
+type testClientCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestClientCA(t *testing.T) *testClientCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating CA key")
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "client CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	test.AssertNotError(t, err, "creating CA certificate")
+	cert, err := x509.ParseCertificate(der)
+	test.AssertNotError(t, err, "parsing CA certificate")
+	return &testClientCA{cert: cert, key: key}
+}
+
+// writeFile writes the CA's certificate to a PEM file and returns its path.
+func (ca *testClientCA) writeFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "client-ca.pem")
+	err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw}), 0600)
+	test.AssertNotError(t, err, "writing CA certificate")
+	return path
+}
+
+// issue returns a client certificate for the given URI SAN, or for the given
+// Common Name if uri is empty.
+func (ca *testClientCA) issue(t *testing.T, uri string, cn string, eku x509.ExtKeyUsage) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating client key")
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{eku},
+	}
+	if uri != "" {
+		parsed, err := url.Parse(uri)
+		test.AssertNotError(t, err, "parsing URI SAN")
+		template.URIs = []*url.URL{parsed}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, key.Public(), ca.key)
+	test.AssertNotError(t, err, "creating client certificate")
+	cert, err := x509.ParseCertificate(der)
+	test.AssertNotError(t, err, "parsing client certificate")
+	return cert
+}
+
+func withPeerCertificate(request *http.Request, cert *x509.Certificate) *http.Request {
+	request.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	return request
+}
+
+func escapedPEM(cert *x509.Certificate) string {
+	return url.QueryEscape(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})))
+}
+
+func TestClientIdentifierIdentify(t *testing.T) {
+	t.Parallel()
+
+	ca := newTestClientCA(t)
+	otherCA := newTestClientCA(t)
+	caFile := ca.writeFile(t)
+
+	ci, err := NewClientIdentifier(ClientIdentityConfig{
+		CACertFile:     caFile,
+		Header:         "X-Client-Cert",
+		TrustedProxies: []string{"10.0.0.0/8"},
+	})
+	test.AssertNotError(t, err, "NewClientIdentifier failed")
+
+	deviceCert := ca.issue(t, "spiffe://example.com/device/1", "ignored", x509.ExtKeyUsageClientAuth)
+	cnCert := ca.issue(t, "", "device-2", x509.ExtKeyUsageClientAuth)
+
+	testCases := []struct {
+		name     string
+		request  func() *http.Request
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "no certificate",
+			request:  func() *http.Request { return httptest.NewRequest("POST", "/", nil) },
+			expected: "",
+		},
+		{
+			name: "TLS certificate with URI SAN",
+			request: func() *http.Request {
+				return withPeerCertificate(httptest.NewRequest("POST", "/", nil), deviceCert)
+			},
+			expected: "spiffe://example.com/device/1",
+		},
+		{
+			name: "TLS certificate with only a Common Name",
+			request: func() *http.Request {
+				return withPeerCertificate(httptest.NewRequest("POST", "/", nil), cnCert)
+			},
+			expected: "device-2",
+		},
+		{
+			name: "TLS certificate from another CA",
+			request: func() *http.Request {
+				return withPeerCertificate(httptest.NewRequest("POST", "/", nil),
+					otherCA.issue(t, "spiffe://example.com/device/1", "", x509.ExtKeyUsageClientAuth))
+			},
+			wantErr: true,
+		},
+		{
+			name: "TLS certificate not valid for client auth",
+			request: func() *http.Request {
+				return withPeerCertificate(httptest.NewRequest("POST", "/", nil),
+					ca.issue(t, "spiffe://example.com/device/1", "", x509.ExtKeyUsageServerAuth))
+			},
+			wantErr: true,
+		},
+		{
+			name: "header from trusted proxy",
+			request: func() *http.Request {
+				r := httptest.NewRequest("POST", "/", nil)
+				r.RemoteAddr = "10.1.2.3:4567"
+				r.Header.Set("X-Client-Cert", escapedPEM(deviceCert))
+				return r
+			},
+			expected: "spiffe://example.com/device/1",
+		},
+		{
+			name: "header from untrusted address is ignored",
+			request: func() *http.Request {
+				r := httptest.NewRequest("POST", "/", nil)
+				r.RemoteAddr = "192.0.2.1:4567"
+				r.Header.Set("X-Client-Cert", escapedPEM(deviceCert))
+				return r
+			},
+			expected: "",
+		},
+		{
+			name: "malformed header",
+			request: func() *http.Request {
+				r := httptest.NewRequest("POST", "/", nil)
+				r.RemoteAddr = "10.1.2.3:4567"
+				r.Header.Set("X-Client-Cert", "not a certificate")
+				return r
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			identity, err := ci.identify(tc.request())
+			if tc.wantErr {
+				test.AssertErrorIs(t, err, berrors.Unauthorized)
+				return
+			}
+			test.AssertNotError(t, err, "identify failed")
+			test.AssertEquals(t, identity, tc.expected)
+		})
+	}
+}
+
+func TestClientIdentifierRequired(t *testing.T) {
+	t.Parallel()
+
+	ca := newTestClientCA(t)
+	ci, err := NewClientIdentifier(ClientIdentityConfig{CACertFile: ca.writeFile(t), Required: true})
+	test.AssertNotError(t, err, "NewClientIdentifier failed")
+
+	_, err = ci.identify(httptest.NewRequest("POST", "/", nil))
+	test.AssertErrorIs(t, err, berrors.Unauthorized)
+}
+
+func TestNewClientIdentifierErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewClientIdentifier(ClientIdentityConfig{CACertFile: filepath.Join(t.TempDir(), "missing.pem")})
+	test.AssertError(t, err, "expected error for missing CA file")
+
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	test.AssertNotError(t, os.WriteFile(empty, []byte("nothing here"), 0600), "writing empty CA file")
+	_, err = NewClientIdentifier(ClientIdentityConfig{CACertFile: empty})
+	test.AssertError(t, err, "expected error for CA file without certificates")
+
+	ca := newTestClientCA(t)
+	_, err = NewClientIdentifier(ClientIdentityConfig{
+		CACertFile:     ca.writeFile(t),
+		Header:         "X-Client-Cert",
+		TrustedProxies: []string{"not a cidr"},
+	})
+	test.AssertError(t, err, "expected error for malformed trusted proxy")
+}
+
+// mockSAWithClientIdentities returns the client identity bound to each
+// registration ID.
+type mockSAWithClientIdentities struct {
+	sapb.StorageAuthorityReadOnlyClient
+	identities map[int64]string
+}
+
+func (sa *mockSAWithClientIdentities) GetRegistrationClientIdentity(_ context.Context, req *sapb.RegistrationID, _ ...grpc.CallOption) (*sapb.ClientIdentity, error) {
+	return &sapb.ClientIdentity{Identity: sa.identities[req.Id]}, nil
+}
+
+func TestCheckClientIdentity(t *testing.T) {
+	t.Parallel()
+
+	wfe, _, _ := setupWFE(t)
+	ca := newTestClientCA(t)
+	ci, err := NewClientIdentifier(ClientIdentityConfig{CACertFile: ca.writeFile(t)})
+	test.AssertNotError(t, err, "NewClientIdentifier failed")
+	wfe.ClientIdentifier = ci
+	wfe.sa = &mockSAWithClientIdentities{
+		StorageAuthorityReadOnlyClient: wfe.sa,
+		identities:                     map[int64]string{1: "spiffe://example.com/device/1"},
+	}
+
+	device1 := ca.issue(t, "spiffe://example.com/device/1", "", x509.ExtKeyUsageClientAuth)
+	device2 := ca.issue(t, "spiffe://example.com/device/2", "", x509.ExtKeyUsageClientAuth)
+
+	err = wfe.checkClientIdentity(ctx, withPeerCertificate(httptest.NewRequest("POST", "/", nil), device1), 1)
+	test.AssertNotError(t, err, "matching identity should be accepted")
+
+	err = wfe.checkClientIdentity(ctx, withPeerCertificate(httptest.NewRequest("POST", "/", nil), device2), 1)
+	test.AssertErrorIs(t, err, berrors.Unauthorized)
+
+	err = wfe.checkClientIdentity(ctx, httptest.NewRequest("POST", "/", nil), 1)
+	test.AssertErrorIs(t, err, berrors.Unauthorized)
+
+	// Accounts which aren't bound to an identity can be used by anyone.
+	err = wfe.checkClientIdentity(ctx, withPeerCertificate(httptest.NewRequest("POST", "/", nil), device2), 2)
+	test.AssertNotError(t, err, "unbound account should be accepted")
+}
+
+// recordingRA records the last registration it was asked to create.
+type recordingRA struct {
+	MockRegistrationAuthority
+	reg *corepb.Registration
+}
+
+func (ra *recordingRA) NewRegistration(ctx context.Context, in *corepb.Registration, opts ...grpc.CallOption) (*corepb.Registration, error) {
+	ra.reg = in
+	return ra.MockRegistrationAuthority.NewRegistration(ctx, in, opts...)
+}
+
+func TestNewAccountClientIdentity(t *testing.T) {
+	t.Parallel()
+
+	key := loadKey(t, []byte(test2KeyPrivatePEM))
+	signedURL := fmt.Sprintf("http://localhost%s", newAcctPath)
+	payload := `{"termsOfServiceAgreed":true}`
+
+	wfe, _, signer := setupWFE(t)
+	ra := &recordingRA{}
+	wfe.ra = ra
+	ca := newTestClientCA(t)
+	ci, err := NewClientIdentifier(ClientIdentityConfig{CACertFile: ca.writeFile(t), Required: true})
+	test.AssertNotError(t, err, "NewClientIdentifier failed")
+	wfe.ClientIdentifier = ci
+
+	// Without a client certificate, the account isn't created.
+	_, _, body := signer.embeddedJWK(key, signedURL, payload)
+	responseWriter := httptest.NewRecorder()
+	wfe.NewAccount(ctx, newRequestEvent(), responseWriter, makePostRequestWithPath(newAcctPath, body))
+	test.AssertEquals(t, responseWriter.Code, http.StatusForbidden)
+	test.Assert(t, ra.reg == nil, "account should not have been created")
+
+	// With one, it's bound to the certificate's identity. The request is now
+	// made over TLS, so the signed URL is an https one.
+	_, _, body = signer.embeddedJWK(key, fmt.Sprintf("https://localhost%s", newAcctPath), payload)
+	request := withPeerCertificate(makePostRequestWithPath(newAcctPath, body),
+		ca.issue(t, "spiffe://example.com/device/1", "", x509.ExtKeyUsageClientAuth))
+	responseWriter = httptest.NewRecorder()
+	wfe.NewAccount(ctx, newRequestEvent(), responseWriter, request)
+	test.AssertEquals(t, responseWriter.Code, http.StatusCreated)
+	test.AssertEquals(t, ra.reg.ClientIdentity, "spiffe://example.com/device/1")
+}
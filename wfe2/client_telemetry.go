@@ -0,0 +1,239 @@
+package wfe2
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// defaultClientFamilies are the ACME clients which are given their own metrics
+// labels if no ClientTelemetryConfig lists others. Each is the lowercased name
+// from the first product token of the client's User-Agent.
+var defaultClientFamilies = []string{
+	"acme.sh",
+	"acme4j",
+	"caddy",
+	"certbot",
+	"certify-the-web",
+	"cert-manager",
+	"certmagic",
+	"eggsampler-acme",
+	"go-http-client",
+	"lego-cli",
+	"posh-acme",
+	"traefik",
+	"win-acme",
+	"xenolf-acme",
+}
+
+const (
+	// clientFamilyNone labels requests without a User-Agent.
+	clientFamilyNone = "none"
+	// clientFamilyOther labels requests from clients which aren't one of the
+	// configured families.
+	clientFamilyOther = "other"
+)
+
+// ClientTelemetryConfig configures how ACME clients are bucketed by their
+// User-Agent for metrics, and which client versions are refused.
+type ClientTelemetryConfig struct {
+	// Families are the clients which are given their own metrics labels,
+	// named as in the first product token of their User-Agent, e.g. "certbot"
+	// for "CertBot/2.11.0 (certbot; linux)". Names are matched
+	// case-insensitively. Requests from any other client are labeled "other".
+	// If empty, a built-in list of popular clients is used.
+	Families []string `validate:"omitempty,dive,required"`
+
+	// Deprecations are client versions which are known to be broken. Their
+	// requests are refused with a problem document carrying the configured
+	// detail, so that their operators learn how to fix them.
+	Deprecations []ClientDeprecationConfig `validate:"omitempty,dive"`
+}
+
+// ClientDeprecationConfig describes versions of a client which are refused.
+type ClientDeprecationConfig struct {
+	// Family is the client, named as in ClientTelemetryConfig.Families. It
+	// needn't be one of the Families.
+	Family string `validate:"required"`
+
+	// BelowVersion is the first version which isn't refused, e.g. "1.2.0".
+	// Versions are compared numerically, component by component, ignoring a
+	// leading "v" and any suffix such as "-beta1". Clients which send no
+	// version aren't refused.
+	BelowVersion string `validate:"required"`
+
+	// Endpoints, if set, are the path patterns of the endpoints at which
+	// requests are refused, e.g. "/acme/finalize/". Otherwise requests are
+	// refused at every endpoint.
+	Endpoints []string `validate:"omitempty,dive,startswith=/"`
+
+	// Detail is sent as the problem detail, and should explain how to upgrade.
+	Detail string `validate:"required"`
+}
+
+// clientDeprecation is a parsed ClientDeprecationConfig.
+type clientDeprecation struct {
+	family       string
+	belowVersion []int
+	endpoints    []string
+	detail       string
+}
+
+// ClientTelemetry buckets ACME clients by their User-Agent, and decides which
+// are refused.
+type ClientTelemetry struct {
+	families     map[string]bool
+	deprecations []clientDeprecation
+}
+
+// defaultClientTelemetry is used when no ClientTelemetry is configured. It
+// labels the default families and refuses no clients.
+var defaultClientTelemetry = mustNewClientTelemetry(ClientTelemetryConfig{})
+
+// NewClientTelemetry returns a ClientTelemetry for the given config.
+func NewClientTelemetry(c ClientTelemetryConfig) (*ClientTelemetry, error) {
+	families := c.Families
+	if len(families) == 0 {
+		families = defaultClientFamilies
+	}
+	ct := &ClientTelemetry{families: make(map[string]bool, len(families))}
+	for _, family := range families {
+		family = strings.ToLower(family)
+		if family == clientFamilyNone || family == clientFamilyOther {
+			return nil, fmt.Errorf("client family %q is reserved", family)
+		}
+		ct.families[family] = true
+	}
+
+	for _, d := range c.Deprecations {
+		belowVersion := parseClientVersion(d.BelowVersion)
+		if belowVersion == nil {
+			return nil, fmt.Errorf("invalid belowVersion %q for client family %q", d.BelowVersion, d.Family)
+		}
+		for _, pattern := range d.Endpoints {
+			if !slices.Contains(endpointPaths, pattern) {
+				return nil, fmt.Errorf("unrecognized endpoint %q in deprecation of client family %q", pattern, d.Family)
+			}
+		}
+		ct.deprecations = append(ct.deprecations, clientDeprecation{
+			family:       strings.ToLower(d.Family),
+			belowVersion: belowVersion,
+			endpoints:    d.Endpoints,
+			detail:       d.Detail,
+		})
+	}
+	return ct, nil
+}
+
+func mustNewClientTelemetry(c ClientTelemetryConfig) *ClientTelemetry {
+	ct, err := NewClientTelemetry(c)
+	if err != nil {
+		panic(err)
+	}
+	return ct
+}
+
+// parseUserAgent returns the lowercased name and the version from the first
+// product token of the given User-Agent.
+func parseUserAgent(userAgent string) (string, string) {
+	fields := strings.Fields(userAgent)
+	if len(fields) == 0 {
+		return "", ""
+	}
+	name, version, _ := strings.Cut(fields[0], "/")
+	return strings.ToLower(name), version
+}
+
+// parseClientVersion returns the numeric components of a version such as
+// "v2.11.0-beta1", or nil if it doesn't start with a number. Parsing stops at
+// the first component which doesn't start with a digit, and any non-digits
+// after a component's leading digits are ignored.
+func parseClientVersion(version string) []int {
+	var parsed []int
+	for part := range strings.SplitSeq(strings.TrimPrefix(version, "v"), ".") {
+		end := strings.IndexFunc(part, func(r rune) bool { return r < '0' || r > '9' })
+		if end == -1 {
+			end = len(part)
+		}
+		n, err := strconv.Atoi(part[:end])
+		if err != nil {
+			break
+		}
+		parsed = append(parsed, n)
+		if end != len(part) {
+			break
+		}
+	}
+	return parsed
+}
+
+// classify returns the family and version labels of the client which sent
+// the given User-Agent. The version is truncated to its major and minor
+// components, and is only given for configured families, to bound the number
+// of labels.
+func (ct *ClientTelemetry) classify(userAgent string) (string, string) {
+	name, version := parseUserAgent(userAgent)
+	if name == "" {
+		return clientFamilyNone, ""
+	}
+	if !ct.families[name] {
+		return clientFamilyOther, ""
+	}
+	parsed := parseClientVersion(version)
+	if len(parsed) > 2 {
+		parsed = parsed[:2]
+	}
+	var parts []string
+	for _, n := range parsed {
+		parts = append(parts, strconv.Itoa(n))
+	}
+	return name, strings.Join(parts, ".")
+}
+
+// deprecated returns the problem detail with which a request to the endpoint
+// with the given path pattern should be refused, and false if the client which
+// sent the given User-Agent isn't refused there.
+func (ct *ClientTelemetry) deprecated(userAgent, pattern string) (string, bool) {
+	if len(ct.deprecations) == 0 {
+		return "", false
+	}
+	name, version := parseUserAgent(userAgent)
+	parsed := parseClientVersion(version)
+	if parsed == nil {
+		return "", false
+	}
+	for _, d := range ct.deprecations {
+		if d.family != name {
+			continue
+		}
+		if len(d.endpoints) > 0 && !slices.Contains(d.endpoints, pattern) {
+			continue
+		}
+		if compareClientVersions(parsed, d.belowVersion) < 0 {
+			return d.detail, true
+		}
+	}
+	return "", false
+}
+
+// compareClientVersions compares two parsed versions, treating missing
+// components as zero, and returns -1, 0 or 1 as a is less than, equal to or
+// greater than b.
+func compareClientVersions(a, b []int) int {
+	for i := range max(len(a), len(b)) {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		c := cmp.Compare(x, y)
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
@@ -0,0 +1,157 @@
+package wfe2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/probs"
+	"github.com/letsencrypt/boulder/test"
+	"github.com/letsencrypt/boulder/web"
+)
+
+func TestParseClientVersion(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		version string
+		want    []int
+	}{
+		{"2.11.0", []int{2, 11, 0}},
+		{"v1.14.5", []int{1, 14, 5}},
+		{"3.0.7-beta1", []int{3, 0, 7}},
+		{"4.0rc1.2", []int{4, 0}},
+		{"2", []int{2}},
+		{"", nil},
+		{"dev", nil},
+	}
+	for _, tc := range testCases {
+		test.AssertDeepEquals(t, parseClientVersion(tc.version), tc.want)
+	}
+
+	test.AssertEquals(t, compareClientVersions([]int{1, 2}, []int{1, 2, 0}), 0)
+	test.AssertEquals(t, compareClientVersions([]int{1, 1, 9}, []int{1, 2}), -1)
+	test.AssertEquals(t, compareClientVersions([]int{1, 10}, []int{1, 9, 9}), 1)
+}
+
+func TestClientTelemetryClassify(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		userAgent   string
+		wantFamily  string
+		wantVersion string
+	}{
+		{"CertBot/2.11.0 (certbot; linux) Authenticator/webroot", "certbot", "2.11"},
+		{"lego-cli/4.14.2 xenolf-acme/4.14.2 (release; linux; amd64)", "lego-cli", "4.14"},
+		{"acme.sh/3.0.7 (https://github.com/acmesh-official/acme.sh)", "acme.sh", "3.0"},
+		{"win-acme", "win-acme", ""},
+		{"Mozilla/5.0 (X11; Linux x86_64)", clientFamilyOther, ""},
+		{"", clientFamilyNone, ""},
+	}
+	for _, tc := range testCases {
+		family, version := defaultClientTelemetry.classify(tc.userAgent)
+		test.AssertEquals(t, family, tc.wantFamily)
+		test.AssertEquals(t, version, tc.wantVersion)
+	}
+
+	// Configured families replace the defaults.
+	ct, err := NewClientTelemetry(ClientTelemetryConfig{Families: []string{"Mozilla"}})
+	test.AssertNotError(t, err, "NewClientTelemetry failed")
+	family, version := ct.classify("Mozilla/5.0 (X11; Linux x86_64)")
+	test.AssertEquals(t, family, "mozilla")
+	test.AssertEquals(t, version, "5.0")
+	family, _ = ct.classify("CertBot/2.11.0")
+	test.AssertEquals(t, family, clientFamilyOther)
+}
+
+func TestNewClientTelemetry(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewClientTelemetry(ClientTelemetryConfig{Families: []string{"Other"}})
+	test.AssertError(t, err, "reserved family accepted")
+	_, err = NewClientTelemetry(ClientTelemetryConfig{Deprecations: []ClientDeprecationConfig{
+		{Family: "certbot", BelowVersion: "latest", Detail: "upgrade"},
+	}})
+	test.AssertError(t, err, "unparseable belowVersion accepted")
+	_, err = NewClientTelemetry(ClientTelemetryConfig{Deprecations: []ClientDeprecationConfig{
+		{Family: "certbot", BelowVersion: "1.0", Endpoints: []string{"/acme/unknown"}, Detail: "upgrade"},
+	}})
+	test.AssertError(t, err, "unknown endpoint accepted")
+}
+
+func TestClientTelemetryDeprecated(t *testing.T) {
+	t.Parallel()
+
+	ct, err := NewClientTelemetry(ClientTelemetryConfig{Deprecations: []ClientDeprecationConfig{
+		{Family: "CertBot", BelowVersion: "1.2", Detail: "Please upgrade certbot"},
+		{Family: "lego-cli", BelowVersion: "4.0.0", Endpoints: []string{finalizeOrderPath}, Detail: "Please upgrade lego"},
+	}})
+	test.AssertNotError(t, err, "NewClientTelemetry failed")
+
+	testCases := []struct {
+		userAgent  string
+		pattern    string
+		wantDetail string
+	}{
+		{"certbot/1.1.9", newOrderPath, "Please upgrade certbot"},
+		{"certbot/1.2.0", newOrderPath, ""},
+		{"certbot/2.0", newOrderPath, ""},
+		{"certbot", newOrderPath, ""},
+		{"lego-cli/3.9.1", finalizeOrderPath, "Please upgrade lego"},
+		{"lego-cli/3.9.1", newOrderPath, ""},
+		{"acme.sh/1.0", newOrderPath, ""},
+	}
+	for _, tc := range testCases {
+		detail, ok := ct.deprecated(tc.userAgent, tc.pattern)
+		test.AssertEquals(t, ok, tc.wantDetail != "")
+		test.AssertEquals(t, detail, tc.wantDetail)
+	}
+
+	_, ok := defaultClientTelemetry.deprecated("certbot/0.1", newOrderPath)
+	test.Assert(t, !ok, "default telemetry refused a client")
+}
+
+func TestHandleFuncClientDeprecation(t *testing.T) {
+	wfe, _, _ := setupWFE(t)
+	var err error
+	wfe.ClientTelemetry, err = NewClientTelemetry(ClientTelemetryConfig{Deprecations: []ClientDeprecationConfig{
+		{Family: "certbot", BelowVersion: "1.2", Detail: "Please upgrade certbot"},
+	}})
+	test.AssertNotError(t, err, "NewClientTelemetry failed")
+
+	serve := func(userAgent string) (*httptest.ResponseRecorder, bool) {
+		var called bool
+		mux := http.NewServeMux()
+		wfe.HandleFunc(mux, newOrderPath, func(context.Context, *web.RequestEvent, http.ResponseWriter, *http.Request) {
+			called = true
+		}, "POST")
+		rw := httptest.NewRecorder()
+		mux.ServeHTTP(rw, &http.Request{
+			Method: "POST",
+			URL:    mustParseURL(newOrderPath),
+			Header: http.Header{"User-Agent": {userAgent}},
+		})
+		return rw, called
+	}
+
+	rw, called := serve("certbot/1.1.0 (certbot; linux)")
+	test.Assert(t, !called, "handler called for deprecated client")
+	test.AssertEquals(t, rw.Code, http.StatusForbidden)
+	var prob probs.ProblemDetails
+	err = json.Unmarshal(rw.Body.Bytes(), &prob)
+	test.AssertNotError(t, err, "decoding problem")
+	test.AssertEquals(t, prob.Type, probs.ErrorNS+probs.UnauthorizedProblem)
+	test.AssertEquals(t, prob.Code, probs.CodeClientDeprecated)
+	test.AssertEquals(t, prob.Detail, "Please upgrade certbot")
+	test.AssertMetricWithLabelsEquals(t, wfe.stats.clientErrors, prometheus.Labels{
+		"family": "certbot", "version": "1.1", "code": string(probs.CodeClientDeprecated),
+	}, 1)
+
+	_, called = serve("certbot/1.2.0 (certbot; linux)")
+	test.Assert(t, called, "handler not called for current client")
+}
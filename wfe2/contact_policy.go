@@ -0,0 +1,173 @@
+package wfe2
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/letsencrypt/boulder/core"
+	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/policy"
+)
+
+// ContactPolicyConfig configures which account contacts are accepted, beyond
+// the syntax checks applied to every contact.
+type ContactPolicyConfig struct {
+	// AllowedSchemes are the URL schemes which contacts may use, e.g.
+	// "mailto" or "tel". Only mailto: contacts are exported as email
+	// addresses; contacts with other schemes are accepted but otherwise
+	// unused. If empty, only "mailto" is allowed.
+	AllowedSchemes []string `validate:"omitempty,dive,required"`
+
+	// DeniedDomains are mail domains which mailto: contacts may not use, in
+	// addition to those which are always forbidden. A domain also denies all
+	// of its subdomains, so a TLD such as "test" denies every address under
+	// it.
+	DeniedDomains []string `validate:"omitempty,dive,required"`
+
+	// ReportAllFailures, if true, checks every contact in a request and
+	// rejects it with a problem naming each contact which failed and why,
+	// rather than only the first failure.
+	ReportAllFailures bool
+}
+
+// ContactPolicy decides whether account contacts are acceptable.
+type ContactPolicy struct {
+	allowedSchemes []string
+	deniedDomains  []string
+	reportAll      bool
+}
+
+// defaultContactPolicy is used when no ContactPolicy is configured. It allows
+// only mailto: contacts.
+var defaultContactPolicy = &ContactPolicy{allowedSchemes: []string{"mailto"}}
+
+// NewContactPolicy returns a ContactPolicy for the given config.
+func NewContactPolicy(c ContactPolicyConfig) (*ContactPolicy, error) {
+	schemes := []string{"mailto"}
+	if len(c.AllowedSchemes) > 0 {
+		schemes = nil
+		for _, scheme := range c.AllowedSchemes {
+			scheme = strings.TrimSuffix(strings.ToLower(scheme), ":")
+			if scheme == "" {
+				return nil, errors.New("empty contact scheme in allowed schemes")
+			}
+			schemes = append(schemes, scheme)
+		}
+	}
+
+	var denied []string
+	for _, domain := range c.DeniedDomains {
+		domain = strings.Trim(strings.ToLower(domain), ".")
+		if domain == "" {
+			return nil, errors.New("empty domain in denied contact domains")
+		}
+		denied = append(denied, domain)
+	}
+
+	return &ContactPolicy{
+		allowedSchemes: schemes,
+		deniedDomains:  denied,
+		reportAll:      c.ReportAllFailures,
+	}, nil
+}
+
+// check returns an error if contact isn't acceptable. If it's an acceptable
+// mailto: contact, its email address is returned.
+func (p *ContactPolicy) check(contact string) (string, error) {
+	if contact == "" {
+		return "", berrors.InvalidEmailError("empty contact")
+	}
+
+	parsed, err := url.Parse(contact)
+	if err != nil {
+		return "", berrors.InvalidEmailError("unparsable contact")
+	}
+
+	if !slices.Contains(p.allowedSchemes, parsed.Scheme) {
+		if len(p.allowedSchemes) == 1 && p.allowedSchemes[0] == "mailto" {
+			return "", berrors.UnsupportedContactError("only contact scheme 'mailto:' is supported")
+		}
+		return "", berrors.UnsupportedContactError("contact scheme %q is not supported", parsed.Scheme)
+	}
+
+	if parsed.Scheme != "mailto" {
+		if !core.IsASCII(contact) {
+			return "", berrors.InvalidEmailError("contact contains non-ASCII characters")
+		}
+		if parsed.Opaque == "" && parsed.Host == "" {
+			return "", berrors.InvalidEmailError("contact has no address")
+		}
+		return "", nil
+	}
+
+	if parsed.RawQuery != "" || contact[len(contact)-1] == '?' {
+		return "", berrors.InvalidEmailError("contact email contains a question mark")
+	}
+
+	if parsed.Fragment != "" || contact[len(contact)-1] == '#' {
+		return "", berrors.InvalidEmailError("contact email contains a '#'")
+	}
+
+	if !core.IsASCII(contact) {
+		return "", berrors.InvalidEmailError("contact email contains non-ASCII characters")
+	}
+
+	err = policy.ValidEmail(parsed.Opaque)
+	if err != nil {
+		return "", err
+	}
+
+	if len(p.deniedDomains) > 0 {
+		// ValidEmail has already checked that the address parses.
+		addr, _ := mail.ParseAddress(parsed.Opaque)
+		domain := strings.ToLower(addr.Address[strings.LastIndex(addr.Address, "@")+1:])
+		for _, denied := range p.deniedDomains {
+			if domain == denied || strings.HasSuffix(domain, "."+denied) {
+				return "", berrors.InvalidEmailError("contact email has forbidden domain %q", domain)
+			}
+		}
+	}
+
+	return parsed.Opaque, nil
+}
+
+// contactsToEmails converts a slice of ACME contacts (e.g.
+// "mailto:person@example.com") to a slice of valid email addresses. If any of
+// the contacts use disallowed schemes, unparsable addresses, or forbidden
+// mail domains, it returns an error so that we can provide feedback to
+// misconfigured clients.
+func (p *ContactPolicy) contactsToEmails(contacts []string) ([]string, error) {
+	var emails []string
+	var failures []string
+	var failureType berrors.ErrorType
+	for _, contact := range contacts {
+		email, err := p.check(contact)
+		if err != nil {
+			if !p.reportAll {
+				return nil, err
+			}
+			var bErr *berrors.BoulderError
+			if !errors.As(err, &bErr) {
+				return nil, err
+			}
+			if len(failures) == 0 {
+				failureType = bErr.Type
+			}
+			failures = append(failures, fmt.Sprintf("%q: %s", contact, bErr.Detail))
+			continue
+		}
+		if email != "" {
+			emails = append(emails, email)
+		}
+	}
+
+	if len(failures) > 0 {
+		return nil, berrors.New(failureType, fmt.Sprintf("%d of %d contacts are invalid: %s",
+			len(failures), len(contacts), strings.Join(failures, "; ")))
+	}
+	return emails, nil
+}
@@ -0,0 +1,121 @@
+package wfe2
+
+import (
+	"slices"
+	"testing"
+
+	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestNewContactPolicyErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewContactPolicy(ContactPolicyConfig{AllowedSchemes: []string{"mailto", ":"}})
+	test.AssertError(t, err, "empty scheme accepted")
+	_, err = NewContactPolicy(ContactPolicyConfig{DeniedDomains: []string{"."}})
+	test.AssertError(t, err, "empty domain accepted")
+}
+
+func TestContactPolicy(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		config   ContactPolicyConfig
+		contacts []string
+		want     []string
+		wantErr  string
+		wantType berrors.ErrorType
+	}{
+		{
+			name:     "default allows mailto",
+			contacts: []string{"mailto:one@mail.com"},
+			want:     []string{"one@mail.com"},
+		},
+		{
+			name:     "default rejects tel",
+			contacts: []string{"tel:+15555550100"},
+			wantErr:  "only contact scheme 'mailto:' is supported",
+			wantType: berrors.UnsupportedContact,
+		},
+		{
+			name:     "allowed tel is not exported",
+			config:   ContactPolicyConfig{AllowedSchemes: []string{"mailto", "TEL:"}},
+			contacts: []string{"tel:+15555550100", "mailto:one@mail.com"},
+			want:     []string{"one@mail.com"},
+		},
+		{
+			name:     "disallowed mailto",
+			config:   ContactPolicyConfig{AllowedSchemes: []string{"tel"}},
+			contacts: []string{"mailto:one@mail.com"},
+			wantErr:  `contact scheme "mailto" is not supported`,
+			wantType: berrors.UnsupportedContact,
+		},
+		{
+			name:     "allowed scheme without address",
+			config:   ContactPolicyConfig{AllowedSchemes: []string{"tel"}},
+			contacts: []string{"tel:"},
+			wantErr:  "contact has no address",
+			wantType: berrors.InvalidEmail,
+		},
+		{
+			name:     "denied domain",
+			config:   ContactPolicyConfig{DeniedDomains: []string{"Mail.com."}},
+			contacts: []string{"mailto:one@mail.com"},
+			wantErr:  `contact email has forbidden domain "mail.com"`,
+			wantType: berrors.InvalidEmail,
+		},
+		{
+			name:     "denied subdomain",
+			config:   ContactPolicyConfig{DeniedDomains: []string{"com"}},
+			contacts: []string{"mailto:one@Sub.Mail.com"},
+			wantErr:  `contact email has forbidden domain "sub.mail.com"`,
+			wantType: berrors.InvalidEmail,
+		},
+		{
+			name:     "denied domain isn't a suffix match",
+			config:   ContactPolicyConfig{DeniedDomains: []string{"ail.com"}},
+			contacts: []string{"mailto:one@mail.com"},
+			want:     []string{"one@mail.com"},
+		},
+		{
+			name:     "first failure only",
+			config:   ContactPolicyConfig{DeniedDomains: []string{"mail.com"}},
+			contacts: []string{"mailto:one@mail.com", "tel:+15555550100"},
+			wantErr:  `contact email has forbidden domain "mail.com"`,
+			wantType: berrors.InvalidEmail,
+		},
+		{
+			name: "all failures",
+			config: ContactPolicyConfig{
+				DeniedDomains:     []string{"mail.com"},
+				ReportAllFailures: true,
+			},
+			contacts: []string{"tel:+15555550100", "mailto:ok@letsencrypt.org", "mailto:one@mail.com"},
+			wantErr:  `2 of 3 contacts are invalid: "tel:+15555550100": only contact scheme 'mailto:' is supported; "mailto:one@mail.com": contact email has forbidden domain "mail.com"`,
+			wantType: berrors.UnsupportedContact,
+		},
+		{
+			name:     "all failures with none failing",
+			config:   ContactPolicyConfig{ReportAllFailures: true},
+			contacts: []string{"mailto:one@mail.com", "mailto:two@mail.com"},
+			want:     []string{"one@mail.com", "two@mail.com"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			p, err := NewContactPolicy(tc.config)
+			test.AssertNotError(t, err, "creating contact policy")
+			got, err := p.contactsToEmails(tc.contacts)
+			if tc.wantErr != "" {
+				test.AssertError(t, err, "invalid contacts accepted")
+				test.AssertEquals(t, err.Error(), tc.wantErr)
+				test.AssertErrorIs(t, err, tc.wantType)
+				return
+			}
+			test.AssertNotError(t, err, "valid contacts rejected")
+			test.Assert(t, slices.Equal(got, tc.want), "wrong emails returned")
+		})
+	}
+}
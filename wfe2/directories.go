@@ -0,0 +1,187 @@
+package wfe2
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/letsencrypt/boulder/web"
+)
+
+// DirectoryConfig configures an additional ACME directory served by the WFE
+// under its own hostname, path prefix, or both, for example to offer a
+// different set of profiles or terms of service to one set of subscribers.
+// Accounts, orders, and certificates are shared between all directories, but
+// the URLs of each are relative to the directory through which they're
+// accessed.
+type DirectoryConfig struct {
+	// Host, if set, is the hostname whose requests are served by this
+	// directory, regardless of port.
+	Host string `validate:"omitempty,hostname"`
+
+	// PathPrefix, if set, is the path under which this directory's endpoints
+	// are served, e.g. "/tenant1" serves the directory at
+	// "/tenant1/directory". It must start, and must not end, with a slash.
+	PathPrefix string `validate:"omitempty,startswith=/"`
+
+	// SubscriberAgreementURL, if set, overrides the default directory's
+	// terms of service.
+	SubscriberAgreementURL string `validate:"omitempty,url"`
+
+	// DirectoryWebsite, if set, overrides the default directory's "website".
+	DirectoryWebsite string `validate:"omitempty,url"`
+
+	// CertProfiles, if set, are the names of the certificate profiles which
+	// are offered by this directory. Each must be one of the WFE's
+	// certProfiles. Otherwise, all of them are offered.
+	CertProfiles []string `validate:"omitempty,dive,alphanum,min=1,max=32"`
+
+	// MaxContactsPerRegistration, if non-zero, overrides the default
+	// directory's limit on the number of contacts in a NewAccount request.
+	MaxContactsPerRegistration int `validate:"omitempty,min=1"`
+}
+
+// Directory is an additional ACME directory served by the WFE.
+type Directory struct {
+	DirectoryConfig
+}
+
+// NewDirectories checks the given directory configs against each other and the
+// certificate profiles known to the WFE, and returns the directories they
+// configure.
+func NewDirectories(configs []DirectoryConfig, certProfiles map[string]string) ([]Directory, error) {
+	var dirs []Directory
+	seen := make(map[string]bool)
+	for _, c := range configs {
+		if c.Host == "" && c.PathPrefix == "" {
+			return nil, errors.New("directory must have a host, a path prefix, or both")
+		}
+		if c.PathPrefix != "" && (!strings.HasPrefix(c.PathPrefix, "/") || strings.HasSuffix(c.PathPrefix, "/")) {
+			return nil, fmt.Errorf("directory path prefix %q must start, and must not end, with a slash", c.PathPrefix)
+		}
+		c.Host = strings.ToLower(c.Host)
+		key := c.Host + c.PathPrefix
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate directory for host %q and path prefix %q", c.Host, c.PathPrefix)
+		}
+		seen[key] = true
+		for _, name := range c.CertProfiles {
+			_, ok := certProfiles[name]
+			if !ok {
+				return nil, fmt.Errorf("directory profile %q is not one of the WFE's certProfiles", name)
+			}
+		}
+		dirs = append(dirs, Directory{c})
+	}
+	return dirs, nil
+}
+
+// matches returns true if the request is for this directory.
+func (d *Directory) matches(r *http.Request) bool {
+	if d.Host != "" {
+		host := r.Host
+		h, _, err := net.SplitHostPort(host)
+		if err == nil {
+			host = h
+		}
+		if !strings.EqualFold(host, d.Host) {
+			return false
+		}
+	}
+	if d.PathPrefix != "" {
+		return r.URL.Path == d.PathPrefix || strings.HasPrefix(r.URL.Path, d.PathPrefix+"/")
+	}
+	return true
+}
+
+// withPolicy returns a copy of the WFE which applies the directory's policy.
+func (d *Directory) withPolicy(wfe *WebFrontEndImpl) *WebFrontEndImpl {
+	tenant := *wfe
+	tenant.Directories = nil
+	if d.SubscriberAgreementURL != "" {
+		tenant.SubscriberAgreementURL = d.SubscriberAgreementURL
+	}
+	if d.DirectoryWebsite != "" {
+		tenant.DirectoryWebsite = d.DirectoryWebsite
+	}
+	if len(d.CertProfiles) != 0 {
+		tenant.certProfiles = make(map[string]string, len(d.CertProfiles))
+		for _, name := range d.CertProfiles {
+			tenant.certProfiles[name] = wfe.certProfiles[name]
+		}
+	}
+	if d.MaxContactsPerRegistration != 0 {
+		tenant.maxContactsPerReg = d.MaxContactsPerRegistration
+	}
+	return &tenant
+}
+
+// directoryRoute serves the requests which match a Directory.
+type directoryRoute struct {
+	Directory
+	mux *http.ServeMux
+}
+
+// stripped returns a shallow copy of the request with the directory's path
+// prefix removed from its URL and stored in its context, so that the URLs
+// constructed by web.RelativeEndpoint include it. The request's RequestURI is
+// unchanged, so that it still matches the URL in the JWS.
+func (d *directoryRoute) stripped(r *http.Request) *http.Request {
+	if d.PathPrefix == "" {
+		return r
+	}
+	r2 := r.WithContext(web.WithPathPrefix(r.Context(), d.PathPrefix))
+	u := *r.URL
+	u.Path = strings.TrimPrefix(r.URL.Path, d.PathPrefix)
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	u.RawPath = ""
+	r2.URL = &u
+	return r2
+}
+
+// directoryMux routes each request to the first directory it matches, or the
+// default directory if it matches none. It satisfies the interface which
+// measured_http requires.
+type directoryMux struct {
+	routes   []directoryRoute
+	fallback *http.ServeMux
+}
+
+// newDirectoryMux returns a mux which serves each of the WFE's Directories,
+// and the default directory for requests which match none of them. The most
+// specific directories, those with the longest path prefix and then those
+// with a host, are matched first.
+func (wfe *WebFrontEndImpl) newDirectoryMux() *directoryMux {
+	m := &directoryMux{fallback: wfe.newServeMux()}
+	for _, d := range wfe.Directories {
+		m.routes = append(m.routes, directoryRoute{d, d.withPolicy(wfe).newServeMux()})
+	}
+	slices.SortStableFunc(m.routes, func(a, b directoryRoute) int {
+		if c := cmp.Compare(len(b.PathPrefix), len(a.PathPrefix)); c != 0 {
+			return c
+		}
+		return cmp.Compare(len(b.Host), len(a.Host))
+	})
+	return m
+}
+
+// Handler returns the handler for the request, and the pattern it matched,
+// prefixed by the host and path prefix of its directory, if any.
+func (m *directoryMux) Handler(r *http.Request) (http.Handler, string) {
+	for _, route := range m.routes {
+		if !route.matches(r) {
+			continue
+		}
+		_, pattern := route.mux.Handler(route.stripped(r))
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route.mux.ServeHTTP(w, route.stripped(r))
+		}), route.Host + route.PathPrefix + pattern
+	}
+	return m.fallback.Handler(r)
+}
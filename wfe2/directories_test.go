@@ -0,0 +1,87 @@
+package wfe2
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestNewDirectories(t *testing.T) {
+	profiles := map[string]string{"default": "a test profile"}
+
+	_, err := NewDirectories([]DirectoryConfig{{}}, profiles)
+	test.AssertError(t, err, "directory without a host or path prefix should be rejected")
+
+	_, err = NewDirectories([]DirectoryConfig{{PathPrefix: "/tenant/"}}, profiles)
+	test.AssertError(t, err, "path prefix with a trailing slash should be rejected")
+
+	_, err = NewDirectories([]DirectoryConfig{{PathPrefix: "/tenant"}, {PathPrefix: "/tenant"}}, profiles)
+	test.AssertError(t, err, "duplicate directories should be rejected")
+
+	_, err = NewDirectories([]DirectoryConfig{{PathPrefix: "/tenant", CertProfiles: []string{"unknown"}}}, profiles)
+	test.AssertError(t, err, "unknown profile should be rejected")
+
+	dirs, err := NewDirectories([]DirectoryConfig{{Host: "ACME.example.com"}, {PathPrefix: "/tenant"}}, profiles)
+	test.AssertNotError(t, err, "valid directories should be accepted")
+	test.AssertEquals(t, len(dirs), 2)
+	test.AssertEquals(t, dirs[0].Host, "acme.example.com")
+}
+
+func TestDirectoriesHandler(t *testing.T) {
+	wfe, _, _ := setupWFE(t)
+	wfe.certProfiles = map[string]string{"default": "a test profile", "shortlived": "a short-lived profile"}
+	var err error
+	wfe.Directories, err = NewDirectories([]DirectoryConfig{
+		{
+			PathPrefix:   "/tenant",
+			CertProfiles: []string{"shortlived"},
+		},
+		{
+			Host:                   "acme.example.com",
+			SubscriberAgreementURL: "http://example.invalid/other-terms",
+		},
+	}, wfe.certProfiles)
+	test.AssertNotError(t, err, "creating directories")
+	handler := wfe.Handler(metrics.NoopRegisterer)
+
+	getDirectory := func(host, path string) map[string]interface{} {
+		t.Helper()
+		responseWriter := httptest.NewRecorder()
+		handler.ServeHTTP(responseWriter, httptest.NewRequest(http.MethodGet, "http://"+host+path, nil))
+		test.AssertEquals(t, responseWriter.Code, http.StatusOK)
+		var dir map[string]interface{}
+		err := json.Unmarshal(responseWriter.Body.Bytes(), &dir)
+		test.AssertNotError(t, err, "unmarshaling directory")
+		return dir
+	}
+
+	// Requests which match no directory are served by the default one.
+	dir := getDirectory("localhost:4300", "/directory")
+	test.AssertEquals(t, dir["newAccount"], "http://localhost:4300/acme/new-acct")
+	meta := dir["meta"].(map[string]interface{})
+	test.AssertEquals(t, meta["termsOfService"], "http://example.invalid/terms")
+	test.AssertEquals(t, len(meta["profiles"].(map[string]interface{})), 2)
+
+	// A path prefix is included in every URL, and only the directory's
+	// profiles are offered.
+	dir = getDirectory("localhost:4300", "/tenant/directory")
+	test.AssertEquals(t, dir["newAccount"], "http://localhost:4300/tenant/acme/new-acct")
+	meta = dir["meta"].(map[string]interface{})
+	profiles := meta["profiles"].(map[string]interface{})
+	test.AssertEquals(t, len(profiles), 1)
+	test.AssertEquals(t, profiles["shortlived"], "a short-lived profile")
+
+	// A host matches regardless of port.
+	dir = getDirectory("acme.example.com:4300", "/directory")
+	test.AssertEquals(t, dir["newAccount"], "http://acme.example.com:4300/acme/new-acct")
+	meta = dir["meta"].(map[string]interface{})
+	test.AssertEquals(t, meta["termsOfService"], "http://example.invalid/other-terms")
+
+	// The default directory's policy is unchanged.
+	test.AssertEquals(t, wfe.SubscriberAgreementURL, "http://example.invalid/terms")
+	test.AssertEquals(t, len(wfe.certProfiles), 2)
+}
@@ -0,0 +1,91 @@
+package wfe2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	exemptionspb "github.com/letsencrypt/boulder/exemptions/proto"
+	"github.com/letsencrypt/boulder/identifier"
+	"github.com/letsencrypt/boulder/probs"
+	"github.com/letsencrypt/boulder/web"
+)
+
+// exemptionRequestJSON is the body of a rate-limit-exemption request.
+type exemptionRequestJSON struct {
+	// Limit is the name of the rate limit, e.g. "CertificatesPerDomain".
+	Limit string `json:"limit"`
+	// Identifiers are those the override applies to, for limits which are
+	// per identifier or per set of identifiers.
+	Identifiers identifier.ACMEIdentifiers `json:"identifiers,omitempty"`
+	// Count and Period are the requested limit, e.g. 100 per "168h".
+	Count         int64  `json:"count"`
+	Period        string `json:"period"`
+	Justification string `json:"justification"`
+}
+
+// exemptionResponseJSON is the response to a rate-limit-exemption request.
+type exemptionResponseJSON struct {
+	ID     int64  `json:"id"`
+	Status string `json:"status"`
+}
+
+// RateLimitExemption is a Boulder-specific endpoint which lets an account ask
+// for a rate limit override, with a justification. The request is queued for
+// review by the exemption-queue service rather than granted immediately.
+func (wfe *WebFrontEndImpl) RateLimitExemption(
+	ctx context.Context,
+	logEvent *web.RequestEvent,
+	response http.ResponseWriter,
+	request *http.Request) {
+	body, _, acct, err := wfe.validPOSTForAccount(request, ctx, logEvent)
+	addRequesterHeader(response, logEvent.Requester)
+	if err != nil {
+		// validPOSTForAccount handles its own setting of logEvent.Errors
+		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Unable to validate JWS"), err)
+		return
+	}
+
+	var exemptionRequest exemptionRequestJSON
+	err = json.Unmarshal(body, &exemptionRequest)
+	if err != nil {
+		wfe.sendError(response, logEvent,
+			probs.Malformed("Unable to unmarshal rate limit exemption request body"), err)
+		return
+	}
+	if exemptionRequest.Limit == "" {
+		wfe.sendError(response, logEvent, probs.Malformed("Rate limit exemption request did not specify a limit"), nil)
+		return
+	}
+	period, err := time.ParseDuration(exemptionRequest.Period)
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.Malformed("Rate limit exemption request has an invalid period"), err)
+		return
+	}
+	logEvent.Identifiers = exemptionRequest.Identifiers
+
+	resp, err := wfe.eq.SubmitExemptionRequest(ctx, &exemptionspb.SubmitExemptionRequestRequest{
+		RegistrationID: acct.ID,
+		Limit:          exemptionRequest.Limit,
+		Identifiers:    exemptionRequest.Identifiers.ToProtoSlice(),
+		Count:          exemptionRequest.Count,
+		Period:         durationpb.New(period),
+		Justification:  exemptionRequest.Justification,
+	})
+	if err != nil {
+		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Error submitting rate limit exemption request"), err)
+		return
+	}
+
+	err = wfe.writeJsonResponse(response, logEvent, http.StatusCreated, exemptionResponseJSON{
+		ID:     resp.Id,
+		Status: resp.Status,
+	})
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.ServerInternal("Error marshaling rate limit exemption response"), err)
+		return
+	}
+}
@@ -0,0 +1,96 @@
+package wfe2
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	berrors "github.com/letsencrypt/boulder/errors"
+	exemptionspb "github.com/letsencrypt/boulder/exemptions/proto"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/probs"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// mockExemptionQueue accepts every request except those for the "Bogus"
+// limit.
+type mockExemptionQueue struct {
+	lastReq *exemptionspb.SubmitExemptionRequestRequest
+}
+
+func (eq *mockExemptionQueue) SubmitExemptionRequest(_ context.Context, in *exemptionspb.SubmitExemptionRequestRequest, _ ...grpc.CallOption) (*exemptionspb.SubmitExemptionRequestResponse, error) {
+	eq.lastReq = in
+	if in.Limit == "Bogus" {
+		return nil, berrors.MalformedError("unknown rate limit %q", in.Limit)
+	}
+	return &exemptionspb.SubmitExemptionRequestResponse{Id: 7, Status: "pending"}, nil
+}
+
+func TestRateLimitExemption(t *testing.T) {
+	wfe, _, signer := setupWFE(t)
+	eq := &mockExemptionQueue{}
+	wfe.eq = eq
+
+	targetPath := "rate-limit-exemption"
+	signedURL := "http://localhost/" + targetPath
+
+	testCases := []struct {
+		Name         string
+		Payload      string
+		ExpectedCode int
+		ExpectedBody string
+	}{
+		{
+			Name:         "no limit",
+			Payload:      `{"count":100,"period":"24h","justification":"hosting provider"}`,
+			ExpectedCode: 400,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Rate limit exemption request did not specify a limit","status":400, "code": "malformed"}`,
+		},
+		{
+			Name:         "invalid period",
+			Payload:      `{"limit":"NewOrdersPerAccount","count":100,"period":"a day","justification":"hosting provider"}`,
+			ExpectedCode: 400,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Rate limit exemption request has an invalid period","status":400, "code": "malformed"}`,
+		},
+		{
+			Name:         "rejected by queue",
+			Payload:      `{"limit":"Bogus","count":100,"period":"24h","justification":"hosting provider"}`,
+			ExpectedCode: 400,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Error submitting rate limit exemption request :: unknown rate limit \"Bogus\"","status":400, "code": "malformed"}`,
+		},
+		{
+			Name:         "queued",
+			Payload:      `{"limit":"CertificatesPerDomain","identifiers":[{"type":"dns","value":"example.com"}],"count":100,"period":"24h","justification":"hosting provider"}`,
+			ExpectedCode: 201,
+			ExpectedBody: `{"id": 7, "status": "pending"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			responseWriter := httptest.NewRecorder()
+			wfe.RateLimitExemption(ctx, newRequestEvent(), responseWriter, signAndPost(signer, targetPath, signedURL, tc.Payload))
+			test.AssertEquals(t, responseWriter.Code, tc.ExpectedCode)
+			test.AssertUnmarshaledEquals(t, responseWriter.Body.String(), tc.ExpectedBody)
+		})
+	}
+
+	test.AssertEquals(t, eq.lastReq.RegistrationID, int64(1))
+	test.AssertEquals(t, eq.lastReq.Limit, "CertificatesPerDomain")
+	test.AssertEquals(t, len(eq.lastReq.Identifiers), 1)
+	test.AssertEquals(t, eq.lastReq.Count, int64(100))
+	test.AssertEquals(t, eq.lastReq.Period.AsDuration(), 24*time.Hour)
+	test.AssertEquals(t, eq.lastReq.Justification, "hosting provider")
+}
+
+func TestRateLimitExemptionNotServed(t *testing.T) {
+	wfe, _, signer := setupWFE(t)
+	mux := wfe.Handler(metrics.NoopRegisterer)
+
+	responseWriter := httptest.NewRecorder()
+	mux.ServeHTTP(responseWriter, signAndPost(signer, rateLimitExemptionPath, "http://localhost"+rateLimitExemptionPath, "{}"))
+	test.AssertEquals(t, responseWriter.Code, 404)
+}
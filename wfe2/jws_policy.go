@@ -0,0 +1,143 @@
+package wfe2
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/go-jose/go-jose/v4"
+
+	"github.com/letsencrypt/boulder/core/jws"
+	berrors "github.com/letsencrypt/boulder/errors"
+)
+
+// knownJWSAlgorithms are the JWS signature algorithms which may be allowed by
+// a JWSAlgorithmPolicy, in the order in which they're advertised.
+var knownJWSAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256,
+	jose.ES256,
+	jose.ES384,
+	jose.ES512,
+	jose.EdDSA,
+}
+
+// JWSAlgorithmPolicyConfig configures which JWS signature algorithms requests
+// may be signed with.
+type JWSAlgorithmPolicyConfig struct {
+	// Allowed are the algorithms which requests may be signed with. If empty,
+	// RS256, ES256, ES384 and ES512 are allowed. Allowing EdDSA only has an
+	// effect if Ed25519 keys are also allowed by the goodKey configs of both
+	// the WFE and the RA.
+	Allowed []string `validate:"omitempty,dive,oneof=RS256 ES256 ES384 ES512 EdDSA"`
+
+	// Deprecated are allowed algorithms which are due to be disabled. Requests
+	// signed with one are still accepted, but any problem document sent in
+	// response to one has a "deprecatedAlgorithm" field naming it, so that
+	// clients can notice before it's removed from Allowed.
+	Deprecated []string `validate:"omitempty,dive,oneof=RS256 ES256 ES384 ES512 EdDSA"`
+}
+
+// JWSAlgorithmPolicy decides which JWS signature algorithms are accepted.
+type JWSAlgorithmPolicy struct {
+	allowed    []jose.SignatureAlgorithm
+	deprecated []jose.SignatureAlgorithm
+}
+
+// defaultJWSAlgorithmPolicy is used when no JWSAlgorithmPolicy is configured.
+// It allows RSA and ECDSA signatures, none of them deprecated.
+var defaultJWSAlgorithmPolicy = &JWSAlgorithmPolicy{allowed: getSupportedAlgs()}
+
+// NewJWSAlgorithmPolicy returns a JWSAlgorithmPolicy for the given config.
+func NewJWSAlgorithmPolicy(c JWSAlgorithmPolicyConfig) (*JWSAlgorithmPolicy, error) {
+	allowed := getSupportedAlgs()
+	if len(c.Allowed) > 0 {
+		allowed = nil
+		for _, name := range c.Allowed {
+			alg := jose.SignatureAlgorithm(name)
+			if !slices.Contains(knownJWSAlgorithms, alg) {
+				return nil, fmt.Errorf("unknown JWS algorithm %q", name)
+			}
+			if !slices.Contains(allowed, alg) {
+				allowed = append(allowed, alg)
+			}
+		}
+	}
+
+	var deprecated []jose.SignatureAlgorithm
+	for _, name := range c.Deprecated {
+		alg := jose.SignatureAlgorithm(name)
+		if !slices.Contains(allowed, alg) {
+			return nil, fmt.Errorf("deprecated JWS algorithm %q is not allowed", name)
+		}
+		deprecated = append(deprecated, alg)
+	}
+	if len(deprecated) == len(allowed) {
+		return nil, errors.New("every allowed JWS algorithm is deprecated")
+	}
+
+	return &JWSAlgorithmPolicy{allowed: allowed, deprecated: deprecated}, nil
+}
+
+// algs returns the allowed algorithms, deprecated or not.
+func (p *JWSAlgorithmPolicy) algs() []jose.SignatureAlgorithm {
+	return slices.Clone(p.allowed)
+}
+
+// isDeprecated returns whether alg is allowed, but due to be disabled.
+func (p *JWSAlgorithmPolicy) isDeprecated(alg jose.SignatureAlgorithm) bool {
+	return slices.Contains(p.deprecated, alg)
+}
+
+// recommended returns the allowed algorithms which aren't deprecated.
+func (p *JWSAlgorithmPolicy) recommended() []jose.SignatureAlgorithm {
+	var algs []jose.SignatureAlgorithm
+	for _, alg := range p.allowed {
+		if !p.isDeprecated(alg) {
+			algs = append(algs, alg)
+		}
+	}
+	return algs
+}
+
+// status describes how the policy treats alg, for metrics. Algorithms which
+// Boulder doesn't know are all described as "unknown", so that clients can't
+// add arbitrary labels.
+func (p *JWSAlgorithmPolicy) status(alg jose.SignatureAlgorithm) (jose.SignatureAlgorithm, string) {
+	switch {
+	case !slices.Contains(knownJWSAlgorithms, alg):
+		return "unknown", "disabled"
+	case p.isDeprecated(alg):
+		return alg, "deprecated"
+	case slices.Contains(p.allowed, alg):
+		return alg, "allowed"
+	default:
+		return alg, "disabled"
+	}
+}
+
+// checkAlgorithm checks that (1) the algorithm in the JWS signature header is
+// allowed, (2) there is a suitable algorithm for the provided key based on its
+// Golang type, (3) the signature header's algorithm matches that algorithm,
+// and (4) the Algorithm field on the JWK is either absent, or matches that
+// algorithm.
+func (p *JWSAlgorithmPolicy) checkAlgorithm(key *jose.JSONWebKey, header jose.Header) error {
+	sigHeaderAlg := jose.SignatureAlgorithm(header.Algorithm)
+	if !slices.Contains(p.allowed, sigHeaderAlg) {
+		return berrors.BadSignatureAlgorithmError(
+			"JWS signature header contains unsupported algorithm %q, expected one of %s",
+			header.Algorithm, p.allowed,
+		)
+	}
+
+	expectedAlg, err := jws.AlgorithmForKey(key)
+	if err != nil {
+		return err
+	}
+	if sigHeaderAlg != expectedAlg {
+		return berrors.MalformedError("JWS signature header algorithm %q does not match expected algorithm %q for JWK", sigHeaderAlg, string(expectedAlg))
+	}
+	if key.Algorithm != "" && key.Algorithm != string(expectedAlg) {
+		return berrors.MalformedError("JWK key header algorithm %q does not match expected algorithm %q for JWK", key.Algorithm, string(expectedAlg))
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package wfe2
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/probs"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestNewJWSAlgorithmPolicyErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewJWSAlgorithmPolicy(JWSAlgorithmPolicyConfig{Allowed: []string{"HS256"}})
+	test.AssertContains(t, err.Error(), `unknown JWS algorithm "HS256"`)
+	_, err = NewJWSAlgorithmPolicy(JWSAlgorithmPolicyConfig{Deprecated: []string{"EdDSA"}})
+	test.AssertContains(t, err.Error(), `deprecated JWS algorithm "EdDSA" is not allowed`)
+	_, err = NewJWSAlgorithmPolicy(JWSAlgorithmPolicyConfig{Allowed: []string{"RS256"}, Deprecated: []string{"RS256"}})
+	test.AssertContains(t, err.Error(), "every allowed JWS algorithm is deprecated")
+}
+
+func TestJWSAlgorithmPolicy(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewJWSAlgorithmPolicy(JWSAlgorithmPolicyConfig{})
+	test.AssertNotError(t, err, "empty config")
+	test.AssertDeepEquals(t, p.algs(), getSupportedAlgs())
+
+	p, err = NewJWSAlgorithmPolicy(JWSAlgorithmPolicyConfig{
+		Allowed:    []string{"ES256", "RS256", "EdDSA", "ES256"},
+		Deprecated: []string{"RS256"},
+	})
+	test.AssertNotError(t, err, "valid config")
+	test.AssertDeepEquals(t, p.algs(), []jose.SignatureAlgorithm{jose.ES256, jose.RS256, jose.EdDSA})
+
+	for _, tc := range []struct {
+		alg        jose.SignatureAlgorithm
+		wantLabel  jose.SignatureAlgorithm
+		wantStatus string
+	}{
+		{jose.ES256, jose.ES256, "allowed"},
+		{jose.EdDSA, jose.EdDSA, "allowed"},
+		{jose.RS256, jose.RS256, "deprecated"},
+		{jose.ES384, jose.ES384, "disabled"},
+		{jose.HS256, "unknown", "disabled"},
+		{"made-up", "unknown", "disabled"},
+	} {
+		label, status := p.status(tc.alg)
+		test.AssertEquals(t, label, tc.wantLabel)
+		test.AssertEquals(t, status, tc.wantStatus)
+	}
+}
+
+func TestCheckAlgorithmEdDSA(t *testing.T) {
+	t.Parallel()
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	jwk := &jose.JSONWebKey{Key: pub}
+
+	err = defaultJWSAlgorithmPolicy.checkAlgorithm(jwk, jose.Header{Algorithm: string(jose.EdDSA)})
+	test.AssertContains(t, err.Error(), `unsupported algorithm "EdDSA"`)
+
+	p, err := NewJWSAlgorithmPolicy(JWSAlgorithmPolicyConfig{Allowed: []string{"ES256", "EdDSA"}})
+	test.AssertNotError(t, err, "valid config")
+	err = p.checkAlgorithm(jwk, jose.Header{Algorithm: string(jose.EdDSA)})
+	test.AssertNotError(t, err, "EdDSA with an Ed25519 key")
+	err = p.checkAlgorithm(jwk, jose.Header{Algorithm: string(jose.ES256)})
+	test.AssertContains(t, err.Error(), `does not match expected algorithm "EdDSA"`)
+}
+
+func TestDeprecatedJWSAlgorithm(t *testing.T) {
+	wfe, _, signer := setupWFE(t)
+	var err error
+	wfe.JWSAlgorithms, err = NewJWSAlgorithmPolicy(JWSAlgorithmPolicyConfig{Deprecated: []string{"RS256"}})
+	test.AssertNotError(t, err, "valid config")
+
+	// The default test key is an RSA key, so this JWS is signed with RS256.
+	jws, jwk, body := signer.embeddedJWK(nil, "http://localhost/test", `{}`)
+	logEvent := newRequestEvent()
+	_, err = wfe.validJWSForKey(
+		context.Background(), &bJSONWebSignature{jws}, jwk, makePostRequestWithPath("test", body), logEvent)
+	test.AssertNotError(t, err, "deprecated algorithm rejected")
+	test.AssertEquals(t, logEvent.JWSAlgorithm, "RS256")
+	test.AssertMetricWithLabelsEquals(
+		t, wfe.stats.jwsSignatureAlgs, prometheus.Labels{"alg": "RS256", "status": "deprecated"}, 1)
+
+	// Any problem sent in response to the request names the deprecated
+	// algorithm.
+	responseWriter := httptest.NewRecorder()
+	wfe.sendError(responseWriter, logEvent, probs.Malformed("oops"), nil)
+	var prob probs.ProblemDetails
+	err = json.Unmarshal(responseWriter.Body.Bytes(), &prob)
+	test.AssertNotError(t, err, "unmarshaling problem")
+	test.AssertEquals(t, prob.DeprecatedAlgorithm, jose.RS256)
+
+	// Problems for requests signed with an algorithm which isn't deprecated
+	// don't.
+	logEvent = newRequestEvent()
+	logEvent.JWSAlgorithm = string(jose.ES256)
+	responseWriter = httptest.NewRecorder()
+	wfe.sendError(responseWriter, logEvent, probs.Malformed("oops"), nil)
+	test.AssertNotContains(t, responseWriter.Body.String(), "deprecatedAlgorithm")
+}
@@ -0,0 +1,134 @@
+package wfe2
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// maxKeepalives is the maximum number of interim responses sent while waiting
+// for a single request to complete. Go's HTTP clients reject responses preceded
+// by more than five interim responses, so we stay below that.
+const maxKeepalives = 4
+
+// keepaliveWriter is an http.ResponseWriter which can send interim 102
+// (Processing) responses while the wrapped handler is still working, so that
+// proxies and load balancers in front of the WFE see activity on otherwise idle
+// connections. Headers set by the handler are held back until it writes its
+// final response, so that interim responses never carry them and never race
+// with the handler modifying them.
+type keepaliveWriter struct {
+	w      http.ResponseWriter
+	header http.Header
+
+	mu   sync.Mutex
+	done bool
+	sent int
+}
+
+// newKeepaliveWriter wraps w, taking ownership of any headers already set on
+// it.
+func newKeepaliveWriter(w http.ResponseWriter) *keepaliveWriter {
+	header := w.Header().Clone()
+	for k := range w.Header() {
+		w.Header().Del(k)
+	}
+	return &keepaliveWriter{w: w, header: header}
+}
+
+func (kw *keepaliveWriter) Header() http.Header {
+	return kw.header
+}
+
+// writeFinalHeaderLocked copies the handler's headers to the wrapped writer and
+// writes the final status code. The caller must hold kw.mu.
+func (kw *keepaliveWriter) writeFinalHeaderLocked(code int) {
+	for k, v := range kw.header {
+		kw.w.Header()[k] = v
+	}
+	kw.w.WriteHeader(code)
+	kw.done = true
+}
+
+func (kw *keepaliveWriter) WriteHeader(code int) {
+	kw.mu.Lock()
+	defer kw.mu.Unlock()
+	if kw.done {
+		return
+	}
+	kw.writeFinalHeaderLocked(code)
+}
+
+func (kw *keepaliveWriter) Write(b []byte) (int, error) {
+	kw.mu.Lock()
+	defer kw.mu.Unlock()
+	if !kw.done {
+		kw.writeFinalHeaderLocked(http.StatusOK)
+	}
+	return kw.w.Write(b)
+}
+
+// Unwrap allows http.ResponseController to reach the underlying writer.
+func (kw *keepaliveWriter) Unwrap() http.ResponseWriter {
+	return kw.w
+}
+
+// keepalive sends an interim response, unless the final response has already
+// been started or the maximum number of interim responses has been sent. It
+// returns false once no further interim responses will be sent.
+func (kw *keepaliveWriter) keepalive() bool {
+	kw.mu.Lock()
+	defer kw.mu.Unlock()
+	if kw.done || kw.sent >= maxKeepalives {
+		return false
+	}
+	kw.w.WriteHeader(http.StatusProcessing)
+	kw.sent++
+	return kw.sent < maxKeepalives
+}
+
+// finish marks the final response as started, even if the handler wrote
+// nothing, so that no further interim responses are sent.
+func (kw *keepaliveWriter) finish() {
+	kw.mu.Lock()
+	defer kw.mu.Unlock()
+	if !kw.done {
+		kw.writeFinalHeaderLocked(http.StatusOK)
+	}
+}
+
+// withKeepalives calls h with a keepaliveWriter wrapping response, sending an
+// interim response every interval until h returns. Interim responses are only
+// sent for HTTP/2 requests, whose clients are required to accept them (RFC
+// 9113, Section 8.1); many HTTP/1.1 clients mistake them for the final
+// response.
+func withKeepalives(interval time.Duration, response http.ResponseWriter, request *http.Request, h func(http.ResponseWriter)) {
+	if interval <= 0 || request.ProtoMajor < 2 {
+		h(response)
+		return
+	}
+
+	kw := newKeepaliveWriter(response)
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if !kw.keepalive() {
+					return
+				}
+			}
+		}
+	}()
+
+	h(kw)
+	close(stop)
+	<-stopped
+	kw.finish()
+}
@@ -0,0 +1,91 @@
+package wfe2
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"net/textproto"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestKeepaliveWriter(t *testing.T) {
+	rw := httptest.NewRecorder()
+	rw.Header().Set("Replay-Nonce", "abc")
+
+	kw := newKeepaliveWriter(rw)
+	// Headers set before wrapping are held back with the handler's headers.
+	test.AssertEquals(t, rw.Header().Get("Replay-Nonce"), "")
+	test.AssertEquals(t, kw.Header().Get("Replay-Nonce"), "abc")
+
+	kw.Header().Set("Content-Type", "application/json")
+
+	kw.WriteHeader(http.StatusCreated)
+	_, err := kw.Write([]byte("{}"))
+	test.AssertNotError(t, err, "writing body")
+	test.Assert(t, !kw.keepalive(), "expected no keepalives after the final response")
+
+	test.AssertEquals(t, rw.Code, http.StatusCreated)
+	test.AssertEquals(t, rw.Header().Get("Replay-Nonce"), "abc")
+	test.AssertEquals(t, rw.Header().Get("Content-Type"), "application/json")
+	test.AssertEquals(t, rw.Body.String(), "{}")
+}
+
+func TestWithKeepalives(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		withKeepalives(10*time.Millisecond, w, r, func(w http.ResponseWriter) {
+			w.Header().Set("Replay-Nonce", "abc")
+			time.Sleep(100 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("done"))
+		})
+	}))
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+
+	get := func(client *http.Client) (*http.Response, int64) {
+		var interim atomic.Int64
+		trace := &httptrace.ClientTrace{
+			Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+				test.AssertEquals(t, code, http.StatusProcessing)
+				test.AssertEquals(t, header.Get("Replay-Nonce"), "")
+				interim.Add(1)
+				return nil
+			},
+		}
+		req, err := http.NewRequestWithContext(httptrace.WithClientTrace(t.Context(), trace), "GET", srv.URL, nil)
+		test.AssertNotError(t, err, "creating request")
+		resp, err := client.Do(req)
+		test.AssertNotError(t, err, "making request")
+		return resp, interim.Load()
+	}
+
+	// HTTP/2 clients receive a bounded number of interim responses, followed
+	// by the complete final response.
+	resp, interim := get(srv.Client())
+	defer resp.Body.Close()
+	test.AssertEquals(t, resp.ProtoMajor, 2)
+	test.AssertEquals(t, interim, int64(maxKeepalives))
+	test.AssertEquals(t, resp.StatusCode, http.StatusOK)
+	test.AssertEquals(t, resp.Header.Get("Replay-Nonce"), "abc")
+	body, err := io.ReadAll(resp.Body)
+	test.AssertNotError(t, err, "reading body")
+	test.AssertEquals(t, string(body), "done")
+
+	// HTTP/1.1 clients receive no interim responses.
+	client := srv.Client()
+	transport := client.Transport.(*http.Transport).Clone()
+	transport.ForceAttemptHTTP2 = false
+	transport.TLSClientConfig.NextProtos = []string{"http/1.1"}
+	client.Transport = transport
+	resp, interim = get(client)
+	defer resp.Body.Close()
+	test.AssertEquals(t, resp.ProtoMajor, 1)
+	test.AssertEquals(t, interim, int64(0))
+	test.AssertEquals(t, resp.Header.Get("Replay-Nonce"), "abc")
+}
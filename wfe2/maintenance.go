@@ -0,0 +1,139 @@
+package wfe2
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/letsencrypt/boulder/config"
+	blog "github.com/letsencrypt/boulder/log"
+)
+
+// MaintenanceConfig configures which endpoints are temporarily out of service,
+// for example to stop new account creation during an incident while still
+// allowing existing accounts to renew.
+type MaintenanceConfig struct {
+	// Endpoints are the endpoints which are in maintenance at startup, keyed
+	// by path, e.g. "/acme/new-acct".
+	Endpoints map[string]EndpointMaintenance `validate:"omitempty,dive,keys,startswith=/,endkeys"`
+
+	// AdminSocket, if set, is the path of a Unix socket on which to serve an
+	// API for changing which endpoints are in maintenance at runtime. A GET
+	// of /maintenance returns the current endpoints, and a PUT replaces them
+	// with those in the request body, which has the same form as Endpoints.
+	AdminSocket string
+}
+
+// EndpointMaintenance describes how an endpoint in maintenance responds.
+type EndpointMaintenance struct {
+	// RetryAfter is sent as the Retry-After header. Defaults to five
+	// minutes.
+	RetryAfter config.Duration `validate:"-"`
+
+	// Detail is sent as the problem detail. Defaults to a generic
+	// explanation.
+	Detail string
+}
+
+const (
+	defaultMaintenanceRetryAfter = 5 * time.Minute
+	defaultMaintenanceDetail     = "This endpoint is temporarily unavailable for maintenance"
+)
+
+// Maintenance holds the endpoints which are in maintenance. It's safe for
+// concurrent use, so that they can be changed while requests are served.
+type Maintenance struct {
+	log blog.Logger
+
+	mu        sync.RWMutex
+	endpoints map[string]EndpointMaintenance
+}
+
+// NewMaintenance returns a Maintenance with the given endpoints in
+// maintenance.
+func NewMaintenance(endpoints map[string]EndpointMaintenance, logger blog.Logger) (*Maintenance, error) {
+	m := &Maintenance{log: logger}
+	err := m.Set(endpoints)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Set replaces the endpoints in maintenance. It returns an error, leaving them
+// unchanged, if any isn't the path of a WFE endpoint.
+func (m *Maintenance) Set(endpoints map[string]EndpointMaintenance) error {
+	updated := make(map[string]EndpointMaintenance, len(endpoints))
+	for pattern, e := range endpoints {
+		if !slices.Contains(endpointPaths, pattern) {
+			return fmt.Errorf("unrecognized endpoint %q in maintenance", pattern)
+		}
+		if e.RetryAfter.Duration < 0 {
+			return fmt.Errorf("retryAfter for endpoint %q must not be negative", pattern)
+		}
+		if e.RetryAfter.Duration == 0 {
+			e.RetryAfter.Duration = defaultMaintenanceRetryAfter
+		}
+		if e.Detail == "" {
+			e.Detail = defaultMaintenanceDetail
+		}
+		updated[pattern] = e
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.endpoints = updated
+	m.log.AuditInfof("Endpoints in maintenance: %v", slices.Sorted(maps.Keys(updated)))
+	return nil
+}
+
+// Endpoints returns a copy of the endpoints in maintenance.
+func (m *Maintenance) Endpoints() map[string]EndpointMaintenance {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return maps.Clone(m.endpoints)
+}
+
+// lookup returns how the endpoint with the given path pattern should respond,
+// and false if it isn't in maintenance.
+func (m *Maintenance) lookup(pattern string) (EndpointMaintenance, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	e, ok := m.endpoints[pattern]
+	return e, ok
+}
+
+// ServeHTTP serves the admin API described by MaintenanceConfig.AdminSocket.
+// It must only be exposed to operators.
+func (m *Maintenance) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/maintenance" {
+		http.NotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var endpoints map[string]EndpointMaintenance
+		err := json.NewDecoder(r.Body).Decode(&endpoints)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("decoding endpoints: %s", err), http.StatusBadRequest)
+			return
+		}
+		err = m.Set(endpoints)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(m.Endpoints())
+}
@@ -0,0 +1,128 @@
+package wfe2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/letsencrypt/boulder/config"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/probs"
+	"github.com/letsencrypt/boulder/test"
+	"github.com/letsencrypt/boulder/web"
+)
+
+func TestMaintenanceSet(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewMaintenance(map[string]EndpointMaintenance{"/acme/unknown": {}}, blog.NewMock())
+	test.AssertError(t, err, "unknown endpoint accepted")
+	_, err = NewMaintenance(map[string]EndpointMaintenance{newAcctPath: {RetryAfter: config.Duration{Duration: -time.Second}}}, blog.NewMock())
+	test.AssertError(t, err, "negative retryAfter accepted")
+
+	m, err := NewMaintenance(map[string]EndpointMaintenance{newAcctPath: {}}, blog.NewMock())
+	test.AssertNotError(t, err, "creating maintenance")
+	e, ok := m.lookup(newAcctPath)
+	test.Assert(t, ok, "new-acct not in maintenance")
+	test.AssertEquals(t, e.RetryAfter.Duration, defaultMaintenanceRetryAfter)
+	test.AssertEquals(t, e.Detail, defaultMaintenanceDetail)
+	_, ok = m.lookup(newOrderPath)
+	test.Assert(t, !ok, "new-order in maintenance")
+
+	// A failed update leaves the endpoints unchanged.
+	err = m.Set(map[string]EndpointMaintenance{newOrderPath: {}, "/acme/unknown": {}})
+	test.AssertError(t, err, "unknown endpoint accepted")
+	_, ok = m.lookup(newAcctPath)
+	test.Assert(t, ok, "failed update changed endpoints")
+
+	err = m.Set(nil)
+	test.AssertNotError(t, err, "ending maintenance")
+	test.AssertEquals(t, len(m.Endpoints()), 0)
+}
+
+func TestMaintenanceAdminAPI(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewMaintenance(nil, blog.NewMock())
+	test.AssertNotError(t, err, "creating maintenance")
+
+	do := func(method, path, body string) (*httptest.ResponseRecorder, map[string]EndpointMaintenance) {
+		rw := httptest.NewRecorder()
+		m.ServeHTTP(rw, httptest.NewRequest(method, path, strings.NewReader(body)))
+		var endpoints map[string]EndpointMaintenance
+		if rw.Code == http.StatusOK {
+			err := json.Unmarshal(rw.Body.Bytes(), &endpoints)
+			test.AssertNotError(t, err, "decoding response")
+		}
+		return rw, endpoints
+	}
+
+	rw, endpoints := do("GET", "/maintenance", "")
+	test.AssertEquals(t, rw.Code, http.StatusOK)
+	test.AssertEquals(t, len(endpoints), 0)
+
+	rw, endpoints = do("PUT", "/maintenance", `{"/acme/new-acct": {"retryAfter": "1h", "detail": "back soon"}}`)
+	test.AssertEquals(t, rw.Code, http.StatusOK)
+	test.AssertDeepEquals(t, endpoints, map[string]EndpointMaintenance{
+		newAcctPath: {RetryAfter: config.Duration{Duration: time.Hour}, Detail: "back soon"},
+	})
+	_, ok := m.lookup(newAcctPath)
+	test.Assert(t, ok, "PUT didn't put new-acct in maintenance")
+
+	rw, _ = do("PUT", "/maintenance", `{"/acme/unknown": {}}`)
+	test.AssertEquals(t, rw.Code, http.StatusBadRequest)
+	rw, _ = do("PUT", "/maintenance", `not json`)
+	test.AssertEquals(t, rw.Code, http.StatusBadRequest)
+	rw, _ = do("POST", "/maintenance", `{}`)
+	test.AssertEquals(t, rw.Code, http.StatusMethodNotAllowed)
+	rw, _ = do("GET", "/other", "")
+	test.AssertEquals(t, rw.Code, http.StatusNotFound)
+
+	rw, endpoints = do("PUT", "/maintenance", `{}`)
+	test.AssertEquals(t, rw.Code, http.StatusOK)
+	test.AssertEquals(t, len(endpoints), 0)
+}
+
+func TestHandleFuncMaintenance(t *testing.T) {
+	wfe, _, _ := setupWFE(t)
+	var err error
+	wfe.Maintenance, err = NewMaintenance(map[string]EndpointMaintenance{
+		newAcctPath: {RetryAfter: config.Duration{Duration: 90 * time.Second}, Detail: "Account creation is paused"},
+	}, blog.NewMock())
+	test.AssertNotError(t, err, "creating maintenance")
+
+	serve := func(pattern string) (*httptest.ResponseRecorder, bool) {
+		var called bool
+		mux := http.NewServeMux()
+		wfe.HandleFunc(mux, pattern, func(context.Context, *web.RequestEvent, http.ResponseWriter, *http.Request) {
+			called = true
+		}, "POST")
+		rw := httptest.NewRecorder()
+		mux.ServeHTTP(rw, &http.Request{Method: "POST", URL: mustParseURL(pattern)})
+		return rw, called
+	}
+
+	rw, called := serve(newAcctPath)
+	test.Assert(t, !called, "handler called for endpoint in maintenance")
+	test.AssertEquals(t, rw.Code, http.StatusServiceUnavailable)
+	test.AssertEquals(t, rw.Header().Get(headerRetryAfter), "90")
+	var prob probs.ProblemDetails
+	err = json.Unmarshal(rw.Body.Bytes(), &prob)
+	test.AssertNotError(t, err, "decoding problem")
+	test.AssertEquals(t, prob.Type, probs.ErrorNS+probs.ServiceUnavailableProblem)
+	test.AssertEquals(t, prob.Detail, "Account creation is paused")
+
+	// Other endpoints, such as new-order for renewals, are unaffected.
+	_, called = serve(newOrderPath)
+	test.Assert(t, called, "handler not called for endpoint outside maintenance")
+
+	// Ending maintenance takes effect immediately.
+	err = wfe.Maintenance.Set(nil)
+	test.AssertNotError(t, err, "ending maintenance")
+	_, called = serve(newAcctPath)
+	test.Assert(t, called, "handler not called after maintenance ended")
+}
@@ -0,0 +1,118 @@
+package wfe2
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/letsencrypt/boulder/config"
+	"github.com/letsencrypt/boulder/nonce"
+)
+
+// NoncePrefetchConfig configures the WFE to include a fresh Replay-Nonce in
+// every response, as RFC 8555 Section 6.5 permits, so that clients need fewer
+// newNonce round trips. To keep this from adding a nonce service round trip to
+// every GET, nonces are fetched ahead of time into a pool.
+type NoncePrefetchConfig struct {
+	// PoolSize is the number of nonces to keep fetched ahead of need.
+	PoolSize int `validate:"required,min=1"`
+
+	// MaxAge is how long a prefetched nonce may wait in the pool before it's
+	// discarded instead of being served. It must be well within the time it
+	// takes the nonce service to issue and redeem its maxUsed nonces, after
+	// which older nonces are rejected.
+	MaxAge config.Duration `validate:"required"`
+
+	// IndexLink, if true, also adds a Link header with rel="index", pointing
+	// to the directory, to responses which aren't from an ACME endpoint, such
+	// as the 404s for unknown paths.
+	IndexLink bool
+}
+
+// prefetchedNonce is a nonce waiting in a noncePool.
+type prefetchedNonce struct {
+	nonce   string
+	fetched time.Time
+}
+
+// noncePool holds nonces fetched from the nonce service ahead of need.
+type noncePool struct {
+	gnc    nonce.Getter
+	nonces chan prefetchedNonce
+	maxAge time.Duration
+	clk    clock.Clock
+}
+
+func newNoncePool(gnc nonce.Getter, c NoncePrefetchConfig, clk clock.Clock) (*noncePool, error) {
+	if c.PoolSize < 1 {
+		return nil, errors.New("nonce pool size must be at least 1")
+	}
+	if c.MaxAge.Duration <= 0 {
+		return nil, errors.New("nonce max age must be positive")
+	}
+	return &noncePool{
+		gnc:    gnc,
+		nonces: make(chan prefetchedNonce, c.PoolSize),
+		maxAge: c.MaxAge.Duration,
+		clk:    clk,
+	}, nil
+}
+
+// fill keeps the pool full until ctx is canceled. Errors from the nonce
+// service are retried after a short delay; in the meantime take falls back to
+// fetching nonces itself.
+func (p *noncePool) fill(ctx context.Context) {
+	for {
+		msg, err := p.gnc.Nonce(ctx, &emptypb.Empty{})
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(100 * time.Millisecond):
+			}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case p.nonces <- prefetchedNonce{nonce: msg.Nonce, fetched: p.clk.Now()}:
+		}
+	}
+}
+
+// take returns a nonce from the pool, discarding any which are older than
+// maxAge, or fetches one from the nonce service if none are ready.
+func (p *noncePool) take(ctx context.Context) (string, error) {
+	for {
+		select {
+		case n := <-p.nonces:
+			if p.clk.Since(n.fetched) > p.maxAge {
+				continue
+			}
+			return n.nonce, nil
+		default:
+			msg, err := p.gnc.Nonce(ctx, &emptypb.Empty{})
+			if err != nil {
+				return "", err
+			}
+			return msg.Nonce, nil
+		}
+	}
+}
+
+// EnableNoncePrefetch configures the WFE to include a Replay-Nonce, taken from
+// a pool of prefetched nonces, in every response, and starts filling the pool
+// until ctx is canceled.
+func (wfe *WebFrontEndImpl) EnableNoncePrefetch(ctx context.Context, c NoncePrefetchConfig) error {
+	pool, err := newNoncePool(wfe.gnc, c, wfe.clk)
+	if err != nil {
+		return err
+	}
+	wfe.noncePool = pool
+	wfe.indexLinkEverywhere = c.IndexLink
+	go pool.fill(ctx)
+	return nil
+}
@@ -0,0 +1,122 @@
+package wfe2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/letsencrypt/boulder/config"
+	noncepb "github.com/letsencrypt/boulder/nonce/proto"
+	"github.com/letsencrypt/boulder/probs"
+	"github.com/letsencrypt/boulder/test"
+	"github.com/letsencrypt/boulder/web"
+)
+
+// countingNonceGetter hands out nonces "1", "2", and so on, or fails if err
+// is set.
+type countingNonceGetter struct {
+	count int
+	err   error
+}
+
+func (g *countingNonceGetter) Nonce(context.Context, *emptypb.Empty, ...grpc.CallOption) (*noncepb.NonceMessage, error) {
+	if g.err != nil {
+		return nil, g.err
+	}
+	g.count++
+	return &noncepb.NonceMessage{Nonce: fmt.Sprint(g.count)}, nil
+}
+
+func TestNoncePoolTake(t *testing.T) {
+	t.Parallel()
+
+	gnc := &countingNonceGetter{}
+	clk := clock.NewFake()
+	pool, err := newNoncePool(gnc, NoncePrefetchConfig{PoolSize: 2, MaxAge: config.Duration{Duration: time.Minute}}, clk)
+	test.AssertNotError(t, err, "newNoncePool failed")
+
+	// Nonces which have waited longer than MaxAge are discarded.
+	pool.nonces <- prefetchedNonce{nonce: "stale", fetched: clk.Now()}
+	clk.Add(2 * time.Minute)
+	pool.nonces <- prefetchedNonce{nonce: "fresh", fetched: clk.Now()}
+	nonce, err := pool.take(context.Background())
+	test.AssertNotError(t, err, "take failed")
+	test.AssertEquals(t, nonce, "fresh")
+
+	// An empty pool falls back to the nonce service.
+	nonce, err = pool.take(context.Background())
+	test.AssertNotError(t, err, "take failed")
+	test.AssertEquals(t, nonce, "1")
+
+	gnc.err = errors.New("nonce service unavailable")
+	_, err = pool.take(context.Background())
+	test.AssertError(t, err, "take should fail when the pool is empty and the nonce service is down")
+
+	_, err = newNoncePool(gnc, NoncePrefetchConfig{PoolSize: 0, MaxAge: config.Duration{Duration: time.Minute}}, clk)
+	test.AssertError(t, err, "zero pool size should be rejected")
+	_, err = newNoncePool(gnc, NoncePrefetchConfig{PoolSize: 1}, clk)
+	test.AssertError(t, err, "zero max age should be rejected")
+}
+
+func TestNoncePoolFill(t *testing.T) {
+	t.Parallel()
+
+	gnc := &countingNonceGetter{}
+	pool, err := newNoncePool(gnc, NoncePrefetchConfig{PoolSize: 3, MaxAge: config.Duration{Duration: time.Minute}}, clock.NewFake())
+	test.AssertNotError(t, err, "newNoncePool failed")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		pool.fill(ctx)
+		close(done)
+	}()
+	for i := 1; i <= 3; i++ {
+		n := <-pool.nonces
+		test.AssertEquals(t, n.nonce, fmt.Sprint(i))
+	}
+	cancel()
+	<-done
+}
+
+func TestNoncePrefetchEveryResponse(t *testing.T) {
+	wfe, _, _ := setupWFE(t)
+
+	// Without prefetching, GETs don't carry a nonce.
+	mux := http.NewServeMux()
+	wfe.HandleFunc(mux, "/test", func(context.Context, *web.RequestEvent, http.ResponseWriter, *http.Request) {}, "GET")
+	rw := httptest.NewRecorder()
+	mux.ServeHTTP(rw, &http.Request{Method: "GET", URL: mustParseURL("/test")})
+	test.AssertEquals(t, rw.Header().Get("Replay-Nonce"), "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := wfe.EnableNoncePrefetch(ctx, NoncePrefetchConfig{PoolSize: 5, MaxAge: config.Duration{Duration: time.Minute}, IndexLink: true})
+	test.AssertNotError(t, err, "EnableNoncePrefetch failed")
+
+	// With prefetching, GETs carry a nonce, including those which fail.
+	mux = http.NewServeMux()
+	wfe.HandleFunc(mux, "/test", func(_ context.Context, logEvent *web.RequestEvent, response http.ResponseWriter, _ *http.Request) {
+		wfe.sendError(response, logEvent, probs.ServerInternal("oops"), errors.New("oops"))
+	}, "GET")
+	rw = httptest.NewRecorder()
+	mux.ServeHTTP(rw, &http.Request{Method: "GET", URL: mustParseURL("/test")})
+	test.AssertEquals(t, rw.Code, http.StatusInternalServerError)
+	test.AssertNotEquals(t, rw.Header().Get("Replay-Nonce"), "")
+
+	// As do 404s, which also get an index link.
+	rw = httptest.NewRecorder()
+	wfe.Index(context.Background(), &web.RequestEvent{}, rw, &http.Request{Method: "GET", URL: &url.URL{Path: "/nope"}})
+	test.AssertEquals(t, rw.Code, http.StatusNotFound)
+	test.AssertNotEquals(t, rw.Header().Get("Replay-Nonce"), "")
+	test.AssertEquals(t, rw.Header().Get("Link"), `<http://localhost/directory>;rel="index"`)
+}
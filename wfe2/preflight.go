@@ -0,0 +1,157 @@
+package wfe2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	bgrpc "github.com/letsencrypt/boulder/grpc"
+	"github.com/letsencrypt/boulder/identifier"
+	"github.com/letsencrypt/boulder/policy"
+	"github.com/letsencrypt/boulder/probs"
+	rapb "github.com/letsencrypt/boulder/ra/proto"
+	"github.com/letsencrypt/boulder/web"
+)
+
+// preflightJSON is the response to a preflight-order request.
+type preflightJSON struct {
+	// Acceptable is true if the order would be accepted and CAA currently
+	// permits issuance for every identifier in it.
+	Acceptable bool `json:"acceptable"`
+
+	// Problems are the reasons the order as a whole would be rejected.
+	Problems []*probs.ProblemDetails `json:"problems,omitempty"`
+
+	Identifiers []preflightIdentifierJSON `json:"identifiers"`
+}
+
+// preflightIdentifierJSON is the result of the checks for one identifier.
+type preflightIdentifierJSON struct {
+	Identifier identifier.ACMEIdentifier `json:"identifier"`
+	Problem    *probs.ProblemDetails     `json:"problem,omitempty"`
+}
+
+// PreflightOrder is a Boulder-specific endpoint which reports whether a
+// new-order request with the same identifiers and profile would currently be
+// accepted, and whether CAA permits issuance for each identifier, so that
+// integrators can check before requesting many certificates. It doesn't
+// create an order, count against any rate limits, or perform validation. The
+// request body may also name a challengeType for which to check CAA.
+func (wfe *WebFrontEndImpl) PreflightOrder(
+	ctx context.Context,
+	logEvent *web.RequestEvent,
+	response http.ResponseWriter,
+	request *http.Request) {
+	body, _, acct, err := wfe.validPOSTForAccount(request, ctx, logEvent)
+	addRequesterHeader(response, logEvent.Requester)
+	if err != nil {
+		// validPOSTForAccount handles its own setting of logEvent.Errors
+		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Unable to validate JWS"), err)
+		return
+	}
+
+	var preflightRequest struct {
+		Identifiers   identifier.ACMEIdentifiers `json:"identifiers"`
+		Profile       string
+		ChallengeType string
+	}
+	err = json.Unmarshal(body, &preflightRequest)
+	if err != nil {
+		wfe.sendError(response, logEvent,
+			probs.Malformed("Unable to unmarshal preflight request body"), err)
+		return
+	}
+
+	if len(preflightRequest.Identifiers) == 0 {
+		wfe.sendError(response, logEvent,
+			probs.Malformed("Preflight request did not specify any identifiers"), nil)
+		return
+	}
+	for _, ident := range preflightRequest.Identifiers {
+		if !ident.Type.IsValid() {
+			wfe.sendError(response, logEvent,
+				probs.UnsupportedIdentifier("Preflight request included unsupported identifier: type %q, value %q",
+					ident.Type, ident.Value),
+				nil)
+			return
+		}
+		if ident.Value == "" {
+			wfe.sendError(response, logEvent, probs.Malformed("Preflight request included empty identifier"), nil)
+			return
+		}
+	}
+	idents := identifier.Normalize(preflightRequest.Identifiers)
+	logEvent.Identifiers = idents
+
+	err = policy.WellFormedIdentifiers(idents)
+	if err != nil {
+		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Invalid identifiers requested"), nil)
+		return
+	}
+
+	err = wfe.validateCertificateProfileName(preflightRequest.Profile)
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.InvalidProfile(err.Error()), err)
+		return
+	}
+
+	isRenewal, err := wfe.isRenewal(ctx, idents)
+	if err != nil {
+		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "While checking renewal exemption status"), err)
+		return
+	}
+
+	resp, err := wfe.ra.PreflightOrder(ctx, &rapb.PreflightOrderRequest{
+		RegistrationID:         acct.ID,
+		Identifiers:            idents.ToProtoSlice(),
+		CertificateProfileName: preflightRequest.Profile,
+		IsRenewal:              isRenewal,
+		ChallengeType:          preflightRequest.ChallengeType,
+	})
+	if err != nil {
+		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Error running preflight checks"), err)
+		return
+	}
+
+	// toProblem converts a problem from the RA, prefixing its type with the
+	// ACME error namespace as sendError does.
+	toProblem := func(pb *corepb.ProblemDetails) (*probs.ProblemDetails, error) {
+		prob, err := bgrpc.PBToProblemDetails(pb)
+		if err != nil || prob == nil {
+			return nil, err
+		}
+		prob.Type = probs.ErrorNS + prob.Type
+		return prob, nil
+	}
+
+	result := preflightJSON{Acceptable: len(resp.Problems) == 0}
+	for _, pb := range resp.Problems {
+		prob, err := toProblem(pb)
+		if err != nil {
+			wfe.sendError(response, logEvent, probs.ServerInternal("Error converting preflight result"), err)
+			return
+		}
+		result.Problems = append(result.Problems, prob)
+	}
+	for _, pb := range resp.Identifiers {
+		prob, err := toProblem(pb.Problem)
+		if err != nil {
+			wfe.sendError(response, logEvent, probs.ServerInternal("Error converting preflight result"), err)
+			return
+		}
+		if prob != nil {
+			result.Acceptable = false
+		}
+		result.Identifiers = append(result.Identifiers, preflightIdentifierJSON{
+			Identifier: identifier.FromProto(pb.Identifier),
+			Problem:    prob,
+		})
+	}
+
+	err = wfe.writeJsonResponse(response, logEvent, http.StatusOK, result)
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.ServerInternal("Error marshaling preflight result"), err)
+		return
+	}
+}
@@ -0,0 +1,118 @@
+package wfe2
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	"github.com/letsencrypt/boulder/probs"
+	rapb "github.com/letsencrypt/boulder/ra/proto"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// mockPreflightRA reports a CAA problem for "caa-forbidden.com" and, if
+// rateLimited is set, a rate limit problem with the order.
+type mockPreflightRA struct {
+	MockRegistrationAuthority
+	rateLimited bool
+	lastReq     *rapb.PreflightOrderRequest
+}
+
+func (ra *mockPreflightRA) PreflightOrder(_ context.Context, in *rapb.PreflightOrderRequest, _ ...grpc.CallOption) (*rapb.PreflightOrderResponse, error) {
+	ra.lastReq = in
+	resp := &rapb.PreflightOrderResponse{}
+	if ra.rateLimited {
+		resp.Problems = append(resp.Problems, &corepb.ProblemDetails{
+			ProblemType: string(probs.RateLimitedProblem),
+			Detail:      "Order would be rate limited :: too many certificates",
+			HttpStatus:  429,
+		})
+	}
+	for _, ident := range in.Identifiers {
+		result := &rapb.PreflightIdentifier{Identifier: ident}
+		if ident.Value == "caa-forbidden.com" {
+			result.Problem = &corepb.ProblemDetails{
+				ProblemType: string(probs.CAAProblem),
+				Detail:      "CAA record for caa-forbidden.com prevents issuance",
+				HttpStatus:  403,
+			}
+		}
+		resp.Identifiers = append(resp.Identifiers, result)
+	}
+	return resp, nil
+}
+
+func TestPreflightOrder(t *testing.T) {
+	wfe, fc, signer := setupWFE(t)
+	ra := &mockPreflightRA{MockRegistrationAuthority: MockRegistrationAuthority{clk: fc}}
+	wfe.ra = ra
+
+	targetPath := "debug/preflight-order"
+	signedURL := "http://localhost/" + targetPath
+
+	testCases := []struct {
+		Name         string
+		Payload      string
+		RateLimited  bool
+		ExpectedBody string
+	}{
+		{
+			Name:         "no identifiers",
+			Payload:      `{}`,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Preflight request did not specify any identifiers","status":400, "code": "malformed"}`,
+		},
+		{
+			Name:         "unsupported identifier type",
+			Payload:      `{"identifiers":[{"type":"fakeID","value":"example.com"}]}`,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `unsupportedIdentifier","detail":"Preflight request included unsupported identifier: type \"fakeID\", value \"example.com\"","status":400, "code": "unsupportedIdentifier"}`,
+		},
+		{
+			Name:         "malformed identifier",
+			Payload:      `{"identifiers":[{"type":"dns","value":"example.invalid"}]}`,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `rejectedIdentifier","detail":"Invalid identifiers requested :: Cannot issue for \"example.invalid\": Domain name does not end with a valid public suffix (TLD)","status":400, "code": "rejectedIdentifier"}`,
+		},
+		{
+			Name:    "acceptable",
+			Payload: `{"identifiers":[{"type":"dns","value":"Example.com"}],"challengeType":"dns-01"}`,
+			ExpectedBody: `{
+				"acceptable": true,
+				"identifiers": [{"identifier": {"type": "dns", "value": "example.com"}}]
+			}`,
+		},
+		{
+			Name:    "CAA problem",
+			Payload: `{"identifiers":[{"type":"dns","value":"example.com"},{"type":"dns","value":"caa-forbidden.com"}]}`,
+			ExpectedBody: `{
+				"acceptable": false,
+				"identifiers": [
+					{"identifier": {"type": "dns", "value": "caa-forbidden.com"}, "problem": {"type":"` + probs.ErrorNS + `caa","detail":"CAA record for caa-forbidden.com prevents issuance","status":403}},
+					{"identifier": {"type": "dns", "value": "example.com"}}
+				]
+			}`,
+		},
+		{
+			Name:        "rate limited",
+			Payload:     `{"identifiers":[{"type":"dns","value":"example.com"}]}`,
+			RateLimited: true,
+			ExpectedBody: `{
+				"acceptable": false,
+				"problems": [{"type":"` + probs.ErrorNS + `rateLimited","detail":"Order would be rate limited :: too many certificates","status":429}],
+				"identifiers": [{"identifier": {"type": "dns", "value": "example.com"}}]
+			}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			ra.rateLimited = tc.RateLimited
+			responseWriter := httptest.NewRecorder()
+			wfe.PreflightOrder(ctx, newRequestEvent(), responseWriter, signAndPost(signer, targetPath, signedURL, tc.Payload))
+			test.AssertUnmarshaledEquals(t, responseWriter.Body.String(), tc.ExpectedBody)
+		})
+	}
+
+	test.AssertEquals(t, ra.lastReq.RegistrationID, int64(1))
+}
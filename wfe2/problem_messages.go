@@ -0,0 +1,159 @@
+package wfe2
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/letsencrypt/boulder/probs"
+)
+
+// ProblemMessagesConfig configures translations of problem documents.
+type ProblemMessagesConfig struct {
+	// CatalogFile is the path to a JSON file mapping language tags, such as
+	// "fr" or "pt-BR", to objects mapping problem codes, such as "malformed",
+	// to the message sent in that language for problems with that code. Codes
+	// without a message in the language the client prefers are sent in
+	// English.
+	CatalogFile string `validate:"required"`
+}
+
+// ProblemMessages translates problem documents into the language a client
+// asks for with its Accept-Language header. English, in which every detail is
+// written, needs no catalog entry and is used whenever a client doesn't ask
+// for a language which has a message for the problem's code.
+type ProblemMessages struct {
+	// messages maps lowercased language tags to messages by problem code.
+	messages map[string]map[probs.Code]string
+}
+
+// NewProblemMessages returns the ProblemMessages described by c.
+func NewProblemMessages(c ProblemMessagesConfig) (*ProblemMessages, error) {
+	contents, err := os.ReadFile(c.CatalogFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading problem message catalog: %w", err)
+	}
+	var catalog map[string]map[probs.Code]string
+	err = json.Unmarshal(contents, &catalog)
+	if err != nil {
+		return nil, fmt.Errorf("parsing problem message catalog: %w", err)
+	}
+
+	messages := make(map[string]map[probs.Code]string, len(catalog))
+	for tag, byCode := range catalog {
+		if tag == "" || tag == "*" {
+			return nil, fmt.Errorf("problem message catalog has invalid language tag %q", tag)
+		}
+		for code, message := range byCode {
+			_, ok := probs.Lookup(code)
+			if !ok {
+				return nil, fmt.Errorf("problem message catalog has message for unknown code %q in %q", code, tag)
+			}
+			if message == "" {
+				return nil, fmt.Errorf("problem message catalog has empty message for code %q in %q", code, tag)
+			}
+		}
+		messages[strings.ToLower(tag)] = byCode
+	}
+	return &ProblemMessages{messages: messages}, nil
+}
+
+// acceptedLanguages returns the language ranges of an Accept-Language header
+// value, lowercased, in order of preference. Ranges with a weight of zero are
+// omitted.
+func acceptedLanguages(acceptLanguage string) []string {
+	type weighted struct {
+		tag    string
+		weight float64
+	}
+	var ranges []weighted
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		weight := 1.0
+		q, ok := strings.CutPrefix(strings.TrimSpace(params), "q=")
+		if ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+		if weight <= 0 {
+			continue
+		}
+		ranges = append(ranges, weighted{tag, weight})
+	}
+	slices.SortStableFunc(ranges, func(a, b weighted) int {
+		switch {
+		case a.weight > b.weight:
+			return -1
+		case a.weight < b.weight:
+			return 1
+		}
+		return 0
+	})
+
+	var tags []string
+	for _, r := range ranges {
+		tags = append(tags, r.tag)
+	}
+	return tags
+}
+
+// message returns the message for code in the language the client most
+// prefers among those with one, and that language's tag. It returns false if
+// the client prefers English, or no language it accepts has a message.
+func (pm *ProblemMessages) message(code probs.Code, acceptLanguage string) (string, string, bool) {
+	for _, tag := range acceptedLanguages(acceptLanguage) {
+		if tag == "*" || tag == "en" || strings.HasPrefix(tag, "en-") {
+			return "", "", false
+		}
+		// A range such as "fr-ca" is satisfied by a message in "fr-ca" or,
+		// failing that, in "fr".
+		for candidate := tag; candidate != ""; {
+			message, ok := pm.messages[candidate][code]
+			if ok {
+				return message, candidate, true
+			}
+			i := strings.LastIndex(candidate, "-")
+			if i < 0 {
+				break
+			}
+			candidate = candidate[:i]
+		}
+	}
+	return "", "", false
+}
+
+// translate replaces the detail of prob, and of each of its subproblems, with
+// the message for its code in the language the client most prefers, followed
+// by the original English detail, which often names the specific identifier
+// or field at fault. It returns the tags of the languages used.
+func (pm *ProblemMessages) translate(prob *probs.ProblemDetails, acceptLanguage string) []string {
+	var used []string
+	translateOne := func(pd *probs.ProblemDetails) {
+		message, tag, ok := pm.message(pd.Code, acceptLanguage)
+		if !ok {
+			return
+		}
+		if pd.Detail != "" {
+			message = fmt.Sprintf("%s :: %s", message, pd.Detail)
+		}
+		pd.Detail = message
+		if !slices.Contains(used, tag) {
+			used = append(used, tag)
+		}
+	}
+	translateOne(prob)
+	for i := range prob.SubProblems {
+		translateOne(&prob.SubProblems[i].ProblemDetails)
+	}
+	return used
+}
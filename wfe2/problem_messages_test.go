@@ -0,0 +1,120 @@
+package wfe2
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/letsencrypt/boulder/identifier"
+	"github.com/letsencrypt/boulder/probs"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// writeProblemMessages writes catalog to a file and returns the
+// ProblemMessagesConfig naming it.
+func writeProblemMessages(t *testing.T, catalog string) ProblemMessagesConfig {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "problem-messages.json")
+	err := os.WriteFile(path, []byte(catalog), 0600)
+	test.AssertNotError(t, err, "writing problem message catalog")
+	return ProblemMessagesConfig{CatalogFile: path}
+}
+
+func TestNewProblemMessages(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewProblemMessages(ProblemMessagesConfig{CatalogFile: filepath.Join(t.TempDir(), "missing.json")})
+	test.AssertError(t, err, "missing catalog accepted")
+
+	for _, catalog := range []string{
+		`{"fr": ["malformed"]}`,
+		`{"fr": {"bogus": "Faux"}}`,
+		`{"fr": {"malformed": ""}}`,
+		`{"*": {"malformed": "Mal formée"}}`,
+	} {
+		_, err = NewProblemMessages(writeProblemMessages(t, catalog))
+		test.AssertError(t, err, catalog+" accepted")
+	}
+
+	pm, err := NewProblemMessages(writeProblemMessages(t, `{"pt-BR": {"malformed": "Malformada"}}`))
+	test.AssertNotError(t, err, "valid catalog rejected")
+	test.AssertEquals(t, pm.messages["pt-br"][probs.CodeMalformed], "Malformada")
+}
+
+func TestAcceptedLanguages(t *testing.T) {
+	t.Parallel()
+
+	test.AssertDeepEquals(t, acceptedLanguages("fr-CH, fr;q=0.9, en;q=0.8, de;q=0.7, *;q=0.5"), []string{"fr-ch", "fr", "en", "de", "*"})
+	test.AssertDeepEquals(t, acceptedLanguages("en;q=0.5, es"), []string{"es", "en"})
+	test.AssertDeepEquals(t, acceptedLanguages("fr;q=0, es;q=bogus, de"), []string{"de"})
+	test.AssertEquals(t, len(acceptedLanguages("")), 0)
+}
+
+func TestProblemMessagesTranslate(t *testing.T) {
+	t.Parallel()
+
+	pm, err := NewProblemMessages(writeProblemMessages(t, `{
+		"fr": {"malformed": "La requête est mal formée", "rejectedIdentifier": "Identifiant refusé"},
+		"fr-CA": {"malformed": "La demande est mal formée"},
+		"es": {"rateLimited": "Límite de frecuencia superado"}
+	}`))
+	test.AssertNotError(t, err, "creating ProblemMessages")
+
+	testCases := []struct {
+		acceptLanguage string
+		wantDetail     string
+		wantLanguages  []string
+	}{
+		{"", "oops", nil},
+		{"en-US, fr", "oops", nil},
+		{"*", "oops", nil},
+		{"de", "oops", nil},
+		{"fr", "La requête est mal formée :: oops", []string{"fr"}},
+		{"fr-BE", "La requête est mal formée :: oops", []string{"fr"}},
+		{"fr-CA", "La demande est mal formée :: oops", []string{"fr-ca"}},
+		// Spanish is preferred, but has no message for malformed problems.
+		{"es, fr;q=0.8", "La requête est mal formée :: oops", []string{"fr"}},
+		{"de, en;q=0.9, fr;q=0.8", "oops", nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.acceptLanguage, func(t *testing.T) {
+			prob := probs.Malformed("oops")
+			languages := pm.translate(prob, tc.acceptLanguage)
+			test.AssertEquals(t, prob.Detail, tc.wantDetail)
+			test.AssertDeepEquals(t, languages, tc.wantLanguages)
+		})
+	}
+
+	prob := probs.Malformed("")
+	prob.SubProblems = []probs.SubProblemDetails{{
+		Identifier:     identifier.NewDNS("example.com"),
+		ProblemDetails: *probs.RejectedIdentifier("example.com is forbidden"),
+	}}
+	pm.translate(prob, "fr")
+	test.AssertEquals(t, prob.Detail, "La requête est mal formée")
+	test.AssertEquals(t, prob.SubProblems[0].Detail, "Identifiant refusé :: example.com is forbidden")
+}
+
+func TestSendErrorProblemMessages(t *testing.T) {
+	wfe, _, _ := setupWFE(t)
+
+	logEvent := newRequestEvent()
+	logEvent.AcceptLanguage = "fr"
+	responseWriter := httptest.NewRecorder()
+	wfe.sendError(responseWriter, logEvent, probs.Malformed("oops"), nil)
+	test.AssertNotContains(t, responseWriter.Body.String(), "La requête")
+	test.AssertEquals(t, responseWriter.Header().Get("Content-Language"), "")
+
+	wfe.ProblemMessages, _ = NewProblemMessages(writeProblemMessages(t, `{"fr": {"malformed": "La requête est mal formée"}}`))
+	responseWriter = httptest.NewRecorder()
+	wfe.sendError(responseWriter, logEvent, probs.Malformed("oops"), nil)
+	var prob probs.ProblemDetails
+	err := json.Unmarshal(responseWriter.Body.Bytes(), &prob)
+	test.AssertNotError(t, err, "unmarshaling problem")
+	test.AssertEquals(t, prob.Detail, "La requête est mal formée :: oops")
+	test.AssertEquals(t, prob.Code, probs.CodeMalformed)
+	test.AssertEquals(t, responseWriter.Header().Get("Content-Language"), "fr")
+	test.AssertEquals(t, responseWriter.Header().Get("Vary"), "Accept-Language")
+}
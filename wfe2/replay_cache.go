@@ -0,0 +1,91 @@
+package wfe2
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/letsencrypt/boulder/config"
+	blog "github.com/letsencrypt/boulder/log"
+	bredis "github.com/letsencrypt/boulder/redis"
+)
+
+// ReplayCacheConfig configures a cache, in Redis, of the signatures of
+// recently redeemed JWS request bodies. It's defense in depth: a replayed JWS
+// should already have been rejected because its nonce was redeemed, but the
+// cache also catches replays which a faulty nonce service lets through, such
+// as one which lost its record of redeemed nonces when it restarted.
+type ReplayCacheConfig struct {
+	// Redis contains the configuration necessary to connect to Redis. All
+	// WFEs should share a Redis, so that replays to a different WFE are
+	// caught.
+	Redis bredis.Config
+
+	// Window is how long a redeemed signature is remembered. Nonces are
+	// usually redeemed within seconds of being issued, so a window of a
+	// minute or so catches nearly all replays while keeping Redis small.
+	Window config.Duration `validate:"required"`
+}
+
+// replayStore is the subset of the Redis client used by ReplayCache.
+type replayStore interface {
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd
+}
+
+// ReplayCache remembers the signatures of recently redeemed JWS request
+// bodies, so that a byte-identical JWS can't be redeemed twice within the
+// window.
+type ReplayCache struct {
+	store  replayStore
+	window time.Duration
+	log    blog.Logger
+	checks *prometheus.CounterVec
+}
+
+// NewReplayCache returns a ReplayCache which stores signatures in client for
+// the given window.
+func NewReplayCache(client *redis.Ring, window time.Duration, stats prometheus.Registerer, logger blog.Logger) *ReplayCache {
+	return newReplayCache(client, window, stats, logger)
+}
+
+func newReplayCache(store replayStore, window time.Duration, stats prometheus.Registerer, logger blog.Logger) *ReplayCache {
+	checks := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "jws_replay_checks",
+		Help: "A counter of JWS signatures checked against the replay cache, labelled by result=[fresh|replayed|error]",
+	}, []string{"result"})
+	stats.MustRegister(checks)
+
+	return &ReplayCache{
+		store:  store,
+		window: window,
+		log:    logger,
+		checks: checks,
+	}
+}
+
+// Redeem records signature as redeemed and returns true, or returns false if
+// it was already redeemed within the window. If Redis can't be reached, the
+// signature is treated as fresh, so that the cache never takes the WFE down
+// with it; the nonce service remains the primary defense against replay.
+func (rc *ReplayCache) Redeem(ctx context.Context, signature []byte) bool {
+	hash := sha256.Sum256(signature)
+	key := "jws-replay:" + hex.EncodeToString(hash[:])
+
+	fresh, err := rc.store.SetNX(ctx, key, 1, rc.window).Result()
+	if err != nil {
+		rc.checks.WithLabelValues("error").Inc()
+		rc.log.Warningf("checking replay cache for JWS signature %x: %s", hash, err)
+		return true
+	}
+	if !fresh {
+		rc.checks.WithLabelValues("replayed").Inc()
+		rc.log.Warningf("JWS signature %x was replayed within %s despite a valid nonce", hash, rc.window)
+		return false
+	}
+	rc.checks.WithLabelValues("fresh").Inc()
+	return true
+}
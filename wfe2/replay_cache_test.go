@@ -0,0 +1,68 @@
+package wfe2
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+
+	berrors "github.com/letsencrypt/boulder/errors"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// fakeReplayStore is an in-memory replayStore which never expires keys.
+type fakeReplayStore struct {
+	keys map[string]bool
+	err  error
+}
+
+func (s *fakeReplayStore) SetNX(_ context.Context, key string, _ interface{}, _ time.Duration) *redis.BoolCmd {
+	if s.err != nil {
+		return redis.NewBoolResult(false, s.err)
+	}
+	if s.keys[key] {
+		return redis.NewBoolResult(false, nil)
+	}
+	s.keys[key] = true
+	return redis.NewBoolResult(true, nil)
+}
+
+func TestReplayCacheRedeem(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := &fakeReplayStore{keys: make(map[string]bool)}
+	rc := newReplayCache(store, time.Minute, metrics.NoopRegisterer, blog.NewMock())
+
+	test.Assert(t, rc.Redeem(ctx, []byte("signature")), "first redemption should be fresh")
+	test.Assert(t, !rc.Redeem(ctx, []byte("signature")), "second redemption should be a replay")
+	test.Assert(t, rc.Redeem(ctx, []byte("other signature")), "a different signature should be fresh")
+	test.AssertMetricWithLabelsEquals(t, rc.checks, prometheus.Labels{"result": "fresh"}, 2)
+	test.AssertMetricWithLabelsEquals(t, rc.checks, prometheus.Labels{"result": "replayed"}, 1)
+
+	// Redis errors mustn't block requests.
+	store.err = errors.New("connection refused")
+	test.Assert(t, rc.Redeem(ctx, []byte("signature")), "redemption should succeed when Redis fails")
+	test.AssertMetricWithLabelsEquals(t, rc.checks, prometheus.Labels{"result": "error"}, 1)
+}
+
+func TestValidJWSForKeyReplayed(t *testing.T) {
+	wfe, _, signer := setupWFE(t)
+	// A nonce service which accepts every nonce, even those already redeemed.
+	wfe.rnc = &recordingNonceRedeemer{}
+	wfe.ReplayCache = newReplayCache(&fakeReplayStore{keys: make(map[string]bool)}, time.Minute, metrics.NoopRegisterer, blog.NewMock())
+
+	jws, jwk, _ := signer.embeddedJWK(nil, "http://localhost/test", `{"test": "payload"}`)
+
+	_, err := wfe.validJWSForKey(context.Background(), &bJSONWebSignature{jws}, jwk, makePostRequestWithPath("test", ""), newRequestEvent())
+	test.AssertNotError(t, err, "first redemption of JWS failed")
+
+	_, err = wfe.validJWSForKey(context.Background(), &bJSONWebSignature{jws}, jwk, makePostRequestWithPath("test", ""), newRequestEvent())
+	test.AssertErrorIs(t, err, berrors.BadNonce)
+	test.AssertContains(t, err.Error(), "JWS has already been redeemed")
+	test.AssertMetricWithLabelsEquals(t, wfe.stats.joseErrorCount, prometheus.Labels{"type": "JWSReplayed"}, 1)
+}
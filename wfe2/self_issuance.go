@@ -0,0 +1,420 @@
+package wfe2
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/eggsampler/acme/v3"
+	"github.com/jmhodges/clock"
+
+	"github.com/letsencrypt/boulder/config"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/privatekey"
+)
+
+// acmeTLS1Protocol is the ALPN protocol with which the VA requests tls-alpn-01
+// challenge certificates. See RFC 8737 Section 6.2.
+const acmeTLS1Protocol = "acme-tls/1"
+
+// idPeAcmeIdentifier is the OID of the extension in which tls-alpn-01
+// challenge certificates carry the key authorization. See RFC 8737 Section
+// 6.1.
+var idPeAcmeIdentifier = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 31}
+
+// SelfIssuanceConfig configures the WFE to obtain and renew the certificate
+// for its own TLS listener from the Boulder instance it fronts, answering
+// tls-alpn-01 challenges on that listener, so that private deployments don't
+// need a certificate from elsewhere for the API host. The VA must reach the
+// TLS listener at each of Hostnames on the port it uses for tls-alpn-01.
+type SelfIssuanceConfig struct {
+	// DirectoryURL is the URL of the directory on the WFE's plaintext
+	// listener, e.g. "http://localhost:4001/directory". It must not use the
+	// TLS listener, so that obtaining its certificate never depends on it
+	// already having one.
+	DirectoryURL string `validate:"required,url,startswith=http://"`
+
+	// Hostnames are the names to include in the certificate.
+	Hostnames []string `validate:"required,min=1,dive,hostname"`
+
+	// Profile is the certificate profile to request. It must be one of the
+	// WFE's CertProfiles. It should be a bootstrap profile whose RA
+	// AllowList admits only the WFE's own account, so that changes to the
+	// profiles offered to subscribers can't take down the API's TLS.
+	Profile string `validate:"required,alphanum,min=1,max=32"`
+
+	// AccountKeyPath is a PEM file holding the key of the ACME account with
+	// which certificates are requested. If it doesn't exist, a new key is
+	// generated and written there.
+	AccountKeyPath string `validate:"required"`
+
+	// RenewBefore is how long before the certificate expires to renew it.
+	// Defaults to a third of the certificate's validity period.
+	RenewBefore config.Duration `validate:"-"`
+
+	// CheckInterval is how often to check whether the certificate needs
+	// renewing, and how long to wait before retrying a failed renewal.
+	// Defaults to one hour.
+	CheckInterval config.Duration `validate:"-"`
+}
+
+// SelfIssuer obtains and renews the WFE's TLS certificate via ACME and serves
+// it, along with tls-alpn-01 challenge certificates, from GetCertificate. The
+// certificate and its key are written to disk, so that they're reused across
+// restarts, and a failed renewal leaves the current certificate in service.
+type SelfIssuer struct {
+	directoryURL   string
+	hostnames      []string
+	profile        string
+	accountKeyPath string
+	renewBefore    time.Duration
+	checkInterval  time.Duration
+	certPath       string
+	keyPath        string
+	clk            clock.Clock
+	log            blog.Logger
+
+	sync.RWMutex
+	cert *tls.Certificate
+	// challengeCerts are the tls-alpn-01 challenge certificates currently
+	// being served, by hostname.
+	challengeCerts map[string]*tls.Certificate
+}
+
+// NewSelfIssuer returns a SelfIssuer which keeps the certificate and key at
+// certPath and keyPath. If a certificate is already there, it's served until
+// it needs renewing.
+func NewSelfIssuer(c SelfIssuanceConfig, certPath, keyPath string, clk clock.Clock, logger blog.Logger) (*SelfIssuer, error) {
+	if c.DirectoryURL == "" {
+		return nil, errors.New("self-issuance directory URL must be set")
+	}
+	if len(c.Hostnames) == 0 {
+		return nil, errors.New("self-issuance requires at least one hostname")
+	}
+	if c.Profile == "" {
+		return nil, errors.New("self-issuance profile must be set")
+	}
+	if c.AccountKeyPath == "" || certPath == "" || keyPath == "" {
+		return nil, errors.New("self-issuance requires account key, certificate, and key paths")
+	}
+	if c.RenewBefore.Duration < 0 {
+		return nil, errors.New("self-issuance renewBefore must not be negative")
+	}
+	checkInterval := c.CheckInterval.Duration
+	if checkInterval <= 0 {
+		checkInterval = time.Hour
+	}
+
+	s := &SelfIssuer{
+		directoryURL:   c.DirectoryURL,
+		hostnames:      c.Hostnames,
+		profile:        c.Profile,
+		accountKeyPath: c.AccountKeyPath,
+		renewBefore:    c.RenewBefore.Duration,
+		checkInterval:  checkInterval,
+		certPath:       certPath,
+		keyPath:        keyPath,
+		clk:            clk,
+		log:            logger,
+		challengeCerts: make(map[string]*tls.Certificate),
+	}
+
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err == nil {
+		s.cert = &cert
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		// A damaged certificate is replaced rather than being fatal, since
+		// replacing it is what the SelfIssuer is for.
+		logger.Warningf("Ignoring unusable self-issued certificate %q: %s", certPath, err)
+	}
+	return s, nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate. It returns the
+// challenge certificate for the requested name to clients which negotiate
+// acme-tls/1, and the current certificate to everyone else.
+func (s *SelfIssuer) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if slices.Contains(hello.SupportedProtos, acmeTLS1Protocol) {
+		cert, ok := s.challengeCerts[hello.ServerName]
+		if !ok {
+			return nil, fmt.Errorf("no tls-alpn-01 challenge pending for %q", hello.ServerName)
+		}
+		return cert, nil
+	}
+	if s.cert == nil {
+		return nil, errors.New("no certificate has been issued yet")
+	}
+	return s.cert, nil
+}
+
+// ConfigureTLS makes c serve certificates from s, and negotiate acme-tls/1
+// with the VA. Any certificates already in c are ignored.
+func (s *SelfIssuer) ConfigureTLS(c *tls.Config) {
+	c.GetCertificate = s.GetCertificate
+	if !slices.Contains(c.NextProtos, acmeTLS1Protocol) {
+		c.NextProtos = append(c.NextProtos, acmeTLS1Protocol)
+	}
+}
+
+// needsRenewal returns true if there's no current certificate, or if it's due
+// for renewal.
+func (s *SelfIssuer) needsRenewal() bool {
+	s.RLock()
+	defer s.RUnlock()
+	if s.cert == nil || s.cert.Leaf == nil {
+		return true
+	}
+	leaf := s.cert.Leaf
+	renewBefore := s.renewBefore
+	if renewBefore == 0 {
+		renewBefore = leaf.NotAfter.Sub(leaf.NotBefore) / 3
+	}
+	return !s.clk.Now().Before(leaf.NotAfter.Add(-renewBefore))
+}
+
+// Run obtains a certificate whenever the current one needs renewing, until
+// ctx is canceled. Failures are logged and retried after the check interval.
+func (s *SelfIssuer) Run(ctx context.Context) {
+	for {
+		if s.needsRenewal() {
+			err := s.renew()
+			if err != nil {
+				s.log.Errf("Obtaining self-issued TLS certificate: %s", err)
+			} else {
+				s.log.Infof("Obtained self-issued TLS certificate for %v", s.hostnames)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.clk.After(s.checkInterval):
+		}
+	}
+}
+
+// renew obtains a new certificate via ACME, writes it to disk, and serves it.
+func (s *SelfIssuer) renew() error {
+	accountKey, err := s.loadAccountKey()
+	if err != nil {
+		return err
+	}
+
+	client, err := acme.NewClient(s.directoryURL)
+	if err != nil {
+		return fmt.Errorf("fetching directory: %w", err)
+	}
+	account, err := client.NewAccount(accountKey, false, true)
+	if err != nil {
+		return fmt.Errorf("registering account: %w", err)
+	}
+
+	var idents []acme.Identifier
+	for _, name := range s.hostnames {
+		idents = append(idents, acme.Identifier{Type: "dns", Value: name})
+	}
+	order, err := client.NewOrderExtension(account, idents, acme.OrderExtension{Profile: s.profile})
+	if err != nil {
+		return fmt.Errorf("creating order: %w", err)
+	}
+
+	for _, authzURL := range order.Authorizations {
+		err = s.validate(client, account, authzURL)
+		if err != nil {
+			return err
+		}
+	}
+
+	certKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return fmt.Errorf("generating certificate key: %w", err)
+	}
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{DNSNames: s.hostnames}, certKey)
+	if err != nil {
+		return fmt.Errorf("creating CSR: %w", err)
+	}
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	if err != nil {
+		return fmt.Errorf("parsing CSR: %w", err)
+	}
+	order, err = client.FinalizeOrder(account, order, csr)
+	if err != nil {
+		return fmt.Errorf("finalizing order: %w", err)
+	}
+	chain, err := client.FetchCertificates(account, order.Certificate)
+	if err != nil {
+		return fmt.Errorf("fetching certificate: %w", err)
+	}
+
+	var certPEM []byte
+	cert := &tls.Certificate{PrivateKey: certKey, Leaf: chain[0]}
+	for _, c := range chain {
+		cert.Certificate = append(cert.Certificate, c.Raw)
+		certPEM = append(certPEM, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.Raw})...)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(certKey)
+	if err != nil {
+		return fmt.Errorf("marshaling certificate key: %w", err)
+	}
+
+	// Write the key first, so that a crash between the two writes leaves a
+	// mismatched pair, which is replaced on the next start, rather than a new
+	// certificate paired with the old key.
+	err = writeFileAtomically(s.keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0600)
+	if err != nil {
+		return fmt.Errorf("writing certificate key: %w", err)
+	}
+	err = writeFileAtomically(s.certPath, certPEM, 0644)
+	if err != nil {
+		return fmt.Errorf("writing certificate: %w", err)
+	}
+
+	s.Lock()
+	s.cert = cert
+	s.Unlock()
+	return nil
+}
+
+// validate completes the tls-alpn-01 challenge of the authorization at
+// authzURL, unless it's already valid.
+func (s *SelfIssuer) validate(client acme.Client, account acme.Account, authzURL string) error {
+	authz, err := client.FetchAuthorization(account, authzURL)
+	if err != nil {
+		return fmt.Errorf("fetching authorization: %w", err)
+	}
+	if authz.Status == "valid" {
+		return nil
+	}
+	chall, ok := authz.ChallengeMap[acme.ChallengeTypeTLSALPN01]
+	if !ok {
+		return fmt.Errorf("authorization for %q offers no tls-alpn-01 challenge", authz.Identifier.Value)
+	}
+
+	challCert, err := newChallengeCert(authz.Identifier.Value, chall.KeyAuthorization)
+	if err != nil {
+		return fmt.Errorf("creating challenge certificate: %w", err)
+	}
+	s.Lock()
+	s.challengeCerts[authz.Identifier.Value] = challCert
+	s.Unlock()
+	defer func() {
+		s.Lock()
+		delete(s.challengeCerts, authz.Identifier.Value)
+		s.Unlock()
+	}()
+
+	_, err = client.UpdateChallenge(account, chall)
+	if err != nil {
+		return fmt.Errorf("validating %q: %w", authz.Identifier.Value, err)
+	}
+	return nil
+}
+
+// loadAccountKey loads the ACME account key, generating and writing one if
+// none exists yet.
+func (s *SelfIssuer) loadAccountKey() (crypto.Signer, error) {
+	_, err := os.Stat(s.accountKeyPath)
+	if err == nil {
+		key, _, err := privatekey.Load(s.accountKeyPath)
+		return key, err
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generating account key: %w", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling account key: %w", err)
+	}
+	err = writeFileAtomically(s.accountKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600)
+	if err != nil {
+		return nil, fmt.Errorf("writing account key: %w", err)
+	}
+	return key, nil
+}
+
+// newChallengeCert returns a self-signed tls-alpn-01 challenge certificate
+// for name, carrying the SHA-256 digest of keyAuth as RFC 8737 Section 3
+// requires.
+func newChallengeCert(name, keyAuth string) (*tls.Certificate, error) {
+	digest := sha256.Sum256([]byte(keyAuth))
+	extValue, err := asn1.Marshal(digest[:])
+	if err != nil {
+		return nil, err
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 127))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(24 * time.Hour),
+		DNSNames:     []string{name},
+		ExtraExtensions: []pkix.Extension{
+			{Id: idPeAcmeIdentifier, Critical: true, Value: extValue},
+		},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, err
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}, nil
+}
+
+// writeFileAtomically writes b to path via a temporary file in the same
+// directory, so that readers never see a partially written file.
+func writeFileAtomically(path string, b []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(b)
+	if err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	err = tmp.Chmod(perm)
+	if err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,229 @@
+package wfe2
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+
+	"github.com/letsencrypt/boulder/config"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// writeTestServerCert writes a self-signed certificate for example.com, valid
+// from notBefore to notAfter, and its key to dir, and returns their paths.
+func writeTestServerCert(t *testing.T, dir string, notBefore, notAfter time.Time) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	test.AssertNotError(t, err, "creating certificate")
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	test.AssertNotError(t, err, "marshaling key")
+
+	certPath := filepath.Join(dir, "cert.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+	err = os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	test.AssertNotError(t, err, "writing certificate")
+	err = os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0600)
+	test.AssertNotError(t, err, "writing key")
+	return certPath, keyPath
+}
+
+func testSelfIssuanceConfig(dir string) SelfIssuanceConfig {
+	return SelfIssuanceConfig{
+		DirectoryURL:   "http://localhost:4001/directory",
+		Hostnames:      []string{"example.com"},
+		Profile:        "bootstrap",
+		AccountKeyPath: filepath.Join(dir, "account.pem"),
+	}
+}
+
+func TestNewSelfIssuer(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "cert.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+	clk := clock.NewFake()
+
+	_, err := NewSelfIssuer(SelfIssuanceConfig{}, certPath, keyPath, clk, blog.NewMock())
+	test.AssertError(t, err, "empty config should be rejected")
+
+	c := testSelfIssuanceConfig(dir)
+	c.RenewBefore = config.Duration{Duration: -time.Hour}
+	_, err = NewSelfIssuer(c, certPath, keyPath, clk, blog.NewMock())
+	test.AssertError(t, err, "negative renewBefore should be rejected")
+
+	c = testSelfIssuanceConfig(dir)
+	_, err = NewSelfIssuer(c, "", keyPath, clk, blog.NewMock())
+	test.AssertError(t, err, "missing certificate path should be rejected")
+
+	// With no certificate on disk yet, one is needed right away, and TLS
+	// clients are refused until it's obtained.
+	s, err := NewSelfIssuer(c, certPath, keyPath, clk, blog.NewMock())
+	test.AssertNotError(t, err, "NewSelfIssuer failed")
+	test.Assert(t, s.needsRenewal(), "should need a certificate")
+	_, err = s.GetCertificate(&tls.ClientHelloInfo{ServerName: "example.com"})
+	test.AssertError(t, err, "GetCertificate should fail without a certificate")
+
+	// A damaged certificate is ignored, so that it can be replaced.
+	err = os.WriteFile(certPath, []byte("not a certificate"), 0600)
+	test.AssertNotError(t, err, "writing damaged certificate")
+	err = os.WriteFile(keyPath, []byte("not a key"), 0600)
+	test.AssertNotError(t, err, "writing damaged key")
+	log := blog.NewMock()
+	s, err = NewSelfIssuer(c, certPath, keyPath, clk, log)
+	test.AssertNotError(t, err, "NewSelfIssuer should tolerate a damaged certificate")
+	test.Assert(t, s.needsRenewal(), "should need a certificate")
+	test.AssertEquals(t, len(log.GetAllMatching("Ignoring unusable self-issued certificate")), 1)
+}
+
+func TestSelfIssuerNeedsRenewal(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	clk := clock.NewFake()
+	notBefore := clk.Now()
+	certPath, keyPath := writeTestServerCert(t, dir, notBefore, notBefore.Add(90*24*time.Hour))
+
+	// By default, certificates are renewed once two thirds of their validity
+	// period has passed.
+	s, err := NewSelfIssuer(testSelfIssuanceConfig(dir), certPath, keyPath, clk, blog.NewMock())
+	test.AssertNotError(t, err, "NewSelfIssuer failed")
+	test.Assert(t, !s.needsRenewal(), "new certificate shouldn't need renewal")
+	clk.Add(59 * 24 * time.Hour)
+	test.Assert(t, !s.needsRenewal(), "certificate shouldn't need renewal yet")
+	clk.Add(24 * time.Hour)
+	test.Assert(t, s.needsRenewal(), "certificate should need renewal")
+
+	c := testSelfIssuanceConfig(dir)
+	c.RenewBefore = config.Duration{Duration: 10 * 24 * time.Hour}
+	s, err = NewSelfIssuer(c, certPath, keyPath, clk, blog.NewMock())
+	test.AssertNotError(t, err, "NewSelfIssuer failed")
+	test.Assert(t, !s.needsRenewal(), "certificate shouldn't need renewal yet")
+	clk.Add(20 * 24 * time.Hour)
+	test.Assert(t, s.needsRenewal(), "certificate should need renewal")
+}
+
+func TestSelfIssuerGetCertificate(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	certPath, keyPath := writeTestServerCert(t, dir, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	s, err := NewSelfIssuer(testSelfIssuanceConfig(dir), certPath, keyPath, clock.New(), blog.NewMock())
+	test.AssertNotError(t, err, "NewSelfIssuer failed")
+	challCert, err := newChallengeCert("example.com", "token.thumbprint")
+	test.AssertNotError(t, err, "newChallengeCert failed")
+	s.challengeCerts["example.com"] = challCert
+
+	tlsConfig := &tls.Config{}
+	s.ConfigureTLS(tlsConfig)
+	tlsConfig.NextProtos = append(tlsConfig.NextProtos, "http/1.1")
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", tlsConfig)
+	test.AssertNotError(t, err, "listening")
+	defer lis.Close()
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.(*tls.Conn).Handshake()
+			_ = conn.Close()
+		}
+	}()
+
+	dial := func(serverName string, protos ...string) (*tls.ConnectionState, error) {
+		d := tls.Dialer{
+			NetDialer: &net.Dialer{Timeout: 5 * time.Second},
+			Config: &tls.Config{
+				ServerName:         serverName,
+				NextProtos:         protos,
+				InsecureSkipVerify: true,
+			},
+		}
+		conn, err := d.DialContext(context.Background(), "tcp", lis.Addr().String())
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+		state := conn.(*tls.Conn).ConnectionState()
+		return &state, nil
+	}
+
+	// Ordinary clients get the current certificate.
+	state, err := dial("example.com", "http/1.1")
+	test.AssertNotError(t, err, "dialing with http/1.1")
+	test.AssertEquals(t, state.NegotiatedProtocol, "http/1.1")
+	test.AssertByteEquals(t, state.PeerCertificates[0].Raw, s.cert.Leaf.Raw)
+
+	// The VA gets the challenge certificate.
+	state, err = dial("example.com", acmeTLS1Protocol)
+	test.AssertNotError(t, err, "dialing with acme-tls/1")
+	test.AssertEquals(t, state.NegotiatedProtocol, acmeTLS1Protocol)
+	test.AssertByteEquals(t, state.PeerCertificates[0].Raw, challCert.Leaf.Raw)
+
+	// But only for names with a pending challenge.
+	_, err = dial("other.example.com", acmeTLS1Protocol)
+	test.AssertError(t, err, "dialing with acme-tls/1 for a name without a pending challenge")
+}
+
+func TestNewChallengeCert(t *testing.T) {
+	t.Parallel()
+	cert, err := newChallengeCert("example.com", "token.thumbprint")
+	test.AssertNotError(t, err, "newChallengeCert failed")
+	test.AssertDeepEquals(t, cert.Leaf.DNSNames, []string{"example.com"})
+
+	var found bool
+	for _, ext := range cert.Leaf.Extensions {
+		if !ext.Id.Equal(idPeAcmeIdentifier) {
+			continue
+		}
+		found = true
+		test.Assert(t, ext.Critical, "acmeIdentifier extension should be critical")
+		var digest []byte
+		rest, err := asn1.Unmarshal(ext.Value, &digest)
+		test.AssertNotError(t, err, "unmarshaling acmeIdentifier extension")
+		test.AssertEquals(t, len(rest), 0)
+		expected := sha256.Sum256([]byte("token.thumbprint"))
+		test.AssertByteEquals(t, digest, expected[:])
+	}
+	test.Assert(t, found, "acmeIdentifier extension should be present")
+}
+
+func TestSelfIssuerLoadAccountKey(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	s, err := NewSelfIssuer(testSelfIssuanceConfig(dir), filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"), clock.NewFake(), blog.NewMock())
+	test.AssertNotError(t, err, "NewSelfIssuer failed")
+
+	// The first load generates a key, and later loads return the same one.
+	generated, err := s.loadAccountKey()
+	test.AssertNotError(t, err, "generating account key")
+	info, err := os.Stat(s.accountKeyPath)
+	test.AssertNotError(t, err, "account key should have been written")
+	test.AssertEquals(t, info.Mode().Perm(), os.FileMode(0600))
+	loaded, err := s.loadAccountKey()
+	test.AssertNotError(t, err, "loading account key")
+	test.Assert(t, generated.Public().(*ecdsa.PublicKey).Equal(loaded.Public()), "loaded key should match generated key")
+}
@@ -14,6 +14,14 @@ type wfe2Stats struct {
 	// csrSignatureAlgs counts the signature algorithms in use for order
 	// finalization CSRs
 	csrSignatureAlgs *prometheus.CounterVec
+	// jwsSignatureAlgs counts the signature algorithms of JWS request bodies,
+	// labeled by how the JWSAlgorithmPolicy treats them:
+	//   - status=[allowed|deprecated|disabled]
+	jwsSignatureAlgs *prometheus.CounterVec
+	// deprecationWarnings counts the responses carrying a Warning header
+	// because the request was signed with a deprecated JWS algorithm, by
+	// algorithm.
+	deprecationWarnings *prometheus.CounterVec
 	// improperECFieldLengths counts the number of ACME account EC JWKs we see
 	// with improper X and Y lengths for their curve
 	improperECFieldLengths prometheus.Counter
@@ -25,6 +33,24 @@ type wfe2Stats struct {
 	//   - isReplacement=[true|false]
 	//   - limitsExempt=[true|false]
 	ariReplacementOrders *prometheus.CounterVec
+	// skippedContacts counts new-account contacts which were not exported
+	// because they're known to be invalid.
+	skippedContacts prometheus.Counter
+	// discardedContacts counts account contacts which were discarded because
+	// the WFE is configured not to keep any.
+	discardedContacts prometheus.Counter
+	// clientErrors counts problem documents sent, labeled by:
+	//   - family=[the client's family, from its User-Agent|other|none]
+	//   - version=[the client's major.minor version]
+	//   - code=[the problem's code]
+	clientErrors *prometheus.CounterVec
+	// clientFinalizations counts successfully finalized orders, labeled by
+	// family and version as for clientErrors.
+	clientFinalizations *prometheus.CounterVec
+	// validationWaits counts polls which asked the RA to wait for a
+	// validation in progress, labeled by:
+	//   - result=[recorded|not_in_progress|timeout|error]
+	validationWaits *prometheus.CounterVec
 }
 
 func initStats(stats prometheus.Registerer) wfe2Stats {
@@ -53,6 +79,24 @@ func initStats(stats prometheus.Registerer) wfe2Stats {
 	)
 	stats.MustRegister(csrSignatureAlgs)
 
+	jwsSignatureAlgs := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "jws_signature_algs",
+			Help: "Number of JWS request signatures by algorithm, labeled status=[allowed|deprecated|disabled]",
+		},
+		[]string{"alg", "status"},
+	)
+	stats.MustRegister(jwsSignatureAlgs)
+
+	deprecationWarnings := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "jws_deprecation_warnings",
+			Help: "Number of responses warning that the request was signed with a deprecated JWS algorithm, by algorithm",
+		},
+		[]string{"alg"},
+	)
+	stats.MustRegister(deprecationWarnings)
+
 	improperECFieldLengths := prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Name: "improper_ec_field_lengths",
@@ -78,12 +122,62 @@ func initStats(stats prometheus.Registerer) wfe2Stats {
 	)
 	stats.MustRegister(ariReplacementOrders)
 
+	skippedContacts := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "skipped_contacts",
+			Help: "Number of new-account contacts not exported because they're known to be invalid",
+		},
+	)
+	stats.MustRegister(skippedContacts)
+
+	discardedContacts := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "discarded_contacts",
+			Help: "Number of account contacts discarded because the WFE doesn't keep contacts",
+		},
+	)
+	stats.MustRegister(discardedContacts)
+
+	clientErrors := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "client_errors",
+			Help: "Number of problem documents sent, by client family, client version and problem code",
+		},
+		[]string{"family", "version", "code"},
+	)
+	stats.MustRegister(clientErrors)
+
+	clientFinalizations := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "client_finalizations",
+			Help: "Number of orders successfully finalized, by client family and client version",
+		},
+		[]string{"family", "version"},
+	)
+	stats.MustRegister(clientFinalizations)
+
+	validationWaits := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "validation_waits",
+			Help: "Number of authorization polls which waited for a validation in progress, labeled result=[recorded|not_in_progress|timeout|error]",
+		},
+		[]string{"result"},
+	)
+	stats.MustRegister(validationWaits)
+
 	return wfe2Stats{
 		httpErrorCount:              httpErrorCount,
 		joseErrorCount:              joseErrorCount,
 		csrSignatureAlgs:            csrSignatureAlgs,
+		jwsSignatureAlgs:            jwsSignatureAlgs,
+		deprecationWarnings:         deprecationWarnings,
 		improperECFieldLengths:      improperECFieldLengths,
 		nonceNoMatchingBackendCount: nonceNoBackendCount,
 		ariReplacementOrders:        ariReplacementOrders,
+		skippedContacts:             skippedContacts,
+		discardedContacts:           discardedContacts,
+		clientErrors:                clientErrors,
+		clientFinalizations:         clientFinalizations,
+		validationWaits:             validationWaits,
 	}
 }
@@ -2,24 +2,22 @@ package wfe2
 
 import (
 	"context"
-	"crypto/ecdsa"
-	"crypto/rsa"
-	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
-	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/go-jose/go-jose/v4"
 	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/core/jws"
 	berrors "github.com/letsencrypt/boulder/errors"
 	"github.com/letsencrypt/boulder/goodkey"
 	"github.com/letsencrypt/boulder/grpc"
@@ -37,23 +35,6 @@ const (
 	maxRequestSize = 50000
 )
 
-func sigAlgorithmForKey(key *jose.JSONWebKey) (jose.SignatureAlgorithm, error) {
-	switch k := key.Key.(type) {
-	case *rsa.PublicKey:
-		return jose.RS256, nil
-	case *ecdsa.PublicKey:
-		switch k.Params().Name {
-		case "P-256":
-			return jose.ES256, nil
-		case "P-384":
-			return jose.ES384, nil
-		case "P-521":
-			return jose.ES512, nil
-		}
-	}
-	return "", berrors.BadPublicKeyError("JWK contains unsupported key type (expected RSA, or ECDSA P-256, P-384, or P-521)")
-}
-
 // getSupportedAlgs returns a sorted slice of joseSignatureAlgorithm's from a
 // map of boulder allowed signature algorithms. We use a function for this to
 // ensure that the source-of-truth slice can never be modified.
@@ -66,62 +47,24 @@ func getSupportedAlgs() []jose.SignatureAlgorithm {
 	}
 }
 
-// Check that (1) there is a suitable algorithm for the provided key based on its
-// Golang type, (2) the Algorithm field on the JWK is either absent, or matches
-// that algorithm, and (3) the Algorithm field on the JWK is present and matches
-// that algorithm.
-func checkAlgorithm(key *jose.JSONWebKey, header jose.Header) error {
-	sigHeaderAlg := jose.SignatureAlgorithm(header.Algorithm)
-	if !slices.Contains(getSupportedAlgs(), sigHeaderAlg) {
-		return berrors.BadSignatureAlgorithmError(
-			"JWS signature header contains unsupported algorithm %q, expected one of %s",
-			header.Algorithm, getSupportedAlgs(),
-		)
-	}
-
-	expectedAlg, err := sigAlgorithmForKey(key)
-	if err != nil {
-		return err
-	}
-	if sigHeaderAlg != expectedAlg {
-		return berrors.MalformedError("JWS signature header algorithm %q does not match expected algorithm %q for JWK", sigHeaderAlg, string(expectedAlg))
-	}
-	if key.Algorithm != "" && key.Algorithm != string(expectedAlg) {
-		return berrors.MalformedError("JWK key header algorithm %q does not match expected algorithm %q for JWK", key.Algorithm, string(expectedAlg))
+// joseError counts err, if it's a failed check from the jws package, by the
+// check which failed, and returns the underlying error.
+func (wfe *WebFrontEndImpl) joseError(err error) error {
+	var jwsErr *jws.Error
+	if errors.As(err, &jwsErr) {
+		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": jwsErr.Kind}).Inc()
+		if jwsErr.Algorithm != "" {
+			wfe.countJWSAlgorithm(jwsErr.Algorithm)
+		}
+		return jwsErr.Err
 	}
-	return nil
+	return err
 }
 
-// jwsAuthType represents whether a given POST request is authenticated using
-// a JWS with an embedded JWK (v1 ACME style, new-account, revoke-cert) or an
-// embedded Key ID (v2 AMCE style) or an unsupported/unknown auth type.
-type jwsAuthType int
-
-const (
-	embeddedJWK jwsAuthType = iota
-	embeddedKeyID
-	invalidAuthType
-)
-
-// checkJWSAuthType examines the protected headers from a bJSONWebSignature to
-// determine if the request being authenticated by the JWS is identified using
-// an embedded JWK or an embedded key ID. If no signatures are present, or
-// mutually exclusive authentication types are specified at the same time, a
-// error is returned. checkJWSAuthType is separate from enforceJWSAuthType so
-// that endpoints that need to handle both embedded JWK and embedded key ID
-// requests can determine which type of request they have and act accordingly
-// (e.g. acme v2 cert revocation).
-func checkJWSAuthType(header jose.Header) (jwsAuthType, error) {
-	// There must not be a Key ID *and* an embedded JWK
-	if header.KeyID != "" && header.JSONWebKey != nil {
-		return invalidAuthType, berrors.MalformedError("jwk and kid header fields are mutually exclusive")
-	} else if header.KeyID != "" {
-		return embeddedKeyID, nil
-	} else if header.JSONWebKey != nil {
-		return embeddedJWK, nil
-	}
-
-	return invalidAuthType, nil
+// jwsVerifier returns a jws.Verifier which enforces the WFE's
+// JWSAlgorithmPolicy.
+func (wfe *WebFrontEndImpl) jwsVerifier() *jws.Verifier {
+	return &jws.Verifier{Algorithms: wfe.jwsAlgorithms().algs()}
 }
 
 // enforceJWSAuthType enforces that the protected headers from a
@@ -130,25 +73,8 @@ func checkJWSAuthType(header jose.Header) (jwsAuthType, error) {
 // error is returned.
 func (wfe *WebFrontEndImpl) enforceJWSAuthType(
 	header jose.Header,
-	expectedAuthType jwsAuthType) error {
-	// Check the auth type for the provided JWS
-	authType, err := checkJWSAuthType(header)
-	if err != nil {
-		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "JWSAuthTypeInvalid"}).Inc()
-		return err
-	}
-	// If the auth type isn't the one expected return a sensible error based on
-	// what was expected
-	if authType != expectedAuthType {
-		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "JWSAuthTypeWrong"}).Inc()
-		switch expectedAuthType {
-		case embeddedKeyID:
-			return berrors.MalformedError("No Key ID in JWS header")
-		case embeddedJWK:
-			return berrors.MalformedError("No embedded JWK in JWS header")
-		}
-	}
-	return nil
+	expectedAuthType jws.AuthType) error {
+	return wfe.joseError(jws.EnforceAuthType(header, expectedAuthType))
 }
 
 // validPOSTRequest checks a *http.Request to ensure it has the headers
@@ -188,52 +114,33 @@ func (wfe *WebFrontEndImpl) validPOSTRequest(request *http.Request) error {
 	return nil
 }
 
-// nonceWellFormed checks a JWS' Nonce header to ensure it is well-formed,
-// otherwise a bad nonce error is returned. This avoids unnecessary RPCs to
-// the nonce redemption service.
-func nonceWellFormed(nonceHeader string, prefixLen int) error {
-	errBadNonce := berrors.BadNonceError("JWS has an invalid anti-replay nonce: %q", nonceHeader)
-	if len(nonceHeader) <= prefixLen {
-		// Nonce header was an unexpected length because there is either:
-		// 1) no nonce, or
-		// 2) no nonce material after the prefix.
-		return errBadNonce
-	}
-	body, err := base64.RawURLEncoding.DecodeString(nonceHeader[prefixLen:])
-	if err != nil {
-		// Nonce was not valid base64url.
-		return errBadNonce
-	}
-	if len(body) != nonce.NonceLen {
-		// Nonce was an unexpected length.
-		return errBadNonce
-	}
-	return nil
-}
-
 // validNonce checks a JWS' Nonce header to ensure it is one that the
 // nonceService knows about, otherwise a bad nonce error is returned.
 // NOTE: this function assumes the JWS has already been verified with the
 // correct public key.
 func (wfe *WebFrontEndImpl) validNonce(ctx context.Context, header jose.Header) error {
-	if len(header.Nonce) == 0 {
-		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "JWSMissingNonce"}).Inc()
-		return berrors.BadNonceError("JWS has no anti-replay nonce")
-	}
-
-	err := nonceWellFormed(header.Nonce, nonce.PrefixLen)
+	headerNonce, err := jws.Nonce(header)
 	if err != nil {
-		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "JWSMalformedNonce"}).Inc()
-		return err
+		return wfe.joseError(err)
 	}
 
 	// Populate the context with the nonce prefix and HMAC key. These are
 	// used by a custom gRPC balancer, known as "noncebalancer", to route
 	// redemption RPCs to the backend that originally issued the nonce.
-	ctx = context.WithValue(ctx, nonce.PrefixCtxKey{}, header.Nonce[:nonce.PrefixLen])
+	prefix := headerNonce[:nonce.PrefixLen]
+	ctx = context.WithValue(ctx, nonce.PrefixCtxKey{}, prefix)
 	ctx = context.WithValue(ctx, nonce.HMACKeyCtxKey{}, wfe.rncKey)
+	if len(wfe.RotationNonceKeys) > 0 {
+		ctx = context.WithValue(ctx, nonce.RotationHMACKeysCtxKey{}, wfe.RotationNonceKeys)
+	}
+	if len(wfe.NoncePrefixRoutes) > 0 {
+		ctx = context.WithValue(ctx, nonce.PrefixRoutesCtxKey{}, wfe.NoncePrefixRoutes)
+	}
+	// Also send the prefix as a header, so that a proxy fronting another
+	// datacenter's nonce backends can route the RPC onwards.
+	ctx = metadata.AppendToOutgoingContext(ctx, nonce.PrefixHeader, prefix)
 
-	resp, err := wfe.rnc.Redeem(ctx, &noncepb.NonceMessage{Nonce: header.Nonce})
+	resp, err := wfe.rnc.Redeem(ctx, &noncepb.NonceMessage{Nonce: headerNonce})
 	if err != nil {
 		rpcStatus, ok := status.FromError(err)
 		if !ok || rpcStatus != nb.ErrNoBackendsMatchPrefix {
@@ -250,7 +157,7 @@ func (wfe *WebFrontEndImpl) validNonce(ctx context.Context, header jose.Header)
 
 	if !resp.Valid {
 		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "JWSInvalidNonce"}).Inc()
-		return berrors.BadNonceError("JWS has an invalid anti-replay nonce: %q", header.Nonce)
+		return berrors.BadNonceError("JWS has an invalid anti-replay nonce: %q", headerNonce)
 	}
 	return nil
 }
@@ -262,62 +169,20 @@ func (wfe *WebFrontEndImpl) validNonce(ctx context.Context, header jose.Header)
 func (wfe *WebFrontEndImpl) validPOSTURL(
 	request *http.Request,
 	header jose.Header) error {
-	extraHeaders := header.ExtraHeaders
-	// Check that there is at least one Extra Header
-	if len(extraHeaders) == 0 {
-		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "JWSNoExtraHeaders"}).Inc()
-		return berrors.MalformedError("JWS header parameter 'url' required")
-	}
-	// Try to read a 'url' Extra Header as a string
-	headerURL, ok := extraHeaders[jose.HeaderKey("url")].(string)
-	if !ok || len(headerURL) == 0 {
-		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "JWSMissingURL"}).Inc()
-		return berrors.MalformedError("JWS header parameter 'url' required")
-	}
 	// Compute the URL we expect to be in the JWS based on the HTTP request
 	expectedURL := url.URL{
 		Scheme: requestProto(request),
 		Host:   request.Host,
 		Path:   request.RequestURI,
 	}
-	// Check that the URL we expect is the one that was found in the signed JWS
-	// header
-	if expectedURL.String() != headerURL {
-		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "JWSMismatchedURL"}).Inc()
-		return berrors.MalformedError("JWS header parameter 'url' incorrect. Expected %q got %q", expectedURL.String(), headerURL)
-	}
-	return nil
+	return wfe.joseError(jws.CheckURL(header, expectedURL.String()))
 }
 
 // matchJWSURLs checks two JWS' URL headers are equal. This is used during key
 // rollover to check that the inner JWS URL matches the outer JWS URL. If the
 // JWS URLs do not match a error is returned.
 func (wfe *WebFrontEndImpl) matchJWSURLs(outer, inner jose.Header) error {
-	// Verify that the outer JWS has a non-empty URL header. This is strictly
-	// defensive since the expectation is that endpoints using `matchJWSURLs`
-	// have received at least one of their JWS from calling validPOSTForAccount(),
-	// which checks the outer JWS has the expected URL header before processing
-	// the inner JWS.
-	outerURL, ok := outer.ExtraHeaders[jose.HeaderKey("url")].(string)
-	if !ok || len(outerURL) == 0 {
-		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "KeyRolloverOuterJWSNoURL"}).Inc()
-		return berrors.MalformedError("Outer JWS header parameter 'url' required")
-	}
-
-	// Verify the inner JWS has a non-empty URL header.
-	innerURL, ok := inner.ExtraHeaders[jose.HeaderKey("url")].(string)
-	if !ok || len(innerURL) == 0 {
-		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "KeyRolloverInnerJWSNoURL"}).Inc()
-		return berrors.MalformedError("Inner JWS header parameter 'url' required")
-	}
-
-	// Verify that the outer URL matches the inner URL
-	if outerURL != innerURL {
-		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "KeyRolloverMismatchedURLs"}).Inc()
-		return berrors.MalformedError("Outer JWS 'url' value %q does not match inner JWS 'url' value %q", outerURL, innerURL)
-	}
-
-	return nil
+	return wfe.joseError(jws.MatchURLs(outer, inner))
 }
 
 // bJSONWebSignature is a new distinct type which embeds the
@@ -332,69 +197,10 @@ type bJSONWebSignature struct {
 // presence of unprotected headers) a error is returned, otherwise a
 // *bJSONWebSignature is returned.
 func (wfe *WebFrontEndImpl) parseJWS(body []byte) (*bJSONWebSignature, error) {
-	// Parse the raw JWS JSON to check that:
-	// * the unprotected Header field is not being used.
-	// * the "signatures" member isn't present, just "signature".
-	//
-	// This must be done prior to `jose.parseSigned` since it will strip away
-	// these headers.
-	var unprotected struct {
-		Header     map[string]string
-		Signatures []interface{}
-	}
-	err := json.Unmarshal(body, &unprotected)
-	if err != nil {
-		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "JWSUnmarshalFailed"}).Inc()
-		return nil, berrors.MalformedError("Parse error reading JWS")
-	}
-
-	// ACME v2 never uses values from the unprotected JWS header. Reject JWS that
-	// include unprotected headers.
-	if unprotected.Header != nil {
-		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "JWSUnprotectedHeaders"}).Inc()
-		return nil, berrors.MalformedError(
-			"JWS \"header\" field not allowed. All headers must be in \"protected\" field")
-	}
-
-	// ACME v2 never uses the "signatures" array of JSON serialized JWS, just the
-	// mandatory "signature" field. Reject JWS that include the "signatures" array.
-	if len(unprotected.Signatures) > 0 {
-		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "JWSMultiSig"}).Inc()
-		return nil, berrors.MalformedError(
-			"JWS \"signatures\" field not allowed. Only the \"signature\" field should contain a signature")
-	}
-
-	// Parse the JWS using go-jose and enforce that the expected one non-empty
-	// signature is present in the parsed JWS.
-	bodyStr := string(body)
-	parsedJWS, err := jose.ParseSigned(bodyStr, getSupportedAlgs())
+	parsedJWS, err := wfe.jwsVerifier().Parse(body)
 	if err != nil {
-		var unexpectedSignAlgoErr *jose.ErrUnexpectedSignatureAlgorithm
-		if errors.As(err, &unexpectedSignAlgoErr) {
-			wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "JWSAlgorithmCheckFailed"}).Inc()
-			return nil, berrors.BadSignatureAlgorithmError(
-				"JWS signature header contains unsupported algorithm %q, expected one of %s",
-				unexpectedSignAlgoErr.Got,
-				getSupportedAlgs(),
-			)
-		}
-
-		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "JWSParseError"}).Inc()
-		return nil, berrors.MalformedError("Parse error reading JWS")
-	}
-	if len(parsedJWS.Signatures) > 1 {
-		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "JWSTooManySignatures"}).Inc()
-		return nil, berrors.MalformedError("Too many signatures in POST body")
-	}
-	if len(parsedJWS.Signatures) == 0 {
-		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "JWSNoSignatures"}).Inc()
-		return nil, berrors.MalformedError("POST JWS not signed")
+		return nil, wfe.joseError(err)
 	}
-	if len(parsedJWS.Signatures) == 1 && len(parsedJWS.Signatures[0].Signature) == 0 {
-		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "JWSEmptySignature"}).Inc()
-		return nil, berrors.MalformedError("POST JWS not signed")
-	}
-
 	return &bJSONWebSignature{parsedJWS}, nil
 }
 
@@ -430,22 +236,10 @@ func (wfe *WebFrontEndImpl) parseJWSRequest(request *http.Request) (*bJSONWebSig
 // have acquired the headers from a bJSONWebSignature returned by parseJWS to
 // ensure it has the correct number of signatures present.
 func (wfe *WebFrontEndImpl) extractJWK(header jose.Header) (*jose.JSONWebKey, error) {
-	// extractJWK expects the request to be using an embedded JWK auth type and
-	// to not contain the mutually exclusive KeyID.
-	if err := wfe.enforceJWSAuthType(header, embeddedJWK); err != nil {
-		return nil, err
-	}
-
-	// We can be sure that JSONWebKey is != nil because we have already called
-	// enforceJWSAuthType()
-	key := header.JSONWebKey
-
-	// If the key isn't considered valid by go-jose return a error immediately
-	if !key.Valid() {
-		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "JWKInvalid"}).Inc()
-		return nil, berrors.MalformedError("Invalid JWK in JWS header")
+	key, err := jws.ExtractJWK(header)
+	if err != nil {
+		return nil, wfe.joseError(err)
 	}
-
 	return key, nil
 }
 
@@ -489,9 +283,21 @@ func (wfe *WebFrontEndImpl) lookupJWK(
 	ctx context.Context,
 	request *http.Request,
 	logEvent *web.RequestEvent) (*jose.JSONWebKey, *core.Registration, error) {
+	return wfe.lookupJWKWithStatus(header, ctx, request, logEvent, false)
+}
+
+// lookupJWKWithStatus is lookupJWK, except that if allowDeactivated is true it
+// also returns deactivated accounts. Callers which allow them must only permit
+// deactivated accounts read-only access.
+func (wfe *WebFrontEndImpl) lookupJWKWithStatus(
+	header jose.Header,
+	ctx context.Context,
+	request *http.Request,
+	logEvent *web.RequestEvent,
+	allowDeactivated bool) (*jose.JSONWebKey, *core.Registration, error) {
 	// We expect the request to be using an embedded Key ID auth type and to not
 	// contain the mutually exclusive embedded JWK.
-	if err := wfe.enforceJWSAuthType(header, embeddedKeyID); err != nil {
+	if err := wfe.enforceJWSAuthType(header, jws.EmbeddedKeyID); err != nil {
 		return nil, nil, err
 	}
 
@@ -519,8 +325,9 @@ func (wfe *WebFrontEndImpl) lookupJWK(
 		return nil, nil, berrors.InternalServerError("Error retrieving account %q: %s", accountURL, err)
 	}
 
-	// Verify the account is not deactivated
-	if core.AcmeStatus(account.Status) != core.StatusValid {
+	// Verify the account is not deactivated, unless the caller allows it
+	status := core.AcmeStatus(account.Status)
+	if status != core.StatusValid && !(allowDeactivated && status == core.StatusDeactivated) {
 		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "JWSKeyIDAccountInvalid"}).Inc()
 		return nil, nil, berrors.UnauthorizedError("Account is not valid, has status %q", account.Status)
 	}
@@ -545,12 +352,16 @@ func (wfe *WebFrontEndImpl) validJWSForKey(
 	ctx context.Context,
 	jws *bJSONWebSignature,
 	jwk *jose.JSONWebKey,
-	request *http.Request) ([]byte, error) {
-	err := checkAlgorithm(jwk, jws.Signatures[0].Header)
+	request *http.Request,
+	logEvent *web.RequestEvent) ([]byte, error) {
+	err := wfe.jwsAlgorithms().checkAlgorithm(jwk, jws.Signatures[0].Header)
 	if err != nil {
 		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "JWSAlgorithmCheckFailed"}).Inc()
 		return nil, err
 	}
+	alg := jose.SignatureAlgorithm(jws.Signatures[0].Header.Algorithm)
+	wfe.countJWSAlgorithm(alg)
+	logEvent.JWSAlgorithm = string(alg)
 
 	// Verify the JWS signature with the public key.
 	// NOTE: It might seem insecure for the WFE to be trusted to verify
@@ -558,10 +369,9 @@ func (wfe *WebFrontEndImpl) validJWSForKey(
 	// RA.  However the WFE is the RA's only view of the outside world
 	// *anyway*, so it could always lie about what key was used by faking
 	// the signature itself.
-	payload, err := jws.Verify(jwk)
+	payload, err := wfe.jwsVerifier().Verify(jws.JSONWebSignature, jwk)
 	if err != nil {
-		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "JWSVerifyFailed"}).Inc()
-		return nil, berrors.MalformedError("JWS verification error")
+		return nil, wfe.joseError(err)
 	}
 
 	// Check that the JWS contains a correct Nonce header
@@ -569,6 +379,13 @@ func (wfe *WebFrontEndImpl) validJWSForKey(
 		return nil, err
 	}
 
+	// Check that this exact JWS hasn't already been redeemed, in case the
+	// nonce service wrongly accepted its nonce twice.
+	if wfe.ReplayCache != nil && !wfe.ReplayCache.Redeem(ctx, jws.Signatures[0].Signature) {
+		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "JWSReplayed"}).Inc()
+		return nil, berrors.BadNonceError("JWS has already been redeemed")
+	}
+
 	// Check that the HTTP request URL matches the URL in the signed JWS
 	if err := wfe.validPOSTURL(request, jws.Signatures[0].Header); err != nil {
 		return nil, err
@@ -601,14 +418,30 @@ func (wfe *WebFrontEndImpl) validJWSForAccount(
 	request *http.Request,
 	ctx context.Context,
 	logEvent *web.RequestEvent) ([]byte, *bJSONWebSignature, *core.Registration, error) {
+	return wfe.validJWSForAccountWithStatus(jws, request, ctx, logEvent, false)
+}
+
+// validJWSForAccountWithStatus is validJWSForAccount, except that if
+// allowDeactivated is true the JWS may also be from a deactivated account.
+func (wfe *WebFrontEndImpl) validJWSForAccountWithStatus(
+	jws *bJSONWebSignature,
+	request *http.Request,
+	ctx context.Context,
+	logEvent *web.RequestEvent,
+	allowDeactivated bool) ([]byte, *bJSONWebSignature, *core.Registration, error) {
 	// Lookup the account and JWK for the key ID that authenticated the JWS
-	pubKey, account, err := wfe.lookupJWK(jws.Signatures[0].Header, ctx, request, logEvent)
+	pubKey, account, err := wfe.lookupJWKWithStatus(jws.Signatures[0].Header, ctx, request, logEvent, allowDeactivated)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	err = wfe.checkClientIdentity(ctx, request, account.ID)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
 	// Verify the JWS with the JWK from the SA
-	payload, err := wfe.validJWSForKey(ctx, jws, pubKey, request)
+	payload, err := wfe.validJWSForKey(ctx, jws, pubKey, request, logEvent)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -633,6 +466,30 @@ func (wfe *WebFrontEndImpl) validPOSTForAccount(
 	return wfe.validJWSForAccount(jws, request, ctx, logEvent)
 }
 
+// validPOSTForReadOnlyAccount is validPOSTForAccount, except that it also
+// accepts POST-as-GET requests from deactivated accounts, which may no longer
+// make any other request. Requests from deactivated accounts with a payload
+// are rejected exactly as by validPOSTForAccount.
+func (wfe *WebFrontEndImpl) validPOSTForReadOnlyAccount(
+	request *http.Request,
+	ctx context.Context,
+	logEvent *web.RequestEvent) ([]byte, *bJSONWebSignature, *core.Registration, error) {
+	// Parse the JWS from the POST request
+	jws, err := wfe.parseJWSRequest(request)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	body, jws, account, err := wfe.validJWSForAccountWithStatus(jws, request, ctx, logEvent, true)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if account.Status == core.StatusDeactivated && string(body) != "" {
+		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "JWSKeyIDAccountInvalid"}).Inc()
+		return nil, nil, nil, berrors.UnauthorizedError("Account is not valid, has status %q", account.Status)
+	}
+	return body, jws, account, nil
+}
+
 // validPOSTAsGETForAccount checks that a given POST request is valid using
 // `validPOSTForAccount`. It additionally validates that the JWS request payload
 // is empty, indicating that it is a POST-as-GET request per ACME draft 15+
@@ -679,7 +536,8 @@ func (wfe *WebFrontEndImpl) validPOSTAsGETForAccount(
 func (wfe *WebFrontEndImpl) validSelfAuthenticatedJWS(
 	ctx context.Context,
 	jws *bJSONWebSignature,
-	request *http.Request) ([]byte, *jose.JSONWebKey, error) {
+	request *http.Request,
+	logEvent *web.RequestEvent) ([]byte, *jose.JSONWebKey, error) {
 	// Extract the embedded JWK from the parsed protected JWS' headers
 	pubKey, err := wfe.extractJWK(jws.Signatures[0].Header)
 	if err != nil {
@@ -687,7 +545,7 @@ func (wfe *WebFrontEndImpl) validSelfAuthenticatedJWS(
 	}
 
 	// Verify the JWS with the embedded JWK
-	payload, err := wfe.validJWSForKey(ctx, jws, pubKey, request)
+	payload, err := wfe.validJWSForKey(ctx, jws, pubKey, request, logEvent)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -700,7 +558,8 @@ func (wfe *WebFrontEndImpl) validSelfAuthenticatedJWS(
 // goodkey policies (key algorithm, length, blocklist, etc).
 func (wfe *WebFrontEndImpl) validSelfAuthenticatedPOST(
 	ctx context.Context,
-	request *http.Request) ([]byte, *jose.JSONWebKey, error) {
+	request *http.Request,
+	logEvent *web.RequestEvent) ([]byte, *jose.JSONWebKey, error) {
 	// Parse the JWS from the POST request
 	jws, err := wfe.parseJWSRequest(request)
 	if err != nil {
@@ -708,7 +567,7 @@ func (wfe *WebFrontEndImpl) validSelfAuthenticatedPOST(
 	}
 
 	// Extract and validate the embedded JWK from the parsed JWS
-	payload, pubKey, err := wfe.validSelfAuthenticatedJWS(ctx, jws, request)
+	payload, pubKey, err := wfe.validSelfAuthenticatedJWS(ctx, jws, request, logEvent)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -726,18 +585,10 @@ func (wfe *WebFrontEndImpl) validSelfAuthenticatedPOST(
 	return payload, pubKey, nil
 }
 
-// rolloverRequest is a client request to change the key for the account ID
-// provided from the specified old key to a new key (the embedded JWK in the
-// inner JWS).
-type rolloverRequest struct {
-	OldKey  jose.JSONWebKey
-	Account string
-}
-
 // rolloverOperation is a struct representing a requested rollover operation
 // from the specified old key to the new key for the given account ID.
 type rolloverOperation struct {
-	rolloverRequest
+	jws.RolloverRequest
 	NewKey jose.JSONWebKey
 }
 
@@ -750,7 +601,7 @@ type rolloverOperation struct {
 // 2) the inner JWS has the same "url" header as the outer JWS
 // 3) the inner JWS is self-authenticated with an embedded JWK
 //
-// This function verifies that the inner JWS' body is a rolloverRequest instance
+// This function verifies that the inner JWS' body is a jws.RolloverRequest
 // that specifies the correct oldKey. The returned rolloverOperation's NewKey
 // field will be set to the JWK from the inner JWS.
 //
@@ -779,57 +630,25 @@ func (wfe *WebFrontEndImpl) validKeyRollover(
 	}
 
 	// Check that the public key and JWS algorithms match expected
-	err = checkAlgorithm(innerJWK, innerJWS.Signatures[0].Header)
+	err = wfe.jwsAlgorithms().checkAlgorithm(innerJWK, innerJWS.Signatures[0].Header)
 	if err != nil {
 		return nil, err
 	}
 
-	// Verify the inner JWS signature with the public key from the embedded JWK.
-	// NOTE(@cpu): We do not use `wfe.validJWSForKey` here because the inner JWS
-	// of a key rollover operation is special (e.g. has no nonce, doesn't have an
-	// HTTP request to match the URL to)
-	innerPayload, err := innerJWS.Verify(innerJWK)
-	if err != nil {
-		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "KeyRolloverJWSVerifyFailed"}).Inc()
-		return nil, berrors.MalformedError("Inner JWS does not verify with embedded JWK")
-	}
+	// Verify the inner JWS with the embedded JWK and check that it's a request
+	// to roll over from oldKey, sent to the same URL as the outer JWS.
 	// NOTE(@cpu): we do not stomp the web.RequestEvent's payload here since that is set
 	// from the outerJWS in validPOSTForAccount and contains the inner JWS and inner
 	// payload already.
-
-	// Verify that the outer and inner JWS protected URL headers match
-	if err := wfe.matchJWSURLs(outerJWS.Signatures[0].Header, innerJWS.Signatures[0].Header); err != nil {
-		return nil, err
-	}
-
-	var req rolloverRequest
-	if json.Unmarshal(innerPayload, &req) != nil {
-		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "KeyRolloverUnmarshalFailed"}).Inc()
-		return nil, berrors.MalformedError("Inner JWS payload did not parse as JSON key rollover object")
-	}
-
-	// If there's no oldkey specified fail before trying to use
-	// core.PublicKeyEqual on a nil argument.
-	if req.OldKey.Key == nil {
-		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "KeyRolloverWrongOldKey"}).Inc()
-		return nil, berrors.MalformedError("Inner JWS does not contain old key field matching current account key")
-	}
-
-	// We must validate that the inner JWS' rollover request specifies the correct
-	// oldKey.
-	keysEqual, err := core.PublicKeysEqual(req.OldKey.Key, oldKey.Key)
+	req, err := wfe.jwsVerifier().VerifyRollover(outerJWS.JSONWebSignature, innerJWS.JSONWebSignature, innerJWK, oldKey)
 	if err != nil {
-		return nil, berrors.MalformedError("Unable to compare new and old keys: %s", err.Error())
-	}
-	if !keysEqual {
-		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "KeyRolloverWrongOldKey"}).Inc()
-		return nil, berrors.MalformedError("Inner JWS does not contain old key field matching current account key")
+		return nil, wfe.joseError(err)
 	}
 
 	// Return a rolloverOperation populated with the validated old JWK, the
 	// requested account, and the new JWK extracted from the inner JWS.
 	return &rolloverOperation{
-		rolloverRequest: rolloverRequest{
+		RolloverRequest: jws.RolloverRequest{
 			OldKey:  *oldKey,
 			Account: req.Account,
 		},
@@ -17,11 +17,13 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/core/jws"
 	corepb "github.com/letsencrypt/boulder/core/proto"
 	berrors "github.com/letsencrypt/boulder/errors"
 	"github.com/letsencrypt/boulder/goodkey"
 	bgrpc "github.com/letsencrypt/boulder/grpc"
 	"github.com/letsencrypt/boulder/grpc/noncebalancer"
+	"github.com/letsencrypt/boulder/nonce"
 	noncepb "github.com/letsencrypt/boulder/nonce/proto"
 	sapb "github.com/letsencrypt/boulder/sa/proto"
 	"github.com/letsencrypt/boulder/test"
@@ -29,24 +31,25 @@ import (
 
 	"github.com/go-jose/go-jose/v4"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
-// sigAlgForKey uses `signatureAlgorithmForKey` but fails immediately using the
+// sigAlgForKey uses `jws.AlgorithmForKey` but fails immediately using the
 // testing object if the sig alg is unknown.
 func sigAlgForKey(t *testing.T, key interface{}) jose.SignatureAlgorithm {
 	var sigAlg jose.SignatureAlgorithm
 	var err error
 	// Gracefully handle the case where a non-pointer public key is given where
-	// sigAlgorithmForKey always wants a pointer. It may be tempting to try and do
-	// `sigAlgorithmForKey(&jose.JSONWebKey{Key: &key})` without a type switch but this produces
+	// jws.AlgorithmForKey always wants a pointer. It may be tempting to try and do
+	// `jws.AlgorithmForKey(&jose.JSONWebKey{Key: &key})` without a type switch but this produces
 	// `*interface {}` and not the desired `*rsa.PublicKey` or `*ecdsa.PublicKey`.
 	switch k := key.(type) {
 	case rsa.PublicKey:
-		sigAlg, err = sigAlgorithmForKey(&jose.JSONWebKey{Key: &k})
+		sigAlg, err = jws.AlgorithmForKey(&jose.JSONWebKey{Key: &k})
 	case ecdsa.PublicKey:
-		sigAlg, err = sigAlgorithmForKey(&jose.JSONWebKey{Key: &k})
+		sigAlg, err = jws.AlgorithmForKey(&jose.JSONWebKey{Key: &k})
 	default:
-		sigAlg, err = sigAlgorithmForKey(&jose.JSONWebKey{Key: k})
+		sigAlg, err = jws.AlgorithmForKey(&jose.JSONWebKey{Key: k})
 	}
 	test.Assert(t, err == nil, fmt.Sprintf("Error getting signature algorithm for key %#v", key))
 	return sigAlg
@@ -427,7 +430,7 @@ func TestCheckAlgorithm(t *testing.T) {
 		},
 	}
 	for i, tc := range testCases {
-		err := checkAlgorithm(&tc.key, tc.jws.Signatures[0].Header)
+		err := defaultJWSAlgorithmPolicy.checkAlgorithm(&tc.key, tc.jws.Signatures[0].Header)
 		if tc.expectedErr != "" && err.Error() != tc.expectedErr {
 			t.Errorf("TestCheckAlgorithm %d: Expected %q, got %q", i, tc.expectedErr, err)
 		}
@@ -448,14 +451,14 @@ func TestCheckAlgorithmSuccess(t *testing.T) {
 		Algorithm: "RS256",
 		Key:       &rsa.PublicKey{},
 	}
-	err := checkAlgorithm(goodJSONWebKeyRS256, jwsRS256.Signatures[0].Header)
+	err := defaultJWSAlgorithmPolicy.checkAlgorithm(goodJSONWebKeyRS256, jwsRS256.Signatures[0].Header)
 	test.AssertNotError(t, err, "RS256 key: Expected nil error")
 
 	badJSONWebKeyRS256 := &jose.JSONWebKey{
 		Algorithm: "ObviouslyWrongButNotZeroValue",
 		Key:       &rsa.PublicKey{},
 	}
-	err = checkAlgorithm(badJSONWebKeyRS256, jwsRS256.Signatures[0].Header)
+	err = defaultJWSAlgorithmPolicy.checkAlgorithm(badJSONWebKeyRS256, jwsRS256.Signatures[0].Header)
 	test.AssertError(t, err, "RS256 key: Expected nil error")
 	test.AssertContains(t, err.Error(), "JWK key header algorithm \"ObviouslyWrongButNotZeroValue\" does not match expected algorithm \"RS256\" for JWK")
 
@@ -474,7 +477,7 @@ func TestCheckAlgorithmSuccess(t *testing.T) {
 			Curve: elliptic.P256(),
 		},
 	}
-	err = checkAlgorithm(goodJSONWebKeyES256, jwsES256.Signatures[0].Header)
+	err = defaultJWSAlgorithmPolicy.checkAlgorithm(goodJSONWebKeyES256, jwsES256.Signatures[0].Header)
 	test.AssertNotError(t, err, "ES256 key: Expected nil error")
 
 	badJSONWebKeyES256 := &jose.JSONWebKey{
@@ -483,7 +486,7 @@ func TestCheckAlgorithmSuccess(t *testing.T) {
 			Curve: elliptic.P256(),
 		},
 	}
-	err = checkAlgorithm(badJSONWebKeyES256, jwsES256.Signatures[0].Header)
+	err = defaultJWSAlgorithmPolicy.checkAlgorithm(badJSONWebKeyES256, jwsES256.Signatures[0].Header)
 	test.AssertError(t, err, "ES256 key: Expected nil error")
 	test.AssertContains(t, err.Error(), "JWK key header algorithm \"ObviouslyWrongButNotZeroValue\" does not match expected algorithm \"ES256\" for JWK")
 }
@@ -608,7 +611,7 @@ func TestEnforceJWSAuthType(t *testing.T) {
 	testCases := []struct {
 		Name          string
 		JWS           *jose.JSONWebSignature
-		AuthType      jwsAuthType
+		AuthType      jws.AuthType
 		WantErrType   berrors.ErrorType
 		WantErrDetail string
 		WantStatType  string
@@ -616,7 +619,7 @@ func TestEnforceJWSAuthType(t *testing.T) {
 		{
 			Name:          "Key ID and embedded JWS",
 			JWS:           conflictJWS,
-			AuthType:      invalidAuthType,
+			AuthType:      jws.InvalidAuthType,
 			WantErrType:   berrors.Malformed,
 			WantErrDetail: "jwk and kid header fields are mutually exclusive",
 			WantStatType:  "JWSAuthTypeInvalid",
@@ -624,7 +627,7 @@ func TestEnforceJWSAuthType(t *testing.T) {
 		{
 			Name:          "Key ID when expected is embedded JWK",
 			JWS:           testKeyIDJWS,
-			AuthType:      embeddedJWK,
+			AuthType:      jws.EmbeddedJWK,
 			WantErrType:   berrors.Malformed,
 			WantErrDetail: "No embedded JWK in JWS header",
 			WantStatType:  "JWSAuthTypeWrong",
@@ -632,7 +635,7 @@ func TestEnforceJWSAuthType(t *testing.T) {
 		{
 			Name:          "Embedded JWK when expected is Key ID",
 			JWS:           testEmbeddedJWS,
-			AuthType:      embeddedKeyID,
+			AuthType:      jws.EmbeddedKeyID,
 			WantErrType:   berrors.Malformed,
 			WantErrDetail: "No Key ID in JWS header",
 			WantStatType:  "JWSAuthTypeWrong",
@@ -640,12 +643,12 @@ func TestEnforceJWSAuthType(t *testing.T) {
 		{
 			Name:     "Key ID when expected is KeyID",
 			JWS:      testKeyIDJWS,
-			AuthType: embeddedKeyID,
+			AuthType: jws.EmbeddedKeyID,
 		},
 		{
 			Name:     "Embedded JWK when expected is embedded JWK",
 			JWS:      testEmbeddedJWS,
-			AuthType: embeddedJWK,
+			AuthType: jws.EmbeddedJWK,
 		},
 	}
 
@@ -791,6 +794,42 @@ func TestValidNonce_NoMatchingBackendFound(t *testing.T) {
 	test.AssertMetricWithLabelsEquals(t, wfe.stats.nonceNoMatchingBackendCount, prometheus.Labels{}, 1)
 }
 
+// recordingNonceRedeemer is a nonce redeemer that records the context of the
+// last redemption RPC and reports every nonce as valid.
+type recordingNonceRedeemer struct {
+	ctx context.Context
+}
+
+func (r *recordingNonceRedeemer) Redeem(ctx context.Context, _ *noncepb.NonceMessage, opts ...grpc.CallOption) (*noncepb.ValidMessage, error) {
+	r.ctx = ctx
+	return &noncepb.ValidMessage{Valid: true}, nil
+}
+
+func TestValidNonce_PrefixRouting(t *testing.T) {
+	wfe, _, signer := setupWFE(t)
+	goodJWS, _, _ := signer.embeddedJWK(nil, "", "")
+	header := goodJWS.Signatures[0].Header
+	prefix := header.Nonce[:nonce.PrefixLen]
+	rnc := &recordingNonceRedeemer{}
+	wfe.rnc = rnc
+
+	// Without routes configured, only the prefix and HMAC key are passed to
+	// the balancer, but the prefix is always sent as a header.
+	err := wfe.validNonce(context.Background(), header)
+	test.AssertNotError(t, err, "validNonce failed")
+	test.AssertEquals(t, rnc.ctx.Value(nonce.PrefixCtxKey{}), prefix)
+	test.AssertNil(t, rnc.ctx.Value(nonce.PrefixRoutesCtxKey{}), "expected no prefix routes")
+	md, ok := metadata.FromOutgoingContext(rnc.ctx)
+	test.Assert(t, ok, "expected outgoing metadata")
+	test.AssertDeepEquals(t, md.Get(nonce.PrefixHeader), []string{prefix})
+
+	routes := map[string]string{prefix: "10.77.77.77:9401"}
+	wfe.NoncePrefixRoutes = routes
+	err = wfe.validNonce(context.Background(), header)
+	test.AssertNotError(t, err, "validNonce failed")
+	test.AssertDeepEquals(t, rnc.ctx.Value(nonce.PrefixRoutesCtxKey{}), routes)
+}
+
 func (rs requestSigner) signExtraHeaders(
 	headers map[jose.HeaderKey]interface{}) (*jose.JSONWebSignature, string) {
 	privateKey := loadKey(rs.t, []byte(test1KeyPrivatePEM))
@@ -1397,7 +1436,7 @@ func TestValidJWSForKey(t *testing.T) {
 			wfe.stats.joseErrorCount.Reset()
 			request := makePostRequestWithPath("test", tc.Body)
 
-			gotPayload, gotErr := wfe.validJWSForKey(context.Background(), &tc.JWS, tc.JWK, request)
+			gotPayload, gotErr := wfe.validJWSForKey(context.Background(), &tc.JWS, tc.JWK, request, newRequestEvent())
 			if tc.WantErrDetail == "" {
 				if gotErr != nil {
 					t.Fatalf("validJWSForKey(%#v, %#v, %#v) = %#v, want nil", tc.JWS, tc.JWK, request, gotErr)
@@ -1544,13 +1583,14 @@ func TestValidPOSTAsGETForAccount(t *testing.T) {
 			Request:       makePostRequestWithPath("test", invalidPayloadRequest),
 			WantErrType:   berrors.Malformed,
 			WantErrDetail: "POST-as-GET requests must have an empty payload",
-			WantLogEvent:  web.RequestEvent{},
+			WantLogEvent:  web.RequestEvent{JWSAlgorithm: "RS256"},
 		},
 		{
 			Name:    "Valid POST-as-GET",
 			Request: makePostRequestWithPath("test", validRequest),
 			WantLogEvent: web.RequestEvent{
-				Method: "POST-as-GET",
+				Method:       "POST-as-GET",
+				JWSAlgorithm: "RS256",
 			},
 		},
 	}
@@ -1628,7 +1668,7 @@ func TestValidSelfAuthenticatedPOSTGoodKeyErrors(t *testing.T) {
 	_, _, validJWSBody := signer.embeddedJWK(nil, "http://localhost/test", `{"test":"passed"}`)
 	request := makePostRequestWithPath("test", validJWSBody)
 
-	_, _, err = wfe.validSelfAuthenticatedPOST(context.Background(), request)
+	_, _, err = wfe.validSelfAuthenticatedPOST(context.Background(), request, newRequestEvent())
 	test.AssertErrorIs(t, err, berrors.InternalServer)
 
 	badKeyCheckFunc := func(ctx context.Context, keyHash []byte) (bool, error) {
@@ -1643,7 +1683,7 @@ func TestValidSelfAuthenticatedPOSTGoodKeyErrors(t *testing.T) {
 	_, _, validJWSBody = signer.embeddedJWK(nil, "http://localhost/test", `{"test":"passed"}`)
 	request = makePostRequestWithPath("test", validJWSBody)
 
-	_, _, err = wfe.validSelfAuthenticatedPOST(context.Background(), request)
+	_, _, err = wfe.validSelfAuthenticatedPOST(context.Background(), request, newRequestEvent())
 	test.AssertErrorIs(t, err, berrors.BadPublicKey)
 }
 
@@ -1688,7 +1728,7 @@ func TestValidSelfAuthenticatedPOST(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.Name, func(t *testing.T) {
 			wfe.stats.joseErrorCount.Reset()
-			gotPayload, gotJWK, gotErr := wfe.validSelfAuthenticatedPOST(context.Background(), tc.Request)
+			gotPayload, gotJWK, gotErr := wfe.validSelfAuthenticatedPOST(context.Background(), tc.Request, newRequestEvent())
 			if tc.WantErrDetail == "" {
 				if gotErr != nil {
 					t.Fatalf("validSelfAuthenticatedPOST(%#v) = %#v, want nil", tc.Request, gotErr)
@@ -14,25 +14,32 @@ import (
 	"net"
 	"net/http"
 	"net/netip"
-	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/go-jose/go-jose/v4"
 	"github.com/jmhodges/clock"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/core/jws"
 	corepb "github.com/letsencrypt/boulder/core/proto"
+	"github.com/letsencrypt/boulder/email"
 	emailpb "github.com/letsencrypt/boulder/email/proto"
 	berrors "github.com/letsencrypt/boulder/errors"
+	exemptionspb "github.com/letsencrypt/boulder/exemptions/proto"
 	"github.com/letsencrypt/boulder/features"
 	"github.com/letsencrypt/boulder/goodkey"
 	bgrpc "github.com/letsencrypt/boulder/grpc"
+	"github.com/letsencrypt/boulder/grpc/affinitybalancer"
 	_ "github.com/letsencrypt/boulder/grpc/noncebalancer" // imported for its init function.
 	"github.com/letsencrypt/boulder/identifier"
 	"github.com/letsencrypt/boulder/issuance"
@@ -69,12 +76,18 @@ const (
 	renewalInfoPath   = "/acme/renewal-info/"
 
 	// Non-ACME paths.
-	getCertPath = "/get/cert/"
-	buildIDPath = "/build"
+	getCertPath             = "/get/cert/"
+	buildIDPath             = "/build"
+	preflightOrderPath      = "/debug/preflight-order"
+	accountKeyAlgorithmPath = "/debug/account-key-algorithm"
+	rateLimitExemptionPath  = "/rate-limit-exemption"
 )
 
 const (
 	headerRetryAfter = "Retry-After"
+	// headerRateLimitDebug is sent, once for each rate limit bucket consulted,
+	// when RateLimitDebugHeaders is enabled.
+	headerRateLimitDebug = "X-RateLimit-Debug"
 	// Our 99th percentile finalize latency is 2.3s. Asking clients to wait 3s
 	// before polling the order to get an updated status means that >99% of
 	// clients will fetch the updated order object exactly once,.
@@ -91,6 +104,12 @@ type WebFrontEndImpl struct {
 	ra rapb.RegistrationAuthorityClient
 	sa sapb.StorageAuthorityReadOnlyClient
 	ee emailpb.ExporterClient
+	// cv, if non-nil, verifies new accounts' contacts. Contacts which it has
+	// found to be invalid are not exported.
+	cv emailpb.ContactVerifierClient
+	// eq, if non-nil, queues subscribers' requests for rate limit overrides,
+	// and the rate-limit-exemption endpoint is served.
+	eq exemptionspb.ExemptionQueueClient
 	// gnc is a nonce-service client used exclusively for the issuance of
 	// nonces. It's configured to route requests to backends colocated with the
 	// WFE.
@@ -149,6 +168,102 @@ type WebFrontEndImpl struct {
 	// requestTimeout is the per-request overall timeout.
 	requestTimeout time.Duration
 
+	// routeTimeouts overrides requestTimeout for specific endpoints, keyed by
+	// the endpoint's path pattern (e.g. "/acme/finalize/").
+	routeTimeouts map[string]time.Duration
+
+	// ValidationWait, if non-zero, is how long a poll of a pending
+	// authorization or challenge waits for the RA to finish a validation of
+	// it which is in progress, so that the result can be returned at once
+	// rather than after the client's next poll.
+	ValidationWait time.Duration
+
+	// DiscardContacts, if set, causes the contacts in new-account and account
+	// update requests to be discarded without being validated, exported, or
+	// verified, and a Warning header to be sent in response to any request
+	// which included some.
+	DiscardContacts bool
+
+	// FinalizeKeepaliveInterval, if non-zero, is how often an interim 102
+	// (Processing) response is sent to HTTP/2 clients while a finalize request
+	// is still being processed.
+	FinalizeKeepaliveInterval time.Duration
+
+	// NoncePrefixRoutes, if non-empty, maps nonce prefixes to the address of
+	// the redemption backend for nonces which carry them. It's consulted when
+	// a prefix can't be derived from any known backend, after which the
+	// backend is chosen by consistent hashing of the prefix. It's passed to
+	// the balancer using the context key `nonce.PrefixRoutesCtxKey`.
+	NoncePrefixRoutes map[string]string
+
+	// RotationNonceKeys, if non-empty, are HMAC keys which nonce backends are
+	// rotating to or from in place of rncKey. The balancer also routes nonces
+	// with prefixes derived from them. They're passed using the context key
+	// `nonce.RotationHMACKeysCtxKey`.
+	RotationNonceKeys [][]byte
+
+	// LogHasher, if set, replaces the identifiers and requesting account ID
+	// in request logs with keyed hashes.
+	LogHasher *web.IdentifierHasher
+
+	// ClientIdentifier, if set, binds new accounts to the identity in the TLS
+	// client certificate with which they're created, and rejects requests
+	// authenticated by those accounts which present a different one.
+	ClientIdentifier *ClientIdentifier
+
+	// ContactPolicy, if set, decides which account contacts are accepted.
+	// Otherwise, only mailto: contacts are.
+	ContactPolicy *ContactPolicy
+
+	// JWSAlgorithms, if set, decides which JWS signature algorithms requests
+	// may be signed with. Otherwise, RSA and ECDSA signatures are accepted.
+	JWSAlgorithms *JWSAlgorithmPolicy
+
+	// Maintenance, if set, holds the endpoints which are temporarily out of
+	// service. Requests to them are rejected with a 503 and a Retry-After.
+	Maintenance *Maintenance
+
+	// ClientTelemetry, if set, decides how clients are labeled in metrics by
+	// their User-Agent, and which client versions are refused. Otherwise,
+	// popular clients are labeled and none are refused.
+	ClientTelemetry *ClientTelemetry
+
+	// ProblemMessages, if set, translates problem documents into the language
+	// each client asks for. Otherwise, they're always in English.
+	ProblemMessages *ProblemMessages
+
+	// ReplayCache, if set, rejects JWS request bodies whose signature was
+	// already redeemed recently, even if their nonce is accepted.
+	ReplayCache *ReplayCache
+
+	// PreflightOrders, if true, serves the Boulder-specific preflight-order
+	// endpoint, which lets accounts check whether an order would be accepted
+	// without creating it.
+	PreflightOrders bool
+
+	// AccountKeyAlgorithms, if true, serves the Boulder-specific
+	// account-key-algorithm endpoint, which reports the algorithm of the
+	// requesting account's key and whether it's deprecated.
+	AccountKeyAlgorithms bool
+
+	// RateLimitDebugHeaders, if true, adds an X-RateLimit-Debug header to
+	// new-account and new-order responses for each rate limit bucket
+	// consulted, describing its remaining capacity and the decision reached.
+	// It exposes bucket keys, so it's only meant for staging environments.
+	RateLimitDebugHeaders bool
+
+	// Directories, if non-empty, are additional ACME directories served
+	// under their own hostnames or path prefixes, each with its own policy.
+	// Requests which match none of them are served by the default directory.
+	Directories []Directory
+
+	// noncePool, if set, supplies the nonces for every response, including
+	// those to GET requests, which otherwise don't carry one.
+	noncePool *noncePool
+	// indexLinkEverywhere adds a Link rel="index" header to responses which
+	// aren't from an ACME endpoint.
+	indexLinkEverywhere bool
+
 	// StaleTimeout determines the required staleness for certificates to be
 	// accessed via the Boulder-specific GET API. Certificates newer than
 	// staleTimeout must be accessed via POST-as-GET and the RFC 8555 ACME API. We
@@ -182,6 +297,8 @@ func NewWebFrontEndImpl(
 	rac rapb.RegistrationAuthorityClient,
 	sac sapb.StorageAuthorityReadOnlyClient,
 	eec emailpb.ExporterClient,
+	cvc emailpb.ContactVerifierClient,
+	eqc exemptionspb.ExemptionQueueClient,
 	gnc nonce.Getter,
 	rnc nonce.Redeemer,
 	rncKey []byte,
@@ -222,6 +339,8 @@ func NewWebFrontEndImpl(
 		ra:                 rac,
 		sa:                 sac,
 		ee:                 eec,
+		cv:                 cvc,
+		eq:                 eqc,
 		gnc:                gnc,
 		rnc:                rnc,
 		rncKey:             rncKey,
@@ -266,7 +385,7 @@ func (wfe *WebFrontEndImpl) HandleFunc(mux *http.ServeMux, pattern string, h web
 		methodsMap["HEAD"] = true
 	}
 	methodsStr := strings.Join(methods, ", ")
-	handler := http.StripPrefix(pattern, web.NewTopHandler(wfe.log,
+	topHandler := web.NewTopHandler(wfe.log,
 		web.WFEHandlerFunc(func(ctx context.Context, logEvent *web.RequestEvent, response http.ResponseWriter, request *http.Request) {
 			span := trace.SpanFromContext(ctx)
 			span.SetName(pattern)
@@ -276,12 +395,14 @@ func (wfe *WebFrontEndImpl) HandleFunc(mux *http.ServeMux, pattern string, h web
 				logEvent.Slug = request.URL.Path
 			}
 			if request.Method != "GET" || pattern == newNoncePath {
-				nonceMsg, err := wfe.gnc.Nonce(ctx, &emptypb.Empty{})
+				nonce, err := wfe.nonce(ctx)
 				if err != nil {
 					wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "unable to get nonce"), err)
 					return
 				}
-				response.Header().Set("Replay-Nonce", nonceMsg.Nonce)
+				response.Header().Set("Replay-Nonce", nonce)
+			} else if wfe.noncePool != nil {
+				wfe.addOptionalNonce(ctx, logEvent, response)
 			}
 			// Per section 7.1 "Resources":
 			//   The "index" link relation is present on all resources other than the
@@ -312,18 +433,104 @@ func (wfe *WebFrontEndImpl) HandleFunc(mux *http.ServeMux, pattern string, h web
 
 			wfe.setCORSHeaders(response, request, "")
 
+			if wfe.Maintenance != nil {
+				if m, ok := wfe.Maintenance.lookup(pattern); ok {
+					response.Header().Set(headerRetryAfter, strconv.Itoa(int(m.RetryAfter.Duration.Seconds())))
+					wfe.sendError(response, logEvent, probs.Maintenance(m.Detail), nil)
+					return
+				}
+			}
+
+			if detail, ok := wfe.clientTelemetry().deprecated(logEvent.UserAgent, pattern); ok {
+				wfe.sendError(response, logEvent, probs.ClientDeprecated(detail), nil)
+				return
+			}
+
 			timeout := wfe.requestTimeout
 			if timeout == 0 {
 				timeout = 5 * time.Minute
 			}
+			if routeTimeout, ok := wfe.routeTimeouts[pattern]; ok {
+				timeout = routeTimeout
+				// The server's write timeout applies to every route; extend or
+				// shorten it to match this route's timeout. This uses the wall
+				// clock because it sets a deadline on the network connection.
+				err := http.NewResponseController(response).SetWriteDeadline(time.Now().Add(timeout + routeWriteGrace))
+				if err != nil {
+					wfe.log.Debugf("Setting write deadline for %s: %s", pattern, err)
+				}
+			}
 			ctx, cancel := context.WithTimeout(ctx, timeout)
 
+			if len(wfe.jwsAlgorithms().deprecated) > 0 {
+				response = &deprecationWarningWriter{ResponseWriter: response, wfe: wfe, logEvent: logEvent}
+			}
+
 			// Call the wrapped handler.
-			h(ctx, logEvent, response, request)
+			if pattern == finalizeOrderPath {
+				withKeepalives(wfe.FinalizeKeepaliveInterval, response, request, func(response http.ResponseWriter) {
+					h(ctx, logEvent, response, request)
+				})
+			} else {
+				h(ctx, logEvent, response, request)
+			}
 			cancel()
 		}),
-	))
-	mux.Handle(pattern, handler)
+	).WithIdentifierHasher(wfe.LogHasher)
+	mux.Handle(pattern, http.StripPrefix(pattern, topHandler))
+}
+
+// nonce returns a fresh nonce, from the prefetch pool if there is one.
+func (wfe *WebFrontEndImpl) nonce(ctx context.Context) (string, error) {
+	if wfe.noncePool != nil {
+		return wfe.noncePool.take(ctx)
+	}
+	nonceMsg, err := wfe.gnc.Nonce(ctx, &emptypb.Empty{})
+	if err != nil {
+		return "", err
+	}
+	return nonceMsg.Nonce, nil
+}
+
+// addOptionalNonce adds a Replay-Nonce header to a response which doesn't
+// require one. If no nonce can be had, the response is sent without.
+func (wfe *WebFrontEndImpl) addOptionalNonce(ctx context.Context, logEvent *web.RequestEvent, response http.ResponseWriter) {
+	nonce, err := wfe.nonce(ctx)
+	if err != nil {
+		logEvent.AddError("unable to get optional nonce: %s", err)
+		return
+	}
+	response.Header().Set("Replay-Nonce", nonce)
+}
+
+// routeWriteGrace is how long after a route's timeout its handler may continue
+// writing a response, so that requests which time out can still be sent a
+// proper error rather than having their connection cut.
+const routeWriteGrace = 5 * time.Second
+
+// endpointPaths are the path patterns of every WFE endpoint handled by
+// HandleFunc.
+var endpointPaths = []string{
+	directoryPath, newNoncePath, newAcctPath, newOrderPath, rolloverPath,
+	revokeCertPath, acctPath, orderPath, authzPath, challengePath,
+	finalizeOrderPath, certPath, renewalInfoPath, getCertPath, buildIDPath,
+	preflightOrderPath, accountKeyAlgorithmPath, rateLimitExemptionPath,
+}
+
+// SetRouteTimeouts overrides the per-request overall timeout for the endpoints
+// with the given path patterns. It returns an error if any pattern isn't the
+// path of a WFE endpoint or any timeout isn't positive.
+func (wfe *WebFrontEndImpl) SetRouteTimeouts(timeouts map[string]time.Duration) error {
+	for pattern, timeout := range timeouts {
+		if !slices.Contains(endpointPaths, pattern) {
+			return fmt.Errorf("unrecognized endpoint %q in route timeouts", pattern)
+		}
+		if timeout <= 0 {
+			return fmt.Errorf("timeout for endpoint %q must be positive", pattern)
+		}
+	}
+	wfe.routeTimeouts = timeouts
+	return nil
 }
 
 func marshalIndent(v interface{}) ([]byte, error) {
@@ -403,6 +610,15 @@ func (wfe *WebFrontEndImpl) relativeDirectory(request *http.Request, directory m
 // Handler returns an http.Handler that uses various functions for
 // various ACME-specified paths.
 func (wfe *WebFrontEndImpl) Handler(stats prometheus.Registerer, oTelHTTPOptions ...otelhttp.Option) http.Handler {
+	if len(wfe.Directories) != 0 {
+		return measured_http.New(wfe.newDirectoryMux(), wfe.clk, stats, oTelHTTPOptions...)
+	}
+	return measured_http.New(wfe.newServeMux(), wfe.clk, stats, oTelHTTPOptions...)
+}
+
+// newServeMux returns a mux which routes each of the ACME and Boulder-specific
+// paths to the WFE's handler for it.
+func (wfe *WebFrontEndImpl) newServeMux() *http.ServeMux {
 	m := http.NewServeMux()
 
 	// POSTable ACME endpoints
@@ -424,6 +640,15 @@ func (wfe *WebFrontEndImpl) Handler(stats prometheus.Registerer, oTelHTTPOptions
 	// Boulder specific endpoints
 	wfe.HandleFunc(m, getCertPath, wfe.Certificate, "GET")
 	wfe.HandleFunc(m, buildIDPath, wfe.BuildID, "GET")
+	if wfe.PreflightOrders {
+		wfe.HandleFunc(m, preflightOrderPath, wfe.PreflightOrder, "POST")
+	}
+	if wfe.AccountKeyAlgorithms {
+		wfe.HandleFunc(m, accountKeyAlgorithmPath, wfe.AccountKeyAlgorithm, "POST")
+	}
+	if wfe.eq != nil {
+		wfe.HandleFunc(m, rateLimitExemptionPath, wfe.RateLimitExemption, "POST")
+	}
 
 	// Endpoint for draft-ietf-acme-ari
 	if features.Get().ServeRenewalInfo {
@@ -434,7 +659,7 @@ func (wfe *WebFrontEndImpl) Handler(stats prometheus.Registerer, oTelHTTPOptions
 	// meaning we can wind up returning 405 when we mean to return 404. See
 	// https://github.com/letsencrypt/boulder/issues/717
 	m.Handle("/", web.NewTopHandler(wfe.log, web.WFEHandlerFunc(wfe.Index)))
-	return measured_http.New(m, wfe.clk, stats, oTelHTTPOptions...)
+	return m
 }
 
 // Method implementations
@@ -448,6 +673,13 @@ func (wfe *WebFrontEndImpl) Index(ctx context.Context, logEvent *web.RequestEven
 	logEvent.Endpoint = "/"
 	logEvent.Slug = request.URL.Path[1:]
 
+	if wfe.noncePool != nil {
+		wfe.addOptionalNonce(ctx, logEvent, response)
+	}
+	if wfe.indexLinkEverywhere {
+		response.Header().Add("Link", link(web.RelativeEndpoint(request, directoryPath), "index"))
+	}
+
 	// http://golang.org/pkg/net/http/#example_ServeMux_Handle
 	// The "/" pattern matches everything, so we need to check
 	// that we're at the root here.
@@ -613,9 +845,15 @@ func (wfe *WebFrontEndImpl) sendError(response http.ResponseWriter, logEvent *we
 	}
 
 	if prob.Type == probs.BadSignatureAlgorithmProblem {
-		prob.Algorithms = getSupportedAlgs()
+		prob.Algorithms = wfe.jwsAlgorithms().algs()
+	}
+	if wfe.jwsAlgorithms().isDeprecated(jose.SignatureAlgorithm(logEvent.JWSAlgorithm)) {
+		prob.DeprecatedAlgorithm = jose.SignatureAlgorithm(logEvent.JWSAlgorithm)
 	}
 
+	family, version := wfe.clientTelemetry().classify(logEvent.UserAgent)
+	wfe.stats.clientErrors.With(prometheus.Labels{"family": family, "version": version, "code": string(prob.Code)}).Inc()
+
 	var bErr *berrors.BoulderError
 	if errors.As(ierr, &bErr) {
 		retryAfterSeconds := int(bErr.RetryAfter.Round(time.Second).Seconds())
@@ -630,6 +868,13 @@ func (wfe *WebFrontEndImpl) sendError(response http.ResponseWriter, logEvent *we
 		response.Header().Add(headerRetryAfter, "60")
 	}
 	wfe.stats.httpErrorCount.With(prometheus.Labels{"type": string(prob.Type)}).Inc()
+	if wfe.ProblemMessages != nil {
+		response.Header().Add("Vary", "Accept-Language")
+		languages := wfe.ProblemMessages.translate(prob, logEvent.AcceptLanguage)
+		if len(languages) > 0 {
+			response.Header().Set("Content-Language", strings.Join(languages, ", "))
+		}
+	}
 	web.SendError(wfe.log, response, logEvent, prob, ierr)
 }
 
@@ -637,11 +882,34 @@ func link(url, relation string) string {
 	return fmt.Sprintf("<%s>;rel=\"%s\"", url, relation)
 }
 
-// contactsToEmails converts a slice of ACME contacts (e.g.
-// "mailto:person@example.com") to a slice of valid email addresses. If any of
-// the contacts contain non-mailto schemes, unparsable addresses, or forbidden
-// mail domains, it returns an error so that we can provide feedback to
-// misconfigured clients.
+// clientTelemetry returns the WFE's ClientTelemetry or, if none is configured,
+// the default.
+func (wfe *WebFrontEndImpl) clientTelemetry() *ClientTelemetry {
+	if wfe.ClientTelemetry == nil {
+		return defaultClientTelemetry
+	}
+	return wfe.ClientTelemetry
+}
+
+// jwsAlgorithms returns the WFE's JWSAlgorithmPolicy or, if none is
+// configured, one which allows RSA and ECDSA signatures.
+func (wfe *WebFrontEndImpl) jwsAlgorithms() *JWSAlgorithmPolicy {
+	if wfe.JWSAlgorithms == nil {
+		return defaultJWSAlgorithmPolicy
+	}
+	return wfe.JWSAlgorithms
+}
+
+// countJWSAlgorithm counts a request signed with alg, labeled with how the
+// JWSAlgorithmPolicy treats it.
+func (wfe *WebFrontEndImpl) countJWSAlgorithm(alg jose.SignatureAlgorithm) {
+	label, status := wfe.jwsAlgorithms().status(alg)
+	wfe.stats.jwsSignatureAlgs.With(prometheus.Labels{"alg": string(label), "status": status}).Inc()
+}
+
+// contactsToEmails converts a slice of ACME contacts to a slice of valid email
+// addresses, according to the WFE's ContactPolicy or, if none is configured,
+// one which allows only mailto: contacts.
 func (wfe *WebFrontEndImpl) contactsToEmails(contacts []string) ([]string, error) {
 	if len(contacts) == 0 {
 		return nil, nil
@@ -651,42 +919,11 @@ func (wfe *WebFrontEndImpl) contactsToEmails(contacts []string) ([]string, error
 		return nil, berrors.MalformedError("too many contacts provided: %d > %d", len(contacts), wfe.maxContactsPerReg)
 	}
 
-	var emails []string
-	for _, contact := range contacts {
-		if contact == "" {
-			return nil, berrors.InvalidEmailError("empty contact")
-		}
-
-		parsed, err := url.Parse(contact)
-		if err != nil {
-			return nil, berrors.InvalidEmailError("unparsable contact")
-		}
-
-		if parsed.Scheme != "mailto" {
-			return nil, berrors.UnsupportedContactError("only contact scheme 'mailto:' is supported")
-		}
-
-		if parsed.RawQuery != "" || contact[len(contact)-1] == '?' {
-			return nil, berrors.InvalidEmailError("contact email contains a question mark")
-		}
-
-		if parsed.Fragment != "" || contact[len(contact)-1] == '#' {
-			return nil, berrors.InvalidEmailError("contact email contains a '#'")
-		}
-
-		if !core.IsASCII(contact) {
-			return nil, berrors.InvalidEmailError("contact email contains non-ASCII characters")
-		}
-
-		err = policy.ValidEmail(parsed.Opaque)
-		if err != nil {
-			return nil, err
-		}
-
-		emails = append(emails, parsed.Opaque)
+	contactPolicy := wfe.ContactPolicy
+	if contactPolicy == nil {
+		contactPolicy = defaultContactPolicy
 	}
-
-	return emails, nil
+	return contactPolicy.contactsToEmails(contacts)
 }
 
 // checkNewAccountLimits checks whether sufficient limit quota exists for the
@@ -695,7 +932,7 @@ func (wfe *WebFrontEndImpl) contactsToEmails(contacts []string) ([]string, error
 // function is returned that can be called to refund the quota if the account
 // creation fails, the func will be nil if any error was encountered during the
 // check.
-func (wfe *WebFrontEndImpl) checkNewAccountLimits(ctx context.Context, ip netip.Addr) (func(), error) {
+func (wfe *WebFrontEndImpl) checkNewAccountLimits(ctx context.Context, response http.ResponseWriter, ip netip.Addr) (func(), error) {
 	txns, err := wfe.txnBuilder.NewAccountLimitTransactions(ip)
 	if err != nil {
 		return nil, fmt.Errorf("building new account limit transactions: %w", err)
@@ -705,6 +942,7 @@ func (wfe *WebFrontEndImpl) checkNewAccountLimits(ctx context.Context, ip netip.
 	if err != nil {
 		return nil, fmt.Errorf("spending new account limits: %w", err)
 	}
+	wfe.addRateLimitDebugHeaders(response, d)
 
 	err = d.Result(wfe.clk.Now())
 	if err != nil {
@@ -719,6 +957,29 @@ func (wfe *WebFrontEndImpl) checkNewAccountLimits(ctx context.Context, ip netip.
 	}, nil
 }
 
+// addRateLimitDebugHeaders adds an X-RateLimit-Debug header to the response for
+// each bucket consulted in reaching d, if RateLimitDebugHeaders is enabled.
+func (wfe *WebFrontEndImpl) addRateLimitDebugHeaders(response http.ResponseWriter, d *ratelimits.Decision) {
+	if !wfe.RateLimitDebugHeaders {
+		return
+	}
+	for _, b := range d.Buckets() {
+		decision := "allowed"
+		if !b.Allowed {
+			decision = "denied"
+		}
+		value := fmt.Sprintf("limit=%s; bucket=%q; remaining=%d; decision=%s",
+			b.Limit, b.BucketKey, b.Remaining, decision)
+		if !b.Allowed {
+			value += fmt.Sprintf("; retry-after=%d", int(b.RetryIn.Round(time.Second).Seconds()))
+		}
+		if !b.Enforced {
+			value += "; enforced=false"
+		}
+		response.Header().Add(headerRateLimitDebug, value)
+	}
+}
+
 // NewAccount is used by clients to submit a new account
 func (wfe *WebFrontEndImpl) NewAccount(
 	ctx context.Context,
@@ -729,7 +990,7 @@ func (wfe *WebFrontEndImpl) NewAccount(
 	// NewAccount uses `validSelfAuthenticatedPOST` instead of
 	// `validPOSTforAccount` because there is no account to authenticate against
 	// until after it is created!
-	body, key, err := wfe.validSelfAuthenticatedPOST(ctx, request)
+	body, key, err := wfe.validSelfAuthenticatedPOST(ctx, request, logEvent)
 	if err != nil {
 		// validSelfAuthenticatedPOST handles its own setting of logEvent.Errors
 		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Unable to validate JWS"), err)
@@ -757,6 +1018,12 @@ func (wfe *WebFrontEndImpl) NewAccount(
 			return
 		}
 
+		err := wfe.checkClientIdentity(ctx, request, acctPB.Id)
+		if err != nil {
+			wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Error checking client identity"), err)
+			return
+		}
+
 		response.Header().Set("Location",
 			web.RelativeEndpoint(request, fmt.Sprintf("%s%d", acctPath, acctPB.Id)))
 		logEvent.Requester = acctPB.Id
@@ -807,12 +1074,25 @@ func (wfe *WebFrontEndImpl) NewAccount(
 		return
 	}
 
-	// Do this extraction now, so that we can reject requests whose contact field
-	// does not contain valid contacts before we actually create the account.
-	emails, err := wfe.contactsToEmails(accountCreateRequest.Contact)
-	if err != nil {
-		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Error validating contact(s)"), nil)
-		return
+	var emails []string
+	if !wfe.discardContacts(response, accountCreateRequest.Contact) {
+		// Do this extraction now, so that we can reject requests whose contact
+		// field does not contain valid contacts before we actually create the
+		// account.
+		emails, err = wfe.contactsToEmails(accountCreateRequest.Contact)
+		if err != nil {
+			wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Error validating contact(s)"), nil)
+			return
+		}
+	}
+
+	var clientIdentity string
+	if wfe.ClientIdentifier != nil {
+		clientIdentity, err = wfe.ClientIdentifier.identify(request)
+		if err != nil {
+			wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Error checking client identity"), err)
+			return
+		}
 	}
 
 	ip, err := extractRequesterIP(request)
@@ -826,7 +1106,7 @@ func (wfe *WebFrontEndImpl) NewAccount(
 		return
 	}
 
-	refundLimits, err := wfe.checkNewAccountLimits(ctx, ip)
+	refundLimits, err := wfe.checkNewAccountLimits(ctx, response, ip)
 	if err != nil {
 		if errors.Is(err, berrors.RateLimit) {
 			wfe.sendError(response, logEvent, probs.RateLimited(err.Error()), err)
@@ -847,8 +1127,9 @@ func (wfe *WebFrontEndImpl) NewAccount(
 
 	// Create corepb.Registration from provided account information
 	reg := corepb.Registration{
-		Agreement: wfe.SubscriberAgreementURL,
-		Key:       keyBytes,
+		Agreement:      wfe.SubscriberAgreementURL,
+		Key:            keyBytes,
+		ClientIdentity: clientIdentity,
 	}
 
 	acctPB, err := wfe.ra.NewRegistration(ctx, &reg)
@@ -905,6 +1186,19 @@ func (wfe *WebFrontEndImpl) NewAccount(
 	}
 	newRegistrationSuccessful = true
 
+	if wfe.cv != nil && len(emails) > 0 {
+		var unverified []string
+		emails, unverified = wfe.checkContactVerifications(ctx, emails)
+		if len(unverified) > 0 {
+			_, err := wfe.cv.VerifyContacts(ctx, &emailpb.VerifyContactsRequest{Emails: unverified})
+			if err != nil {
+				// Verification is best-effort: these contacts will be
+				// verified the next time they're provided.
+				wfe.log.Warningf("Sending contacts for verification: %s", err)
+			}
+		}
+	}
+
 	if wfe.ee != nil && len(emails) > 0 {
 		_, err := wfe.ee.SendContacts(ctx, &emailpb.SendContactsRequest{
 			// Note: We are explicitly using the contacts provided by the
@@ -918,6 +1212,59 @@ func (wfe *WebFrontEndImpl) NewAccount(
 	}
 }
 
+// discardedContactsWarning is sent in a Warning header in response to a request
+// whose contacts were discarded. RFC 9111 Section 5.5: 299 is a persistent
+// warning, with no agent.
+const discardedContactsWarning = `299 - "This server does not keep contact information; the contacts provided were discarded"`
+
+// discardContacts returns true if the WFE is configured to discard contacts,
+// in which case it adds a Warning header to the response if any were provided.
+func (wfe *WebFrontEndImpl) discardContacts(response http.ResponseWriter, contacts []string) bool {
+	if !wfe.DiscardContacts {
+		return false
+	}
+	if len(contacts) > 0 {
+		response.Header().Add("Warning", discardedContactsWarning)
+		wfe.stats.discardedContacts.Add(float64(len(contacts)))
+	}
+	return true
+}
+
+// checkContactVerifications consults the SA for the verification state of the
+// given emails. It returns those which are not known to be invalid, and the
+// subset of those which have never been verified. If the SA can't be reached,
+// all of the emails are returned as unverified.
+func (wfe *WebFrontEndImpl) checkContactVerifications(ctx context.Context, emails []string) ([]string, []string) {
+	hashes := make([][]byte, len(emails))
+	for i, addr := range emails {
+		hashes[i] = email.ContactHash(addr)
+	}
+	resp, err := wfe.sa.GetContactVerifications(ctx, &sapb.ContactHashes{ContactHashes: hashes})
+	if err != nil {
+		wfe.log.Warningf("Getting contact verifications: %s", err)
+		return emails, emails
+	}
+
+	statuses := make(map[string]core.AcmeStatus, len(resp.Verifications))
+	for _, v := range resp.Verifications {
+		statuses[string(v.ContactHash)] = core.AcmeStatus(v.Status)
+	}
+
+	var usable, unverified []string
+	for i, addr := range emails {
+		status, ok := statuses[string(hashes[i])]
+		if status == core.StatusInvalid {
+			wfe.stats.skippedContacts.Inc()
+			continue
+		}
+		usable = append(usable, addr)
+		if !ok {
+			unverified = append(unverified, addr)
+		}
+	}
+	return usable, unverified
+}
+
 // parseRevocation accepts the payload for a revocation request and parses it
 // into both the certificate to be revoked and the requested revocation reason
 // (if any). Returns an error if any of the parsing fails, or if the given cert
@@ -1037,7 +1384,7 @@ func (wfe *WebFrontEndImpl) revokeCertByCertKey(
 	// `validSelfAuthenticatedJWS` similar to new-reg and key rollover.
 	// We do *not* use `validSelfAuthenticatedPOST` here because we've already
 	// read the HTTP request body in `parseJWSRequest` and it is now empty.
-	jwsBody, jwk, prob := wfe.validSelfAuthenticatedJWS(ctx, outerJWS, request)
+	jwsBody, jwk, prob := wfe.validSelfAuthenticatedJWS(ctx, outerJWS, request, logEvent)
 	if prob != nil {
 		return prob
 	}
@@ -1091,15 +1438,16 @@ func (wfe *WebFrontEndImpl) RevokeCertificate(
 	// certificates are authorized to be revoked by the requester
 
 	// Parse the JWS from the HTTP Request
-	jws, err := wfe.parseJWSRequest(request)
+	outerJWS, err := wfe.parseJWSRequest(request)
 	if err != nil {
 		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Unable to validate JWS"), err)
 		return
 	}
 
 	// Figure out which type of authentication this JWS uses
-	authType, err := checkJWSAuthType(jws.Signatures[0].Header)
+	authType, err := jws.CheckAuthType(outerJWS.Signatures[0].Header)
 	if err != nil {
+		err = wfe.joseError(err)
 		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Unable to validate JWS"), err)
 		return
 	}
@@ -1107,10 +1455,10 @@ func (wfe *WebFrontEndImpl) RevokeCertificate(
 	// Handle the revocation request according to how it is authenticated, or if
 	// the authentication type is unknown, error immediately
 	switch authType {
-	case embeddedKeyID:
-		err = wfe.revokeCertBySubscriberKey(ctx, jws, request, logEvent)
-	case embeddedJWK:
-		err = wfe.revokeCertByCertKey(ctx, jws, request, logEvent)
+	case jws.EmbeddedKeyID:
+		err = wfe.revokeCertBySubscriberKey(ctx, outerJWS, request, logEvent)
+	case jws.EmbeddedJWK:
+		err = wfe.revokeCertByCertKey(ctx, outerJWS, request, logEvent)
 	default:
 		err = berrors.MalformedError("Malformed JWS, no KeyID or embedded JWK")
 	}
@@ -1150,7 +1498,7 @@ func (wfe *WebFrontEndImpl) Challenge(
 		wfe.sendError(response, logEvent, probs.Malformed("Invalid authorization ID"), nil)
 		return
 	}
-	authzPB, err := wfe.ra.GetAuthorization(ctx, &rapb.GetAuthorizationRequest{Id: authorizationID})
+	authzPB, err := wfe.getAuthorization(ctx, authorizationID, request.Method != "POST")
 	if err != nil {
 		if errors.Is(err, berrors.NotFound) {
 			wfe.sendError(response, logEvent, probs.NotFound("No such challenge"), nil)
@@ -1238,9 +1586,10 @@ func (wfe *WebFrontEndImpl) prepChallengeForDisplay(
 		challenge.Status = authz.Status
 	}
 
-	// This field is not useful for the client, only internal debugging,
+	// These fields are not useful for the client, only internal debugging,
 	for idx := range challenge.ValidationRecord {
 		challenge.ValidationRecord[idx].ResolverAddrs = nil
+		challenge.ValidationRecord[idx].Fingerprint = ""
 	}
 }
 
@@ -1350,7 +1699,9 @@ func (wfe *WebFrontEndImpl) postChallenge(
 			return
 		}
 
-		authzPB, err = wfe.ra.PerformValidation(ctx, &rapb.PerformValidationRequest{
+		// Send the validation to the RA to which polls of this authorization
+		// send their AwaitValidation requests, so that it can answer them.
+		authzPB, err = wfe.ra.PerformValidation(affinitybalancer.WithKey(ctx, authz.ID), &rapb.PerformValidationRequest{
 			Authz:          authzPB,
 			ChallengeIndex: int64(challengeIndex),
 		})
@@ -1389,10 +1740,12 @@ func (wfe *WebFrontEndImpl) Account(
 	logEvent *web.RequestEvent,
 	response http.ResponseWriter,
 	request *http.Request) {
-	body, _, currAcct, err := wfe.validPOSTForAccount(request, ctx, logEvent)
+	// Deactivated accounts may still fetch their own account object, to follow
+	// the revocation of their certificates.
+	body, _, currAcct, err := wfe.validPOSTForReadOnlyAccount(request, ctx, logEvent)
 	addRequesterHeader(response, logEvent.Requester)
 	if err != nil {
-		// validPOSTForAccount handles its own setting of logEvent.Errors
+		// validPOSTForReadOnlyAccount handles its own setting of logEvent.Errors
 		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Unable to validate JWS"), err)
 		return
 	}
@@ -1419,53 +1772,121 @@ func (wfe *WebFrontEndImpl) Account(
 		// recommended way to fetch the account object in ACMEv1.
 		acct = currAcct
 	} else {
-		acct, err = wfe.updateAccount(ctx, body, currAcct)
+		acct, err = wfe.updateAccount(ctx, response, body, currAcct)
 		if err != nil {
 			wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Unable to update account"), nil)
 			return
 		}
 	}
 
+	respObj := accountJSON{Registration: acct}
+	if acct.Status == core.StatusDeactivated {
+		respObj.CertificateRevocation, err = wfe.getCertificateRevocation(ctx, currAcct.ID)
+		if err != nil {
+			wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Unable to get certificate revocation status"), err)
+			return
+		}
+	}
+
 	if len(wfe.SubscriberAgreementURL) > 0 {
 		response.Header().Add("Link", link(wfe.SubscriberAgreementURL, "terms-of-service"))
 	}
 
 	prepAccountForDisplay(acct)
 
-	err = wfe.writeJsonResponse(response, logEvent, http.StatusOK, acct)
+	err = wfe.writeJsonResponse(response, logEvent, http.StatusOK, respObj)
 	if err != nil {
 		wfe.sendError(response, logEvent, probs.ServerInternal("Failed to marshal account"), err)
 		return
 	}
 }
 
+// accountJSON is an account as returned by the Account handler, with the
+// progress of the revocation of its certificates if it requested that when it
+// was deactivated.
+type accountJSON struct {
+	*core.Registration
+	CertificateRevocation *certificateRevocationJSON `json:"certificateRevocation,omitempty"`
+}
+
+// certificateRevocationJSON describes the revocation of a deactivated
+// account's certificates. Its status is "processing" until every certificate
+// has been tried, and "valid" after.
+type certificateRevocationJSON struct {
+	Status    core.AcmeStatus `json:"status"`
+	Requested time.Time       `json:"requested"`
+	Total     int64           `json:"total"`
+	Revoked   int64           `json:"revoked"`
+	Failed    int64           `json:"failed"`
+	Completed *time.Time      `json:"completed,omitempty"`
+}
+
+// getCertificateRevocation returns the progress of the revocation of the given
+// deactivated account's certificates, or nil if it didn't request that.
+func (wfe *WebFrontEndImpl) getCertificateRevocation(ctx context.Context, regID int64) (*certificateRevocationJSON, error) {
+	progress, err := wfe.sa.GetAccountRevocation(ctx, &sapb.RegistrationID{Id: regID})
+	if err != nil {
+		if errors.Is(err, berrors.NotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	revocation := &certificateRevocationJSON{
+		Status:    core.StatusProcessing,
+		Requested: progress.Requested.AsTime(),
+		Total:     progress.Total,
+		Revoked:   progress.Revoked,
+		Failed:    progress.Failed,
+	}
+	if progress.Completed != nil {
+		completed := progress.Completed.AsTime()
+		revocation.Status = core.StatusValid
+		revocation.Completed = &completed
+	}
+	return revocation, nil
+}
+
 // updateAccount unmarshals an account update request from the provided
 // requestBody to update the given registration. Important: It is assumed the
 // request has already been authenticated by the caller. If the request is a
 // valid update the resulting updated account is returned, otherwise a problem
 // is returned.
-func (wfe *WebFrontEndImpl) updateAccount(ctx context.Context, requestBody []byte, currAcct *core.Registration) (*core.Registration, error) {
+func (wfe *WebFrontEndImpl) updateAccount(ctx context.Context, response http.ResponseWriter, requestBody []byte, currAcct *core.Registration) (*core.Registration, error) {
 	// Only the Status field of an account may be updated this way.
-	// For key updates clients should be using the key change endpoint.
+	// For key updates clients should be using the key change endpoint. When
+	// deactivating, the account may also ask for all of its unexpired
+	// certificates to be revoked; otherwise they're left valid.
 	var accountUpdateRequest struct {
-		Status core.AcmeStatus `json:"status"`
+		Status             core.AcmeStatus `json:"status"`
+		Contact            []string        `json:"contact"`
+		RevokeCertificates bool            `json:"revokeCertificates"`
 	}
 
 	err := json.Unmarshal(requestBody, &accountUpdateRequest)
 	if err != nil {
 		return nil, berrors.MalformedError("parsing account update request: %s", err)
 	}
+	wfe.discardContacts(response, accountUpdateRequest.Contact)
+	if accountUpdateRequest.RevokeCertificates && accountUpdateRequest.Status != core.StatusDeactivated {
+		return nil, berrors.MalformedError("revokeCertificates may only be set when deactivating an account")
+	}
 
 	switch accountUpdateRequest.Status {
 	case core.StatusValid, "":
 		// They probably intended to update their contact address, but we don't do
 		// that anymore, so simply return their account as-is. We don't error out
-		// here because it would break too many clients.
+		// here because it would break too many clients. An empty contact list,
+		// which asks for the account's contacts to be removed (RFC 8555 Section
+		// 7.3.2), succeeds in the same way: accounts have no stored contacts, so
+		// the account returned has none either way.
 		return currAcct, nil
 
 	case core.StatusDeactivated:
-		updatedAcct, err := wfe.ra.DeactivateRegistration(
-			ctx, &rapb.DeactivateRegistrationRequest{RegistrationID: currAcct.ID})
+		updatedAcct, err := wfe.ra.DeactivateRegistration(ctx, &rapb.DeactivateRegistrationRequest{
+			RegistrationID:     currAcct.ID,
+			RevokeCertificates: accountUpdateRequest.RevokeCertificates,
+		})
 		if err != nil {
 			return nil, fmt.Errorf("deactivating account: %w", err)
 		}
@@ -1562,7 +1983,7 @@ func (wfe *WebFrontEndImpl) Authorization(
 		return
 	}
 
-	authzPB, err := wfe.ra.GetAuthorization(ctx, &rapb.GetAuthorizationRequest{Id: authzID})
+	authzPB, err := wfe.getAuthorization(ctx, authzID, string(requestBody) == "")
 	if errors.Is(err, berrors.NotFound) {
 		wfe.sendError(response, logEvent, probs.NotFound("No such authorization"), nil)
 		return
@@ -1627,6 +2048,36 @@ func (wfe *WebFrontEndImpl) Authorization(
 	}
 }
 
+// getAuthorization gets an authorization from the RA. If poll is true and
+// ValidationWait is non-zero, it first asks the RA to wait for the result of a
+// validation of the authorization which is in progress, and returns that if
+// it's recorded in time. Otherwise, whether the wait timed out or failed, the
+// authorization is read from the SA as usual.
+//
+// AwaitValidation carries the same affinity key as the PerformValidation
+// request for the authorization, so that, if the RA client uses the affinity
+// balancer, it reaches the RA which is performing the validation.
+func (wfe *WebFrontEndImpl) getAuthorization(ctx context.Context, authzID int64, poll bool) (*corepb.Authorization, error) {
+	if poll && wfe.ValidationWait > 0 {
+		waitCtx, cancel := context.WithTimeout(affinitybalancer.WithKey(ctx, strconv.FormatInt(authzID, 10)), wfe.ValidationWait)
+		authzPB, err := wfe.ra.AwaitValidation(waitCtx, &rapb.AwaitValidationRequest{AuthzID: authzID})
+		cancel()
+		if err == nil {
+			wfe.stats.validationWaits.WithLabelValues("recorded").Inc()
+			return authzPB, nil
+		}
+		switch {
+		case errors.Is(err, berrors.NotFound):
+			wfe.stats.validationWaits.WithLabelValues("not_in_progress").Inc()
+		case errors.Is(err, context.DeadlineExceeded) || status.Code(err) == codes.DeadlineExceeded:
+			wfe.stats.validationWaits.WithLabelValues("timeout").Inc()
+		default:
+			wfe.stats.validationWaits.WithLabelValues("error").Inc()
+		}
+	}
+	return wfe.ra.GetAuthorization(ctx, &rapb.GetAuthorizationRequest{Id: authzID})
+}
+
 // Certificate is used by clients to request a copy of their current certificate, or to
 // request a reissuance of the certificate.
 func (wfe *WebFrontEndImpl) Certificate(ctx context.Context, logEvent *web.RequestEvent, response http.ResponseWriter, request *http.Request) {
@@ -2028,7 +2479,7 @@ func (wfe *WebFrontEndImpl) orderToOrderJSON(request *http.Request, order *corep
 //
 // Precondition: idents must be a list of identifiers that all pass
 // policy.WellFormedIdentifiers.
-func (wfe *WebFrontEndImpl) checkNewOrderLimits(ctx context.Context, regId int64, idents identifier.ACMEIdentifiers, isRenewal bool) (func(), error) {
+func (wfe *WebFrontEndImpl) checkNewOrderLimits(ctx context.Context, response http.ResponseWriter, regId int64, idents identifier.ACMEIdentifiers, isRenewal bool) (func(), error) {
 	txns, err := wfe.txnBuilder.NewOrderLimitTransactions(regId, idents, isRenewal)
 	if err != nil {
 		return nil, fmt.Errorf("building new order limit transactions: %w", err)
@@ -2038,6 +2489,7 @@ func (wfe *WebFrontEndImpl) checkNewOrderLimits(ctx context.Context, regId int64
 	if err != nil {
 		return nil, fmt.Errorf("spending new order limits: %w", err)
 	}
+	wfe.addRateLimitDebugHeaders(response, d)
 
 	err = d.Result(wfe.clk.Now())
 	if err != nil {
@@ -2052,6 +2504,21 @@ func (wfe *WebFrontEndImpl) checkNewOrderLimits(ctx context.Context, regId int64
 	}, nil
 }
 
+// isRenewal returns true if a certificate for exactly the given identifiers
+// was issued recently enough that a new order for them is a renewal, and thus
+// exempt from the NewOrdersPerAccount and CertificatesPerDomain limits.
+func (wfe *WebFrontEndImpl) isRenewal(ctx context.Context, idents identifier.ACMEIdentifiers) (bool, error) {
+	timestamps, err := wfe.sa.FQDNSetTimestampsForWindow(ctx, &sapb.CountFQDNSetsRequest{
+		Identifiers: idents.ToProtoSlice(),
+		Window:      durationpb.New(120 * 24 * time.Hour),
+		Limit:       1,
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(timestamps.Timestamps) > 0, nil
+}
+
 // orderMatchesReplacement checks if the order matches the provided certificate
 // as identified by the provided ARI CertID. This function ensures that:
 //   - the certificate being replaced exists,
@@ -2339,16 +2806,11 @@ func (wfe *WebFrontEndImpl) NewOrder(
 		// The Subscriber does not have an ARI exemption. However, we can check
 		// if the order is a renewal, and thus exempt from the NewOrdersPerAccount
 		// and CertificatesPerDomain limits.
-		timestamps, err := wfe.sa.FQDNSetTimestampsForWindow(ctx, &sapb.CountFQDNSetsRequest{
-			Identifiers: idents.ToProtoSlice(),
-			Window:      durationpb.New(120 * 24 * time.Hour),
-			Limit:       1,
-		})
+		isRenewal, err = wfe.isRenewal(ctx, idents)
 		if err != nil {
 			wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "While checking renewal exemption status"), err)
 			return
 		}
-		isRenewal = len(timestamps.Timestamps) > 0
 	}
 
 	err = wfe.validateCertificateProfileName(newOrderRequest.Profile)
@@ -2360,7 +2822,7 @@ func (wfe *WebFrontEndImpl) NewOrder(
 
 	var refundLimits func()
 	if !isARIRenewal {
-		refundLimits, err = wfe.checkNewOrderLimits(ctx, acct.ID, idents, isRenewal)
+		refundLimits, err = wfe.checkNewOrderLimits(ctx, response, acct.ID, idents, isRenewal)
 		if err != nil {
 			if errors.Is(err, berrors.RateLimit) {
 				wfe.sendError(response, logEvent, probs.RateLimited(err.Error()), err)
@@ -2549,8 +3011,10 @@ func (wfe *WebFrontEndImpl) FinalizeOrder(ctx context.Context, logEvent *web.Req
 		return
 	}
 
-	// Only ready orders can be finalized.
-	if order.Status != string(core.StatusReady) {
+	// Only ready orders can be finalized. Orders which have already begun
+	// finalization carry the hash of their CSR, and are passed to the RA,
+	// which returns them unchanged if this request repeats the original.
+	if order.Status != string(core.StatusReady) && len(order.CsrHash) == 0 {
 		wfe.sendError(response, logEvent, probs.OrderNotReady(fmt.Sprintf("Order's status (%q) is not acceptable for finalization", order.Status)), nil)
 		return
 	}
@@ -2617,6 +3081,9 @@ func (wfe *WebFrontEndImpl) FinalizeOrder(ctx context.Context, logEvent *web.Req
 		response.Header().Set(headerRetryAfter, strconv.Itoa(orderRetryAfter))
 	}
 
+	family, version := wfe.clientTelemetry().classify(logEvent.UserAgent)
+	wfe.stats.clientFinalizations.With(prometheus.Labels{"family": family, "version": version}).Inc()
+
 	err = wfe.writeJsonResponse(response, logEvent, http.StatusOK, respObj)
 	if err != nil {
 		wfe.sendError(response, logEvent, probs.ServerInternal("Unable to write finalize order response"), err)
@@ -2693,6 +3160,13 @@ func (wfe *WebFrontEndImpl) RenewalInfo(ctx context.Context, logEvent *web.Reque
 		return
 	}
 
+	replaced, err := wfe.sa.ReplacementOrderExists(ctx, &sapb.Serial{Serial: decodedSerial})
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.ServerInternal("Error checking replacement status"), err)
+		return
+	}
+	renewalInfo.Replaced = replaced.GetExists()
+
 	response.Header().Set(headerRetryAfter, fmt.Sprintf("%d", int(6*time.Hour/time.Second)))
 	err = wfe.writeJsonResponse(response, logEvent, http.StatusOK, renewalInfo)
 	if err != nil {
@@ -26,6 +26,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -34,6 +35,8 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/crypto/ocsp"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -41,6 +44,8 @@ import (
 	"github.com/letsencrypt/boulder/config"
 	"github.com/letsencrypt/boulder/core"
 	corepb "github.com/letsencrypt/boulder/core/proto"
+	"github.com/letsencrypt/boulder/email"
+	emailpb "github.com/letsencrypt/boulder/email/proto"
 	berrors "github.com/letsencrypt/boulder/errors"
 	"github.com/letsencrypt/boulder/features"
 	"github.com/letsencrypt/boulder/goodkey"
@@ -188,6 +193,7 @@ type MockRegistrationAuthority struct {
 	rapb.RegistrationAuthorityClient
 	clk                  clock.Clock
 	lastRevocationReason revocation.Reason
+	lastDeactivation     *rapb.DeactivateRegistrationRequest
 }
 
 func (ra *MockRegistrationAuthority) NewRegistration(ctx context.Context, in *corepb.Registration, _ ...grpc.CallOption) (*corepb.Registration, error) {
@@ -204,7 +210,8 @@ func (ra *MockRegistrationAuthority) UpdateRegistrationKey(ctx context.Context,
 	}, nil
 }
 
-func (ra *MockRegistrationAuthority) DeactivateRegistration(context.Context, *rapb.DeactivateRegistrationRequest, ...grpc.CallOption) (*corepb.Registration, error) {
+func (ra *MockRegistrationAuthority) DeactivateRegistration(_ context.Context, in *rapb.DeactivateRegistrationRequest, _ ...grpc.CallOption) (*corepb.Registration, error) {
+	ra.lastDeactivation = in
 	return &corepb.Registration{
 		Status: string(core.StatusDeactivated),
 		Key:    []byte(test1KeyPublicJSON),
@@ -410,9 +417,9 @@ func setupWFE(t *testing.T) (WebFrontEndImpl, clock.FakeClock, requestSigner) {
 	rnc := inmemNonceService
 
 	// Setup rate limiting.
-	limiter, err := ratelimits.NewLimiter(fc, ratelimits.NewInmemSource(), stats)
+	limiter, err := ratelimits.NewLimiter(fc, ratelimits.NewInmemSource(), stats, blog.NewMock())
 	test.AssertNotError(t, err, "making limiter")
-	txnBuilder, err := ratelimits.NewTransactionBuilderFromFiles("../test/config-next/wfe2-ratelimit-defaults.yml", "")
+	txnBuilder, err := ratelimits.NewTransactionBuilderFromFiles("../test/config-next/wfe2-ratelimit-defaults.yml", "", ratelimits.KeyConfig{})
 	test.AssertNotError(t, err, "making transaction composer")
 
 	unpauseSigner, err := unpause.NewJWTSigner(cmd.HMACKeyConfig{KeyFile: "../test/secrets/sfe_unpause_key"})
@@ -432,6 +439,8 @@ func setupWFE(t *testing.T) (WebFrontEndImpl, clock.FakeClock, requestSigner) {
 		&MockRegistrationAuthority{clk: fc},
 		mockSA,
 		nil,
+		nil,
+		nil,
 		gnc,
 		rnc,
 		rncKey,
@@ -498,6 +507,34 @@ func addHeadIfGet(s []string) []string {
 	return s
 }
 
+func TestHandleFuncRouteTimeouts(t *testing.T) {
+	wfe, _, _ := setupWFE(t)
+	wfe.requestTimeout = time.Minute
+
+	err := wfe.SetRouteTimeouts(map[string]time.Duration{"/acme/unknown": time.Second})
+	test.AssertError(t, err, "expected unknown endpoint to be rejected")
+	err = wfe.SetRouteTimeouts(map[string]time.Duration{newNoncePath: 0})
+	test.AssertError(t, err, "expected zero timeout to be rejected")
+	err = wfe.SetRouteTimeouts(map[string]time.Duration{newNoncePath: time.Second})
+	test.AssertNotError(t, err, "setting route timeouts")
+
+	deadlineFor := func(pattern string) time.Duration {
+		var remaining time.Duration
+		mux := http.NewServeMux()
+		wfe.HandleFunc(mux, pattern, func(ctx context.Context, _ *web.RequestEvent, _ http.ResponseWriter, _ *http.Request) {
+			deadline, ok := ctx.Deadline()
+			test.Assert(t, ok, "expected a deadline")
+			remaining = time.Until(deadline)
+		}, "GET")
+		req := &http.Request{Method: "GET", URL: mustParseURL(pattern)}
+		mux.ServeHTTP(httptest.NewRecorder(), req)
+		return remaining
+	}
+
+	test.Assert(t, deadlineFor(newNoncePath) <= time.Second, "expected new-nonce to use its route timeout")
+	test.Assert(t, deadlineFor(directoryPath) > time.Second, "expected directory to use the default timeout")
+}
+
 func TestHandleFunc(t *testing.T) {
 	wfe, _, _ := setupWFE(t)
 	var mux *http.ServeMux
@@ -542,7 +579,7 @@ func TestHandleFunc(t *testing.T) {
 			test.AssertEquals(t, sortHeader(rw.Header().Get("Allow")), sortHeader(strings.Join(addHeadIfGet(c.allowed), ", ")))
 			test.AssertUnmarshaledEquals(t,
 				rw.Body.String(),
-				`{"type":"`+probs.ErrorNS+`malformed","detail":"Method not allowed","status":405}`)
+				`{"type":"`+probs.ErrorNS+`malformed","detail":"Method not allowed","status":405, "code": "methodNotAllowed"}`)
 		}
 		if c.reqMethod == "GET" && c.pattern != newNoncePath {
 			nonce := rw.Header().Get("Replay-Nonce")
@@ -566,7 +603,7 @@ func TestHandleFunc(t *testing.T) {
 	// Disallowed method returns error JSON in body
 	runWrappedHandler(&http.Request{Method: "PUT"}, "/test", "GET", "POST")
 	test.AssertEquals(t, rw.Header().Get("Content-Type"), "application/problem+json")
-	test.AssertUnmarshaledEquals(t, rw.Body.String(), `{"type":"`+probs.ErrorNS+`malformed","detail":"Method not allowed","status":405}`)
+	test.AssertUnmarshaledEquals(t, rw.Body.String(), `{"type":"`+probs.ErrorNS+`malformed","detail":"Method not allowed","status":405, "code": "methodNotAllowed"}`)
 	test.AssertEquals(t, sortHeader(rw.Header().Get("Allow")), "GET, HEAD, POST")
 
 	// Disallowed method special case: response to HEAD has got no body
@@ -580,7 +617,7 @@ func TestHandleFunc(t *testing.T) {
 	test.AssertEquals(t, rw.Code, http.StatusMethodNotAllowed)
 	test.AssertEquals(t, rw.Header().Get("Content-Type"), "application/problem+json")
 	test.AssertEquals(t, rw.Header().Get("Allow"), "POST")
-	test.AssertUnmarshaledEquals(t, rw.Body.String(), `{"type":"`+probs.ErrorNS+`malformed","detail":"Method not allowed","status":405}`)
+	test.AssertUnmarshaledEquals(t, rw.Body.String(), `{"type":"`+probs.ErrorNS+`malformed","detail":"Method not allowed","status":405, "code": "methodNotAllowed"}`)
 
 	wfe.AllowOrigins = []string{"*"}
 	testOrigin := "https://example.com"
@@ -1220,25 +1257,25 @@ func TestChallengeHandler(t *testing.T) {
 			Name:           "Expired challenge",
 			Request:        post("1/3/7TyhFQ"),
 			ExpectedStatus: http.StatusNotFound,
-			ExpectedBody:   `{"type":"` + probs.ErrorNS + `malformed","detail":"Expired authorization","status":404}`,
+			ExpectedBody:   `{"type":"` + probs.ErrorNS + `malformed","detail":"Expired authorization","status":404, "code": "notFound"}`,
 		},
 		{
 			Name:           "Missing challenge",
 			Request:        post("1/1/"),
 			ExpectedStatus: http.StatusNotFound,
-			ExpectedBody:   `{"type":"` + probs.ErrorNS + `malformed","detail":"No such challenge","status":404}`,
+			ExpectedBody:   `{"type":"` + probs.ErrorNS + `malformed","detail":"No such challenge","status":404, "code": "notFound"}`,
 		},
 		{
 			Name:           "Unspecified database error",
 			Request:        post("1/4/7TyhFQ"),
 			ExpectedStatus: http.StatusInternalServerError,
-			ExpectedBody:   `{"type":"` + probs.ErrorNS + `serverInternal","detail":"Problem getting authorization","status":500}`,
+			ExpectedBody:   `{"type":"` + probs.ErrorNS + `serverInternal","detail":"Problem getting authorization","status":500, "code": "serverInternal"}`,
 		},
 		{
 			Name:           "POST-as-GET, wrong owner",
 			Request:        postAsGet(1, "1/5/7TyhFQ", ""),
 			ExpectedStatus: http.StatusForbidden,
-			ExpectedBody:   `{"type":"` + probs.ErrorNS + `unauthorized","detail":"User account ID doesn't match account ID in authorization","status":403}`,
+			ExpectedBody:   `{"type":"` + probs.ErrorNS + `unauthorized","detail":"User account ID doesn't match account ID in authorization","status":403, "code": "unauthorized"}`,
 		},
 		{
 			Name:           "Valid POST-as-GET",
@@ -1317,7 +1354,8 @@ func TestUpdateChallengeHandlerRAError(t *testing.T) {
 	test.AssertUnmarshaledEquals(t, body, `{
 		"type": "urn:ietf:params:acme:error:serverInternal",
 	  "detail": "Unable to update challenge",
-		"status": 500
+		"status": 500,
+		"code": "serverInternal"
 	}`)
 }
 
@@ -1342,7 +1380,7 @@ func TestBadNonce(t *testing.T) {
 	test.AssertNotError(t, err, "Failed to sign body")
 	wfe.NewAccount(ctx, newRequestEvent(), responseWriter,
 		makePostRequestWithPath("nonce", result.FullSerialize()))
-	test.AssertUnmarshaledEquals(t, responseWriter.Body.String(), `{"type":"`+probs.ErrorNS+`badNonce","detail":"Unable to validate JWS :: JWS has no anti-replay nonce","status":400}`)
+	test.AssertUnmarshaledEquals(t, responseWriter.Body.String(), `{"type":"`+probs.ErrorNS+`badNonce","detail":"Unable to validate JWS :: JWS has no anti-replay nonce","status":400, "code": "badNonce"}`)
 }
 
 func TestNewECDSAAccount(t *testing.T) {
@@ -1519,19 +1557,19 @@ func TestNewAccount(t *testing.T) {
 					"Content-Type":   {expectedJWSContentType},
 				},
 			},
-			`{"type":"` + probs.ErrorNS + `malformed","detail":"Unable to validate JWS :: No body on POST","status":400}`,
+			`{"type":"` + probs.ErrorNS + `malformed","detail":"Unable to validate JWS :: No body on POST","status":400, "code": "malformed"}`,
 		},
 
 		// POST, but body that isn't valid JWS
 		{
 			makePostRequestWithPath(newAcctPath, "hi"),
-			`{"type":"` + probs.ErrorNS + `malformed","detail":"Unable to validate JWS :: Parse error reading JWS","status":400}`,
+			`{"type":"` + probs.ErrorNS + `malformed","detail":"Unable to validate JWS :: Parse error reading JWS","status":400, "code": "malformed"}`,
 		},
 
 		// POST, Properly JWS-signed, but payload is "foo", not base64-encoded JSON.
 		{
 			makePostRequestWithPath(newAcctPath, fooBody),
-			`{"type":"` + probs.ErrorNS + `malformed","detail":"Unable to validate JWS :: Request payload did not parse as JSON","status":400}`,
+			`{"type":"` + probs.ErrorNS + `malformed","detail":"Unable to validate JWS :: Request payload did not parse as JSON","status":400, "code": "malformed"}`,
 		},
 
 		// Same signed body, but payload modified by one byte, breaking signature.
@@ -1539,11 +1577,11 @@ func TestNewAccount(t *testing.T) {
 		{
 			makePostRequestWithPath(newAcctPath,
 				`{"payload":"Zm9x","protected":"eyJhbGciOiJSUzI1NiIsImp3ayI6eyJrdHkiOiJSU0EiLCJuIjoicW5BUkxyVDdYejRnUmNLeUxkeWRtQ3ItZXk5T3VQSW1YNFg0MHRoazNvbjI2RmtNem5SM2ZSanM2NmVMSzdtbVBjQlo2dU9Kc2VVUlU2d0FhWk5tZW1vWXgxZE12cXZXV0l5aVFsZUhTRDdROHZCcmhSNnVJb080akF6SlpSLUNoelp1U0R0N2lITi0zeFVWc3B1NVhHd1hVX01WSlpzaFR3cDRUYUZ4NWVsSElUX09iblR2VE9VM1hoaXNoMDdBYmdaS21Xc1ZiWGg1cy1DcklpY1U0T2V4SlBndW5XWl9ZSkp1ZU9LbVR2bkxsVFY0TXpLUjJvWmxCS1oyN1MwLVNmZFZfUUR4X3lkbGU1b01BeUtWdGxBVjM1Y3lQTUlzWU53Z1VHQkNkWV8yVXppNWVYMGxUYzdNUFJ3ejZxUjFraXAtaTU5VmNHY1VRZ3FIVjZGeXF3IiwiZSI6IkFRQUIifSwia2lkIjoiIiwibm9uY2UiOiJyNHpuenZQQUVwMDlDN1JwZUtYVHhvNkx3SGwxZVBVdmpGeXhOSE1hQnVvIiwidXJsIjoiaHR0cDovL2xvY2FsaG9zdC9hY21lL25ldy1yZWcifQ","signature":"jcTdxSygm_cvD7KbXqsxgnoPApCTSkV4jolToSOd2ciRkg5W7Yl0ZKEEKwOc-dYIbQiwGiDzisyPCicwWsOUA1WSqHylKvZ3nxSMc6KtwJCW2DaOqcf0EEjy5VjiZJUrOt2c-r6b07tbn8sfOJKwlF2lsOeGi4s-rtvvkeQpAU-AWauzl9G4bv2nDUeCviAZjHx_PoUC-f9GmZhYrbDzAvXZ859ktM6RmMeD0OqPN7bhAeju2j9Gl0lnryZMtq2m0J2m1ucenQBL1g4ZkP1JiJvzd2cAz5G7Ftl2YeJJyWhqNd3qq0GVOt1P11s8PTGNaSoM0iR9QfUxT9A6jxARtg"}`),
-			`{"type":"` + probs.ErrorNS + `malformed","detail":"Unable to validate JWS :: JWS verification error","status":400}`,
+			`{"type":"` + probs.ErrorNS + `malformed","detail":"Unable to validate JWS :: JWS verification error","status":400, "code": "malformed"}`,
 		},
 		{
 			makePostRequestWithPath(newAcctPath, wrongAgreementBody),
-			`{"type":"` + probs.ErrorNS + `malformed","detail":"must agree to terms of service","status":400}`,
+			`{"type":"` + probs.ErrorNS + `malformed","detail":"must agree to terms of service","status":400, "code": "malformed"}`,
 		},
 	}
 	for _, rt := range acctErrTests {
@@ -1774,7 +1812,7 @@ func TestGetAuthorizationHandler(t *testing.T) {
 	})
 	test.AssertEquals(t, responseWriter.Code, http.StatusNotFound)
 	test.AssertUnmarshaledEquals(t, responseWriter.Body.String(),
-		`{"type":"`+probs.ErrorNS+`malformed","detail":"Expired authorization","status":404}`)
+		`{"type":"`+probs.ErrorNS+`malformed","detail":"Expired authorization","status":404, "code": "notFound"}`)
 	responseWriter.Body.Reset()
 
 	// Ensure that a valid authorization can't be reached with an invalid URL
@@ -1783,7 +1821,7 @@ func TestGetAuthorizationHandler(t *testing.T) {
 		Method: "GET",
 	})
 	test.AssertUnmarshaledEquals(t, responseWriter.Body.String(),
-		`{"type":"`+probs.ErrorNS+`malformed","detail":"Invalid authorization ID","status":400}`)
+		`{"type":"`+probs.ErrorNS+`malformed","detail":"Invalid authorization ID","status":400, "code": "malformed"}`)
 
 	_, _, jwsBody := signer.byKeyID(1, nil, "http://localhost/1/1", "")
 	postAsGet := makePostRequestWithPath("1/1", jwsBody)
@@ -1825,7 +1863,8 @@ func TestAuthorizationHandler500(t *testing.T) {
 	expected := `{
          "type": "urn:ietf:params:acme:error:serverInternal",
 				 "detail": "Problem getting authorization",
-				 "status": 500
+				 "status": 500,
+				 "code": "serverInternal"
   }`
 	test.AssertUnmarshaledEquals(t, responseWriter.Body.String(), expected)
 }
@@ -1893,14 +1932,14 @@ func TestAccount(t *testing.T) {
 	})
 	test.AssertUnmarshaledEquals(t,
 		responseWriter.Body.String(),
-		`{"type":"`+probs.ErrorNS+`malformed","detail":"Method not allowed","status":405}`)
+		`{"type":"`+probs.ErrorNS+`malformed","detail":"Method not allowed","status":405, "code": "methodNotAllowed"}`)
 	responseWriter.Body.Reset()
 
 	// Test POST invalid JSON
 	wfe.Account(ctx, newRequestEvent(), responseWriter, makePostRequestWithPath("2", "invalid"))
 	test.AssertUnmarshaledEquals(t,
 		responseWriter.Body.String(),
-		`{"type":"`+probs.ErrorNS+`malformed","detail":"Unable to validate JWS :: Parse error reading JWS","status":400}`)
+		`{"type":"`+probs.ErrorNS+`malformed","detail":"Unable to validate JWS :: Parse error reading JWS","status":400, "code": "malformed"}`)
 	responseWriter.Body.Reset()
 
 	key := loadKey(t, []byte(test2KeyPrivatePEM))
@@ -1918,7 +1957,7 @@ func TestAccount(t *testing.T) {
 	wfe.Account(ctx, newRequestEvent(), responseWriter, request)
 	test.AssertUnmarshaledEquals(t,
 		responseWriter.Body.String(),
-		`{"type":"`+probs.ErrorNS+`accountDoesNotExist","detail":"Unable to validate JWS :: Account \"http://localhost/acme/acct/102\" not found","status":400}`)
+		`{"type":"`+probs.ErrorNS+`accountDoesNotExist","detail":"Unable to validate JWS :: Account \"http://localhost/acme/acct/102\" not found","status":400, "code": "accountDoesNotExist"}`)
 	responseWriter.Body.Reset()
 
 	key = loadKey(t, []byte(test1KeyPrivatePEM))
@@ -1972,7 +2011,8 @@ func TestAccount(t *testing.T) {
 	test.AssertUnmarshaledEquals(t, responseWriter.Body.String(), `{
 		"type": "urn:ietf:params:acme:error:unauthorized",
 		"detail": "Request signing key did not match account key",
-		"status": 403
+		"status": 403,
+		"code": "unauthorized"
 	}`)
 }
 
@@ -1996,11 +2036,21 @@ func TestUpdateAccount(t *testing.T) {
 			req:      `{"contact": ["mailto:admin@example.com"]}`,
 			wantAcct: &core.Registration{Status: core.StatusValid},
 		},
+		{
+			name:     "empty status removing contacts",
+			req:      `{"contact": []}`,
+			wantAcct: &core.Registration{Status: core.StatusValid},
+		},
 		{
 			name:     "valid",
 			req:      `{"status": "valid"}`,
 			wantAcct: &core.Registration{Status: core.StatusValid},
 		},
+		{
+			name:     "valid removing contacts",
+			req:      `{"status": "valid", "contact": []}`,
+			wantAcct: &core.Registration{Status: core.StatusValid},
+		},
 		{
 			name:     "valid with contact",
 			req:      `{"status": "valid", "contact": ["mailto:admin@example.com"]}`,
@@ -2034,7 +2084,7 @@ func TestUpdateAccount(t *testing.T) {
 
 			currAcct := core.Registration{Status: core.StatusValid}
 
-			gotAcct, gotProb := wfe.updateAccount(context.Background(), []byte(tc.req), &currAcct)
+			gotAcct, gotProb := wfe.updateAccount(context.Background(), httptest.NewRecorder(), []byte(tc.req), &currAcct)
 			if tc.wantAcct != nil {
 				if gotAcct.Status != tc.wantAcct.Status {
 					t.Errorf("want status %s, got %s", tc.wantAcct.Status, gotAcct.Status)
@@ -2057,14 +2107,24 @@ func TestUpdateAccount(t *testing.T) {
 
 type mockSAWithCert struct {
 	sapb.StorageAuthorityReadOnlyClient
-	cert   *x509.Certificate
-	status core.OCSPStatus
+	cert     *x509.Certificate
+	status   core.OCSPStatus
+	replaced bool
 }
 
 func newMockSAWithCert(t *testing.T, sa sapb.StorageAuthorityReadOnlyClient) *mockSAWithCert {
 	cert, err := core.LoadCert("../test/hierarchy/ee-r3.cert.pem")
 	test.AssertNotError(t, err, "Failed to load test cert")
-	return &mockSAWithCert{sa, cert, core.OCSPStatusGood}
+	return &mockSAWithCert{sa, cert, core.OCSPStatusGood, false}
+}
+
+// ReplacementOrderExists returns the mock SA's replaced flag, if the given
+// serial matches. Otherwise, returns false.
+func (sa *mockSAWithCert) ReplacementOrderExists(_ context.Context, req *sapb.Serial, _ ...grpc.CallOption) (*sapb.Exists, error) {
+	if req.Serial != core.SerialToString(sa.cert.SerialNumber) {
+		return &sapb.Exists{Exists: false}, nil
+	}
+	return &sapb.Exists{Exists: sa.replaced}, nil
 }
 
 // GetCertificate returns the mock SA's hard-coded certificate, issued by the
@@ -2160,7 +2220,7 @@ func TestGetCertificate(t *testing.T) {
 	reqPath := fmt.Sprintf("/acme/cert/%s", core.SerialToString(cert.SerialNumber))
 	pkixContent := "application/pem-certificate-chain"
 	noCache := "public, max-age=0, no-cache"
-	notFound := `{"type":"` + probs.ErrorNS + `malformed","detail":"Certificate not found","status":404}`
+	notFound := `{"type":"` + probs.ErrorNS + `malformed","detail":"Certificate not found","status":404, "code": "notFound"}`
 
 	testCases := []struct {
 		Name            string
@@ -2198,6 +2258,7 @@ func TestGetCertificate(t *testing.T) {
 			ExpectedBody: `{
 				"type": "urn:ietf:params:acme:error:malformed",
 				"status": 400,
+				"code": "malformed",
 				"detail": "Unable to validate JWS :: POST-as-GET requests must have an empty payload"
 			}`,
 		},
@@ -2208,6 +2269,7 @@ func TestGetCertificate(t *testing.T) {
 			ExpectedBody: `{
 				"type": "urn:ietf:params:acme:error:unauthorized",
 				"status": 403,
+				"code": "unauthorized",
 				"detail": "Account in use did not issue specified certificate"
 			}`,
 		},
@@ -2253,13 +2315,13 @@ func TestGetCertificate(t *testing.T) {
 			Name:           "Valid serial (explicit non-existent alternate chain)",
 			Request:        makeGet(reqPath + "/2"),
 			ExpectedStatus: http.StatusNotFound,
-			ExpectedBody:   `{"type":"` + probs.ErrorNS + `malformed","detail":"Unknown issuance chain","status":404}`,
+			ExpectedBody:   `{"type":"` + probs.ErrorNS + `malformed","detail":"Unknown issuance chain","status":404, "code": "notFound"}`,
 		},
 		{
 			Name:           "Valid serial (explicit negative alternate chain)",
 			Request:        makeGet(reqPath + "/-1"),
 			ExpectedStatus: http.StatusBadRequest,
-			ExpectedBody:   `{"type":"` + probs.ErrorNS + `malformed","detail":"Chain ID must be a non-negative integer","status":400}`,
+			ExpectedBody:   `{"type":"` + probs.ErrorNS + `malformed","detail":"Chain ID must be a non-negative integer","status":400, "code": "malformed"}`,
 		},
 	}
 
@@ -2418,7 +2480,8 @@ func TestGetCertificateNew(t *testing.T) {
 			ExpectedBody: `{
 				"type": "` + probs.ErrorNS + `unauthorized",
 				"detail": "Certificate is too new for GET API. You should only use this non-standard API to access resources created more than 10s ago",
-				"status": 403
+				"status": 403,
+				"code": "unauthorized"
 			}`,
 		},
 		{
@@ -2549,6 +2612,7 @@ func TestGetCertificateServerError(t *testing.T) {
 	body := `{
 		"type": "urn:ietf:params:acme:error:serverInternal",
 		"status": 500,
+		"code": "serverInternal",
 		"detail": "Failed to retrieve certificate"
 	}`
 	test.AssertUnmarshaledEquals(t, responseWriter.Body.String(), body)
@@ -2597,7 +2661,7 @@ func TestDeactivateAuthorizationHandler(t *testing.T) {
 	wfe.AuthorizationHandler(ctx, newRequestEvent(), responseWriter, request)
 	test.AssertUnmarshaledEquals(t,
 		responseWriter.Body.String(),
-		`{"type": "`+probs.ErrorNS+`malformed","detail": "Invalid status value","status": 400}`)
+		`{"type": "`+probs.ErrorNS+`malformed","detail": "Invalid status value","status": 400, "code": "malformed"}`)
 
 	responseWriter.Body.Reset()
 	payload = `{"status":"deactivated"}`
@@ -2639,7 +2703,7 @@ func TestDeactivateAccount(t *testing.T) {
 	wfe.Account(ctx, newRequestEvent(), responseWriter, request)
 	test.AssertUnmarshaledEquals(t,
 		responseWriter.Body.String(),
-		`{"type": "`+probs.ErrorNS+`malformed","detail": "Unable to update account :: invalid status \"asd\" for account update request, must be \"valid\" or \"deactivated\"","status": 400}`)
+		`{"type": "`+probs.ErrorNS+`malformed","detail": "Unable to update account :: invalid status \"asd\" for account update request, must be \"valid\" or \"deactivated\"","status": 400, "code": "malformed"}`)
 
 	responseWriter.Body.Reset()
 	payload = `{"status":"deactivated"}`
@@ -2692,10 +2756,107 @@ func TestDeactivateAccount(t *testing.T) {
 		`{
 		  "type": "`+probs.ErrorNS+`unauthorized",
 		  "detail": "Unable to validate JWS :: Account is not valid, has status \"deactivated\"",
-		  "status": 403
+		  "status": 403,
+		  "code": "unauthorized"
 		}`)
 }
 
+// mockSAWithAccountRevocation returns the same certificate revocation progress
+// for every account.
+type mockSAWithAccountRevocation struct {
+	sapb.StorageAuthorityReadOnlyClient
+	progress *sapb.AccountRevocation
+}
+
+func (sa *mockSAWithAccountRevocation) GetAccountRevocation(_ context.Context, _ *sapb.RegistrationID, _ ...grpc.CallOption) (*sapb.AccountRevocation, error) {
+	return sa.progress, nil
+}
+
+func TestDeactivateAccountRevokingCertificates(t *testing.T) {
+	wfe, fc, signer := setupWFE(t)
+	ra := wfe.ra.(*MockRegistrationAuthority)
+
+	// Certificates can only be revoked by deactivating the account.
+	responseWriter := httptest.NewRecorder()
+	_, _, body := signer.byKeyID(1, nil, "http://localhost/1", `{"status":"valid","revokeCertificates":true}`)
+	wfe.Account(ctx, newRequestEvent(), responseWriter, makePostRequestWithPath("1", body))
+	test.AssertUnmarshaledEquals(t,
+		responseWriter.Body.String(),
+		`{"type": "`+probs.ErrorNS+`malformed","detail": "Unable to update account :: revokeCertificates may only be set when deactivating an account","status": 400, "code": "malformed"}`)
+	test.AssertBoxedNil(t, ra.lastDeactivation, "account shouldn't have been deactivated")
+
+	// Certificates are left valid unless their revocation is requested.
+	responseWriter = httptest.NewRecorder()
+	_, _, body = signer.byKeyID(1, nil, "http://localhost/1", `{"status":"deactivated"}`)
+	wfe.Account(ctx, newRequestEvent(), responseWriter, makePostRequestWithPath("1", body))
+	test.AssertEquals(t, responseWriter.Code, http.StatusOK)
+	test.Assert(t, !ra.lastDeactivation.RevokeCertificates, "certificates shouldn't have been revoked")
+
+	responseWriter = httptest.NewRecorder()
+	_, _, body = signer.byKeyID(1, nil, "http://localhost/1", `{"status":"deactivated","revokeCertificates":true}`)
+	wfe.Account(ctx, newRequestEvent(), responseWriter, makePostRequestWithPath("1", body))
+	test.AssertEquals(t, responseWriter.Code, http.StatusOK)
+	test.AssertEquals(t, ra.lastDeactivation.RegistrationID, int64(1))
+	test.Assert(t, ra.lastDeactivation.RevokeCertificates, "certificates should have been revoked")
+
+	// A deactivated account can still fetch its account object, which shows
+	// the progress of the revocation.
+	key := loadKey(t, []byte(test3KeyPrivatePEM))
+	requested := fc.Now()
+	mockSA := &mockSAWithAccountRevocation{
+		StorageAuthorityReadOnlyClient: wfe.sa,
+		progress: &sapb.AccountRevocation{
+			RegistrationID: 3,
+			Requested:      timestamppb.New(requested),
+			Total:          3,
+			Revoked:        1,
+		},
+	}
+	wfe.sa = mockSA
+	responseWriter = httptest.NewRecorder()
+	_, _, body = signer.byKeyID(3, key, "http://localhost/3", "")
+	wfe.Account(ctx, newRequestEvent(), responseWriter, makePostRequestWithPath("3", body))
+	test.AssertEquals(t, responseWriter.Code, http.StatusOK)
+	var acct struct {
+		Status                core.AcmeStatus
+		CertificateRevocation certificateRevocationJSON
+	}
+	err := json.Unmarshal(responseWriter.Body.Bytes(), &acct)
+	test.AssertNotError(t, err, "unmarshalling account")
+	test.AssertEquals(t, acct.Status, core.StatusDeactivated)
+	test.AssertDeepEquals(t, acct.CertificateRevocation, certificateRevocationJSON{
+		Status:    core.StatusProcessing,
+		Requested: requested,
+		Total:     3,
+		Revoked:   1,
+	})
+
+	completed := requested.Add(time.Minute)
+	mockSA.progress.Revoked = 2
+	mockSA.progress.Failed = 1
+	mockSA.progress.Completed = timestamppb.New(completed)
+	responseWriter = httptest.NewRecorder()
+	_, _, body = signer.byKeyID(3, key, "http://localhost/3", "")
+	wfe.Account(ctx, newRequestEvent(), responseWriter, makePostRequestWithPath("3", body))
+	test.AssertEquals(t, responseWriter.Code, http.StatusOK)
+	err = json.Unmarshal(responseWriter.Body.Bytes(), &acct)
+	test.AssertNotError(t, err, "unmarshalling account")
+	test.AssertDeepEquals(t, acct.CertificateRevocation, certificateRevocationJSON{
+		Status:    core.StatusValid,
+		Requested: requested,
+		Total:     3,
+		Revoked:   2,
+		Failed:    1,
+		Completed: &completed,
+	})
+
+	// But it can't do anything else with it.
+	responseWriter = httptest.NewRecorder()
+	_, _, body = signer.byKeyID(3, key, "http://localhost/3", "{}")
+	wfe.Account(ctx, newRequestEvent(), responseWriter, makePostRequestWithPath("3", body))
+	test.AssertEquals(t, responseWriter.Code, http.StatusForbidden)
+}
+
 func TestNewOrder(t *testing.T) {
 	wfe, _, signer := setupWFE(t)
 	responseWriter := httptest.NewRecorder()
@@ -2772,52 +2933,52 @@ func TestNewOrder(t *testing.T) {
 					"Content-Type":   {expectedJWSContentType},
 				},
 			},
-			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Unable to validate JWS :: No body on POST","status":400}`,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Unable to validate JWS :: No body on POST","status":400, "code": "malformed"}`,
 		},
 		{
 			Name:         "POST, with an invalid JWS body",
 			Request:      makePostRequestWithPath("hi", "hi"),
-			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Unable to validate JWS :: Parse error reading JWS","status":400}`,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Unable to validate JWS :: Parse error reading JWS","status":400, "code": "malformed"}`,
 		},
 		{
 			Name:         "POST, properly signed JWS, payload isn't valid",
 			Request:      signAndPost(signer, targetPath, signedURL, "foo"),
-			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Unable to validate JWS :: Request payload did not parse as JSON","status":400}`,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Unable to validate JWS :: Request payload did not parse as JSON","status":400, "code": "malformed"}`,
 		},
 		{
 			Name:         "POST, empty DNS identifier",
 			Request:      signAndPost(signer, targetPath, signedURL, `{"identifiers":[{"type":"dns","value":""}]}`),
-			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"NewOrder request included empty identifier","status":400}`,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"NewOrder request included empty identifier","status":400, "code": "malformed"}`,
 		},
 		{
 			Name:         "POST, empty IP identifier",
 			Request:      signAndPost(signer, targetPath, signedURL, `{"identifiers":[{"type":"ip","value":""}]}`),
-			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"NewOrder request included empty identifier","status":400}`,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"NewOrder request included empty identifier","status":400, "code": "malformed"}`,
 		},
 		{
 			Name:         "POST, invalid DNS identifier",
 			Request:      signAndPost(signer, targetPath, signedURL, `{"identifiers":[{"type":"dns","value":"example.invalid"}]}`),
-			ExpectedBody: `{"type":"` + probs.ErrorNS + `rejectedIdentifier","detail":"Invalid identifiers requested :: Cannot issue for \"example.invalid\": Domain name does not end with a valid public suffix (TLD)","status":400}`,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `rejectedIdentifier","detail":"Invalid identifiers requested :: Cannot issue for \"example.invalid\": Domain name does not end with a valid public suffix (TLD)","status":400, "code": "rejectedIdentifier"}`,
 		},
 		{
 			Name:         "POST, invalid IP identifier",
 			Request:      signAndPost(signer, targetPath, signedURL, `{"identifiers":[{"type":"ip","value":"127.0.0.0.0.0.0.1"}]}`),
-			ExpectedBody: `{"type":"` + probs.ErrorNS + `rejectedIdentifier","detail":"Invalid identifiers requested :: Cannot issue for \"127.0.0.0.0.0.0.1\": IP address is invalid","status":400}`,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `rejectedIdentifier","detail":"Invalid identifiers requested :: Cannot issue for \"127.0.0.0.0.0.0.1\": IP address is invalid","status":400, "code": "rejectedIdentifier"}`,
 		},
 		{
 			Name:         "POST, no identifiers in payload",
 			Request:      signAndPost(signer, targetPath, signedURL, "{}"),
-			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"NewOrder request did not specify any identifiers","status":400}`,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"NewOrder request did not specify any identifiers","status":400, "code": "malformed"}`,
 		},
 		{
 			Name:         "POST, invalid identifier type in payload",
 			Request:      signAndPost(signer, targetPath, signedURL, invalidIdentifierBody),
-			ExpectedBody: `{"type":"` + probs.ErrorNS + `unsupportedIdentifier","detail":"NewOrder request included unsupported identifier: type \"fakeID\", value \"www.i-am-21.com\"","status":400}`,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `unsupportedIdentifier","detail":"NewOrder request included unsupported identifier: type \"fakeID\", value \"www.i-am-21.com\"","status":400, "code": "unsupportedIdentifier"}`,
 		},
 		{
 			Name:         "POST, notAfter and notBefore in payload",
 			Request:      signAndPost(signer, targetPath, signedURL, `{"identifiers":[{"type": "dns", "value": "not-example.com"}], "notBefore":"now", "notAfter": "later"}`),
-			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"NotBefore and NotAfter are not supported","status":400}`,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"NotBefore and NotAfter are not supported","status":400, "code": "malformed"}`,
 		},
 		{
 			Name:    "POST, good payload, all names too long to fit in CN",
@@ -2947,27 +3108,27 @@ func TestFinalizeOrder(t *testing.T) {
 					"Content-Type":   {expectedJWSContentType},
 				},
 			},
-			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Unable to validate JWS :: No body on POST","status":400}`,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Unable to validate JWS :: No body on POST","status":400, "code": "malformed"}`,
 		},
 		{
 			Name:         "POST, with an invalid JWS body",
 			Request:      makePostRequestWithPath(targetPath, "hi"),
-			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Unable to validate JWS :: Parse error reading JWS","status":400}`,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Unable to validate JWS :: Parse error reading JWS","status":400, "code": "malformed"}`,
 		},
 		{
 			Name:         "POST, properly signed JWS, payload isn't valid",
 			Request:      signAndPost(signer, targetPath, signedURL, "foo"),
-			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Unable to validate JWS :: Request payload did not parse as JSON","status":400}`,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Unable to validate JWS :: Request payload did not parse as JSON","status":400, "code": "malformed"}`,
 		},
 		{
 			Name:         "Invalid path",
 			Request:      signAndPost(signer, "1", "http://localhost/1", "{}"),
-			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Invalid request path","status":404}`,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Invalid request path","status":404, "code": "notFound"}`,
 		},
 		{
 			Name:         "Bad acct ID in path",
 			Request:      signAndPost(signer, "a/1", "http://localhost/a/1", "{}"),
-			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Invalid account ID","status":400}`,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Invalid account ID","status":400, "code": "malformed"}`,
 		},
 		{
 			Name: "Mismatched acct ID in path/JWS",
@@ -2977,35 +3138,35 @@ func TestFinalizeOrder(t *testing.T) {
 			// stripped by the global WFE2 handler. We need the JWS URL to match the request
 			// URL so we fudge both such that the finalize-order prefix has been removed.
 			Request:      signAndPost(signer, "2/1", "http://localhost/2/1", "{}"),
-			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Mismatched account ID","status":400}`,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Mismatched account ID","status":400, "code": "malformed"}`,
 		},
 		{
 			Name:         "Order ID is invalid",
 			Request:      signAndPost(signer, "1/okwhatever/finalize-order", "http://localhost/1/okwhatever/finalize-order", "{}"),
-			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Invalid order ID","status":400}`,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Invalid order ID","status":400, "code": "malformed"}`,
 		},
 		{
 			Name: "Order doesn't exist",
 			// mocks/mocks.go's StorageAuthority's GetOrder mock treats ID 2 as missing
 			Request:      signAndPost(signer, "1/2", "http://localhost/1/2", "{}"),
-			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"No order for ID 2","status":404}`,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"No order for ID 2","status":404, "code": "notFound"}`,
 		},
 		{
 			Name: "Order is already finalized",
 			// mocks/mocks.go's StorageAuthority's GetOrder mock treats ID 1 as an Order with a Serial
 			Request:      signAndPost(signer, "1/1", "http://localhost/1/1", goodCertCSRPayload),
-			ExpectedBody: `{"type":"` + probs.ErrorNS + `orderNotReady","detail":"Order's status (\"valid\") is not acceptable for finalization","status":403}`,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `orderNotReady","detail":"Order's status (\"valid\") is not acceptable for finalization","status":403, "code": "orderNotReady"}`,
 		},
 		{
 			Name: "Order is expired",
 			// mocks/mocks.go's StorageAuthority's GetOrder mock treats ID 7 as an Order that has already expired
 			Request:      signAndPost(signer, "1/7", "http://localhost/1/7", goodCertCSRPayload),
-			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Order 7 is expired","status":404}`,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `malformed","detail":"Order 7 is expired","status":404, "code": "notFound"}`,
 		},
 		{
 			Name:         "Good CSR, Pending Order",
 			Request:      signAndPost(signer, "1/4", "http://localhost/1/4", goodCertCSRPayload),
-			ExpectedBody: `{"type":"` + probs.ErrorNS + `orderNotReady","detail":"Order's status (\"pending\") is not acceptable for finalization","status":403}`,
+			ExpectedBody: `{"type":"` + probs.ErrorNS + `orderNotReady","detail":"Order's status (\"pending\") is not acceptable for finalization","status":403, "code": "orderNotReady"}`,
 		},
 		{
 			Name:    "Good CSR, Ready Order",
@@ -3075,7 +3236,8 @@ func TestKeyRollover(t *testing.T) {
 		`{
 		  "type": "`+probs.ErrorNS+`malformed",
 		  "detail": "Unable to validate JWS :: Parse error reading JWS",
-		  "status": 400
+		  "status": 400,
+		  "code": "malformed"
 		}`)
 
 	testCases := []struct {
@@ -3091,7 +3253,8 @@ func TestKeyRollover(t *testing.T) {
 			ExpectedResponse: `{
 		     "type": "` + probs.ErrorNS + `malformed",
 		     "detail": "Inner key rollover request specified Account \"\", but outer JWS has Key ID \"http://localhost/acme/acct/1\"",
-		     "status": 400
+		     "status": 400,
+		     "code": "malformed"
 		   }`,
 			NewKey:        newKeyPriv,
 			ErrorStatType: "KeyRolloverMismatchedAccount",
@@ -3102,7 +3265,8 @@ func TestKeyRollover(t *testing.T) {
 			ExpectedResponse: `{
 		     "type": "` + probs.ErrorNS + `malformed",
 		     "detail": "Unable to validate JWS :: Inner JWS does not contain old key field matching current account key",
-		     "status": 400
+		     "status": 400,
+		     "code": "malformed"
 		   }`,
 			NewKey:        newKeyPriv,
 			ErrorStatType: "KeyRolloverWrongOldKey",
@@ -3113,7 +3277,8 @@ func TestKeyRollover(t *testing.T) {
 			ExpectedResponse: `{
                           "type": "urn:ietf:params:acme:error:conflict",
                           "detail": "New key is already in use for a different account",
-                          "status": 409
+                          "status": 409,
+                          "code": "conflict"
                         }`,
 			NewKey: existingKey,
 		},
@@ -3162,7 +3327,8 @@ func TestKeyRolloverMismatchedJWSURLs(t *testing.T) {
 		{
 			"type": "urn:ietf:params:acme:error:malformed",
 			"detail": "Unable to validate JWS :: Outer JWS 'url' value \"http://localhost/key-change\" does not match inner JWS 'url' value \"http://localhost/wrong-url\"",
-			"status": 400
+			"status": 400,
+			"code": "malformed"
 		}`)
 }
 
@@ -3192,42 +3358,42 @@ func TestGetOrder(t *testing.T) {
 		{
 			Name:     "404 request",
 			Request:  makeGet("1/2"),
-			Response: `{"type":"` + probs.ErrorNS + `malformed","detail":"No order for ID 2", "status":404}`,
+			Response: `{"type":"` + probs.ErrorNS + `malformed","detail":"No order for ID 2", "status":404, "code": "notFound"}`,
 		},
 		{
 			Name:     "Invalid request path",
 			Request:  makeGet("asd"),
-			Response: `{"type":"` + probs.ErrorNS + `malformed","detail":"Invalid request path","status":404}`,
+			Response: `{"type":"` + probs.ErrorNS + `malformed","detail":"Invalid request path","status":404, "code": "notFound"}`,
 		},
 		{
 			Name:     "Invalid account ID",
 			Request:  makeGet("asd/asd"),
-			Response: `{"type":"` + probs.ErrorNS + `malformed","detail":"Invalid account ID","status":400}`,
+			Response: `{"type":"` + probs.ErrorNS + `malformed","detail":"Invalid account ID","status":400, "code": "malformed"}`,
 		},
 		{
 			Name:     "Invalid order ID",
 			Request:  makeGet("1/asd"),
-			Response: `{"type":"` + probs.ErrorNS + `malformed","detail":"Invalid order ID","status":400}`,
+			Response: `{"type":"` + probs.ErrorNS + `malformed","detail":"Invalid order ID","status":400, "code": "malformed"}`,
 		},
 		{
 			Name:     "Real request, wrong account",
 			Request:  makeGet("2/1"),
-			Response: `{"type":"` + probs.ErrorNS + `malformed","detail":"No order found for account ID 2", "status":404}`,
+			Response: `{"type":"` + probs.ErrorNS + `malformed","detail":"No order found for account ID 2", "status":404, "code": "notFound"}`,
 		},
 		{
 			Name:     "Internal error request",
 			Request:  makeGet("1/3"),
-			Response: `{"type":"` + probs.ErrorNS + `serverInternal","detail":"Failed to retrieve order for ID 3","status":500}`,
+			Response: `{"type":"` + probs.ErrorNS + `serverInternal","detail":"Failed to retrieve order for ID 3","status":500, "code": "serverInternal"}`,
 		},
 		{
 			Name:     "Invalid POST-as-GET",
 			Request:  makePost(1, "1/1", "{}"),
-			Response: `{"type":"` + probs.ErrorNS + `malformed","detail":"Unable to validate JWS :: POST-as-GET requests must have an empty payload", "status":400}`,
+			Response: `{"type":"` + probs.ErrorNS + `malformed","detail":"Unable to validate JWS :: POST-as-GET requests must have an empty payload", "status":400, "code": "malformed"}`,
 		},
 		{
 			Name:     "Valid POST-as-GET, wrong account",
 			Request:  makePost(1, "2/1", ""),
-			Response: `{"type":"` + probs.ErrorNS + `malformed","detail":"No order found for account ID 2", "status":404}`,
+			Response: `{"type":"` + probs.ErrorNS + `malformed","detail":"No order found for account ID 2", "status":404, "code": "notFound"}`,
 		},
 		{
 			Name:     "Valid POST-as-GET",
@@ -3378,7 +3544,7 @@ func TestRevokeCertificateNotIssued(t *testing.T) {
 		makePostRequestWithPath("revoke-cert", jwsBody))
 	// It should result in a 404 response with a problem body
 	test.AssertEquals(t, responseWriter.Code, 404)
-	test.AssertEquals(t, responseWriter.Body.String(), "{\n  \"type\": \"urn:ietf:params:acme:error:malformed\",\n  \"detail\": \"Unable to revoke :: Certificate from unrecognized issuer\",\n  \"status\": 404\n}")
+	test.AssertEquals(t, responseWriter.Body.String(), "{\n  \"type\": \"urn:ietf:params:acme:error:malformed\",\n  \"detail\": \"Unable to revoke :: Certificate from unrecognized issuer\",\n  \"status\": 404,\n  \"code\": \"notFound\"\n}")
 }
 
 func TestRevokeCertificateExpired(t *testing.T) {
@@ -3403,7 +3569,7 @@ func TestRevokeCertificateExpired(t *testing.T) {
 	wfe.RevokeCertificate(ctx, newRequestEvent(), responseWriter,
 		makePostRequestWithPath("revoke-cert", jwsBody))
 	test.AssertEquals(t, responseWriter.Code, 403)
-	test.AssertEquals(t, responseWriter.Body.String(), "{\n  \"type\": \"urn:ietf:params:acme:error:unauthorized\",\n  \"detail\": \"Unable to revoke :: Certificate is expired\",\n  \"status\": 403\n}")
+	test.AssertEquals(t, responseWriter.Body.String(), "{\n  \"type\": \"urn:ietf:params:acme:error:unauthorized\",\n  \"detail\": \"Unable to revoke :: Certificate is expired\",\n  \"status\": 403,\n  \"code\": \"unauthorized\"\n}")
 }
 
 func TestRevokeCertificateReasons(t *testing.T) {
@@ -3438,13 +3604,13 @@ func TestRevokeCertificateReasons(t *testing.T) {
 			Name:             "Unsupported reason",
 			Reason:           &reason2,
 			ExpectedHTTPCode: http.StatusBadRequest,
-			ExpectedBody:     `{"type":"` + probs.ErrorNS + `badRevocationReason","detail":"Unable to revoke :: disallowed revocation reason: 2","status":400}`,
+			ExpectedBody:     `{"type":"` + probs.ErrorNS + `badRevocationReason","detail":"Unable to revoke :: disallowed revocation reason: 2","status":400, "code": "badRevocationReason"}`,
 		},
 		{
 			Name:             "Non-existent reason",
 			Reason:           &reason100,
 			ExpectedHTTPCode: http.StatusBadRequest,
-			ExpectedBody:     `{"type":"` + probs.ErrorNS + `badRevocationReason","detail":"Unable to revoke :: disallowed revocation reason: 100","status":400}`,
+			ExpectedBody:     `{"type":"` + probs.ErrorNS + `badRevocationReason","detail":"Unable to revoke :: disallowed revocation reason: 100","status":400, "code": "badRevocationReason"}`,
 		},
 	}
 
@@ -3490,7 +3656,7 @@ func TestRevokeCertificateWrongCertificateKey(t *testing.T) {
 		makePostRequestWithPath("revoke-cert", jwsBody))
 	test.AssertEquals(t, responseWriter.Code, 403)
 	test.AssertUnmarshaledEquals(t, responseWriter.Body.String(),
-		`{"type":"`+probs.ErrorNS+`unauthorized","detail":"Unable to revoke :: JWK embedded in revocation request must be the same public key as the cert to be revoked","status":403}`)
+		`{"type":"`+probs.ErrorNS+`unauthorized","detail":"Unable to revoke :: JWK embedded in revocation request must be the same public key as the cert to be revoked","status":403, "code": "unauthorized"}`)
 }
 
 type mockSAGetRegByKeyFails struct {
@@ -3561,7 +3727,8 @@ func TestNewAccountWhenGetRegByKeyNotFound(t *testing.T) {
 	{
 		"type": "urn:ietf:params:acme:error:accountDoesNotExist",
 		"detail": "No account exists with the provided key",
-		"status": 400
+		"status": 400,
+		"code": "accountDoesNotExist"
 	}`)
 }
 
@@ -3715,7 +3882,7 @@ func TestFinalizeSCTError(t *testing.T) {
 	// a serverInternal error with the right message.
 	test.AssertUnmarshaledEquals(t,
 		responseWriter.Body.String(),
-		`{"type":"`+probs.ErrorNS+`serverInternal","detail":"Error finalizing order :: Unable to meet CA SCT embedding requirements","status":500}`)
+		`{"type":"`+probs.ErrorNS+`serverInternal","detail":"Error finalizing order :: Unable to meet CA SCT embedding requirements","status":500, "code": "serverInternal"}`)
 }
 
 func TestOrderToOrderJSONV2Authorizations(t *testing.T) {
@@ -3807,6 +3974,22 @@ func TestARI(t *testing.T) {
 	test.AssertNotError(t, err, "unmarshalling renewal info")
 	test.Assert(t, ri.SuggestedWindow.Start.After(cert.NotBefore), "suggested window begins before cert issuance")
 	test.Assert(t, ri.SuggestedWindow.End.Before(cert.NotAfter), "suggested window ends after cert expiry")
+	test.Assert(t, !ri.Replaced, "cert without a replacement order should not be marked replaced")
+	test.AssertNotContains(t, resp.Body.String(), "replaced")
+
+	// Ensure that a query for a cert with a replacement order marks it as
+	// replaced.
+	msa.replaced = true
+	req, event = makeGet(certID, renewalInfoPath)
+	resp = httptest.NewRecorder()
+	wfe.RenewalInfo(context.Background(), event, resp, req)
+	test.AssertEquals(t, resp.Code, http.StatusOK)
+	var replacedRI core.RenewalInfo
+	err = json.Unmarshal(resp.Body.Bytes(), &replacedRI)
+	test.AssertNotError(t, err, "unmarshalling renewal info")
+	test.Assert(t, replacedRI.Replaced, "cert with a replacement order should be marked replaced")
+	test.AssertEquals(t, replacedRI.SuggestedWindow, ri.SuggestedWindow)
+	msa.replaced = false
 
 	// Ensure that a correct draft-ietf-acme-ari03 query for a revoked cert
 	// results in a renewal window in the past.
@@ -4266,6 +4449,49 @@ func TestNewOrderRateLimits(t *testing.T) {
 	test.AssertEquals(t, responseWriter.Code, http.StatusCreated)
 }
 
+func TestRateLimitDebugHeaders(t *testing.T) {
+	wfe, _, signer := setupWFE(t)
+
+	txnBuilder, err := ratelimits.NewTransactionBuilder(ratelimits.LimitConfigs{
+		ratelimits.NewOrdersPerAccount.String(): &ratelimits.LimitConfig{
+			Burst:  2,
+			Count:  2,
+			Period: config.Duration{Duration: time.Hour * 24}},
+	})
+	test.AssertNotError(t, err, "making transaction composer")
+	wfe.txnBuilder = txnBuilder
+	mux := wfe.Handler(metrics.NoopRegisterer)
+
+	newOrder := func() *httptest.ResponseRecorder {
+		t.Helper()
+		r := signAndPost(signer, newOrderPath, "http://localhost"+newOrderPath,
+			`{"Identifiers": [{"type": "dns", "value": "example.com"}]}`)
+		responseWriter := httptest.NewRecorder()
+		mux.ServeHTTP(responseWriter, r)
+		return responseWriter
+	}
+
+	// Without RateLimitDebugHeaders, no debug headers are sent.
+	responseWriter := newOrder()
+	test.AssertEquals(t, responseWriter.Code, http.StatusCreated)
+	test.AssertEquals(t, len(responseWriter.Header().Values(headerRateLimitDebug)), 0)
+
+	wfe.RateLimitDebugHeaders = true
+	responseWriter = newOrder()
+	test.AssertEquals(t, responseWriter.Code, http.StatusCreated)
+	debug := responseWriter.Header().Values(headerRateLimitDebug)
+	test.AssertEquals(t, len(debug), 1)
+	test.AssertContains(t, debug[0], "limit=NewOrdersPerAccount; bucket=")
+	test.AssertContains(t, debug[0], "; remaining=0; decision=allowed")
+
+	// Denied requests also describe how long to wait.
+	responseWriter = newOrder()
+	test.AssertEquals(t, responseWriter.Code, http.StatusTooManyRequests)
+	debug = responseWriter.Header().Values(headerRateLimitDebug)
+	test.AssertEquals(t, len(debug), 1)
+	test.AssertContains(t, debug[0], "; remaining=0; decision=denied; retry-after=43200")
+}
+
 func TestNewAccountCreatesContacts(t *testing.T) {
 	t.Parallel()
 
@@ -4347,3 +4573,294 @@ ALTERNATE SITE: If no reply, move to Observation Point B at Broken Cairn.`},
 		})
 	}
 }
+
+// mockSAWithContactVerifications is a mock SA which knows the verification
+// state of the contacts in its verifications map.
+type mockSAWithContactVerifications struct {
+	sapb.StorageAuthorityReadOnlyClient
+	verifications map[string]core.AcmeStatus
+}
+
+func (sa *mockSAWithContactVerifications) GetContactVerifications(_ context.Context, req *sapb.ContactHashes, _ ...grpc.CallOption) (*sapb.ContactVerifications, error) {
+	var resp sapb.ContactVerifications
+	for addr, status := range sa.verifications {
+		hash := email.ContactHash(addr)
+		if slices.ContainsFunc(req.ContactHashes, func(h []byte) bool { return bytes.Equal(h, hash) }) {
+			resp.Verifications = append(resp.Verifications, &sapb.ContactVerification{ContactHash: hash, Status: string(status)})
+		}
+	}
+	return &resp, nil
+}
+
+// mockContactVerifier records the contacts it's asked to verify.
+type mockContactVerifier struct {
+	emailpb.ContactVerifierClient
+
+	sync.Mutex
+	verifying []string
+}
+
+func (cv *mockContactVerifier) VerifyContacts(_ context.Context, req *emailpb.VerifyContactsRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	cv.Lock()
+	defer cv.Unlock()
+	cv.verifying = append(cv.verifying, req.Emails...)
+	return &emptypb.Empty{}, nil
+}
+
+func TestNewAccountSkipsInvalidContacts(t *testing.T) {
+	t.Parallel()
+
+	key := loadKey(t, []byte(test2KeyPrivatePEM))
+	signedURL := fmt.Sprintf("http://localhost%s", newAcctPath)
+
+	wfe, _, signer := setupWFE(t)
+	wfe.maxContactsPerReg = 3
+	mockPardotClient, mockImpl := mocks.NewMockPardotClientImpl()
+	wfe.ee = mocks.NewMockExporterImpl(mockPardotClient)
+	cv := &mockContactVerifier{}
+	wfe.cv = cv
+	wfe.sa = &mockSAWithContactVerifications{
+		StorageAuthorityReadOnlyClient: wfe.sa,
+		verifications: map[string]core.AcmeStatus{
+			"valid@mail.com":   core.StatusValid,
+			"invalid@mail.com": core.StatusInvalid,
+		},
+	}
+
+	payload := `{"contact":["mailto:valid@mail.com","mailto:invalid@mail.com","mailto:new@mail.com"],"termsOfServiceAgreed":true}`
+	_, _, body := signer.embeddedJWK(key, signedURL, payload)
+	request := makePostRequestWithPath(newAcctPath, body)
+
+	responseWriter := httptest.NewRecorder()
+	wfe.NewAccount(context.Background(), newRequestEvent(), responseWriter, request)
+	test.AssertEquals(t, responseWriter.Code, http.StatusCreated)
+
+	// Only the contact which has never been verified is sent for
+	// verification, and the one known to be invalid isn't exported.
+	test.AssertDeepEquals(t, cv.verifying, []string{"new@mail.com"})
+	exported := mockImpl.GetCreatedContacts()
+	slices.Sort(exported)
+	test.AssertDeepEquals(t, exported, []string{"new@mail.com", "valid@mail.com"})
+	test.AssertMetricWithLabelsEquals(t, wfe.stats.skippedContacts, prometheus.Labels{}, 1)
+}
+
+func TestDiscardContacts(t *testing.T) {
+	t.Parallel()
+
+	key := loadKey(t, []byte(test2KeyPrivatePEM))
+	signedURL := fmt.Sprintf("http://localhost%s", newAcctPath)
+
+	wfe, _, signer := setupWFE(t)
+	wfe.DiscardContacts = true
+	mockPardotClient, mockImpl := mocks.NewMockPardotClientImpl()
+	wfe.ee = mocks.NewMockExporterImpl(mockPardotClient)
+	cv := &mockContactVerifier{}
+	wfe.cv = cv
+
+	// Contacts aren't validated, so a malformed one doesn't cause an error.
+	payload := `{"contact":["mailto:person@mail.com","mailto:lol@%mail.com"],"termsOfServiceAgreed":true}`
+	_, _, body := signer.embeddedJWK(key, signedURL, payload)
+	responseWriter := httptest.NewRecorder()
+	wfe.NewAccount(context.Background(), newRequestEvent(), responseWriter, makePostRequestWithPath(newAcctPath, body))
+	test.AssertEquals(t, responseWriter.Code, http.StatusCreated)
+	test.AssertEquals(t, responseWriter.Header().Get("Warning"), discardedContactsWarning)
+	test.AssertEquals(t, len(mockImpl.GetCreatedContacts()), 0)
+	test.AssertEquals(t, len(cv.verifying), 0)
+	test.AssertMetricWithLabelsEquals(t, wfe.stats.discardedContacts, prometheus.Labels{}, 2)
+
+	// Contacts provided in an update are discarded too.
+	_, _, body = signer.byKeyID(1, nil, "http://localhost/1", `{"contact":["mailto:person@mail.com"]}`)
+	responseWriter = httptest.NewRecorder()
+	wfe.Account(ctx, newRequestEvent(), responseWriter, makePostRequestWithPath("1", body))
+	test.AssertEquals(t, responseWriter.Code, http.StatusOK)
+	test.AssertEquals(t, responseWriter.Header().Get("Warning"), discardedContactsWarning)
+	test.AssertEquals(t, len(mockImpl.GetCreatedContacts()), 0)
+	test.AssertMetricWithLabelsEquals(t, wfe.stats.discardedContacts, prometheus.Labels{}, 3)
+
+	// No warning is sent if no contacts are provided.
+	_, _, body = signer.byKeyID(1, nil, "http://localhost/1", `{"contact":[]}`)
+	responseWriter = httptest.NewRecorder()
+	wfe.Account(ctx, newRequestEvent(), responseWriter, makePostRequestWithPath("1", body))
+	test.AssertEquals(t, responseWriter.Code, http.StatusOK)
+	test.AssertEquals(t, responseWriter.Header().Get("Warning"), "")
+}
+
+// RAAwaitingValidation is a fake RA whose AwaitValidation method returns a
+// valid authorization for ID 2, and NotFound for any other, as if no
+// validation of it were in progress. If err is set, it's returned instead.
+type RAAwaitingValidation struct {
+	*MockRegistrationAuthority
+	awaited []int64
+	err     error
+}
+
+func (ra *RAAwaitingValidation) AwaitValidation(_ context.Context, in *rapb.AwaitValidationRequest, _ ...grpc.CallOption) (*corepb.Authorization, error) {
+	ra.awaited = append(ra.awaited, in.AuthzID)
+	if ra.err != nil {
+		return nil, ra.err
+	}
+	if in.AuthzID != 2 {
+		return nil, berrors.NotFoundError("no validation in progress")
+	}
+	return &corepb.Authorization{
+		Id:             "2",
+		RegistrationID: 1,
+		Identifier:     identifier.NewDNS("not-an-example.com").ToProto(),
+		Status:         string(core.StatusValid),
+		Expires:        timestamppb.New(ra.clk.Now().AddDate(100, 0, 0)),
+		Challenges: []*corepb.Challenge{
+			{Id: 1, Type: "http-01", Status: string(core.StatusValid), Token: "token"},
+		},
+	}, nil
+}
+
+// RAWithFailedAttempt is a fake RA for which a validation of authorization 2
+// has failed, but left it pending for another attempt, as the RA does when the
+// authorization's profile allows several attempts.
+type RAWithFailedAttempt struct {
+	*MockRegistrationAuthority
+	validations int
+}
+
+func (ra *RAWithFailedAttempt) failedAttempt() *corepb.Authorization {
+	return &corepb.Authorization{
+		Id:             "2",
+		RegistrationID: 1,
+		Identifier:     identifier.NewDNS("not-an-example.com").ToProto(),
+		Status:         string(core.StatusPending),
+		Expires:        timestamppb.New(ra.clk.Now().AddDate(100, 0, 0)),
+		Challenges: []*corepb.Challenge{
+			{
+				Id:     1,
+				Type:   "http-01",
+				Status: string(core.StatusPending),
+				Token:  "token",
+				Error: &corepb.ProblemDetails{
+					ProblemType: string(probs.ConnectionProblem),
+					Detail:      "timeout",
+					HttpStatus:  http.StatusBadRequest,
+				},
+			},
+		},
+	}
+}
+
+func (ra *RAWithFailedAttempt) AwaitValidation(context.Context, *rapb.AwaitValidationRequest, ...grpc.CallOption) (*corepb.Authorization, error) {
+	return ra.failedAttempt(), nil
+}
+
+func (ra *RAWithFailedAttempt) GetAuthorization(_ context.Context, in *rapb.GetAuthorizationRequest, _ ...grpc.CallOption) (*corepb.Authorization, error) {
+	if in.Id != 2 {
+		return ra.MockRegistrationAuthority.GetAuthorization(ctx, in)
+	}
+	return ra.failedAttempt(), nil
+}
+
+func (ra *RAWithFailedAttempt) PerformValidation(_ context.Context, in *rapb.PerformValidationRequest, _ ...grpc.CallOption) (*corepb.Authorization, error) {
+	ra.validations++
+	return in.Authz, nil
+}
+
+func TestAuthorizationAfterFailedAttempt(t *testing.T) {
+	wfe, fc, signer := setupWFE(t)
+	ra := &RAWithFailedAttempt{MockRegistrationAuthority: &MockRegistrationAuthority{clk: fc}}
+	wfe.ra = ra
+
+	// Polling, whether the RA has the result of the validation in hand or the
+	// authorization is read as usual, shows the failed attempt's problem on
+	// the pending challenge.
+	for _, wait := range []time.Duration{time.Second, 0} {
+		wfe.ValidationWait = wait
+		responseWriter := httptest.NewRecorder()
+		wfe.AuthorizationHandler(ctx, newRequestEvent(), responseWriter, &http.Request{
+			Method: "GET",
+			URL:    mustParseURL("1/2"),
+		})
+		test.AssertEquals(t, responseWriter.Code, http.StatusOK)
+		var authz core.Authorization
+		err := json.Unmarshal(responseWriter.Body.Bytes(), &authz)
+		test.AssertNotError(t, err, "unmarshalling authorization")
+		test.AssertEquals(t, authz.Status, core.StatusPending)
+		test.AssertEquals(t, len(authz.Challenges), 1)
+		test.AssertEquals(t, authz.Challenges[0].Status, core.StatusPending)
+		test.AssertNotNil(t, authz.Challenges[0].Error, "pending challenge should carry the failed attempt's problem")
+		test.AssertEquals(t, authz.Challenges[0].Error.Type, probs.ErrorNS+probs.ConnectionProblem)
+		test.AssertEquals(t, authz.Challenges[0].Error.Detail, "timeout")
+	}
+
+	// The challenge can still be retried.
+	signedURL := "http://localhost/1/2/7TyhFQ"
+	_, _, jwsBody := signer.byKeyID(1, nil, signedURL, `{}`)
+	responseWriter := httptest.NewRecorder()
+	wfe.ChallengeHandler(ctx, newRequestEvent(), responseWriter, makePostRequestWithPath("1/2/7TyhFQ", jwsBody))
+	test.AssertEquals(t, responseWriter.Code, http.StatusOK)
+	test.AssertEquals(t, ra.validations, 1)
+}
+
+func TestAuthorizationAwaitsValidation(t *testing.T) {
+	wfe, fc, _ := setupWFE(t)
+	ra := &RAAwaitingValidation{MockRegistrationAuthority: &MockRegistrationAuthority{clk: fc}}
+	wfe.ra = ra
+
+	// Without a ValidationWait, the RA isn't asked to wait.
+	responseWriter := httptest.NewRecorder()
+	wfe.AuthorizationHandler(ctx, newRequestEvent(), responseWriter, &http.Request{
+		Method: "GET",
+		URL:    mustParseURL("1/2"),
+	})
+	test.AssertEquals(t, responseWriter.Code, http.StatusOK)
+	test.AssertEquals(t, len(ra.awaited), 0)
+	test.AssertContains(t, responseWriter.Body.String(), `"status": "pending"`)
+
+	// With one, the result of the validation in progress is returned.
+	wfe.ValidationWait = time.Second
+	responseWriter = httptest.NewRecorder()
+	wfe.AuthorizationHandler(ctx, newRequestEvent(), responseWriter, &http.Request{
+		Method: "GET",
+		URL:    mustParseURL("1/2"),
+	})
+	test.AssertEquals(t, responseWriter.Code, http.StatusOK)
+	test.AssertDeepEquals(t, ra.awaited, []int64{2})
+	test.AssertContains(t, responseWriter.Body.String(), `"status": "valid"`)
+
+	// If no validation is in progress, the authorization is read as usual.
+	responseWriter = httptest.NewRecorder()
+	wfe.ChallengeHandler(ctx, newRequestEvent(), responseWriter, &http.Request{
+		Method: "GET",
+		URL:    mustParseURL("1/1/7TyhFQ"),
+	})
+	test.AssertEquals(t, responseWriter.Code, http.StatusOK)
+	test.AssertDeepEquals(t, ra.awaited, []int64{2, 1})
+}
+
+func TestAuthorizationAwaitResults(t *testing.T) {
+	wfe, fc, _ := setupWFE(t)
+	ra := &RAAwaitingValidation{MockRegistrationAuthority: &MockRegistrationAuthority{clk: fc}}
+	wfe.ra = ra
+	wfe.ValidationWait = time.Second
+
+	testCases := []struct {
+		name   string
+		err    error
+		result string
+	}{
+		{"no validation in progress", berrors.NotFoundError("no validation in progress"), "not_in_progress"},
+		{"context deadline", context.DeadlineExceeded, "timeout"},
+		{"gRPC deadline", status.Error(codes.DeadlineExceeded, "deadline exceeded"), "timeout"},
+		{"RA unavailable", status.Error(codes.Unavailable, "connection refused"), "error"},
+		{"internal error", berrors.InternalServerError("oops"), "error"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			wfe.stats.validationWaits.Reset()
+			ra.err = tc.err
+
+			// Whatever the reason the wait didn't return a result, the
+			// authorization is read as usual.
+			authzPB, err := wfe.getAuthorization(ctx, 1, true)
+			test.AssertNotError(t, err, "getting authorization")
+			test.AssertEquals(t, authzPB.Id, "1")
+			test.AssertMetricWithLabelsEquals(t, wfe.stats.validationWaits, prometheus.Labels{"result": tc.result}, 1)
+		})
+	}
+}